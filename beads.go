@@ -9,8 +9,10 @@ package beads
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/plugins"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -50,6 +52,28 @@ func FindAllDatabases() []DatabaseInfo {
 	return beads.FindAllDatabases()
 }
 
+// PluginContext is the project context bd passes to plugins (bd-<name>
+// executables run as "bd <name>")
+type PluginContext = plugins.Context
+
+// LoadPluginContext reads the context bd passed to the running plugin
+func LoadPluginContext() (*PluginContext, error) {
+	return plugins.LoadContext()
+}
+
+// OpenPluginStorage opens the database of the project the running plugin
+// was invoked in
+func OpenPluginStorage(ctx context.Context) (Storage, error) {
+	c, err := plugins.LoadContext()
+	if err != nil {
+		return nil, err
+	}
+	if c.DBPath == "" {
+		return nil, fmt.Errorf("bd found no database for plugin %s", c.Plugin)
+	}
+	return beads.NewSQLiteStorage(ctx, c.DBPath)
+}
+
 // Core types from internal/types
 type (
 	Issue              = types.Issue
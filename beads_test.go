@@ -104,3 +104,22 @@ func TestConstants(t *testing.T) {
 		t.Errorf("DepRelated = %q, want %q", beads.DepRelated, "related")
 	}
 }
+
+func TestOpenPluginStorage(t *testing.T) {
+	ctx := context.Background()
+	dbPath := filepath.Join(t.TempDir(), "beads.db")
+	t.Setenv("BD_PLUGIN_CONTEXT", `{"plugin":"report","db_path":"`+filepath.ToSlash(dbPath)+`"}`)
+
+	pc, err := beads.LoadPluginContext()
+	if err != nil {
+		t.Fatalf("LoadPluginContext failed: %v", err)
+	}
+	if pc.Plugin != "report" {
+		t.Errorf("Plugin = %q, want report", pc.Plugin)
+	}
+	store, err := beads.OpenPluginStorage(ctx)
+	if err != nil {
+		t.Fatalf("OpenPluginStorage failed: %v", err)
+	}
+	defer store.Close()
+}
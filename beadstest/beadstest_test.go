@@ -0,0 +1,95 @@
+package beadstest_test
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads"
+	"github.com/steveyegge/beads/beadstest"
+)
+
+func TestProjectAndIssues(t *testing.T) {
+	ctx := context.Background()
+	p := beadstest.NewProjectWithPrefix(t, "demo")
+	if _, err := os.Stat(p.DBPath); err != nil {
+		t.Fatalf("database not created: %v", err)
+	}
+
+	issue := beadstest.CreateIssue(t, p.Store, "Fix login",
+		beadstest.WithType(beads.TypeBug),
+		beadstest.WithPriority(0),
+		beadstest.WithLabels("auth"),
+	)
+	if !strings.HasPrefix(issue.ID, "demo-") || issue.IssueType != beads.TypeBug || issue.Priority != 0 {
+		t.Errorf("CreateIssue = %+v", issue)
+	}
+	labels, err := p.Store.GetLabels(ctx, issue.ID)
+	if err != nil || len(labels) != 1 || labels[0] != "auth" {
+		t.Errorf("labels = %v, %v", labels, err)
+	}
+
+	closed := beadstest.CreateIssue(t, p.Store, "Old work", beadstest.WithStatus(beads.StatusClosed))
+	if closed.Status != beads.StatusClosed || closed.ClosedAt == nil {
+		t.Errorf("closed issue = %+v", closed)
+	}
+}
+
+func TestBuildGraph(t *testing.T) {
+	for name, s := range map[string]beads.Storage{
+		"sqlite": beadstest.NewProject(t).Store,
+		"memory": beadstest.NewMemoryStore(t),
+	} {
+		t.Run(name, func(t *testing.T) {
+			g := beadstest.BuildGraph(t, s, `
+				# a small release
+				epic parent-of api
+				api blocks ui
+				docs
+			`)
+			if len(g) != 4 || g["epic"].IssueType != beads.TypeEpic || g["api"].IssueType != beads.TypeTask {
+				t.Fatalf("graph = %+v", g)
+			}
+
+			ready, err := s.GetReadyWork(context.Background(), beads.WorkFilter{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, issue := range ready {
+				if issue.ID == g["ui"].ID {
+					t.Error("ui should be blocked by api")
+				}
+			}
+		})
+	}
+}
+
+func TestHistory(t *testing.T) {
+	p := beadstest.NewProject(t)
+	issue := beadstest.CreateIssue(t, p.Store, "Ship it")
+	claimed := time.Now().Add(-72 * time.Hour).UTC().Truncate(time.Second)
+	closedAt := claimed.Add(24 * time.Hour)
+
+	events := beadstest.History(t, p.Store, issue.ID,
+		beadstest.Claim("alice").At(claimed),
+		beadstest.Comment("halfway").By("bob"),
+		beadstest.Close("done").At(closedAt),
+	)
+	if len(events) < 3 {
+		t.Fatalf("History recorded %d events, want at least 3", len(events))
+	}
+	first, last := events[0], events[len(events)-1]
+	if first.Actor != "alice" || !first.CreatedAt.Equal(claimed) {
+		t.Errorf("first event = %s by %s at %v", first.EventType, first.Actor, first.CreatedAt)
+	}
+	if last.EventType != beads.EventClosed || !last.CreatedAt.Equal(closedAt) {
+		t.Errorf("last event = %s at %v", last.EventType, last.CreatedAt)
+	}
+
+	got := beadstest.GetIssue(t, p.Store, issue.ID)
+	if got.Status != beads.StatusClosed || got.Assignee != "alice" || got.ClosedAt == nil || !got.ClosedAt.Equal(closedAt) {
+		t.Errorf("issue after history = %+v", got)
+	}
+}
@@ -0,0 +1,115 @@
+package beadstest
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads"
+)
+
+// Graph maps the node names of a BuildGraph spec to the issues created
+type Graph map[string]*beads.Issue
+
+// IDs returns the IDs of the named nodes, in order
+func (g Graph) IDs(names ...string) []string {
+	ids := make([]string, len(names))
+	for i, name := range names {
+		if issue := g[name]; issue != nil {
+			ids[i] = issue.ID
+		}
+	}
+	return ids
+}
+
+// graphEdges maps spec verbs to a dependency type and whether the edge
+// points from the right-hand node to the left-hand one ("a blocks b" means
+// b depends on a)
+var graphEdges = map[string]struct {
+	depType  beads.DependencyType
+	reversed bool
+}{
+	"blocks":          {beads.DepBlocks, true},
+	"parent-of":       {beads.DepParentChild, true},
+	"child-of":        {beads.DepParentChild, false},
+	"depends-on":      {beads.DepBlocks, false},
+	"related":         {beads.DepRelated, false},
+	"discovered-from": {beads.DepDiscoveredFrom, false},
+}
+
+// BuildGraph creates one issue per node named in spec and the dependencies
+// between them. Each line is either a lone node name or an edge:
+//
+//	a blocks b            b can't start until a is closed
+//	a depends-on b        a can't start until b is closed
+//	epic parent-of a      a is a child of epic
+//	a child-of epic       the same, written from the child
+//	a related b
+//	a discovered-from b
+//
+// Blank lines and lines starting with # are ignored. Node names become the
+// issue titles; nodes that are parents are created as epics, the rest as
+// open P2 tasks. Use CreateIssue and AddDependency directly for anything
+// more specific.
+func BuildGraph(t testing.TB, s beads.Storage, spec string) Graph {
+	t.Helper()
+	type edge struct{ from, to, verb string }
+	var names []string
+	var edges []edge
+	parents := make(map[string]bool)
+	seen := make(map[string]bool)
+	addNode := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	for n, line := range strings.Split(spec, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		switch len(fields) {
+		case 1:
+			addNode(fields[0])
+		case 3:
+			kind, ok := graphEdges[fields[1]]
+			if !ok {
+				t.Fatalf("beadstest: graph line %d: unknown relation %q", n+1, fields[1])
+			}
+			addNode(fields[0])
+			addNode(fields[2])
+			from, to := fields[0], fields[2]
+			if kind.reversed {
+				from, to = to, from
+			}
+			if kind.depType == beads.DepParentChild {
+				parents[to] = true
+			}
+			edges = append(edges, edge{from, to, fields[1]})
+		default:
+			t.Fatalf("beadstest: graph line %d: want \"name\" or \"a <relation> b\", got %q", n+1, strings.TrimSpace(line))
+		}
+	}
+
+	g := make(Graph, len(names))
+	for _, name := range names {
+		issueType := beads.TypeTask
+		if parents[name] {
+			issueType = beads.TypeEpic
+		}
+		g[name] = CreateIssue(t, s, name, WithType(issueType))
+	}
+	for _, e := range edges {
+		dep := &beads.Dependency{
+			IssueID:     g[e.from].ID,
+			DependsOnID: g[e.to].ID,
+			Type:        graphEdges[e.verb].depType,
+		}
+		if err := s.AddDependency(context.Background(), dep, DefaultActor); err != nil {
+			t.Fatalf("beadstest: failed to add %s %s %s: %v", e.from, e.verb, e.to, err)
+		}
+	}
+	return g
+}
@@ -0,0 +1,192 @@
+package beadstest
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads"
+)
+
+// Step is one change in an issue's history, applied by History
+type Step struct {
+	name  string
+	apply func(ctx context.Context, s beads.Storage, id, actor string) error
+	actor string
+	at    time.Time
+}
+
+// By records the step as actor instead of DefaultActor
+func (st Step) By(actor string) Step {
+	st.actor = actor
+	return st
+}
+
+// At backdates the step: its events, any comment it adds, and the issue's
+// updated_at (and closed_at, for Close) get this time instead of now.
+// Backdating issue timestamps needs a SQL store; on the memory store only
+// the events move.
+func (st Step) At(at time.Time) Step {
+	st.at = at
+	return st
+}
+
+// Claim assigns the issue and moves it to in_progress, like bd update --claim
+func Claim(assignee string) Step {
+	return update("claim", map[string]interface{}{
+		"status":   string(beads.StatusInProgress),
+		"assignee": assignee,
+	}).By(assignee)
+}
+
+// Assign changes the assignee
+func Assign(assignee string) Step {
+	return update("assign", map[string]interface{}{"assignee": assignee})
+}
+
+// SetStatus changes the status
+func SetStatus(status beads.Status) Step {
+	return update("set status", map[string]interface{}{"status": string(status)})
+}
+
+// Reopen moves a closed issue back to open
+func Reopen() Step {
+	return update("reopen", map[string]interface{}{"status": string(beads.StatusOpen)})
+}
+
+// Close closes the issue with reason
+func Close(reason string) Step {
+	return Step{name: "close", apply: func(ctx context.Context, s beads.Storage, id, actor string) error {
+		return s.CloseIssue(ctx, id, reason, actor)
+	}}
+}
+
+// Comment adds a comment
+func Comment(text string) Step {
+	return Step{name: "comment", apply: func(ctx context.Context, s beads.Storage, id, actor string) error {
+		_, err := s.AddIssueComment(ctx, id, actor, text)
+		return err
+	}}
+}
+
+// Label adds a label
+func Label(label string) Step {
+	return Step{name: "label", apply: func(ctx context.Context, s beads.Storage, id, actor string) error {
+		return s.AddLabel(ctx, id, label, actor)
+	}}
+}
+
+func update(name string, updates map[string]interface{}) Step {
+	return Step{name: name, apply: func(ctx context.Context, s beads.Storage, id, actor string) error {
+		return s.UpdateIssue(ctx, id, updates, actor)
+	}}
+}
+
+// History applies steps to issue id in order and returns the events they
+// recorded, oldest first. Steps without At happen now; give each step a
+// time to build a history that spans days or weeks.
+//
+// The memory store records events only for status, assignee and other
+// field changes, so comments and labels leave no event there.
+func History(t testing.TB, s beads.Storage, id string, steps ...Step) []*beads.Event {
+	t.Helper()
+	ctx := context.Background()
+	db := s.UnderlyingDB()
+	start := markHistory(t, s, db, id)
+	for i, st := range steps {
+		actor := st.actor
+		if actor == "" {
+			actor = DefaultActor
+		}
+		before := markHistory(t, s, db, id)
+		if err := st.apply(ctx, s, id, actor); err != nil {
+			t.Fatalf("beadstest: step %d (%s) on %s: %v", i+1, st.name, id, err)
+		}
+		if !st.at.IsZero() {
+			backdate(t, s, db, id, before, st)
+		}
+	}
+	return eventsSince(t, s, db, id, start)
+}
+
+// historyMark is where an issue's history stands: the last event and
+// comment IDs on SQL stores, the number of events on the memory store
+type historyMark struct {
+	event, comment int64
+	count          int
+}
+
+func markHistory(t testing.TB, s beads.Storage, db *sql.DB, id string) historyMark {
+	t.Helper()
+	var mark historyMark
+	if db == nil {
+		events, err := s.GetEvents(context.Background(), id, 0)
+		if err != nil {
+			t.Fatalf("beadstest: failed to get events for %s: %v", id, err)
+		}
+		mark.count = len(events)
+		return mark
+	}
+	err := db.QueryRow(`
+		SELECT (SELECT COALESCE(MAX(id), 0) FROM events WHERE issue_id = ?),
+		       (SELECT COALESCE(MAX(id), 0) FROM comments WHERE issue_id = ?)
+	`, id, id).Scan(&mark.event, &mark.comment)
+	if err != nil {
+		t.Fatalf("beadstest: failed to read history of %s: %v", id, err)
+	}
+	return mark
+}
+
+func backdate(t testing.TB, s beads.Storage, db *sql.DB, id string, since historyMark, st Step) {
+	t.Helper()
+	at := st.at.UTC()
+	if db == nil {
+		// The memory store hands out its own event records
+		events, err := s.GetEvents(context.Background(), id, 0)
+		if err != nil {
+			t.Fatalf("beadstest: failed to get events for %s: %v", id, err)
+		}
+		for _, e := range events[since.count:] {
+			e.CreatedAt = at
+		}
+		return
+	}
+	type statement struct {
+		query string
+		args  []interface{}
+	}
+	statements := []statement{
+		{`UPDATE events SET created_at = ? WHERE issue_id = ? AND id > ?`, []interface{}{at, id, since.event}},
+		{`UPDATE comments SET created_at = ? WHERE issue_id = ? AND id > ?`, []interface{}{at, id, since.comment}},
+		{`UPDATE issues SET updated_at = ? WHERE id = ?`, []interface{}{at, id}},
+	}
+	if st.name == "close" {
+		statements = append(statements, statement{`UPDATE issues SET closed_at = ? WHERE id = ?`, []interface{}{at, id}})
+	}
+	for _, stmt := range statements {
+		if _, err := db.Exec(stmt.query, stmt.args...); err != nil {
+			t.Fatalf("beadstest: failed to backdate %s on %s: %v", st.name, id, err)
+		}
+	}
+}
+
+func eventsSince(t testing.TB, s beads.Storage, db *sql.DB, id string, start historyMark) []*beads.Event {
+	t.Helper()
+	events, err := s.GetEvents(context.Background(), id, 0)
+	if err != nil {
+		t.Fatalf("beadstest: failed to get events for %s: %v", id, err)
+	}
+	if db == nil {
+		return append([]*beads.Event(nil), events[start.count:]...)
+	}
+	var recorded []*beads.Event
+	for _, e := range events {
+		if e.ID > start.event {
+			recorded = append(recorded, e)
+		}
+	}
+	sort.SliceStable(recorded, func(i, j int) bool { return recorded[i].ID < recorded[j].ID })
+	return recorded
+}
@@ -0,0 +1,100 @@
+package beadstest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/beads"
+)
+
+// IssueOption changes an issue built by NewIssue or CreateIssue
+type IssueOption func(*beads.Issue)
+
+// WithStatus sets the status. CreateIssue closes closed issues through
+// the store, so they get a closed_at and a closed event like real ones.
+func WithStatus(status beads.Status) IssueOption {
+	return func(i *beads.Issue) { i.Status = status }
+}
+
+// WithPriority sets the priority (0-4)
+func WithPriority(priority int) IssueOption {
+	return func(i *beads.Issue) { i.Priority = priority }
+}
+
+// WithType sets the issue type
+func WithType(issueType beads.IssueType) IssueOption {
+	return func(i *beads.Issue) { i.IssueType = issueType }
+}
+
+// WithAssignee sets the assignee
+func WithAssignee(assignee string) IssueOption {
+	return func(i *beads.Issue) { i.Assignee = assignee }
+}
+
+// WithDescription sets the description
+func WithDescription(description string) IssueOption {
+	return func(i *beads.Issue) { i.Description = description }
+}
+
+// WithLabels adds labels
+func WithLabels(labels ...string) IssueOption {
+	return func(i *beads.Issue) { i.Labels = append(i.Labels, labels...) }
+}
+
+// WithID sets an explicit ID instead of letting the store generate one
+func WithID(id string) IssueOption {
+	return func(i *beads.Issue) { i.ID = id }
+}
+
+// NewIssue builds an unsaved issue: an open P2 task unless opts say otherwise
+func NewIssue(title string, opts ...IssueOption) *beads.Issue {
+	issue := &beads.Issue{
+		Title:     title,
+		Status:    beads.StatusOpen,
+		Priority:  2,
+		IssueType: beads.TypeTask,
+	}
+	for _, opt := range opts {
+		opt(issue)
+	}
+	return issue
+}
+
+// CreateIssue builds an issue like NewIssue and saves it to s, along with
+// its labels, and returns it with the ID the store assigned
+func CreateIssue(t testing.TB, s beads.Storage, title string, opts ...IssueOption) *beads.Issue {
+	t.Helper()
+	ctx := context.Background()
+	issue := NewIssue(title, opts...)
+	closed := issue.Status == beads.StatusClosed
+	if closed {
+		issue.Status = beads.StatusOpen
+	}
+	if err := s.CreateIssue(ctx, issue, DefaultActor); err != nil {
+		t.Fatalf("beadstest: failed to create %q: %v", title, err)
+	}
+	for _, label := range issue.Labels {
+		if err := s.AddLabel(ctx, issue.ID, label, DefaultActor); err != nil {
+			t.Fatalf("beadstest: failed to label %s: %v", issue.ID, err)
+		}
+	}
+	if closed {
+		if err := s.CloseIssue(ctx, issue.ID, "done", DefaultActor); err != nil {
+			t.Fatalf("beadstest: failed to close %s: %v", issue.ID, err)
+		}
+	}
+	return GetIssue(t, s, issue.ID)
+}
+
+// GetIssue reloads an issue from s, failing the test if it doesn't exist
+func GetIssue(t testing.TB, s beads.Storage, id string) *beads.Issue {
+	t.Helper()
+	issue, err := s.GetIssue(context.Background(), id)
+	if err != nil {
+		t.Fatalf("beadstest: failed to get %s: %v", id, err)
+	}
+	if issue == nil {
+		t.Fatalf("beadstest: issue %s not found", id)
+	}
+	return issue
+}
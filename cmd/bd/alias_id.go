@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var aliasIDCmd = &cobra.Command{
+	Use:   "alias-id <id> [alias...]",
+	Short: "Give an issue human-friendly aliases usable anywhere an ID is",
+	Long: `Give an issue one or more aliases, such as "auth-refactor" for bd-a3f8e9.
+
+Aliases are accepted anywhere an issue ID is (bd show auth-refactor, bd dep add
+auth-refactor bd-12, ...), are listed by bd show and are exported to JSONL so
+every clone resolves them the same way. An alias is lowercase letters, digits
+and single hyphens or underscores, names exactly one issue, and can't be an
+existing issue ID.
+
+Examples:
+  bd alias-id bd-a3f8e9 auth-refactor    # Add an alias
+  bd alias-id auth-refactor              # List the issue's aliases
+  bd alias-id --remove auth-refactor     # Remove an alias`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		remove, _ := cmd.Flags().GetBool("remove")
+
+		if err := ensureDirectMode("alias-id requires direct database access"); err != nil {
+			FatalError("%v", err)
+		}
+		ctx := rootCtx
+		if err := ensureDatabaseFresh(ctx); err != nil {
+			FatalError("%v", err)
+		}
+
+		if remove {
+			CheckReadonly("alias-id --remove")
+			for _, alias := range args {
+				if err := store.RemoveIssueAlias(ctx, alias, actor); err != nil {
+					FatalError("%v", err)
+				}
+			}
+			markDirtyAndScheduleFlush()
+			if jsonOutput {
+				outputJSON(map[string]interface{}{"removed": args})
+				return
+			}
+			green := color.New(color.FgGreen).SprintFunc()
+			fmt.Printf("%s Removed %s\n", green("✓"), strings.Join(args, ", "))
+			return
+		}
+
+		issueID, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			FatalError("%v", err)
+		}
+		if len(args) > 1 {
+			CheckReadonly("alias-id")
+			for _, alias := range args[1:] {
+				if err := store.SetIssueAlias(ctx, issueID, alias, actor); err != nil {
+					FatalError("%v", err)
+				}
+			}
+			markDirtyAndScheduleFlush()
+		}
+
+		aliases, err := store.GetAliasesForIssues(ctx, []string{issueID})
+		if err != nil {
+			FatalError("%v", err)
+		}
+		list := aliases[issueID]
+		if list == nil {
+			list = []string{}
+		}
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"id": issueID, "aliases": list})
+			return
+		}
+		if len(args) > 1 {
+			green := color.New(color.FgGreen).SprintFunc()
+			fmt.Printf("%s %s is now also %s\n", green("✓"), issueID, strings.Join(args[1:], ", "))
+			return
+		}
+		if len(list) == 0 {
+			fmt.Printf("%s has no aliases\n", issueID)
+			return
+		}
+		fmt.Printf("%s: %s\n", issueID, strings.Join(list, ", "))
+	},
+}
+
+func init() {
+	aliasIDCmd.Flags().Bool("remove", false, "Remove the given aliases instead of adding")
+	rootCmd.AddCommand(aliasIDCmd)
+}
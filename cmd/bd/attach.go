@@ -0,0 +1,350 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/attachments"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var attachCmd = &cobra.Command{
+	Use:   "attach <id> [file]",
+	Short: "Attach logs, screenshots and patches to an issue",
+	Long: `Attach a file to an issue.
+
+The file is stored by the project's attachment provider and only its URL and
+SHA-256 are recorded on the issue (and exported to JSONL). 'bd attach get'
+(or --get) downloads attachments and verifies them against the recorded hash.
+
+The text of markdown, plain text and PDF attachments is extracted so
+'bd search' finds issues by attachment content; 'bd attach --preview' shows
+it. See 'bd index --attachments'.
+
+Providers (attachments.provider in config.yaml):
+  blobs   The default: content-addressed under .beads/blobs/, so identical
+          files are stored once; commit the directory to share attachments
+          through git, or ignore it to keep them local
+  local   Copy into attachments.path, e.g. a shared network mount
+  s3      S3 or S3-compatible store (attachments.bucket, attachments.region,
+          attachments.endpoint for MinIO/R2; AWS_ACCESS_KEY_ID and
+          AWS_SECRET_ACCESS_KEY from the environment)
+  gcs     Google Cloud Storage (attachments.bucket; GOOGLE_OAUTH_ACCESS_TOKEN
+          or an authenticated gcloud CLI)
+
+attachments.prefix sets a key prefix inside the bucket.
+
+Examples:
+  bd attach bd-42 crash.log          # Upload and attach
+  bd attach bd-42                    # List attachments
+  bd attach get bd-42                # Download all into the current directory
+  bd attach get bd-42 crash.log -o /tmp/crash.log
+  bd attach --preview bd-42 design.pdf`,
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		get, _ := cmd.Flags().GetBool("get")
+		preview, _ := cmd.Flags().GetBool("preview")
+		output, _ := cmd.Flags().GetString("output")
+
+		issueID := attachIssueID(args[0])
+		name := ""
+		if len(args) > 1 {
+			name = args[1]
+		}
+		switch {
+		case get:
+			runAttachGet(issueID, name, output)
+		case preview:
+			runAttachPreview(issueID, name)
+		case len(args) > 1:
+			CheckReadonly("attach")
+			runAttachPut(issueID, args[1])
+		default:
+			runAttachList(issueID)
+		}
+	},
+}
+
+var attachGetCmd = &cobra.Command{
+	Use:   "get <id> [name]",
+	Short: "Download an issue's attachments and verify their checksums",
+	Long: `Download the attachments of an issue, or the one matching name (file name
+or SHA-256 prefix), and verify each against its recorded hash.
+
+Examples:
+  bd attach get bd-42                          # All, into the current directory
+  bd attach get bd-42 crash.log -o /tmp/crash.log
+  bd attach get bd-42 3f2a9c -o downloads/`,
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		issueID := attachIssueID(args[0])
+		name := ""
+		if len(args) > 1 {
+			name = args[1]
+		}
+		runAttachGet(issueID, name, output)
+	},
+}
+
+// attachIssueID switches to direct mode and resolves the issue argument
+func attachIssueID(arg string) string {
+	if err := ensureDirectMode("attach requires direct database access"); err != nil {
+		FatalError("%v", err)
+	}
+	ctx := rootCtx
+	if err := ensureDatabaseFresh(ctx); err != nil {
+		FatalError("%v", err)
+	}
+	issueID, err := utils.ResolvePartialID(ctx, store, arg)
+	if err != nil {
+		FatalError("%v", err)
+	}
+	return issueID
+}
+
+// defaultAttachmentProvider is used when attachments.provider is unset
+const defaultAttachmentProvider = "blobs"
+
+// attachmentOptions reads the provider settings from config.yaml
+func attachmentOptions() attachments.Options {
+	blobDir := ""
+	if dbPath != "" {
+		blobDir = filepath.Join(filepath.Dir(dbPath), "blobs")
+	}
+	return attachments.Options{
+		BlobDir:  blobDir,
+		Path:     config.GetString("attachments.path"),
+		Bucket:   config.GetString("attachments.bucket"),
+		Prefix:   config.GetString("attachments.prefix"),
+		Region:   config.GetString("attachments.region"),
+		Endpoint: config.GetString("attachments.endpoint"),
+	}
+}
+
+func runAttachPut(issueID, file string) {
+	// #nosec G304 - file named by the user on the command line
+	data, err := os.ReadFile(file)
+	if err != nil {
+		FatalError("reading %s: %v", file, err)
+	}
+	attachment := uploadAttachment(file, data)
+	recordAttachment(issueID, attachment, data)
+
+	if jsonOutput {
+		outputJSON(attachment)
+		return
+	}
+	green := color.New(color.FgGreen).SprintFunc()
+	fmt.Printf("%s Attached %s to %s (%s)\n", green("✓"), attachment.Name, issueID, attachment.URL)
+}
+
+// uploadAttachment stores data with the configured provider and returns an
+// attachment record for it that is not yet tied to an issue
+func uploadAttachment(name string, data []byte) *types.Attachment {
+	providerName := config.GetString("attachments.provider")
+	if providerName == "" {
+		providerName = defaultAttachmentProvider
+	}
+	opts := attachmentOptions()
+	provider, err := attachments.New(providerName, opts)
+	if err != nil {
+		FatalError("%v", err)
+	}
+
+	sha := attachments.Hash(data)
+	url, err := provider.Put(rootCtx, attachments.Key(opts.Prefix, sha, name), data)
+	if err != nil {
+		FatalError("upload failed: %v", err)
+	}
+	return &types.Attachment{
+		Name:      filepath.Base(name),
+		URL:       url,
+		SHA256:    sha,
+		Size:      int64(len(data)),
+		CreatedBy: actor,
+	}
+}
+
+// recordAttachment adds an uploaded attachment to issueID and indexes its
+// text while the content is in hand
+func recordAttachment(issueID string, attachment *types.Attachment, data []byte) {
+	ctx := rootCtx
+	attachment.IssueID = issueID
+	if err := store.AddAttachment(ctx, attachment); err != nil {
+		FatalError("%v", err)
+	}
+	markDirtyAndScheduleFlush()
+
+	if indexer := newAttachmentIndexer(store); indexer != nil {
+		if err := indexer.Index(ctx, attachment, data); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: attachment text not indexed: %v\n", err)
+		}
+	}
+}
+
+func runAttachList(issueID string) {
+	list, err := store.GetAttachments(rootCtx, issueID)
+	if err != nil {
+		FatalError("%v", err)
+	}
+	if jsonOutput {
+		if list == nil {
+			list = []*types.Attachment{}
+		}
+		outputJSON(list)
+		return
+	}
+	if len(list) == 0 {
+		fmt.Printf("No attachments on %s\n", issueID)
+		return
+	}
+	fmt.Printf("Attachments on %s:\n", issueID)
+	for _, a := range list {
+		fmt.Printf("  %s  %s  %s\n", a.Name, formatAttachmentSize(a.Size), a.URL)
+	}
+}
+
+// runAttachGet downloads the issue's attachments (or the one matching name,
+// by file name or hash prefix) and verifies each against its recorded hash
+func runAttachGet(issueID, name, output string) {
+	selected := selectAttachments(issueID, name)
+
+	// A single download may go to an explicit file path; otherwise output is
+	// a directory
+	dir, file := output, ""
+	if len(selected) == 1 && output != "" {
+		if info, err := os.Stat(output); err != nil || !info.IsDir() {
+			dir, file = "", output
+		}
+	}
+
+	var written []string
+	for _, a := range selected {
+		data := downloadAttachment(a)
+		dest := file
+		if dest == "" {
+			dest = filepath.Join(dir, filepath.Base(a.Name))
+		}
+		// #nosec G306 - downloaded attachment, same permissions as other user files
+		if err := os.WriteFile(dest, data, 0644); err != nil {
+			FatalError("writing %s: %v", dest, err)
+		}
+		written = append(written, dest)
+	}
+
+	if jsonOutput {
+		outputJSON(map[string]interface{}{"issue_id": issueID, "files": written})
+		return
+	}
+	green := color.New(color.FgGreen).SprintFunc()
+	for _, dest := range written {
+		fmt.Printf("%s Downloaded %s (sha256 verified)\n", green("✓"), dest)
+	}
+}
+
+// runAttachPreview prints the text extracted from the issue's attachments
+// (or the one matching name)
+func runAttachPreview(issueID, name string) {
+	type preview struct {
+		Name  string `json:"name"`
+		Text  string `json:"text,omitempty"`
+		Error string `json:"error,omitempty"`
+	}
+	var previews []preview
+	for _, a := range selectAttachments(issueID, name) {
+		p := preview{Name: a.Name}
+		if !attachments.Extractable(a.Name) {
+			p.Error = "no preview for this file type"
+		} else if text, err := attachments.ExtractText(a.Name, downloadAttachment(a)); err != nil {
+			p.Error = err.Error()
+		} else {
+			p.Text = text
+		}
+		previews = append(previews, p)
+	}
+
+	if jsonOutput {
+		outputJSON(previews)
+		return
+	}
+	bold := color.New(color.Bold).SprintFunc()
+	for i, p := range previews {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("%s\n", bold(p.Name))
+		switch {
+		case p.Error != "":
+			fmt.Printf("  (%s)\n", p.Error)
+		case strings.TrimSpace(p.Text) == "":
+			fmt.Printf("  (no text found)\n")
+		default:
+			fmt.Println(strings.TrimRight(p.Text, "\n"))
+		}
+	}
+}
+
+// selectAttachments returns the issue's attachments, or the one matching
+// name by file name or hash prefix
+func selectAttachments(issueID, name string) []*types.Attachment {
+	list, err := store.GetAttachments(rootCtx, issueID)
+	if err != nil {
+		FatalError("%v", err)
+	}
+	var selected []*types.Attachment
+	for _, a := range list {
+		if name == "" || a.Name == name || (len(name) >= 6 && strings.HasPrefix(a.SHA256, name)) {
+			selected = append(selected, a)
+		}
+	}
+	if len(selected) == 0 {
+		if name != "" {
+			FatalError("no attachment %q on %s", name, issueID)
+		}
+		FatalError("%s has no attachments", issueID)
+	}
+	return selected
+}
+
+// downloadAttachment fetches an attachment and verifies its hash
+func downloadAttachment(a *types.Attachment) []byte {
+	provider, err := attachments.ForURL(a.URL, attachmentOptions())
+	if err != nil {
+		FatalError("%v", err)
+	}
+	data, err := provider.Get(rootCtx, a.URL)
+	if err != nil {
+		FatalError("download of %s failed: %v", a.Name, err)
+	}
+	if err := attachments.Verify(data, a.SHA256); err != nil {
+		FatalError("%s: %v", a.Name, err)
+	}
+	return data
+}
+
+func formatAttachmentSize(size int64) string {
+	switch {
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(size)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", size)
+	}
+}
+
+func init() {
+	attachCmd.Flags().Bool("get", false, "Download attachments and verify their checksums")
+	attachCmd.Flags().Bool("preview", false, "Show the text extracted from attachments")
+	attachCmd.Flags().StringP("output", "o", "", "Download destination (file for a single attachment, otherwise a directory)")
+	attachGetCmd.Flags().StringP("output", "o", "", "Download destination (file for a single attachment, otherwise a directory)")
+	attachCmd.AddCommand(attachGetCmd)
+	rootCmd.AddCommand(attachCmd)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/attachments"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+)
+
+// newAttachmentIndexer returns the indexer that extracts attachment text
+// for bd search, or nil when attachments.index is off
+func newAttachmentIndexer(s storage.Storage) *attachments.Indexer {
+	textStore, ok := s.(attachments.TextStore)
+	if !ok || !config.GetBool("attachments.index") {
+		return nil
+	}
+	return &attachments.Indexer{
+		Store:   textStore,
+		Options: attachmentOptions(),
+		MaxMB:   config.GetInt("attachments.index_max_mb"),
+	}
+}
+
+// startAttachmentIndexer indexes attachments in the background, including
+// ones added in other clones and imported from JSONL
+func startAttachmentIndexer(ctx context.Context, store storage.Storage, log daemonLogger) {
+	indexer := newAttachmentIndexer(store)
+	if indexer == nil {
+		return
+	}
+	indexer.Logf = log.log
+	go indexer.Run(ctx)
+}
+
+// runIndexAttachments implements bd index --attachments
+func runIndexAttachments(sqliteStore *sqlite.SQLiteStorage, rebuild bool) {
+	ctx := rootCtx
+	indexer := newAttachmentIndexer(sqliteStore)
+	if indexer == nil {
+		FatalErrorWithHint("attachment indexing is disabled",
+			"set attachments.index: true in .beads/config.yaml")
+	}
+	if rebuild {
+		if err := sqliteStore.ClearAttachmentText(ctx); err != nil {
+			FatalError("%v", err)
+		}
+	}
+	n, err := indexer.IndexPending(ctx)
+	if err != nil {
+		FatalError("%v", err)
+	}
+	if jsonOutput {
+		outputJSON(map[string]interface{}{"attachments_indexed": n})
+		return
+	}
+	fmt.Printf("Indexed text of %d attachments\n", n)
+}
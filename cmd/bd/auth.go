@@ -0,0 +1,257 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/oidc"
+)
+
+// authRefreshMargin refreshes tokens this long before they expire so a
+// request in flight doesn't arrive with a stale token
+const authRefreshMargin = time.Minute
+
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Log in to a bd server with your organization's SSO (OIDC)",
+	Long: `Obtain identity tokens for servers running 'bd serve --multi-tenant' with
+OIDC enabled.
+
+Login uses the device authorization flow: bd prints a URL and a code, you
+approve the login in a browser, and bd stores the resulting tokens in
+~/.beads/oidc-tokens.json (readable only by you). 'bd auth token' prints a
+current ID token, refreshing it when needed, for use as a bearer token:
+
+  curl -H "Authorization: Bearer $(bd auth token)" https://beads.example.com/t/acme/ready
+
+The provider comes from oidc.issuer and oidc.client_id in config.yaml (or
+BD_OIDC_ISSUER / BD_OIDC_CLIENT_ID), the same settings the server uses.`,
+}
+
+var authLoginCmd = &cobra.Command{
+	Use:   "login",
+	Short: "Log in with the device authorization flow",
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := authConfig(cmd)
+		client, err := oidc.NewClient(rootCtx, cfg, nil)
+		if err != nil {
+			FatalError("%v", err)
+		}
+		auth, err := client.StartDeviceFlow(rootCtx)
+		if err != nil {
+			FatalError("%v", err)
+		}
+
+		// Instructions go to stderr so --json output stays parseable
+		if auth.VerificationURIComplete != "" {
+			fmt.Fprintf(os.Stderr, "Open %s to log in\n", auth.VerificationURIComplete)
+			fmt.Fprintf(os.Stderr, "(or visit %s and enter code %s)\n", auth.VerificationURI, auth.UserCode)
+		} else {
+			fmt.Fprintf(os.Stderr, "Open %s and enter code %s\n", auth.VerificationURI, auth.UserCode)
+		}
+		fmt.Fprintln(os.Stderr, "Waiting for approval...")
+
+		tok, err := client.PollToken(rootCtx, auth)
+		if err != nil {
+			FatalError("login failed: %v", err)
+		}
+		id, err := oidc.NewVerifier(cfg, nil).Verify(rootCtx, tok.IDToken)
+		if err != nil {
+			FatalErrorWithHint(fmt.Sprintf("provider returned a token bd can't verify: %v", err), "check oidc.client_id and oidc.audience match the provider's client")
+		}
+		if err := saveAuthToken(cfg, tok); err != nil {
+			FatalError("%v", err)
+		}
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"issuer": cfg.Issuer, "actor": id.Actor, "expiry": tok.Expiry})
+			return
+		}
+		fmt.Printf("Logged in as %s (%s)\n", id.Actor, cfg.Issuer)
+	},
+}
+
+var authTokenCmd = &cobra.Command{
+	Use:   "token",
+	Short: "Print a current ID token, refreshing it if needed",
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := authConfig(cmd)
+		tok, err := loadAuthToken(cfg)
+		if err != nil {
+			FatalError("%v", err)
+		}
+		if tok == nil {
+			FatalErrorWithHint("not logged in to "+cfg.Issuer, "run 'bd auth login'")
+		}
+
+		if tok.Expired(time.Now(), authRefreshMargin) {
+			if tok.RefreshToken == "" {
+				FatalErrorWithHint("token expired and the provider issued no refresh token", "run 'bd auth login' again")
+			}
+			client, err := oidc.NewClient(rootCtx, cfg, nil)
+			if err != nil {
+				FatalError("%v", err)
+			}
+			if tok, err = client.Refresh(rootCtx, tok.RefreshToken); err != nil {
+				FatalErrorWithHint(err.Error(), "run 'bd auth login' again")
+			}
+			if err := saveAuthToken(cfg, tok); err != nil {
+				FatalError("%v", err)
+			}
+		}
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"id_token": tok.IDToken, "expiry": tok.Expiry})
+			return
+		}
+		fmt.Println(tok.IDToken)
+	},
+}
+
+var authStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the stored login for the configured provider",
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := authConfig(cmd)
+		tok, err := loadAuthToken(cfg)
+		if err != nil {
+			FatalError("%v", err)
+		}
+		status := map[string]interface{}{"issuer": cfg.Issuer, "logged_in": tok != nil}
+		if tok != nil {
+			status["expiry"] = tok.Expiry
+			status["expired"] = tok.Expired(time.Now(), 0)
+			status["refreshable"] = tok.RefreshToken != ""
+		}
+		if jsonOutput {
+			outputJSON(status)
+			return
+		}
+		if tok == nil {
+			fmt.Printf("Not logged in to %s\n", cfg.Issuer)
+			return
+		}
+		state := "valid until " + displayTime(tok.Expiry)
+		if tok.Expired(time.Now(), 0) {
+			state = "expired " + displayTime(tok.Expiry)
+			if tok.RefreshToken != "" {
+				state += " (refreshes automatically)"
+			}
+		}
+		fmt.Printf("Logged in to %s, token %s\n", cfg.Issuer, state)
+	},
+}
+
+var authLogoutCmd = &cobra.Command{
+	Use:   "logout",
+	Short: "Forget the stored tokens for the configured provider",
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := authConfig(cmd)
+		if err := saveAuthToken(cfg, nil); err != nil {
+			FatalError("%v", err)
+		}
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"issuer": cfg.Issuer, "logged_in": false})
+			return
+		}
+		fmt.Printf("Logged out of %s\n", cfg.Issuer)
+	},
+}
+
+// authConfig loads the provider settings, letting --issuer and --client-id
+// override config.yaml
+func authConfig(cmd *cobra.Command) *oidc.Config {
+	cfg, err := oidc.FromConfig()
+	if err != nil {
+		FatalError("%v", err)
+	}
+	if issuer, _ := cmd.Flags().GetString("issuer"); issuer != "" {
+		cfg.Issuer = strings.TrimRight(issuer, "/")
+	}
+	if clientID, _ := cmd.Flags().GetString("client-id"); clientID != "" {
+		cfg.ClientID = clientID
+	}
+	if !cfg.Enabled() || cfg.ClientID == "" {
+		FatalErrorWithHint("no OIDC provider configured", "set oidc.issuer and oidc.client_id in config.yaml or pass --issuer and --client-id")
+	}
+	return cfg
+}
+
+// authTokensPath is the per-user token file, keyed by issuer and client
+func authTokensPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, ".beads", "oidc-tokens.json"), nil
+}
+
+func authTokenKey(cfg *oidc.Config) string {
+	return cfg.Issuer + " " + cfg.ClientID
+}
+
+func readAuthTokens() (map[string]*oidc.Token, string, error) {
+	path, err := authTokensPath()
+	if err != nil {
+		return nil, "", err
+	}
+	tokens := make(map[string]*oidc.Token)
+	data, err := os.ReadFile(path) // #nosec G304 - fixed path under the user's home
+	if os.IsNotExist(err) {
+		return tokens, path, nil
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return nil, "", fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return tokens, path, nil
+}
+
+// loadAuthToken returns the stored token for the provider, or nil
+func loadAuthToken(cfg *oidc.Config) (*oidc.Token, error) {
+	tokens, _, err := readAuthTokens()
+	if err != nil {
+		return nil, err
+	}
+	return tokens[authTokenKey(cfg)], nil
+}
+
+// saveAuthToken stores tok for the provider; nil removes it
+func saveAuthToken(cfg *oidc.Config, tok *oidc.Token) error {
+	tokens, path, err := readAuthTokens()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		delete(tokens, authTokenKey(cfg))
+	} else {
+		tokens[authTokenKey(cfg)] = tok
+	}
+	data, err := json.MarshalIndent(tokens, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+func init() {
+	for _, c := range []*cobra.Command{authLoginCmd, authTokenCmd, authStatusCmd, authLogoutCmd} {
+		c.Flags().String("issuer", "", "OIDC issuer URL (overrides oidc.issuer)")
+		c.Flags().String("client-id", "", "OIDC client ID (overrides oidc.client_id)")
+		authCmd.AddCommand(c)
+	}
+	rootCmd.AddCommand(authCmd)
+}
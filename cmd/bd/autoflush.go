@@ -1,7 +1,6 @@
 package main
 
 import (
-	"bufio"
 	"bytes"
 	"context"
 	"crypto/sha256"
@@ -18,7 +17,11 @@ import (
 	"github.com/steveyegge/beads/internal/beads"
 	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/debug"
+	"github.com/steveyegge/beads/internal/encryption"
+	"github.com/steveyegge/beads/internal/redact"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/util"
 	"github.com/steveyegge/beads/internal/utils"
 )
 
@@ -86,6 +89,7 @@ func autoImportIfNewer() {
 
 	// Find JSONL path
 	jsonlPath := findJSONLPath()
+	assembleJSONLShards(jsonlPath)
 
 	// Read JSONL file
 	jsonlData, err := os.ReadFile(jsonlPath)
@@ -101,7 +105,9 @@ func autoImportIfNewer() {
 	currentHash := hex.EncodeToString(hasher.Sum(nil))
 
 	// Get content hash from DB metadata (try new key first, fall back to old for migration - bd-39o)
-	ctx := rootCtx
+	// An operation of its own, so bd undo can take back a bad import without
+	// the command that triggered it
+	ctx := sqlite.WithOperation(rootCtx, sqlite.NewOperationKey(), actor, "auto-import")
 	lastHash, err := store.GetMetadata(ctx, "jsonl_content_hash")
 	if err != nil || lastHash == "" {
 		lastHash, err = store.GetMetadata(ctx, "last_import_hash")
@@ -143,8 +149,7 @@ func autoImportIfNewer() {
 	}
 
 	// Content changed - parse all issues
-	scanner := bufio.NewScanner(bytes.NewReader(jsonlData))
-	scanner.Buffer(make([]byte, 0, 1024), 2*1024*1024) // 2MB buffer for large JSON lines
+	scanner := util.NewJSONLScanner(bytes.NewReader(jsonlData))
 	var allIssues []*types.Issue
 	lineNo := 0
 
@@ -440,6 +445,16 @@ func validateJSONLIntegrity(ctx context.Context, jsonlPath string) (bool, error)
 }
 
 func writeJSONLAtomic(jsonlPath string, issues []*types.Issue) ([]string, error) {
+	// Secrets are redacted from the JSONL even when they are stored in the DB
+	redactor, err := redact.FromConfig()
+	if err != nil {
+		return nil, err
+	}
+	fieldCipher, err := encryption.FromConfig(filepath.Dir(jsonlPath))
+	if err != nil {
+		return nil, err
+	}
+
 	// Sort issues by ID for consistent output
 	sort.Slice(issues, func(i, j int) bool {
 		return issues[i].ID < issues[j].ID
@@ -466,7 +481,11 @@ func writeJSONLAtomic(jsonlPath string, issues []*types.Issue) ([]string, error)
 	exportedIDs := make([]string, 0, len(issues))
 	
 	for _, issue := range issues {
-		if err := encoder.Encode(issue); err != nil {
+		exported, err := fieldCipher.ForExport(redactor.ForExport(issue))
+		if err != nil {
+			return nil, err
+		}
+		if err := encoder.Encode(exported); err != nil {
 		 return nil, fmt.Errorf("failed to encode issue %s: %w", issue.ID, err)
 		}
 		
@@ -497,6 +516,10 @@ func writeJSONLAtomic(jsonlPath string, issues []*types.Issue) ([]string, error)
 		debug.Logf("failed to set file permissions: %v", err)
 	}
 
+	if err := syncJSONLShards(jsonlPath); err != nil {
+		return nil, fmt.Errorf("failed to write JSONL shards: %w", err)
+	}
+
 	return exportedIDs, nil
 }
 
@@ -619,8 +642,10 @@ func flushToJSONLWithState(state flushState) {
 	var dirtyIDs []string
 
 	if fullExport {
-		// Full export: get ALL issues (needed after ID-changing operations like renumber)
-		allIssues, err2 := store.SearchIssues(ctx, "", types.IssueFilter{})
+		// Full export: get ALL issues (needed after ID-changing operations like renumber).
+		// Tombstones are included, as they are in incremental exports, so a
+		// full rebuild doesn't drop deletions from the JSONL.
+		allIssues, err2 := store.SearchIssues(ctx, "", types.IssueFilter{IncludeTombstones: true})
 		if err2 != nil {
 			recordFailure(fmt.Errorf("failed to get all issues: %w", err2))
 			return
@@ -649,10 +674,7 @@ func flushToJSONLWithState(state flushState) {
 	issueMap := make(map[string]*types.Issue)
 	if !fullExport {
 		if existingFile, err := os.Open(jsonlPath); err == nil {
-			scanner := bufio.NewScanner(existingFile)
-			// Increase buffer to handle large JSON lines (bd-c6cf)
-			// Default scanner limit is 64KB which can cause silent truncation
-			scanner.Buffer(make([]byte, 0, 1024), 2*1024*1024) // 2MB max line size
+			scanner := util.NewJSONLScanner(existingFile)
 			lineNum := 0
 			for scanner.Scan() {
 				lineNum++
@@ -699,6 +721,30 @@ func flushToJSONLWithState(state flushState) {
 		}
 		issue.Dependencies = deps
 
+		// Get attachments for this issue
+		attachments, err := store.GetAttachments(ctx, issueID)
+		if err != nil {
+			recordFailure(fmt.Errorf("failed to get attachments for %s: %w", issueID, err))
+			return
+		}
+		issue.Attachments = attachments
+
+		// Get work log for this issue
+		workLog, err := store.GetWorkLog(ctx, issueID)
+		if err != nil {
+			recordFailure(fmt.Errorf("failed to get work log for %s: %w", issueID, err))
+			return
+		}
+		issue.WorkLog = workLog
+
+		// Get comments for this issue
+		comments, err := store.GetIssueComments(ctx, issueID)
+		if err != nil {
+			recordFailure(fmt.Errorf("failed to get comments for %s: %w", issueID, err))
+			return
+		}
+		issue.Comments = comments
+
 		// Update map
 		issueMap[issueID] = issue
 	}
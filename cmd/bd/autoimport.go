@@ -1,7 +1,6 @@
 package main
 
 import (
-	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
@@ -16,6 +15,7 @@ import (
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/syncbranch"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/util"
 	"github.com/steveyegge/beads/internal/utils"
 	"gopkg.in/yaml.v3"
 )
@@ -279,9 +279,7 @@ func importFromGit(ctx context.Context, dbFilePath string, store storage.Storage
 	}
 
 	// Parse JSONL data
-	scanner := bufio.NewScanner(bytes.NewReader(jsonlData))
-	// Increase buffer size to handle large JSONL lines (e.g., big descriptions)
-	scanner.Buffer(make([]byte, 0, 1024*1024), 64*1024*1024) // allow up to 64MB per line
+	scanner := util.NewJSONLScanner(bytes.NewReader(jsonlData))
 	var issues []*types.Issue
 
 	for scanner.Scan() {
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/steveyegge/beads/internal/configfile"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/postgres"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+)
+
+// postgresDSN returns the DSN of the project database when the project at
+// dbPath is configured for the postgres backend, and "" when it uses SQLite
+func postgresDSN(dbPath string) string {
+	if dbPath == "" {
+		return ""
+	}
+	cfg, err := configfile.Load(filepath.Dir(dbPath))
+	if err != nil || cfg == nil || cfg.GetBackend() != configfile.BackendPostgres {
+		return ""
+	}
+	return cfg.GetPostgresDSN()
+}
+
+// usesPostgres reports whether the project at dbPath is configured for the
+// postgres backend, whether or not a DSN is set
+func usesPostgres(dbPath string) bool {
+	if dbPath == "" {
+		return false
+	}
+	cfg, err := configfile.Load(filepath.Dir(dbPath))
+	return err == nil && cfg != nil && cfg.GetBackend() == configfile.BackendPostgres
+}
+
+// openStore opens the project database at dbPath with its configured
+// backend. readOnly applies to SQLite only: a PostgreSQL server enforces its
+// own permissions.
+func openStore(ctx context.Context, dbPath string, readOnly bool) (storage.Storage, error) {
+	if usesPostgres(dbPath) {
+		dsn := postgresDSN(dbPath)
+		if dsn == "" {
+			return nil, fmt.Errorf("%s sets backend %q but no DSN: set postgres_dsn there or %s",
+				configfile.ConfigPath(filepath.Dir(dbPath)), configfile.BackendPostgres, configfile.PostgresDSNEnvVar)
+		}
+		pg, err := postgres.New(ctx, dsn)
+		if err != nil {
+			return nil, err
+		}
+		return pg, nil
+	}
+
+	var s *sqlite.SQLiteStorage
+	var err error
+	if readOnly {
+		s, err = sqlite.NewReadOnly(ctx, dbPath, lockTimeout)
+	} else {
+		s, err = sqlite.NewWithTimeout(ctx, dbPath, lockTimeout)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
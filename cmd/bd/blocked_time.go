@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// BlockedTimeEntry is one issue's row in 'bd stats blocked-time'
+type BlockedTimeEntry struct {
+	ID     string       `json:"id"`
+	Title  string       `json:"title"`
+	Status types.Status `json:"status"`
+	types.StateTime
+}
+
+// BlockedTimeReport is the output of 'bd stats blocked-time'
+type BlockedTimeReport struct {
+	Issues   []*BlockedTimeEntry `json:"issues"`   // Most blocked first
+	Blockers []*BlockedTimeEntry `json:"blockers"` // Most blocking first
+}
+
+var blockedTimeCmd = &cobra.Command{
+	Use:   "blocked-time",
+	Short: "Show which issues spend the most time blocked, and what blocks them",
+	Long: `Show the cumulative time issues have spent blocked, ready and in progress,
+reconstructed from the event history, to find chronic bottlenecks.
+
+An open issue counts as blocked while any of its blocks dependencies is open
+(or while its status is blocked), and as ready otherwise. The second table
+ranks blockers by the blocked time they caused their dependents.
+
+Examples:
+  bd stats blocked-time
+  bd stats blocked-time --top 20 --open
+  bd stats blocked-time --json`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		top, _ := cmd.Flags().GetInt("top")
+		openOnly, _ := cmd.Flags().GetBool("open")
+
+		if err := ensureDirectMode("stats blocked-time requires direct database access"); err != nil {
+			FatalError("%v", err)
+		}
+		sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+		if !ok {
+			FatalError("stats blocked-time requires SQLite storage")
+		}
+		ctx := rootCtx
+
+		times, err := sqliteStore.GetStateTimes(ctx, time.Now())
+		if err != nil {
+			FatalError("%v", err)
+		}
+		issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+		if err != nil {
+			FatalError("%v", err)
+		}
+
+		report := buildBlockedTimeReport(issues, times, top, openOnly)
+		if jsonOutput {
+			outputJSON(report)
+			return
+		}
+		printBlockedTimeReport(report)
+	},
+}
+
+// buildBlockedTimeReport ranks issues by blocked time and by blocking time,
+// keeping the top n of each (n <= 0 keeps all)
+func buildBlockedTimeReport(issues []*types.Issue, times map[string]*types.StateTime, n int, openOnly bool) *BlockedTimeReport {
+	report := &BlockedTimeReport{Issues: []*BlockedTimeEntry{}, Blockers: []*BlockedTimeEntry{}}
+	for _, issue := range issues {
+		st := times[issue.ID]
+		if st == nil || (openOnly && issue.Status == types.StatusClosed) {
+			continue
+		}
+		entry := &BlockedTimeEntry{ID: issue.ID, Title: issue.Title, Status: issue.Status, StateTime: *st}
+		if st.BlockedHours > 0 {
+			report.Issues = append(report.Issues, entry)
+		}
+		if st.BlockingHours > 0 {
+			report.Blockers = append(report.Blockers, entry)
+		}
+	}
+	sort.SliceStable(report.Issues, func(i, j int) bool {
+		if report.Issues[i].BlockedHours != report.Issues[j].BlockedHours {
+			return report.Issues[i].BlockedHours > report.Issues[j].BlockedHours
+		}
+		return report.Issues[i].ID < report.Issues[j].ID
+	})
+	sort.SliceStable(report.Blockers, func(i, j int) bool {
+		if report.Blockers[i].BlockingHours != report.Blockers[j].BlockingHours {
+			return report.Blockers[i].BlockingHours > report.Blockers[j].BlockingHours
+		}
+		return report.Blockers[i].ID < report.Blockers[j].ID
+	})
+	if n > 0 && len(report.Issues) > n {
+		report.Issues = report.Issues[:n]
+	}
+	if n > 0 && len(report.Blockers) > n {
+		report.Blockers = report.Blockers[:n]
+	}
+	return report
+}
+
+// heatBar renders value relative to max as a bar of width cells
+func heatBar(value, maxValue float64, width int) string {
+	if maxValue <= 0 {
+		return strings.Repeat("░", width)
+	}
+	filled := int(value/maxValue*float64(width) + 0.5)
+	if filled > width {
+		filled = width
+	}
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
+
+// issueStateTime returns one issue's state times in direct mode, or nil when
+// the store can't reconstruct them
+func issueStateTime(ctx context.Context, id string) *types.StateTime {
+	sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+	if !ok {
+		return nil
+	}
+	times, err := sqliteStore.GetStateTimes(ctx, time.Now())
+	if err != nil {
+		return nil
+	}
+	return times[id]
+}
+
+// formatStateTime renders an issue's state times for bd show
+func formatStateTime(st *types.StateTime) string {
+	s := fmt.Sprintf("ready %s · blocked %s · in progress %s",
+		formatHours(st.ReadyHours), formatHours(st.BlockedHours), formatHours(st.InProgressHours))
+	if st.BlockingHours > 0 {
+		s += fmt.Sprintf(" · held up others %s", formatHours(st.BlockingHours))
+	}
+	return s
+}
+
+func printBlockedTimeReport(report *BlockedTimeReport) {
+	cyan := color.New(color.FgCyan).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
+
+	if len(report.Issues) == 0 {
+		fmt.Printf("\n%s No issue has spent time blocked\n\n", cyan("⏱"))
+		return
+	}
+
+	fmt.Printf("\n%s Most blocked issues\n\n", cyan("⏱"))
+	fmt.Printf("  %-14s %-20s %8s %8s %8s  %s\n", "ISSUE", "", "BLOCKED", "READY", "WORK", "TITLE")
+	maxHours := report.Issues[0].BlockedHours
+	for _, e := range report.Issues {
+		fmt.Printf("  %-14s %s %8s %8s %8s  %s\n", e.ID, red(heatBar(e.BlockedHours, maxHours, 20)),
+			formatHours(e.BlockedHours), formatHours(e.ReadyHours), formatHours(e.InProgressHours), truncateTitle(e.Title, 40))
+	}
+
+	if len(report.Blockers) > 0 {
+		fmt.Printf("\n%s Biggest blockers (blocked time caused)\n\n", cyan("⛔"))
+		maxHours = report.Blockers[0].BlockingHours
+		for _, e := range report.Blockers {
+			fmt.Printf("  %-14s %s %8s  %-11s %s\n", e.ID, red(heatBar(e.BlockingHours, maxHours, 20)),
+				formatHours(e.BlockingHours), e.Status, truncateTitle(e.Title, 40))
+		}
+	}
+	fmt.Println()
+}
+
+func init() {
+	blockedTimeCmd.Flags().Int("top", 20, "Show at most this many issues and blockers (0 = all)")
+	blockedTimeCmd.Flags().Bool("open", false, "Only include issues that are not closed")
+	statsCmd.AddCommand(blockedTimeCmd)
+}
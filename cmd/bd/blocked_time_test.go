@@ -0,0 +1,55 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestBuildBlockedTimeReport(t *testing.T) {
+	issues := []*types.Issue{
+		{ID: "bd-1", Title: "Blocker", Status: types.StatusClosed},
+		{ID: "bd-2", Title: "Long wait", Status: types.StatusOpen},
+		{ID: "bd-3", Title: "Short wait", Status: types.StatusInProgress},
+		{ID: "bd-4", Title: "Never blocked", Status: types.StatusOpen},
+	}
+	times := map[string]*types.StateTime{
+		"bd-1": {ReadyHours: 5, BlockingHours: 30},
+		"bd-2": {BlockedHours: 20},
+		"bd-3": {BlockedHours: 10, InProgressHours: 2},
+		"bd-4": {ReadyHours: 40},
+	}
+
+	report := buildBlockedTimeReport(issues, times, 0, false)
+	if len(report.Issues) != 2 || report.Issues[0].ID != "bd-2" || report.Issues[1].ID != "bd-3" {
+		t.Errorf("issues = %+v, want bd-2 then bd-3", report.Issues)
+	}
+	if len(report.Blockers) != 1 || report.Blockers[0].ID != "bd-1" {
+		t.Errorf("blockers = %+v, want bd-1", report.Blockers)
+	}
+
+	report = buildBlockedTimeReport(issues, times, 1, true)
+	if len(report.Issues) != 1 || report.Issues[0].ID != "bd-2" {
+		t.Errorf("top 1 issues = %+v, want bd-2", report.Issues)
+	}
+	if len(report.Blockers) != 0 {
+		t.Errorf("--open should drop the closed blocker, got %+v", report.Blockers)
+	}
+}
+
+func TestHeatBar(t *testing.T) {
+	tests := []struct {
+		value, max float64
+		want       string
+	}{
+		{10, 10, "█████"},
+		{5, 10, "███░░"},
+		{0, 10, "░░░░░"},
+		{3, 0, "░░░░░"},
+	}
+	for _, tt := range tests {
+		if got := heatBar(tt.value, tt.max, 5); got != tt.want {
+			t.Errorf("heatBar(%v, %v) = %q, want %q", tt.value, tt.max, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// charsPerToken is the usual rough estimate for English text and JSON
+const charsPerToken = 4
+
+// elisionReserve is room kept for the markers smartTruncate inserts
+const elisionReserve = 2 * 40
+
+// estimateTokens approximates how many LLM tokens s takes
+func estimateTokens(s string) int {
+	return (utf8.RuneCountInString(s) + charsPerToken - 1) / charsPerToken
+}
+
+// budgetField is one long text field that --budget may shorten
+type budgetField struct {
+	name string
+	text *string
+}
+
+// fitJSONBudget shortens the long text of one issue (description, design,
+// acceptance criteria, notes, comments) until its JSON form fits in budget
+// tokens, and returns the names of the fields it elided from. Short fields
+// are kept whole; the remaining budget is shared evenly by the long ones.
+func fitJSONBudget(details interface{}, issue *types.Issue, comments []*types.Comment, budget int) []string {
+	data, err := json.Marshal(details)
+	if err != nil || estimateTokens(string(data)) <= budget {
+		return nil
+	}
+
+	fields := []budgetField{
+		{"description", &issue.Description},
+		{"design", &issue.Design},
+		{"acceptance_criteria", &issue.AcceptanceCriteria},
+		{"notes", &issue.Notes},
+	}
+	for i, c := range comments {
+		fields = append(fields, budgetField{fmt.Sprintf("comments[%d]", i), &c.Text})
+	}
+	textTokens := 0
+	for _, f := range fields {
+		textTokens += estimateTokens(*f.text)
+	}
+	available := budget - (estimateTokens(string(data)) - textTokens)
+
+	// Water-filling: every field smaller than an even share keeps all of it
+	shares := make([]int, len(fields))
+	remaining := make([]int, 0, len(fields))
+	for i := range fields {
+		remaining = append(remaining, i)
+	}
+	for len(remaining) > 0 {
+		share := max(available, 0) / len(remaining)
+		var next []int
+		for _, i := range remaining {
+			if size := estimateTokens(*fields[i].text); size <= share {
+				shares[i] = size
+				available -= size
+			} else {
+				next = append(next, i)
+			}
+		}
+		if len(next) == len(remaining) {
+			for _, i := range next {
+				shares[i] = share
+			}
+			break
+		}
+		remaining = next
+	}
+
+	var elided []string
+	for i, f := range fields {
+		if text, cut := smartTruncate(*f.text, shares[i]); cut {
+			*f.text = text
+			elided = append(elided, f.name)
+		}
+	}
+	return elided
+}
+
+// smartTruncate shortens text to about maxTokens, keeping markdown checklist
+// items, the head and the tail, and marking each elided run of lines. Text
+// that is one long line is cut in the middle instead.
+func smartTruncate(text string, maxTokens int) (string, bool) {
+	if estimateTokens(text) <= maxTokens {
+		return text, false
+	}
+	limit := maxTokens*charsPerToken - elisionReserve
+	lines := strings.Split(text, "\n")
+	if len(lines) < 3 {
+		return truncateMiddle(text, limit), true
+	}
+
+	keep := make([]bool, len(lines))
+	cost := func(i int) int { return utf8.RuneCountInString(lines[i]) + 1 }
+	used := 0
+	for i, line := range lines {
+		if storage.IsChecklistLine(line) && used+cost(i) <= limit {
+			keep[i] = true
+			used += cost(i)
+		}
+	}
+	headLimit := used + (limit-used)*2/3
+	for i := 0; i < len(lines) && used+cost(i) <= headLimit; i++ {
+		if !keep[i] {
+			keep[i] = true
+			used += cost(i)
+		}
+	}
+	for i := len(lines) - 1; i >= 0 && (keep[i] || used+cost(i) <= limit); i-- {
+		if !keep[i] {
+			keep[i] = true
+			used += cost(i)
+		}
+	}
+
+	var out []string
+	for i := 0; i < len(lines); {
+		if keep[i] {
+			out = append(out, lines[i])
+			i++
+			continue
+		}
+		start := i
+		for i < len(lines) && !keep[i] {
+			i++
+		}
+		out = append(out, fmt.Sprintf("[… %d lines elided …]", i-start))
+	}
+	return strings.Join(out, "\n"), true
+}
+
+// truncateMiddle keeps the first two thirds and last third of limit
+// characters of text
+func truncateMiddle(text string, limit int) string {
+	runes := []rune(text)
+	limit = max(limit, 0)
+	head := limit * 2 / 3
+	tail := limit - head
+	return fmt.Sprintf("%s[… %d chars elided …]%s", string(runes[:head]), len(runes)-head-tail, string(runes[len(runes)-tail:]))
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSmartTruncate(t *testing.T) {
+	var lines []string
+	for i := 0; i < 200; i++ {
+		lines = append(lines, fmt.Sprintf("line %03d of a long design discussion", i))
+	}
+	lines[100] = "- [ ] bd-12 keep this checklist item"
+	text := strings.Join(lines, "\n")
+
+	if got, cut := smartTruncate("short", 100); cut || got != "short" {
+		t.Errorf("short text changed: %q", got)
+	}
+
+	got, cut := smartTruncate(text, 300)
+	if !cut {
+		t.Fatal("expected truncation")
+	}
+	if estimateTokens(got) > 300 {
+		t.Errorf("result has %d tokens, want <= 300", estimateTokens(got))
+	}
+	for _, want := range []string{"line 000", "line 199", "- [ ] bd-12 keep this checklist item", "lines elided …]"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("truncated text lacks %q", want)
+		}
+	}
+
+	oneLine := strings.Repeat("x", 5000)
+	got, _ = smartTruncate(oneLine, 200)
+	if !strings.Contains(got, "chars elided") || estimateTokens(got) > 200 {
+		t.Errorf("single line not cut in the middle: %d tokens", estimateTokens(got))
+	}
+}
+
+func TestFitJSONBudget(t *testing.T) {
+	issue := &types.Issue{
+		ID:          "bd-1",
+		Title:       "Budgeted",
+		Description: strings.Repeat("a long paragraph\n", 2000),
+		Notes:       "short notes",
+	}
+	comments := []*types.Comment{{Text: strings.Repeat("comment line\n", 1000)}}
+	details := struct {
+		*types.Issue
+		Comments []*types.Comment `json:"comments"`
+	}{issue, comments}
+
+	elided := fitJSONBudget(details, issue, comments, 1000)
+	if len(elided) != 2 || elided[0] != "description" || elided[1] != "comments[0]" {
+		t.Errorf("elided = %v, want [description comments[0]]", elided)
+	}
+	if issue.Notes != "short notes" {
+		t.Errorf("short field was changed: %q", issue.Notes)
+	}
+	data, _ := json.Marshal(details)
+	if tokens := estimateTokens(string(data)); tokens > 1100 {
+		t.Errorf("output is %d tokens, want about 1000", tokens)
+	}
+}
@@ -0,0 +1,709 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// bulkOp is one operation read by bd bulk. Which fields apply depends on Op.
+// Pointer fields distinguish "unchanged" from "set to empty" in updates.
+type bulkOp struct {
+	Op  string `json:"op"`
+	Ref string `json:"ref,omitempty"` // create: name later operations use as "@ref"
+	ID  string `json:"id,omitempty"`  // update/close/dep: the issue; create: explicit ID
+
+	Title              *string  `json:"title,omitempty"`
+	Description        *string  `json:"description,omitempty"`
+	Design             *string  `json:"design,omitempty"`
+	AcceptanceCriteria *string  `json:"acceptance_criteria,omitempty"`
+	Notes              *string  `json:"notes,omitempty"`
+	Status             *string  `json:"status,omitempty"`
+	Priority           *int     `json:"priority,omitempty"`
+	IssueType          string   `json:"issue_type,omitempty"`
+	Assignee           *string  `json:"assignee,omitempty"`
+	ExternalRef        *string  `json:"external_ref,omitempty"`
+	Labels             []string `json:"labels,omitempty"`
+	Parent             string   `json:"parent,omitempty"`
+	Deps               []string `json:"deps,omitempty"` // "bd-1" (blocks) or "type:bd-1"
+
+	Reason    string `json:"reason,omitempty"`     // close
+	DependsOn string `json:"depends_on,omitempty"` // dep
+	DepType   string `json:"dep_type,omitempty"`   // dep
+
+	pos string // "line 3" or "item 3", for errors
+}
+
+// BulkChange records what one operation did (or would do, in a dry run)
+type BulkChange struct {
+	Index     int                        `json:"index"`
+	Op        string                     `json:"op"`
+	ID        string                     `json:"id"`
+	Ref       string                     `json:"ref,omitempty"`
+	Title     string                     `json:"title,omitempty"`
+	Priority  *int                       `json:"priority,omitempty"`
+	IssueType types.IssueType            `json:"issue_type,omitempty"`
+	Labels    []string                   `json:"labels,omitempty"`
+	Deps      []*types.Dependency        `json:"dependencies,omitempty"`
+	Fields    map[string]BulkFieldChange `json:"fields,omitempty"`
+	Reason    string                     `json:"reason,omitempty"`
+}
+
+// BulkFieldChange is a field's value before and after an update
+type BulkFieldChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// BulkResult is the outcome of bd bulk
+type BulkResult struct {
+	DryRun  bool          `json:"dry_run"`
+	Changes []*BulkChange `json:"changes"`
+}
+
+// errBulkDryRun rolls back a dry run's transaction after it has run
+var errBulkDryRun = errors.New("dry run")
+
+// bulkUpdateFields are the issue fields an update operation can set, in
+// the order changes are reported
+var bulkUpdateFields = []string{"title", "status", "priority", "assignee", "description", "design", "acceptance_criteria", "notes", "external_ref"}
+
+var bulkCmd = &cobra.Command{
+	Use:   "bulk",
+	Short: "Apply a batch of operations in one transaction",
+	Long: `Read create, update, close and dep operations as JSON from stdin (or --file)
+and apply them in a single transaction: either every operation succeeds or
+nothing changes. Generating many issues this way is much faster than one CLI
+call per issue, and no other writer can interleave with the batch.
+
+Input is a JSON array of operations or JSON lines, one operation per line:
+
+  {"op": "create", "ref": "api", "title": "Design API", "priority": 1, "issue_type": "feature", "labels": ["backend"]}
+  {"op": "create", "title": "Write client", "deps": ["@api"], "parent": "bd-a3f8"}
+  {"op": "update", "id": "bd-c2d4", "status": "in_progress", "assignee": "alice"}
+  {"op": "close", "id": "bd-e5f6", "reason": "Superseded"}
+  {"op": "dep", "id": "bd-e5f6", "depends_on": "@api", "dep_type": "related"}
+
+create takes title (required), description, design, acceptance_criteria,
+notes, priority, issue_type, assignee, external_ref, labels, parent, deps
+("bd-1" for blocks or "type:bd-1") and an optional explicit id. A create's
+ref names the new issue for later operations as "@ref", wherever an issue ID
+is expected.
+
+update sets any of title, status, priority, assignee, description, design,
+acceptance_criteria, notes and external_ref. Close issues with a close
+operation, which applies the project's close reasons and verification rules.
+Text in creates and updates is redacted and encrypted as with bd create and
+bd update.
+
+--dry-run runs the batch inside the transaction, prints the resulting
+changes, and rolls it back. New issue IDs in a dry run are placeholders;
+real IDs are assigned when the batch is applied.
+
+Examples:
+  bd bulk < plan.jsonl
+  bd bulk --dry-run --file plan.json
+  generate-plan | bd bulk --json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		file, _ := cmd.Flags().GetString("file")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		if !dryRun {
+			CheckReadonly("bulk")
+		}
+		// One transaction over the whole batch needs the store itself
+		if err := ensureDirectMode("bulk requires direct database access"); err != nil {
+			FatalError("%v", err)
+		}
+		ctx := rootCtx
+		if err := ensureDatabaseFresh(ctx); err != nil {
+			FatalError("%v", err)
+		}
+
+		var in io.Reader = os.Stdin
+		if file != "" && file != "-" {
+			// #nosec G304 - user-provided file path is intentional
+			f, err := os.Open(file)
+			if err != nil {
+				FatalError("opening %s: %v", file, err)
+			}
+			defer func() { _ = f.Close() }()
+			in = f
+		}
+		ops, err := parseBulkOps(in)
+		if err != nil {
+			FatalError("%v", err)
+		}
+		if len(ops) == 0 {
+			FatalErrorWithHint("no operations to apply", `pass JSON lines such as {"op": "create", "title": "..."} on stdin`)
+		}
+		protectBulkOps(ops)
+
+		changes, err := applyBulkOps(ctx, store, ops, actor, dryRun)
+		if err != nil {
+			FatalError("bulk failed, nothing was changed: %v", err)
+		}
+		if !dryRun {
+			markDirtyAndScheduleFlush()
+		}
+
+		if jsonOutput {
+			outputJSON(&BulkResult{DryRun: dryRun, Changes: changes})
+			return
+		}
+		printBulkChanges(changes, dryRun)
+	},
+}
+
+// parseBulkOps reads a JSON array of operations, or one operation per line
+func parseBulkOps(r io.Reader) ([]*bulkOp, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading operations: %w", err)
+	}
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return nil, nil
+	}
+
+	var ops []*bulkOp
+	if trimmed[0] == '[' {
+		var items []json.RawMessage
+		if err := json.Unmarshal(trimmed, &items); err != nil {
+			return nil, fmt.Errorf("parsing operations: %w", err)
+		}
+		for i, item := range items {
+			op, err := decodeBulkOp(item, fmt.Sprintf("item %d", i+1))
+			if err != nil {
+				return nil, err
+			}
+			ops = append(ops, op)
+		}
+		return ops, nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		op, err := decodeBulkOp(line, fmt.Sprintf("line %d", lineNum))
+		if err != nil {
+			return nil, err
+		}
+		ops = append(ops, op)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading operations: %w", err)
+	}
+	return ops, nil
+}
+
+// decodeBulkOp decodes and checks one operation. Unknown fields are
+// rejected, so a misspelled field fails the batch instead of being ignored.
+func decodeBulkOp(data []byte, pos string) (*bulkOp, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var op bulkOp
+	if err := dec.Decode(&op); err != nil {
+		return nil, fmt.Errorf("%s: %v", pos, err)
+	}
+	op.pos = pos
+	if op.Op == "dep_add" {
+		op.Op = "dep"
+	}
+
+	var problem string
+	switch op.Op {
+	case "create":
+		if op.Title == nil || strings.TrimSpace(*op.Title) == "" {
+			problem = "title is required"
+		} else if op.Status != nil || op.Reason != "" || op.DependsOn != "" || op.DepType != "" {
+			problem = "create takes no status, reason, depends_on or dep_type"
+		}
+	case "update":
+		switch {
+		case op.ID == "":
+			problem = "id is required"
+		case op.Status != nil && *op.Status == string(types.StatusClosed):
+			problem = `use {"op": "close"} to close an issue`
+		case op.Ref != "" || len(op.Labels) > 0 || op.Parent != "" || len(op.Deps) > 0 || op.IssueType != "" || op.Reason != "" || op.DependsOn != "":
+			problem = "update only sets " + strings.Join(bulkUpdateFields, ", ")
+		case len(op.updates()) == 0:
+			problem = "no fields to update"
+		}
+	case "close":
+		if op.ID == "" {
+			problem = "id is required"
+		}
+	case "dep":
+		if op.ID == "" || op.DependsOn == "" {
+			problem = "id and depends_on are required"
+		} else if op.DepType != "" && !types.DependencyType(op.DepType).IsValid() {
+			problem = fmt.Sprintf("invalid dep_type %q", op.DepType)
+		}
+	case "":
+		problem = "op is required (create, update, close or dep)"
+	default:
+		problem = fmt.Sprintf("unknown op %q (use create, update, close or dep)", op.Op)
+	}
+	if problem != "" {
+		return nil, fmt.Errorf("%s: %s", pos, problem)
+	}
+	return &op, nil
+}
+
+// protectBulkOps strips secrets from the text of create and update
+// operations (redaction.* config) and encrypts their sensitive fields
+// (encryption.fields config) in place, as bd create and bd update do
+func protectBulkOps(ops []*bulkOp) {
+	var texts []*string
+	for _, op := range ops {
+		if op.Op != "create" && op.Op != "update" {
+			continue
+		}
+		for _, text := range []*string{op.Title, op.Description, op.Design, op.AcceptanceCriteria, op.Notes} {
+			if text != nil {
+				texts = append(texts, text)
+			}
+		}
+	}
+	redactInput(texts...)
+
+	for _, op := range ops {
+		if op.Op != "create" && op.Op != "update" {
+			continue
+		}
+		fields := make(map[string]*string)
+		for field, text := range map[string]*string{"description": op.Description, "design": op.Design, "acceptance_criteria": op.AcceptanceCriteria, "notes": op.Notes} {
+			if text != nil {
+				fields[field] = text
+			}
+		}
+		encryptInput(fields)
+	}
+}
+
+// updates returns the fields an update operation sets
+func (op *bulkOp) updates() map[string]interface{} {
+	updates := make(map[string]interface{})
+	set := func(key string, v *string) {
+		if v != nil {
+			updates[key] = *v
+		}
+	}
+	set("title", op.Title)
+	set("status", op.Status)
+	set("assignee", op.Assignee)
+	set("description", op.Description)
+	set("design", op.Design)
+	set("acceptance_criteria", op.AcceptanceCriteria)
+	set("notes", op.Notes)
+	set("external_ref", op.ExternalRef)
+	if op.Priority != nil {
+		updates["priority"] = *op.Priority
+	}
+	return updates
+}
+
+// bulkPlan holds what applyBulkOps works out before the transaction opens:
+// existing issue IDs are resolved and close rules checked up front, so the
+// transaction itself only writes
+type bulkPlan struct {
+	resolved map[string]string // ID as written -> full ID, for existing issues
+	refs     map[string]int    // ref -> index of the create defining it
+	reasons  map[int]string    // close op index -> resolved close reason
+	deps     map[int][]*types.Dependency
+}
+
+// applyBulkOps applies ops in one transaction and returns what each did.
+// With dryRun the transaction is rolled back after the changes are recorded.
+func applyBulkOps(ctx context.Context, s storage.Storage, ops []*bulkOp, actor string, dryRun bool) ([]*BulkChange, error) {
+	plan, err := planBulkOps(ctx, s, ops, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []*BulkChange
+	err = s.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		changes = nil
+		created := make(map[string]string) // ref -> new ID
+		id := func(raw string) string {
+			if ref, ok := strings.CutPrefix(raw, "@"); ok {
+				return created[ref]
+			}
+			return plan.resolved[raw]
+		}
+
+		for i, op := range ops {
+			change := &BulkChange{Index: i + 1, Op: op.Op, Ref: op.Ref}
+			var err error
+			switch op.Op {
+			case "create":
+				err = applyBulkCreate(ctx, tx, op, plan.deps[i], id, actor, change)
+				if err == nil && op.Ref != "" {
+					created[op.Ref] = change.ID
+				}
+			case "update":
+				change.ID = id(op.ID)
+				err = applyBulkUpdate(ctx, tx, change.ID, op.updates(), actor, change)
+			case "close":
+				change.ID, change.Reason = id(op.ID), plan.reasons[i]
+				err = tx.CloseIssue(ctx, change.ID, change.Reason, actor)
+			case "dep":
+				change.ID = id(op.ID)
+				dep := &types.Dependency{IssueID: change.ID, DependsOnID: id(op.DependsOn), Type: types.DepBlocks}
+				if op.DepType != "" {
+					dep.Type = types.DependencyType(op.DepType)
+				}
+				change.Deps = []*types.Dependency{dep}
+				err = tx.AddDependency(ctx, dep, actor)
+			}
+			if err != nil {
+				return fmt.Errorf("%s (%s): %w", op.pos, op.Op, err)
+			}
+			changes = append(changes, change)
+		}
+		if dryRun {
+			return errBulkDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errBulkDryRun) {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// planBulkOps checks references, resolves existing issue IDs, and applies
+// the close rules that 'bd close' applies
+func planBulkOps(ctx context.Context, s storage.Storage, ops []*bulkOp, actor string) (*bulkPlan, error) {
+	plan := &bulkPlan{
+		resolved: make(map[string]string),
+		refs:     make(map[string]int),
+		reasons:  make(map[int]string),
+		deps:     make(map[int][]*types.Dependency),
+	}
+	createdBy := make(map[string]*bulkOp) // ref -> create op, for close checks
+
+	// resolve checks an issue reference: "@ref" must name an earlier create,
+	// anything else an existing issue
+	resolve := func(op *bulkOp, raw string) error {
+		if ref, ok := strings.CutPrefix(raw, "@"); ok {
+			if _, defined := plan.refs[ref]; !defined {
+				return fmt.Errorf("%s: @%s does not name an earlier create", op.pos, ref)
+			}
+			return nil
+		}
+		if _, done := plan.resolved[raw]; done {
+			return nil
+		}
+		full, err := utils.ResolvePartialID(ctx, s, raw)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op.pos, err)
+		}
+		plan.resolved[raw] = full
+		return nil
+	}
+
+	var policy *types.VerificationPolicy
+	for i, op := range ops {
+		var refs []string
+		switch op.Op {
+		case "create":
+			deps, err := parseBulkDeps(op)
+			if err != nil {
+				return nil, err
+			}
+			plan.deps[i] = deps
+			for _, dep := range deps {
+				refs = append(refs, dep.DependsOnID)
+			}
+		case "update", "close":
+			refs = []string{op.ID}
+		case "dep":
+			refs = []string{op.ID, op.DependsOn}
+		}
+		for _, raw := range refs {
+			if err := resolve(op, raw); err != nil {
+				return nil, err
+			}
+		}
+
+		switch op.Op {
+		case "create":
+			if op.Ref != "" {
+				if _, dup := plan.refs[op.Ref]; dup {
+					return nil, fmt.Errorf("%s: ref %q is already defined", op.pos, op.Ref)
+				}
+				plan.refs[op.Ref] = i
+				createdBy[op.Ref] = op
+			}
+		case "close":
+			reason := op.Reason
+			if reason == "" {
+				reason = "Closed"
+			}
+			reason, err := storage.ResolveCloseReason(ctx, s, reason, "")
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", op.pos, err)
+			}
+			plan.reasons[i] = reason
+
+			ref, isRef := strings.CutPrefix(op.ID, "@")
+			if !isRef {
+				if err := storage.CheckVerifiedClose(ctx, s, plan.resolved[op.ID], actor); err != nil {
+					return nil, fmt.Errorf("%s: %w", op.pos, err)
+				}
+				if err := storage.CheckDefinitionOfDone(ctx, s, plan.resolved[op.ID]); err != nil {
+					return nil, fmt.Errorf("%s: %w", op.pos, err)
+				}
+				continue
+			}
+			// An issue created in this batch can't have been resolved with
+			// evidence yet, so it can only be closed if no review is required
+			if policy == nil {
+				if policy, err = loadVerificationPolicy(ctx, s); err != nil {
+					return nil, err
+				}
+			}
+			create := createdBy[ref]
+			issue := &types.Issue{Priority: 2}
+			if create.Priority != nil {
+				issue.Priority = *create.Priority
+			}
+			if policy.Requires(issue, create.Labels) {
+				return nil, fmt.Errorf("%s: @%s requires verification before closing; close it after it is resolved (bd resolve)", op.pos, ref)
+			}
+			// Nor can its definition of done have been confirmed
+			def, strict, err := storage.LoadDoneDefinition(ctx, s)
+			if err != nil {
+				return nil, err
+			}
+			if pending := types.PendingCriteria(def.Criteria(create.Labels, nil)); strict && len(pending) > 0 {
+				return nil, fmt.Errorf("%s: @%s is not done: %s not confirmed; close it with bd close --done", op.pos, ref, strings.Join(pending, ", "))
+			}
+		}
+	}
+	return plan, nil
+}
+
+// loadVerificationPolicy reads the close.verify_* settings
+func loadVerificationPolicy(ctx context.Context, s storage.Storage) (*types.VerificationPolicy, error) {
+	labels, err := s.GetConfig(ctx, "close.verify_labels")
+	if err != nil {
+		return nil, err
+	}
+	priority, err := s.GetConfig(ctx, "close.verify_priority")
+	if err != nil {
+		return nil, err
+	}
+	policy, err := types.ParseVerificationPolicy(labels, priority)
+	if err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// parseBulkDeps reads a create's parent and deps. DependsOnID holds the
+// reference as written until the transaction resolves it.
+func parseBulkDeps(op *bulkOp) ([]*types.Dependency, error) {
+	var deps []*types.Dependency
+	if op.Parent != "" {
+		deps = append(deps, &types.Dependency{DependsOnID: op.Parent, Type: types.DepParentChild})
+	}
+	for _, spec := range op.Deps {
+		dep := &types.Dependency{DependsOnID: strings.TrimSpace(spec), Type: types.DepBlocks}
+		// "@ref" and plain IDs have no type; "type:id" does
+		if depType, id, ok := strings.Cut(spec, ":"); ok && !strings.HasPrefix(spec, "@") {
+			dep.Type, dep.DependsOnID = types.DependencyType(strings.TrimSpace(depType)), strings.TrimSpace(id)
+		}
+		if !dep.Type.IsValid() || dep.DependsOnID == "" {
+			return nil, fmt.Errorf("%s: invalid dependency %q (use bd-1, @ref, or type:bd-1)", op.pos, spec)
+		}
+		deps = append(deps, dep)
+	}
+	return deps, nil
+}
+
+func applyBulkCreate(ctx context.Context, tx storage.Transaction, op *bulkOp, deps []*types.Dependency, id func(string) string, actor string, change *BulkChange) error {
+	str := func(v *string) string {
+		if v == nil {
+			return ""
+		}
+		return *v
+	}
+	issue := &types.Issue{
+		ID:                 op.ID,
+		Title:              str(op.Title),
+		Description:        str(op.Description),
+		Design:             str(op.Design),
+		AcceptanceCriteria: str(op.AcceptanceCriteria),
+		Notes:              str(op.Notes),
+		Assignee:           str(op.Assignee),
+		Status:             types.StatusOpen,
+		Priority:           2,
+		IssueType:          types.TypeTask,
+		ExternalRef:        op.ExternalRef,
+	}
+	if op.Priority != nil {
+		issue.Priority = *op.Priority
+	}
+	if op.IssueType != "" {
+		issue.IssueType = types.IssueType(op.IssueType)
+	}
+	if err := tx.CreateIssue(ctx, issue, actor); err != nil {
+		return err
+	}
+	change.ID, change.Title, change.Priority, change.IssueType = issue.ID, issue.Title, &issue.Priority, issue.IssueType
+
+	for _, label := range op.Labels {
+		if err := tx.AddLabel(ctx, issue.ID, label, actor); err != nil {
+			return fmt.Errorf("adding label %q: %w", label, err)
+		}
+	}
+	change.Labels = op.Labels
+	for _, d := range deps {
+		dep := &types.Dependency{IssueID: issue.ID, DependsOnID: id(d.DependsOnID), Type: d.Type}
+		if err := tx.AddDependency(ctx, dep, actor); err != nil {
+			return fmt.Errorf("adding dependency on %s: %w", dep.DependsOnID, err)
+		}
+		change.Deps = append(change.Deps, dep)
+	}
+	return nil
+}
+
+func applyBulkUpdate(ctx context.Context, tx storage.Transaction, id string, updates map[string]interface{}, actor string, change *BulkChange) error {
+	before, err := tx.GetIssue(ctx, id)
+	if err != nil {
+		return err
+	}
+	if before == nil {
+		return fmt.Errorf("issue %s not found", id)
+	}
+	if err := tx.UpdateIssue(ctx, id, updates, actor); err != nil {
+		return err
+	}
+	after, err := tx.GetIssue(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	change.Title = after.Title
+	change.Fields = make(map[string]BulkFieldChange)
+	for key := range updates {
+		oldValue, newValue := bulkFieldValue(before, key), bulkFieldValue(after, key)
+		if oldValue != newValue {
+			change.Fields[key] = BulkFieldChange{Old: oldValue, New: newValue}
+		}
+	}
+	return nil
+}
+
+// bulkFieldValue returns one of bulkUpdateFields from an issue
+func bulkFieldValue(issue *types.Issue, key string) interface{} {
+	switch key {
+	case "title":
+		return issue.Title
+	case "status":
+		return string(issue.Status)
+	case "priority":
+		return issue.Priority
+	case "assignee":
+		return issue.Assignee
+	case "description":
+		return issue.Description
+	case "design":
+		return issue.Design
+	case "acceptance_criteria":
+		return issue.AcceptanceCriteria
+	case "notes":
+		return issue.Notes
+	case "external_ref":
+		if issue.ExternalRef == nil {
+			return ""
+		}
+		return *issue.ExternalRef
+	}
+	return nil
+}
+
+func printBulkChanges(changes []*BulkChange, dryRun bool) {
+	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+	if dryRun {
+		fmt.Printf("%s Dry run: %d operations would apply (nothing was changed; new IDs are placeholders):\n", yellow("⚠"), len(changes))
+	} else {
+		fmt.Printf("%s Applied %d operations:\n", green("✓"), len(changes))
+	}
+
+	for _, c := range changes {
+		switch c.Op {
+		case "create":
+			ref := ""
+			if c.Ref != "" {
+				ref = " (@" + c.Ref + ")"
+			}
+			fmt.Printf("  + %s [P%d %s] %s%s\n", c.ID, *c.Priority, c.IssueType, c.Title, ref)
+			if len(c.Labels) > 0 {
+				fmt.Printf("      labels: %s\n", strings.Join(c.Labels, ", "))
+			}
+			for _, dep := range c.Deps {
+				fmt.Printf("      depends on %s (%s)\n", dep.DependsOnID, dep.Type)
+			}
+		case "update":
+			if len(c.Fields) == 0 {
+				fmt.Printf("  ~ %s: no changes\n", c.ID)
+				continue
+			}
+			fmt.Printf("  ~ %s: %s\n", c.ID, c.Title)
+			for _, key := range bulkUpdateFields {
+				if f, ok := c.Fields[key]; ok {
+					fmt.Printf("      %s: %s → %s\n", key, formatBulkValue(f.Old), formatBulkValue(f.New))
+				}
+			}
+		case "close":
+			fmt.Printf("  ✓ %s closed: %s\n", c.ID, c.Reason)
+		case "dep":
+			dep := c.Deps[0]
+			fmt.Printf("  → %s depends on %s (%s)\n", dep.IssueID, dep.DependsOnID, dep.Type)
+		}
+	}
+}
+
+// formatBulkValue renders a field value on one line
+func formatBulkValue(v interface{}) string {
+	s, ok := v.(string)
+	if !ok {
+		return fmt.Sprint(v)
+	}
+	if first, _, multiline := strings.Cut(s, "\n"); multiline {
+		s = first + " …"
+	}
+	if runes := []rune(s); len(runes) > 60 {
+		s = string(runes[:57]) + "..."
+	}
+	return fmt.Sprintf("%q", s)
+}
+
+func init() {
+	bulkCmd.Flags().StringP("file", "f", "", "Read operations from a file instead of stdin")
+	bulkCmd.Flags().Bool("dry-run", false, "Show the changes the batch would make, then roll them back")
+	rootCmd.AddCommand(bulkCmd)
+}
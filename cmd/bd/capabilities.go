@@ -0,0 +1,226 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// CapabilitiesReport describes the full bd command tree for editor integrations
+type CapabilitiesReport struct {
+	Version     string              `json:"version"`
+	GlobalFlags []FlagCapability    `json:"global_flags"`
+	Commands    []CommandCapability `json:"commands"`
+}
+
+// CommandCapability describes a single command and its subcommands
+type CommandCapability struct {
+	Name        string              `json:"name"`
+	Path        string              `json:"path"` // Full invocation, e.g. "bd dep add"
+	Usage       string              `json:"usage"`
+	Short       string              `json:"short,omitempty"`
+	Long        string              `json:"long,omitempty"`
+	Example     string              `json:"example,omitempty"`
+	Aliases     []string            `json:"aliases,omitempty"`
+	Args        []ArgCapability     `json:"args,omitempty"`
+	Flags       []FlagCapability    `json:"flags,omitempty"`
+	Subcommands []CommandCapability `json:"subcommands,omitempty"`
+	Runnable    bool                `json:"runnable"` // False for pure grouping commands like "bd dep"
+}
+
+// ArgCapability describes a positional argument parsed from a command's usage line
+type ArgCapability struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"` // issue_id, path, or string
+	Required bool   `json:"required"`
+	Variadic bool   `json:"variadic"`
+}
+
+// FlagCapability describes a command-line flag
+type FlagCapability struct {
+	Name      string `json:"name"`
+	Shorthand string `json:"shorthand,omitempty"`
+	Type      string `json:"type"` // pflag type: bool, string, int, stringSlice, duration, ...
+	Default   string `json:"default,omitempty"`
+	Usage     string `json:"usage"`
+	Required  bool   `json:"required,omitempty"`
+}
+
+var capabilitiesCmd = &cobra.Command{
+	Use:   "capabilities",
+	Short: "Describe all commands and flags (for editor integrations)",
+	Long: `Print the complete bd command tree with descriptions, positional
+arguments, and flags.
+
+With --json the output is a stable, machine-readable description that editor
+plugins (VS Code, JetBrains, etc.) can use to build command palettes and forms
+without maintaining their own copy of bd's CLI definitions.
+
+Examples:
+  bd capabilities
+  bd capabilities --json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		report := buildCapabilities(rootCmd)
+
+		if jsonOutput {
+			outputJSON(report)
+			return
+		}
+
+		for _, c := range report.Commands {
+			printCapability(c, 0)
+		}
+	},
+}
+
+// buildCapabilities walks the command tree rooted at root
+func buildCapabilities(root *cobra.Command) *CapabilitiesReport {
+	report := &CapabilitiesReport{
+		Version:     Version,
+		GlobalFlags: collectFlags(root.PersistentFlags()),
+	}
+	for _, sub := range visibleSubcommands(root) {
+		report.Commands = append(report.Commands, describeCommand(sub))
+	}
+	return report
+}
+
+func describeCommand(cmd *cobra.Command) CommandCapability {
+	c := CommandCapability{
+		Name:     cmd.Name(),
+		Path:     cmd.CommandPath(),
+		Usage:    cmd.UseLine(),
+		Short:    cmd.Short,
+		Long:     cmd.Long,
+		Example:  cmd.Example,
+		Aliases:  cmd.Aliases,
+		Args:     parseUsageArgs(cmd.Use),
+		Flags:    collectFlags(cmd.LocalNonPersistentFlags()),
+		Runnable: cmd.Runnable(),
+	}
+	// Persistent flags declared on a subcommand apply to it and its children
+	c.Flags = append(c.Flags, collectFlags(cmd.PersistentFlags())...)
+	sort.Slice(c.Flags, func(i, j int) bool { return c.Flags[i].Name < c.Flags[j].Name })
+
+	for _, sub := range visibleSubcommands(cmd) {
+		c.Subcommands = append(c.Subcommands, describeCommand(sub))
+	}
+	return c
+}
+
+// visibleSubcommands returns non-hidden, non-deprecated subcommands sorted by name
+func visibleSubcommands(cmd *cobra.Command) []*cobra.Command {
+	var subs []*cobra.Command
+	for _, sub := range cmd.Commands() {
+		if sub.Hidden || sub.Deprecated != "" || sub.Name() == "help" {
+			continue
+		}
+		subs = append(subs, sub)
+	}
+	sort.Slice(subs, func(i, j int) bool { return subs[i].Name() < subs[j].Name() })
+	return subs
+}
+
+func collectFlags(fs *pflag.FlagSet) []FlagCapability {
+	var flags []FlagCapability
+	fs.VisitAll(func(f *pflag.Flag) {
+		if f.Hidden || f.Deprecated != "" || f.Name == "help" {
+			return
+		}
+		_, required := f.Annotations[cobra.BashCompOneRequiredFlag]
+		flag := FlagCapability{
+			Name:      f.Name,
+			Shorthand: f.Shorthand,
+			Type:      f.Value.Type(),
+			Usage:     f.Usage,
+			Required:  required,
+		}
+		if f.DefValue != "" && f.DefValue != "[]" && !(flag.Type == "bool" && f.DefValue == "false") {
+			flag.Default = f.DefValue
+		}
+		flags = append(flags, flag)
+	})
+	return flags
+}
+
+// parseUsageArgs extracts positional arguments from a cobra Use string such as
+// "dep add [issue-id] [depends-on-id]" or "delete <issue-id> [issue-id...]".
+// <name> is required, [name] is optional, and a trailing "..." is variadic.
+// Flags shown in the usage line (e.g. "--of <canonical>") are skipped.
+func parseUsageArgs(use string) []ArgCapability {
+	fields := strings.Fields(use)
+	if len(fields) <= 1 {
+		return nil
+	}
+
+	var args []ArgCapability
+	seen := make(map[string]bool)
+	for i := 1; i < len(fields); i++ {
+		token := fields[i]
+		if strings.HasPrefix(token, "-") {
+			// Skip the flag's value placeholder too
+			if i+1 < len(fields) && strings.HasPrefix(fields[i+1], "<") {
+				i++
+			}
+			continue
+		}
+
+		var arg ArgCapability
+		switch {
+		case strings.HasPrefix(token, "<") && strings.HasSuffix(token, ">"):
+			arg.Required = true
+			token = strings.TrimSuffix(strings.TrimPrefix(token, "<"), ">")
+		case strings.HasPrefix(token, "[") && strings.HasSuffix(token, "]"):
+			token = strings.TrimSuffix(strings.TrimPrefix(token, "["), "]")
+		default:
+			continue
+		}
+		if strings.HasSuffix(token, "...") {
+			arg.Variadic = true
+			token = strings.TrimSuffix(token, "...")
+		}
+
+		// "delete <issue-id> [issue-id...]" describes one variadic argument
+		if seen[token] {
+			for j := range args {
+				if args[j].Name == token {
+					args[j].Variadic = args[j].Variadic || arg.Variadic
+				}
+			}
+			continue
+		}
+		seen[token] = true
+
+		arg.Name = token
+		arg.Type = argType(token)
+		args = append(args, arg)
+	}
+	return args
+}
+
+// argType infers an argument's type from its placeholder name
+func argType(name string) string {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.Contains(lower, "path") || strings.Contains(lower, "file"):
+		return "path"
+	case strings.HasPrefix(lower, "id") || strings.HasSuffix(lower, "-id"):
+		return "issue_id"
+	}
+	return "string"
+}
+
+func printCapability(c CommandCapability, depth int) {
+	indent := strings.Repeat("  ", depth)
+	fmt.Printf("%s%-*s %s\n", indent, 24-len(indent), c.Name, c.Short)
+	for _, sub := range c.Subcommands {
+		printCapability(sub, depth+1)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(capabilitiesCmd)
+}
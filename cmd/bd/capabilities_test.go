@@ -0,0 +1,86 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestParseUsageArgs(t *testing.T) {
+	tests := []struct {
+		use  string
+		want []ArgCapability
+	}{
+		{"ready", nil},
+		{"show [id...]", []ArgCapability{
+			{Name: "id", Type: "issue_id", Variadic: true},
+		}},
+		{"delete <issue-id> [issue-id...]", []ArgCapability{
+			{Name: "issue-id", Type: "issue_id", Required: true, Variadic: true},
+		}},
+		{"duplicate <id> --of <canonical>", []ArgCapability{
+			{Name: "id", Type: "issue_id", Required: true},
+		}},
+		{"set <key> <value>", []ArgCapability{
+			{Name: "key", Type: "string", Required: true},
+			{Name: "value", Type: "string", Required: true},
+		}},
+		{"logs <workspace-path|pid>", []ArgCapability{
+			{Name: "workspace-path|pid", Type: "path", Required: true},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.use, func(t *testing.T) {
+			got := parseUsageArgs(tt.use)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseUsageArgs(%q) = %+v, want %+v", tt.use, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildCapabilities(t *testing.T) {
+	root := &cobra.Command{Use: "bd"}
+	root.PersistentFlags().Bool("json", false, "Output in JSON format")
+
+	group := &cobra.Command{Use: "dep", Short: "Manage dependencies"}
+	add := &cobra.Command{Use: "add [issue-id] [depends-on-id]", Short: "Add a dependency", Run: func(*cobra.Command, []string) {}}
+	add.Flags().StringP("type", "t", "blocks", "Dependency type")
+	add.Flags().String("secret", "", "hidden")
+	_ = add.Flags().MarkHidden("secret")
+	group.AddCommand(add)
+
+	hidden := &cobra.Command{Use: "internal", Hidden: true, Run: func(*cobra.Command, []string) {}}
+	root.AddCommand(group, hidden)
+
+	report := buildCapabilities(root)
+
+	if len(report.GlobalFlags) != 1 || report.GlobalFlags[0].Name != "json" || report.GlobalFlags[0].Type != "bool" {
+		t.Errorf("GlobalFlags = %+v, want single json bool flag", report.GlobalFlags)
+	}
+	if len(report.Commands) != 1 {
+		t.Fatalf("Commands = %+v, want only the visible dep command", report.Commands)
+	}
+
+	dep := report.Commands[0]
+	if dep.Runnable {
+		t.Error("dep should not be runnable (grouping command)")
+	}
+	if len(dep.Subcommands) != 1 {
+		t.Fatalf("dep subcommands = %+v, want [add]", dep.Subcommands)
+	}
+
+	got := dep.Subcommands[0]
+	if got.Path != "bd dep add" || !got.Runnable {
+		t.Errorf("add = %+v, want runnable 'bd dep add'", got)
+	}
+	if len(got.Args) != 2 || got.Args[1].Name != "depends-on-id" {
+		t.Errorf("add args = %+v", got.Args)
+	}
+	wantFlag := FlagCapability{Name: "type", Shorthand: "t", Type: "string", Default: "blocks", Usage: "Dependency type"}
+	if len(got.Flags) != 1 || got.Flags[0] != wantFlag {
+		t.Errorf("add flags = %+v, want [%+v]", got.Flags, wantFlag)
+	}
+}
@@ -0,0 +1,367 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Keep a Changelog sections, in the order they are printed
+var changelogSections = []string{"Added", "Changed", "Deprecated", "Removed", "Fixed", "Security"}
+
+// changelogPrecedence decides the section of an issue whose labels map to
+// several: a security fix belongs under Security, not Fixed
+var changelogPrecedence = []string{"Security", "Removed", "Deprecated", "Fixed", "Added", "Changed"}
+
+// changelogSkip as a mapped section leaves matching issues out
+const changelogSkip = "skip"
+
+// defaultChangelogLabels maps common labels to sections. Projects extend or
+// override it with bd config set changelog.label_map.<label> <section>.
+var defaultChangelogLabels = map[string]string{
+	"feature":      "Added",
+	"enhancement":  "Added",
+	"bug":          "Fixed",
+	"fix":          "Fixed",
+	"regression":   "Fixed",
+	"security":     "Security",
+	"deprecation":  "Deprecated",
+	"removal":      "Removed",
+	"breaking":     "Changed",
+	"no-changelog": changelogSkip,
+}
+
+// defaultChangelogTypes is the fallback for issues no label places, set with
+// changelog.type_map.<type>
+var defaultChangelogTypes = map[string]string{
+	string(types.TypeBug):     "Fixed",
+	string(types.TypeFeature): "Added",
+	string(types.TypeTask):    "Changed",
+	string(types.TypeEpic):    "Added",
+	string(types.TypeChore):   changelogSkip,
+	string(types.TypeSpike):   changelogSkip,
+	string(types.TypeMessage): changelogSkip,
+}
+
+// ChangelogEntry is one closed issue in a changelog section
+type ChangelogEntry struct {
+	ID       string    `json:"id"`
+	Title    string    `json:"title"`
+	ClosedAt time.Time `json:"closed_at"`
+	Labels   []string  `json:"labels,omitempty"`
+}
+
+// ChangelogSection groups entries under a heading such as "Fixed"
+type ChangelogSection struct {
+	Name    string            `json:"name"`
+	Entries []*ChangelogEntry `json:"entries"`
+}
+
+// Changelog is the output of bd changelog
+type Changelog struct {
+	Version  string              `json:"version"`
+	Date     string              `json:"date,omitempty"`
+	Since    time.Time           `json:"since"`
+	Until    time.Time           `json:"until"`
+	Sections []*ChangelogSection `json:"sections"`
+	Skipped  int                 `json:"skipped"` // closed in range but left out
+}
+
+var changelogCmd = &cobra.Command{
+	Use:   "changelog",
+	Short: "Generate a changelog section from closed issues",
+	Long: `Generate a Keep a Changelog section from the issues closed between two
+git tags, commits or dates.
+
+--since and --until each take a git ref (tag or commit, resolved to its
+commit date) or a date. --until defaults to now. The heading uses --version,
+else the --until tag without its "v" prefix, else "Unreleased".
+
+Issues are grouped by label, falling back to issue type:
+
+  feature, enhancement    Added        bug, fix, regression   Fixed
+  breaking                Changed      security               Security
+  deprecation             Deprecated   removal                Removed
+
+Issues typed bug, feature or epic land under Fixed or Added and other tasks
+under Changed; chores and issues labelled no-changelog are left out, as are
+issues closed as wontfix, duplicate or obsolete. Change the mapping per
+project, using "skip" to leave issues out:
+
+  bd config set changelog.label_map.perf Changed
+  bd config set changelog.label_map.docs skip
+  bd config set changelog.type_map.chore Changed
+
+Examples:
+  bd changelog --since v1.2.0
+  bd changelog --since v1.2.0 --until v1.3.0
+  bd changelog --since 2026-01-01 --version 1.3.0 >> release-notes.md
+  bd changelog --since v1.2.0 --format json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		sinceStr, _ := cmd.Flags().GetString("since")
+		untilStr, _ := cmd.Flags().GetString("until")
+		version, _ := cmd.Flags().GetString("version")
+		format, _ := cmd.Flags().GetString("format")
+		noIDs, _ := cmd.Flags().GetBool("no-ids")
+
+		if sinceStr == "" {
+			FatalErrorWithHint("--since is required", "bd changelog --since v1.2.0")
+		}
+		if format != "keepachangelog" && format != "json" {
+			FatalError("invalid --format %q (valid: keepachangelog, json)", format)
+		}
+		ctx := rootCtx
+		since, _, err := resolveChangelogBound(ctx, sinceStr)
+		if err != nil {
+			FatalError("--since: %v", err)
+		}
+		until := time.Now().UTC()
+		untilRef := ""
+		if untilStr != "" {
+			if until, untilRef, err = resolveChangelogBound(ctx, untilStr); err != nil {
+				FatalError("--until: %v", err)
+			}
+		}
+		if !until.After(since) {
+			FatalError("--until (%s) must be after --since (%s)", until.Format(time.RFC3339), since.Format(time.RFC3339))
+		}
+
+		if err := ensureDirectMode("changelog requires direct database access"); err != nil {
+			FatalError("%v", err)
+		}
+		status := types.StatusClosed
+		issues, err := store.SearchIssues(ctx, "", types.IssueFilter{Status: &status, ClosedAfter: &since, ClosedBefore: &until})
+		if err != nil {
+			FatalError("%v", err)
+		}
+		ids := make([]string, len(issues))
+		for i, issue := range issues {
+			ids[i] = issue.ID
+		}
+		labels, err := store.GetLabelsForIssues(ctx, ids)
+		if err != nil {
+			FatalError("%v", err)
+		}
+		config, err := store.GetAllConfig(ctx)
+		if err != nil {
+			FatalError("%v", err)
+		}
+		labelMap, typeMap := changelogMappings(config)
+		custom, err := store.GetCustomCloseReasons(ctx)
+		if err != nil {
+			FatalError("%v", err)
+		}
+
+		cl := buildChangelog(issues, labels, labelMap, typeMap, custom)
+		cl.Since, cl.Until = since, until
+		switch {
+		case version != "":
+			cl.Version = strings.TrimPrefix(version, "v")
+		case untilRef != "":
+			cl.Version = strings.TrimPrefix(untilRef, "v")
+		default:
+			cl.Version = "Unreleased"
+		}
+		if cl.Version != "Unreleased" {
+			cl.Date = until.Local().Format("2006-01-02")
+		}
+
+		if jsonOutput || format == "json" {
+			outputJSON(cl)
+			return
+		}
+		fmt.Print(renderKeepAChangelog(cl, !noIDs))
+	},
+}
+
+func init() {
+	changelogCmd.Flags().String("since", "", "Start of the range: git tag, commit, or date (required)")
+	changelogCmd.Flags().String("until", "", "End of the range: git tag, commit, or date (default: now)")
+	changelogCmd.Flags().String("version", "", "Version for the section heading")
+	changelogCmd.Flags().String("format", "keepachangelog", "Output format: keepachangelog, json")
+	changelogCmd.Flags().Bool("no-ids", false, "Leave issue IDs out of the entries")
+	rootCmd.AddCommand(changelogCmd)
+}
+
+// resolveChangelogBound turns a date or a git ref into a time. For a ref it
+// also returns the ref, so a tag can name the version.
+func resolveChangelogBound(ctx context.Context, s string) (time.Time, string, error) {
+	if t, err := parseTimeFlag(s); err == nil {
+		return t.UTC(), "", nil
+	}
+	if strings.HasPrefix(s, "-") {
+		return time.Time{}, "", fmt.Errorf("%q is neither a date nor a git ref", s)
+	}
+	out, err := exec.CommandContext(ctx, "git", "log", "-1", "--format=%cI", s+"^{commit}", "--").Output() // #nosec G204 -- ref passed as one argument
+	if err != nil {
+		return time.Time{}, "", fmt.Errorf("%q is neither a date nor a git ref", s)
+	}
+	t, err := time.Parse(time.RFC3339, strings.TrimSpace(string(out)))
+	if err != nil {
+		return time.Time{}, "", fmt.Errorf("reading commit date of %s: %w", s, err)
+	}
+	return t.UTC(), s, nil
+}
+
+// changelogMappings applies changelog.label_map.* and changelog.type_map.*
+// config over the defaults. Section names are matched case-insensitively.
+func changelogMappings(config map[string]string) (labelMap, typeMap map[string]string) {
+	labelMap = make(map[string]string, len(defaultChangelogLabels))
+	for k, v := range defaultChangelogLabels {
+		labelMap[k] = v
+	}
+	typeMap = make(map[string]string, len(defaultChangelogTypes))
+	for k, v := range defaultChangelogTypes {
+		typeMap[k] = v
+	}
+	for key, value := range config {
+		var target map[string]string
+		var name string
+		switch {
+		case strings.HasPrefix(key, "changelog.label_map."):
+			target, name = labelMap, strings.TrimPrefix(key, "changelog.label_map.")
+		case strings.HasPrefix(key, "changelog.type_map."):
+			target, name = typeMap, strings.TrimPrefix(key, "changelog.type_map.")
+		default:
+			continue
+		}
+		target[name] = canonicalChangelogSection(value)
+	}
+	return labelMap, typeMap
+}
+
+// canonicalChangelogSection returns the standard spelling of a section, or
+// the value as given for custom sections
+func canonicalChangelogSection(value string) string {
+	value = strings.TrimSpace(value)
+	if strings.EqualFold(value, changelogSkip) {
+		return changelogSkip
+	}
+	for _, s := range changelogSections {
+		if strings.EqualFold(value, s) {
+			return s
+		}
+	}
+	return value
+}
+
+// changelogSection picks the section for one issue, or "" to leave it out
+func changelogSection(issue *types.Issue, labels []string, labelMap, typeMap map[string]string) string {
+	matched := map[string]bool{}
+	for _, l := range labels {
+		if section, ok := labelMap[l]; ok {
+			if section == changelogSkip {
+				return ""
+			}
+			matched[section] = true
+		}
+	}
+	for _, s := range changelogPrecedence {
+		if matched[s] {
+			return s
+		}
+	}
+	// Custom sections, alphabetically for a stable choice
+	var custom []string
+	for s := range matched {
+		custom = append(custom, s)
+	}
+	if len(custom) > 0 {
+		sort.Strings(custom)
+		return custom[0]
+	}
+	if section := typeMap[string(issue.IssueType)]; section != changelogSkip {
+		return section
+	}
+	return ""
+}
+
+// buildChangelog groups closed issues into sections. Issues closed as
+// wontfix, duplicate or obsolete didn't ship and are counted as skipped.
+func buildChangelog(issues []*types.Issue, labels map[string][]string, labelMap, typeMap map[string]string, customReasons []string) *Changelog {
+	cl := &Changelog{Sections: []*ChangelogSection{}}
+	bySection := make(map[string]*ChangelogSection)
+
+	sorted := append([]*types.Issue{}, issues...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Priority != sorted[j].Priority {
+			return sorted[i].Priority < sorted[j].Priority
+		}
+		return closedAt(sorted[i]).Before(closedAt(sorted[j]))
+	})
+
+	for _, issue := range sorted {
+		switch types.CloseReasonCategory(issue.CloseReason, customReasons) {
+		case types.CloseReasonWontfix, types.CloseReasonDuplicate, types.CloseReasonObsolete:
+			cl.Skipped++
+			continue
+		}
+		name := changelogSection(issue, labels[issue.ID], labelMap, typeMap)
+		if name == "" {
+			cl.Skipped++
+			continue
+		}
+		section := bySection[name]
+		if section == nil {
+			section = &ChangelogSection{Name: name}
+			bySection[name] = section
+		}
+		section.Entries = append(section.Entries, &ChangelogEntry{
+			ID:       issue.ID,
+			Title:    issue.Title,
+			ClosedAt: closedAt(issue),
+			Labels:   labels[issue.ID],
+		})
+	}
+
+	// Standard sections in Keep a Changelog order, then custom ones
+	for _, name := range changelogSections {
+		if section := bySection[name]; section != nil {
+			cl.Sections = append(cl.Sections, section)
+			delete(bySection, name)
+		}
+	}
+	var rest []string
+	for name := range bySection {
+		rest = append(rest, name)
+	}
+	sort.Strings(rest)
+	for _, name := range rest {
+		cl.Sections = append(cl.Sections, bySection[name])
+	}
+	return cl
+}
+
+func closedAt(issue *types.Issue) time.Time {
+	if issue.ClosedAt != nil {
+		return *issue.ClosedAt
+	}
+	return issue.UpdatedAt
+}
+
+// renderKeepAChangelog formats a changelog as a Keep a Changelog section
+func renderKeepAChangelog(cl *Changelog, withIDs bool) string {
+	var b strings.Builder
+	if cl.Date != "" {
+		fmt.Fprintf(&b, "## [%s] - %s\n", cl.Version, cl.Date)
+	} else {
+		fmt.Fprintf(&b, "## [%s]\n", cl.Version)
+	}
+	for _, section := range cl.Sections {
+		fmt.Fprintf(&b, "\n### %s\n\n", section.Name)
+		for _, e := range section.Entries {
+			if withIDs {
+				fmt.Fprintf(&b, "- %s (%s)\n", e.Title, e.ID)
+			} else {
+				fmt.Fprintf(&b, "- %s\n", e.Title)
+			}
+		}
+	}
+	return b.String()
+}
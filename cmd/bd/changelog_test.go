@@ -0,0 +1,74 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestBuildChangelog(t *testing.T) {
+	base := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	closed := func(id, title string, typ types.IssueType, priority int, reason string, hours int) *types.Issue {
+		at := base.Add(time.Duration(hours) * time.Hour)
+		return &types.Issue{ID: id, Title: title, IssueType: typ, Priority: priority, Status: types.StatusClosed, CloseReason: reason, ClosedAt: &at}
+	}
+	issues := []*types.Issue{
+		closed("bd-1", "Dark mode", types.TypeFeature, 2, "", 1),
+		closed("bd-2", "Fix crash", types.TypeBug, 1, "fixed", 2),
+		closed("bd-3", "Token leak", types.TypeBug, 0, "", 3),
+		closed("bd-4", "Bump deps", types.TypeChore, 2, "", 4),
+		closed("bd-5", "Nope", types.TypeFeature, 2, "wontfix: not needed", 5),
+		closed("bd-6", "Faster sync", types.TypeTask, 2, "", 6),
+		closed("bd-7", "Docs tweak", types.TypeTask, 2, "", 7),
+		closed("bd-8", "Earlier crash", types.TypeBug, 1, "", 0),
+	}
+	labels := map[string][]string{
+		"bd-3": {"bug", "security"},
+		"bd-6": {"perf"},
+		"bd-7": {"docs"},
+	}
+	labelMap, typeMap := changelogMappings(map[string]string{
+		"changelog.label_map.perf": "changed",
+		"changelog.label_map.docs": "Skip",
+		"other.key":                "x",
+	})
+
+	cl := buildChangelog(issues, labels, labelMap, typeMap, nil)
+	cl.Version = "1.3.0"
+	cl.Date = "2026-03-02"
+	want := `## [1.3.0] - 2026-03-02
+
+### Added
+
+- Dark mode (bd-1)
+
+### Changed
+
+- Faster sync (bd-6)
+
+### Fixed
+
+- Earlier crash (bd-8)
+- Fix crash (bd-2)
+
+### Security
+
+- Token leak (bd-3)
+`
+	if got := renderKeepAChangelog(cl, true); got != want {
+		t.Errorf("changelog:\n%s\nwant:\n%s", got, want)
+	}
+	// Chore, wontfix and docs-labelled issues are left out
+	if cl.Skipped != 3 {
+		t.Errorf("skipped = %d, want 3", cl.Skipped)
+	}
+
+	// Custom sections follow the standard ones
+	labelMap["perf"] = "Performance"
+	cl = buildChangelog(issues[5:6], labels, labelMap, typeMap, nil)
+	cl.Version = "Unreleased"
+	if got := renderKeepAChangelog(cl, false); got != "## [Unreleased]\n\n### Performance\n\n- Faster sync\n" {
+		t.Errorf("custom section:\n%s", got)
+	}
+}
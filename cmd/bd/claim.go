@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var claimCmd = &cobra.Command{
+	Use:   "claim <id>",
+	Short: "Claim an issue so no one else works on it",
+	Long: `Claim an issue for the actor (--actor, BD_ACTOR or your user name): it
+becomes in progress and assigned to you. The check and the claim are one
+atomic step, so when two agents claim the same issue exactly one succeeds;
+the other is told who holds it.
+
+With --ttl (or the claim.ttl config) the claim is a lease that expires
+unless renewed: claiming an issue you already hold extends it. When a lease
+runs out the issue goes back to open and unassigned, released by the
+daemon within a minute or, without a daemon, by the next claim. A claim
+also ends when the issue leaves in progress or is reassigned.
+
+bd ready --claim claims the top ready issue the same way. bd show lists an
+issue's claim.
+
+Leases live in the local database; they aren't synced through git.
+
+Examples:
+  bd claim bd-42 --ttl 30m     # Claim for 30 minutes; run again to renew
+  bd claim bd-42               # Claim until released or closed
+  bd claim bd-42 --release     # Give it back (open, unassigned)
+  bd claim bd-42 --force       # Take it over from its holder`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		release, _ := cmd.Flags().GetBool("release")
+		force, _ := cmd.Flags().GetBool("force")
+		ttlFlag, _ := cmd.Flags().GetString("ttl")
+		if release && ttlFlag != "" {
+			FatalError("--ttl cannot be used with --release")
+		}
+		ttl, err := claimTTL(ttlFlag)
+		if err != nil {
+			FatalError("%v", err)
+		}
+
+		CheckReadonly("claim")
+		if err := ensureDirectMode("claim requires direct database access"); err != nil {
+			FatalError("%v", err)
+		}
+		ctx := rootCtx
+		if err := ensureDatabaseFresh(ctx); err != nil {
+			FatalError("%v", err)
+		}
+		sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+		if !ok {
+			FatalError("claim requires the SQLite backend")
+		}
+		issueID, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			FatalError("%v", err)
+		}
+
+		green := color.New(color.FgGreen).SprintFunc()
+		if release {
+			if err := sqliteStore.ReleaseClaim(ctx, issueID, actor, force); err != nil {
+				failClaim(err)
+			}
+			markDirtyAndScheduleFlush()
+			if jsonOutput {
+				outputJSON(map[string]interface{}{"id": issueID, "released": true})
+				return
+			}
+			fmt.Printf("%s Released %s\n", green("✓"), issueID)
+			return
+		}
+
+		lease, err := sqliteStore.ClaimIssue(ctx, issueID, actor, ttl, force)
+		if err != nil {
+			failClaim(err)
+		}
+		markDirtyAndScheduleFlush()
+		if jsonOutput {
+			outputJSON(lease)
+			return
+		}
+		fmt.Printf("%s Claimed %s: %s\n", green("✓"), issueID, formatLease(lease, time.Now()))
+	},
+}
+
+// claimTTL returns the lease length for a claim: the --ttl flag, else the
+// claim.ttl config, else 0 (no expiry)
+func claimTTL(flag string) (time.Duration, error) {
+	value, source := flag, "--ttl"
+	if value == "" {
+		value, source = config.GetString("claim.ttl"), "claim.ttl"
+	}
+	if value == "" {
+		return 0, nil
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: use a duration such as 30m or 2h", source, value)
+	}
+	if ttl <= 0 {
+		return 0, fmt.Errorf("invalid %s %q: must be positive", source, value)
+	}
+	return ttl, nil
+}
+
+// failClaim exits with a hint when the issue is held by someone else
+func failClaim(err error) {
+	var conflict *sqlite.LeaseConflictError
+	if errors.As(err, &conflict) {
+		FatalErrorWithHint(err.Error(), fmt.Sprintf("use --force to take %s over from %s", conflict.IssueID, conflict.Holder))
+	}
+	FatalError("%v", err)
+}
+
+// formatLease describes a claim for people: its holder and when it expires
+func formatLease(lease *types.Lease, now time.Time) string {
+	if lease.ExpiresAt == nil {
+		return fmt.Sprintf("%s, no expiry", lease.Holder)
+	}
+	if lease.Expired(now) {
+		return fmt.Sprintf("%s, expired %s", lease.Holder, displayTime(*lease.ExpiresAt))
+	}
+	return fmt.Sprintf("%s until %s (%s left)", lease.Holder, displayTime(*lease.ExpiresAt), formatHours(lease.ExpiresAt.Sub(now).Hours()))
+}
+
+// issueLease returns an issue's claim in direct mode, or nil
+func issueLease(ctx context.Context, id string) *types.Lease {
+	sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+	if !ok {
+		return nil
+	}
+	lease, err := sqliteStore.GetLease(ctx, id)
+	if err != nil {
+		return nil
+	}
+	return lease
+}
+
+func init() {
+	claimCmd.Flags().String("ttl", "", "Lease length, e.g. 30m (default: claim.ttl config, else no expiry)")
+	claimCmd.Flags().Bool("release", false, "Give up the claim: the issue goes back to open and unassigned")
+	claimCmd.Flags().Bool("force", false, "Claim or release even if someone else holds the issue")
+	rootCmd.AddCommand(claimCmd)
+}
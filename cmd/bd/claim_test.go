@@ -0,0 +1,51 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestClaimTTL(t *testing.T) {
+	config.Set("claim.ttl", "")
+	if ttl, err := claimTTL(""); err != nil || ttl != 0 {
+		t.Errorf("claimTTL with nothing set = %v, %v; want no expiry", ttl, err)
+	}
+	if ttl, err := claimTTL("30m"); err != nil || ttl != 30*time.Minute {
+		t.Errorf("claimTTL(30m) = %v, %v", ttl, err)
+	}
+
+	config.Set("claim.ttl", "2h")
+	defer config.Set("claim.ttl", "")
+	if ttl, err := claimTTL(""); err != nil || ttl != 2*time.Hour {
+		t.Errorf("claimTTL from config = %v, %v; want 2h", ttl, err)
+	}
+	if ttl, err := claimTTL("10m"); err != nil || ttl != 10*time.Minute {
+		t.Errorf("--ttl should override claim.ttl, got %v, %v", ttl, err)
+	}
+
+	for _, bad := range []string{"soon", "-5m", "0s"} {
+		if _, err := claimTTL(bad); err == nil || !strings.Contains(err.Error(), "--ttl") {
+			t.Errorf("claimTTL(%q) error = %v, want one naming --ttl", bad, err)
+		}
+	}
+}
+
+func TestFormatLease(t *testing.T) {
+	now := time.Now()
+	expires := now.Add(45 * time.Minute)
+	lease := &types.Lease{IssueID: "bd-1", Holder: "alice", ClaimedAt: now}
+	if got := formatLease(lease, now); got != "alice, no expiry" {
+		t.Errorf("formatLease without expiry = %q", got)
+	}
+	lease.ExpiresAt = &expires
+	if got := formatLease(lease, now); !strings.HasPrefix(got, "alice until ") || !strings.HasSuffix(got, "(45m left)") {
+		t.Errorf("formatLease = %q", got)
+	}
+	if got := formatLease(lease, now.Add(time.Hour)); !strings.HasPrefix(got, "alice, expired ") {
+		t.Errorf("formatLease after expiry = %q", got)
+	}
+}
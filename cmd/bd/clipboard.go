@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/fatih/color"
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Clipboard access, replaceable in tests. The clipboard package uses
+// pbcopy/pbpaste on macOS, the Win32 API on Windows, and wl-clipboard, xclip
+// or xsel on Linux.
+var (
+	clipboardRead  = clipboard.ReadAll
+	clipboardWrite = clipboard.WriteAll
+)
+
+// copyIssuesToClipboard copies issues to the clipboard as markdown in the
+// 'bd create --file' format, so they paste into chat or an editor and back
+// into bd with 'bd create --from-clipboard'
+func copyIssuesToClipboard(ctx context.Context, ids []string) {
+	var sections []string
+	for _, id := range ids {
+		issue, err := fetchIssueForCopy(ctx, id)
+		if err != nil {
+			FatalError("%v", err)
+		}
+		decryptForDisplay(issue)
+		sections = append(sections, formatIssueMarkdown(issue))
+	}
+	if err := clipboardWrite(strings.Join(sections, "\n")); err != nil {
+		FatalError("copying to clipboard: %v", err)
+	}
+	// stderr, so --json output stays parseable
+	green := color.New(color.FgGreen).SprintFunc()
+	fmt.Fprintf(os.Stderr, "%s Copied %s to the clipboard\n", green("✓"), strings.Join(ids, ", "))
+}
+
+// fetchIssueForCopy loads an issue with its labels
+func fetchIssueForCopy(ctx context.Context, id string) (*types.Issue, error) {
+	if daemonClient != nil {
+		resp, err := daemonClient.Show(&rpc.ShowArgs{ID: id})
+		if err != nil {
+			return nil, fmt.Errorf("fetching %s: %w", id, err)
+		}
+		var issue *types.Issue
+		if err := json.Unmarshal(resp.Data, &issue); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", id, err)
+		}
+		if issue == nil {
+			return nil, fmt.Errorf("issue %s not found", id)
+		}
+		return issue, nil
+	}
+	issue, err := store.GetIssue(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("fetching %s: %w", id, err)
+	}
+	if issue == nil {
+		return nil, fmt.Errorf("issue %s not found", id)
+	}
+	if issue.Labels, err = store.GetLabels(ctx, id); err != nil {
+		return nil, fmt.Errorf("fetching labels of %s: %w", id, err)
+	}
+	return issue, nil
+}
+
+// formatIssueMarkdown renders an issue in the markdown format parseMarkdown
+// reads. Dependencies are left out: their IDs rarely mean anything where
+// the text is pasted.
+func formatIssueMarkdown(issue *types.Issue) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## %s\n", issue.Title)
+	section := func(name, content string) {
+		if content = strings.TrimSpace(content); content != "" {
+			fmt.Fprintf(&b, "\n### %s\n%s\n", name, content)
+		}
+	}
+	section("Priority", fmt.Sprintf("P%d", issue.Priority))
+	section("Type", string(issue.IssueType))
+	section("Description", issue.Description)
+	section("Design", issue.Design)
+	section("Acceptance Criteria", issue.AcceptanceCriteria)
+	section("Notes", issue.Notes)
+	section("Assignee", issue.Assignee)
+	section("Labels", strings.Join(issue.Labels, ", "))
+	return b.String()
+}
+
+// clipboardIssueTemplates parses clipboard text as 'bd create --file'
+// markdown if it starts with a "## Title" line, and returns nil otherwise
+func clipboardIssueTemplates(text string) []*IssueTemplate {
+	first, _, _ := strings.Cut(strings.TrimSpace(text), "\n")
+	if !h2Regex.MatchString(strings.TrimRight(first, "\r")) {
+		return nil
+	}
+	templates, err := parseMarkdown(strings.NewReader(text))
+	if err != nil {
+		return nil
+	}
+	return templates
+}
+
+// splitClipboardText reads plain clipboard text as an issue: the first
+// non-empty line (without markdown heading marks) is the title, the rest
+// the description
+func splitClipboardText(text string) (title, description string) {
+	text = strings.TrimSpace(strings.ReplaceAll(text, "\r\n", "\n"))
+	first, rest, _ := strings.Cut(text, "\n")
+	title = strings.TrimSpace(strings.TrimLeft(first, "#"))
+	return title, strings.TrimSpace(rest)
+}
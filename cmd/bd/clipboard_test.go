@@ -0,0 +1,59 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestFormatIssueMarkdownRoundTrip(t *testing.T) {
+	issue := &types.Issue{
+		Title:              "Login fails",
+		Description:        "Users get a 500\n\nafter the upgrade",
+		Design:             "Check the session store",
+		AcceptanceCriteria: "- Login works",
+		Notes:              "Seen on staging",
+		Priority:           1,
+		IssueType:          types.TypeBug,
+		Assignee:           "alice",
+		Labels:             []string{"auth", "web"},
+	}
+	templates := clipboardIssueTemplates(formatIssueMarkdown(issue) + "\n" + formatIssueMarkdown(&types.Issue{Title: "Second", Priority: 3, IssueType: types.TypeTask}))
+	if len(templates) != 2 {
+		t.Fatalf("got %d templates, want 2", len(templates))
+	}
+	want := &IssueTemplate{
+		Title:              issue.Title,
+		Description:        issue.Description,
+		Design:             issue.Design,
+		AcceptanceCriteria: issue.AcceptanceCriteria,
+		Notes:              issue.Notes,
+		Priority:           1,
+		IssueType:          types.TypeBug,
+		Assignee:           "alice",
+		Labels:             []string{"auth", "web"},
+	}
+	if !reflect.DeepEqual(templates[0], want) {
+		t.Errorf("round trip = %+v, want %+v", templates[0], want)
+	}
+	if templates[1].Title != "Second" || templates[1].Priority != 3 {
+		t.Errorf("second template = %+v", templates[1])
+	}
+}
+
+func TestClipboardPlainText(t *testing.T) {
+	// Text that doesn't start with "## Title" isn't parsed as issues, even
+	// with headings further down
+	text := "# Flaky CI\r\n\r\nTimes out daily.\r\n\r\n## Logs\r\n..."
+	if templates := clipboardIssueTemplates(text); templates != nil {
+		t.Errorf("plain text parsed as %d issues", len(templates))
+	}
+	title, description := splitClipboardText(text)
+	if title != "Flaky CI" || description != "Times out daily.\n\n## Logs\n..." {
+		t.Errorf("splitClipboardText() = %q, %q", title, description)
+	}
+	if title, description := splitClipboardText("  \n"); title != "" || description != "" {
+		t.Errorf("empty clipboard = %q, %q", title, description)
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// printClosedByReason prints the 'bd stats' breakdown of closed issues by
+// close reason: built-in reasons first, then custom ones, then free text
+func printClosedByReason(counts map[string]int) {
+	if len(counts) == 0 {
+		return
+	}
+	rank := func(reason string) int {
+		for i, builtin := range types.BuiltinCloseReasons {
+			if reason == builtin {
+				return i
+			}
+		}
+		if reason == types.CloseReasonOther {
+			return len(types.BuiltinCloseReasons) + 1
+		}
+		return len(types.BuiltinCloseReasons)
+	}
+	reasons := make([]string, 0, len(counts))
+	for reason := range counts {
+		reasons = append(reasons, reason)
+	}
+	sort.Slice(reasons, func(i, j int) bool {
+		ri, rj := rank(reasons[i]), rank(reasons[j])
+		if ri != rj {
+			return ri < rj
+		}
+		return reasons[i] < reasons[j]
+	})
+
+	fmt.Printf("\nClosed by Reason:\n")
+	for _, reason := range reasons {
+		fmt.Printf("  %-21s %d\n", reason+":", counts[reason])
+	}
+}
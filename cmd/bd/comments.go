@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"os/user"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -29,7 +30,11 @@ Examples:
   bd comments add bd-123 "This is a comment"
 
   # Add a comment from a file
-  bd comments add bd-123 -f notes.txt`,
+  bd comments add bd-123 -f notes.txt
+
+  # Edit or delete a comment by the #ID shown in the list
+  bd comments edit 42 "Corrected text"
+  bd comments delete 42`,
 	Args: cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		issueID := args[0]
@@ -96,7 +101,11 @@ Examples:
 
 		fmt.Printf("\nComments on %s:\n\n", issueID)
 		for _, comment := range comments {
-			fmt.Printf("[%s] %s at %s\n", comment.Author, comment.Text, comment.CreatedAt.Format("2006-01-02 15:04"))
+			edited := ""
+			if comment.UpdatedAt != nil {
+				edited = " (edited)"
+			}
+			fmt.Printf("#%d [%s] %s at %s%s\n", comment.ID, comment.Author, comment.Text, displayTime(comment.CreatedAt), edited)
 			fmt.Println()
 		}
 	},
@@ -134,6 +143,7 @@ Examples:
 		} else {
 			commentText = args[1]
 		}
+		redactInput(&commentText)
 
 		// Get author from author flag, BD_ACTOR var, or system USER var
 		author, _ := cmd.Flags().GetString("author")
@@ -197,6 +207,7 @@ Examples:
 				fmt.Fprintf(os.Stderr, "Error adding comment: %v\n", err)
 				os.Exit(1)
 			}
+			markDirtyAndScheduleFlush()
 		}
 
 		if jsonOutput {
@@ -224,19 +235,177 @@ Examples:
   bd comment bd-123 "Working on this now"
 
   # Add a comment from a file
-  bd comment bd-123 -f notes.txt`,
+  bd comment bd-123 -f notes.txt
+
+  # The 'bd comments' subcommands work here too
+  bd comment list bd-123
+  bd comment edit 42 "Corrected text"
+  bd comment delete 42`,
 	Args: cobra.MinimumNArgs(1),
 	Run: commentsAddCmd.Run,
 }
 
+// newCommentEditCmd builds 'edit', which both 'bd comments' and 'bd comment' have
+func newCommentEditCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "edit [comment-id] [text]",
+		Short: "Replace the text of a comment",
+		Long: `Replace the text of a comment, identified by the #ID 'bd comments' shows.
+
+The edit time is recorded and exported, so the newer text wins when clones
+import each other's JSONL.
+
+Examples:
+  bd comments edit 42 "Corrected text"
+  bd comments edit 42 -f notes.txt`,
+		Args: cobra.RangeArgs(1, 2),
+		Run: func(cmd *cobra.Command, args []string) {
+			CheckReadonly("comment edit")
+			commentID := parseCommentID(args[0])
+
+			text, _ := cmd.Flags().GetString("file")
+			if text != "" {
+				data, err := os.ReadFile(text) // #nosec G304 - user-provided file path is intentional
+				if err != nil {
+					FatalError("reading file: %v", err)
+				}
+				text = string(data)
+			} else if len(args) < 2 {
+				FatalError("comment text required (use -f to read from file)")
+			} else {
+				text = args[1]
+			}
+			redactInput(&text)
+
+			var comment *types.Comment
+			if daemonClient != nil {
+				resp, err := daemonClient.UpdateComment(&rpc.CommentUpdateArgs{CommentID: commentID, Text: text})
+				if err != nil && !isUnknownOperationError(err) {
+					FatalError("editing comment: %v", err)
+				}
+				if err == nil {
+					comment = &types.Comment{}
+					if err := json.Unmarshal(resp.Data, comment); err != nil {
+						FatalError("decoding comment: %v", err)
+					}
+				} else if err := fallbackToDirectMode("daemon does not support comment_update RPC"); err != nil {
+					FatalError("editing comment: %v", err)
+				}
+			}
+			if comment == nil {
+				if err := ensureStoreActive(); err != nil {
+					FatalError("editing comment: %v", err)
+				}
+				var err error
+				if comment, err = store.UpdateIssueComment(rootCtx, commentID, text, actor); err != nil {
+					FatalError("editing comment: %v", err)
+				}
+				markDirtyAndScheduleFlush()
+			}
+
+			if jsonOutput {
+				outputJSON(comment)
+				return
+			}
+			fmt.Printf("Comment #%d on %s updated\n", comment.ID, comment.IssueID)
+		},
+	}
+	cmd.Flags().StringP("file", "f", "", "Read comment text from file")
+	return cmd
+}
+
+// newCommentDeleteCmd builds 'delete', which both 'bd comments' and 'bd comment' have
+func newCommentDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete [comment-id...]",
+		Short: "Delete comments",
+		Long: `Delete comments, identified by the #ID 'bd comments' shows.
+
+The text is kept in the issue's event history (comment_deleted).
+
+Examples:
+  bd comments delete 42
+  bd comments delete 42 43`,
+		Args: cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			CheckReadonly("comment delete")
+			var deleted []*types.Comment
+			for _, arg := range args {
+				commentID := parseCommentID(arg)
+
+				var comment *types.Comment
+				if daemonClient != nil {
+					resp, err := daemonClient.DeleteComment(&rpc.CommentDeleteArgs{CommentID: commentID})
+					if err != nil && !isUnknownOperationError(err) {
+						FatalError("deleting comment: %v", err)
+					}
+					if err == nil {
+						comment = &types.Comment{}
+						if err := json.Unmarshal(resp.Data, comment); err != nil {
+							FatalError("decoding comment: %v", err)
+						}
+					} else if err := fallbackToDirectMode("daemon does not support comment_delete RPC"); err != nil {
+						FatalError("deleting comment: %v", err)
+					}
+				}
+				if comment == nil {
+					if err := ensureStoreActive(); err != nil {
+						FatalError("deleting comment: %v", err)
+					}
+					var err error
+					if comment, err = store.DeleteIssueComment(rootCtx, commentID, actor); err != nil {
+						FatalError("deleting comment: %v", err)
+					}
+					markDirtyAndScheduleFlush()
+				}
+				deleted = append(deleted, comment)
+			}
+
+			if jsonOutput {
+				outputJSON(deleted)
+				return
+			}
+			for _, comment := range deleted {
+				fmt.Printf("Comment #%d deleted from %s\n", comment.ID, comment.IssueID)
+			}
+		},
+	}
+}
+
+// parseCommentID accepts a comment ID as shown by 'bd comments' (42 or #42)
+func parseCommentID(arg string) int64 {
+	id, err := strconv.ParseInt(strings.TrimPrefix(arg, "#"), 10, 64)
+	if err != nil || id <= 0 {
+		FatalError("invalid comment ID %q (use the #ID shown by 'bd comments <issue-id>')", arg)
+	}
+	return id
+}
+
 func init() {
-	commentsCmd.AddCommand(commentsAddCmd)
+	commentsCmd.AddCommand(commentsAddCmd, newCommentEditCmd(), newCommentDeleteCmd())
 	commentsAddCmd.Flags().StringP("file", "f", "", "Read comment text from file")
 	commentsAddCmd.Flags().StringP("author", "a", "", "Add author to comment")
 	
 	// Add the same flags to the alias
 	commentCmd.Flags().StringP("file", "f", "", "Read comment text from file")
 	commentCmd.Flags().StringP("author", "a", "", "Add author to comment")
+
+	// and the same subcommands, so 'bd comment add/list/edit/delete' work
+	commentAddCmd := &cobra.Command{
+		Use:   commentsAddCmd.Use,
+		Short: commentsAddCmd.Short,
+		Args:  cobra.MinimumNArgs(1),
+		Run:   commentsAddCmd.Run,
+	}
+	commentAddCmd.Flags().StringP("file", "f", "", "Read comment text from file")
+	commentAddCmd.Flags().StringP("author", "a", "", "Add author to comment")
+	commentListCmd := &cobra.Command{
+		Use:   "list [issue-id]",
+		Short: "List comments on an issue",
+		Args:  cobra.ExactArgs(1),
+		Run:   commentsCmd.Run,
+	}
+	commentCmd.AddCommand(commentAddCmd, commentListCmd, newCommentEditCmd(), newCommentDeleteCmd())
 	
 	rootCmd.AddCommand(commentsCmd)
 	rootCmd.AddCommand(commentCmd)
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -23,7 +24,7 @@ func TestCompactSuite(t *testing.T) {
 		issue := &types.Issue{
 			ID:          "test-dryrun-1",
 			Title:       "Test Issue",
-			Description: "This is a long description that should be compacted. " + string(make([]byte, 500)),
+			Description: "This is a long description that should be compacted. " + strings.Repeat("x", 500),
 			Status:      types.StatusClosed,
 			Priority:    2,
 			IssueType:   types.TypeTask,
@@ -118,7 +119,7 @@ func TestCompactSuite(t *testing.T) {
 			issue := &types.Issue{
 				ID:          id,
 				Title:       "Test Issue",
-				Description: string(make([]byte, 500)),
+				Description: strings.Repeat("x", 500),
 				Status:      types.StatusClosed,
 				Priority:    2,
 				IssueType:   types.TypeTask,
@@ -148,7 +149,7 @@ func TestCompactSuite(t *testing.T) {
 		issue := &types.Issue{
 			ID:          "test-json-1",
 			Title:       "Test Issue",
-			Description: string(make([]byte, 500)),
+			Description: strings.Repeat("x", 500),
 			Status:      types.StatusClosed,
 			Priority:    2,
 			IssueType:   types.TypeTask,
@@ -173,7 +174,7 @@ func TestCompactSuite(t *testing.T) {
 		issue := &types.Issue{
 			ID:          "test-single-1",
 			Title:       "Test Compact Issue",
-			Description: string(make([]byte, 500)),
+			Description: strings.Repeat("x", 500),
 			Status:      types.StatusClosed,
 			Priority:    2,
 			IssueType:   types.TypeTask,
@@ -200,7 +201,7 @@ func TestCompactSuite(t *testing.T) {
 			issue := &types.Issue{
 				ID:          fmt.Sprintf("test-all-%d", i),
 				Title:       "Test Issue",
-				Description: string(make([]byte, 500)),
+				Description: strings.Repeat("x", 500),
 				Status:      types.StatusClosed,
 				Priority:    2,
 				IssueType:   types.TypeTask,
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// Thresholds for suggesting complexity from how much an issue says about
+// itself (description, design and acceptance criteria, in bytes)
+const (
+	complexityTrivialText = 160
+	complexityComplexText = 1500
+)
+
+// complexityMinHistory is how many classified issues sharing labels it
+// takes before label history outweighs the text heuristics
+const complexityMinHistory = 2
+
+// researchWords mark an issue as an open question rather than a build task
+var researchWords = []string{"investigate", "research", "spike", "explore", "evaluate", "prototype", "figure out", "why does", "root cause"}
+
+// ComplexitySuggestion is the output of 'bd complexity suggest'
+type ComplexitySuggestion struct {
+	IssueID   string                       `json:"issue_id"`
+	Title     string                       `json:"title"`
+	Suggested types.Complexity             `json:"suggested"`
+	Current   types.Complexity             `json:"current,omitempty"`
+	Basis     string                       `json:"basis"` // "labels", "keywords" or "text"
+	Reason    string                       `json:"reason"`
+	Votes     map[types.Complexity]float64 `json:"votes,omitempty"` // label-similarity weight per complexity
+	Applied   bool                         `json:"applied,omitempty"`
+}
+
+// parseComplexityFlag parses --complexity; "" and "none" mean unclassified
+func parseComplexityFlag(value string) (types.Complexity, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" || value == "none" {
+		return "", nil
+	}
+	c := types.Complexity(value)
+	if !c.IsValid() {
+		return "", fmt.Errorf("invalid complexity %q (valid: trivial, standard, complex, research, none)", value)
+	}
+	return c, nil
+}
+
+var complexityCmd = &cobra.Command{
+	Use:   "complexity",
+	Short: "Classify issues by complexity for agent routing",
+	Long: `Complexity (trivial, standard, complex, research) tells orchestrators which
+agent should pick up an issue: trivial work can go to a cheap, fast model,
+complex and research items to a stronger one.
+
+Set it with 'bd create --complexity' or 'bd update --complexity', filter
+with 'bd ready --complexity' and 'bd list --complexity', or let
+'bd complexity suggest' propose one.`,
+}
+
+var complexitySuggestCmd = &cobra.Command{
+	Use:   "suggest [issue-id...]",
+	Short: "Suggest a complexity from label history and description",
+	Long: `Propose a complexity for issues.
+
+When at least two classified issues share labels with an issue, their
+complexities vote, weighted by label overlap. Otherwise research wording in
+the title ("investigate", "spike", "root cause", ...) suggests research,
+and the length of the description, design and acceptance criteria decides
+between trivial, standard and complex; epics are complex.
+
+Examples:
+  bd complexity suggest bd-42
+  bd complexity suggest bd-42 bd-43 --apply
+  bd complexity suggest --unclassified --apply --json   # Every open issue without one`,
+	Run: func(cmd *cobra.Command, args []string) {
+		apply, _ := cmd.Flags().GetBool("apply")
+		unclassified, _ := cmd.Flags().GetBool("unclassified")
+		if len(args) == 0 && !unclassified {
+			FatalErrorWithHint("no issues given", "pass issue IDs or --unclassified")
+		}
+		if apply {
+			CheckReadonly("complexity suggest --apply")
+		}
+		if err := ensureDirectMode("complexity suggest requires direct database access"); err != nil {
+			FatalError("%v", err)
+		}
+		ctx := rootCtx
+
+		issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+		if err != nil {
+			FatalError("%v", err)
+		}
+		ids := make([]string, len(issues))
+		byID := make(map[string]*types.Issue, len(issues))
+		for i, issue := range issues {
+			ids[i] = issue.ID
+			byID[issue.ID] = issue
+		}
+		labels, err := store.GetLabelsForIssues(ctx, ids)
+		if err != nil {
+			FatalError("%v", err)
+		}
+
+		var targets []*types.Issue
+		for _, arg := range args {
+			id, err := utils.ResolvePartialID(ctx, store, arg)
+			if err != nil {
+				FatalError("resolving %s: %v", arg, err)
+			}
+			if byID[id] == nil {
+				FatalError("issue %s not found", id)
+			}
+			targets = append(targets, byID[id])
+		}
+		if unclassified {
+			for _, issue := range issues {
+				if issue.Complexity == "" && issue.Status != types.StatusClosed {
+					targets = append(targets, issue)
+				}
+			}
+		}
+
+		suggestions := make([]*ComplexitySuggestion, 0, len(targets))
+		for _, target := range targets {
+			s := suggestComplexity(target, issues, labels)
+			if apply && s.Suggested != s.Current {
+				if err := store.UpdateIssue(ctx, target.ID, map[string]interface{}{"complexity": string(s.Suggested)}, actor); err != nil {
+					FatalError("setting complexity of %s: %v", target.ID, err)
+				}
+				s.Applied = true
+			}
+			suggestions = append(suggestions, s)
+		}
+		if apply {
+			markDirtyAndScheduleFlush()
+		}
+
+		if jsonOutput {
+			outputJSON(suggestions)
+			return
+		}
+		printComplexitySuggestions(suggestions)
+	},
+}
+
+// suggestComplexity proposes a complexity for target. Classified issues that
+// share labels with it vote first; failing enough of those, research
+// wording and then the amount of text decide.
+func suggestComplexity(target *types.Issue, issues []*types.Issue, labels map[string][]string) *ComplexitySuggestion {
+	s := &ComplexitySuggestion{IssueID: target.ID, Title: target.Title, Current: target.Complexity}
+
+	votes := make(map[types.Complexity]float64)
+	voters := 0
+	for _, issue := range issues {
+		if issue.ID == target.ID || issue.Complexity == "" || issue.Status == types.StatusTombstone {
+			continue
+		}
+		if score := jaccard(labels[target.ID], labels[issue.ID]); score > 0 {
+			votes[issue.Complexity] += score
+			voters++
+		}
+	}
+	if voters >= complexityMinHistory {
+		s.Suggested = topComplexityVote(votes)
+		s.Basis = "labels"
+		s.Reason = fmt.Sprintf("%d classified issues share labels with it", voters)
+		s.Votes = votes
+		return s
+	}
+
+	title := strings.ToLower(target.Title)
+	for _, word := range researchWords {
+		if strings.Contains(title, word) {
+			s.Suggested = types.ComplexityResearch
+			s.Basis = "keywords"
+			s.Reason = fmt.Sprintf("title mentions %q", word)
+			return s
+		}
+	}
+
+	size := len(strings.TrimSpace(target.Description)) + len(strings.TrimSpace(target.Design)) +
+		len(strings.TrimSpace(target.AcceptanceCriteria))
+	s.Basis = "text"
+	switch {
+	case target.IssueType == types.TypeEpic:
+		s.Suggested = types.ComplexityComplex
+		s.Reason = "epics span several pieces of work"
+	case size >= complexityComplexText:
+		s.Suggested = types.ComplexityComplex
+		s.Reason = fmt.Sprintf("%d characters of description, design and acceptance criteria", size)
+	case size < complexityTrivialText:
+		s.Suggested = types.ComplexityTrivial
+		s.Reason = "little or no description"
+		if size > 0 {
+			s.Reason = fmt.Sprintf("only %d characters of description", size)
+		}
+	default:
+		s.Suggested = types.ComplexityStandard
+		s.Reason = fmt.Sprintf("%d characters of description, design and acceptance criteria", size)
+	}
+	return s
+}
+
+// topComplexityVote picks the complexity with the most weight; ties go to
+// the more demanding one, since under-routing costs more than over-routing
+func topComplexityVote(votes map[types.Complexity]float64) types.Complexity {
+	order := []types.Complexity{types.ComplexityResearch, types.ComplexityComplex, types.ComplexityStandard, types.ComplexityTrivial}
+	best := types.ComplexityStandard
+	bestWeight := 0.0
+	for _, c := range order {
+		if votes[c] > bestWeight+1e-9 {
+			best, bestWeight = c, votes[c]
+		}
+	}
+	return best
+}
+
+func printComplexitySuggestions(suggestions []*ComplexitySuggestion) {
+	if len(suggestions) == 0 {
+		fmt.Println("No issues to classify")
+		return
+	}
+	bold := color.New(color.Bold).SprintFunc()
+	green := color.New(color.FgGreen).SprintFunc()
+	for _, s := range suggestions {
+		current := ""
+		if s.Current != "" && s.Current != s.Suggested {
+			current = fmt.Sprintf(" (currently %s)", s.Current)
+		}
+		applied := ""
+		if s.Applied {
+			applied = " " + green("✓ applied")
+		}
+		fmt.Printf("%s %s: %s%s%s\n", s.IssueID, truncateTitle(s.Title, 40), bold(string(s.Suggested)), current, applied)
+		fmt.Printf("  %s\n", s.Reason)
+		if len(s.Votes) > 0 {
+			keys := make([]string, 0, len(s.Votes))
+			for c := range s.Votes {
+				keys = append(keys, string(c))
+			}
+			sort.Strings(keys)
+			parts := make([]string, len(keys))
+			for i, k := range keys {
+				parts[i] = fmt.Sprintf("%s %.2f", k, s.Votes[types.Complexity(k)])
+			}
+			fmt.Printf("  votes: %s\n", strings.Join(parts, ", "))
+		}
+	}
+}
+
+func init() {
+	complexitySuggestCmd.Flags().Bool("apply", false, "Set each issue's complexity to the suggestion")
+	complexitySuggestCmd.Flags().Bool("unclassified", false, "Suggest for every open issue without a complexity")
+	complexityCmd.AddCommand(complexitySuggestCmd)
+	rootCmd.AddCommand(complexityCmd)
+}
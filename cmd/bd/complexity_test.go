@@ -0,0 +1,53 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSuggestComplexity(t *testing.T) {
+	issues := []*types.Issue{
+		{ID: "bd-1", Complexity: types.ComplexityComplex},
+		{ID: "bd-2", Complexity: types.ComplexityComplex},
+		{ID: "bd-3", Complexity: types.ComplexityTrivial},
+		{ID: "bd-4", Complexity: types.ComplexityTrivial, Status: types.StatusTombstone},
+	}
+	labels := map[string][]string{
+		"bd-1": {"backend", "db"},
+		"bd-2": {"db"},
+		"bd-3": {"docs"},
+		"bd-4": {"db"},
+		"bd-9": {"db"},
+	}
+
+	target := &types.Issue{ID: "bd-9", Title: "Fix typo"}
+	s := suggestComplexity(target, issues, labels)
+	if s.Basis != "labels" || s.Suggested != types.ComplexityComplex {
+		t.Errorf("label history: got %s from %s, want complex from labels", s.Suggested, s.Basis)
+	}
+
+	// One labelled voter is not enough history; research words win next
+	labels["bd-9"] = []string{"docs"}
+	target.Title = "Investigate slow startup"
+	s = suggestComplexity(target, issues, labels)
+	if s.Suggested != types.ComplexityResearch {
+		t.Errorf("research title: got %s from %s", s.Suggested, s.Basis)
+	}
+
+	tests := []struct {
+		issue *types.Issue
+		want  types.Complexity
+	}{
+		{&types.Issue{ID: "bd-10", Title: "Fix typo"}, types.ComplexityTrivial},
+		{&types.Issue{ID: "bd-11", Title: "Add flag", Description: strings.Repeat("x", 400)}, types.ComplexityStandard},
+		{&types.Issue{ID: "bd-12", Title: "Rewrite sync", Design: strings.Repeat("x", 2000)}, types.ComplexityComplex},
+		{&types.Issue{ID: "bd-13", Title: "Auth", IssueType: types.TypeEpic}, types.ComplexityComplex},
+	}
+	for _, tt := range tests {
+		if s := suggestComplexity(tt.issue, nil, nil); s.Suggested != tt.want || s.Basis != "text" {
+			t.Errorf("%s: got %s from %s, want %s from text", tt.issue.Title, s.Suggested, s.Basis, tt.want)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// configBundle is the optional first line of an export made with
+// --include-config. It carries the project config stored in the database
+// (workflow, routing rules, label definitions, ...), which otherwise never
+// leaves the machine because the database is not committed.
+type configBundle struct {
+	Config map[string]string `json:"_config"`
+}
+
+// localConfigSuffixes mark config keys that describe this machine or hold
+// credentials, so they are never written to an export
+var localConfigSuffixes = []string{"token", "secret", "password", "api_key", "last_sync"}
+
+// isLocalConfigKey reports whether key must stay out of exported config
+func isLocalConfigKey(key string) bool {
+	lower := strings.ToLower(key)
+	for _, suffix := range localConfigSuffixes {
+		if strings.HasSuffix(lower, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// exportableConfig returns the project config to include in an export
+func exportableConfig(ctx context.Context, s storage.Storage) (map[string]string, error) {
+	all, err := s.GetAllConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	config := make(map[string]string, len(all))
+	for key, value := range all {
+		if !isLocalConfigKey(key) {
+			config[key] = value
+		}
+	}
+	return config, nil
+}
+
+// parseConfigBundle recognizes a config line in an export. ok is false for
+// ordinary issue lines.
+func parseConfigBundle(line []byte) (config map[string]string, ok bool, err error) {
+	if !bytes.HasPrefix(bytes.TrimSpace(line), []byte(`{"_config"`)) {
+		return nil, false, nil
+	}
+	var bundle configBundle
+	if err := json.Unmarshal(line, &bundle); err != nil {
+		return nil, true, err
+	}
+	return bundle.Config, true, nil
+}
+
+// restoreConfig writes imported config into the database, returning the keys
+// whose value changed. Local-only keys are ignored even if present.
+func restoreConfig(ctx context.Context, s storage.Storage, config map[string]string, dryRun bool) ([]string, error) {
+	existing, err := s.GetAllConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var changed []string
+	for key, value := range config {
+		if isLocalConfigKey(key) {
+			continue
+		}
+		if current, ok := existing[key]; ok && current == value {
+			continue
+		}
+		changed = append(changed, key)
+		if dryRun {
+			continue
+		}
+		if err := s.SetConfig(ctx, key, value); err != nil {
+			return changed, err
+		}
+	}
+	sort.Strings(changed)
+	return changed, nil
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestIsLocalConfigKey(t *testing.T) {
+	tests := map[string]bool{
+		"jira.api_token":         true,
+		"linear.api_key":         true,
+		"jira.last_sync":         true,
+		"issue_prefix":           false,
+		"close.verify_labels":    false,
+		"contributor.auto_route": false,
+	}
+	for key, want := range tests {
+		if got := isLocalConfigKey(key); got != want {
+			t.Errorf("isLocalConfigKey(%q) = %v, want %v", key, got, want)
+		}
+	}
+}
+
+func TestConfigBundleRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	src := newTestStore(t, filepath.Join(t.TempDir(), ".beads", "beads.db"))
+	for key, value := range map[string]string{
+		"close.verify_labels": "security",
+		"status.custom":       "review",
+		"jira.api_token":      "secret",
+	} {
+		if err := src.SetConfig(ctx, key, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	config, err := exportableConfig(ctx, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := config["jira.api_token"]; ok {
+		t.Error("exported config must not contain credentials")
+	}
+	line, err := json.Marshal(configBundle{Config: config})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, ok, err := parseConfigBundle(line)
+	if !ok || err != nil {
+		t.Fatalf("parseConfigBundle = %v, %v", ok, err)
+	}
+	if _, ok, _ := parseConfigBundle([]byte(`{"id":"bd-1","title":"x"}`)); ok {
+		t.Error("an issue line was taken for a config line")
+	}
+
+	dst := newTestStore(t, filepath.Join(t.TempDir(), ".beads", "beads.db"))
+	if err := dst.SetConfig(ctx, "status.custom", "review"); err != nil {
+		t.Fatal(err)
+	}
+	changed, err := restoreConfig(ctx, dst, parsed, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"close.verify_labels"}; !reflect.DeepEqual(changed, want) {
+		t.Errorf("dry-run changed = %v, want %v", changed, want)
+	}
+	if value, _ := dst.GetConfig(ctx, "close.verify_labels"); value != "" {
+		t.Errorf("dry run wrote config: %q", value)
+	}
+	if _, err := restoreConfig(ctx, dst, parsed, false); err != nil {
+		t.Fatal(err)
+	}
+	if value, _ := dst.GetConfig(ctx, "close.verify_labels"); value != "security" {
+		t.Errorf("close.verify_labels = %q after restore, want security", value)
+	}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/fatih/color"
+	"github.com/steveyegge/beads/internal/debug"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+	"golang.org/x/term"
+)
+
+// contentPreviewBytes bounds the head of a converted field that stays inline
+const contentPreviewBytes = 2048
+
+// pendingAttachment is field content uploaded by --attach-oversized, to be
+// recorded once the issue it belongs to is known
+type pendingAttachment struct {
+	attachment *types.Attachment
+	data       []byte
+}
+
+// loadContentLimits returns the project's content.* limits. Without a local
+// store (daemon mode) the defaults are used; the daemon enforces the
+// configured limits itself.
+func loadContentLimits() sqlite.ContentLimits {
+	getConfig := func(context.Context, string) (string, error) { return "", nil }
+	if store != nil {
+		getConfig = store.GetConfig
+	}
+	limits, err := sqlite.LoadContentLimits(rootCtx, getConfig)
+	if err != nil {
+		debug.Logf("Warning: failed to read content limits: %v\n", err)
+	}
+	return limits
+}
+
+// guardContent checks the long text fields about to be written. Values over
+// content.max_bytes or holding binary data are rejected unless attach is set
+// or the user agrees to convert them at the prompt; converted values are
+// uploaded as attachments and replaced by a short stub. Values over
+// content.warn_bytes draw a warning, or are converted when attach is set.
+func guardContent(fields map[string]*string, attach bool) []*pendingAttachment {
+	limits := loadContentLimits()
+	var pending []*pendingAttachment
+	for _, field := range sqlite.ContentFields {
+		ptr, ok := fields[field]
+		if !ok || *ptr == "" {
+			continue
+		}
+		value := *ptr
+		convert := attach && limits.WarnBytes > 0 && len(value) > limits.WarnBytes
+		if err := limits.Check(field, value); err != nil {
+			if !attach && !confirmAttachContent(err) {
+				FatalErrorWithHint(err.Error(),
+					"re-run with --attach-oversized to store it as an attachment instead")
+			}
+			convert = true
+		} else if !convert && limits.WarnBytes > 0 && len(value) > limits.WarnBytes {
+			yellow := color.New(color.FgYellow).SprintFunc()
+			fmt.Fprintf(os.Stderr, "%s %s is %s; consider --attach-oversized to keep large logs out of the issue\n",
+				yellow("⚠"), field, formatAttachmentSize(int64(len(value))))
+		}
+		if !convert {
+			continue
+		}
+
+		if err := ensureDirectMode("--attach-oversized requires direct database access"); err != nil {
+			FatalError("%v", err)
+		}
+		data := []byte(value)
+		attachment := uploadAttachment(contentAttachmentName(field, value), data)
+		*ptr = contentStub(field, value, attachment)
+		pending = append(pending, &pendingAttachment{attachment: attachment, data: data})
+	}
+	return pending
+}
+
+// guardContentUpdates applies guardContent to the text fields of an update map
+func guardContentUpdates(updates map[string]interface{}, attach bool) []*pendingAttachment {
+	fields := make(map[string]*string)
+	for _, field := range sqlite.ContentFields {
+		if v, ok := updates[field].(string); ok {
+			fields[field] = &v
+		}
+	}
+	pending := guardContent(fields, attach)
+	for field, v := range fields {
+		updates[field] = *v
+	}
+	return pending
+}
+
+// confirmAttachContent offers to convert a rejected field to an attachment
+// when a user is at the terminal
+func confirmAttachContent(reason error) bool {
+	if jsonOutput || !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stderr.Fd())) {
+		return false
+	}
+	fmt.Fprintf(os.Stderr, "%v\nStore it as an attachment instead? [y/N] ", reason)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+// recordPendingAttachments attaches converted content to issueID
+func recordPendingAttachments(issueID string, pending []*pendingAttachment) {
+	for _, p := range pending {
+		attachment := *p.attachment
+		recordAttachment(issueID, &attachment, p.data)
+	}
+}
+
+// contentAttachmentName names the attachment for a converted field
+func contentAttachmentName(field, value string) string {
+	if sqlite.LooksBinary(value) {
+		return field + ".bin"
+	}
+	return field + ".txt"
+}
+
+// contentStub is what stays in a converted field: the head of the text, so
+// readers still get the gist, and a pointer to the full content
+func contentStub(field, value string, attachment *types.Attachment) string {
+	note := fmt.Sprintf("[Full %s (%s) moved to attachment %s, sha256 %.12s; 'bd attach get' downloads it]",
+		field, formatAttachmentSize(attachment.Size), attachment.Name, attachment.SHA256)
+	if sqlite.LooksBinary(value) {
+		return note
+	}
+	head := value
+	if len(head) > contentPreviewBytes {
+		head = head[:contentPreviewBytes]
+		if i := strings.LastIndexByte(head, '\n'); i > 0 {
+			head = head[:i]
+		}
+		for !utf8.ValidString(head) {
+			head = head[:len(head)-1]
+		}
+	}
+	return strings.TrimRight(head, "\n") + "\n\n" + note
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestContentStub(t *testing.T) {
+	attachment := &types.Attachment{Name: "description.txt", SHA256: "0123456789abcdef", Size: 3 << 20}
+
+	log := strings.Repeat("line of build output\n", 1000)
+	stub := contentStub("description", log, attachment)
+	if len(stub) > contentPreviewBytes+200 {
+		t.Errorf("stub is %d bytes, want about %d", len(stub), contentPreviewBytes)
+	}
+	if !strings.HasPrefix(stub, "line of build output\n") {
+		t.Errorf("stub should keep the head of the text, got %q", stub[:40])
+	}
+	if !strings.Contains(stub, "\n\n[Full description (3.0 MB) moved to attachment description.txt, sha256 0123456789ab;") {
+		t.Errorf("stub should point at the attachment, got %q", stub[len(stub)-150:])
+	}
+
+	binary := contentStub("description", "\x89PNG\x00\x00", attachment)
+	if !strings.HasPrefix(binary, "[Full description") {
+		t.Errorf("binary content should leave only the pointer, got %q", binary)
+	}
+
+	if got := contentAttachmentName("notes", "\x00"); got != "notes.bin" {
+		t.Errorf("contentAttachmentName(binary) = %q, want notes.bin", got)
+	}
+	if got := contentAttachmentName("design", "text"); got != "design.txt" {
+		t.Errorf("contentAttachmentName(text) = %q, want design.txt", got)
+	}
+}
@@ -13,7 +13,9 @@ import (
 	"github.com/steveyegge/beads/internal/hooks"
 	"github.com/steveyegge/beads/internal/routing"
 	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/util"
 	"github.com/steveyegge/beads/internal/validation"
 )
 
@@ -25,6 +27,33 @@ var createCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		CheckReadonly("create")
 		file, _ := cmd.Flags().GetString("file")
+		templateName, _ := cmd.Flags().GetString("template")
+		if templateName != "" && (file != "" || cmd.Flags().Changed("from-clipboard")) {
+			FatalError("--template creates a single issue; it cannot be combined with --file or --from-clipboard")
+		}
+
+		// --from-clipboard takes "## Title" markdown like --file, or plain text
+		// whose first line is the title and the rest the description
+		var clipboardDescription string
+		if fromClipboard, _ := cmd.Flags().GetBool("from-clipboard"); fromClipboard {
+			if len(args) > 0 || file != "" || cmd.Flags().Changed("title") {
+				FatalError("cannot combine --from-clipboard with a title or --file")
+			}
+			text, err := clipboardRead()
+			if err != nil {
+				FatalError("reading clipboard: %v", err)
+			}
+			if templates := clipboardIssueTemplates(text); templates != nil {
+				createIssuesFromTemplates(templates, "clipboard")
+				return
+			}
+			title, description := splitClipboardText(text)
+			if title == "" {
+				FatalError("clipboard is empty")
+			}
+			args = []string{title}
+			clipboardDescription = description
+		}
 
 		// If file flag is provided, parse markdown and create multiple issues
 		if file != "" {
@@ -54,6 +83,16 @@ var createCmd = &cobra.Command{
 			FatalError("title required (or use --file to create from markdown)")
 		}
 
+		// An issue template (bd template add) presets what isn't given here
+		var tmpl *CreateTemplate
+		if templateName != "" {
+			var err error
+			if tmpl, err = loadIssueTemplate(templateName); err != nil {
+				FatalError("%v", err)
+			}
+			title = tmpl.prefixTitle(title)
+		}
+
 		// Get silent flag
 		silent, _ := cmd.Flags().GetBool("silent")
 
@@ -66,6 +105,12 @@ var createCmd = &cobra.Command{
 
 		// Get field values
 		description, _ := getDescriptionFlag(cmd)
+		if description == "" {
+			description = clipboardDescription
+		}
+		if description == "" && tmpl != nil {
+			description = tmpl.Description
+		}
 
 		// Warn if creating an issue without a description (unless it's a test issue or silent mode)
 		if description == "" && !strings.Contains(strings.ToLower(title), "test") && !silent && !debug.IsQuiet() {
@@ -77,6 +122,17 @@ var createCmd = &cobra.Command{
 
 		design, _ := cmd.Flags().GetString("design")
 		acceptance, _ := cmd.Flags().GetString("acceptance")
+		if acceptance == "" && tmpl != nil {
+			acceptance = tmpl.AcceptanceCriteria
+		}
+
+		// Strip secrets before they reach the database (redaction.* config)
+		redactInput(&title, &description, &design, &acceptance)
+		// Move log dumps and binary pastes to attachments (content.* config)
+		attachOversized, _ := cmd.Flags().GetBool("attach-oversized")
+		pendingAttachments := guardContent(map[string]*string{"description": &description, "design": &design, "acceptance_criteria": &acceptance}, attachOversized)
+		// and encrypt sensitive fields (encryption.fields config)
+		encryptInput(map[string]*string{"description": &description, "design": &design, "acceptance_criteria": &acceptance})
 
 		// Parse priority (supports both "1" and "P1" formats)
 		priorityStr, _ := cmd.Flags().GetString("priority")
@@ -93,6 +149,17 @@ var createCmd = &cobra.Command{
 		if len(labelAlias) > 0 {
 			labels = append(labels, labelAlias...)
 		}
+		if tmpl != nil {
+			if tmpl.Priority != nil && !cmd.Flags().Changed("priority") {
+				priority = *tmpl.Priority
+			}
+			if tmpl.Type != "" && !cmd.Flags().Changed("type") {
+				issueType = tmpl.Type
+			}
+			if len(tmpl.Labels) > 0 {
+				labels = util.NormalizeLabels(append(append([]string{}, tmpl.Labels...), labels...))
+			}
+		}
 
 		explicitID, _ := cmd.Flags().GetString("id")
 		parentID, _ := cmd.Flags().GetString("parent")
@@ -110,6 +177,35 @@ var createCmd = &cobra.Command{
 			}
 			estimatedMinutes = &est
 		}
+		var timeboxMinutes *int
+		if cmd.Flags().Changed("timebox") {
+			value, _ := cmd.Flags().GetString("timebox")
+			minutes, err := parseTimebox(value)
+			if err != nil {
+				FatalError("invalid --timebox: %v", err)
+			}
+			timeboxMinutes = &minutes
+		} else if issueType == string(types.TypeSpike) {
+			FatalError("spikes need a timebox (e.g. --timebox 2d)")
+		}
+		complexityFlag, _ := cmd.Flags().GetString("complexity")
+		complexity, err := parseComplexityFlag(complexityFlag)
+		if err != nil {
+			FatalError("%v", err)
+		}
+		fieldFlags, _ := cmd.Flags().GetStringArray("field")
+		fields, err := parseFieldFlags(fieldFlags)
+		if err != nil {
+			FatalError("invalid --field: %v", err)
+		}
+		if tmpl != nil {
+			fields = tmpl.withFieldDefaults(fields)
+			if missing := tmpl.missingFields(fields); len(missing) > 0 {
+				FatalErrorWithHint(fmt.Sprintf("template %s requires field(s): %s", tmpl.Name, strings.Join(missing, ", ")),
+					fmt.Sprintf("set them with --field %s=<value>", missing[0]))
+			}
+		}
+		fields = encryptFieldValues(fields)
 		// Use global jsonOutput set by PersistentPreRun
 
 		// Determine target repository using routing logic
@@ -210,8 +306,11 @@ var createCmd = &cobra.Command{
 				Assignee:           assignee,
 				ExternalRef:        externalRef,
 				EstimatedMinutes:   estimatedMinutes,
+				TimeboxMinutes:     timeboxMinutes,
+				Complexity:         string(complexity),
 				Labels:             labels,
 				Dependencies:       deps,
+				Fields:             fields,
 			}
 
 			resp, err := daemonClient.Create(createArgs)
@@ -229,6 +328,7 @@ var createCmd = &cobra.Command{
 			if hookRunner != nil {
 				hookRunner.Run(hooks.EventCreate, &issue)
 			}
+			decryptForDisplay(&issue)
 
 			if jsonOutput {
 				fmt.Println(string(resp.Data))
@@ -257,6 +357,8 @@ var createCmd = &cobra.Command{
 			Assignee:           assignee,
 			ExternalRef:        externalRefPtr,
 			EstimatedMinutes:   estimatedMinutes,
+			TimeboxMinutes:     timeboxMinutes,
+			Complexity:         complexity,
 		}
 
 		ctx := rootCtx
@@ -296,9 +398,16 @@ var createCmd = &cobra.Command{
 			// If error getting parent or parent has no source_repo, continue with default
 		}
 		
+		// Check custom fields before anything is written
+		fields, err = storage.NormalizeFields(ctx, store, fields, false)
+		if err != nil {
+			FatalError("%v", err)
+		}
+
 		if err := store.CreateIssue(ctx, issue, actor); err != nil {
 			FatalError("%v", err)
 		}
+		recordPendingAttachments(issue.ID, pendingAttachments)
 
 		// If parent was specified, add parent-child dependency
 		if parentID != "" {
@@ -319,6 +428,15 @@ var createCmd = &cobra.Command{
 			}
 		}
 
+		// Set custom fields if specified
+		if len(fields) > 0 {
+			if err := storage.SetIssueFields(ctx, store, issue.ID, fields, actor); err != nil {
+				WarnError("failed to set fields: %v", err)
+			} else {
+				issue.Fields = fields
+			}
+		}
+
 		// Add dependencies if specified (format: type:id or just id for default "blocks" type)
 		for _, depSpec := range deps {
 			// Skip empty specs (e.g., from trailing commas)
@@ -362,6 +480,9 @@ var createCmd = &cobra.Command{
 			}
 		}
 
+		// Checklist items referencing other issues become soft dependencies
+		syncChecklistDeps(ctx, issue.ID, issue.Description)
+
 		// Schedule auto-flush
 		markDirtyAndScheduleFlush()
 
@@ -369,6 +490,7 @@ var createCmd = &cobra.Command{
 		if hookRunner != nil {
 			hookRunner.Run(hooks.EventCreate, issue)
 		}
+		decryptForDisplay(issue)
 
 		if jsonOutput {
 			outputJSON(issue)
@@ -389,10 +511,12 @@ var createCmd = &cobra.Command{
 
 func init() {
 	createCmd.Flags().StringP("file", "f", "", "Create multiple issues from markdown file")
+	createCmd.Flags().Bool("from-clipboard", false, "Create from the clipboard: markdown like --file, or a title line followed by the description")
 	createCmd.Flags().String("title", "", "Issue title (alternative to positional argument)")
+	createCmd.Flags().String("template", "", "Start from an issue template (see bd template add)")
 	createCmd.Flags().Bool("silent", false, "Output only the issue ID (for scripting)")
 	registerPriorityFlag(createCmd, "2")
-	createCmd.Flags().StringP("type", "t", "task", "Issue type (bug|feature|task|epic|chore)")
+	createCmd.Flags().StringP("type", "t", "task", "Issue type (bug|feature|task|epic|chore|spike)")
 	registerCommonIssueFlags(createCmd)
 	createCmd.Flags().StringSliceP("labels", "l", []string{}, "Labels (comma-separated)")
 	createCmd.Flags().StringSlice("label", []string{}, "Alias for --labels")
@@ -403,6 +527,9 @@ func init() {
 	createCmd.Flags().Bool("force", false, "Force creation even if prefix doesn't match database prefix")
 	createCmd.Flags().String("repo", "", "Target repository for issue (overrides auto-routing)")
 	createCmd.Flags().IntP("estimate", "e", 0, "Time estimate in minutes (e.g., 60 for 1 hour)")
+	createCmd.Flags().String("timebox", "", "Timebox for a spike (e.g., 4h, 2d, 1w); required with --type spike")
+	createCmd.Flags().String("complexity", "", "Complexity for agent routing: trivial, standard, complex, research")
+	createCmd.Flags().StringArray("field", nil, "Custom field value, name=value (repeatable; see bd field)")
 	// Note: --json flag is defined as a persistent flag in main.go, not here
 	rootCmd.AddCommand(createCmd)
 }
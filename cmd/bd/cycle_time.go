@@ -0,0 +1,270 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+)
+
+// PercentileSummary holds duration percentiles (in hours) for a set of samples
+type PercentileSummary struct {
+	Samples  int     `json:"samples"`
+	P50Hours float64 `json:"p50_hours"`
+	P90Hours float64 `json:"p90_hours"`
+	P99Hours float64 `json:"p99_hours"`
+}
+
+// CycleTimeGroup is the cycle-time breakdown for one group of closed issues
+type CycleTimeGroup struct {
+	Key       string             `json:"key"`
+	Count     int                `json:"count"`
+	LeadTime  *PercentileSummary `json:"lead_time"`            // created → closed
+	QueueTime *PercentileSummary `json:"queue_time,omitempty"` // created → in_progress
+	WorkTime  *PercentileSummary `json:"work_time,omitempty"`  // in_progress → closed
+}
+
+// CycleTimeReport is the output of 'bd stats cycle-time'
+type CycleTimeReport struct {
+	Since      *time.Time        `json:"since,omitempty"`
+	Overall    *CycleTimeGroup   `json:"overall"`
+	ByLabel    []*CycleTimeGroup `json:"by_label,omitempty"`
+	ByPriority []*CycleTimeGroup `json:"by_priority,omitempty"`
+	ByActor    []*CycleTimeGroup `json:"by_actor,omitempty"`
+}
+
+var cycleTimeDimensions = []string{"label", "priority", "actor"}
+
+var cycleTimeCmd = &cobra.Command{
+	Use:   "cycle-time",
+	Short: "Show cycle-time percentiles for closed issues",
+	Long: `Show how long closed issues took to move through their lifecycle.
+
+Durations are derived from the event history:
+  lead time   created → closed
+  queue time  created → first in_progress
+  work time   first in_progress → closed
+
+Percentiles (p50/p90/p99) are reported overall and broken down by label,
+priority, and actor (the actor who closed the issue, or its assignee).
+
+Examples:
+  bd stats cycle-time
+  bd stats cycle-time --by label
+  bd stats cycle-time --since 2025-01-01 --json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		by, _ := cmd.Flags().GetStringSlice("by")
+		sinceStr, _ := cmd.Flags().GetString("since")
+
+		for _, dim := range by {
+			if !slices.Contains(cycleTimeDimensions, dim) {
+				FatalError("invalid --by value %q (valid: %s)", dim, strings.Join(cycleTimeDimensions, ", "))
+			}
+		}
+
+		var since time.Time
+		if sinceStr != "" {
+			t, err := parseTimeFlag(sinceStr)
+			if err != nil {
+				FatalError("parsing --since: %v", err)
+			}
+			since = t
+		}
+
+		if err := ensureDirectMode("stats cycle-time requires direct database access"); err != nil {
+			FatalError("%v", err)
+		}
+		sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+		if !ok {
+			FatalError("stats cycle-time requires SQLite storage")
+		}
+
+		samples, err := sqliteStore.GetCycleTimeSamples(rootCtx, since)
+		if err != nil {
+			FatalError("%v", err)
+		}
+
+		report := buildCycleTimeReport(samples, by)
+		if !since.IsZero() {
+			report.Since = &since
+		}
+
+		if jsonOutput {
+			outputJSON(report)
+			return
+		}
+		printCycleTimeReport(report)
+	},
+}
+
+// buildCycleTimeReport aggregates samples into overall and per-dimension groups
+func buildCycleTimeReport(samples []*sqlite.CycleTimeSample, dims []string) *CycleTimeReport {
+	report := &CycleTimeReport{
+		Overall: summarizeCycleTimes("all", samples),
+	}
+
+	for _, dim := range dims {
+		groups := make(map[string][]*sqlite.CycleTimeSample)
+		for _, sample := range samples {
+			for _, key := range cycleTimeKeys(sample, dim) {
+				groups[key] = append(groups[key], sample)
+			}
+		}
+
+		keys := make([]string, 0, len(groups))
+		for key := range groups {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		result := make([]*CycleTimeGroup, 0, len(keys))
+		for _, key := range keys {
+			result = append(result, summarizeCycleTimes(key, groups[key]))
+		}
+
+		switch dim {
+		case "label":
+			report.ByLabel = result
+		case "priority":
+			report.ByPriority = result
+		case "actor":
+			report.ByActor = result
+		}
+	}
+
+	return report
+}
+
+// cycleTimeKeys returns the group keys a sample belongs to for a dimension.
+// An issue with several labels counts toward each of them.
+func cycleTimeKeys(sample *sqlite.CycleTimeSample, dim string) []string {
+	switch dim {
+	case "label":
+		if len(sample.Labels) == 0 {
+			return []string{"(none)"}
+		}
+		return sample.Labels
+	case "priority":
+		return []string{fmt.Sprintf("P%d", sample.Priority)}
+	case "actor":
+		if sample.Actor == "" {
+			return []string{"(unknown)"}
+		}
+		return []string{sample.Actor}
+	}
+	return nil
+}
+
+// summarizeCycleTimes computes lead/queue/work percentiles for a group
+func summarizeCycleTimes(key string, samples []*sqlite.CycleTimeSample) *CycleTimeGroup {
+	var lead, queue, work []float64
+	for _, sample := range samples {
+		lead = append(lead, sample.ClosedAt.Sub(sample.CreatedAt).Hours())
+		if sample.StartedAt != nil {
+			queue = append(queue, sample.StartedAt.Sub(sample.CreatedAt).Hours())
+			work = append(work, sample.ClosedAt.Sub(*sample.StartedAt).Hours())
+		}
+	}
+
+	group := &CycleTimeGroup{
+		Key:      key,
+		Count:    len(samples),
+		LeadTime: summarizePercentiles(lead),
+	}
+	if len(queue) > 0 {
+		group.QueueTime = summarizePercentiles(queue)
+		group.WorkTime = summarizePercentiles(work)
+	}
+	return group
+}
+
+func summarizePercentiles(values []float64) *PercentileSummary {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	return &PercentileSummary{
+		Samples:  len(sorted),
+		P50Hours: percentile(sorted, 50),
+		P90Hours: percentile(sorted, 90),
+		P99Hours: percentile(sorted, 99),
+	}
+}
+
+// percentile returns the nearest-rank percentile of an ascending slice.
+// Negative durations (clock skew between clones) are clamped to zero.
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return math.Max(0, sorted[rank-1])
+}
+
+func printCycleTimeReport(report *CycleTimeReport) {
+	cyan := color.New(color.FgCyan).SprintFunc()
+
+	if report.Overall.Count == 0 {
+		fmt.Printf("\n%s No closed issues to measure\n\n", cyan("⏱"))
+		return
+	}
+
+	fmt.Printf("\n%s Cycle time (%d closed issues)\n", cyan("⏱"), report.Overall.Count)
+	if report.Since != nil {
+		fmt.Printf("Closed since %s\n", displayDate(*report.Since))
+	}
+	fmt.Println()
+
+	printCycleTimeTable("Overall", []*CycleTimeGroup{report.Overall})
+	printCycleTimeTable("By label", report.ByLabel)
+	printCycleTimeTable("By priority", report.ByPriority)
+	printCycleTimeTable("By actor", report.ByActor)
+}
+
+func printCycleTimeTable(title string, groups []*CycleTimeGroup) {
+	if len(groups) == 0 {
+		return
+	}
+	fmt.Printf("%s:\n", title)
+	fmt.Printf("  %-20s %5s  %-26s %-26s\n", "", "n", "lead p50/p90/p99", "work p50/p90/p99")
+	for _, g := range groups {
+		work := "-"
+		if g.WorkTime != nil {
+			work = formatPercentiles(g.WorkTime)
+		}
+		fmt.Printf("  %-20s %5d  %-26s %-26s\n", truncateTitle(g.Key, 20), g.Count, formatPercentiles(g.LeadTime), work)
+	}
+	fmt.Println()
+}
+
+func formatPercentiles(s *PercentileSummary) string {
+	return fmt.Sprintf("%s/%s/%s", formatHours(s.P50Hours), formatHours(s.P90Hours), formatHours(s.P99Hours))
+}
+
+// formatHours renders a duration in hours using the most readable unit
+func formatHours(h float64) string {
+	switch {
+	case h < 1:
+		return fmt.Sprintf("%.0fm", h*60)
+	case h < 48:
+		return fmt.Sprintf("%.1fh", h)
+	default:
+		return fmt.Sprintf("%.1fd", h/24)
+	}
+}
+
+func init() {
+	cycleTimeCmd.Flags().StringSlice("by", cycleTimeDimensions, "Breakdown dimensions: label, priority, actor")
+	cycleTimeCmd.Flags().String("since", "", "Only include issues closed on or after this date (YYYY-MM-DD or RFC3339)")
+	statsCmd.AddCommand(cycleTimeCmd)
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+)
+
+func TestPercentile(t *testing.T) {
+	values := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	tests := []struct {
+		p    float64
+		want float64
+	}{
+		{50, 5},
+		{90, 9},
+		{99, 10},
+		{0, 1},
+	}
+	for _, tt := range tests {
+		if got := percentile(values, tt.p); got != tt.want {
+			t.Errorf("percentile(%v) = %v, want %v", tt.p, got, tt.want)
+		}
+	}
+	if got := percentile(nil, 50); got != 0 {
+		t.Errorf("percentile of empty slice = %v, want 0", got)
+	}
+	if got := percentile([]float64{-3}, 50); got != 0 {
+		t.Errorf("negative durations should clamp to 0, got %v", got)
+	}
+}
+
+func TestBuildCycleTimeReport(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	started := base.Add(2 * time.Hour)
+	samples := []*sqlite.CycleTimeSample{
+		{IssueID: "bd-1", Priority: 1, Actor: "alice", Labels: []string{"api", "auth"}, CreatedAt: base, StartedAt: &started, ClosedAt: base.Add(10 * time.Hour)},
+		{IssueID: "bd-2", Priority: 2, Actor: "bob", CreatedAt: base, ClosedAt: base.Add(4 * time.Hour)},
+	}
+
+	report := buildCycleTimeReport(samples, []string{"label", "priority", "actor"})
+
+	if report.Overall.Count != 2 {
+		t.Fatalf("expected overall count 2, got %d", report.Overall.Count)
+	}
+	if report.Overall.LeadTime.P99Hours != 10 {
+		t.Errorf("expected p99 lead time 10h, got %v", report.Overall.LeadTime.P99Hours)
+	}
+	if report.Overall.WorkTime == nil || report.Overall.WorkTime.Samples != 1 || report.Overall.WorkTime.P50Hours != 8 {
+		t.Errorf("expected single 8h work-time sample, got %+v", report.Overall.WorkTime)
+	}
+
+	labelKeys := map[string]int{}
+	for _, g := range report.ByLabel {
+		labelKeys[g.Key] = g.Count
+	}
+	if labelKeys["api"] != 1 || labelKeys["auth"] != 1 || labelKeys["(none)"] != 1 {
+		t.Errorf("unexpected label groups: %v", labelKeys)
+	}
+	if len(report.ByPriority) != 2 || report.ByPriority[0].Key != "P1" {
+		t.Errorf("unexpected priority groups: %+v", report.ByPriority)
+	}
+	if len(report.ByActor) != 2 {
+		t.Errorf("expected 2 actor groups, got %d", len(report.ByActor))
+	}
+
+	onlyActor := buildCycleTimeReport(samples, []string{"actor"})
+	if onlyActor.ByLabel != nil || onlyActor.ByPriority != nil {
+		t.Error("expected only actor breakdown when --by actor")
+	}
+}
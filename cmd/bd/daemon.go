@@ -14,6 +14,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/cmd/bd/doctor"
 	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/daemon"
 	"github.com/steveyegge/beads/internal/rpc"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
@@ -25,13 +26,19 @@ var daemonCmd = &cobra.Command{
 	Long: `Manage the background daemon that automatically syncs issues with git remote.
 
 The daemon will:
-- Poll for changes at configurable intervals (default: 5 seconds)
+- Poll for changes at configurable intervals (default: 5 seconds), backing
+  off to daemon-idle-backoff (default: 5 minutes) while the project is idle
 - Export pending database changes to JSONL
 - Auto-commit changes if --auto-commit flag set
 - Auto-push commits if --auto-push flag set
 - Pull remote changes periodically
 - Auto-import when remote changes detected
 
+Config changes apply without a restart: the daemon checks the config table
+and config.yaml every interval (and on SIGHUP), updates daemon.interval,
+daemon.auto_commit and daemon.auto_push in place, restarts webhooks, event
+bus relays and publishing when their settings change, and logs each change.
+
 Common operations:
   bd daemon --start              Start the daemon (background)
   bd daemon --start --foreground Start in foreground (for systemd/supervisord)
@@ -39,6 +46,23 @@ Common operations:
   bd daemon --stop-all           Stop ALL running bd daemons
   bd daemon --status             Check if daemon is running
   bd daemon --health             Check daemon health and metrics
+  bd daemon pause --sync         Pause git sync during manual git work
+  bd daemon resume               Resume after a pause
+
+Remote agents:
+  --listen host:port (or daemon-listen / BEADS_DAEMON_LISTEN) also serves
+  the Beads gRPC API (internal/rpc/beadspb/beads.proto) over TLS, using the
+  certificate in --tls-cert/--tls-key. bd on other machines uses this
+  daemon by setting BEADS_DAEMON_ADDR=host:port, plus BEADS_DAEMON_TLS_CA
+  when the certificate isn't signed by a public CA. Both sides need the
+  same BEADS_DAEMON_TOKEN (or daemon-token in config.yaml).
+
+Monitoring:
+  --metrics-addr host:port (or daemon-metrics-addr /
+  BEADS_DAEMON_METRICS_ADDR) serves Prometheus metrics at /metrics: RPC
+  requests, sync runs and git commits/pushes by result, the time of the
+  last successful sync, issue counts by status and database latency. The
+  endpoint has no authentication; bind it to localhost.
 
 Run 'bd daemon' with no flags to see available options.`,
 	Run: func(cmd *cobra.Command, args []string) {
@@ -54,6 +78,20 @@ Run 'bd daemon' with no flags to see available options.`,
 		localMode, _ := cmd.Flags().GetBool("local")
 		logFile, _ := cmd.Flags().GetString("log")
 		foreground, _ := cmd.Flags().GetBool("foreground")
+		if cmd.Flags().Changed("listen") {
+			listen, _ := cmd.Flags().GetString("listen")
+			config.Set("daemon-listen", listen)
+		}
+		for flag, key := range map[string]string{"tls-cert": "daemon-tls-cert", "tls-key": "daemon-tls-key"} {
+			if cmd.Flags().Changed(flag) {
+				value, _ := cmd.Flags().GetString(flag)
+				config.Set(key, value)
+			}
+		}
+		if cmd.Flags().Changed("metrics-addr") {
+			metricsAddr, _ := cmd.Flags().GetString("metrics-addr")
+			config.Set("daemon-metrics-addr", metricsAddr)
+		}
 
 		// If no operation flags provided, show help
 		if !start && !stop && !stopAll && !status && !health && !metrics {
@@ -76,6 +114,20 @@ Run 'bd daemon' with no flags to see available options.`,
 					}
 				}
 			}
+			if !cmd.Flags().Changed("interval") {
+				if dbPath := beads.FindDatabasePath(); dbPath != "" {
+					ctx := context.Background()
+					store, err := sqlite.New(ctx, dbPath)
+					if err == nil {
+						if configVal, err := store.GetConfig(ctx, "daemon.interval"); err == nil && configVal != "" {
+							if configInterval, err := time.ParseDuration(configVal); err == nil {
+								interval = configInterval
+							}
+						}
+						_ = store.Close()
+					}
+				}
+			}
 			if !cmd.Flags().Changed("auto-push") {
 				if dbPath := beads.FindDatabasePath(); dbPath != "" {
 					ctx := context.Background()
@@ -168,6 +220,19 @@ Run 'bd daemon' with no flags to see available options.`,
 			}
 		}
 
+		// Remote access needs a shared token and a certificate; catch that
+		// before forking
+		if config.GetString("daemon-listen") != "" {
+			if config.GetString("daemon-token") == "" {
+				FatalErrorWithHint("--listen requires a daemon token",
+					"set BEADS_DAEMON_TOKEN (e.g. to the output of 'openssl rand -hex 32') or daemon-token in config.yaml")
+			}
+			if config.GetString("daemon-tls-cert") == "" || config.GetString("daemon-tls-key") == "" {
+				FatalErrorWithHint("--listen requires a TLS certificate",
+					"pass --tls-cert and --tls-key (or set BEADS_DAEMON_TLS_CERT and BEADS_DAEMON_TLS_KEY)")
+			}
+		}
+
 		// Validate --local mode constraints
 		if localMode {
 			if autoCommit {
@@ -205,6 +270,10 @@ Run 'bd daemon' with no flags to see available options.`,
 		}
 		if dbPath != "" {
 			warnWorktreeDaemon(dbPath)
+			if reason := storageNotWritable(dbPath); reason != "" {
+				FatalErrorWithHint("cannot start the daemon: "+reason,
+					"commands read the database directly here; no daemon is needed")
+			}
 		}
 
 		// Start daemon
@@ -235,6 +304,10 @@ func init() {
 	daemonCmd.Flags().Bool("metrics", false, "Show detailed daemon metrics")
 	daemonCmd.Flags().String("log", "", "Log file path (default: .beads/daemon.log)")
 	daemonCmd.Flags().Bool("foreground", false, "Run in foreground (don't daemonize)")
+	daemonCmd.Flags().String("listen", "", "Also serve the gRPC API to remote clients on this TCP address (requires BEADS_DAEMON_TOKEN and a TLS certificate)")
+	daemonCmd.Flags().String("tls-cert", "", "TLS certificate (PEM) for --listen")
+	daemonCmd.Flags().String("tls-key", "", "TLS private key (PEM) for --listen")
+	daemonCmd.Flags().String("metrics-addr", "", "Serve Prometheus metrics at http://<addr>/metrics (e.g. 127.0.0.1:9464)")
 	daemonCmd.Flags().BoolVar(&jsonOutput, "json", false, "Output JSON format")
 	rootCmd.AddCommand(daemonCmd)
 }
@@ -318,6 +391,9 @@ func runDaemonLoop(interval time.Duration, autoCommit, autoPush, localMode bool,
 		log.log("Daemon started in LOCAL mode (interval: %v, no git sync)", interval)
 	} else {
 		log.log("Daemon started (interval: %v, auto-commit: %v, auto-push: %v)", interval, autoCommit, autoPush)
+		if repo := describeSyncRepo(ctx); repo != "" {
+			log.log("%s", repo)
+		}
 	}
 
 	// Check for multiple .db files (ambiguity error)
@@ -355,6 +431,11 @@ func runDaemonLoop(interval time.Duration, autoCommit, autoPush, localMode bool,
 		}
 	}
 
+	if usesPostgres(daemonDBPath) {
+		log.log("Error: this project uses the postgres backend; bd commands talk to the server directly and no daemon is needed")
+		return // Use return instead of os.Exit to allow defers to run
+	}
+
 	// Validate using canonical name
 	dbBaseName := filepath.Base(daemonDBPath)
 	if dbBaseName != beads.CanonicalDatabaseName {
@@ -373,7 +454,7 @@ func runDaemonLoop(interval time.Duration, autoCommit, autoPush, localMode bool,
 		log.log("Warning: could not remove daemon-error file: %v", err)
 	}
 
-	store, err := sqlite.New(ctx, daemonDBPath)
+	store, err := sqlite.NewWithTimeout(ctx, daemonDBPath, lockTimeout)
 	if err != nil {
 		log.log("Error: cannot open database: %v", err)
 		return // Use return instead of os.Exit to allow defers to run
@@ -465,6 +546,25 @@ func runDaemonLoop(interval time.Duration, autoCommit, autoPush, localMode bool,
 	if err != nil {
 		return
 	}
+	if listen := config.GetString("daemon-listen"); listen != "" {
+		addr, err := listenRemote(server, listen)
+		if err != nil {
+			log.log("Error: cannot accept remote connections: %v", err)
+			_ = server.Stop()
+			return
+		}
+		log.log("Accepting remote connections on %s", addr)
+	}
+	daemonMetrics = server.Metrics()
+	if metricsAddr := config.GetString("daemon-metrics-addr"); metricsAddr != "" {
+		addr, err := server.ServeMetrics(metricsAddr)
+		if err != nil {
+			log.log("Error: cannot serve metrics: %v", err)
+			_ = server.Stop()
+			return
+		}
+		log.log("Serving metrics on http://%s/metrics", addr)
+	}
 
 	// Choose event loop based on BEADS_DAEMON_MODE (need to determine early for SetConfig)
 	daemonMode := os.Getenv("BEADS_DAEMON_MODE")
@@ -504,15 +604,16 @@ func runDaemonLoop(interval time.Duration, autoCommit, autoPush, localMode bool,
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
-	// Create sync function based on mode
-	var doSync func()
-	if localMode {
-		doSync = createLocalSyncFunc(ctx, store, log)
-	} else {
-		doSync = createSyncFunc(ctx, store, autoCommit, autoPush, log)
-	}
+	// Sync settings and background tasks follow config changes while the
+	// daemon runs (see daemon_reload.go)
+	settings := &daemonSettings{interval: interval, autoCommit: autoCommit, autoPush: autoPush}
+	doSync := reloadableSync(ctx, store, settings, ticker, localMode, log)
 	doSync()
 
+	configWatcher := newDaemonConfigWatcher(ctx, store, settings, server, localMode, daemonMode, log)
+	configWatcher.startTasks(ctx)
+	go configWatcher.run(ctx, interval)
+
 	// Get parent PID for monitoring (exit if parent dies)
 	parentPID := computeDaemonParentPID()
 	log.log("Monitoring parent process (PID %d)", parentPID)
@@ -533,7 +634,7 @@ func runDaemonLoop(interval time.Duration, autoCommit, autoPush, localMode bool,
 				doExport = createLocalExportFunc(ctx, store, log)
 				doAutoImport = createLocalAutoImportFunc(ctx, store, log)
 			} else {
-				doExport = createExportFunc(ctx, store, autoCommit, autoPush, log)
+				doExport = reloadableExport(ctx, store, settings, log)
 				doAutoImport = createAutoImportFunc(ctx, store, log)
 			}
 			runEventDrivenLoop(ctx, cancel, server, serverErrChan, store, jsonlPath, doExport, doAutoImport, parentPID, log)
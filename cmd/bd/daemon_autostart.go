@@ -404,6 +404,12 @@ func emitVerboseWarning() {
 	case FallbackFlagNoDaemon:
 		// Don't warn when user explicitly requested --no-daemon
 		return
+	case FallbackReadOnlyStorage:
+		// Expected: a daemon couldn't write there either
+		return
+	case FallbackPostgresBackend:
+		// Expected: clients of a PostgreSQL database don't use a daemon
+		return
 	}
 }
 
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// idleBackoff stretches the time between sync cycles while nothing changes:
+// each idle cycle doubles the wait, up to max, and any change resets it to
+// the base interval
+type idleBackoff struct {
+	base time.Duration
+	max  time.Duration
+	wait time.Duration // current time between sync cycles
+	last time.Time     // when the last sync cycle ran
+}
+
+// newIdleBackoff returns the backoff for a daemon polling every interval,
+// or nil when daemon-idle-backoff disables it (zero, or not above interval)
+func newIdleBackoff(interval time.Duration) *idleBackoff {
+	max := config.GetDuration("daemon-idle-backoff")
+	if max <= interval {
+		return nil
+	}
+	return &idleBackoff{base: interval, max: max, wait: interval}
+}
+
+// due reports whether the next sync cycle is due at now
+func (b *idleBackoff) due(now time.Time) bool {
+	return now.Sub(b.last) >= b.wait
+}
+
+// record notes a sync cycle run at now and whether it saw any change, and
+// returns the wait until the next one
+func (b *idleBackoff) record(now time.Time, changed bool) time.Duration {
+	b.last = now
+	if changed {
+		b.wait = b.base
+	} else if b.wait = 2 * b.wait; b.wait > b.max {
+		b.wait = b.max
+	}
+	return b.wait
+}
+
+// adaptiveSync wraps a polling daemon's sync cycle so that it runs less often
+// while the project is idle. The ticker still fires every interval, but the
+// cycle (export, commit, pull, import) is skipped until the backoff is due,
+// unless a cheap check finds local work: dirty issues, or a JSONL changed
+// on disk since the last cycle (a manual pull or checkout).
+func adaptiveSync(ctx context.Context, store storage.Storage, jsonlPath string, interval time.Duration, doSync func(), log daemonLogger) func() {
+	b := newIdleBackoff(interval)
+	if b == nil {
+		return doSync
+	}
+	log.log("Idle backoff enabled (up to %v between sync cycles)", b.max)
+
+	var lastHash string
+	var lastModTime time.Time
+	jsonlModTime := func() time.Time {
+		if info, err := os.Stat(jsonlPath); err == nil {
+			return info.ModTime()
+		}
+		return time.Time{}
+	}
+
+	return func() {
+		pending := !jsonlModTime().Equal(lastModTime)
+		if dirty, err := store.GetDirtyIssues(ctx); err == nil && len(dirty) > 0 {
+			pending = true
+		}
+		if !pending && !b.due(time.Now()) {
+			return
+		}
+
+		doSync()
+
+		// Exports rewrite the JSONL every cycle, so compare its contents: they
+		// change when local edits were exported or remote ones pulled in
+		hash, _ := computeJSONLHash(jsonlPath)
+		changed := pending || hash != lastHash
+		lastHash, lastModTime = hash, jsonlModTime()
+
+		prev := b.wait
+		next := b.record(time.Now(), changed)
+		switch {
+		case next < prev:
+			log.log("Change detected, back to syncing every %v", next)
+		case next > prev:
+			log.log("Idle, next sync cycle in %v", next)
+		}
+	}
+}
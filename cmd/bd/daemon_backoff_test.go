@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestIdleBackoff(t *testing.T) {
+	config.Set("daemon-idle-backoff", "40s")
+	defer config.Set("daemon-idle-backoff", "5m")
+
+	b := newIdleBackoff(5 * time.Second)
+	now := time.Now()
+	var waits []time.Duration
+	for i := 0; i < 5; i++ {
+		waits = append(waits, b.record(now, false))
+	}
+	want := []time.Duration{10 * time.Second, 20 * time.Second, 40 * time.Second, 40 * time.Second, 40 * time.Second}
+	for i := range want {
+		if waits[i] != want[i] {
+			t.Fatalf("idle waits = %v, want %v", waits, want)
+		}
+	}
+	if b.due(now.Add(39*time.Second)) || !b.due(now.Add(40*time.Second)) {
+		t.Error("due() doesn't follow the current wait")
+	}
+	if got := b.record(now, true); got != 5*time.Second {
+		t.Errorf("wait after a change = %v, want 5s", got)
+	}
+
+	config.Set("daemon-idle-backoff", "0")
+	if newIdleBackoff(5*time.Second) != nil {
+		t.Error("daemon-idle-backoff=0 should disable the backoff")
+	}
+}
+
+func TestAdaptiveSyncSkipsIdleCycles(t *testing.T) {
+	config.Set("daemon-idle-backoff", "1h")
+	defer config.Set("daemon-idle-backoff", "5m")
+	ctx := context.Background()
+	dir := t.TempDir()
+	s := newTestStore(t, filepath.Join(dir, "beads.db"))
+	jsonlPath := filepath.Join(dir, "issues.jsonl")
+	if err := os.WriteFile(jsonlPath, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	syncs := 0
+	log := daemonLogger{logFunc: func(string, ...interface{}) {}}
+	doSync := adaptiveSync(ctx, s, jsonlPath, time.Minute, func() {
+		syncs++
+		dirty, _ := s.GetDirtyIssues(ctx)
+		_ = s.ClearDirtyIssuesByID(ctx, dirty)
+	}, log)
+
+	doSync() // first cycle always runs
+	doSync() // nothing changed and not due yet
+	doSync()
+	if syncs != 1 {
+		t.Fatalf("syncs while idle = %d, want 1", syncs)
+	}
+
+	issue := &types.Issue{Title: "Wake up", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatal(err)
+	}
+	doSync()
+	if syncs != 2 {
+		t.Fatalf("syncs after a change = %d, want 2", syncs)
+	}
+}
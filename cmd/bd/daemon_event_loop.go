@@ -7,13 +7,29 @@ import (
 	"runtime"
 	"time"
 
+	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/rpc"
 	"github.com/steveyegge/beads/internal/storage"
 )
 
+// defaultDaemonDebounce is the batch window for event-driven sync when
+// daemon-debounce is unset or invalid
+const defaultDaemonDebounce = 500 * time.Millisecond
+
+// getDaemonDebounce returns the configured event-driven debounce window
+// (daemon-debounce in config.yaml or BEADS_DAEMON_DEBOUNCE)
+func getDaemonDebounce() time.Duration {
+	duration := config.GetDuration("daemon-debounce")
+	if duration <= 0 {
+		return defaultDaemonDebounce
+	}
+	return duration
+}
+
 // runEventDrivenLoop implements event-driven daemon architecture.
 // Replaces polling ticker with reactive event handlers for:
 // - File system changes (JSONL modifications)
+// - Database writes from outside the daemon (SQLite file and WAL)
 // - RPC mutations (create, update, delete)
 // - Git operations (via hooks, optional)
 // - Parent process monitoring (exit if parent dies)
@@ -34,13 +50,15 @@ func runEventDrivenLoop(
 	defer signal.Stop(sigChan)
 
 	// Debounced sync actions
-	exportDebouncer := NewDebouncer(500*time.Millisecond, func() {
+	debounce := getDaemonDebounce()
+	log.log("Event debounce window: %v", debounce)
+	exportDebouncer := NewDebouncer(debounce, func() {
 		log.log("Export triggered by mutation events")
 		doExport()
 	})
 	defer exportDebouncer.Cancel()
 
-	importDebouncer := NewDebouncer(500*time.Millisecond, func() {
+	importDebouncer := NewDebouncer(debounce, func() {
 		log.log("Import triggered by file change")
 		doAutoImport()
 	})
@@ -58,6 +76,22 @@ func runEventDrivenLoop(
 		fallbackTicker = time.NewTicker(60 * time.Second)
 		defer fallbackTicker.Stop()
 	} else {
+		// Writes that bypass the daemon (bd --no-daemon, other tools) only
+		// show up as database changes. Export when they left dirty issues;
+		// the daemon's own export also touches the WAL but leaves none.
+		if dbPath := store.Path(); dbPath != "" {
+			watcher.WatchDatabase(dbPath, debounce, func() {
+				dirty, err := store.GetDirtyIssues(ctx)
+				if err != nil {
+					log.log("Database change check failed: %v", err)
+					return
+				}
+				if len(dirty) > 0 {
+					log.log("Database change detected (%d dirty issues)", len(dirty))
+					exportDebouncer.Trigger()
+				}
+			})
+		}
 		watcher.Start(ctx, log)
 		defer func() { _ = watcher.Close() }()
 	}
@@ -131,7 +165,7 @@ func runEventDrivenLoop(
 
 		case sig := <-sigChan:
 			if isReloadSignal(sig) {
-				log.log("Received reload signal, ignoring")
+				log.log("Received reload signal, reloading config")
 				continue
 			}
 			log.log("Received signal %v, shutting down...", sig)
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+
+	"github.com/steveyegge/beads/internal/eventbus"
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// startEventBusRelays starts one relay per configured message bus
+// (events.nats.url, events.amqp.url). Each runs until ctx is cancelled.
+func startEventBusRelays(ctx context.Context, store storage.Storage, log daemonLogger) {
+	source, ok := store.(eventbus.Source)
+	if !ok {
+		return
+	}
+	for _, kind := range []string{eventbus.KindNATS, eventbus.KindAMQP} {
+		urlKey, topicKey := eventbus.ConfigKeys(kind)
+		url, _ := store.GetConfig(ctx, urlKey)
+		if url == "" {
+			continue
+		}
+		topic, _ := store.GetConfig(ctx, topicKey)
+		relay := &eventbus.Relay{
+			Config: eventbus.Config{Kind: kind, URL: url, Topic: topic},
+			Source: source,
+			Logf:   log.log,
+		}
+		log.log("Publishing events to %s", kind)
+		go relay.Run(ctx)
+	}
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// externalRefreshInterval is how often the daemon rechecks blockers in
+// linked workspaces
+const externalRefreshInterval = time.Minute
+
+// startExternalRefresher keeps the status of issues in linked workspaces
+// that local issues depend on current, every minute until ctx is
+// cancelled. Problems are logged only when they change, so an unlinked
+// workspace doesn't fill the log.
+func startExternalRefresher(ctx context.Context, store storage.Storage, log daemonLogger) {
+	go func() {
+		ticker := time.NewTicker(externalRefreshInterval)
+		defer ticker.Stop()
+		var lastProblem string
+		for {
+			problem := ""
+			if err := refreshExternalIssues(ctx, store); err != nil {
+				problem = strings.ReplaceAll(err.Error(), "\n", "; ")
+			}
+			if problem != lastProblem && problem != "" {
+				log.log("Cross-workspace dependencies: %s", problem)
+			}
+			lastProblem = problem
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/github"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+)
+
+// maxGateWebhookBody caps webhook deliveries; check_run payloads are a few KB
+const maxGateWebhookBody = 1 << 20
+
+// startGateWatcher keeps CI gates (bd gate) current until ctx is cancelled:
+// it polls GitHub for unmet gates every gates.poll_interval and, when
+// gates.webhook_listen is set, accepts signed check_run and status webhooks.
+func startGateWatcher(ctx context.Context, store storage.Storage, log daemonLogger) {
+	s, ok := store.(*sqlite.SQLiteStorage)
+	if !ok {
+		return
+	}
+	if listen := config.GetString("gates.webhook_listen"); listen != "" {
+		startGateWebhookServer(ctx, s, listen, config.GetString("gates.webhook_secret"), log)
+	}
+
+	interval, err := time.ParseDuration(config.GetString("gates.poll_interval"))
+	if err != nil {
+		log.log("Warning: gate polling disabled: invalid gates.poll_interval: %v", err)
+		return
+	}
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			// Only talk to GitHub while something is waiting on it
+			if unmet, err := s.ListGates(ctx, true); err == nil && len(unmet) > 0 {
+				if _, err := pollGates(ctx, s, log.log); err != nil {
+					log.log("Gate polling failed: %v", err)
+				}
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func startGateWebhookServer(ctx context.Context, s *sqlite.SQLiteStorage, listen, secret string, log daemonLogger) {
+	if secret == "" {
+		log.log("Warning: gate webhooks disabled: gates.webhook_listen needs gates.webhook_secret")
+		return
+	}
+	ln, err := net.Listen("tcp", listen)
+	if err != nil {
+		log.log("Warning: gate webhooks disabled: %v", err)
+		return
+	}
+	server := &http.Server{
+		Handler:           gateWebhookHandler(s, secret, log),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+	go func() {
+		if err := server.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.log("Gate webhook server stopped: %v", err)
+		}
+	}()
+	log.log("Accepting CI gate webhooks on %s", ln.Addr())
+}
+
+// gateWebhookHandler applies GitHub check_run and status deliveries to the
+// gates on their check and refs. Other events are acknowledged and ignored,
+// so the webhook can be subscribed to more than it needs.
+func gateWebhookHandler(s *sqlite.SQLiteStorage, secret string, log daemonLogger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxGateWebhookBody))
+		if err != nil {
+			http.Error(w, "failed to read body", http.StatusBadRequest)
+			return
+		}
+		if !github.VerifySignature(secret, body, r.Header.Get("X-Hub-Signature-256")) {
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+		event, err := github.ParseCheckEvent(r.Header.Get("X-GitHub-Event"), body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if event == nil {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		for _, ref := range event.Refs {
+			changed, err := s.SetGateState(r.Context(), event.Repo, event.Name, ref, event.State, event.Detail, event.URL)
+			if err != nil {
+				log.log("Gate webhook failed: %v", err)
+				http.Error(w, "failed to update gates", http.StatusInternalServerError)
+				return
+			}
+			for _, gate := range changed {
+				log.log("%s: %s %s", gate.IssueID, gate, gate.State)
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
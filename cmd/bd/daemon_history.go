@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+)
+
+// historyPruneInterval is how often the daemon prunes event history
+const historyPruneInterval = 24 * time.Hour
+
+// startHistoryPruner rolls up and deletes events older than
+// history.retention (bd prune-history) at startup and then daily until ctx
+// is cancelled. Without history.retention events are kept forever.
+func startHistoryPruner(ctx context.Context, store storage.Storage, log daemonLogger) {
+	s, ok := store.(*sqlite.SQLiteStorage)
+	if !ok {
+		return
+	}
+	value, err := s.GetConfig(ctx, historyRetentionKey)
+	if err != nil || value == "" {
+		return
+	}
+	retention, err := parseHistoryRetention(value)
+	if err != nil {
+		log.log("Warning: history pruning disabled: %v", err)
+		return
+	}
+	log.log("Pruning event history older than %s", value)
+
+	go func() {
+		ticker := time.NewTicker(historyPruneInterval)
+		defer ticker.Stop()
+		for {
+			result, err := s.PruneEvents(ctx, time.Now().Add(-retention), false)
+			if err != nil {
+				log.log("History pruning failed: %v", err)
+			} else if result.EventsPruned > 0 || result.OperationsPruned > 0 {
+				log.log("Pruned %d events into %d issue summaries, and %d undo log operations",
+					result.EventsPruned, result.IssuesSummarized, result.OperationsPruned)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
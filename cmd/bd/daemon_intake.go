@@ -0,0 +1,283 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/encryption"
+	"github.com/steveyegge/beads/internal/intake"
+	"github.com/steveyegge/beads/internal/redact"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// maxIntakeBody caps intake reports; one with a long stack trace is still
+// well under it
+const maxIntakeBody = 1 << 20
+
+// intakeActor is recorded as the creator of issues filed through intake
+const intakeActor = "intake"
+
+// startIntakeServer accepts bug reports from outside bd (crash reporters,
+// forms) on POST /intake at intake.listen until ctx is cancelled. Callers
+// authenticate with intake.token as a bearer token.
+func startIntakeServer(ctx context.Context, store storage.Storage, log daemonLogger) {
+	listen := config.GetString("intake.listen")
+	if listen == "" {
+		return
+	}
+	token := config.GetString("intake.token")
+	if token == "" {
+		log.log("Warning: intake disabled: intake.listen needs intake.token")
+		return
+	}
+	s, ok := store.(*sqlite.SQLiteStorage)
+	if !ok {
+		return
+	}
+	// Hooks run in the project root, like plugins
+	root := filepath.Dir(filepath.Dir(s.Path()))
+	handler := &intakeHandler{
+		store:     store,
+		token:     token,
+		hooks:     intake.ParseHooks(config.GetStringSlice("intake.hooks"), root),
+		dir:       root,
+		beadsDir:  filepath.Dir(s.Path()),
+		threshold: config.GetFloat64("intake.dedupe_threshold"),
+		log:       log,
+	}
+
+	ln, err := net.Listen("tcp", listen)
+	if err != nil {
+		log.log("Warning: intake disabled: %v", err)
+		return
+	}
+	server := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+	go func() {
+		if err := server.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.log("Intake server stopped: %v", err)
+		}
+	}()
+	log.log("Accepting issue reports on http://%s/intake", ln.Addr())
+}
+
+// intakeHandler files reports posted to /intake: it runs them past the
+// intake hooks, then either files a new issue or, when the report matches
+// an open issue, comments on that one instead.
+type intakeHandler struct {
+	store     storage.Storage
+	token     string
+	hooks     []intake.Hook
+	dir       string
+	beadsDir  string  // Where the encryption key is looked up
+	threshold float64 // Similarity to an open issue that makes a report a duplicate; 0 only matches external_ref
+	log       daemonLogger
+
+	mu sync.Mutex // Serializes dedupe and filing so a burst of one report files it once
+}
+
+// intakeResponse is the reply to a report that was filed
+type intakeResponse struct {
+	ID         string  `json:"id"`
+	Source     string  `json:"source"`
+	Duplicate  bool    `json:"duplicate"`
+	Similarity float64 `json:"similarity,omitempty"` // How closely a duplicate matched; 1 for the same external_ref
+}
+
+func (h *intakeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/intake" {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(h.token)) != 1 {
+		http.Error(w, "invalid token", http.StatusUnauthorized)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxIntakeBody+1))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+	if len(body) > maxIntakeBody {
+		http.Error(w, "report too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	report, err := intake.Parse(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	report, err = intake.RunHooks(r.Context(), h.hooks, h.dir, report)
+	if err != nil {
+		var rejected *intake.RejectedError
+		if errors.As(err, &rejected) {
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+		h.log.log("Intake: %v", err)
+		http.Error(w, "intake hook failed", http.StatusInternalServerError)
+		return
+	}
+	// Crash reports routinely carry tokens and keys: strip them before
+	// anything is stored (redaction.* config)
+	redactor, err := redact.FromConfig()
+	if err != nil {
+		h.log.log("Intake: %v", err)
+		http.Error(w, "failed to file report", http.StatusInternalServerError)
+		return
+	}
+	redactor.RedactTexts(&report.Title, &report.Description, &report.Reporter)
+	issue, err := report.Issue()
+	if err != nil {
+		http.Error(w, "invalid report: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.file(r.Context(), report, issue)
+	if err != nil {
+		h.log.log("Intake failed: %v", err)
+		http.Error(w, "failed to file report", http.StatusInternalServerError)
+		return
+	}
+	status := http.StatusCreated
+	if resp.Duplicate {
+		status = http.StatusOK
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// file files the report's issue, or comments on the open issue it
+// duplicates. The issue's encrypted fields (encryption.fields) are sealed
+// before it is stored.
+func (h *intakeHandler) file(ctx context.Context, report *intake.Report, issue *types.Issue) (*intakeResponse, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	fieldCipher, err := encryption.FromConfig(h.beadsDir)
+	if err != nil {
+		return nil, err
+	}
+	existing, similarity, err := h.findDuplicate(ctx, report, issue, fieldCipher)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		comment := intakeDuplicateComment(report, issue, existing, slices.Contains(fieldCipher.Fields(), "description"))
+		if _, err := h.store.AddIssueComment(ctx, existing.ID, intakeActor, comment); err != nil {
+			return nil, fmt.Errorf("failed to comment on %s: %w", existing.ID, err)
+		}
+		h.log.log("Intake: %s reported again via %s", existing.ID, issue.Source)
+		return &intakeResponse{ID: existing.ID, Source: issue.Source, Duplicate: true, Similarity: similarity}, nil
+	}
+
+	if err := fieldCipher.EncryptIssue(issue); err != nil {
+		return nil, fmt.Errorf("failed to encrypt report: %w", err)
+	}
+	if err := h.store.CreateIssue(ctx, issue, intakeActor); err != nil {
+		return nil, fmt.Errorf("failed to create issue: %w", err)
+	}
+	for _, label := range report.Labels {
+		if err := h.store.AddLabel(ctx, issue.ID, strings.TrimSpace(label), intakeActor); err != nil {
+			return nil, fmt.Errorf("failed to label %s: %w", issue.ID, err)
+		}
+	}
+	h.log.log("Intake: filed %s from %s: %s", issue.ID, issue.Source, issue.Title)
+	return &intakeResponse{ID: issue.ID, Source: issue.Source}, nil
+}
+
+// intakeDedupeStatuses are the statuses of issues a report can repeat;
+// closed issues, usually most of a backlog, are never loaded
+var intakeDedupeStatuses = []types.Status{types.StatusOpen, types.StatusInProgress, types.StatusBlocked, types.StatusResolved}
+
+// findDuplicate returns the issue a report repeats: the one with its
+// external_ref, or else the open issue most similar to it, if at least
+// threshold similar (see bd duplicates --workspace). Encrypted candidates
+// are compared decrypted when the key is available.
+func (h *intakeHandler) findDuplicate(ctx context.Context, report *intake.Report, issue *types.Issue, fieldCipher *encryption.Cipher) (*types.Issue, float64, error) {
+	if issue.ExternalRef != nil {
+		existing, err := h.store.GetIssueByExternalRef(ctx, *issue.ExternalRef)
+		if err != nil {
+			return nil, 0, err
+		}
+		if existing != nil && existing.Status != types.StatusTombstone {
+			return existing, 1, nil
+		}
+	}
+	if h.threshold <= 0 {
+		return nil, 0, nil
+	}
+
+	// Compare what was reported, without the reporter noted in the description
+	reported := newWorkspaceIssue(nil, &types.Issue{Title: issue.Title, Description: report.Description})
+	var best *types.Issue
+	bestScore := 0.0
+	for _, status := range intakeDedupeStatuses {
+		candidates, err := h.store.SearchIssues(ctx, "", types.IssueFilter{Status: &status})
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, candidate := range candidates {
+			compared := candidate
+			if encryption.HasEncrypted(candidate) && fieldCipher.HasKey() {
+				plain := *candidate
+				if err := fieldCipher.DecryptIssue(&plain); err == nil {
+					compared = &plain
+				}
+			}
+			score := dedupeSimilarity(reported, newWorkspaceIssue(nil, compared))
+			if score >= h.threshold && score > bestScore {
+				best, bestScore = candidate, score
+			}
+		}
+	}
+	return best, bestScore, nil
+}
+
+// intakeDuplicateComment records a repeated report on the issue it repeats.
+// Comments aren't encrypted, so when descriptions are, the report's
+// description is left out rather than stored in plaintext.
+func intakeDuplicateComment(report *intake.Report, issue, existing *types.Issue, encryptedDescription bool) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Reported again via %s", issue.Source)
+	if ref := issue.ExternalRef; ref != nil && (existing.ExternalRef == nil || *existing.ExternalRef != *ref) {
+		fmt.Fprintf(&b, " (%s)", *ref)
+	}
+	if reporter := strings.TrimSpace(report.Reporter); reporter != "" {
+		fmt.Fprintf(&b, " by %s", reporter)
+	}
+	fmt.Fprintf(&b, ":\n\n%s", issue.Title)
+	if report.Description != "" && !encryptedDescription {
+		fmt.Fprintf(&b, "\n\n%s", report.Description)
+	}
+	return b.String()
+}
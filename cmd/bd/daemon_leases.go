@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+)
+
+// leaseExpiryInterval is how often the daemon releases expired claims
+const leaseExpiryInterval = time.Minute
+
+// startLeaseExpirer hands issues whose claim (bd claim --ttl) ran out back
+// to open and unassigned, every minute until ctx is cancelled. Without a
+// daemon, expired claims are released the next time someone claims work.
+func startLeaseExpirer(ctx context.Context, store storage.Storage, log daemonLogger) {
+	s, ok := store.(*sqlite.SQLiteStorage)
+	if !ok {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(leaseExpiryInterval)
+		defer ticker.Stop()
+		for {
+			expired, err := s.ExpireLeases(ctx, time.Now())
+			if err != nil {
+				log.log("Lease expiry failed: %v", err)
+			}
+			for _, lease := range expired {
+				log.log("Claim on %s by %s expired; issue reopened", lease.IssueID, lease.Holder)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
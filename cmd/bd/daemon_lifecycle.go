@@ -10,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/daemon"
 	"github.com/steveyegge/beads/internal/rpc"
 )
@@ -42,6 +43,11 @@ func formatUptime(seconds float64) string {
 
 // showDaemonStatus displays the current daemon status
 func showDaemonStatus(pidFile string) {
+	pause, err := loadDaemonPause(filepath.Dir(pidFile))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
 	if isRunning, pid := isDaemonRunning(pidFile); isRunning {
 		var started string
 		if info, err := os.Stat(pidFile); err == nil {
@@ -85,6 +91,9 @@ func showDaemonStatus(pidFile string) {
 				status["sync_interval"] = rpcStatus.SyncInterval
 				status["daemon_mode"] = rpcStatus.DaemonMode
 			}
+			if pause != nil {
+				status["paused"] = pause
+			}
 			outputJSON(status)
 			return
 		}
@@ -106,12 +115,39 @@ func showDaemonStatus(pidFile string) {
 				fmt.Printf("  Local Mode: %v (no git sync)\n", rpcStatus.LocalMode)
 			}
 		}
+		printDaemonPause(pause)
 	} else {
 		if jsonOutput {
-			outputJSON(map[string]interface{}{"running": false})
+			status := map[string]interface{}{"running": false}
+			if pause != nil {
+				status["paused"] = pause
+			}
+			outputJSON(status)
 			return
 		}
 		fmt.Println("Daemon is not running")
+		printDaemonPause(pause)
+	}
+}
+
+// printDaemonPause adds the pause state, if any, to bd daemon --status
+func printDaemonPause(pause *daemonPause) {
+	if pause == nil {
+		return
+	}
+	fmt.Printf("  Paused: %s", pause.Scope)
+	if pause.PausedBy != "" {
+		fmt.Printf(" by %s", pause.PausedBy)
+	}
+	fmt.Printf(" at %s\n", pause.PausedAt.Local().Format("2006-01-02 15:04:05"))
+	if pause.Reason != "" {
+		fmt.Printf("  Reason: %s\n", pause.Reason)
+	}
+	if pause.Until != nil {
+		fmt.Printf("  Resumes: %s (in %s)\n", pause.Until.Local().Format("2006-01-02 15:04:05"),
+			time.Until(*pause.Until).Round(time.Minute))
+	} else {
+		fmt.Println("  Resumes: on 'bd daemon resume'")
 	}
 }
 
@@ -224,6 +260,15 @@ func showDaemonMetrics() {
 	fmt.Printf("  Memory Sys: %d MB\n", metrics.MemorySysMB)
 	fmt.Printf("  Goroutines: %d\n\n", metrics.GoroutineCount)
 
+	// Database connection pools; waits here mean requests queued for SQLite
+	if pool := metrics.DBPool; pool != nil {
+		fmt.Printf("Database Pools:\n")
+		fmt.Printf("  Read: %d/%d in use, %d waits (%.1f ms total)\n",
+			pool.Read.InUse, pool.Read.MaxOpen, pool.Read.WaitCount, pool.Read.WaitMS)
+		fmt.Printf("  Write: %d/%d in use, %d waits (%.1f ms total, %.1f ms max), %d timeouts\n\n",
+			pool.Write.InUse, pool.Write.MaxOpen, pool.Write.WaitCount, pool.Write.WaitMS, pool.Write.MaxWaitMS, pool.Write.Timeouts)
+	}
+
 	// Operation metrics
 	if len(metrics.Operations) > 0 {
 		fmt.Printf("Operation Metrics:\n")
@@ -393,6 +438,14 @@ func startDaemon(interval time.Duration, autoCommit, autoPush, localMode, foregr
 	if logFile != "" {
 		args = append(args, "--log", logFile)
 	}
+	if listen := config.GetString("daemon-listen"); listen != "" {
+		args = append(args, "--listen", listen,
+			"--tls-cert", config.GetString("daemon-tls-cert"),
+			"--tls-key", config.GetString("daemon-tls-key"))
+	}
+	if metricsAddr := config.GetString("daemon-metrics-addr"); metricsAddr != "" {
+		args = append(args, "--metrics-addr", metricsAddr)
+	}
 
 	cmd := exec.Command(exe, args...) // #nosec G204 - bd daemon command from trusted binary
 	cmd.Env = append(os.Environ(), "BD_DAEMON_FOREGROUND=1")
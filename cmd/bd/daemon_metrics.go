@@ -0,0 +1,49 @@
+package main
+
+import (
+	"time"
+
+	"github.com/steveyegge/beads/internal/rpc"
+)
+
+// daemonMetrics is the running daemon's RPC server metrics, which the sync
+// loop records into for bd daemon --metrics-addr. Nil outside the daemon.
+var daemonMetrics *rpc.Metrics
+
+// syncRun records the result of one export, import or sync cycle. A run
+// that returns without calling skipped or succeeded counts as failed.
+type syncRun struct {
+	kind   string
+	result string
+}
+
+// startSyncRun begins recording a cycle; defer its finish
+func startSyncRun(kind string) *syncRun {
+	return &syncRun{kind: kind, result: rpc.SyncFailed}
+}
+
+// skipped marks a cycle that had nothing to do or wasn't allowed to run
+func (r *syncRun) skipped() { r.result = rpc.SyncSkipped }
+
+// succeeded marks a cycle that completed
+func (r *syncRun) succeeded() { r.result = rpc.SyncSucceeded }
+
+func (r *syncRun) finish() {
+	if daemonMetrics != nil {
+		daemonMetrics.RecordSyncRun(r.kind, r.result)
+	}
+}
+
+// recordGitOp records the outcome of a git commit, pull or push
+func recordGitOp(op string, err error) {
+	if daemonMetrics != nil {
+		daemonMetrics.RecordGitOp(op, err)
+	}
+}
+
+// observeDBLatency records how long a database step of the sync loop took
+func observeDBLatency(query string, start time.Time) {
+	if daemonMetrics != nil {
+		daemonMetrics.ObserveDBLatency(query, time.Since(start))
+	}
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"context"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+)
+
+// startWatchNotifications starts delivering notifications to watchers
+// (bd watch). It always runs, since watches can be added while the daemon
+// is up, and stops when ctx is cancelled.
+func startWatchNotifications(ctx context.Context, store storage.Storage, log daemonLogger) {
+	sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+	if !ok {
+		return
+	}
+	notifier := newWatchNotifier(ctx, sqliteStore)
+	notifier.Logf = log.log
+	go notifier.Run(ctx)
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// daemonPauseFile holds the pause state in .beads; the daemon checks it
+// before every export, import and sync so pausing needs no RPC and works
+// whether or not the daemon is running
+const daemonPauseFile = "daemon.pause"
+
+// Pause scopes, each including the ones before it
+const (
+	pauseScopePush = "push" // no git push; exports and commits continue
+	pauseScopeSync = "sync" // no git commit/pull/push and no auto-import
+	pauseScopeAll  = "all"  // no background work at all, not even JSONL export
+)
+
+var pauseScopeRank = map[string]int{pauseScopePush: 1, pauseScopeSync: 2, pauseScopeAll: 3}
+
+// daemonPause is the content of .beads/daemon.pause
+type daemonPause struct {
+	Scope    string     `json:"scope"`
+	Reason   string     `json:"reason,omitempty"`
+	PausedBy string     `json:"paused_by,omitempty"`
+	PausedAt time.Time  `json:"paused_at"`
+	Until    *time.Time `json:"until,omitempty"` // auto-resume time; nil means until bd daemon resume
+}
+
+// blocks reports whether the pause covers scope. A nil pause blocks nothing.
+func (p *daemonPause) blocks(scope string) bool {
+	return p != nil && pauseScopeRank[p.Scope] >= pauseScopeRank[scope]
+}
+
+// restrict turns off the daemon operations the pause covers
+func (p *daemonPause) restrict(autoCommit, autoPush, skipGit bool) (bool, bool, bool) {
+	if p.blocks(pauseScopeSync) {
+		return false, false, true
+	}
+	if p.blocks(pauseScopePush) {
+		autoPush = false
+	}
+	return autoCommit, autoPush, skipGit
+}
+
+func (p *daemonPause) String() string {
+	s := "paused " + p.Scope
+	if p.Reason != "" {
+		s += ": " + p.Reason
+	}
+	if p.Until != nil {
+		s += fmt.Sprintf(" (until %s)", p.Until.Local().Format("15:04"))
+	}
+	return s
+}
+
+// loadDaemonPause returns the pause in effect for beadsDir, or nil. An
+// expired pause is removed so the daemon resumes on its own.
+func loadDaemonPause(beadsDir string) (*daemonPause, error) {
+	path := filepath.Join(beadsDir, daemonPauseFile)
+	// #nosec G304 - path is inside the beads directory
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var pause daemonPause
+	if err := json.Unmarshal(data, &pause); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", daemonPauseFile, err)
+	}
+	if _, ok := pauseScopeRank[pause.Scope]; !ok {
+		return nil, fmt.Errorf("invalid %s: unknown scope %q", daemonPauseFile, pause.Scope)
+	}
+	if pause.Until != nil && !time.Now().Before(*pause.Until) {
+		_ = os.Remove(path)
+		return nil, nil
+	}
+	return &pause, nil
+}
+
+// daemonPauseFor is what the daemon's sync functions use: a pause file it
+// cannot read is treated as a full pause, since running anyway could race
+// whatever the user is doing
+func daemonPauseFor(beadsDir string, log daemonLogger) *daemonPause {
+	pause, err := loadDaemonPause(beadsDir)
+	if err != nil {
+		log.log("Warning: %v; treating daemon as paused", err)
+		return &daemonPause{Scope: pauseScopeAll, Reason: "unreadable pause file"}
+	}
+	return pause
+}
+
+func saveDaemonPause(beadsDir string, pause *daemonPause) error {
+	data, err := json.MarshalIndent(pause, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(beadsDir, daemonPauseFile), append(data, '\n'), 0600)
+}
+
+var daemonPauseCmd = &cobra.Command{
+	Use:   "pause",
+	Short: "Pause daemon sync while you work on git by hand",
+	Long: `Pause the daemon's background work so manual git operations (rebases,
+history rewrites, resolving conflicts) don't race it.
+
+Scopes:
+  --push   Stop pushing; exports and commits continue
+  --sync   Stop all git operations (commit, pull, push) and auto-import;
+           the database is still exported to JSONL (default)
+  --all    Stop everything, including JSONL export
+
+The pause is stored in .beads/daemon.pause, so it applies to a running
+daemon immediately and to one started later. It lasts until 'bd daemon
+resume' or, with --for, until the duration has passed.
+
+Examples:
+  bd daemon pause --sync --for 2h --reason "rebasing onto main"
+  bd daemon pause --all
+  bd daemon resume`,
+	Run: func(cmd *cobra.Command, args []string) {
+		push, _ := cmd.Flags().GetBool("push")
+		sync, _ := cmd.Flags().GetBool("sync")
+		all, _ := cmd.Flags().GetBool("all")
+		duration, _ := cmd.Flags().GetDuration("for")
+		reason, _ := cmd.Flags().GetString("reason")
+
+		scope := pauseScopeSync
+		switch {
+		case boolCount(push, sync, all) > 1:
+			FatalError("use only one of --push, --sync and --all")
+		case push:
+			scope = pauseScopePush
+		case all:
+			scope = pauseScopeAll
+		}
+		if duration < 0 {
+			FatalError("--for must be positive")
+		}
+
+		beadsDir, err := ensureBeadsDir()
+		if err != nil {
+			FatalError("%v", err)
+		}
+		pause := &daemonPause{
+			Scope:    scope,
+			Reason:   reason,
+			PausedBy: fallbackActor(),
+			PausedAt: time.Now().UTC(),
+		}
+		if duration > 0 {
+			until := pause.PausedAt.Add(duration)
+			pause.Until = &until
+		}
+		if err := saveDaemonPause(beadsDir, pause); err != nil {
+			FatalError("failed to save pause state: %v", err)
+		}
+
+		if jsonOutput {
+			outputJSON(pause)
+			return
+		}
+		fmt.Printf("Daemon %s\n", pause)
+		if running, _ := isDaemonRunning(filepath.Join(beadsDir, "daemon.pid")); !running {
+			fmt.Println("  (no daemon is running; the pause applies when one starts)")
+		}
+		fmt.Println("Resume with: bd daemon resume")
+	},
+}
+
+var daemonResumeCmd = &cobra.Command{
+	Use:   "resume",
+	Short: "Resume a paused daemon",
+	Run: func(cmd *cobra.Command, args []string) {
+		beadsDir, err := ensureBeadsDir()
+		if err != nil {
+			FatalError("%v", err)
+		}
+		pause, err := loadDaemonPause(beadsDir)
+		if err != nil {
+			// Unreadable state is removed like any other
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+		if err := os.Remove(filepath.Join(beadsDir, daemonPauseFile)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			FatalError("failed to remove pause state: %v", err)
+		}
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"resumed": pause != nil})
+			return
+		}
+		if pause == nil {
+			fmt.Println("Daemon was not paused")
+			return
+		}
+		fmt.Println("Daemon resumed; it catches up on the next sync cycle")
+	},
+}
+
+func boolCount(values ...bool) int {
+	n := 0
+	for _, v := range values {
+		if v {
+			n++
+		}
+	}
+	return n
+}
+
+func init() {
+	daemonPauseCmd.Flags().Bool("push", false, "Pause only git push")
+	daemonPauseCmd.Flags().Bool("sync", false, "Pause git commit/pull/push and auto-import (default)")
+	daemonPauseCmd.Flags().Bool("all", false, "Pause all background work, including JSONL export")
+	daemonPauseCmd.Flags().Duration("for", 0, "Resume automatically after this long (e.g. 2h)")
+	daemonPauseCmd.Flags().String("reason", "", "Why the daemon is paused (shown in status)")
+	daemonCmd.AddCommand(daemonPauseCmd)
+	daemonCmd.AddCommand(daemonResumeCmd)
+}
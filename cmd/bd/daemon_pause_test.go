@@ -0,0 +1,70 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDaemonPauseScopes(t *testing.T) {
+	var none *daemonPause
+	if none.blocks(pauseScopePush) {
+		t.Error("nil pause should block nothing")
+	}
+	if c, p, s := none.restrict(true, true, false); !c || !p || s {
+		t.Errorf("nil pause restrict = %v %v %v", c, p, s)
+	}
+
+	push := &daemonPause{Scope: pauseScopePush}
+	if !push.blocks(pauseScopePush) || push.blocks(pauseScopeSync) {
+		t.Error("push pause should block only pushes")
+	}
+	if c, p, s := push.restrict(true, true, false); !c || p || s {
+		t.Errorf("push pause restrict = %v %v %v", c, p, s)
+	}
+
+	sync := &daemonPause{Scope: pauseScopeSync}
+	if !sync.blocks(pauseScopePush) || sync.blocks(pauseScopeAll) {
+		t.Error("sync pause should include push but not all")
+	}
+	if c, p, s := sync.restrict(true, true, false); c || p || !s {
+		t.Errorf("sync pause restrict = %v %v %v", c, p, s)
+	}
+}
+
+func TestLoadDaemonPause(t *testing.T) {
+	dir := t.TempDir()
+	if pause, err := loadDaemonPause(dir); err != nil || pause != nil {
+		t.Fatalf("no pause file: %v, %v", pause, err)
+	}
+
+	until := time.Now().Add(time.Hour)
+	if err := saveDaemonPause(dir, &daemonPause{Scope: pauseScopeAll, Reason: "rebase", PausedAt: time.Now(), Until: &until}); err != nil {
+		t.Fatal(err)
+	}
+	pause, err := loadDaemonPause(dir)
+	if err != nil || pause == nil || pause.Scope != pauseScopeAll || pause.Reason != "rebase" {
+		t.Fatalf("loadDaemonPause = %+v, %v", pause, err)
+	}
+
+	// An expired pause resumes the daemon and is cleaned up
+	past := time.Now().Add(-time.Minute)
+	pause.Until = &past
+	if err := saveDaemonPause(dir, pause); err != nil {
+		t.Fatal(err)
+	}
+	if pause, err := loadDaemonPause(dir); err != nil || pause != nil {
+		t.Errorf("expired pause: %v, %v", pause, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, daemonPauseFile)); !os.IsNotExist(err) {
+		t.Error("expired pause file was not removed")
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, daemonPauseFile), []byte(`{"scope":"everything"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadDaemonPause(dir); err == nil {
+		t.Error("expected an error for an unknown scope")
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/publish"
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// startPublisher republishes the read-only snapshot (bd publish) to
+// publish.target every publish.interval until ctx is cancelled. An empty
+// target or an interval of 0 leaves publishing to the command.
+func startPublisher(ctx context.Context, store storage.Storage, log daemonLogger) {
+	targetSpec := config.GetString("publish.target")
+	if targetSpec == "" {
+		return
+	}
+	target, err := publish.ParseTarget(targetSpec)
+	if err != nil {
+		log.log("Warning: scheduled publishing disabled: %v", err)
+		return
+	}
+	interval, err := time.ParseDuration(config.GetString("publish.interval"))
+	if err != nil {
+		log.log("Warning: scheduled publishing disabled: invalid publish.interval: %v", err)
+		return
+	}
+	if interval <= 0 {
+		return
+	}
+	log.log("Publishing snapshots to %s every %v", target, interval)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			publishFromDaemon(ctx, store, target, log)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func publishFromDaemon(ctx context.Context, store storage.Storage, target *publish.Target, log daemonLogger) {
+	snapshot, err := buildPublishSnapshot(ctx, store)
+	if err != nil {
+		log.log("Publish failed: %v", err)
+		return
+	}
+	result, err := publishSnapshot(ctx, snapshot, target, true)
+	if err != nil {
+		log.log("Publish failed: %v", err)
+		return
+	}
+	if result.Changed || result.Pushed {
+		log.log("Published %d issues to %s", len(snapshot.Issues), result.Target)
+	}
+}
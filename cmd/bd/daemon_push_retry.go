@@ -0,0 +1,192 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/merge"
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// pushRetryDelay is the backoff step between push retries: the second
+// retry waits one step, the third two, and so on
+var pushRetryDelay = 2 * time.Second
+
+// pushRejectedByRemote reports whether a push failed because the remote
+// branch has commits we don't, as opposed to auth, network or hook errors
+// that another attempt won't fix
+func pushRejectedByRemote(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "[rejected]") ||
+		strings.Contains(msg, "non-fast-forward") ||
+		strings.Contains(msg, "fetch first")
+}
+
+// pushWithRetry runs push and, while the remote rejects it for being
+// behind, calls integrate to bring in the remote commits and tries again,
+// up to sync.push_retries times (0 restores fail-on-first-rejection)
+func pushWithRetry(ctx context.Context, log daemonLogger, push func() error, integrate func() error) error {
+	retries := config.GetInt("sync.push_retries")
+	for attempt := 1; ; attempt++ {
+		err := push()
+		if err == nil || attempt > retries || !pushRejectedByRemote(err) {
+			return err
+		}
+		if attempt > 1 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Duration(attempt-1) * pushRetryDelay):
+			}
+		}
+		log.log("Push rejected, remote has new commits; rebasing and retrying (%d/%d)", attempt, retries)
+		if err := integrate(); err != nil {
+			return fmt.Errorf("push rejected and integrating remote changes failed: %w", err)
+		}
+	}
+}
+
+// integrateRemote brings remote/branch into the checkout at dir: a rebase
+// when it applies cleanly, otherwise a merge whose JSONL conflicts are
+// resolved with the issue-aware 3-way merge. Conflicts in any other file
+// abort the merge and leave the checkout as it was.
+func integrateRemote(ctx context.Context, dir, remote, branch, jsonlRelPath string, log daemonLogger) error {
+	if out, err := gitIn(ctx, dir, "fetch", remote, branch); err != nil {
+		return fmt.Errorf("git fetch failed: %w\n%s", err, out)
+	}
+	upstream := remote + "/" + branch
+	if _, err := gitIn(ctx, dir, "-c", "rebase.autoStash=true", "rebase", upstream); err == nil {
+		log.log("Rebased onto %s", upstream)
+		return nil
+	}
+	_, _ = gitIn(ctx, dir, "rebase", "--abort")
+
+	out, err := gitIn(ctx, dir, "merge", "--no-edit", upstream)
+	if err == nil {
+		log.log("Merged %s (rebase had conflicts)", upstream)
+		return nil
+	}
+	conflicted, _ := gitIn(ctx, dir, "diff", "--name-only", "--diff-filter=U")
+	files := strings.Fields(conflicted)
+	if len(files) != 1 || files[0] != filepath.ToSlash(jsonlRelPath) {
+		_, _ = gitIn(ctx, dir, "merge", "--abort")
+		if len(files) == 0 {
+			return fmt.Errorf("git merge failed: %w\n%s", err, out)
+		}
+		return fmt.Errorf("merging %s conflicts in %s; resolve by hand", upstream, strings.Join(files, ", "))
+	}
+	if err := resolveJSONLConflict(ctx, dir, jsonlRelPath); err != nil {
+		_, _ = gitIn(ctx, dir, "merge", "--abort")
+		return err
+	}
+	if out, err := gitIn(ctx, dir, "add", jsonlRelPath); err != nil {
+		_, _ = gitIn(ctx, dir, "merge", "--abort")
+		return fmt.Errorf("git add failed: %w\n%s", err, out)
+	}
+	if out, err := gitIn(ctx, dir, "commit", "--no-edit", "--no-verify"); err != nil {
+		_, _ = gitIn(ctx, dir, "merge", "--abort")
+		return fmt.Errorf("git commit failed: %w\n%s", err, out)
+	}
+	log.log("Merged %s, resolving JSONL conflicts issue by issue", upstream)
+	return nil
+}
+
+// resolveJSONLConflict replaces a conflicted JSONL with the 3-way merge of
+// its index stages: base (1), ours (2) and theirs (3)
+func resolveJSONLConflict(ctx context.Context, dir, jsonlRelPath string) error {
+	tmpDir, err := os.MkdirTemp("", "bd-push-merge-")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	var stages [3]string
+	for i, name := range []string{"base", "ours", "theirs"} {
+		// A missing stage (the file was added on both sides) merges as empty
+		content, _ := gitIn(ctx, dir, "show", fmt.Sprintf(":%d:%s", i+1, filepath.ToSlash(jsonlRelPath)))
+		stages[i] = filepath.Join(tmpDir, name+".jsonl")
+		if err := os.WriteFile(stages[i], []byte(content), 0600); err != nil {
+			return err
+		}
+	}
+	if err := merge.Merge3Way(filepath.Join(dir, jsonlRelPath), stages[0], stages[1], stages[2], false); err != nil {
+		return fmt.Errorf("JSONL merge left conflicts: %w", err)
+	}
+	return nil
+}
+
+// gitIn runs git in dir and returns its combined output
+func gitIn(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...) // #nosec G204 - args are fixed git subcommands and config-derived refs
+	out, err := cmd.CombinedOutput()
+	return string(out), err
+}
+
+// gitUpstream returns the remote and branch the checkout at dir pushes to
+func gitUpstream(ctx context.Context, dir string) (string, string, error) {
+	branch, err := gitIn(ctx, dir, "symbolic-ref", "--short", "HEAD")
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get current branch: %w", err)
+	}
+	branch = strings.TrimSpace(branch)
+	remote, err := gitIn(ctx, dir, "config", "--get", fmt.Sprintf("branch.%s.remote", branch))
+	if err != nil {
+		remote = "origin"
+	}
+	return strings.TrimSpace(remote), branch, nil
+}
+
+// daemonPushWithRetry is gitPush for the daemon: when the remote has moved
+// on it pulls the new commits in, merges them into the database the way a
+// sync cycle does (including remote deletions) and pushes again
+func daemonPushWithRetry(ctx context.Context, store storage.Storage, jsonlPath string, log daemonLogger) error {
+	return pushWithRetry(ctx, log, func() error { return gitPush(ctx) }, func() error {
+		repoRoot := getRepoRootForWorktree(ctx)
+		if repoRoot == "" {
+			return fmt.Errorf("cannot determine repository root")
+		}
+		relPath, err := filepath.Rel(repoRoot, jsonlPath)
+		if err != nil {
+			return err
+		}
+		remote, branch, err := gitUpstream(ctx, repoRoot)
+		if err != nil {
+			return err
+		}
+		if err := captureLeftSnapshot(jsonlPath); err != nil {
+			return fmt.Errorf("failed to capture snapshot: %w", err)
+		}
+		if err := integrateRemote(ctx, repoRoot, remote, branch, relPath, log); err != nil {
+			return err
+		}
+		if err := applyDeletionsFromMerge(ctx, store, jsonlPath); err != nil {
+			return fmt.Errorf("3-way merge failed: %w", err)
+		}
+		if err := importToJSONLWithStore(ctx, store, jsonlPath); err != nil {
+			return fmt.Errorf("import failed: %w", err)
+		}
+		// The deletion pass can rewrite the JSONL; commit that before pushing
+		if changed, err := gitHasChanges(ctx, jsonlPath); err == nil && changed {
+			message := fmt.Sprintf("bd daemon sync: %s", time.Now().Format("2006-01-02 15:04:05"))
+			if err := gitCommit(ctx, jsonlPath, message); err != nil {
+				return err
+			}
+		}
+		if err := updateBaseSnapshot(jsonlPath); err != nil {
+			log.log("Warning: failed to update base snapshot: %v", err)
+		}
+		if err := NewSnapshotManager(jsonlPath).Cleanup(); err != nil {
+			log.log("Warning: failed to clean up snapshots: %v", err)
+		}
+		return nil
+	})
+}
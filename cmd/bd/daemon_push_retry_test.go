@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/config"
+)
+
+func TestPushRejectedByRemote(t *testing.T) {
+	for msg, want := range map[string]bool{
+		" ! [rejected]        main -> main (fetch first)":         true,
+		" ! [rejected]        main -> main (non-fast-forward)":    true,
+		" ! [remote rejected] main -> main (pre-receive hook)":    false,
+		"fatal: could not read Username for 'https://github.com'": false,
+	} {
+		if got := pushRejectedByRemote(errors.New("git push failed: exit status 1\n" + msg)); got != want {
+			t.Errorf("pushRejectedByRemote(%q) = %v, want %v", msg, got, want)
+		}
+	}
+}
+
+func TestPushWithRetryMergesJSONLConflict(t *testing.T) {
+	ctx := context.Background()
+	git := func(dir string, args ...string) string {
+		t.Helper()
+		out, err := gitIn(ctx, dir, args...)
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+		return out
+	}
+	issue := func(id, title string) string {
+		return fmt.Sprintf(`{"id":%q,"title":%q,"status":"open","priority":2,"issue_type":"task","created_at":"2024-01-01T00:00:00Z"}`, id, title)
+	}
+	writeJSONL := func(dir string, lines ...string) {
+		t.Helper()
+		path := filepath.Join(dir, ".beads", "issues.jsonl")
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		git(dir, "add", ".beads/issues.jsonl")
+		git(dir, "commit", "-q", "-m", "update issues")
+	}
+	clone := func(remote, name string) string {
+		dir := filepath.Join(filepath.Dir(remote), name)
+		git(filepath.Dir(remote), "clone", "-q", remote, dir)
+		git(dir, "config", "user.email", "test@example.com")
+		git(dir, "config", "user.name", "Test User")
+		return dir
+	}
+
+	root := t.TempDir()
+	remote := filepath.Join(root, "remote.git")
+	git(root, "init", "-q", "--bare", remote)
+	alice := clone(remote, "alice")
+	writeJSONL(alice, issue("bd-1", "First"))
+	git(alice, "push", "-q", "origin", "HEAD")
+	bob := clone(remote, "bob")
+
+	// Both edit the same JSONL: alice retitles bd-1 and pushes first, bob
+	// adds bd-2 right below it, so the rebase conflicts textually
+	writeJSONL(alice, issue("bd-1", "First, retitled"))
+	git(alice, "push", "-q")
+	writeJSONL(bob, issue("bd-1", "First"), issue("bd-2", "Second"))
+
+	config.Set("sync.push_retries", 2)
+	defer config.Set("sync.push_retries", 3)
+	log := daemonLogger{logFunc: func(format string, args ...interface{}) { t.Logf(format, args...) }}
+	remoteName, branch, err := gitUpstream(ctx, bob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pushes := 0
+	push := func() error {
+		pushes++
+		if out, err := gitIn(ctx, bob, "push"); err != nil {
+			return fmt.Errorf("git push failed: %w\n%s", err, out)
+		}
+		return nil
+	}
+	integrate := func() error {
+		return integrateRemote(ctx, bob, remoteName, branch, filepath.Join(".beads", "issues.jsonl"), log)
+	}
+	if err := pushWithRetry(ctx, log, push, integrate); err != nil {
+		t.Fatalf("pushWithRetry: %v", err)
+	}
+	if pushes != 2 {
+		t.Errorf("pushes = %d, want 2", pushes)
+	}
+
+	git(alice, "pull", "-q", "--no-rebase")
+	data, err := os.ReadFile(filepath.Join(alice, ".beads", "issues.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "First, retitled") || !strings.Contains(string(data), "bd-2") || strings.Contains(string(data), "<<<<<<<") {
+		t.Errorf("merged JSONL lost a side:\n%s", data)
+	}
+}
+
+func TestPushWithRetryGivesUp(t *testing.T) {
+	config.Set("sync.push_retries", 1)
+	defer config.Set("sync.push_retries", 3)
+	log := daemonLogger{logFunc: func(string, ...interface{}) {}}
+	rejected := errors.New("git push failed: exit status 1\n ! [rejected] main -> main (fetch first)")
+
+	pushes, integrations := 0, 0
+	err := pushWithRetry(context.Background(), log,
+		func() error { pushes++; return rejected },
+		func() error { integrations++; return nil })
+	if err != rejected || pushes != 2 || integrations != 1 {
+		t.Errorf("err = %v, pushes = %d, integrations = %d", err, pushes, integrations)
+	}
+
+	pushes = 0
+	err = pushWithRetry(context.Background(), log,
+		func() error { pushes++; return rejected },
+		func() error { return errors.New("conflicts in README.md") })
+	if err == nil || !strings.Contains(err.Error(), "README.md") || pushes != 1 {
+		t.Errorf("integration failure: err = %v, pushes = %d", err, pushes)
+	}
+}
@@ -0,0 +1,302 @@
+package main
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/redact"
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// Config keys that only take effect when the daemon starts
+var daemonRestartKeys = []string{"daemon-listen", "daemon-token", "daemon-tls-cert", "daemon-tls-key", "daemon-tls-ca", "daemon-metrics-addr", "daemon-addr", "daemon-debounce", "lock-timeout"}
+
+// daemonSettings are the daemon options a config change can update while it
+// runs. The config watcher writes them and sync cycles read them.
+type daemonSettings struct {
+	mu         sync.Mutex
+	interval   time.Duration
+	autoCommit bool
+	autoPush   bool
+	// generation is bumped on every change, so sync functions built from
+	// older settings know to rebuild
+	generation int
+}
+
+func (s *daemonSettings) get() (interval time.Duration, autoCommit, autoPush bool, generation int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.interval, s.autoCommit, s.autoPush, s.generation
+}
+
+// reloadableSync returns the polling daemon's sync cycle. It rebuilds the
+// cycle, including its idle backoff, whenever the settings change, and moves
+// the ticker to a new interval. It runs on the event loop goroutine, which
+// is the only reader of the ticker.
+func reloadableSync(ctx context.Context, store storage.Storage, settings *daemonSettings, ticker *time.Ticker, localMode bool, log daemonLogger) func() {
+	var doSync func()
+	var current time.Duration
+	built := -1
+	return func() {
+		interval, autoCommit, autoPush, generation := settings.get()
+		if generation != built {
+			if localMode {
+				doSync = createLocalSyncFunc(ctx, store, log)
+			} else {
+				doSync = createSyncFunc(ctx, store, autoCommit, autoPush, log)
+			}
+			doSync = adaptiveSync(ctx, store, findJSONLPath(), interval, doSync, log)
+			if current != 0 && interval != current {
+				ticker.Reset(interval)
+			}
+			built, current = generation, interval
+		}
+		doSync()
+	}
+}
+
+// reloadableExport returns the event-driven daemon's export, using the
+// auto-commit and auto-push settings in effect when it runs
+func reloadableExport(ctx context.Context, store storage.Storage, settings *daemonSettings, log daemonLogger) func() {
+	return func() {
+		_, autoCommit, autoPush, _ := settings.get()
+		createExportFunc(ctx, store, autoCommit, autoPush, log)()
+	}
+}
+
+// daemonTask is a background task that is restarted when config it reads
+// changes
+type daemonTask struct {
+	name     string
+	prefixes []string // config keys, in the config table or config.yaml
+	start    func(ctx context.Context, store storage.Storage, log daemonLogger)
+	cancel   context.CancelFunc
+}
+
+func (t *daemonTask) matches(key string) bool {
+	for _, prefix := range t.prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// daemonConfigWatcher applies config changes to a running daemon. It polls
+// the config table and config.yaml, and checks immediately on SIGHUP.
+// Settings read on every cycle (sync.push_retries, redaction, ...) need
+// nothing more than the reloaded config; sync settings are updated in
+// place, background tasks are restarted, and settings that only apply at
+// startup are logged as needing a restart.
+type daemonConfigWatcher struct {
+	store      storage.Storage
+	settings   *daemonSettings
+	server     *rpc.Server
+	localMode  bool
+	daemonMode string
+	tasks      []*daemonTask
+	log        daemonLogger
+
+	file    string // config.yaml watched for edits
+	fileMod time.Time
+	table   map[string]string
+}
+
+func newDaemonConfigWatcher(ctx context.Context, store storage.Storage, settings *daemonSettings, server *rpc.Server, localMode bool, daemonMode string, log daemonLogger) *daemonConfigWatcher {
+	w := &daemonConfigWatcher{
+		store:      store,
+		settings:   settings,
+		server:     server,
+		localMode:  localMode,
+		daemonMode: daemonMode,
+		log:        log,
+		file:       config.File(),
+		tasks: []*daemonTask{
+			{name: "event bus relays", prefixes: []string{"events."}, start: startEventBusRelays},
+			{name: "ready webhooks", prefixes: []string{"ready_webhook."}, start: startReadyWebhooks},
+			{name: "webhooks", prefixes: []string{"webhook."}, start: startEventWebhooks},
+			{name: "watch notifications", start: startWatchNotifications},
+			{name: "attachment indexer", prefixes: []string{"attachments."}, start: startAttachmentIndexer},
+			{name: "publisher", prefixes: []string{"publish."}, start: startPublisher},
+			{name: "CI gates", prefixes: []string{"gates.", "github."}, start: startGateWatcher},
+			{name: "history pruning", prefixes: []string{"history.retention"}, start: startHistoryPruner},
+			{name: "lease expiry", start: startLeaseExpirer},
+			{name: "spike timeboxes", start: startTimeboxWatcher},
+			{name: "cross-workspace dependencies", prefixes: []string{"workspaces."}, start: startExternalRefresher},
+			{name: "intake", prefixes: []string{"intake."}, start: startIntakeServer},
+		},
+	}
+	if w.file == "" && dbPath != "" {
+		w.file = filepath.Join(filepath.Dir(dbPath), "config.yaml")
+	}
+	w.fileMod = modTime(w.file)
+	w.table, _ = store.GetAllConfig(ctx)
+	return w
+}
+
+// startTasks starts every background task
+func (w *daemonConfigWatcher) startTasks(ctx context.Context) {
+	for _, task := range w.tasks {
+		w.startTask(ctx, task)
+	}
+}
+
+func (w *daemonConfigWatcher) startTask(ctx context.Context, task *daemonTask) {
+	taskCtx, cancel := context.WithCancel(ctx)
+	task.cancel = cancel
+	task.start(taskCtx, w.store, w.log)
+}
+
+// run checks for changes every interval until ctx is cancelled
+func (w *daemonConfigWatcher) run(ctx context.Context, interval time.Duration) {
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, daemonSignals...)
+	defer signal.Stop(sigChan)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case sig := <-sigChan:
+			if isReloadSignal(sig) {
+				w.check(ctx)
+			}
+		case <-ticker.C:
+			w.check(ctx)
+		}
+	}
+}
+
+// check applies whatever changed since the last check
+func (w *daemonConfigWatcher) check(ctx context.Context) {
+	var changes []config.Change
+
+	if mod := modTime(w.file); !mod.Equal(w.fileMod) {
+		w.fileMod = mod
+		fileChanges, err := config.Reload(w.file)
+		if err != nil {
+			w.log.log("Warning: config.yaml not reloaded: %v", err)
+		}
+		changes = append(changes, fileChanges...)
+	}
+
+	table, err := w.store.GetAllConfig(ctx)
+	if err != nil {
+		w.log.log("Warning: config check failed: %v", err)
+	} else {
+		changes = append(changes, diffConfig(w.table, table)...)
+		w.table = table
+	}
+
+	if len(changes) > 0 {
+		w.apply(ctx, changes)
+	}
+}
+
+func (w *daemonConfigWatcher) apply(ctx context.Context, changes []config.Change) {
+	w.settings.mu.Lock()
+	settingsChanged := false
+	for _, c := range changes {
+		w.log.log("Config changed: %s: %s -> %s", c.Key, displayConfigValue(c.Key, c.Old), displayConfigValue(c.Key, c.New))
+		switch c.Key {
+		case "daemon.auto_commit", "daemon.auto_push":
+			if w.localMode {
+				w.log.log("  %s is ignored in local mode", c.Key)
+				continue
+			}
+			if c.Key == "daemon.auto_commit" {
+				w.settings.autoCommit = c.New == "true"
+			} else {
+				w.settings.autoPush = c.New == "true"
+			}
+			settingsChanged = true
+		case "daemon.interval":
+			interval, err := time.ParseDuration(c.New)
+			if err != nil || interval <= 0 {
+				w.log.log("  Warning: ignoring invalid daemon.interval %q", c.New)
+				continue
+			}
+			w.settings.interval = interval
+			settingsChanged = true
+		case "daemon-idle-backoff":
+			settingsChanged = true
+		default:
+			for _, key := range daemonRestartKeys {
+				if c.Key == key {
+					w.log.log("  %s takes effect when the daemon restarts (bd daemon --stop && bd daemon --start)", c.Key)
+				}
+			}
+		}
+	}
+	if settingsChanged {
+		w.settings.generation++
+		if w.server != nil {
+			w.server.SetConfig(w.settings.autoCommit, w.settings.autoPush, w.localMode, w.settings.interval.String(), w.daemonMode)
+		}
+	}
+	w.settings.mu.Unlock()
+
+	for _, task := range w.tasks {
+		for _, c := range changes {
+			if task.matches(c.Key) {
+				w.log.log("Restarting %s", task.name)
+				task.cancel()
+				w.startTask(ctx, task)
+				break
+			}
+		}
+	}
+}
+
+// diffConfig lists the keys whose values differ between two config tables
+func diffConfig(old, new map[string]string) []config.Change {
+	var changes []config.Change
+	for key, value := range new {
+		if old[key] != value {
+			changes = append(changes, config.Change{Key: key, Old: old[key], New: value})
+		}
+	}
+	for key, value := range old {
+		if _, ok := new[key]; !ok {
+			changes = append(changes, config.Change{Key: key, Old: value})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	return changes
+}
+
+// displayConfigValue renders a value for the daemon log, hiding credentials
+func displayConfigValue(key, value string) string {
+	if value == "" {
+		return "(unset)"
+	}
+	name := strings.ToLower(key[strings.LastIndex(key, ".")+1:])
+	if name == "key" || strings.Contains(name, "token") || strings.Contains(name, "secret") || strings.Contains(name, "password") {
+		return redact.Mask(value)
+	}
+	if u, err := url.Parse(value); err == nil && u.User != nil {
+		return u.Redacted()
+	}
+	return value
+}
+
+func modTime(path string) time.Time {
+	if path == "" {
+		return time.Time{}
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
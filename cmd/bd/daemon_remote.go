@@ -0,0 +1,49 @@
+package main
+
+import (
+	"net"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/rpc"
+)
+
+// connectRemoteDaemon points every command at a daemon on another machine
+// (BEADS_DAEMON_ADDR, served by bd daemon --listen). There is no local
+// database to fall back to, so a daemon that can't be reached is fatal.
+func connectRemoteDaemon(addr string) {
+	token := config.GetString("daemon-token")
+	if token == "" {
+		FatalErrorWithHint("BEADS_DAEMON_ADDR is set but no daemon token is configured",
+			"set BEADS_DAEMON_TOKEN to the token the daemon was started with")
+	}
+	tlsConfig, err := rpc.RemoteClientTLS(config.GetString("daemon-tls-ca"))
+	if err != nil {
+		FatalErrorWithHint(err.Error(), "check BEADS_DAEMON_TLS_CA")
+	}
+	client, err := rpc.ConnectRemote(addr, token, tlsConfig, 5*time.Second)
+	if err != nil {
+		FatalErrorWithHint(err.Error(), "check BEADS_DAEMON_ADDR and that the daemon was started with --listen")
+	}
+	client.SetActor(actor)
+	client.SetOperation(&cmdOperation)
+
+	daemonClient = client
+	daemonStatus = DaemonStatus{
+		Mode:           cmdDaemon,
+		Connected:      true,
+		SocketPath:     addr,
+		FallbackReason: FallbackNone,
+		Health:         statusHealthy,
+	}
+}
+
+// listenRemote serves the daemon's gRPC API on addr (bd daemon --listen)
+// with the configured token and TLS certificate
+func listenRemote(server *rpc.Server, addr string) (net.Addr, error) {
+	tlsConfig, err := rpc.RemoteServerTLS(config.GetString("daemon-tls-cert"), config.GetString("daemon-tls-key"))
+	if err != nil {
+		return nil, err
+	}
+	return server.ListenRemote(addr, config.GetString("daemon-token"), tlsConfig)
+}
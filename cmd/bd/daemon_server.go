@@ -87,7 +87,7 @@ func runEventLoop(ctx context.Context, cancel context.CancelFunc, ticker *time.T
 			}
 		case sig := <-sigChan:
 			if isReloadSignal(sig) {
-				log.log("Received reload signal, ignoring (daemon continues running)")
+				log.log("Received reload signal, reloading config")
 				continue
 			}
 			log.log("Received signal %v, shutting down gracefully...", sig)
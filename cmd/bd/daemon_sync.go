@@ -1,7 +1,6 @@
 package main
 
 import (
-	"bufio"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -13,15 +12,26 @@ import (
 
 	"github.com/steveyegge/beads/internal/beads"
 	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/encryption"
+	"github.com/steveyegge/beads/internal/redact"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/util"
 )
 
 // exportToJSONLWithStore exports issues to JSONL using the provided store.
 // If multi-repo mode is configured, routes issues to their respective JSONL files.
 // Otherwise, exports to a single JSONL file.
 func exportToJSONLWithStore(ctx context.Context, store storage.Storage, jsonlPath string) error {
+	// Snapshot dirty issues before reading so that only changes included in
+	// this export are cleared afterwards. The event-driven daemon relies on
+	// the flags to tell writes made outside it from its own export.
+	dirtyIDs, err := store.GetDirtyIssues(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get dirty issues: %w", err)
+	}
+
 	// Try multi-repo export first
 	sqliteStore, ok := store.(*sqlite.SQLiteStorage)
 	if ok {
@@ -31,6 +41,7 @@ func exportToJSONLWithStore(ctx context.Context, store storage.Storage, jsonlPat
 		}
 		if results != nil {
 			// Multi-repo mode active - export succeeded
+			clearDaemonDirtyFlags(ctx, store, dirtyIDs)
 			return nil
 		}
 	}
@@ -89,6 +100,51 @@ func exportToJSONLWithStore(ctx context.Context, store storage.Storage, jsonlPat
 		issue.Comments = comments
 	}
 
+	// Populate attachments for all issues
+	for _, issue := range issues {
+		attachments, err := store.GetAttachments(ctx, issue.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get attachments for %s: %w", issue.ID, err)
+		}
+		issue.Attachments = attachments
+	}
+
+	// Populate work log for all issues
+	for _, issue := range issues {
+		workLog, err := store.GetWorkLog(ctx, issue.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get work log for %s: %w", issue.ID, err)
+		}
+		issue.WorkLog = workLog
+	}
+
+	// Populate aliases for all issues
+	issueIDs := make([]string, len(issues))
+	for i, issue := range issues {
+		issueIDs[i] = issue.ID
+	}
+	allAliases, err := store.GetAliasesForIssues(ctx, issueIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get aliases: %w", err)
+	}
+	for _, issue := range issues {
+		issue.Aliases = allAliases[issue.ID]
+	}
+	allTranslations, err := store.GetTranslationsForIssues(ctx, issueIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get translations: %w", err)
+	}
+	for _, issue := range issues {
+		issue.Translations = allTranslations[issue.ID]
+	}
+	allFields, err := store.GetFieldsForIssues(ctx, issueIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get fields: %w", err)
+	}
+	for _, issue := range issues {
+		issue.Fields = allFields[issue.ID]
+	}
+
 	// Create temp file for atomic write
 	dir := filepath.Dir(jsonlPath)
 	base := filepath.Base(jsonlPath)
@@ -107,9 +163,24 @@ func exportToJSONLWithStore(ctx context.Context, store storage.Storage, jsonlPat
 		}
 	}()
 
-	// Write JSONL
+	// Write JSONL, redacting secrets that are stored in the DB
+	redactor, redactErr := redact.FromConfig()
+	if redactErr != nil {
+		writeErr = redactErr
+		return writeErr
+	}
+	fieldCipher, cipherErr := encryption.FromConfig(dir)
+	if cipherErr != nil {
+		writeErr = cipherErr
+		return writeErr
+	}
 	for _, issue := range issues {
-		data, marshalErr := json.Marshal(issue)
+		exported, exportErr := fieldCipher.ForExport(redactor.ForExport(issue))
+		if exportErr != nil {
+			writeErr = exportErr
+			return writeErr
+		}
+		data, marshalErr := json.Marshal(exported)
 		if marshalErr != nil {
 			writeErr = fmt.Errorf("failed to marshal issue %s: %w", issue.ID, marshalErr)
 			return writeErr
@@ -136,9 +207,25 @@ func exportToJSONLWithStore(ctx context.Context, store storage.Storage, jsonlPat
 		return writeErr
 	}
 
+	if err := syncJSONLShards(jsonlPath); err != nil {
+		return fmt.Errorf("failed to write JSONL shards: %w", err)
+	}
+
+	clearDaemonDirtyFlags(ctx, store, dirtyIDs)
 	return nil
 }
 
+// clearDaemonDirtyFlags clears the dirty flags snapshotted before an export.
+// Failure is non-fatal: the next export simply includes the issues again.
+func clearDaemonDirtyFlags(ctx context.Context, store storage.Storage, ids []string) {
+	if len(ids) == 0 {
+		return
+	}
+	if err := store.ClearDirtyIssuesByID(ctx, ids); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to clear dirty flags: %v\n", err)
+	}
+}
+
 // importToJSONLWithStore imports issues from JSONL using the provided store
 func importToJSONLWithStore(ctx context.Context, store storage.Storage, jsonlPath string) error {
 	// Try multi-repo import first
@@ -155,6 +242,8 @@ func importToJSONLWithStore(ctx context.Context, store storage.Storage, jsonlPat
 	}
 
 	// Single-repo mode - use existing logic
+	assembleJSONLShards(jsonlPath)
+
 	// Read JSONL file
 	file, err := os.Open(jsonlPath) // #nosec G304 - controlled path from config
 	if err != nil {
@@ -164,7 +253,7 @@ func importToJSONLWithStore(ctx context.Context, store storage.Storage, jsonlPat
 
 	// Parse all issues
 	var issues []*types.Issue
-	scanner := bufio.NewScanner(file)
+	scanner := util.NewJSONLScanner(file)
 	lineNum := 0
 
 	for scanner.Scan() {
@@ -393,6 +482,8 @@ func performExport(ctx context.Context, store storage.Storage, autoCommit, autoP
 	return func() {
 		exportCtx, exportCancel := context.WithTimeout(ctx, 30*time.Second)
 		defer exportCancel()
+		run := startSyncRun("export")
+		defer run.finish()
 
 		mode := "export"
 		if skipGit {
@@ -415,12 +506,22 @@ func performExport(ctx context.Context, store storage.Storage, autoCommit, autoP
 			} else {
 				log.log("Skipping %s (locked by %s)", mode, holder)
 			}
+			run.skipped()
 			return
 		}
 		if holder != "" {
 			log.log("Removed stale lock (%s), proceeding", holder)
 		}
 
+		// Respect bd daemon pause
+		pause := daemonPauseFor(beadsDir, log)
+		if pause.blocks(pauseScopeAll) {
+			log.log("Skipping %s (%s)", mode, pause)
+			run.skipped()
+			return
+		}
+		autoCommit, autoPush, skipGit := pause.restrict(autoCommit, autoPush, skipGit)
+
 		// Pre-export validation
 		if err := validatePreExport(exportCtx, store, jsonlPath); err != nil {
 			log.log("Pre-export validation failed: %v", err)
@@ -428,7 +529,10 @@ func performExport(ctx context.Context, store storage.Storage, autoCommit, autoP
 		}
 
 		// Export to JSONL
-		if err := exportToJSONLWithStore(exportCtx, store, jsonlPath); err != nil {
+		exportStart := time.Now()
+		err = exportToJSONLWithStore(exportCtx, store, jsonlPath)
+		observeDBLatency("export", exportStart)
+		if err != nil {
 			log.log("Export failed: %v", err)
 			return
 		}
@@ -462,6 +566,9 @@ func performExport(ctx context.Context, store storage.Storage, autoCommit, autoP
 			// mean the local state is authoritative and should not be merged with worktree.
 			// This is critical for delete mutations to be properly reflected in the sync branch.
 			committed, err := syncBranchCommitAndPushWithOptions(exportCtx, store, autoPush, true, log)
+			if committed || err != nil {
+				recordGitOp("sync_branch_commit", err)
+			}
 			if err != nil {
 				log.log("Sync branch commit failed: %v", err)
 				return
@@ -477,7 +584,9 @@ func performExport(ctx context.Context, store storage.Storage, autoCommit, autoP
 
 				if hasChanges {
 					message := fmt.Sprintf("bd daemon export: %s", time.Now().Format("2006-01-02 15:04:05"))
-					if err := gitCommit(exportCtx, jsonlPath, message); err != nil {
+					err := gitCommit(exportCtx, jsonlPath, message)
+					recordGitOp("commit", err)
+					if err != nil {
 						log.log("Commit failed: %v", err)
 						return
 					}
@@ -485,7 +594,9 @@ func performExport(ctx context.Context, store storage.Storage, autoCommit, autoP
 
 					// Auto-push if enabled
 					if autoPush {
-						if err := gitPush(exportCtx); err != nil {
+						err := daemonPushWithRetry(exportCtx, store, jsonlPath, log)
+						recordGitOp("push", err)
+						if err != nil {
 							log.log("Push failed: %v", err)
 							return
 						}
@@ -495,6 +606,7 @@ func performExport(ctx context.Context, store storage.Storage, autoCommit, autoP
 			}
 		}
 
+		run.succeeded()
 		if skipGit {
 			log.log("Local export complete")
 		} else {
@@ -521,6 +633,8 @@ func performAutoImport(ctx context.Context, store storage.Storage, skipGit bool,
 	return func() {
 		importCtx, importCancel := context.WithTimeout(ctx, 1*time.Minute)
 		defer importCancel()
+		run := startSyncRun("import")
+		defer run.finish()
 
 		mode := "auto-import"
 		if skipGit {
@@ -543,18 +657,30 @@ func performAutoImport(ctx context.Context, store storage.Storage, skipGit bool,
 			} else {
 				log.log("Skipping %s (locked by %s)", mode, holder)
 			}
+			run.skipped()
 			return
 		}
 		if holder != "" {
 			log.log("Removed stale lock (%s), proceeding", holder)
 		}
 
+		// JSONL changes during a pause come from the user's git work, not
+		// from a finished pull; import them after bd daemon resume
+		if pause := daemonPauseFor(beadsDir, log); pause.blocks(pauseScopeSync) {
+			log.log("Skipping %s (%s)", mode, pause)
+			run.skipped()
+			return
+		}
+
 		// Check JSONL content hash to avoid redundant imports
 		// Use content-based check (not mtime) to avoid git resurrection bug (bd-khnb)
+		// Shards are assembled first so changes to them count as JSONL changes
+		assembleJSONLShards(jsonlPath)
 		// Use getRepoKeyForPath for multi-repo support (bd-ar2.10, bd-ar2.11)
 		repoKey := getRepoKeyForPath(jsonlPath)
 		if !hasJSONLChanged(importCtx, store, jsonlPath, repoKey) {
 			log.log("Skipping %s: JSONL content unchanged", mode)
+			run.skipped()
 			return
 		}
 		log.log("JSONL content changed, proceeding with %s...", mode)
@@ -575,6 +701,9 @@ func performAutoImport(ctx context.Context, store storage.Storage, skipGit bool,
 
 			// Try sync branch first
 			pulled, err := syncBranchPull(importCtx, store, log)
+			if pulled || err != nil {
+				recordGitOp("sync_branch_pull", err)
+			}
 			if err != nil {
 				log.log("Sync branch pull failed: %v", err)
 				return
@@ -582,7 +711,9 @@ func performAutoImport(ctx context.Context, store storage.Storage, skipGit bool,
 
 			// If sync branch not configured, use regular pull
 			if !pulled {
-				if err := gitPull(importCtx); err != nil {
+				err := gitPull(importCtx)
+				recordGitOp("pull", err)
+				if err != nil {
 					log.log("Pull failed: %v", err)
 					return
 				}
@@ -598,7 +729,10 @@ func performAutoImport(ctx context.Context, store storage.Storage, skipGit bool,
 		}
 
 		// Import from JSONL
-		if err := importToJSONLWithStore(importCtx, store, jsonlPath); err != nil {
+		importStart := time.Now()
+		err = importToJSONLWithStore(importCtx, store, jsonlPath)
+		observeDBLatency("import", importStart)
+		if err != nil {
 			log.log("Import failed: %v", err)
 			return
 		}
@@ -616,6 +750,7 @@ func performAutoImport(ctx context.Context, store storage.Storage, skipGit bool,
 			return
 		}
 
+		run.succeeded()
 		if skipGit {
 			log.log("Local auto-import complete")
 		} else {
@@ -642,6 +777,8 @@ func performSync(ctx context.Context, store storage.Storage, autoCommit, autoPus
 	return func() {
 		syncCtx, syncCancel := context.WithTimeout(ctx, 2*time.Minute)
 		defer syncCancel()
+		run := startSyncRun("sync")
+		defer run.finish()
 
 		mode := "sync cycle"
 		if skipGit {
@@ -667,12 +804,22 @@ func performSync(ctx context.Context, store storage.Storage, autoCommit, autoPus
 			} else {
 				log.log("Skipping database (locked by %s)", holder)
 			}
+			run.skipped()
 			return
 		}
 		if holder != "" {
 			log.log("Removed stale lock (%s), proceeding with %s", holder, mode)
 		}
 
+		// Respect bd daemon pause
+		pause := daemonPauseFor(beadsDir, log)
+		if pause.blocks(pauseScopeAll) {
+			log.log("Skipping %s (%s)", mode, pause)
+			run.skipped()
+			return
+		}
+		autoCommit, autoPush, skipGit := pause.restrict(autoCommit, autoPush, skipGit)
+
 		// Integrity check: validate before export
 		if err := validatePreExport(syncCtx, store, jsonlPath); err != nil {
 			log.log("Pre-export validation failed: %v", err)
@@ -692,7 +839,10 @@ func performSync(ctx context.Context, store storage.Storage, autoCommit, autoPus
 			log.log("Found %d orphaned dependencies: %v", len(orphaned), orphaned)
 		}
 
-		if err := exportToJSONLWithStore(syncCtx, store, jsonlPath); err != nil {
+		exportStart := time.Now()
+		err = exportToJSONLWithStore(syncCtx, store, jsonlPath)
+		observeDBLatency("export", exportStart)
+		if err != nil {
 			log.log("Export failed: %v", err)
 			return
 		}
@@ -721,6 +871,7 @@ func performSync(ctx context.Context, store storage.Storage, autoCommit, autoPus
 		// Local-only sync is export-only since there's no remote to sync with
 		if skipGit {
 			log.log("Local %s complete", mode)
+			run.succeeded()
 			return
 		}
 
@@ -749,6 +900,9 @@ func performSync(ctx context.Context, store storage.Storage, autoCommit, autoPus
 		if autoCommit {
 			// Try sync branch commit first
 			committed, err := syncBranchCommitAndPush(syncCtx, store, autoPush, log)
+			if committed || err != nil {
+				recordGitOp("sync_branch_commit", err)
+			}
 			if err != nil {
 				log.log("Sync branch commit failed: %v", err)
 				return
@@ -764,7 +918,9 @@ func performSync(ctx context.Context, store storage.Storage, autoCommit, autoPus
 
 				if hasChanges {
 					message := fmt.Sprintf("bd daemon sync: %s", time.Now().Format("2006-01-02 15:04:05"))
-					if err := gitCommit(syncCtx, jsonlPath, message); err != nil {
+					err := gitCommit(syncCtx, jsonlPath, message)
+					recordGitOp("commit", err)
+					if err != nil {
 						log.log("Commit failed: %v", err)
 						return
 					}
@@ -775,6 +931,9 @@ func performSync(ctx context.Context, store storage.Storage, autoCommit, autoPus
 
 		// Pull (try sync branch first)
 		pulled, err := syncBranchPull(syncCtx, store, log)
+		if pulled || err != nil {
+			recordGitOp("sync_branch_pull", err)
+		}
 		if err != nil {
 			log.log("Sync branch pull failed: %v", err)
 			return
@@ -782,7 +941,9 @@ func performSync(ctx context.Context, store storage.Storage, autoCommit, autoPus
 
 		// If sync branch not configured, use regular pull
 		if !pulled {
-			if err := gitPull(syncCtx); err != nil {
+			err := gitPull(syncCtx)
+			recordGitOp("pull", err)
+			if err != nil {
 				log.log("Pull failed: %v", err)
 				return
 			}
@@ -815,7 +976,10 @@ func performSync(ctx context.Context, store storage.Storage, autoCommit, autoPus
 			}
 		}
 
-		if err := importToJSONLWithStore(syncCtx, store, jsonlPath); err != nil {
+		importStart := time.Now()
+		err = importToJSONLWithStore(syncCtx, store, jsonlPath)
+		observeDBLatency("import", importStart)
+		if err != nil {
 			log.log("Import failed: %v", err)
 			return
 		}
@@ -870,13 +1034,16 @@ func performSync(ctx context.Context, store storage.Storage, autoCommit, autoPus
 		}
 
 		if autoPush && autoCommit {
-			if err := gitPush(syncCtx); err != nil {
+			err := daemonPushWithRetry(syncCtx, store, jsonlPath, log)
+			recordGitOp("push", err)
+			if err != nil {
 				log.log("Push failed: %v", err)
 				return
 			}
 			log.log("Pushed to remote")
 		}
 
+		run.succeeded()
 		log.log("Sync cycle complete")
 	}
 }
@@ -44,14 +44,15 @@ func syncBranchCommitAndPushWithOptions(ctx context.Context, store storage.Stora
 	
 	log.log("Using sync branch: %s", syncBranch)
 	
-	// Get main repo root (for worktrees, this is the main repo, not worktree)
-	repoRoot, err := git.GetMainRepoRoot()
+	// Get the sync repo root (for worktrees, the main repo, not the worktree;
+	// for submodules, the submodule; or sync.git_dir)
+	repoRoot, err := syncRepoRoot(ctx)
 	if err != nil {
 		return false, fmt.Errorf("failed to get main repo root: %w", err)
 	}
 	
-	// Use worktree-aware git directory detection
-	gitDir, err := git.GetGitDir()
+	// Use worktree- and submodule-aware git directory detection
+	gitDir, err := syncGitDir(ctx, repoRoot)
 	if err != nil {
 		return false, fmt.Errorf("not a git repository: %w", err)
 	}
@@ -122,7 +123,15 @@ func syncBranchCommitAndPushWithOptions(ctx context.Context, store storage.Stora
 	
 	// Push if enabled
 	if autoPush {
-		if err := gitPushFromWorktree(ctx, worktreePath, syncBranch); err != nil {
+		push := func() error { return gitPushFromWorktree(ctx, worktreePath, syncBranch) }
+		integrate := func() error {
+			remote, _, err := gitUpstream(ctx, worktreePath)
+			if err != nil {
+				return err
+			}
+			return integrateRemote(ctx, worktreePath, remote, syncBranch, jsonlRelPath, log)
+		}
+		if err := pushWithRetry(ctx, log, push, integrate); err != nil {
 			return false, fmt.Errorf("failed to push from worktree: %w", err)
 		}
 		log.log("Pushed sync branch %s to remote", syncBranch)
@@ -222,14 +231,15 @@ func syncBranchPull(ctx context.Context, store storage.Storage, log daemonLogger
 		return false, nil
 	}
 	
-	// Get main repo root (for worktrees, this is the main repo, not worktree)
-	repoRoot, err := git.GetMainRepoRoot()
+	// Get the sync repo root (for worktrees, the main repo, not the worktree;
+	// for submodules, the submodule; or sync.git_dir)
+	repoRoot, err := syncRepoRoot(ctx)
 	if err != nil {
 		return false, fmt.Errorf("failed to get main repo root: %w", err)
 	}
 	
-	// Use worktree-aware git directory detection
-	gitDir, err := git.GetGitDir()
+	// Use worktree- and submodule-aware git directory detection
+	gitDir, err := syncGitDir(ctx, repoRoot)
 	if err != nil {
 		return false, fmt.Errorf("not a git repository: %w", err)
 	}
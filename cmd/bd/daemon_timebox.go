@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"github.com/steveyegge/beads/internal/hooks"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+)
+
+// timeboxCheckInterval is how often the daemon looks for elapsed spikes
+const timeboxCheckInterval = time.Minute
+
+// startTimeboxWatcher asks for the outcome of spikes whose timebox ran out
+// (see bd spike), every minute until ctx is cancelled. Without a daemon,
+// bd spike check does the same once.
+func startTimeboxWatcher(ctx context.Context, store storage.Storage, log daemonLogger) {
+	s, ok := store.(*sqlite.SQLiteStorage)
+	if !ok {
+		return
+	}
+	runner := hooks.NewRunner(filepath.Join(filepath.Dir(s.Path()), "hooks"))
+
+	go func() {
+		ticker := time.NewTicker(timeboxCheckInterval)
+		defer ticker.Stop()
+		for {
+			if _, err := promptElapsedTimeboxes(ctx, s, runner, log.log); err != nil {
+				log.log("Timebox check failed: %v", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
@@ -14,6 +14,7 @@ import (
 )
 
 // FileWatcher monitors JSONL and git ref changes using filesystem events or polling.
+// With WatchDatabase it also reports writes to the SQLite database and its WAL.
 type FileWatcher struct {
 	watcher        *fsnotify.Watcher
 	debouncer      *Debouncer
@@ -28,6 +29,10 @@ type FileWatcher struct {
 	gitHeadPath    string
 	lastHeadModTime time.Time
 	lastHeadExists bool
+	dbPath         string     // SQLite database; "" unless WatchDatabase was called
+	dbDebouncer    *Debouncer
+	lastDBModTime  time.Time
+	lastDBSize     int64
 	cancel         context.CancelFunc
 	wg             sync.WaitGroup // Track goroutines for graceful shutdown (bd-jo38)
 }
@@ -120,6 +125,43 @@ func NewFileWatcher(jsonlPath string, onChanged func()) (*FileWatcher, error) {
 	return fw, nil
 }
 
+// WatchDatabase also reports writes to the SQLite database at dbPath and its
+// -wal file. These catch changes made by processes that bypass the daemon,
+// such as bd --no-daemon. onChanged is debounced separately from JSONL and
+// git changes. Must be called before Start.
+func (fw *FileWatcher) WatchDatabase(dbPath string, debounce time.Duration, onChanged func()) {
+	fw.dbPath = dbPath
+	fw.dbDebouncer = NewDebouncer(debounce, onChanged)
+	fw.lastDBModTime, fw.lastDBSize = databaseFileState(dbPath)
+
+	// The database normally sits next to the JSONL, whose directory is
+	// already watched; watching the directory also sees the WAL appear
+	if fw.watcher != nil && filepath.Dir(dbPath) != fw.parentDir {
+		_ = fw.watcher.Add(filepath.Dir(dbPath)) // Best effort
+	}
+}
+
+// isDatabaseFile reports whether path is the watched database or its WAL
+func (fw *FileWatcher) isDatabaseFile(path string) bool {
+	return fw.dbPath != "" && (path == fw.dbPath || path == fw.dbPath+"-wal")
+}
+
+// databaseFileState returns the latest modification time and combined size of
+// the database and its WAL, for change detection when polling
+func databaseFileState(dbPath string) (time.Time, int64) {
+	var modTime time.Time
+	var size int64
+	for _, path := range []string{dbPath, dbPath + "-wal"} {
+		if stat, err := os.Stat(path); err == nil {
+			if stat.ModTime().After(modTime) {
+				modTime = stat.ModTime()
+			}
+			size += stat.Size()
+		}
+	}
+	return modTime, size
+}
+
 // Start begins monitoring filesystem events or polling.
 // Runs in background goroutine until context is canceled.
 // Should only be called once per FileWatcher instance.
@@ -170,6 +212,12 @@ func (fw *FileWatcher) Start(ctx context.Context, log daemonLogger) {
 					continue
 				}
 
+				// Handle database/WAL writes (not logged: every write touches the WAL)
+				if fw.isDatabaseFile(event.Name) && event.Op&(fsnotify.Write|fsnotify.Create) != 0 {
+					fw.dbDebouncer.Trigger()
+					continue
+				}
+
 				// Handle .git/HEAD changes (branch switches)
 				if event.Name == fw.gitHeadPath && event.Op&(fsnotify.Write|fsnotify.Create) != 0 {
 					log.log("Git HEAD change detected: %s", event.Name)
@@ -303,6 +351,16 @@ func (fw *FileWatcher) startPolling(ctx context.Context, log daemonLogger) {
 					fw.debouncer.Trigger()
 				}
 
+				// Check the database and WAL
+				if fw.dbPath != "" {
+					modTime, size := databaseFileState(fw.dbPath)
+					if !modTime.Equal(fw.lastDBModTime) || size != fw.lastDBSize {
+						fw.lastDBModTime = modTime
+						fw.lastDBSize = size
+						fw.dbDebouncer.Trigger()
+					}
+				}
+
 			case <-ctx.Done():
 				return
 			}
@@ -319,6 +377,9 @@ func (fw *FileWatcher) Close() error {
 	// Wait for goroutines to finish before cleanup (bd-jo38)
 	fw.wg.Wait()
 	fw.debouncer.Cancel()
+	if fw.dbDebouncer != nil {
+		fw.dbDebouncer.Cancel()
+	}
 	if fw.watcher != nil {
 		return fw.watcher.Close()
 	}
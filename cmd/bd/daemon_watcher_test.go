@@ -322,6 +322,49 @@ func TestFileWatcher_PollingFallback(t *testing.T) {
 	}
 }
 
+func TestFileWatcher_DatabaseChangeDetection(t *testing.T) {
+	t.Parallel()
+	for _, polling := range []bool{false, true} {
+		dir := t.TempDir()
+		jsonlPath := filepath.Join(dir, "test.jsonl")
+		dbPath := filepath.Join(dir, "beads.db")
+		for _, path := range []string{jsonlPath, dbPath} {
+			if err := os.WriteFile(path, []byte("{}"), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		var jsonlCalls, dbCalls int32
+		fw, err := NewFileWatcher(jsonlPath, func() { atomic.AddInt32(&jsonlCalls, 1) })
+		if err != nil {
+			t.Fatal(err)
+		}
+		if polling {
+			fw.pollingMode = true
+			fw.pollInterval = 50 * time.Millisecond
+		}
+		fw.WatchDatabase(dbPath, 10*time.Millisecond, func() { atomic.AddInt32(&dbCalls, 1) })
+
+		ctx, cancel := context.WithCancel(context.Background())
+		fw.Start(ctx, newMockLogger())
+		time.Sleep(10 * time.Millisecond)
+
+		// A write from another process lands in the WAL first
+		if err := os.WriteFile(dbPath+"-wal", []byte("frame"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		waitFor(t, 500*time.Millisecond, 5*time.Millisecond, func() bool {
+			return atomic.LoadInt32(&dbCalls) >= 1
+		})
+		if n := atomic.LoadInt32(&jsonlCalls); n != 0 {
+			t.Errorf("polling=%v: database write triggered the JSONL callback %d times", polling, n)
+		}
+
+		cancel()
+		_ = fw.Close()
+	}
+}
+
 func TestFileWatcher_PollingFileDisappearance(t *testing.T) {
 	t.Parallel()
 	dir := t.TempDir()
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/webhook"
+)
+
+// startReadyWebhooks starts notifying the configured readiness webhooks
+// (ready_webhook.<name>.*) about issues that become ready. It runs until
+// ctx is cancelled.
+func startReadyWebhooks(ctx context.Context, store storage.Storage, log daemonLogger) {
+	config, err := store.GetAllConfig(ctx)
+	if err != nil {
+		log.log("Warning: ready webhooks disabled: %v", err)
+		return
+	}
+	subs, err := webhook.ParseReadySubscriptions(config)
+	if err != nil {
+		log.log("Warning: ready webhooks disabled: %v", err)
+		return
+	}
+	if len(subs) == 0 {
+		return
+	}
+	notifier := &webhook.ReadyNotifier{
+		Subscriptions: subs,
+		Source:        store,
+		Logf:          log.log,
+	}
+	log.log("Notifying %d ready webhook(s)", len(subs))
+	go notifier.Run(ctx)
+}
+
+// startEventWebhooks starts sending issue lifecycle events to the configured
+// webhook endpoints (webhook.url, webhook.<name>.*). It runs until ctx is
+// cancelled.
+func startEventWebhooks(ctx context.Context, store storage.Storage, log daemonLogger) {
+	source, ok := store.(webhook.EventSource)
+	if !ok {
+		return
+	}
+	config, err := store.GetAllConfig(ctx)
+	if err != nil {
+		log.log("Warning: webhooks disabled: %v", err)
+		return
+	}
+	endpoints, err := webhook.ParseEndpoints(config)
+	if err != nil {
+		log.log("Warning: webhooks disabled: %v", err)
+		return
+	}
+	if len(endpoints) == 0 {
+		return
+	}
+	dispatcher := &webhook.Dispatcher{
+		Endpoints: endpoints,
+		Source:    source,
+		Logf:      log.log,
+	}
+	log.log("Sending events to %d webhook(s)", len(endpoints))
+	go dispatcher.Run(ctx)
+}
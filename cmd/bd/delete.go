@@ -15,6 +15,7 @@ import (
 	"github.com/steveyegge/beads/internal/rpc"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/util"
 )
 
 // deleteViaDaemon uses the RPC daemon to delete issues
@@ -374,7 +375,7 @@ func removeIssueFromJSONL(issueID string) error {
 		return fmt.Errorf("failed to open JSONL: %w", err)
 	}
 	var issues []*types.Issue
-	scanner := bufio.NewScanner(f)
+	scanner := util.NewJSONLScanner(f)
 	for scanner.Scan() {
 		line := scanner.Text()
 		if strings.TrimSpace(line) == "" {
@@ -0,0 +1,311 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/cmd/bd/doctor"
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/configfile"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// demoBacklog is the sample project 'bd demo' creates: two epics with a mix
+// of closed, in-progress, ready and blocked work, so every walkthrough step
+// has something interesting to show
+var demoBacklog = &workflowTemplate{
+	Name:        "demo",
+	Description: "Sample web app backlog for the bd walkthrough",
+	Issues: []workflowIssue{
+		{
+			Key:         "accounts",
+			Title:       "User accounts",
+			Description: "Let people sign up, log in and recover their password.",
+			Type:        types.TypeEpic,
+			Priority:    1,
+			Labels:      []string{"backend"},
+		},
+		{
+			Key:         "schema",
+			Title:       "Design the users table",
+			Description: "Columns for email, password hash and verification state.",
+			Type:        types.TypeTask,
+			Priority:    1,
+			Status:      types.StatusClosed,
+			Assignee:    "alice",
+			Labels:      []string{"backend", "db"},
+			Parent:      "accounts",
+		},
+		{
+			Key:         "signup",
+			Title:       "Implement the signup endpoint",
+			Description: "POST /signup validates the email, hashes the password and stores the user.",
+			Type:        types.TypeFeature,
+			Priority:    1,
+			Status:      types.StatusInProgress,
+			Assignee:    "alice",
+			Labels:      []string{"backend"},
+			Parent:      "accounts",
+			BlockedBy:   []string{"schema"},
+		},
+		{
+			Key:         "reset",
+			Title:       "Password reset emails",
+			Description: "Send a signed, expiring reset link. Needs accounts to exist first.",
+			Type:        types.TypeFeature,
+			Priority:    2,
+			Labels:      []string{"backend", "email"},
+			Parent:      "accounts",
+			BlockedBy:   []string{"signup"},
+		},
+		{
+			Key:         "ratelimit",
+			Title:       "Rate-limit login attempts",
+			Description: "Lock out an IP after repeated failures to slow down credential stuffing.",
+			Type:        types.TypeTask,
+			Priority:    2,
+			Labels:      []string{"backend", "security"},
+			Parent:      "accounts",
+			BlockedBy:   []string{"signup"},
+		},
+		{
+			Key:         "dashboard",
+			Title:       "Analytics dashboard",
+			Description: "Give the team a daily view of sign-ups and activity.",
+			Type:        types.TypeEpic,
+			Priority:    2,
+			Labels:      []string{"frontend"},
+		},
+		{
+			Key:         "charts",
+			Title:       "Pick a charting library",
+			Description: "Compare two or three options for bundle size and accessibility.",
+			Type:        types.TypeTask,
+			Priority:    2,
+			Labels:      []string{"frontend", "research"},
+			Parent:      "dashboard",
+		},
+		{
+			Key:         "dau",
+			Title:       "Daily active users chart",
+			Description: "Plot DAU for the last 30 days. Needs the charting library and real sign-ups.",
+			Type:        types.TypeFeature,
+			Priority:    2,
+			Labels:      []string{"frontend"},
+			Parent:      "dashboard",
+			BlockedBy:   []string{"charts", "signup"},
+		},
+		{
+			Key:         "csv",
+			Title:       "Export dashboard data as CSV",
+			Description: "Nice to have once the charts exist.",
+			Type:        types.TypeFeature,
+			Priority:    3,
+			Labels:      []string{"frontend"},
+			Parent:      "dashboard",
+			BlockedBy:   []string{"dau"},
+		},
+		{
+			Key:         "plus",
+			Title:       "Crash when an email address contains a plus sign",
+			Description: "alice+test@example.com returns a 500 from the signup form.",
+			Type:        types.TypeBug,
+			Priority:    0,
+			Labels:      []string{"backend"},
+		},
+		{
+			Key:         "toolchain",
+			Title:       "Upgrade the Go toolchain",
+			Description: "Routine chore with no dependencies, ready whenever someone has a spare hour.",
+			Type:        types.TypeChore,
+			Priority:    3,
+			Labels:      []string{"infra"},
+		},
+	},
+}
+
+var demoCmd = &cobra.Command{
+	Use:   "demo [dir]",
+	Short: "Create a sandbox project with a sample backlog and a guided tour",
+	Long: `Create a self-contained sandbox project for trying bd without touching
+your own repositories.
+
+The sandbox (default: ./beads-demo) is a fresh git repository with a beads
+database holding a realistic backlog: two epics, a closed task, work in
+progress, blocked issues and ready work. bd demo then prints a walkthrough of
+the commands worth trying next, using the real issue IDs.
+
+Delete the directory when you are done; nothing outside it is changed.
+
+Examples:
+  bd demo                 # Create ./beads-demo
+  bd demo /tmp/try-beads  # Create the sandbox somewhere else`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		dir := "beads-demo"
+		if len(args) > 0 {
+			dir = args[0]
+		}
+		prefix, _ := cmd.Flags().GetString("prefix")
+
+		absDir, err := filepath.Abs(dir)
+		if err != nil {
+			FatalError("failed to resolve %s: %v", dir, err)
+		}
+		ids, err := createDemoProject(rootCtx, absDir, prefix)
+		if err != nil {
+			FatalError("%v", err)
+		}
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{
+				"path":   absDir,
+				"prefix": prefix,
+				"issues": ids,
+			})
+			return
+		}
+		printDemoWalkthrough(dir, ids)
+	},
+}
+
+// createDemoProject creates the sandbox in dir and returns the demo issue IDs
+// keyed by their demoBacklog key. dir must not exist or be empty.
+func createDemoProject(ctx context.Context, dir, prefix string) (map[string]string, error) {
+	if entries, err := os.ReadDir(dir); err == nil && len(entries) > 0 {
+		return nil, fmt.Errorf("%s already exists and is not empty; pick another directory", dir)
+	}
+	beadsDir := filepath.Join(dir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0750); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", beadsDir, err)
+	}
+
+	// A git repository makes bd sync and the hooks behave as in a real project
+	gitInit := exec.Command("git", "init", "-q")
+	gitInit.Dir = dir
+	if err := gitInit.Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: git init failed (%v); the demo works without git but bd sync will not\n", err)
+	}
+
+	cfg := configfile.DefaultConfig()
+	if err := cfg.Save(beadsDir); err != nil {
+		return nil, fmt.Errorf("failed to write metadata.json: %w", err)
+	}
+	if err := createConfigYaml(beadsDir, false); err != nil {
+		return nil, fmt.Errorf("failed to write config.yaml: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(beadsDir, ".gitignore"), []byte(doctor.GitignoreTemplate), 0600); err != nil {
+		return nil, fmt.Errorf("failed to write .gitignore: %w", err)
+	}
+
+	dbFile := cfg.DatabasePath(beadsDir)
+	s, err := sqlite.New(ctx, dbFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create database: %w", err)
+	}
+	defer func() { _ = s.Close() }()
+
+	if err := s.SetConfig(ctx, "issue_prefix", prefix); err != nil {
+		return nil, fmt.Errorf("failed to set issue prefix: %w", err)
+	}
+	if err := s.SetMetadata(ctx, "bd_version", Version); err != nil {
+		return nil, fmt.Errorf("failed to store version metadata: %w", err)
+	}
+	// Without a repository fingerprint the daemon refuses the database as legacy
+	if err := setDemoFingerprint(ctx, s, dir); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v; the daemon will not start in the demo\n", err)
+	}
+
+	created, err := applyWorkflowTemplate(ctx, s, demoBacklog, true, "demo")
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[string]string, len(created))
+	for i, issue := range created {
+		ids[demoBacklog.Issues[i].Key] = issue.ID
+	}
+
+	exported, err := writeJSONLAtomic(beads.FindJSONLPath(dbFile), created)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export demo issues: %w", err)
+	}
+	if err := s.ClearDirtyIssuesByID(ctx, exported); err != nil {
+		return nil, fmt.Errorf("failed to clear dirty flags: %w", err)
+	}
+	return ids, nil
+}
+
+// setDemoFingerprint stores repo_id and clone_id like bd init does. The
+// fingerprint helpers inspect the git repository in the working directory,
+// so they run from dir.
+func setDemoFingerprint(ctx context.Context, s *sqlite.SQLiteStorage, dir string) error {
+	origDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		return fmt.Errorf("failed to enter %s: %w", dir, err)
+	}
+	defer func() { _ = os.Chdir(origDir) }()
+
+	repoID, err := beads.ComputeRepoID()
+	if err != nil {
+		return fmt.Errorf("could not compute repository ID: %w", err)
+	}
+	if err := s.SetMetadata(ctx, "repo_id", repoID); err != nil {
+		return fmt.Errorf("failed to set repo_id: %w", err)
+	}
+	cloneID, err := beads.GetCloneID()
+	if err != nil {
+		return fmt.Errorf("could not compute clone ID: %w", err)
+	}
+	if err := s.SetMetadata(ctx, "clone_id", cloneID); err != nil {
+		return fmt.Errorf("failed to set clone_id: %w", err)
+	}
+	return nil
+}
+
+// printDemoWalkthrough prints the guided tour, one step per command
+func printDemoWalkthrough(dir string, ids map[string]string) {
+	green := color.New(color.FgGreen).SprintFunc()
+	cyan := color.New(color.FgCyan).SprintFunc()
+	bold := color.New(color.Bold).SprintFunc()
+
+	fmt.Printf("\n%s Demo project created in %s with %d issues\n\n", green("✓"), dir, len(ids))
+	fmt.Printf("%s\n\n", bold("WALKTHROUGH"))
+
+	steps := []struct {
+		command string
+		explain string
+	}{
+		{"cd " + dir, "Every bd command below runs inside the sandbox"},
+		{"bd list", "The whole backlog, most urgent first"},
+		{"bd ready", "Unblocked work, led by the P0 bug"},
+		{"bd blocked", "Issues waiting on something else, and what they wait for"},
+		{"bd show " + ids["signup"], "Details of the in-progress signup work and what depends on it"},
+		{"bd dep tree " + ids["csv"], "The chain of blockers behind the CSV export"},
+		{"bd epic status", "Progress of each epic from its children"},
+		{"bd update " + ids["plus"] + " --status in_progress --assignee you", "Claim the P0 bug"},
+		{"bd close " + ids["signup"] + " --reason \"Shipped\"", "Finish signup..."},
+		{"bd ready", "...and watch password reset and rate limiting become ready"},
+		{"bd create \"Add a logout button\" -t task -p 2 --deps blocks:" + ids["signup"], "File new work linked to existing issues"},
+		{"bd stats", "Counts by status across the project"},
+	}
+	for i, step := range steps {
+		fmt.Printf("  %2d. %s\n      %s\n", i+1, cyan(step.command), step.explain)
+	}
+
+	fmt.Printf("\nEvery command accepts %s for scripts and agents. Run %s for the full guide.\n", cyan("--json"), cyan("bd quickstart"))
+	fmt.Printf("Delete %s when you are done; nothing outside it was changed.\n\n", dir)
+}
+
+func init() {
+	demoCmd.Flags().String("prefix", "demo", "Issue prefix for the demo project")
+	rootCmd.AddCommand(demoCmd)
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestCreateDemoProject(t *testing.T) {
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), "beads-demo")
+
+	ids, err := createDemoProject(ctx, dir, "demo")
+	if err != nil {
+		t.Fatalf("createDemoProject: %v", err)
+	}
+	if len(ids) != len(demoBacklog.Issues) {
+		t.Fatalf("got %d IDs, want %d", len(ids), len(demoBacklog.Issues))
+	}
+
+	for _, name := range []string{"metadata.json", "config.yaml", ".gitignore", "issues.jsonl"} {
+		if _, err := os.Stat(filepath.Join(dir, ".beads", name)); err != nil {
+			t.Errorf("missing .beads/%s: %v", name, err)
+		}
+	}
+	data, _ := os.ReadFile(filepath.Join(dir, ".beads", "issues.jsonl"))
+	if got := strings.Count(string(data), "\n"); got != len(ids) {
+		t.Errorf("issues.jsonl has %d lines, want %d", got, len(ids))
+	}
+
+	s, err := sqlite.New(ctx, filepath.Join(dir, ".beads", "beads.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	// The daemon rejects databases without a repository fingerprint
+	if repoID, err := s.GetMetadata(ctx, "repo_id"); err != nil || repoID == "" {
+		t.Errorf("repo_id not set (err=%v)", err)
+	}
+
+	// The walkthrough relies on this shape: the P0 bug is ready, signup
+	// blocks three issues, and the CSV export sits at the end of a chain
+	ready, err := s.GetReadyWork(ctx, types.WorkFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	readyIDs := make(map[string]bool)
+	for _, issue := range ready {
+		readyIDs[issue.ID] = true
+	}
+	for _, key := range []string{"plus", "charts", "toolchain"} {
+		if !readyIDs[ids[key]] {
+			t.Errorf("%s (%s) should be ready", key, ids[key])
+		}
+	}
+	for _, key := range []string{"reset", "ratelimit", "dau", "csv"} {
+		if readyIDs[ids[key]] {
+			t.Errorf("%s (%s) should be blocked", key, ids[key])
+		}
+	}
+
+	if _, err := createDemoProject(ctx, dir, "demo"); err == nil {
+		t.Error("expected an error when the directory is not empty")
+	}
+}
@@ -2,6 +2,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -10,6 +11,7 @@ import (
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/utils"
@@ -44,15 +46,19 @@ var depAddCmd = &cobra.Command{
 				os.Exit(1)
 			}
 			
-			resolveArgs = &rpc.ResolveIDArgs{ID: args[1]}
-			resp, err = daemonClient.ResolveID(resolveArgs)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error resolving dependency ID %s: %v\n", args[1], err)
-				os.Exit(1)
-			}
-			if err := json.Unmarshal(resp.Data, &toID); err != nil {
-				fmt.Fprintf(os.Stderr, "Error unmarshaling resolved ID: %v\n", err)
-				os.Exit(1)
+			if types.IsQualifiedID(args[1]) {
+				toID = args[1] // an issue in a linked workspace
+			} else {
+				resolveArgs = &rpc.ResolveIDArgs{ID: args[1]}
+				resp, err = daemonClient.ResolveID(resolveArgs)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error resolving dependency ID %s: %v\n", args[1], err)
+					os.Exit(1)
+				}
+				if err := json.Unmarshal(resp.Data, &toID); err != nil {
+					fmt.Fprintf(os.Stderr, "Error unmarshaling resolved ID: %v\n", err)
+					os.Exit(1)
+				}
 			}
 		} else {
 			var err error
@@ -62,12 +68,19 @@ var depAddCmd = &cobra.Command{
 				os.Exit(1)
 			}
 			
-			toID, err = utils.ResolvePartialID(ctx, store, args[1])
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error resolving dependency ID %s: %v\n", args[1], err)
-				os.Exit(1)
+			if types.IsQualifiedID(args[1]) {
+				toID = args[1]
+			} else {
+				toID, err = utils.ResolvePartialID(ctx, store, args[1])
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error resolving dependency ID %s: %v\n", args[1], err)
+					os.Exit(1)
+				}
 			}
 		}
+		if types.IsQualifiedID(toID) {
+			checkLinkedTarget(ctx, toID)
+		}
 
 		// If daemon is running, use RPC
 		if daemonClient != nil {
@@ -109,6 +122,12 @@ var depAddCmd = &cobra.Command{
 		// Schedule auto-flush
 		markDirtyAndScheduleFlush()
 
+		if types.IsQualifiedID(toID) {
+			// Record the blocker's status now; checkLinkedTarget has already
+			// warned about anything unresolved
+			_ = refreshExternalIssues(ctx, store)
+		}
+
 		// Check for cycles after adding dependency
 		cycles, err := store.DetectCycles(ctx)
 		if err != nil {
@@ -172,15 +191,19 @@ var depRemoveCmd = &cobra.Command{
 				os.Exit(1)
 			}
 			
-			resolveArgs = &rpc.ResolveIDArgs{ID: args[1]}
-			resp, err = daemonClient.ResolveID(resolveArgs)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error resolving dependency ID %s: %v\n", args[1], err)
-				os.Exit(1)
-			}
-			if err := json.Unmarshal(resp.Data, &toID); err != nil {
-				fmt.Fprintf(os.Stderr, "Error unmarshaling resolved ID: %v\n", err)
-				os.Exit(1)
+			if types.IsQualifiedID(args[1]) {
+				toID = args[1] // an issue in a linked workspace
+			} else {
+				resolveArgs = &rpc.ResolveIDArgs{ID: args[1]}
+				resp, err = daemonClient.ResolveID(resolveArgs)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error resolving dependency ID %s: %v\n", args[1], err)
+					os.Exit(1)
+				}
+				if err := json.Unmarshal(resp.Data, &toID); err != nil {
+					fmt.Fprintf(os.Stderr, "Error unmarshaling resolved ID: %v\n", err)
+					os.Exit(1)
+				}
 			}
 		} else {
 			var err error
@@ -190,10 +213,14 @@ var depRemoveCmd = &cobra.Command{
 				os.Exit(1)
 			}
 			
-			toID, err = utils.ResolvePartialID(ctx, store, args[1])
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error resolving dependency ID %s: %v\n", args[1], err)
-				os.Exit(1)
+			if types.IsQualifiedID(args[1]) {
+				toID = args[1]
+			} else {
+				toID, err = utils.ResolvePartialID(ctx, store, args[1])
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error resolving dependency ID %s: %v\n", args[1], err)
+					os.Exit(1)
+				}
 			}
 		}
 
@@ -736,8 +763,17 @@ func mergeBidirectionalTrees(downTree, upTree []*types.TreeNode, rootID string)
 	return result
 }
 
+// syncChecklistDeps keeps the soft dependencies derived from checklist items
+// in an issue's description in step with it (direct mode; the daemon does
+// the same in its create and update handlers)
+func syncChecklistDeps(ctx context.Context, issueID, description string) {
+	if _, err := storage.SyncChecklistDependencies(ctx, store, issueID, description, actor); err != nil {
+		WarnError("failed to sync checklist dependencies for %s: %v", issueID, err)
+	}
+}
+
 func init() {
-	depAddCmd.Flags().StringP("type", "t", "blocks", "Dependency type (blocks|related|parent-child|discovered-from)")
+	depAddCmd.Flags().StringP("type", "t", "blocks", "Dependency type (blocks|soft-blocks|related|parent-child|discovered-from)")
 	// Note: --json flag is defined as a persistent flag in main.go, not here
 
 	// Note: --json flag is defined as a persistent flag in main.go, not here
@@ -746,7 +782,7 @@ func init() {
 	depTreeCmd.Flags().IntP("max-depth", "d", 50, "Maximum tree depth to display (safety limit)")
 	depTreeCmd.Flags().Bool("reverse", false, "Show dependent tree (deprecated: use --direction=up)")
 	depTreeCmd.Flags().String("direction", "", "Tree direction: 'down' (dependencies), 'up' (dependents), or 'both'")
-	depTreeCmd.Flags().String("status", "", "Filter to only show issues with this status (open, in_progress, blocked, closed)")
+	depTreeCmd.Flags().String("status", "", "Filter to only show issues with this status (open, in_progress, blocked, resolved, closed)")
 	depTreeCmd.Flags().String("format", "", "Output format: 'mermaid' for Mermaid.js flowchart")
 	// Note: --json flag is defined as a persistent flag in main.go, not here
 
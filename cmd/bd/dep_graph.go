@@ -0,0 +1,399 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var depGraphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Export the dependency graph as Graphviz DOT or Mermaid",
+	Long: `Export the dependency graph in a format that renders as a diagram, for
+design reviews and docs.
+
+Without --root the graph has every issue that has a dependency or a
+dependent. With --root it has the issues reachable from that issue:
+what it depends on (--direction=down, the default), what depends on it
+(up), or both, up to --depth levels away.
+
+Nodes are colored by status. Edges point from an issue to what it depends
+on and are styled by dependency type. Issues and edges that form a cycle
+are drawn in red.
+
+Examples:
+  bd dep graph > deps.dot && dot -Tsvg deps.dot -o deps.svg
+  bd dep graph --format=mermaid --root bd-123 --depth 2
+  bd dep graph --root bd-123 --direction=both --json`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		format, _ := cmd.Flags().GetString("format")
+		rootArg, _ := cmd.Flags().GetString("root")
+		depth, _ := cmd.Flags().GetInt("depth")
+		direction, _ := cmd.Flags().GetString("direction")
+
+		if format != "dot" && format != "mermaid" {
+			FatalError("--format must be 'dot' or 'mermaid'")
+		}
+		if direction != "down" && direction != "up" && direction != "both" {
+			FatalError("--direction must be 'down', 'up', or 'both'")
+		}
+		if depth < 0 {
+			FatalError("--depth must be >= 0")
+		}
+		if rootArg == "" && (cmd.Flags().Changed("depth") || cmd.Flags().Changed("direction")) {
+			FatalError("--depth and --direction need --root")
+		}
+		if err := ensureDirectMode("dep graph requires direct database access"); err != nil {
+			FatalError("%v", err)
+		}
+		ctx := rootCtx
+
+		var rootID string
+		if rootArg != "" {
+			var err error
+			if rootID, err = utils.ResolvePartialID(ctx, store, rootArg); err != nil {
+				FatalError("resolving %s: %v", rootArg, err)
+			}
+		}
+
+		graph, err := buildDepGraph(ctx, store, rootID, depth, direction)
+		if err != nil {
+			FatalError("%v", err)
+		}
+		if jsonOutput {
+			outputJSON(graph)
+			return
+		}
+		if format == "mermaid" {
+			writeMermaidGraph(os.Stdout, graph)
+		} else {
+			writeDotGraph(os.Stdout, graph)
+		}
+	},
+}
+
+// depGraph is a set of issues and the dependencies between them
+type depGraph struct {
+	Root   string              `json:"root,omitempty"`
+	Nodes  []*types.Issue      `json:"nodes"`
+	Edges  []*types.Dependency `json:"edges"`
+	Cycles [][]string          `json:"cycles"` // issue IDs of each cycle, sorted
+}
+
+// buildDepGraph collects the graph from the dependency table. With a root it
+// walks depth levels (0 = unlimited) in direction; without one it takes
+// every issue with a dependency or dependent. Tombstoned issues are left
+// out, as are edges to issues that aren't in the graph.
+func buildDepGraph(ctx context.Context, s storage.Storage, rootID string, depth int, direction string) (*depGraph, error) {
+	records, err := s.GetAllDependencyRecords(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load dependencies: %w", err)
+	}
+	dependents := make(map[string][]*types.Dependency)
+	for _, deps := range records {
+		for _, dep := range deps {
+			dependents[dep.DependsOnID] = append(dependents[dep.DependsOnID], dep)
+		}
+	}
+
+	include := make(map[string]bool)
+	if rootID == "" {
+		for id, deps := range records {
+			include[id] = true
+			for _, dep := range deps {
+				include[dep.DependsOnID] = true
+			}
+		}
+	} else {
+		include[rootID] = true
+		frontier := []string{rootID}
+		for level := 1; len(frontier) > 0 && (depth == 0 || level <= depth); level++ {
+			var next []string
+			visit := func(id string) {
+				if !include[id] {
+					include[id] = true
+					next = append(next, id)
+				}
+			}
+			for _, id := range frontier {
+				if direction != "up" {
+					for _, dep := range records[id] {
+						visit(dep.DependsOnID)
+					}
+				}
+				if direction != "down" {
+					for _, dep := range dependents[id] {
+						visit(dep.IssueID)
+					}
+				}
+			}
+			frontier = next
+		}
+	}
+
+	graph := &depGraph{Root: rootID, Nodes: []*types.Issue{}, Edges: []*types.Dependency{}, Cycles: [][]string{}}
+	if len(include) == 0 {
+		return graph, nil
+	}
+	ids := make([]string, 0, len(include))
+	for id := range include {
+		ids = append(ids, id)
+	}
+	issues, err := s.SearchIssues(ctx, "", types.IssueFilter{IDs: ids})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load issues: %w", err)
+	}
+	present := make(map[string]bool)
+	for _, issue := range issues {
+		graph.Nodes = append(graph.Nodes, issue)
+		present[issue.ID] = true
+	}
+	if rootID != "" && !present[rootID] {
+		return nil, fmt.Errorf("issue %s not found", rootID)
+	}
+	sort.Slice(graph.Nodes, func(i, j int) bool { return graph.Nodes[i].ID < graph.Nodes[j].ID })
+
+	for _, issue := range graph.Nodes {
+		for _, dep := range records[issue.ID] {
+			if present[dep.DependsOnID] {
+				graph.Edges = append(graph.Edges, dep)
+			}
+		}
+	}
+	sort.SliceStable(graph.Edges, func(i, j int) bool {
+		a, b := graph.Edges[i], graph.Edges[j]
+		if a.IssueID != b.IssueID {
+			return a.IssueID < b.IssueID
+		}
+		return a.DependsOnID < b.DependsOnID
+	})
+	graph.Cycles = findGraphCycles(graph.Edges)
+	return graph, nil
+}
+
+// findGraphCycles returns the strongly connected components that form a
+// cycle (Tarjan's algorithm), each as sorted issue IDs
+func findGraphCycles(edges []*types.Dependency) [][]string {
+	adjacent := make(map[string][]string)
+	selfLoop := make(map[string]bool)
+	var nodes []string
+	seen := make(map[string]bool)
+	for _, edge := range edges {
+		adjacent[edge.IssueID] = append(adjacent[edge.IssueID], edge.DependsOnID)
+		if edge.IssueID == edge.DependsOnID {
+			selfLoop[edge.IssueID] = true
+		}
+		for _, id := range []string{edge.IssueID, edge.DependsOnID} {
+			if !seen[id] {
+				seen[id] = true
+				nodes = append(nodes, id)
+			}
+		}
+	}
+
+	index := make(map[string]int)
+	lowlink := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	cycles := [][]string{}
+	var connect func(id string)
+	connect = func(id string) {
+		index[id] = len(index)
+		lowlink[id] = index[id]
+		stack = append(stack, id)
+		onStack[id] = true
+		for _, next := range adjacent[id] {
+			if _, visited := index[next]; !visited {
+				connect(next)
+				lowlink[id] = min(lowlink[id], lowlink[next])
+			} else if onStack[next] {
+				lowlink[id] = min(lowlink[id], index[next])
+			}
+		}
+		if lowlink[id] != index[id] {
+			return
+		}
+		var component []string
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == id {
+				break
+			}
+		}
+		if len(component) > 1 || selfLoop[id] {
+			sort.Strings(component)
+			cycles = append(cycles, component)
+		}
+	}
+	for _, id := range nodes {
+		if _, visited := index[id]; !visited {
+			connect(id)
+		}
+	}
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i][0] < cycles[j][0] })
+	return cycles
+}
+
+// cycleMembers maps each issue in a cycle to its cycle's index, so an edge
+// is part of a cycle when both ends map to the same index
+func (g *depGraph) cycleMembers() map[string]int {
+	members := make(map[string]int)
+	for i, cycle := range g.Cycles {
+		for _, id := range cycle {
+			members[id] = i
+		}
+	}
+	return members
+}
+
+func (g *depGraph) inCycle(members map[string]int, edge *types.Dependency) bool {
+	from, ok := members[edge.IssueID]
+	to, ok2 := members[edge.DependsOnID]
+	return ok && ok2 && from == to
+}
+
+// Node fill colors by status, shared by both formats
+var graphStatusColors = map[types.Status]string{
+	types.StatusOpen:       "#ffffff",
+	types.StatusInProgress: "#fff3b0",
+	types.StatusBlocked:    "#f4a6a6",
+	types.StatusResolved:   "#b8e0f0",
+	types.StatusClosed:     "#d9d9d9",
+}
+
+const graphCycleColor = "#d00000"
+
+// writeDotGraph renders the graph in Graphviz DOT, styled like
+// bd list --format=dot
+func writeDotGraph(w io.Writer, g *depGraph) {
+	members := g.cycleMembers()
+	fmt.Fprintln(w, "digraph dependencies {")
+	fmt.Fprintln(w, "  rankdir=TB;")
+	fmt.Fprintln(w, "  node [shape=box, style=\"rounded,filled\", fillcolor=\"#ffffff\"];")
+	fmt.Fprintln(w)
+	for _, issue := range g.Nodes {
+		label := fmt.Sprintf("%s\n[%s P%d]\n%s\n(%s)", issue.ID, issue.IssueType, issue.Priority, issue.Title, issue.Status)
+		attrs := fmt.Sprintf("label=%q, fillcolor=%q", label, graphStatusColors[issue.Status])
+		if issue.Status == types.StatusClosed {
+			attrs += ", fontcolor=dimgray"
+		}
+		if _, ok := members[issue.ID]; ok {
+			attrs += fmt.Sprintf(", color=%q, penwidth=2", graphCycleColor)
+		}
+		if issue.ID == g.Root {
+			attrs += ", peripheries=2"
+		}
+		fmt.Fprintf(w, "  %q [%s];\n", issue.ID, attrs)
+	}
+	if len(g.Edges) > 0 {
+		fmt.Fprintln(w)
+	}
+	for _, edge := range g.Edges {
+		edgeColor, style := "black", "solid"
+		switch edge.Type {
+		case types.DepBlocks:
+			style = "bold"
+		case types.DepParentChild:
+			edgeColor = "blue"
+		case types.DepDiscoveredFrom:
+			edgeColor, style = "green", "dashed"
+		case types.DepRelated:
+			edgeColor, style = "gray", "dashed"
+		}
+		attrs := fmt.Sprintf("label=%q, color=%q, style=%s", edge.Type, edgeColor, style)
+		if g.inCycle(members, edge) {
+			attrs = fmt.Sprintf("label=%q, color=%q, style=%s, penwidth=2", edge.Type, graphCycleColor, style)
+		}
+		fmt.Fprintf(w, "  %q -> %q [%s];\n", edge.IssueID, edge.DependsOnID, attrs)
+	}
+	fmt.Fprintln(w, "}")
+}
+
+var mermaidUnsafeID = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// writeMermaidGraph renders the graph as a Mermaid flowchart
+func writeMermaidGraph(w io.Writer, g *depGraph) {
+	members := g.cycleMembers()
+	// Mermaid node IDs can't contain '-' or '.' reliably, so nodes get a
+	// sanitized ID and show the issue ID in their label
+	nodeIDs := make(map[string]string)
+	used := make(map[string]bool)
+	for _, issue := range g.Nodes {
+		id := "n_" + mermaidUnsafeID.ReplaceAllString(issue.ID, "_")
+		for base, n := id, 2; used[id]; n++ {
+			id = fmt.Sprintf("%s_%d", base, n)
+		}
+		used[id] = true
+		nodeIDs[issue.ID] = id
+	}
+
+	fmt.Fprintln(w, "flowchart TD")
+	byStatus := make(map[types.Status][]string)
+	var cycleNodes []string
+	for _, issue := range g.Nodes {
+		label := fmt.Sprintf("%s %s: %s", getStatusEmoji(issue.Status), issue.ID, issue.Title)
+		// #quot; is Mermaid's entity for a double quote inside a label
+		label = strings.ReplaceAll(label, `"`, "#quot;")
+		fmt.Fprintf(w, "  %s[\"%s\"]\n", nodeIDs[issue.ID], label)
+		byStatus[issue.Status] = append(byStatus[issue.Status], nodeIDs[issue.ID])
+		if _, ok := members[issue.ID]; ok {
+			cycleNodes = append(cycleNodes, nodeIDs[issue.ID])
+		}
+	}
+
+	var cycleEdges []string
+	for i, edge := range g.Edges {
+		arrow := "-->"
+		if edge.Type == types.DepRelated || edge.Type == types.DepDiscoveredFrom {
+			arrow = "-.->"
+		} else if edge.Type == types.DepBlocks {
+			arrow = "==>"
+		}
+		fmt.Fprintf(w, "  %s %s|%s| %s\n", nodeIDs[edge.IssueID], arrow, edge.Type, nodeIDs[edge.DependsOnID])
+		if g.inCycle(members, edge) {
+			cycleEdges = append(cycleEdges, fmt.Sprint(i))
+		}
+	}
+
+	statuses := make([]string, 0, len(byStatus))
+	for status := range byStatus {
+		statuses = append(statuses, string(status))
+	}
+	sort.Strings(statuses)
+	for _, status := range statuses {
+		fill := graphStatusColors[types.Status(status)]
+		if fill == "" {
+			fill = "#ffffff"
+		}
+		fmt.Fprintf(w, "  classDef %s fill:%s,stroke:#333\n", status, fill)
+		fmt.Fprintf(w, "  class %s %s\n", strings.Join(byStatus[types.Status(status)], ","), status)
+	}
+	if len(cycleNodes) > 0 {
+		fmt.Fprintf(w, "  classDef cycle stroke:%s,stroke-width:3px\n", graphCycleColor)
+		fmt.Fprintf(w, "  class %s cycle\n", strings.Join(cycleNodes, ","))
+	}
+	if len(cycleEdges) > 0 {
+		fmt.Fprintf(w, "  linkStyle %s stroke:%s,stroke-width:3px\n", strings.Join(cycleEdges, ","), graphCycleColor)
+	}
+}
+
+func init() {
+	depGraphCmd.Flags().String("format", "dot", "Output format: 'dot' (Graphviz) or 'mermaid'")
+	depGraphCmd.Flags().String("root", "", "Only graph issues reachable from this issue")
+	depGraphCmd.Flags().Int("depth", 0, "With --root, maximum distance from the root (0 = unlimited)")
+	depGraphCmd.Flags().String("direction", "down", "With --root: 'down' (dependencies), 'up' (dependents), or 'both'")
+	depCmd.AddCommand(depGraphCmd)
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestBuildDepGraph(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t, filepath.Join(t.TempDir(), ".beads", "beads.db"))
+	for _, issue := range []*types.Issue{
+		{ID: "test-1", Title: "Epic", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeEpic},
+		{ID: "test-2", Title: "API", Status: types.StatusInProgress, Priority: 2, IssueType: types.TypeTask},
+		{ID: "test-3", Title: "Schema", Status: types.StatusClosed, Priority: 2, IssueType: types.TypeTask},
+		{ID: "test-4", Title: "Docs", Status: types.StatusOpen, Priority: 3, IssueType: types.TypeTask},
+		{ID: "test-5", Title: "Unrelated", Status: types.StatusOpen, Priority: 3, IssueType: types.TypeTask},
+	} {
+		if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	for _, dep := range []*types.Dependency{
+		{IssueID: "test-1", DependsOnID: "test-2", Type: types.DepBlocks},
+		{IssueID: "test-2", DependsOnID: "test-3", Type: types.DepBlocks},
+		{IssueID: "test-4", DependsOnID: "test-1", Type: types.DepRelated},
+	} {
+		if err := s.AddDependency(ctx, dep, "test"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+
+	nodeIDs := func(g *depGraph) string {
+		var ids []string
+		for _, node := range g.Nodes {
+			ids = append(ids, node.ID)
+		}
+		return strings.Join(ids, ",")
+	}
+
+	tests := []struct {
+		name      string
+		root      string
+		depth     int
+		direction string
+		want      string
+		edges     int
+	}{
+		{"whole graph", "", 0, "down", "test-1,test-2,test-3,test-4", 3},
+		{"down from root", "test-1", 0, "down", "test-1,test-2,test-3", 2},
+		{"depth limit", "test-1", 1, "down", "test-1,test-2", 1},
+		{"up from root", "test-2", 0, "up", "test-1,test-2,test-4", 2},
+		{"both directions", "test-2", 1, "both", "test-1,test-2,test-3", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := buildDepGraph(ctx, s, tt.root, tt.depth, tt.direction)
+			if err != nil {
+				t.Fatalf("buildDepGraph failed: %v", err)
+			}
+			if got := nodeIDs(g); got != tt.want {
+				t.Errorf("nodes = %s, want %s", got, tt.want)
+			}
+			if len(g.Edges) != tt.edges {
+				t.Errorf("got %d edges, want %d", len(g.Edges), tt.edges)
+			}
+			if len(g.Cycles) != 0 {
+				t.Errorf("unexpected cycles %v", g.Cycles)
+			}
+		})
+	}
+
+	if _, err := buildDepGraph(ctx, s, "test-99", 0, "down"); err == nil {
+		t.Errorf("expected an error for a missing root")
+	}
+}
+
+func TestFindGraphCycles(t *testing.T) {
+	edge := func(from, to string) *types.Dependency {
+		return &types.Dependency{IssueID: from, DependsOnID: to, Type: types.DepBlocks}
+	}
+	cycles := findGraphCycles([]*types.Dependency{
+		edge("a", "b"), edge("b", "c"), edge("c", "a"), // a -> b -> c -> a
+		edge("c", "d"), // d hangs off the cycle
+		edge("e", "e"), // self loop
+		edge("f", "g"),
+	})
+	if len(cycles) != 2 || strings.Join(cycles[0], ",") != "a,b,c" || strings.Join(cycles[1], ",") != "e" {
+		t.Errorf("cycles = %v, want [[a b c] [e]]", cycles)
+	}
+}
+
+func testCycleGraph() *depGraph {
+	issue := func(id, title string, status types.Status) *types.Issue {
+		return &types.Issue{ID: id, Title: title, Status: status, Priority: 2, IssueType: types.TypeTask}
+	}
+	edges := []*types.Dependency{
+		{IssueID: "bd-1", DependsOnID: "bd-2", Type: types.DepBlocks},
+		{IssueID: "bd-2", DependsOnID: "bd-1", Type: types.DepRelated},
+		{IssueID: "bd-2", DependsOnID: "bd-3.1", Type: types.DepParentChild},
+	}
+	return &depGraph{
+		Root:   "bd-1",
+		Nodes:  []*types.Issue{issue("bd-1", `Say "hi"`, types.StatusOpen), issue("bd-2", "Loop", types.StatusBlocked), issue("bd-3.1", "Leaf", types.StatusClosed)},
+		Edges:  edges,
+		Cycles: findGraphCycles(edges),
+	}
+}
+
+func TestWriteDotGraph(t *testing.T) {
+	var buf bytes.Buffer
+	writeDotGraph(&buf, testCycleGraph())
+	out := buf.String()
+	for _, want := range []string{
+		"digraph dependencies {",
+		`"bd-1" [label="bd-1\n[task P2]\nSay \"hi\"\n(open)", fillcolor="#ffffff", color="#d00000", penwidth=2, peripheries=2];`,
+		`"bd-2" [label="bd-2\n[task P2]\nLoop\n(blocked)", fillcolor="#f4a6a6", color="#d00000", penwidth=2];`,
+		`"bd-3.1" [label="bd-3.1\n[task P2]\nLeaf\n(closed)", fillcolor="#d9d9d9", fontcolor=dimgray];`,
+		`"bd-1" -> "bd-2" [label="blocks", color="#d00000", style=bold, penwidth=2];`,
+		`"bd-2" -> "bd-3.1" [label="parent-child", color="blue", style=solid];`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("DOT output missing %s\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteMermaidGraph(t *testing.T) {
+	var buf bytes.Buffer
+	writeMermaidGraph(&buf, testCycleGraph())
+	out := buf.String()
+	for _, want := range []string{
+		"flowchart TD",
+		`n_bd_1["☐ bd-1: Say #quot;hi#quot;"]`,
+		`n_bd_3_1["☑ bd-3.1: Leaf"]`,
+		"n_bd_1 ==>|blocks| n_bd_2",
+		"n_bd_2 -.->|related| n_bd_1",
+		"n_bd_2 -->|parent-child| n_bd_3_1",
+		"class n_bd_2 blocked",
+		"class n_bd_1,n_bd_2 cycle",
+		"linkStyle 0,1 stroke:#d00000",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Mermaid output missing %s\n%s", want, out)
+		}
+	}
+}
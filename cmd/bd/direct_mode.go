@@ -7,12 +7,14 @@ import (
 
 	"github.com/steveyegge/beads/internal/beads"
 	"github.com/steveyegge/beads/internal/debug"
-	"github.com/steveyegge/beads/internal/storage/sqlite"
 )
 
 // ensureDirectMode makes sure the CLI is operating in direct-storage mode.
 // If the daemon is active, it is cleanly disconnected and the shared store is opened.
 func ensureDirectMode(reason string) error {
+	if daemonClient != nil && daemonClient.Remote() {
+		return fmt.Errorf("%s, which a remote daemon (BEADS_DAEMON_ADDR) can't provide", reason)
+	}
 	if daemonClient != nil {
 		if err := fallbackToDirectMode(reason); err != nil {
 			return err
@@ -85,7 +87,7 @@ func ensureStoreActive() error {
 		}
 	}
 
-	sqlStore, err := sqlite.New(rootCtx, dbPath)
+	sqlStore, err := openStore(rootCtx, dbPath, false)
 	if err != nil {
 		// Check for fresh clone scenario (bd-dmb)
 		if isFreshCloneError(err) {
@@ -0,0 +1,479 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/util"
+)
+
+var dispatchCmd = &cobra.Command{
+	Use:   "dispatch",
+	Short: "Hand ready work to parallel agents as self-contained packets",
+	Long: `Claim the top ready issues, one per agent, and write each to a work
+packet in --out: a JSON file with the issue, its labels, what it depends
+on, its comments and instructions for finishing or returning it. An agent
+process needs only its packet, not access to the database.
+
+Each issue is claimed (see bd claim) for the packet's holder, <actor>/agent-N,
+so other agents and 'bd ready --claim' leave it alone; with --ttl (or the
+claim.ttl config) the claim expires unless renewed. Long text is shortened
+to fit --budget tokens, as with bd show --json --budget. Packets are
+numbered after those already in --out, so dispatching again adds packets.
+
+When there is less ready work than agents, fewer packets are written.
+
+bd dispatch release returns the packets in --out (or the given packet
+files): the claims on unfinished issues are released, putting them back to
+open and unassigned, and the packet files of released and finished issues
+are removed.
+
+Examples:
+  bd dispatch --agents 4 --out packets/
+  bd dispatch --agents 2 --label backend --ttl 2h --budget 4000
+  bd dispatch release                        # Return every packet in packets/
+  bd dispatch release packets/agent-3.json   # Return one`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		agents, _ := cmd.Flags().GetInt("agents")
+		outDir, _ := cmd.Flags().GetString("out")
+		budget, _ := cmd.Flags().GetInt("budget")
+		ttlFlag, _ := cmd.Flags().GetString("ttl")
+		sortPolicy, _ := cmd.Flags().GetString("sort")
+		labels, _ := cmd.Flags().GetStringSlice("label")
+		labelsAny, _ := cmd.Flags().GetStringSlice("label-any")
+		complexityFlags, _ := cmd.Flags().GetStringSlice("complexity")
+
+		if agents < 1 {
+			FatalError("--agents must be at least 1")
+		}
+		if budget < 0 {
+			FatalError("--budget cannot be negative")
+		}
+		ttl, err := claimTTL(ttlFlag)
+		if err != nil {
+			FatalError("%v", err)
+		}
+		complexity, err := types.ParseComplexities(complexityFlags)
+		if err != nil {
+			FatalError("invalid --complexity: %v", err)
+		}
+		filter := types.WorkFilter{
+			SortPolicy: types.SortPolicy(sortPolicy),
+			Labels:     util.NormalizeLabels(labels),
+			LabelsAny:  util.NormalizeLabels(labelsAny),
+			Complexity: complexity,
+		}
+		if !filter.SortPolicy.IsValid() {
+			FatalError("invalid sort policy %q (valid: hybrid, priority, oldest)", sortPolicy)
+		}
+		if cmd.Flags().Changed("priority") {
+			priority, _ := cmd.Flags().GetInt("priority")
+			filter.Priority = &priority
+		}
+
+		CheckReadonly("dispatch")
+		sqliteStore := dispatchStore("dispatch")
+		ctx := rootCtx
+		root := ""
+		if beadsDir := findBeadsDir(); beadsDir != "" {
+			root = filepath.Dir(beadsDir)
+		}
+
+		packets, err := dispatchPackets(ctx, sqliteStore, dispatchOptions{
+			dir:       outDir,
+			agents:    agents,
+			filter:    filter,
+			ttl:       ttl,
+			budget:    budget,
+			holder:    actor,
+			workspace: root,
+		})
+		if len(packets) > 0 {
+			markDirtyAndScheduleFlush()
+		}
+		if err != nil {
+			FatalError("%v", err)
+		}
+
+		if jsonOutput {
+			if packets == nil {
+				packets = []*dispatchedPacket{}
+			}
+			outputJSON(packets)
+			return
+		}
+		if len(packets) == 0 {
+			yellow := color.New(color.FgYellow).SprintFunc()
+			fmt.Printf("\n%s No ready work to dispatch\n\n", yellow("✨"))
+			return
+		}
+		green := color.New(color.FgGreen).SprintFunc()
+		fmt.Printf("%s Dispatched %d packet(s) to %s\n", green("✓"), len(packets), outDir)
+		for _, p := range packets {
+			fmt.Printf("  %s  %s: %s\n", filepath.Base(p.File), p.IssueID, p.Title)
+		}
+		if len(packets) < agents {
+			fmt.Printf("\nOnly %d ready issue(s) for %d agents\n", len(packets), agents)
+		}
+		fmt.Printf("\nReturn unfinished packets with 'bd dispatch release'\n")
+	},
+}
+
+var dispatchReleaseCmd = &cobra.Command{
+	Use:   "release [packet...]",
+	Short: "Return work packets: release unfinished claims and remove the packets",
+	Args:  cobra.ArbitraryArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		outDir, _ := cmd.Flags().GetString("out")
+		keep, _ := cmd.Flags().GetBool("keep")
+
+		CheckReadonly("dispatch release")
+		paths := args
+		if len(paths) == 0 {
+			var err error
+			paths, err = packetFiles(outDir)
+			if err != nil {
+				FatalError("%v", err)
+			}
+		}
+		sqliteStore := dispatchStore("dispatch release")
+		results, err := releasePackets(rootCtx, sqliteStore, paths, keep)
+		for _, r := range results {
+			if r.Result == packetReleased {
+				markDirtyAndScheduleFlush()
+				break
+			}
+		}
+		if err != nil {
+			FatalError("%v", err)
+		}
+
+		if jsonOutput {
+			if results == nil {
+				results = []*packetRelease{}
+			}
+			outputJSON(results)
+			return
+		}
+		if len(results) == 0 {
+			fmt.Printf("No packets in %s\n", outDir)
+			return
+		}
+		green := color.New(color.FgGreen).SprintFunc()
+		yellow := color.New(color.FgYellow).SprintFunc()
+		for _, r := range results {
+			mark := green("✓")
+			if r.Result == packetKept {
+				mark = yellow("!")
+			}
+			fmt.Printf("%s %s  %s: %s\n", mark, filepath.Base(r.File), r.IssueID, r.Detail)
+		}
+	},
+}
+
+// dispatchStore returns the SQLite store that claims go through, in direct
+// mode
+func dispatchStore(command string) *sqlite.SQLiteStorage {
+	if err := ensureDirectMode(command + " requires direct database access"); err != nil {
+		FatalError("%v", err)
+	}
+	if err := ensureDatabaseFresh(rootCtx); err != nil {
+		FatalError("%v", err)
+	}
+	sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+	if !ok {
+		FatalError("%s requires the SQLite backend", command)
+	}
+	return sqliteStore
+}
+
+// DispatchPacket is the work packet handed to one agent: a claimed issue
+// with the context needed to do it
+type DispatchPacket struct {
+	Packet       string                               `json:"packet"`
+	Holder       string                               `json:"holder"` // Holds the claim; act as it with --actor
+	IssuedAt     time.Time                            `json:"issued_at"`
+	ExpiresAt    *time.Time                           `json:"expires_at,omitempty"` // When the claim lapses unless renewed
+	Workspace    string                               `json:"workspace,omitempty"`  // Project root the issue lives in
+	Issue        *types.Issue                         `json:"issue"`
+	Labels       []string                             `json:"labels,omitempty"`
+	Dependencies []*types.IssueWithDependencyMetadata `json:"dependencies,omitempty"` // Blockers (done), parent epic, related issues
+	Comments     []*types.Comment                     `json:"comments,omitempty"`
+	Instructions []string                             `json:"instructions"`
+	Elided       []string                             `json:"elided,omitempty"` // Fields shortened to fit the budget
+}
+
+// dispatchedPacket reports a packet bd dispatch wrote
+type dispatchedPacket struct {
+	Packet    string     `json:"packet"`
+	File      string     `json:"file"`
+	IssueID   string     `json:"issue_id"`
+	Title     string     `json:"title"`
+	Holder    string     `json:"holder"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Tokens    int        `json:"tokens"` // Estimated size of the packet
+}
+
+type dispatchOptions struct {
+	dir       string
+	agents    int
+	filter    types.WorkFilter
+	ttl       time.Duration
+	budget    int    // Tokens per packet; 0 for no limit
+	holder    string // Actor the packet holders are named after
+	workspace string
+}
+
+// packetName matches the packet files bd dispatch writes
+var packetName = regexp.MustCompile(`^agent-(\d+)\.json$`)
+
+// dispatchPackets claims up to opts.agents ready issues and writes a packet
+// for each. It returns the packets written even when a later one fails.
+func dispatchPackets(ctx context.Context, s *sqlite.SQLiteStorage, opts dispatchOptions) ([]*dispatchedPacket, error) {
+	if err := os.MkdirAll(opts.dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", opts.dir, err)
+	}
+	next, err := nextPacketNumber(opts.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var written []*dispatchedPacket
+	for i := 0; i < opts.agents; i++ {
+		name := fmt.Sprintf("agent-%d", next+i)
+		holder := opts.holder + "/" + name
+		issue, err := s.ClaimReadyWorkWithLease(ctx, opts.filter, holder, opts.ttl)
+		if err != nil {
+			return written, fmt.Errorf("failed to claim work for %s: %w", name, err)
+		}
+		if issue == nil {
+			break
+		}
+
+		packet, err := buildPacket(ctx, s, name, holder, issue, opts)
+		if err == nil {
+			var data []byte
+			data, err = json.MarshalIndent(packet, "", "  ")
+			if err == nil {
+				path := filepath.Join(opts.dir, name+".json")
+				if err = writePacketFile(path, append(data, '\n')); err == nil {
+					written = append(written, &dispatchedPacket{
+						Packet:    name,
+						File:      path,
+						IssueID:   issue.ID,
+						Title:     issue.Title,
+						Holder:    holder,
+						ExpiresAt: packet.ExpiresAt,
+						Tokens:    estimateTokens(string(data)),
+					})
+					continue
+				}
+			}
+		}
+		// Don't leave the issue claimed by a packet no agent will get
+		if releaseErr := s.ReleaseClaim(ctx, issue.ID, holder, false); releaseErr != nil {
+			err = errors.Join(err, releaseErr)
+		}
+		return written, fmt.Errorf("failed to write packet %s for %s: %w", name, issue.ID, err)
+	}
+	return written, nil
+}
+
+// buildPacket gathers the context for a claimed issue and fits it into the
+// budget
+func buildPacket(ctx context.Context, s *sqlite.SQLiteStorage, name, holder string, issue *types.Issue, opts dispatchOptions) (*DispatchPacket, error) {
+	lease, err := s.GetLease(ctx, issue.ID)
+	if err != nil {
+		return nil, err
+	}
+	packet := &DispatchPacket{
+		Packet:    name,
+		Holder:    holder,
+		IssuedAt:  time.Now().UTC(),
+		Workspace: opts.workspace,
+		Issue:     issue,
+	}
+	if lease != nil {
+		packet.ExpiresAt = lease.ExpiresAt
+	}
+	decryptForDisplay(issue)
+	if packet.Labels, err = s.GetLabels(ctx, issue.ID); err != nil {
+		return nil, err
+	}
+	if packet.Dependencies, err = s.GetDependenciesWithMetadata(ctx, issue.ID); err != nil {
+		return nil, err
+	}
+	if packet.Comments, err = s.GetIssueComments(ctx, issue.ID); err != nil {
+		return nil, err
+	}
+
+	packet.Instructions = []string{
+		fmt.Sprintf("Work on %s. It is claimed for you as %s; run bd with --actor %s.", issue.ID, holder, holder),
+		fmt.Sprintf("When it is done: bd --actor %s close %s --reason fixed", holder, issue.ID),
+		fmt.Sprintf("To record progress: bd --actor %s comments add %s \"<note>\"", holder, issue.ID),
+		fmt.Sprintf("To give it back unfinished: bd --actor %s claim %s --release", holder, issue.ID),
+	}
+	if packet.ExpiresAt != nil {
+		packet.Instructions = append(packet.Instructions,
+			fmt.Sprintf("The claim lapses at %s; renew it with: bd --actor %s claim %s --ttl %s",
+				packet.ExpiresAt.Format(time.RFC3339), holder, issue.ID, opts.ttl))
+	}
+	if opts.budget > 0 {
+		packet.Elided = fitJSONBudget(packet, issue, packet.Comments, opts.budget)
+	}
+	return packet, nil
+}
+
+// nextPacketNumber returns the number after the highest packet in dir
+func nextPacketNumber(dir string) (int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+	highest := 0
+	for _, entry := range entries {
+		if m := packetName.FindStringSubmatch(entry.Name()); m != nil {
+			if n, err := strconv.Atoi(m[1]); err == nil && n > highest {
+				highest = n
+			}
+		}
+	}
+	return highest + 1, nil
+}
+
+// packetFiles lists the packets in dir, in number order
+func packetFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+	type numbered struct {
+		n    int
+		path string
+	}
+	var found []numbered
+	for _, entry := range entries {
+		if m := packetName.FindStringSubmatch(entry.Name()); m != nil {
+			n, _ := strconv.Atoi(m[1])
+			found = append(found, numbered{n, filepath.Join(dir, entry.Name())})
+		}
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].n < found[j].n })
+	paths := make([]string, len(found))
+	for i, f := range found {
+		paths[i] = f.path
+	}
+	return paths, nil
+}
+
+// writePacketFile writes a packet so an agent never reads half of one
+func writePacketFile(path string, data []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// Outcomes of returning a packet
+const (
+	packetReleased = "released" // Unfinished: claim released, issue back to open
+	packetDone     = "done"     // Issue closed; nothing to release
+	packetLapsed   = "lapsed"   // Claim already gone (expired, released or taken over)
+	packetKept     = "kept"     // Claim held by someone else; packet left in place
+)
+
+// packetRelease reports what returning a packet did
+type packetRelease struct {
+	File    string `json:"file"`
+	Packet  string `json:"packet"`
+	IssueID string `json:"issue_id"`
+	Result  string `json:"result"`
+	Detail  string `json:"detail"`
+}
+
+// releasePackets returns the packets at paths: the claims on their
+// unfinished issues are released, and the packet files are removed unless
+// keep is set. A packet whose issue is now claimed by someone else is left
+// alone.
+func releasePackets(ctx context.Context, s *sqlite.SQLiteStorage, paths []string, keep bool) ([]*packetRelease, error) {
+	var results []*packetRelease
+	for _, path := range paths {
+		data, err := os.ReadFile(path) // #nosec G304 - packet paths come from the user
+		if err != nil {
+			return results, fmt.Errorf("failed to read packet: %w", err)
+		}
+		var packet DispatchPacket
+		if err := json.Unmarshal(data, &packet); err != nil || packet.Issue == nil || packet.Holder == "" {
+			return results, fmt.Errorf("%s is not a work packet", path)
+		}
+
+		r := &packetRelease{File: path, Packet: packet.Packet, IssueID: packet.Issue.ID}
+		issue, err := s.GetIssue(ctx, packet.Issue.ID)
+		if err != nil {
+			return results, err
+		}
+		lease, err := s.GetLease(ctx, packet.Issue.ID)
+		if err != nil {
+			return results, err
+		}
+		switch {
+		case issue == nil || issue.Status == types.StatusTombstone:
+			r.Result, r.Detail = packetLapsed, "issue deleted"
+		case issue.Status == types.StatusClosed || issue.Status == types.StatusResolved:
+			r.Result, r.Detail = packetDone, string(issue.Status)
+		case lease != nil && lease.Holder == packet.Holder:
+			if err := s.ReleaseClaim(ctx, issue.ID, packet.Holder, false); err != nil {
+				return results, fmt.Errorf("failed to release %s: %w", issue.ID, err)
+			}
+			r.Result, r.Detail = packetReleased, "released, back to open"
+		case lease != nil && !lease.Expired(time.Now()):
+			r.Result, r.Detail = packetKept, "now claimed by "+lease.Holder+"; packet kept"
+		default:
+			r.Result, r.Detail = packetLapsed, "claim already ended"
+		}
+
+		if r.Result != packetKept && !keep {
+			if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return append(results, r), fmt.Errorf("failed to remove %s: %w", path, err)
+			}
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+func init() {
+	dispatchCmd.Flags().Int("agents", 1, "Number of packets to write, one issue each")
+	dispatchCmd.PersistentFlags().String("out", "packets", "Directory the packets are written to and returned from")
+	dispatchCmd.Flags().Int("budget", 8000, "Fit each packet into about this many tokens by eliding the middle of long text (0: no limit)")
+	dispatchCmd.Flags().String("ttl", "", "Lease each claim for this long, e.g. 2h (default: claim.ttl config, else no expiry)")
+	dispatchCmd.Flags().StringP("sort", "s", "hybrid", "Sort policy: hybrid (default), priority, oldest")
+	dispatchCmd.Flags().IntP("priority", "p", 0, "Only issues with this priority")
+	dispatchCmd.Flags().StringSliceP("label", "l", []string{}, "Only issues with all of these labels")
+	dispatchCmd.Flags().StringSlice("label-any", []string{}, "Only issues with at least one of these labels")
+	dispatchCmd.Flags().StringSlice("complexity", []string{}, "Only issues with one of these complexities (trivial, standard, complex, research)")
+	dispatchReleaseCmd.Flags().Bool("keep", false, "Keep the packet files")
+	dispatchCmd.AddCommand(dispatchReleaseCmd)
+	rootCmd.AddCommand(dispatchCmd)
+}
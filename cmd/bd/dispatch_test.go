@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestDispatchAndReleasePackets(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	s := newTestStore(t, filepath.Join(dir, ".beads", "beads.db"))
+	outDir := filepath.Join(dir, "packets")
+
+	var ids []string
+	for i, title := range []string{"Fix login", "Add export", "Write docs"} {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: i, IssueType: types.TypeTask,
+			Description: strings.Repeat("A long line of description text.\n", 400)}
+		if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, issue.ID)
+	}
+
+	opts := dispatchOptions{dir: outDir, agents: 2, filter: types.WorkFilter{SortPolicy: types.SortPolicyPriority}, ttl: time.Hour, budget: 1000, holder: "alice"}
+	packets, err := dispatchPackets(ctx, s, opts)
+	if err != nil {
+		t.Fatalf("dispatchPackets failed: %v", err)
+	}
+	if len(packets) != 2 || packets[0].IssueID != ids[0] || packets[1].IssueID != ids[1] {
+		t.Fatalf("packets = %+v, want %s and %s", packets, ids[0], ids[1])
+	}
+	for _, p := range packets {
+		if p.Tokens > 1000 {
+			t.Errorf("%s is about %d tokens, over the budget", p.Packet, p.Tokens)
+		}
+	}
+
+	var packet DispatchPacket
+	data, err := os.ReadFile(filepath.Join(outDir, "agent-1.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &packet); err != nil {
+		t.Fatal(err)
+	}
+	if packet.Holder != "alice/agent-1" || packet.Issue.ID != ids[0] || packet.ExpiresAt == nil || len(packet.Elided) == 0 || len(packet.Instructions) == 0 {
+		t.Errorf("packet = %+v", packet)
+	}
+	claimed, err := s.GetIssue(ctx, ids[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claimed.Status != types.StatusInProgress || claimed.Assignee != "alice/agent-1" {
+		t.Errorf("dispatched issue is %s for %q, want in_progress for alice/agent-1", claimed.Status, claimed.Assignee)
+	}
+
+	// Dispatching again numbers on and only finds the unclaimed issue
+	more, err := dispatchPackets(ctx, s, opts)
+	if err != nil {
+		t.Fatalf("dispatchPackets failed: %v", err)
+	}
+	if len(more) != 1 || more[0].Packet != "agent-3" || more[0].IssueID != ids[2] {
+		t.Errorf("second dispatch = %+v, want agent-3 for %s", more, ids[2])
+	}
+
+	// agent-1 finished, agent-2 didn't, and agent-3's issue was taken over
+	if err := s.CloseIssue(ctx, ids[0], "fixed", "alice/agent-1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.ClaimIssue(ctx, ids[2], "bob", 0, true); err != nil {
+		t.Fatal(err)
+	}
+	paths, err := packetFiles(outDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	results, err := releasePackets(ctx, s, paths, false)
+	if err != nil {
+		t.Fatalf("releasePackets failed: %v", err)
+	}
+	var got []string
+	for _, r := range results {
+		got = append(got, r.Packet+":"+r.Result)
+	}
+	if want := "agent-1:done agent-2:released agent-3:kept"; strings.Join(got, " ") != want {
+		t.Errorf("results = %q, want %q", strings.Join(got, " "), want)
+	}
+	released, err := s.GetIssue(ctx, ids[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if released.Status != types.StatusOpen || released.Assignee != "" {
+		t.Errorf("released issue is %s for %q, want open and unassigned", released.Status, released.Assignee)
+	}
+	if left, _ := packetFiles(outDir); len(left) != 1 || filepath.Base(left[0]) != "agent-3.json" {
+		t.Errorf("packets left = %v, want only agent-3.json", left)
+	}
+}
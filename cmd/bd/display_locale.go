@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+var (
+	displayLocaleOnce  sync.Once
+	displayLocaleValue string
+)
+
+// displayLocale returns the configured locale for human-readable output,
+// or "" to show issues in their original language. An invalid setting is
+// reported once and ignored.
+func displayLocale() string {
+	displayLocaleOnce.Do(func() {
+		locale := config.GetString("locale")
+		if locale == "" {
+			return
+		}
+		normalized, err := types.NormalizeLocale(locale)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v; showing original text\n", err)
+			return
+		}
+		displayLocaleValue = normalized
+	})
+	return displayLocaleValue
+}
+
+// localizeForDisplay translates titles and descriptions read directly from
+// the store; the daemon does the same for list and ready when sent a locale
+func localizeForDisplay(issues []*types.Issue) {
+	if err := storage.Localize(rootCtx, store, issues, displayLocale()); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to load translations: %v\n", err)
+	}
+}
+
+// translationLocales lists the locales an issue is translated to
+func translationLocales(issue *types.Issue) string {
+	locales := make([]string, len(issue.Translations))
+	for i, t := range issue.Translations {
+		locales[i] = t.Locale
+	}
+	return strings.Join(locales, ", ")
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/beads/internal/timefmt"
+)
+
+var (
+	displayFormatterOnce sync.Once
+	displayFormatter     *timefmt.Formatter
+)
+
+// timeFormatter returns the formatter configured by time.zone and
+// time.format. An invalid setting is reported once and the default
+// (local zone, absolute) is used, so a typo never breaks a read command.
+func timeFormatter() *timefmt.Formatter {
+	displayFormatterOnce.Do(func() {
+		f, err := timefmt.FromConfig()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v; using local time\n", err)
+			f, _ = timefmt.New("", "")
+		}
+		displayFormatter = f
+	})
+	return displayFormatter
+}
+
+// displayTime renders a timestamp for human-readable output
+func displayTime(t time.Time) string {
+	return timeFormatter().Format(t)
+}
+
+// displayDate renders the day of a timestamp for human-readable output
+func displayDate(t time.Time) string {
+	return timeFormatter().FormatDate(t)
+}
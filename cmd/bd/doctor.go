@@ -24,6 +24,7 @@ import (
 	"github.com/steveyegge/beads/internal/daemon"
 	"github.com/steveyegge/beads/internal/git"
 	"github.com/steveyegge/beads/internal/syncbranch"
+	"github.com/steveyegge/beads/internal/util"
 )
 
 // Status constants for doctor checks
@@ -58,6 +59,8 @@ var (
 	doctorOutput      string // bd-9cc: export diagnostics to file
 	perfMode          bool
 	checkHealthMode   bool
+	scanSecretsMode   bool
+	verifyMode        bool
 )
 
 // ConfigKeyHintsDoctor is the config key for suppressing doctor hints
@@ -83,6 +86,7 @@ This command checks:
   - Database-JSONL sync status
   - File permissions
   - Circular dependencies
+  - Referential integrity (dangling deps/labels/comments, unknown assignees)
   - Git hooks (pre-commit, post-merge, pre-push)
   - .beads/.gitignore up to date
   - Metadata.json version tracking (LastBdVersion field)
@@ -94,6 +98,23 @@ Performance Mode (--perf):
   - Generates CPU profile for analysis
   - Outputs shareable report for bug reports
 
+Secret Scan (--scan-secrets):
+  Search stored issue text and the JSONL export for matches of the
+  redaction rules (redaction.* in config.yaml): API keys, tokens, private
+  keys, and any custom patterns such as internal hostnames. Run it before
+  pushing; exits non-zero if anything is found.
+
+Integrity Check (--verify):
+  Recompute every issue's checksum and compare the database with the JSONL
+  export, reporting rows changed outside bd, partial writes, and issues
+  that are missing, repeated or differ on either side. Also reports rows
+  referring to missing issues, children of missing or deleted parents,
+  corrupt timestamps, and attachment blobs that are missing or damaged.
+  With --fix, damaged rows are restored from the JSONL when it still holds
+  an intact copy, timestamps are rebuilt from the issue's history, dangling
+  rows are deleted, and the JSONL is re-exported when bd wrote it last.
+  Exits non-zero if problems remain.
+
 Export Mode (--output):
   Save diagnostics to a JSON file for historical analysis and bug reporting.
   Includes timestamp and platform info for tracking intermittent issues.
@@ -107,6 +128,8 @@ Examples:
   bd doctor --fix -i     # Confirm each fix individually (bd-3xl)
   bd doctor --dry-run    # Preview what --fix would do without making changes
   bd doctor --perf       # Performance diagnostics
+  bd doctor --scan-secrets  # Find secrets already stored in issues
+  bd doctor --verify --fix  # Detect and repair silent corruption
   bd doctor --output diagnostics.json  # Export diagnostics to file`,
 	Run: func(cmd *cobra.Command, args []string) {
 		// Use global jsonOutput set by PersistentPreRun
@@ -130,6 +153,18 @@ Examples:
 			return
 		}
 
+		// Scan stored text for secrets if --scan-secrets flag is set
+		if scanSecretsMode {
+			runScanSecrets(absPath)
+			return
+		}
+
+		// Verify issue checksums and the JSONL export if --verify flag is set
+		if verifyMode {
+			runVerify(absPath)
+			return
+		}
+
 		// Run quick health check if --check-health flag is set
 		if checkHealthMode {
 			runCheckHealth(absPath)
@@ -375,6 +410,10 @@ func applyFixList(path string, fixes []doctorCheck) {
 			err = fix.SchemaCompatibility(path)
 		case "Git Merge Driver":
 			err = fix.MergeDriver(path)
+		case "Referential Integrity":
+			err = fix.ReferentialIntegrity(path)
+		case "Checklist Dependencies":
+			err = fix.ChecklistDependencies(path)
 		case "Sync Branch Config":
 			// No auto-fix: sync-branch should be added to config.yaml (version controlled)
 			fmt.Printf("  ⚠ Add 'sync-branch: beads-sync' to .beads/config.yaml\n")
@@ -872,6 +911,18 @@ func runDiagnostics(path string) doctorResult {
 		result.OverallOK = false
 	}
 
+	// Check 10a: Referential integrity (dangling references, unknown assignees)
+	refIntegrityCheck := convertDoctorCheck(doctor.CheckReferentialIntegrity(path))
+	result.Checks = append(result.Checks, refIntegrityCheck)
+	if refIntegrityCheck.Status == statusError {
+		result.OverallOK = false
+	}
+
+	// Check 10b: Checklist references vs. checklist-derived dependencies
+	checklistCheck := convertDoctorCheck(doctor.CheckChecklistDependencies(path))
+	result.Checks = append(result.Checks, checklistCheck)
+	// Drift only affects ready-work ordering, so don't fail the overall check
+
 	// Check 11: Claude integration
 	claudeCheck := convertDoctorCheck(doctor.CheckClaude())
 	result.Checks = append(result.Checks, claudeCheck)
@@ -1826,7 +1877,7 @@ func countJSONLIssues(jsonlPath string) (int, map[string]int, error) {
 	prefixes := make(map[string]int)
 	errorCount := 0
 
-	scanner := bufio.NewScanner(file)
+	scanner := util.NewJSONLScanner(file)
 	for scanner.Scan() {
 		line := scanner.Bytes()
 		if len(line) == 0 {
@@ -2358,8 +2409,8 @@ func checkMergeDriver(path string) doctorCheck {
 		}
 	}
 
-	// Check if config is correct
-	if currentConfig != correctConfig {
+	// Check if config is correct; bd init --git-merge installs bd merge-driver
+	if currentConfig != correctConfig && currentConfig != structuredMergeDriver {
 		return doctorCheck{
 			Name:    "Git Merge Driver",
 			Status:  statusWarning,
@@ -2944,5 +2995,7 @@ func init() {
 	rootCmd.AddCommand(doctorCmd)
 	doctorCmd.Flags().BoolVar(&perfMode, "perf", false, "Run performance diagnostics and generate CPU profile")
 	doctorCmd.Flags().BoolVar(&checkHealthMode, "check-health", false, "Quick health check for git hooks (silent on success)")
+	doctorCmd.Flags().BoolVar(&scanSecretsMode, "scan-secrets", false, "Scan stored issues and JSONL for text matching redaction rules")
+	doctorCmd.Flags().BoolVar(&verifyMode, "verify", false, "Verify issue checksums and compare the database with the JSONL export")
 	doctorCmd.Flags().StringVarP(&doctorOutput, "output", "o", "", "Export diagnostics to JSON file (bd-9cc)")
 }
@@ -0,0 +1,150 @@
+package doctor
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	_ "github.com/ncruces/go-sqlite3/driver"
+	_ "github.com/ncruces/go-sqlite3/embed"
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/configfile"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// CheckChecklistDependencies reports issues whose checklist references and
+// checklist-derived soft dependencies disagree. They drift when descriptions
+// change through a path that doesn't sync them, such as a JSONL import or an
+// issue referenced before it existed.
+func CheckChecklistDependencies(repoPath string) DoctorCheck {
+	beadsDir := filepath.Join(repoPath, ".beads")
+
+	var dbPath string
+	if cfg, err := configfile.Load(beadsDir); err == nil && cfg != nil && cfg.Database != "" {
+		dbPath = cfg.DatabasePath(beadsDir)
+	} else {
+		dbPath = filepath.Join(beadsDir, beads.CanonicalDatabaseName)
+	}
+
+	if _, err := os.Stat(dbPath); os.IsNotExist(err) {
+		return DoctorCheck{
+			Name:    "Checklist Dependencies",
+			Status:  "ok",
+			Message: "N/A (no database)",
+		}
+	}
+
+	db, err := sql.Open("sqlite3", "file:"+dbPath+"?mode=ro&_pragma=busy_timeout(30000)")
+	if err != nil {
+		return DoctorCheck{
+			Name:    "Checklist Dependencies",
+			Status:  "warning",
+			Message: "Unable to open database",
+			Detail:  err.Error(),
+		}
+	}
+	defer db.Close()
+
+	details, drifted, err := findChecklistDrift(db)
+	if err != nil {
+		return DoctorCheck{
+			Name:    "Checklist Dependencies",
+			Status:  "warning",
+			Message: "Unable to check checklist dependencies",
+			Detail:  err.Error(),
+		}
+	}
+	if drifted == 0 {
+		return DoctorCheck{
+			Name:    "Checklist Dependencies",
+			Status:  "ok",
+			Message: "Checklist references and dependencies agree",
+		}
+	}
+	if len(details) > maxIntegrityDetails {
+		details = append(details[:maxIntegrityDetails], fmt.Sprintf("... and %d more", len(details)-maxIntegrityDetails))
+	}
+	return DoctorCheck{
+		Name:    "Checklist Dependencies",
+		Status:  "warning",
+		Message: fmt.Sprintf("%d issue(s) with checklist dependencies out of sync", drifted),
+		Detail:  strings.Join(details, "; "),
+		Fix:     "Run 'bd doctor --fix' to resync dependencies from checklists",
+	}
+}
+
+// findChecklistDrift returns one detail line per missing or stale checklist
+// dependency and the number of issues affected
+func findChecklistDrift(db *sql.DB) ([]string, int, error) {
+	descriptions := make(map[string]string)
+	live := make(map[string]bool)
+	var ids []string
+	rows, err := db.Query(`SELECT id, description, status FROM issues ORDER BY id`)
+	if err != nil {
+		return nil, 0, err
+	}
+	for rows.Next() {
+		var id, description, status string
+		if err := rows.Scan(&id, &description, &status); err != nil {
+			_ = rows.Close()
+			return nil, 0, err
+		}
+		if status == string(types.StatusTombstone) {
+			continue
+		}
+		live[id] = true
+		descriptions[id] = description
+		ids = append(ids, id)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	deps := make(map[string][]*types.Dependency)
+	dependsOn := make(map[string]map[string]bool)
+	rows, err = db.Query(`SELECT issue_id, depends_on_id, type, COALESCE(metadata, '') FROM dependencies`)
+	if err != nil {
+		return nil, 0, err
+	}
+	for rows.Next() {
+		dep := &types.Dependency{}
+		if err := rows.Scan(&dep.IssueID, &dep.DependsOnID, &dep.Type, &dep.Metadata); err != nil {
+			_ = rows.Close()
+			return nil, 0, err
+		}
+		deps[dep.IssueID] = append(deps[dep.IssueID], dep)
+		if dependsOn[dep.IssueID] == nil {
+			dependsOn[dep.IssueID] = make(map[string]bool)
+		}
+		dependsOn[dep.IssueID][dep.DependsOnID] = true
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	var details []string
+	drifted := 0
+	for _, id := range ids {
+		issueID := id
+		missing, stale := storage.ChecklistDrift(issueID, descriptions[issueID],
+			func(ref string) bool { return live[ref] },
+			deps[issueID],
+			func(ref string) bool { return dependsOn[ref][issueID] })
+		if len(missing) == 0 && len(stale) == 0 {
+			continue
+		}
+		drifted++
+		for _, ref := range missing {
+			details = append(details, fmt.Sprintf("%s lists %s without a dependency", issueID, ref))
+		}
+		for _, ref := range stale {
+			details = append(details, fmt.Sprintf("%s depends on %s but no longer lists it", issueID, ref))
+		}
+	}
+	return details, drifted, nil
+}
@@ -361,7 +361,7 @@ func checkDatabaseConfigValues(repoPath string) []string {
 			}
 			// Check for conflicts with built-in statuses
 			switch status {
-			case "open", "in_progress", "blocked", "closed":
+			case "open", "in_progress", "blocked", "resolved", "closed":
 				issues = append(issues, fmt.Sprintf("status.custom: %q conflicts with built-in status", status))
 			}
 		}
@@ -0,0 +1,50 @@
+package fix
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/configfile"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ChecklistDependencies resyncs every issue's checklist-derived soft
+// dependencies with the checklist items in its description
+func ChecklistDependencies(path string) error {
+	if err := validateBeadsWorkspace(path); err != nil {
+		return err
+	}
+
+	beadsDir := filepath.Join(path, ".beads")
+	dbPath := filepath.Join(beadsDir, beads.CanonicalDatabaseName)
+	if cfg, err := configfile.Load(beadsDir); err == nil && cfg != nil && cfg.Database != "" {
+		dbPath = cfg.DatabasePath(beadsDir)
+	}
+
+	ctx := context.Background()
+	store, err := sqlite.New(ctx, dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		return fmt.Errorf("failed to list issues: %w", err)
+	}
+	added, removed := 0, 0
+	for _, issue := range issues {
+		result, err := storage.SyncChecklistDependencies(ctx, store, issue.ID, issue.Description, "doctor")
+		if err != nil {
+			return fmt.Errorf("failed to sync %s: %w", issue.ID, err)
+		}
+		added += len(result.Added)
+		removed += len(result.Removed)
+	}
+	fmt.Printf("  Added %d and removed %d checklist dependencies\n", added, removed)
+	return nil
+}
@@ -0,0 +1,41 @@
+package fix
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+
+	_ "github.com/ncruces/go-sqlite3/driver"
+	_ "github.com/ncruces/go-sqlite3/embed"
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/configfile"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+)
+
+// ReferentialIntegrity deletes dependencies, labels, comments, and events that
+// reference missing issues. Unknown assignees are reported but not changed.
+func ReferentialIntegrity(path string) error {
+	if err := validateBeadsWorkspace(path); err != nil {
+		return err
+	}
+
+	beadsDir := filepath.Join(path, ".beads")
+	dbPath := filepath.Join(beadsDir, beads.CanonicalDatabaseName)
+	if cfg, err := configfile.Load(beadsDir); err == nil && cfg != nil && cfg.Database != "" {
+		dbPath = cfg.DatabasePath(beadsDir)
+	}
+
+	db, err := sql.Open("sqlite3", "file:"+dbPath+"?_pragma=busy_timeout(30000)")
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	removed, err := sqlite.RepairIntegrityViolations(context.Background(), db)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("  Removed %d dangling row(s)\n", removed)
+	return nil
+}
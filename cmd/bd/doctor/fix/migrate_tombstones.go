@@ -1,7 +1,6 @@
 package fix
 
 import (
-	"bufio"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -9,6 +8,7 @@ import (
 	"time"
 
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/util"
 )
 
 // legacyDeletionRecord represents a single deletion entry from the legacy deletions.jsonl manifest.
@@ -35,8 +35,7 @@ func loadLegacyDeletions(path string) (map[string]legacyDeletionRecord, error) {
 	}
 	defer f.Close()
 
-	scanner := bufio.NewScanner(f)
-	scanner.Buffer(make([]byte, 0, 1024), 1024*1024)
+	scanner := util.NewJSONLScanner(f)
 
 	for scanner.Scan() {
 		line := scanner.Text()
@@ -92,8 +91,7 @@ func MigrateTombstones(path string) error {
 	// Load existing JSONL to check for already-existing tombstones
 	existingTombstones := make(map[string]bool)
 	if file, err := os.Open(filepath.Clean(jsonlPath)); err == nil {
-		scanner := bufio.NewScanner(file)
-		scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+		scanner := util.NewJSONLScanner(file)
 		for scanner.Scan() {
 			var issue struct {
 				ID     string `json:"id"`
@@ -18,8 +18,12 @@ const GitignoreTemplate = `# SQLite databases
 daemon.lock
 daemon.log
 daemon.pid
+daemon.pause
 bd.sock
 
+# Field encryption key (never commit it)
+encryption.key
+
 # Local version tracking (prevents upgrade notification spam after git ops)
 .local_version
 
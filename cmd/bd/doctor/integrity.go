@@ -0,0 +1,120 @@
+package doctor
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	_ "github.com/ncruces/go-sqlite3/driver"
+	_ "github.com/ncruces/go-sqlite3/embed"
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/configfile"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+)
+
+// maxIntegrityDetails caps how many violations are listed in the check detail
+const maxIntegrityDetails = 5
+
+// CheckReferentialIntegrity reports rows that reference missing issues
+// (dependencies, labels, comments, events) and assignees outside the
+// integrity.assignees roster. Such rows can only exist in databases written
+// before foreign keys were enforced or by imports that bypassed them.
+func CheckReferentialIntegrity(repoPath string) DoctorCheck {
+	beadsDir := filepath.Join(repoPath, ".beads")
+
+	var dbPath string
+	if cfg, err := configfile.Load(beadsDir); err == nil && cfg != nil && cfg.Database != "" {
+		dbPath = cfg.DatabasePath(beadsDir)
+	} else {
+		dbPath = filepath.Join(beadsDir, beads.CanonicalDatabaseName)
+	}
+
+	if _, err := os.Stat(dbPath); os.IsNotExist(err) {
+		return DoctorCheck{
+			Name:    "Referential Integrity",
+			Status:  "ok",
+			Message: "N/A (no database)",
+		}
+	}
+
+	db, err := sql.Open("sqlite3", "file:"+dbPath+"?mode=ro&_pragma=busy_timeout(30000)")
+	if err != nil {
+		return DoctorCheck{
+			Name:    "Referential Integrity",
+			Status:  "warning",
+			Message: "Unable to open database",
+			Detail:  err.Error(),
+		}
+	}
+	defer db.Close()
+
+	violations, err := sqlite.FindIntegrityViolations(context.Background(), db)
+	if err != nil {
+		return DoctorCheck{
+			Name:    "Referential Integrity",
+			Status:  "warning",
+			Message: "Unable to check referential integrity",
+			Detail:  err.Error(),
+		}
+	}
+
+	if len(violations) == 0 {
+		return DoctorCheck{
+			Name:    "Referential Integrity",
+			Status:  "ok",
+			Message: "No dangling references",
+		}
+	}
+
+	dangling := 0
+	var details []string
+	for _, v := range violations {
+		if v.Kind != sqlite.ViolationUnknownAssignee {
+			dangling++
+		}
+		if len(details) < maxIntegrityDetails {
+			details = append(details, formatViolation(v))
+		}
+	}
+	if len(violations) > maxIntegrityDetails {
+		details = append(details, fmt.Sprintf("... and %d more", len(violations)-maxIntegrityDetails))
+	}
+
+	unknownAssignees := len(violations) - dangling
+	var parts []string
+	if dangling > 0 {
+		parts = append(parts, fmt.Sprintf("%d dangling reference(s)", dangling))
+	}
+	if unknownAssignees > 0 {
+		parts = append(parts, fmt.Sprintf("%d unknown assignee(s)", unknownAssignees))
+	}
+
+	fix := "Run 'bd doctor --fix' to delete dangling rows"
+	if dangling == 0 {
+		fix = "Reassign with 'bd update <id> --assignee <name>' or extend integrity.assignees"
+	} else if unknownAssignees > 0 {
+		fix += "; reassign unknown assignees with 'bd update <id> --assignee <name>'"
+	}
+
+	return DoctorCheck{
+		Name:    "Referential Integrity",
+		Status:  "error",
+		Message: "Found " + strings.Join(parts, " and "),
+		Detail:  strings.Join(details, "; "),
+		Fix:     fix,
+	}
+}
+
+func formatViolation(v sqlite.IntegrityViolation) string {
+	switch v.Kind {
+	case sqlite.ViolationMissingDependsOn:
+		return fmt.Sprintf("dependency %s → %s (missing target)", v.IssueID, v.Ref)
+	case sqlite.ViolationUnknownAssignee:
+		return fmt.Sprintf("%s assigned to unknown %q", v.IssueID, v.Ref)
+	default:
+		return fmt.Sprintf("%s row for missing issue %s", v.Table, v.IssueID)
+	}
+}
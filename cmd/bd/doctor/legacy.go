@@ -1,7 +1,6 @@
 package doctor
 
 import (
-	"bufio"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -10,6 +9,7 @@ import (
 
 	"github.com/steveyegge/beads/internal/beads"
 	"github.com/steveyegge/beads/internal/configfile"
+	"github.com/steveyegge/beads/internal/util"
 )
 
 // CheckLegacyBeadsSlashCommands detects old /beads:* slash commands in documentation
@@ -437,7 +437,7 @@ func countJSONLIssuesAndPrefix(jsonlPath string) (int, string) {
 	count := 0
 	prefixCounts := make(map[string]int)
 
-	scanner := bufio.NewScanner(file)
+	scanner := util.NewJSONLScanner(file)
 	for scanner.Scan() {
 		line := scanner.Bytes()
 		if len(line) == 0 {
@@ -0,0 +1,135 @@
+package doctor
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/configfile"
+	"github.com/steveyegge/beads/internal/redact"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/util"
+)
+
+// SecretFinding is a redaction rule match in stored issue text. Source is
+// "database" or the JSONL file name, so users can tell whether the secret is
+// already committed.
+type SecretFinding struct {
+	redact.Finding
+	Source string `json:"source"`
+}
+
+// ScanSecrets reports text in the database and the JSONL export that matches
+// the redactor's rules. The database is opened read-only; nothing is changed.
+func ScanSecrets(repoPath string, r *redact.Redactor) ([]SecretFinding, error) {
+	beadsDir := filepath.Join(repoPath, ".beads")
+	cfg, err := configfile.Load(beadsDir)
+	if err != nil || cfg == nil {
+		cfg = configfile.DefaultConfig()
+	}
+
+	var findings []SecretFinding
+
+	dbPath := cfg.DatabasePath(beadsDir)
+	if cfg.Database == "" {
+		dbPath = filepath.Join(beadsDir, beads.CanonicalDatabaseName)
+	}
+	if _, err := os.Stat(dbPath); err == nil {
+		issues, err := readIssueText(dbPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read database: %w", err)
+		}
+		for _, issue := range issues {
+			for _, f := range r.Scan(issue) {
+				findings = append(findings, SecretFinding{Finding: f, Source: "database"})
+			}
+		}
+	}
+
+	jsonlPath := cfg.JSONLPath(beadsDir)
+	if _, err := os.Stat(jsonlPath); err == nil {
+		issues, err := readJSONLIssues(jsonlPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", filepath.Base(jsonlPath), err)
+		}
+		for _, issue := range issues {
+			for _, f := range r.Scan(issue) {
+				findings = append(findings, SecretFinding{Finding: f, Source: filepath.Base(jsonlPath)})
+			}
+		}
+	}
+
+	return findings, nil
+}
+
+// readIssueText loads the free-text fields and comments of every issue
+func readIssueText(dbPath string) ([]*types.Issue, error) {
+	db, err := sql.Open("sqlite3", "file:"+dbPath+"?mode=ro&_pragma=busy_timeout(30000)")
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	rows, err := db.Query(`SELECT id, title, description, design, acceptance_criteria, notes FROM issues ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var issues []*types.Issue
+	byID := make(map[string]*types.Issue)
+	for rows.Next() {
+		var issue types.Issue
+		var design, acceptance, notes sql.NullString
+		if err := rows.Scan(&issue.ID, &issue.Title, &issue.Description, &design, &acceptance, &notes); err != nil {
+			return nil, err
+		}
+		issue.Design, issue.AcceptanceCriteria, issue.Notes = design.String, acceptance.String, notes.String
+		issues = append(issues, &issue)
+		byID[issue.ID] = &issue
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	comments, err := db.Query(`SELECT id, issue_id, text FROM comments ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer comments.Close()
+	for comments.Next() {
+		var c types.Comment
+		if err := comments.Scan(&c.ID, &c.IssueID, &c.Text); err != nil {
+			return nil, err
+		}
+		if issue := byID[c.IssueID]; issue != nil {
+			issue.Comments = append(issue.Comments, &c)
+		}
+	}
+	return issues, comments.Err()
+}
+
+// readJSONLIssues parses the JSONL export, skipping malformed lines (other
+// checks report those)
+func readJSONLIssues(path string) ([]*types.Issue, error) {
+	// #nosec G304 -- path is the configured JSONL export inside .beads
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var issues []*types.Issue
+	scanner := util.NewJSONLScanner(f)
+	for scanner.Scan() {
+		var issue types.Issue
+		if json.Unmarshal(scanner.Bytes(), &issue) != nil || issue.ID == "" {
+			continue
+		}
+		issues = append(issues, &issue)
+	}
+	return issues, scanner.Err()
+}
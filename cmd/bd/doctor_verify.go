@@ -0,0 +1,549 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/fatih/color"
+	"github.com/steveyegge/beads/internal/attachments"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/configfile"
+	"github.com/steveyegge/beads/internal/encryption"
+	"github.com/steveyegge/beads/internal/redact"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/util"
+)
+
+// Kinds of problems bd doctor --verify reports
+const (
+	verifyChecksum       = "checksum"              // fields don't hash to the stored checksum
+	verifyExportMismatch = "export_mismatch"       // JSONL copy differs from the database
+	verifyMissingJSONL   = "missing_from_jsonl"    // in the database, not exported
+	verifyMissingDB      = "missing_from_database" // in the JSONL, not in the database
+	verifyMalformedJSONL = "malformed_jsonl"       // JSONL line that doesn't parse
+	verifyDuplicateID    = "duplicate_id"          // JSONL holds the issue more than once
+	verifyDanglingRef    = "dangling_reference"    // dependency, label, comment... of a missing issue
+	verifyDanglingParent = "dangling_parent"       // child of a missing or deleted issue
+	verifyTimestamp      = "bad_timestamp"         // corrupt or contradictory timestamp
+	verifyBrokenBlob     = "broken_blob"           // attachment blob missing or corrupt
+)
+
+// VerifyProblem is one integrity problem found by bd doctor --verify
+type VerifyProblem struct {
+	IssueID string `json:"issue_id,omitempty"`
+	Kind    string `json:"kind"`
+	Detail  string `json:"detail"`
+	Repair  string `json:"repair,omitempty"` // what --fix did about it
+}
+
+// VerifyReport is the result of bd doctor --verify
+type VerifyReport struct {
+	Database      string           `json:"database"`
+	JSONL         string           `json:"jsonl,omitempty"`
+	Checked       int              `json:"checked"`
+	PendingExport int              `json:"pending_export"` // changed since the last export; not compared
+	JSONLChanged  bool             `json:"jsonl_changed"`  // JSONL differs from what bd last wrote or read
+	Problems      []*VerifyProblem `json:"problems"`
+	Repaired      []*VerifyProblem `json:"repaired,omitempty"`
+	Skipped       string           `json:"skipped,omitempty"` // why the JSONL wasn't compared
+
+	stored map[string]string       // stored checksum of each checksum problem
+	jsonl  map[string]*types.Issue // parsed JSONL, by ID
+}
+
+// runVerify implements bd doctor --verify: recompute every issue's checksum
+// and compare the database with its JSONL export
+func runVerify(path string) {
+	ctx := rootCtx
+	beadsDir := filepath.Join(path, ".beads")
+	dbFile := getCheckHealthDBPath(beadsDir)
+	if _, err := os.Stat(dbFile); err != nil {
+		FatalErrorWithHint(fmt.Sprintf("no database at %s", dbFile), "run 'bd init' first")
+	}
+	cfg, err := configfile.Load(beadsDir)
+	if err != nil || cfg == nil {
+		cfg = configfile.DefaultConfig()
+	}
+	jsonlPath := cfg.JSONLPath(beadsDir)
+
+	s, err := sqlite.New(ctx, dbFile)
+	if err != nil {
+		FatalError("failed to open database: %v", err)
+	}
+	defer func() { _ = s.Close() }()
+	redactor := loadRedactor()
+	cipher, err := encryption.FromConfig(beadsDir)
+	if err != nil {
+		FatalErrorWithHint(err.Error(), "fix encryption.fields in .beads/config.yaml or the encryption key")
+	}
+
+	report, err := verifyIntegrity(ctx, s, jsonlPath, redactor, cipher)
+	if err != nil {
+		FatalError("%v", err)
+	}
+	if doctorFix && len(report.Problems) > 0 {
+		repaired, err := repairIntegrity(ctx, s, jsonlPath, report)
+		if err != nil {
+			FatalError("repair failed: %v", err)
+		}
+		if report, err = verifyIntegrity(ctx, s, jsonlPath, redactor, cipher); err != nil {
+			FatalError("%v", err)
+		}
+		report.Repaired = repaired
+	}
+
+	if jsonOutput {
+		if report.Problems == nil {
+			report.Problems = []*VerifyProblem{}
+		}
+		outputJSON(report)
+	} else {
+		printVerifyReport(report)
+	}
+	if len(report.Problems) > 0 {
+		os.Exit(1)
+	}
+}
+
+// verifyIntegrity checks every issue's checksum, timestamps, references,
+// parents and attachment blobs and, unless the JSONL is missing, that each
+// issue's JSONL copy matches what an export would write
+func verifyIntegrity(ctx context.Context, s *sqlite.SQLiteStorage, jsonlPath string, r *redact.Redactor, c *encryption.Cipher) (*VerifyReport, error) {
+	report := &VerifyReport{Database: s.Path(), stored: make(map[string]string)}
+	defer func() {
+		sort.SliceStable(report.Problems, func(i, j int) bool { return report.Problems[i].IssueID < report.Problems[j].IssueID })
+	}()
+
+	readable, err := verifyRows(ctx, s, report)
+	if err != nil {
+		return nil, err
+	}
+	if !readable {
+		// Issues with unreadable timestamps can't be loaded to compare
+		report.Skipped = "issues with unreadable timestamps can't be read; repair them to compare with the JSONL"
+		if err := s.UnderlyingDB().QueryRowContext(ctx, `SELECT COUNT(*) FROM issues`).Scan(&report.Checked); err != nil {
+			return nil, err
+		}
+		return report, nil
+	}
+
+	mismatches, err := s.VerifyContentHashes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range mismatches {
+		report.stored[m.IssueID] = m.Stored
+		report.Problems = append(report.Problems, &VerifyProblem{
+			IssueID: m.IssueID,
+			Kind:    verifyChecksum,
+			Detail:  "fields don't match the stored checksum (partial write or edit outside bd)",
+		})
+	}
+
+	// Tombstones are included so that JSONL files written with them don't
+	// look like they hold issues the database lacks
+	issues, err := s.SearchIssues(ctx, "", types.IssueFilter{IncludeTombstones: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read issues: %w", err)
+	}
+	report.Checked = len(issues)
+
+	// In multi-repo mode issues are spread over several JSONL files
+	if _, err := os.Stat(jsonlPath); err != nil || config.GetMultiRepoConfig() != nil {
+		return report, nil
+	}
+	report.JSONL = jsonlPath
+	report.JSONLChanged = hasJSONLChanged(ctx, s, jsonlPath, "")
+
+	jsonl, malformed, duplicates, err := readVerifyJSONL(jsonlPath)
+	if err != nil {
+		return nil, err
+	}
+	report.jsonl = jsonl
+	for _, line := range malformed {
+		report.Problems = append(report.Problems, &VerifyProblem{Kind: verifyMalformedJSONL, Detail: line})
+	}
+	for _, dup := range duplicates {
+		report.Problems = append(report.Problems, &VerifyProblem{IssueID: dup.id, Kind: verifyDuplicateID, Detail: dup.detail})
+	}
+
+	dirtyIDs, err := s.GetDirtyIssues(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dirty issues: %w", err)
+	}
+	dirty := make(map[string]bool, len(dirtyIDs))
+	for _, id := range dirtyIDs {
+		dirty[id] = true
+	}
+
+	inDB := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		inDB[issue.ID] = true
+		if dirty[issue.ID] {
+			report.PendingExport++
+			continue
+		}
+		exported, ok := jsonl[issue.ID]
+		if !ok && issue.Status == types.StatusTombstone {
+			continue
+		}
+		if !ok {
+			report.Problems = append(report.Problems, &VerifyProblem{
+				IssueID: issue.ID,
+				Kind:    verifyMissingJSONL,
+				Detail:  "not in the JSONL although nothing is waiting to be exported",
+			})
+			continue
+		}
+		want, err := exportChecksum(issue, r, c)
+		if err != nil {
+			return nil, err
+		}
+		got, err := jsonlChecksum(exported, c)
+		if err != nil || got != want {
+			detail := "JSONL copy differs from the database"
+			if err != nil {
+				detail = fmt.Sprintf("JSONL copy can't be decrypted: %v", err)
+			}
+			report.Problems = append(report.Problems, &VerifyProblem{IssueID: issue.ID, Kind: verifyExportMismatch, Detail: detail})
+		}
+	}
+	for id := range jsonl {
+		if !inDB[id] {
+			report.Problems = append(report.Problems, &VerifyProblem{
+				IssueID: id,
+				Kind:    verifyMissingDB,
+				Detail:  "in the JSONL but not in the database",
+			})
+		}
+	}
+	return report, nil
+}
+
+// verifyRows checks the database rows themselves: timestamps, references
+// to missing issues, children of missing parents and attachment blobs. It
+// reports false if some issue's timestamps are unreadable, which makes
+// loading that issue fail.
+func verifyRows(ctx context.Context, s *sqlite.SQLiteStorage, report *VerifyReport) (bool, error) {
+	db := s.UnderlyingDB()
+	readable := true
+
+	stamps, err := sqlite.FindTimestampProblems(ctx, db)
+	if err != nil {
+		return false, err
+	}
+	for _, p := range stamps {
+		if p.Kind == sqlite.TimestampUnreadable {
+			readable = false
+		}
+		report.Problems = append(report.Problems, &VerifyProblem{IssueID: p.IssueID, Kind: verifyTimestamp, Detail: describeTimestampProblem(p)})
+	}
+
+	violations, err := sqlite.FindIntegrityViolations(ctx, db)
+	if err != nil {
+		return false, err
+	}
+	for _, v := range violations {
+		detail := fmt.Sprintf("%s row for missing issue %s", v.Table, v.IssueID)
+		switch v.Kind {
+		case sqlite.ViolationUnknownAssignee:
+			continue // a roster question for bd doctor, not damage
+		case sqlite.ViolationMissingDependsOn:
+			detail = fmt.Sprintf("depends on missing issue %s", v.Ref)
+		}
+		report.Problems = append(report.Problems, &VerifyProblem{IssueID: v.IssueID, Kind: verifyDanglingRef, Detail: detail})
+	}
+
+	parents, err := sqlite.FindDanglingParents(ctx, db)
+	if err != nil {
+		return false, err
+	}
+	for _, p := range parents {
+		detail := fmt.Sprintf("parent %s doesn't exist", p.ParentID)
+		if p.Kind == sqlite.ParentDeleted {
+			detail = fmt.Sprintf("parent %s was deleted", p.ParentID)
+		}
+		report.Problems = append(report.Problems, &VerifyProblem{IssueID: p.IssueID, Kind: verifyDanglingParent, Detail: detail})
+	}
+
+	blobs, err := findBrokenBlobs(ctx, db, filepath.Join(filepath.Dir(s.Path()), "blobs"))
+	if err != nil {
+		return false, err
+	}
+	report.Problems = append(report.Problems, blobs...)
+	return readable, nil
+}
+
+func describeTimestampProblem(p sqlite.TimestampProblem) string {
+	switch p.Kind {
+	case sqlite.TimestampUnreadable:
+		return fmt.Sprintf("%s %q is not a date", p.Column, p.Value)
+	case sqlite.TimestampOutOfRange:
+		return fmt.Sprintf("%s %s is before 1970 or in the future", p.Column, p.Value)
+	case sqlite.TimestampBeforeCreated:
+		return fmt.Sprintf("%s %s is before created_at", p.Column, p.Value)
+	case sqlite.TimestampMissing:
+		return "closed without closed_at"
+	case sqlite.TimestampNotClosed:
+		return fmt.Sprintf("closed_at %s set on an issue that isn't closed", p.Value)
+	}
+	return fmt.Sprintf("%s: %s", p.Column, p.Kind)
+}
+
+// findBrokenBlobs reports attachments stored in the blob store (blob: URLs,
+// see bd attach) whose blob is missing from blobDir or doesn't hash to the
+// checksum recorded for it. Other providers' attachments live elsewhere
+// and aren't checked.
+func findBrokenBlobs(ctx context.Context, db *sql.DB, blobDir string) ([]*VerifyProblem, error) {
+	rows, err := db.QueryContext(ctx, `SELECT issue_id, name, url, sha256 FROM attachments WHERE url LIKE 'blob:%' ORDER BY issue_id, id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read attachments: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var problems []*VerifyProblem
+	checked := make(map[string]string) // blob hash -> what's wrong with it
+	for rows.Next() {
+		var issueID, name, rawURL, sha string
+		if err := rows.Scan(&issueID, &name, &rawURL, &sha); err != nil {
+			return nil, fmt.Errorf("failed to scan attachment: %w", err)
+		}
+		u, err := url.Parse(rawURL)
+		if err != nil || len(u.Opaque) != 64 {
+			problems = append(problems, &VerifyProblem{IssueID: issueID, Kind: verifyBrokenBlob, Detail: fmt.Sprintf("%s: malformed blob URL %q", name, rawURL)})
+			continue
+		}
+		wrong, ok := checked[u.Opaque]
+		if !ok {
+			// #nosec G304 - path built from the blob's hash
+			data, err := os.ReadFile(attachments.BlobPath(blobDir, u.Opaque))
+			switch {
+			case os.IsNotExist(err):
+				wrong = "blob is missing (pull the commit that added it)"
+			case err != nil:
+				wrong = fmt.Sprintf("blob can't be read: %v", err)
+			case attachments.Hash(data) != u.Opaque:
+				wrong = "blob content doesn't match its hash"
+			}
+			checked[u.Opaque] = wrong
+		}
+		if wrong == "" && sha != "" && sha != u.Opaque {
+			wrong = "recorded checksum doesn't match the blob"
+		}
+		if wrong != "" {
+			problems = append(problems, &VerifyProblem{IssueID: issueID, Kind: verifyBrokenBlob, Detail: fmt.Sprintf("%s: %s", name, wrong)})
+		}
+	}
+	return problems, rows.Err()
+}
+
+// exportChecksum is the checksum of an issue as an export would write it,
+// with redaction applied and encrypted fields compared as plaintext (their
+// ciphertext differs on every export)
+func exportChecksum(issue *types.Issue, r *redact.Redactor, c *encryption.Cipher) (string, error) {
+	view := *r.ForExport(issue)
+	if c.HasKey() {
+		if err := c.DecryptIssue(&view); err != nil {
+			return "", err
+		}
+	}
+	return view.ComputeContentHash(), nil
+}
+
+// jsonlChecksum is the checksum of an issue read from the JSONL
+func jsonlChecksum(issue *types.Issue, c *encryption.Cipher) (string, error) {
+	view := *issue
+	if c.HasKey() {
+		if err := c.DecryptIssue(&view); err != nil {
+			return "", err
+		}
+	}
+	return view.ComputeContentHash(), nil
+}
+
+// jsonlDuplicate is a JSONL line repeating an issue ID
+type jsonlDuplicate struct {
+	id     string
+	detail string
+}
+
+// readVerifyJSONL parses the JSONL by issue ID and describes the lines
+// that don't parse and those that repeat an ID (a bad merge or an
+// interrupted write). The last copy of a repeated issue wins, as on import.
+func readVerifyJSONL(path string) (map[string]*types.Issue, []string, []jsonlDuplicate, error) {
+	// #nosec G304 -- path is the configured JSONL export inside .beads
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	issues := make(map[string]*types.Issue)
+	lines := make(map[string]int)
+	var malformed []string
+	var duplicates []jsonlDuplicate
+	scanner := util.NewJSONLScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var issue types.Issue
+		if err := json.Unmarshal(line, &issue); err != nil {
+			malformed = append(malformed, fmt.Sprintf("line %d: %v", n, err))
+			continue
+		}
+		if issue.ID == "" {
+			malformed = append(malformed, fmt.Sprintf("line %d: no issue ID", n))
+			continue
+		}
+		if first, ok := lines[issue.ID]; ok {
+			duplicates = append(duplicates, jsonlDuplicate{id: issue.ID, detail: fmt.Sprintf("line %d repeats line %d", n, first)})
+		} else {
+			lines[issue.ID] = n
+		}
+		issues[issue.ID] = &issue
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to read %s: %w", filepath.Base(path), err)
+	}
+	return issues, malformed, duplicates, nil
+}
+
+// repairIntegrity fixes what verifyIntegrity found. A row whose checksum
+// fails is restored from the JSONL when the JSONL copy still hashes to the
+// stored checksum, and otherwise accepted as it is. Timestamps are repaired
+// from the issue's other timestamps and history, and rows referring to
+// missing issues are deleted. JSONL problems are fixed by exporting again,
+// unless the JSONL changed since bd last wrote or read it: then it may hold
+// someone else's work, and it is left alone. Dangling parents and broken
+// blobs need someone to restore what is missing, so they are only reported.
+func repairIntegrity(ctx context.Context, s *sqlite.SQLiteStorage, jsonlPath string, report *VerifyReport) ([]*VerifyProblem, error) {
+	var repaired []*VerifyProblem
+	reexport := false
+	fixedTimestamps, fixedRefs := false, false
+	for _, p := range report.Problems {
+		switch p.Kind {
+		case verifyTimestamp:
+			if !fixedTimestamps {
+				if _, err := sqlite.RepairTimestamps(ctx, s.UnderlyingDB()); err != nil {
+					return repaired, err
+				}
+				fixedTimestamps = true
+			}
+			p.Repair = "taken from the issue's other timestamps and history"
+			repaired = append(repaired, p)
+		case verifyDanglingRef:
+			if !fixedRefs {
+				if _, err := s.RepairIntegrityViolations(ctx); err != nil {
+					return repaired, err
+				}
+				fixedRefs = true
+			}
+			p.Repair = "deleted the dangling row"
+			repaired = append(repaired, p)
+		case verifyChecksum:
+			if copy, ok := report.jsonl[p.IssueID]; ok && copy.ComputeContentHash() == report.stored[p.IssueID] {
+				if err := s.UpdateIssue(ctx, p.IssueID, checksumFields(copy), "bd-doctor"); err != nil {
+					return repaired, fmt.Errorf("failed to restore %s: %w", p.IssueID, err)
+				}
+				p.Repair = "restored from the JSONL"
+			} else {
+				if err := s.RefreshContentHash(ctx, p.IssueID); err != nil {
+					return repaired, err
+				}
+				p.Repair = "no intact copy; accepted the current contents"
+			}
+			repaired = append(repaired, p)
+		case verifyExportMismatch, verifyMissingJSONL, verifyMalformedJSONL, verifyDuplicateID:
+			if report.JSONLChanged {
+				continue
+			}
+			reexport = true
+			p.Repair = "exported again from the database"
+			repaired = append(repaired, p)
+		}
+	}
+	if reexport {
+		if err := exportToJSONLWithStore(ctx, s, jsonlPath); err != nil {
+			return repaired, fmt.Errorf("export failed: %w", err)
+		}
+		log := daemonLogger{logFunc: func(format string, args ...interface{}) {
+			fmt.Fprintf(os.Stderr, format+"\n", args...)
+		}}
+		updateExportMetadata(ctx, s, jsonlPath, log, "")
+	}
+	return repaired, nil
+}
+
+// checksumFields are the hashed fields of issue as UpdateIssue updates
+func checksumFields(issue *types.Issue) map[string]interface{} {
+	updates := map[string]interface{}{
+		"title":               issue.Title,
+		"description":         issue.Description,
+		"design":              issue.Design,
+		"acceptance_criteria": issue.AcceptanceCriteria,
+		"notes":               issue.Notes,
+		"status":              string(issue.Status),
+		"priority":            issue.Priority,
+		"issue_type":          string(issue.IssueType),
+		"assignee":            issue.Assignee,
+		"external_ref":        nil,
+		"complexity":          string(issue.Complexity),
+		"source":              issue.Source,
+	}
+	if issue.ExternalRef != nil {
+		updates["external_ref"] = *issue.ExternalRef
+	}
+	return updates
+}
+
+func printVerifyReport(report *VerifyReport) {
+	green := color.New(color.FgGreen).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+
+	for _, p := range report.Repaired {
+		fmt.Printf("%s %-10s %s: %s\n", green("✓"), p.IssueID, p.Kind, p.Repair)
+	}
+	if len(report.Repaired) > 0 {
+		fmt.Println()
+	}
+
+	if report.Skipped != "" {
+		fmt.Println(yellow("JSONL not compared: " + report.Skipped))
+	} else if report.JSONL == "" {
+		fmt.Println(yellow("No JSONL export found; checked the database only"))
+	}
+	if len(report.Problems) == 0 {
+		fmt.Printf("%s Verified %d issue(s): checksums match", green("✓"), report.Checked)
+		if report.JSONL != "" {
+			fmt.Printf(" and %s agrees with the database", filepath.Base(report.JSONL))
+		}
+		fmt.Println()
+		if report.PendingExport > 0 {
+			fmt.Printf("  %d issue(s) changed since the last export were not compared\n", report.PendingExport)
+		}
+		return
+	}
+
+	fmt.Printf("%s Found %d problem(s) in %d issue(s):\n\n", red("✖"), len(report.Problems), report.Checked)
+	for _, p := range report.Problems {
+		fmt.Printf("  %-10s %-22s %s\n", p.IssueID, p.Kind, p.Detail)
+	}
+	fmt.Println()
+	if report.JSONLChanged {
+		fmt.Printf("%s changed since bd last wrote or read it, so it may hold work that isn't\n", filepath.Base(report.JSONL))
+		fmt.Println("imported yet. Run 'bd sync --import-only' if the change is expected, or restore")
+		fmt.Println("the file from git if it isn't, then verify again.")
+	} else if !doctorFix {
+		fmt.Println("Run 'bd doctor --verify --fix' to repair.")
+	}
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestVerifyIntegrity(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	s := newTestStore(t, filepath.Join(dir, "beads.db"))
+	jsonlPath := filepath.Join(dir, "issues.jsonl")
+
+	var ids []string
+	for _, title := range []string{"Intact", "Tampered", "Rewritten"} {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, issue.ID)
+	}
+	if err := exportToJSONLWithStore(ctx, s, jsonlPath); err != nil {
+		t.Fatal(err)
+	}
+	log := daemonLogger{logFunc: func(string, ...interface{}) {}}
+	updateExportMetadata(ctx, s, jsonlPath, log, "")
+
+	report, err := verifyIntegrity(ctx, s, jsonlPath, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Checked != 3 || len(report.Problems) != 0 {
+		t.Fatalf("clean report = %+v", report)
+	}
+
+	// A row edited behind bd's back, and a row whose export went stale
+	if _, err := s.UnderlyingDB().ExecContext(ctx, `UPDATE issues SET title = 'garbage' WHERE id = ?`, ids[1]); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.UnderlyingDB().ExecContext(ctx, `UPDATE issues SET priority = 0 WHERE id = ?`, ids[2]); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.RefreshContentHash(ctx, ids[2]); err != nil {
+		t.Fatal(err)
+	}
+	report, err = verifyIntegrity(ctx, s, jsonlPath, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kinds := make(map[string]string)
+	for _, p := range report.Problems {
+		kinds[p.IssueID] += p.Kind + " "
+	}
+	if !strings.Contains(kinds[ids[1]], verifyChecksum) || kinds[ids[2]] != verifyExportMismatch+" " || kinds[ids[0]] != "" {
+		t.Fatalf("problems = %v", kinds)
+	}
+
+	repaired, err := repairIntegrity(ctx, s, jsonlPath, report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(repaired) == 0 {
+		t.Fatal("nothing repaired")
+	}
+	restored, err := s.GetIssue(ctx, ids[1])
+	if err != nil || restored.Title != "Tampered" {
+		t.Errorf("tampered issue after repair = %+v, %v", restored, err)
+	}
+	if report, err = verifyIntegrity(ctx, s, jsonlPath, nil, nil); err != nil || len(report.Problems) != 0 {
+		t.Errorf("report after repair = %+v, %v", report, err)
+	}
+	data, err := os.ReadFile(jsonlPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"priority":0`) {
+		t.Errorf("JSONL not re-exported:\n%s", data)
+	}
+}
+
+func TestVerifyIntegrityRows(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	s := newTestStore(t, filepath.Join(dir, "beads.db"))
+	jsonlPath := filepath.Join(dir, "issues.jsonl")
+
+	var ids []string
+	for _, title := range []string{"Garbled", "Attached", "Linked"} {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, issue.ID)
+	}
+	if err := exportToJSONLWithStore(ctx, s, jsonlPath); err != nil {
+		t.Fatal(err)
+	}
+	log := daemonLogger{logFunc: func(string, ...interface{}) {}}
+	updateExportMetadata(ctx, s, jsonlPath, log, "")
+
+	// An attachment whose blob never arrived, a dependency on an issue that
+	// doesn't exist, and a timestamp that can't be read
+	sha := strings.Repeat("ab", 32)
+	if err := s.AddAttachment(ctx, &types.Attachment{IssueID: ids[1], Name: "trace.log", URL: "blob:" + sha + "#trace.log", SHA256: sha}); err != nil {
+		t.Fatal(err)
+	}
+	conn, err := s.UnderlyingDB().Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	for _, stmt := range []string{
+		`PRAGMA foreign_keys = OFF`,
+		`INSERT INTO dependencies (issue_id, depends_on_id, type, created_by) VALUES ('` + ids[2] + `', 'bd-gone', 'blocks', 'test')`,
+		`UPDATE issues SET created_at = 'garbage' WHERE id = '` + ids[0] + `'`,
+	} {
+		if _, err := conn.ExecContext(ctx, stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	report, err := verifyIntegrity(ctx, s, jsonlPath, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kinds := make(map[string]string)
+	for _, p := range report.Problems {
+		kinds[p.IssueID] += p.Kind + " "
+	}
+	if kinds[ids[0]] != verifyTimestamp+" " || kinds[ids[1]] != verifyBrokenBlob+" " || kinds[ids[2]] != verifyDanglingRef+" " {
+		t.Fatalf("problems = %v", kinds)
+	}
+	if report.Skipped == "" || report.Checked != 3 {
+		t.Errorf("report = %+v, want the JSONL comparison skipped", report)
+	}
+
+	repaired, err := repairIntegrity(ctx, s, jsonlPath, report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(repaired) != 2 {
+		t.Errorf("repaired = %+v, want the timestamp and the dependency", repaired)
+	}
+
+	// With the issues readable again the JSONL is compared, and a line
+	// repeated by a bad merge shows up
+	data, err := os.ReadFile(jsonlPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, _, _ := strings.Cut(string(data), "\n")
+	if err := os.WriteFile(jsonlPath, append(data, first+"\n"...), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if report, err = verifyIntegrity(ctx, s, jsonlPath, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	kinds = make(map[string]string)
+	for _, p := range report.Problems {
+		kinds[p.IssueID] += p.Kind + " "
+	}
+	if report.Skipped != "" || !strings.Contains(kinds[ids[1]], verifyBrokenBlob) || len(report.Problems) != 2 || !strings.Contains(fmt.Sprint(kinds), verifyDuplicateID) {
+		t.Errorf("problems after repair = %v (skipped %q), want the blob and the duplicate line", kinds, report.Skipped)
+	}
+}
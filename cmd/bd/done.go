@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"golang.org/x/term"
+)
+
+// donePrompt returns the reader to ask about unconfirmed criteria on, or
+// nil when bd close isn't run interactively
+func donePrompt(jsonOutput bool) *bufio.Reader {
+	if jsonOutput || !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return nil
+	}
+	return bufio.NewReader(os.Stdin)
+}
+
+// issueDoneCriteria returns an issue's definition of done, through the
+// daemon when one is running
+func issueDoneCriteria(ctx context.Context, id string) ([]types.DoneCriterion, error) {
+	if daemonClient != nil {
+		resp, err := daemonClient.Show(&rpc.ShowArgs{ID: id})
+		if err != nil {
+			return nil, err
+		}
+		var details struct {
+			DefinitionOfDone []types.DoneCriterion `json:"definition_of_done"`
+		}
+		if err := json.Unmarshal(resp.Data, &details); err != nil {
+			return nil, err
+		}
+		return details.DefinitionOfDone, nil
+	}
+	return storage.GetDoneCriteria(ctx, store, id)
+}
+
+// confirmDoneCriteria records the criteria of an issue's definition of done
+// confirmed with --done ("all" confirms every one) or, when prompt is set,
+// by answering y. Returns the criteria still unconfirmed.
+func confirmDoneCriteria(ctx context.Context, id string, done []string, prompt *bufio.Reader) ([]string, error) {
+	criteria, err := issueDoneCriteria(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	pending := types.PendingCriteria(criteria)
+	if len(pending) == 0 {
+		return nil, nil
+	}
+
+	all := false
+	for _, d := range done {
+		all = all || strings.EqualFold(d, "all")
+	}
+	var confirmed, remaining []string
+	asked := false
+	for _, c := range pending {
+		ok := all
+		for _, d := range done {
+			ok = ok || strings.EqualFold(strings.TrimSpace(d), c)
+		}
+		if !ok && prompt != nil {
+			if !asked {
+				fmt.Printf("\nDefinition of done for %s:\n", id)
+				asked = true
+			}
+			fmt.Printf("  %s? [y/N] ", c)
+			answer, _ := prompt.ReadString('\n')
+			answer = strings.ToLower(strings.TrimSpace(answer))
+			ok = answer == "y" || answer == "yes"
+		}
+		if ok {
+			confirmed = append(confirmed, c)
+		} else {
+			remaining = append(remaining, c)
+		}
+	}
+	if len(confirmed) == 0 {
+		return remaining, nil
+	}
+
+	text := types.DoneComment(confirmed)
+	if daemonClient != nil {
+		_, err = daemonClient.AddComment(&rpc.CommentAddArgs{ID: id, Author: actor, Text: text})
+	} else {
+		_, err = store.AddIssueComment(ctx, id, actor, text)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("recording definition of done: %w", err)
+	}
+	return remaining, nil
+}
+
+// warnPendingDone notes a close that left criteria unconfirmed (allowed
+// unless done.strict is set)
+func warnPendingDone(id string, pending []string) {
+	if len(pending) == 0 {
+		return
+	}
+	yellow := color.New(color.FgYellow).SprintFunc()
+	fmt.Fprintf(os.Stderr, "%s %s closed with its definition of done unconfirmed: %s\n", yellow("⚠"), id, strings.Join(pending, ", "))
+}
+
+func printDoneCriteria(criteria []types.DoneCriterion) {
+	if len(criteria) == 0 {
+		return
+	}
+	dim := color.New(color.Faint).SprintFunc()
+	fmt.Printf("\nDefinition of Done:\n")
+	for _, c := range criteria {
+		mark, by := "[ ]", ""
+		if c.Done {
+			mark = "[x]"
+			if c.ConfirmedBy != "" {
+				by = " (" + c.ConfirmedBy + ")"
+			}
+		}
+		fmt.Printf("  %s %s%s %s\n", mark, c.Criterion, by, dim(c.Label))
+	}
+}
@@ -17,13 +17,33 @@ The merge target is chosen by:
 1. Reference count (most referenced issue wins)
 2. Lexicographically smallest ID if reference counts are equal
 Only groups issues with matching status (open with open, closed with closed).
+--workspace instead compares the open issues of every project registered with
+'bd project add' (and the current project), flagging likely duplicates filed
+in different projects, such as the same bug reported in an app and in the
+library it uses. Issues match on title and description words (--threshold
+sets the similarity needed, 0-1). The canonical issue of each group is the
+earliest filed, or the earliest in the --owner project. --link closes every
+other issue in the group as a duplicate of it ("Duplicate of lib:lib-12")
+and records a beads:<project>:<id> cross-reference (see 'bd xref').
 Example:
   bd duplicates                    # Show all duplicate groups
   bd duplicates --auto-merge       # Automatically merge all duplicates
-  bd duplicates --dry-run          # Show what would be merged`,
+  bd duplicates --dry-run          # Show what would be merged
+  bd duplicates --workspace                    # Likely duplicates across projects
+  bd duplicates --workspace --owner lib --link # Link them, owned by lib`,
 	Run: func(cmd *cobra.Command, _ []string) {
 		autoMerge, _ := cmd.Flags().GetBool("auto-merge")
 		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		if workspace, _ := cmd.Flags().GetBool("workspace"); workspace {
+			if autoMerge {
+				FatalError("--auto-merge merges within one project; use --link with --workspace")
+			}
+			threshold, _ := cmd.Flags().GetFloat64("threshold")
+			owner, _ := cmd.Flags().GetString("owner")
+			link, _ := cmd.Flags().GetBool("link")
+			runWorkspaceDuplicates(threshold, owner, link, dryRun)
+			return
+		}
 		// Block writes in readonly mode (merging modifies data)
 		if autoMerge && !dryRun {
 			CheckReadonly("duplicates --auto-merge")
@@ -160,6 +180,10 @@ Example:
 func init() {
 	duplicatesCmd.Flags().Bool("auto-merge", false, "Automatically merge all duplicates")
 	duplicatesCmd.Flags().Bool("dry-run", false, "Show what would be merged without making changes")
+	duplicatesCmd.Flags().Bool("workspace", false, "Find likely duplicates across all registered projects")
+	duplicatesCmd.Flags().Float64("threshold", 0.6, "Similarity (0-1) needed to flag a cross-project duplicate (with --workspace)")
+	duplicatesCmd.Flags().String("owner", "", "Project whose issue is canonical when a group spans it (with --workspace)")
+	duplicatesCmd.Flags().Bool("link", false, "Close each cross-project duplicate in favor of its canonical issue (with --workspace)")
 	rootCmd.AddCommand(duplicatesCmd)
 }
 // contentKey represents the fields we use to identify duplicate issues
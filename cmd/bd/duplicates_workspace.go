@@ -0,0 +1,456 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/fatih/color"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// workspaceProject is one project scanned by bd duplicates --workspace
+type workspaceProject struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+
+	// ref is what --project takes to reach the project: its registered
+	// name, or its path if it isn't registered
+	ref string
+}
+
+// workspaceIssue is an open issue and the project it belongs to
+type workspaceIssue struct {
+	Project *workspaceProject
+	Issue   *types.Issue
+
+	title, description []string // similarity tokens
+}
+
+// WorkspaceDuplicateGroup is a set of likely duplicates filed in different
+// projects, with the issue that owns the work
+type WorkspaceDuplicateGroup struct {
+	Title     string                    `json:"title"`
+	Canonical WorkspaceDuplicate        `json:"canonical"`
+	Issues    []WorkspaceDuplicate      `json:"issues"`
+	Actions   []string                  `json:"suggested_actions"`
+	Links     []*WorkspaceDuplicateLink `json:"links,omitempty"`
+
+	canonical  *workspaceIssue
+	duplicates []*workspaceIssue
+}
+
+// WorkspaceDuplicate is one issue in a WorkspaceDuplicateGroup
+type WorkspaceDuplicate struct {
+	Project     string  `json:"project"`
+	ID          string  `json:"id"`
+	Title       string  `json:"title"`
+	Status      string  `json:"status"`
+	Priority    int     `json:"priority"`
+	Similarity  float64 `json:"similarity"` // to the canonical issue
+	IsCanonical bool    `json:"is_canonical"`
+}
+
+// WorkspaceDuplicateLink is the outcome of linking one duplicate
+type WorkspaceDuplicateLink struct {
+	Project string `json:"project"`
+	ID      string `json:"id"`
+	Linked  bool   `json:"linked"`
+	Error   string `json:"error,omitempty"`
+}
+
+// dedupeStopWords are too common in issue titles to suggest a duplicate
+var dedupeStopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "when": true, "from": true,
+	"into": true, "that": true, "this": true, "are": true, "was": true, "not": true,
+	"but": true, "after": true, "before": true, "should": true, "does": true, "can": true,
+}
+
+// runWorkspaceDuplicates implements bd duplicates --workspace: it compares
+// the open issues of every registered project (and the current one) and
+// reports likely duplicates filed in different projects
+func runWorkspaceDuplicates(threshold float64, owner string, link, dryRun bool) {
+	if threshold <= 0 || threshold > 1 {
+		FatalError("--threshold must be between 0 and 1")
+	}
+	if link && !dryRun {
+		CheckReadonly("duplicates --workspace --link")
+	}
+	projectList, err := workspaceProjects()
+	if err != nil {
+		FatalError("%v", err)
+	}
+	if owner != "" && !slices.ContainsFunc(projectList, func(p *workspaceProject) bool { return p.Name == owner }) {
+		FatalErrorWithHint(fmt.Sprintf("--owner %q is not a workspace project", owner), "see 'bd project list'")
+	}
+	if len(projectList) < 2 {
+		FatalErrorWithHint("--workspace needs at least two projects", "register projects with 'bd project add <path>'")
+	}
+
+	var issues []*workspaceIssue
+	for _, p := range projectList {
+		loaded, err := loadWorkspaceIssues(rootCtx, p)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: skipping project %s: %v\n", p.Name, err)
+			continue
+		}
+		issues = append(issues, loaded...)
+	}
+	groups := findWorkspaceDuplicates(issues, threshold, owner)
+
+	if link && !dryRun {
+		exe, err := os.Executable()
+		if err != nil {
+			FatalError("failed to locate bd: %v", err)
+		}
+		for _, g := range groups {
+			linkWorkspaceDuplicates(exe, g)
+		}
+	}
+
+	if jsonOutput {
+		names := make([]string, len(projectList))
+		for i, p := range projectList {
+			names[i] = p.Name
+		}
+		if groups == nil {
+			groups = []*WorkspaceDuplicateGroup{}
+		}
+		outputJSON(map[string]interface{}{
+			"projects":         names,
+			"duplicate_groups": len(groups),
+			"groups":           groups,
+		})
+		return
+	}
+	printWorkspaceDuplicates(groups, len(projectList), link, dryRun)
+}
+
+// workspaceProjects returns the registered projects plus the current one,
+// if it isn't registered
+func workspaceProjects() ([]*workspaceProject, error) {
+	registered, err := projectRegistry().List()
+	if err != nil {
+		return nil, err
+	}
+	var list []*workspaceProject
+	seen := make(map[string]bool)
+	for _, p := range registered {
+		if _, err := os.Stat(filepath.Join(p.Path, ".beads")); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: skipping project %s: %s has no .beads directory\n", p.Name, p.Path)
+			continue
+		}
+		list = append(list, &workspaceProject{Name: p.Name, Path: p.Path, ref: p.Name})
+		seen[canonicalPath(p.Path)] = true
+	}
+	// The current directory counts if it is in a project (~/.beads, which
+	// holds the registry, has no database)
+	if beadsDir, err := findBeadsDirFrom("."); err == nil && fileExists(projectDatabasePath(beadsDir)) {
+		if root := filepath.Dir(beadsDir); !seen[canonicalPath(root)] {
+			list = append(list, &workspaceProject{Name: filepath.Base(root), Path: root, ref: root})
+		}
+	}
+	return list, nil
+}
+
+// canonicalPath resolves symlinks so one directory is recognized under
+// different spellings
+func canonicalPath(path string) string {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+	return filepath.Clean(path)
+}
+
+// loadWorkspaceIssues reads a project's open issues from its database
+func loadWorkspaceIssues(ctx context.Context, p *workspaceProject) ([]*workspaceIssue, error) {
+	path := projectDatabasePath(filepath.Join(p.Path, ".beads"))
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("no database at %s", path)
+	}
+	s, err := sqlite.New(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = s.Close() }()
+
+	all, err := s.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		return nil, err
+	}
+	var issues []*workspaceIssue
+	for _, issue := range all {
+		if issue.Status == types.StatusClosed {
+			continue
+		}
+		issues = append(issues, newWorkspaceIssue(p, issue))
+	}
+	return issues, nil
+}
+
+func newWorkspaceIssue(p *workspaceProject, issue *types.Issue) *workspaceIssue {
+	return &workspaceIssue{
+		Project:     p,
+		Issue:       issue,
+		title:       dedupeTokens(issue.Title),
+		description: dedupeTokens(issue.Description),
+	}
+}
+
+// dedupeTokens splits text into lowercase words for comparison, dropping
+// short and common words and a plural "s"
+func dedupeTokens(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var tokens []string
+	for _, w := range words {
+		if len(w) < 3 || dedupeStopWords[w] {
+			continue
+		}
+		if len(w) > 3 && strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss") {
+			w = strings.TrimSuffix(w, "s")
+		}
+		tokens = append(tokens, w)
+	}
+	return tokens
+}
+
+// dedupeSimilarity scores two issues in [0, 1]. Titles carry most of the
+// weight; descriptions count only when both issues have one.
+func dedupeSimilarity(a, b *workspaceIssue) float64 {
+	title := jaccard(a.title, b.title)
+	if len(a.description) == 0 || len(b.description) == 0 {
+		return title
+	}
+	return 0.7*title + 0.3*jaccard(a.description, b.description)
+}
+
+// findWorkspaceDuplicates groups issues from different projects whose
+// similarity reaches threshold. Similar pairs chain into one group. The
+// canonical issue is the earliest one filed in the owner project if given,
+// otherwise the earliest one filed anywhere.
+func findWorkspaceDuplicates(issues []*workspaceIssue, threshold float64, owner string) []*WorkspaceDuplicateGroup {
+	// Only compare issues sharing a title word
+	byToken := make(map[string][]int)
+	for i, wi := range issues {
+		for _, tok := range uniqueStrings(wi.title) {
+			byToken[tok] = append(byToken[tok], i)
+		}
+	}
+
+	parent := make([]int, len(issues))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	compared := make(map[[2]int]bool)
+	for _, members := range byToken {
+		for x := 0; x < len(members); x++ {
+			for y := x + 1; y < len(members); y++ {
+				i, j := members[x], members[y]
+				if issues[i].Project == issues[j].Project || compared[[2]int{i, j}] {
+					continue
+				}
+				compared[[2]int{i, j}] = true
+				if dedupeSimilarity(issues[i], issues[j]) >= threshold {
+					parent[find(i)] = find(j)
+				}
+			}
+		}
+	}
+
+	clusters := make(map[int][]*workspaceIssue)
+	for i, wi := range issues {
+		root := find(i)
+		clusters[root] = append(clusters[root], wi)
+	}
+
+	var groups []*WorkspaceDuplicateGroup
+	for _, members := range clusters {
+		if len(members) < 2 {
+			continue
+		}
+		canonical := chooseWorkspaceCanonical(members, owner)
+		group := &WorkspaceDuplicateGroup{Title: canonical.Issue.Title, canonical: canonical}
+		for _, m := range members {
+			d := WorkspaceDuplicate{
+				Project:     m.Project.Name,
+				ID:          m.Issue.ID,
+				Title:       m.Issue.Title,
+				Status:      string(m.Issue.Status),
+				Priority:    m.Issue.Priority,
+				Similarity:  1,
+				IsCanonical: m == canonical,
+			}
+			if m != canonical {
+				group.duplicates = append(group.duplicates, m)
+				d.Similarity = float64(int(dedupeSimilarity(m, canonical)*100)) / 100
+				group.Actions = append(group.Actions, workspaceLinkCommands(m, canonical))
+			}
+			if d.IsCanonical {
+				group.Canonical = d
+			}
+			group.Issues = append(group.Issues, d)
+		}
+		sort.SliceStable(group.Issues, func(i, j int) bool {
+			a, b := group.Issues[i], group.Issues[j]
+			if a.IsCanonical != b.IsCanonical {
+				return a.IsCanonical
+			}
+			if a.Similarity != b.Similarity {
+				return a.Similarity > b.Similarity
+			}
+			return a.Project+a.ID < b.Project+b.ID
+		})
+		groups = append(groups, group)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		a, b := groups[i].Canonical, groups[j].Canonical
+		if a.Priority != b.Priority {
+			return a.Priority < b.Priority
+		}
+		return a.Project+a.ID < b.Project+b.ID
+	})
+	return groups
+}
+
+// chooseWorkspaceCanonical picks the issue the others duplicate
+func chooseWorkspaceCanonical(members []*workspaceIssue, owner string) *workspaceIssue {
+	candidates := members
+	if owner != "" {
+		var owned []*workspaceIssue
+		for _, m := range members {
+			if m.Project.Name == owner {
+				owned = append(owned, m)
+			}
+		}
+		if len(owned) > 0 {
+			candidates = owned
+		}
+	}
+	best := candidates[0]
+	for _, m := range candidates[1:] {
+		if m.Issue.CreatedAt.Before(best.Issue.CreatedAt) ||
+			(m.Issue.CreatedAt.Equal(best.Issue.CreatedAt) && m.Issue.ID < best.Issue.ID) {
+			best = m
+		}
+	}
+	return best
+}
+
+// workspaceLinkArgs returns the bd invocations that link a duplicate to its
+// canonical issue: close it with a reason naming the canonical issue, and
+// record a cross-reference to it (beads:<project>:<id>) when the project
+// name can be used in one
+func workspaceLinkArgs(dup, canonical *workspaceIssue) [][]string {
+	target := canonical.Project.Name + ":" + canonical.Issue.ID
+	args := [][]string{
+		{"--project", dup.Project.ref, "close", dup.Issue.ID, "--reason", "Duplicate of " + target},
+	}
+	if !strings.ContainsAny(canonical.Project.Name, " \t:") {
+		args = append(args, []string{"--project", dup.Project.ref, "xref", dup.Issue.ID, "--add", "beads:" + target})
+	}
+	return args
+}
+
+// workspaceLinkCommands renders workspaceLinkArgs as a shell command line
+func workspaceLinkCommands(dup, canonical *workspaceIssue) string {
+	var cmds []string
+	for _, args := range workspaceLinkArgs(dup, canonical) {
+		quoted := make([]string, len(args))
+		for i, a := range args {
+			quoted[i] = shellQuote(a)
+		}
+		cmds = append(cmds, "bd "+strings.Join(quoted, " "))
+	}
+	return strings.Join(cmds, " && ")
+}
+
+// shellQuote quotes s for a POSIX shell if it needs quoting
+func shellQuote(s string) string {
+	if s != "" && !strings.ContainsAny(s, " \t\n'\"\\$`!*?[]{}()<>|&;#~") {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// linkWorkspaceDuplicates links every duplicate in a group to the canonical
+// issue by running bd in the duplicate's project, so its daemon, JSONL and
+// hooks see the change as they would any other command
+func linkWorkspaceDuplicates(exe string, g *WorkspaceDuplicateGroup) {
+	for _, dup := range g.duplicates {
+		result := &WorkspaceDuplicateLink{Project: dup.Project.Name, ID: dup.Issue.ID, Linked: true}
+		for _, args := range workspaceLinkArgs(dup, g.canonical) {
+			// #nosec G204 - re-running bd with arguments built from issue IDs
+			child := exec.Command(exe, args...)
+			if out, err := child.CombinedOutput(); err != nil {
+				result.Linked = false
+				result.Error = strings.TrimSpace(string(out))
+				if result.Error == "" {
+					result.Error = err.Error()
+				}
+				break
+			}
+		}
+		g.Links = append(g.Links, result)
+	}
+}
+
+func printWorkspaceDuplicates(groups []*WorkspaceDuplicateGroup, projectCount int, link, dryRun bool) {
+	yellow := color.New(color.FgYellow).SprintFunc()
+	cyan := color.New(color.FgCyan).SprintFunc()
+	green := color.New(color.FgGreen).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
+
+	if len(groups) == 0 {
+		fmt.Printf("No likely duplicates across %d projects!\n", projectCount)
+		return
+	}
+	fmt.Printf("%s Found %d likely duplicate group(s) across %d projects:\n\n", yellow("🔍"), len(groups), projectCount)
+	for i, g := range groups {
+		fmt.Printf("%s Group %d: %s\n", cyan("━━"), i+1, g.Title)
+		for _, d := range g.Issues {
+			if d.IsCanonical {
+				fmt.Printf("%s%s %s (%s, P%d) canonical\n", green("→ "), d.Project, d.ID, d.Status, d.Priority)
+				continue
+			}
+			fmt.Printf("  %s %s (%s, P%d) %d%% similar: %s\n", d.Project, d.ID, d.Status, d.Priority, int(d.Similarity*100), d.Title)
+		}
+		switch {
+		case len(g.Links) > 0:
+			for _, l := range g.Links {
+				if l.Linked {
+					fmt.Printf("  %s Linked %s %s as a duplicate\n", green("✓"), l.Project, l.ID)
+				} else {
+					fmt.Printf("  %s Failed to link %s %s: %s\n", red("✗"), l.Project, l.ID, l.Error)
+				}
+			}
+		default:
+			for _, action := range g.Actions {
+				fmt.Printf("  %s %s\n", cyan("Suggested:"), action)
+			}
+		}
+		fmt.Println()
+	}
+	switch {
+	case link && dryRun:
+		fmt.Printf("%s Dry run - would run the suggested commands for %d group(s)\n", yellow("⚠"), len(groups))
+	case !link:
+		fmt.Printf("%s Run with --link to close each duplicate in favor of its canonical issue (--owner <project> picks the owning project)\n", cyan("💡"))
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestDedupeTokens(t *testing.T) {
+	got := dedupeTokens("Login fails with Unicode passwords (again!) on iOS 17; see the class")
+	want := []string{"login", "fail", "unicode", "password", "again", "ios", "see", "class"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("dedupeTokens = %v, want %v", got, want)
+	}
+}
+
+func TestFindWorkspaceDuplicates(t *testing.T) {
+	app := &workspaceProject{Name: "app", ref: "app"}
+	lib := &workspaceProject{Name: "lib", ref: "lib"}
+	cli := &workspaceProject{Name: "cli", ref: "/src/my cli"}
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	issue := func(p *workspaceProject, id, title string, days int) *workspaceIssue {
+		return newWorkspaceIssue(p, &types.Issue{ID: id, Title: title, Status: types.StatusOpen, Priority: 2, CreatedAt: base.AddDate(0, 0, days)})
+	}
+	issues := []*workspaceIssue{
+		issue(app, "app-1", "Login fails on unicode password", 0),
+		issue(lib, "lib-1", "Login fails with unicode passwords", 3),
+		issue(cli, "cli-1", "Unicode password login fails", 5),
+		issue(app, "app-2", "Login fails for unicode passwords", 1), // same project as app-1
+		issue(lib, "lib-2", "Add retry to HTTP client", 0),
+		issue(app, "app-3", "Dark mode", 0),
+	}
+
+	groups := findWorkspaceDuplicates(issues, 0.6, "")
+	if len(groups) != 1 {
+		t.Fatalf("groups = %d, want 1", len(groups))
+	}
+	g := groups[0]
+	// Pairs chain: app-2 joins through its match with lib-1
+	var ids []string
+	for _, d := range g.Issues {
+		ids = append(ids, d.ID)
+	}
+	if g.Canonical.ID != "app-1" || len(ids) != 4 || ids[0] != "app-1" {
+		t.Errorf("group = %v with canonical %s, want the four login issues led by the earliest, app-1", ids, g.Canonical.ID)
+	}
+
+	// --owner makes the owner's issue canonical even if filed later
+	groups = findWorkspaceDuplicates(issues, 0.6, "lib")
+	if groups[0].Canonical.ID != "lib-1" || !groups[0].Issues[0].IsCanonical {
+		t.Errorf("owner lib canonical = %+v", groups[0].Canonical)
+	}
+	wantAction := `bd --project '/src/my cli' close cli-1 --reason 'Duplicate of lib:lib-1' && bd --project '/src/my cli' xref cli-1 --add beads:lib:lib-1`
+	found := false
+	for _, action := range groups[0].Actions {
+		found = found || action == wantAction
+	}
+	if !found {
+		t.Errorf("actions = %q, want one to be %q", groups[0].Actions, wantAction)
+	}
+
+	// Issues in one project are never grouped on their own
+	if groups := findWorkspaceDuplicates(issues[3:4:4], 0.1, ""); len(groups) != 0 {
+		t.Errorf("single project groups = %+v", groups)
+	}
+	if groups := findWorkspaceDuplicates(append([]*workspaceIssue{issues[0]}, issues[3]), 0.1, ""); len(groups) != 0 {
+		t.Errorf("same-project pair grouped: %+v", groups)
+	}
+}
+
+func TestLoadWorkspaceIssues(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	s := newTestStore(t, filepath.Join(dir, ".beads", "beads.db"))
+	for _, issue := range []*types.Issue{
+		{Title: "Open one", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+		{Title: "Done one", Status: types.StatusClosed, Priority: 2, IssueType: types.TypeTask, ClosedAt: ptrTime(time.Now())},
+	} {
+		if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue: %v", err)
+		}
+	}
+
+	issues, err := loadWorkspaceIssues(ctx, &workspaceProject{Name: "p", Path: dir})
+	if err != nil {
+		t.Fatalf("loadWorkspaceIssues: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Issue.Title != "Open one" || issues[0].Project.Name != "p" {
+		t.Errorf("issues = %+v, want only the open one", issues)
+	}
+
+	if _, err := loadWorkspaceIssues(ctx, &workspaceProject{Name: "empty", Path: t.TempDir()}); err == nil {
+		t.Error("expected an error for a project without a database")
+	}
+}
@@ -0,0 +1,520 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// EffortCommit is a commit linked to one or more issues
+type EffortCommit struct {
+	Hash    string    `json:"hash"`
+	Time    time.Time `json:"time"`
+	Author  string    `json:"author"`
+	Subject string    `json:"subject"`
+	Added   int       `json:"added"`
+	Deleted int       `json:"deleted"`
+	Files   []string  `json:"files,omitempty"`
+	message string
+}
+
+// IssueEffort is the git activity for one issue, or for an epic and all of
+// its descendants
+type IssueEffort struct {
+	ID               string          `json:"id"`
+	Title            string          `json:"title"`
+	Status           types.Status    `json:"status"`
+	Commits          int             `json:"commits"`
+	Added            int             `json:"added"`
+	Deleted          int             `json:"deleted"`
+	FilesChanged     int             `json:"files_changed"`
+	ActiveDays       int             `json:"active_days"`
+	FirstCommit      *time.Time      `json:"first_commit,omitempty"`
+	LastCommit       *time.Time      `json:"last_commit,omitempty"`
+	ActualMinutes    int             `json:"actual_minutes"`
+	EstimatedMinutes *int            `json:"estimated_minutes,omitempty"`
+	Ratio            *float64        `json:"ratio,omitempty"` // actual / estimated
+	Branches         []string        `json:"branches,omitempty"`
+	Issues           int             `json:"issues,omitempty"` // epics: issues in the tree with commits
+	CommitList       []*EffortCommit `json:"commit_list,omitempty"`
+}
+
+// EffortReport is the output of 'bd effort report'
+type EffortReport struct {
+	Since              *time.Time     `json:"since,omitempty"`
+	SessionGapMinutes  int            `json:"session_gap_minutes"`
+	FirstCommitMinutes int            `json:"first_commit_minutes"`
+	Issues             []*IssueEffort `json:"issues"`
+	Epics              []*IssueEffort `json:"epics"`
+}
+
+// effortLogFormat separates commits with RS and header fields with US so
+// multi-line bodies parse unambiguously; --numstat lines follow the last US
+const effortLogFormat = "%x1e%H%x1f%at%x1f%an%x1f%s%x1f%b%x1f"
+
+var effortCmd = &cobra.Command{
+	Use:   "effort",
+	Short: "Estimate actual effort from git history",
+}
+
+var effortReportCmd = &cobra.Command{
+	Use:   "report [issue-id...]",
+	Short: "Report commits, churn and active time per issue and epic",
+	Long: `Estimate the effort that went into issues from the git history, without
+any manual time logging, and compare it with estimated_minutes.
+
+A commit counts toward an issue when its message mentions the issue ID (for
+example the "refs bd-12" line the prepare-commit-msg hook adds), or when it
+is on a branch whose name contains the ID and not on the default branch.
+
+Actual time is estimated from commit timestamps: commits closer together than
+--session-gap belong to one work session, and each session is credited
+--first-commit for the work before its first commit. Active days are the
+distinct days with commits. Epics roll up every descendant.
+
+Examples:
+  bd effort report
+  bd effort report bd-12 bd-15
+  bd effort report --since 2025-01-01 --json
+  bd effort report bd-3 --commits`,
+	Run: func(cmd *cobra.Command, args []string) {
+		sinceStr, _ := cmd.Flags().GetString("since")
+		sessionGap, _ := cmd.Flags().GetDuration("session-gap")
+		firstCommit, _ := cmd.Flags().GetDuration("first-commit")
+		showCommits, _ := cmd.Flags().GetBool("commits")
+		if sessionGap <= 0 {
+			FatalError("--session-gap must be positive")
+		}
+		if firstCommit < 0 {
+			FatalError("--first-commit cannot be negative")
+		}
+
+		var since time.Time
+		if sinceStr != "" {
+			t, err := parseTimeFlag(sinceStr)
+			if err != nil {
+				FatalError("parsing --since: %v", err)
+			}
+			since = t
+		}
+
+		if err := ensureDirectMode("effort report requires direct database access"); err != nil {
+			FatalError("%v", err)
+		}
+		ctx := rootCtx
+
+		issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+		if err != nil {
+			FatalError("%v", err)
+		}
+		commits, err := readEffortCommits(ctx, since)
+		if err != nil {
+			FatalErrorWithHint(err.Error(), "run bd effort report inside the project's git repository")
+		}
+		branchCommits := readEffortBranches(ctx, issues)
+
+		report, err := buildEffortReport(ctx, store, issues, commits, branchCommits, args, sessionGap, firstCommit)
+		if err != nil {
+			FatalError("%v", err)
+		}
+		if !since.IsZero() {
+			report.Since = &since
+		}
+		if !showCommits {
+			for _, e := range append(report.Issues, report.Epics...) {
+				e.CommitList = nil
+			}
+		}
+
+		if jsonOutput {
+			outputJSON(report)
+			return
+		}
+		printEffortReport(report)
+	},
+}
+
+// readEffortCommits lists non-merge commits on all refs with their churn
+func readEffortCommits(ctx context.Context, since time.Time) ([]*EffortCommit, error) {
+	gitArgs := []string{"log", "--all", "--no-merges", "--numstat", "--format=" + effortLogFormat}
+	if !since.IsZero() {
+		gitArgs = append(gitArgs, "--since="+since.Format(time.RFC3339))
+	}
+	out, err := exec.CommandContext(ctx, "git", gitArgs...).Output() // #nosec G204 -- fixed arguments
+	if err != nil {
+		return nil, fmt.Errorf("reading git history: %w", err)
+	}
+	return parseEffortLog(string(out)), nil
+}
+
+// parseEffortLog parses git log output in effortLogFormat with --numstat
+func parseEffortLog(out string) []*EffortCommit {
+	var commits []*EffortCommit
+	for _, record := range strings.Split(out, "\x1e") {
+		fields := strings.SplitN(record, "\x1f", 6)
+		if len(fields) < 6 {
+			continue
+		}
+		ts, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		c := &EffortCommit{
+			Hash:    fields[0],
+			Time:    time.Unix(ts, 0).UTC(),
+			Author:  fields[2],
+			Subject: fields[3],
+			message: fields[3] + "\n" + fields[4],
+		}
+		for _, line := range strings.Split(fields[5], "\n") {
+			parts := strings.SplitN(line, "\t", 3)
+			if len(parts) != 3 {
+				continue
+			}
+			// Binary files report "-" for both counts
+			added, _ := strconv.Atoi(parts[0])
+			deleted, _ := strconv.Atoi(parts[1])
+			c.Added += added
+			c.Deleted += deleted
+			c.Files = append(c.Files, parts[2])
+		}
+		commits = append(commits, c)
+	}
+	return commits
+}
+
+// readEffortBranches maps issue IDs to the commits on branches named after
+// them that aren't on the default branch. Without a default branch to
+// compare against, branches are not used.
+func readEffortBranches(ctx context.Context, issues []*types.Issue) map[string]map[string][]string {
+	base := defaultBranchRef(ctx)
+	if base == "" {
+		return nil
+	}
+	out, err := exec.CommandContext(ctx, "git", "for-each-ref", "--format=%(refname:short)", "refs/heads", "refs/remotes").Output()
+	if err != nil {
+		return nil
+	}
+
+	result := make(map[string]map[string][]string) // issue → branch → hashes
+	for _, branch := range strings.Fields(string(out)) {
+		if branch == base || strings.HasSuffix(branch, "/HEAD") {
+			continue
+		}
+		var ids []string
+		for _, issue := range issues {
+			if branchMentionsIssue(branch, issue.ID) {
+				ids = append(ids, issue.ID)
+			}
+		}
+		if len(ids) == 0 {
+			continue
+		}
+		hashes, err := exec.CommandContext(ctx, "git", "rev-list", "--no-merges", branch, "--not", base).Output() // #nosec G204 -- ref names from git itself
+		if err != nil {
+			continue
+		}
+		for _, id := range ids {
+			if result[id] == nil {
+				result[id] = make(map[string][]string)
+			}
+			result[id][branch] = strings.Fields(string(hashes))
+		}
+	}
+	return result
+}
+
+// defaultBranchRef returns origin's default branch, or main/master locally
+func defaultBranchRef(ctx context.Context) string {
+	if out, err := exec.CommandContext(ctx, "git", "symbolic-ref", "--short", "refs/remotes/origin/HEAD").Output(); err == nil {
+		return strings.TrimSpace(string(out))
+	}
+	for _, name := range []string{"main", "master"} {
+		if exec.CommandContext(ctx, "git", "rev-parse", "--verify", "--quiet", "refs/heads/"+name).Run() == nil { // #nosec G204 -- fixed names
+			return name
+		}
+	}
+	return ""
+}
+
+// branchMentionsIssue reports whether a branch name such as "feature/bd-12-login"
+// refers to issueID. The ID must be delimited, so bd-1 doesn't match bd-12.
+func branchMentionsIssue(branch, issueID string) bool {
+	re := regexp.MustCompile(`(^|[/_.-])` + regexp.QuoteMeta(issueID) + `($|[/_-]|\.(\D|$))`)
+	return re.MatchString(branch)
+}
+
+// effortTokenPattern matches words that could be issue IDs
+var effortTokenPattern = regexp.MustCompile(`[\w.-]+`)
+
+// mentionedIssueIDs returns the known issue IDs in a commit message. It
+// follows the rule appendIssueRefs uses: an ID may be followed by sentence
+// punctuation but not by more ID characters, except a '.' and a non-digit.
+func mentionedIssueIDs(msg string, known map[string]bool) []string {
+	seen := make(map[string]bool)
+	var ids []string
+	for _, token := range effortTokenPattern.FindAllString(msg, -1) {
+		candidates := []string{token}
+		for i := 0; i < len(token); i++ {
+			if token[i] == '.' && (i == len(token)-1 || token[i+1] < '0' || token[i+1] > '9') {
+				candidates = append(candidates, token[:i])
+			}
+		}
+		for _, c := range candidates {
+			if known[c] && !seen[c] {
+				seen[c] = true
+				ids = append(ids, c)
+			}
+		}
+	}
+	return ids
+}
+
+// buildEffortReport links commits to issues and summarizes them. With
+// onlyIDs, only those issues (and epics among them) are reported.
+func buildEffortReport(ctx context.Context, s storage.Storage, issues []*types.Issue, commits []*EffortCommit, branchCommits map[string]map[string][]string, onlyIDs []string, sessionGap, firstCommit time.Duration) (*EffortReport, error) {
+	byID := make(map[string]*types.Issue, len(issues))
+	known := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		byID[issue.ID] = issue
+		known[issue.ID] = true
+	}
+	byHash := make(map[string]*EffortCommit, len(commits))
+	for _, c := range commits {
+		byHash[c.Hash] = c
+	}
+
+	linked := make(map[string]map[string]*EffortCommit) // issue → hash → commit
+	link := func(id string, c *EffortCommit) {
+		if linked[id] == nil {
+			linked[id] = make(map[string]*EffortCommit)
+		}
+		linked[id][c.Hash] = c
+	}
+	for _, c := range commits {
+		for _, id := range mentionedIssueIDs(c.message, known) {
+			link(id, c)
+		}
+	}
+	branches := make(map[string][]string)
+	for id, byBranch := range branchCommits {
+		for branch, hashes := range byBranch {
+			for _, h := range hashes {
+				// Commits outside --since aren't in byHash
+				if c, ok := byHash[h]; ok {
+					link(id, c)
+				}
+			}
+			branches[id] = append(branches[id], branch)
+		}
+		sort.Strings(branches[id])
+	}
+
+	selected := make(map[string]bool)
+	for _, id := range onlyIDs {
+		if _, ok := byID[id]; !ok {
+			return nil, fmt.Errorf("issue %s not found", id)
+		}
+		selected[id] = true
+	}
+
+	report := &EffortReport{
+		SessionGapMinutes:  int(sessionGap / time.Minute),
+		FirstCommitMinutes: int(firstCommit / time.Minute),
+		Issues:             []*IssueEffort{},
+		Epics:              []*IssueEffort{},
+	}
+
+	ids := make([]string, 0, len(linked))
+	for id := range linked {
+		if len(selected) == 0 || selected[id] {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		e := summarizeEffort(byID[id], linked[id], sessionGap, firstCommit)
+		e.Branches = branches[id]
+		e.EstimatedMinutes = byID[id].EstimatedMinutes
+		e.Ratio = effortRatio(e.ActualMinutes, e.EstimatedMinutes)
+		report.Issues = append(report.Issues, e)
+	}
+
+	// Epics roll up their whole tree, compared against the rolled-up estimate
+	epicIDs := storage.EpicIDs(issues)
+	if len(selected) > 0 {
+		var filtered []string
+		for _, id := range epicIDs {
+			if selected[id] {
+				filtered = append(filtered, id)
+			}
+		}
+		epicIDs = filtered
+	}
+	progress, err := storage.ChildProgress(ctx, s, epicIDs, true)
+	if err != nil {
+		return nil, err
+	}
+	deps, err := s.GetAllDependencyRecords(ctx)
+	if err != nil {
+		return nil, err
+	}
+	children := make(map[string][]string)
+	for childID, records := range deps {
+		for _, dep := range records {
+			if dep.Type == types.DepParentChild {
+				children[dep.DependsOnID] = append(children[dep.DependsOnID], childID)
+			}
+		}
+	}
+	for _, epicID := range epicIDs {
+		tree := append([]string{epicID}, storage.CollectDescendants(epicID, children)...)
+		union := make(map[string]*EffortCommit)
+		withCommits := 0
+		for _, id := range tree {
+			if len(linked[id]) > 0 {
+				withCommits++
+			}
+			for h, c := range linked[id] {
+				union[h] = c
+			}
+		}
+		if len(union) == 0 {
+			continue
+		}
+		e := summarizeEffort(byID[epicID], union, sessionGap, firstCommit)
+		e.Issues = withCommits
+		if p := progress[epicID]; p != nil && p.EstimatedMinutes != nil && *p.EstimatedMinutes > 0 {
+			e.EstimatedMinutes = p.EstimatedMinutes
+		} else {
+			e.EstimatedMinutes = byID[epicID].EstimatedMinutes
+		}
+		e.Ratio = effortRatio(e.ActualMinutes, e.EstimatedMinutes)
+		report.Epics = append(report.Epics, e)
+	}
+	return report, nil
+}
+
+// summarizeEffort totals a set of commits for one issue or epic tree
+func summarizeEffort(issue *types.Issue, commits map[string]*EffortCommit, sessionGap, firstCommit time.Duration) *IssueEffort {
+	e := &IssueEffort{ID: issue.ID, Title: issue.Title, Status: issue.Status, Commits: len(commits)}
+	files := make(map[string]bool)
+	days := make(map[string]bool)
+	loc := timeFormatter().Location
+	times := make([]time.Time, 0, len(commits))
+	for _, c := range commits {
+		e.Added += c.Added
+		e.Deleted += c.Deleted
+		for _, f := range c.Files {
+			files[f] = true
+		}
+		days[c.Time.In(loc).Format("2006-01-02")] = true
+		times = append(times, c.Time)
+		e.CommitList = append(e.CommitList, c)
+	}
+	sort.Slice(e.CommitList, func(i, j int) bool { return e.CommitList[i].Time.Before(e.CommitList[j].Time) })
+	e.FilesChanged = len(files)
+	e.ActiveDays = len(days)
+	if len(e.CommitList) > 0 {
+		first, last := e.CommitList[0].Time, e.CommitList[len(e.CommitList)-1].Time
+		e.FirstCommit, e.LastCommit = &first, &last
+	}
+	e.ActualMinutes = estimateSessionMinutes(times, sessionGap, firstCommit)
+	return e
+}
+
+// estimateSessionMinutes credits the time between commits closer together
+// than gap, plus firstCommit for the start of each session
+func estimateSessionMinutes(times []time.Time, gap, firstCommit time.Duration) int {
+	if len(times) == 0 {
+		return 0
+	}
+	sorted := append([]time.Time(nil), times...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Before(sorted[j]) })
+	total := firstCommit
+	for i := 1; i < len(sorted); i++ {
+		if d := sorted[i].Sub(sorted[i-1]); d < gap {
+			total += d
+		} else {
+			total += firstCommit
+		}
+	}
+	return int(total / time.Minute)
+}
+
+func effortRatio(actual int, estimated *int) *float64 {
+	if estimated == nil || *estimated <= 0 {
+		return nil
+	}
+	r := float64(actual) / float64(*estimated)
+	return &r
+}
+
+func printEffortReport(report *EffortReport) {
+	cyan := color.New(color.FgCyan).SprintFunc()
+	if len(report.Issues) == 0 {
+		fmt.Printf("\n%s No commits reference any issue\n", cyan("⏱"))
+		fmt.Println("Mention issue IDs in commit messages (bd hooks install adds them for claimed work)")
+		fmt.Println()
+		return
+	}
+
+	fmt.Printf("\n%s Effort from git (%d issues with linked commits)\n", cyan("⏱"), len(report.Issues))
+	if report.Since != nil {
+		fmt.Printf("Commits since %s\n", displayDate(*report.Since))
+	}
+	fmt.Printf("Sessions: commits less than %s apart; %s credited per session start\n\n",
+		formatHours(float64(report.SessionGapMinutes)/60), formatHours(float64(report.FirstCommitMinutes)/60))
+
+	printEffortTable(report.Issues, false)
+	if len(report.Epics) > 0 {
+		fmt.Println("Epics (including all descendants):")
+		printEffortTable(report.Epics, true)
+	}
+}
+
+func printEffortTable(rows []*IssueEffort, epics bool) {
+	fmt.Printf("  %-14s %-28s %7s %13s %5s %8s %8s %6s\n", "ID", "TITLE", "COMMITS", "+/-", "DAYS", "ACTUAL", "ESTIMATE", "RATIO")
+	for _, e := range rows {
+		estimate, ratio := "-", "-"
+		if e.EstimatedMinutes != nil {
+			estimate = formatHours(float64(*e.EstimatedMinutes) / 60)
+		}
+		if e.Ratio != nil {
+			ratio = fmt.Sprintf("%.1fx", *e.Ratio)
+			if *e.Ratio > 1.5 {
+				ratio = color.New(color.FgYellow).Sprint(ratio)
+			}
+		}
+		title := e.Title
+		if epics {
+			title = fmt.Sprintf("%s (%d issues)", title, e.Issues)
+		}
+		fmt.Printf("  %-14s %-28s %7d %13s %5d %8s %8s %6s\n",
+			e.ID, truncateTitle(title, 28), e.Commits, fmt.Sprintf("+%d/-%d", e.Added, e.Deleted),
+			e.ActiveDays, formatHours(float64(e.ActualMinutes)/60), estimate, ratio)
+		for _, c := range e.CommitList {
+			fmt.Printf("      %s %s %s\n", c.Hash[:min(7, len(c.Hash))], displayDate(c.Time), truncateTitle(c.Subject, 60))
+		}
+	}
+	fmt.Println()
+}
+
+func init() {
+	effortReportCmd.Flags().String("since", "", "Only count commits on or after this date (YYYY-MM-DD or RFC3339)")
+	effortReportCmd.Flags().Duration("session-gap", 2*time.Hour, "Commits closer together than this belong to one work session")
+	effortReportCmd.Flags().Duration("first-commit", 30*time.Minute, "Time credited for the work before each session's first commit")
+	effortReportCmd.Flags().Bool("commits", false, "List the linked commits under each issue")
+	effortCmd.AddCommand(effortReportCmd)
+	rootCmd.AddCommand(effortCmd)
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestParseEffortLog(t *testing.T) {
+	out := "\x1eaaa\x1f1735725600\x1fAlice\x1fAdd login\x1frefs bd-1\n\x1f\n\n3\t1\tlogin.go\n-\t-\tlogo.png\n" +
+		"\x1ebbb\x1f1735729200\x1fBob\x1fFix typo\x1f\x1f\n\n1\t1\tREADME.md\n"
+	commits := parseEffortLog(out)
+	if len(commits) != 2 {
+		t.Fatalf("got %d commits, want 2", len(commits))
+	}
+	c := commits[0]
+	if c.Hash != "aaa" || c.Author != "Alice" || c.Subject != "Add login" || c.Added != 3 || c.Deleted != 1 {
+		t.Errorf("first commit = %+v", c)
+	}
+	if !reflect.DeepEqual(c.Files, []string{"login.go", "logo.png"}) {
+		t.Errorf("files = %v", c.Files)
+	}
+	if !c.Time.Equal(time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("time = %v", c.Time)
+	}
+	if commits[1].Added != 1 || len(commits[1].Files) != 1 {
+		t.Errorf("second commit = %+v", commits[1])
+	}
+}
+
+func TestMentionedIssueIDs(t *testing.T) {
+	known := map[string]bool{"bd-1": true, "bd-12": true, "bd-1.2": true}
+	tests := []struct {
+		msg  string
+		want []string
+	}{
+		{"Fix login (bd-12)", []string{"bd-12"}},
+		{"Closes bd-1.", []string{"bd-1"}},
+		{"bd-1.2: child task", []string{"bd-1.2"}},
+		{"see bd-1, bd-12", []string{"bd-1", "bd-12"}},
+		{"bd-123 and xbd-1 and bd-1-old", nil},
+		{"refs bd-1\nrefs bd-1", []string{"bd-1"}},
+	}
+	for _, tt := range tests {
+		if got := mentionedIssueIDs(tt.msg, known); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("mentionedIssueIDs(%q) = %v, want %v", tt.msg, got, tt.want)
+		}
+	}
+}
+
+func TestBranchMentionsIssue(t *testing.T) {
+	tests := []struct {
+		branch string
+		want   bool
+	}{
+		{"bd-12", true},
+		{"feature/bd-12-login", true},
+		{"origin/alice/bd-12", true},
+		{"fix_bd-12.retry", true},
+		{"feature/bd-123", false},
+		{"feature/bd-12.1", false},
+		{"abd-12", false},
+	}
+	for _, tt := range tests {
+		if got := branchMentionsIssue(tt.branch, "bd-12"); got != tt.want {
+			t.Errorf("branchMentionsIssue(%q) = %v, want %v", tt.branch, got, tt.want)
+		}
+	}
+}
+
+func TestEstimateSessionMinutes(t *testing.T) {
+	base := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	times := []time.Time{
+		base.Add(45 * time.Minute), // out of order on purpose
+		base,
+		base.Add(5 * time.Hour), // new session
+	}
+	// 30m + 45m for the first session, 30m for the second
+	if got := estimateSessionMinutes(times, 2*time.Hour, 30*time.Minute); got != 105 {
+		t.Errorf("got %d minutes, want 105", got)
+	}
+	if got := estimateSessionMinutes(nil, 2*time.Hour, 30*time.Minute); got != 0 {
+		t.Errorf("no commits: got %d minutes, want 0", got)
+	}
+}
+
+func TestBuildEffortReport(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t, filepath.Join(t.TempDir(), "test.db"))
+
+	estimate := func(m int) *int { return &m }
+	epic := &types.Issue{ID: "test-1", Title: "Epic", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeEpic}
+	child := &types.Issue{ID: "test-1.1", Title: "Child", Status: types.StatusClosed, Priority: 2, IssueType: types.TypeTask, EstimatedMinutes: estimate(60)}
+	other := &types.Issue{ID: "test-2", Title: "Other", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, EstimatedMinutes: estimate(30)}
+	now := time.Now()
+	child.ClosedAt = &now
+	for _, issue := range []*types.Issue{epic, child, other} {
+		if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.AddDependency(ctx, &types.Dependency{IssueID: child.ID, DependsOnID: epic.ID, Type: types.DepParentChild}, "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	day1 := time.Date(2025, 3, 3, 10, 0, 0, 0, time.UTC)
+	commits := []*EffortCommit{
+		{Hash: "c1", Time: day1, Added: 10, Files: []string{"a.go"}, message: "Start test-1.1"},
+		{Hash: "c2", Time: day1.Add(time.Hour), Added: 5, Deleted: 2, Files: []string{"a.go", "b.go"}, message: "wip"},
+		{Hash: "c3", Time: day1.Add(24 * time.Hour), Deleted: 1, Files: []string{"c.go"}, message: "Plan for epic test-1 and test-2"},
+		{Hash: "c4", Time: day1, message: "unrelated"},
+	}
+	// c2 is only linked through a branch named after the child
+	branchCommits := map[string]map[string][]string{
+		"test-1.1": {"feature/test-1.1": {"c1", "c2"}},
+	}
+
+	all, _ := s.SearchIssues(ctx, "", types.IssueFilter{})
+	report, err := buildEffortReport(ctx, s, all, commits, branchCommits, nil, 2*time.Hour, 30*time.Minute)
+	if err != nil {
+		t.Fatalf("buildEffortReport: %v", err)
+	}
+	if len(report.Issues) != 3 {
+		t.Fatalf("got %d issues, want 3: %+v", len(report.Issues), report.Issues)
+	}
+
+	c := report.Issues[1]
+	if c.ID != "test-1.1" || c.Commits != 2 || c.Added != 15 || c.Deleted != 2 || c.FilesChanged != 2 || c.ActiveDays != 1 {
+		t.Errorf("child effort = %+v", c)
+	}
+	if c.ActualMinutes != 90 || c.Ratio == nil || *c.Ratio != 1.5 {
+		t.Errorf("child actual = %d ratio = %v, want 90 and 1.5", c.ActualMinutes, c.Ratio)
+	}
+	if !reflect.DeepEqual(c.Branches, []string{"feature/test-1.1"}) {
+		t.Errorf("branches = %v", c.Branches)
+	}
+
+	if len(report.Epics) != 1 {
+		t.Fatalf("got %d epics, want 1", len(report.Epics))
+	}
+	e := report.Epics[0]
+	if e.Commits != 3 || e.ActiveDays != 2 || e.Issues != 2 || e.ActualMinutes != 120 {
+		t.Errorf("epic effort = %+v", e)
+	}
+	if e.EstimatedMinutes == nil || *e.EstimatedMinutes != 60 {
+		t.Errorf("epic estimate = %v, want the rolled-up 60", e.EstimatedMinutes)
+	}
+
+	report, err = buildEffortReport(ctx, s, all, commits, branchCommits, []string{"test-2"}, 2*time.Hour, 30*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Issues) != 1 || report.Issues[0].ID != "test-2" || len(report.Epics) != 0 {
+		t.Errorf("filtered report = %+v / %+v", report.Issues, report.Epics)
+	}
+	if _, err := buildEffortReport(ctx, s, all, commits, branchCommits, []string{"test-99"}, 2*time.Hour, 30*time.Minute); err == nil {
+		t.Error("expected an unknown issue ID to fail")
+	}
+}
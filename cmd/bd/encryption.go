@@ -0,0 +1,344 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/encryption"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// encryptionBeadsDir is the .beads directory whose key encrypts this
+// project's fields
+func encryptionBeadsDir() string {
+	if dbPath != "" {
+		return filepath.Dir(dbPath)
+	}
+	if found := beads.FindDatabasePath(); found != "" {
+		return filepath.Dir(found)
+	}
+	return ".beads"
+}
+
+// loadFieldCipher returns the cipher configured by encryption.* in
+// config.yaml, or nil when field encryption is not in use
+func loadFieldCipher() *encryption.Cipher {
+	c, err := encryption.FromConfig(encryptionBeadsDir())
+	if err != nil {
+		FatalErrorWithHint(err.Error(), "fix encryption.fields in .beads/config.yaml or the encryption key")
+	}
+	return c
+}
+
+// encryptInput encrypts user-supplied values of encrypted fields in place,
+// keyed by field name, before they are sent to the database
+func encryptInput(fields map[string]*string) {
+	c := loadFieldCipher()
+	if !c.Enabled() {
+		return
+	}
+	for _, field := range c.Fields() {
+		ptr, ok := fields[field]
+		if !ok {
+			continue
+		}
+		out, err := c.Encrypt(field, *ptr)
+		if err != nil {
+			FatalErrorWithHint(fmt.Sprintf("cannot encrypt %s: %v", field, err), "ask a teammate for the project key or run 'bd encryption init'")
+		}
+		*ptr = out
+	}
+}
+
+// encryptUpdates encrypts the encrypted fields of an update map in place
+func encryptUpdates(updates map[string]interface{}) {
+	if err := loadFieldCipher().EncryptUpdates(updates); err != nil {
+		FatalErrorWithHint(fmt.Sprintf("cannot encrypt update: %v", err), "ask a teammate for the project key or run 'bd encryption init'")
+	}
+}
+
+// encryptFieldValues returns custom field values with the encrypted fields
+// sealed, before they are sent to the database
+func encryptFieldValues(fields types.CustomFields) types.CustomFields {
+	sealed, err := loadFieldCipher().EncryptFields(fields)
+	if err != nil {
+		FatalErrorWithHint(fmt.Sprintf("cannot encrypt field: %v", err), "ask a teammate for the project key or run 'bd encryption init'")
+	}
+	return sealed
+}
+
+// loadIssueFields fills in the custom field values of issues from a search,
+// which does not load them
+func loadIssueFields(ctx context.Context, issues []*types.Issue) error {
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	fields, err := store.GetFieldsForIssues(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, issue := range issues {
+		issue.Fields = fields[issue.ID]
+	}
+	return nil
+}
+
+// warnedCiphertext keeps the missing-key warning to once per command
+var warnedCiphertext bool
+
+// decryptForDisplay decrypts issues in place. Values that cannot be
+// decrypted stay as ciphertext, with a single warning.
+func decryptForDisplay(issues ...*types.Issue) {
+	var c *encryption.Cipher
+	loaded := false
+	for _, issue := range issues {
+		if !encryption.HasEncrypted(issue) {
+			continue
+		}
+		if !loaded {
+			c, loaded = loadFieldCipher(), true
+		}
+		if err := c.DecryptIssue(issue); err != nil && !warnedCiphertext {
+			warnedCiphertext = true
+			yellow := color.New(color.FgYellow).SprintFunc()
+			fmt.Fprintf(os.Stderr, "%s Some fields are shown encrypted: %v\n", yellow("⚠"), err)
+		}
+	}
+}
+
+func decryptCountedForDisplay(counted []*types.IssueWithCounts) {
+	issues := make([]*types.Issue, len(counted))
+	for i, issue := range counted {
+		issues[i] = issue.Issue
+	}
+	decryptForDisplay(issues...)
+}
+
+// decryptForEdit decrypts an issue whose text is about to be rewritten;
+// editing ciphertext would destroy it, so failure is fatal
+func decryptForEdit(issue *types.Issue) {
+	if !encryption.HasEncrypted(issue) {
+		return
+	}
+	if err := loadFieldCipher().DecryptIssue(issue); err != nil {
+		FatalErrorWithHint(err.Error(), "ask a teammate for the project key")
+	}
+}
+
+var encryptionCmd = &cobra.Command{
+	Use:   "encryption",
+	Short: "Manage client-side encryption of sensitive issue fields",
+	Long: `Manage client-side encryption of sensitive issue fields.
+
+List the fields to encrypt in .beads/config.yaml:
+
+  encryption:
+    fields: [description, notes]
+
+bd encrypts those fields before storing them, so the database and the JSONL
+committed to git only contain ciphertext; bd show and bd list decrypt them
+when the key is available. The key is per project and is never committed:
+it lives in .beads/encryption.key (gitignored) or in BD_ENCRYPTION_KEY.
+Share it with teammates out of band. Clones without the key see ciphertext
+and can still sync, but cannot create or edit encrypted fields.
+
+Encrypted fields cannot be searched or filtered by their text.
+
+Fields: description, design, acceptance_criteria, notes, and custom fields
+(bd field define) such as customer_name. Encrypted custom field values are
+stored without checks against the field's type, so encrypt string fields.
+Titles stay readable so lists and dependency trees keep working.`,
+}
+
+var encryptionInitCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Generate this project's encryption key",
+	Run: func(cmd *cobra.Command, args []string) {
+		force, _ := cmd.Flags().GetBool("force")
+		beadsDir := encryptionBeadsDir()
+		keyPath := encryption.KeyPath(beadsDir)
+		if _, err := os.Stat(keyPath); err == nil && !force {
+			FatalErrorWithHint(fmt.Sprintf("%s already exists", keyPath), "use --force to replace it; values encrypted with the old key become unreadable")
+		}
+
+		key, err := encryption.GenerateKey()
+		if err != nil {
+			FatalError("failed to generate key: %v", err)
+		}
+		if err := os.MkdirAll(filepath.Dir(keyPath), 0700); err != nil {
+			FatalError("%v", err)
+		}
+		if err := os.WriteFile(keyPath, []byte(encryption.EncodeKey(key)+"\n"), 0600); err != nil {
+			FatalError("failed to write key: %v", err)
+		}
+		if err := ensureGitignored(beadsDir, encryption.KeyFileName); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v; make sure %s is not committed\n", err, keyPath)
+		}
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"key_file": keyPath, "key_id": encryption.KeyID(key)})
+			return
+		}
+		green := color.New(color.FgGreen).SprintFunc()
+		fmt.Printf("%s Created encryption key %s in %s\n", green("✓"), encryption.KeyID(key), keyPath)
+		if len(config.GetStringSlice("encryption.fields")) == 0 {
+			fmt.Println("  List the fields to encrypt under encryption.fields in .beads/config.yaml")
+		}
+		fmt.Println("  Share the key with teammates out of band; never commit it")
+	},
+}
+
+var encryptionStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show encrypted fields, the key and any plaintext left to encrypt",
+	Run: func(cmd *cobra.Command, args []string) {
+		c := loadFieldCipher()
+		keySource := "none"
+		switch {
+		case config.GetString("encryption.key") != "":
+			keySource = "BD_ENCRYPTION_KEY"
+		case c.HasKey():
+			keySource = encryption.KeyPath(encryptionBeadsDir())
+		}
+
+		plaintext := 0
+		if c.Enabled() && store != nil {
+			issues, err := store.SearchIssues(rootCtx, "", types.IssueFilter{})
+			if err != nil {
+				FatalError("%v", err)
+			}
+			if err := loadIssueFields(rootCtx, issues); err != nil {
+				FatalError("%v", err)
+			}
+			for _, issue := range issues {
+				if len(c.Plaintext(issue)) > 0 {
+					plaintext++
+				}
+			}
+		}
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{
+				"fields":           c.Fields(),
+				"key_id":           c.KeyID(),
+				"key_source":       keySource,
+				"plaintext_issues": plaintext,
+			})
+			return
+		}
+		if !c.Enabled() {
+			fmt.Println("No fields are encrypted (set encryption.fields in .beads/config.yaml)")
+		} else {
+			fmt.Printf("Encrypted fields: %s\n", strings.Join(c.Fields(), ", "))
+		}
+		if c.HasKey() {
+			fmt.Printf("Key: %s (%s)\n", c.KeyID(), keySource)
+		} else {
+			fmt.Println("Key: none (run 'bd encryption init' or set BD_ENCRYPTION_KEY)")
+		}
+		if plaintext > 0 {
+			fmt.Printf("%d issue(s) still store these fields in plaintext; run 'bd encryption apply'\n", plaintext)
+		}
+	},
+}
+
+var encryptionApplyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Encrypt values stored before their field was marked encrypted",
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("encryption apply")
+		if err := ensureDirectMode("encryption apply requires direct database access"); err != nil {
+			FatalError("%v", err)
+		}
+		c := loadFieldCipher()
+		if !c.Enabled() {
+			FatalErrorWithHint("no fields are encrypted", "set encryption.fields in .beads/config.yaml")
+		}
+		if !c.HasKey() {
+			FatalErrorWithHint(encryption.ErrNoKey.Error(), "run 'bd encryption init' first")
+		}
+
+		ctx := rootCtx
+		issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+		if err != nil {
+			FatalError("%v", err)
+		}
+		if err := loadIssueFields(ctx, issues); err != nil {
+			FatalError("%v", err)
+		}
+		var encrypted []string
+		for _, issue := range issues {
+			updates, err := c.SealUpdates(issue)
+			if err != nil {
+				FatalError("%v", err)
+			}
+			sealed, err := c.SealFields(issue)
+			if err != nil {
+				FatalError("%v", err)
+			}
+			if updates == nil && sealed == nil {
+				continue
+			}
+			if updates != nil {
+				if err := store.UpdateIssue(ctx, issue.ID, updates, actor); err != nil {
+					FatalError("failed to encrypt %s: %v", issue.ID, err)
+				}
+			}
+			if err := storage.SetIssueFields(ctx, store, issue.ID, sealed, actor); err != nil {
+				FatalError("failed to encrypt %s: %v", issue.ID, err)
+			}
+			encrypted = append(encrypted, issue.ID)
+		}
+		if len(encrypted) > 0 {
+			markDirtyAndScheduleFlush()
+		}
+
+		if jsonOutput {
+			if encrypted == nil {
+				encrypted = []string{}
+			}
+			outputJSON(map[string]interface{}{"encrypted": encrypted})
+			return
+		}
+		green := color.New(color.FgGreen).SprintFunc()
+		fmt.Printf("%s Encrypted %d issue(s)\n", green("✓"), len(encrypted))
+	},
+}
+
+// ensureGitignored appends pattern to .beads/.gitignore unless it is listed
+func ensureGitignored(beadsDir, pattern string) error {
+	path := filepath.Join(beadsDir, ".gitignore")
+	// #nosec G304 - path is inside the beads directory
+	content, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		if strings.TrimSpace(line) == pattern {
+			return nil
+		}
+	}
+	if len(content) > 0 && !strings.HasSuffix(string(content), "\n") {
+		content = append(content, '\n')
+	}
+	content = append(content, pattern+"\n"...)
+	return os.WriteFile(path, content, 0600)
+}
+
+func init() {
+	encryptionInitCmd.Flags().Bool("force", false, "Replace an existing key")
+	encryptionCmd.AddCommand(encryptionInitCmd)
+	encryptionCmd.AddCommand(encryptionStatusCmd)
+	encryptionCmd.AddCommand(encryptionApplyCmd)
+	rootCmd.AddCommand(encryptionCmd)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/rpc"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
 )
 var epicCmd = &cobra.Command{
 	Use:   "epic",
@@ -15,8 +16,18 @@ var epicCmd = &cobra.Command{
 var epicStatusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Show epic completion status",
+	Long: `Show completion status of open epics.
+
+bd epic status <id> shows the rollup of that issue's whole parent-child
+hierarchy instead: how many descendants are closed, in progress, blocked or
+open, and the progress of each direct child.`,
+	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		eligibleOnly, _ := cmd.Flags().GetBool("eligible-only")
+		if len(args) == 1 {
+			showEpicRollup(args[0])
+			return
+		}
 		// Use global jsonOutput set by PersistentPreRun
 		var epics []*types.EpicStatus
 		var err error
@@ -205,6 +216,34 @@ var closeEligibleEpicsCmd = &cobra.Command{
 		}
 	},
 }
+// showEpicRollup prints the hierarchy rollup for one issue
+func showEpicRollup(id string) {
+	ctx := rootCtx
+	var resolvedID string
+	if daemonClient != nil {
+		resp, err := daemonClient.ResolveID(&rpc.ResolveIDArgs{ID: id})
+		if err != nil {
+			FatalError("resolving ID %s: %v", id, err)
+		}
+		if err := json.Unmarshal(resp.Data, &resolvedID); err != nil {
+			FatalError("unmarshaling resolved ID: %v", err)
+		}
+	} else {
+		var err error
+		if resolvedID, err = utils.ResolvePartialID(ctx, store, id); err != nil {
+			FatalError("%v", err)
+		}
+	}
+	rollup, err := buildEpicRollup(ctx, resolvedID, make(map[string]bool))
+	if err != nil {
+		FatalError("%v", err)
+	}
+	if jsonOutput {
+		outputJSON(rollup)
+		return
+	}
+	printEpicRollup(rollup)
+}
 func init() {
 	epicCmd.AddCommand(epicStatusCmd)
 	epicCmd.AddCommand(closeEligibleEpicsCmd)
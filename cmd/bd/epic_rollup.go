@@ -0,0 +1,284 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/steveyegge/beads/internal/hooks"
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+	"golang.org/x/term"
+)
+
+// epicCloseReason is recorded on parents closed once all their children were
+const epicCloseReason = "All children completed"
+
+// epicRollup is an issue's progress over every descendant in its
+// parent-child hierarchy, not just its direct children
+type epicRollup struct {
+	Epic             *types.Issue  `json:"epic"`
+	Total            int           `json:"total_descendants"`
+	Closed           int           `json:"closed"`
+	Resolved         int           `json:"resolved"`
+	InProgress       int           `json:"in_progress"`
+	Blocked          int           `json:"blocked"`
+	Open             int           `json:"open"`
+	Percent          int           `json:"percent"`
+	EligibleForClose bool          `json:"eligible_for_close"`
+	Children         []*epicRollup `json:"children,omitempty"`
+}
+
+// issueFamily returns an issue with its parents and direct children,
+// through the daemon when one is running
+func issueFamily(ctx context.Context, id string) (issue *types.Issue, parents, children []*types.Issue, err error) {
+	var deps, dependents []*types.IssueWithDependencyMetadata
+	if daemonClient != nil {
+		resp, err := daemonClient.Show(&rpc.ShowArgs{ID: id})
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		var details struct {
+			types.Issue
+			Dependencies []*types.IssueWithDependencyMetadata `json:"dependencies"`
+			Dependents   []*types.IssueWithDependencyMetadata `json:"dependents"`
+		}
+		if err := json.Unmarshal(resp.Data, &details); err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to parse %s: %w", id, err)
+		}
+		issue, deps, dependents = &details.Issue, details.Dependencies, details.Dependents
+	} else {
+		sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+		if !ok {
+			return nil, nil, nil, fmt.Errorf("epic rollups require SQLite storage")
+		}
+		if issue, err = sqliteStore.GetIssue(ctx, id); err != nil {
+			return nil, nil, nil, err
+		}
+		if issue == nil {
+			return nil, nil, nil, fmt.Errorf("issue %s not found", id)
+		}
+		if deps, err = sqliteStore.GetDependenciesWithMetadata(ctx, id); err != nil {
+			return nil, nil, nil, err
+		}
+		if dependents, err = sqliteStore.GetDependentsWithMetadata(ctx, id); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	for _, dep := range deps {
+		if dep.DependencyType == types.DepParentChild {
+			parent := dep.Issue
+			parents = append(parents, &parent)
+		}
+	}
+	for _, dep := range dependents {
+		if dep.DependencyType == types.DepParentChild && dep.Status != types.StatusTombstone {
+			child := dep.Issue
+			children = append(children, &child)
+		}
+	}
+	return issue, parents, children, nil
+}
+
+// buildEpicRollup totals the statuses of every descendant of id. seen
+// guards against parent-child cycles.
+func buildEpicRollup(ctx context.Context, id string, seen map[string]bool) (*epicRollup, error) {
+	seen[id] = true
+	issue, _, children, err := issueFamily(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	rollup := &epicRollup{Epic: issue}
+	for _, child := range children {
+		if seen[child.ID] {
+			continue
+		}
+		sub, err := buildEpicRollup(ctx, child.ID, seen)
+		if err != nil {
+			return nil, err
+		}
+		rollup.Children = append(rollup.Children, sub)
+		rollup.Total += 1 + sub.Total
+		rollup.Closed += sub.Closed
+		rollup.Resolved += sub.Resolved
+		rollup.InProgress += sub.InProgress
+		rollup.Blocked += sub.Blocked
+		rollup.Open += sub.Open
+		switch child.Status {
+		case types.StatusClosed:
+			rollup.Closed++
+		case types.StatusResolved:
+			rollup.Resolved++
+		case types.StatusInProgress:
+			rollup.InProgress++
+		case types.StatusBlocked:
+			rollup.Blocked++
+		default:
+			rollup.Open++
+		}
+	}
+	if rollup.Total > 0 {
+		rollup.Percent = rollup.Closed * 100 / rollup.Total
+	}
+	rollup.EligibleForClose = len(children) > 0 && allClosed(children) && issue.Status != types.StatusClosed
+	return rollup, nil
+}
+
+func allClosed(issues []*types.Issue) bool {
+	for _, issue := range issues {
+		if issue.Status != types.StatusClosed {
+			return false
+		}
+	}
+	return true
+}
+
+// printEpicRollup prints a rollup with a progress bar and one line per
+// direct child
+func printEpicRollup(rollup *epicRollup) {
+	cyan := color.New(color.FgCyan).SprintFunc()
+	green := color.New(color.FgGreen).SprintFunc()
+	bold := color.New(color.Bold).SprintFunc()
+
+	epic := rollup.Epic
+	fmt.Printf("%s %s %s [%s, %s]\n", statusMarker(epic.Status), cyan(epic.ID), bold(epic.Title), epic.IssueType, epic.Status)
+	if rollup.Total == 0 {
+		fmt.Println("   No children")
+		return
+	}
+	fmt.Printf("   Progress: %s %d/%d closed (%d%%)\n", progressBar(rollup.Closed, rollup.Total), rollup.Closed, rollup.Total, rollup.Percent)
+	var parts []string
+	for _, part := range []struct {
+		n     int
+		label string
+	}{
+		{rollup.InProgress, "in progress"},
+		{rollup.Resolved, "resolved"},
+		{rollup.Blocked, "blocked"},
+		{rollup.Open, "open"},
+	} {
+		if part.n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", part.n, part.label))
+		}
+	}
+	if len(parts) > 0 {
+		fmt.Printf("   Remaining: %s\n", strings.Join(parts, ", "))
+	}
+	if rollup.EligibleForClose {
+		fmt.Printf("   %s\n", green("All children closed - eligible for closure"))
+	}
+	fmt.Println()
+	for _, child := range rollup.Children {
+		line := fmt.Sprintf("   %s %s %s", statusMarker(child.Epic.Status), child.Epic.ID, child.Epic.Title)
+		if child.Total > 0 {
+			line += fmt.Sprintf(" (%d/%d)", child.Closed, child.Total)
+		}
+		fmt.Println(line)
+	}
+}
+
+func statusMarker(status types.Status) string {
+	switch status {
+	case types.StatusClosed:
+		return color.New(color.FgGreen).Sprint("✓")
+	case types.StatusInProgress, types.StatusResolved:
+		return color.New(color.FgYellow).Sprint("◐")
+	case types.StatusBlocked:
+		return color.New(color.FgRed).Sprint("●")
+	}
+	return "○"
+}
+
+// completedParents returns the open parents of the given issues whose
+// children are now all closed
+func completedParents(ctx context.Context, closedIDs []string) []*types.Issue {
+	var completed []*types.Issue
+	seen := make(map[string]bool)
+	for _, id := range closedIDs {
+		_, parents, _, err := issueFamily(ctx, id)
+		if err != nil {
+			continue
+		}
+		for _, parent := range parents {
+			if seen[parent.ID] || parent.Status == types.StatusClosed || parent.Status == types.StatusTombstone {
+				continue
+			}
+			seen[parent.ID] = true
+			_, _, children, err := issueFamily(ctx, parent.ID)
+			if err == nil && allClosed(children) {
+				completed = append(completed, parent)
+			}
+		}
+	}
+	return completed
+}
+
+// offerParentClosure runs after bd close. When the closed issues were the
+// last open children of a parent, it asks whether to close the parent too
+// (and then its parent, up the hierarchy); without a terminal it prints the
+// command instead.
+func offerParentClosure(ctx context.Context, closedIDs []string) {
+	if jsonOutput || len(closedIDs) == 0 {
+		return
+	}
+	interactive := term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stdout.Fd()))
+	reader := bufio.NewReader(os.Stdin)
+	green := color.New(color.FgGreen).SprintFunc()
+
+	for len(closedIDs) > 0 {
+		var closedNow []string
+		for _, parent := range completedParents(ctx, closedIDs) {
+			if !interactive {
+				fmt.Printf("\nAll children of %s (%s) are closed. Close it with: bd close %s\n", parent.ID, parent.Title, parent.ID)
+				continue
+			}
+			fmt.Printf("\nAll children of %s (%s) are closed. Close it too? [y/N] ", parent.ID, parent.Title)
+			answer, _ := reader.ReadString('\n')
+			answer = strings.ToLower(strings.TrimSpace(answer))
+			if answer != "y" && answer != "yes" {
+				continue
+			}
+			if err := closeCompletedParent(ctx, parent.ID); err != nil {
+				fmt.Fprintf(os.Stderr, "Error closing %s: %v\n", parent.ID, err)
+				continue
+			}
+			fmt.Printf("%s Closed %s: %s\n", green("✓"), parent.ID, epicCloseReason)
+			closedNow = append(closedNow, parent.ID)
+		}
+		closedIDs = closedNow
+	}
+}
+
+func closeCompletedParent(ctx context.Context, id string) error {
+	if daemonClient != nil {
+		resp, err := daemonClient.CloseIssue(&rpc.CloseArgs{ID: id, Reason: epicCloseReason})
+		if err != nil {
+			return err
+		}
+		var issue types.Issue
+		if err := json.Unmarshal(resp.Data, &issue); err == nil && hookRunner != nil {
+			hookRunner.Run(hooks.EventClose, &issue)
+		}
+		return nil
+	}
+	if err := storage.CheckVerifiedClose(ctx, store, id, actor); err != nil {
+		return err
+	}
+	if err := storage.CheckDefinitionOfDone(ctx, store, id); err != nil {
+		return err
+	}
+	if err := store.CloseIssue(ctx, id, epicCloseReason, actor); err != nil {
+		return err
+	}
+	if issue, _ := store.GetIssue(ctx, id); issue != nil && hookRunner != nil {
+		hookRunner.Run(hooks.EventClose, issue)
+	}
+	markDirtyAndScheduleFlush()
+	return nil
+}
@@ -202,3 +202,57 @@ func TestEpicEligibleForClose(t *testing.T) {
 		t.Error("Epic should be eligible for close when all children are closed")
 	}
 }
+
+func TestBuildEpicRollup(t *testing.T) {
+	tmpDir := t.TempDir()
+	testDB := filepath.Join(tmpDir, ".beads", "beads.db")
+	sqliteStore := newTestStore(t, testDB)
+	ctx := context.Background()
+
+	// epic -> (a closed, b in progress -> (b.1 closed, b.2 open))
+	for _, issue := range []*types.Issue{
+		{ID: "test-1", Title: "Epic", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeEpic},
+		{ID: "test-2", Title: "A", Status: types.StatusClosed, Priority: 2, IssueType: types.TypeTask, ClosedAt: ptrTime(time.Now())},
+		{ID: "test-3", Title: "B", Status: types.StatusInProgress, Priority: 2, IssueType: types.TypeFeature},
+		{ID: "test-4", Title: "B.1", Status: types.StatusClosed, Priority: 2, IssueType: types.TypeTask, ClosedAt: ptrTime(time.Now())},
+		{ID: "test-5", Title: "B.2", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+	} {
+		if err := sqliteStore.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for child, parent := range map[string]string{"test-2": "test-1", "test-3": "test-1", "test-4": "test-3", "test-5": "test-3"} {
+		dep := &types.Dependency{IssueID: child, DependsOnID: parent, Type: types.DepParentChild}
+		if err := sqliteStore.AddDependency(ctx, dep, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	oldStore, oldClient := store, daemonClient
+	store, daemonClient = sqliteStore, nil
+	defer func() { store, daemonClient = oldStore, oldClient }()
+
+	rollup, err := buildEpicRollup(ctx, "test-1", make(map[string]bool))
+	if err != nil {
+		t.Fatalf("buildEpicRollup failed: %v", err)
+	}
+	if rollup.Total != 4 || rollup.Closed != 2 || rollup.InProgress != 1 || rollup.Open != 1 || rollup.Percent != 50 {
+		t.Errorf("rollup = %d total, %d closed, %d in progress, %d open, %d%%; want 4, 2, 1, 1, 50%%",
+			rollup.Total, rollup.Closed, rollup.InProgress, rollup.Open, rollup.Percent)
+	}
+	if len(rollup.Children) != 2 || rollup.EligibleForClose {
+		t.Errorf("children = %d, eligible = %v", len(rollup.Children), rollup.EligibleForClose)
+	}
+
+	// Closing the last open grandchild completes B, but not the epic
+	if parents := completedParents(ctx, []string{"test-5"}); len(parents) != 0 {
+		t.Errorf("completedParents before closing = %v", parents)
+	}
+	if err := sqliteStore.CloseIssue(ctx, "test-5", "done", "test"); err != nil {
+		t.Fatal(err)
+	}
+	parents := completedParents(ctx, []string{"test-5"})
+	if len(parents) != 1 || parents[0].ID != "test-3" {
+		t.Errorf("completedParents = %v, want [test-3]", parents)
+	}
+}
@@ -20,6 +20,7 @@ import (
 //	}
 func FatalError(format string, args ...interface{}) {
 	fmt.Fprintf(os.Stderr, "Error: "+format+"\n", args...)
+	finishJournal(1, fmt.Sprintf(format, args...))
 	os.Exit(1)
 }
 
@@ -32,6 +33,7 @@ func FatalError(format string, args ...interface{}) {
 func FatalErrorWithHint(message, hint string) {
 	fmt.Fprintf(os.Stderr, "Error: %s\n", message)
 	fmt.Fprintf(os.Stderr, "Hint: %s\n", hint)
+	finishJournal(1, message)
 	os.Exit(1)
 }
 
@@ -65,6 +67,10 @@ func WarnError(format string, args ...interface{}) {
 //	    },
 //	}
 func CheckReadonly(operation string) {
+	if readonlyReason != "" {
+		FatalErrorWithHint(fmt.Sprintf("operation '%s' is not allowed: %s", operation, readonlyReason),
+			"bd can only read issues here; make changes in a writable checkout")
+	}
 	if readonlyMode {
 		FatalError("operation '%s' is not allowed in read-only mode", operation)
 	}
@@ -0,0 +1,392 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// Sources of actual time for closed issues
+const (
+	actualSourceAuto  = "auto"  // git effort when the issue has commits, else cycle time
+	actualSourceGit   = "git"   // session time estimated from linked commits
+	actualSourceCycle = "cycle" // in_progress → closed
+)
+
+// EstimateProfile is what similarity is computed from, plus the actual time
+// for closed issues
+type EstimateProfile struct {
+	ID               string          `json:"id"`
+	Title            string          `json:"title"`
+	IssueType        types.IssueType `json:"issue_type"`
+	Labels           []string        `json:"labels,omitempty"`
+	Dirs             []string        `json:"dirs,omitempty"` // directories touched by linked commits
+	EstimatedMinutes *int            `json:"estimated_minutes,omitempty"`
+	ActualMinutes    int             `json:"actual_minutes,omitempty"`
+	ActualSource     string          `json:"actual_source,omitempty"`
+}
+
+// SimilarIssue is one historical issue a suggestion is based on
+type SimilarIssue struct {
+	*EstimateProfile
+	Similarity float64 `json:"similarity"`
+}
+
+// EstimateSuggestion is the output of 'bd estimate suggest'
+type EstimateSuggestion struct {
+	IssueID          string          `json:"issue_id"`
+	SuggestedMinutes int             `json:"suggested_minutes"`
+	LowMinutes       int             `json:"low_minutes"`  // 25th percentile of the similar issues
+	HighMinutes      int             `json:"high_minutes"` // 75th percentile
+	CurrentMinutes   *int            `json:"current_minutes,omitempty"`
+	Accuracy         *float64        `json:"accuracy,omitempty"` // median actual/estimate over similar issues that had estimates
+	Basis            string          `json:"basis"`              // "similar" or "issue_type" (fallback)
+	Similar          []*SimilarIssue `json:"similar"`
+	Applied          bool            `json:"applied,omitempty"`
+}
+
+var estimateCmd = &cobra.Command{
+	Use:   "estimate",
+	Short: "Learn estimates from completed work",
+}
+
+var estimateSuggestCmd = &cobra.Command{
+	Use:   "suggest <issue-id>",
+	Short: "Suggest an estimate from similar completed issues",
+	Long: `Propose estimated_minutes for an issue from how long similar closed issues
+actually took.
+
+Similarity combines shared labels, shared directories touched by the
+issues' linked commits (see bd effort report) and issue type. The suggestion
+is the similarity-weighted median of the --top most similar issues, with the
+25th-75th percentile range. When nothing is similar, issues of the same type
+are used instead.
+
+Actual time comes from --source:
+  auto   git session time when the issue has linked commits, else cycle time
+  git    only issues with linked commits (bd effort report's actual time)
+  cycle  time from in_progress to closed
+
+The report also shows how past estimates of the similar issues compared
+with their actual time, so teams can see whether they tend to over- or
+under-estimate that kind of work.
+
+Examples:
+  bd estimate suggest bd-42
+  bd estimate suggest bd-42 --apply      # Set estimated_minutes to the suggestion
+  bd estimate suggest bd-42 --source cycle --top 10 --json`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		top, _ := cmd.Flags().GetInt("top")
+		source, _ := cmd.Flags().GetString("source")
+		apply, _ := cmd.Flags().GetBool("apply")
+		if top <= 0 {
+			FatalError("--top must be positive")
+		}
+		if source != actualSourceAuto && source != actualSourceGit && source != actualSourceCycle {
+			FatalError("invalid --source %q (valid: auto, git, cycle)", source)
+		}
+		if apply {
+			CheckReadonly("estimate suggest --apply")
+		}
+
+		if err := ensureDirectMode("estimate suggest requires direct database access"); err != nil {
+			FatalError("%v", err)
+		}
+		sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+		if !ok {
+			FatalError("estimate suggest requires SQLite storage")
+		}
+		ctx := rootCtx
+
+		id, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			FatalError("resolving %s: %v", args[0], err)
+		}
+
+		issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+		if err != nil {
+			FatalError("%v", err)
+		}
+		ids := make([]string, len(issues))
+		for i, issue := range issues {
+			ids[i] = issue.ID
+		}
+		labels, err := store.GetLabelsForIssues(ctx, ids)
+		if err != nil {
+			FatalError("%v", err)
+		}
+
+		// Git history is optional: without it only labels and type count
+		gitMinutes := make(map[string]int)
+		dirs := make(map[string][]string)
+		if commits, err := readEffortCommits(ctx, time.Time{}); err == nil {
+			report, err := buildEffortReport(ctx, store, issues, commits, readEffortBranches(ctx, issues), nil, 2*time.Hour, 30*time.Minute)
+			if err != nil {
+				FatalError("%v", err)
+			}
+			for _, e := range report.Issues {
+				gitMinutes[e.ID] = e.ActualMinutes
+				dirs[e.ID] = commitDirs(e.CommitList)
+			}
+		} else if source == actualSourceGit {
+			FatalErrorWithHint(err.Error(), "run bd estimate suggest inside the project's git repository")
+		}
+
+		samples, err := sqliteStore.GetCycleTimeSamples(ctx, time.Time{})
+		if err != nil {
+			FatalError("%v", err)
+		}
+		cycleMinutes := make(map[string]int)
+		for _, sample := range samples {
+			if sample.StartedAt != nil {
+				cycleMinutes[sample.IssueID] = int(sample.ClosedAt.Sub(*sample.StartedAt) / time.Minute)
+			}
+		}
+
+		var target *EstimateProfile
+		var history []*EstimateProfile
+		for _, issue := range issues {
+			profile := &EstimateProfile{
+				ID:               issue.ID,
+				Title:            issue.Title,
+				IssueType:        issue.IssueType,
+				Labels:           labels[issue.ID],
+				Dirs:             dirs[issue.ID],
+				EstimatedMinutes: issue.EstimatedMinutes,
+			}
+			if issue.ID == id {
+				target = profile
+				continue
+			}
+			if issue.Status != types.StatusClosed {
+				continue
+			}
+			profile.ActualMinutes, profile.ActualSource = actualMinutes(issue.ID, source, gitMinutes, cycleMinutes)
+			if profile.ActualMinutes > 0 {
+				history = append(history, profile)
+			}
+		}
+		if target == nil {
+			FatalError("issue %s not found", id)
+		}
+
+		suggestion := suggestEstimate(target, history, top)
+		if suggestion == nil {
+			FatalErrorWithHint("no closed issues with a known actual time to learn from",
+				"close issues after moving them to in_progress, or mention their IDs in commit messages")
+		}
+
+		if apply {
+			minutes := suggestion.SuggestedMinutes
+			if err := store.UpdateIssue(ctx, id, map[string]interface{}{"estimated_minutes": minutes}, actor); err != nil {
+				FatalError("setting estimate: %v", err)
+			}
+			markDirtyAndScheduleFlush()
+			suggestion.Applied = true
+		}
+
+		if jsonOutput {
+			outputJSON(suggestion)
+			return
+		}
+		printEstimateSuggestion(suggestion)
+	},
+}
+
+// actualMinutes picks an issue's actual time from the chosen source
+func actualMinutes(id, source string, gitMinutes, cycleMinutes map[string]int) (int, string) {
+	switch source {
+	case actualSourceGit:
+		return gitMinutes[id], actualSourceGit
+	case actualSourceCycle:
+		return cycleMinutes[id], actualSourceCycle
+	}
+	if m := gitMinutes[id]; m > 0 {
+		return m, actualSourceGit
+	}
+	return cycleMinutes[id], actualSourceCycle
+}
+
+// commitDirs lists the directories touched by a set of commits
+func commitDirs(commits []*EffortCommit) []string {
+	seen := make(map[string]bool)
+	for _, c := range commits {
+		for _, f := range c.Files {
+			seen[path.Dir(f)] = true
+		}
+	}
+	result := make([]string, 0, len(seen))
+	for d := range seen {
+		result = append(result, d)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// estimateSimilarity scores two issues in [0, 1]: label overlap and
+// directory overlap (Jaccard) weigh most, a matching type adds a little
+func estimateSimilarity(a, b *EstimateProfile) float64 {
+	score := 0.45*jaccard(a.Labels, b.Labels) + 0.45*jaccard(a.Dirs, b.Dirs)
+	if a.IssueType == b.IssueType {
+		score += 0.1
+	}
+	return score
+}
+
+func jaccard(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	inA := make(map[string]bool, len(a))
+	for _, s := range a {
+		inA[s] = true
+	}
+	union := len(inA)
+	shared := 0
+	counted := make(map[string]bool, len(b))
+	for _, s := range b {
+		if counted[s] {
+			continue
+		}
+		counted[s] = true
+		if inA[s] {
+			shared++
+		} else {
+			union++
+		}
+	}
+	return float64(shared) / float64(union)
+}
+
+// suggestEstimate proposes an estimate for target from the top most similar
+// closed issues in history. Issues that only share the type are a fallback
+// when nothing shares labels or directories. Returns nil without history.
+func suggestEstimate(target *EstimateProfile, history []*EstimateProfile, top int) *EstimateSuggestion {
+	var similar []*SimilarIssue
+	for _, h := range history {
+		if score := estimateSimilarity(target, h); score > 0.1 {
+			similar = append(similar, &SimilarIssue{EstimateProfile: h, Similarity: score})
+		}
+	}
+	basis := "similar"
+	if len(similar) == 0 {
+		basis = "issue_type"
+		for _, h := range history {
+			if h.IssueType == target.IssueType {
+				similar = append(similar, &SimilarIssue{EstimateProfile: h, Similarity: 0.1})
+			}
+		}
+	}
+	if len(similar) == 0 {
+		return nil
+	}
+	sort.SliceStable(similar, func(i, j int) bool {
+		if similar[i].Similarity != similar[j].Similarity {
+			return similar[i].Similarity > similar[j].Similarity
+		}
+		return similar[i].ID < similar[j].ID
+	})
+	if len(similar) > top {
+		similar = similar[:top]
+	}
+
+	s := &EstimateSuggestion{
+		IssueID:          target.ID,
+		SuggestedMinutes: weightedPercentile(similar, 0.5),
+		LowMinutes:       weightedPercentile(similar, 0.25),
+		HighMinutes:      weightedPercentile(similar, 0.75),
+		CurrentMinutes:   target.EstimatedMinutes,
+		Basis:            basis,
+		Similar:          similar,
+	}
+	var ratios []float64
+	for _, sim := range similar {
+		if sim.EstimatedMinutes != nil && *sim.EstimatedMinutes > 0 {
+			ratios = append(ratios, float64(sim.ActualMinutes)/float64(*sim.EstimatedMinutes))
+		}
+	}
+	if len(ratios) > 0 {
+		sort.Float64s(ratios)
+		median := ratios[len(ratios)/2]
+		if len(ratios)%2 == 0 {
+			median = (ratios[len(ratios)/2-1] + median) / 2
+		}
+		s.Accuracy = &median
+	}
+	return s
+}
+
+// weightedPercentile returns the actual minutes at percentile p of the
+// similar issues, each weighted by its similarity
+func weightedPercentile(similar []*SimilarIssue, p float64) int {
+	sorted := append([]*SimilarIssue(nil), similar...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].ActualMinutes < sorted[j].ActualMinutes })
+	total := 0.0
+	for _, s := range sorted {
+		total += s.Similarity
+	}
+	cumulative := 0.0
+	for _, s := range sorted {
+		cumulative += s.Similarity
+		if cumulative >= p*total-1e-9 {
+			return s.ActualMinutes
+		}
+	}
+	return sorted[len(sorted)-1].ActualMinutes
+}
+
+func printEstimateSuggestion(s *EstimateSuggestion) {
+	cyan := color.New(color.FgCyan).SprintFunc()
+	hours := func(m int) string { return formatHours(float64(m) / 60) }
+
+	fmt.Printf("\n%s Suggested estimate for %s: %s (%d minutes)\n", cyan("⏱"), s.IssueID,
+		hours(s.SuggestedMinutes), s.SuggestedMinutes)
+	fmt.Printf("  Likely range: %s – %s\n", hours(s.LowMinutes), hours(s.HighMinutes))
+	if s.CurrentMinutes != nil {
+		fmt.Printf("  Current estimate: %s\n", hours(*s.CurrentMinutes))
+	}
+	if s.Accuracy != nil {
+		direction := "took longer than estimated"
+		if *s.Accuracy < 1 {
+			direction = "finished under their estimates"
+		}
+		if math.Abs(*s.Accuracy-1) < 0.1 {
+			direction = "matched their estimates"
+		}
+		fmt.Printf("  Past estimates: similar issues %s (actual/estimate %.1fx)\n", direction, *s.Accuracy)
+	}
+	if s.Basis == "issue_type" {
+		fmt.Println("  Nothing shares labels or code paths; based on issues of the same type")
+	}
+
+	fmt.Printf("\n  %-14s %-30s %6s %8s %8s  %s\n", "ID", "TITLE", "MATCH", "ACTUAL", "ESTIMATE", "SOURCE")
+	for _, sim := range s.Similar {
+		estimate := "-"
+		if sim.EstimatedMinutes != nil {
+			estimate = hours(*sim.EstimatedMinutes)
+		}
+		fmt.Printf("  %-14s %-30s %5.0f%% %8s %8s  %s\n", sim.ID, truncateTitle(sim.Title, 30),
+			sim.Similarity*100, hours(sim.ActualMinutes), estimate, sim.ActualSource)
+	}
+	if s.Applied {
+		fmt.Printf("\n%s Set estimated_minutes to %d\n", color.New(color.FgGreen).Sprint("✓"), s.SuggestedMinutes)
+	}
+	fmt.Println()
+}
+
+func init() {
+	estimateSuggestCmd.Flags().Int("top", 5, "Number of similar issues to base the suggestion on")
+	estimateSuggestCmd.Flags().String("source", actualSourceAuto, "Actual time source: auto, git, cycle")
+	estimateSuggestCmd.Flags().Bool("apply", false, "Set the issue's estimated_minutes to the suggestion")
+	estimateCmd.AddCommand(estimateSuggestCmd)
+	rootCmd.AddCommand(estimateCmd)
+}
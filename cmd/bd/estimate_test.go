@@ -0,0 +1,67 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSuggestEstimate(t *testing.T) {
+	intp := func(v int) *int { return &v }
+	target := &EstimateProfile{ID: "bd-9", IssueType: types.TypeBug, Labels: []string{"auth", "backend"}, Dirs: []string{"internal/auth"}}
+	history := []*EstimateProfile{
+		{ID: "bd-1", IssueType: types.TypeBug, Labels: []string{"auth", "backend"}, Dirs: []string{"internal/auth"}, ActualMinutes: 120, EstimatedMinutes: intp(60)},
+		{ID: "bd-2", IssueType: types.TypeBug, Labels: []string{"auth"}, ActualMinutes: 90, EstimatedMinutes: intp(60)},
+		{ID: "bd-3", IssueType: types.TypeTask, Labels: []string{"backend"}, Dirs: []string{"internal/auth"}, ActualMinutes: 240},
+		{ID: "bd-4", IssueType: types.TypeTask, Labels: []string{"docs"}, ActualMinutes: 15},
+		{ID: "bd-5", IssueType: types.TypeBug, Labels: []string{"ui"}, ActualMinutes: 30},
+	}
+
+	s := suggestEstimate(target, history, 3)
+	if s == nil || s.Basis != "similar" {
+		t.Fatalf("suggestion = %+v", s)
+	}
+	if len(s.Similar) != 3 || s.Similar[0].ID != "bd-1" || s.Similar[0].Similarity != 1 {
+		t.Fatalf("similar = %v", s.Similar)
+	}
+	for _, sim := range s.Similar {
+		if sim.ID == "bd-4" || sim.ID == "bd-5" {
+			t.Errorf("%s should not be among the top matches", sim.ID)
+		}
+	}
+	// bd-1 (weight 1) dominates the weighted median
+	if s.SuggestedMinutes != 120 || s.LowMinutes != 120 || s.HighMinutes != 240 {
+		t.Errorf("suggested %d (%d-%d), want 120 (120-240)", s.SuggestedMinutes, s.LowMinutes, s.HighMinutes)
+	}
+	// bd-1 took 2x, bd-2 1.5x
+	if s.Accuracy == nil || *s.Accuracy != 1.75 {
+		t.Errorf("accuracy = %v, want 1.75", s.Accuracy)
+	}
+
+	// Nothing shares labels or paths: fall back to the issue type
+	lonely := &EstimateProfile{ID: "bd-10", IssueType: types.TypeTask, Labels: []string{"infra"}}
+	s = suggestEstimate(lonely, history, 5)
+	if s == nil || s.Basis != "issue_type" || len(s.Similar) != 2 {
+		t.Fatalf("fallback suggestion = %+v", s)
+	}
+
+	if suggestEstimate(lonely, nil, 5) != nil {
+		t.Error("expected no suggestion without history")
+	}
+}
+
+func TestJaccard(t *testing.T) {
+	tests := []struct {
+		a, b []string
+		want float64
+	}{
+		{nil, []string{"x"}, 0},
+		{[]string{"a", "b"}, []string{"b", "c"}, 1.0 / 3},
+		{[]string{"a"}, []string{"a", "a"}, 1},
+	}
+	for _, tt := range tests {
+		if got := jaccard(tt.a, tt.b); got != tt.want {
+			t.Errorf("jaccard(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
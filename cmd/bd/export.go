@@ -12,9 +12,12 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/debug"
+	"github.com/steveyegge/beads/internal/encryption"
+	"github.com/steveyegge/beads/internal/redact"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/util"
+	"github.com/steveyegge/beads/internal/utils"
 	"github.com/steveyegge/beads/internal/validation"
 )
 
@@ -118,8 +121,14 @@ Issues are sorted by ID for consistent diffs.
 
 Output to stdout by default, or use -o flag for file output.
 
+With --include-config the export starts with a config line holding the
+project config from the database (workflow, routing, label definitions), so
+'bd import --config' can restore a complete project on another machine.
+Credentials and machine-local keys (tokens, last-sync markers) are left out.
+
 Examples:
   bd export --status open -o open-issues.jsonl
+  bd export --include-config -o backup.jsonl
   bd export --type bug --priority-max 1
   bd export --created-after 2025-01-01 --assignee alice`,
 	Run: func(cmd *cobra.Command, args []string) {
@@ -127,6 +136,7 @@ Examples:
 		output, _ := cmd.Flags().GetString("output")
 		statusFilter, _ := cmd.Flags().GetString("status")
 		force, _ := cmd.Flags().GetBool("force")
+		includeConfig, _ := cmd.Flags().GetBool("include-config")
 
 		// Additional filter flags
 		assignee, _ := cmd.Flags().GetString("assignee")
@@ -147,6 +157,12 @@ Examples:
 			os.Exit(1)
 		}
 
+		// The synced JSONL must stay issues-only: other clones import it
+		if includeConfig && output != "" && utils.CanonicalizePath(output) == utils.CanonicalizePath(findJSONLPath()) {
+			fmt.Fprintf(os.Stderr, "Error: --include-config cannot write to the synced JSONL file; use -o with another path\n")
+			os.Exit(1)
+		}
+
 		// Export command requires direct database access for consistent snapshot
 		// If daemon is connected, close it and open direct connection
 		if daemonClient != nil {
@@ -370,6 +386,73 @@ Examples:
 			issue.Labels = labels
 		}
 
+		// Populate attachments for all issues
+		for _, issue := range issues {
+			attachments, err := store.GetAttachments(ctx, issue.ID)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error getting attachments for %s: %v\n", issue.ID, err)
+				os.Exit(1)
+			}
+			issue.Attachments = attachments
+		}
+
+		// Populate work log for all issues
+		for _, issue := range issues {
+			workLog, err := store.GetWorkLog(ctx, issue.ID)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error getting work log for %s: %v\n", issue.ID, err)
+				os.Exit(1)
+			}
+			issue.WorkLog = workLog
+		}
+
+		// Populate aliases for all issues
+		issueIDs := make([]string, len(issues))
+		for i, issue := range issues {
+			issueIDs[i] = issue.ID
+		}
+		allAliases, err := store.GetAliasesForIssues(ctx, issueIDs)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting aliases: %v\n", err)
+			os.Exit(1)
+		}
+		for _, issue := range issues {
+			issue.Aliases = allAliases[issue.ID]
+		}
+		allTranslations, err := store.GetTranslationsForIssues(ctx, issueIDs)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting translations: %v\n", err)
+			os.Exit(1)
+		}
+		for _, issue := range issues {
+			issue.Translations = allTranslations[issue.ID]
+		}
+		allFields, err := store.GetFieldsForIssues(ctx, issueIDs)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting fields: %v\n", err)
+			os.Exit(1)
+		}
+		for _, issue := range issues {
+			issue.Fields = allFields[issue.ID]
+		}
+		allComments, err := store.GetCommentsForIssues(ctx, issueIDs)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting comments: %v\n", err)
+			os.Exit(1)
+		}
+		for _, issue := range issues {
+			issue.Comments = allComments[issue.ID]
+		}
+
+		var exportConfig map[string]string
+		if includeConfig {
+			exportConfig, err = exportableConfig(ctx, store)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error reading config: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
 		// Open output
 		out := os.Stdout
 		var tempFile *os.File
@@ -406,11 +489,32 @@ Examples:
 		}
 
 		// Write JSONL (timestamp-only deduplication DISABLED due to bd-160)
+		redactor, err := redact.FromConfig()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fieldCipher, err := encryption.FromConfig(filepath.Dir(dbPath))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
 		encoder := json.NewEncoder(out)
+		if includeConfig {
+			if err := encoder.Encode(configBundle{Config: exportConfig}); err != nil {
+				fmt.Fprintf(os.Stderr, "Error encoding config: %v\n", err)
+				os.Exit(1)
+			}
+		}
 		exportedIDs := make([]string, 0, len(issues))
 		skippedCount := 0
 		for _, issue := range issues {
-			if err := encoder.Encode(issue); err != nil {
+			exported, err := fieldCipher.ForExport(redactor.ForExport(issue))
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if err := encoder.Encode(exported); err != nil {
 			 fmt.Fprintf(os.Stderr, "Error encoding issue %s: %v\n", issue.ID, err)
 			 os.Exit(1)
 			}
@@ -478,6 +582,9 @@ Examples:
 			fmt.Fprintf(os.Stderr, "Error: Export verification failed: %v\n", err)
 			os.Exit(1)
 		}
+		if includeConfig {
+			actualCount-- // The config line decodes as an (empty) issue
+		}
 		if actualCount != len(exportedIDs) {
 			fmt.Fprintf(os.Stderr, "Error: Export verification failed\n")
 			fmt.Fprintf(os.Stderr, "  Expected: %d issues\n", len(exportedIDs))
@@ -490,6 +597,11 @@ Examples:
 			// Only do this when exporting to default JSONL path (not arbitrary outputs)
 			// This prevents validatePreExport from incorrectly blocking on next export
 			if output == "" || output == findJSONLPath() {
+				if err := syncJSONLShards(finalPath); err != nil {
+					fmt.Fprintf(os.Stderr, "Error: failed to write JSONL shards: %v\n", err)
+					os.Exit(1)
+				}
+
 				beadsDir := filepath.Dir(finalPath)
 				dbPath := filepath.Join(beadsDir, "beads.db")
 				if err := TouchDatabaseFile(dbPath, finalPath); err != nil {
@@ -510,6 +622,9 @@ Examples:
 			if output != "" {
 				stats["output_file"] = output
 			}
+			if includeConfig {
+				stats["config_keys"] = len(exportConfig)
+			}
 			data, _ := json.MarshalIndent(stats, "", "  ")
 			fmt.Fprintln(os.Stderr, string(data))
 		}
@@ -521,6 +636,7 @@ func init() {
 	exportCmd.Flags().StringP("output", "o", "", "Output file (default: stdout)")
 	exportCmd.Flags().StringP("status", "s", "", "Filter by status")
 	exportCmd.Flags().Bool("force", false, "Force export even if database is empty")
+	exportCmd.Flags().Bool("include-config", false, "Include project config from the database as the first line")
 	exportCmd.Flags().BoolVar(&jsonOutput, "json", false, "Output export statistics in JSON format")
 
 	// Filter flags
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/debug"
+	"github.com/steveyegge/beads/internal/export"
+)
+
+// syncJSONLShards brings the shard directory in line with export.layout after
+// the JSONL at jsonlPath was written: sharded layouts re-split it, the single
+// layout removes shards left over from an earlier sharded setup.
+func syncJSONLShards(jsonlPath string) error {
+	layout := config.GetString("export.layout")
+	shardBy := config.GetString("export.shard_by")
+	if err := export.ValidateLayout(layout, shardBy); err != nil {
+		return err
+	}
+
+	if layout != export.LayoutSharded {
+		if export.HasShards(jsonlPath) {
+			debug.Logf("export.layout is %s, removing shards in %s", layout, export.ShardDir(jsonlPath))
+			return export.RemoveShards(jsonlPath)
+		}
+		return nil
+	}
+
+	if _, err := export.WriteShards(jsonlPath, shardBy); err != nil {
+		return err
+	}
+	return ignoreShardedJSONL(jsonlPath)
+}
+
+// assembleJSONLShards rebuilds the JSONL from its shards (e.g. after a pull)
+// so importers can keep reading a single file. Conflicted or unreadable
+// shards leave the JSONL untouched.
+func assembleJSONLShards(jsonlPath string) {
+	changed, err := export.AssembleShards(jsonlPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to assemble %s from shards: %v\n", filepath.Base(jsonlPath), err)
+		return
+	}
+	if changed {
+		debug.Logf("assembled %s from %s", jsonlPath, export.ShardDir(jsonlPath))
+	}
+}
+
+// ignoreShardedJSONL makes .beads/.gitignore ignore the assembled JSONL, which
+// is only a local working copy once the shards are committed
+func ignoreShardedJSONL(jsonlPath string) error {
+	gitignorePath := filepath.Join(filepath.Dir(jsonlPath), ".gitignore")
+	// #nosec G304 - path derived from the JSONL location
+	content, err := os.ReadFile(gitignorePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	name := filepath.Base(jsonlPath)
+	lines := strings.Split(string(content), "\n")
+	found := false
+	for i, line := range lines {
+		switch strings.TrimSpace(line) {
+		case name:
+			return nil
+		case "!" + name:
+			lines[i] = name
+			found = true
+		}
+	}
+	updated := strings.Join(lines, "\n")
+	if !found {
+		if !strings.HasSuffix(updated, "\n") {
+			updated += "\n"
+		}
+		updated += fmt.Sprintf("\n# Assembled from %s/ (export.layout: sharded)\n%s\n", filepath.Base(export.ShardDir(jsonlPath)), name)
+	}
+	// #nosec G306 - matches the permissions bd init uses for .gitignore
+	return os.WriteFile(gitignorePath, []byte(updated), 0600)
+}
@@ -0,0 +1,323 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/parquet"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ParquetTableResult describes one file written by 'bd export parquet'
+type ParquetTableResult struct {
+	Table string `json:"table"`
+	Path  string `json:"path"`
+	Rows  int    `json:"rows"`
+}
+
+var exportParquetCmd = &cobra.Command{
+	Use:   "parquet",
+	Short: "Export the backlog to Parquet files for analytics",
+	Long: `Export issues, labels, dependencies, events and comments to Apache Parquet,
+one file per table, so DuckDB, Spark or pandas can analyze backlog history
+without touching the live database.
+
+The issues table goes to --out; the others are written next to it with the
+table name before the extension:
+
+  backlog.parquet               issues (including tombstones)
+  backlog.labels.parquet        issue_id, label
+  backlog.dependencies.parquet  issue_id, depends_on_id, type, created_at, created_by
+  backlog.events.parquet        the full audit trail
+  backlog.comments.parquet      issue comments
+
+Timestamps are stored as UTC microseconds; empty optional text is null.
+
+Examples:
+  bd export parquet --out backlog.parquet
+  duckdb -c "SELECT status, count(*) FROM 'backlog.parquet' GROUP BY status"
+  duckdb -c "SELECT i.id, count(*) FROM 'backlog.parquet' i
+             JOIN 'backlog.events.parquet' e ON e.issue_id = i.id GROUP BY i.id"`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		out, _ := cmd.Flags().GetString("out")
+		if !strings.HasSuffix(out, ".parquet") {
+			FatalErrorWithHint(fmt.Sprintf("--out must end in .parquet, got %q", out), "e.g. --out backlog.parquet")
+		}
+		if err := ensureDirectMode("parquet export requires direct database access"); err != nil {
+			FatalError("%v", err)
+		}
+
+		results, err := exportParquet(rootCtx, store, out)
+		if err != nil {
+			FatalError("%v", err)
+		}
+		if jsonOutput {
+			outputJSON(results)
+			return
+		}
+		for _, r := range results {
+			fmt.Printf("Wrote %d %s to %s\n", r.Rows, r.Table, r.Path)
+		}
+	},
+}
+
+// parquetTablePath names a table's file: issues use out itself, other tables
+// insert their name before the extension
+func parquetTablePath(out, table string) string {
+	if table == "issues" {
+		return out
+	}
+	return strings.TrimSuffix(out, ".parquet") + "." + table + ".parquet"
+}
+
+// exportParquet writes every table of the backlog next to out
+func exportParquet(ctx context.Context, s storage.Storage, out string) ([]ParquetTableResult, error) {
+	issues, err := s.SearchIssues(ctx, "", types.IssueFilter{IncludeTombstones: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issues: %w", err)
+	}
+	sort.Slice(issues, func(i, j int) bool { return issues[i].ID < issues[j].ID })
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+
+	createdBy := "bd version " + Version
+	tables := []struct {
+		name  string
+		build func() (*parquet.Writer, error)
+	}{
+		{"issues", func() (*parquet.Writer, error) { return parquetIssues(createdBy, issues) }},
+		{"labels", func() (*parquet.Writer, error) { return parquetLabels(ctx, s, createdBy, ids) }},
+		{"dependencies", func() (*parquet.Writer, error) { return parquetDependencies(ctx, s, createdBy) }},
+		{"events", func() (*parquet.Writer, error) { return parquetEvents(ctx, s, createdBy, ids) }},
+		{"comments", func() (*parquet.Writer, error) { return parquetComments(ctx, s, createdBy, ids) }},
+	}
+
+	if dir := filepath.Dir(out); dir != "." {
+		if err := os.MkdirAll(dir, 0750); err != nil {
+			return nil, fmt.Errorf("failed to create %s: %w", dir, err)
+		}
+	}
+	var results []ParquetTableResult
+	for _, table := range tables {
+		w, err := table.build()
+		if err != nil {
+			return nil, fmt.Errorf("failed to build %s table: %w", table.name, err)
+		}
+		path := parquetTablePath(out, table.name)
+		if err := writeParquetFile(path, w); err != nil {
+			return nil, err
+		}
+		results = append(results, ParquetTableResult{Table: table.name, Path: path, Rows: w.Rows()})
+	}
+	return results, nil
+}
+
+// writeParquetFile writes through a temp file so readers never see a
+// partial file
+func writeParquetFile(path string, w *parquet.Writer) error {
+	f, err := os.CreateTemp(filepath.Dir(path), ".bd-parquet-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmp := f.Name()
+	defer func() { _ = os.Remove(tmp) }()
+	if _, err := w.WriteTo(f); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	// #nosec G302 -- export files are meant to be shared like the JSONL
+	if err := os.Chmod(tmp, 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// nullString maps empty optional text to null
+func nullString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func nullStringPtr(s *string) interface{} {
+	if s == nil {
+		return nil
+	}
+	return nullString(*s)
+}
+
+func nullInt(n *int) interface{} {
+	if n == nil {
+		return nil
+	}
+	return *n
+}
+
+func nullTime(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return *t
+}
+
+func parquetIssues(createdBy string, issues []*types.Issue) (*parquet.Writer, error) {
+	w := parquet.NewWriter(createdBy,
+		parquet.Column{Name: "id", Type: parquet.String},
+		parquet.Column{Name: "title", Type: parquet.String},
+		parquet.Column{Name: "description", Type: parquet.String, Optional: true},
+		parquet.Column{Name: "design", Type: parquet.String, Optional: true},
+		parquet.Column{Name: "acceptance_criteria", Type: parquet.String, Optional: true},
+		parquet.Column{Name: "notes", Type: parquet.String, Optional: true},
+		parquet.Column{Name: "status", Type: parquet.String},
+		parquet.Column{Name: "priority", Type: parquet.Int64},
+		parquet.Column{Name: "issue_type", Type: parquet.String},
+		parquet.Column{Name: "assignee", Type: parquet.String, Optional: true},
+		parquet.Column{Name: "estimated_minutes", Type: parquet.Int64, Optional: true},
+		parquet.Column{Name: "created_at", Type: parquet.Timestamp},
+		parquet.Column{Name: "updated_at", Type: parquet.Timestamp},
+		parquet.Column{Name: "closed_at", Type: parquet.Timestamp, Optional: true},
+		parquet.Column{Name: "close_reason", Type: parquet.String, Optional: true},
+		parquet.Column{Name: "external_ref", Type: parquet.String, Optional: true},
+		parquet.Column{Name: "deleted_at", Type: parquet.Timestamp, Optional: true},
+		parquet.Column{Name: "original_type", Type: parquet.String, Optional: true},
+	)
+	for _, issue := range issues {
+		err := w.Append(
+			issue.ID, issue.Title, nullString(issue.Description), nullString(issue.Design),
+			nullString(issue.AcceptanceCriteria), nullString(issue.Notes),
+			string(issue.Status), issue.Priority, string(issue.IssueType), nullString(issue.Assignee),
+			nullInt(issue.EstimatedMinutes), issue.CreatedAt, issue.UpdatedAt, nullTime(issue.ClosedAt),
+			nullString(issue.CloseReason), nullStringPtr(issue.ExternalRef), nullTime(issue.DeletedAt),
+			nullString(issue.OriginalType),
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return w, nil
+}
+
+func parquetLabels(ctx context.Context, s storage.Storage, createdBy string, ids []string) (*parquet.Writer, error) {
+	labels, err := s.GetLabelsForIssues(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	w := parquet.NewWriter(createdBy,
+		parquet.Column{Name: "issue_id", Type: parquet.String},
+		parquet.Column{Name: "label", Type: parquet.String},
+	)
+	for _, id := range ids {
+		issueLabels := append([]string(nil), labels[id]...)
+		sort.Strings(issueLabels)
+		for _, label := range issueLabels {
+			if err := w.Append(id, label); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return w, nil
+}
+
+func parquetDependencies(ctx context.Context, s storage.Storage, createdBy string) (*parquet.Writer, error) {
+	deps, err := s.GetAllDependencyRecords(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var all []*types.Dependency
+	for _, records := range deps {
+		all = append(all, records...)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].IssueID != all[j].IssueID {
+			return all[i].IssueID < all[j].IssueID
+		}
+		return all[i].DependsOnID < all[j].DependsOnID
+	})
+	w := parquet.NewWriter(createdBy,
+		parquet.Column{Name: "issue_id", Type: parquet.String},
+		parquet.Column{Name: "depends_on_id", Type: parquet.String},
+		parquet.Column{Name: "type", Type: parquet.String},
+		parquet.Column{Name: "created_at", Type: parquet.Timestamp},
+		parquet.Column{Name: "created_by", Type: parquet.String, Optional: true},
+	)
+	for _, dep := range all {
+		if err := w.Append(dep.IssueID, dep.DependsOnID, string(dep.Type), dep.CreatedAt, nullString(dep.CreatedBy)); err != nil {
+			return nil, err
+		}
+	}
+	return w, nil
+}
+
+func parquetEvents(ctx context.Context, s storage.Storage, createdBy string, ids []string) (*parquet.Writer, error) {
+	var events []*types.Event
+	for _, id := range ids {
+		issueEvents, err := s.GetEvents(ctx, id, 0)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, issueEvents...)
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].ID < events[j].ID })
+	w := parquet.NewWriter(createdBy,
+		parquet.Column{Name: "id", Type: parquet.Int64},
+		parquet.Column{Name: "issue_id", Type: parquet.String},
+		parquet.Column{Name: "event_type", Type: parquet.String},
+		parquet.Column{Name: "actor", Type: parquet.String},
+		parquet.Column{Name: "old_value", Type: parquet.String, Optional: true},
+		parquet.Column{Name: "new_value", Type: parquet.String, Optional: true},
+		parquet.Column{Name: "comment", Type: parquet.String, Optional: true},
+		parquet.Column{Name: "created_at", Type: parquet.Timestamp},
+	)
+	for _, e := range events {
+		err := w.Append(e.ID, e.IssueID, string(e.EventType), e.Actor,
+			nullStringPtr(e.OldValue), nullStringPtr(e.NewValue), nullStringPtr(e.Comment), e.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return w, nil
+}
+
+func parquetComments(ctx context.Context, s storage.Storage, createdBy string, ids []string) (*parquet.Writer, error) {
+	comments, err := s.GetCommentsForIssues(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	w := parquet.NewWriter(createdBy,
+		parquet.Column{Name: "id", Type: parquet.Int64},
+		parquet.Column{Name: "issue_id", Type: parquet.String},
+		parquet.Column{Name: "author", Type: parquet.String},
+		parquet.Column{Name: "text", Type: parquet.String},
+		parquet.Column{Name: "created_at", Type: parquet.Timestamp},
+	)
+	for _, id := range ids {
+		for _, c := range comments[id] {
+			if err := w.Append(c.ID, c.IssueID, c.Author, c.Text, c.CreatedAt); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return w, nil
+}
+
+func init() {
+	exportParquetCmd.Flags().String("out", "backlog.parquet", "Issues file; other tables are written alongside it")
+	exportCmd.AddCommand(exportParquetCmd)
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestExportParquet(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	s := newTestStore(t, filepath.Join(dir, "test.db"))
+
+	a := &types.Issue{ID: "test-1", Title: "First", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, Assignee: "alice"}
+	b := &types.Issue{ID: "test-2", Title: "Second", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeBug}
+	for _, issue := range []*types.Issue{a, b} {
+		if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.AddDependency(ctx, &types.Dependency{IssueID: b.ID, DependsOnID: a.ID, Type: types.DepBlocks}, "test"); err != nil {
+		t.Fatal(err)
+	}
+	for _, label := range []string{"backend", "api"} {
+		if err := s.AddLabel(ctx, a.ID, label, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := s.AddIssueComment(ctx, a.ID, "bob", "Looks good"); err != nil {
+		t.Fatal(err)
+	}
+
+	out := filepath.Join(dir, "analytics", "backlog.parquet")
+	results, err := exportParquet(ctx, s, out)
+	if err != nil {
+		t.Fatalf("exportParquet: %v", err)
+	}
+
+	want := map[string]int{"issues": 2, "labels": 2, "dependencies": 1, "comments": 1}
+	seen := make(map[string]bool)
+	for _, r := range results {
+		seen[r.Table] = true
+		if r.Path != parquetTablePath(out, r.Table) {
+			t.Errorf("%s written to %s", r.Table, r.Path)
+		}
+		if n, ok := want[r.Table]; ok && r.Rows != n {
+			t.Errorf("%s: %d rows, want %d", r.Table, r.Rows, n)
+		}
+		data, err := os.ReadFile(r.Path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.HasPrefix(data, []byte("PAR1")) || !bytes.HasSuffix(data, []byte("PAR1")) {
+			t.Errorf("%s is not a Parquet file", r.Path)
+		}
+	}
+	if !seen["events"] || len(results) != 5 {
+		t.Errorf("tables = %+v", results)
+	}
+	if events := results[3]; events.Table != "events" || events.Rows < 2 {
+		t.Errorf("events = %+v, want at least the two creations", events)
+	}
+
+	leftovers, _ := filepath.Glob(filepath.Join(dir, "analytics", ".bd-parquet-*"))
+	if len(leftovers) != 0 {
+		t.Errorf("temp files left behind: %v", leftovers)
+	}
+}
+
+func TestParquetTablePath(t *testing.T) {
+	if got := parquetTablePath("out/backlog.parquet", "issues"); got != "out/backlog.parquet" {
+		t.Errorf("issues path = %s", got)
+	}
+	if got := parquetTablePath("out/backlog.parquet", "events"); got != "out/backlog.events.parquet" {
+		t.Errorf("events path = %s", got)
+	}
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+var fieldCmd = &cobra.Command{
+	Use:   "field",
+	Short: "Define custom issue fields",
+	Long: `Custom fields add project-specific, typed data to issues, such as a
+severity or a story-point count.
+
+A field has a type: enum (one of a fixed list of values), int, string or
+date (YYYY-MM-DD). Once defined, set it with 'bd create --field' or
+'bd update --field', and filter with 'bd list --field' and
+'bd search --field'. Values are checked against the definition when they
+are written and exported to JSONL with the issue ("fields").
+
+Definitions are stored in the database config as field.<name>.`,
+}
+
+var fieldDefineCmd = &cobra.Command{
+	Use:   "define <name>",
+	Short: "Define or redefine a custom field",
+	Long: `Define a custom field, or change an existing definition.
+
+Redefining a field doesn't touch the values issues already have; they are
+checked against the new definition the next time they are written.
+
+Examples:
+  bd field define severity --type enum --values low,med,high
+  bd field define points --type int
+  bd field define due --type date
+  bd field define customer --type string`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("field define")
+		fieldType, _ := cmd.Flags().GetString("type")
+		values, _ := cmd.Flags().GetStringSlice("values")
+
+		if err := ensureDirectMode("field define requires direct database access"); err != nil {
+			FatalError("%v", err)
+		}
+		def, err := types.NewFieldDef(strings.ToLower(strings.TrimSpace(args[0])), types.FieldType(fieldType), values)
+		if err != nil {
+			FatalError("%v", err)
+		}
+		if err := storage.DefineField(rootCtx, store, def); err != nil {
+			FatalError("%v", err)
+		}
+
+		if jsonOutput {
+			outputJSON(def)
+			return
+		}
+		green := color.New(color.FgGreen).SprintFunc()
+		fmt.Printf("%s Defined field %s (%s)\n", green("✓"), def.Name, describeFieldDef(def))
+	},
+}
+
+var fieldListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List custom field definitions",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := ensureDirectMode("field list requires direct database access"); err != nil {
+			FatalError("%v", err)
+		}
+		defs, err := sortedFieldDefs()
+		if err != nil {
+			FatalError("%v", err)
+		}
+
+		if jsonOutput {
+			outputJSON(defs)
+			return
+		}
+		if len(defs) == 0 {
+			fmt.Println("No custom fields defined (bd field define <name> --type ...)")
+			return
+		}
+		for _, def := range defs {
+			fmt.Printf("  %-20s %s\n", def.Name, describeFieldDef(def))
+		}
+	},
+}
+
+var fieldRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Remove a custom field definition",
+	Long: `Remove a custom field definition.
+
+Values issues already have are kept and still exported; clear them with
+'bd update <id> --field <name>='.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("field remove")
+		if err := ensureDirectMode("field remove requires direct database access"); err != nil {
+			FatalError("%v", err)
+		}
+		name := strings.ToLower(strings.TrimSpace(args[0]))
+		defs, err := storage.GetFieldDefs(rootCtx, store)
+		if err != nil {
+			FatalError("%v", err)
+		}
+		if defs[name] == nil {
+			FatalError("no field %q is defined", name)
+		}
+		if err := store.DeleteConfig(rootCtx, storage.FieldConfigPrefix+name); err != nil {
+			FatalError("%v", err)
+		}
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"name": name, "removed": true})
+			return
+		}
+		green := color.New(color.FgGreen).SprintFunc()
+		fmt.Printf("%s Removed field %s\n", green("✓"), name)
+	},
+}
+
+// sortedFieldDefs returns the project's field definitions ordered by name
+func sortedFieldDefs() ([]*types.FieldDef, error) {
+	byName, err := storage.GetFieldDefs(rootCtx, store)
+	if err != nil {
+		return nil, err
+	}
+	defs := make([]*types.FieldDef, 0, len(byName))
+	for _, def := range byName {
+		defs = append(defs, def)
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Name < defs[j].Name })
+	return defs, nil
+}
+
+func describeFieldDef(def *types.FieldDef) string {
+	if def.Type == types.FieldEnum {
+		return fmt.Sprintf("enum: %s", strings.Join(def.Values, ", "))
+	}
+	return string(def.Type)
+}
+
+// parseFieldFlags parses repeated --field name=value flags. An empty value
+// (name=) clears the field where clearing applies.
+func parseFieldFlags(values []string) (types.CustomFields, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	fields := make(types.CustomFields, len(values))
+	for _, v := range values {
+		name, value, ok := strings.Cut(v, "=")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !ok || name == "" {
+			return nil, fmt.Errorf("%q is not name=value", v)
+		}
+		if _, dup := fields[name]; dup {
+			return nil, fmt.Errorf("field %s given twice", name)
+		}
+		fields[name] = strings.TrimSpace(value)
+	}
+	return fields, nil
+}
+
+// formatIssueFields renders custom field values for bd show, by name:
+// "points=3, severity=high"
+func formatIssueFields(fields types.CustomFields) string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = name + "=" + fields[name]
+	}
+	return strings.Join(parts, ", ")
+}
+
+func init() {
+	fieldDefineCmd.Flags().String("type", "", "Field type: enum, int, string or date (required)")
+	fieldDefineCmd.Flags().StringSlice("values", nil, "Allowed values of an enum field (comma-separated)")
+	_ = fieldDefineCmd.MarkFlagRequired("type")
+	fieldCmd.AddCommand(fieldDefineCmd, fieldListCmd, fieldRemoveCmd)
+	rootCmd.AddCommand(fieldCmd)
+}
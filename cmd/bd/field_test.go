@@ -0,0 +1,35 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestParseFieldFlags(t *testing.T) {
+	got, err := parseFieldFlags([]string{"Severity=high", " points = 3 ", "due="})
+	if err != nil {
+		t.Fatalf("parseFieldFlags failed: %v", err)
+	}
+	want := types.CustomFields{"severity": "high", "points": "3", "due": ""}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseFieldFlags() = %v, want %v", got, want)
+	}
+	if got, err := parseFieldFlags(nil); got != nil || err != nil {
+		t.Errorf("parseFieldFlags(nil) = %v, %v", got, err)
+	}
+
+	for _, bad := range [][]string{{"severity"}, {"=high"}, {"points=1", "POINTS=2"}} {
+		if _, err := parseFieldFlags(bad); err == nil {
+			t.Errorf("parseFieldFlags(%q) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestFormatIssueFields(t *testing.T) {
+	got := formatIssueFields(types.CustomFields{"severity": "high", "points": "3"})
+	if got != "points=3, severity=high" {
+		t.Errorf("formatIssueFields() = %q", got)
+	}
+}
@@ -0,0 +1,362 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/filterexpr"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+var filterCmd = &cobra.Command{
+	Use:   "filter",
+	Short: "Save and reuse named issue filters",
+	Long: `Saved filters give a name to a filter expression so it can be reused with
+'bd list --filter', 'bd watch --filter' and 'bd stats --filter'.
+
+An expression is a space-separated list of terms, all of which must match:
+  status=open          status, type, assignee: exact value
+  label=backend        a label the issue has (repeat for several)
+  priority<=1          priority with =, <, <=, > or >= (0-4 or P0-P4)
+  title="login page"   title contains the text (case-insensitive)
+  complexity=trivial,standard
+                       any of the listed complexities
+  field.severity=high  a custom field value
+
+Filters are stored in the database and are not synced through git; share
+them with 'bd filter export' and 'bd filter import'.`,
+}
+
+var filterSaveCmd = &cobra.Command{
+	Use:   "save <name> <expression>",
+	Short: "Save or replace a named filter",
+	Long: `Save a filter expression under a name, replacing any filter with that name.
+
+Examples:
+  bd filter save my-backlog 'status=open priority<=1 label=backend'
+  bd filter save triage 'status=open type=bug' --description "Bugs to triage"`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("filter save")
+		description, _ := cmd.Flags().GetString("description")
+		sqliteStore := savedFilterStore("filter save")
+
+		filter := &types.SavedFilter{
+			Name:        args[0],
+			Query:       args[1],
+			Description: description,
+			CreatedBy:   actor,
+		}
+		if err := sqliteStore.SaveFilter(rootCtx, filter); err != nil {
+			FatalError("%v", err)
+		}
+
+		if jsonOutput {
+			outputJSON(filter)
+			return
+		}
+		green := color.New(color.FgGreen).SprintFunc()
+		fmt.Printf("%s Saved filter %s: %s\n", green("✓"), filter.Name, filter.Query)
+	},
+}
+
+var filterListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List saved filters",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		filters, err := savedFilterStore("filter list").ListSavedFilters(rootCtx)
+		if err != nil {
+			FatalError("%v", err)
+		}
+
+		if jsonOutput {
+			if filters == nil {
+				filters = []*types.SavedFilter{}
+			}
+			outputJSON(filters)
+			return
+		}
+		if len(filters) == 0 {
+			fmt.Println("No saved filters (create one with: bd filter save <name> '<expression>')")
+			return
+		}
+		for _, f := range filters {
+			fmt.Printf("%-20s %s\n", f.Name, f.Query)
+			if f.Description != "" {
+				fmt.Printf("%-20s %s\n", "", f.Description)
+			}
+		}
+	},
+}
+
+var filterShowCmd = &cobra.Command{
+	Use:   "show <name>",
+	Short: "Show a saved filter",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		filter := loadSavedFilter("filter show", args[0])
+
+		if jsonOutput {
+			outputJSON(filter)
+			return
+		}
+		fmt.Printf("Name:        %s\n", filter.Name)
+		fmt.Printf("Query:       %s\n", filter.Query)
+		if filter.Description != "" {
+			fmt.Printf("Description: %s\n", filter.Description)
+		}
+		if filter.CreatedBy != "" {
+			fmt.Printf("Created by:  %s\n", filter.CreatedBy)
+		}
+		fmt.Printf("Updated:     %s\n", filter.UpdatedAt.Local().Format("2006-01-02 15:04"))
+	},
+}
+
+var filterDeleteCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "Delete a saved filter",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("filter delete")
+		deleted, err := savedFilterStore("filter delete").DeleteSavedFilter(rootCtx, args[0])
+		if err != nil {
+			FatalError("%v", err)
+		}
+		if !deleted {
+			FatalErrorWithHint(fmt.Sprintf("no saved filter named %q", args[0]), "bd filter list")
+		}
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"name": args[0], "deleted": true})
+			return
+		}
+		fmt.Printf("Deleted filter %s\n", args[0])
+	},
+}
+
+var filterExportCmd = &cobra.Command{
+	Use:   "export [file]",
+	Short: "Export saved filters as JSONL",
+	Long: `Write saved filters as JSON lines, one filter per line, to a file or stdout.
+
+Examples:
+  bd filter export > filters.jsonl
+  bd filter export .beads/filters.jsonl`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		filters, err := savedFilterStore("filter export").ListSavedFilters(rootCtx)
+		if err != nil {
+			FatalError("%v", err)
+		}
+
+		out := io.Writer(os.Stdout)
+		if len(args) == 1 && args[0] != "-" {
+			// #nosec G304 - user-specified output path
+			f, err := os.Create(args[0])
+			if err != nil {
+				FatalError("failed to create %s: %v", args[0], err)
+			}
+			defer func() { _ = f.Close() }()
+			out = f
+		}
+		encoder := json.NewEncoder(out)
+		encoder.SetEscapeHTML(false)
+		for _, filter := range filters {
+			if err := encoder.Encode(filter); err != nil {
+				FatalError("failed to write filter %s: %v", filter.Name, err)
+			}
+		}
+		if len(args) == 1 && args[0] != "-" {
+			fmt.Fprintf(os.Stderr, "Exported %d filters to %s\n", len(filters), args[0])
+		}
+	},
+}
+
+var filterImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import saved filters from JSONL",
+	Long: `Read saved filters written by 'bd filter export' ("-" reads stdin). Filters
+with the same name as an existing one replace it.
+
+Examples:
+  bd filter import filters.jsonl`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("filter import")
+		sqliteStore := savedFilterStore("filter import")
+
+		in := io.Reader(os.Stdin)
+		if args[0] != "-" {
+			// #nosec G304 - user-specified input path
+			f, err := os.Open(args[0])
+			if err != nil {
+				FatalError("failed to open %s: %v", args[0], err)
+			}
+			defer func() { _ = f.Close() }()
+			in = f
+		}
+		filters, err := readSavedFilters(in)
+		if err != nil {
+			FatalError("%v", err)
+		}
+		for _, filter := range filters {
+			if err := sqliteStore.SaveFilter(rootCtx, filter); err != nil {
+				FatalError("filter %s: %v", filter.Name, err)
+			}
+		}
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"imported": len(filters)})
+			return
+		}
+		fmt.Printf("Imported %d filters\n", len(filters))
+	},
+}
+
+// readSavedFilters decodes JSONL written by bd filter export, skipping blank
+// lines
+func readSavedFilters(r io.Reader) ([]*types.SavedFilter, error) {
+	var filters []*types.SavedFilter
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var filter types.SavedFilter
+		if err := json.Unmarshal([]byte(line), &filter); err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+		filters = append(filters, &filter)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read filters: %w", err)
+	}
+	return filters, nil
+}
+
+// savedFilterStore switches to direct mode, where saved filters are read,
+// and returns the SQLite store
+func savedFilterStore(command string) *sqlite.SQLiteStorage {
+	if err := ensureDirectMode(command + " requires direct database access"); err != nil {
+		FatalError("%v", err)
+	}
+	sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+	if !ok {
+		FatalError("%s requires the SQLite backend", command)
+	}
+	return sqliteStore
+}
+
+// loadSavedFilter returns the named filter or exits if there is none
+func loadSavedFilter(command, name string) *types.SavedFilter {
+	filter, err := savedFilterStore(command).GetSavedFilter(rootCtx, name)
+	if err != nil {
+		FatalError("%v", err)
+	}
+	if filter == nil {
+		FatalErrorWithHint(fmt.Sprintf("no saved filter named %q", name), "bd filter list")
+	}
+	return filter
+}
+
+// applySavedFilter sets bd list flags from a saved filter's terms. Flags
+// given on the command line win over the filter, except --label and
+// --field, which add to it.
+func applySavedFilter(cmd *cobra.Command, query string) error {
+	expr, err := filterexpr.Parse(query)
+	if err != nil {
+		return err
+	}
+	flags := cmd.Flags()
+	set := func(name, value string) error {
+		if flags.Changed(name) {
+			return nil
+		}
+		return flags.Set(name, value)
+	}
+	for _, t := range expr.Terms {
+		switch {
+		case t.Key == "status", t.Key == "type", t.Key == "assignee", t.Key == "complexity":
+			err = set(t.Key, t.Value)
+		case t.Key == "title":
+			err = set("title-contains", t.Value)
+		case t.Key == "label":
+			err = flags.Set("label", t.Value)
+		case strings.HasPrefix(t.Key, "field."):
+			err = flags.Set("field", strings.TrimPrefix(t.Key, "field.")+"="+t.Value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	// Priority terms combine into one range
+	f := expr.IssueFilter()
+	if f.PriorityMin != nil {
+		if err := set("priority-min", fmt.Sprint(*f.PriorityMin)); err != nil {
+			return err
+		}
+	}
+	if f.PriorityMax != nil {
+		if err := set("priority-max", fmt.Sprint(*f.PriorityMax)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runFilteredStats prints bd stats for the issues matching a saved filter
+func runFilteredStats(name string) {
+	filter := loadSavedFilter("stats --filter", name)
+	expr, err := filterexpr.Parse(filter.Query)
+	if err != nil {
+		FatalError("saved filter %s: %v", name, err)
+	}
+	ctx := rootCtx
+	if err := ensureDatabaseFresh(ctx); err != nil {
+		FatalError("%v", err)
+	}
+	stats, err := savedFilterStore("stats --filter").GetFilteredStatistics(ctx, expr.IssueFilter())
+	if err != nil {
+		FatalError("%v", err)
+	}
+
+	if jsonOutput {
+		outputJSON(stats)
+		return
+	}
+	cyan := color.New(color.FgCyan).SprintFunc()
+	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+	fmt.Printf("\n%s Beads Statistics for %s (%s):\n\n", cyan("📊"), filter.Name, filter.Query)
+	fmt.Printf("Total Issues:           %d\n", stats.TotalIssues)
+	fmt.Printf("Open:                   %s\n", green(fmt.Sprintf("%d", stats.OpenIssues)))
+	fmt.Printf("In Progress:            %s\n", yellow(fmt.Sprintf("%d", stats.InProgressIssues)))
+	if stats.ResolvedIssues > 0 {
+		fmt.Printf("Resolved:               %d (awaiting verification)\n", stats.ResolvedIssues)
+	}
+	fmt.Printf("Closed:                 %d\n", stats.ClosedIssues)
+	fmt.Printf("Blocked:                %d\n", stats.BlockedIssues)
+	fmt.Printf("Ready:                  %s\n", green(fmt.Sprintf("%d", stats.ReadyIssues)))
+	if stats.AverageLeadTime > 0 {
+		fmt.Printf("Avg Lead Time:          %.1f hours\n", stats.AverageLeadTime)
+	}
+	printClosedByReason(stats.ClosedByReason)
+	fmt.Println()
+}
+
+func init() {
+	filterSaveCmd.Flags().String("description", "", "What the filter is for")
+	filterCmd.AddCommand(filterSaveCmd, filterListCmd, filterShowCmd, filterDeleteCmd, filterExportCmd, filterImportCmd)
+	rootCmd.AddCommand(filterCmd)
+}
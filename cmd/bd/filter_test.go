@@ -0,0 +1,66 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestApplySavedFilter(t *testing.T) {
+	newListFlags := func() *cobra.Command {
+		cmd := &cobra.Command{}
+		for _, name := range []string{"status", "type", "assignee", "title-contains", "priority-min", "priority-max"} {
+			cmd.Flags().String(name, "", "")
+		}
+		cmd.Flags().StringSlice("label", nil, "")
+		cmd.Flags().StringSlice("complexity", nil, "")
+		cmd.Flags().StringArray("field", nil, "")
+		return cmd
+	}
+
+	cmd := newListFlags()
+	if err := cmd.Flags().Parse([]string{"--status", "in_progress", "--label", "api"}); err != nil {
+		t.Fatal(err)
+	}
+	query := `status=open priority>=1 priority<=2 label=backend title="login page" complexity=trivial,standard field.team=core`
+	if err := applySavedFilter(cmd, query); err != nil {
+		t.Fatalf("applySavedFilter: %v", err)
+	}
+
+	str := func(name string) string { v, _ := cmd.Flags().GetString(name); return v }
+	if str("status") != "in_progress" {
+		t.Errorf("status = %q, want the command line's in_progress", str("status"))
+	}
+	if str("priority-min") != "1" || str("priority-max") != "2" || str("title-contains") != "login page" {
+		t.Errorf("priority %s-%s, title %q", str("priority-min"), str("priority-max"), str("title-contains"))
+	}
+	labels, _ := cmd.Flags().GetStringSlice("label")
+	complexity, _ := cmd.Flags().GetStringSlice("complexity")
+	fields, _ := cmd.Flags().GetStringArray("field")
+	if strings.Join(labels, ",") != "api,backend" || len(complexity) != 2 || strings.Join(fields, ",") != "team=core" {
+		t.Errorf("labels %v, complexity %v, fields %v", labels, complexity, fields)
+	}
+
+	if err := applySavedFilter(newListFlags(), "owner=alice"); err == nil {
+		t.Error("an invalid query should fail")
+	}
+}
+
+func TestReadSavedFilters(t *testing.T) {
+	input := `{"name":"my-backlog","query":"status=open priority<=1","created_by":"alice","created_at":"2026-01-02T00:00:00Z","updated_at":"2026-01-02T00:00:00Z"}
+
+{"name":"bugs","query":"type=bug","created_at":"2026-01-02T00:00:00Z","updated_at":"2026-01-02T00:00:00Z"}
+`
+	filters, err := readSavedFilters(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("readSavedFilters: %v", err)
+	}
+	if len(filters) != 2 || filters[0].Name != "my-backlog" || filters[0].CreatedBy != "alice" || filters[1].Query != "type=bug" {
+		t.Errorf("filters = %+v", filters)
+	}
+
+	if _, err := readSavedFilters(strings.NewReader("{\"name\":\"a\"}\nnot json\n")); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("error = %v, want one naming line 2", err)
+	}
+}
@@ -20,6 +20,7 @@ func registerCommonIssueFlags(cmd *cobra.Command) {
 	cmd.Flags().String("design", "", "Design notes")
 	cmd.Flags().String("acceptance", "", "Acceptance criteria")
 	cmd.Flags().String("external-ref", "", "External reference (e.g., 'gh-9', 'jira-ABC')")
+	cmd.Flags().Bool("attach-oversized", false, "Store oversized or binary text fields as attachments, leaving a short stub")
 }
 
 // getDescriptionFlag retrieves the description value, checking --body-file, --description-file,
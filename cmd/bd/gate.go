@@ -0,0 +1,353 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/github"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var gateCmd = &cobra.Command{
+	Use:   "gate",
+	Short: "Hold issues back until a CI check passes",
+	Long: `Gates keep an issue out of ready work until a named CI check on a branch
+or pull request is green, so deploy and follow-up work only becomes ready
+once the build it depends on passes.
+
+A gate names a GitHub check run or commit status (e.g. "build" or
+"ci/jenkins") and a branch (--branch) or pull request (--pr), on github.repo
+or the repository given with --repo. A new gate is pending; the issue counts
+as blocked until the check reports success, and blocks again if a later run
+fails.
+
+Gate states are updated by:
+  - the daemon, which polls GitHub every gates.poll_interval (default 2m)
+    using the github.* credentials
+  - GitHub webhooks (check_run and status events) sent to the daemon's
+    gates.webhook_listen address, signed with gates.webhook_secret
+  - bd gate check, which polls once
+  - bd gate report, for CI systems that report results themselves
+
+Gates live in the local database and are not exported to JSONL.
+
+Examples:
+  bd gate add bd-42 --check build --branch main
+  bd gate add bd-43 --check ci/jenkins --pr 128 --repo acme/infra
+  bd gate list
+  bd gate check
+  bd gate report --check build --branch main --state success`,
+}
+
+var gateAddCmd = &cobra.Command{
+	Use:   "add <issue-id>",
+	Short: "Gate an issue on a CI check",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("gate add")
+		check, _ := cmd.Flags().GetString("check")
+		repo, _ := cmd.Flags().GetString("repo")
+		ref := gateRefFromFlags(cmd, true)
+		if check == "" {
+			FatalError("--check is required")
+		}
+		s := gateStore()
+		ctx := rootCtx
+		issueID, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			FatalError("%v", err)
+		}
+		// Record the repository explicitly, so results from other
+		// repositories never satisfy the gate
+		if repo == "" {
+			repo, _ = githubRepoFromConfig(ctx, store)
+		} else {
+			owner, name, err := github.ParseRepo(repo)
+			if err != nil {
+				FatalError("%v", err)
+			}
+			repo = owner + "/" + name
+		}
+
+		gate := &sqlite.Gate{IssueID: issueID, Check: check, Ref: ref, Repo: repo, State: sqlite.GatePending}
+		if err := s.AddGate(ctx, gate, actor); err != nil {
+			FatalError("%v", err)
+		}
+		if jsonOutput {
+			outputJSON(gate)
+			return
+		}
+		green := color.New(color.FgGreen).SprintFunc()
+		fmt.Printf("%s %s now waits for %s\n", green("✓"), issueID, gate)
+		if repo == "" {
+			fmt.Println("  github.repo is not set, so only bd gate report and webhooks update this gate")
+		}
+	},
+}
+
+var gateRemoveCmd = &cobra.Command{
+	Use:   "remove <issue-id>",
+	Short: "Remove an issue's gate",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("gate remove")
+		check, _ := cmd.Flags().GetString("check")
+		ref := gateRefFromFlags(cmd, false)
+		if check == "" {
+			FatalError("--check is required")
+		}
+		s := gateStore()
+		ctx := rootCtx
+		issueID, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			FatalError("%v", err)
+		}
+		removed, err := s.RemoveGate(ctx, issueID, check, ref)
+		if err != nil {
+			FatalError("%v", err)
+		}
+		if removed == 0 {
+			FatalError("%s has no %s gate", issueID, check)
+		}
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"issue_id": issueID, "check": check, "removed": removed})
+			return
+		}
+		green := color.New(color.FgGreen).SprintFunc()
+		fmt.Printf("%s Removed %d %s gate(s) from %s\n", green("✓"), removed, check, issueID)
+	},
+}
+
+var gateListCmd = &cobra.Command{
+	Use:   "list [issue-id]",
+	Short: "List gates and their states",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		unmet, _ := cmd.Flags().GetBool("unmet")
+		s := gateStore()
+		ctx := rootCtx
+		var gates []*sqlite.Gate
+		var err error
+		if len(args) == 1 {
+			issueID, resolveErr := utils.ResolvePartialID(ctx, store, args[0])
+			if resolveErr != nil {
+				FatalError("%v", resolveErr)
+			}
+			gates, err = s.GetGates(ctx, issueID)
+		} else {
+			gates, err = s.ListGates(ctx, unmet)
+		}
+		if err != nil {
+			FatalError("%v", err)
+		}
+		if jsonOutput {
+			if gates == nil {
+				gates = []*sqlite.Gate{}
+			}
+			outputJSON(gates)
+			return
+		}
+		if len(gates) == 0 {
+			fmt.Println("No gates")
+			return
+		}
+		for _, gate := range gates {
+			printGate(gate)
+		}
+	},
+}
+
+var gateCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Poll GitHub once for every unmet gate",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("gate check")
+		s := gateStore()
+		changed, err := pollGates(rootCtx, s, func(format string, args ...interface{}) {
+			if !jsonOutput {
+				fmt.Printf("  "+format+"\n", args...)
+			}
+		})
+		if err != nil {
+			FatalError("%v", err)
+		}
+		if jsonOutput {
+			if changed == nil {
+				changed = []*sqlite.Gate{}
+			}
+			outputJSON(changed)
+			return
+		}
+		if len(changed) == 0 {
+			fmt.Println("No gate changed")
+		}
+	},
+}
+
+var gateReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Record a CI result for the gates on a check",
+	Long: `Record a CI result for every gate on --check and the branch or pull
+request, for CI systems that report to beads themselves instead of to
+GitHub. Without --repo, gates on any repository match.
+
+Examples:
+  bd gate report --check build --branch main --state success
+  bd gate report --check e2e --pr 128 --state failure --url "$BUILD_URL"`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("gate report")
+		check, _ := cmd.Flags().GetString("check")
+		state, _ := cmd.Flags().GetString("state")
+		repo, _ := cmd.Flags().GetString("repo")
+		url, _ := cmd.Flags().GetString("url")
+		detail, _ := cmd.Flags().GetString("detail")
+		ref := gateRefFromFlags(cmd, true)
+		if check == "" || state == "" {
+			FatalError("--check and --state are required")
+		}
+		s := gateStore()
+		changed, err := s.SetGateState(rootCtx, repo, check, ref, state, detail, url)
+		if err != nil {
+			FatalError("%v", err)
+		}
+		if jsonOutput {
+			if changed == nil {
+				changed = []*sqlite.Gate{}
+			}
+			outputJSON(changed)
+			return
+		}
+		if len(changed) == 0 {
+			fmt.Printf("No gate on %s changed\n", (&sqlite.Gate{Check: check, Ref: ref, Repo: repo}).String())
+			return
+		}
+		for _, gate := range changed {
+			printGate(gate)
+		}
+	},
+}
+
+// gateStore opens the database for a gate command
+func gateStore() *sqlite.SQLiteStorage {
+	if err := ensureDirectMode("gate requires direct database access"); err != nil {
+		FatalError("%v", err)
+	}
+	s, ok := store.(*sqlite.SQLiteStorage)
+	if !ok {
+		FatalError("gate requires SQLite storage")
+	}
+	if err := ensureDatabaseFresh(rootCtx); err != nil {
+		FatalError("%v", err)
+	}
+	return s
+}
+
+// gateRefFromFlags reads --branch or --pr into a gate ref
+func gateRefFromFlags(cmd *cobra.Command, required bool) string {
+	branch, _ := cmd.Flags().GetString("branch")
+	pr, _ := cmd.Flags().GetInt("pr")
+	switch {
+	case branch != "" && pr != 0:
+		FatalError("use either --branch or --pr, not both")
+	case pr < 0:
+		FatalError("--pr must be a pull request number")
+	case pr > 0:
+		return sqlite.PullRequestRef(pr)
+	case branch == "" && required:
+		FatalError("--branch or --pr is required")
+	}
+	return strings.TrimPrefix(branch, "refs/heads/")
+}
+
+func printGate(gate *sqlite.Gate) {
+	stateColor := color.New(color.FgYellow).SprintFunc()
+	switch gate.State {
+	case sqlite.GateSuccess:
+		stateColor = color.New(color.FgGreen).SprintFunc()
+	case sqlite.GateFailure:
+		stateColor = color.New(color.FgRed).SprintFunc()
+	}
+	fmt.Printf("%s  %-8s %s", gate.IssueID, stateColor(gate.State), gate)
+	if gate.Detail != "" && gate.Detail != gate.State {
+		fmt.Printf(" (%s)", gate.Detail)
+	}
+	fmt.Println()
+	if gate.URL != "" {
+		fmt.Printf("    %s\n", gate.URL)
+	}
+}
+
+// pollGates asks GitHub for the latest result of every unmet gate's check
+// and returns the gates whose state changed. Gates sharing a repository,
+// check and ref cost one lookup.
+func pollGates(ctx context.Context, s *sqlite.SQLiteStorage, logf func(string, ...interface{})) ([]*sqlite.Gate, error) {
+	gates, err := s.ListGates(ctx, true)
+	if err != nil || len(gates) == 0 {
+		return nil, err
+	}
+	client, err := githubTokenClient(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	// Gates added before github.repo was set fall back to it
+	defaultRepo, _ := githubRepoFromConfig(ctx, s)
+
+	type key struct{ repo, check, ref string }
+	seen := make(map[key]bool)
+	var changed []*sqlite.Gate
+	for _, gate := range gates {
+		repo := gate.Repo
+		if repo == "" {
+			repo = defaultRepo
+		}
+		k := key{repo, gate.Check, gate.Ref}
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		if repo == "" {
+			logf("%s: no repository (set github.repo or re-add the gate with --repo)", gate)
+			continue
+		}
+
+		repoClient := *client
+		repoClient.Owner, repoClient.Repo, _ = github.ParseRepo(repo)
+		result, err := repoClient.CheckStatus(ctx, gate.Ref, gate.Check)
+		if err != nil {
+			logf("%s: %v", gate, err)
+			continue
+		}
+		updated, err := s.SetGateState(ctx, repo, gate.Check, gate.Ref, result.State, result.Detail, result.URL)
+		if err != nil {
+			return changed, err
+		}
+		for _, u := range updated {
+			logf("%s: %s %s", u.IssueID, u, u.State)
+		}
+		changed = append(changed, updated...)
+	}
+	return changed, nil
+}
+
+func init() {
+	for _, c := range []*cobra.Command{gateAddCmd, gateRemoveCmd, gateReportCmd} {
+		c.Flags().String("check", "", "Check run or commit status name (e.g. build, ci/jenkins)")
+		c.Flags().String("branch", "", "Branch the check runs on")
+		c.Flags().Int("pr", 0, "Pull request the check runs on")
+	}
+	gateAddCmd.Flags().String("repo", "", "GitHub repository (default: github.repo)")
+	gateReportCmd.Flags().String("repo", "", "Only update gates on this repository (owner/repo)")
+	gateReportCmd.Flags().String("state", "", "Result: success, failure or pending")
+	gateReportCmd.Flags().String("url", "", "Link to the CI run")
+	gateReportCmd.Flags().String("detail", "", "Short description of the result")
+	gateListCmd.Flags().Bool("unmet", false, "Only gates that haven't passed, on open issues")
+
+	gateCmd.AddCommand(gateAddCmd, gateRemoveCmd, gateListCmd, gateCheckCmd, gateReportCmd)
+	rootCmd.AddCommand(gateCmd)
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestPollGates(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t, filepath.Join(t.TempDir(), ".beads", "beads.db"))
+
+	calls := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/o/r/branches/main", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(`{"commit":{"sha":"abc"}}`))
+	})
+	mux.HandleFunc("GET /repos/o/r/commits/abc/check-runs", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"check_runs":[{"name":"build","status":"completed","conclusion":"success","html_url":"https://ci/1"}]}`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	for key, value := range map[string]string{githubRepoKey: "o/r", githubTokenKey: "tok", githubAPIURLKey: srv.URL} {
+		if err := s.SetConfig(ctx, key, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, id := range []string{"test-1", "test-2"} {
+		issue := &types.Issue{ID: id, Title: "Deploy", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+		if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		// test-1 predates github.repo; test-2 names it
+		repo := ""
+		if id == "test-2" {
+			repo = "o/r"
+		}
+		if err := s.AddGate(ctx, &sqlite.Gate{IssueID: id, Check: "build", Ref: "main", Repo: repo}, "test"); err != nil {
+			t.Fatalf("AddGate failed: %v", err)
+		}
+	}
+
+	changed, err := pollGates(ctx, s, t.Logf)
+	if err != nil {
+		t.Fatalf("pollGates failed: %v", err)
+	}
+	if len(changed) != 2 {
+		t.Errorf("changed %d gates, want 2", len(changed))
+	}
+	if calls != 1 {
+		t.Errorf("looked up the branch %d times, want once for both gates", calls)
+	}
+	if unmet, _ := s.ListGates(ctx, true); len(unmet) != 0 {
+		t.Errorf("unmet gates after polling: %v", unmet)
+	}
+}
+
+func TestGateWebhookHandler(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t, filepath.Join(t.TempDir(), ".beads", "beads.db"))
+	issue := &types.Issue{ID: "test-1", Title: "Deploy", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := s.AddGate(ctx, &sqlite.Gate{IssueID: "test-1", Check: "build", Ref: sqlite.PullRequestRef(7), Repo: "o/r"}, "test"); err != nil {
+		t.Fatalf("AddGate failed: %v", err)
+	}
+	handler := gateWebhookHandler(s, "secret", daemonLogger{logFunc: t.Logf})
+
+	deliver := func(event, body, secret string) int {
+		t.Helper()
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(body))
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(body))
+		req.Header.Set("X-GitHub-Event", event)
+		req.Header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	passed := `{"check_run":{"name":"build","status":"completed","conclusion":"success","pull_requests":[{"number":7}]},"repository":{"full_name":"o/r"}}`
+	if code := deliver("check_run", passed, "wrong"); code != http.StatusUnauthorized {
+		t.Errorf("bad signature: status %d, want 401", code)
+	}
+	if code := deliver("ping", `{}`, "secret"); code != http.StatusNoContent {
+		t.Errorf("ping: status %d, want 204", code)
+	}
+	if gates, _ := s.ListGates(ctx, true); len(gates) != 1 {
+		t.Fatalf("gate should still be unmet, got %v", gates)
+	}
+	if code := deliver("check_run", passed, "secret"); code != http.StatusNoContent {
+		t.Errorf("check_run: status %d, want 204", code)
+	}
+	if gates, _ := s.ListGates(ctx, true); len(gates) != 0 {
+		t.Errorf("gate should have passed, got %v", gates)
+	}
+}
@@ -0,0 +1,859 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/github"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// GitHubSyncStats counts what a GitHub sync did, or would do with --dry-run
+type GitHubSyncStats struct {
+	Pushed        int `json:"pushed"`         // GitHub issues updated from beads
+	Pulled        int `json:"pulled"`         // beads issues updated from GitHub
+	CreatedRemote int `json:"created_remote"` // GitHub issues created for beads issues
+	CreatedLocal  int `json:"created_local"`  // beads issues created for GitHub issues
+	Linked        int `json:"linked"`         // existing pairs relinked from the body footer
+	Conflicts     int `json:"conflicts"`      // changed on both sides and left alone
+	Errors        int `json:"errors"`
+}
+
+// GitHubSyncAction is one change a sync makes to either side
+type GitHubSyncAction struct {
+	Action  string `json:"action"` // push, pull, create_remote, create_local, link, conflict
+	IssueID string `json:"issue_id,omitempty"`
+	Number  int    `json:"number,omitempty"`
+	Title   string `json:"title"`
+	Detail  string `json:"detail,omitempty"`
+}
+
+// GitHubSyncResult is the output of bd github sync
+type GitHubSyncResult struct {
+	Success  bool               `json:"success"`
+	DryRun   bool               `json:"dry_run,omitempty"`
+	Repo     string             `json:"repo"`
+	Stats    GitHubSyncStats    `json:"stats"`
+	Actions  []GitHubSyncAction `json:"actions,omitempty"`
+	LastSync string             `json:"last_sync,omitempty"`
+	Warnings []string           `json:"warnings,omitempty"`
+	RunID    string             `json:"run_id,omitempty"` // the sync log entry (bd sync log)
+}
+
+// Config keys for the GitHub integration (bd config set)
+const (
+	githubRepoKey     = "github.repo"
+	githubOrgKey      = "github.org"
+	githubTokenKey    = "github.token"
+	githubAPIURLKey   = "github.api_url"
+	githubLastSyncKey = "github.last_sync"
+)
+
+var githubCmd = &cobra.Command{
+	Use:   "github",
+	Short: "GitHub Issues integration commands",
+	Long: `Synchronize issues between beads and GitHub Issues.
+
+Configuration:
+  bd config set github.repo "owner/repo"
+  bd config set github.token "YOUR_TOKEN"          # Or GITHUB_TOKEN / GH_TOKEN
+  bd config set github.api_url "https://ghe.example.com/api/v3"  # GitHub Enterprise
+
+Examples:
+  bd github sync              # Bidirectional sync (pull then push)
+  bd github sync --pull       # Import GitHub issues only
+  bd github sync --push       # Export beads issues only
+  bd github sync --dry-run    # Preview without changes
+  bd github status            # Show link status`,
+}
+
+var githubSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Synchronize issues with GitHub",
+	Long: `Synchronize issues between beads and a GitHub repository.
+
+Title, description, open/closed state, labels and assignee are kept in step.
+Each linked pair is recorded in the external_refs table along with a hash
+of those fields as they were when both sides last agreed, so a sync can
+tell which side changed:
+
+  changed in beads only    pushed to GitHub (with --push or bidirectional)
+  changed on GitHub only   pulled into beads (with --pull or bidirectional)
+  changed on both sides    a conflict: reported and skipped unless
+                           --prefer-local or --prefer-github is given
+
+Open beads issues without a GitHub issue are created on GitHub; GitHub
+issues (not pull requests) without a beads issue are created in beads.
+Pushed bodies end with a footer naming the beads ID and cross-references
+to other synced issues ("Blocked by #12", "Part of #3"), which relinks the
+pair if the local database is rebuilt. #N mentions in pulled descriptions
+become related links between the synced issues.
+
+Modes:
+  --pull         Only import changes from GitHub
+  --push         Only export changes to GitHub
+  (no flags)     Bidirectional: pull then push
+
+Examples:
+  bd github sync
+  bd github sync --dry-run --json
+  bd github sync --prefer-github     # Resolve conflicts with the GitHub version
+  bd github sync --full              # Re-read every GitHub issue, not only recent ones`,
+	Run: func(cmd *cobra.Command, args []string) {
+		pull, _ := cmd.Flags().GetBool("pull")
+		push, _ := cmd.Flags().GetBool("push")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		preferLocal, _ := cmd.Flags().GetBool("prefer-local")
+		preferGitHub, _ := cmd.Flags().GetBool("prefer-github")
+		full, _ := cmd.Flags().GetBool("full")
+
+		if !dryRun {
+			CheckReadonly("github sync")
+		}
+		if preferLocal && preferGitHub {
+			FatalError("cannot use both --prefer-local and --prefer-github")
+		}
+		if err := ensureDirectMode("github sync requires direct database access"); err != nil {
+			FatalError("%v", err)
+		}
+		sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+		if !ok {
+			FatalError("github sync requires SQLite storage")
+		}
+		ctx := rootCtx
+
+		client, err := githubClientFromConfig(ctx, store)
+		if err != nil {
+			FatalError("%v", err)
+		}
+		if !pull && !push {
+			pull, push = true, true
+		}
+		opts := githubSyncOptions{Pull: pull, Push: push, DryRun: dryRun}
+		switch {
+		case preferLocal:
+			opts.Prefer = "local"
+		case preferGitHub:
+			opts.Prefer = "github"
+		}
+		if !full {
+			if last, _ := store.GetConfig(ctx, githubLastSyncKey); last != "" {
+				if opts.Since, err = time.Parse(time.RFC3339, last); err != nil {
+					FatalError("invalid %s %q: %v", githubLastSyncKey, last, err)
+				}
+			}
+		}
+
+		syncer := &githubSyncer{store: sqliteStore, client: client, opts: opts, actor: actor}
+		run := startSyncAudit(ctx, sqlite.SyncProviderGitHub, client.Owner+"/"+client.Repo, dryRun, nil)
+		result, err := syncer.run(ctx)
+		finishSyncAudit(ctx, run, githubSyncError(result, err), githubSyncChanges(result))
+		if err != nil {
+			if jsonOutput {
+				outputJSON(&GitHubSyncResult{Repo: client.Owner + "/" + client.Repo, Warnings: []string{err.Error()}})
+				os.Exit(1)
+			}
+			FatalError("github sync failed: %v", err)
+		}
+		if !dryRun && syncer.changedLocal {
+			markDirtyAndScheduleFlush()
+		}
+		if run != nil {
+			result.RunID = run.ID
+		}
+
+		if jsonOutput {
+			outputJSON(result)
+		} else {
+			printGitHubSyncResult(result)
+		}
+		if !result.Success {
+			os.Exit(1)
+		}
+	},
+}
+
+var githubStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show GitHub sync status",
+	Long: `Show the configured repository, the last sync time, how many issues
+are linked to GitHub issues, and how many open issues are not linked yet.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := ensureDirectMode("github status requires direct database access"); err != nil {
+			FatalError("%v", err)
+		}
+		sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+		if !ok {
+			FatalError("github status requires SQLite storage")
+		}
+		ctx := rootCtx
+
+		repo, _ := githubRepoFromConfig(ctx, store)
+		lastSync, _ := store.GetConfig(ctx, githubLastSyncKey)
+		linked := 0
+		localOnly := 0
+		if repo != "" {
+			refs, err := sqliteStore.GetExternalRefs(ctx, githubSystem(repo))
+			if err != nil {
+				FatalError("%v", err)
+			}
+			isLinked := make(map[string]bool, len(refs))
+			for _, ref := range refs {
+				isLinked[ref.IssueID] = true
+			}
+			linked = len(refs)
+			issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+			if err != nil {
+				FatalError("%v", err)
+			}
+			for _, issue := range issues {
+				if !isLinked[issue.ID] && githubPushable(issue) {
+					localOnly++
+				}
+			}
+		}
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{
+				"configured": repo != "",
+				"repo":       repo,
+				"last_sync":  lastSync,
+				"linked":     linked,
+				"local_only": localOnly,
+			})
+			return
+		}
+		if repo == "" {
+			fmt.Println("GitHub sync: not configured")
+			fmt.Println()
+			fmt.Println("To configure:")
+			fmt.Println("  bd config set github.repo \"owner/repo\"")
+			fmt.Println("  bd config set github.token \"YOUR_TOKEN\"   # or export GITHUB_TOKEN")
+			return
+		}
+		fmt.Printf("Repository:  %s\n", repo)
+		if lastSync != "" {
+			if t, err := time.Parse(time.RFC3339, lastSync); err == nil {
+				lastSync = displayTime(t)
+			}
+			fmt.Printf("Last sync:   %s\n", lastSync)
+		} else {
+			fmt.Println("Last sync:   never")
+		}
+		fmt.Printf("Linked:      %d\n", linked)
+		fmt.Printf("Local only:  %d\n", localOnly)
+		if localOnly > 0 {
+			fmt.Printf("\nRun 'bd github sync --push' to create %d GitHub issue(s)\n", localOnly)
+		}
+	},
+}
+
+func init() {
+	githubSyncCmd.Flags().Bool("pull", false, "Only pull changes from GitHub")
+	githubSyncCmd.Flags().Bool("push", false, "Only push changes to GitHub")
+	githubSyncCmd.Flags().Bool("dry-run", false, "Preview sync without making changes")
+	githubSyncCmd.Flags().Bool("prefer-local", false, "Resolve conflicts with the beads version")
+	githubSyncCmd.Flags().Bool("prefer-github", false, "Resolve conflicts with the GitHub version")
+	githubSyncCmd.Flags().Bool("full", false, "Read every GitHub issue instead of those updated since the last sync")
+
+	githubCmd.AddCommand(githubSyncCmd)
+	githubCmd.AddCommand(githubStatusCmd)
+	rootCmd.AddCommand(githubCmd)
+}
+
+// githubRepoFromConfig returns "owner/repo" from github.repo, which may
+// also be a bare repository name qualified by github.org
+func githubRepoFromConfig(ctx context.Context, s storage.Storage) (string, error) {
+	repo, _ := s.GetConfig(ctx, githubRepoKey)
+	if repo == "" {
+		return "", fmt.Errorf("%s not configured\nRun: bd config set %s \"owner/repo\"", githubRepoKey, githubRepoKey)
+	}
+	if org, _ := s.GetConfig(ctx, githubOrgKey); org != "" {
+		if _, _, err := github.ParseRepo(repo); err != nil {
+			repo = org + "/" + repo
+		}
+	}
+	owner, name, err := github.ParseRepo(repo)
+	if err != nil {
+		return "", err
+	}
+	return owner + "/" + name, nil
+}
+
+// githubClientFromConfig builds an API client from github.* config, with
+// the token falling back to GITHUB_TOKEN and GH_TOKEN
+func githubClientFromConfig(ctx context.Context, s storage.Storage) (*github.Client, error) {
+	repo, err := githubRepoFromConfig(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	client, err := githubTokenClient(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	client.Owner, client.Repo, _ = github.ParseRepo(repo)
+	return client, nil
+}
+
+// githubTokenClient builds an API client with no repository set, for
+// callers that name the repository themselves
+func githubTokenClient(ctx context.Context, s storage.Storage) (*github.Client, error) {
+	token, _ := s.GetConfig(ctx, githubTokenKey)
+	for _, env := range []string{"GITHUB_TOKEN", "GH_TOKEN"} {
+		if token == "" {
+			token = os.Getenv(env)
+		}
+	}
+	if token == "" {
+		return nil, fmt.Errorf("GitHub token not configured\nRun: bd config set %s \"YOUR_TOKEN\"\nOr: export GITHUB_TOKEN=YOUR_TOKEN", githubTokenKey)
+	}
+	apiURL, _ := s.GetConfig(ctx, githubAPIURLKey)
+	return &github.Client{APIURL: apiURL, Token: token, UserAgent: "beads-cli/" + Version}, nil
+}
+
+// githubSystem is the external_refs system name for a repository
+func githubSystem(repo string) string {
+	return "github:" + repo
+}
+
+// githubPushable reports whether an unlinked issue gets a GitHub issue.
+// Closed work, agent messages and ephemeral issues stay local.
+func githubPushable(issue *types.Issue) bool {
+	return issue.Status != types.StatusClosed && !issue.Ephemeral && issue.IssueType != types.TypeMessage
+}
+
+type githubSyncOptions struct {
+	Pull, Push bool
+	DryRun     bool
+	Prefer     string    // "local", "github", or "" to skip conflicts
+	Since      time.Time // only read GitHub issues updated since; zero reads all
+}
+
+// githubSyncer reconciles one repository with the local database
+type githubSyncer struct {
+	store  *sqlite.SQLiteStorage
+	client *github.Client
+	opts   githubSyncOptions
+	actor  string
+
+	system       string
+	result       *GitHubSyncResult
+	byIssue      map[string]*sqlite.ExternalRef
+	byNumber     map[int]*sqlite.ExternalRef
+	local        map[string]*types.Issue
+	labels       map[string][]string
+	pulled       []string // issues whose description may mention other synced issues
+	changedLocal bool
+}
+
+func (s *githubSyncer) run(ctx context.Context) (*GitHubSyncResult, error) {
+	repo := s.client.Owner + "/" + s.client.Repo
+	s.system = githubSystem(repo)
+	s.result = &GitHubSyncResult{Success: true, DryRun: s.opts.DryRun, Repo: repo}
+
+	refs, err := s.store.GetExternalRefs(ctx, s.system)
+	if err != nil {
+		return nil, err
+	}
+	s.byIssue = make(map[string]*sqlite.ExternalRef, len(refs))
+	s.byNumber = make(map[int]*sqlite.ExternalRef, len(refs))
+	for _, ref := range refs {
+		s.link(ref)
+	}
+
+	// Read GitHub before beads so nothing changed during the sync is older
+	// than the recorded sync time
+	started := time.Now().UTC()
+	remote, err := s.client.ListIssues(ctx, s.opts.Since)
+	if err != nil {
+		return nil, fmt.Errorf("listing GitHub issues: %w", err)
+	}
+	remoteByNumber := make(map[int]*github.Issue, len(remote))
+	for _, r := range remote {
+		remoteByNumber[r.Number] = r
+	}
+
+	issues, err := s.store.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		return nil, err
+	}
+	s.local = make(map[string]*types.Issue, len(issues))
+	ids := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		s.local[issue.ID] = issue
+		ids = append(ids, issue.ID)
+	}
+	sort.Strings(ids)
+	if s.labels, err = s.store.GetLabelsForIssues(ctx, ids); err != nil {
+		return nil, err
+	}
+	deps, err := s.store.GetAllDependencyRecords(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Linked pairs: compare both sides with the last agreed state
+	for _, ref := range refs {
+		if issue := s.local[ref.IssueID]; issue != nil {
+			number, _ := strconv.Atoi(ref.RemoteID)
+			s.reconcile(ctx, ref, issue, remoteByNumber[number], deps)
+		}
+	}
+
+	// GitHub issues with no beads issue: relink from the footer, or import
+	sort.Slice(remote, func(i, j int) bool { return remote[i].Number < remote[j].Number })
+	for _, r := range remote {
+		if s.byNumber[r.Number] != nil {
+			continue
+		}
+		_, beadsID := github.SplitBody(r.Body)
+		if beadsID != "" && s.byIssue[beadsID] == nil {
+			if issue := s.local[beadsID]; issue != nil {
+				ref := &sqlite.ExternalRef{IssueID: issue.ID, System: s.system, RemoteID: strconv.Itoa(r.Number), URL: r.HTMLURL}
+				s.record(GitHubSyncAction{Action: "link", IssueID: issue.ID, Number: r.Number, Title: issue.Title})
+				s.result.Stats.Linked++
+				if !s.opts.DryRun {
+					if err := s.store.SetExternalRef(ctx, ref); err != nil {
+						s.fail(issue.ID, err)
+						continue
+					}
+				}
+				s.link(ref)
+				// Nothing is known about the last agreed state, so any
+				// difference is a conflict
+				s.reconcile(ctx, ref, issue, r, deps)
+				continue
+			}
+		}
+		if s.opts.Pull {
+			s.createLocal(ctx, r)
+		}
+	}
+
+	// Open beads issues with no GitHub issue. An issue created before the
+	// issues it depends on has no GitHub numbers to refer to yet, so its
+	// footer is rewritten once they all exist.
+	if s.opts.Push {
+		sentRefs := make(map[string]int)
+		for _, id := range ids {
+			if issue := s.local[id]; s.byIssue[id] == nil && githubPushable(issue) {
+				sentRefs[id] = len(s.crossRefs(id, deps))
+				s.createRemote(ctx, issue, deps)
+			}
+		}
+		for _, id := range ids {
+			ref := s.byIssue[id]
+			if sent, ok := sentRefs[id]; ok && ref != nil && !s.opts.DryRun && len(s.crossRefs(id, deps)) > sent {
+				s.updateFooter(ctx, ref, s.local[id], deps)
+			}
+		}
+	}
+
+	s.linkMentions(ctx, deps)
+
+	if !s.opts.DryRun && s.result.Stats.Errors == 0 {
+		s.result.LastSync = started.Format(time.RFC3339)
+		if err := s.store.SetConfig(ctx, githubLastSyncKey, s.result.LastSync); err != nil {
+			s.result.Warnings = append(s.result.Warnings, fmt.Sprintf("failed to record last sync: %v", err))
+		}
+	}
+	return s.result, nil
+}
+
+// reconcile brings one linked pair into agreement. r is nil when the GitHub
+// issue hasn't changed since the last sync.
+func (s *githubSyncer) reconcile(ctx context.Context, ref *sqlite.ExternalRef, issue *types.Issue, r *github.Issue, deps map[string][]*types.Dependency) {
+	localFields := github.LocalFields(issue, s.labels[issue.ID])
+	localHash := localFields.Hash()
+	localChanged := localHash != ref.SyncHash
+	remoteChanged := false
+	var remoteFields github.Fields
+	if r != nil {
+		remoteFields = github.RemoteFields(r)
+		remoteChanged = remoteFields.Hash() != ref.SyncHash
+	}
+
+	switch {
+	case !localChanged && !remoteChanged:
+		return
+	case localChanged && remoteChanged && localHash == remoteFields.Hash():
+		// Both sides made the same change
+		if !s.opts.DryRun {
+			ref.SyncHash = localHash
+			if err := s.store.SetExternalRef(ctx, ref); err != nil {
+				s.fail(issue.ID, err)
+			}
+		}
+		return
+	case localChanged && remoteChanged:
+		switch {
+		case s.opts.Prefer == "local" && s.opts.Push:
+			s.push(ctx, ref, issue, localFields, deps, "conflict resolved in favor of beads")
+		case s.opts.Prefer == "github" && s.opts.Pull:
+			s.pull(ctx, ref, issue, r, "conflict resolved in favor of GitHub")
+		default:
+			s.record(GitHubSyncAction{Action: "conflict", IssueID: issue.ID, Number: r.Number, Title: issue.Title,
+				Detail: "changed in beads and on GitHub since the last sync"})
+			s.result.Stats.Conflicts++
+		}
+	case localChanged:
+		if s.opts.Push {
+			s.push(ctx, ref, issue, localFields, deps, "")
+		}
+	default:
+		if s.opts.Pull {
+			s.pull(ctx, ref, issue, r, "")
+		}
+	}
+}
+
+// push updates the GitHub issue from the beads issue
+func (s *githubSyncer) push(ctx context.Context, ref *sqlite.ExternalRef, issue *types.Issue, fields github.Fields, deps map[string][]*types.Dependency, detail string) {
+	number, _ := strconv.Atoi(ref.RemoteID)
+	s.record(GitHubSyncAction{Action: "push", IssueID: issue.ID, Number: number, Title: issue.Title, Detail: detail})
+	s.result.Stats.Pushed++
+	if s.opts.DryRun {
+		return
+	}
+	updated, err := s.client.UpdateIssue(ctx, number, github.Request(fields, issue.ID, s.crossRefs(issue.ID, deps)))
+	if err != nil {
+		s.fail(issue.ID, err)
+		return
+	}
+	ref.URL = updated.HTMLURL
+	ref.SyncHash = fields.Hash()
+	ref.RemoteUpdatedAt = &updated.UpdatedAt
+	if err := s.store.SetExternalRef(ctx, ref); err != nil {
+		s.fail(issue.ID, err)
+	}
+}
+
+// updateFooter rewrites the cross-references of a GitHub issue bd just
+// created, without counting as another push
+func (s *githubSyncer) updateFooter(ctx context.Context, ref *sqlite.ExternalRef, issue *types.Issue, deps map[string][]*types.Dependency) {
+	number, _ := strconv.Atoi(ref.RemoteID)
+	fields := github.LocalFields(issue, s.labels[issue.ID])
+	updated, err := s.client.UpdateIssue(ctx, number, github.Request(fields, issue.ID, s.crossRefs(issue.ID, deps)))
+	if err != nil {
+		s.fail(issue.ID, err)
+		return
+	}
+	ref.RemoteUpdatedAt = &updated.UpdatedAt
+	if err := s.store.SetExternalRef(ctx, ref); err != nil {
+		s.fail(issue.ID, err)
+	}
+}
+
+// pull updates the beads issue from the GitHub issue
+func (s *githubSyncer) pull(ctx context.Context, ref *sqlite.ExternalRef, issue *types.Issue, r *github.Issue, detail string) {
+	s.record(GitHubSyncAction{Action: "pull", IssueID: issue.ID, Number: r.Number, Title: r.Title, Detail: detail})
+	s.result.Stats.Pulled++
+	if s.opts.DryRun {
+		return
+	}
+	fields := github.RemoteFields(r)
+	if err := s.applyFields(ctx, issue, fields); err != nil {
+		s.fail(issue.ID, err)
+		return
+	}
+	ref.URL = r.HTMLURL
+	ref.SyncHash = fields.Hash()
+	ref.RemoteUpdatedAt = &r.UpdatedAt
+	if err := s.store.SetExternalRef(ctx, ref); err != nil {
+		s.fail(issue.ID, err)
+		return
+	}
+	s.pulled = append(s.pulled, issue.ID)
+}
+
+// applyFields makes the beads issue match fields
+func (s *githubSyncer) applyFields(ctx context.Context, issue *types.Issue, fields github.Fields) error {
+	current := github.LocalFields(issue, s.labels[issue.ID])
+	updates := map[string]interface{}{}
+	if current.Title != fields.Title {
+		updates["title"] = fields.Title
+	}
+	if current.Description != fields.Description {
+		updates["description"] = fields.Description
+	}
+	if current.Assignee != fields.Assignee {
+		updates["assignee"] = fields.Assignee
+	}
+	if current.Closed && !fields.Closed {
+		updates["status"] = string(types.StatusOpen)
+	}
+	if len(updates) > 0 {
+		if err := s.store.UpdateIssue(ctx, issue.ID, updates, s.actor); err != nil {
+			return err
+		}
+		s.changedLocal = true
+	}
+	if fields.Closed && !current.Closed {
+		if err := s.store.CloseIssue(ctx, issue.ID, "Closed on GitHub", s.actor); err != nil {
+			return err
+		}
+		s.changedLocal = true
+	}
+
+	want := make(map[string]bool, len(fields.Labels))
+	for _, l := range fields.Labels {
+		want[l] = true
+	}
+	have := make(map[string]bool, len(current.Labels))
+	for _, l := range current.Labels {
+		have[l] = true
+		if !want[l] {
+			if err := s.store.RemoveLabel(ctx, issue.ID, l, s.actor); err != nil {
+				return err
+			}
+			s.changedLocal = true
+		}
+	}
+	for _, l := range fields.Labels {
+		if !have[l] {
+			if err := s.store.AddLabel(ctx, issue.ID, l, s.actor); err != nil {
+				return err
+			}
+			s.changedLocal = true
+		}
+	}
+	return nil
+}
+
+// createLocal imports a GitHub issue as a new beads task
+func (s *githubSyncer) createLocal(ctx context.Context, r *github.Issue) {
+	s.record(GitHubSyncAction{Action: "create_local", Number: r.Number, Title: r.Title})
+	s.result.Stats.CreatedLocal++
+	if s.opts.DryRun {
+		return
+	}
+	fields := github.RemoteFields(r)
+	url := r.HTMLURL
+	issue := &types.Issue{
+		Title:       fields.Title,
+		Description: fields.Description,
+		Status:      types.StatusOpen,
+		Priority:    2,
+		IssueType:   types.TypeTask,
+		Assignee:    fields.Assignee,
+		ExternalRef: &url,
+	}
+	if err := s.store.CreateIssue(ctx, issue, s.actor); err != nil {
+		s.fail(fmt.Sprintf("#%d", r.Number), err)
+		return
+	}
+	s.changedLocal = true
+	s.result.Actions[len(s.result.Actions)-1].IssueID = issue.ID
+	s.local[issue.ID] = issue
+	if err := s.applyFields(ctx, issue, fields); err != nil {
+		s.fail(issue.ID, err)
+		return
+	}
+	ref := &sqlite.ExternalRef{IssueID: issue.ID, System: s.system, RemoteID: strconv.Itoa(r.Number),
+		URL: r.HTMLURL, SyncHash: fields.Hash(), RemoteUpdatedAt: &r.UpdatedAt}
+	if err := s.store.SetExternalRef(ctx, ref); err != nil {
+		s.fail(issue.ID, err)
+		return
+	}
+	s.link(ref)
+	s.pulled = append(s.pulled, issue.ID)
+}
+
+// createRemote opens a GitHub issue for a beads issue
+func (s *githubSyncer) createRemote(ctx context.Context, issue *types.Issue, deps map[string][]*types.Dependency) {
+	s.record(GitHubSyncAction{Action: "create_remote", IssueID: issue.ID, Title: issue.Title})
+	s.result.Stats.CreatedRemote++
+	if s.opts.DryRun {
+		return
+	}
+	fields := github.LocalFields(issue, s.labels[issue.ID])
+	created, err := s.client.CreateIssue(ctx, github.Request(fields, issue.ID, s.crossRefs(issue.ID, deps)))
+	if err != nil {
+		s.fail(issue.ID, err)
+		return
+	}
+	s.result.Actions[len(s.result.Actions)-1].Number = created.Number
+	ref := &sqlite.ExternalRef{IssueID: issue.ID, System: s.system, RemoteID: strconv.Itoa(created.Number),
+		URL: created.HTMLURL, SyncHash: fields.Hash(), RemoteUpdatedAt: &created.UpdatedAt}
+	if err := s.store.SetExternalRef(ctx, ref); err != nil {
+		s.fail(issue.ID, err)
+		return
+	}
+	s.link(ref)
+	if issue.ExternalRef == nil || *issue.ExternalRef == "" {
+		if err := s.store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"external_ref": created.HTMLURL}, s.actor); err != nil {
+			s.fail(issue.ID, err)
+			return
+		}
+		s.changedLocal = true
+	}
+}
+
+// crossRefs lists an issue's dependencies on other synced issues as
+// GitHub references for the body footer
+func (s *githubSyncer) crossRefs(issueID string, deps map[string][]*types.Dependency) []string {
+	var refs []string
+	for _, dep := range deps[issueID] {
+		ref := s.byIssue[dep.DependsOnID]
+		if ref == nil {
+			continue
+		}
+		switch dep.Type {
+		case types.DepBlocks:
+			refs = append(refs, "Blocked by #"+ref.RemoteID)
+		case types.DepParentChild:
+			refs = append(refs, "Part of #"+ref.RemoteID)
+		case types.DepRelated, types.DepRelatesTo:
+			refs = append(refs, "Related to #"+ref.RemoteID)
+		case types.DepDiscoveredFrom:
+			refs = append(refs, "Discovered from #"+ref.RemoteID)
+		}
+	}
+	return refs
+}
+
+// linkMentions turns #N mentions in pulled descriptions into related links
+// between synced issues that aren't linked in beads yet
+func (s *githubSyncer) linkMentions(ctx context.Context, deps map[string][]*types.Dependency) {
+	linked := func(a, b string) bool {
+		for _, dep := range deps[a] {
+			if dep.DependsOnID == b {
+				return true
+			}
+		}
+		for _, dep := range deps[b] {
+			if dep.DependsOnID == a {
+				return true
+			}
+		}
+		return false
+	}
+	for _, id := range s.pulled {
+		issue, err := s.store.GetIssue(ctx, id)
+		if err != nil || issue == nil {
+			continue
+		}
+		for _, number := range github.Mentions(issue.Description) {
+			ref := s.byNumber[number]
+			if ref == nil || ref.IssueID == id || linked(id, ref.IssueID) {
+				continue
+			}
+			dep := &types.Dependency{IssueID: id, DependsOnID: ref.IssueID, Type: types.DepRelated}
+			if err := s.store.AddDependency(ctx, dep, s.actor); err != nil {
+				s.result.Warnings = append(s.result.Warnings, fmt.Sprintf("linking %s to %s (#%d): %v", id, ref.IssueID, number, err))
+				continue
+			}
+			deps[id] = append(deps[id], dep)
+			s.changedLocal = true
+		}
+	}
+}
+
+func (s *githubSyncer) link(ref *sqlite.ExternalRef) {
+	s.byIssue[ref.IssueID] = ref
+	if number, err := strconv.Atoi(ref.RemoteID); err == nil {
+		s.byNumber[number] = ref
+	}
+}
+
+func (s *githubSyncer) record(action GitHubSyncAction) {
+	s.result.Actions = append(s.result.Actions, action)
+}
+
+func (s *githubSyncer) fail(what string, err error) {
+	s.result.Success = false
+	s.result.Stats.Errors++
+	s.result.Warnings = append(s.result.Warnings, fmt.Sprintf("%s: %v", what, err))
+}
+
+// githubSyncChanges converts a sync's actions for the sync log. Relinks and
+// skipped conflicts only touch the local side's link, so they count as inbound.
+func githubSyncChanges(result *GitHubSyncResult) []sqlite.SyncChange {
+	if result == nil {
+		return nil
+	}
+	changes := []sqlite.SyncChange{}
+	for _, a := range result.Actions {
+		c := sqlite.SyncChange{Direction: sqlite.SyncInbound, Action: a.Action, IssueID: a.IssueID, Title: a.Title, Detail: a.Detail}
+		switch a.Action {
+		case "push":
+			c.Direction, c.Action = sqlite.SyncOutbound, "update"
+		case "create_remote":
+			c.Direction, c.Action = sqlite.SyncOutbound, "create"
+		case "pull":
+			c.Action = "update"
+		case "create_local":
+			c.Action = "create"
+		}
+		if a.Number > 0 {
+			c.Remote = fmt.Sprintf("#%d", a.Number)
+		}
+		changes = append(changes, c)
+	}
+	return changes
+}
+
+// githubSyncError is the sync log's outcome for a sync: its error, or the
+// per-issue failures of a sync that otherwise ran
+func githubSyncError(result *GitHubSyncResult, err error) error {
+	if err != nil || result == nil || result.Success {
+		return err
+	}
+	return errors.New(strings.Join(result.Warnings, "; "))
+}
+
+func printGitHubSyncResult(result *GitHubSyncResult) {
+	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+	verbs := map[string]string{
+		"push":          "push",
+		"pull":          "pull",
+		"create_remote": "create on GitHub",
+		"create_local":  "import",
+		"link":          "relink",
+		"conflict":      "conflict",
+	}
+
+	if result.DryRun {
+		fmt.Printf("Dry run against %s (no changes made):\n", result.Repo)
+	}
+	for _, a := range result.Actions {
+		number := ""
+		if a.Number > 0 {
+			number = fmt.Sprintf("#%d", a.Number)
+		}
+		verb := verbs[a.Action]
+		if a.Action == "conflict" {
+			verb = yellow(verb)
+		}
+		fmt.Printf("  %-16s %-10s %-6s %s", verb, a.IssueID, number, truncateTitle(a.Title, 60))
+		if a.Detail != "" {
+			fmt.Printf(" (%s)", a.Detail)
+		}
+		fmt.Println()
+	}
+
+	st := result.Stats
+	if len(result.Actions) == 0 {
+		fmt.Printf("%s %s is up to date\n", green("✓"), result.Repo)
+	} else if !result.DryRun {
+		fmt.Printf("%s Synced %s: %d pushed, %d pulled, %d created on GitHub, %d imported, %d relinked\n",
+			green("✓"), result.Repo, st.Pushed, st.Pulled, st.CreatedRemote, st.CreatedLocal, st.Linked)
+	}
+	if st.Conflicts > 0 {
+		fmt.Printf("%s %d conflict(s) skipped; rerun with --prefer-local or --prefer-github\n", yellow("⚠"), st.Conflicts)
+	}
+	for _, w := range result.Warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", w)
+	}
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/github"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// fakeGitHub serves the parts of the issues API that sync uses
+type fakeGitHub struct {
+	mu     sync.Mutex
+	issues map[int]*github.Issue
+	next   int
+}
+
+func newFakeGitHub(t *testing.T) (*fakeGitHub, *github.Client) {
+	t.Helper()
+	f := &fakeGitHub{issues: make(map[int]*github.Issue), next: 1}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/o/r/issues", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		var since time.Time
+		if s := r.URL.Query().Get("since"); s != "" {
+			since, _ = time.Parse(time.RFC3339, s)
+		}
+		list := []*github.Issue{}
+		for n := 1; n < f.next; n++ {
+			if issue := f.issues[n]; issue != nil && !issue.UpdatedAt.Before(since) {
+				list = append(list, issue)
+			}
+		}
+		_ = json.NewEncoder(w).Encode(list)
+	})
+	mux.HandleFunc("POST /repos/o/r/issues", func(w http.ResponseWriter, r *http.Request) {
+		var req github.IssueRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		_ = json.NewEncoder(w).Encode(f.put(0, &req))
+	})
+	mux.HandleFunc("PATCH /repos/o/r/issues/{n}", func(w http.ResponseWriter, r *http.Request) {
+		var req github.IssueRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		n, _ := strconv.Atoi(r.PathValue("n"))
+		_ = json.NewEncoder(w).Encode(f.put(n, &req))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return f, &github.Client{APIURL: srv.URL, Token: "tok", Owner: "o", Repo: "r"}
+}
+
+// put creates (n == 0) or replaces an issue, as a user or the API would
+func (f *fakeGitHub) put(n int, req *github.IssueRequest) *github.Issue {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if n == 0 {
+		n = f.next
+		f.next++
+	}
+	issue := &github.Issue{Number: n, Title: req.Title, Body: req.Body, State: req.State,
+		HTMLURL: "https://github.com/o/r/issues/" + strconv.Itoa(n), UpdatedAt: time.Now().UTC()}
+	if issue.State == "" {
+		issue.State = "open"
+	}
+	for _, l := range req.Labels {
+		issue.Labels = append(issue.Labels, github.Label{Name: l})
+	}
+	for _, a := range req.Assignees {
+		issue.Assignees = append(issue.Assignees, github.User{Login: a})
+	}
+	f.issues[n] = issue
+	return issue
+}
+
+func (f *fakeGitHub) get(n int) *github.Issue {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.issues[n]
+}
+
+func TestGitHubSync(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t, filepath.Join(t.TempDir(), ".beads", "beads.db"))
+	fake, client := newFakeGitHub(t)
+	sync := func(opts githubSyncOptions) *GitHubSyncResult {
+		t.Helper()
+		if last, _ := s.GetConfig(ctx, githubLastSyncKey); last != "" {
+			opts.Since, _ = time.Parse(time.RFC3339, last)
+			opts.Since = opts.Since.Add(-time.Second) // the fake's clock has sub-second precision
+		}
+		result, err := (&githubSyncer{store: s, client: client, opts: opts, actor: "test"}).run(ctx)
+		if err != nil {
+			t.Fatalf("sync: %v", err)
+		}
+		if !result.Success {
+			t.Fatalf("sync failed: %v", result.Warnings)
+		}
+		return result
+	}
+	both := githubSyncOptions{Pull: true, Push: true}
+
+	parent := &types.Issue{Title: "Auth epic", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeEpic}
+	child := &types.Issue{Title: "Login form", Description: "Add a form", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, Assignee: "octocat"}
+	done := &types.Issue{Title: "Old work", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{parent, child, done} {
+		if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_ = s.AddLabel(ctx, child.ID, "ui", "test")
+	_ = s.AddDependency(ctx, &types.Dependency{IssueID: child.ID, DependsOnID: parent.ID, Type: types.DepParentChild}, "test")
+	_ = s.CloseIssue(ctx, done.ID, "done", "test")
+	fake.put(0, &github.IssueRequest{Title: "Reported upstream", Body: "Crashes", Labels: []string{"bug"}})
+
+	// Dry run changes nothing
+	result := sync(githubSyncOptions{Pull: true, Push: true, DryRun: true})
+	if result.Stats.CreatedLocal != 1 || result.Stats.CreatedRemote != 2 || fake.next != 2 {
+		t.Fatalf("dry run stats %+v, fake has %d issues", result.Stats, fake.next-1)
+	}
+
+	result = sync(both)
+	if result.Stats.CreatedLocal != 1 || result.Stats.CreatedRemote != 2 {
+		t.Fatalf("first sync stats %+v", result.Stats)
+	}
+	refs, _ := s.GetExternalRefs(ctx, "github:o/r")
+	if len(refs) != 3 {
+		t.Fatalf("expected 3 links, got %d", len(refs))
+	}
+	number := map[string]int{}
+	var imported string
+	for _, ref := range refs {
+		number[ref.IssueID], _ = strconv.Atoi(ref.RemoteID)
+		if ref.RemoteID == "1" {
+			imported = ref.IssueID
+		}
+	}
+	if _, ok := number[done.ID]; ok {
+		t.Error("closed issues should not be pushed")
+	}
+	remoteChild := fake.get(number[child.ID])
+	if !strings.Contains(remoteChild.Body, "<!-- beads:"+child.ID+" -->") ||
+		!strings.Contains(remoteChild.Body, "Part of #"+strconv.Itoa(number[parent.ID])) {
+		t.Errorf("child body missing footer: %q", remoteChild.Body)
+	}
+	if len(remoteChild.Assignees) != 1 || remoteChild.Labels[0].Name != "ui" {
+		t.Errorf("child not mapped: %+v", remoteChild)
+	}
+	got, _ := s.GetIssue(ctx, child.ID)
+	if got.ExternalRef == nil || *got.ExternalRef != remoteChild.HTMLURL {
+		t.Errorf("external_ref = %v, want %s", got.ExternalRef, remoteChild.HTMLURL)
+	}
+	if labels, _ := s.GetLabels(ctx, imported); len(labels) != 1 || labels[0] != "bug" {
+		t.Errorf("imported labels = %v", labels)
+	}
+
+	// Nothing changed: nothing to do
+	if result = sync(both); len(result.Actions) != 0 {
+		t.Fatalf("second sync should be a no-op, got %+v", result.Actions)
+	}
+
+	// One change on each side flows the right way
+	_ = s.UpdateIssue(ctx, child.ID, map[string]interface{}{"title": "Login form v2"}, "test")
+	r := fake.get(number[imported])
+	body := "Crashes, see #" + strconv.Itoa(number[parent.ID])
+	fake.put(r.Number, &github.IssueRequest{Title: r.Title, Body: body, State: "closed", Labels: []string{"bug"}})
+	result = sync(both)
+	if result.Stats.Pushed != 1 || result.Stats.Pulled != 1 {
+		t.Fatalf("stats %+v, want one push and one pull", result.Stats)
+	}
+	if fake.get(number[child.ID]).Title != "Login form v2" {
+		t.Error("title change was not pushed")
+	}
+	if got, _ := s.GetIssue(ctx, imported); got.Status != types.StatusClosed {
+		t.Errorf("imported status = %s, want closed", got.Status)
+	}
+	// The #N mention of the epic becomes a related link
+	deps, _ := s.GetDependencyRecords(ctx, imported)
+	if len(deps) != 1 || deps[0].DependsOnID != parent.ID || deps[0].Type != types.DepRelated {
+		t.Errorf("imported deps = %+v, want related to %s", deps, parent.ID)
+	}
+
+	// Both sides change the same issue: a conflict until a side is preferred
+	_ = s.UpdateIssue(ctx, parent.ID, map[string]interface{}{"title": "Auth (local)"}, "test")
+	r = fake.get(number[parent.ID])
+	fake.put(r.Number, &github.IssueRequest{Title: "Auth (remote)", Body: r.Body, State: "open"})
+	result = sync(both)
+	if result.Stats.Conflicts != 1 || result.Stats.Pushed+result.Stats.Pulled != 0 {
+		t.Fatalf("stats %+v, want a single conflict", result.Stats)
+	}
+	if got, _ := s.GetIssue(ctx, parent.ID); got.Title != "Auth (local)" || fake.get(r.Number).Title != "Auth (remote)" {
+		t.Error("a conflict should leave both sides alone")
+	}
+	sync(githubSyncOptions{Pull: true, Push: true, Prefer: "github"})
+	if got, _ := s.GetIssue(ctx, parent.ID); got.Title != "Auth (remote)" {
+		t.Errorf("title = %q, want the GitHub version", got.Title)
+	}
+
+	// Losing the links (a fresh clone) relinks from the body footer
+	for _, ref := range refs {
+		_, _ = s.DeleteExternalRef(ctx, ref.IssueID, ref.System)
+	}
+	_ = s.DeleteConfig(ctx, githubLastSyncKey)
+	result = sync(githubSyncOptions{Push: true})
+	if result.Stats.Linked != 2 || result.Stats.CreatedRemote != 0 || result.Stats.Conflicts != 0 {
+		t.Errorf("relink stats %+v, want 2 relinked and nothing created", result.Stats)
+	}
+}
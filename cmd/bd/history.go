@@ -0,0 +1,116 @@
+package main
+
+import (
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/debug"
+	"github.com/steveyegge/beads/internal/journal"
+	"github.com/steveyegge/beads/internal/redact"
+)
+
+// The command journal (history: true in config.yaml or BD_HISTORY=1)
+var (
+	cmdJournal      *journal.Journal
+	cmdJournalEntry *journal.Entry
+)
+
+// journalSkipCommands are never recorded: shell completion runs on every
+// keypress and replays would otherwise record themselves
+var journalSkipCommands = []string{"__complete", "__completeNoDesc", "completion", "replay"}
+
+// startJournal records this invocation if the journal is enabled. It runs
+// before flag parsing, so it reads --actor from the raw arguments.
+func startJournal() {
+	args := os.Args[1:]
+	if !config.GetBool("history") || len(args) == 0 || slices.Contains(journalSkipCommands, args[0]) {
+		return
+	}
+	path := config.GetString("history-file")
+	if path == "" {
+		var err error
+		if path, err = journal.DefaultPath(); err != nil {
+			debug.Logf("journal disabled: %v", err)
+			return
+		}
+	}
+	j, err := journal.Open(path)
+	if err != nil {
+		debug.Logf("journal disabled: %v", err)
+		return
+	}
+	dir, _ := os.Getwd()
+	entry, err := j.Start(journalArgs(args), dir, journalActor(args), Version)
+	if err != nil {
+		debug.Logf("journal: %v", err)
+		return
+	}
+	cmdJournal, cmdJournalEntry = j, entry
+}
+
+// finishJournal records how this invocation ended. Exits that bypass it
+// leave the entry without a result.
+func finishJournal(exitCode int, errMsg string) {
+	if cmdJournal == nil {
+		return
+	}
+	if err := cmdJournal.Finish(cmdJournalEntry, exitCode, errMsg); err != nil {
+		debug.Logf("journal: %v", err)
+	}
+	cmdJournal = nil
+}
+
+// journalArgs redacts secrets from the arguments before they are written:
+// values of secret config keys and anything the redaction rules match
+func journalArgs(args []string) []string {
+	out := slices.Clone(args)
+	var positional []int
+	for i, arg := range out {
+		if !strings.HasPrefix(arg, "-") {
+			positional = append(positional, i)
+		}
+	}
+	if len(positional) >= 4 && out[positional[0]] == "config" && out[positional[1]] == "set" && isLocalConfigKey(out[positional[2]]) {
+		out[positional[3]] = redact.DefaultReplacement
+	}
+
+	redactor, err := redact.New(redact.DefaultRules, nil, "")
+	if err != nil {
+		return out
+	}
+	for i := range out {
+		out[i], _ = redactor.Redact(out[i])
+	}
+	return out
+}
+
+// journalActor is the actor the invocation will run as, resolved the same
+// way as the --actor flag
+func journalActor(args []string) string {
+	for i, arg := range args {
+		if value, ok := strings.CutPrefix(arg, "--actor="); ok {
+			return value
+		}
+		if arg == "--actor" && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	if value := config.GetString("actor"); value != "" {
+		return value
+	}
+	return fallbackActor()
+}
+
+// fallbackActor is the actor for commands that run before, or without, the
+// database setup that normally resolves it
+func fallbackActor() string {
+	if actor != "" {
+		return actor
+	}
+	if bdActor := os.Getenv("BD_ACTOR"); bdActor != "" {
+		return bdActor
+	}
+	return os.Getenv("USER")
+}
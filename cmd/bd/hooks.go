@@ -19,7 +19,7 @@ var hooksFS embed.FS
 
 func getEmbeddedHooks() (map[string]string, error) {
 	hooks := make(map[string]string)
-	hookNames := []string{"pre-commit", "post-merge", "pre-push", "post-checkout"}
+	hookNames := []string{"pre-commit", "post-merge", "pre-push", "post-checkout", "prepare-commit-msg"}
 
 	for _, name := range hookNames {
 		content, err := hooksFS.ReadFile("templates/hooks/" + name)
@@ -149,10 +149,12 @@ var hooksCmd = &cobra.Command{
 	Long: `Install, uninstall, or list git hooks that provide automatic bd sync.
 
 The hooks ensure that:
-- pre-commit: Flushes pending changes to JSONL before commit
+- pre-commit: Flushes pending changes to JSONL before commit, refusing
+  the commit if the JSONL is still stale
 - post-merge: Imports updated JSONL after pull/merge
 - pre-push: Prevents pushing stale JSONL
-- post-checkout: Imports JSONL after branch checkout`,
+- post-checkout: Imports JSONL after branch checkout
+- prepare-commit-msg: Appends "refs <id>" for your claimed issues`,
 }
 
 var hooksInstallCmd = &cobra.Command{
@@ -165,10 +167,11 @@ Use --shared to install to a versioned directory (.beads-hooks/) that can be
 committed to git and shared with team members.
 
 Installed hooks:
-  - pre-commit: Flush changes to JSONL before commit
+  - pre-commit: Flush changes to JSONL before commit (refuse if still stale)
   - post-merge: Import JSONL after pull/merge
   - pre-push: Prevent pushing stale JSONL
-  - post-checkout: Import JSONL after branch checkout`,
+  - post-checkout: Import JSONL after branch checkout
+  - prepare-commit-msg: Append "refs <id>" for your claimed issues`,
 	Run: func(cmd *cobra.Command, args []string) {
 		force, _ := cmd.Flags().GetBool("force")
 		shared, _ := cmd.Flags().GetBool("shared")
@@ -355,7 +358,7 @@ func uninstallHooks() error {
 		return err
 	}
 	hooksDir := filepath.Join(gitDir, "hooks")
-	hookNames := []string{"pre-commit", "post-merge", "pre-push", "post-checkout"}
+	hookNames := []string{"pre-commit", "post-merge", "pre-push", "post-checkout", "prepare-commit-msg"}
 
 	for _, hookName := range hookNames {
 		hookPath := filepath.Join(hooksDir, hookName)
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Exit codes for 'bd hooks check-export', relied on by the pre-commit hook
+const (
+	exportStale     = 1
+	exportUncertain = 2
+)
+
+var hooksCheckExportCmd = &cobra.Command{
+	Use:   "check-export",
+	Short: "Check that the JSONL reflects all database changes",
+	Long: `Check whether the database has changes that have not been exported to JSONL.
+
+Used by the pre-commit hook to refuse commits with a stale JSONL.
+
+Exit codes:
+  0  JSONL is up to date
+  1  Database has unexported changes
+  2  Could not check (e.g. no database found)`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		hookStore, path, err := openHookStore()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exportUncertain)
+		}
+		dirty, jsonlPath, jsonlMissing, err := checkExportFreshness(hookStore, path)
+		_ = hookStore.Close()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exportUncertain)
+		}
+		inSync := len(dirty) == 0 && !jsonlMissing
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{
+				"in_sync":       inSync,
+				"jsonl":         jsonlPath,
+				"jsonl_missing": jsonlMissing,
+				"dirty_issues":  dirty,
+			})
+		} else if !inSync {
+			if jsonlMissing {
+				fmt.Fprintf(os.Stderr, "%s does not exist but the database has issues\n", jsonlPath)
+			}
+			if len(dirty) > 0 {
+				shown := dirty
+				if len(shown) > 10 {
+					shown = shown[:10]
+				}
+				more := ""
+				if len(dirty) > len(shown) {
+					more = fmt.Sprintf(" (and %d more)", len(dirty)-len(shown))
+				}
+				fmt.Fprintf(os.Stderr, "%d issue(s) changed since the last export: %s%s\n",
+					len(dirty), strings.Join(shown, ", "), more)
+			}
+		}
+
+		if !inSync {
+			os.Exit(exportStale)
+		}
+	},
+}
+
+var hooksPrepareCommitMsgCmd = &cobra.Command{
+	Use:   "prepare-commit-msg <msg-file> [source] [sha]",
+	Short: "Append refs for claimed issues to a commit message",
+	Long: `Append a "refs <id>" line to a commit message for each issue you have claimed
+(status in_progress, assigned to the current actor).
+
+Called by the prepare-commit-msg hook with git's arguments. Merge and squash
+messages are left alone, as are issues the message already mentions.`,
+	Args: cobra.RangeArgs(1, 3),
+	Run: func(cmd *cobra.Command, args []string) {
+		msgFile := args[0]
+		if len(args) > 1 && (args[1] == "merge" || args[1] == "squash") {
+			return
+		}
+
+		hookStore, _, err := openHookStore()
+		if err != nil {
+			FatalError("%v", err)
+		}
+		defer func() { _ = hookStore.Close() }()
+
+		claimant := hookActor()
+		status := types.StatusInProgress
+		claimed, err := hookStore.SearchIssues(rootCtx, "", types.IssueFilter{Status: &status, Assignee: &claimant})
+		if err != nil {
+			FatalError("%v", err)
+		}
+		if len(claimed) == 0 {
+			return
+		}
+		ids := make([]string, len(claimed))
+		for i, issue := range claimed {
+			ids[i] = issue.ID
+		}
+
+		// #nosec G304 -- path supplied by git to the hook
+		content, err := os.ReadFile(msgFile)
+		if err != nil {
+			FatalError("reading commit message: %v", err)
+		}
+		updated := appendIssueRefs(string(content), ids)
+		if updated == string(content) {
+			return
+		}
+		// #nosec G306 -- commit message file is owned by git; keep its permissions conventional
+		if err := os.WriteFile(msgFile, []byte(updated), 0644); err != nil {
+			FatalError("writing commit message: %v", err)
+		}
+	},
+}
+
+// checkExportFreshness reports issues changed since the last export, and
+// whether the JSONL is missing even though the database has issues
+func checkExportFreshness(s *sqlite.SQLiteStorage, path string) (dirty []string, jsonlPath string, jsonlMissing bool, err error) {
+	ctx := rootCtx
+	dirty, err = s.GetDirtyIssues(ctx)
+	if err != nil {
+		return nil, "", false, err
+	}
+	jsonlPath = beads.FindJSONLPath(path)
+	if _, statErr := os.Stat(jsonlPath); os.IsNotExist(statErr) {
+		// A brand-new database with no issues has nothing to export yet
+		stats, err := s.GetStatistics(ctx)
+		if err != nil {
+			return nil, "", false, err
+		}
+		jsonlMissing = stats.TotalIssues > 0
+	}
+	return dirty, jsonlPath, jsonlMissing, nil
+}
+
+// appendIssueRefs adds a "refs" line for ids not already mentioned in msg.
+// The line goes above git's comment block so it survives both comment
+// stripping and the scissors line of verbose commits.
+func appendIssueRefs(msg string, ids []string) string {
+	var missing []string
+	for _, id := range ids {
+		re := regexp.MustCompile(`(^|[^\w.-])` + regexp.QuoteMeta(id) + `($|[^\w.-]|\.(\D|$))`)
+		if !re.MatchString(msg) {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) == 0 {
+		return msg
+	}
+
+	lines := strings.Split(msg, "\n")
+	split := len(lines)
+	for i, line := range lines {
+		if strings.HasPrefix(line, "#") {
+			split = i
+			break
+		}
+	}
+	body := strings.TrimRight(strings.Join(lines[:split], "\n"), "\n")
+	comments := strings.Join(lines[split:], "\n")
+
+	refs := "refs " + strings.Join(missing, ", ")
+	// With an empty body this leaves the subject line blank for the user
+	var b strings.Builder
+	b.WriteString(body)
+	b.WriteString("\n\n")
+	b.WriteString(refs)
+	b.WriteString("\n")
+	if comments != "" {
+		b.WriteString(comments)
+	}
+	return b.String()
+}
+
+// openHookStore opens the workspace database directly. Hook subcommands skip
+// the normal database setup so hooks stay fast and never start a daemon.
+func openHookStore() (*sqlite.SQLiteStorage, string, error) {
+	path := dbPath
+	if path == "" {
+		path = beads.FindDatabasePath()
+	}
+	if path == "" {
+		return nil, "", fmt.Errorf("no beads database found")
+	}
+	s, err := sqlite.New(rootCtx, path)
+	if err != nil {
+		return nil, "", err
+	}
+	return s, path, nil
+}
+
+// hookActor resolves the actor the same way normal commands do
+func hookActor() string {
+	if actor != "" {
+		return actor
+	}
+	if bdActor := os.Getenv("BD_ACTOR"); bdActor != "" {
+		return bdActor
+	}
+	if user := os.Getenv("USER"); user != "" {
+		return user
+	}
+	return "unknown"
+}
+
+func init() {
+	hooksCmd.AddCommand(hooksCheckExportCmd)
+	hooksCmd.AddCommand(hooksPrepareCommitMsgCmd)
+}
@@ -0,0 +1,44 @@
+package main
+
+import "testing"
+
+func TestAppendIssueRefs(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  string
+		ids  []string
+		want string
+	}{
+		{
+			name: "message from -m",
+			msg:  "Fix parser\n",
+			ids:  []string{"bd-a1"},
+			want: "Fix parser\n\nrefs bd-a1\n",
+		},
+		{
+			name: "refs go above git comments",
+			msg:  "\n# Please enter the commit message\n# Lines starting with '#' are ignored\n",
+			ids:  []string{"bd-a1", "bd-b2"},
+			want: "\n\nrefs bd-a1, bd-b2\n# Please enter the commit message\n# Lines starting with '#' are ignored\n",
+		},
+		{
+			name: "already mentioned",
+			msg:  "Fix bd-a1.\n",
+			ids:  []string{"bd-a1"},
+			want: "Fix bd-a1.\n",
+		},
+		{
+			name: "longer or child IDs are different issues",
+			msg:  "Fix bd-a12 and bd-a1.2\n",
+			ids:  []string{"bd-a1"},
+			want: "Fix bd-a12 and bd-a1.2\n\nrefs bd-a1\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := appendIssueRefs(tt.msg, tt.ids); got != tt.want {
+				t.Errorf("appendIssueRefs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -16,7 +16,7 @@ func TestGetEmbeddedHooks(t *testing.T) {
 		t.Fatalf("getEmbeddedHooks() failed: %v", err)
 	}
 
-	expectedHooks := []string{"pre-commit", "post-merge", "pre-push", "post-checkout"}
+	expectedHooks := []string{"pre-commit", "post-merge", "pre-push", "post-checkout", "prepare-commit-msg"}
 	for _, hookName := range expectedHooks {
 		content, ok := hooks[hookName]
 		if !ok {
@@ -16,30 +16,52 @@ import (
 	"github.com/steveyegge/beads/internal/debug"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/util"
 	"github.com/steveyegge/beads/internal/utils"
 	"golang.org/x/term"
 )
 
 var importCmd = &cobra.Command{
-	Use:   "import",
+	Use:   "import [file.md]",
 	Short: "Import issues from JSONL format",
 	Long: `Import issues from JSON Lines format (one JSON object per line).
 
 Reads from stdin by default, or use -i flag for file input.
 
+--format=markdown imports a TODO-style markdown file instead:
+  - Each heading becomes an issue (an epic if anything is nested under it)
+  - Each checkbox ("- [ ] item") becomes a child issue of the heading or
+    checkbox above it, linked with a parent-child dependency
+  - Checked boxes ("- [x] item") become closed issues
+  - Other text becomes the description of the heading or item it follows
+  Importing the same file twice creates the issues twice; use --dry-run to
+  preview. Example: bd import --format=markdown TODO.md
+
 Behavior:
   - Existing issues (same ID) are updated
   - New issues are created
   - Collisions (same ID, different content) are detected and reported
   - Use --dedupe-after to find and merge content duplicates after import
   - Use --dry-run to preview changes without applying them
+  - Use --config to restore project config from 'bd export --include-config'
 
 NOTE: Import requires direct database access and does not work with daemon mode.
       The command automatically uses --no-daemon when executed.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		CheckReadonly("import")
+		format, _ := cmd.Flags().GetString("format")
+		switch format {
+		case "jsonl":
+		case "markdown", "md":
+			format = "markdown"
+			if len(args) > 1 {
+				FatalError("markdown import takes one file")
+			}
+		default:
+			FatalError("invalid --format %q (valid: jsonl, markdown)", format)
+		}
 		// Check for positional arguments (common mistake: bd import file.jsonl instead of bd import -i file.jsonl)
-		if len(args) > 0 {
+		if len(args) > 0 && format != "markdown" {
 			fmt.Fprintf(os.Stderr, "Error: Unexpected argument(s): %v\n\n", args)
 			fmt.Fprintf(os.Stderr, "Did you mean: bd import -i %s\n\n", args[0])
 			fmt.Fprintf(os.Stderr, "The import command does not accept positional arguments.\n")
@@ -98,8 +120,17 @@ NOTE: Import requires direct database access and does not work with daemon mode.
 		force, _ := cmd.Flags().GetBool("force")
 		protectLeftSnapshot, _ := cmd.Flags().GetBool("protect-left-snapshot")
 		noGitHistory, _ := cmd.Flags().GetBool("no-git-history")
+		restoreConfigFlag, _ := cmd.Flags().GetBool("config")
 		_ = noGitHistory // Accepted for compatibility with bd sync subprocess calls
 
+		if format == "markdown" {
+			if len(args) > 0 {
+				input = args[0]
+			}
+			runMarkdownImport(input, dryRun)
+			return
+		}
+
 		// Check if stdin is being used interactively (not piped)
 		if input == "" && term.IsTerminal(int(os.Stdin.Fd())) {
 			fmt.Fprintf(os.Stderr, "Error: No input specified.\n\n")
@@ -115,6 +146,9 @@ NOTE: Import requires direct database access and does not work with daemon mode.
 		// Open input
 		in := os.Stdin
 		if input != "" {
+			// A sharded layout may have updated shards (e.g. after a pull)
+			assembleJSONLShards(input)
+
 			// #nosec G304 - user-provided file path is intentional
 			f, err := os.Open(input)
 			if err != nil {
@@ -131,9 +165,10 @@ NOTE: Import requires direct database access and does not work with daemon mode.
 
 		// Phase 1: Read and parse all JSONL
 		ctx := rootCtx
-		scanner := bufio.NewScanner(in)
+		scanner := util.NewJSONLScanner(in)
 
 		var allIssues []*types.Issue
+		var importedConfig map[string]string
 		lineNum := 0
 
 		for scanner.Scan() {
@@ -188,8 +223,9 @@ NOTE: Import requires direct database access and does not work with daemon mode.
 						}
 					}()
 					in = f
-					scanner = bufio.NewScanner(in)
+					scanner = util.NewJSONLScanner(in)
 					allIssues = nil // Reset issues list
+					importedConfig = nil
 					lineNum = 0     // Reset line counter
 					continue        // Restart parsing from beginning
 				} else {
@@ -199,6 +235,16 @@ NOTE: Import requires direct database access and does not work with daemon mode.
 				}
 			}
 
+			// A config line written by 'bd export --include-config'
+			if config, ok, err := parseConfigBundle(rawLine); ok {
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error parsing config on line %d: %v\n", lineNum, err)
+					os.Exit(1)
+				}
+				importedConfig = config
+				continue
+			}
+
 			// Parse JSON
 			var issue types.Issue
 			if err := json.Unmarshal([]byte(line), &issue); err != nil {
@@ -214,6 +260,28 @@ NOTE: Import requires direct database access and does not work with daemon mode.
 			os.Exit(1)
 		}
 
+		// Restore config before the prefix check so an exported issue_prefix wins
+		if importedConfig != nil {
+			if restoreConfigFlag {
+				changed, err := restoreConfig(ctx, store, importedConfig, dryRun)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error restoring config: %v\n", err)
+					os.Exit(1)
+				}
+				verb := "Restored"
+				if dryRun {
+					verb = "Would restore"
+				}
+				fmt.Fprintf(os.Stderr, "%s %d config key(s)", verb, len(changed))
+				if len(changed) > 0 {
+					fmt.Fprintf(os.Stderr, ": %s", strings.Join(changed, ", "))
+				}
+				fmt.Fprintln(os.Stderr)
+			} else {
+				fmt.Fprintf(os.Stderr, "Note: input contains project config (%d keys); pass --config to restore it\n", len(importedConfig))
+			}
+		}
+
 		// Check if database needs initialization (prefix not set)
 		// Detect prefix from the imported issues (bd-8an fix)
 		initCtx := rootCtx
@@ -770,10 +838,12 @@ func detectPrefixFromIssues(issues []*types.Issue) string {
 
 func init() {
 	importCmd.Flags().StringP("input", "i", "", "Input file (default: stdin)")
+	importCmd.Flags().String("format", "jsonl", "Input format: jsonl, markdown (TODO-style headings and checkboxes)")
 	importCmd.Flags().BoolP("skip-existing", "s", false, "Skip existing issues instead of updating them")
 	importCmd.Flags().Bool("strict", false, "Fail on dependency errors instead of treating them as warnings")
 	importCmd.Flags().Bool("dedupe-after", false, "Detect and report content duplicates after import")
 	importCmd.Flags().Bool("dry-run", false, "Preview collision detection without making changes")
+	importCmd.Flags().Bool("config", false, "Restore project config from an export made with --include-config")
 	importCmd.Flags().Bool("rename-on-import", false, "Rename imported issues to match database prefix (updates all references)")
 	importCmd.Flags().Bool("clear-duplicate-external-refs", false, "Clear duplicate external_ref values (keeps first occurrence)")
 	importCmd.Flags().String("orphan-handling", "", "How to handle missing parent issues: strict/resurrect/skip/allow (default: use config or 'allow')")
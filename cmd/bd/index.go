@@ -0,0 +1,189 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/embeddings"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+var indexCmd = &cobra.Command{
+	Use:   "index",
+	Short: "Compute embeddings for semantic search",
+	Long: `Compute vector embeddings for issues so 'bd search --semantic' can find
+issues by meaning rather than exact words.
+
+Vectors are stored in a local sidecar table and never exported to JSONL.
+Indexing is incremental: only issues whose text changed since the last run
+are re-embedded.
+
+Providers:
+  local    Offline hashed n-gram vectors (default, no network or API key)
+  openai   OpenAI-compatible /embeddings API (OPENAI_API_KEY; set
+           embeddings.base_url to use a self-hosted server such as Ollama)
+
+Defaults come from config.yaml (embeddings.provider, embeddings.model,
+embeddings.base_url); flags override them.
+
+--attachments instead extracts the text of attachments (markdown, plain text
+and text-based PDFs) so 'bd search' matches it. The daemon does this in the
+background; attachments over attachments.index_max_mb are skipped.
+
+--search rebuilds the full-text index behind 'bd search'. It is kept current
+automatically, so this is only needed if results look stale.
+
+Examples:
+  bd index
+  bd index --provider openai
+  bd index --provider openai --model text-embedding-3-large --rebuild
+  bd index --clear
+  bd index --attachments --rebuild
+  bd index --search`,
+	Run: func(cmd *cobra.Command, args []string) {
+		rebuild, _ := cmd.Flags().GetBool("rebuild")
+		clearAll, _ := cmd.Flags().GetBool("clear")
+
+		if err := ensureDirectMode("index requires direct database access"); err != nil {
+			FatalError("%v", err)
+		}
+		sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+		if !ok {
+			FatalError("index requires SQLite storage")
+		}
+		ctx := rootCtx
+
+		if searchIndex, _ := cmd.Flags().GetBool("search"); searchIndex {
+			if err := sqliteStore.RebuildSearchIndex(ctx); err != nil {
+				FatalError("%v", err)
+			}
+			if jsonOutput {
+				outputJSON(map[string]interface{}{"rebuilt": "search"})
+				return
+			}
+			fmt.Println("Rebuilt the full-text search index")
+			return
+		}
+
+		if indexAttachments, _ := cmd.Flags().GetBool("attachments"); indexAttachments {
+			runIndexAttachments(sqliteStore, rebuild)
+			return
+		}
+
+		if clearAll {
+			n, err := sqliteStore.DeleteEmbeddings(ctx, "", "")
+			if err != nil {
+				FatalError("%v", err)
+			}
+			if jsonOutput {
+				outputJSON(map[string]interface{}{"deleted": n})
+				return
+			}
+			fmt.Printf("Deleted %d embeddings\n", n)
+			return
+		}
+
+		provider := embeddingsProviderFromFlags(cmd)
+
+		issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+		if err != nil {
+			FatalError("%v", err)
+		}
+		ids := make([]string, len(issues))
+		for i, issue := range issues {
+			ids[i] = issue.ID
+		}
+		labelsMap, err := store.GetLabelsForIssues(ctx, ids)
+		if err != nil {
+			FatalError("failed to get labels: %v", err)
+		}
+
+		existing := map[string]string{}
+		if !rebuild {
+			existing, err = sqliteStore.GetEmbeddingHashes(ctx, provider.Name(), provider.Model())
+			if err != nil {
+				FatalError("%v", err)
+			}
+		}
+
+		var pending []*sqlite.IssueEmbedding
+		var texts []string
+		for _, issue := range issues {
+			issue.Labels = labelsMap[issue.ID]
+			text := embeddings.IssueText(issue)
+			hash := embeddings.TextHash(text)
+			if existing[issue.ID] == hash {
+				continue
+			}
+			pending = append(pending, &sqlite.IssueEmbedding{
+				IssueID:     issue.ID,
+				Provider:    provider.Name(),
+				Model:       provider.Model(),
+				ContentHash: hash,
+			})
+			texts = append(texts, text)
+		}
+
+		if len(pending) > 0 {
+			vectors, err := provider.Embed(ctx, texts)
+			if err != nil {
+				FatalError("embedding failed: %v", err)
+			}
+			for i, vec := range vectors {
+				pending[i].Vector = vec
+			}
+			if err := sqliteStore.UpsertEmbeddings(ctx, pending); err != nil {
+				FatalError("%v", err)
+			}
+		}
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{
+				"provider":  provider.Name(),
+				"model":     provider.Model(),
+				"indexed":   len(pending),
+				"unchanged": len(issues) - len(pending),
+			})
+			return
+		}
+		fmt.Printf("Indexed %d issues with %s/%s (%d unchanged)\n",
+			len(pending), provider.Name(), provider.Model(), len(issues)-len(pending))
+	},
+}
+
+// embeddingsProviderFromFlags resolves the provider from --provider/--model,
+// falling back to the embeddings.* config keys
+func embeddingsProviderFromFlags(cmd *cobra.Command) embeddings.Provider {
+	name := config.GetString("embeddings.provider")
+	if cmd.Flags().Changed("provider") {
+		name, _ = cmd.Flags().GetString("provider")
+	}
+	if name == "" {
+		name = "local"
+	}
+	model := config.GetString("embeddings.model")
+	if cmd.Flags().Changed("model") {
+		model, _ = cmd.Flags().GetString("model")
+	}
+
+	provider, err := embeddings.New(name, embeddings.Options{
+		Model:   model,
+		BaseURL: config.GetString("embeddings.base_url"),
+	})
+	if err != nil {
+		FatalError("%v", err)
+	}
+	return provider
+}
+
+func init() {
+	indexCmd.Flags().String("provider", "", "Embeddings provider: local, openai (default from embeddings.provider)")
+	indexCmd.Flags().String("model", "", "Provider model (default from embeddings.model or the provider's default)")
+	indexCmd.Flags().Bool("rebuild", false, "Re-embed every issue, even if unchanged")
+	indexCmd.Flags().Bool("clear", false, "Delete all stored embeddings")
+	indexCmd.Flags().Bool("attachments", false, "Extract attachment text for bd search instead of embedding issues")
+	indexCmd.Flags().Bool("search", false, "Rebuild the full-text search index instead of embedding issues")
+	rootCmd.AddCommand(indexCmd)
+}
@@ -18,9 +18,12 @@ import (
 	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/configfile"
 	"github.com/steveyegge/beads/internal/git"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/postgres"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/syncbranch"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/util"
 	"github.com/steveyegge/beads/internal/utils"
 )
 
@@ -35,7 +38,13 @@ With --no-db: creates .beads/ directory and issues.jsonl file instead of SQLite
 With --stealth: configures global git settings for invisible beads usage:
   • Global gitignore to prevent beads files from being committed
   • Claude Code settings with bd onboard instruction
-  Perfect for personal use without affecting repo collaborators.`,
+  Perfect for personal use without affecting repo collaborators.
+
+With --template: preloads a workflow's statuses, routing and labelled sample issues:
+  kanban       backlog/review statuses, class-of-service labels
+  scrum        backlog/review statuses, a sprint epic with stories and ceremonies
+  agent-swarm  review status, required close reasons, a swarm epic with
+               blocker-ordered agent tasks and needs-human gates`,
 	Run: func(cmd *cobra.Command, _ []string) {
 		prefix, _ := cmd.Flags().GetString("prefix")
 		quiet, _ := cmd.Flags().GetBool("quiet")
@@ -44,8 +53,58 @@ With --stealth: configures global git settings for invisible beads usage:
 		team, _ := cmd.Flags().GetBool("team")
 		stealth, _ := cmd.Flags().GetBool("stealth")
 		skipMergeDriver, _ := cmd.Flags().GetBool("skip-merge-driver")
+		gitMerge, _ := cmd.Flags().GetBool("git-merge")
 		skipHooks, _ := cmd.Flags().GetBool("skip-hooks")
+		installAllHooks, _ := cmd.Flags().GetBool("install-git-hooks")
 		force, _ := cmd.Flags().GetBool("force")
+		templateName, _ := cmd.Flags().GetString("template")
+		backend, _ := cmd.Flags().GetString("backend")
+		dsn, _ := cmd.Flags().GetString("dsn")
+
+		// Reject unknown backends up front rather than silently creating a
+		// SQLite database the user didn't ask for
+		switch backend {
+		case configfile.BackendSQLite:
+			if dsn != "" {
+				fmt.Fprintf(os.Stderr, "Error: --dsn requires --backend=%s\n", configfile.BackendPostgres)
+				os.Exit(1)
+			}
+		case configfile.BackendPostgres:
+			if noDb {
+				fmt.Fprintf(os.Stderr, "Error: --backend=%s is not supported with --no-db\n", backend)
+				os.Exit(1)
+			}
+			if dbPath != "" || os.Getenv("BEADS_DB") != "" {
+				fmt.Fprintf(os.Stderr, "Error: --backend=%s keeps issues on the server; don't combine it with --db or BEADS_DB\n", backend)
+				os.Exit(1)
+			}
+			if dsn == "" && os.Getenv(configfile.PostgresDSNEnvVar) == "" {
+				fmt.Fprintf(os.Stderr, "Error: --backend=%s needs --dsn or %s\n", backend, configfile.PostgresDSNEnvVar)
+				os.Exit(1)
+			}
+			// --dsn is saved in metadata.json, which is committed to git
+			if configfile.DSNHasPassword(dsn) {
+				FatalErrorWithHint("--dsn must not contain a password: it is saved in .beads/metadata.json, which is committed",
+					fmt.Sprintf("pass credentials in %s (or ~/.pgpass) and give --dsn without the password", configfile.PostgresDSNEnvVar))
+			}
+		default:
+			fmt.Fprintf(os.Stderr, "Error: storage backend %q is not supported (use %s or %s)\n", backend, configfile.BackendSQLite, configfile.BackendPostgres)
+			os.Exit(1)
+		}
+
+		// Validate the workflow template before touching anything on disk
+		var workflow *workflowTemplate
+		if templateName != "" {
+			if noDb {
+				fmt.Fprintf(os.Stderr, "Error: --template is not supported with --no-db\n")
+				os.Exit(1)
+			}
+			var err error
+			if workflow, err = lookupWorkflowTemplate(templateName); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
 
 		// Initialize config (PersistentPreRun doesn't run for init command)
 		if err := config.Initialize(); err != nil {
@@ -53,9 +112,30 @@ With --stealth: configures global git settings for invisible beads usage:
 			// Non-fatal - continue with defaults
 		}
 
+		if gitMerge && skipMergeDriver {
+			fmt.Fprintf(os.Stderr, "Error: cannot use both --git-merge and --skip-merge-driver\n")
+			os.Exit(1)
+		}
+		if gitMerge && !isGitRepo() {
+			fmt.Fprintf(os.Stderr, "Error: --git-merge requires a git repository\n")
+			os.Exit(1)
+		}
+
 		// Safety guard: check for existing JSONL with issues (bd-emg)
 		// This prevents accidental re-initialization in fresh clones
 		if !force {
+			// In an initialized workspace --git-merge only switches the merge driver
+			if gitMerge && checkExistingBeadsData(prefix) != nil {
+				if err := installStructuredMergeDriver(); err != nil {
+					fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+					os.Exit(1)
+				}
+				if !quiet {
+					green := color.New(color.FgGreen).SprintFunc()
+					fmt.Printf("%s Installed the field-level merge driver (%s)\n", green("✓"), structuredMergeDriver)
+				}
+				return
+			}
 			if err := checkExistingBeadsData(prefix); err != nil {
 				fmt.Fprintf(os.Stderr, "%v\n", err)
 				os.Exit(1)
@@ -243,7 +323,17 @@ With --stealth: configures global git settings for invisible beads usage:
 		}
 
 		ctx := rootCtx
-		store, err := sqlite.New(ctx, initDBPath)
+		var store storage.Storage
+		if backend == configfile.BackendPostgres {
+			// An explicit --dsn wins over BEADS_POSTGRES_DSN here; afterwards
+			// the variable overrides what is saved in metadata.json
+			if dsn == "" {
+				dsn = os.Getenv(configfile.PostgresDSNEnvVar)
+			}
+			store, err = postgres.New(ctx, dsn)
+		} else {
+			store, err = sqlite.New(ctx, initDBPath)
+		}
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: failed to create database: %v\n", err)
 			os.Exit(1)
@@ -345,6 +435,16 @@ With --stealth: configures global git settings for invisible beads usage:
 					}
 				}
 			}
+			if backend == configfile.BackendPostgres {
+				cfg.Backend = backend
+				// A DSN taken from the environment stays there; --dsn was
+				// checked above not to carry a password
+				if flagDSN, _ := cmd.Flags().GetString("dsn"); flagDSN != "" {
+					cfg.PostgresDSN = flagDSN
+				}
+			} else {
+				cfg.Backend, cfg.PostgresDSN = "", ""
+			}
 			if err := cfg.Save(beadsDir); err != nil {
 				fmt.Fprintf(os.Stderr, "Warning: failed to create metadata.json: %v\n", err)
 				// Non-fatal - continue anyway
@@ -365,7 +465,13 @@ With --stealth: configures global git settings for invisible beads usage:
 
 		// Check if git has existing issues to import (fresh clone scenario)
 		issueCount, jsonlPath, gitRef := checkGitForIssues()
-		if issueCount > 0 {
+		if issueCount > 0 && backend == configfile.BackendPostgres {
+			// JSONL import and export are SQLite features; a shared server is
+			// loaded once, by whoever sets it up
+			if !quiet {
+				fmt.Fprintf(os.Stderr, "\nNote: found %d issues in git; they are not imported into PostgreSQL automatically.\n", issueCount)
+			}
+		} else if issueCount > 0 {
 			if !quiet {
 				fmt.Fprintf(os.Stderr, "\n✓ Database initialized. Found %d issues in git, importing...\n", issueCount)
 			}
@@ -399,13 +505,35 @@ With --stealth: configures global git settings for invisible beads usage:
 			}
 		}
 
+		// Apply the workflow template. Sample issues are skipped when issues
+		// were imported from git so an existing project isn't cluttered.
+		if workflow != nil {
+			if err := applyInitTemplate(ctx, store, workflow, beadsDir, initDBPath, issueCount == 0, quiet); err != nil {
+				fmt.Fprintf(os.Stderr, "Error applying %s template: %v\n", workflow.Name, err)
+				_ = store.Close()
+				os.Exit(1)
+			}
+		}
+
 		if err := store.Close(); err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: failed to close database: %v\n", err)
 		}
 
-		// Check if we're in a git repo and hooks aren't installed
-		// Install by default unless --skip-hooks is passed
-		if !skipHooks && isGitRepo() && !hooksInstalled() {
+		// --install-git-hooks installs the full versioned hook set, including
+		// the stale-export check and prepare-commit-msg issue refs
+		if installAllHooks && !skipHooks && isGitRepo() {
+			embeddedHooks, err := getEmbeddedHooks()
+			if err == nil {
+				err = installHooks(embeddedHooks, false, false)
+			}
+			if err != nil && !quiet {
+				yellow := color.New(color.FgYellow).SprintFunc()
+				fmt.Fprintf(os.Stderr, "\n%s Failed to install git hooks: %v\n", yellow("⚠"), err)
+				fmt.Fprintf(os.Stderr, "You can try again with: %s\n\n", color.New(color.FgCyan).Sprint("bd hooks install"))
+			}
+		} else if !skipHooks && isGitRepo() && !hooksInstalled() {
+			// Check if we're in a git repo and hooks aren't installed
+			// Install by default unless --skip-hooks is passed
 			if err := installGitHooks(); err != nil && !quiet {
 				yellow := color.New(color.FgYellow).SprintFunc()
 				fmt.Fprintf(os.Stderr, "\n%s Failed to install git hooks: %v\n", yellow("⚠"), err)
@@ -415,7 +543,13 @@ With --stealth: configures global git settings for invisible beads usage:
 
 		// Check if we're in a git repo and merge driver isn't configured
 		// Install by default unless --skip-merge-driver is passed
-		if !skipMergeDriver && isGitRepo() && !mergeDriverInstalled() {
+		if gitMerge {
+			if err := installStructuredMergeDriver(); err != nil && !quiet {
+				yellow := color.New(color.FgYellow).SprintFunc()
+				fmt.Fprintf(os.Stderr, "\n%s Failed to install merge driver: %v\n", yellow("⚠"), err)
+				fmt.Fprintf(os.Stderr, "You can try again with: %s\n\n", color.New(color.FgCyan).Sprint("bd init --git-merge"))
+			}
+		} else if !skipMergeDriver && isGitRepo() && !mergeDriverInstalled() {
 			if err := installMergeDriver(); err != nil && !quiet {
 				yellow := color.New(color.FgYellow).SprintFunc()
 				fmt.Fprintf(os.Stderr, "\n%s Failed to install merge driver: %v\n", yellow("⚠"), err)
@@ -438,7 +572,11 @@ With --stealth: configures global git settings for invisible beads usage:
 		cyan := color.New(color.FgCyan).SprintFunc()
 
 		fmt.Printf("\n%s bd initialized successfully!\n\n", green("✓"))
-		fmt.Printf("  Database: %s\n", cyan(initDBPath))
+		if backend == configfile.BackendPostgres {
+			fmt.Printf("  Database: %s\n", cyan(store.Path()))
+		} else {
+			fmt.Printf("  Database: %s\n", cyan(initDBPath))
+		}
 		fmt.Printf("  Issue prefix: %s\n", cyan(prefix))
 		fmt.Printf("  Issues will be named: %s\n\n", cyan(prefix+"-1, "+prefix+"-2, ..."))
 		fmt.Printf("Run %s to get started.\n\n", cyan("bd quickstart"))
@@ -475,7 +613,12 @@ func init() {
 	initCmd.Flags().Bool("team", false, "Run team workflow setup wizard")
 	initCmd.Flags().Bool("stealth", false, "Enable stealth mode: global gitattributes and gitignore, no local repo tracking")
 	initCmd.Flags().Bool("skip-hooks", false, "Skip git hooks installation")
+	initCmd.Flags().Bool("install-git-hooks", false, "Install all bd git hooks, including the stale-export pre-commit check and prepare-commit-msg issue refs")
 	initCmd.Flags().Bool("skip-merge-driver", false, "Skip git merge driver setup")
+	initCmd.Flags().Bool("git-merge", false, "Use the field-level merge driver (bd merge-driver) for the JSONL; in an initialized workspace, only install it")
+	initCmd.Flags().String("template", "", "Preload a workflow: kanban, scrum or agent-swarm")
+	initCmd.Flags().String("backend", "sqlite", "Storage backend: sqlite (a database file per clone) or postgres (one shared server)")
+	initCmd.Flags().String("dsn", "", "PostgreSQL connection string for --backend=postgres (default: $BEADS_POSTGRES_DSN)")
 	initCmd.Flags().Bool("force", false, "Force re-initialization even if JSONL already has issues (may cause data loss)")
 	rootCmd.AddCommand(initCmd)
 }
@@ -992,13 +1135,23 @@ func mergeDriverInstalled() bool {
 
 // installMergeDriver configures git to use bd merge for JSONL files
 func installMergeDriver() error {
+	return configureMergeDriver("bd merge %A %O %A %B", "bd JSONL merge driver")
+}
+
+// installStructuredMergeDriver configures git to use bd merge-driver, which
+// merges JSONL issues field by field (bd init --git-merge)
+func installStructuredMergeDriver() error {
+	return configureMergeDriver(structuredMergeDriver, "bd field-level JSONL merge driver")
+}
+
+func configureMergeDriver(driver, name string) error {
 	// Configure git merge driver
-	cmd := exec.Command("git", "config", "merge.beads.driver", "bd merge %A %O %A %B")
+	cmd := exec.Command("git", "config", "merge.beads.driver", driver) // #nosec G204 -- driver is one of the fixed commands above
 	if output, err := cmd.CombinedOutput(); err != nil {
 		return fmt.Errorf("failed to configure git merge driver: %w\n%s", err, output)
 	}
 
-	cmd = exec.Command("git", "config", "merge.beads.name", "bd JSONL merge driver")
+	cmd = exec.Command("git", "config", "merge.beads.name", name) // #nosec G204 -- fixed name
 	if output, err := cmd.CombinedOutput(); err != nil {
 		// Non-fatal, the name is just descriptive
 		fmt.Fprintf(os.Stderr, "Warning: failed to set merge driver name: %v\n%s", err, output)
@@ -1295,7 +1448,7 @@ func readFirstIssueFromJSONL(path string) (*types.Issue, error) {
 	}
 	defer file.Close()
 
-	scanner := bufio.NewScanner(file)
+	scanner := util.NewJSONLScanner(file)
 	lineNum := 0
 	for scanner.Scan() {
 		lineNum++
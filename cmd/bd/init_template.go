@@ -0,0 +1,428 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// workflowTemplate describes what 'bd init --template' preloads for a workflow:
+// custom statuses and other database config, routing settings for config.yaml,
+// and a handful of sample issues that introduce the workflow's labels
+type workflowTemplate struct {
+	Name        string
+	Description string
+	Config      map[string]string // database config (bd config set)
+	Routing     map[string]string // routing.* keys written to config.yaml
+	Issues      []workflowIssue
+}
+
+// workflowIssue is a sample issue. Parent and BlockedBy refer to other
+// samples by Key, since IDs are only known after creation.
+type workflowIssue struct {
+	Key         string
+	Title       string
+	Description string
+	Type        types.IssueType
+	Priority    int
+	Status      types.Status
+	Assignee    string
+	Labels      []string
+	Parent      string
+	BlockedBy   []string
+}
+
+// sharedRouting keeps every issue in the project repository: team workflows
+// want one board, not contributor issues diverted to ~/.beads-planning
+var sharedRouting = map[string]string{
+	"mode":    "explicit",
+	"default": ".",
+}
+
+var workflowTemplates = map[string]*workflowTemplate{
+	"kanban": {
+		Name:        "kanban",
+		Description: "Continuous flow with a backlog, WIP and review columns",
+		Config: map[string]string{
+			"status.custom": "backlog,review",
+		},
+		Routing: sharedRouting,
+		Issues: []workflowIssue{
+			{
+				Key:         "wip",
+				Title:       "Agree on WIP limits for in_progress and review",
+				Description: "Kanban works by limiting work in progress. Pick a limit per column and check it with 'bd list --status in_progress'.",
+				Type:        types.TypeChore,
+				Priority:    1,
+				Labels:      []string{"class:standard"},
+			},
+			{
+				Key:         "expedite",
+				Title:       "Example: production outage (expedite)",
+				Description: "Expedite items jump the queue and may exceed WIP limits. Close this sample once the team knows the convention.",
+				Type:        types.TypeBug,
+				Priority:    0,
+				Labels:      []string{"class:expedite"},
+			},
+			{
+				Key:         "review",
+				Title:       "Example: change waiting for review",
+				Description: "Move work here with 'bd update <id> --status review' when it is ready for a second pair of eyes.",
+				Type:        types.TypeTask,
+				Priority:    2,
+				Status:      "review",
+				Labels:      []string{"class:standard"},
+			},
+			{
+				Key:         "backlog",
+				Title:       "Example: idea parked in the backlog",
+				Description: "Backlog items are not ready to pull yet. Promote them with 'bd update <id> --status open'.",
+				Type:        types.TypeFeature,
+				Priority:    3,
+				Status:      "backlog",
+				Labels:      []string{"class:intangible"},
+			},
+		},
+	},
+	"scrum": {
+		Name:        "scrum",
+		Description: "Time-boxed sprints with a product backlog and sprint review",
+		Config: map[string]string{
+			"status.custom": "backlog,review",
+		},
+		Routing: sharedRouting,
+		Issues: []workflowIssue{
+			{
+				Key:         "sprint",
+				Title:       "Sprint 1",
+				Description: "Sprint goal: replace with what the team commits to this sprint. Stories are children of the sprint epic.",
+				Type:        types.TypeEpic,
+				Priority:    1,
+				Labels:      []string{"sprint:1"},
+			},
+			{
+				Key:         "planning",
+				Title:       "Sprint 1 planning",
+				Description: "Pull stories from the backlog into the sprint and estimate them with points:N labels.",
+				Type:        types.TypeChore,
+				Priority:    1,
+				Labels:      []string{"sprint:1", "ceremony"},
+				Parent:      "sprint",
+			},
+			{
+				Key:         "story",
+				Title:       "Example story: as a user I can sign in",
+				Description: "Stories carry acceptance criteria and a points:N estimate.",
+				Type:        types.TypeFeature,
+				Priority:    2,
+				Labels:      []string{"sprint:1", "points:3"},
+				Parent:      "sprint",
+				BlockedBy:   []string{"planning"},
+			},
+			{
+				Key:         "retro",
+				Title:       "Sprint 1 review and retrospective",
+				Description: "Demo finished stories, then decide what to change for the next sprint.",
+				Type:        types.TypeChore,
+				Priority:    2,
+				Labels:      []string{"sprint:1", "ceremony"},
+				Parent:      "sprint",
+				BlockedBy:   []string{"story"},
+			},
+			{
+				Key:         "backlog",
+				Title:       "Example: product backlog item",
+				Description: "Unscheduled work waits in the backlog until a planning session pulls it into a sprint.",
+				Type:        types.TypeFeature,
+				Priority:    3,
+				Status:      "backlog",
+				Labels:      []string{"points:5"},
+			},
+		},
+	},
+	"agent-swarm": {
+		Name:        "agent-swarm",
+		Description: "Many coding agents pulling ready work, with human review gates",
+		Config: map[string]string{
+			"status.custom":        "review",
+			"close.require_reason": "true",
+		},
+		Routing: sharedRouting,
+		Issues: []workflowIssue{
+			{
+				Key:         "goal",
+				Title:       "Swarm goal: replace with the outcome you want",
+				Description: "Agents pick up the children of this epic with 'bd ready --claim'. Break the goal into small tasks with explicit blockers so agents can work in parallel.",
+				Type:        types.TypeEpic,
+				Priority:    1,
+				Labels:      []string{"swarm"},
+			},
+			{
+				Key:         "spec",
+				Title:       "Write the spec agents will implement against",
+				Description: "A human-owned task: agents should not start until the spec is closed.",
+				Type:        types.TypeTask,
+				Priority:    1,
+				Labels:      []string{"swarm", "needs-human"},
+				Parent:      "goal",
+			},
+			{
+				Key:         "impl-a",
+				Title:       "Example: implement part A",
+				Description: "Agent task. On completion move to review with 'bd update <id> --status review' instead of closing.",
+				Type:        types.TypeTask,
+				Priority:    2,
+				Labels:      []string{"swarm", "agent"},
+				Parent:      "goal",
+				BlockedBy:   []string{"spec"},
+			},
+			{
+				Key:         "impl-b",
+				Title:       "Example: implement part B",
+				Description: "Independent of part A, so a second agent can claim it at the same time.",
+				Type:        types.TypeTask,
+				Priority:    2,
+				Labels:      []string{"swarm", "agent"},
+				Parent:      "goal",
+				BlockedBy:   []string{"spec"},
+			},
+			{
+				Key:         "integrate",
+				Title:       "Integrate and verify parts A and B",
+				Description: "Runs after both agent tasks; a human closes the epic once this passes.",
+				Type:        types.TypeTask,
+				Priority:    2,
+				Labels:      []string{"swarm", "needs-human"},
+				Parent:      "goal",
+				BlockedBy:   []string{"impl-a", "impl-b"},
+			},
+		},
+	},
+}
+
+// workflowTemplateNames returns the available template names, sorted
+func workflowTemplateNames() []string {
+	names := make([]string, 0, len(workflowTemplates))
+	for name := range workflowTemplates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lookupWorkflowTemplate returns the named template or an error listing the
+// valid names
+func lookupWorkflowTemplate(name string) (*workflowTemplate, error) {
+	tmpl, ok := workflowTemplates[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown template %q (available: %s)", name, strings.Join(workflowTemplateNames(), ", "))
+	}
+	return tmpl, nil
+}
+
+// Labels returns every label used by the template's sample issues, sorted
+func (t *workflowTemplate) Labels() []string {
+	seen := make(map[string]bool)
+	var labels []string
+	for _, issue := range t.Issues {
+		for _, label := range issue.Labels {
+			if !seen[label] {
+				seen[label] = true
+				labels = append(labels, label)
+			}
+		}
+	}
+	sort.Strings(labels)
+	return labels
+}
+
+// applyWorkflowTemplate writes the template's config and, when withIssues is
+// set, creates its sample issues. The created issues are returned with labels
+// and dependencies populated so the caller can export them.
+func applyWorkflowTemplate(ctx context.Context, s storage.Storage, tmpl *workflowTemplate, withIssues bool, actorName string) ([]*types.Issue, error) {
+	keys := make([]string, 0, len(tmpl.Config))
+	for key := range tmpl.Config {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	// Statuses must be configured before any sample issue uses them
+	for _, key := range keys {
+		if err := s.SetConfig(ctx, key, tmpl.Config[key]); err != nil {
+			return nil, fmt.Errorf("failed to set %s: %w", key, err)
+		}
+	}
+	if !withIssues {
+		return nil, nil
+	}
+
+	created := make([]*types.Issue, 0, len(tmpl.Issues))
+	err := s.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		created = created[:0]
+		byKey := make(map[string]*types.Issue, len(tmpl.Issues))
+		for _, sample := range tmpl.Issues {
+			status := sample.Status
+			if status == "" {
+				status = types.StatusOpen
+			}
+			issue := &types.Issue{
+				Title:       sample.Title,
+				Description: sample.Description,
+				Status:      status,
+				Priority:    sample.Priority,
+				IssueType:   sample.Type,
+				Assignee:    sample.Assignee,
+			}
+			if status == types.StatusClosed {
+				now := time.Now()
+				issue.ClosedAt = &now
+			}
+			if err := tx.CreateIssue(ctx, issue, actorName); err != nil {
+				return fmt.Errorf("failed to create sample issue %q: %w", sample.Title, err)
+			}
+			for _, label := range sample.Labels {
+				if err := tx.AddLabel(ctx, issue.ID, label, actorName); err != nil {
+					return fmt.Errorf("failed to label %s: %w", issue.ID, err)
+				}
+			}
+			byKey[sample.Key] = issue
+			created = append(created, issue)
+		}
+
+		for i, sample := range tmpl.Issues {
+			issue := created[i]
+			var deps []*types.Dependency
+			if sample.Parent != "" {
+				deps = append(deps, &types.Dependency{IssueID: issue.ID, DependsOnID: byKey[sample.Parent].ID, Type: types.DepParentChild})
+			}
+			for _, blocker := range sample.BlockedBy {
+				deps = append(deps, &types.Dependency{IssueID: issue.ID, DependsOnID: byKey[blocker].ID, Type: types.DepBlocks})
+			}
+			for _, dep := range deps {
+				if err := tx.AddDependency(ctx, dep, actorName); err != nil {
+					return fmt.Errorf("failed to link %s to %s: %w", dep.IssueID, dep.DependsOnID, err)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Re-read so exported issues match what a later export would write
+	for i, issue := range created {
+		stored, err := s.GetIssue(ctx, issue.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read back %s: %w", issue.ID, err)
+		}
+		if stored.Labels, err = s.GetLabels(ctx, issue.ID); err != nil {
+			return nil, fmt.Errorf("failed to read labels of %s: %w", issue.ID, err)
+		}
+		deps, err := s.GetDependencyRecords(ctx, issue.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read dependencies of %s: %w", issue.ID, err)
+		}
+		stored.Dependencies = deps
+		created[i] = stored
+	}
+	return created, nil
+}
+
+// applyInitTemplate applies a workflow template during 'bd init' and writes
+// the sample issues straight to JSONL so they are ready to commit
+func applyInitTemplate(ctx context.Context, s storage.Storage, tmpl *workflowTemplate, beadsDir, dbFile string, withIssues, quiet bool) error {
+	created, err := applyWorkflowTemplate(ctx, s, tmpl, withIssues, actor)
+	if err != nil {
+		return err
+	}
+
+	if len(created) > 0 {
+		jsonlPath := beads.FindJSONLPath(dbFile)
+		exported, err := writeJSONLAtomic(jsonlPath, created)
+		if err != nil {
+			return fmt.Errorf("failed to export sample issues: %w", err)
+		}
+		if err := s.ClearDirtyIssuesByID(ctx, exported); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to clear dirty flags: %v\n", err)
+		}
+	}
+
+	routingWritten, err := appendRoutingConfig(beadsDir, tmpl)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to write routing to config.yaml: %v\n", err)
+	}
+
+	if quiet {
+		return nil
+	}
+	green := color.New(color.FgGreen).SprintFunc()
+	fmt.Printf("  Template: %s (%s)\n", tmpl.Name, tmpl.Description)
+	if statuses := tmpl.Config["status.custom"]; statuses != "" {
+		fmt.Printf("    %s Custom statuses: %s\n", green("✓"), statuses)
+	}
+	if routingWritten {
+		fmt.Printf("    %s Routing: all issues stay in this repository\n", green("✓"))
+	}
+	if len(created) > 0 {
+		fmt.Printf("    %s Created %d sample issues\n", green("✓"), len(created))
+		fmt.Printf("    %s Labels: %s\n", green("✓"), strings.Join(tmpl.Labels(), ", "))
+	} else if !withIssues {
+		fmt.Printf("    Skipped sample issues (project already has issues)\n")
+	}
+	return nil
+}
+
+// appendRoutingConfig adds the template's routing settings to config.yaml.
+// An existing top-level routing block is left alone rather than duplicated.
+func appendRoutingConfig(beadsDir string, tmpl *workflowTemplate) (bool, error) {
+	if len(tmpl.Routing) == 0 {
+		return false, nil
+	}
+	configYamlPath := filepath.Join(beadsDir, "config.yaml")
+	// #nosec G304 - path is inside the .beads directory
+	existing, err := os.ReadFile(configYamlPath)
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+	for _, line := range strings.Split(string(existing), "\n") {
+		if strings.HasPrefix(line, "routing:") {
+			return false, nil
+		}
+	}
+
+	keys := make([]string, 0, len(tmpl.Routing))
+	for key := range tmpl.Routing {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	if len(existing) > 0 && !strings.HasSuffix(string(existing), "\n") {
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "\n# Routing set by 'bd init --template %s'\n", tmpl.Name)
+	b.WriteString("routing:\n")
+	for _, key := range keys {
+		fmt.Fprintf(&b, "  %s: %q\n", key, tmpl.Routing[key])
+	}
+
+	// #nosec G302 - config.yaml is committed and shared like the JSONL
+	f, err := os.OpenFile(configYamlPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return false, err
+	}
+	if _, err := f.WriteString(b.String()); err != nil {
+		_ = f.Close()
+		return false, err
+	}
+	return true, f.Close()
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestApplyWorkflowTemplates(t *testing.T) {
+	for _, name := range workflowTemplateNames() {
+		t.Run(name, func(t *testing.T) {
+			tmpl, err := lookupWorkflowTemplate(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctx := context.Background()
+			s := newTestStore(t, filepath.Join(t.TempDir(), ".beads", "beads.db"))
+
+			created, err := applyWorkflowTemplate(ctx, s, tmpl, true, "test")
+			if err != nil {
+				t.Fatalf("applyWorkflowTemplate: %v", err)
+			}
+			if len(created) != len(tmpl.Issues) {
+				t.Fatalf("created %d issues, want %d", len(created), len(tmpl.Issues))
+			}
+
+			for key, want := range tmpl.Config {
+				got, err := s.GetConfig(ctx, key)
+				if err != nil || got != want {
+					t.Errorf("config %s = %q (%v), want %q", key, got, err, want)
+				}
+			}
+
+			for i, sample := range tmpl.Issues {
+				issue := created[i]
+				want := append([]string(nil), sample.Labels...)
+				sort.Strings(want)
+				if strings.Join(issue.Labels, ",") != strings.Join(want, ",") {
+					t.Errorf("%s labels = %v, want %v", issue.ID, issue.Labels, want)
+				}
+				wantDeps := len(sample.BlockedBy)
+				if sample.Parent != "" {
+					wantDeps++
+				}
+				if len(issue.Dependencies) != wantDeps {
+					t.Errorf("%s has %d dependencies, want %d", issue.ID, len(issue.Dependencies), wantDeps)
+				}
+				if sample.Status != "" && issue.Status != sample.Status {
+					t.Errorf("%s status = %s, want %s", issue.ID, issue.Status, sample.Status)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyWorkflowTemplateWithoutIssues(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t, filepath.Join(t.TempDir(), ".beads", "beads.db"))
+	tmpl, _ := lookupWorkflowTemplate("agent-swarm")
+
+	created, err := applyWorkflowTemplate(ctx, s, tmpl, false, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(created) != 0 {
+		t.Errorf("created %d issues, want none", len(created))
+	}
+	issues, err := s.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("database has %d issues, want none", len(issues))
+	}
+	if got, _ := s.GetConfig(ctx, "close.require_reason"); got != "true" {
+		t.Errorf("close.require_reason = %q, want true", got)
+	}
+}
+
+func TestLookupWorkflowTemplateUnknown(t *testing.T) {
+	_, err := lookupWorkflowTemplate("waterfall")
+	if err == nil || !strings.Contains(err.Error(), "kanban") {
+		t.Errorf("expected error listing available templates, got %v", err)
+	}
+}
+
+func TestAppendRoutingConfig(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte("# no-db: false"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tmpl, _ := lookupWorkflowTemplate("kanban")
+
+	written, err := appendRoutingConfig(dir, tmpl)
+	if err != nil || !written {
+		t.Fatalf("appendRoutingConfig = %v, %v", written, err)
+	}
+	data, _ := os.ReadFile(configPath)
+	if !strings.Contains(string(data), "\nrouting:\n  default: \".\"\n  mode: \"explicit\"\n") {
+		t.Errorf("routing block missing:\n%s", data)
+	}
+
+	// A second run must not add a duplicate routing key
+	written, err = appendRoutingConfig(dir, tmpl)
+	if err != nil || written {
+		t.Fatalf("second appendRoutingConfig = %v, %v", written, err)
+	}
+	again, _ := os.ReadFile(configPath)
+	if string(again) != string(data) {
+		t.Errorf("config.yaml changed on second run:\n%s", again)
+	}
+}
@@ -259,6 +259,7 @@ func checkOrphanedDeps(ctx context.Context, store storage.Storage) ([]string, er
 	}
 
 	// Check both sides: dependencies where either issue_id or depends_on_id doesn't exist
+	// (a depends_on_id with a slash names an issue in a linked workspace)
 	rows, err := db.QueryContext(ctx, `
 		SELECT DISTINCT d.issue_id 
 		FROM dependencies d 
@@ -268,7 +269,7 @@ func checkOrphanedDeps(ctx context.Context, store storage.Storage) ([]string, er
 		SELECT DISTINCT d.depends_on_id 
 		FROM dependencies d 
 		LEFT JOIN issues i ON d.depends_on_id = i.id 
-		WHERE i.id IS NULL
+		WHERE i.id IS NULL AND instr(d.depends_on_id, '/') = 0
 	`)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check for orphaned dependencies: %w", err)
@@ -462,6 +463,51 @@ func computeDBHash(ctx context.Context, store storage.Storage) (string, error) {
 		issue.Comments = comments
 	}
 
+	// Populate attachments
+	for _, issue := range issues {
+		attachments, err := store.GetAttachments(ctx, issue.ID)
+		if err != nil {
+			return "", fmt.Errorf("failed to get attachments for %s: %w", issue.ID, err)
+		}
+		issue.Attachments = attachments
+	}
+
+	// Populate work log
+	for _, issue := range issues {
+		workLog, err := store.GetWorkLog(ctx, issue.ID)
+		if err != nil {
+			return "", fmt.Errorf("failed to get work log for %s: %w", issue.ID, err)
+		}
+		issue.WorkLog = workLog
+	}
+
+	// Populate aliases
+	issueIDs := make([]string, len(issues))
+	for i, issue := range issues {
+		issueIDs[i] = issue.ID
+	}
+	allAliases, err := store.GetAliasesForIssues(ctx, issueIDs)
+	if err != nil {
+		return "", fmt.Errorf("failed to get aliases: %w", err)
+	}
+	for _, issue := range issues {
+		issue.Aliases = allAliases[issue.ID]
+	}
+	allTranslations, err := store.GetTranslationsForIssues(ctx, issueIDs)
+	if err != nil {
+		return "", fmt.Errorf("failed to get translations: %w", err)
+	}
+	for _, issue := range issues {
+		issue.Translations = allTranslations[issue.ID]
+	}
+	allFields, err := store.GetFieldsForIssues(ctx, issueIDs)
+	if err != nil {
+		return "", fmt.Errorf("failed to get fields: %w", err)
+	}
+	for _, issue := range issues {
+		issue.Fields = allFields[issue.ID]
+	}
+
 	// Serialize to JSON and hash
 	var buf bytes.Buffer
 	encoder := json.NewEncoder(&buf)
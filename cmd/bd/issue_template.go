@@ -0,0 +1,190 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/util"
+	"gopkg.in/yaml.v3"
+)
+
+// issueTemplatesDir is the directory in .beads holding issue templates
+// (bd template add), one <name>.yaml each, so they are shared through git
+// along with the issues
+const issueTemplatesDir = "templates"
+
+// issueTemplateNamePattern allows the same names as custom fields
+var issueTemplateNamePattern = regexp.MustCompile(`^[a-z][a-z0-9]*([-_][a-z0-9]+)*$`)
+
+// CreateTemplate is an issue template: it presets a kind of issue for
+// bd create --template, with defaults for what the command line leaves out
+// and custom fields the issue must have
+type CreateTemplate struct {
+	Name               string            `yaml:"-" json:"name"`
+	TitlePrefix        string            `yaml:"title_prefix,omitempty" json:"title_prefix,omitempty"`
+	Description        string            `yaml:"description,omitempty" json:"description,omitempty"`
+	AcceptanceCriteria string            `yaml:"acceptance_criteria,omitempty" json:"acceptance_criteria,omitempty"`
+	Type               string            `yaml:"type,omitempty" json:"type,omitempty"`
+	Priority           *int              `yaml:"priority,omitempty" json:"priority,omitempty"`
+	Labels             []string          `yaml:"labels,omitempty" json:"labels,omitempty"`
+	Fields             map[string]string `yaml:"fields,omitempty" json:"fields,omitempty"` // Default custom field values
+	RequiredFields     []string          `yaml:"required_fields,omitempty" json:"required_fields,omitempty"`
+}
+
+// parseIssueTemplate reads a template file, rejecting unknown keys so a
+// misspelled one doesn't go unnoticed
+func parseIssueTemplate(name string, data []byte) (*CreateTemplate, error) {
+	if !issueTemplateNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid template name %q: use lowercase letters, digits and single hyphens or underscores, starting with a letter", name)
+	}
+	tmpl := &CreateTemplate{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(tmpl); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("template %s: %w", name, err)
+	}
+	tmpl.Name = name
+
+	if tmpl.Type != "" && !types.IssueType(tmpl.Type).IsValid() {
+		return nil, fmt.Errorf("template %s: invalid type %q", name, tmpl.Type)
+	}
+	if tmpl.Priority != nil && (*tmpl.Priority < 0 || *tmpl.Priority > 4) {
+		return nil, fmt.Errorf("template %s: priority must be between 0 and 4 (got %d)", name, *tmpl.Priority)
+	}
+	tmpl.Labels = util.NormalizeLabels(tmpl.Labels)
+	fields := make(map[string]string, len(tmpl.Fields))
+	for field, value := range tmpl.Fields {
+		field = strings.ToLower(strings.TrimSpace(field))
+		if err := types.ValidateFieldName(field); err != nil {
+			return nil, fmt.Errorf("template %s: %w", name, err)
+		}
+		fields[field] = strings.TrimSpace(value)
+	}
+	tmpl.Fields = fields
+	for i, field := range tmpl.RequiredFields {
+		field = strings.ToLower(strings.TrimSpace(field))
+		if err := types.ValidateFieldName(field); err != nil {
+			return nil, fmt.Errorf("template %s: required_fields: %w", name, err)
+		}
+		tmpl.RequiredFields[i] = field
+	}
+	return tmpl, nil
+}
+
+// issueTemplatePath returns where the template name is kept
+func issueTemplatePath(beadsDir, name string) string {
+	return filepath.Join(beadsDir, issueTemplatesDir, name+".yaml")
+}
+
+// loadIssueTemplate reads the project's template name
+func loadIssueTemplate(name string) (*CreateTemplate, error) {
+	beadsDir := findBeadsDir()
+	if beadsDir == "" {
+		return nil, fmt.Errorf("no .beads directory found")
+	}
+	if !issueTemplateNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid template name %q", name)
+	}
+	data, err := os.ReadFile(issueTemplatePath(beadsDir, name)) // #nosec G304 - name can't leave the templates directory
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("no issue template %q (add one with bd template add %s --file <yaml>)", name, name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template %s: %w", name, err)
+	}
+	return parseIssueTemplate(name, data)
+}
+
+// listIssueTemplates reads every template in beadsDir, by name
+func listIssueTemplates(beadsDir string) ([]*CreateTemplate, error) {
+	entries, err := os.ReadDir(filepath.Join(beadsDir, issueTemplatesDir))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var templates []*CreateTemplate
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".yaml")
+		if !ok || entry.IsDir() || !issueTemplateNamePattern.MatchString(name) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(beadsDir, issueTemplatesDir, entry.Name())) // #nosec G304 - listed from the templates directory
+		if err != nil {
+			return nil, err
+		}
+		tmpl, err := parseIssueTemplate(name, data)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, tmpl)
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates, nil
+}
+
+// prefixTitle puts the template's title prefix before title, unless it is
+// already there
+func (t *CreateTemplate) prefixTitle(title string) string {
+	if t.TitlePrefix == "" || strings.HasPrefix(title, t.TitlePrefix) {
+		return title
+	}
+	return t.TitlePrefix + title
+}
+
+// withFieldDefaults adds the template's field values to fields for those
+// not given
+func (t *CreateTemplate) withFieldDefaults(fields types.CustomFields) types.CustomFields {
+	if len(t.Fields) == 0 {
+		return fields
+	}
+	merged := make(types.CustomFields, len(t.Fields)+len(fields))
+	for name, value := range t.Fields {
+		merged[name] = value
+	}
+	for name, value := range fields {
+		merged[name] = value
+	}
+	return merged
+}
+
+// missingFields returns the required fields fields has no value for
+func (t *CreateTemplate) missingFields(fields types.CustomFields) []string {
+	var missing []string
+	for _, name := range t.RequiredFields {
+		if strings.TrimSpace(fields[name]) == "" {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+// describe summarizes what the template presets, for bd template list
+func (t *CreateTemplate) describe() string {
+	var parts []string
+	if t.Type != "" {
+		parts = append(parts, t.Type)
+	}
+	if t.Priority != nil {
+		parts = append(parts, fmt.Sprintf("P%d", *t.Priority))
+	}
+	if t.TitlePrefix != "" {
+		parts = append(parts, fmt.Sprintf("title %q…", t.TitlePrefix))
+	}
+	if len(t.Labels) > 0 {
+		parts = append(parts, "labels: "+strings.Join(t.Labels, ", "))
+	}
+	if len(t.RequiredFields) > 0 {
+		parts = append(parts, "requires: "+strings.Join(t.RequiredFields, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
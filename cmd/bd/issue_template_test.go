@@ -0,0 +1,93 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestParseIssueTemplate(t *testing.T) {
+	data := []byte(`
+title_prefix: "[Bug] "
+type: bug
+priority: 1
+labels: [triage, " crash "]
+description: |
+  ## Steps to reproduce
+fields:
+  Severity: medium
+required_fields: [component, severity]
+`)
+	tmpl, err := parseIssueTemplate("bug", data)
+	if err != nil {
+		t.Fatalf("parseIssueTemplate failed: %v", err)
+	}
+	if tmpl.Name != "bug" || tmpl.Type != "bug" || tmpl.Priority == nil || *tmpl.Priority != 1 {
+		t.Errorf("template = %+v", tmpl)
+	}
+	if strings.Join(tmpl.Labels, ",") != "triage,crash" || tmpl.Fields["severity"] != "medium" {
+		t.Errorf("labels = %v, fields = %v", tmpl.Labels, tmpl.Fields)
+	}
+
+	if got := tmpl.prefixTitle("Crash on save"); got != "[Bug] Crash on save" {
+		t.Errorf("prefixTitle = %q", got)
+	}
+	if got := tmpl.prefixTitle("[Bug] Crash on save"); got != "[Bug] Crash on save" {
+		t.Errorf("prefixTitle doubled the prefix: %q", got)
+	}
+
+	fields := tmpl.withFieldDefaults(types.CustomFields{"severity": "high"})
+	if fields["severity"] != "high" {
+		t.Errorf("a given field should win over the default, got %v", fields)
+	}
+	if missing := tmpl.missingFields(fields); len(missing) != 1 || missing[0] != "component" {
+		t.Errorf("missingFields = %v, want [component]", missing)
+	}
+	fields["component"] = "editor"
+	if missing := tmpl.missingFields(fields); len(missing) != 0 {
+		t.Errorf("missingFields = %v, want none", missing)
+	}
+
+	for name, bad := range map[string]string{
+		"misspelled key": "titel_prefix: x",
+		"bad type":       "type: story",
+		"bad priority":   "priority: 7",
+		"bad field":      "required_fields: [\"has space\"]",
+	} {
+		if _, err := parseIssueTemplate("bug", []byte(bad)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+	if _, err := parseIssueTemplate("../bug", nil); err == nil {
+		t.Error("expected an error for a name outside the templates directory")
+	}
+}
+
+func TestListIssueTemplates(t *testing.T) {
+	beadsDir := t.TempDir()
+	if templates, err := listIssueTemplates(beadsDir); err != nil || len(templates) != 0 {
+		t.Fatalf("no templates directory: got %v, %v", templates, err)
+	}
+	for name, content := range map[string]string{"feature": "type: feature", "bug": "type: bug", "notes.txt": "ignored"} {
+		path := issueTemplatePath(beadsDir, name)
+		if name == "notes.txt" {
+			path = filepath.Join(beadsDir, issueTemplatesDir, name)
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	templates, err := listIssueTemplates(beadsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(templates) != 2 || templates[0].Name != "bug" || templates[1].Name != "feature" {
+		t.Errorf("templates = %+v, want bug and feature", templates)
+	}
+}
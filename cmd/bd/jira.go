@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -34,6 +35,7 @@ type JiraSyncResult struct {
 	LastSync  string         `json:"last_sync,omitempty"`
 	Error     string         `json:"error,omitempty"`
 	Warnings  []string       `json:"warnings,omitempty"`
+	RunID     string         `json:"run_id,omitempty"` // the sync log entry (bd sync log)
 }
 
 var jiraCmd = &cobra.Command{
@@ -121,6 +123,10 @@ Examples:
 
 		ctx := rootCtx
 		result := &JiraSyncResult{Success: true}
+		jiraURL, _ := store.GetConfig(ctx, "jira.url")
+		jiraProject, _ := store.GetConfig(ctx, "jira.project")
+		run := startSyncAudit(ctx, sqlite.SyncProviderJira, strings.TrimSuffix(jiraURL, "/")+" "+jiraProject, dryRun, nil)
+		changes := []sqlite.SyncChange{}
 
 		// Step 1: Pull from Jira
 		if pull {
@@ -132,6 +138,7 @@ Examples:
 
 			pullStats, err := doPullFromJira(ctx, dryRun, state)
 			if err != nil {
+				finishSyncAudit(ctx, run, err, changes)
 				result.Success = false
 				result.Error = err.Error()
 				if jsonOutput {
@@ -146,6 +153,7 @@ Examples:
 			result.Stats.Created += pullStats.Created
 			result.Stats.Updated += pullStats.Updated
 			result.Stats.Skipped += pullStats.Skipped
+			changes = append(changes, pullStats.Changes...)
 
 			if !dryRun {
 				fmt.Printf("✓ Pulled %d issues (%d created, %d updated)\n",
@@ -189,6 +197,7 @@ Examples:
 
 			pushStats, err := doPushToJira(ctx, dryRun, createOnly, updateRefs)
 			if err != nil {
+				finishSyncAudit(ctx, run, err, append(changes, pushStats.Changes...))
 				result.Success = false
 				result.Error = err.Error()
 				if jsonOutput {
@@ -204,8 +213,11 @@ Examples:
 			result.Stats.Updated += pushStats.Updated
 			result.Stats.Skipped += pushStats.Skipped
 			result.Stats.Errors += pushStats.Errors
+			changes = append(changes, pushStats.Changes...)
 
-			if !dryRun {
+			if dryRun {
+				printSyncChanges("Outbound", pushStats.Changes)
+			} else {
 				fmt.Printf("✓ Pushed %d issues (%d created, %d updated)\n",
 					result.Stats.Pushed, pushStats.Created, pushStats.Updated)
 			}
@@ -219,6 +231,15 @@ Examples:
 			}
 		}
 
+		var syncErr error
+		if result.Stats.Errors > 0 {
+			syncErr = fmt.Errorf("%d issue(s) failed", result.Stats.Errors)
+		}
+		finishSyncAudit(ctx, run, syncErr, changes)
+		if run != nil {
+			result.RunID = run.ID
+		}
+
 		// Output result
 		if jsonOutput {
 			outputJSON(result)
@@ -374,6 +395,7 @@ type PullStats struct {
 	Created int
 	Updated int
 	Skipped int
+	Changes []sqlite.SyncChange // the issues created or updated, for the sync log
 }
 
 // doPullFromJira imports issues from Jira using the Python script.
@@ -401,19 +423,6 @@ func doPullFromJira(ctx context.Context, dryRun bool, state string) (*PullStats,
 		return stats, fmt.Errorf("failed to fetch from Jira: %w", err)
 	}
 
-	if dryRun {
-		// Count issues in output
-		scanner := bufio.NewScanner(strings.NewReader(string(output)))
-		count := 0
-		for scanner.Scan() {
-			if strings.TrimSpace(scanner.Text()) != "" {
-				count++
-			}
-		}
-		fmt.Printf("  Would import %d issues from Jira\n", count)
-		return stats, nil
-	}
-
 	// Parse JSONL and import
 	scanner := bufio.NewScanner(strings.NewReader(string(output)))
 	var issues []*types.Issue
@@ -435,6 +444,22 @@ func doPullFromJira(ctx context.Context, dryRun bool, state string) (*PullStats,
 		return stats, fmt.Errorf("failed to read JSONL: %w", err)
 	}
 
+	stats.Changes = planJiraPull(ctx, issues)
+	if dryRun {
+		for _, c := range stats.Changes {
+			if c.Action == "create" {
+				stats.Created++
+			} else {
+				stats.Updated++
+			}
+		}
+		stats.Skipped = len(issues) - len(stats.Changes)
+		fmt.Printf("  Would import %d issues from Jira (%d new, %d updated, %d unchanged)\n",
+			len(stats.Changes), stats.Created, stats.Updated, stats.Skipped)
+		printSyncChanges("Inbound", stats.Changes)
+		return stats, nil
+	}
+
 	// Import issues using shared logic
 	opts := ImportOptions{
 		DryRun:     false,
@@ -453,12 +478,55 @@ func doPullFromJira(ctx context.Context, dryRun bool, state string) (*PullStats,
 	return stats, nil
 }
 
+// planJiraPull lists what importing the issues read from Jira changes: the
+// issues that are new and, matched by external_ref or ID, those that are
+// newer in Jira and differ
+func planJiraPull(ctx context.Context, issues []*types.Issue) []sqlite.SyncChange {
+	changes := []sqlite.SyncChange{}
+	for _, issue := range issues {
+		ref := externalRefOf(issue)
+		change := sqlite.SyncChange{Direction: sqlite.SyncInbound, Action: "create", IssueID: issue.ID,
+			Remote: jiraKeyOf(ref), Title: issue.Title}
+		var existing *types.Issue
+		if ref != "" {
+			existing, _ = store.GetIssueByExternalRef(ctx, ref)
+		}
+		if existing == nil && issue.ID != "" {
+			existing, _ = store.GetIssue(ctx, issue.ID)
+		}
+		if existing != nil {
+			// The import keeps the local version unless Jira's is newer
+			if !issue.UpdatedAt.After(existing.UpdatedAt) {
+				continue
+			}
+			change.IssueID = existing.ID
+			existing.Dependencies, _ = store.GetDependencyRecords(ctx, existing.ID)
+			fields := changedSyncFields(existing, issue)
+			if len(fields) == 0 {
+				continue
+			}
+			change.Action, change.Detail = "update", strings.Join(fields, ", ")
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// jiraKeyOf returns the issue key (PROJ-7) of a Jira browse URL
+func jiraKeyOf(externalRef string) string {
+	if i := strings.LastIndex(externalRef, "/browse/"); i >= 0 {
+		return externalRef[i+len("/browse/"):]
+	}
+	return ""
+}
+
 // PushStats tracks push operation statistics.
 type PushStats struct {
 	Created int
 	Updated int
 	Skipped int
 	Errors  int
+	Changes []sqlite.SyncChange // the Jira issues created or updated, for the sync log
 }
 
 // doPushToJira exports issues to Jira using the Python script.
@@ -493,6 +561,10 @@ func doPushToJira(ctx context.Context, dryRun bool, createOnly bool, updateRefs
 	}
 
 	jsonlContent := strings.Join(jsonlLines, "\n")
+	titles := make(map[string]string, len(issues))
+	for _, issue := range issues {
+		titles[issue.ID] = issue.Title
+	}
 
 	// Build command
 	args := []string{scriptPath, "--from-config"}
@@ -523,14 +595,28 @@ func doPushToJira(ctx context.Context, dryRun bool, createOnly bool, updateRefs
 			continue
 		}
 
-		// Parse mapping output: {"bd_id": "...", "jira_key": "...", "external_ref": "..."}
+		// Parse mapping output: {"bd_id": "...", "action": "create", "jira_key": "...", "external_ref": "..."}
+		// or {"bd_id": "...", "action": "update", "jira_key": "...", "fields": [...]}.
+		// Lines without an action come from older scripts, which only print creates.
 		var mapping struct {
-			BDID        string `json:"bd_id"`
-			JiraKey     string `json:"jira_key"`
-			ExternalRef string `json:"external_ref"`
+			BDID        string   `json:"bd_id"`
+			Action      string   `json:"action"`
+			JiraKey     string   `json:"jira_key"`
+			ExternalRef string   `json:"external_ref"`
+			Fields      []string `json:"fields"`
 		}
 		if err := json.Unmarshal([]byte(line), &mapping); err == nil && mapping.BDID != "" {
+			change := sqlite.SyncChange{Direction: sqlite.SyncOutbound, Action: "create", IssueID: mapping.BDID,
+				Remote: mapping.JiraKey, Title: titles[mapping.BDID]}
+			if mapping.Action == "update" {
+				change.Action = "update"
+				change.Detail = strings.Join(mapping.Fields, ", ")
+				stats.Updated++
+				stats.Changes = append(stats.Changes, change)
+				continue
+			}
 			stats.Created++
+			stats.Changes = append(stats.Changes, change)
 
 			// Update external_ref if requested
 			if updateRefs && !dryRun && mapping.ExternalRef != "" {
@@ -542,12 +628,32 @@ func doPushToJira(ctx context.Context, dryRun bool, createOnly bool, updateRefs
 					stats.Errors++
 				}
 			}
+			if !dryRun && mapping.JiraKey != "" {
+				linkJiraRef(ctx, mapping.BDID, mapping.JiraKey, mapping.ExternalRef)
+			}
 		}
 	}
 
 	return stats, nil
 }
 
+// linkJiraRef records a pushed issue's Jira key in the cross-reference table
+// so bd xref --external finds it. It needs the local SQLite store; failures
+// only warn, since the push itself succeeded.
+func linkJiraRef(ctx context.Context, issueID, key, url string) {
+	sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+	if !ok {
+		return
+	}
+	ref := &sqlite.ExternalRef{IssueID: issueID, System: "jira", RemoteID: key}
+	if strings.HasPrefix(url, "http") {
+		ref.URL = url
+	}
+	if err := sqliteStore.LinkExternalRef(ctx, ref); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to record Jira key %s for %s: %v\n", key, issueID, err)
+	}
+}
+
 // findJiraScript locates the Jira Python script.
 func findJiraScript(name string) (string, error) {
 	// Check common locations
@@ -649,7 +755,7 @@ func reimportConflicts(_ context.Context, conflicts []JiraConflict) error {
 	fmt.Fprintf(os.Stderr, "Warning: conflict resolution (--prefer-jira) not fully implemented\n")
 	fmt.Fprintf(os.Stderr, "  %d issue(s) may have conflicts that need manual review:\n", len(conflicts))
 	for _, c := range conflicts {
-		fmt.Fprintf(os.Stderr, "    - %s (local updated: %s)\n", c.IssueID, c.LocalUpdated.Format(time.RFC3339))
+		fmt.Fprintf(os.Stderr, "    - %s (local updated: %s)\n", c.IssueID, displayTime(c.LocalUpdated))
 	}
 	return nil
 }
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/lint"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var lintCmd = &cobra.Command{
+	Use:   "lint [id...]",
+	Short: "Check the backlog against hygiene rules",
+	Long: `Check open issues against backlog hygiene rules and report violations.
+
+Rules:
+  empty-epic          Epic without child issues (warning)
+  milestone-crossing  Blocking dependency between issues in different
+                      milestones, from milestone:<name> labels (warning)
+  missing-estimate    P0/P1 issue without an estimate (warning)
+  title-length        Title longer than lint.title_max_length (default 80) (warning)
+  unlabeled           Issue without any label (info)
+
+Each rule's severity is configurable, and off disables it:
+  bd config set lint.unlabeled.severity error
+  bd config set lint.title-length.severity off
+  bd config set lint.title_max_length 100
+  bd config set lint.milestone_prefix "release:"
+
+Suppress a rule for one issue with a label:
+  bd label add bd-42 lint-ignore:missing-estimate    # or lint-ignore:all
+
+In CI, --fail-on makes findings at or above a severity exit with status 1
+(default error).
+
+Examples:
+  bd lint
+  bd lint bd-42 bd-43
+  bd lint --fail-on warning --json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		failOnFlag, _ := cmd.Flags().GetString("fail-on")
+		failOn, err := lint.ParseSeverity(failOnFlag)
+		if err != nil {
+			FatalError("--fail-on: %v", err)
+		}
+
+		if err := ensureDirectMode("lint requires direct database access"); err != nil {
+			FatalError("%v", err)
+		}
+		ctx := rootCtx
+
+		cfg, err := loadLintConfig()
+		if err != nil {
+			FatalError("%v", err)
+		}
+
+		issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+		if err != nil {
+			FatalError("%v", err)
+		}
+		ids := make([]string, len(issues))
+		for i, issue := range issues {
+			ids[i] = issue.ID
+		}
+		labels, err := store.GetLabelsForIssues(ctx, ids)
+		if err != nil {
+			FatalError("%v", err)
+		}
+		deps, err := store.GetAllDependencyRecords(ctx)
+		if err != nil {
+			FatalError("%v", err)
+		}
+
+		findings := lint.Run(&lint.Backlog{Issues: issues, Labels: labels, Deps: deps}, cfg)
+		if len(args) > 0 {
+			wanted := make(map[string]bool)
+			for _, id := range args {
+				fullID, err := utils.ResolvePartialID(ctx, store, id)
+				if err != nil {
+					FatalError("resolving %s: %v", id, err)
+				}
+				wanted[fullID] = true
+			}
+			filtered := []lint.Finding{}
+			for _, f := range findings {
+				if wanted[f.IssueID] {
+					filtered = append(filtered, f)
+				}
+			}
+			findings = filtered
+		}
+
+		failed := false
+		for _, f := range findings {
+			if failOn != lint.SeverityOff && f.Severity.AtLeast(failOn) {
+				failed = true
+			}
+		}
+
+		if jsonOutput {
+			outputJSON(findings)
+		} else {
+			printLintFindings(findings)
+		}
+		if failed {
+			os.Exit(1)
+		}
+	},
+}
+
+// loadLintConfig reads rule severities and tunables from bd config
+func loadLintConfig() (*lint.Config, error) {
+	ctx := rootCtx
+	cfg := &lint.Config{Severity: make(map[string]lint.Severity)}
+	for _, rule := range lint.Rules() {
+		key := "lint." + rule.Name + ".severity"
+		value, err := store.GetConfig(ctx, key)
+		if err != nil || value == "" {
+			continue
+		}
+		severity, err := lint.ParseSeverity(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		cfg.Severity[rule.Name] = severity
+	}
+	if value, _ := store.GetConfig(ctx, "lint.title_max_length"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("lint.title_max_length: invalid length %q", value)
+		}
+		cfg.TitleMaxLength = n
+	}
+	cfg.MilestonePrefix, _ = store.GetConfig(ctx, "lint.milestone_prefix")
+	return cfg, nil
+}
+
+func printLintFindings(findings []lint.Finding) {
+	if len(findings) == 0 {
+		green := color.New(color.FgGreen).SprintFunc()
+		fmt.Printf("%s No lint findings\n", green("✓"))
+		return
+	}
+	colors := map[lint.Severity]func(a ...interface{}) string{
+		lint.SeverityError:   color.New(color.FgRed).SprintFunc(),
+		lint.SeverityWarning: color.New(color.FgYellow).SprintFunc(),
+		lint.SeverityInfo:    color.New(color.FgCyan).SprintFunc(),
+	}
+	counts := make(map[lint.Severity]int)
+	for _, f := range findings {
+		counts[f.Severity]++
+		fmt.Printf("%-14s %-7s %-19s %s\n", f.IssueID, colors[f.Severity](f.Severity), f.Rule, f.Message)
+	}
+	fmt.Printf("\n%d error(s), %d warning(s), %d info\n",
+		counts[lint.SeverityError], counts[lint.SeverityWarning], counts[lint.SeverityInfo])
+}
+
+func init() {
+	lintCmd.Flags().String("fail-on", "error", "Exit with status 1 on findings at or above this severity (error, warning, info, off)")
+	rootCmd.AddCommand(lintCmd)
+}
@@ -6,12 +6,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"sort"
 	"strings"
 	"text/template"
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/rpc"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
@@ -37,63 +37,41 @@ func parseTimeFlag(s string) (time.Time, error) {
 	return time.Time{}, fmt.Errorf("unable to parse time %q (try formats: 2006-01-02, 2006-01-02T15:04:05, or RFC3339)", s)
 }
 
-// sortIssues sorts a slice of issues by the specified field and direction
-func sortIssues(issues []*types.Issue, sortBy string, reverse bool) {
+// defaultSortKeys is the storage order: priority, then newest first
+var defaultSortKeys = types.SortKeys{{Field: "priority"}, {Field: "created_at", Desc: true}}
+
+// resolveSortKeys turns --sort and --reverse into sort keys for the query.
+// fallback is a default spec (list.sort) used when --sort isn't given.
+// Returns nil to keep the storage order.
+func resolveSortKeys(sortBy, fallback string, reverse bool) (types.SortKeys, error) {
 	if sortBy == "" {
-		return
+		sortBy = fallback
 	}
-
-	sort.Slice(issues, func(i, j int) bool {
-		var less bool
-
-		switch sortBy {
-		case "priority":
-			// Lower priority numbers come first (P0 > P1 > P2 > P3 > P4)
-			less = issues[i].Priority < issues[j].Priority
-		case "created":
-			// Default: newest first (descending)
-			less = issues[i].CreatedAt.After(issues[j].CreatedAt)
-		case "updated":
-			// Default: newest first (descending)
-			less = issues[i].UpdatedAt.After(issues[j].UpdatedAt)
-		case "closed":
-			// Default: newest first (descending)
-			// Handle nil ClosedAt values
-			if issues[i].ClosedAt == nil && issues[j].ClosedAt == nil {
-				less = false
-			} else if issues[i].ClosedAt == nil {
-				less = false // nil sorts last
-			} else if issues[j].ClosedAt == nil {
-				less = true // non-nil sorts before nil
-			} else {
-				less = issues[i].ClosedAt.After(*issues[j].ClosedAt)
-			}
-		case "status":
-			less = issues[i].Status < issues[j].Status
-		case "id":
-			less = issues[i].ID < issues[j].ID
-		case "title":
-			less = strings.ToLower(issues[i].Title) < strings.ToLower(issues[j].Title)
-		case "type":
-			less = issues[i].IssueType < issues[j].IssueType
-		case "assignee":
-			less = issues[i].Assignee < issues[j].Assignee
-		default:
-			// Unknown sort field, no sorting
-			less = false
-		}
-
-		if reverse {
-			return !less
-		}
-		return less
-	})
+	keys, err := types.ParseSortKeys(sortBy)
+	if err != nil {
+		return nil, err
+	}
+	if reverse {
+		if len(keys) == 0 {
+			keys = defaultSortKeys
+		}
+		keys = keys.Reverse()
+	}
+	return keys, nil
 }
 
 var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List issues",
 	Run: func(cmd *cobra.Command, args []string) {
+		// A saved filter fills in the flags it covers before they are read
+		if name, _ := cmd.Flags().GetString("filter"); name != "" {
+			saved := loadSavedFilter("list --filter", name)
+			if err := applySavedFilter(cmd, saved.Query); err != nil {
+				FatalError("saved filter %s: %v", name, err)
+			}
+		}
+
 		status, _ := cmd.Flags().GetString("status")
 		assignee, _ := cmd.Flags().GetString("assignee")
 		issueType, _ := cmd.Flags().GetString("type")
@@ -101,6 +79,8 @@ var listCmd = &cobra.Command{
 		formatStr, _ := cmd.Flags().GetString("format")
 		labels, _ := cmd.Flags().GetStringSlice("label")
 		labelsAny, _ := cmd.Flags().GetStringSlice("label-any")
+		complexityFlags, _ := cmd.Flags().GetStringSlice("complexity")
+		fieldFlags, _ := cmd.Flags().GetStringArray("field")
 		titleSearch, _ := cmd.Flags().GetString("title")
 		idFilter, _ := cmd.Flags().GetString("id")
 		longFormat, _ := cmd.Flags().GetBool("long")
@@ -119,6 +99,13 @@ var listCmd = &cobra.Command{
 		updatedBefore, _ := cmd.Flags().GetString("updated-before")
 		closedAfter, _ := cmd.Flags().GetString("closed-after")
 		closedBefore, _ := cmd.Flags().GetString("closed-before")
+		closedOnly, _ := cmd.Flags().GetBool("closed")
+		closeReason, _ := cmd.Flags().GetString("reason")
+		cursor, _ := cmd.Flags().GetString("cursor")
+		pageSize, _ := cmd.Flags().GetInt("page-size")
+		rollUp, _ := cmd.Flags().GetBool("roll-up")
+		paginated := cursor != "" || pageSize > 0
+		stream, _ := cmd.Flags().GetBool("stream")
 		
 		// Empty/null check flags
 		emptyDesc, _ := cmd.Flags().GetBool("empty-description")
@@ -135,8 +122,61 @@ var listCmd = &cobra.Command{
 		labels = util.NormalizeLabels(labels)
 	labelsAny = util.NormalizeLabels(labelsAny)
 
+		// --closed is shorthand for --status closed
+		if closedOnly {
+			if status != "" && status != string(types.StatusClosed) {
+				fmt.Fprintf(os.Stderr, "Error: --closed conflicts with --status %s\n", status)
+				os.Exit(1)
+			}
+			status = string(types.StatusClosed)
+		}
+
+		// Pages follow the storage order, so another sort order or a
+		// separate limit would make cursors skip or repeat issues
+		if paginated {
+			if sortBy != "" || reverse {
+				fmt.Fprintf(os.Stderr, "Error: --cursor/--page-size cannot be combined with --sort or --reverse\n")
+				os.Exit(1)
+			}
+			if limit > 0 {
+				fmt.Fprintf(os.Stderr, "Error: use --page-size instead of --limit when paginating\n")
+				os.Exit(1)
+			}
+		}
+
+		// Streams walk the pages internally, in the same fixed order
+		if stream {
+			if paginated {
+				fmt.Fprintf(os.Stderr, "Error: --stream cannot be combined with --cursor or --page-size\n")
+				os.Exit(1)
+			}
+			if sortBy != "" || reverse {
+				fmt.Fprintf(os.Stderr, "Error: --stream cannot be combined with --sort or --reverse\n")
+				os.Exit(1)
+			}
+			if formatStr != "" || longFormat {
+				fmt.Fprintf(os.Stderr, "Error: --stream always writes JSON Lines; drop --format/--long\n")
+				os.Exit(1)
+			}
+		}
+
+		// Sorting happens in the query, before --limit applies
+		defaultSort := ""
+		if !paginated && !stream {
+			defaultSort = config.GetString("list.sort")
+		}
+		sortKeys, err := resolveSortKeys(sortBy, defaultSort, reverse)
+		if err != nil {
+			if sortBy == "" {
+				FatalErrorWithHint(fmt.Sprintf("invalid list.sort: %v", err), "fix list.sort in config.yaml")
+			}
+			FatalError("invalid --sort: %v", err)
+		}
+
 		filter := types.IssueFilter{
-			Limit: limit,
+			Limit:       limit,
+			CloseReason: closeReason,
+			Sort:        sortKeys,
 		}
 		if status != "" && status != "all" {
 			s := types.Status(status)
@@ -165,6 +205,21 @@ var listCmd = &cobra.Command{
 		if len(labelsAny) > 0 {
 			filter.LabelsAny = labelsAny
 		}
+		complexity, err := types.ParseComplexities(complexityFlags)
+		if err != nil {
+			FatalError("invalid --complexity: %v", err)
+		}
+		filter.Complexity = complexity
+		fields, err := parseFieldFlags(fieldFlags)
+		if err != nil {
+			FatalError("invalid --field: %v", err)
+		}
+		if daemonClient == nil {
+			if fields, err = storage.NormalizeFields(rootCtx, store, fields, false); err != nil {
+				FatalError("%v", err)
+			}
+		}
+		filter.Fields = fields
 		if titleSearch != "" {
 			filter.TitleSearch = titleSearch
 		}
@@ -298,6 +353,8 @@ var listCmd = &cobra.Command{
 			if len(labelsAny) > 0 {
 				listArgs.LabelsAny = labelsAny
 			}
+			listArgs.Complexity = complexityFlags
+			listArgs.Fields = fields
 			// Forward title search via Query field (searches title/description/id)
 			if titleSearch != "" {
 			 listArgs.Query = titleSearch
@@ -310,6 +367,7 @@ var listCmd = &cobra.Command{
 			listArgs.TitleContains = titleContains
 			listArgs.DescriptionContains = descContains
 			listArgs.NotesContains = notesContains
+			listArgs.CloseReason = closeReason
 			
 			// Date ranges
 			if filter.CreatedAfter != nil {
@@ -340,12 +398,46 @@ var listCmd = &cobra.Command{
 			listArgs.PriorityMin = filter.PriorityMin
 			listArgs.PriorityMax = filter.PriorityMax
 
+			// Cursor pagination
+			listArgs.Cursor = cursor
+			listArgs.PageSize = pageSize
+			listArgs.RollUp = rollUp
+			listArgs.Sort = sortKeys.String()
+			if !jsonOutput && !stream {
+				listArgs.Locale = displayLocale()
+			}
+
+			if stream {
+				if err := streamIssuesDaemon(listArgs, limit); err != nil {
+					fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+					os.Exit(1)
+				}
+				return
+			}
+
 			 resp, err := daemonClient.List(listArgs)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 				os.Exit(1)
 			}
 
+			if paginated {
+				var page rpc.ListPage
+				if err := json.Unmarshal(resp.Data, &page); err != nil {
+					fmt.Fprintf(os.Stderr, "Error parsing response: %v\n", err)
+					os.Exit(1)
+				}
+				decryptCountedForDisplay(page.Issues)
+				if jsonOutput {
+					outputJSON(page)
+					return
+				}
+				issues, progress := splitIssueCounts(page.Issues)
+				printIssueList(issues, nil, progress, longFormat)
+				printNextCursor(page.NextCursor)
+				return
+			}
+
 			if jsonOutput {
 				// For JSON output, preserve the full response with counts
 				var issuesWithCounts []*types.IssueWithCounts
@@ -353,6 +445,7 @@ var listCmd = &cobra.Command{
 					fmt.Fprintf(os.Stderr, "Error parsing response: %v\n", err)
 					os.Exit(1)
 				}
+				decryptCountedForDisplay(issuesWithCounts)
 				outputJSON(issuesWithCounts)
 				return
 			}
@@ -360,58 +453,41 @@ var listCmd = &cobra.Command{
 			// Show upgrade notification if needed (bd-loka)
 			maybeShowUpgradeNotification()
 
-			var issues []*types.Issue
-			if err := json.Unmarshal(resp.Data, &issues); err != nil {
+			var issuesWithCounts []*types.IssueWithCounts
+			if err := json.Unmarshal(resp.Data, &issuesWithCounts); err != nil {
 				fmt.Fprintf(os.Stderr, "Error parsing response: %v\n", err)
 				os.Exit(1)
 			}
+			decryptCountedForDisplay(issuesWithCounts)
+			issues, progress := splitIssueCounts(issuesWithCounts)
 
-			// Apply sorting
-			sortIssues(issues, sortBy, reverse)
-
-			if longFormat {
-				// Long format: multi-line with details
-				fmt.Printf("\nFound %d issues:\n\n", len(issues))
-				for _, issue := range issues {
-					fmt.Printf("%s [P%d] [%s] %s\n", issue.ID, issue.Priority, issue.IssueType, issue.Status)
-					fmt.Printf("  %s\n", issue.Title)
-					if issue.Assignee != "" {
-						fmt.Printf("  Assignee: %s\n", issue.Assignee)
-					}
-					if len(issue.Labels) > 0 {
-						fmt.Printf("  Labels: %v\n", issue.Labels)
-					}
-					fmt.Println()
-				}
-			} else {
-				// Compact format: one line per issue
-				for _, issue := range issues {
-					labelsStr := ""
-					if len(issue.Labels) > 0 {
-						labelsStr = fmt.Sprintf(" %v", issue.Labels)
-					}
-					assigneeStr := ""
-					if issue.Assignee != "" {
-						assigneeStr = fmt.Sprintf(" @%s", issue.Assignee)
-					}
-					fmt.Printf("%s [P%d] [%s] %s%s%s - %s\n",
-						issue.ID, issue.Priority, issue.IssueType, issue.Status,
-						assigneeStr, labelsStr, issue.Title)
-				}
-			}
+			printIssueList(issues, nil, progress, longFormat)
 			return
 		}
 
 		// Direct mode
 		// ctx already created above for staleness check
-		issues, err := store.SearchIssues(ctx, "", filter)
+		if stream {
+			if err := streamIssuesDirect(ctx, filter, rollUp); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+		var issues []*types.Issue
+		var nextCursor string
+		if paginated {
+			issues, nextCursor, err = storage.SearchIssuesPage(ctx, store, "", filter, cursor, pageSize)
+		} else {
+			issues, err = store.SearchIssues(ctx, "", filter)
+		}
 		if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 		}
 
 	// If no issues found, check if git has issues and auto-import
-	if len(issues) == 0 {
+	if len(issues) == 0 && !paginated {
 		if checkAndAutoImport(ctx, store) {
 			// Re-run the query after import
 			issues, err = store.SearchIssues(ctx, "", filter)
@@ -422,8 +498,10 @@ var listCmd = &cobra.Command{
 		}
 	}
 
-		// Apply sorting
-		sortIssues(issues, sortBy, reverse)
+		if !jsonOutput {
+			localizeForDisplay(issues)
+		}
+		decryptForDisplay(issues...)
 
 		// Handle format flag
 		if formatStr != "" {
@@ -434,34 +512,20 @@ var listCmd = &cobra.Command{
 			return
 		}
 
-		if jsonOutput {
-			// Get labels and dependency counts in bulk (single query instead of N queries)
-			issueIDs := make([]string, len(issues))
-			for i, issue := range issues {
-				issueIDs[i] = issue.ID
-			}
-			labelsMap, _ := store.GetLabelsForIssues(ctx, issueIDs)
-			depCounts, _ := store.GetDependencyCounts(ctx, issueIDs)
-
-			// Populate labels for JSON output
-			for _, issue := range issues {
-				issue.Labels = labelsMap[issue.ID]
-			}
+		// Child completion for epics, with estimates when rolled up
+		progress, err := storage.ChildProgress(ctx, store, storage.EpicIDs(issues), rollUp)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
 
-			// Build response with counts
-			issuesWithCounts := make([]*types.IssueWithCounts, len(issues))
-			for i, issue := range issues {
-				counts := depCounts[issue.ID]
-				if counts == nil {
-					counts = &types.DependencyCounts{DependencyCount: 0, DependentCount: 0}
-				}
-				issuesWithCounts[i] = &types.IssueWithCounts{
-					Issue:           issue,
-					DependencyCount: counts.DependencyCount,
-					DependentCount:  counts.DependentCount,
-				}
+		if jsonOutput {
+			counted := issuesWithCounts(ctx, issues, progress)
+			if paginated {
+				outputJSON(rpc.ListPage{Issues: counted, NextCursor: nextCursor})
+				return
 			}
-			outputJSON(issuesWithCounts)
+			outputJSON(counted)
 			return
 		}
 
@@ -475,40 +539,8 @@ var listCmd = &cobra.Command{
 		}
 		labelsMap, _ := store.GetLabelsForIssues(ctx, issueIDs)
 
-		if longFormat {
-			// Long format: multi-line with details
-			fmt.Printf("\nFound %d issues:\n\n", len(issues))
-			for _, issue := range issues {
-				labels := labelsMap[issue.ID]
-
-				fmt.Printf("%s [P%d] [%s] %s\n", issue.ID, issue.Priority, issue.IssueType, issue.Status)
-				fmt.Printf("  %s\n", issue.Title)
-				if issue.Assignee != "" {
-					fmt.Printf("  Assignee: %s\n", issue.Assignee)
-				}
-				if len(labels) > 0 {
-					fmt.Printf("  Labels: %v\n", labels)
-				}
-				fmt.Println()
-			}
-		} else {
-			// Compact format: one line per issue
-			for _, issue := range issues {
-				labels := labelsMap[issue.ID]
-
-				labelsStr := ""
-				if len(labels) > 0 {
-					labelsStr = fmt.Sprintf(" %v", labels)
-				}
-				assigneeStr := ""
-				if issue.Assignee != "" {
-					assigneeStr = fmt.Sprintf(" @%s", issue.Assignee)
-				}
-				fmt.Printf("%s [P%d] [%s] %s%s%s - %s\n",
-					issue.ID, issue.Priority, issue.IssueType, issue.Status,
-					assigneeStr, labelsStr, issue.Title)
-			}
-		}
+		printIssueList(issues, labelsMap, progress, longFormat)
+		printNextCursor(nextCursor)
 
 		// Show tip after successful list (direct mode only)
 		maybeShowTip(store)
@@ -516,19 +548,22 @@ var listCmd = &cobra.Command{
 }
 
 func init() {
-	listCmd.Flags().StringP("status", "s", "", "Filter by status (open, in_progress, blocked, closed)")
+	listCmd.Flags().StringP("status", "s", "", "Filter by status (open, in_progress, blocked, resolved, closed)")
 	registerPriorityFlag(listCmd, "")
 	listCmd.Flags().StringP("assignee", "a", "", "Filter by assignee")
 	listCmd.Flags().StringP("type", "t", "", "Filter by type (bug, feature, task, epic, chore)")
 	listCmd.Flags().StringSliceP("label", "l", []string{}, "Filter by labels (AND: must have ALL). Can combine with --label-any")
 	listCmd.Flags().StringSlice("label-any", []string{}, "Filter by labels (OR: must have AT LEAST ONE). Can combine with --label")
+	listCmd.Flags().StringSlice("complexity", []string{}, "Filter by complexity (trivial, standard, complex, research; OR semantics)")
+	listCmd.Flags().StringArray("field", nil, "Filter by custom field value, name=value (repeatable; AND semantics)")
 	listCmd.Flags().String("title", "", "Filter by title text (case-insensitive substring match)")
+	listCmd.Flags().String("filter", "", "Apply a saved filter (see bd filter); other filter flags narrow it further")
 	listCmd.Flags().String("id", "", "Filter by specific issue IDs (comma-separated, e.g., bd-1,bd-5,bd-10)")
 	listCmd.Flags().IntP("limit", "n", 0, "Limit results")
 	listCmd.Flags().String("format", "", "Output format: 'digraph' (for golang.org/x/tools/cmd/digraph), 'dot' (Graphviz), or Go template")
 	listCmd.Flags().Bool("all", false, "Show all issues (default behavior; flag provided for CLI familiarity)")
 	listCmd.Flags().Bool("long", false, "Show detailed multi-line output for each issue")
-	listCmd.Flags().String("sort", "", "Sort by field: priority, created, updated, closed, status, id, title, type, assignee")
+	listCmd.Flags().String("sort", "", "Sort by comma-separated fields, '-' for descending (e.g. priority,-updated_at,estimate; default: list.sort config)")
 	listCmd.Flags().BoolP("reverse", "r", false, "Reverse sort order")
 	
 	// Pattern matching
@@ -543,6 +578,8 @@ func init() {
 	listCmd.Flags().String("updated-before", "", "Filter issues updated before date (YYYY-MM-DD or RFC3339)")
 	listCmd.Flags().String("closed-after", "", "Filter issues closed after date (YYYY-MM-DD or RFC3339)")
 	listCmd.Flags().String("closed-before", "", "Filter issues closed before date (YYYY-MM-DD or RFC3339)")
+	listCmd.Flags().Bool("closed", false, "Show only closed issues (shorthand for --status closed)")
+	listCmd.Flags().String("reason", "", "Filter closed issues by close reason (e.g. fixed, wontfix, duplicate, obsolete)")
 	
 	// Empty/null checks
 	listCmd.Flags().Bool("empty-description", false, "Filter issues with empty or missing description")
@@ -553,10 +590,113 @@ func init() {
 	listCmd.Flags().String("priority-min", "", "Filter by minimum priority (inclusive, 0-4 or P0-P4)")
 	listCmd.Flags().String("priority-max", "", "Filter by maximum priority (inclusive, 0-4 or P0-P4)")
 	
+	// Cursor pagination
+	listCmd.Flags().String("cursor", "", "Resume after the position in a previous page's next_cursor")
+	listCmd.Flags().Int("page-size", 0, "Return one page of this many issues plus a next_cursor (max 1000)")
+	listCmd.Flags().Bool("stream", false, "Write issues as JSON Lines while they're read, a page at a time (bounded memory for huge lists)")
+
+	// Epic progress
+	listCmd.Flags().Bool("roll-up", false, "Sum estimated minutes over each epic's descendants (total and remaining)")
+	
 	// Note: --json flag is defined as a persistent flag in main.go, not here
 	rootCmd.AddCommand(listCmd)
 }
 
+// printIssueList prints issues in the compact or --long format. labelsMap
+// overrides issue.Labels when non-nil (direct mode loads labels in bulk).
+// Epics with an entry in progress get an inline child completion bar.
+func printIssueList(issues []*types.Issue, labelsMap map[string][]string, progress map[string]*types.ChildProgress, longFormat bool) {
+	labelsFor := func(issue *types.Issue) []string {
+		if labelsMap != nil {
+			return labelsMap[issue.ID]
+		}
+		return issue.Labels
+	}
+
+	if longFormat {
+		// Long format: multi-line with details
+		fmt.Printf("\nFound %d issues:\n\n", len(issues))
+		for _, issue := range issues {
+			labels := labelsFor(issue)
+
+			fmt.Printf("%s [P%d] [%s] %s\n", issue.ID, issue.Priority, issue.IssueType, issue.Status)
+			fmt.Printf("  %s\n", issue.Title)
+			if p := progress[issue.ID]; p != nil {
+				fmt.Printf("  Progress: %s\n", formatChildProgress(p))
+			}
+			if issue.Assignee != "" {
+				fmt.Printf("  Assignee: %s\n", issue.Assignee)
+			}
+			if len(labels) > 0 {
+				fmt.Printf("  Labels: %v\n", labels)
+			}
+			fmt.Println()
+		}
+		return
+	}
+
+	// Compact format: one line per issue
+	for _, issue := range issues {
+		labels := labelsFor(issue)
+
+		labelsStr := ""
+		if len(labels) > 0 {
+			labelsStr = fmt.Sprintf(" %v", labels)
+		}
+		assigneeStr := ""
+		if issue.Assignee != "" {
+			assigneeStr = fmt.Sprintf(" @%s", issue.Assignee)
+		}
+		progressStr := ""
+		if p := progress[issue.ID]; p != nil {
+			progressStr = " " + formatChildProgress(p)
+		}
+		fmt.Printf("%s [P%d] [%s] %s%s%s%s - %s\n",
+			issue.ID, issue.Priority, issue.IssueType, issue.Status,
+			progressStr, assigneeStr, labelsStr, issue.Title)
+	}
+}
+
+// epicBarWidth is the number of cells in the inline epic progress bar
+const epicBarWidth = 10
+
+// formatChildProgress renders child completion as "[███░░░░░░░] 3/10",
+// followed by the estimate roll-up when it was requested
+func formatChildProgress(p *types.ChildProgress) string {
+	filled := 0
+	if p.Total > 0 {
+		filled = p.Closed * epicBarWidth / p.Total
+	}
+	out := fmt.Sprintf("[%s%s] %d/%d",
+		strings.Repeat("█", filled), strings.Repeat("░", epicBarWidth-filled), p.Closed, p.Total)
+	if p.EstimatedMinutes != nil && p.RemainingMinutes != nil {
+		out += fmt.Sprintf(" (est %d min, %d min left)", *p.EstimatedMinutes, *p.RemainingMinutes)
+	}
+	return out
+}
+
+// splitIssueCounts unpacks a daemon list response into the issues and the
+// epic progress attached to them
+func splitIssueCounts(list []*types.IssueWithCounts) ([]*types.Issue, map[string]*types.ChildProgress) {
+	issues := make([]*types.Issue, len(list))
+	progress := make(map[string]*types.ChildProgress)
+	for i, item := range list {
+		issues[i] = item.Issue
+		if item.Progress != nil {
+			progress[item.Issue.ID] = item.Progress
+		}
+	}
+	return issues, progress
+}
+
+// printNextCursor tells the user how to fetch the next page, if there is one
+func printNextCursor(nextCursor string) {
+	if nextCursor == "" {
+		return
+	}
+	fmt.Printf("\nMore issues: repeat with --cursor %s\n", nextCursor)
+}
+
 // outputDotFormat outputs issues in Graphviz DOT format
 func outputDotFormat(ctx context.Context, store storage.Storage, issues []*types.Issue) error {
 	fmt.Println("digraph dependencies {")
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// issuesWithCounts loads labels and custom fields into issues and pairs each
+// with its dependency counts and epic progress, as bd list --json prints them
+func issuesWithCounts(ctx context.Context, issues []*types.Issue, progress map[string]*types.ChildProgress) []*types.IssueWithCounts {
+	// Get labels and dependency counts in bulk (single query instead of N queries)
+	issueIDs := make([]string, len(issues))
+	for i, issue := range issues {
+		issueIDs[i] = issue.ID
+	}
+	labelsMap, _ := store.GetLabelsForIssues(ctx, issueIDs)
+	fieldsMap, _ := store.GetFieldsForIssues(ctx, issueIDs)
+	depCounts, _ := store.GetDependencyCounts(ctx, issueIDs)
+
+	counted := make([]*types.IssueWithCounts, len(issues))
+	for i, issue := range issues {
+		issue.Labels = labelsMap[issue.ID]
+		issue.Fields = fieldsMap[issue.ID]
+		counts := depCounts[issue.ID]
+		if counts == nil {
+			counts = &types.DependencyCounts{DependencyCount: 0, DependentCount: 0}
+		}
+		counted[i] = &types.IssueWithCounts{
+			Issue:           issue,
+			DependencyCount: counts.DependencyCount,
+			DependentCount:  counts.DependentCount,
+			Progress:        progress[issue.ID],
+		}
+	}
+	return counted
+}
+
+// issueStream writes bd list --stream output: one JSON object per line,
+// flushed after every page so consumers see results as they're read
+type issueStream struct {
+	w   *bufio.Writer
+	enc *json.Encoder
+}
+
+func newIssueStream() *issueStream {
+	w := bufio.NewWriter(os.Stdout)
+	return &issueStream{w: w, enc: json.NewEncoder(w)}
+}
+
+func (s *issueStream) writePage(issues []*types.IssueWithCounts) error {
+	for _, issue := range issues {
+		if err := s.enc.Encode(issue); err != nil {
+			return err
+		}
+	}
+	return s.w.Flush()
+}
+
+// streamIssuesDirect streams the issues matching filter from the database,
+// holding one page in memory at a time
+func streamIssuesDirect(ctx context.Context, filter types.IssueFilter, rollUp bool) error {
+	out := newIssueStream()
+	return storage.ForEachIssuePage(ctx, store, "", filter, storage.MaxPageSize, func(issues []*types.Issue) error {
+		decryptForDisplay(issues...)
+		progress, err := storage.ChildProgress(ctx, store, storage.EpicIDs(issues), rollUp)
+		if err != nil {
+			return err
+		}
+		return out.writePage(issuesWithCounts(ctx, issues, progress))
+	})
+}
+
+// streamIssuesDaemon streams the issues matching args a page at a time
+// through the daemon. limit, if set, caps the total.
+func streamIssuesDaemon(args *rpc.ListArgs, limit int) error {
+	out := newIssueStream()
+	args.Limit = 0
+	for remaining := limit; ; {
+		args.PageSize = storage.MaxPageSize
+		if remaining > 0 && remaining < args.PageSize {
+			args.PageSize = remaining
+		}
+		resp, err := daemonClient.List(args)
+		if err != nil {
+			return err
+		}
+		var page rpc.ListPage
+		if err := json.Unmarshal(resp.Data, &page); err != nil {
+			return fmt.Errorf("parsing response: %w", err)
+		}
+		decryptCountedForDisplay(page.Issues)
+		if err := out.writePage(page.Issues); err != nil {
+			return err
+		}
+		if remaining > 0 {
+			if remaining -= len(page.Issues); remaining <= 0 {
+				return nil
+			}
+		}
+		if page.NextCursor == "" {
+			return nil
+		}
+		args.Cursor = page.NextCursor
+	}
+}
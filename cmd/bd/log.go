@@ -0,0 +1,328 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// LogEntry is one event in bd log
+type LogEntry struct {
+	Project   string          `json:"project,omitempty"` // With --all-projects
+	EventID   int64           `json:"event_id"`
+	IssueID   string          `json:"issue_id"`
+	Title     string          `json:"title,omitempty"`
+	EventType types.EventType `json:"event_type"`
+	Actor     string          `json:"actor"`
+	Summary   string          `json:"summary"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+var logCmd = &cobra.Command{
+	Use:   "log",
+	Short: "Show the activity log: who did what to which issue",
+	Long: `Show the project's activity log, oldest first: issues created, updated,
+closed and reopened, comments, labels and dependencies, with who made each
+change. By default it shows today's activity.
+
+--since and --until take how long ago ("30m", "2h", "3d") or a time
+(2006-01-02, 2006-01-02T15:04:05, RFC3339).
+
+--all-projects reads every project registered with 'bd project add' (and
+the current project), one project after another. Add --merge to interleave
+them into a single chronological stream with each line prefixed by its
+project: one audit trail of everything people and agents did across the
+workspace.
+
+Examples:
+  bd log                                # Today, this project
+  bd log --since 2h --actor claude-3    # One agent's last two hours
+  bd log --all-projects --merge         # Today, across the workspace
+  bd log --all-projects --merge --since 2025-06-01 --until 2025-06-02 --json`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, _ []string) {
+		sinceFlag, _ := cmd.Flags().GetString("since")
+		untilFlag, _ := cmd.Flags().GetString("until")
+		actorFilter, _ := cmd.Flags().GetString("actor")
+		limit, _ := cmd.Flags().GetInt("limit")
+		allProjects, _ := cmd.Flags().GetBool("all-projects")
+		merge, _ := cmd.Flags().GetBool("merge")
+
+		if merge && !allProjects {
+			FatalErrorWithHint("--merge interleaves projects", "use it with --all-projects")
+		}
+		now := time.Now()
+		since := startOfDay(now)
+		if sinceFlag != "" {
+			t, err := parseSinceFlag("--since", sinceFlag, now)
+			if err != nil {
+				FatalError("%v", err)
+			}
+			since = t
+		}
+		var until time.Time
+		if untilFlag != "" {
+			t, err := parseSinceFlag("--until", untilFlag, now)
+			if err != nil {
+				FatalError("%v", err)
+			}
+			until = t
+			if !until.After(since) {
+				FatalError("--until must be after --since (%s)", displayTime(since))
+			}
+		}
+		filter := func(entries []*LogEntry) []*LogEntry {
+			if actorFilter != "" {
+				var kept []*LogEntry
+				for _, e := range entries {
+					if e.Actor == actorFilter {
+						kept = append(kept, e)
+					}
+				}
+				entries = kept
+			}
+			if limit > 0 && len(entries) > limit {
+				entries = entries[len(entries)-limit:]
+			}
+			return entries
+		}
+
+		if !allProjects {
+			if err := ensureDirectMode("log requires direct database access"); err != nil {
+				FatalError("%v", err)
+			}
+			sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+			if !ok {
+				FatalError("log requires the SQLite backend")
+			}
+			if err := ensureDatabaseFresh(rootCtx); err != nil {
+				FatalError("%v", err)
+			}
+			activity, err := sqliteStore.GetActivity(rootCtx, since, until)
+			if err != nil {
+				FatalError("%v", err)
+			}
+			entries := filter(logEntries("", activity))
+			if jsonOutput {
+				outputJSON(nonNilEntries(entries))
+				return
+			}
+			printLog(entries, since, false)
+			return
+		}
+
+		projectList, err := workspaceProjects()
+		if err != nil {
+			FatalError("%v", err)
+		}
+		if len(projectList) == 0 {
+			FatalErrorWithHint("no workspace projects", "register projects with 'bd project add <path>'")
+		}
+		var streams [][]*LogEntry
+		for _, p := range projectList {
+			activity, err := loadWorkspaceActivity(rootCtx, p, since, until)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: skipping project %s: %v\n", p.Name, err)
+				continue
+			}
+			streams = append(streams, logEntries(p.Name, activity))
+		}
+
+		var entries []*LogEntry
+		if merge {
+			entries = filter(mergeLogStreams(streams))
+		} else {
+			for _, stream := range streams {
+				entries = append(entries, filter(stream)...)
+			}
+		}
+		if jsonOutput {
+			outputJSON(nonNilEntries(entries))
+			return
+		}
+		printLog(entries, since, merge)
+	},
+}
+
+func init() {
+	logCmd.Flags().String("since", "", "Show activity from this long ago or this time on (default: start of today)")
+	logCmd.Flags().String("until", "", "Show activity before this time or this long ago")
+	logCmd.Flags().String("actor", "", "Only show changes made by this actor")
+	logCmd.Flags().IntP("limit", "n", 0, "Show only the most recent N events (per project unless --merge)")
+	logCmd.Flags().Bool("all-projects", false, "Show the activity of every registered project")
+	logCmd.Flags().Bool("merge", false, "Interleave the projects' activity chronologically (with --all-projects)")
+	rootCmd.AddCommand(logCmd)
+}
+
+// startOfDay returns midnight of t's day, in t's location
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// loadWorkspaceActivity reads a project's activity from its database
+func loadWorkspaceActivity(ctx context.Context, p *workspaceProject, since, until time.Time) ([]*sqlite.ActivityEvent, error) {
+	path := projectDatabasePath(filepath.Join(p.Path, ".beads"))
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("no database at %s", path)
+	}
+	s, err := sqlite.New(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = s.Close() }()
+	return s.GetActivity(ctx, since, until)
+}
+
+func logEntries(project string, activity []*sqlite.ActivityEvent) []*LogEntry {
+	entries := make([]*LogEntry, 0, len(activity))
+	for _, a := range activity {
+		entries = append(entries, &LogEntry{
+			Project:   project,
+			EventID:   a.ID,
+			IssueID:   a.IssueID,
+			Title:     a.Title,
+			EventType: a.EventType,
+			Actor:     a.Actor,
+			Summary:   summarizeEvent(&a.Event),
+			CreatedAt: a.CreatedAt,
+		})
+	}
+	return entries
+}
+
+// mergeLogStreams interleaves per-project streams, each already in order,
+// into one ordered by time. Events at the same instant keep their project
+// order, then their order within the project.
+func mergeLogStreams(streams [][]*LogEntry) []*LogEntry {
+	var merged []*LogEntry
+	for _, stream := range streams {
+		merged = append(merged, stream...)
+	}
+	sort.SliceStable(merged, func(i, j int) bool { return merged[i].CreatedAt.Before(merged[j].CreatedAt) })
+	return merged
+}
+
+func nonNilEntries(entries []*LogEntry) []*LogEntry {
+	if entries == nil {
+		return []*LogEntry{}
+	}
+	return entries
+}
+
+// summarizeEvent describes what an event changed, in a few words
+func summarizeEvent(e *types.Event) string {
+	comment := ""
+	if e.Comment != nil {
+		comment = firstLine(*e.Comment)
+	}
+	switch e.EventType {
+	case types.EventCreated:
+		return "created"
+	case types.EventClosed:
+		if comment != "" {
+			return "closed: " + comment
+		}
+		return "closed"
+	case types.EventReopened:
+		return "reopened"
+	case types.EventStatusChanged:
+		if status := changedField(e.NewValue, "status"); status != "" {
+			return "status → " + status
+		}
+		return "status changed"
+	case types.EventUpdated:
+		if fields := changedFields(e.NewValue); len(fields) > 0 {
+			return "updated " + strings.Join(fields, ", ")
+		}
+		return "updated"
+	case types.EventCommented:
+		return "commented: " + comment
+	case "renamed":
+		if e.OldValue != nil {
+			return "renamed from " + *e.OldValue
+		}
+	}
+	if comment != "" {
+		return comment
+	}
+	return strings.ReplaceAll(string(e.EventType), "_", " ")
+}
+
+// changedFields returns the fields in an update event's new value, sorted
+func changedFields(value *string) []string {
+	if value == nil {
+		return nil
+	}
+	var updates map[string]interface{}
+	if err := json.Unmarshal([]byte(*value), &updates); err != nil {
+		return nil
+	}
+	fields := make([]string, 0, len(updates))
+	for field := range updates {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// changedField returns a string field from an update event's new value
+func changedField(value *string, field string) string {
+	if value == nil {
+		return ""
+	}
+	var updates map[string]interface{}
+	if err := json.Unmarshal([]byte(*value), &updates); err != nil {
+		return ""
+	}
+	s, _ := updates[field].(string)
+	return s
+}
+
+// firstLine returns the first line of text, shortened to fit a log line
+func firstLine(text string) string {
+	text, _, _ = strings.Cut(strings.TrimSpace(text), "\n")
+	return truncateTitle(text, 80)
+}
+
+func printLog(entries []*LogEntry, since time.Time, merged bool) {
+	if len(entries) == 0 {
+		fmt.Printf("No activity since %s\n", displayTime(since))
+		return
+	}
+	cyan := color.New(color.FgCyan).SprintFunc()
+	dim := color.New(color.Faint).SprintFunc()
+
+	width := 0
+	for _, e := range entries {
+		width = max(width, len([]rune(e.Project)))
+	}
+	project := ""
+	for _, e := range entries {
+		prefix := ""
+		if merged {
+			prefix = cyan(padRight(e.Project, width)) + "  "
+		} else if e.Project != project {
+			if project != "" {
+				fmt.Println()
+			}
+			project = e.Project
+			fmt.Printf("%s\n", cyan("== "+project+" =="))
+		}
+		line := fmt.Sprintf("%s  %s%s  %s %s", dim(displayTime(e.CreatedAt)), prefix, e.Actor, e.IssueID, e.Summary)
+		if e.Title != "" {
+			line += dim(" — " + truncateTitle(e.Title, 60))
+		}
+		fmt.Println(line)
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestLogEntriesAndMerge(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	s := newTestStore(t, filepath.Join(dir, ".beads", "beads.db"))
+
+	issue := &types.Issue{Title: "Login broken", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeBug}
+	if err := s.CreateIssue(ctx, issue, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.UpdateIssue(ctx, issue.ID, map[string]interface{}{"status": string(types.StatusInProgress)}, "agent-7"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.UpdateIssue(ctx, issue.ID, map[string]interface{}{"priority": 0, "title": "Login broken on Safari"}, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.CloseIssue(ctx, issue.ID, "fixed upstream\nsee lib", "agent-7"); err != nil {
+		t.Fatal(err)
+	}
+	activity, err := s.GetActivity(ctx, time.Now().Add(-time.Hour), time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var summaries []string
+	for _, e := range logEntries("app", activity) {
+		summaries = append(summaries, e.Actor+": "+e.Summary)
+	}
+	want := "alice: created | agent-7: status → in_progress | alice: updated priority, title | agent-7: closed: fixed upstream"
+	if got := strings.Join(summaries, " | "); got != want {
+		t.Errorf("summaries = %q, want %q", got, want)
+	}
+
+	// Streams interleave by time; ties keep project order
+	t0 := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	entry := func(project string, minutes int) *LogEntry {
+		return &LogEntry{Project: project, IssueID: project + "-1", CreatedAt: t0.Add(time.Duration(minutes) * time.Minute)}
+	}
+	merged := mergeLogStreams([][]*LogEntry{
+		{entry("app", 0), entry("app", 5), entry("app", 9)},
+		{entry("lib", 1), entry("lib", 5)},
+		nil,
+	})
+	var order []string
+	for _, e := range merged {
+		order = append(order, e.Project)
+	}
+	if got := strings.Join(order, " "); got != "app lib app lib app" {
+		t.Errorf("merged order = %q", got)
+	}
+}
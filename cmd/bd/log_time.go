@@ -0,0 +1,193 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var logTimeCmd = &cobra.Command{
+	Use:   "log-time <id> [duration]",
+	Short: "Log time spent on an issue",
+	Long: `Log time spent on an issue, or list the time logged on it.
+
+The duration is a number of minutes or a Go duration such as 45m, 1h30m or
+1.5h, rounded to the minute. Entries are recorded for the actor (--actor,
+BD_ACTOR or your user name), so agents and people can log separately and
+their effort be compared: bd show totals an issue's time per actor, and
+bd stats totals it per actor across issues.
+
+Entries are exported to JSONL with the issue (work_log) and imported back,
+so every clone sees the same log.
+
+Examples:
+  bd log-time bd-42 45m --note "debugging the flaky test"
+  bd log-time bd-42 1h30m --actor claude
+  bd log-time bd-42 90                   # Minutes
+  bd log-time bd-42                      # List the issue's entries`,
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		note, _ := cmd.Flags().GetString("note")
+
+		if err := ensureDirectMode("log-time requires direct database access"); err != nil {
+			FatalError("%v", err)
+		}
+		ctx := rootCtx
+		if err := ensureDatabaseFresh(ctx); err != nil {
+			FatalError("%v", err)
+		}
+		issueID, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			FatalError("%v", err)
+		}
+
+		if len(args) == 1 {
+			if note != "" {
+				FatalError("--note needs a duration to log")
+			}
+			runLogTimeList(ctx, issueID)
+			return
+		}
+
+		CheckReadonly("log-time")
+		minutes, err := parseWorkDuration(args[1])
+		if err != nil {
+			FatalError("%v", err)
+		}
+		entry := &types.WorkEntry{IssueID: issueID, Actor: actor, Minutes: minutes, Note: note}
+		if err := store.AddWorkEntry(ctx, entry); err != nil {
+			FatalError("%v", err)
+		}
+		markDirtyAndScheduleFlush()
+
+		if jsonOutput {
+			outputJSON(entry)
+			return
+		}
+		green := color.New(color.FgGreen).SprintFunc()
+		fmt.Printf("%s Logged %s on %s", green("✓"), formatWorkMinutes(minutes), issueID)
+		if totals := issueTimeLogged(ctx, store, issueID); totals != nil {
+			fmt.Printf(" (%s in total)", formatWorkMinutes(totals.Minutes))
+		}
+		fmt.Println()
+	},
+}
+
+func runLogTimeList(ctx context.Context, issueID string) {
+	entries, err := store.GetWorkLog(ctx, issueID)
+	if err != nil {
+		FatalError("%v", err)
+	}
+	if jsonOutput {
+		if entries == nil {
+			entries = []*types.WorkEntry{}
+		}
+		outputJSON(map[string]interface{}{"id": issueID, "entries": entries, "totals": types.SumWorkLog(entries)})
+		return
+	}
+	if len(entries) == 0 {
+		fmt.Printf("No time logged on %s\n", issueID)
+		return
+	}
+	fmt.Printf("Time logged on %s:\n", issueID)
+	for _, e := range entries {
+		line := fmt.Sprintf("  %s  %-8s %-16s %s", displayTime(e.CreatedAt), formatWorkMinutes(e.Minutes), e.Actor, e.Note)
+		fmt.Println(strings.TrimRight(line, " "))
+	}
+	fmt.Printf("Total: %s\n", formatTimeLogged(types.SumWorkLog(entries)))
+}
+
+// parseWorkDuration reads a duration to log: whole minutes ("90") or a Go
+// duration ("45m", "1h30m", "1.5h"), rounded to the minute
+func parseWorkDuration(s string) (int, error) {
+	s = strings.TrimSpace(s)
+	minutes := 0
+	if n, err := strconv.Atoi(s); err == nil {
+		minutes = n
+	} else {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q (use minutes or e.g. 45m, 1h30m)", s)
+		}
+		minutes = int(math.Round(d.Minutes()))
+	}
+	if minutes <= 0 {
+		return 0, fmt.Errorf("duration %q must be at least a minute", s)
+	}
+	return minutes, nil
+}
+
+// formatWorkMinutes renders logged time as hours and minutes: 45m, 2h, 1h30m
+func formatWorkMinutes(minutes int) string {
+	h, m := minutes/60, minutes%60
+	switch {
+	case h == 0:
+		return fmt.Sprintf("%dm", m)
+	case m == 0:
+		return fmt.Sprintf("%dh", h)
+	default:
+		return fmt.Sprintf("%dh%02dm", h, m)
+	}
+}
+
+// formatTimeLogged renders a total with its per-actor breakdown, most time
+// first: "2h15m (alice 1h30m, claude 45m)"
+func formatTimeLogged(totals *types.WorkLogTotals) string {
+	actors := actorsByTime(totals.ByActor)
+	parts := make([]string, len(actors))
+	for i, a := range actors {
+		parts[i] = a + " " + formatWorkMinutes(totals.ByActor[a])
+	}
+	return fmt.Sprintf("%s (%s)", formatWorkMinutes(totals.Minutes), strings.Join(parts, ", "))
+}
+
+// actorsByTime orders actors by time logged, most first
+func actorsByTime(byActor map[string]int) []string {
+	actors := make([]string, 0, len(byActor))
+	for a := range byActor {
+		actors = append(actors, a)
+	}
+	sort.Slice(actors, func(i, j int) bool {
+		if byActor[actors[i]] != byActor[actors[j]] {
+			return byActor[actors[i]] > byActor[actors[j]]
+		}
+		return actors[i] < actors[j]
+	})
+	return actors
+}
+
+// issueTimeLogged totals an issue's work log, or returns nil if nothing is
+// logged (or the log can't be read)
+func issueTimeLogged(ctx context.Context, s storage.Storage, issueID string) *types.WorkLogTotals {
+	entries, err := s.GetWorkLog(ctx, issueID)
+	if err != nil || len(entries) == 0 {
+		return nil
+	}
+	return types.SumWorkLog(entries)
+}
+
+// printTimeLogged prints the 'bd stats' breakdown of logged time by actor
+func printTimeLogged(byActor map[string]int) {
+	if len(byActor) == 0 {
+		return
+	}
+	fmt.Printf("\nTime Logged by Actor:\n")
+	for _, a := range actorsByTime(byActor) {
+		fmt.Printf("  %-21s %s\n", a+":", formatWorkMinutes(byActor[a]))
+	}
+}
+
+func init() {
+	logTimeCmd.Flags().StringP("note", "n", "", "What the time was spent on")
+	rootCmd.AddCommand(logTimeCmd)
+}
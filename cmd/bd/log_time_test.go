@@ -0,0 +1,54 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestParseWorkDuration(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int
+	}{
+		{"45", 45},
+		{"45m", 45},
+		{"1h30m", 90},
+		{"1.5h", 90},
+		{"90s", 2},
+		{"29s", 0}, // under half a minute rounds to nothing
+		{"0", 0},
+		{"-5m", 0},
+		{"soon", 0},
+	}
+	for _, tt := range tests {
+		got, err := parseWorkDuration(tt.in)
+		if tt.want == 0 {
+			if err == nil {
+				t.Errorf("parseWorkDuration(%q) = %d, want error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("parseWorkDuration(%q) = %d, %v; want %d", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestFormatTimeLogged(t *testing.T) {
+	for minutes, want := range map[int]string{5: "5m", 120: "2h", 65: "1h05m", 1530: "25h30m"} {
+		if got := formatWorkMinutes(minutes); got != want {
+			t.Errorf("formatWorkMinutes(%d) = %q, want %q", minutes, got, want)
+		}
+	}
+
+	totals := types.SumWorkLog([]*types.WorkEntry{
+		{Actor: "claude", Minutes: 45},
+		{Actor: "alice", Minutes: 60},
+		{Actor: "alice", Minutes: 30},
+		{Actor: "bob", Minutes: 45},
+	})
+	if got, want := formatTimeLogged(totals), "3h (alice 1h30m, bob 45m, claude 45m)"; got != want {
+		t.Errorf("formatTimeLogged = %q, want %q", got, want)
+	}
+}
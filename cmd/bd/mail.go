@@ -412,7 +412,7 @@ func runMailRead(cmd *cobra.Command, args []string) error {
 	fmt.Printf("From:    %s\n", issue.Sender)
 	fmt.Printf("To:      %s\n", issue.Assignee)
 	fmt.Printf("Subject: %s\n", issue.Title)
-	fmt.Printf("Time:    %s\n", issue.CreatedAt.Format("2006-01-02 15:04:05"))
+	fmt.Printf("Time:    %s\n", displayTime(issue.CreatedAt))
 	if issue.Priority <= 1 {
 		fmt.Printf("Priority: P%d\n", issue.Priority)
 	}
@@ -22,7 +22,6 @@ import (
 	"github.com/steveyegge/beads/internal/rpc"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/memory"
-	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/utils"
 )
 
@@ -53,6 +52,8 @@ const (
 	FallbackAutoStartDisabled = "auto_start_disabled"
 	FallbackAutoStartFailed   = "auto_start_failed"
 	FallbackDaemonUnsupported = "daemon_unsupported"
+	FallbackReadOnlyStorage   = "read_only_storage"
+	FallbackPostgresBackend   = "postgres_backend"
 )
 
 var (
@@ -111,8 +112,9 @@ var (
 	profileEnabled bool
 	profileFile    *os.File
 	traceFile      *os.File
-	verboseFlag    bool // Enable verbose/debug output
-	quietFlag      bool // Suppress non-essential output
+	verboseFlag    bool   // Enable verbose/debug output
+	quietFlag      bool   // Suppress non-essential output
+	projectFlag    string // Run against a registered project name or path
 )
 
 func init() {
@@ -136,6 +138,7 @@ func init() {
 	rootCmd.PersistentFlags().BoolVar(&profileEnabled, "profile", false, "Generate CPU profile for performance analysis")
 	rootCmd.PersistentFlags().BoolVarP(&verboseFlag, "verbose", "v", false, "Enable verbose/debug output")
 	rootCmd.PersistentFlags().BoolVarP(&quietFlag, "quiet", "q", false, "Suppress non-essential output (errors only)")
+	rootCmd.PersistentFlags().StringVar(&projectFlag, "project", "", "Run against a registered project name or a project path (like git -C)")
 
 	// Add --version flag to root command (same behavior as version subcommand)
 	rootCmd.Flags().BoolP("version", "V", false, "Print version information")
@@ -162,6 +165,12 @@ var rootCmd = &cobra.Command{
 		debug.SetVerbose(verboseFlag)
 		debug.SetQuiet(quietFlag)
 
+		// --project switches into the project before config and database
+		// discovery, so everything behaves as if bd ran from there
+		if projectFlag != "" {
+			switchToProject(projectFlag)
+		}
+
 		// Apply viper configuration if flags weren't explicitly set
 		// Priority: flags > viper (config file + env vars) > defaults
 		// Do this BEFORE early-return so init/version/help respect config
@@ -215,19 +224,28 @@ var rootCmd = &cobra.Command{
 		// Skip database initialization for commands that don't need a database
 		noDbCommands := []string{
 			cmdDaemon,
+			"auth",
 			"bash",
+			"capabilities",
 			"completion",
+			"demo",
 			"doctor",
 			"fish",
 			"help",
 			"hooks",
 			"init",
 			"merge",
+			"merge-driver",
 			"onboard",
+			"plugins",
 			"powershell",
 			"prime",
+			"project",
 			"quickstart",
+			"replay",
+			"self-update",
 			"setup",
+			"tenant",
 			"version",
 			"zsh",
 		}
@@ -242,12 +260,37 @@ var rootCmd = &cobra.Command{
 		if slices.Contains(noDbCommands, cmdName) {
 			return
 		}
+		// Plugins find the database themselves, after parsing bd's flags
+		if _, ok := cmd.Annotations[pluginAnnotation]; ok {
+			return
+		}
 
 		// Also skip for --version flag on root command (cmdName would be "bd")
 		if v, _ := cmd.Flags().GetBool("version"); v {
 			return
 		}
 
+		// Multi-tenant hosting opens per-tenant databases from --data-dir
+		if cmd.Name() == "serve" {
+			if mt, _ := cmd.Flags().GetBool("multi-tenant"); mt {
+				return
+			}
+		}
+
+		// A workspace duplicate scan opens each project's database itself
+		if cmd.Name() == "duplicates" {
+			if ws, _ := cmd.Flags().GetBool("workspace"); ws {
+				return
+			}
+		}
+
+		// So does a workspace activity log
+		if cmd.Name() == "log" && !cmd.Parent().HasParent() {
+			if all, _ := cmd.Flags().GetBool("all-projects"); all {
+				return
+			}
+		}
+
 		// Auto-detect sandboxed environment (bd-u3t: Phase 2 for GH #353)
 		// Only auto-enable if user hasn't explicitly set --sandbox or --no-daemon
 		if !cmd.Flags().Changed("sandbox") && !cmd.Flags().Changed("no-daemon") {
@@ -302,6 +345,21 @@ var rootCmd = &cobra.Command{
 			return
 		}
 
+		// Remote daemon (BEADS_DAEMON_ADDR): commands go to a daemon on another
+		// machine, and no local database is involved
+		if addr := config.GetString("daemon-addr"); addr != "" {
+			if actor == "" {
+				if user := os.Getenv("USER"); user != "" {
+					actor = user
+				} else {
+					actor = "unknown"
+				}
+			}
+			startOperation()
+			connectRemoteDaemon(addr)
+			return
+		}
+
 		// Initialize database path
 		if dbPath == "" {
 			// Use public API to find database (same logic as extensions)
@@ -386,10 +444,20 @@ var rootCmd = &cobra.Command{
 				actor = "unknown"
 			}
 		}
+		startOperation()
+
+		// A database bd can't write to (read-only mount, CI cache) can still be
+		// read: serve reads from it and refuse writes up front, rather than
+		// failing on SQLite errors
+		if reason := storageNotWritable(dbPath); reason != "" {
+			enterReadonlyStorage(reason)
+		}
 
 		// Track bd version changes (bd-loka)
 		// Best-effort tracking - failures are silent
-		trackBdVersion()
+		if readonlyReason == "" {
+			trackBdVersion()
+		}
 
 		// Initialize daemon status
 		socketPath := getSocketPath()
@@ -403,9 +471,17 @@ var rootCmd = &cobra.Command{
 		}
 
 		// Try to connect to daemon first (unless --no-daemon flag is set or worktree safety check fails)
-		if noDaemon {
+		if readonlyReason != "" {
+			daemonStatus.FallbackReason = FallbackReadOnlyStorage
+			daemonStatus.Detail = readonlyReason
+		} else if noDaemon {
 			daemonStatus.FallbackReason = FallbackFlagNoDaemon
 			debug.Logf("--no-daemon flag set, using direct mode")
+		} else if usesPostgres(dbPath) {
+			// Every client shares the server, so there is no local database
+			// for a daemon to serve
+			daemonStatus.FallbackReason = FallbackPostgresBackend
+			debug.Logf("postgres backend, using direct mode")
 		} else if shouldDisableDaemonForWorktree() {
 			// In a git worktree without sync-branch configured - daemon is unsafe
 			// because all worktrees share the same .beads directory and the daemon
@@ -442,6 +518,7 @@ var rootCmd = &cobra.Command{
 								}
 								health, healthErr = client.Health()
 								if healthErr == nil && health.Status == statusHealthy {
+									client.SetOperation(&cmdOperation)
 									daemonClient = client
 									daemonStatus.Mode = cmdDaemon
 									daemonStatus.Connected = true
@@ -459,6 +536,7 @@ var rootCmd = &cobra.Command{
 							health.Version, Version)
 					} else {
 						// Daemon is healthy and compatible - use it
+						client.SetOperation(&cmdOperation)
 						daemonClient = client
 						daemonStatus.Mode = cmdDaemon
 						daemonStatus.Connected = true
@@ -509,6 +587,7 @@ var rootCmd = &cobra.Command{
 						// Check health of auto-started daemon
 						health, healthErr := client.Health()
 						if healthErr == nil && health.Status == statusHealthy {
+							client.SetOperation(&cmdOperation)
 							daemonClient = client
 							daemonStatus.Mode = cmdDaemon
 							daemonStatus.Connected = true
@@ -573,11 +652,13 @@ var rootCmd = &cobra.Command{
 		// Auto-migrate database on version bump (bd-jgxi)
 		// Do this AFTER daemon check but BEFORE opening database for main operation
 		// This ensures: 1) no daemon has DB open, 2) we don't open DB twice
-		autoMigrateOnVersionBump(dbPath)
+		if readonlyReason == "" {
+			autoMigrateOnVersionBump(dbPath)
+		}
 
 		// Fall back to direct storage access
 		var err error
-		store, err = sqlite.NewWithTimeout(rootCtx, dbPath, lockTimeout)
+		store, err = openStore(rootCtx, dbPath, readonlyReason != "")
 		if err != nil {
 			// Check for fresh clone scenario (bd-dmb)
 			beadsDir := filepath.Dir(dbPath)
@@ -611,7 +692,10 @@ var rootCmd = &cobra.Command{
 		}
 
 		// Warn if multiple databases detected in directory hierarchy
-		warnMultipleDatabases(dbPath)
+		// (a subproject nested inside its parent is intentional)
+		if parent, _ := store.GetConfig(rootCtx, subprojectParentKey); parent == "" {
+			warnMultipleDatabases(dbPath)
+		}
 
 		// Auto-import if JSONL is newer than DB (e.g., after git pull)
 		// Skip for import command itself to avoid recursion
@@ -698,9 +782,13 @@ var rootCmd = &cobra.Command{
 // Defaults to 5 seconds if not set or invalid
 
 func main() {
+	startJournal()
+	registerPlugins()
 	if err := rootCmd.Execute(); err != nil {
+		finishJournal(1, err.Error())
 		os.Exit(1)
 	}
+	finishJournal(0, "")
 }
 
 // isFreshCloneError checks if the error is due to a fresh clone scenario
@@ -5,6 +5,7 @@ package main
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -32,6 +33,7 @@ type IssueTemplate struct {
 	Description        string
 	Design             string
 	AcceptanceCriteria string
+	Notes              string
 	Priority           int
 	IssueType          types.IssueType
 	Assignee           string
@@ -94,6 +96,8 @@ func processIssueSection(issue *IssueTemplate, section, content string) {
 		issue.Design = content
 	case "acceptance criteria", "acceptance":
 		issue.AcceptanceCriteria = content
+	case "notes":
+		issue.Notes = content
 	case "assignee":
 		issue.Assignee = strings.TrimSpace(content)
 	case "labels":
@@ -154,6 +158,9 @@ func validateMarkdownPath(path string) (string, error) {
 //	- Criterion 1
 //	- Criterion 2
 //
+//	### Notes
+//	Working notes...
+//
 //	### Assignee
 //	username
 //
@@ -250,8 +257,8 @@ func (s *markdownParseState) finalize() ([]*IssueTemplate, error) {
 }
 
 // createMarkdownScanner creates a scanner with appropriate buffer size
-func createMarkdownScanner(file *os.File) *bufio.Scanner {
-	scanner := bufio.NewScanner(file)
+func createMarkdownScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
 	// Increase buffer size for large markdown files
 	const maxScannerBuffer = 1024 * 1024 // 1MB
 	buf := make([]byte, maxScannerBuffer)
@@ -274,9 +281,14 @@ func parseMarkdownFile(path string) ([]*IssueTemplate, error) {
 	defer func() {
 		_ = file.Close() // Close errors on read-only operations are not actionable
 	}()
+	return parseMarkdown(file)
+}
 
+// parseMarkdown extracts issue templates from markdown in the format
+// parseMarkdownFile documents
+func parseMarkdown(r io.Reader) ([]*IssueTemplate, error) {
 	state := &markdownParseState{}
-	scanner := createMarkdownScanner(file)
+	scanner := createMarkdownScanner(r)
 
 	for scanner.Scan() {
 		line := scanner.Text()
@@ -317,7 +329,12 @@ func createIssuesFromMarkdown(_ *cobra.Command, filepath string) {
 		fmt.Fprintf(os.Stderr, "No issues found in markdown file\n")
 		os.Exit(1)
 	}
+	createIssuesFromTemplates(templates, filepath)
+}
 
+// createIssuesFromTemplates creates the parsed issues, reporting them as
+// created from source
+func createIssuesFromTemplates(templates []*IssueTemplate, source string) {
 	ctx := rootCtx
 	createdIssues := []*types.Issue{}
 	failedIssues := []string{}
@@ -329,6 +346,7 @@ func createIssuesFromMarkdown(_ *cobra.Command, filepath string) {
 			Description:        template.Description,
 			Design:             template.Design,
 			AcceptanceCriteria: template.AcceptanceCriteria,
+			Notes:              template.Notes,
 			Status:             types.StatusOpen,
 			Priority:           template.Priority,
 			IssueType:          template.IssueType,
@@ -408,7 +426,7 @@ func createIssuesFromMarkdown(_ *cobra.Command, filepath string) {
 		outputJSON(createdIssues)
 	} else {
 		green := color.New(color.FgGreen).SprintFunc()
-		fmt.Printf("%s Created %d issues from %s:\n", green("✓"), len(createdIssues), filepath)
+		fmt.Printf("%s Created %d issues from %s:\n", green("✓"), len(createdIssues), source)
 		for _, issue := range createdIssues {
 			fmt.Printf("  %s: %s [P%d, %s]\n", issue.ID, issue.Title, issue.Priority, issue.IssueType)
 		}
@@ -0,0 +1,299 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+var (
+	// todoHeadingRegex matches ATX headings (# to ######), ignoring closing #s
+	todoHeadingRegex = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+
+	// todoCheckboxRegex matches task list items: "- [ ] text", "* [x] text",
+	// "1. [X] text"
+	todoCheckboxRegex = regexp.MustCompile(`^(\s*)(?:[-*+]|\d+[.)])\s+\[([ xX])\]\s+(.*)$`)
+
+	// todoFenceRegex matches the start or end of a fenced code block
+	todoFenceRegex = regexp.MustCompile("^\\s*(```|~~~)")
+)
+
+// markdownTodo is one issue parsed from a TODO-style markdown file: a
+// heading or a checkbox, with the headings and checkboxes nested under it
+type markdownTodo struct {
+	Title       string          `json:"title"`
+	Description string          `json:"description,omitempty"`
+	Heading     bool            `json:"heading,omitempty"`
+	Checked     bool            `json:"checked,omitempty"`
+	Line        int             `json:"line"`
+	Children    []*markdownTodo `json:"children,omitempty"`
+
+	desc   []string
+	indent int
+}
+
+// parseMarkdownTodos turns a TODO-style markdown file into a tree of
+// issues. Headings nest by level, checkboxes nest by indentation under the
+// heading (or checkbox) above them, and other text becomes the description
+// of the heading or checkbox it follows. Text inside fenced code blocks is
+// kept as description, never parsed as checkboxes.
+func parseMarkdownTodos(r io.Reader) ([]*markdownTodo, error) {
+	var roots []*markdownTodo
+	var headings []*markdownTodo // open headings, outermost first
+	var items []*markdownTodo    // open checkboxes, outermost first
+	var current *markdownTodo    // where description text goes
+	inFence := false
+
+	addChild := func(parent, child *markdownTodo) {
+		if parent == nil {
+			roots = append(roots, child)
+		} else {
+			parent.Children = append(parent.Children, child)
+		}
+	}
+	heading := func() *markdownTodo {
+		if len(headings) == 0 {
+			return nil
+		}
+		return headings[len(headings)-1]
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+
+		fence := todoFenceRegex.MatchString(line)
+		if !inFence && !fence {
+			if m := todoHeadingRegex.FindStringSubmatch(line); m != nil && m[2] != "" {
+				level := len(m[1])
+				for len(headings) > 0 && headings[len(headings)-1].indent >= level {
+					headings = headings[:len(headings)-1]
+				}
+				node := &markdownTodo{Title: m[2], Heading: true, Line: lineNum, indent: level}
+				addChild(heading(), node)
+				headings = append(headings, node)
+				items = nil
+				current = node
+				continue
+			}
+			if m := todoCheckboxRegex.FindStringSubmatch(line); m != nil && strings.TrimSpace(m[3]) != "" {
+				indent := markdownIndent(m[1])
+				for len(items) > 0 && items[len(items)-1].indent >= indent {
+					items = items[:len(items)-1]
+				}
+				node := &markdownTodo{Title: strings.TrimSpace(m[3]), Checked: m[2] != " ", Line: lineNum, indent: indent}
+				if len(items) > 0 {
+					addChild(items[len(items)-1], node)
+				} else {
+					addChild(heading(), node)
+				}
+				items = append(items, node)
+				current = node
+				continue
+			}
+		}
+		// Unindented text (including a code fence) ends the list; it
+		// describes the heading
+		if !inFence && line != "" && len(items) > 0 && markdownIndent(line) <= items[0].indent {
+			items = nil
+			current = heading()
+		}
+		if fence {
+			inFence = !inFence
+		}
+		if current != nil {
+			if len(items) > 0 && current == items[len(items)-1] {
+				line = strings.TrimPrefix(line, strings.Repeat(" ", current.indent+2))
+			}
+			current.desc = append(current.desc, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading markdown: %w", err)
+	}
+
+	var finish func(nodes []*markdownTodo)
+	finish = func(nodes []*markdownTodo) {
+		for _, n := range nodes {
+			n.Description = strings.TrimSpace(strings.Join(n.desc, "\n"))
+			n.desc = nil
+			finish(n.Children)
+		}
+	}
+	finish(roots)
+	return roots, nil
+}
+
+// markdownIndent returns the width of a line's leading whitespace, counting
+// a tab as four columns
+func markdownIndent(line string) int {
+	width := 0
+	for _, c := range line {
+		switch c {
+		case ' ':
+			width++
+		case '\t':
+			width += 4
+		default:
+			return width
+		}
+	}
+	return width
+}
+
+// importedTodo pairs a parsed item with the issue created for it
+type importedTodo struct {
+	Issue    *types.Issue    `json:"issue"`
+	ParentID string          `json:"parent_id,omitempty"`
+	Children []*importedTodo `json:"children,omitempty"`
+}
+
+// createMarkdownTodos creates the issues for a parsed tree in one
+// transaction. Headings with children become epics and everything else
+// tasks; each child gets a parent-child dependency on its parent, and
+// checked boxes are closed.
+func createMarkdownTodos(ctx context.Context, s storage.Storage, todos []*markdownTodo, source, actor string) ([]*importedTodo, error) {
+	var result []*importedTodo
+	err := s.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		var create func(nodes []*markdownTodo, parentID string) ([]*importedTodo, error)
+		create = func(nodes []*markdownTodo, parentID string) ([]*importedTodo, error) {
+			var created []*importedTodo
+			for _, n := range nodes {
+				issue := &types.Issue{
+					Title:       n.Title,
+					Description: n.Description,
+					Status:      types.StatusOpen,
+					Priority:    2,
+					IssueType:   types.TypeTask,
+				}
+				if n.Heading && len(n.Children) > 0 {
+					issue.IssueType = types.TypeEpic
+				}
+				if err := tx.CreateIssue(ctx, issue, actor); err != nil {
+					return nil, fmt.Errorf("%s:%d %q: %w", source, n.Line, n.Title, err)
+				}
+				if parentID != "" {
+					dep := &types.Dependency{IssueID: issue.ID, DependsOnID: parentID, Type: types.DepParentChild}
+					if err := tx.AddDependency(ctx, dep, actor); err != nil {
+						return nil, fmt.Errorf("%s:%d: linking %s to %s: %w", source, n.Line, issue.ID, parentID, err)
+					}
+				}
+				item := &importedTodo{Issue: issue, ParentID: parentID}
+				children, err := create(n.Children, issue.ID)
+				if err != nil {
+					return nil, err
+				}
+				item.Children = children
+				if n.Checked {
+					if err := tx.CloseIssue(ctx, issue.ID, "Checked off in "+source, actor); err != nil {
+						return nil, fmt.Errorf("%s:%d: closing %s: %w", source, n.Line, issue.ID, err)
+					}
+					issue.Status = types.StatusClosed
+				}
+				created = append(created, item)
+			}
+			return created, nil
+		}
+		var err error
+		result, err = create(todos, "")
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// runMarkdownImport implements bd import --format=markdown
+func runMarkdownImport(path string, dryRun bool) {
+	if path == "" {
+		FatalErrorWithHint("markdown import needs a file", "bd import --format=markdown TODO.md")
+	}
+	// #nosec G304 - user-provided file path is intentional
+	f, err := os.Open(path)
+	if err != nil {
+		FatalError("opening %s: %v", path, err)
+	}
+	defer func() { _ = f.Close() }()
+	todos, err := parseMarkdownTodos(f)
+	if err != nil {
+		FatalError("%v", err)
+	}
+	if len(todos) == 0 {
+		FatalErrorWithHint(fmt.Sprintf("no headings or checkboxes found in %s", path),
+			"use '# Heading' lines and '- [ ] item' checkboxes")
+	}
+
+	source := filepath.Base(path)
+	if dryRun {
+		if jsonOutput {
+			outputJSON(todos)
+			return
+		}
+		fmt.Printf("Would import from %s:\n", path)
+		printMarkdownTodos(todos, 1)
+		return
+	}
+
+	created, err := createMarkdownTodos(rootCtx, store, todos, source, actor)
+	if err != nil {
+		FatalError("import failed, nothing was created: %v", err)
+	}
+	markDirtyAndScheduleFlush()
+
+	if jsonOutput {
+		outputJSON(created)
+		return
+	}
+	total, closed := countImportedTodos(created)
+	green := color.New(color.FgGreen).SprintFunc()
+	fmt.Printf("%s Imported %d issues from %s (%d closed):\n", green("✓"), total, path, closed)
+	printImportedTodos(created, 1)
+}
+
+func printMarkdownTodos(todos []*markdownTodo, depth int) {
+	for _, n := range todos {
+		box := ""
+		switch {
+		case n.Heading:
+		case n.Checked:
+			box = "[x] "
+		default:
+			box = "[ ] "
+		}
+		fmt.Printf("%s%s%s\n", strings.Repeat("  ", depth), box, n.Title)
+		printMarkdownTodos(n.Children, depth+1)
+	}
+}
+
+func printImportedTodos(items []*importedTodo, depth int) {
+	for _, item := range items {
+		fmt.Printf("%s%s: %s [%s, %s]\n", strings.Repeat("  ", depth), item.Issue.ID, item.Issue.Title, item.Issue.IssueType, item.Issue.Status)
+		printImportedTodos(item.Children, depth+1)
+	}
+}
+
+func countImportedTodos(items []*importedTodo) (total, closed int) {
+	for _, item := range items {
+		total++
+		if item.Issue.Status == types.StatusClosed {
+			closed++
+		}
+		t, c := countImportedTodos(item.Children)
+		total += t
+		closed += c
+	}
+	return total, closed
+}
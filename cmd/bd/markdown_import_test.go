@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+const testTodoMarkdown = `# Release 1.0
+
+Ship the first stable release.
+
+## Backend
+- [x] Add migrations
+- [ ] Rate limiting
+  Per-token limits, see the design doc.
+  - [ ] Redis counter
+  - [x] Config flag
+
+## Docs
+- [ ] Write README
+
+` + "```" + `
+- [ ] not a task
+` + "```" + `
+`
+
+func TestParseMarkdownTodos(t *testing.T) {
+	todos, err := parseMarkdownTodos(strings.NewReader(testTodoMarkdown))
+	if err != nil {
+		t.Fatalf("parseMarkdownTodos: %v", err)
+	}
+	if len(todos) != 1 || todos[0].Title != "Release 1.0" || !todos[0].Heading {
+		t.Fatalf("roots = %+v", todos)
+	}
+	release := todos[0]
+	if release.Description != "Ship the first stable release." {
+		t.Errorf("release description = %q", release.Description)
+	}
+	if len(release.Children) != 2 {
+		t.Fatalf("release children = %d, want 2", len(release.Children))
+	}
+	backend, docs := release.Children[0], release.Children[1]
+	if backend.Title != "Backend" || len(backend.Children) != 2 {
+		t.Fatalf("backend = %+v", backend)
+	}
+	if !backend.Children[0].Checked || backend.Children[1].Checked {
+		t.Errorf("checked states wrong: %+v", backend.Children)
+	}
+	rate := backend.Children[1]
+	if rate.Description != "Per-token limits, see the design doc." {
+		t.Errorf("item description = %q", rate.Description)
+	}
+	if len(rate.Children) != 2 || rate.Children[0].Title != "Redis counter" || !rate.Children[1].Checked {
+		t.Errorf("nested checkboxes = %+v", rate.Children)
+	}
+	if len(docs.Children) != 1 || !strings.Contains(docs.Description, "- [ ] not a task") {
+		t.Errorf("fenced checkbox should be description: children %+v, description %q", docs.Children, docs.Description)
+	}
+}
+
+func TestCreateMarkdownTodos(t *testing.T) {
+	store := newTestStore(t, filepath.Join(t.TempDir(), "test.db"))
+	ctx := context.Background()
+
+	todos, err := parseMarkdownTodos(strings.NewReader(testTodoMarkdown))
+	if err != nil {
+		t.Fatal(err)
+	}
+	created, err := createMarkdownTodos(ctx, store, todos, "TODO.md", "test")
+	if err != nil {
+		t.Fatalf("createMarkdownTodos: %v", err)
+	}
+	total, closed := countImportedTodos(created)
+	if total != 8 || closed != 2 {
+		t.Errorf("created %d issues (%d closed), want 8 (2 closed)", total, closed)
+	}
+
+	release := created[0].Issue
+	if release.IssueType != types.TypeEpic {
+		t.Errorf("heading with children should be an epic, got %s", release.IssueType)
+	}
+	migrations := created[0].Children[0].Children[0].Issue
+	got, err := store.GetIssue(ctx, migrations.ID)
+	if err != nil || got.Status != types.StatusClosed || got.CloseReason != "Checked off in TODO.md" {
+		t.Errorf("checked box not closed: %+v, %v", got, err)
+	}
+	deps, err := store.GetDependencyRecords(ctx, migrations.ID)
+	if err != nil || len(deps) != 1 || deps[0].Type != types.DepParentChild || deps[0].DependsOnID != created[0].Children[0].Issue.ID {
+		t.Errorf("child not linked to its heading: %+v, %v", deps, err)
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/mcp"
+)
+
+var mcpCmd = &cobra.Command{
+	Use:   "mcp",
+	Short: "Run an MCP server exposing beads tools over stdio",
+	Long: `Run a Model Context Protocol server over stdin/stdout, so Claude and other
+MCP-capable agents can use beads as native tools instead of shelling out to
+the CLI.
+
+Tools (each with a JSON schema for its arguments):
+  ready    Unblocked work, highest priority first
+  list     List or search issues
+  show     Issue with labels, dependencies, dependents, and comments
+  create   Create an issue, optionally with labels and deps ("type:id")
+  update   Update status, priority, assignee, and text fields
+  close    Close an issue
+  dep      Add a dependency (blocks, related, parent-child, discovered-from)
+
+The server works on the database bd would use in the current directory.
+Mutations are flushed to JSONL exactly like CLI commands, and issue text is
+redacted (redaction.*) and encrypted (encryption.fields) the same way. Diagnostics go to
+stderr; stdout carries only protocol messages.
+
+Register it with your agent, for example:
+  claude mcp add beads -- bd mcp
+
+or in an MCP client config:
+  {"mcpServers": {"beads": {"command": "bd", "args": ["mcp"]}}}`,
+	Run: func(cmd *cobra.Command, args []string) {
+		// The server keeps a store open across many tool calls
+		if err := ensureDirectMode("mcp requires direct database access"); err != nil {
+			FatalError("%v", err)
+		}
+
+		server := mcp.NewServer(mcp.Config{
+			Version:  Version,
+			Actor:    actor,
+			Store:    store,
+			BeadsDir: encryptionBeadsDir(),
+			OnMutate: markDirtyAndScheduleFlush,
+		}, os.Stdin, os.Stdout)
+
+		if err := server.Serve(rootCtx); err != nil && err != context.Canceled {
+			FatalError("mcp: %v", err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(mcpCmd)
+}
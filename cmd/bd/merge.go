@@ -25,7 +25,10 @@ To merge duplicate issues, use 'bd duplicates --auto-merge' instead.
 
 This tool handles 3-way merges during git pull/merge operations. It intelligently
 merges issues based on identity (id + created_at + created_by), applies field-specific
-merge rules, combines dependencies, and outputs conflict markers for unresolvable conflicts.
+merge rules, combines dependencies, and merges the remaining fields (assignee, labels,
+comments, ...) one by one; the side updated later wins a field both sides changed. Only
+an issue whose sides changed the same list element differently is written between
+conflict markers.
 
 Designed to work as a git merge driver. Configure with:
 
@@ -33,7 +36,8 @@ Designed to work as a git merge driver. Configure with:
   git config merge.beads.name "bd JSONL merge driver"
   echo ".beads/issues.jsonl merge=beads" >> .gitattributes
 
-Or use 'bd init' which automatically configures the merge driver.
+Or use 'bd init' which automatically configures the merge driver. 'bd init --git-merge'
+installs 'bd merge-driver' instead, which runs the same merge with git's argument order.
 
 Exit codes:
   0 - Merge successful (no conflicts)
@@ -137,8 +141,9 @@ func cleanupMergeArtifacts(outputPath string, debug bool) {
 		}
 	}
 
-	// 2. Run git clean -f in .beads/ directory to remove untracked files
-	cleanCmd := exec.Command("git", "clean", "-f")
+	// 2. Run git clean -f in .beads/ directory to remove untracked files,
+	// sparing attachment blobs that haven't been committed yet
+	cleanCmd := exec.Command("git", "clean", "-f", "-e", "blobs/")
 	cleanCmd.Dir = beadsDir
 	if debug {
 		cleanCmd.Stderr = os.Stderr
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/merge"
+)
+
+// structuredMergeDriver is the git config value that runs bd merge-driver.
+// Git passes %O (base), %A (ours, also the result) and %B (theirs).
+const structuredMergeDriver = "bd merge-driver %O %A %B"
+
+var mergeDriverCmd = &cobra.Command{
+	Use:   "merge-driver <base> <ours> <theirs>",
+	Short: "Field-by-field git merge driver for beads JSONL files",
+	Long: `bd merge-driver merges the JSONL issue file field by field when two branches
+edit it, so a git merge no longer leaves conflict markers that break import.
+It runs the same merge as 'bd merge', with git's argument order.
+
+For each issue present on both sides:
+  - a field changed on one side only takes that side's value
+  - a field both sides changed takes the value of the side with the later
+    updated_at (then closed_at); updated_at and closed_at take the later time
+  - labels, dependencies, comments, attachments, aliases and translations are
+    merged element by element; removals win, and a comment edited on both
+    sides keeps the later edit
+Issues deleted on one side stay deleted, issues added on either side are
+kept, and tombstones win over live copies until they expire.
+
+Only when both sides change the same element of a list (an attachment, a
+translation) differently is the issue left for manual resolution: the two
+versions, each carrying every field that did merge, are written between
+conflict markers and the driver exits 1. Keep one line (or combine them) and
+delete the markers to resolve.
+
+The result is written over <ours> unless --output is given. Install it with
+'bd init --git-merge', or:
+
+  git config merge.beads.driver "bd merge-driver %O %A %B"
+  git config merge.beads.name "bd field-level JSONL merge driver"
+  echo ".beads/issues.jsonl merge=beads" >> .gitattributes
+
+Exit codes:
+  0 - Merged cleanly
+  1 - Merged with conflicts (conflict markers in the output)
+  2 - Error (invalid arguments, unreadable file, etc.)`,
+	Args: cobra.ExactArgs(3),
+	// PreRun disables PersistentPreRun for this command (no database needed)
+	PreRun: func(cmd *cobra.Command, args []string) {},
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		basePath, oursPath, theirsPath := args[0], args[1], args[2]
+		if output == "" {
+			output = oursPath
+		}
+
+		if err := merge.Merge3Way(output, basePath, oursPath, theirsPath, false); err != nil {
+			if strings.HasPrefix(err.Error(), "merge completed with") {
+				fmt.Fprintf(os.Stderr, "bd merge-driver: %v; keep one line of each marked pair, delete the markers, then git add the file\n", err)
+				os.Exit(1)
+			}
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(2)
+		}
+		os.Exit(0)
+	},
+}
+
+func init() {
+	mergeDriverCmd.Flags().StringP("output", "o", "", "Write the result here instead of over <ours>")
+	rootCmd.AddCommand(mergeDriverCmd)
+}
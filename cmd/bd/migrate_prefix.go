@@ -0,0 +1,146 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+)
+
+var migratePrefixCmd = &cobra.Command{
+	Use:   "migrate-prefix <new-prefix>",
+	Short: "Move the project to a new issue prefix in one atomic step",
+	Long: `Move every issue from the current prefix to a new one (e.g. bd- → web-).
+
+Unlike rename-prefix, the whole migration runs in a single database
+transaction, so it either fully happens or leaves the database untouched:
+  - issue IDs, including hierarchical children (bd-a3f8.1 → web-a3f8.1)
+  - both ends of every dependency edge
+  - labels, comments, events, attachments, aliases and snapshots
+  - references in titles, descriptions, design, acceptance criteria,
+    notes and comment text
+  - the issue_prefix config
+
+The JSONL is then rewritten in full (atomically, via a temp file) and a
+mapping report lists every old → new ID. Use --mapping-file to save the
+mapping as JSON, e.g. for rewriting links in other tools.
+
+Issues whose IDs already use another prefix are left alone, as are
+references to IDs that do not exist. The migration refuses to run if any
+new ID would collide with an existing issue.
+
+EXAMPLES:
+  bd migrate-prefix web --dry-run                      # Preview the mapping
+  bd migrate-prefix web                                # Migrate bd-* to web-*
+  bd migrate-prefix web --mapping-file prefix-map.json # Also save the mapping`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		mappingFile, _ := cmd.Flags().GetString("mapping-file")
+		if !dryRun {
+			CheckReadonly("migrate-prefix")
+		}
+
+		if err := ensureDirectMode("migrate-prefix requires direct database access"); err != nil {
+			FatalError("%v", err)
+		}
+		sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+		if !ok {
+			FatalError("migrate-prefix requires SQLite storage")
+		}
+
+		newPrefix := strings.TrimRight(args[0], "-")
+		if err := validatePrefix(newPrefix); err != nil {
+			FatalError("%v", err)
+		}
+
+		ctx := rootCtx
+		oldPrefix, err := store.GetConfig(ctx, "issue_prefix")
+		if err != nil || oldPrefix == "" {
+			FatalError("failed to get current prefix: %v", err)
+		}
+
+		migration, err := sqliteStore.MigratePrefix(ctx, oldPrefix, newPrefix, actor, dryRun)
+		if err != nil {
+			FatalError("%v", err)
+		}
+
+		jsonlPath := ""
+		if !dryRun {
+			// IDs changed everywhere, so rebuild the JSONL from scratch now
+			// rather than leaving a half-old export for the debounced flush
+			flushMutex.Lock()
+			failuresBefore := flushFailureCount
+			flushMutex.Unlock()
+			flushToJSONLWithState(flushState{forceDirty: true, forceFullExport: true})
+			flushMutex.Lock()
+			exportFailed := flushFailureCount > failuresBefore
+			flushMutex.Unlock()
+			if exportFailed {
+				FatalErrorWithHint("prefix migrated but the JSONL export failed", "run 'bd export -o "+findJSONLPath()+"' to rewrite it")
+			}
+			jsonlPath = findJSONLPath()
+
+			if mappingFile != "" {
+				data, err := json.MarshalIndent(migration.Renamed, "", "  ")
+				if err != nil {
+					FatalError("%v", err)
+				}
+				// nolint:gosec // G306: mapping report is not sensitive
+				if err := os.WriteFile(mappingFile, append(data, '\n'), 0644); err != nil {
+					FatalError("writing mapping file: %v", err)
+				}
+			}
+		}
+
+		if jsonOutput {
+			outputJSON(struct {
+				*sqlite.PrefixMigration
+				DryRun    bool   `json:"dry_run"`
+				JSONLPath string `json:"jsonl_path,omitempty"`
+			}{migration, dryRun, jsonlPath})
+			return
+		}
+		printPrefixMigration(migration, dryRun, jsonlPath, mappingFile)
+	},
+}
+
+// printPrefixMigration shows the old → new mapping report
+func printPrefixMigration(m *sqlite.PrefixMigration, dryRun bool, jsonlPath, mappingFile string) {
+	cyan := color.New(color.FgCyan).SprintFunc()
+	green := color.New(color.FgGreen).SprintFunc()
+
+	if dryRun {
+		fmt.Printf("DRY RUN: would migrate %d issues from '%s' to '%s'\n", len(m.Renamed), m.OldPrefix, m.NewPrefix)
+	} else {
+		fmt.Printf("%s Migrated %d issues from '%s' to '%s'\n", green("✓"), len(m.Renamed), m.OldPrefix, m.NewPrefix)
+	}
+	if len(m.Renamed) > 0 {
+		fmt.Println()
+		width := 0
+		for id := range m.Renamed {
+			width = max(width, len(id))
+		}
+		for _, oldID := range m.OldIDs() {
+			fmt.Printf("  %-*s → %s\n", width, oldID, cyan(m.Renamed[oldID]))
+		}
+		fmt.Println()
+	}
+	fmt.Printf("Text references rewritten: %d\n", m.TextEdits)
+	if jsonlPath != "" {
+		fmt.Printf("JSONL rewritten: %s\n", jsonlPath)
+	}
+	if mappingFile != "" && !dryRun {
+		fmt.Printf("Mapping saved: %s\n", mappingFile)
+	}
+}
+
+func init() {
+	migratePrefixCmd.Flags().Bool("dry-run", false, "Show the mapping without changing anything")
+	migratePrefixCmd.Flags().String("mapping-file", "", "Also write the old → new ID mapping to this JSON file")
+	rootCmd.AddCommand(migratePrefixCmd)
+}
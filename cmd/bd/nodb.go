@@ -1,7 +1,6 @@
 package main
 
 import (
-	"bufio"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -12,6 +11,7 @@ import (
 	"github.com/steveyegge/beads/internal/debug"
 	"github.com/steveyegge/beads/internal/storage/memory"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/util"
 	"github.com/steveyegge/beads/internal/utils"
 )
 
@@ -87,7 +87,7 @@ func loadIssuesFromJSONL(path string) ([]*types.Issue, error) {
 	defer file.Close()
 
 	var issues []*types.Issue
-	scanner := bufio.NewScanner(file)
+	scanner := util.NewJSONLScanner(file)
 
 	lineNum := 0
 	for scanner.Scan() {
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/plugins"
+)
+
+// pluginAnnotation marks plugin commands, holding the executable path
+const pluginAnnotation = "bd-plugin"
+
+var pluginsCmd = &cobra.Command{
+	Use:   "plugins",
+	Short: "List plugins (bd-<name> executables and config.yaml plugins)",
+	Long: `List the plugins bd runs as subcommands.
+
+Any executable named bd-<name> on PATH runs as "bd <name>", as do commands
+declared under plugins: in config.yaml:
+
+  plugins:
+    deploy: ./scripts/deploy.sh --env prod
+    triage:
+      command: python3 tools/triage.py
+      description: Triage new bugs
+      stdin: context
+
+Relative paths are resolved against the repository root. Built-in commands
+win over plugins, and config.yaml plugins over PATH ones.
+
+Plugins get their arguments as given and the project through the
+environment: BD_PLUGIN_CONTEXT holds JSON with the database, .beads
+directory, JSONL path, repository root, actor and bd binary, and BEADS_DB,
+BEADS_DIR and BD_ACTOR are set so bd commands the plugin runs use the same
+project. With stdin: context, the JSON is also written to the plugin's
+stdin. Go plugins can read it with beads.LoadPluginContext.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		found, err := discoverPlugins()
+		if err != nil {
+			FatalError("%v", err)
+		}
+		if jsonOutput {
+			if found == nil {
+				found = []*plugins.Plugin{}
+			}
+			outputJSON(found)
+			return
+		}
+		if len(found) == 0 {
+			fmt.Println("No plugins found (add bd-<name> executables to PATH, or plugins: to config.yaml)")
+			return
+		}
+		for _, p := range found {
+			command := strings.Join(append([]string{p.Path}, p.Args...), " ")
+			fmt.Printf("%-16s %-7s %s\n", p.Name, p.Source, command)
+			if p.Description != "" {
+				fmt.Printf("%-16s         %s\n", "", p.Description)
+			}
+			if p.Shadowed {
+				reason := "an earlier plugin"
+				if builtinCommand(p.Name) {
+					reason = "the built-in command"
+				}
+				fmt.Printf("%-16s         shadowed by %s\n", "", reason)
+			}
+		}
+	},
+}
+
+// discoverPlugins finds the plugins declared in config.yaml and on PATH
+func discoverPlugins() ([]*plugins.Plugin, error) {
+	declared, err := plugins.ParseDeclared(config.GetStringMap("plugins"))
+	if err != nil {
+		return nil, err
+	}
+	return plugins.Discover(os.Getenv("PATH"), declared, pluginBaseDir(), builtinCommand), nil
+}
+
+// pluginBaseDir is the directory relative plugin commands are resolved
+// against: the repository root for .beads/config.yaml
+func pluginBaseDir() string {
+	file := config.File()
+	if file == "" {
+		wd, _ := os.Getwd()
+		return wd
+	}
+	dir := filepath.Dir(file)
+	if filepath.Base(dir) == ".beads" {
+		return filepath.Dir(dir)
+	}
+	return dir
+}
+
+// builtinCommand reports whether name is one of bd's own commands
+func builtinCommand(name string) bool {
+	if name == "help" || name == "completion" {
+		return true
+	}
+	for _, cmd := range rootCmd.Commands() {
+		if _, ok := cmd.Annotations[pluginAnnotation]; ok {
+			continue
+		}
+		if cmd.Name() == name || slices.Contains(cmd.Aliases, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// registerPlugins adds a subcommand for every plugin that isn't shadowed.
+// It runs from main, after the built-in commands are registered.
+func registerPlugins() {
+	found, err := discoverPlugins()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: plugins disabled: %v\n", err)
+		return
+	}
+	for _, p := range found {
+		if p.Shadowed {
+			continue
+		}
+		rootCmd.AddCommand(newPluginCommand(p))
+	}
+}
+
+func newPluginCommand(p *plugins.Plugin) *cobra.Command {
+	short := p.Description
+	if short == "" {
+		short = "Plugin (" + filepath.Base(p.Path) + ")"
+	}
+	return &cobra.Command{
+		Use:   p.Name,
+		Short: short,
+		// Everything after the name belongs to the plugin
+		DisableFlagParsing: true,
+		Annotations:        map[string]string{pluginAnnotation: p.Path},
+		Run: func(cmd *cobra.Command, args []string) {
+			globalArgs, pluginArgs := splitPluginArgs(os.Args[1:], p.Name, args)
+			// bd's own flags before the name, e.g. bd --db x.db deploy
+			if err := rootCmd.PersistentFlags().Parse(globalArgs); err != nil {
+				FatalError("%v", err)
+			}
+			if projectFlag != "" {
+				switchToProject(projectFlag)
+			}
+			os.Exit(runPlugin(p, pluginArgs))
+		},
+	}
+}
+
+// splitPluginArgs separates bd's global flags from the plugin's arguments.
+// With flag parsing disabled, cobra passes both as args; os.Args shows
+// which came before the plugin name.
+func splitPluginArgs(osArgs []string, name string, args []string) (global, rest []string) {
+	for i, arg := range osArgs {
+		if arg != name || len(osArgs)-1 != len(args) {
+			continue
+		}
+		if slices.Equal(osArgs[:i], args[:i]) && slices.Equal(osArgs[i+1:], args[i:]) {
+			return args[:i], args[i:]
+		}
+	}
+	return nil, args
+}
+
+// pluginContext describes the project the plugin runs in
+func pluginContext(p *plugins.Plugin, args []string) *plugins.Context {
+	c := &plugins.Context{
+		Plugin:   p.Name,
+		Args:     args,
+		Version:  Version,
+		Actor:    fallbackActor(),
+		JSON:     jsonOutput,
+		Readonly: readonlyMode,
+	}
+	c.Executable, _ = os.Executable()
+	c.WorkDir, _ = os.Getwd()
+	c.DBPath = dbPath
+	if c.DBPath == "" {
+		c.DBPath = beads.FindDatabasePath()
+	}
+	if c.DBPath != "" {
+		if abs, err := filepath.Abs(c.DBPath); err == nil {
+			c.DBPath = abs
+		}
+		c.BeadsDir = filepath.Dir(c.DBPath)
+		c.JSONLPath = beads.FindJSONLPath(c.DBPath)
+	} else {
+		c.BeadsDir = beads.FindBeadsDir()
+	}
+	if c.BeadsDir != "" {
+		c.RepoRoot = filepath.Dir(c.BeadsDir)
+	}
+	return c
+}
+
+// runPlugin runs a plugin and returns its exit code
+func runPlugin(p *plugins.Plugin, args []string) int {
+	c := pluginContext(p, args)
+	env, err := c.Env()
+	if err != nil {
+		FatalError("plugin %s: %v", p.Name, err)
+	}
+	cmd := exec.Command(p.Path, append(slices.Clone(p.Args), args...)...) // #nosec G204 -- plugins are the user's own executables
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Stdin = os.Stdin
+	if p.Stdin == plugins.StdinContext {
+		data, _ := json.Marshal(c)
+		cmd.Stdin = bytes.NewReader(data)
+	}
+
+	err = cmd.Run()
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		finishJournal(0, "")
+		return 0
+	case errors.As(err, &exitErr):
+		finishJournal(exitErr.ExitCode(), "")
+		return exitErr.ExitCode()
+	}
+	FatalError("plugin %s: %v", p.Name, err)
+	return 1
+}
+
+func init() {
+	rootCmd.AddCommand(pluginsCmd)
+}
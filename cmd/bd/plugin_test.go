@@ -0,0 +1,34 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSplitPluginArgs(t *testing.T) {
+	tests := []struct {
+		osArgs, args []string
+		global, rest string
+	}{
+		{[]string{"deploy", "--env", "prod"}, []string{"--env", "prod"}, "", "--env prod"},
+		{[]string{"--db", "x.db", "deploy", "-v"}, []string{"--db", "x.db", "-v"}, "--db x.db", "-v"},
+		// The name appearing again as an argument
+		{[]string{"deploy", "deploy"}, []string{"deploy"}, "", "deploy"},
+		// Run without os.Args, as in tests
+		{[]string{"-test.v"}, []string{"a"}, "", "a"},
+	}
+	for _, tt := range tests {
+		global, rest := splitPluginArgs(tt.osArgs, "deploy", tt.args)
+		if strings.Join(global, " ") != tt.global || strings.Join(rest, " ") != tt.rest {
+			t.Errorf("splitPluginArgs(%v) = %v, %v; want %q, %q", tt.osArgs, global, rest, tt.global, tt.rest)
+		}
+	}
+}
+
+func TestBuiltinCommand(t *testing.T) {
+	for name, want := range map[string]bool{"ready": true, "help": true, "plugins": true, "deploy": false} {
+		if got := builtinCommand(name); got != want {
+			t.Errorf("builtinCommand(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/projects"
+)
+
+var projectCmd = &cobra.Command{
+	Use:   "project",
+	Short: "Manage the registry of known projects for --project",
+	Long: `Manage your user-level registry of beads projects (~/.beads/projects.json).
+
+A registered project can be targeted by name from any directory with the
+global --project flag, so agents working across repositories don't need to
+cd into each one:
+
+  bd project add ~/code/api --name api
+  bd --project api ready
+
+--project also accepts a path (anything containing a slash or starting with
+. or ~), which works like git -C without registering anything. bd runs as if
+started in that directory, so relative paths in arguments resolve from there.`,
+}
+
+var projectAddCmd = &cobra.Command{
+	Use:   "add <path>",
+	Short: "Register a project directory",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name, _ := cmd.Flags().GetString("name")
+
+		path, err := projects.Normalize(args[0])
+		if err != nil {
+			FatalErrorWithHint(fmt.Sprintf("%v", err), "run 'bd init' in the project first")
+		}
+		if name == "" {
+			name = filepath.Base(path)
+		}
+		reg := projectRegistry()
+		if err := reg.Add(projects.Project{Name: name, Path: path}); err != nil {
+			FatalError("%v", err)
+		}
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"name": name, "path": path})
+			return
+		}
+		fmt.Printf("Registered project %s → %s\n", name, path)
+	},
+}
+
+var projectListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List registered projects",
+	Run: func(cmd *cobra.Command, args []string) {
+		list, err := projectRegistry().List()
+		if err != nil {
+			FatalError("%v", err)
+		}
+
+		type projectRow struct {
+			Name    string `json:"name"`
+			Path    string `json:"path"`
+			Missing bool   `json:"missing,omitempty"`
+			AddedAt string `json:"added_at"`
+		}
+		rows := make([]projectRow, 0, len(list))
+		for _, p := range list {
+			_, statErr := os.Stat(filepath.Join(p.Path, ".beads"))
+			rows = append(rows, projectRow{
+				Name:    p.Name,
+				Path:    p.Path,
+				Missing: statErr != nil,
+				AddedAt: p.AddedAt.Format("2006-01-02"),
+			})
+		}
+		if jsonOutput {
+			outputJSON(rows)
+			return
+		}
+		if len(rows) == 0 {
+			fmt.Println("No projects registered (add one with 'bd project add <path>')")
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "NAME\tPATH\tADDED")
+		for _, r := range rows {
+			path := r.Path
+			if r.Missing {
+				path += " (missing)"
+			}
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", r.Name, path, r.AddedAt)
+		}
+		_ = w.Flush()
+	},
+}
+
+var projectRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Unregister a project (its files are untouched)",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := projectRegistry().Remove(args[0]); err != nil {
+			FatalError("%v", err)
+		}
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"name": args[0], "removed": true})
+			return
+		}
+		fmt.Printf("Removed project %s\n", args[0])
+	},
+}
+
+func projectRegistry() *projects.Registry {
+	reg, err := projects.NewRegistry()
+	if err != nil {
+		FatalError("%v", err)
+	}
+	return reg
+}
+
+// switchToProject handles --project: it resolves the value to a project
+// directory, changes into it and reloads config from there. A relative --db
+// is made absolute first so it still means what the user typed.
+func switchToProject(value string) {
+	dir, err := projectRegistry().Resolve(value)
+	if err != nil {
+		FatalErrorWithHint(fmt.Sprintf("--project: %v", err), "see 'bd project list', or pass a path to a directory containing .beads")
+	}
+	if dbPath != "" && !filepath.IsAbs(dbPath) {
+		if abs, err := filepath.Abs(dbPath); err == nil {
+			dbPath = abs
+		}
+	}
+	if err := os.Chdir(dir); err != nil {
+		FatalError("--project: %v", err)
+	}
+	if err := config.Initialize(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to initialize config: %v\n", err)
+	}
+}
+
+func init() {
+	projectAddCmd.Flags().String("name", "", "Name to register the project under (default: directory name)")
+
+	projectCmd.AddCommand(projectAddCmd, projectListCmd, projectRemoveCmd)
+	rootCmd.AddCommand(projectCmd)
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+)
+
+// historyRetentionKey is the config key for how long events are kept
+const historyRetentionKey = "history.retention"
+
+var pruneHistoryCmd = &cobra.Command{
+	Use:   "prune-history",
+	Short: "Roll up and delete old event history",
+	Long: `Delete events older than the retention period, first rolling each issue's
+pruned events up into a summary record. Blocked and ready time
+(bd blocked-time), cycle times (bd cycle-time) and close reasons are computed
+from the summaries afterwards, so they report the same numbers as before.
+
+The retention period comes from --retention or the history.retention config
+key ("180d", "4320h"). The daemon prunes daily when history.retention is set:
+
+  bd config set history.retention 180d
+
+Events adding blocks dependencies that are still in place are kept, since
+the time an issue spends blocked is measured until the dependency is
+removed. bd ready --diff-since cannot reach back past the pruned history,
+and neither can bd undo and bd history, whose operations log is pruned to
+the same cutoff.
+
+Examples:
+  bd prune-history --dry-run
+  bd prune-history --retention 90d`,
+	Run: func(cmd *cobra.Command, args []string) {
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		retentionFlag, _ := cmd.Flags().GetString("retention")
+
+		if !dryRun {
+			CheckReadonly("prune-history")
+		}
+		if err := ensureDirectMode("prune-history requires direct database access"); err != nil {
+			FatalError("%v", err)
+		}
+		sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+		if !ok {
+			FatalError("prune-history requires the SQLite backend")
+		}
+		ctx := rootCtx
+
+		value := retentionFlag
+		if value == "" {
+			var err error
+			if value, err = sqliteStore.GetConfig(ctx, historyRetentionKey); err != nil {
+				FatalError("%v", err)
+			}
+		}
+		if value == "" {
+			FatalErrorWithHint("no retention period", "pass --retention or run: bd config set history.retention 180d")
+		}
+		retention, err := parseHistoryRetention(value)
+		if err != nil {
+			FatalError("%v", err)
+		}
+
+		result, err := sqliteStore.PruneEvents(ctx, time.Now().Add(-retention), dryRun)
+		if err != nil {
+			FatalError("%v", err)
+		}
+
+		if jsonOutput {
+			outputJSON(result)
+			return
+		}
+		verb := "Pruned"
+		if dryRun {
+			verb = "Would prune"
+		}
+		fmt.Printf("%s %d events from before %s into %d issue summaries",
+			verb, result.EventsPruned, result.Before.Local().Format("2006-01-02 15:04"), result.IssuesSummarized)
+		if result.EventsKept > 0 {
+			fmt.Printf(" (kept %d for dependencies still in place)", result.EventsKept)
+		}
+		if result.OperationsPruned > 0 {
+			fmt.Printf(", and %d operations from the bd undo log", result.OperationsPruned)
+		}
+		fmt.Println()
+	},
+}
+
+// parseHistoryRetention reads a history.retention value: days ("180d") or
+// a Go duration ("4320h")
+func parseHistoryRetention(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n > 0 {
+			return time.Duration(n) * 24 * time.Hour, nil
+		}
+	}
+	if d, err := time.ParseDuration(s); err == nil && d > 0 {
+		return d, nil
+	}
+	return 0, fmt.Errorf("invalid %s %q (use a number of days such as 180d, or a duration such as 4320h)", historyRetentionKey, s)
+}
+
+func init() {
+	pruneHistoryCmd.Flags().Bool("dry-run", false, "Report what would be pruned without changing anything")
+	pruneHistoryCmd.Flags().String("retention", "", "Keep events newer than this (default: history.retention)")
+	rootCmd.AddCommand(pruneHistoryCmd)
+}
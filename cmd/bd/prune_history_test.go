@@ -0,0 +1,24 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseHistoryRetention(t *testing.T) {
+	for input, want := range map[string]time.Duration{
+		"180d":   180 * 24 * time.Hour,
+		" 7d ":   7 * 24 * time.Hour,
+		"4320h":  4320 * time.Hour,
+		"90m30s": 90*time.Minute + 30*time.Second,
+	} {
+		if got, err := parseHistoryRetention(input); err != nil || got != want {
+			t.Errorf("parseHistoryRetention(%q) = %v, %v; want %v", input, got, err, want)
+		}
+	}
+	for _, input := range []string{"", "0d", "-5d", "0", "forever", "1.5d"} {
+		if _, err := parseHistoryRetention(input); err == nil {
+			t.Errorf("parseHistoryRetention(%q) should fail", input)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/attachments"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/publish"
+	"github.com/steveyegge/beads/internal/redact"
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+var publishCmd = &cobra.Command{
+	Use:   "publish",
+	Short: "Publish a read-only snapshot of the issues for stakeholders",
+	Long: `Publish a filtered, read-only snapshot of the issues as issues.json and a
+static index.html, for people who follow the project without running bd.
+
+Targets:
+  gh-pages             the gh-pages branch, pushed for GitHub Pages
+  branch:<name>        any other branch
+  dir:<path>           a local directory (e.g. one a web server serves)
+  s3://bucket/prefix   an S3 bucket (credentials as for attachments)
+  gs://bucket/prefix   a Google Cloud Storage bucket
+
+Branches are committed with git plumbing, so the checkout, index and current
+branch are never touched, and pushed to publish.remote (default origin).
+Nothing is committed when the snapshot hasn't changed.
+
+Only issues fit for an outside audience are published: issues labeled with
+one of publish.exclude_labels (default: confidential, private), issues with
+encrypted fields, messages, ephemeral issues and tombstones are left out, as
+are dependencies on them. Assignees, design, notes, acceptance criteria and
+comments are never published, and text passes through the redaction rules.
+Closed issues are included unless publish.include_closed is false.
+
+Set publish.target in .beads/config.yaml and the daemon republishes every
+publish.interval (default 1h).
+
+Examples:
+  bd publish --target gh-pages
+  bd publish --target dir:/var/www/roadmap
+  bd publish --target s3://acme-status/beads
+  bd publish --dry-run --json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		targetFlag, _ := cmd.Flags().GetString("target")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		noPush, _ := cmd.Flags().GetBool("no-push")
+
+		if targetFlag == "" {
+			targetFlag = config.GetString("publish.target")
+		}
+		var target *publish.Target
+		if targetFlag != "" || !dryRun {
+			var err error
+			if target, err = publish.ParseTarget(targetFlag); err != nil {
+				FatalErrorWithHint(err.Error(), "bd publish --target gh-pages, or set publish.target in .beads/config.yaml")
+			}
+		}
+		if err := ensureDirectMode("publish requires direct database access"); err != nil {
+			FatalError("%v", err)
+		}
+		ctx := rootCtx
+		if err := ensureDatabaseFresh(ctx); err != nil {
+			FatalError("%v", err)
+		}
+
+		snapshot, err := buildPublishSnapshot(ctx, store)
+		if err != nil {
+			FatalError("%v", err)
+		}
+		if dryRun {
+			if jsonOutput {
+				outputJSON(snapshot)
+				return
+			}
+			where := "(no target)"
+			if target != nil {
+				where = target.String()
+			}
+			fmt.Printf("Would publish %d issues to %s (%d withheld)\n", len(snapshot.Issues), where, snapshot.Withheld)
+			for _, issue := range snapshot.Issues {
+				fmt.Printf("  %s  [%s] %s\n", issue.ID, issue.Status, issue.Title)
+			}
+			return
+		}
+
+		result, err := publishSnapshot(ctx, snapshot, target, !noPush)
+		if err != nil {
+			FatalError("%v", err)
+		}
+		if jsonOutput {
+			outputJSON(result)
+			return
+		}
+		green := color.New(color.FgGreen).SprintFunc()
+		switch {
+		case !result.Changed && !result.Pushed:
+			fmt.Printf("Snapshot unchanged; %s is up to date\n", result.Target)
+		case result.Commit != "" && !result.Pushed:
+			fmt.Printf("%s Published %d issues to %s at %.12s (not pushed)\n", green("✓"), len(snapshot.Issues), result.Target, result.Commit)
+		default:
+			fmt.Printf("%s Published %d issues to %s\n", green("✓"), len(snapshot.Issues), result.Target)
+		}
+		if snapshot.Withheld > 0 {
+			fmt.Printf("  %d issue(s) withheld as confidential\n", snapshot.Withheld)
+		}
+	},
+}
+
+// buildPublishSnapshot builds the snapshot described by the publish.* config
+func buildPublishSnapshot(ctx context.Context, s storage.Storage) (*publish.Snapshot, error) {
+	redactor, err := redact.FromConfig()
+	if err != nil {
+		return nil, err
+	}
+	title := config.GetString("publish.title")
+	if title == "" {
+		prefix, _ := s.GetConfig(ctx, "issue_prefix")
+		title = strings.TrimSpace(prefix + " issues")
+	}
+	return publish.Build(ctx, s, publish.Options{
+		Title:         title,
+		ExcludeLabels: config.GetStringSlice("publish.exclude_labels"),
+		IncludeClosed: config.GetBool("publish.include_closed"),
+		Redactor:      redactor,
+	})
+}
+
+// publishSnapshot writes the snapshot to target; push controls whether a
+// branch target is pushed to publish.remote
+func publishSnapshot(ctx context.Context, snapshot *publish.Snapshot, target *publish.Target, push bool) (*publish.Result, error) {
+	files, err := snapshot.Files()
+	if err != nil {
+		return nil, err
+	}
+	switch target.Kind {
+	case publish.TargetDir:
+		return publish.WriteDir(target.Path, files)
+	case publish.TargetBranch:
+		repoDir := filepath.Dir(dbPath)
+		if dbPath == "" {
+			repoDir, _ = os.Getwd()
+		}
+		opts := publish.BranchOptions{
+			RepoDir: repoDir,
+			Branch:  target.Branch,
+			Message: fmt.Sprintf("Publish %d issues", len(snapshot.Issues)),
+		}
+		if remote := config.GetString("publish.remote"); push && remote != "" {
+			if _, err := gitIn(ctx, repoDir, "remote", "get-url", remote); err == nil {
+				opts.Remote = remote
+			}
+		}
+		return publish.CommitBranch(ctx, opts, files)
+	default:
+		opts := attachmentOptions()
+		opts.Bucket = target.Bucket
+		providerName := "s3"
+		if target.Kind == publish.TargetGCS {
+			providerName = "gcs"
+		}
+		provider, err := attachments.New(providerName, opts)
+		if err != nil {
+			return nil, err
+		}
+		result, err := publish.Upload(ctx, provider, target.Prefix, files)
+		if err != nil {
+			return nil, err
+		}
+		result.Target = target.String()
+		return result, nil
+	}
+}
+
+func init() {
+	publishCmd.Flags().String("target", "", "Where to publish (default: publish.target)")
+	publishCmd.Flags().Bool("dry-run", false, "Show what would be published without publishing")
+	publishCmd.Flags().Bool("no-push", false, "Commit a branch target without pushing it")
+	rootCmd.AddCommand(publishCmd)
+}
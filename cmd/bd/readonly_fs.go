@@ -0,0 +1,23 @@
+package main
+
+import (
+	"github.com/steveyegge/beads/internal/debug"
+)
+
+// readonlyReason says why bd switched to read-only mode by itself, e.g. a
+// repository on a read-only mount. It is empty when bd can write, or when
+// read-only mode was asked for (--readonly).
+var readonlyReason string
+
+// enterReadonlyStorage makes bd read-only because the database can't be
+// written (see storageNotWritable). Reads are served from the database as
+// it is: there is no daemon to start, and auto-import and auto-flush would
+// only fail. Write commands are refused by CheckReadonly.
+func enterReadonlyStorage(reason string) {
+	readonlyReason = reason
+	readonlyMode = true
+	noDaemon = true
+	autoImportEnabled = false
+	autoFlushEnabled = false
+	debug.Logf("read-only storage: %s", reason)
+}
@@ -0,0 +1,29 @@
+//go:build unix
+
+package main
+
+import (
+	"errors"
+	"path/filepath"
+
+	"golang.org/x/sys/unix"
+)
+
+// storageNotWritable says why the database at dbPath (or its directory,
+// where SQLite keeps its journal) can't be written, or returns "" if it can
+// or doesn't exist yet.
+//
+// access(2) answers without touching the directory, so the daemon's file
+// watcher doesn't see a probe file come and go on every command.
+func storageNotWritable(dbPath string) string {
+	for _, path := range []string{filepath.Dir(dbPath), dbPath} {
+		err := unix.Access(path, unix.W_OK)
+		switch {
+		case errors.Is(err, unix.EROFS):
+			return path + " is on a read-only filesystem"
+		case errors.Is(err, unix.EACCES), errors.Is(err, unix.EPERM):
+			return path + " is not writable"
+		}
+	}
+	return ""
+}
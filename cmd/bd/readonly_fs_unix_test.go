@@ -0,0 +1,41 @@
+//go:build unix
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestStorageNotWritable(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "beads.db")
+	if reason := storageNotWritable(dbPath); reason != "" {
+		t.Errorf("missing database in a writable directory: got %q", reason)
+	}
+	if err := os.WriteFile(dbPath, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if reason := storageNotWritable(dbPath); reason != "" {
+		t.Errorf("writable database: got %q", reason)
+	}
+
+	if os.Geteuid() == 0 {
+		t.Skip("root can write regardless of permissions")
+	}
+	if err := os.Chmod(dbPath, 0o400); err != nil {
+		t.Fatal(err)
+	}
+	if reason := storageNotWritable(dbPath); !strings.Contains(reason, "beads.db is not writable") {
+		t.Errorf("read-only database: got %q", reason)
+	}
+	if err := os.Chmod(dir, 0o500); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chmod(dir, 0o700) }()
+	if reason := storageNotWritable(dbPath); reason != dir+" is not writable" {
+		t.Errorf("read-only directory: got %q", reason)
+	}
+}
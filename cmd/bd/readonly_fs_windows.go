@@ -0,0 +1,19 @@
+//go:build windows
+
+package main
+
+import (
+	"os"
+)
+
+// storageNotWritable says why the database at dbPath can't be written, or
+// returns "" if it can or doesn't exist yet.
+//
+// Windows has no access(2); a file with the read-only attribute is the case
+// that can be told without writing.
+func storageNotWritable(dbPath string) string {
+	if info, err := os.Stat(dbPath); err == nil && info.Mode().Perm()&0o200 == 0 {
+		return dbPath + " is read-only"
+	}
+	return ""
+}
@@ -3,6 +3,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/rpc"
@@ -25,6 +26,11 @@ var readyCmd = &cobra.Command{
 		// Normalize labels: trim, dedupe, remove empty
 		labels = util.NormalizeLabels(labels)
 		labelsAny = util.NormalizeLabels(labelsAny)
+		complexityFlags, _ := cmd.Flags().GetStringSlice("complexity")
+		complexity, err := types.ParseComplexities(complexityFlags)
+		if err != nil {
+			FatalError("invalid --complexity: %v", err)
+		}
 
 		filter := types.WorkFilter{
 			// Leave Status empty to get both 'open' and 'in_progress' (bd-165)
@@ -33,6 +39,7 @@ var readyCmd = &cobra.Command{
 			SortPolicy: types.SortPolicy(sortPolicy),
 			Labels:     labels,
 			LabelsAny:  labelsAny,
+			Complexity: complexity,
 		}
 		// Use Changed() to properly handle P0 (priority=0)
 		if cmd.Flags().Changed("priority") {
@@ -47,20 +54,54 @@ var readyCmd = &cobra.Command{
 			fmt.Fprintf(os.Stderr, "Error: invalid sort policy '%s'. Valid values: hybrid, priority, oldest\n", sortPolicy)
 			os.Exit(1)
 		}
+		readyArgs := &rpc.ReadyArgs{
+			Assignee:   assignee,
+			Unassigned: unassigned,
+			Limit:      limit,
+			SortPolicy: sortPolicy,
+			Labels:     labels,
+			LabelsAny:  labelsAny,
+			Complexity: complexityFlags,
+			Priority:   filter.Priority,
+		}
+		if !jsonOutput {
+			readyArgs.Locale = displayLocale()
+		}
+		claim, _ := cmd.Flags().GetBool("claim")
+		forActor, _ := cmd.Flags().GetString("for")
+		groupBy, _ := cmd.Flags().GetString("group-by")
+		diffSince, _ := cmd.Flags().GetString("diff-since")
+		if diffSince != "" && (claim || forActor != "" || groupBy != "") {
+			FatalError("--diff-since cannot be combined with --claim, --for or --group-by")
+		}
+		if diffSince != "" {
+			runReadyDiff(diffSince)
+			return
+		}
+		if claim && forActor != "" {
+			FatalError("--claim and --for cannot be used together")
+		}
+		if groupBy != "" && (claim || forActor != "") {
+			FatalError("--group-by cannot be combined with --claim or --for")
+		}
+		ttlFlag, _ := cmd.Flags().GetString("ttl")
+		if ttlFlag != "" && !claim {
+			FatalError("--ttl requires --claim")
+		}
+		if claim {
+			runReadyClaim(filter, readyArgs, ttlFlag)
+			return
+		}
+		if forActor != "" {
+			runReadyFor(filter, forActor)
+			return
+		}
+		if groupBy != "" {
+			runReadyGrouped(filter, readyArgs, groupBy)
+			return
+		}
 		// If daemon is running, use RPC
 		if daemonClient != nil {
-			readyArgs := &rpc.ReadyArgs{
-				Assignee:   assignee,
-				Unassigned: unassigned,
-				Limit:      limit,
-				SortPolicy: sortPolicy,
-				Labels:     labels,
-				LabelsAny:  labelsAny,
-			}
-			if cmd.Flags().Changed("priority") {
-				priority, _ := cmd.Flags().GetInt("priority")
-				readyArgs.Priority = &priority
-			}
 			resp, err := daemonClient.Ready(readyArgs)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -109,9 +150,15 @@ var readyCmd = &cobra.Command{
 				if issue.EstimatedMinutes != nil {
 					fmt.Printf("   Estimate: %d min\n", *issue.EstimatedMinutes)
 				}
+				if issue.Complexity != "" {
+					fmt.Printf("   Complexity: %s\n", issue.Complexity)
+				}
 				if issue.Assignee != "" {
 					fmt.Printf("   Assignee: %s\n", issue.Assignee)
 				}
+				if len(issue.SoftBlockedBy) > 0 {
+					fmt.Printf("   Preferably after: %s\n", strings.Join(issue.SoftBlockedBy, ", "))
+				}
 			}
 			fmt.Println()
 			return
@@ -127,6 +174,7 @@ var readyCmd = &cobra.Command{
 				os.Exit(1)
 			}
 		}
+		warnExternalIssues(ctx)
 
 		issues, err := store.GetReadyWork(ctx, filter)
 		if err != nil {
@@ -144,6 +192,9 @@ var readyCmd = &cobra.Command{
 			}
 		}
 	}
+		if !jsonOutput {
+			localizeForDisplay(issues)
+		}
 		if jsonOutput {
 			// Always output array, even if empty
 			if issues == nil {
@@ -180,9 +231,15 @@ var readyCmd = &cobra.Command{
 			if issue.EstimatedMinutes != nil {
 				fmt.Printf("   Estimate: %d min\n", *issue.EstimatedMinutes)
 			}
+			if issue.Complexity != "" {
+				fmt.Printf("   Complexity: %s\n", issue.Complexity)
+			}
 			if issue.Assignee != "" {
 				fmt.Printf("   Assignee: %s\n", issue.Assignee)
 			}
+			if len(issue.SoftBlockedBy) > 0 {
+				fmt.Printf("   Preferably after: %s\n", strings.Join(issue.SoftBlockedBy, ", "))
+			}
 		}
 		fmt.Println()
 
@@ -206,6 +263,7 @@ var blockedCmd = &cobra.Command{
 			}
 			defer func() { _ = store.Close() }()
 			}
+		warnExternalIssues(ctx)
 		blocked, err := store.GetBlockedIssues(ctx)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -232,8 +290,13 @@ var blockedCmd = &cobra.Command{
 			if blockedBy == nil {
 				blockedBy = []string{}
 			}
-			fmt.Printf("  Blocked by %d open dependencies: %v\n",
-				issue.BlockedByCount, blockedBy)
+			if issue.BlockedByCount > 0 || len(issue.BlockedByGates) == 0 {
+				fmt.Printf("  Blocked by %d open dependencies: %v\n",
+					issue.BlockedByCount, blockedBy)
+			}
+			if len(issue.BlockedByGates) > 0 {
+				fmt.Printf("  Waiting on CI: %s\n", strings.Join(issue.BlockedByGates, ", "))
+			}
 			fmt.Println()
 		}
 	},
@@ -241,11 +304,29 @@ var blockedCmd = &cobra.Command{
 var statsCmd = &cobra.Command{
 	Use:   "stats",
 	Short: "Show statistics",
+	Long: `Show issue counts, blocked and ready work, lead time, open issues by
+priority and label, and the last week's activity.
+
+Counts, lead time and the breakdowns are read from summary tables that the
+database keeps current on every write, so bd stats stays fast on large
+projects. --exact computes them from the issues instead. Activity counts
+the events of each day, including those of issues deleted or events pruned
+since (bd prune-history); --exact only sees the events still stored.
+
+Examples:
+  bd stats
+  bd stats --exact --json
+  bd stats --filter my-backlog`,
 	Run: func(cmd *cobra.Command, args []string) {
+		if name, _ := cmd.Flags().GetString("filter"); name != "" {
+			runFilteredStats(name)
+			return
+		}
+		exact, _ := cmd.Flags().GetBool("exact")
 		// Use global jsonOutput set by PersistentPreRun (respects config.yaml + env vars)
 		// If daemon is running, use RPC
 		if daemonClient != nil {
-			resp, err := daemonClient.Stats()
+			resp, err := daemonClient.StatsWithArgs(&rpc.StatsArgs{Exact: exact})
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 				os.Exit(1)
@@ -266,6 +347,9 @@ var statsCmd = &cobra.Command{
 			fmt.Printf("Total Issues:      %d\n", stats.TotalIssues)
 			fmt.Printf("Open:              %s\n", green(fmt.Sprintf("%d", stats.OpenIssues)))
 			fmt.Printf("In Progress:       %s\n", yellow(fmt.Sprintf("%d", stats.InProgressIssues)))
+			if stats.ResolvedIssues > 0 {
+				fmt.Printf("Resolved:          %d (awaiting verification)\n", stats.ResolvedIssues)
+			}
 			fmt.Printf("Closed:            %d\n", stats.ClosedIssues)
 			fmt.Printf("Blocked:           %d\n", stats.BlockedIssues)
 			fmt.Printf("Ready:             %s\n", green(fmt.Sprintf("%d", stats.ReadyIssues)))
@@ -275,12 +359,19 @@ var statsCmd = &cobra.Command{
 			if stats.AverageLeadTime > 0 {
 				fmt.Printf("Avg Lead Time:     %.1f hours\n", stats.AverageLeadTime)
 			}
+			printClosedByReason(stats.ClosedByReason)
+			printTimeLogged(stats.MinutesLoggedByActor)
+			printStatsBreakdown(&stats)
 			fmt.Println()
 			return
 		}
 		// Direct mode
 		ctx := rootCtx
-		stats, err := store.GetStatistics(ctx)
+		getStats := store.GetStatistics
+		if sqliteStore, ok := store.(*sqlite.SQLiteStorage); ok && exact {
+			getStats = sqliteStore.GetExactStatistics
+		}
+		stats, err := getStats(ctx)
 		if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
@@ -289,7 +380,7 @@ var statsCmd = &cobra.Command{
 	if stats.TotalIssues == 0 {
 		if checkAndAutoImport(ctx, store) {
 			// Re-run the stats after import
-			stats, err = store.GetStatistics(ctx)
+			stats, err = getStats(ctx)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 				os.Exit(1)
@@ -307,6 +398,9 @@ var statsCmd = &cobra.Command{
 		fmt.Printf("Total Issues:           %d\n", stats.TotalIssues)
 		fmt.Printf("Open:                   %s\n", green(fmt.Sprintf("%d", stats.OpenIssues)))
 		fmt.Printf("In Progress:            %s\n", yellow(fmt.Sprintf("%d", stats.InProgressIssues)))
+		if stats.ResolvedIssues > 0 {
+			fmt.Printf("Resolved:               %d (awaiting verification)\n", stats.ResolvedIssues)
+		}
 		fmt.Printf("Closed:                 %d\n", stats.ClosedIssues)
 		fmt.Printf("Blocked:                %d\n", stats.BlockedIssues)
 		fmt.Printf("Ready:                  %s\n", green(fmt.Sprintf("%d", stats.ReadyIssues)))
@@ -319,6 +413,9 @@ var statsCmd = &cobra.Command{
 		if stats.AverageLeadTime > 0 {
 			fmt.Printf("Avg Lead Time:          %.1f hours\n", stats.AverageLeadTime)
 		}
+		printClosedByReason(stats.ClosedByReason)
+		printTimeLogged(stats.MinutesLoggedByActor)
+		printStatsBreakdown(stats)
 		fmt.Println()
 	},
 }
@@ -330,7 +427,15 @@ func init() {
 	readyCmd.Flags().StringP("sort", "s", "hybrid", "Sort policy: hybrid (default), priority, oldest")
 	readyCmd.Flags().StringSliceP("label", "l", []string{}, "Filter by labels (AND: must have ALL). Can combine with --label-any")
 	readyCmd.Flags().StringSlice("label-any", []string{}, "Filter by labels (OR: must have AT LEAST ONE). Can combine with --label")
+	readyCmd.Flags().StringSlice("complexity", []string{}, "Only issues with one of these complexities (trivial, standard, complex, research)")
+	readyCmd.Flags().Bool("claim", false, "Atomically claim the top ready issue (set in_progress, assign to --actor)")
+	readyCmd.Flags().String("ttl", "", "With --claim, lease the issue for this long, e.g. 30m (default: claim.ttl config, else no expiry)")
+	readyCmd.Flags().String("group-by", "", "Group ready work by epic or label, with each group's overall progress")
+	readyCmd.Flags().String("diff-since", "", "Show issues that entered or left the ready queue since a duration ago (1h, 2d) or a time, and why")
+	readyCmd.Flags().String("for", "", "Plan for an actor: their ready work now and what becomes ready once in-progress work completes")
 	rootCmd.AddCommand(readyCmd)
 	rootCmd.AddCommand(blockedCmd)
+	statsCmd.Flags().String("filter", "", "Only count issues matching a saved filter (see bd filter)")
+	statsCmd.Flags().Bool("exact", false, "Count from the issues instead of the incrementally maintained summary tables")
 	rootCmd.AddCommand(statsCmd)
 }
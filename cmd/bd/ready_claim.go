@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// runReadyClaim atomically claims the top ready issue for the current actor,
+// so concurrent agents running 'bd ready --claim' never get the same issue.
+// With a TTL (ttlFlag or claim.ttl) the claim is a lease, as with bd claim.
+func runReadyClaim(filter types.WorkFilter, readyArgs *rpc.ReadyArgs, ttlFlag string) {
+	ttl, err := claimTTL(ttlFlag)
+	if err != nil {
+		FatalError("%v", err)
+	}
+	var claimed *types.Issue
+	if daemonClient != nil {
+		readyArgs.Claim = true
+		if ttl > 0 {
+			readyArgs.ClaimTTL = ttl.String()
+		}
+		resp, err := daemonClient.Ready(readyArgs)
+		if err != nil {
+			FatalError("%v", err)
+		}
+		if err := json.Unmarshal(resp.Data, &claimed); err != nil {
+			FatalError("parsing response: %v", err)
+		}
+	} else {
+		ctx := rootCtx
+		if err := ensureDatabaseFresh(ctx); err != nil {
+			FatalError("%v", err)
+		}
+		if sqliteStore, ok := store.(*sqlite.SQLiteStorage); ok {
+			claimed, err = sqliteStore.ClaimReadyWorkWithLease(ctx, filter, actor, ttl)
+		} else {
+			claimed, err = store.ClaimReadyWork(ctx, filter, actor)
+		}
+		if err != nil {
+			FatalError("%v", err)
+		}
+		if claimed != nil {
+			markDirtyAndScheduleFlush()
+		}
+	}
+
+	if jsonOutput {
+		outputJSON(claimed)
+		return
+	}
+	if claimed == nil {
+		yellow := color.New(color.FgYellow).SprintFunc()
+		fmt.Printf("\n%s No ready work to claim\n\n", yellow("✨"))
+		return
+	}
+	green := color.New(color.FgGreen).SprintFunc()
+	fmt.Printf("%s Claimed %s: %s [P%d] (assignee: %s)\n", green("✓"), claimed.ID, claimed.Title, claimed.Priority, claimed.Assignee)
+	if ttl > 0 {
+		fmt.Printf("  Lease expires %s unless renewed with 'bd claim %s'\n", displayTime(time.Now().Add(ttl)), claimed.ID)
+	}
+}
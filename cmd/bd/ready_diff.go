@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// runReadyDiff implements bd ready --diff-since: which issues entered and
+// left the ready queue since then and why, so an orchestrator can dispatch
+// incrementally instead of re-reading the whole queue
+func runReadyDiff(sinceFlag string) {
+	if err := ensureDirectMode("ready --diff-since requires direct database access"); err != nil {
+		FatalError("%v", err)
+	}
+	sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+	if !ok {
+		FatalError("ready --diff-since requires the SQLite backend")
+	}
+	ctx := rootCtx
+	if err := ensureDatabaseFresh(ctx); err != nil {
+		FatalError("%v", err)
+	}
+
+	now := time.Now()
+	since, err := parseDiffSince(sinceFlag, now)
+	if err != nil {
+		FatalError("%v", err)
+	}
+	diff, err := sqliteStore.GetReadyDiff(ctx, since, now)
+	if err != nil {
+		FatalError("%v", err)
+	}
+
+	if jsonOutput {
+		outputJSON(diff)
+		return
+	}
+	printReadyDiff(diff)
+}
+
+// parseDiffSince reads a --diff-since value, which can't be in the future
+func parseDiffSince(s string, now time.Time) (time.Time, error) {
+	t, err := parseSinceFlag("--diff-since", s, now)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if t.After(now) {
+		return time.Time{}, fmt.Errorf("--diff-since %q is in the future", s)
+	}
+	return t, nil
+}
+
+// parseSinceFlag reads a time flag given as how long ago ("30m", "1h",
+// "2d", "2w") or as a time (YYYY-MM-DD, RFC3339, ...)
+func parseSinceFlag(flag, s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n > 0 {
+			return now.AddDate(0, 0, -n), nil
+		}
+	}
+	if weeks, ok := strings.CutSuffix(s, "w"); ok {
+		if n, err := strconv.Atoi(weeks); err == nil && n > 0 {
+			return now.AddDate(0, 0, -7*n), nil
+		}
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		if d <= 0 {
+			return time.Time{}, fmt.Errorf("%s %q must be a positive duration", flag, s)
+		}
+		return now.Add(-d), nil
+	}
+	t, err := parseTimeFlag(s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s %q (use a duration such as 1h or 2d, or a time such as 2006-01-02)", flag, s)
+	}
+	return t, nil
+}
+
+func printReadyDiff(diff *types.ReadyDiff) {
+	cyan := color.New(color.FgCyan).SprintFunc()
+	green := color.New(color.FgGreen).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
+
+	if len(diff.Entered) == 0 && len(diff.Left) == 0 {
+		fmt.Printf("\n%s No changes to the ready queue since %s\n\n", green("✨"), displayTime(diff.Since))
+		return
+	}
+	fmt.Printf("\n%s Ready queue since %s: %d entered, %d left\n", cyan("📋"), displayTime(diff.Since), len(diff.Entered), len(diff.Left))
+	if len(diff.Entered) > 0 {
+		fmt.Printf("\nEntered:\n")
+		for _, c := range diff.Entered {
+			fmt.Printf("  %s [P%d] %s: %s (%s)\n", green("+"), c.Priority, c.ID, c.Title, describeReadyReason(c))
+		}
+	}
+	if len(diff.Left) > 0 {
+		fmt.Printf("\nLeft:\n")
+		for _, c := range diff.Left {
+			fmt.Printf("  %s [P%d] %s: %s (%s)\n", red("-"), c.Priority, c.ID, c.Title, describeReadyReason(c))
+		}
+	}
+	fmt.Println()
+}
+
+// describeReadyReason renders why an issue entered or left the ready queue
+func describeReadyReason(c *types.ReadyChange) string {
+	switch c.Reason {
+	case types.ReadyReasonNewIssue:
+		return "new issue"
+	case types.ReadyReasonBlockerClosed:
+		return "blocker closed: " + strings.Join(c.Blockers, ", ")
+	case types.ReadyReasonDependencyRemoved:
+		return "blocking dependency removed"
+	case types.ReadyReasonClaimReleased:
+		return "claim released"
+	case types.ReadyReasonBlocked:
+		return "blocked by " + strings.Join(c.Blockers, ", ")
+	case types.ReadyReasonStatusChanged:
+		return "status changed"
+	default:
+		return string(c.Reason)
+	}
+}
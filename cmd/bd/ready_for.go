@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ReadyPlan is the ready queue an actor can expect now and after the work
+// currently in progress completes
+type ReadyPlan struct {
+	Actor      string                `json:"actor"`
+	InProgress []*types.Issue        `json:"in_progress"` // assumed to complete
+	Ready      []*types.Issue        `json:"ready"`
+	Next       []*types.BlockedIssue `json:"next"` // blocked_by: the in-progress issues each waits on
+}
+
+// runReadyFor implements bd ready --for: a one-step look-ahead of the ready
+// queue for forActor, so an orchestrator can stage the next assignments
+func runReadyFor(filter types.WorkFilter, forActor string) {
+	if err := ensureDirectMode("ready --for requires direct database access"); err != nil {
+		FatalError("%v", err)
+	}
+	sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+	if !ok {
+		FatalError("ready --for requires the SQLite backend")
+	}
+	ctx := rootCtx
+	if err := ensureDatabaseFresh(ctx); err != nil {
+		FatalError("%v", err)
+	}
+
+	if filter.Assignee == nil && !filter.Unassigned {
+		filter.AvailableTo = &forActor
+	}
+	filter.Status = types.StatusOpen
+	plan, err := planReadyWork(ctx, sqliteStore, filter, forActor)
+	if err != nil {
+		FatalError("%v", err)
+	}
+
+	if jsonOutput {
+		outputJSON(plan)
+		return
+	}
+	printReadyPlan(plan)
+}
+
+func planReadyWork(ctx context.Context, s *sqlite.SQLiteStorage, filter types.WorkFilter, forActor string) (*ReadyPlan, error) {
+	plan := &ReadyPlan{Actor: forActor}
+	var err error
+	if plan.Ready, err = s.GetReadyWork(ctx, filter); err != nil {
+		return nil, err
+	}
+	if plan.Next, err = s.GetNextReadyWork(ctx, filter); err != nil {
+		return nil, err
+	}
+	inProgress := types.StatusInProgress
+	if plan.InProgress, err = s.SearchIssues(ctx, "", types.IssueFilter{Status: &inProgress}); err != nil {
+		return nil, err
+	}
+	if plan.Ready == nil {
+		plan.Ready = []*types.Issue{}
+	}
+	if plan.InProgress == nil {
+		plan.InProgress = []*types.Issue{}
+	}
+	return plan, nil
+}
+
+func printReadyPlan(plan *ReadyPlan) {
+	cyan := color.New(color.FgCyan).SprintFunc()
+	gray := color.New(color.FgHiBlack).SprintFunc()
+
+	owners := make(map[string]string, len(plan.InProgress))
+	var mine []string
+	for _, issue := range plan.InProgress {
+		owners[issue.ID] = issue.Assignee
+		if issue.Assignee == plan.Actor {
+			mine = append(mine, issue.ID)
+		}
+	}
+
+	fmt.Printf("\n%s Ready for %s now (%d):\n\n", cyan("📋"), plan.Actor, len(plan.Ready))
+	if len(plan.Ready) == 0 {
+		fmt.Println("  (nothing)")
+	}
+	for i, issue := range plan.Ready {
+		fmt.Printf("%d. [P%d] %s: %s\n", i+1, issue.Priority, issue.ID, issue.Title)
+	}
+
+	fmt.Printf("\n%s Ready next, once the %d in-progress issue(s) complete (%d):\n\n", cyan("🔮"), len(plan.InProgress), len(plan.Next))
+	if len(plan.Next) == 0 {
+		fmt.Println("  (nothing new)")
+	}
+	for i, issue := range plan.Next {
+		fmt.Printf("%d. [P%d] %s: %s\n", i+1, issue.Priority, issue.ID, issue.Title)
+		after := make([]string, len(issue.BlockedBy))
+		for j, id := range issue.BlockedBy {
+			after[j] = id
+			if owner := owners[id]; owner != "" {
+				after[j] = fmt.Sprintf("%s (%s)", id, owner)
+			}
+		}
+		fmt.Printf("   After: %s\n", strings.Join(after, ", "))
+	}
+	if len(mine) > 0 {
+		fmt.Printf("\n%s\n", gray(fmt.Sprintf("%s is working on: %s", plan.Actor, strings.Join(mine, ", "))))
+	}
+	fmt.Println()
+}
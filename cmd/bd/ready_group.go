@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// runReadyGrouped implements bd ready --group-by: the usual ready work,
+// shown under the epic each item advances (or each label it carries) with
+// that epic's or label's overall progress
+func runReadyGrouped(filter types.WorkFilter, readyArgs *rpc.ReadyArgs, groupBy string) {
+	if groupBy != "epic" && groupBy != "label" {
+		FatalError("invalid --group-by %q (valid: epic, label)", groupBy)
+	}
+	ctx := rootCtx
+
+	var issues []*types.Issue
+	if daemonClient != nil {
+		resp, err := daemonClient.Ready(readyArgs)
+		if err != nil {
+			FatalError("%v", err)
+		}
+		if err := json.Unmarshal(resp.Data, &issues); err != nil {
+			FatalError("parsing response: %v", err)
+		}
+	} else {
+		if err := ensureDatabaseFresh(ctx); err != nil {
+			FatalError("%v", err)
+		}
+		var err error
+		if issues, err = store.GetReadyWork(ctx, filter); err != nil {
+			FatalError("%v", err)
+		}
+		if !jsonOutput {
+			localizeForDisplay(issues)
+		}
+	}
+
+	// The hierarchy and label totals aren't in the ready response, so read
+	// them directly, as bd blocked does when a daemon is running
+	if store == nil {
+		var err error
+		store, err = sqlite.New(ctx, dbPath)
+		if err != nil {
+			FatalError("failed to open database: %v", err)
+		}
+		defer func() { _ = store.Close() }()
+	}
+	var groups []*types.ReadyGroup
+	var err error
+	if groupBy == "epic" {
+		groups, err = storage.GroupByEpic(ctx, store, issues)
+	} else {
+		groups, err = storage.GroupByLabel(ctx, store, issues)
+	}
+	if err != nil {
+		FatalError("%v", err)
+	}
+
+	if jsonOutput {
+		if groups == nil {
+			groups = []*types.ReadyGroup{}
+		}
+		outputJSON(groups)
+		return
+	}
+	if len(issues) == 0 {
+		green := color.New(color.FgGreen).SprintFunc()
+		fmt.Printf("\n%s No ready work found\n\n", green("✨"))
+		return
+	}
+	printReadyGroups(groups, len(issues), groupBy)
+}
+
+func printReadyGroups(groups []*types.ReadyGroup, total int, groupBy string) {
+	cyan := color.New(color.FgCyan).SprintFunc()
+	bold := color.New(color.Bold).SprintFunc()
+	fmt.Printf("\n%s Ready work (%d issues with no blockers), by %s:\n", cyan("📋"), total, groupBy)
+
+	n := 0
+	for _, group := range groups {
+		fmt.Println()
+		switch {
+		case group.Epic != nil:
+			fmt.Printf("%s %s %s\n", bold(group.Epic.ID), group.Epic.Title, formatChildProgress(group.Progress))
+		case group.Label != "":
+			fmt.Printf("%s %s\n", bold(group.Label), formatChildProgress(group.Progress))
+		default:
+			fmt.Printf("%s\n", bold("No "+groupBy))
+		}
+		for _, issue := range group.Issues {
+			n++
+			fmt.Printf("  %d. [P%d] %s: %s\n", n, issue.Priority, issue.ID, issue.Title)
+			if issue.Assignee != "" {
+				fmt.Printf("     Assignee: %s\n", issue.Assignee)
+			}
+			if len(issue.SoftBlockedBy) > 0 {
+				fmt.Printf("     Preferably after: %s\n", strings.Join(issue.SoftBlockedBy, ", "))
+			}
+		}
+	}
+	fmt.Println()
+}
@@ -348,3 +348,32 @@ func TestReadyWorkUnassigned(t *testing.T) {
 		}
 	}
 }
+
+func TestParseDiffSince(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		in      string
+		want    time.Time
+		wantErr bool
+	}{
+		{"1h", now.Add(-time.Hour), false},
+		{"90m", now.Add(-90 * time.Minute), false},
+		{"2d", now.AddDate(0, 0, -2), false},
+		{"2025-03-09", time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC), false},
+		{"2025-03-10T11:30:00Z", now.Add(-30 * time.Minute), false},
+		{"0s", time.Time{}, true},
+		{"-1h", time.Time{}, true},
+		{"2025-04-01", time.Time{}, true}, // future
+		{"yesterday", time.Time{}, true},
+	}
+	for _, tt := range tests {
+		got, err := parseDiffSince(tt.in, now)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseDiffSince(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("parseDiffSince(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
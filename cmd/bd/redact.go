@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/steveyegge/beads/cmd/bd/doctor"
+	"github.com/steveyegge/beads/internal/redact"
+)
+
+// loadRedactor returns the redactor configured by redaction.* in config.yaml
+func loadRedactor() *redact.Redactor {
+	r, err := redact.FromConfig()
+	if err != nil {
+		FatalErrorWithHint(err.Error(), "fix redaction.rules or redaction.patterns in .beads/config.yaml")
+	}
+	return r
+}
+
+// redactInput strips configured secrets from user-supplied issue text in
+// place, warning on stderr when anything was removed
+func redactInput(texts ...*string) {
+	warnRedacted(loadRedactor().RedactTexts(texts...))
+}
+
+// warnRedacted tells the user which rules fired so the redaction is never
+// silent
+func warnRedacted(rules []string) {
+	if len(rules) == 0 {
+		return
+	}
+	seen := make(map[string]bool)
+	var unique []string
+	for _, rule := range rules {
+		if !seen[rule] {
+			seen[rule] = true
+			unique = append(unique, rule)
+		}
+	}
+	sort.Strings(unique)
+	yellow := color.New(color.FgYellow).SprintFunc()
+	fmt.Fprintf(os.Stderr, "%s Redacted text matching: %s\n", yellow("⚠"), strings.Join(unique, ", "))
+}
+
+// runScanSecrets implements bd doctor --scan-secrets: list stored text that
+// matches a redaction rule and exit non-zero if any is found
+func runScanSecrets(path string) {
+	r := loadRedactor()
+	if !r.Enabled() {
+		FatalErrorWithHint("no redaction rules are enabled", "set redaction.rules or redaction.patterns in .beads/config.yaml")
+	}
+
+	findings, err := doctor.ScanSecrets(path, r)
+	if err != nil {
+		FatalError("%v", err)
+	}
+
+	if jsonOutput {
+		if findings == nil {
+			findings = []doctor.SecretFinding{}
+		}
+		outputJSON(findings)
+	} else if len(findings) == 0 {
+		green := color.New(color.FgGreen).SprintFunc()
+		fmt.Printf("%s No secrets found (%d rule(s) checked)\n", green("✓"), len(r.Rules()))
+	} else {
+		red := color.New(color.FgRed).SprintFunc()
+		fmt.Printf("%s Found %d possible secret(s):\n\n", red("✖"), len(findings))
+		for _, f := range findings {
+			fmt.Printf("  %s %s [%s] %s (%s)\n", f.IssueID, f.Field, f.Source, f.Excerpt, f.Rule)
+		}
+		fmt.Println()
+		fmt.Println("Exports already redact these, but the database keeps the original text.")
+		fmt.Println("Edit the affected fields with 'bd update' or 'bd edit', then run 'bd sync'.")
+		fmt.Println("If a secret appears in the JSONL source, it has been committed: rotate it.")
+	}
+
+	if len(findings) > 0 {
+		os.Exit(1)
+	}
+}
@@ -70,7 +70,7 @@ Interactive mode with --interactive prompts for each orphan.`,
 				continue
 			}
 			for _, dep := range deps {
-				if !validIDs[dep.DependsOnID] {
+				if !validIDs[dep.DependsOnID] && !types.IsQualifiedID(dep.DependsOnID) {
 					orphans = append(orphans, orphan{
 						issueID:     dep.IssueID,
 						dependsOnID: dep.DependsOnID,
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/journal"
+	"github.com/steveyegge/beads/internal/redact"
+)
+
+// replayStripFlags select the project a recorded command ran against; a
+// replay runs against the current one instead
+var replayStripFlags = []string{"--db", "--project"}
+
+// ReplayResult is the outcome of one replayed command
+type ReplayResult struct {
+	Args         []string `json:"args"`
+	OriginalExit *int     `json:"original_exit,omitempty"`
+	ExitCode     int      `json:"exit_code"`
+	Matched      bool     `json:"matched"` // same success/failure as the original, if it was recorded
+	Output       string   `json:"output,omitempty"`
+}
+
+var replayCmd = &cobra.Command{
+	Use:   "replay",
+	Short: "Re-run commands recorded in a command journal",
+	Long: `Re-run the bd commands recorded in a command journal against the
+current project, to reproduce a bug or repeat a workflow elsewhere.
+
+Record commands by enabling the journal (history: true in config.yaml or
+BD_HISTORY=1); they are appended to ~/.beads/history.jsonl, or to the file
+named by history-file / BD_HISTORY_FILE.
+
+Commands run in order in the current directory. --db and --project are
+dropped so they apply here, and arguments (including issue IDs) are
+otherwise replayed verbatim, so a journal that refers to existing issues
+replays best against a copy of the original project. Commands recorded with
+secrets redacted are skipped. Replay stops at the first command that fails
+where the original succeeded, unless --keep-going.
+
+Examples:
+  bd replay --from bug-report.jsonl --dry-run
+  bd replay --from ~/.beads/history.jsonl --last 20
+  bd replay --from steps.jsonl --keep-going`,
+	Run: func(cmd *cobra.Command, args []string) {
+		from, _ := cmd.Flags().GetString("from")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		keepGoing, _ := cmd.Flags().GetBool("keep-going")
+		last, _ := cmd.Flags().GetInt("last")
+
+		if from == "" {
+			FatalError("--from is required")
+		}
+		entries, err := journal.Read(from)
+		if err != nil {
+			FatalError("failed to read journal: %v", err)
+		}
+		entries = replayableEntries(entries)
+		if last > 0 && len(entries) > last {
+			entries = entries[len(entries)-last:]
+		}
+		if len(entries) == 0 {
+			FatalError("no commands to replay in %s", from)
+		}
+
+		if dryRun {
+			if jsonOutput {
+				outputJSON(entries)
+				return
+			}
+			for _, entry := range entries {
+				fmt.Printf("bd %s\n", strings.Join(replayArgs(entry.Args), " "))
+			}
+			fmt.Printf("\n%d command(s) would be replayed\n", len(entries))
+			return
+		}
+
+		exe, err := os.Executable()
+		if err != nil {
+			FatalError("failed to locate bd: %v", err)
+		}
+		var results []*ReplayResult
+		stopped := false
+		for _, entry := range entries {
+			result := replayEntry(exe, entry)
+			results = append(results, result)
+			if result.regressed() && !keepGoing {
+				stopped = true
+				break
+			}
+		}
+
+		if jsonOutput {
+			outputJSON(results)
+		} else {
+			printReplaySummary(results, len(entries))
+		}
+		if stopped {
+			os.Exit(1)
+		}
+	},
+}
+
+// replayableEntries drops commands a replay should not or cannot run again
+func replayableEntries(entries []*journal.Entry) []*journal.Entry {
+	var out []*journal.Entry
+	for _, entry := range entries {
+		if args := replayArgs(entry.Args); len(args) == 0 || args[0] == "replay" {
+			continue
+		}
+		if slices.ContainsFunc(entry.Args, func(arg string) bool { return strings.Contains(arg, redact.DefaultReplacement) }) {
+			fmt.Fprintf(os.Stderr, "Skipping bd %s: recorded with secrets redacted\n", strings.Join(entry.Args, " "))
+			continue
+		}
+		out = append(out, entry)
+	}
+	return out
+}
+
+// replayArgs removes the flags that tie a recorded command to its project
+func replayArgs(args []string) []string {
+	var out []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		stripped := false
+		for _, flag := range replayStripFlags {
+			if arg == flag {
+				i++ // and its value
+				stripped = true
+				break
+			}
+			if strings.HasPrefix(arg, flag+"=") {
+				stripped = true
+				break
+			}
+		}
+		if !stripped {
+			out = append(out, arg)
+		}
+	}
+	return out
+}
+
+func replayEntry(exe string, entry *journal.Entry) *ReplayResult {
+	args := replayArgs(entry.Args)
+	result := &ReplayResult{Args: args, OriginalExit: entry.ExitCode}
+	fmt.Fprintf(os.Stderr, "%s bd %s\n", color.New(color.FgCyan).Sprint("▶"), strings.Join(args, " "))
+
+	// #nosec G204 - re-running bd with arguments from the user's journal
+	child := exec.Command(exe, args...)
+	child.Env = append(os.Environ(), "BD_HISTORY=0")
+	var output bytes.Buffer
+	if jsonOutput {
+		child.Stdout = &output
+		child.Stderr = &output
+	} else {
+		child.Stdout = os.Stdout
+		child.Stderr = os.Stderr
+	}
+	err := child.Run()
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+	case errors.As(err, &exitErr):
+		result.ExitCode = exitErr.ExitCode()
+	default:
+		result.ExitCode = -1
+		fmt.Fprintf(&output, "%v", err)
+	}
+	result.Output = output.String()
+	result.Matched = entry.ExitCode != nil && (result.ExitCode == 0) == (*entry.ExitCode == 0)
+	return result
+}
+
+// regressed reports whether a command that originally succeeded failed
+func (r *ReplayResult) regressed() bool {
+	return r.OriginalExit != nil && *r.OriginalExit == 0 && r.ExitCode != 0
+}
+
+func printReplaySummary(results []*ReplayResult, total int) {
+	matched, unknown := 0, 0
+	for _, result := range results {
+		switch {
+		case result.Matched:
+			matched++
+		case result.OriginalExit == nil:
+			unknown++
+		}
+	}
+	fmt.Printf("\nReplayed %d of %d command(s): %d matched the original result, %d differed, %d had no recorded result\n",
+		len(results), total, matched, len(results)-matched-unknown, unknown)
+	for _, result := range results {
+		if result.Matched || result.OriginalExit == nil {
+			continue
+		}
+		original := fmt.Sprintf("originally exited %d", *result.OriginalExit)
+		fmt.Printf("  %s bd %s: exited %d, %s\n", color.New(color.FgYellow).Sprint("⚠"),
+			strings.Join(result.Args, " "), result.ExitCode, original)
+	}
+}
+
+func init() {
+	replayCmd.Flags().String("from", "", "Journal file to replay (required)")
+	replayCmd.Flags().Bool("dry-run", false, "Print the commands without running them")
+	replayCmd.Flags().Bool("keep-going", false, "Continue after a command fails where the original succeeded")
+	replayCmd.Flags().Int("last", 0, "Replay only the last N commands")
+	rootCmd.AddCommand(replayCmd)
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var resolveCmd = &cobra.Command{
+	Use:   "resolve [id...]",
+	Short: "Mark issues resolved with evidence, pending a reviewer's close",
+	Long: `Mark issues resolved: the work is done and the evidence is attached, but a
+human or reviewer agent still has to verify it and perform the final close.
+
+The evidence (links, test output, commit SHAs) is recorded as a comment whose
+author is the resolver. Resolved issues leave the ready queue and no longer
+block their dependents; find the review queue with 'bd list --status resolved'.
+
+Projects can require this two-phase close for some issues. For issues with a
+listed label or at the given priority or more urgent, 'bd close' then refuses
+unless the issue is resolved and the closer is not the resolver:
+
+  bd config set close.verify_labels "security,release"
+  bd config set close.verify_priority 1      # P0 and P1
+
+A reviewer who rejects the resolution sends the issue back with
+'bd update <id> --status in_progress'.
+
+Examples:
+  bd resolve bd-42 --evidence "PR #118, all tests green"
+  bd resolve bd-42 --evidence-file test-output.txt`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("resolve")
+		evidence, _ := cmd.Flags().GetStringArray("evidence")
+		evidenceFile, _ := cmd.Flags().GetString("evidence-file")
+		if evidenceFile != "" {
+			data, err := os.ReadFile(evidenceFile) // #nosec G304 - user-provided file path is intentional
+			if err != nil {
+				FatalError("reading evidence file: %v", err)
+			}
+			evidence = append(evidence, strings.TrimRight(string(data), "\n"))
+		}
+		text := strings.TrimSpace(strings.Join(evidence, "\n"))
+		if text == "" {
+			FatalErrorWithHint("evidence is required to resolve an issue", "pass --evidence \"...\" or --evidence-file <path>")
+		}
+		text = types.ResolutionCommentPrefix + text
+		redactInput(&text)
+
+		ctx := rootCtx
+		resolvedStatus := string(types.StatusResolved)
+		resolved := []*types.Issue{}
+
+		// If daemon is running, use RPC
+		if daemonClient != nil {
+			for _, id := range args {
+				resp, err := daemonClient.ResolveID(&rpc.ResolveIDArgs{ID: id})
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error resolving ID %s: %v\n", id, err)
+					continue
+				}
+				var fullID string
+				if err := json.Unmarshal(resp.Data, &fullID); err != nil {
+					fmt.Fprintf(os.Stderr, "Error unmarshaling resolved ID: %v\n", err)
+					continue
+				}
+				resp, err = daemonClient.Show(&rpc.ShowArgs{ID: fullID})
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error fetching %s: %v\n", fullID, err)
+					continue
+				}
+				var current types.Issue
+				if err := json.Unmarshal(resp.Data, &current); err == nil && current.Status == types.StatusClosed {
+					fmt.Fprintf(os.Stderr, "Error resolving %s: issue is already closed\n", fullID)
+					continue
+				}
+				resp, err = daemonClient.Update(&rpc.UpdateArgs{ID: fullID, Status: &resolvedStatus})
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error resolving %s: %v\n", fullID, err)
+					continue
+				}
+				if _, err := daemonClient.AddComment(&rpc.CommentAddArgs{ID: fullID, Author: actor, Text: text}); err != nil {
+					fmt.Fprintf(os.Stderr, "Error recording evidence for %s: %v\n", fullID, err)
+					continue
+				}
+				var issue types.Issue
+				if err := json.Unmarshal(resp.Data, &issue); err == nil {
+					resolved = append(resolved, &issue)
+				}
+				if !jsonOutput {
+					printResolved(fullID)
+				}
+			}
+			if jsonOutput {
+				outputJSON(resolved)
+			}
+			return
+		}
+
+		for _, id := range args {
+			fullID, err := utils.ResolvePartialID(ctx, store, id)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error resolving %s: %v\n", id, err)
+				continue
+			}
+			issue, err := store.GetIssue(ctx, fullID)
+			if err != nil || issue == nil {
+				fmt.Fprintf(os.Stderr, "Error resolving %s: issue not found\n", fullID)
+				continue
+			}
+			if issue.Status == types.StatusClosed {
+				fmt.Fprintf(os.Stderr, "Error resolving %s: issue is already closed\n", fullID)
+				continue
+			}
+			if err := store.UpdateIssue(ctx, fullID, map[string]interface{}{"status": resolvedStatus}, actor); err != nil {
+				fmt.Fprintf(os.Stderr, "Error resolving %s: %v\n", fullID, err)
+				continue
+			}
+			if _, err := store.AddIssueComment(ctx, fullID, actor, text); err != nil {
+				fmt.Fprintf(os.Stderr, "Error recording evidence for %s: %v\n", fullID, err)
+				continue
+			}
+			if issue, _ := store.GetIssue(ctx, fullID); issue != nil {
+				resolved = append(resolved, issue)
+			}
+			if !jsonOutput {
+				printResolved(fullID)
+			}
+		}
+		markDirtyAndScheduleFlush()
+		if jsonOutput {
+			outputJSON(resolved)
+		}
+	},
+}
+
+func printResolved(id string) {
+	green := color.New(color.FgGreen).SprintFunc()
+	fmt.Printf("%s Resolved %s (awaiting verification; close with 'bd close %s')\n", green("✓"), id, id)
+}
+
+func init() {
+	resolveCmd.Flags().StringArray("evidence", nil, "Evidence the work is done: links, test output, commit SHAs (repeatable)")
+	resolveCmd.Flags().String("evidence-file", "", "Read evidence from a file")
+	rootCmd.AddCommand(resolveCmd)
+}
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"bufio"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -11,6 +10,7 @@ import (
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/util"
 )
 
 var restoreCmd = &cobra.Command{
@@ -161,10 +161,7 @@ func readIssueFromJSONL(jsonlPath, issueID string) (*types.Issue, error) {
 	}
 	defer func() { _ = file.Close() }()
 
-	scanner := bufio.NewScanner(file)
-	// Increase buffer size for large issues
-	buf := make([]byte, 0, 64*1024)
-	scanner.Buffer(buf, 10*1024*1024) // 10MB max
+	scanner := util.NewJSONLScanner(file)
 
 	for scanner.Scan() {
 		var issue types.Issue
@@ -223,10 +220,10 @@ func displayRestoredIssue(issue *types.Issue, commitHash string) {
 		fmt.Printf("%s %s\n", bold("Labels:"), strings.Join(issue.Labels, ", "))
 	}
 
-	fmt.Printf("\n%s %s\n", bold("Created:"), issue.CreatedAt.Format("2006-01-02 15:04:05"))
-	fmt.Printf("%s %s\n", bold("Updated:"), issue.UpdatedAt.Format("2006-01-02 15:04:05"))
+	fmt.Printf("\n%s %s\n", bold("Created:"), displayTime(issue.CreatedAt))
+	fmt.Printf("%s %s\n", bold("Updated:"), displayTime(issue.UpdatedAt))
 	if issue.ClosedAt != nil {
-		fmt.Printf("%s %s\n", bold("Closed:"), issue.ClosedAt.Format("2006-01-02 15:04:05"))
+		fmt.Printf("%s %s\n", bold("Closed:"), displayTime(*issue.ClosedAt))
 	}
 
 	if len(issue.Dependencies) > 0 {
@@ -239,7 +236,7 @@ func displayRestoredIssue(issue *types.Issue, commitHash string) {
 	if issue.CompactionLevel > 0 {
 		fmt.Printf("\n%s Level %d", yellow("⚠️  This issue was compacted:"), issue.CompactionLevel)
 		if issue.CompactedAt != nil {
-			fmt.Printf(" at %s", issue.CompactedAt.Format("2006-01-02 15:04:05"))
+			fmt.Printf(" at %s", displayTime(*issue.CompactedAt))
 		}
 		if issue.OriginalSize > 0 {
 			currentSize := len(issue.Description) + len(issue.Design) + len(issue.AcceptanceCriteria) + len(issue.Notes)
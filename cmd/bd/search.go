@@ -9,6 +9,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/util"
 	"github.com/steveyegge/beads/internal/validation"
@@ -17,9 +18,20 @@ import (
 var searchCmd = &cobra.Command{
 	Use:   "search [query]",
 	Short: "Search issues by text query",
-	Long: `Search issues across title, description, and ID.
+	Long: `Search issues across title, description, comments, attachment text, and ID.
+
+Words are matched through a full-text index and results are ranked by
+relevance, with title matches first:
+  - every word must match, and matches as a prefix ("time" finds "timeout")
+  - "quoted phrases" match exactly
+  - title:, desc:, comment: and attach: limit a word or phrase to one field
+  - OR between two terms matches either
+Partial IDs match as substrings. --sort replaces relevance order.
 
 Examples:
+  bd search "flaky test timeout"
+  bd search 'title:flaky desc:"connection reset"'
+  bd search "login OR oauth" --status open
   bd search "authentication bug"
   bd search "login" --status open
   bd search "database" --label backend --limit 10
@@ -29,7 +41,11 @@ Examples:
   bd search "bug" --created-after 2025-01-01
   bd search "refactor" --updated-after 2025-01-01 --priority-min 1
   bd search "bug" --sort priority
-  bd search "task" --sort created --reverse`,
+  bd search "task" --sort created --reverse
+  bd search --semantic "flaky auth token refresh"
+
+--semantic ranks issues by embedding similarity instead of matching words.
+Build the index first with 'bd index' (same --provider/--model).`,
 	Run: func(cmd *cobra.Command, args []string) {
 		// Get query from args or --query flag
 		queryFlag, _ := cmd.Flags().GetString("query")
@@ -56,6 +72,7 @@ Examples:
 		limit, _ := cmd.Flags().GetInt("limit")
 		labels, _ := cmd.Flags().GetStringSlice("label")
 		labelsAny, _ := cmd.Flags().GetStringSlice("label-any")
+		fieldFlags, _ := cmd.Flags().GetStringArray("field")
 		longFormat, _ := cmd.Flags().GetBool("long")
 		sortBy, _ := cmd.Flags().GetString("sort")
 		reverse, _ := cmd.Flags().GetBool("reverse")
@@ -76,9 +93,15 @@ Examples:
 		labels = util.NormalizeLabels(labels)
 		labelsAny = util.NormalizeLabels(labelsAny)
 
+		sortKeys, err := resolveSortKeys(sortBy, "", reverse)
+		if err != nil {
+			FatalError("invalid --sort: %v", err)
+		}
+
 		// Build filter
 		filter := types.IssueFilter{
 			Limit: limit,
+			Sort:  sortKeys,
 		}
 
 		if status != "" && status != "all" {
@@ -103,6 +126,17 @@ Examples:
 			filter.LabelsAny = labelsAny
 		}
 
+		fields, err := parseFieldFlags(fieldFlags)
+		if err != nil {
+			FatalError("invalid --field: %v", err)
+		}
+		if daemonClient == nil {
+			if fields, err = storage.NormalizeFields(rootCtx, store, fields, false); err != nil {
+				FatalError("%v", err)
+			}
+		}
+		filter.Fields = fields
+
 		// Date ranges
 		if createdAfter != "" {
 			t, err := parseTimeFlag(createdAfter)
@@ -173,6 +207,11 @@ Examples:
 
 		ctx := rootCtx
 
+		if semantic, _ := cmd.Flags().GetBool("semantic"); semantic {
+			runSemanticSearch(cmd, query, filter, limit, longFormat)
+			return
+		}
+
 		// Check database freshness before reading (skip when using daemon)
 		if daemonClient == nil {
 			if err := ensureDatabaseFresh(ctx); err != nil {
@@ -184,7 +223,7 @@ Examples:
 		// If daemon is running, use RPC
 		if daemonClient != nil {
 			listArgs := &rpc.ListArgs{
-				Query:     query, // This will search title/description/id with OR logic
+				Query:     query, // Full-text index plus partial ID match (see SearchIssues)
 				Status:    status,
 				IssueType: issueType,
 				Assignee:  assignee,
@@ -198,6 +237,7 @@ Examples:
 			if len(labelsAny) > 0 {
 				listArgs.LabelsAny = labelsAny
 			}
+			listArgs.Fields = fields
 
 			// Date ranges
 			if filter.CreatedAfter != nil {
@@ -222,6 +262,7 @@ Examples:
 			// Priority range
 			listArgs.PriorityMin = filter.PriorityMin
 			listArgs.PriorityMax = filter.PriorityMax
+			listArgs.Sort = sortKeys.String()
 
 			resp, err := daemonClient.List(listArgs)
 			if err != nil {
@@ -245,15 +286,12 @@ Examples:
 				os.Exit(1)
 			}
 
-			// Apply sorting
-			sortIssues(issues, sortBy, reverse)
-
 			outputSearchResults(issues, query, longFormat)
 			return
 		}
 
 		// Direct mode - search using store
-		// The query parameter in SearchIssues already searches across title, description, and id
+		// SearchIssues matches the query against the full-text index and IDs
 		issues, err := store.SearchIssues(ctx, query, filter)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -272,9 +310,6 @@ Examples:
 			}
 		}
 
-		// Apply sorting
-		sortIssues(issues, sortBy, reverse)
-
 		if jsonOutput {
 			// Get labels and dependency counts
 			issueIDs := make([]string, len(issues))
@@ -370,15 +405,19 @@ func outputSearchResults(issues []*types.Issue, query string, longFormat bool) {
 
 func init() {
 	searchCmd.Flags().String("query", "", "Search query (alternative to positional argument)")
-	searchCmd.Flags().StringP("status", "s", "", "Filter by status (open, in_progress, blocked, closed)")
+	searchCmd.Flags().StringP("status", "s", "", "Filter by status (open, in_progress, blocked, resolved, closed)")
 	searchCmd.Flags().StringP("assignee", "a", "", "Filter by assignee")
 	searchCmd.Flags().StringP("type", "t", "", "Filter by type (bug, feature, task, epic, chore)")
 	searchCmd.Flags().StringSliceP("label", "l", []string{}, "Filter by labels (AND: must have ALL)")
 	searchCmd.Flags().StringSlice("label-any", []string{}, "Filter by labels (OR: must have AT LEAST ONE)")
+	searchCmd.Flags().StringArray("field", nil, "Filter by custom field value, name=value (repeatable; AND semantics)")
 	searchCmd.Flags().IntP("limit", "n", 50, "Limit results (default: 50)")
 	searchCmd.Flags().Bool("long", false, "Show detailed multi-line output for each issue")
-	searchCmd.Flags().String("sort", "", "Sort by field: priority, created, updated, closed, status, id, title, type, assignee")
+	searchCmd.Flags().String("sort", "", "Sort by comma-separated fields, '-' for descending (e.g. priority,-updated_at)")
 	searchCmd.Flags().BoolP("reverse", "r", false, "Reverse sort order")
+	searchCmd.Flags().Bool("semantic", false, "Rank by embedding similarity (requires 'bd index')")
+	searchCmd.Flags().String("provider", "", "Embeddings provider for --semantic (default from embeddings.provider)")
+	searchCmd.Flags().String("model", "", "Embeddings model for --semantic (default from embeddings.model)")
 
 	// Date range flags
 	searchCmd.Flags().String("created-after", "", "Filter issues created after date (YYYY-MM-DD or RFC3339)")
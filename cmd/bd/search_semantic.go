@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/embeddings"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// semanticResult is an issue with its similarity to the query
+type semanticResult struct {
+	*types.Issue
+	Score float64 `json:"score"`
+}
+
+// runSemanticSearch ranks indexed issues by similarity to query, then applies
+// the regular search filters to the ranked candidates
+func runSemanticSearch(cmd *cobra.Command, query string, filter types.IssueFilter, limit int, longFormat bool) {
+	if err := ensureDirectMode("search --semantic requires direct database access"); err != nil {
+		FatalError("%v", err)
+	}
+	sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+	if !ok {
+		FatalError("search --semantic requires SQLite storage")
+	}
+	ctx := rootCtx
+	if err := ensureDatabaseFresh(ctx); err != nil {
+		FatalError("%v", err)
+	}
+
+	provider := embeddingsProviderFromFlags(cmd)
+	stored, err := sqliteStore.GetEmbeddings(ctx, provider.Name(), provider.Model())
+	if err != nil {
+		FatalError("%v", err)
+	}
+	if len(stored) == 0 {
+		FatalErrorWithHint(fmt.Sprintf("no embeddings for %s/%s", provider.Name(), provider.Model()),
+			fmt.Sprintf("run 'bd index --provider %s' first", provider.Name()))
+	}
+
+	vectors, err := provider.Embed(ctx, []string{query})
+	if err != nil {
+		FatalError("embedding query failed: %v", err)
+	}
+	candidates := make(map[string][]float32, len(stored))
+	for _, e := range stored {
+		candidates[e.IssueID] = e.Vector
+	}
+	matches := embeddings.Rank(vectors[0], candidates, 0, 0)
+
+	ids := make([]string, len(matches))
+	for i, m := range matches {
+		ids[i] = m.IssueID
+	}
+	filter.IDs = ids
+	filter.Limit = 0
+	var issues []*types.Issue
+	if len(ids) > 0 {
+		issues, err = store.SearchIssues(ctx, "", filter)
+		if err != nil {
+			FatalError("%v", err)
+		}
+	}
+	byID := make(map[string]*types.Issue, len(issues))
+	for _, issue := range issues {
+		byID[issue.ID] = issue
+	}
+
+	results := []semanticResult{}
+	for _, m := range matches {
+		issue, ok := byID[m.IssueID]
+		if !ok {
+			continue
+		}
+		results = append(results, semanticResult{Issue: issue, Score: m.Score})
+		if limit > 0 && len(results) >= limit {
+			break
+		}
+	}
+
+	resultIDs := make([]string, len(results))
+	for i, r := range results {
+		resultIDs[i] = r.ID
+	}
+	labelsMap, _ := store.GetLabelsForIssues(ctx, resultIDs)
+	for _, r := range results {
+		r.Labels = labelsMap[r.ID]
+	}
+
+	if jsonOutput {
+		outputJSON(results)
+		return
+	}
+	if len(results) == 0 {
+		fmt.Printf("No issues found similar to '%s'\n", query)
+		return
+	}
+
+	fmt.Printf("Found %d issues similar to '%s':\n", len(results), query)
+	for _, r := range results {
+		if longFormat {
+			fmt.Printf("%.3f  %s [P%d] [%s] %s\n", r.Score, r.ID, r.Priority, r.IssueType, r.Status)
+			fmt.Printf("       %s\n", r.Title)
+			if r.Assignee != "" {
+				fmt.Printf("       Assignee: %s\n", r.Assignee)
+			}
+			if len(r.Labels) > 0 {
+				fmt.Printf("       Labels: %v\n", r.Labels)
+			}
+			fmt.Println()
+			continue
+		}
+		fmt.Printf("%.3f  %s [P%d] [%s] %s - %s\n", r.Score, r.ID, r.Priority, r.IssueType, r.Status, r.Title)
+	}
+}
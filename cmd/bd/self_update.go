@@ -0,0 +1,207 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/selfupdate"
+)
+
+var selfUpdateCmd = &cobra.Command{
+	Use:   "self-update",
+	Short: "Update bd to the latest release",
+	Long: `Download the latest bd release for this platform and replace the running
+binary with it, without a package manager.
+
+The archive is checked against the release's checksums.txt before anything is
+touched. When self-update.public_key is configured, checksums.txt must also
+carry a valid ed25519 signature (checksums.txt.sig), so a compromised mirror
+can't serve a different binary. The new binary has to run and report the
+expected version before it replaces the old one, which is kept next to it as
+bd.old for --rollback.
+
+Releases come from GitHub unless self-update.feed points at a mirror serving
+the same releases API (useful for fleets without internet access).
+
+Examples:
+  bd self-update                 # Install the latest release
+  bd self-update --check         # Only report whether an update is available
+  bd self-update --to 0.31.0     # Install a specific release (also downgrades)
+  bd self-update --rollback      # Go back to the binary replaced last time`,
+	Run: func(cmd *cobra.Command, args []string) {
+		checkOnly, _ := cmd.Flags().GetBool("check")
+		target, _ := cmd.Flags().GetString("to")
+		rollback, _ := cmd.Flags().GetBool("rollback")
+		force, _ := cmd.Flags().GetBool("force")
+
+		exe, err := currentExecutable()
+		if err != nil {
+			FatalError("cannot locate the bd binary: %v", err)
+		}
+		if !checkOnly && !force {
+			if manager := packageManagerFor(exe); manager != "" {
+				FatalErrorWithHint(fmt.Sprintf("%s is managed by %s", exe, manager),
+					fmt.Sprintf("update it with %s, or pass --force to replace it anyway", manager))
+			}
+		}
+
+		if rollback {
+			if err := selfupdate.Rollback(exe); err != nil {
+				FatalError("rollback failed: %v", err)
+			}
+			if jsonOutput {
+				outputJSON(map[string]interface{}{"rolled_back": true, "path": exe})
+				return
+			}
+			fmt.Printf("Restored the previous bd binary at %s\n", exe)
+			return
+		}
+
+		updater, err := newSelfUpdater()
+		if err != nil {
+			FatalErrorWithHint(err.Error(), "fix self-update.public_key in config.yaml")
+		}
+		ctx, cancel := context.WithTimeout(rootCtx, 10*time.Minute)
+		defer cancel()
+
+		var rel *selfupdate.Release
+		if target != "" {
+			rel, err = updater.Release(ctx, target)
+		} else {
+			rel, err = updater.Latest(ctx)
+		}
+		if err != nil {
+			FatalError("cannot read release feed: %v", err)
+		}
+		latest := rel.Version()
+		available := compareVersions(latest, Version) > 0 || (target != "" && latest != Version)
+
+		if checkOnly {
+			if jsonOutput {
+				outputJSON(map[string]interface{}{
+					"current_version":  Version,
+					"latest_version":   latest,
+					"update_available": available,
+				})
+				return
+			}
+			if available {
+				fmt.Printf("bd %s is available (you have %s). Run 'bd self-update' to install it.\n", latest, Version)
+			} else {
+				fmt.Printf("bd %s is up to date\n", Version)
+			}
+			return
+		}
+		if !available && !force {
+			if jsonOutput {
+				outputJSON(map[string]interface{}{"updated": false, "version": Version})
+				return
+			}
+			fmt.Printf("bd %s is up to date\n", Version)
+			return
+		}
+
+		if !jsonOutput {
+			fmt.Printf("Downloading bd %s for %s/%s...\n", latest, runtime.GOOS, runtime.GOARCH)
+		}
+		binary, signed, err := updater.Download(ctx, rel, runtime.GOOS, runtime.GOARCH)
+		if err != nil {
+			FatalError("%v", err)
+		}
+		check := func(path string) error { return checkNewBinary(ctx, path, latest) }
+		if err := selfupdate.Install(exe, binary, check); err != nil {
+			FatalErrorWithHint(err.Error(), "nothing was changed; check write access to "+filepath.Dir(exe))
+		}
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{
+				"updated":          true,
+				"previous_version": Version,
+				"version":          latest,
+				"path":             exe,
+				"signed":           signed,
+			})
+			return
+		}
+		verified := "checksum verified"
+		if signed {
+			verified = "signature and checksum verified"
+		}
+		fmt.Printf("✓ Updated bd %s → %s (%s)\n", Version, latest, verified)
+		fmt.Printf("  Previous binary kept at %s; 'bd self-update --rollback' restores it\n", selfupdate.BackupPath(exe))
+		fmt.Println("  Restart running daemons to pick it up: bd daemons killall")
+	},
+}
+
+// newSelfUpdater configures the release feed and signing key from config
+func newSelfUpdater() (*selfupdate.Updater, error) {
+	u := &selfupdate.Updater{
+		Feed:      config.GetString("self-update.feed"),
+		UserAgent: "beads-cli/" + Version,
+	}
+	if key := config.GetString("self-update.public_key"); key != "" {
+		pub, err := selfupdate.ParsePublicKey(key)
+		if err != nil {
+			return nil, err
+		}
+		u.PublicKey = pub
+	}
+	return u, nil
+}
+
+// currentExecutable is the real path of the running bd binary
+func currentExecutable() (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	return filepath.EvalSymlinks(exe)
+}
+
+// packageManagerFor names the package manager that installed exe, if any:
+// replacing its files behind its back breaks its next upgrade
+func packageManagerFor(exe string) string {
+	p := filepath.ToSlash(exe)
+	switch {
+	case strings.Contains(p, "/Cellar/") || strings.Contains(p, "/homebrew/"):
+		return "brew"
+	case strings.HasPrefix(p, "/nix/store/"):
+		return "nix"
+	case strings.Contains(p, "/scoop/apps/"):
+		return "scoop"
+	case strings.Contains(p, "/node_modules/"):
+		return "npm"
+	}
+	return ""
+}
+
+// checkNewBinary runs a downloaded binary and makes sure it is the release
+// we asked for before it replaces the running one
+func checkNewBinary(ctx context.Context, path, version string) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, path, "version").CombinedOutput() // #nosec G204 - path is the verified download
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out)))
+	}
+	if !strings.Contains(string(out), version) {
+		return fmt.Errorf("reports %q, expected version %s", strings.TrimSpace(string(out)), version)
+	}
+	return nil
+}
+
+func init() {
+	selfUpdateCmd.Flags().Bool("check", false, "Only check whether a newer release is available")
+	selfUpdateCmd.Flags().String("to", "", "Install this release instead of the latest")
+	selfUpdateCmd.Flags().Bool("rollback", false, "Restore the binary replaced by the last update")
+	selfUpdateCmd.Flags().Bool("force", false, "Reinstall even if up to date, or replace a package-managed binary")
+	rootCmd.AddCommand(selfUpdateCmd)
+}
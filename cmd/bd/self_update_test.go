@@ -0,0 +1,17 @@
+package main
+
+import "testing"
+
+func TestPackageManagerFor(t *testing.T) {
+	for exe, want := range map[string]string{
+		"/opt/homebrew/Cellar/bd/0.30.5/bin/bd":  "brew",
+		"/nix/store/abc123-beads-0.30.5/bin/bd":  "nix",
+		"/usr/lib/node_modules/@beads/bd/bin/bd": "npm",
+		"/usr/local/bin/bd":                      "",
+		"/home/agent/.local/bin/bd":              "",
+	} {
+		if got := packageManagerFor(exe); got != want {
+			t.Errorf("packageManagerFor(%q) = %q, want %q", exe, got, want)
+		}
+	}
+}
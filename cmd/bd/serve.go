@@ -0,0 +1,256 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/configfile"
+	"github.com/steveyegge/beads/internal/editorrpc"
+	"github.com/steveyegge/beads/internal/hosting"
+	"github.com/steveyegge/beads/internal/oidc"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run a long-lived server for editors, browsers or hosting",
+	Long: `Run bd as a long-lived backend process.
+
+--lsp-like speaks JSON-RPC 2.0 over stdin/stdout with LSP-style
+Content-Length framing, so editor extensions (VS Code, JetBrains, Neovim)
+can keep one bd process per workspace instead of spawning the CLI per action.
+
+Methods:
+  initialize      Handshake; optional rootPath opens a project
+  project/open    Switch to the project containing a path
+  issues/query    List or search issues (ready: true for unblocked work;
+                  pageSize/cursor page through results with nextCursor)
+  issues/get      Issue with labels, dependencies, dependents, and comments
+  issues/create   Create an issue
+  issues/update   Update title, status, priority, assignee, and text fields
+  issues/close    Close an issue
+  comments/add    Add a comment
+  lens/resolve    Find issue IDs in a document and resolve their live status
+  watch/start     Push issues/changed notifications as issues change
+  watch/stop      Stop change notifications
+  shutdown, exit  Terminate the server
+
+Mutations are flushed to JSONL exactly like CLI commands. Diagnostics go to
+stderr; stdout carries only protocol messages.
+
+--multi-tenant hosts many projects from one HTTP server. Each tenant gets an
+isolated database under --data-dir, its own bearer token, and optional quotas
+(manage tenants with 'bd tenant'). Requests are routed by path:
+
+  GET   /t/<tenant>/issues               List issues (?status=&type=&assignee=&priority=&label=&q=&limit=;
+                                         ?page_size=&cursor= returns {issues, next_cursor})
+  POST  /t/<tenant>/issues               Create an issue
+  GET   /t/<tenant>/issues/<id>          Show an issue
+  PATCH /t/<tenant>/issues/<id>          Update an issue
+  POST  /t/<tenant>/issues/<id>/close    Close an issue
+  POST  /t/<tenant>/issues/<id>/comments Add a comment
+  GET   /t/<tenant>/ready                Ready work
+  GET   /t/<tenant>/stats                Statistics and quota usage
+  GET   /healthz                         Liveness check (no auth)
+
+Requests authenticate with "Authorization: Bearer <token>". The optional
+X-Beads-Actor header attributes changes to a person; otherwise the tenant
+name is used. Hosted tenants have no JSONL or git sync.
+
+With oidc.issuer and oidc.client_id set, the bearer token may instead be an
+ID token from your SSO provider (obtained with 'bd auth login'). The actor
+comes from the token's email claim, and oidc.roles rules such as
+"eng=writer" or "support=acme:reader" map its groups to reader or writer
+access per tenant. Identities matching no rule are refused.
+
+--web serves a read-only web viewer on the given address: an issue list with
+search and filters, issue details with comments, and dependency graphs, for
+people who want to browse the tracker without installing bd. It reads
+through the daemon when one is running, so pages reflect what the daemon
+syncs; otherwise from the database directly. Nothing can be changed from
+the viewer, and it has no login: bind it to 127.0.0.1 or put it behind an
+authenticating proxy if the tracker isn't public. The JSON it renders is
+available too, under /api/project, /api/issues (?q=&status=&type=&priority=
+&label=&assignee=&limit=), /api/issues/<id> and /api/graph/<id> (?depth=1-3).
+
+Examples:
+  bd serve --lsp-like
+  bd serve --lsp-like --watch-interval 500ms
+  bd serve --web :8080
+  bd serve --web 127.0.0.1:8080
+  bd serve --multi-tenant --data-dir /var/lib/beads --listen :8420`,
+	Run: func(cmd *cobra.Command, args []string) {
+		lspLike, _ := cmd.Flags().GetBool("lsp-like")
+		multiTenant, _ := cmd.Flags().GetBool("multi-tenant")
+		web, _ := cmd.Flags().GetString("web")
+		watchInterval, _ := cmd.Flags().GetDuration("watch-interval")
+
+		modes := 0
+		for _, on := range []bool{lspLike, multiTenant, web != ""} {
+			if on {
+				modes++
+			}
+		}
+		if modes > 1 {
+			FatalError("--lsp-like, --multi-tenant and --web are mutually exclusive")
+		}
+		if web != "" {
+			runWebViewer(web)
+			return
+		}
+		if multiTenant {
+			dataDir, _ := cmd.Flags().GetString("data-dir")
+			listen, _ := cmd.Flags().GetString("listen")
+			runMultiTenantServer(dataDir, listen)
+			return
+		}
+		if !lspLike {
+			FatalErrorWithHint("no server mode selected", "use 'bd serve --lsp-like' for the editor JSON-RPC server, 'bd serve --web :8080' for the web viewer or 'bd serve --multi-tenant' for hosting")
+		}
+
+		// The server keeps a store open across many requests
+		if err := ensureDirectMode("serve requires direct database access"); err != nil {
+			FatalError("%v", err)
+		}
+
+		root, _ := filepath.Abs(filepath.Dir(filepath.Dir(dbPath)))
+		server := editorrpc.NewServer(editorrpc.Config{
+			Version:       Version,
+			Actor:         actor,
+			Project:       &editorrpc.Project{Root: root, DBPath: dbPath, Store: store},
+			OpenProject:   openServeProject,
+			OnMutate:      markDirtyAndScheduleFlush,
+			WatchInterval: watchInterval,
+		}, os.Stdin, os.Stdout)
+
+		if err := server.Serve(rootCtx); err != nil && err != context.Canceled {
+			FatalError("serve: %v", err)
+		}
+	},
+}
+
+// runMultiTenantServer serves every tenant in dataDir over HTTP until interrupted
+func runMultiTenantServer(dataDir, listen string) {
+	if dataDir == "" {
+		FatalErrorWithHint("--data-dir is required with --multi-tenant", "create tenants first with 'bd tenant add <name> --data-dir <dir>'")
+	}
+	host, err := hosting.NewHost(dataDir)
+	if err != nil {
+		FatalError("%v", err)
+	}
+	defer func() { _ = host.Close() }()
+
+	oidcCfg, err := oidc.FromConfig()
+	if err != nil {
+		FatalError("%v", err)
+	}
+	if oidcCfg.Enabled() {
+		host.SetIdentityVerifier(oidc.NewVerifier(oidcCfg, nil))
+		fmt.Fprintf(os.Stderr, "Accepting OIDC identities from %s\n", oidcCfg.Issuer)
+		if len(oidcCfg.Roles) == 0 {
+			fmt.Fprintf(os.Stderr, "Warning: oidc.roles is empty, so every identity will be refused; add rules such as \"my-group=writer\"\n")
+		}
+	}
+
+	server := &http.Server{
+		Addr:              listen,
+		Handler:           host,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-rootCtx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Fprintf(os.Stderr, "Serving tenants from %s on %s\n", dataDir, listen)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		FatalError("serve: %v", err)
+	}
+}
+
+// openServeProject switches the process-wide store to the project containing
+// path, flushing pending changes for the current project first
+func openServeProject(ctx context.Context, path string) (*editorrpc.Project, error) {
+	beadsDir, err := findBeadsDirFrom(path)
+	if err != nil {
+		return nil, err
+	}
+
+	newDBPath := projectDatabasePath(beadsDir)
+	root := filepath.Dir(beadsDir)
+
+	if newDBPath == dbPath && store != nil {
+		return &editorrpc.Project{Root: root, DBPath: dbPath, Store: store}, nil
+	}
+
+	newStore, err := sqlite.New(ctx, newDBPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", newDBPath, err)
+	}
+
+	if flushManager != nil {
+		if err := flushManager.FlushNow(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to flush %s before switching projects: %v\n", dbPath, err)
+		}
+	}
+
+	storeMutex.Lock()
+	if store != nil {
+		_ = store.Close()
+	}
+	store = newStore
+	dbPath = newDBPath
+	storeActive = true
+	storeMutex.Unlock()
+
+	if autoImportEnabled {
+		autoImportIfNewer()
+	}
+
+	return &editorrpc.Project{Root: root, DBPath: newDBPath, Store: newStore}, nil
+}
+
+// projectDatabasePath returns the database of the project whose .beads
+// directory is beadsDir, honoring a database set in its metadata.json
+func projectDatabasePath(beadsDir string) string {
+	if cfg, err := configfile.Load(beadsDir); err == nil && cfg != nil && cfg.Database != "" {
+		return cfg.DatabasePath(beadsDir)
+	}
+	return filepath.Join(beadsDir, beads.CanonicalDatabaseName)
+}
+
+// findBeadsDirFrom walks up from path to the nearest .beads directory
+func findBeadsDirFrom(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	for dir := abs; ; dir = filepath.Dir(dir) {
+		candidate := filepath.Join(dir, ".beads")
+		if info, err := os.Stat(candidate); err == nil && info.IsDir() {
+			return candidate, nil
+		}
+		if filepath.Dir(dir) == dir {
+			return "", fmt.Errorf("no .beads directory found at or above %s", abs)
+		}
+	}
+}
+
+func init() {
+	serveCmd.Flags().Bool("lsp-like", false, "Serve JSON-RPC 2.0 over stdio for editor extensions")
+	serveCmd.Flags().Duration("watch-interval", time.Second, "Polling interval for watch/start change notifications")
+	serveCmd.Flags().Bool("multi-tenant", false, "Host many projects over HTTP with per-tenant databases and tokens")
+	serveCmd.Flags().String("data-dir", "", "Directory holding tenant databases (with --multi-tenant)")
+	serveCmd.Flags().String("listen", "127.0.0.1:8420", "Address to listen on (with --multi-tenant)")
+	serveCmd.Flags().String("web", "", "Serve the read-only web viewer on this address (e.g. :8080)")
+	rootCmd.AddCommand(serveCmd)
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/webui"
+)
+
+// runWebViewer serves the read-only web viewer on listen until interrupted,
+// reading through the daemon when one is running
+func runWebViewer(listen string) {
+	var source webui.Source
+	if daemonClient != nil {
+		absDBPath, _ := filepath.Abs(dbPath)
+		source = daemonWebSource{socketPath: getSocketPath(), dbPath: absDBPath}
+	} else {
+		if store == nil {
+			FatalError("no database found; run 'bd init' first")
+		}
+		if err := ensureDatabaseFresh(rootCtx); err != nil {
+			FatalError("%v", err)
+		}
+		source = webui.StoreSource{Store: store}
+	}
+
+	project := filepath.Base(filepath.Dir(filepath.Dir(dbPath)))
+	server := &http.Server{
+		Addr:              listen,
+		Handler:           webui.NewServer(source, project),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-rootCtx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	via := "the database"
+	if daemonClient != nil {
+		via = "the daemon"
+	}
+	fmt.Fprintf(os.Stderr, "Serving a read-only viewer of %s (via %s) on http://%s/\n", project, via, webListenURL(listen))
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		FatalError("serve: %v", err)
+	}
+}
+
+// webListenURL turns a listen address such as ":8080" into one to browse to
+func webListenURL(listen string) string {
+	if len(listen) > 0 && listen[0] == ':' {
+		return "localhost" + listen
+	}
+	return listen
+}
+
+// daemonWebSource reads the viewer's data over the daemon's RPC. Each call
+// gets its own connection: the daemon drops idle ones, and may restart while
+// the viewer runs.
+type daemonWebSource struct {
+	socketPath string
+	dbPath     string
+}
+
+func (d daemonWebSource) connect() (*rpc.Client, error) {
+	client, err := rpc.TryConnect(d.socketPath)
+	if err != nil {
+		return nil, err
+	}
+	if client == nil {
+		return nil, fmt.Errorf("the daemon is not running")
+	}
+	client.SetDatabasePath(d.dbPath)
+	return client, nil
+}
+
+func (d daemonWebSource) ListIssues(_ context.Context, query string, filter types.IssueFilter) ([]*types.Issue, error) {
+	args := &rpc.ListArgs{Query: query, Priority: filter.Priority, Labels: filter.Labels, Limit: filter.Limit}
+	if filter.Status != nil {
+		args.Status = string(*filter.Status)
+	}
+	if filter.IssueType != nil {
+		args.IssueType = string(*filter.IssueType)
+	}
+	if filter.Assignee != nil {
+		args.Assignee = *filter.Assignee
+	}
+	client, err := d.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = client.Close() }()
+	resp, err := client.List(args)
+	if err != nil {
+		return nil, err
+	}
+	var issues []*types.Issue
+	if err := json.Unmarshal(resp.Data, &issues); err != nil {
+		return nil, fmt.Errorf("parsing list response: %w", err)
+	}
+	return issues, nil
+}
+
+func (d daemonWebSource) GetIssue(_ context.Context, id string) (*webui.IssueDetail, error) {
+	client, err := d.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = client.Close() }()
+	resp, err := client.Show(&rpc.ShowArgs{ID: id})
+	if err != nil {
+		if strings.Contains(err.Error(), "issue not found") {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if string(resp.Data) == "null" || len(resp.Data) == 0 {
+		return nil, nil
+	}
+	var detail webui.IssueDetail
+	if err := json.Unmarshal(resp.Data, &detail); err != nil {
+		return nil, fmt.Errorf("parsing show response: %w", err)
+	}
+	resp, err = client.ListComments(&rpc.CommentListArgs{ID: id})
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(resp.Data, &detail.Comments); err != nil {
+		return nil, fmt.Errorf("parsing comments: %w", err)
+	}
+	return &detail, nil
+}
+
+func (d daemonWebSource) Statistics(context.Context) (*types.Statistics, error) {
+	client, err := d.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = client.Close() }()
+	resp, err := client.Stats()
+	if err != nil {
+		return nil, err
+	}
+	var stats types.Statistics
+	if err := json.Unmarshal(resp.Data, &stats); err != nil {
+		return nil, fmt.Errorf("parsing stats: %w", err)
+	}
+	return &stats, nil
+}
@@ -8,6 +8,7 @@ import (
 	"os/exec"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -27,8 +28,22 @@ var showCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		jsonOutput, _ := cmd.Flags().GetBool("json")
 		showThread, _ := cmd.Flags().GetBool("thread")
+		showGraph, _ := cmd.Flags().GetBool("graph")
+		graphDepth, _ := cmd.Flags().GetInt("graph-depth")
+		graphDepth = clampGraphDepth(graphDepth)
+		budget, _ := cmd.Flags().GetInt("budget")
+		copyToClipboard, _ := cmd.Flags().GetBool("copy")
+		issueBudget := budget / len(args)
 		ctx := rootCtx
 
+		// The relation graph walks dependencies across issues, which the daemon
+		// protocol doesn't expose
+		if showGraph {
+			if err := ensureDirectMode("show --graph requires direct database access"); err != nil {
+				FatalError("%v", err)
+			}
+		}
+
 		// Check database freshness before reading (bd-2q6d, bd-c4rq)
 		// Skip check when using daemon (daemon auto-imports on staleness)
 		if daemonClient == nil {
@@ -66,6 +81,10 @@ var showCmd = &cobra.Command{
 			}
 		}
 
+		if copyToClipboard {
+			copyIssuesToClipboard(ctx, resolvedIDs)
+		}
+
 		// Handle --thread flag: show full conversation thread
 		if showThread && len(resolvedIDs) > 0 {
 			showMessageThread(ctx, resolvedIDs[0], jsonOutput)
@@ -86,12 +105,24 @@ var showCmd = &cobra.Command{
 				if jsonOutput {
 					type IssueDetails struct {
 						types.Issue
-						Labels       []string                             `json:"labels,omitempty"`
-						Dependencies []*types.IssueWithDependencyMetadata `json:"dependencies,omitempty"`
-						Dependents   []*types.IssueWithDependencyMetadata `json:"dependents,omitempty"`
+						Labels               []string                             `json:"labels,omitempty"`
+						Dependencies         []*types.IssueWithDependencyMetadata `json:"dependencies,omitempty"`
+						Dependents           []*types.IssueWithDependencyMetadata `json:"dependents,omitempty"`
+						ExternalDependencies []*types.ExternalDependency          `json:"external_dependencies,omitempty"`
+						StateTime            *types.StateTime                     `json:"state_time,omitempty"`
+						TimeLogged           *types.WorkLogTotals                 `json:"time_logged,omitempty"`
+						Lease                *types.Lease                         `json:"lease,omitempty"`
+						Timebox              *types.Timebox                       `json:"timebox,omitempty"`
+						CommentCount         int                                  `json:"comment_count,omitempty"`
+						DefinitionOfDone     []types.DoneCriterion                `json:"definition_of_done,omitempty"`
+						Elided               []string                             `json:"elided,omitempty"`
 					}
 					var details IssueDetails
 					if err := json.Unmarshal(resp.Data, &details); err == nil {
+						decryptForDisplay(&details.Issue)
+						if budget > 0 {
+							details.Elided = fitJSONBudget(details, &details.Issue, nil, issueBudget)
+						}
 						allDetails = append(allDetails, details)
 					}
 				} else {
@@ -107,9 +138,16 @@ var showCmd = &cobra.Command{
 					// Parse response and use existing formatting code
 					type IssueDetails struct {
 						types.Issue
-						Labels       []string                             `json:"labels,omitempty"`
-						Dependencies []*types.IssueWithDependencyMetadata `json:"dependencies,omitempty"`
-						Dependents   []*types.IssueWithDependencyMetadata `json:"dependents,omitempty"`
+						Labels               []string                             `json:"labels,omitempty"`
+						Dependencies         []*types.IssueWithDependencyMetadata `json:"dependencies,omitempty"`
+						Dependents           []*types.IssueWithDependencyMetadata `json:"dependents,omitempty"`
+						ExternalDependencies []*types.ExternalDependency          `json:"external_dependencies,omitempty"`
+						StateTime            *types.StateTime                     `json:"state_time,omitempty"`
+						TimeLogged           *types.WorkLogTotals                 `json:"time_logged,omitempty"`
+						Lease                *types.Lease                         `json:"lease,omitempty"`
+						Timebox              *types.Timebox                       `json:"timebox,omitempty"`
+						CommentCount         int                                  `json:"comment_count,omitempty"`
+						DefinitionOfDone     []types.DoneCriterion                `json:"definition_of_done,omitempty"`
 					}
 					var details IssueDetails
 					if err := json.Unmarshal(resp.Data, &details); err != nil {
@@ -117,6 +155,8 @@ var showCmd = &cobra.Command{
 						os.Exit(1)
 					}
 					issue := &details.Issue
+					issue.Localize(displayLocale())
+					decryptForDisplay(issue)
 
 					cyan := color.New(color.FgCyan).SprintFunc()
 
@@ -142,11 +182,35 @@ var showCmd = &cobra.Command{
 					if issue.Assignee != "" {
 						fmt.Printf("Assignee: %s\n", issue.Assignee)
 					}
+					if details.Lease != nil {
+						fmt.Printf("Claimed: %s\n", formatLease(details.Lease, time.Now()))
+					}
 					if issue.EstimatedMinutes != nil {
 						fmt.Printf("Estimated: %d minutes\n", *issue.EstimatedMinutes)
 					}
-					fmt.Printf("Created: %s\n", issue.CreatedAt.Format("2006-01-02 15:04"))
-					fmt.Printf("Updated: %s\n", issue.UpdatedAt.Format("2006-01-02 15:04"))
+					if details.Timebox != nil {
+						fmt.Printf("Timebox: %s\n", formatTimeboxState(details.Timebox, time.Now()))
+					}
+					if details.TimeLogged != nil {
+						fmt.Printf("Time logged: %s\n", formatTimeLogged(details.TimeLogged))
+					}
+					if issue.Complexity != "" {
+						fmt.Printf("Complexity: %s\n", issue.Complexity)
+					}
+					if issue.Source != "" {
+						fmt.Printf("Source: %s\n", issue.Source)
+					}
+					if len(issue.Fields) > 0 {
+						fmt.Printf("Fields: %s\n", formatIssueFields(issue.Fields))
+					}
+					fmt.Printf("Created: %s\n", displayTime(issue.CreatedAt))
+					fmt.Printf("Updated: %s\n", displayTime(issue.UpdatedAt))
+					if issue.ClosedAt != nil {
+						fmt.Printf("Closed: %s\n", displayTime(*issue.ClosedAt))
+					}
+					if details.StateTime != nil {
+						fmt.Printf("Time: %s\n", formatStateTime(details.StateTime))
+					}
 
 					// Show compaction status
 					if issue.CompactionLevel > 0 {
@@ -166,7 +230,7 @@ var showCmd = &cobra.Command{
 						}
 						compactedDate := ""
 						if issue.CompactedAt != nil {
-							compactedDate = issue.CompactedAt.Format("2006-01-02")
+							compactedDate = displayDate(*issue.CompactedAt)
 						}
 						fmt.Printf("%s Compacted: %s (Tier %d)\n", tierEmoji2, compactedDate, issue.CompactionLevel)
 					}
@@ -187,6 +251,16 @@ var showCmd = &cobra.Command{
 					if len(details.Labels) > 0 {
 						fmt.Printf("\nLabels: %v\n", details.Labels)
 					}
+					printDoneCriteria(details.DefinitionOfDone)
+					if len(issue.Aliases) > 0 {
+						fmt.Printf("\nAliases: %s\n", strings.Join(issue.Aliases, ", "))
+					}
+					if len(issue.Translations) > 0 {
+						fmt.Printf("\nTranslations: %s\n", translationLocales(issue))
+					}
+					if details.CommentCount > 0 {
+						fmt.Printf("\nComments: %d (bd comments %s)\n", details.CommentCount, issue.ID)
+					}
 
 					if len(details.Dependencies) > 0 {
 						fmt.Printf("\nDepends on (%d):\n", len(details.Dependencies))
@@ -194,14 +268,17 @@ var showCmd = &cobra.Command{
 							fmt.Printf("  → %s: %s [P%d]\n", dep.ID, dep.Title, dep.Priority)
 						}
 					}
+					printExternalDependencies(details.ExternalDependencies)
 
 					if len(details.Dependents) > 0 {
 						// Group by dependency type for clarity
-						var blocks, children, related, discovered []*types.IssueWithDependencyMetadata
+						var blocks, softBlocks, children, related, discovered []*types.IssueWithDependencyMetadata
 						for _, dep := range details.Dependents {
 							switch dep.DependencyType {
 							case types.DepBlocks:
 								blocks = append(blocks, dep)
+							case types.DepSoftBlocks:
+								softBlocks = append(softBlocks, dep)
 							case types.DepParentChild:
 								children = append(children, dep)
 							case types.DepRelated:
@@ -225,6 +302,12 @@ var showCmd = &cobra.Command{
 								fmt.Printf("  ← %s: %s [P%d - %s]\n", dep.ID, dep.Title, dep.Priority, dep.Status)
 							}
 						}
+						if len(softBlocks) > 0 {
+							fmt.Printf("\nSoft blocks (%d):\n", len(softBlocks))
+							for _, dep := range softBlocks {
+								fmt.Printf("  ⇠ %s: %s [P%d - %s]\n", dep.ID, dep.Title, dep.Priority, dep.Status)
+							}
+						}
 						if len(related) > 0 {
 							fmt.Printf("\nRelated (%d):\n", len(related))
 							for _, dep := range related {
@@ -261,23 +344,38 @@ var showCmd = &cobra.Command{
 				fmt.Fprintf(os.Stderr, "Issue %s not found\n", id)
 				continue
 			}
+			if !jsonOutput {
+				issue.Localize(displayLocale())
+			}
+			decryptForDisplay(issue)
 
 			if jsonOutput {
 				// Include labels, dependencies (with metadata), dependents (with metadata), and comments in JSON output
 				type IssueDetails struct {
 					*types.Issue
-					Labels       []string                             `json:"labels,omitempty"`
-					Dependencies []*types.IssueWithDependencyMetadata `json:"dependencies,omitempty"`
-					Dependents   []*types.IssueWithDependencyMetadata `json:"dependents,omitempty"`
-					Comments     []*types.Comment                     `json:"comments,omitempty"`
+					Labels               []string                             `json:"labels,omitempty"`
+					Dependencies         []*types.IssueWithDependencyMetadata `json:"dependencies,omitempty"`
+					Dependents           []*types.IssueWithDependencyMetadata `json:"dependents,omitempty"`
+					ExternalDependencies []*types.ExternalDependency          `json:"external_dependencies,omitempty"`
+					Comments             []*types.Comment                     `json:"comments,omitempty"`
+					CommentCount         int                                  `json:"comment_count,omitempty"`
+					DefinitionOfDone     []types.DoneCriterion                `json:"definition_of_done,omitempty"`
+					Graph                []*RelationNode                      `json:"graph,omitempty"`
+					StateTime            *types.StateTime                     `json:"state_time,omitempty"`
+					TimeLogged           *types.WorkLogTotals                 `json:"time_logged,omitempty"`
+					Lease                *types.Lease                         `json:"lease,omitempty"`
+					Timebox              *types.Timebox                       `json:"timebox,omitempty"`
+					Elided               []string                             `json:"elided,omitempty"`
 				}
-				details := &IssueDetails{Issue: issue}
+				details := &IssueDetails{Issue: issue, StateTime: issueStateTime(ctx, issue.ID), TimeLogged: issueTimeLogged(ctx, store, issue.ID), Lease: issueLease(ctx, issue.ID)}
+				details.Timebox, _ = storage.GetTimebox(ctx, store, issue)
 				details.Labels, _ = store.GetLabels(ctx, issue.ID)
 
 				// Get dependencies with metadata (dependency_type field)
 				if sqliteStore, ok := store.(*sqlite.SQLiteStorage); ok {
 					details.Dependencies, _ = sqliteStore.GetDependenciesWithMetadata(ctx, issue.ID)
 					details.Dependents, _ = sqliteStore.GetDependentsWithMetadata(ctx, issue.ID)
+					details.ExternalDependencies, _ = sqliteStore.GetExternalDependencies(ctx, issue.ID)
 				} else {
 					// Fallback to regular methods without metadata for other storage backends
 					deps, _ := store.GetDependencies(ctx, issue.ID)
@@ -291,6 +389,17 @@ var showCmd = &cobra.Command{
 				}
 
 				details.Comments, _ = store.GetIssueComments(ctx, issue.ID)
+				details.CommentCount = len(details.Comments)
+				details.DefinitionOfDone, _ = storage.GetDoneCriteria(ctx, store, issue.ID)
+				if showGraph {
+					details.Graph, err = loadRelationGraph(ctx, store, issue.ID, graphDepth)
+					if err != nil {
+						FatalError("%v", err)
+					}
+				}
+				if budget > 0 {
+					details.Elided = fitJSONBudget(details, issue, details.Comments, issueBudget)
+				}
 				allDetails = append(allDetails, details)
 				continue
 			}
@@ -323,11 +432,35 @@ var showCmd = &cobra.Command{
 			if issue.Assignee != "" {
 				fmt.Printf("Assignee: %s\n", issue.Assignee)
 			}
+			if lease := issueLease(ctx, issue.ID); lease != nil {
+				fmt.Printf("Claimed: %s\n", formatLease(lease, time.Now()))
+			}
 			if issue.EstimatedMinutes != nil {
 				fmt.Printf("Estimated: %d minutes\n", *issue.EstimatedMinutes)
 			}
-			fmt.Printf("Created: %s\n", issue.CreatedAt.Format("2006-01-02 15:04"))
-			fmt.Printf("Updated: %s\n", issue.UpdatedAt.Format("2006-01-02 15:04"))
+			if timebox, _ := storage.GetTimebox(ctx, store, issue); timebox != nil {
+				fmt.Printf("Timebox: %s\n", formatTimeboxState(timebox, time.Now()))
+			}
+			if totals := issueTimeLogged(ctx, store, issue.ID); totals != nil {
+				fmt.Printf("Time logged: %s\n", formatTimeLogged(totals))
+			}
+			if issue.Complexity != "" {
+				fmt.Printf("Complexity: %s\n", issue.Complexity)
+			}
+			if issue.Source != "" {
+				fmt.Printf("Source: %s\n", issue.Source)
+			}
+			if len(issue.Fields) > 0 {
+				fmt.Printf("Fields: %s\n", formatIssueFields(issue.Fields))
+			}
+			fmt.Printf("Created: %s\n", displayTime(issue.CreatedAt))
+			fmt.Printf("Updated: %s\n", displayTime(issue.UpdatedAt))
+			if issue.ClosedAt != nil {
+				fmt.Printf("Closed: %s\n", displayTime(*issue.ClosedAt))
+			}
+			if st := issueStateTime(ctx, issue.ID); st != nil {
+				fmt.Printf("Time: %s\n", formatStateTime(st))
+			}
 
 			// Show compaction status footer
 			if issue.CompactionLevel > 0 {
@@ -349,7 +482,7 @@ var showCmd = &cobra.Command{
 				}
 				compactedDate := ""
 				if issue.CompactedAt != nil {
-					compactedDate = issue.CompactedAt.Format("2006-01-02")
+					compactedDate = displayDate(*issue.CompactedAt)
 				}
 				fmt.Printf("%s Compacted: %s (%s)\n", tierEmoji, compactedDate, tierName)
 			}
@@ -372,6 +505,15 @@ var showCmd = &cobra.Command{
 			if len(labels) > 0 {
 				fmt.Printf("\nLabels: %v\n", labels)
 			}
+			if criteria, _ := storage.GetDoneCriteria(ctx, store, issue.ID); len(criteria) > 0 {
+				printDoneCriteria(criteria)
+			}
+			if len(issue.Aliases) > 0 {
+				fmt.Printf("\nAliases: %s\n", strings.Join(issue.Aliases, ", "))
+			}
+			if len(issue.Translations) > 0 {
+				fmt.Printf("\nTranslations: %s\n", translationLocales(issue))
+			}
 
 			// Show dependencies
 			deps, _ := store.GetDependencies(ctx, issue.ID)
@@ -381,6 +523,10 @@ var showCmd = &cobra.Command{
 					fmt.Printf("  → %s: %s [P%d]\n", dep.ID, dep.Title, dep.Priority)
 				}
 			}
+			if sqliteStore, ok := store.(*sqlite.SQLiteStorage); ok {
+				externalDeps, _ := sqliteStore.GetExternalDependencies(ctx, issue.ID)
+				printExternalDependencies(externalDeps)
+			}
 
 			// Show dependents - grouped by dependency type for clarity
 			// Use GetDependentsWithMetadata to get the dependency type
@@ -389,11 +535,13 @@ var showCmd = &cobra.Command{
 				dependentsWithMeta, _ := sqliteStore.GetDependentsWithMetadata(ctx, issue.ID)
 				if len(dependentsWithMeta) > 0 {
 					// Group by dependency type
-					var blocks, children, related, discovered []*types.IssueWithDependencyMetadata
+					var blocks, softBlocks, children, related, discovered []*types.IssueWithDependencyMetadata
 					for _, dep := range dependentsWithMeta {
 						switch dep.DependencyType {
 						case types.DepBlocks:
 							blocks = append(blocks, dep)
+						case types.DepSoftBlocks:
+							softBlocks = append(softBlocks, dep)
 						case types.DepParentChild:
 							children = append(children, dep)
 						case types.DepRelated:
@@ -417,6 +565,12 @@ var showCmd = &cobra.Command{
 							fmt.Printf("  ← %s: %s [P%d - %s]\n", dep.ID, dep.Title, dep.Priority, dep.Status)
 						}
 					}
+					if len(softBlocks) > 0 {
+						fmt.Printf("\nSoft blocks (%d):\n", len(softBlocks))
+						for _, dep := range softBlocks {
+							fmt.Printf("  ⇠ %s: %s [P%d - %s]\n", dep.ID, dep.Title, dep.Priority, dep.Status)
+						}
+					}
 					if len(related) > 0 {
 						fmt.Printf("\nRelated (%d):\n", len(related))
 						for _, dep := range related {
@@ -441,12 +595,24 @@ var showCmd = &cobra.Command{
 				}
 			}
 
+			if showGraph {
+				nodes, err := loadRelationGraph(ctx, store, issue.ID, graphDepth)
+				if err != nil {
+					FatalError("%v", err)
+				}
+				renderRelationGraph(issue, nodes, graphDepth)
+			}
+
 			// Show comments
 			comments, _ := store.GetIssueComments(ctx, issue.ID)
 			if len(comments) > 0 {
 				fmt.Printf("\nComments (%d):\n", len(comments))
 				for _, comment := range comments {
-					fmt.Printf("  [%s at %s]\n  %s\n\n", comment.Author, comment.CreatedAt.Format("2006-01-02 15:04"), comment.Text)
+					edited := ""
+					if comment.UpdatedAt != nil {
+						edited = " (edited)"
+					}
+					fmt.Printf("  #%d [%s at %s]%s\n  %s\n\n", comment.ID, comment.Author, displayTime(comment.CreatedAt), edited, comment.Text)
 				}
 			}
 
@@ -525,6 +691,24 @@ var updateCmd = &cobra.Command{
 			}
 			updates["estimated_minutes"] = estimate
 		}
+		if cmd.Flags().Changed("timebox") {
+			value, _ := cmd.Flags().GetString("timebox")
+			minutes, err := parseTimebox(value)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: invalid --timebox: %v\n", err)
+				os.Exit(1)
+			}
+			updates["timebox_minutes"] = minutes
+		}
+		if cmd.Flags().Changed("complexity") {
+			complexityFlag, _ := cmd.Flags().GetString("complexity")
+			complexity, err := parseComplexityFlag(complexityFlag)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			updates["complexity"] = string(complexity)
+		}
 		if cmd.Flags().Changed("type") {
 			issueType, _ := cmd.Flags().GetString("type")
 			// Validate issue type
@@ -546,6 +730,15 @@ var updateCmd = &cobra.Command{
 			setLabels, _ := cmd.Flags().GetStringSlice("set-labels")
 			updates["set_labels"] = setLabels
 		}
+		if cmd.Flags().Changed("field") {
+			fieldFlags, _ := cmd.Flags().GetStringArray("field")
+			fields, err := parseFieldFlags(fieldFlags)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: invalid --field: %v\n", err)
+				os.Exit(1)
+			}
+			updates["fields"] = fields
+		}
 		if cmd.Flags().Changed("type") {
 			issueType, _ := cmd.Flags().GetString("type")
 			// Validate issue type
@@ -556,10 +749,27 @@ var updateCmd = &cobra.Command{
 			updates["issue_type"] = issueType
 		}
 
+		locale, _ := cmd.Flags().GetString("locale")
+		if locale != "" {
+			var err error
+			if locale, err = types.NormalizeLocale(locale); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if _, ok := updates["title"]; !ok && !descChanged {
+				fmt.Fprintf(os.Stderr, "Error: --locale needs --title and/or --description to translate\n")
+				os.Exit(1)
+			}
+		}
+
 		if len(updates) == 0 {
 			fmt.Println("No updates specified")
 			return
 		}
+		warnRedacted(loadRedactor().RedactUpdates(updates))
+		attachOversized, _ := cmd.Flags().GetBool("attach-oversized")
+		pendingAttachments := guardContentUpdates(updates, attachOversized)
+		encryptUpdates(updates)
 
 		ctx := rootCtx
 
@@ -593,7 +803,7 @@ var updateCmd = &cobra.Command{
 		if daemonClient != nil {
 			updatedIssues := []*types.Issue{}
 			for _, id := range resolvedIDs {
-				updateArgs := &rpc.UpdateArgs{ID: id}
+				updateArgs := &rpc.UpdateArgs{ID: id, Locale: locale}
 
 				// Map updates to RPC args
 				if status, ok := updates["status"].(string); ok {
@@ -626,6 +836,12 @@ var updateCmd = &cobra.Command{
 				if estimate, ok := updates["estimated_minutes"].(int); ok {
 					updateArgs.EstimatedMinutes = &estimate
 				}
+				if timebox, ok := updates["timebox_minutes"].(int); ok {
+					updateArgs.TimeboxMinutes = &timebox
+				}
+				if complexity, ok := updates["complexity"].(string); ok {
+					updateArgs.Complexity = &complexity
+				}
 				if issueType, ok := updates["issue_type"].(string); ok {
 					updateArgs.IssueType = &issueType
 				}
@@ -638,6 +854,9 @@ var updateCmd = &cobra.Command{
 				if setLabels, ok := updates["set_labels"].([]string); ok {
 					updateArgs.SetLabels = setLabels
 				}
+				if fields, ok := updates["fields"].(types.CustomFields); ok {
+					updateArgs.Fields = fields
+				}
 				if issueType, ok := updates["issue_type"].(string); ok {
 					updateArgs.IssueType = &issueType
 				}
@@ -676,16 +895,54 @@ var updateCmd = &cobra.Command{
 			// Apply regular field updates if any
 			regularUpdates := make(map[string]interface{})
 			for k, v := range updates {
-				if k != "add_labels" && k != "remove_labels" && k != "set_labels" {
+				if k != "add_labels" && k != "remove_labels" && k != "set_labels" && k != "fields" {
 					regularUpdates[k] = v
 				}
 			}
+			// With --locale, the title and description go to that translation
+			if locale != "" {
+				var title, description *string
+				if v, ok := regularUpdates["title"].(string); ok {
+					title = &v
+				}
+				if v, ok := regularUpdates["description"].(string); ok {
+					description = &v
+				}
+				delete(regularUpdates, "title")
+				delete(regularUpdates, "description")
+				if err := storage.UpdateTranslation(ctx, store, id, locale, title, description, actor); err != nil {
+					fmt.Fprintf(os.Stderr, "Error updating %s translation of %s: %v\n", locale, id, err)
+					continue
+				}
+			}
+			if status, ok := regularUpdates["status"].(string); ok && status == string(types.StatusClosed) {
+				if err := storage.CheckVerifiedClose(ctx, store, id, actor); err != nil {
+					fmt.Fprintf(os.Stderr, "Error updating %s: %v\n", id, err)
+					continue
+				}
+				if err := storage.CheckDefinitionOfDone(ctx, store, id); err != nil {
+					fmt.Fprintf(os.Stderr, "Error updating %s: %v\n", id, err)
+					continue
+				}
+			}
 			if len(regularUpdates) > 0 {
 				if err := store.UpdateIssue(ctx, id, regularUpdates, actor); err != nil {
 					fmt.Fprintf(os.Stderr, "Error updating %s: %v\n", id, err)
 					continue
 				}
 			}
+			if description, ok := regularUpdates["description"].(string); ok {
+				syncChecklistDeps(ctx, id, description)
+			}
+			recordPendingAttachments(id, pendingAttachments)
+
+			// Set custom fields
+			if fields, ok := updates["fields"].(types.CustomFields); ok {
+				if err := storage.SetIssueFields(ctx, store, id, fields, actor); err != nil {
+					fmt.Fprintf(os.Stderr, "Error setting fields on %s: %v\n", id, err)
+					continue
+				}
+			}
 
 			// Handle label operations
 			// Set labels (replaces all existing labels)
@@ -849,6 +1106,7 @@ Examples:
 				os.Exit(1)
 			}
 		}
+		decryptForEdit(issue)
 
 		// Get the current field value
 		var currentValue string
@@ -915,6 +1173,9 @@ Examples:
 			os.Exit(1)
 		}
 
+		redactInput(&newValue)
+		encryptInput(map[string]*string{fieldToEdit: &newValue})
+
 		// Update the issue
 		updates := map[string]interface{}{
 			fieldToEdit: newValue,
@@ -948,6 +1209,9 @@ Examples:
 				fmt.Fprintf(os.Stderr, "Error updating issue: %v\n", err)
 				os.Exit(1)
 			}
+			if description, ok := updates["description"].(string); ok {
+				syncChecklistDeps(ctx, id, description)
+			}
 			markDirtyAndScheduleFlush()
 		}
 
@@ -964,10 +1228,10 @@ var closeCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		CheckReadonly("close")
 		reason, _ := cmd.Flags().GetString("reason")
-		if reason == "" {
-			reason = "Closed"
-		}
+		note, _ := cmd.Flags().GetString("note")
+		done, _ := cmd.Flags().GetStringArray("done")
 		jsonOutput, _ := cmd.Flags().GetBool("json")
+		prompt := donePrompt(jsonOutput)
 
 		ctx := rootCtx
 
@@ -997,19 +1261,29 @@ var closeCmd = &cobra.Command{
 			}
 		}
 
+		// Parents whose last open children these were get a closure prompt
+		var closedIDs []string
+
 		// If daemon is running, use RPC
 		if daemonClient != nil {
 			closedIssues := []*types.Issue{}
 			for _, id := range resolvedIDs {
+				pending, err := confirmDoneCriteria(ctx, id, done, prompt)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error closing %s: %v\n", id, err)
+					continue
+				}
 				closeArgs := &rpc.CloseArgs{
 					ID:     id,
 					Reason: reason,
+					Note:   note,
 				}
 				resp, err := daemonClient.CloseIssue(closeArgs)
 				if err != nil {
 					fmt.Fprintf(os.Stderr, "Error closing %s: %v\n", id, err)
 					continue
 				}
+				closedIDs = append(closedIDs, id)
 
 				var issue types.Issue
 				if err := json.Unmarshal(resp.Data, &issue); err == nil {
@@ -1023,23 +1297,48 @@ var closeCmd = &cobra.Command{
 				}
 				if !jsonOutput {
 					green := color.New(color.FgGreen).SprintFunc()
-					fmt.Printf("%s Closed %s: %s\n", green("✓"), id, reason)
+					fmt.Printf("%s Closed %s: %s\n", green("✓"), id, issue.CloseReason)
 				}
+				warnPendingDone(id, pending)
 			}
 
 			if jsonOutput && len(closedIssues) > 0 {
 				outputJSON(closedIssues)
 			}
+			offerParentClosure(ctx, closedIDs)
 			return
 		}
 
 		// Direct mode
+		// Apply the close reason taxonomy (close.reasons, close.require_reason)
+		reason, err := storage.ResolveCloseReason(ctx, store, reason, note)
+		if err != nil {
+			FatalError("%v", err)
+		}
+		if reason == "" {
+			reason = "Closed"
+		}
+
 		closedIssues := []*types.Issue{}
 		for _, id := range resolvedIDs {
+			pending, err := confirmDoneCriteria(ctx, id, done, prompt)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error closing %s: %v\n", id, err)
+				continue
+			}
+			if err := storage.CheckVerifiedClose(ctx, store, id, actor); err != nil {
+				fmt.Fprintf(os.Stderr, "Error closing %s: %v\n", id, err)
+				continue
+			}
+			if err := storage.CheckDefinitionOfDone(ctx, store, id); err != nil {
+				fmt.Fprintf(os.Stderr, "Error closing %s: %v\n", id, err)
+				continue
+			}
 			if err := store.CloseIssue(ctx, id, reason, actor); err != nil {
 				fmt.Fprintf(os.Stderr, "Error closing %s: %v\n", id, err)
 				continue
 			}
+			closedIDs = append(closedIDs, id)
 
 			// Run close hook (bd-kwro.8)
 			issue, _ := store.GetIssue(ctx, id)
@@ -1055,6 +1354,7 @@ var closeCmd = &cobra.Command{
 				green := color.New(color.FgGreen).SprintFunc()
 				fmt.Printf("%s Closed %s: %s\n", green("✓"), id, reason)
 			}
+			warnPendingDone(id, pending)
 		}
 
 		// Schedule auto-flush if any issues were closed
@@ -1065,6 +1365,7 @@ var closeCmd = &cobra.Command{
 		if jsonOutput && len(closedIssues) > 0 {
 			outputJSON(closedIssues)
 		}
+		offerParentClosure(ctx, closedIDs)
 	},
 }
 
@@ -1190,7 +1491,7 @@ func showMessageThread(ctx context.Context, messageID string, jsonOutput bool) {
 		indent := strings.Repeat("  ", depth)
 
 		// Format timestamp
-		timeStr := msg.CreatedAt.Format("2006-01-02 15:04")
+		timeStr := displayTime(msg.CreatedAt)
 
 		// Status indicator
 		statusIcon := "📧"
@@ -1308,17 +1609,25 @@ func findReplies(ctx context.Context, issueID string, daemonClient *rpc.Client,
 func init() {
 	showCmd.Flags().Bool("json", false, "Output JSON format")
 	showCmd.Flags().Bool("thread", false, "Show full conversation thread (for messages)")
+	showCmd.Flags().Bool("graph", false, "Show an ASCII graph of related issues (blockers, dependents, parent, duplicates)")
+	showCmd.Flags().Int("graph-depth", 1, "Relation hops to include with --graph (max 5)")
+	showCmd.Flags().Bool("copy", false, "Also copy the issues to the clipboard as markdown (paste back with bd create --from-clipboard)")
+	showCmd.Flags().Int("budget", 0, "With --json, fit the output into about this many tokens by eliding the middle of long text")
 	rootCmd.AddCommand(showCmd)
 
 	updateCmd.Flags().StringP("status", "s", "", "New status")
 	registerPriorityFlag(updateCmd, "")
 	updateCmd.Flags().String("title", "", "New title")
-	updateCmd.Flags().StringP("type", "t", "", "New type (bug|feature|task|epic|chore)")
+	updateCmd.Flags().String("locale", "", "Set the title/description translation for this locale (e.g. ja, pt-BR) instead of the original text")
+	updateCmd.Flags().StringP("type", "t", "", "New type (bug|feature|task|epic|chore|spike)")
 	registerCommonIssueFlags(updateCmd)
 	updateCmd.Flags().String("notes", "", "Additional notes")
 	updateCmd.Flags().String("acceptance-criteria", "", "DEPRECATED: use --acceptance")
 	_ = updateCmd.Flags().MarkHidden("acceptance-criteria")
 	updateCmd.Flags().IntP("estimate", "e", 0, "Time estimate in minutes (e.g., 60 for 1 hour)")
+	updateCmd.Flags().String("timebox", "", "Timebox for a spike (e.g., 4h, 2d, 1w)")
+	updateCmd.Flags().String("complexity", "", "Complexity for agent routing: trivial, standard, complex, research (none clears)")
+	updateCmd.Flags().StringArray("field", nil, "Set a custom field, name=value (repeatable; name= clears it)")
 	updateCmd.Flags().StringSlice("add-label", nil, "Add labels (repeatable)")
 	updateCmd.Flags().StringSlice("remove-label", nil, "Remove labels (repeatable)")
 	updateCmd.Flags().StringSlice("set-labels", nil, "Set labels, replacing all existing (repeatable)")
@@ -1333,7 +1642,9 @@ func init() {
 	editCmd.Flags().Bool("acceptance", false, "Edit the acceptance criteria")
 	rootCmd.AddCommand(editCmd)
 
-	closeCmd.Flags().StringP("reason", "r", "", "Reason for closing")
+	closeCmd.Flags().StringP("reason", "r", "", "Reason for closing (fixed, wontfix, duplicate, obsolete, or a custom reason from close.reasons)")
+	closeCmd.Flags().String("note", "", "Detail to record with the close reason")
+	closeCmd.Flags().StringArray("done", nil, "Confirm a definition-of-done criterion (repeatable; \"all\" confirms every one)")
 	closeCmd.Flags().Bool("json", false, "Output JSON format")
 	rootCmd.AddCommand(closeCmd)
 }
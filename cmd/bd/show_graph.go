@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/fatih/color"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// maxShowGraphDepth bounds 'bd show --graph-depth' so busy graphs stay readable
+const maxShowGraphDepth = 5
+
+// RelationNode is a neighbor in an issue's relation graph, as seen from the
+// issue it hangs off
+type RelationNode struct {
+	Relation string          `json:"relation"` // e.g. "blocked by", "parent", "duplicate of"
+	ID       string          `json:"id"`
+	Title    string          `json:"title"`
+	Status   types.Status    `json:"status"`
+	Priority int             `json:"priority"`
+	Missing  bool            `json:"missing,omitempty"` // Dangling or cross-repo reference
+	Children []*RelationNode `json:"children,omitempty"`
+}
+
+// relationOrder sorts relations so the most actionable come first
+var relationOrder = map[string]int{
+	"parent":          0,
+	"blocked by":      1,
+	"blocks":          2,
+	"child":           3,
+	"duplicate of":    4,
+	"duplicated by":   5,
+	"supersedes":      6,
+	"superseded by":   7,
+	"related":         8,
+	"discovered from": 9,
+	"discovered":      10,
+}
+
+// relationLabel names an edge from the perspective of one of its ends.
+// outgoing is true when that end is the dependency's IssueID.
+func relationLabel(depType types.DependencyType, outgoing bool) string {
+	switch depType {
+	case types.DepBlocks:
+		return dirLabel(outgoing, "blocked by", "blocks")
+	case types.DepParentChild:
+		return dirLabel(outgoing, "parent", "child")
+	case types.DepSoftBlocks:
+		return dirLabel(outgoing, "preferably after", "preferably before")
+	case types.DepDuplicates:
+		return dirLabel(outgoing, "duplicate of", "duplicated by")
+	case types.DepSupersedes:
+		return dirLabel(outgoing, "supersedes", "superseded by")
+	case types.DepDiscoveredFrom:
+		return dirLabel(outgoing, "discovered from", "discovered")
+	case types.DepRelated, types.DepRelatesTo:
+		return "related"
+	default:
+		return dirLabel(outgoing, string(depType), string(depType)+" (from)")
+	}
+}
+
+// dirLabel picks the label for the outgoing or incoming end of an edge
+func dirLabel(outgoing bool, out, in string) string {
+	if outgoing {
+		return out
+	}
+	return in
+}
+
+// loadRelationGraph walks the relations around rootID up to depth hops. Each
+// issue appears once, so cycles and shared neighbors don't repeat.
+func loadRelationGraph(ctx context.Context, s storage.Storage, rootID string, depth int) ([]*RelationNode, error) {
+	allDeps, err := s.GetAllDependencyRecords(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load dependencies: %w", err)
+	}
+
+	type edge struct {
+		otherID  string
+		relation string
+	}
+	edges := make(map[string][]edge)
+	for issueID, deps := range allDeps {
+		for _, dep := range deps {
+			edges[issueID] = append(edges[issueID], edge{dep.DependsOnID, relationLabel(dep.Type, true)})
+			edges[dep.DependsOnID] = append(edges[dep.DependsOnID], edge{issueID, relationLabel(dep.Type, false)})
+		}
+	}
+
+	issueCache := make(map[string]*types.Issue)
+	getIssue := func(id string) *types.Issue {
+		if issue, ok := issueCache[id]; ok {
+			return issue
+		}
+		issue, err := s.GetIssue(ctx, id)
+		if err != nil {
+			issue = nil
+		}
+		issueCache[id] = issue
+		return issue
+	}
+
+	visited := map[string]bool{rootID: true}
+	var expand func(id string, level int) []*RelationNode
+	expand = func(id string, level int) []*RelationNode {
+		var nodes []*RelationNode
+		for _, e := range edges[id] {
+			if visited[e.otherID] {
+				continue
+			}
+			visited[e.otherID] = true
+			issue := getIssue(e.otherID)
+			if issue == nil {
+				nodes = append(nodes, &RelationNode{Relation: e.relation, ID: e.otherID, Missing: true})
+				continue
+			}
+			nodes = append(nodes, &RelationNode{
+				Relation: e.relation,
+				ID:       issue.ID,
+				Title:    issue.Title,
+				Status:   issue.Status,
+				Priority: issue.Priority,
+			})
+		}
+		sort.Slice(nodes, func(i, j int) bool {
+			oi, oj := relationOrder[nodes[i].Relation], relationOrder[nodes[j].Relation]
+			if _, ok := relationOrder[nodes[i].Relation]; !ok {
+				oi = len(relationOrder)
+			}
+			if _, ok := relationOrder[nodes[j].Relation]; !ok {
+				oj = len(relationOrder)
+			}
+			if oi != oj {
+				return oi < oj
+			}
+			return nodes[i].ID < nodes[j].ID
+		})
+		// Mark the whole level before descending so siblings aren't repeated
+		// as grandchildren
+		if level < depth {
+			for _, node := range nodes {
+				if !node.Missing {
+					node.Children = expand(node.ID, level+1)
+				}
+			}
+		}
+		return nodes
+	}
+	return expand(rootID, 1), nil
+}
+
+// renderRelationGraph prints the tree returned by loadRelationGraph
+func renderRelationGraph(root *types.Issue, nodes []*RelationNode, depth int) {
+	cyan := color.New(color.FgCyan).SprintFunc()
+	fmt.Printf("\nGraph (depth %d):\n", depth)
+	fmt.Printf("%s [%s] %s\n", cyan(root.ID), root.Status, root.Title)
+	if len(nodes) == 0 {
+		fmt.Println("└── (no relations)")
+		return
+	}
+	renderRelationNodes(nodes, "")
+}
+
+func renderRelationNodes(nodes []*RelationNode, prefix string) {
+	cyan := color.New(color.FgCyan).SprintFunc()
+	faint := color.New(color.Faint).SprintFunc()
+
+	width := 0
+	for _, node := range nodes {
+		width = max(width, len(node.Relation))
+	}
+	for i, node := range nodes {
+		branch, indent := "├── ", "│   "
+		if i == len(nodes)-1 {
+			branch, indent = "└── ", "    "
+		}
+		line := fmt.Sprintf("%s %s [%s] %s", padRight(node.Relation, width), cyan(node.ID), node.Status, truncateTitle(node.Title, 60))
+		if node.Missing {
+			line = fmt.Sprintf("%s %s (not found)", padRight(node.Relation, width), cyan(node.ID))
+		}
+		if node.Status == types.StatusClosed || node.Status == types.StatusTombstone {
+			line = faint(line)
+		}
+		fmt.Printf("%s%s%s\n", prefix, branch, line)
+		if len(node.Children) > 0 {
+			renderRelationNodes(node.Children, prefix+indent)
+		}
+	}
+}
+
+// clampGraphDepth keeps --graph-depth within 1..maxShowGraphDepth
+func clampGraphDepth(depth int) int {
+	return min(max(depth, 1), maxShowGraphDepth)
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestLoadRelationGraph(t *testing.T) {
+	s := newTestStore(t, filepath.Join(t.TempDir(), ".beads", "beads.db"))
+	ctx := context.Background()
+
+	newIssue := func(title string, issueType types.IssueType) *types.Issue {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: issueType}
+		if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return issue
+	}
+	addDep := func(from, to *types.Issue, depType types.DependencyType) {
+		dep := &types.Dependency{IssueID: from.ID, DependsOnID: to.ID, Type: depType}
+		if err := s.AddDependency(ctx, dep, "test"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+
+	epic := newIssue("Epic", types.TypeEpic)
+	task := newIssue("Task", types.TypeTask)
+	blocker := newIssue("Blocker", types.TypeTask)
+	upstream := newIssue("Upstream", types.TypeTask)
+	dependent := newIssue("Dependent", types.TypeTask)
+	dup := newIssue("Dup", types.TypeBug)
+
+	addDep(task, epic, types.DepParentChild)
+	addDep(task, blocker, types.DepBlocks)
+	addDep(blocker, upstream, types.DepBlocks)
+	addDep(dependent, task, types.DepBlocks)
+	addDep(dup, task, types.DepDuplicates)
+
+	nodes, err := loadRelationGraph(ctx, s, task.ID, 1)
+	if err != nil {
+		t.Fatalf("loadRelationGraph failed: %v", err)
+	}
+	want := []struct{ relation, id string }{
+		{"parent", epic.ID},
+		{"blocked by", blocker.ID},
+		{"blocks", dependent.ID},
+		{"duplicated by", dup.ID},
+	}
+	if len(nodes) != len(want) {
+		t.Fatalf("got %d nodes, want %d", len(nodes), len(want))
+	}
+	for i, w := range want {
+		if nodes[i].Relation != w.relation || nodes[i].ID != w.id {
+			t.Errorf("node %d = %s %s, want %s %s", i, nodes[i].Relation, nodes[i].ID, w.relation, w.id)
+		}
+		if len(nodes[i].Children) != 0 {
+			t.Errorf("depth 1 node %s has children", nodes[i].ID)
+		}
+	}
+
+	// Depth 2 reaches the blocker's own blocker, without looping back to the task
+	nodes, err = loadRelationGraph(ctx, s, task.ID, 2)
+	if err != nil {
+		t.Fatalf("loadRelationGraph failed: %v", err)
+	}
+	children := nodes[1].Children
+	if len(children) != 1 || children[0].ID != upstream.ID || children[0].Relation != "blocked by" {
+		t.Errorf("blocker children = %+v, want upstream as blocked by", children)
+	}
+	if len(nodes[0].Children) != 0 {
+		t.Errorf("epic should have no further relations, got %+v", nodes[0].Children)
+	}
+}
+
+func TestClampGraphDepth(t *testing.T) {
+	for in, want := range map[int]int{-1: 1, 0: 1, 1: 1, 3: 3, 9: maxShowGraphDepth} {
+		if got := clampGraphDepth(in); got != want {
+			t.Errorf("clampGraphDepth(%d) = %d, want %d", in, got, want)
+		}
+	}
+}
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"bufio"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -11,6 +10,8 @@ import (
 	"reflect"
 	"strings"
 	"time"
+
+	"github.com/steveyegge/beads/internal/util"
 )
 
 const (
@@ -370,7 +371,7 @@ func (sm *SnapshotManager) buildIDToLineMap(path string) (map[string]string, err
 	}
 	defer f.Close()
 
-	scanner := bufio.NewScanner(f)
+	scanner := util.NewJSONLScanner(f)
 	for scanner.Scan() {
 		line := scanner.Text()
 		if line == "" {
@@ -408,7 +409,7 @@ func (sm *SnapshotManager) buildIDSet(path string) (map[string]bool, error) {
 	}
 	defer f.Close()
 
-	scanner := bufio.NewScanner(f)
+	scanner := util.NewJSONLScanner(f)
 	for scanner.Scan() {
 		line := scanner.Text()
 		if line == "" {
@@ -0,0 +1,378 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/hooks"
+	"github.com/steveyegge/beads/internal/notify"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var spikeCmd = &cobra.Command{
+	Use:   "spike",
+	Short: "Work with time-boxed spikes",
+	Long: `A spike is a time-boxed investigation: an issue of type spike with a
+timebox set by --timebox on bd create or bd update (e.g. 4h, 2d, 1w).
+
+The clock starts when the spike goes in_progress. When the timebox runs
+out, the daemon (or bd spike check, without one) asks for an outcome:
+  - a timebox_elapsed event, which reaches watchers, webhooks and bd watch
+  - a bd mail message to the assignee, or whoever started the spike
+  - the .beads/hooks/on_timebox hook, with the spike as its input
+
+bd spike conclude closes a spike with its outcome and files the follow-up
+work it found, linked back to the spike with discovered-from.
+
+Examples:
+  bd create "Can we drop the cache layer?" --type spike --timebox 2d
+  bd spike list
+  bd spike conclude bd-42 --outcome "Yes, with a warm-up job" \
+      --follow-up "Add cache warm-up job" --follow-up "Remove cache layer"
+  bd update bd-42 --timebox 3d   # extend`,
+}
+
+var spikeListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List spikes and where they stand against their timebox",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		all, _ := cmd.Flags().GetBool("all")
+		spikeStore()
+		ctx := rootCtx
+
+		spikeType := types.TypeSpike
+		issues, err := store.SearchIssues(ctx, "", types.IssueFilter{IssueType: &spikeType})
+		if err != nil {
+			FatalError("%v", err)
+		}
+
+		type spikeInfo struct {
+			*types.Issue
+			Timebox *types.Timebox `json:"timebox,omitempty"`
+		}
+		spikes := make([]spikeInfo, 0, len(issues))
+		for _, issue := range issues {
+			if issue.Status == types.StatusClosed && !all {
+				continue
+			}
+			decryptForDisplay(issue)
+			timebox, err := storage.GetTimebox(ctx, store, issue)
+			if err != nil {
+				FatalError("%v", err)
+			}
+			spikes = append(spikes, spikeInfo{Issue: issue, Timebox: timebox})
+		}
+		if jsonOutput {
+			outputJSON(spikes)
+			return
+		}
+		if len(spikes) == 0 {
+			fmt.Println("No spikes")
+			return
+		}
+		now := time.Now()
+		red := color.New(color.FgRed).SprintFunc()
+		for _, spike := range spikes {
+			state := "no timebox"
+			if spike.Timebox != nil {
+				state = formatTimeboxState(spike.Timebox, now)
+				if spike.Timebox.Elapsed && spike.Status != types.StatusClosed {
+					state = red(state)
+				}
+			}
+			fmt.Printf("%s  %-11s %s\n    Timebox: %s\n", spike.ID, spike.Status, spike.Title, state)
+		}
+	},
+}
+
+var spikeCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Ask for the outcome of spikes whose timebox ran out",
+	Long: `Report every in-progress spike whose timebox has run out and hasn't
+been reported yet, as the daemon does every minute. Use it from cron or CI
+when no daemon runs.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("spike check")
+		s := spikeStore()
+		elapsed, err := promptElapsedTimeboxes(rootCtx, s, hookRunner, func(format string, args ...interface{}) {
+			if !jsonOutput {
+				fmt.Printf("  "+format+"\n", args...)
+			}
+		})
+		if err != nil {
+			FatalError("%v", err)
+		}
+		if len(elapsed) > 0 {
+			markDirtyAndScheduleFlush()
+		}
+		if jsonOutput {
+			issues := make([]*types.Issue, len(elapsed))
+			for i, e := range elapsed {
+				issues[i] = e.Issue
+			}
+			outputJSON(issues)
+			return
+		}
+		if len(elapsed) == 0 {
+			fmt.Println("No timebox elapsed")
+		}
+	},
+}
+
+var spikeConcludeCmd = &cobra.Command{
+	Use:   "conclude <id>",
+	Short: "Close a spike with its outcome and file follow-up issues",
+	Long: `Close a spike, recording its outcome as the close note, and create a
+task for each --follow-up, at the spike's priority and linked to it with a
+discovered-from dependency.
+
+The close follows the same rules as bd close: --reason picks a reason from
+the close reason taxonomy, and --done confirms the definition of done.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("spike conclude")
+		outcome, _ := cmd.Flags().GetString("outcome")
+		followUps, _ := cmd.Flags().GetStringArray("follow-up")
+		reason, _ := cmd.Flags().GetString("reason")
+		done, _ := cmd.Flags().GetStringArray("done")
+		if strings.TrimSpace(outcome) == "" {
+			FatalError("--outcome is required: what did the spike find?")
+		}
+		for _, title := range followUps {
+			if strings.TrimSpace(title) == "" {
+				FatalError("--follow-up needs a title")
+			}
+		}
+		spikeStore()
+		ctx := rootCtx
+
+		id, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			FatalError("%v", err)
+		}
+		spike, err := store.GetIssue(ctx, id)
+		if err != nil {
+			FatalError("%v", err)
+		}
+		if spike == nil {
+			FatalError("issue %s not found", id)
+		}
+		if spike.IssueType != types.TypeSpike {
+			FatalError("%s is a %s, not a spike", id, spike.IssueType)
+		}
+		if spike.Status == types.StatusClosed {
+			FatalError("%s is already closed", id)
+		}
+
+		closeReason, err := storage.ResolveCloseReason(ctx, store, reason, outcome)
+		if err != nil {
+			FatalError("%v", err)
+		}
+		pending, err := confirmDoneCriteria(ctx, id, done, donePrompt(jsonOutput))
+		if err != nil {
+			FatalError("%v", err)
+		}
+		if err := storage.CheckVerifiedClose(ctx, store, id, actor); err != nil {
+			FatalError("%v", err)
+		}
+		if err := storage.CheckDefinitionOfDone(ctx, store, id); err != nil {
+			FatalError("%v", err)
+		}
+		if err := store.CloseIssue(ctx, id, closeReason, actor); err != nil {
+			FatalError("closing %s: %v", id, err)
+		}
+		if closed, _ := store.GetIssue(ctx, id); closed != nil {
+			spike = closed
+		}
+		if hookRunner != nil {
+			hookRunner.Run(hooks.EventClose, spike)
+		}
+
+		created, err := createSpikeFollowUps(ctx, spike, outcome, followUps)
+		markDirtyAndScheduleFlush()
+		if err != nil {
+			FatalError("%s was concluded, but %v", id, err)
+		}
+
+		if jsonOutput {
+			if created == nil {
+				created = []*types.Issue{}
+			}
+			outputJSON(map[string]interface{}{"spike": spike, "follow_ups": created})
+			return
+		}
+		green := color.New(color.FgGreen).SprintFunc()
+		fmt.Printf("%s Concluded %s: %s\n", green("✓"), id, closeReason)
+		for _, issue := range created {
+			fmt.Printf("  Follow-up: %s %s\n", issue.ID, issue.Title)
+		}
+		warnPendingDone(id, pending)
+	},
+}
+
+// spikeStore opens the database for a spike command
+func spikeStore() *sqlite.SQLiteStorage {
+	if err := ensureDirectMode("spike requires direct database access"); err != nil {
+		FatalError("%v", err)
+	}
+	s, ok := store.(*sqlite.SQLiteStorage)
+	if !ok {
+		FatalError("spike requires SQLite storage")
+	}
+	if err := ensureDatabaseFresh(rootCtx); err != nil {
+		FatalError("%v", err)
+	}
+	return s
+}
+
+// createSpikeFollowUps files a task for each title, discovered from spike
+func createSpikeFollowUps(ctx context.Context, spike *types.Issue, outcome string, titles []string) ([]*types.Issue, error) {
+	var created []*types.Issue
+	for _, title := range titles {
+		issue := &types.Issue{
+			Title:       strings.TrimSpace(title),
+			Description: fmt.Sprintf("Follow-up from spike %s (%s).\n\nOutcome: %s", spike.ID, spike.Title, outcome),
+			Status:      types.StatusOpen,
+			Priority:    spike.Priority,
+			IssueType:   types.TypeTask,
+			SourceRepo:  spike.SourceRepo,
+		}
+		if err := store.CreateIssue(ctx, issue, actor); err != nil {
+			return created, fmt.Errorf("creating follow-up %q: %w", title, err)
+		}
+		dep := &types.Dependency{IssueID: issue.ID, DependsOnID: spike.ID, Type: types.DepDiscoveredFrom}
+		if err := store.AddDependency(ctx, dep, actor); err != nil {
+			return created, fmt.Errorf("linking follow-up %s: %w", issue.ID, err)
+		}
+		if hookRunner != nil {
+			hookRunner.Run(hooks.EventCreate, issue)
+		}
+		created = append(created, issue)
+	}
+	return created, nil
+}
+
+// promptElapsedTimeboxes asks for the outcome of every spike whose timebox
+// ran out since the last pass: it records a timebox_elapsed event, mails
+// the assignee (or whoever started the spike) and runs the on_timebox hook
+func promptElapsedTimeboxes(ctx context.Context, s *sqlite.SQLiteStorage, runner *hooks.Runner, logf func(format string, args ...interface{})) ([]*sqlite.ElapsedTimebox, error) {
+	elapsed, err := s.GetElapsedTimeboxes(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range elapsed {
+		spike := e.Issue
+		timebox := formatTimebox(*spike.TimeboxMinutes)
+		if err := s.RecordTimeboxElapsed(ctx, spike.ID, notify.Sender, timebox+" timebox elapsed"); err != nil {
+			return nil, err
+		}
+		logf("%s: %s timebox elapsed", spike.ID, timebox)
+
+		recipient := spike.Assignee
+		if recipient == "" {
+			recipient = e.StartedBy
+		}
+		if recipient != "" {
+			if err := s.CreateIssue(ctx, timeboxMessage(e, recipient), notify.Sender); err != nil {
+				logf("%s: could not notify %s: %v", spike.ID, recipient, err)
+			}
+		}
+		if runner != nil {
+			runner.Run(hooks.EventTimebox, spike)
+		}
+	}
+	return elapsed, nil
+}
+
+// timeboxMessage is the bd mail message asking recipient for a spike's
+// outcome
+func timeboxMessage(e *sqlite.ElapsedTimebox, recipient string) *types.Issue {
+	spike := e.Issue
+	var body strings.Builder
+	fmt.Fprintf(&body, "The %s timebox on %s, started %s, has run out.\n\n",
+		formatTimebox(*spike.TimeboxMinutes), spike.ID, e.StartedAt.Local().Format("2006-01-02 15:04"))
+	fmt.Fprintf(&body, "Record what it found and file the follow-up work:\n")
+	fmt.Fprintf(&body, "  bd spike conclude %s --outcome \"...\" --follow-up \"title\"\n\n", spike.ID)
+	fmt.Fprintf(&body, "Or give it more time:\n  bd update %s --timebox %s\n", spike.ID, formatTimebox(2**spike.TimeboxMinutes))
+
+	now := time.Now()
+	return &types.Issue{
+		Title:       "[timebox] " + spike.ID + " " + spike.Title + ": outcome?",
+		Description: body.String(),
+		Status:      types.StatusOpen,
+		Priority:    spike.Priority,
+		IssueType:   types.TypeMessage,
+		Assignee:    recipient,
+		Sender:      notify.Sender,
+		Ephemeral:   true,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// parseTimebox reads a timebox: whole minutes ("90"), a Go duration ("4h",
+// "1h30m"), days ("2d") or weeks ("1w")
+func parseTimebox(s string) (int, error) {
+	s = strings.TrimSpace(s)
+	for suffix, days := range map[string]int{"d": 1, "w": 7} {
+		if n, ok := strings.CutSuffix(s, suffix); ok {
+			count, err := strconv.Atoi(n)
+			if err != nil || count <= 0 {
+				return 0, fmt.Errorf("invalid timebox %q (use e.g. 4h, 2d or 1w)", s)
+			}
+			return count * days * 24 * 60, nil
+		}
+	}
+	minutes, err := parseWorkDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timebox %q (use e.g. 4h, 2d or 1w)", s)
+	}
+	return minutes, nil
+}
+
+// formatTimebox renders a timebox the way it is usually given: 2d, 1w, 4h
+func formatTimebox(minutes int) string {
+	const day = 24 * 60
+	switch {
+	case minutes >= 7*day && minutes%(7*day) == 0:
+		return fmt.Sprintf("%dw", minutes/(7*day))
+	case minutes >= day && minutes%day == 0:
+		return fmt.Sprintf("%dd", minutes/day)
+	default:
+		return formatWorkMinutes(minutes)
+	}
+}
+
+// formatTimeboxState renders a timebox and how much of it is left, e.g.
+// "2d, ends 2025-01-03 14:00 (5.5h left)"
+func formatTimeboxState(tb *types.Timebox, now time.Time) string {
+	switch {
+	case tb.EndsAt == nil:
+		return formatTimebox(tb.Minutes) + ", not running"
+	case tb.Elapsed:
+		return fmt.Sprintf("%s, elapsed %s", formatTimebox(tb.Minutes), displayTime(*tb.EndsAt))
+	default:
+		return fmt.Sprintf("%s, ends %s (%s left)", formatTimebox(tb.Minutes), displayTime(*tb.EndsAt), formatHours(tb.EndsAt.Sub(now).Hours()))
+	}
+}
+
+func init() {
+	spikeListCmd.Flags().Bool("all", false, "Include closed spikes")
+	spikeConcludeCmd.Flags().String("outcome", "", "What the spike found (required)")
+	spikeConcludeCmd.Flags().StringArray("follow-up", nil, "Title of a follow-up task to create (repeatable)")
+	spikeConcludeCmd.Flags().StringP("reason", "r", "", "Close reason from the taxonomy (default: none)")
+	spikeConcludeCmd.Flags().StringArray("done", nil, "Confirm a definition-of-done criterion (repeatable; \"all\" confirms every one)")
+
+	spikeCmd.AddCommand(spikeListCmd, spikeCheckCmd, spikeConcludeCmd)
+	rootCmd.AddCommand(spikeCmd)
+}
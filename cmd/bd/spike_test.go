@@ -0,0 +1,56 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestParseTimebox(t *testing.T) {
+	for in, want := range map[string]int{"90": 90, "4h": 240, "1h30m": 90, "2d": 2880, "1w": 10080, " 3d ": 4320} {
+		if got, err := parseTimebox(in); err != nil || got != want {
+			t.Errorf("parseTimebox(%q) = %d, %v; want %d", in, got, err, want)
+		}
+	}
+	for _, bad := range []string{"", "soon", "0d", "-1w", "1.5d", "0"} {
+		if _, err := parseTimebox(bad); err == nil {
+			t.Errorf("parseTimebox(%q) should fail", bad)
+		}
+	}
+	for minutes, want := range map[int]string{45: "45m", 240: "4h", 90: "1h30m", 2880: "2d", 10080: "1w", 20160: "2w", 12960: "9d"} {
+		if got := formatTimebox(minutes); got != want {
+			t.Errorf("formatTimebox(%d) = %q, want %q", minutes, got, want)
+		}
+	}
+}
+
+func TestFormatTimeboxState(t *testing.T) {
+	now := time.Now()
+	if got := formatTimeboxState(types.NewTimebox(2880, nil, now), now); got != "2d, not running" {
+		t.Errorf("unstarted timebox = %q", got)
+	}
+	started := now.Add(-2 * time.Hour)
+	if got := formatTimeboxState(types.NewTimebox(240, &started, now), now); !strings.HasPrefix(got, "4h, ends ") || !strings.HasSuffix(got, "(2.0h left)") {
+		t.Errorf("running timebox = %q", got)
+	}
+	if got := formatTimeboxState(types.NewTimebox(60, &started, now), now); !strings.HasPrefix(got, "1h, elapsed ") {
+		t.Errorf("elapsed timebox = %q", got)
+	}
+}
+
+func TestTimeboxMessage(t *testing.T) {
+	minutes := 2880
+	spike := &types.Issue{ID: "bd-42", Title: "Drop the cache?", Priority: 1, IssueType: types.TypeSpike, TimeboxMinutes: &minutes}
+	msg := timeboxMessage(&sqlite.ElapsedTimebox{Issue: spike, StartedAt: time.Now().Add(-49 * time.Hour)}, "alice")
+	if msg.Assignee != "alice" || msg.IssueType != types.TypeMessage || !msg.Ephemeral {
+		t.Errorf("message = %+v, want an ephemeral message to alice", msg)
+	}
+	for _, want := range []string{"2d timebox on bd-42", "bd spike conclude bd-42 --outcome", "bd update bd-42 --timebox 4d"} {
+		if !strings.Contains(msg.Description, want) {
+			t.Errorf("message body %q lacks %q", msg.Description, want)
+		}
+	}
+}
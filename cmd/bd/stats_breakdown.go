@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// statsTopLabels is how many labels bd stats lists
+const statsTopLabels = 5
+
+// printStatsBreakdown prints open issues by priority and label and the last
+// week's activity
+func printStatsBreakdown(stats *types.Statistics) {
+	if len(stats.OpenByPriority) > 0 {
+		var parts []string
+		for p := 0; p <= 4; p++ {
+			key := fmt.Sprintf("P%d", p)
+			if n := stats.OpenByPriority[key]; n > 0 {
+				parts = append(parts, fmt.Sprintf("%s %d", key, n))
+			}
+		}
+		fmt.Printf("\nOpen by Priority:       %s\n", strings.Join(parts, " · "))
+	}
+
+	if len(stats.OpenByLabel) > 0 {
+		labels := make([]string, 0, len(stats.OpenByLabel))
+		for label := range stats.OpenByLabel {
+			labels = append(labels, label)
+		}
+		sort.Slice(labels, func(i, j int) bool {
+			if stats.OpenByLabel[labels[i]] != stats.OpenByLabel[labels[j]] {
+				return stats.OpenByLabel[labels[i]] > stats.OpenByLabel[labels[j]]
+			}
+			return labels[i] < labels[j]
+		})
+		fmt.Printf("\nOpen by Label:\n")
+		for i, label := range labels {
+			if i == statsTopLabels {
+				fmt.Printf("  (%d more)\n", len(labels)-statsTopLabels)
+				break
+			}
+			fmt.Printf("  %-21s %d\n", label+":", stats.OpenByLabel[label])
+		}
+	}
+
+	if len(stats.RecentActivity) > 0 {
+		fmt.Printf("\nLast 7 Days:\n")
+		for _, day := range stats.RecentActivity {
+			fmt.Printf("  %-21s %d created, %d closed, %d events\n", day.Date+":", day.Created, day.Closed, day.Events)
+		}
+	}
+}
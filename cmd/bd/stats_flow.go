@@ -0,0 +1,535 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Flow analytics (bd stats velocity, burndown, lead-time) read each issue's
+// status history from the status_transitions table, which the database
+// keeps on every status change and history pruning doesn't touch.
+
+var flowPeriodNames = []string{"day", "week", "month"}
+
+var flowFormats = []string{"table", "json", "csv"}
+
+// VelocityPeriod counts the issues created and closed in one period
+type VelocityPeriod struct {
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+	Created int       `json:"created"`
+	Closed  int       `json:"closed"`
+	Partial bool      `json:"partial,omitempty"` // The current period, still under way
+}
+
+// VelocityReport is the output of 'bd stats velocity'
+type VelocityReport struct {
+	Period        string            `json:"period"`
+	Labels        []string          `json:"labels,omitempty"`
+	Periods       []*VelocityPeriod `json:"periods"`
+	AverageClosed float64           `json:"average_closed"` // Per complete period
+}
+
+// BurndownDay is the state of the backlog at the end of one day
+type BurndownDay struct {
+	Date      string `json:"date"`      // YYYY-MM-DD
+	Remaining int    `json:"remaining"` // Not closed at the end of the day (now, for today)
+	Added     int    `json:"added"`
+	Closed    int    `json:"closed"`
+}
+
+// BurndownReport is the output of 'bd stats burndown'
+type BurndownReport struct {
+	Since  time.Time      `json:"since"`
+	Labels []string       `json:"labels,omitempty"`
+	Days   []*BurndownDay `json:"days"`
+}
+
+// LeadTimePeriod summarizes the lead time of the issues closed in one period
+type LeadTimePeriod struct {
+	Start    time.Time          `json:"start"`
+	End      time.Time          `json:"end"`
+	Closed   int                `json:"closed"`
+	LeadTime *PercentileSummary `json:"lead_time,omitempty"`
+	Partial  bool               `json:"partial,omitempty"`
+}
+
+// LeadTimeReport is the output of 'bd stats lead-time'
+type LeadTimeReport struct {
+	Period  string             `json:"period"`
+	Labels  []string           `json:"labels,omitempty"`
+	Periods []*LeadTimePeriod  `json:"periods"`
+	Overall *PercentileSummary `json:"overall,omitempty"` // Every close in the periods shown
+}
+
+var velocityCmd = &cobra.Command{
+	Use:   "velocity",
+	Short: "Show how many issues are created and closed per week",
+	Long: `Show throughput: how many issues were created and closed in each of the
+last --periods periods (weeks by default, starting Monday), and the average
+closed per complete period. The current period is marked as partial.
+
+An issue counts once per period it was closed in, so one that is reopened
+and closed again later counts again.
+
+Examples:
+  bd stats velocity
+  bd stats velocity --period month --periods 6
+  bd stats velocity --label team:payments --format csv > velocity.csv`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, _ []string) {
+		format := flowFormat(cmd)
+		period, periods := flowPeriodFlags(cmd)
+		labels, _ := cmd.Flags().GetStringSlice("label")
+		history := loadFlowHistory("stats velocity", labels)
+
+		report := buildVelocityReport(history, period, periods, time.Now())
+		report.Labels = labels
+		switch format {
+		case "json":
+			outputJSON(report)
+		case "csv":
+			rows := [][]string{{"period_start", "period_end", "created", "closed"}}
+			for _, p := range report.Periods {
+				rows = append(rows, []string{csvDate(p.Start), csvDate(p.End), strconv.Itoa(p.Created), strconv.Itoa(p.Closed)})
+			}
+			writeFlowCSV(rows)
+		default:
+			printVelocityReport(report)
+		}
+	},
+}
+
+var burndownCmd = &cobra.Command{
+	Use:   "burndown",
+	Short: "Show the open backlog day by day",
+	Long: `Show, for every day since --since, how many issues were still not closed
+at the end of the day, and how many were added and closed that day.
+Use --label to follow one team, milestone or sprint.
+
+--since takes how long ago ("2w", "10d") or a date (default 2w).
+
+Examples:
+  bd stats burndown
+  bd stats burndown --since=2w --label sprint-12
+  bd stats burndown --since 2025-06-01 --json`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, _ []string) {
+		format := flowFormat(cmd)
+		sinceFlag, _ := cmd.Flags().GetString("since")
+		labels, _ := cmd.Flags().GetStringSlice("label")
+		now := time.Now()
+		since, err := parseSinceFlag("--since", sinceFlag, now)
+		if err != nil {
+			FatalError("%v", err)
+		}
+		if since.After(now) {
+			FatalError("--since %q is in the future", sinceFlag)
+		}
+		history := loadFlowHistory("stats burndown", labels)
+
+		report := buildBurndownReport(history, since, now)
+		report.Labels = labels
+		switch format {
+		case "json":
+			outputJSON(report)
+		case "csv":
+			rows := [][]string{{"date", "remaining", "added", "closed"}}
+			for _, d := range report.Days {
+				rows = append(rows, []string{d.Date, strconv.Itoa(d.Remaining), strconv.Itoa(d.Added), strconv.Itoa(d.Closed)})
+			}
+			writeFlowCSV(rows)
+		default:
+			printBurndownReport(report)
+		}
+	},
+}
+
+var leadTimeCmd = &cobra.Command{
+	Use:   "lead-time",
+	Short: "Show lead time (created → closed) per week",
+	Long: `Show lead time, from creation to closing, for the issues closed in each of
+the last --periods periods (weeks by default): p50/p90/p99 per period and
+over all of them, to see whether delivery is speeding up or slowing down.
+'bd stats cycle-time' breaks lead time down by label, priority and actor.
+
+Examples:
+  bd stats lead-time
+  bd stats lead-time --period month --periods 12 --format csv`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, _ []string) {
+		format := flowFormat(cmd)
+		period, periods := flowPeriodFlags(cmd)
+		labels, _ := cmd.Flags().GetStringSlice("label")
+		history := loadFlowHistory("stats lead-time", labels)
+
+		report := buildLeadTimeReport(history, period, periods, time.Now())
+		report.Labels = labels
+		switch format {
+		case "json":
+			outputJSON(report)
+		case "csv":
+			rows := [][]string{{"period_start", "period_end", "closed", "p50_hours", "p90_hours", "p99_hours"}}
+			for _, p := range report.Periods {
+				row := []string{csvDate(p.Start), csvDate(p.End), strconv.Itoa(p.Closed), "", "", ""}
+				if p.LeadTime != nil {
+					row[3], row[4], row[5] = csvHours(p.LeadTime.P50Hours), csvHours(p.LeadTime.P90Hours), csvHours(p.LeadTime.P99Hours)
+				}
+				rows = append(rows, row)
+			}
+			writeFlowCSV(rows)
+		default:
+			printLeadTimeReport(report)
+		}
+	},
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{velocityCmd, burndownCmd, leadTimeCmd} {
+		cmd.Flags().StringSliceP("label", "l", nil, "Only count issues with these labels (AND)")
+		cmd.Flags().String("format", "table", "Output format: table, json, csv")
+		statsCmd.AddCommand(cmd)
+	}
+	for _, cmd := range []*cobra.Command{velocityCmd, leadTimeCmd} {
+		cmd.Flags().String("period", "week", "Period length: day, week, month")
+		cmd.Flags().Int("periods", 8, "Number of periods to show, ending with the current one")
+	}
+	burndownCmd.Flags().String("since", "2w", "First day to show: how long ago (2w, 10d) or a date")
+}
+
+// flowFormat returns the output format; --json wins over --format
+func flowFormat(cmd *cobra.Command) string {
+	format, _ := cmd.Flags().GetString("format")
+	if !slices.Contains(flowFormats, format) {
+		FatalError("invalid --format %q (valid: %s)", format, strings.Join(flowFormats, ", "))
+	}
+	if jsonOutput {
+		return "json"
+	}
+	return format
+}
+
+func flowPeriodFlags(cmd *cobra.Command) (string, int) {
+	period, _ := cmd.Flags().GetString("period")
+	periods, _ := cmd.Flags().GetInt("periods")
+	if !slices.Contains(flowPeriodNames, period) {
+		FatalError("invalid --period %q (valid: %s)", period, strings.Join(flowPeriodNames, ", "))
+	}
+	if periods < 1 {
+		FatalError("--periods must be at least 1")
+	}
+	return period, periods
+}
+
+// flowHistory is the status history of each issue in scope, oldest first
+type flowHistory map[string][]*sqlite.StatusTransition
+
+// loadFlowHistory reads the status history of every issue with all of labels
+func loadFlowHistory(command string, labels []string) flowHistory {
+	if err := ensureDirectMode(command + " requires direct database access"); err != nil {
+		FatalError("%v", err)
+	}
+	sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+	if !ok {
+		FatalError("%s requires the SQLite backend", command)
+	}
+	ctx := rootCtx
+	if err := ensureDatabaseFresh(ctx); err != nil {
+		FatalError("%v", err)
+	}
+	transitions, err := sqliteStore.GetStatusTransitions(ctx)
+	if err != nil {
+		FatalError("%v", err)
+	}
+	history := make(flowHistory)
+	for _, t := range transitions {
+		history[t.IssueID] = append(history[t.IssueID], t)
+	}
+	if len(labels) == 0 {
+		return history
+	}
+
+	ids := make([]string, 0, len(history))
+	for id := range history {
+		ids = append(ids, id)
+	}
+	issueLabels, err := sqliteStore.GetLabelsForIssues(ctx, ids)
+	if err != nil {
+		FatalError("%v", err)
+	}
+	for id := range history {
+		for _, label := range labels {
+			if !slices.Contains(issueLabels[id], label) {
+				delete(history, id)
+				break
+			}
+		}
+	}
+	return history
+}
+
+// statusAt returns an issue's status at t, and false if it didn't exist yet
+func statusAt(transitions []*sqlite.StatusTransition, t time.Time) (types.Status, bool) {
+	var status types.Status
+	found := false
+	for _, tr := range transitions {
+		if tr.At.After(t) {
+			break
+		}
+		status, found = tr.To, true
+	}
+	return status, found
+}
+
+// flowWindow is one period, [Start, End)
+type flowWindow struct {
+	Start, End time.Time
+}
+
+// flowPeriods returns the last n periods, the current one included
+func flowPeriods(period string, n int, now time.Time) []flowWindow {
+	start := flowPeriodStart(now, period)
+	windows := make([]flowWindow, n)
+	for i := n - 1; i >= 0; i-- {
+		windows[i] = flowWindow{Start: start, End: addFlowPeriod(start, period, 1)}
+		start = addFlowPeriod(start, period, -1)
+	}
+	return windows
+}
+
+// flowPeriodStart returns the start of the period containing t; weeks start
+// on Monday
+func flowPeriodStart(t time.Time, period string) time.Time {
+	day := startOfDay(t)
+	switch period {
+	case "day":
+		return day
+	case "month":
+		return day.AddDate(0, 0, 1-day.Day())
+	default:
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	}
+}
+
+func addFlowPeriod(t time.Time, period string, n int) time.Time {
+	switch period {
+	case "day":
+		return t.AddDate(0, 0, n)
+	case "month":
+		return t.AddDate(0, n, 0)
+	default:
+		return t.AddDate(0, 0, 7*n)
+	}
+}
+
+// periodIndex returns the window containing t, or -1
+func periodIndex(windows []flowWindow, t time.Time) int {
+	for i, w := range windows {
+		if !t.Before(w.Start) && t.Before(w.End) {
+			return i
+		}
+	}
+	return -1
+}
+
+func buildVelocityReport(history flowHistory, period string, n int, now time.Time) *VelocityReport {
+	windows := flowPeriods(period, n, now)
+	report := &VelocityReport{Period: period, Periods: make([]*VelocityPeriod, n)}
+	for i, w := range windows {
+		report.Periods[i] = &VelocityPeriod{Start: w.Start, End: w.End, Partial: w.End.After(now)}
+	}
+	for _, transitions := range history {
+		closedIn := make(map[int]bool)
+		for _, t := range transitions {
+			i := periodIndex(windows, t.At)
+			if i < 0 {
+				continue
+			}
+			if t.From == "" {
+				report.Periods[i].Created++
+			}
+			if t.To == types.StatusClosed && !closedIn[i] {
+				closedIn[i] = true
+				report.Periods[i].Closed++
+			}
+		}
+	}
+
+	complete, closed := 0, 0
+	for _, p := range report.Periods {
+		if !p.Partial {
+			complete++
+			closed += p.Closed
+		}
+	}
+	if complete > 0 {
+		report.AverageClosed = float64(closed) / float64(complete)
+	}
+	return report
+}
+
+func buildBurndownReport(history flowHistory, since, now time.Time) *BurndownReport {
+	report := &BurndownReport{Since: startOfDay(since)}
+	for day := report.Since; day.Before(now); day = day.AddDate(0, 0, 1) {
+		end := day.AddDate(0, 0, 1)
+		snapshot := end
+		if snapshot.After(now) {
+			snapshot = now
+		}
+		entry := &BurndownDay{Date: day.Format("2006-01-02")}
+		for _, transitions := range history {
+			if status, ok := statusAt(transitions, snapshot); ok && status != types.StatusClosed && status != types.StatusTombstone {
+				entry.Remaining++
+			}
+			closed := false
+			for _, t := range transitions {
+				if t.At.Before(day) || !t.At.Before(end) {
+					continue
+				}
+				if t.From == "" {
+					entry.Added++
+				}
+				if t.To == types.StatusClosed {
+					closed = true
+				}
+			}
+			if closed {
+				entry.Closed++
+			}
+		}
+		report.Days = append(report.Days, entry)
+	}
+	return report
+}
+
+func buildLeadTimeReport(history flowHistory, period string, n int, now time.Time) *LeadTimeReport {
+	windows := flowPeriods(period, n, now)
+	samples := make([][]float64, n)
+	var all []float64
+	for _, transitions := range history {
+		created := transitions[0].At
+		// The last close in each period counts
+		lastClose := make(map[int]time.Time)
+		for _, t := range transitions {
+			if t.To != types.StatusClosed {
+				continue
+			}
+			if i := periodIndex(windows, t.At); i >= 0 {
+				lastClose[i] = t.At
+			}
+		}
+		for i, at := range lastClose {
+			hours := at.Sub(created).Hours()
+			samples[i] = append(samples[i], hours)
+			all = append(all, hours)
+		}
+	}
+
+	report := &LeadTimeReport{Period: period, Periods: make([]*LeadTimePeriod, n)}
+	for i, w := range windows {
+		p := &LeadTimePeriod{Start: w.Start, End: w.End, Closed: len(samples[i]), Partial: w.End.After(now)}
+		if len(samples[i]) > 0 {
+			p.LeadTime = summarizePercentiles(samples[i])
+		}
+		report.Periods[i] = p
+	}
+	if len(all) > 0 {
+		report.Overall = summarizePercentiles(all)
+	}
+	return report
+}
+
+// flowBar draws value as a bar scaled so that most fills width
+func flowBar(value, most, width int) string {
+	if most <= 0 || value <= 0 {
+		return ""
+	}
+	return strings.Repeat("█", max(1, value*width/most))
+}
+
+func flowPeriodLabel(start time.Time, partial bool) string {
+	label := displayDate(start)
+	if partial {
+		label += "*"
+	}
+	return label
+}
+
+func printVelocityReport(report *VelocityReport) {
+	cyan := color.New(color.FgCyan).SprintFunc()
+	most := 0
+	for _, p := range report.Periods {
+		most = max(most, p.Closed)
+	}
+	fmt.Printf("\n%s Velocity per %s%s\n\n", cyan("📈"), report.Period, flowLabelNote(report.Labels))
+	fmt.Printf("  %-12s %7s %7s\n", report.Period, "created", "closed")
+	for _, p := range report.Periods {
+		line := fmt.Sprintf("  %-12s %7d %7d  %s", flowPeriodLabel(p.Start, p.Partial), p.Created, p.Closed, flowBar(p.Closed, most, 30))
+		fmt.Println(strings.TrimRight(line, " "))
+	}
+	fmt.Printf("\nAverage closed per %s: %.1f (* still under way, not averaged)\n\n", report.Period, report.AverageClosed)
+}
+
+func printBurndownReport(report *BurndownReport) {
+	cyan := color.New(color.FgCyan).SprintFunc()
+	most := 0
+	for _, d := range report.Days {
+		most = max(most, d.Remaining)
+	}
+	fmt.Printf("\n%s Burndown since %s%s\n\n", cyan("📉"), displayDate(report.Since), flowLabelNote(report.Labels))
+	fmt.Printf("  %-10s %9s %6s %7s\n", "date", "remaining", "added", "closed")
+	for _, d := range report.Days {
+		line := fmt.Sprintf("  %-10s %9d %6d %7d  %s", d.Date, d.Remaining, d.Added, d.Closed, flowBar(d.Remaining, most, 30))
+		fmt.Println(strings.TrimRight(line, " "))
+	}
+	fmt.Println()
+}
+
+func printLeadTimeReport(report *LeadTimeReport) {
+	cyan := color.New(color.FgCyan).SprintFunc()
+	fmt.Printf("\n%s Lead time per %s%s\n\n", cyan("⏱"), report.Period, flowLabelNote(report.Labels))
+	fmt.Printf("  %-12s %6s  %s\n", report.Period, "closed", "p50/p90/p99")
+	for _, p := range report.Periods {
+		lead := "-"
+		if p.LeadTime != nil {
+			lead = formatPercentiles(p.LeadTime)
+		}
+		fmt.Printf("  %-12s %6d  %s\n", flowPeriodLabel(p.Start, p.Partial), p.Closed, lead)
+	}
+	if report.Overall != nil {
+		fmt.Printf("\nOverall (%d closed): %s\n", report.Overall.Samples, formatPercentiles(report.Overall))
+	}
+	fmt.Println()
+}
+
+func flowLabelNote(labels []string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	return " (labels: " + strings.Join(labels, ", ") + ")"
+}
+
+func csvDate(t time.Time) string {
+	return t.Format("2006-01-02")
+}
+
+func csvHours(h float64) string {
+	return strconv.FormatFloat(h, 'f', 2, 64)
+}
+
+func writeFlowCSV(rows [][]string) {
+	w := csv.NewWriter(os.Stdout)
+	if err := w.WriteAll(rows); err != nil {
+		FatalError("failed to write CSV: %v", err)
+	}
+}
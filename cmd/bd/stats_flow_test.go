@@ -0,0 +1,79 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestFlowReports(t *testing.T) {
+	// Wednesday; the current week started Monday the 13th
+	now := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	day := func(d, hour int) time.Time { return time.Date(2025, 1, d, hour, 0, 0, 0, time.UTC) }
+	tr := func(id string, from, to types.Status, at time.Time) *sqlite.StatusTransition {
+		return &sqlite.StatusTransition{IssueID: id, From: from, To: to, At: at}
+	}
+	history := flowHistory{
+		// Closed last week after two days
+		"bd-1": {tr("bd-1", "", types.StatusOpen, day(6, 9)), tr("bd-1", types.StatusOpen, types.StatusClosed, day(8, 9))},
+		// Closed last week, reopened and closed again this week
+		"bd-2": {
+			tr("bd-2", "", types.StatusOpen, day(7, 9)),
+			tr("bd-2", types.StatusOpen, types.StatusClosed, day(9, 9)),
+			tr("bd-2", types.StatusClosed, types.StatusOpen, day(13, 9)),
+			tr("bd-2", types.StatusOpen, types.StatusClosed, day(14, 9)),
+		},
+		// Created this week, still in progress
+		"bd-3": {tr("bd-3", "", types.StatusOpen, day(13, 10)), tr("bd-3", types.StatusOpen, types.StatusInProgress, day(14, 10))},
+	}
+
+	velocity := buildVelocityReport(history, "week", 2, now)
+	if len(velocity.Periods) != 2 || !velocity.Periods[0].Start.Equal(day(6, 0)) || !velocity.Periods[1].Partial {
+		t.Fatalf("velocity periods = %+v", velocity.Periods)
+	}
+	if p := velocity.Periods[0]; p.Created != 2 || p.Closed != 2 {
+		t.Errorf("last week = %+v, want 2 created, 2 closed", p)
+	}
+	if p := velocity.Periods[1]; p.Created != 1 || p.Closed != 1 {
+		t.Errorf("this week = %+v, want 1 created, 1 closed", p)
+	}
+	if velocity.AverageClosed != 2 {
+		t.Errorf("average closed = %v, want 2 (complete weeks only)", velocity.AverageClosed)
+	}
+
+	burndown := buildBurndownReport(history, day(8, 15), now)
+	want := map[string]BurndownDay{
+		"2025-01-08": {Remaining: 1, Closed: 1},
+		"2025-01-09": {Remaining: 0, Closed: 1},
+		"2025-01-13": {Remaining: 2, Added: 1},
+		"2025-01-15": {Remaining: 1},
+	}
+	if len(burndown.Days) != 8 {
+		t.Fatalf("burndown has %d days, want 8", len(burndown.Days))
+	}
+	for _, d := range burndown.Days {
+		if w, ok := want[d.Date]; ok && (d.Remaining != w.Remaining || d.Added != w.Added || d.Closed != w.Closed) {
+			t.Errorf("%s = %+v, want %+v", d.Date, *d, w)
+		}
+	}
+
+	lead := buildLeadTimeReport(history, "week", 2, now)
+	if p := lead.Periods[0]; p.Closed != 2 || p.LeadTime.P50Hours != 48 {
+		t.Errorf("last week lead time = %+v", p.LeadTime)
+	}
+	if p := lead.Periods[1]; p.Closed != 1 || p.LeadTime.P50Hours != 7*24 {
+		t.Errorf("this week lead time = %+v, want from creation to the last close", p.LeadTime)
+	}
+	if lead.Overall == nil || lead.Overall.Samples != 3 {
+		t.Errorf("overall = %+v, want 3 samples", lead.Overall)
+	}
+
+	if got := flowPeriodStart(day(5, 20), "week"); !got.Equal(day(-1, 0)) {
+		t.Errorf("week of Sunday Jan 5 starts %v, want Monday Dec 30", got)
+	}
+	if got := flowPeriodStart(now, "month"); !got.Equal(day(1, 0)) {
+		t.Errorf("month starts %v", got)
+	}
+}
@@ -0,0 +1,334 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/cmd/bd/doctor"
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/configfile"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+)
+
+// Config keys linking a subproject and its parent. The parent lists its
+// subprojects under subproject.<name>.path/prefix (paths relative to the
+// parent root); the subproject records its name and the way back.
+const (
+	subprojectConfigPrefix = "subproject."
+	subprojectParentKey    = "subproject.parent"
+	subprojectNameKey      = "subproject.name"
+)
+
+// subprojectSkipPrefixes are parent config keys a subproject does not
+// inherit: its own identity and integrations the parent's daemon already runs
+var subprojectSkipPrefixes = []string{
+	"issue_prefix",
+	"subproject.",
+	"repos.",
+	"events.",
+	"ready_webhook.",
+	"webhook.",
+}
+
+// Subproject is a nested backlog registered with its parent
+type Subproject struct {
+	Name   string `json:"name"`
+	Path   string `json:"path"` // relative to the parent root
+	Prefix string `json:"prefix"`
+	Exists bool   `json:"exists"`
+}
+
+var subprojectCmd = &cobra.Command{
+	Use:   "subproject",
+	Short: "Manage per-team backlogs nested inside this project",
+	Long: `Manage per-team backlogs nested inside a monorepo's project.
+
+A subproject is a separate .beads directory in a subdirectory, with its own
+prefix and issues. bd commands run inside the subdirectory use it; commands
+run elsewhere use the parent. The parent and the subproject are linked
+through config so either side can find the other.`,
+}
+
+var subprojectCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a nested backlog for a sub-team",
+	Long: `Create a nested backlog at --path inside this project.
+
+The subproject gets its own database and prefix (default: the name) and
+inherits the parent's shareable project config: custom statuses, close
+reasons, lint rules and other workflow settings. Secrets, the parent's
+prefix and integrations that the parent's daemon already runs (events.*,
+webhook.*, ready_webhook.*) are not copied.
+
+Both sides are linked: the parent records subproject.<name>.path and
+subproject.<name>.prefix, and the subproject records subproject.parent
+(the relative path back) and subproject.name.
+
+Examples:
+  bd subproject create payments --path services/payments
+  bd subproject create search --path services/search --prefix srch`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+		path, _ := cmd.Flags().GetString("path")
+		prefix, _ := cmd.Flags().GetString("prefix")
+		CheckReadonly("subproject create")
+
+		if path == "" {
+			FatalError("--path is required")
+		}
+		if strings.ContainsAny(name, ". \t") || name == "" {
+			FatalError("invalid subproject name %q (no dots or spaces)", name)
+		}
+		if prefix == "" {
+			prefix = name
+		}
+		prefix = strings.TrimRight(prefix, "-")
+		if err := validatePrefix(prefix); err != nil {
+			FatalErrorWithHint(err.Error(), "choose one with --prefix")
+		}
+
+		if err := ensureDirectMode("subproject create requires direct database access"); err != nil {
+			FatalError("%v", err)
+		}
+		ctx := rootCtx
+		parentRoot, err := filepath.Abs(filepath.Dir(filepath.Dir(dbPath)))
+		if err != nil {
+			FatalError("%v", err)
+		}
+
+		sub, err := createSubproject(ctx, store, parentRoot, name, path, prefix)
+		if err != nil {
+			FatalError("%v", err)
+		}
+		markDirtyAndScheduleFlush()
+
+		if jsonOutput {
+			outputJSON(sub)
+			return
+		}
+		green := color.New(color.FgGreen).SprintFunc()
+		cyan := color.New(color.FgCyan).SprintFunc()
+		fmt.Printf("%s Created subproject %s at %s\n", green("✓"), cyan(sub.Name), sub.Path)
+		fmt.Printf("  Issues will be named: %s\n", cyan(sub.Prefix+"-<hash>"))
+		fmt.Printf("  Run bd commands from %s to work on its backlog\n", sub.Path)
+	},
+}
+
+var subprojectListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List this project's subprojects",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := ensureDirectMode("subproject list requires direct database access"); err != nil {
+			FatalError("%v", err)
+		}
+		ctx := rootCtx
+		parentRoot, err := filepath.Abs(filepath.Dir(filepath.Dir(dbPath)))
+		if err != nil {
+			FatalError("%v", err)
+		}
+		subs, err := listSubprojects(ctx, store, parentRoot)
+		if err != nil {
+			FatalError("%v", err)
+		}
+		parent, _ := store.GetConfig(ctx, subprojectParentKey)
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"parent": parent, "subprojects": subs})
+			return
+		}
+		if parent != "" {
+			fmt.Printf("Parent project: %s\n\n", parent)
+		}
+		if len(subs) == 0 {
+			fmt.Println("No subprojects (create one with bd subproject create <name> --path <dir>)")
+			return
+		}
+		for _, sub := range subs {
+			missing := ""
+			if !sub.Exists {
+				missing = color.New(color.FgYellow).Sprint("  (missing .beads)")
+			}
+			fmt.Printf("  %-16s %-8s %s%s\n", sub.Name, sub.Prefix+"-", sub.Path, missing)
+		}
+	},
+}
+
+// createSubproject initializes a nested .beads at path (relative to the
+// current directory) inside parentRoot, copies inheritable config and links
+// both databases
+func createSubproject(ctx context.Context, parent storage.Storage, parentRoot, name, path, prefix string) (*Subproject, error) {
+	existing, err := listSubprojects(ctx, parent, parentRoot)
+	if err != nil {
+		return nil, err
+	}
+	for _, sub := range existing {
+		if sub.Name == name {
+			return nil, fmt.Errorf("subproject %s already exists at %s", name, sub.Path)
+		}
+		if sub.Prefix == prefix {
+			return nil, fmt.Errorf("prefix %s is already used by subproject %s", prefix, sub.Name)
+		}
+	}
+	if parentPrefix, _ := parent.GetConfig(ctx, "issue_prefix"); parentPrefix == prefix {
+		return nil, fmt.Errorf("prefix %s is the parent project's prefix", prefix)
+	}
+
+	root, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	rel, err := filepath.Rel(parentRoot, root)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil, fmt.Errorf("%s is not inside the project at %s", path, parentRoot)
+	}
+	beadsDir := filepath.Join(root, ".beads")
+	if _, err := os.Stat(beadsDir); err == nil {
+		return nil, fmt.Errorf("%s already has a .beads directory", path)
+	}
+	if err := os.MkdirAll(beadsDir, 0750); err != nil {
+		return nil, fmt.Errorf("failed to create .beads directory: %w", err)
+	}
+
+	inherited, err := inheritableConfig(ctx, parent)
+	if err != nil {
+		return nil, err
+	}
+	back, err := filepath.Rel(root, parentRoot)
+	if err != nil {
+		return nil, err
+	}
+	inherited["issue_prefix"] = prefix
+	inherited[subprojectNameKey] = name
+	inherited[subprojectParentKey] = filepath.ToSlash(back)
+	if err := initNestedBeads(ctx, beadsDir, inherited); err != nil {
+		return nil, err
+	}
+
+	rel = filepath.ToSlash(rel)
+	if err := parent.SetConfig(ctx, subprojectConfigPrefix+name+".path", rel); err != nil {
+		return nil, err
+	}
+	if err := parent.SetConfig(ctx, subprojectConfigPrefix+name+".prefix", prefix); err != nil {
+		return nil, err
+	}
+	return &Subproject{Name: name, Path: rel, Prefix: prefix, Exists: true}, nil
+}
+
+// initNestedBeads does what bd init does for a new database, minus git
+// hooks and the merge driver, which the enclosing repository already has
+func initNestedBeads(ctx context.Context, beadsDir string, config map[string]string) error {
+	if err := os.WriteFile(filepath.Join(beadsDir, ".gitignore"), []byte(doctor.GitignoreTemplate), 0600); err != nil {
+		return fmt.Errorf("failed to create .gitignore: %w", err)
+	}
+	sub, err := sqlite.New(ctx, filepath.Join(beadsDir, beads.CanonicalDatabaseName))
+	if err != nil {
+		return fmt.Errorf("failed to create database: %w", err)
+	}
+	defer func() { _ = sub.Close() }()
+
+	keys := make([]string, 0, len(config))
+	for key := range config {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := sub.SetConfig(ctx, key, config[key]); err != nil {
+			return fmt.Errorf("failed to set %s: %w", key, err)
+		}
+	}
+	if err := sub.SetMetadata(ctx, "bd_version", Version); err != nil {
+		return fmt.Errorf("failed to store version metadata: %w", err)
+	}
+	if repoID, err := beads.ComputeRepoID(); err == nil {
+		_ = sub.SetMetadata(ctx, "repo_id", repoID)
+	}
+	if cloneID, err := beads.GetCloneID(); err == nil {
+		_ = sub.SetMetadata(ctx, "clone_id", cloneID)
+	}
+
+	if err := configfile.DefaultConfig().Save(beadsDir); err != nil {
+		return fmt.Errorf("failed to create metadata.json: %w", err)
+	}
+	if err := createConfigYaml(beadsDir, false); err != nil {
+		return fmt.Errorf("failed to create config.yaml: %w", err)
+	}
+	if err := createReadme(beadsDir); err != nil {
+		return fmt.Errorf("failed to create README.md: %w", err)
+	}
+	return nil
+}
+
+// inheritableConfig is the parent's shareable config minus the keys a
+// subproject keeps for itself
+func inheritableConfig(ctx context.Context, parent storage.Storage) (map[string]string, error) {
+	config, err := exportableConfig(ctx, parent)
+	if err != nil {
+		return nil, err
+	}
+	for key := range config {
+		for _, skip := range subprojectSkipPrefixes {
+			if strings.HasPrefix(key, skip) {
+				delete(config, key)
+				break
+			}
+		}
+	}
+	return config, nil
+}
+
+// listSubprojects reads the subproject.<name>.* keys of the parent
+func listSubprojects(ctx context.Context, parent storage.Storage, parentRoot string) ([]*Subproject, error) {
+	all, err := parent.GetAllConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]*Subproject)
+	for key, value := range all {
+		rest, ok := strings.CutPrefix(key, subprojectConfigPrefix)
+		if !ok {
+			continue
+		}
+		name, field, ok := strings.Cut(rest, ".")
+		if !ok {
+			continue // subproject.parent / subproject.name describe this project itself
+		}
+		sub := byName[name]
+		if sub == nil {
+			sub = &Subproject{Name: name}
+			byName[name] = sub
+		}
+		switch field {
+		case "path":
+			sub.Path = value
+		case "prefix":
+			sub.Prefix = value
+		}
+	}
+
+	subs := make([]*Subproject, 0, len(byName))
+	for _, sub := range byName {
+		if sub.Path != "" {
+			_, err := os.Stat(filepath.Join(parentRoot, filepath.FromSlash(sub.Path), ".beads"))
+			sub.Exists = err == nil
+		}
+		subs = append(subs, sub)
+	}
+	sort.Slice(subs, func(i, j int) bool { return subs[i].Name < subs[j].Name })
+	return subs, nil
+}
+
+func init() {
+	subprojectCreateCmd.Flags().String("path", "", "Directory for the subproject, inside this project (required)")
+	subprojectCreateCmd.Flags().String("prefix", "", "Issue prefix for the subproject (default: the name)")
+	subprojectCmd.AddCommand(subprojectCreateCmd)
+	subprojectCmd.AddCommand(subprojectListCmd)
+	rootCmd.AddCommand(subprojectCmd)
+}
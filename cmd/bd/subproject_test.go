@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+)
+
+func TestCreateSubproject(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	parent := newTestStoreWithPrefix(t, filepath.Join(root, ".beads", "beads.db"), "mono")
+	for key, value := range map[string]string{
+		"status.custom":          "review",
+		"jira.api_token":         "secret",
+		"ready_webhook.ci.url":   "http://localhost:9000/",
+		"subproject.search.path": "services/search",
+	} {
+		if err := parent.SetConfig(ctx, key, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	subPath := filepath.Join(root, "services", "payments")
+	sub, err := createSubproject(ctx, parent, root, "payments", subPath, "pay")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sub.Path != "services/payments" || sub.Prefix != "pay" {
+		t.Errorf("subproject = %+v", sub)
+	}
+
+	child, err := sqlite.New(ctx, filepath.Join(subPath, ".beads", beads.CanonicalDatabaseName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer child.Close()
+	config, err := child.GetAllConfig(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for key, want := range map[string]string{
+		"issue_prefix":      "pay",
+		"status.custom":     "review",
+		"subproject.name":   "payments",
+		"subproject.parent": "../..",
+	} {
+		if config[key] != want {
+			t.Errorf("subproject %s = %q, want %q", key, config[key], want)
+		}
+	}
+	for _, key := range []string{"jira.api_token", "ready_webhook.ci.url", "subproject.search.path"} {
+		if _, ok := config[key]; ok {
+			t.Errorf("subproject inherited %s", key)
+		}
+	}
+
+	subs, err := listSubprojects(ctx, parent, root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(subs) != 2 || subs[0].Name != "payments" || !subs[0].Exists || subs[1].Name != "search" || subs[1].Exists {
+		t.Errorf("listSubprojects = %+v, %+v", subs[0], subs[1])
+	}
+
+	for name, args := range map[string][2]string{
+		"duplicate name":   {"payments", "pay2"},
+		"duplicate prefix": {"billing", "pay"},
+		"parent prefix":    {"billing", "mono"},
+	} {
+		if _, err := createSubproject(ctx, parent, root, args[0], filepath.Join(root, "other"), args[1]); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+	if _, err := createSubproject(ctx, parent, root, "outside", t.TempDir(), "out"); err == nil {
+		t.Error("expected an error for a path outside the project")
+	}
+}
@@ -16,10 +16,14 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/debug"
-	"github.com/steveyegge/beads/internal/git"
+	"github.com/steveyegge/beads/internal/export"
+	"github.com/steveyegge/beads/internal/encryption"
+	"github.com/steveyegge/beads/internal/redact"
 	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/syncbranch"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/util"
 )
 
 var syncCmd = &cobra.Command{
@@ -110,7 +114,7 @@ Use --merge to merge the sync branch back to main branch.`,
 
 		// If from-main mode, one-way sync from main branch (gt-ick9: ephemeral branch support)
 		if fromMain {
-			if err := doSyncFromMain(ctx, jsonlPath, renameOnImport, dryRun, noGitHistory); err != nil {
+			if err := auditedSyncFromMain(ctx, jsonlPath, renameOnImport, dryRun, noGitHistory); err != nil {
 				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 				os.Exit(1)
 			}
@@ -186,7 +190,7 @@ Use --merge to merge the sync branch back to main branch.`,
 				// Remote exists but no upstream - use from-main mode
 				fmt.Println("→ No upstream configured, using --from-main mode")
 				// Force noGitHistory=true for auto-detected from-main mode (fixes #417)
-				if err := doSyncFromMain(ctx, jsonlPath, renameOnImport, dryRun, true); err != nil {
+				if err := auditedSyncFromMain(ctx, jsonlPath, renameOnImport, dryRun, true); err != nil {
 					fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 					os.Exit(1)
 				}
@@ -195,6 +199,31 @@ Use --merge to merge the sync branch back to main branch.`,
 			// If no remote at all, gitPull/gitPush will gracefully skip
 		}
 
+		// Work out the exact changes up front: a dry run shows them, and the
+		// sync log records them. A run that exits on an error below stays
+		// incomplete in the log.
+		plan, err := planGitSync(ctx, jsonlPath, "", !noPull, false)
+		if err != nil {
+			if dryRun {
+				fmt.Fprintf(os.Stderr, "Error: failed to work out changes: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Fprintf(os.Stderr, "Warning: failed to work out changes for the sync log: %v\n", err)
+			plan = &gitSyncPlan{Outbound: []sqlite.SyncChange{}, Inbound: []sqlite.SyncChange{}}
+		}
+		if noPull {
+			plan.Inbound = []sqlite.SyncChange{}
+		}
+		syncRun := startSyncAudit(ctx, sqlite.SyncProviderGit, plan.Remote, dryRun, plan.changes())
+		if dryRun {
+			if jsonOutput {
+				finishSyncAudit(ctx, syncRun, nil, nil)
+				outputJSON(gitSyncPlanJSON(plan, syncRun))
+				return
+			}
+			printGitSyncPlan(plan)
+		}
+
 		// Step 1: Export pending changes (but check for stale DB first)
 		skipExport := false // Track if we should skip export due to ZFC import
 		if dryRun {
@@ -377,6 +406,7 @@ Use --merge to merge the sync branch back to main branch.`,
 				}
 			}
 
+			finishSyncAudit(ctx, syncRun, nil, nil)
 			fmt.Println("\n✓ Sync complete")
 			return
 		}
@@ -726,6 +756,7 @@ Use --merge to merge the sync branch back to main branch.`,
 			}
 		}
 
+		finishSyncAudit(ctx, syncRun, nil, nil)
 		if dryRun {
 			fmt.Println("\n✓ Dry run complete (no changes made)")
 		} else {
@@ -781,7 +812,8 @@ func isGitRepo() bool {
 
 // gitHasUnmergedPaths checks for unmerged paths or merge in progress
 func gitHasUnmergedPaths() (bool, error) {
-	cmd := exec.Command("git", "status", "--porcelain")
+	repoRoot := getRepoRootForWorktree(rootCtx)
+	cmd := exec.Command("git", "-C", repoRoot, "status", "--porcelain")
 	out, err := cmd.Output()
 	if err != nil {
 		return false, fmt.Errorf("git status failed: %w", err)
@@ -798,7 +830,7 @@ func gitHasUnmergedPaths() (bool, error) {
 	}
 
 	// Check if MERGE_HEAD exists (merge in progress)
-	if exec.Command("git", "rev-parse", "-q", "--verify", "MERGE_HEAD").Run() == nil {
+	if exec.Command("git", "-C", repoRoot, "rev-parse", "-q", "--verify", "MERGE_HEAD").Run() == nil {
 		return true, nil
 	}
 
@@ -808,8 +840,10 @@ func gitHasUnmergedPaths() (bool, error) {
 // gitHasUpstream checks if the current branch has an upstream configured
 // Uses git config directly for compatibility with Git for Windows
 func gitHasUpstream() bool {
+	repoRoot := getRepoRootForWorktree(rootCtx)
+
 	// Get current branch name
-	branchCmd := exec.Command("git", "symbolic-ref", "--short", "HEAD")
+	branchCmd := exec.Command("git", "-C", repoRoot, "symbolic-ref", "--short", "HEAD")
 	branchOutput, err := branchCmd.Output()
 	if err != nil {
 		return false
@@ -817,8 +851,8 @@ func gitHasUpstream() bool {
 	branch := strings.TrimSpace(string(branchOutput))
 	
 	// Check if remote and merge refs are configured
-	remoteCmd := exec.Command("git", "config", "--get", fmt.Sprintf("branch.%s.remote", branch))
-	mergeCmd := exec.Command("git", "config", "--get", fmt.Sprintf("branch.%s.merge", branch))
+	remoteCmd := exec.Command("git", "-C", repoRoot, "config", "--get", fmt.Sprintf("branch.%s.remote", branch))
+	mergeCmd := exec.Command("git", "-C", repoRoot, "config", "--get", fmt.Sprintf("branch.%s.merge", branch))
 	
 	remoteErr := remoteCmd.Run()
 	mergeErr := mergeCmd.Run()
@@ -828,7 +862,12 @@ func gitHasUpstream() bool {
 
 // gitHasChanges checks if the specified file has uncommitted changes
 func gitHasChanges(ctx context.Context, filePath string) (bool, error) {
-	cmd := exec.CommandContext(ctx, "git", "status", "--porcelain", filePath)
+	repoRoot := getRepoRootForWorktree(ctx)
+	relPath, err := filepath.Rel(repoRoot, filePath)
+	if err != nil || !filepath.IsAbs(repoRoot) {
+		relPath = filePath
+	}
+	cmd := exec.CommandContext(ctx, "git", "-C", repoRoot, "status", "--porcelain", relPath)
 	output, err := cmd.Output()
 	if err != nil {
 		return false, fmt.Errorf("git status failed: %w", err)
@@ -836,12 +875,13 @@ func gitHasChanges(ctx context.Context, filePath string) (bool, error) {
 	return len(strings.TrimSpace(string(output))) > 0, nil
 }
 
-// getRepoRootForWorktree returns the main repository root for running git commands
-// This is always the main repository root, never the worktree root
-func getRepoRootForWorktree(_ context.Context) string {
-	repoRoot, err := git.GetMainRepoRoot()
+// getRepoRootForWorktree returns the repository root for running sync git commands
+// (see syncRepoRoot): the repository holding .beads, which for worktrees is the main
+// repository, never the worktree root, and for submodules the submodule
+func getRepoRootForWorktree(ctx context.Context) string {
+	repoRoot, err := syncRepoRoot(ctx)
 	if err != nil {
-		// Fallback to current directory if GetMainRepoRoot fails
+		// Fallback to current directory if syncRepoRoot fails
 		return "."
 	}
 	return repoRoot
@@ -919,7 +959,7 @@ func gitCommit(ctx context.Context, filePath string, message string) error {
 
 // gitCommitBeadsDir stages and commits only sync-related files in .beads/ (bd-red fix)
 // This ensures bd sync doesn't accidentally commit other staged files.
-// Only stages specific sync files (issues.jsonl, deletions.jsonl, metadata.json)
+// Only stages specific sync files (issues.jsonl or its shards, deletions.jsonl, metadata.json)
 // to avoid staging gitignored snapshot files that may be tracked. (bd-guc fix)
 // Worktree-aware: handles cases where .beads is in the main repo but we're running from a worktree.
 func gitCommitBeadsDir(ctx context.Context, message string) error {
@@ -943,6 +983,20 @@ func gitCommitBeadsDir(ctx context.Context, message string) error {
 		filepath.Join(beadsDir, "metadata.json"),
 	}
 
+	// With the sharded layout the shards are committed instead of issues.jsonl,
+	// which becomes an untracked local copy (export.layout)
+	jsonlPath := filepath.Join(beadsDir, "issues.jsonl")
+	sharded := config.GetString("export.layout") == export.LayoutSharded && export.HasShards(jsonlPath)
+	if sharded {
+		syncFiles[0] = export.ShardDir(jsonlPath)
+		if relPath, err := filepath.Rel(repoRoot, jsonlPath); err == nil {
+			rmCmd := exec.CommandContext(ctx, "git", "-C", repoRoot, "rm", "--cached", "--quiet", "--ignore-unmatch", relPath)
+			if output, err := rmCmd.CombinedOutput(); err != nil {
+				return fmt.Errorf("git rm --cached failed: %w\n%s", err, output)
+			}
+		}
+	}
+
 	// Only add files that exist
 	var filesToAdd []string
 	for _, f := range syncFiles {
@@ -992,7 +1046,7 @@ func gitCommitBeadsDir(ctx context.Context, message string) error {
 
 // hasGitRemote checks if a git remote exists in the repository
 func hasGitRemote(ctx context.Context) bool {
-	cmd := exec.CommandContext(ctx, "git", "remote")
+	cmd := exec.CommandContext(ctx, "git", "-C", getRepoRootForWorktree(ctx), "remote")
 	output, err := cmd.Output()
 	if err != nil {
 		return false
@@ -1002,8 +1056,8 @@ func hasGitRemote(ctx context.Context) bool {
 
 // isInRebase checks if we're currently in a git rebase state
 func isInRebase() bool {
-	// Get actual git directory (handles worktrees)
-	gitDir, err := git.GetGitDir()
+	// Get actual git directory (handles worktrees and submodules)
+	gitDir, err := syncGitDir(rootCtx, getRepoRootForWorktree(rootCtx))
 	if err != nil {
 		return false
 	}
@@ -1024,7 +1078,7 @@ func isInRebase() bool {
 // hasJSONLConflict checks if the beads JSONL file has a merge conflict
 // Returns true only if the JSONL file (issues.jsonl or beads.jsonl) is the only file in conflict
 func hasJSONLConflict() bool {
-	cmd := exec.Command("git", "status", "--porcelain")
+	cmd := exec.Command("git", "-C", getRepoRootForWorktree(rootCtx), "status", "--porcelain")
 	out, err := cmd.Output()
 	if err != nil {
 		return false
@@ -1059,7 +1113,7 @@ func hasJSONLConflict() bool {
 
 // runGitRebaseContinue continues a rebase after resolving conflicts
 func runGitRebaseContinue(ctx context.Context) error {
-	cmd := exec.CommandContext(ctx, "git", "rebase", "--continue")
+	cmd := exec.CommandContext(ctx, "git", "-C", getRepoRootForWorktree(ctx), "rebase", "--continue")
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return fmt.Errorf("git rebase --continue failed: %w\n%s", err, output)
@@ -1096,10 +1150,11 @@ func gitPull(ctx context.Context) error {
 	if !hasGitRemote(ctx) {
 		return nil // Gracefully skip - local-only mode
 	}
+	repoRoot := getRepoRootForWorktree(ctx)
 	
 	// Get current branch name
 	// Use symbolic-ref to work in fresh repos without commits (bd-flil)
-	branchCmd := exec.CommandContext(ctx, "git", "symbolic-ref", "--short", "HEAD")
+	branchCmd := exec.CommandContext(ctx, "git", "-C", repoRoot, "symbolic-ref", "--short", "HEAD")
 	branchOutput, err := branchCmd.Output()
 	if err != nil {
 		return fmt.Errorf("failed to get current branch: %w", err)
@@ -1107,7 +1162,7 @@ func gitPull(ctx context.Context) error {
 	branch := strings.TrimSpace(string(branchOutput))
 	
 	// Get remote name for current branch (usually "origin")
-	remoteCmd := exec.CommandContext(ctx, "git", "config", "--get", fmt.Sprintf("branch.%s.remote", branch))
+	remoteCmd := exec.CommandContext(ctx, "git", "-C", repoRoot, "config", "--get", fmt.Sprintf("branch.%s.remote", branch))
 	remoteOutput, err := remoteCmd.Output()
 	if err != nil {
 		// If no remote configured, default to "origin"
@@ -1116,7 +1171,7 @@ func gitPull(ctx context.Context) error {
 	remote := strings.TrimSpace(string(remoteOutput))
 	
 	// Pull with explicit remote and branch
-	cmd := exec.CommandContext(ctx, "git", "pull", remote, branch)
+	cmd := exec.CommandContext(ctx, "git", "-C", repoRoot, "pull", remote, branch)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return fmt.Errorf("git pull failed: %w\n%s", err, output)
@@ -1132,7 +1187,7 @@ func gitPush(ctx context.Context) error {
 		return nil // Gracefully skip - local-only mode
 	}
 
-	cmd := exec.CommandContext(ctx, "git", "push")
+	cmd := exec.CommandContext(ctx, "git", "-C", getRepoRootForWorktree(ctx), "push")
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return fmt.Errorf("git push failed: %w\n%s", err, output)
@@ -1151,7 +1206,7 @@ func restoreBeadsDirFromBranch(ctx context.Context) error {
 
 	// Restore .beads/ from HEAD (current branch's committed state)
 	// Using -- to ensure .beads/ is treated as a path, not a branch name
-	cmd := exec.CommandContext(ctx, "git", "checkout", "HEAD", "--", beadsDir)
+	cmd := exec.CommandContext(ctx, "git", "-C", getRepoRootForWorktree(ctx), "checkout", "HEAD", "--", beadsDir)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return fmt.Errorf("git checkout failed: %w\n%s", err, output)
@@ -1194,19 +1249,54 @@ func getDefaultBranchForRemote(ctx context.Context, remote string) string {
 	return "main"
 }
 
-// doSyncFromMain performs a one-way sync from the default branch (main/master)
-// Used for ephemeral branches without upstream tracking (gt-ick9)
-// This fetches beads from main and imports them, discarding local beads changes.
-// If sync.remote is configured (e.g., "upstream" for fork workflows), uses that remote
-// instead of "origin" (bd-bx9).
-func doSyncFromMain(ctx context.Context, jsonlPath string, renameOnImport bool, dryRun bool, noGitHistory bool) error {
-	// Determine which remote to use (default: origin, but can be configured via sync.remote)
+// syncRemote returns the remote --from-main syncs with: origin, unless
+// sync.remote names another (e.g. "upstream" for fork workflows)
+func syncRemote(ctx context.Context) string {
 	remote := "origin"
 	if err := ensureStoreActive(); err == nil && store != nil {
 		if configuredRemote, err := store.GetConfig(ctx, "sync.remote"); err == nil && configuredRemote != "" {
 			remote = configuredRemote
 		}
 	}
+	return remote
+}
+
+// auditedSyncFromMain runs doSyncFromMain with its changes worked out
+// first, shown for a dry run and recorded in the sync log
+func auditedSyncFromMain(ctx context.Context, jsonlPath string, renameOnImport bool, dryRun bool, noGitHistory bool) error {
+	remote := syncRemote(ctx)
+	remoteRef := remote + "/" + getDefaultBranchForRemote(ctx, remote)
+	plan, err := planGitSync(ctx, jsonlPath, remoteRef, true, true)
+	if err != nil {
+		if dryRun {
+			return fmt.Errorf("failed to work out changes: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Warning: failed to work out changes for the sync log: %v\n", err)
+		plan = &gitSyncPlan{Remote: remoteRef, Outbound: []sqlite.SyncChange{}, Inbound: []sqlite.SyncChange{}}
+	}
+	run := startSyncAudit(ctx, sqlite.SyncProviderGit, plan.Remote, dryRun, plan.changes())
+	if dryRun {
+		finishSyncAudit(ctx, run, nil, nil)
+		if jsonOutput {
+			outputJSON(gitSyncPlanJSON(plan, run))
+			return nil
+		}
+		printGitSyncPlan(plan)
+	}
+	err = doSyncFromMain(ctx, jsonlPath, renameOnImport, dryRun, noGitHistory)
+	if !dryRun {
+		finishSyncAudit(ctx, run, err, nil)
+	}
+	return err
+}
+
+// doSyncFromMain performs a one-way sync from the default branch (main/master)
+// Used for ephemeral branches without upstream tracking (gt-ick9)
+// This fetches beads from main and imports them, discarding local beads changes.
+// If sync.remote is configured (e.g., "upstream" for fork workflows), uses that remote
+// instead of "origin" (bd-bx9).
+func doSyncFromMain(ctx context.Context, jsonlPath string, renameOnImport bool, dryRun bool, noGitHistory bool) error {
+	remote := syncRemote(ctx)
 
 	if dryRun {
 		fmt.Println("→ [DRY RUN] Would sync beads from main branch")
@@ -1337,6 +1427,51 @@ func exportToJSONL(ctx context.Context, jsonlPath string) error {
 		issue.Comments = comments
 	}
 
+	// Populate attachments for all issues
+	for _, issue := range issues {
+		attachments, err := store.GetAttachments(ctx, issue.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get attachments for %s: %w", issue.ID, err)
+		}
+		issue.Attachments = attachments
+	}
+
+	// Populate work log for all issues
+	for _, issue := range issues {
+		workLog, err := store.GetWorkLog(ctx, issue.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get work log for %s: %w", issue.ID, err)
+		}
+		issue.WorkLog = workLog
+	}
+
+	// Populate aliases for all issues
+	issueIDs := make([]string, len(issues))
+	for i, issue := range issues {
+		issueIDs[i] = issue.ID
+	}
+	allAliases, err := store.GetAliasesForIssues(ctx, issueIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get aliases: %w", err)
+	}
+	for _, issue := range issues {
+		issue.Aliases = allAliases[issue.ID]
+	}
+	allTranslations, err := store.GetTranslationsForIssues(ctx, issueIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get translations: %w", err)
+	}
+	for _, issue := range issues {
+		issue.Translations = allTranslations[issue.ID]
+	}
+	allFields, err := store.GetFieldsForIssues(ctx, issueIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get fields: %w", err)
+	}
+	for _, issue := range issues {
+		issue.Fields = allFields[issue.ID]
+	}
+
 	// Create temp file for atomic write
 	dir := filepath.Dir(jsonlPath)
 	base := filepath.Base(jsonlPath)
@@ -1350,11 +1485,23 @@ func exportToJSONL(ctx context.Context, jsonlPath string) error {
 		_ = os.Remove(tempPath)
 	}()
 
-	// Write JSONL
+	// Write JSONL, redacting secrets that are stored in the DB
+	redactor, err := redact.FromConfig()
+	if err != nil {
+		return err
+	}
+	fieldCipher, err := encryption.FromConfig(dir)
+	if err != nil {
+		return err
+	}
 	encoder := json.NewEncoder(tempFile)
 	exportedIDs := make([]string, 0, len(issues))
 	for _, issue := range issues {
-		if err := encoder.Encode(issue); err != nil {
+		exported, err := fieldCipher.ForExport(redactor.ForExport(issue))
+		if err != nil {
+			return err
+		}
+		if err := encoder.Encode(exported); err != nil {
 			return fmt.Errorf("failed to encode issue %s: %w", issue.ID, err)
 		}
 		exportedIDs = append(exportedIDs, issue.ID)
@@ -1374,6 +1521,10 @@ func exportToJSONL(ctx context.Context, jsonlPath string) error {
 		fmt.Fprintf(os.Stderr, "Warning: failed to set file permissions: %v\n", err)
 	}
 
+	if err := syncJSONLShards(jsonlPath); err != nil {
+		return fmt.Errorf("failed to write JSONL shards: %w", err)
+	}
+
 	// Clear dirty flags for exported issues
 	if err := store.ClearDirtyIssuesByID(ctx, exportedIDs); err != nil {
 		// Non-fatal warning
@@ -1991,8 +2142,7 @@ func checkPrefixMismatch(ctx context.Context, jsonlPath string) (*PrefixMismatch
 	}
 	defer f.Close()
 
-	scanner := bufio.NewScanner(f)
-	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	scanner := util.NewJSONLScanner(f)
 
 	for scanner.Scan() {
 		line := scanner.Bytes()
@@ -2068,8 +2218,7 @@ func checkOrphanedChildrenInJSONL(jsonlPath string) (*OrphanedChildren, error) {
 	existingIDs := make(map[string]bool)
 	parentRefs := make(map[string]string) // child ID -> parent ID
 
-	scanner := bufio.NewScanner(f)
-	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	scanner := util.NewJSONLScanner(f)
 
 	for scanner.Scan() {
 		line := scanner.Bytes()
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// startSyncAudit records the start of a sync, with the changes it plans,
+// in the sync log. The log never stands in the way of a sync: without
+// SQLite storage, in read-only mode or on errors it is skipped (with a
+// warning for errors), and nil is returned.
+func startSyncAudit(ctx context.Context, provider, target string, dryRun bool, planned []sqlite.SyncChange) *sqlite.SyncRun {
+	sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+	if !ok || readonlyMode {
+		return nil
+	}
+	run := &sqlite.SyncRun{Provider: provider, Target: target, DryRun: dryRun, Actor: actor, Changes: planned}
+	if err := sqliteStore.StartSyncRun(ctx, run); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to write sync log: %v\n", err)
+		return nil
+	}
+	return run
+}
+
+// finishSyncAudit records the outcome of a run startSyncAudit started.
+// Non-nil changes replace the planned ones.
+func finishSyncAudit(ctx context.Context, run *sqlite.SyncRun, runErr error, changes []sqlite.SyncChange) {
+	sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+	if run == nil || !ok {
+		return
+	}
+	if err := sqliteStore.FinishSyncRun(ctx, run, runErr, changes); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to write sync log: %v\n", err)
+	}
+}
+
+var syncLogCmd = &cobra.Command{
+	Use:   "log [run-id]",
+	Short: "Show the sync audit log",
+	Long: `Show the audit log of syncs with git (bd sync), GitHub (bd github sync)
+and Jira (bd jira sync), newest first, dry runs included.
+
+Every sync records who ran it, against what, the issues it moved in each
+direction (inbound: into beads, outbound: to the remote) and how it ended.
+A run shown as incomplete started but never recorded its outcome, which
+usually means bd exited on an error.
+
+The log is append-only: the database refuses to change or delete its
+records, and each record carries a hash chained to the one before it.
+--verify checks the chain, to detect records altered outside bd.
+
+Give a run ID (or a prefix of one) to list all of that run's changes.
+
+Examples:
+  bd sync log
+  bd sync log --provider github --limit 5
+  bd sync log --issue bd-42          # Syncs that moved bd-42
+  bd sync log 3f9a                   # One run in full
+  bd sync log --verify`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		provider, _ := cmd.Flags().GetString("provider")
+		issueID, _ := cmd.Flags().GetString("issue")
+		limit, _ := cmd.Flags().GetInt("limit")
+		verify, _ := cmd.Flags().GetBool("verify")
+
+		if err := ensureDirectMode("sync log requires direct database access"); err != nil {
+			FatalError("%v", err)
+		}
+		sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+		if !ok {
+			FatalError("sync log requires SQLite storage")
+		}
+		ctx := rootCtx
+
+		if verify {
+			n, err := sqliteStore.VerifySyncLog(ctx)
+			if jsonOutput {
+				result := map[string]interface{}{"ok": err == nil, "records": n}
+				if err != nil {
+					result["error"] = err.Error()
+				}
+				outputJSON(result)
+				if err != nil {
+					os.Exit(1)
+				}
+				return
+			}
+			if err != nil {
+				FatalError("%v", err)
+			}
+			fmt.Printf("%s Sync log intact (%d records)\n", color.New(color.FgGreen).Sprint("✓"), n)
+			return
+		}
+
+		switch provider {
+		case "", sqlite.SyncProviderGit, sqlite.SyncProviderGitHub, sqlite.SyncProviderJira:
+		default:
+			FatalError("unknown provider %q (use git, github or jira)", provider)
+		}
+		filter := sqlite.SyncRunFilter{Provider: provider, Limit: limit}
+		if len(args) == 1 {
+			filter.RunID = args[0]
+			filter.Limit = 0
+		}
+		if issueID != "" {
+			id, err := utils.ResolvePartialID(ctx, store, issueID)
+			if err != nil {
+				// Issues deleted since can still be looked up by their ID
+				id = issueID
+			}
+			filter.IssueID = id
+		}
+		runs, err := sqliteStore.GetSyncRuns(ctx, filter)
+		if err != nil {
+			FatalError("%v", err)
+		}
+		if len(args) == 1 {
+			switch len(runs) {
+			case 0:
+				FatalError("no sync run %q", args[0])
+			case 1:
+			default:
+				FatalError("sync run ID %q is ambiguous (%d runs)", args[0], len(runs))
+			}
+		}
+
+		if jsonOutput {
+			if len(args) == 1 {
+				outputJSON(runs[0])
+				return
+			}
+			if runs == nil {
+				runs = []*sqlite.SyncRun{}
+			}
+			outputJSON(runs)
+			return
+		}
+		if len(runs) == 0 {
+			fmt.Println("No syncs recorded")
+			return
+		}
+		for _, run := range runs {
+			printSyncRun(run, len(args) == 1)
+		}
+	},
+}
+
+// printSyncRun prints a run's summary line and, with all, every change
+func printSyncRun(run *sqlite.SyncRun, all bool) {
+	green := color.New(color.FgGreen).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+
+	status := run.Status
+	switch run.Status {
+	case sqlite.SyncSucceeded:
+		status = green(status)
+	case sqlite.SyncFailed:
+		status = red(status)
+	default:
+		status = yellow(status)
+	}
+	kind := "sync"
+	if run.DryRun {
+		kind = "dry run"
+	}
+	inbound, outbound := 0, 0
+	for _, c := range run.Changes {
+		if c.Direction == sqlite.SyncInbound {
+			inbound++
+		} else {
+			outbound++
+		}
+	}
+	fmt.Printf("%s  %s  %-6s %-7s %s  %d in, %d out", run.ID[:8], displayTime(run.StartedAt), run.Provider, kind, status, inbound, outbound)
+	if run.Target != "" {
+		fmt.Printf("  %s", run.Target)
+	}
+	if run.Actor != "" {
+		fmt.Printf("  by %s", run.Actor)
+	}
+	fmt.Println()
+	if run.Error != "" {
+		fmt.Printf("          %s\n", run.Error)
+	}
+	if !all {
+		return
+	}
+	var in, out []sqlite.SyncChange
+	for _, c := range run.Changes {
+		if c.Direction == sqlite.SyncInbound {
+			in = append(in, c)
+		} else {
+			out = append(out, c)
+		}
+	}
+	printSyncChanges("Outbound", out)
+	printSyncChanges("Inbound", in)
+}
+
+// printSyncChanges lists one direction's changes
+func printSyncChanges(label string, changes []sqlite.SyncChange) {
+	if len(changes) == 0 {
+		fmt.Printf("  %s: none\n", label)
+		return
+	}
+	fmt.Printf("  %s (%d):\n", label, len(changes))
+	for _, c := range changes {
+		fmt.Printf("    %-8s %-12s", c.Action, c.IssueID)
+		if c.Remote != "" {
+			fmt.Printf(" %-8s", c.Remote)
+		}
+		fmt.Printf(" %s", truncateTitle(c.Title, 50))
+		if c.Detail != "" {
+			fmt.Printf(" (%s)", c.Detail)
+		}
+		fmt.Println()
+	}
+}
+
+func init() {
+	syncLogCmd.Flags().String("provider", "", "Only show syncs with this provider: git, github or jira")
+	syncLogCmd.Flags().String("issue", "", "Only show syncs that moved this issue")
+	syncLogCmd.Flags().Int("limit", 20, "Maximum number of runs to show (0 for all)")
+	syncLogCmd.Flags().Bool("verify", false, "Check that no record was altered or removed")
+	syncLogCmd.Flags().BoolVar(&jsonOutput, "json", false, "Output JSON format")
+	syncCmd.AddCommand(syncLogCmd)
+}
@@ -0,0 +1,325 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/encryption"
+	"github.com/steveyegge/beads/internal/redact"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/syncbranch"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// gitSyncPlan is what bd sync exchanges with the remote. Issues that
+// changed in the database since the last commit shared with the remote go
+// out; issues that changed on the remote since then come in.
+type gitSyncPlan struct {
+	Remote   string              `json:"remote,omitempty"` // the ref compared against; empty without an upstream
+	Outbound []sqlite.SyncChange `json:"outbound"`
+	Inbound  []sqlite.SyncChange `json:"inbound"`
+}
+
+// changes returns every change of the plan, outbound first
+func (p *gitSyncPlan) changes() []sqlite.SyncChange {
+	return append(slices.Clone(p.Outbound), p.Inbound...)
+}
+
+// planGitSync works out a sync's changes. remoteRef is the ref to sync with,
+// or empty for the upstream of the branch bd sync commits to; with fetch,
+// the remote is fetched first so the inbound side is current. oneWay plans
+// a sync that only takes the remote's issues (--from-main).
+func planGitSync(ctx context.Context, jsonlPath, remoteRef string, fetch, oneWay bool) (*gitSyncPlan, error) {
+	repoRoot, err := syncRepoRootFor(ctx, filepath.Dir(jsonlPath))
+	if err != nil {
+		return nil, fmt.Errorf("not in a git repository: %w", err)
+	}
+	relPath, err := repoRelativePath(repoRoot, jsonlPath)
+	if err != nil {
+		return nil, err
+	}
+
+	localRef := "HEAD"
+	if branch, _ := syncbranch.Get(ctx, store); branch != "" {
+		if _, err := gitOutput(ctx, repoRoot, "rev-parse", "--verify", "--quiet", "refs/heads/"+branch); err == nil {
+			localRef = branch
+		}
+		if remoteRef == "" {
+			if _, err := gitOutput(ctx, repoRoot, "rev-parse", "--verify", "--quiet", "refs/remotes/origin/"+branch); err == nil {
+				remoteRef = "origin/" + branch
+			}
+		}
+	}
+	if remoteRef == "" {
+		remoteRef, _ = gitOutput(ctx, repoRoot, "rev-parse", "--abbrev-ref", "--symbolic-full-name", localRef+"@{upstream}")
+	}
+	if remoteRef != "" && fetch {
+		remote, _, _ := strings.Cut(remoteRef, "/")
+		if _, err := gitOutput(ctx, repoRoot, "fetch", "--quiet", remote); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: git fetch %s failed, comparing with the last fetched state: %v\n", remote, err)
+		}
+	}
+
+	local, err := localSyncIssues(ctx)
+	if err != nil {
+		return nil, err
+	}
+	plan := &gitSyncPlan{Remote: remoteRef, Outbound: []sqlite.SyncChange{}, Inbound: []sqlite.SyncChange{}}
+	if oneWay {
+		remote, err := jsonlIssuesAt(ctx, repoRoot, remoteRef, relPath)
+		if err != nil {
+			return nil, err
+		}
+		// Importing leaves issues the remote doesn't have alone
+		for _, c := range diffSyncIssues(local, remote, sqlite.SyncInbound) {
+			if c.Action != "delete" || remote[c.IssueID] != nil {
+				plan.Inbound = append(plan.Inbound, c)
+			}
+		}
+		return plan, nil
+	}
+
+	// Both sides are compared with the last commit they share; without an
+	// upstream, outbound is what isn't committed yet
+	base := localRef
+	if remoteRef != "" {
+		if base, err = gitOutput(ctx, repoRoot, "merge-base", localRef, remoteRef); err != nil {
+			base = "" // unrelated histories: everything is new
+		}
+	}
+	baseIssues, err := jsonlIssuesAt(ctx, repoRoot, base, relPath)
+	if err != nil {
+		return nil, err
+	}
+	plan.Outbound = diffSyncIssues(baseIssues, local, sqlite.SyncOutbound)
+	if remoteRef != "" {
+		remote, err := jsonlIssuesAt(ctx, repoRoot, remoteRef, relPath)
+		if err != nil {
+			return nil, err
+		}
+		plan.Inbound = diffSyncIssues(baseIssues, remote, sqlite.SyncInbound)
+	}
+
+	// Issues changed on both sides are merged field by field
+	outbound := make(map[string]int)
+	for i, c := range plan.Outbound {
+		outbound[c.IssueID] = i
+	}
+	for i, c := range plan.Inbound {
+		if j, ok := outbound[c.IssueID]; ok {
+			plan.Inbound[i].Detail = joinDetail(c.Detail, "also changed locally")
+			plan.Outbound[j].Detail = joinDetail(plan.Outbound[j].Detail, "also changed on the remote")
+		}
+	}
+	return plan, nil
+}
+
+func gitOutput(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...)
+	out, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func repoRelativePath(repoRoot, path string) (string, error) {
+	root, err := filepath.EvalSymlinks(repoRoot)
+	if err != nil {
+		return "", err
+	}
+	dir, err := filepath.EvalSymlinks(filepath.Dir(path))
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(root, filepath.Join(dir, filepath.Base(path)))
+	if err != nil {
+		return "", err
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// localSyncIssues returns the database's issues as bd sync would export them
+func localSyncIssues(ctx context.Context) (map[string]*types.Issue, error) {
+	if err := ensureStoreActive(); err != nil {
+		return nil, err
+	}
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeTombstones: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issues: %w", err)
+	}
+	allDeps, err := store.GetAllDependencyRecords(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dependencies: %w", err)
+	}
+	redactor, err := redact.FromConfig()
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*types.Issue, len(issues))
+	for _, issue := range issues {
+		issue.Dependencies = allDeps[issue.ID]
+		if issue.Labels, err = store.GetLabels(ctx, issue.ID); err != nil {
+			return nil, fmt.Errorf("failed to get labels for %s: %w", issue.ID, err)
+		}
+		if issue.Comments, err = store.GetIssueComments(ctx, issue.ID); err != nil {
+			return nil, fmt.Errorf("failed to get comments for %s: %w", issue.ID, err)
+		}
+		byID[issue.ID] = redactor.ForExport(issue)
+	}
+	return byID, nil
+}
+
+// jsonlIssuesAt reads the JSONL file as of a commit; an empty ref or a
+// commit without the file has no issues
+func jsonlIssuesAt(ctx context.Context, repoRoot, ref, relPath string) (map[string]*types.Issue, error) {
+	issues := make(map[string]*types.Issue)
+	if ref == "" {
+		return issues, nil
+	}
+	data, err := gitOutput(ctx, repoRoot, "show", ref+":"+relPath)
+	if err != nil {
+		return issues, nil
+	}
+	cipher, _ := encryption.FromConfig(filepath.Join(repoRoot, filepath.Dir(relPath)))
+	scanner := bufio.NewScanner(bytes.NewReader([]byte(data)))
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var issue types.Issue
+		if err := json.Unmarshal(line, &issue); err != nil {
+			return nil, fmt.Errorf("%s at %s: %w", relPath, ref, err)
+		}
+		if cipher.HasKey() {
+			_ = cipher.DecryptIssue(&issue)
+		}
+		issues[issue.ID] = &issue
+	}
+	return issues, scanner.Err()
+}
+
+// diffSyncIssues lists how to get from the before issues to the after ones
+func diffSyncIssues(before, after map[string]*types.Issue, direction string) []sqlite.SyncChange {
+	changes := []sqlite.SyncChange{}
+	for id, issue := range after {
+		old := before[id]
+		switch {
+		case old == nil && !issue.IsTombstone():
+			changes = append(changes, sqlite.SyncChange{Direction: direction, Action: "create", IssueID: id, Title: issue.Title})
+		case old == nil:
+		case issue.IsTombstone() && !old.IsTombstone():
+			changes = append(changes, sqlite.SyncChange{Direction: direction, Action: "delete", IssueID: id, Title: old.Title})
+		default:
+			if fields := changedSyncFields(old, issue); len(fields) > 0 {
+				changes = append(changes, sqlite.SyncChange{Direction: direction, Action: "update", IssueID: id,
+					Title: issue.Title, Detail: strings.Join(fields, ", ")})
+			}
+		}
+	}
+	for id, old := range before {
+		if _, ok := after[id]; !ok && !old.IsTombstone() {
+			changes = append(changes, sqlite.SyncChange{Direction: direction, Action: "delete", IssueID: id, Title: old.Title})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].IssueID < changes[j].IssueID })
+	return changes
+}
+
+// changedSyncFields names the exported fields that differ between two
+// versions of an issue
+func changedSyncFields(a, b *types.Issue) []string {
+	var fields []string
+	for _, f := range []struct {
+		name string
+		same bool
+	}{
+		{"title", a.Title == b.Title},
+		{"description", a.Description == b.Description},
+		{"design", a.Design == b.Design},
+		{"acceptance", a.AcceptanceCriteria == b.AcceptanceCriteria},
+		{"notes", a.Notes == b.Notes},
+		{"status", a.Status == b.Status},
+		{"priority", a.Priority == b.Priority},
+		{"type", a.IssueType == b.IssueType},
+		{"assignee", a.Assignee == b.Assignee},
+		{"external_ref", externalRefOf(a) == externalRefOf(b)},
+		{"labels", slices.Equal(sortedCopy(a.Labels), sortedCopy(b.Labels))},
+		{"dependencies", slices.Equal(depKeys(a.Dependencies), depKeys(b.Dependencies))},
+		{"comments", len(a.Comments) == len(b.Comments)},
+	} {
+		if !f.same {
+			fields = append(fields, f.name)
+		}
+	}
+	// The content hash also covers fields without their own entry above
+	if len(fields) == 0 && a.ComputeContentHash() != b.ComputeContentHash() {
+		fields = append(fields, "other fields")
+	}
+	return fields
+}
+
+func externalRefOf(issue *types.Issue) string {
+	if issue.ExternalRef == nil {
+		return ""
+	}
+	return *issue.ExternalRef
+}
+
+func sortedCopy(values []string) []string {
+	out := slices.Clone(values)
+	sort.Strings(out)
+	return out
+}
+
+func depKeys(deps []*types.Dependency) []string {
+	keys := make([]string, 0, len(deps))
+	for _, dep := range deps {
+		keys = append(keys, string(dep.Type)+":"+dep.DependsOnID)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func joinDetail(detail, extra string) string {
+	if detail == "" {
+		return extra
+	}
+	return detail + "; " + extra
+}
+
+// printGitSyncPlan shows a dry run's changes
+func printGitSyncPlan(plan *gitSyncPlan) {
+	where := plan.Remote
+	if where == "" {
+		where = "the last commit (no upstream)"
+	}
+	fmt.Printf("→ [DRY RUN] Changes against %s:\n", where)
+	printSyncChanges("Outbound", plan.Outbound)
+	printSyncChanges("Inbound", plan.Inbound)
+}
+
+// gitSyncPlanJSON is a dry run's --json output
+func gitSyncPlanJSON(plan *gitSyncPlan, run *sqlite.SyncRun) map[string]interface{} {
+	result := map[string]interface{}{
+		"dry_run":  true,
+		"remote":   plan.Remote,
+		"outbound": plan.Outbound,
+		"inbound":  plan.Inbound,
+	}
+	if run != nil {
+		result["run_id"] = run.ID
+	}
+	return result
+}
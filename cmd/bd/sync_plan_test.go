@@ -0,0 +1,97 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestDiffSyncIssues(t *testing.T) {
+	issue := func(id, title string, priority int, labels ...string) *types.Issue {
+		return &types.Issue{ID: id, Title: title, Status: types.StatusOpen, Priority: priority,
+			IssueType: types.TypeTask, Labels: labels}
+	}
+	tombstone := issue("bd-4", "Gone", 2)
+	tombstone.Status = types.StatusTombstone
+	now := time.Now()
+	tombstone.DeletedAt = &now
+
+	before := map[string]*types.Issue{
+		"bd-1": issue("bd-1", "Same", 2, "a", "b"),
+		"bd-2": issue("bd-2", "Old title", 2),
+		"bd-3": issue("bd-3", "Removed", 2),
+		"bd-4": issue("bd-4", "Gone", 2),
+	}
+	after := map[string]*types.Issue{
+		"bd-1": issue("bd-1", "Same", 2, "b", "a"), // label order doesn't matter
+		"bd-2": issue("bd-2", "New title", 1),
+		"bd-4": tombstone,
+		"bd-5": issue("bd-5", "Added", 2),
+	}
+
+	changes := diffSyncIssues(before, after, sqlite.SyncOutbound)
+	want := []sqlite.SyncChange{
+		{Direction: sqlite.SyncOutbound, Action: "update", IssueID: "bd-2", Title: "New title", Detail: "title, priority"},
+		{Direction: sqlite.SyncOutbound, Action: "delete", IssueID: "bd-3", Title: "Removed"},
+		{Direction: sqlite.SyncOutbound, Action: "delete", IssueID: "bd-4", Title: "Gone"},
+		{Direction: sqlite.SyncOutbound, Action: "create", IssueID: "bd-5", Title: "Added"},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("got %d changes, want %d: %+v", len(changes), len(want), changes)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Errorf("change %d = %+v, want %+v", i, changes[i], want[i])
+		}
+	}
+
+	if changes := diffSyncIssues(after, after, sqlite.SyncInbound); len(changes) != 0 {
+		t.Errorf("identical sides gave %+v", changes)
+	}
+}
+
+func TestGitHubSyncChanges(t *testing.T) {
+	result := &GitHubSyncResult{Success: true, Actions: []GitHubSyncAction{
+		{Action: "push", IssueID: "bd-1", Number: 3, Title: "Pushed", Detail: "title"},
+		{Action: "create_remote", IssueID: "bd-2", Number: 4, Title: "New on GitHub"},
+		{Action: "create_local", IssueID: "bd-3", Number: 5, Title: "Imported"},
+		{Action: "conflict", IssueID: "bd-4", Number: 6, Title: "Both"},
+	}}
+	changes := githubSyncChanges(result)
+	want := []sqlite.SyncChange{
+		{Direction: sqlite.SyncOutbound, Action: "update", IssueID: "bd-1", Remote: "#3", Title: "Pushed", Detail: "title"},
+		{Direction: sqlite.SyncOutbound, Action: "create", IssueID: "bd-2", Remote: "#4", Title: "New on GitHub"},
+		{Direction: sqlite.SyncInbound, Action: "create", IssueID: "bd-3", Remote: "#5", Title: "Imported"},
+		{Direction: sqlite.SyncInbound, Action: "conflict", IssueID: "bd-4", Remote: "#6", Title: "Both"},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("got %d changes, want %d", len(changes), len(want))
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Errorf("change %d = %+v, want %+v", i, changes[i], want[i])
+		}
+	}
+
+	if err := githubSyncError(result, nil); err != nil {
+		t.Errorf("successful sync gave error %v", err)
+	}
+	result.Success, result.Warnings = false, []string{"push bd-1: boom"}
+	if err := githubSyncError(result, nil); err == nil || err.Error() != "push bd-1: boom" {
+		t.Errorf("failed sync gave error %v", err)
+	}
+}
+
+func TestJiraKeyOf(t *testing.T) {
+	for ref, want := range map[string]string{
+		"https://company.atlassian.net/browse/PROJ-7": "PROJ-7",
+		"https://github.com/org/repo/issues/1":        "",
+		"":                                            "",
+	} {
+		if got := jiraKeyOf(ref); got != want {
+			t.Errorf("jiraKeyOf(%q) = %q, want %q", ref, got, want)
+		}
+	}
+}
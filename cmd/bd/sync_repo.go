@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/git"
+)
+
+// syncBeadsDir returns the directory holding the database and JSONL that
+// sync commits
+func syncBeadsDir() string {
+	if dbPath != "" {
+		if info, err := os.Stat(filepath.Dir(dbPath)); err == nil && info.IsDir() {
+			return filepath.Dir(dbPath)
+		}
+	}
+	return findBeadsDir()
+}
+
+// syncRepoRoot returns the work tree of the repository sync commits to,
+// pulls into and pushes from: sync.git_dir when set, else the repository
+// that contains .beads. When .beads lives in a submodule or a repository
+// nested in another, that is the inner repository, whatever directory bd
+// runs from.
+func syncRepoRoot(ctx context.Context) (string, error) {
+	return syncRepoRootFor(ctx, syncBeadsDir())
+}
+
+// syncRepoRootFor is syncRepoRoot for the .beads directory (or JSONL
+// directory) dir
+func syncRepoRootFor(ctx context.Context, dir string) (string, error) {
+	if override := config.GetString("sync.git_dir"); override != "" {
+		return resolveSyncGitDir(ctx, override, dir)
+	}
+	if dir != "" {
+		if root, err := gitOutput(ctx, dir, "rev-parse", "--show-toplevel"); err == nil && root != "" {
+			return root, nil
+		}
+	}
+	return git.GetMainRepoRoot()
+}
+
+// resolveSyncGitDir turns a sync.git_dir value into a work tree. The value
+// may name the work tree or its git directory (e.g. a submodule's
+// .git/modules/<name>); a relative path is taken from the directory
+// containing .beads.
+func resolveSyncGitDir(ctx context.Context, value, beadsDir string) (string, error) {
+	path := value
+	if !filepath.IsAbs(path) && beadsDir != "" {
+		path = filepath.Join(filepath.Dir(beadsDir), path)
+	}
+	if inGitDir, err := gitOutput(ctx, path, "rev-parse", "--is-inside-git-dir"); err == nil && inGitDir == "true" {
+		root, err := git.WorkTreeForGitDir(path)
+		if err != nil {
+			return "", fmt.Errorf("sync.git_dir %s: %w", value, err)
+		}
+		return root, nil
+	}
+	root, err := gitOutput(ctx, path, "rev-parse", "--show-toplevel")
+	if err != nil || root == "" {
+		return "", fmt.Errorf("sync.git_dir %s is not a git repository", value)
+	}
+	return root, nil
+}
+
+// syncGitDir returns the git directory of the sync repository at repoRoot.
+// Run from inside that repository it is the current checkout's (a linked
+// worktree has its own); otherwise it is the repository's own.
+func syncGitDir(ctx context.Context, repoRoot string) (string, error) {
+	if cwdRoot, err := git.GetMainRepoRoot(); err == nil && sameDir(cwdRoot, repoRoot) {
+		return git.GetGitDir()
+	}
+	gitDir, err := gitOutput(ctx, repoRoot, "rev-parse", "--absolute-git-dir")
+	if err != nil {
+		return "", fmt.Errorf("not a git repository: %s", repoRoot)
+	}
+	return gitDir, nil
+}
+
+// describeSyncRepo says which repository the daemon syncs, noting when it
+// is a submodule: its commits advance the submodule, but the superproject's
+// pointer to it is left for the user to commit
+func describeSyncRepo(ctx context.Context) string {
+	root, err := syncRepoRoot(ctx)
+	if err != nil {
+		return ""
+	}
+	if super := git.GetSuperprojectRoot(root); super != "" {
+		return fmt.Sprintf("Syncing git repository %s (a submodule of %s; commit its new pointer in the superproject yourself)", root, super)
+	}
+	return fmt.Sprintf("Syncing git repository %s", root)
+}
+
+// sameDir reports whether two paths name the same directory
+func sameDir(a, b string) bool {
+	if ra, err := filepath.EvalSymlinks(a); err == nil {
+		a = ra
+	}
+	if rb, err := filepath.EvalSymlinks(b); err == nil {
+		b = rb
+	}
+	return filepath.Clean(a) == filepath.Clean(b)
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/config"
+)
+
+// gitInit creates a repository with one commit at dir
+func gitInit(t *testing.T, dir string) {
+	t.Helper()
+	for _, args := range [][]string{
+		{"init", "-q", dir},
+		{"-C", dir, "-c", "user.email=test@example.com", "-c", "user.name=Test", "commit", "-q", "--allow-empty", "-m", "init"},
+	} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+}
+
+func TestSyncRepoRootSubmodule(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	ctx := context.Background()
+	tmp := t.TempDir()
+	origin, parent := filepath.Join(tmp, "origin"), filepath.Join(tmp, "parent")
+	gitInit(t, origin)
+	gitInit(t, parent)
+	if out, err := exec.Command("git", "-C", parent, "-c", "protocol.file.allow=always", "submodule", "add", "-q", origin, "sub").CombinedOutput(); err != nil {
+		t.Fatalf("submodule add: %v\n%s", err, out)
+	}
+	sub := filepath.Join(parent, "sub")
+	beadsDir := filepath.Join(sub, ".beads")
+	if err := os.MkdirAll(beadsDir, 0750); err != nil {
+		t.Fatal(err)
+	}
+
+	check := func(name, got, want string) {
+		t.Helper()
+		got, _ = filepath.EvalSymlinks(got)
+		want, _ = filepath.EvalSymlinks(want)
+		if got != want {
+			t.Errorf("%s: sync repo = %s, want %s", name, got, want)
+		}
+	}
+
+	config.Set("sync.git_dir", "")
+	defer config.Set("sync.git_dir", "")
+	root, err := syncRepoRootFor(ctx, beadsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check(".beads in a submodule", root, sub)
+
+	// sync.git_dir takes a work tree or a git dir, relative to the project
+	for value, want := range map[string]string{
+		"..":                  parent,
+		"../.git/modules/sub": sub,
+		sub:                   sub,
+	} {
+		config.Set("sync.git_dir", value)
+		root, err := syncRepoRootFor(ctx, beadsDir)
+		if err != nil {
+			t.Errorf("sync.git_dir %s: %v", value, err)
+			continue
+		}
+		check("sync.git_dir "+value, root, want)
+	}
+
+	config.Set("sync.git_dir", filepath.Join(tmp, "missing"))
+	if _, err := syncRepoRootFor(ctx, beadsDir); err == nil {
+		t.Error("a sync.git_dir that isn't a repository should fail")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"time"
@@ -41,9 +42,28 @@ type InstantiateResult struct {
 var templateCmd = &cobra.Command{
 	Use:   "template",
 	Short: "Manage issue templates",
-	Long: `Manage Beads templates for creating issue hierarchies.
-
-Templates are epics with the "template" label. They can have child issues
+	Long: `Manage Beads templates for creating issues and issue hierarchies.
+
+Issue templates preset a kind of issue for bd create --template: a title
+prefix, description skeleton, labels, priority, type, and custom fields the
+issue must have. They are YAML files kept in .beads/templates:
+
+  title_prefix: "[Bug] "
+  type: bug
+  priority: 1
+  labels: [triage]
+  description: |
+    ## Steps to reproduce
+    ## Expected
+    ## Actual
+  fields:
+    severity: medium
+  required_fields: [component]
+
+  bd template add bug --file bug.yaml
+  bd create "Crash on save" --template bug --field component=editor
+
+Epic templates are epics with the "template" label. They can have child issues
 with {{variable}} placeholders that get substituted during instantiation.
 
 To create a template:
@@ -96,10 +116,21 @@ var templateListCmd = &cobra.Command{
 			return
 		}
 
+		var issueTemplates []*CreateTemplate
+		if beadsDir := findBeadsDir(); beadsDir != "" {
+			var err error
+			if issueTemplates, err = listIssueTemplates(beadsDir); err != nil {
+				fmt.Fprintf(os.Stderr, "Error loading issue templates: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
 		// Human-readable output
-		if len(beadsTemplates) == 0 {
+		if len(beadsTemplates) == 0 && len(issueTemplates) == 0 {
 			fmt.Println("No templates available.")
-			fmt.Println("\nTo create a template:")
+			fmt.Println("\nTo create an issue template:")
+			fmt.Println("  bd template add <name> --file <yaml>   (see bd template --help)")
+			fmt.Println("\nTo create an epic template:")
 			fmt.Println("  1. Create an epic with child issues")
 			fmt.Println("  2. Add the 'template' label: bd label add <epic-id> template")
 			fmt.Println("  3. Use {{variable}} placeholders in titles/descriptions")
@@ -109,6 +140,17 @@ var templateListCmd = &cobra.Command{
 		green := color.New(color.FgGreen).SprintFunc()
 		cyan := color.New(color.FgCyan).SprintFunc()
 
+		if len(issueTemplates) > 0 {
+			fmt.Printf("%s\n", green("Issue templates (for bd create --template):"))
+			for _, tmpl := range issueTemplates {
+				fmt.Printf("  %s: %s\n", cyan(tmpl.Name), tmpl.describe())
+			}
+			fmt.Println()
+		}
+		if len(beadsTemplates) == 0 {
+			return
+		}
+
 		fmt.Printf("%s\n", green("Templates (for bd template instantiate):"))
 		for _, tmpl := range beadsTemplates {
 			vars := extractVariables(tmpl.Title + " " + tmpl.Description)
@@ -315,12 +357,115 @@ Example:
 	},
 }
 
+var templateAddCmd = &cobra.Command{
+	Use:   "add <name> --file <yaml>",
+	Short: "Add or replace an issue template for bd create --template",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("template add")
+		name := args[0]
+		file, _ := cmd.Flags().GetString("file")
+		if file == "" {
+			FatalError("--file is required")
+		}
+		data, err := os.ReadFile(file) // #nosec G304 - user-provided template file
+		if err != nil {
+			FatalError("failed to read %s: %v", file, err)
+		}
+		tmpl, err := parseIssueTemplate(name, data)
+		if err != nil {
+			FatalError("%v", err)
+		}
+
+		// Field definitions are in the database, so only check them there;
+		// a template naming an undefined field would fail every create
+		if store != nil {
+			ctx := rootCtx
+			defs, err := storage.GetFieldDefs(ctx, store)
+			if err != nil {
+				FatalError("failed to load field definitions: %v", err)
+			}
+			for _, field := range tmpl.RequiredFields {
+				if defs[field] == nil {
+					FatalErrorWithHint(fmt.Sprintf("template %s requires unknown field %q", name, field),
+						fmt.Sprintf("define it first with bd field define %s --type ...", field))
+				}
+			}
+			if _, err := storage.NormalizeFields(ctx, store, tmpl.Fields, false); err != nil {
+				FatalError("template %s: %v", name, err)
+			}
+		}
+
+		beadsDir := findBeadsDir()
+		if beadsDir == "" {
+			FatalError("no .beads directory found")
+		}
+		path := issueTemplatePath(beadsDir, name)
+		_, statErr := os.Stat(path)
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			FatalError("failed to create templates directory: %v", err)
+		}
+		// Keep the file as written, comments and all
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			FatalError("failed to write template: %v", err)
+		}
+
+		if jsonOutput {
+			outputJSON(tmpl)
+			return
+		}
+		verb := "Added"
+		if statErr == nil {
+			verb = "Updated"
+		}
+		green := color.New(color.FgGreen).SprintFunc()
+		fmt.Printf("%s %s issue template %s\n", green("✓"), verb, name)
+		if summary := tmpl.describe(); summary != "" {
+			fmt.Printf("  %s\n", summary)
+		}
+		fmt.Printf("  Use it with: bd create \"<title>\" --template %s\n", name)
+	},
+}
+
+var templateRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Remove an issue template",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("template remove")
+		name := args[0]
+		if !issueTemplateNamePattern.MatchString(name) {
+			FatalError("invalid template name %q", name)
+		}
+		beadsDir := findBeadsDir()
+		if beadsDir == "" {
+			FatalError("no .beads directory found")
+		}
+		if err := os.Remove(issueTemplatePath(beadsDir, name)); err != nil {
+			if os.IsNotExist(err) {
+				FatalError("no issue template %q", name)
+			}
+			FatalError("failed to remove template: %v", err)
+		}
+
+		if jsonOutput {
+			outputJSON(map[string]string{"removed": name})
+			return
+		}
+		green := color.New(color.FgGreen).SprintFunc()
+		fmt.Printf("%s Removed issue template %s\n", green("✓"), name)
+	},
+}
+
 func init() {
+	templateAddCmd.Flags().StringP("file", "f", "", "YAML file defining the template")
 	templateInstantiateCmd.Flags().StringSlice("var", []string{}, "Variable substitution (key=value)")
 	templateInstantiateCmd.Flags().Bool("dry-run", false, "Preview what would be created")
 	templateInstantiateCmd.Flags().String("assignee", "", "Assign the root epic to this agent/user")
 
 	templateCmd.AddCommand(templateListCmd)
+	templateCmd.AddCommand(templateAddCmd)
+	templateCmd.AddCommand(templateRemoveCmd)
 	templateCmd.AddCommand(templateShowCmd)
 	templateCmd.AddCommand(templateInstantiateCmd)
 	rootCmd.AddCommand(templateCmd)
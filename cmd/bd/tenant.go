@@ -0,0 +1,181 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/hosting"
+)
+
+var tenantCmd = &cobra.Command{
+	Use:   "tenant",
+	Short: "Manage tenants for bd serve --multi-tenant",
+	Long: `Manage the tenants hosted by 'bd serve --multi-tenant'.
+
+Each tenant gets an isolated database under --data-dir and a bearer token.
+Tokens are shown once when created or rotated; only their hashes are stored.
+A running server picks up changes without a restart.`,
+}
+
+var tenantAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Create a tenant and print its token",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		dataDir := tenantDataDir(cmd)
+		prefix, _ := cmd.Flags().GetString("prefix")
+		quota := tenantQuotaFlags(cmd)
+
+		token, err := hosting.CreateTenant(rootCtx, dataDir, args[0], prefix, quota)
+		if err != nil {
+			FatalError("%v", err)
+		}
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"tenant": args[0], "token": token})
+			return
+		}
+		fmt.Printf("Created tenant %s\n", args[0])
+		fmt.Printf("Token (shown once): %s\n", token)
+	},
+}
+
+var tenantListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List tenants and their quotas",
+	Run: func(cmd *cobra.Command, args []string) {
+		dataDir := tenantDataDir(cmd)
+		reg, err := hosting.LoadRegistry(dataDir)
+		if err != nil {
+			FatalError("%v", err)
+		}
+
+		type tenantRow struct {
+			Name      string        `json:"name"`
+			Prefix    string        `json:"prefix"`
+			Quota     hosting.Quota `json:"quota"`
+			DBPath    string        `json:"db_path"`
+			CreatedAt string        `json:"created_at"`
+		}
+		rows := make([]tenantRow, 0, len(reg.Tenants))
+		for _, t := range reg.Tenants {
+			rows = append(rows, tenantRow{
+				Name:      t.Name,
+				Prefix:    t.Prefix,
+				Quota:     t.Quota,
+				DBPath:    hosting.TenantDBPath(dataDir, t.Name),
+				CreatedAt: t.CreatedAt.Format("2006-01-02"),
+			})
+		}
+		if jsonOutput {
+			outputJSON(rows)
+			return
+		}
+		if len(rows) == 0 {
+			fmt.Println("No tenants")
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "NAME\tPREFIX\tMAX ISSUES\tMAX DB BYTES\tCREATED")
+		for _, r := range rows {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", r.Name, r.Prefix,
+				quotaLimit(int64(r.Quota.MaxIssues)), quotaLimit(r.Quota.MaxDBBytes), r.CreatedAt)
+		}
+		_ = w.Flush()
+	},
+}
+
+var tenantRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Remove a tenant",
+	Long: `Remove a tenant from the registry. Its token stops working immediately.
+
+The tenant's database is kept on disk unless --purge is given.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		purge, _ := cmd.Flags().GetBool("purge")
+		if err := hosting.RemoveTenant(tenantDataDir(cmd), args[0], purge); err != nil {
+			FatalError("%v", err)
+		}
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"tenant": args[0], "removed": true, "purged": purge})
+			return
+		}
+		fmt.Printf("Removed tenant %s\n", args[0])
+	},
+}
+
+var tenantRotateCmd = &cobra.Command{
+	Use:   "rotate-token <name>",
+	Short: "Replace a tenant's token",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		token, err := hosting.RotateToken(tenantDataDir(cmd), args[0])
+		if err != nil {
+			FatalError("%v", err)
+		}
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"tenant": args[0], "token": token})
+			return
+		}
+		fmt.Printf("New token for %s (shown once): %s\n", args[0], token)
+	},
+}
+
+var tenantQuotaCmd = &cobra.Command{
+	Use:   "quota <name>",
+	Short: "Set a tenant's quotas (0 = unlimited)",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		quota := tenantQuotaFlags(cmd)
+		if err := hosting.SetQuota(tenantDataDir(cmd), args[0], quota); err != nil {
+			FatalError("%v", err)
+		}
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"tenant": args[0], "quota": quota})
+			return
+		}
+		fmt.Printf("Updated quota for %s: max issues %s, max db bytes %s\n", args[0],
+			quotaLimit(int64(quota.MaxIssues)), quotaLimit(quota.MaxDBBytes))
+	},
+}
+
+func tenantDataDir(cmd *cobra.Command) string {
+	dataDir, _ := cmd.Flags().GetString("data-dir")
+	if dataDir == "" {
+		FatalErrorWithHint("--data-dir is required", "pass the same directory used with 'bd serve --multi-tenant --data-dir'")
+	}
+	return dataDir
+}
+
+func tenantQuotaFlags(cmd *cobra.Command) hosting.Quota {
+	maxIssues, _ := cmd.Flags().GetInt("max-issues")
+	maxDBBytes, _ := cmd.Flags().GetInt64("max-db-bytes")
+	if maxIssues < 0 || maxDBBytes < 0 {
+		FatalError("quotas must be zero (unlimited) or positive")
+	}
+	return hosting.Quota{MaxIssues: maxIssues, MaxDBBytes: maxDBBytes}
+}
+
+func quotaLimit(n int64) string {
+	if n <= 0 {
+		return "unlimited"
+	}
+	return fmt.Sprintf("%d", n)
+}
+
+func init() {
+	tenantCmd.PersistentFlags().String("data-dir", "", "Directory holding tenant databases")
+
+	tenantAddCmd.Flags().String("prefix", "", "Issue prefix for the tenant (default: tenant name)")
+	for _, c := range []*cobra.Command{tenantAddCmd, tenantQuotaCmd} {
+		c.Flags().Int("max-issues", 0, "Maximum number of issues (0 = unlimited)")
+		c.Flags().Int64("max-db-bytes", 0, "Maximum database size in bytes (0 = unlimited)")
+	}
+	tenantRemoveCmd.Flags().Bool("purge", false, "Also delete the tenant's database")
+
+	tenantCmd.AddCommand(tenantAddCmd, tenantListCmd, tenantRemoveCmd, tenantRotateCmd, tenantQuotaCmd)
+	rootCmd.AddCommand(tenantCmd)
+}
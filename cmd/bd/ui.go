@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/hooks"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/tui"
+	"github.com/steveyegge/beads/internal/types"
+	"golang.org/x/term"
+)
+
+var uiCmd = &cobra.Command{
+	Use:   "ui",
+	Short: "Browse and triage issues in an interactive terminal UI",
+	Long: `Browse and triage issues in a full-screen terminal UI: a list of issues
+next to the selected issue's details or dependency tree, refreshed while
+agents work.
+
+Views (tab / shift+tab): active, ready, in progress, blocked, all.
+
+Keys:
+  j/k, arrows    move            /          filter by ID or title
+  enter, t       toggle the dependency tree
+  o / i / b      set open / in progress / blocked
+  x              close           c          claim (in progress, assigned to you)
+  0-4            set priority    + / -      raise / lower priority
+  r              refresh         ?          help
+  q              quit
+
+Edits behave like the matching bd commands: hooks run, close reasons and
+verified-close rules apply, and the JSONL is flushed. Edits are disabled
+with --readonly.
+
+Examples:
+  bd ui
+  bd ui --refresh 2s
+  bd ui --readonly`,
+	Run: func(cmd *cobra.Command, args []string) {
+		refresh, _ := cmd.Flags().GetDuration("refresh")
+
+		if !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stdout.Fd())) {
+			FatalErrorWithHint("bd ui needs an interactive terminal", "use bd list or bd ready --json from scripts")
+		}
+		if err := ensureDirectMode("ui requires direct database access"); err != nil {
+			FatalError("%v", err)
+		}
+		ctx := rootCtx
+		if err := ensureDatabaseFresh(ctx); err != nil {
+			FatalError("%v", err)
+		}
+
+		model := tui.New(ctx, store, uiActions{}, tui.Options{ReadOnly: readonlyMode, Refresh: refresh})
+		if _, err := tea.NewProgram(model, tea.WithAltScreen(), tea.WithContext(ctx)).Run(); err != nil {
+			FatalError("%v", err)
+		}
+	},
+}
+
+// uiActions makes bd ui's edits in direct mode
+type uiActions struct{}
+
+func (uiActions) SetStatus(ctx context.Context, id string, status types.Status) error {
+	return uiUpdate(ctx, id, map[string]interface{}{"status": string(status)})
+}
+
+func (uiActions) SetPriority(ctx context.Context, id string, priority int) error {
+	return uiUpdate(ctx, id, map[string]interface{}{"priority": priority})
+}
+
+// Claim assigns the issue to the current actor and starts it, unless someone
+// else already holds it
+func (uiActions) Claim(ctx context.Context, id string) error {
+	err := store.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		issue, err := tx.GetIssue(ctx, id)
+		if err != nil {
+			return err
+		}
+		if issue == nil {
+			return fmt.Errorf("issue %s not found", id)
+		}
+		if issue.Assignee != "" && issue.Assignee != actor {
+			return fmt.Errorf("%s is assigned to %s", id, issue.Assignee)
+		}
+		return tx.UpdateIssue(ctx, id, map[string]interface{}{
+			"status":   string(types.StatusInProgress),
+			"assignee": actor,
+		}, actor)
+	})
+	if err != nil {
+		return err
+	}
+	uiUpdated(ctx, id, hooks.EventUpdate)
+	return nil
+}
+
+func (uiActions) Close(ctx context.Context, id string) error {
+	reason, err := storage.ResolveCloseReason(ctx, store, "", "")
+	if err != nil {
+		return err
+	}
+	if reason == "" {
+		reason = "Closed"
+	}
+	if err := storage.CheckVerifiedClose(ctx, store, id, actor); err != nil {
+		return err
+	}
+	if err := storage.CheckDefinitionOfDone(ctx, store, id); err != nil {
+		return err
+	}
+	if err := store.CloseIssue(ctx, id, reason, actor); err != nil {
+		return err
+	}
+	uiUpdated(ctx, id, hooks.EventClose)
+	return nil
+}
+
+func uiUpdate(ctx context.Context, id string, updates map[string]interface{}) error {
+	if err := store.UpdateIssue(ctx, id, updates, actor); err != nil {
+		return err
+	}
+	uiUpdated(ctx, id, hooks.EventUpdate)
+	return nil
+}
+
+// uiUpdated runs the hook for an edit and schedules the JSONL flush
+func uiUpdated(ctx context.Context, id, event string) {
+	if hookRunner != nil {
+		if issue, _ := store.GetIssue(ctx, id); issue != nil {
+			hookRunner.Run(event, issue)
+		}
+	}
+	markDirtyAndScheduleFlush()
+}
+
+func init() {
+	uiCmd.Flags().Duration("refresh", 5*time.Second, "Reload the issue list this often (0 disables)")
+	rootCmd.AddCommand(uiCmd)
+}
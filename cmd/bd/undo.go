@@ -0,0 +1,349 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// cmdOperation is this invocation in the operation log behind bd undo. It
+// is set up by startOperation and sent to the daemon with every request.
+var cmdOperation rpc.OperationInfo
+
+// operationCommandMax bounds the command line recorded for an operation
+const operationCommandMax = 200
+
+// startOperation logs this invocation's writes as one operation, recorded
+// with its command line on one line (secrets redacted as in the command
+// journal)
+func startOperation() {
+	words := []string{"bd"}
+	for _, arg := range journalArgs(os.Args[1:]) {
+		words = append(words, shellQuote(arg))
+	}
+	command := truncateTitle(strings.Join(strings.Fields(strings.Join(words, " ")), " "), operationCommandMax)
+	cmdOperation = rpc.OperationInfo{Key: sqlite.NewOperationKey(), Actor: actor, Command: command}
+	rootCtx = sqlite.WithOperation(rootCtx, cmdOperation.Key, actor, command)
+}
+
+var undoCmd = &cobra.Command{
+	Use:   "undo [operation]",
+	Short: "Take back the changes of recent bd commands",
+	Long: `Revert what a bd command changed: an accidental close, a bulk edit, a bad
+import. Every change to issues, labels and dependencies is recorded in an
+append-only operations log, one operation per bd command (or per daemon
+request from other clients); list it with bd history.
+
+Without arguments the latest operation is undone; --last N undoes the
+latest N, newest first, or name one with its number from bd history.
+Updated issues get their earlier values back, deleted ones come back with
+their labels and dependencies, and issues the operation created become
+tombstones so the removal syncs to other clones. Comments, attachments and
+logged time are not part of the log.
+
+An undo is an operation itself: undo it to redo. bd undo refuses when a
+later command changed the same issues, since reverting would discard that
+change too; check with --dry-run and pass --force to undo anyway.
+
+Examples:
+  bd undo
+  bd undo --last 3 --dry-run
+  bd undo 42 --force`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		last, _ := cmd.Flags().GetInt("last")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		force, _ := cmd.Flags().GetBool("force")
+
+		if !dryRun {
+			CheckReadonly("undo")
+		}
+		if len(args) > 0 && cmd.Flags().Changed("last") {
+			FatalError("pass an operation or --last, not both")
+		}
+		if last < 1 {
+			FatalError("--last must be at least 1")
+		}
+		s := operationLogStore("undo")
+		ctx := rootCtx
+
+		var ids []int64
+		if len(args) > 0 {
+			id, err := parseOperationID(args[0])
+			if err != nil {
+				FatalError("%v", err)
+			}
+			ids = append(ids, id)
+		} else {
+			ops, err := s.GetUndoableOperations(ctx, last)
+			if err != nil {
+				FatalError("%v", err)
+			}
+			if len(ops) == 0 {
+				if jsonOutput {
+					outputJSON([]*sqlite.UndoResult{})
+					return
+				}
+				fmt.Println("Nothing to undo")
+				return
+			}
+			for _, op := range ops {
+				ids = append(ids, op.ID)
+			}
+		}
+
+		results, err := s.UndoOperations(ctx, ids, actor, force, dryRun)
+		if err != nil {
+			if errors.Is(err, sqlite.ErrConflict) && len(results) > 0 {
+				conflicted := results[len(results)-1]
+				fmt.Fprintf(os.Stderr, "Cannot undo #%d (%s); later commands changed the same issues:\n",
+					conflicted.Operation.ID, operationCommand(conflicted.Operation))
+				for _, c := range conflicted.Conflicts {
+					fmt.Fprintf(os.Stderr, "  %s\n", c)
+				}
+				FatalErrorWithHint("nothing was undone", "undo those commands first, or pass --force to discard their changes too")
+			}
+			FatalError("%v", err)
+		}
+		if !dryRun {
+			markDirtyAndScheduleFlush()
+		}
+
+		if jsonOutput {
+			outputJSON(results)
+			return
+		}
+		green := color.New(color.FgGreen).SprintFunc()
+		verb := green("✓") + " Undid"
+		if dryRun {
+			verb = "Would undo"
+		}
+		for _, r := range results {
+			fmt.Printf("%s #%d %s: %d change%s to %s\n", verb, r.Operation.ID, operationCommand(r.Operation),
+				r.Reverted, pluralize(r.Reverted), strings.Join(r.Issues, ", "))
+			if len(r.Conflicts) > 0 {
+				fmt.Printf("  discarding later changes: %s\n", strings.Join(r.Conflicts, "; "))
+			}
+		}
+	},
+}
+
+var historyCmd = &cobra.Command{
+	Use:   "history [issue-id]",
+	Short: "Show the operations log, or every change to one issue",
+	Long: `Show the operations log that bd undo works from: the latest bd commands
+that changed issues, labels or dependencies, newest first, with the issues
+each one touched and whether it has been undone.
+
+Given an issue, show every logged change to it, its labels and its
+dependencies, oldest first, field by field. Deleted issues keep their
+history.
+
+The log is pruned with the event history (bd prune-history,
+history.retention).
+
+Examples:
+  bd history
+  bd history --limit 50
+  bd history bd-42`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		limit, _ := cmd.Flags().GetInt("limit")
+		s := operationLogStore("history")
+		ctx := rootCtx
+
+		if len(args) == 0 {
+			ops, err := s.ListOperations(ctx, limit)
+			if err != nil {
+				FatalError("%v", err)
+			}
+			if jsonOutput {
+				if ops == nil {
+					ops = []*sqlite.Operation{}
+				}
+				outputJSON(ops)
+				return
+			}
+			if len(ops) == 0 {
+				fmt.Println("No operations logged yet")
+				return
+			}
+			for _, op := range ops {
+				printOperation(op)
+			}
+			return
+		}
+
+		// A deleted issue no longer resolves, but its history is still there
+		id := args[0]
+		if resolved, err := utils.ResolvePartialID(ctx, store, id); err == nil {
+			id = resolved
+		}
+		changes, err := s.GetIssueHistory(ctx, id)
+		if err != nil {
+			FatalError("%v", err)
+		}
+		if jsonOutput {
+			if changes == nil {
+				changes = []*sqlite.OperationChange{}
+			}
+			outputJSON(changes)
+			return
+		}
+		if len(changes) == 0 {
+			fmt.Printf("No logged changes to %s\n", id)
+			return
+		}
+		for i, c := range changes {
+			if i == 0 || !sameOperation(changes[i-1], c) {
+				printChangeHeader(c)
+			}
+			for _, line := range describeChange(c) {
+				fmt.Printf("    %s\n", line)
+			}
+		}
+	},
+}
+
+// operationLogStore returns the SQLite store bd undo and bd history read
+// the operations log from
+func operationLogStore(command string) *sqlite.SQLiteStorage {
+	if err := ensureDirectMode(command + " requires direct database access"); err != nil {
+		FatalError("%v", err)
+	}
+	s, ok := store.(*sqlite.SQLiteStorage)
+	if !ok {
+		FatalError("%s requires SQLite storage", command)
+	}
+	if err := ensureDatabaseFresh(rootCtx); err != nil {
+		FatalError("%v", err)
+	}
+	return s
+}
+
+// parseOperationID reads an operation number as bd history prints it
+func parseOperationID(s string) (int64, error) {
+	id, err := strconv.ParseInt(strings.TrimPrefix(s, "#"), 10, 64)
+	if err != nil || id < 1 {
+		return 0, fmt.Errorf("invalid operation %q: use a number from bd history", s)
+	}
+	return id, nil
+}
+
+// operationCommand is how an operation is named in output
+func operationCommand(op *sqlite.Operation) string {
+	if op.Command == "" {
+		return "unnamed write"
+	}
+	return op.Command
+}
+
+func printOperation(op *sqlite.Operation) {
+	gray := color.New(color.FgHiBlack).SprintFunc()
+	line := fmt.Sprintf("#%-5d %s  %-10s %s", op.ID, displayTime(op.CreatedAt), op.Actor, operationCommand(op))
+	issues := op.Issues
+	if len(issues) > 5 {
+		issues = append(issues[:5:5], fmt.Sprintf("+%d more", len(op.Issues)-5))
+	}
+	line += gray(fmt.Sprintf("  (%d change%s: %s)", op.Changes, pluralize(op.Changes), strings.Join(issues, ", ")))
+	if op.UndoneBy != nil {
+		line += fmt.Sprintf("  undone by #%d", *op.UndoneBy)
+	}
+	fmt.Println(line)
+}
+
+func printChangeHeader(c *sqlite.OperationChange) {
+	if c.OpID == nil {
+		fmt.Printf("%s  changed outside bd\n", displayTime(c.At))
+		return
+	}
+	line := fmt.Sprintf("#%d  %s  %s  %s", *c.OpID, displayTime(c.At), c.Actor, c.Command)
+	if c.UndoneBy != nil {
+		line += fmt.Sprintf("  (undone by #%d)", *c.UndoneBy)
+	}
+	fmt.Println(strings.TrimRight(line, " "))
+}
+
+func sameOperation(a, b *sqlite.OperationChange) bool {
+	return a.OpID != nil && b.OpID != nil && *a.OpID == *b.OpID
+}
+
+// describeChange renders a logged change for bd history, one line per
+// changed field
+func describeChange(c *sqlite.OperationChange) []string {
+	switch c.Kind {
+	case "label":
+		image := c.After
+		sign := "+"
+		if c.Action == "delete" {
+			image, sign = c.Before, "-"
+		}
+		return []string{fmt.Sprintf("%s label %v", sign, image["label"])}
+	case "dependency":
+		image := c.After
+		sign := "+"
+		if c.Action == "delete" {
+			image, sign = c.Before, "-"
+		} else if c.Action == "update" {
+			sign = "~"
+		}
+		return []string{fmt.Sprintf("%s depends on %v (%v)", sign, image["depends_on_id"], image["type"])}
+	}
+
+	switch c.Action {
+	case "insert":
+		return []string{fmt.Sprintf("created: %s", historyValue(c.After["title"]))}
+	case "delete":
+		return []string{"deleted"}
+	}
+	var fields []string
+	for field := range c.After {
+		if field == "updated_at" || field == "content_hash" {
+			continue
+		}
+		if fmt.Sprint(c.Before[field]) != fmt.Sprint(c.After[field]) {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+	lines := make([]string, len(fields))
+	for i, field := range fields {
+		lines[i] = fmt.Sprintf("%s: %s → %s", field, historyValue(c.Before[field]), historyValue(c.After[field]))
+	}
+	return lines
+}
+
+// historyValue renders one field value on a single line
+func historyValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "(none)"
+	case string:
+		if v == "" {
+			return `""`
+		}
+		return strconv.Quote(truncateTitle(strings.Join(strings.Fields(v), " "), 60))
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func init() {
+	undoCmd.Flags().Int("last", 1, "Undo the latest N operations")
+	undoCmd.Flags().Bool("dry-run", false, "Show what would be undone without changing anything")
+	undoCmd.Flags().Bool("force", false, "Undo even if later commands changed the same issues")
+	historyCmd.Flags().Int("limit", 20, "Number of operations to show")
+	rootCmd.AddCommand(undoCmd)
+	rootCmd.AddCommand(historyCmd)
+}
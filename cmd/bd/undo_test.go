@@ -0,0 +1,66 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+)
+
+func TestParseOperationID(t *testing.T) {
+	for input, want := range map[string]int64{"12": 12, "#12": 12} {
+		if got, err := parseOperationID(input); err != nil || got != want {
+			t.Errorf("parseOperationID(%q) = %d, %v; want %d", input, got, err, want)
+		}
+	}
+	for _, input := range []string{"", "#", "0", "-3", "bd-12"} {
+		if _, err := parseOperationID(input); err == nil {
+			t.Errorf("parseOperationID(%q) should fail", input)
+		}
+	}
+}
+
+func TestDescribeChange(t *testing.T) {
+	tests := []struct {
+		name   string
+		change *sqlite.OperationChange
+		want   []string
+	}{
+		{
+			name: "created",
+			change: &sqlite.OperationChange{Kind: "issue", Action: "insert",
+				After: map[string]interface{}{"title": "Fix  login\nnow"}},
+			want: []string{`created: "Fix login now"`},
+		},
+		{
+			name: "fields changed",
+			change: &sqlite.OperationChange{Kind: "issue", Action: "update",
+				Before: map[string]interface{}{"status": "open", "priority": float64(2), "closed_at": nil, "updated_at": "a", "content_hash": "x", "title": "Same"},
+				After:  map[string]interface{}{"status": "closed", "priority": float64(1), "closed_at": "2026-10-16", "updated_at": "b", "content_hash": "y", "title": "Same"}},
+			want: []string{`closed_at: (none) → "2026-10-16"`, "priority: 2 → 1", `status: "open" → "closed"`},
+		},
+		{
+			name:   "deleted",
+			change: &sqlite.OperationChange{Kind: "issue", Action: "delete", Before: map[string]interface{}{"title": "Gone"}},
+			want:   []string{"deleted"},
+		},
+		{
+			name:   "label removed",
+			change: &sqlite.OperationChange{Kind: "label", Action: "delete", Before: map[string]interface{}{"label": "urgent"}},
+			want:   []string{"- label urgent"},
+		},
+		{
+			name: "dependency added",
+			change: &sqlite.OperationChange{Kind: "dependency", Action: "insert",
+				After: map[string]interface{}{"depends_on_id": "bd-2", "type": "blocks"}},
+			want: []string{"+ depends on bd-2 (blocks)"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := describeChange(tt.change); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("describeChange = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,279 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/notify"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var watchIdentity string
+
+var watchCmd = &cobra.Command{
+	Use:   "watch [id...]",
+	Short: "Stream changes, or get notified about changes to issues or labels",
+	Long: `Without issue IDs or --watch-label, stream changes as they happen, one JSON
+object per line: issues created, updated, closed and reopened, and
+dependency, label and comment changes. Each line is the event (its id is the
+cursor for --since) with the issue as it is now. Narrow the stream with
+--filter key=value, where key is one of:
+  type    create, update, status, close, reopen, dep, comment, label
+          (or an event type such as dependency_added)
+  issue   an issue ID (also matches its children, e.g. bd-42.1)
+  actor   who made the change
+  label   a label the issue has
+Repeating a key matches any of its values; different keys must all match.
+--filter <name> streams only changes to issues that match a saved filter
+(see bd filter) as they are after the change.
+With a daemon the changes are pushed by it; without one, bd watch polls the
+database every second. Stop it with Ctrl-C.
+
+With issue IDs or --watch-label, watch issues, or every issue with a label,
+and get notified about any change made by someone else: status, fields,
+comments, labels, dependencies.
+
+Notifications go to the watcher's channels (config notify.<watcher>.channels):
+  mail      A bd mail message to you (default; see bd mail inbox)
+  webhook   A JSON POST to notify.<watcher>.webhook
+
+The daemon delivers notifications as changes happen. Without a daemon, run
+'bd watching --deliver' (e.g. from cron or an agent loop).
+
+The watcher is your bd mail identity (--identity, BEADS_IDENTITY, identity
+in config.yaml, or git user.name). Watches are local to this database and
+are not synced through git.
+
+Examples:
+  bd watch
+  bd watch --filter type=close --filter type=create
+  bd watch --filter label=frontend,type=dep --since 1200
+  bd watch --filter my-backlog --filter type=status
+  bd watch bd-42 bd-43
+  bd watch --watch-label frontend
+  bd config set notify.alice.channels mail,webhook
+  bd config set notify.alice.webhook https://hooks.example.com/alice
+  bd unwatch bd-42`,
+	Run: func(cmd *cobra.Command, args []string) {
+		labels, _ := cmd.Flags().GetStringSlice("watch-label")
+		streaming := cmd.Flags().Changed("filter") || cmd.Flags().Changed("since")
+		if len(args) == 0 && len(labels) == 0 {
+			runWatchStream(cmd)
+			return
+		}
+		if streaming {
+			FatalErrorWithHint("--filter and --since stream changes and can't be combined with issue IDs or --watch-label",
+				"bd watch --filter issue=bd-42")
+		}
+		runWatchChange(cmd, args, true)
+	},
+}
+
+var unwatchCmd = &cobra.Command{
+	Use:   "unwatch [id...]",
+	Short: "Stop watching issues or labels",
+	Run: func(cmd *cobra.Command, args []string) {
+		runWatchChange(cmd, args, false)
+	},
+}
+
+var watchingCmd = &cobra.Command{
+	Use:   "watching",
+	Short: "List the issues and labels you watch",
+	Long: `List your watches, or everyone's with --all.
+
+--deliver sends pending notifications once and exits; use it when no daemon
+is running.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		all, _ := cmd.Flags().GetBool("all")
+		deliver, _ := cmd.Flags().GetBool("deliver")
+
+		if err := ensureDirectMode("watching requires direct database access"); err != nil {
+			FatalError("%v", err)
+		}
+		sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+		if !ok {
+			FatalError("watching requires the SQLite backend")
+		}
+		ctx := rootCtx
+
+		if deliver {
+			CheckReadonly("watching --deliver")
+			sent, err := newWatchNotifier(ctx, sqliteStore).Deliver(ctx)
+			markDirtyAndScheduleFlush()
+			if jsonOutput {
+				result := map[string]interface{}{"sent": sent}
+				if err != nil {
+					result["error"] = err.Error()
+				}
+				outputJSON(result)
+				return
+			}
+			fmt.Printf("Sent %d notification(s)\n", sent)
+			if err != nil {
+				FatalError("%v", err)
+			}
+			return
+		}
+
+		watcher := config.GetIdentity(watchIdentity)
+		if all {
+			watcher = ""
+		}
+		watches, err := sqliteStore.GetWatches(ctx, watcher)
+		if err != nil {
+			FatalError("%v", err)
+		}
+		if jsonOutput {
+			if watches == nil {
+				watches = []*types.Watch{}
+			}
+			outputJSON(watches)
+			return
+		}
+		if len(watches) == 0 {
+			fmt.Println("Not watching anything (add a watch with bd watch <id> or bd watch --watch-label <label>)")
+			return
+		}
+
+		titles := make(map[string]string)
+		for _, w := range watches {
+			if w.IssueID == "" {
+				continue
+			}
+			if issue, err := store.GetIssue(ctx, w.IssueID); err == nil && issue != nil {
+				titles[w.IssueID] = issue.Title
+			}
+		}
+		cyan := color.New(color.FgCyan).SprintFunc()
+		for _, w := range watches {
+			prefix := "  "
+			if all {
+				prefix = fmt.Sprintf("  %-16s ", w.Watcher)
+			}
+			if w.Label != "" {
+				fmt.Printf("%slabel %s\n", prefix, cyan(w.Label))
+			} else {
+				fmt.Printf("%s%s %s\n", prefix, cyan(w.IssueID), truncateTitle(titles[w.IssueID], 60))
+			}
+		}
+	},
+}
+
+// runWatchChange adds or removes the watches named by args and --watch-label
+func runWatchChange(cmd *cobra.Command, args []string, add bool) {
+	labels, _ := cmd.Flags().GetStringSlice("watch-label")
+	name := "unwatch"
+	if add {
+		name = "watch"
+	}
+	if len(args) == 0 && len(labels) == 0 {
+		FatalErrorWithHint(name+" needs issue IDs or --watch-label", "bd "+name+" bd-42 or bd "+name+" --watch-label frontend")
+	}
+	CheckReadonly(name)
+	if err := ensureDirectMode(name + " requires direct database access"); err != nil {
+		FatalError("%v", err)
+	}
+	sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+	if !ok {
+		FatalError("%s requires the SQLite backend", name)
+	}
+	ctx := rootCtx
+	watcher := config.GetIdentity(watchIdentity)
+
+	var watches []*types.Watch
+	for _, arg := range args {
+		id, err := utils.ResolvePartialID(ctx, store, arg)
+		if err != nil {
+			if add {
+				FatalError("%v", err)
+			}
+			id = arg // an issue that is gone can still be unwatched
+		}
+		watches = append(watches, &types.Watch{Watcher: watcher, IssueID: id})
+	}
+	for _, label := range labels {
+		watches = append(watches, &types.Watch{Watcher: watcher, Label: strings.TrimSpace(label)})
+	}
+
+	var changed []string
+	for _, w := range watches {
+		target := w.IssueID
+		if w.Label != "" {
+			target = "label " + w.Label
+		}
+		if add {
+			if err := sqliteStore.AddWatch(ctx, w); err != nil {
+				FatalError("%v", err)
+			}
+			changed = append(changed, target)
+			continue
+		}
+		removed, err := sqliteStore.RemoveWatch(ctx, w)
+		if err != nil {
+			FatalError("%v", err)
+		}
+		if removed {
+			changed = append(changed, target)
+		} else if !jsonOutput {
+			fmt.Printf("Not watching %s\n", target)
+		}
+	}
+
+	if jsonOutput {
+		if changed == nil {
+			changed = []string{}
+		}
+		outputJSON(map[string]interface{}{"watcher": watcher, name + "ed": changed})
+		return
+	}
+	if len(changed) == 0 {
+		return
+	}
+	green := color.New(color.FgGreen).SprintFunc()
+	if add {
+		fmt.Printf("%s %s is watching %s\n", green("✓"), watcher, strings.Join(changed, ", "))
+	} else {
+		fmt.Printf("%s %s stopped watching %s\n", green("✓"), watcher, strings.Join(changed, ", "))
+	}
+}
+
+// newWatchNotifier wires a notify.Notifier to the store: channels come from
+// the notify.<watcher>.* config and mail notifications are stored as bd mail
+// messages
+func newWatchNotifier(ctx context.Context, s *sqlite.SQLiteStorage) *notify.Notifier {
+	createMessage := func(ctx context.Context, msg *types.Issue) error {
+		return s.CreateIssue(ctx, msg, notify.Sender)
+	}
+	return &notify.Notifier{
+		Source: s,
+		Channels: func(watcher string) ([]notify.Channel, error) {
+			cfg, err := s.GetAllConfig(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return notify.Channels(cfg, watcher, createMessage)
+		},
+	}
+}
+
+func init() {
+	for _, c := range []*cobra.Command{watchCmd, unwatchCmd} {
+		c.Flags().StringSlice("watch-label", nil, "Watch every issue with this label (repeatable)")
+		c.Flags().StringVar(&watchIdentity, "identity", "", "Watcher identity (default: your bd mail identity)")
+	}
+	watchCmd.Flags().StringSlice("filter", nil, "Stream only matching changes: type=, issue=, actor=, label= or a saved filter name (repeatable)")
+	watchCmd.Flags().Int64("since", 0, "Stream changes after this event ID, to resume an earlier stream")
+	watchingCmd.Flags().StringVar(&watchIdentity, "identity", "", "Watcher identity (default: your bd mail identity)")
+	watchingCmd.Flags().Bool("all", false, "List everyone's watches")
+	watchingCmd.Flags().Bool("deliver", false, "Send pending notifications once and exit")
+	rootCmd.AddCommand(watchCmd)
+	rootCmd.AddCommand(unwatchCmd)
+	rootCmd.AddCommand(watchingCmd)
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/events"
+	"github.com/steveyegge/beads/internal/eventstream"
+	"github.com/steveyegge/beads/internal/rpc"
+)
+
+// watchPollInterval is how often bd watch reads new events without a daemon
+const watchPollInterval = time.Second
+
+// runWatchStream prints events as JSON lines until interrupted, from the
+// daemon's subscription endpoint or, without a daemon, by polling the
+// events table
+func runWatchStream(cmd *cobra.Command) {
+	terms, _ := cmd.Flags().GetStringSlice("filter")
+	var savedNames []string
+	terms = slices.DeleteFunc(terms, func(term string) bool {
+		if term = strings.TrimSpace(term); term != "" && !strings.Contains(term, "=") {
+			savedNames = append(savedNames, term)
+			return true
+		}
+		return false
+	})
+	filter, err := eventstream.ParseFilter(terms)
+	if err != nil {
+		FatalErrorWithHint(err.Error(), "bd watch --filter type=close --filter label=frontend")
+	}
+	// A bare name is a saved filter the changed issue must match. Saved
+	// filters are read from the database, so the stream then polls it directly.
+	switch len(savedNames) {
+	case 0:
+	case 1:
+		filter.Query = loadSavedFilter("watch --filter", savedNames[0]).Query
+	default:
+		FatalError("only one saved filter can be watched at a time (got %s)", strings.Join(savedNames, ", "))
+	}
+	args := &rpc.SubscribeArgs{Filter: filter}
+	if cmd.Flags().Changed("since") {
+		since, _ := cmd.Flags().GetInt64("since")
+		args.Since = &since
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	emit := func(m *eventstream.Message) error {
+		return encoder.Encode(m)
+	}
+	ctx := rootCtx
+
+	if daemonClient != nil {
+		if err := daemonClient.Subscribe(ctx, args, emit); err != nil {
+			FatalError("%v", err)
+		}
+		return
+	}
+	if err := pollEventStream(ctx, args, emit); err != nil {
+		FatalError("%v", err)
+	}
+}
+
+// pollEventStream is the direct-mode stream: it reads new events every
+// watchPollInterval until ctx is cancelled
+func pollEventStream(ctx context.Context, args *rpc.SubscribeArgs, emit func(*eventstream.Message) error) error {
+	src, ok := store.(eventstream.Source)
+	if !ok {
+		return fmt.Errorf("bd watch requires the SQLite backend")
+	}
+	cursor, err := events.Start(ctx, src, args.Since)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(watchPollInterval)
+	defer ticker.Stop()
+	for {
+		messages, next, err := eventstream.Poll(ctx, src, cursor, args.Filter)
+		if err != nil && ctx.Err() == nil {
+			return err
+		}
+		cursor = next
+		for _, m := range messages {
+			if err := emit(m); err != nil {
+				return err
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/configfile"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// linkedWorkspaceRoot returns the root directory of the linked workspace
+// name, the part of a qualified ID (api/bd-42) before the slash: the path
+// set as workspaces.<name> in config.yaml, relative to this project's root,
+// or else the project registered under that name with 'bd project add'.
+func linkedWorkspaceRoot(name string) (string, error) {
+	// Viper lowercases map keys
+	if value, ok := config.GetStringMap("workspaces")[strings.ToLower(name)]; ok {
+		path, ok := value.(string)
+		if !ok || path == "" {
+			return "", fmt.Errorf("workspaces.%s in config.yaml must be a path", name)
+		}
+		if path == "~" || strings.HasPrefix(path, "~/") {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return "", fmt.Errorf("failed to get home directory: %w", err)
+			}
+			path = filepath.Join(home, strings.TrimPrefix(path, "~"))
+		}
+		if !filepath.IsAbs(path) {
+			beadsDir := findBeadsDir()
+			if beadsDir == "" {
+				return "", fmt.Errorf("workspace %q: no .beads directory to resolve %s against", name, path)
+			}
+			path = filepath.Join(filepath.Dir(beadsDir), path)
+		}
+		if _, err := os.Stat(filepath.Join(path, ".beads")); err != nil {
+			return "", fmt.Errorf("workspace %q (%s) has no .beads directory", name, path)
+		}
+		return path, nil
+	}
+
+	p, err := projectRegistry().Lookup(name)
+	if err != nil {
+		return "", err
+	}
+	if p == nil {
+		return "", fmt.Errorf("unknown workspace %q", name)
+	}
+	if _, err := os.Stat(filepath.Join(p.Path, ".beads")); err != nil {
+		return "", fmt.Errorf("workspace %q (%s) has no .beads directory", name, p.Path)
+	}
+	return p.Path, nil
+}
+
+// loadLinkedIssues reads the issues with the given local IDs from the
+// workspace at root: from its database, or from its JSONL when it has no
+// database yet (a fresh clone). Issues not found are left out.
+func loadLinkedIssues(ctx context.Context, root string, ids []string) (map[string]*types.Issue, error) {
+	beadsDir := filepath.Join(root, ".beads")
+	found := make(map[string]*types.Issue)
+
+	if path := projectDatabasePath(beadsDir); fileExists(path) {
+		s, err := sqlite.New(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = s.Close() }()
+		for _, id := range ids {
+			issue, err := s.GetIssue(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			if issue != nil {
+				found[id] = issue
+			}
+		}
+		return found, nil
+	}
+
+	jsonlPath := filepath.Join(beadsDir, "issues.jsonl")
+	if cfg, err := configfile.Load(beadsDir); err == nil && cfg != nil {
+		jsonlPath = cfg.JSONLPath(beadsDir)
+	}
+	issues, err := loadIssuesFromJSONL(jsonlPath)
+	if err != nil {
+		return nil, fmt.Errorf("no database, and %w", err)
+	}
+	wanted := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		wanted[id] = true
+	}
+	for _, issue := range issues {
+		if wanted[issue.ID] {
+			found[issue.ID] = issue
+		}
+	}
+	return found, nil
+}
+
+// checkLinkedTarget vets the target of 'bd dep add' that names an issue in a
+// linked workspace: the workspace must be linked, and a warning is printed
+// if the issue isn't there (yet), as it will block until it is found.
+func checkLinkedTarget(ctx context.Context, id string) {
+	workspace, localID, _ := types.ParseQualifiedID(id)
+	root, err := linkedWorkspaceRoot(workspace)
+	if err != nil {
+		FatalErrorWithHint(err.Error(),
+			fmt.Sprintf("link it under workspaces: in .beads/config.yaml (%s: <path>), or run 'bd project add <path> --name %s'", workspace, workspace))
+	}
+	issues, err := loadLinkedIssues(ctx, root, []string{localID})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: can't read workspace %q: %v\n", workspace, err)
+		return
+	}
+	if issues[localID] == nil {
+		fmt.Fprintf(os.Stderr, "Warning: %s not found in %s; it blocks until it is\n", id, root)
+	}
+}
+
+// refreshExternalIssues looks up every issue in a linked workspace that a
+// local dependency points at and records its status, which 'bd ready'
+// consults. An issue that can't be found (unknown workspace, missing issue)
+// keeps blocking; the returned error lists what couldn't be resolved.
+func refreshExternalIssues(ctx context.Context, store storage.Storage) error {
+	s, ok := store.(*sqlite.SQLiteStorage)
+	if !ok {
+		return nil
+	}
+	targets, err := s.ExternalDependencyTargets(ctx)
+	if err != nil {
+		return err
+	}
+
+	byWorkspace := make(map[string][]string)
+	for _, id := range targets {
+		workspace, localID, ok := types.ParseQualifiedID(id)
+		if ok {
+			byWorkspace[workspace] = append(byWorkspace[workspace], localID)
+		}
+	}
+	names := make([]string, 0, len(byWorkspace))
+	for name := range byWorkspace {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var found []*types.ExternalIssue
+	var problems []error
+	now := time.Now()
+	for _, name := range names {
+		root, err := linkedWorkspaceRoot(name)
+		if err != nil {
+			problems = append(problems, err)
+			continue
+		}
+		issues, err := loadLinkedIssues(ctx, root, byWorkspace[name])
+		if err != nil {
+			problems = append(problems, fmt.Errorf("workspace %q: %w", name, err))
+			continue
+		}
+		for _, localID := range byWorkspace[name] {
+			issue := issues[localID]
+			if issue == nil {
+				problems = append(problems, fmt.Errorf("%s/%s not found", name, localID))
+				continue
+			}
+			found = append(found, &types.ExternalIssue{
+				ID:        name + "/" + localID,
+				Status:    issue.Status,
+				Title:     issue.Title,
+				CheckedAt: now,
+			})
+		}
+	}
+
+	if err := s.SetExternalIssues(ctx, found); err != nil {
+		return err
+	}
+	return errors.Join(problems...)
+}
+
+// warnExternalIssues refreshes external blockers in direct mode before a
+// command that reports on blocking, warning about any it couldn't resolve.
+// The daemon refreshes them itself, and read-only mode keeps the last
+// known state.
+func warnExternalIssues(ctx context.Context) {
+	if daemonClient != nil || readonlyMode {
+		return
+	}
+	if err := refreshExternalIssues(ctx, store); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: cross-workspace dependencies: %v\n", strings.ReplaceAll(err.Error(), "\n", "; "))
+	}
+}
+
+// printExternalDependencies lists an issue's dependencies on issues in
+// linked workspaces for 'bd show'
+func printExternalDependencies(deps []*types.ExternalDependency) {
+	if len(deps) == 0 {
+		return
+	}
+	fmt.Printf("\nDepends on other workspaces (%d):\n", len(deps))
+	for _, dep := range deps {
+		blocking := ""
+		if dep.Blocking() {
+			blocking = " (blocking)"
+		}
+		if dep.Issue == nil {
+			fmt.Printf("  → %s: unresolved [%s]%s\n", dep.DependsOnID, dep.Type, blocking)
+			continue
+		}
+		fmt.Printf("  → %s: %s [%s, %s]%s\n", dep.DependsOnID, dep.Issue.Title, dep.Type, dep.Issue.Status, blocking)
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestRefreshExternalIssues(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv("HOME", t.TempDir()) // empty projects registry
+	root := t.TempDir()
+
+	apiStore := newTestStoreWithPrefix(t, filepath.Join(root, "api", ".beads", "beads.db"), "api")
+	endpoint := &types.Issue{Title: "New endpoint", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	if err := apiStore.CreateIssue(ctx, endpoint, "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	appStore := newTestStoreWithPrefix(t, filepath.Join(root, "app", ".beads", "beads.db"), "app")
+	work := &types.Issue{Title: "Use the endpoint", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	if err := appStore.CreateIssue(ctx, work, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := appStore.AddDependency(ctx, &types.Dependency{IssueID: work.ID, DependsOnID: "api/" + endpoint.ID, Type: types.DepBlocks}, "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	isReady := func() bool {
+		t.Helper()
+		issues, err := appStore.GetReadyWork(ctx, types.WorkFilter{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, issue := range issues {
+			if issue.ID == work.ID {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Not linked: the blocker can't be found and keeps blocking
+	if err := refreshExternalIssues(ctx, appStore); err == nil || !strings.Contains(err.Error(), `unknown workspace "api"`) {
+		t.Errorf("refresh without a link = %v", err)
+	}
+	if isReady() {
+		t.Errorf("an unresolved blocker should block")
+	}
+
+	config.Set("workspaces", map[string]interface{}{"api": filepath.Join(root, "api")})
+	defer config.Set("workspaces", map[string]interface{}{})
+	if got, err := linkedWorkspaceRoot("API"); err != nil || got != filepath.Join(root, "api") {
+		t.Errorf("linkedWorkspaceRoot(API) = %q, %v", got, err)
+	}
+
+	if err := refreshExternalIssues(ctx, appStore); err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	if isReady() {
+		t.Errorf("an open blocker in the linked workspace should block")
+	}
+
+	if err := apiStore.CloseIssue(ctx, endpoint.ID, "done", "test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := refreshExternalIssues(ctx, appStore); err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	if !isReady() {
+		t.Errorf("closing the blocker in its workspace should unblock")
+	}
+
+	// A workspace without a database is read from its JSONL
+	jsonlOnly := filepath.Join(root, "docs")
+	if err := os.MkdirAll(filepath.Join(jsonlOnly, ".beads"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	line := `{"id":"docs-1","title":"Write it up","status":"closed","priority":2,"issue_type":"task"}` + "\n"
+	if err := os.WriteFile(filepath.Join(jsonlOnly, ".beads", "issues.jsonl"), []byte(line), 0644); err != nil {
+		t.Fatal(err)
+	}
+	issues, err := loadLinkedIssues(ctx, jsonlOnly, []string{"docs-1", "docs-2"})
+	if err != nil || len(issues) != 1 || issues["docs-1"].Status != types.StatusClosed {
+		t.Errorf("loadLinkedIssues from JSONL = %v, %v", issues, err)
+	}
+}
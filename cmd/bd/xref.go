@@ -0,0 +1,271 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// XrefMatch is one issue found by bd xref --external
+type XrefMatch struct {
+	*sqlite.ExternalRef
+	Title string `json:"title"`
+}
+
+var xrefCmd = &cobra.Command{
+	Use:   "xref [issue-id]",
+	Short: "Show and manage links to issues in other trackers",
+	Long: `Show the issues in other trackers (GitHub, Jira, Linear, PagerDuty, ...)
+that a beads issue is linked to, or find the beads issue for an external ID.
+
+Links are recorded by bd github sync, bd jira sync and imports: an issue whose
+external_ref is a GitHub, Jira, Linear or PagerDuty URL, a Jira key, "gh-N"
+or a "system:remote-id" pair is linked automatically. --add records a link by
+hand and also sets external_ref when the issue has none, so the link travels
+with the JSONL to other clones.
+
+--external matches remote IDs case-insensitively. --system narrows the search
+to one system; "github" covers every "github:owner/repo". A URL is parsed into
+its system and ID.
+
+Examples:
+  bd xref bd-42
+  bd xref --external JIRA-123
+  bd xref --external 12 --system github
+  bd xref --external https://github.com/acme/app/issues/12
+  bd xref bd-42 --add pagerduty:Q1ABC2 --url https://acme.pagerduty.com/incidents/Q1ABC2
+  bd xref bd-42 --add https://linear.app/acme/issue/ENG-12
+  bd xref bd-42 --remove pagerduty`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		external, _ := cmd.Flags().GetString("external")
+		system, _ := cmd.Flags().GetString("system")
+		add, _ := cmd.Flags().GetString("add")
+		url, _ := cmd.Flags().GetString("url")
+		remove, _ := cmd.Flags().GetString("remove")
+
+		if external != "" && len(args) > 0 {
+			FatalError("give either an issue ID or --external, not both")
+		}
+		if external == "" && len(args) == 0 {
+			FatalErrorWithHint("an issue ID or --external is required", "bd xref bd-42 or bd xref --external JIRA-123")
+		}
+		if add != "" && remove != "" {
+			FatalError("cannot use both --add and --remove")
+		}
+		if (add != "" || remove != "") && len(args) == 0 {
+			FatalError("--add and --remove need an issue ID")
+		}
+		if err := ensureDirectMode("xref requires direct database access"); err != nil {
+			FatalError("%v", err)
+		}
+		sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+		if !ok {
+			FatalError("xref requires SQLite storage")
+		}
+		ctx := rootCtx
+		if err := ensureDatabaseFresh(ctx); err != nil {
+			FatalError("%v", err)
+		}
+
+		if external != "" {
+			runXrefFind(sqliteStore, external, system)
+			return
+		}
+		issueID, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			FatalError("%v", err)
+		}
+		switch {
+		case add != "":
+			CheckReadonly("xref")
+			runXrefAdd(sqliteStore, issueID, add, url)
+		case remove != "":
+			CheckReadonly("xref")
+			runXrefRemove(sqliteStore, issueID, remove)
+		default:
+			runXrefList(sqliteStore, issueID)
+		}
+	},
+}
+
+func runXrefList(s *sqlite.SQLiteStorage, issueID string) {
+	ctx := rootCtx
+	refs, err := s.GetExternalRefsForIssue(ctx, issueID)
+	if err != nil {
+		FatalError("%v", err)
+	}
+	// An external_ref recorded before the table existed, or imported with a
+	// strict conflict skipped, still counts
+	issue, err := store.GetIssue(ctx, issueID)
+	if err != nil {
+		FatalError("%v", err)
+	}
+	if issue != nil && issue.ExternalRef != nil {
+		if system, remoteID, ok := sqlite.ParseExternalRef(*issue.ExternalRef); ok && !hasExternalRef(refs, system) {
+			ref := &sqlite.ExternalRef{IssueID: issueID, System: system, RemoteID: remoteID}
+			if strings.HasPrefix(*issue.ExternalRef, "http") {
+				ref.URL = *issue.ExternalRef
+			}
+			refs = append(refs, ref)
+		}
+	}
+
+	if jsonOutput {
+		if refs == nil {
+			refs = []*sqlite.ExternalRef{}
+		}
+		outputJSON(refs)
+		return
+	}
+	if len(refs) == 0 {
+		if issue != nil && issue.ExternalRef != nil && *issue.ExternalRef != "" {
+			fmt.Printf("No cross-references for %s (external_ref: %s)\n", issueID, *issue.ExternalRef)
+		} else {
+			fmt.Printf("No cross-references for %s\n", issueID)
+		}
+		return
+	}
+	fmt.Printf("Cross-references for %s:\n", issueID)
+	for _, ref := range refs {
+		line := fmt.Sprintf("  %-24s %s", ref.System, ref.RemoteID)
+		if ref.URL != "" {
+			line += "  " + ref.URL
+		}
+		fmt.Println(line)
+	}
+}
+
+func runXrefFind(s *sqlite.SQLiteStorage, external, system string) {
+	ctx := rootCtx
+	remoteID := external
+	if parsedSystem, parsedID, ok := sqlite.ParseExternalRef(external); ok && parsedID != external {
+		remoteID = parsedID
+		if system == "" {
+			system = parsedSystem
+		}
+	}
+	refs, err := s.FindExternalRefs(ctx, remoteID, system)
+	if err != nil {
+		FatalError("%v", err)
+	}
+	// Fall back to the issues' own external_ref field
+	if len(refs) == 0 && system == "" {
+		issue, err := store.GetIssueByExternalRef(ctx, external)
+		if err != nil {
+			FatalError("%v", err)
+		}
+		if issue != nil {
+			refs = append(refs, &sqlite.ExternalRef{IssueID: issue.ID, System: "external_ref", RemoteID: external})
+		}
+	}
+
+	matches := make([]*XrefMatch, 0, len(refs))
+	for _, ref := range refs {
+		match := &XrefMatch{ExternalRef: ref}
+		if issue, err := store.GetIssue(ctx, ref.IssueID); err == nil && issue != nil {
+			match.Title = issue.Title
+		}
+		matches = append(matches, match)
+	}
+
+	if jsonOutput {
+		outputJSON(matches)
+		return
+	}
+	if len(matches) == 0 {
+		FatalError("no issue linked to %s", external)
+	}
+	for _, m := range matches {
+		fmt.Printf("%s  %s (%s %s)\n", m.IssueID, m.Title, m.System, m.RemoteID)
+	}
+}
+
+func runXrefAdd(s *sqlite.SQLiteStorage, issueID, value, url string) {
+	ctx := rootCtx
+	system, remoteID, ok := sqlite.ParseExternalRef(value)
+	if !ok {
+		FatalErrorWithHint(fmt.Sprintf("cannot tell the system of %q", value),
+			"use system:remote-id, e.g. --add jira:PROJ-123, or a tracker URL")
+	}
+	if url == "" && (strings.HasPrefix(value, "http:") || strings.HasPrefix(value, "https:")) {
+		url = value
+	}
+
+	existing, err := s.FindExternalRefs(ctx, remoteID, system)
+	if err != nil {
+		FatalError("%v", err)
+	}
+	for _, ref := range existing {
+		if ref.System == system && ref.IssueID != issueID {
+			FatalError("%s %s is already linked to %s", system, remoteID, ref.IssueID)
+		}
+	}
+	ref := &sqlite.ExternalRef{IssueID: issueID, System: system, RemoteID: remoteID, URL: url}
+	if err := s.LinkExternalRef(ctx, ref); err != nil {
+		FatalError("%v", err)
+	}
+
+	// Keep the link in the issue itself when it has no external_ref yet, so
+	// imports on other clones recreate it
+	issue, err := store.GetIssue(ctx, issueID)
+	if err != nil {
+		FatalError("%v", err)
+	}
+	if issue != nil && (issue.ExternalRef == nil || *issue.ExternalRef == "") {
+		externalRef := url
+		if externalRef == "" {
+			externalRef = system + ":" + remoteID
+		}
+		if err := store.UpdateIssue(ctx, issueID, map[string]interface{}{"external_ref": externalRef}, actor); err != nil {
+			FatalError("%v", err)
+		}
+		markDirtyAndScheduleFlush()
+	}
+
+	if jsonOutput {
+		outputJSON(ref)
+		return
+	}
+	green := color.New(color.FgGreen).SprintFunc()
+	fmt.Printf("%s Linked %s to %s %s\n", green("✓"), issueID, system, remoteID)
+}
+
+func runXrefRemove(s *sqlite.SQLiteStorage, issueID, system string) {
+	removed, err := s.DeleteExternalRef(rootCtx, issueID, system)
+	if err != nil {
+		FatalError("%v", err)
+	}
+	if !removed {
+		FatalError("%s has no %s cross-reference", issueID, system)
+	}
+
+	if jsonOutput {
+		outputJSON(map[string]interface{}{"issue_id": issueID, "system": system, "removed": true})
+		return
+	}
+	green := color.New(color.FgGreen).SprintFunc()
+	fmt.Printf("%s Removed the %s cross-reference from %s\n", green("✓"), system, issueID)
+}
+
+func hasExternalRef(refs []*sqlite.ExternalRef, system string) bool {
+	for _, ref := range refs {
+		if ref.System == system {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	xrefCmd.Flags().String("external", "", "Find the issue linked to this external ID or URL")
+	xrefCmd.Flags().String("system", "", "Limit --external to one system (e.g. jira, github, github:owner/repo)")
+	xrefCmd.Flags().String("add", "", "Link the issue: system:remote-id or a tracker URL")
+	xrefCmd.Flags().String("url", "", "URL of the remote issue, with --add")
+	xrefCmd.Flags().String("remove", "", "Remove the issue's link to this system")
+	rootCmd.AddCommand(xrefCmd)
+}
@@ -420,13 +420,13 @@ class BeadsToJira:
         result = self._make_request("POST", "issue", {"fields": fields})
         return result.get("key")
 
-    def update_issue(self, jira_key: str, bd_issue: Dict) -> bool:
-        """Update an existing Jira issue. Returns True if updated."""
+    def update_issue(self, jira_key: str, bd_issue: Dict) -> List[str]:
+        """Update an existing Jira issue. Returns the Jira fields that changed."""
         # First, get current issue to compare
         try:
             current = self._make_request("GET", f"issue/{jira_key}")
         except RuntimeError:
-            return False
+            return []
 
         current_fields = current.get("fields", {})
         updates = {}
@@ -453,36 +453,38 @@ class BeadsToJira:
         if current_labels != new_labels:
             updates["labels"] = list(new_labels)
 
+        # Status changes through a transition rather than a field update
+        current_status = current_fields.get("status", {}).get("name", "").lower()
+        target_status = bd_issue.get("status", "open")
+        target_jira_status = self.status_map.get(target_status, "To Do").lower()
+        changed = list(updates.keys())
+        if current_status != target_jira_status:
+            changed.append("status")
+
         if self.dry_run:
             if updates:
                 print(f"[DRY RUN] Would update {jira_key}: {list(updates.keys())}", file=sys.stderr)
-            return bool(updates)
+            if current_status != target_jira_status:
+                print(f"[DRY RUN] Would transition {jira_key} to {target_jira_status}", file=sys.stderr)
+            return changed
 
         # Apply field updates
         if updates:
             self._make_request("PUT", f"issue/{jira_key}", {"fields": updates})
 
-        # Handle status transition separately
-        current_status = current_fields.get("status", {}).get("name", "").lower()
-        target_status = bd_issue.get("status", "open")
-        target_jira_status = self.status_map.get(target_status, "To Do").lower()
-
         if current_status != target_jira_status:
             transition_id = self.find_transition(jira_key, target_status)
             if transition_id:
-                if self.dry_run:
-                    print(f"[DRY RUN] Would transition {jira_key} to {target_jira_status}", file=sys.stderr)
-                else:
-                    try:
-                        self._make_request(
-                            "POST",
-                            f"issue/{jira_key}/transitions",
-                            {"transition": {"id": transition_id}}
-                        )
-                    except RuntimeError as e:
-                        print(f"Warning: Could not transition {jira_key}: {e}", file=sys.stderr)
+                try:
+                    self._make_request(
+                        "POST",
+                        f"issue/{jira_key}/transitions",
+                        {"transition": {"id": transition_id}}
+                    )
+                except RuntimeError as e:
+                    print(f"Warning: Could not transition {jira_key}: {e}", file=sys.stderr)
 
-        return bool(updates) or current_status != target_jira_status
+        return changed
 
     def process_issue(self, bd_issue: Dict) -> None:
         """Process a single bd issue."""
@@ -502,8 +504,13 @@ class BeadsToJira:
                     return
 
                 # Update existing issue
-                if self.update_issue(jira_key, bd_issue):
+                changed = self.update_issue(jira_key, bd_issue)
+                if changed:
                     self.updated.append((bd_id, jira_key))
+                    print(
+                        json.dumps({"bd_id": bd_id, "jira_key": jira_key, "action": "update", "fields": changed}),
+                        file=sys.stdout
+                    )
                 else:
                     self.skipped.append((bd_id, f"No changes for {jira_key}"))
             else:
@@ -512,13 +519,13 @@ class BeadsToJira:
                 if new_key:
                     self.created.append((bd_id, new_key))
 
-                    # Output the mapping for updating external_ref
+                    # Output the mapping for updating external_ref (dry runs
+                    # have no key yet)
+                    mapping = {"bd_id": bd_id, "action": "create"}
                     if not self.dry_run:
-                        new_ref = f"{self.jira_url}/browse/{new_key}"
-                        print(
-                            json.dumps({"bd_id": bd_id, "jira_key": new_key, "external_ref": new_ref}),
-                            file=sys.stdout
-                        )
+                        mapping["jira_key"] = new_key
+                        mapping["external_ref"] = f"{self.jira_url}/browse/{new_key}"
+                    print(json.dumps(mapping), file=sys.stdout)
 
         except RuntimeError as e:
             self.errors.append((bd_id, str(e)))
@@ -16,6 +16,7 @@ from .models import (
     CreateIssueParams,
     InitParams,
     Issue,
+    IssuePage,
     ListIssuesParams,
     ReadyWorkParams,
     ReopenIssueParams,
@@ -85,6 +86,11 @@ class BdClientBase(ABC):
         """List issues with optional filters."""
         pass
 
+    @abstractmethod
+    async def list_issues_page(self, params: ListIssuesParams) -> IssuePage:
+        """List one page of issues, resuming after params.cursor."""
+        pass
+
     @abstractmethod
     async def show(self, params: ShowIssueParams) -> Issue:
         """Show detailed issue information."""
@@ -421,6 +427,37 @@ class BdCliClient(BdClientBase):
 
         return [Issue.model_validate(issue) for issue in data]
 
+    async def list_issues_page(self, params: ListIssuesParams) -> IssuePage:
+        """List one page of issues with stable cursor ordering.
+
+        Args:
+            params: Query parameters; cursor is the previous page's next_cursor
+
+        Returns:
+            The page and the cursor for the next one (None on the last page)
+        """
+        args = ["list", "--page-size", str(params.page_size or params.limit)]
+
+        if params.cursor:
+            args.extend(["--cursor", params.cursor])
+        if params.status:
+            args.extend(["--status", params.status])
+        if params.priority is not None:
+            args.extend(["--priority", str(params.priority)])
+        if params.issue_type:
+            args.extend(["--type", params.issue_type])
+        if params.assignee:
+            args.extend(["--assignee", params.assignee])
+
+        data = await self._run_command(*args)
+        if not isinstance(data, dict):
+            return IssuePage(issues=[])
+
+        return IssuePage(
+            issues=[Issue.model_validate(issue) for issue in data.get("issues") or []],
+            next_cursor=data.get("next_cursor") or None,
+        )
+
     async def show(self, params: ShowIssueParams) -> Issue:
         """Show issue details.
 
@@ -15,6 +15,7 @@ from .models import (
     CreateIssueParams,
     InitParams,
     Issue,
+    IssuePage,
     ListIssuesParams,
     ReadyWorkParams,
     ReopenIssueParams,
@@ -386,6 +387,36 @@ class BdDaemonClient(BdClientBase):
             return []
         return [Issue(**issue) for issue in issues_data]
 
+    async def list_issues_page(self, params: ListIssuesParams) -> IssuePage:
+        """List one page of issues with stable cursor ordering.
+
+        Args:
+            params: List filter parameters; cursor is the previous page's next_cursor
+
+        Returns:
+            The page and the cursor for the next one (None on the last page)
+        """
+        args: Dict[str, Any] = {"page_size": params.page_size or params.limit}
+        if params.cursor:
+            args["cursor"] = params.cursor
+        if params.status:
+            args["status"] = params.status
+        if params.priority is not None:
+            args["priority"] = params.priority
+        if params.issue_type:
+            args["issue_type"] = params.issue_type
+        if params.assignee:
+            args["assignee"] = params.assignee
+
+        data = await self._send_request("list", args)
+        page_data = json.loads(data) if isinstance(data, str) else data
+        if not page_data:
+            return IssuePage(issues=[])
+        return IssuePage(
+            issues=[Issue(**issue) for issue in page_data.get("issues") or []],
+            next_cursor=page_data.get("next_cursor") or None,
+        )
+
     async def show(self, params: ShowIssueParams) -> Issue:
         """Show detailed issue information.
 
@@ -54,6 +54,16 @@ class CompactedResult(BaseModel):
     hint: str = "Use show(issue_id) for full issue details"
 
 
+class PaginatedResult(BaseModel):
+    """One page of a cursor-paginated list() call.
+
+    Pass next_cursor back as cursor to fetch the following page; it is None
+    on the last page.
+    """
+    issues: list[IssueMinimal]
+    next_cursor: str | None = None
+
+
 # =============================================================================
 # ORIGINAL MODELS (unchanged for backward compatibility)
 # =============================================================================
@@ -178,6 +188,15 @@ class ListIssuesParams(BaseModel):
     issue_type: IssueType | None = None
     assignee: str | None = None
     limit: int = Field(default=20, ge=1, le=100)  # Reduced to avoid MCP buffer overflow
+    cursor: str | None = None  # next_cursor from a previous page
+    page_size: int | None = Field(default=None, ge=1, le=1000)
+
+
+class IssuePage(BaseModel):
+    """One page of issues from cursor-paginated listing."""
+
+    issues: list[Issue]
+    next_cursor: str | None = None
 
 
 class ShowIssueParams(BaseModel):
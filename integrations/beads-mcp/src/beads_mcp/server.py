@@ -32,6 +32,7 @@ from beads_mcp.models import (
     IssueMinimal,
     IssueStatus, 
     IssueType, 
+    PaginatedResult,
     Stats,
 )
 from beads_mcp.tools import (
@@ -44,6 +45,7 @@ from beads_mcp.tools import (
     beads_init,
     beads_inspect_migration,
     beads_list_issues,
+    beads_list_issues_page,
     beads_quickstart,
     beads_ready_work,
     beads_repair_deps,
@@ -378,9 +380,11 @@ async def get_tool_info(tool_name: str) -> dict[str, Any]:
                 "issue_type": "bug|feature|task|epic|chore (optional)",
                 "assignee": "str (optional)",
                 "limit": "int (1-100, default 20)",
+                "page_size": "int 1-1000 (optional, returns one page plus next_cursor)",
+                "cursor": "str (optional, next_cursor from the previous page)",
                 "workspace_root": "str (optional)"
             },
-            "returns": "List of issues (compacted if >20 results)",
+            "returns": "List of issues (compacted if >20 results), or {issues, next_cursor} when paginating",
             "example": "list(status='open', priority=1, limit=10)"
         },
         "show": {
@@ -638,7 +642,8 @@ async def ready_work(
 
 @mcp.tool(
     name="list",
-    description="List all issues with optional filters (status, priority, type, assignee). Returns minimal format for context efficiency.",
+    description="""List all issues with optional filters (status, priority, type, assignee). Returns minimal format for context efficiency.
+Pass page_size (and then cursor=next_cursor) to walk large result sets page by page.""",
 )
 @with_workspace
 async def list_issues(
@@ -647,15 +652,32 @@ async def list_issues(
     issue_type: IssueType | None = None,
     assignee: str | None = None,
     limit: int = 20,
+    cursor: str | None = None,
+    page_size: int | None = None,
     workspace_root: str | None = None,
-) -> list[IssueMinimal] | CompactedResult:
+) -> list[IssueMinimal] | CompactedResult | PaginatedResult:
     """List all issues with optional filters.
     
     Returns minimal issue format to reduce context usage by ~80%.
     Use show(issue_id) for full details including dependencies.
     
-    If results exceed threshold, returns compacted preview.
+    If results exceed threshold, returns compacted preview. With page_size or
+    cursor, returns one page plus next_cursor instead (never compacted).
     """
+    if cursor is not None or page_size is not None:
+        page = await beads_list_issues_page(
+            status=status,
+            priority=priority,
+            issue_type=issue_type,
+            assignee=assignee,
+            cursor=cursor,
+            page_size=page_size or limit,
+        )
+        return PaginatedResult(
+            issues=[_to_minimal(issue) for issue in page.issues],
+            next_cursor=page.next_cursor,
+        )
+
     issues = await beads_list_issues(
         status=status,
         priority=priority,
@@ -23,6 +23,7 @@ from .models import (
     DependencyType,
     InitParams,
     Issue,
+    IssuePage,
     IssueStatus,
     IssueType,
     ListIssuesParams,
@@ -336,6 +337,28 @@ async def beads_list_issues(
     return await client.list_issues(params)
 
 
+async def beads_list_issues_page(
+    status: Annotated[IssueStatus | None, "Filter by status (open, in_progress, blocked, closed)"] = None,
+    priority: Annotated[int | None, "Filter by priority (0-4, 0=highest)"] = None,
+    issue_type: Annotated[IssueType | None, "Filter by type (bug, feature, task, epic, chore)"] = None,
+    assignee: Annotated[str | None, "Filter by assignee"] = None,
+    cursor: Annotated[str | None, "next_cursor from the previous page (omit for the first page)"] = None,
+    page_size: Annotated[int, "Issues per page (1-1000)"] = 50,
+) -> IssuePage:
+    """List issues one page at a time in a stable order."""
+    client = await _get_client()
+
+    params = ListIssuesParams(
+        status=status,
+        priority=priority,
+        issue_type=issue_type,
+        assignee=assignee,
+        cursor=cursor,
+        page_size=page_size,
+    )
+    return await client.list_issues_page(params)
+
+
 async def beads_show_issue(
     issue_id: Annotated[str, "Issue ID (e.g., bd-1)"],
 ) -> Issue:
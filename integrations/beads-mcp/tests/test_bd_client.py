@@ -217,6 +217,36 @@ async def test_list_issues_invalid_response(bd_client, mock_process):
     assert issues == []
 
 
+@pytest.mark.asyncio
+async def test_list_issues_page(bd_client, mock_process):
+    """Test list_issues_page passes the cursor and parses next_cursor."""
+    page_data = {
+        "issues": [
+            {
+                "id": "bd-2",
+                "title": "Issue 2",
+                "status": "open",
+                "priority": 1,
+                "issue_type": "task",
+                "created_at": "2024-01-01T00:00:00Z",
+                "updated_at": "2024-01-01T00:00:00Z",
+            },
+        ],
+        "next_cursor": "abc123",
+    }
+    mock_process.communicate = AsyncMock(return_value=(json.dumps(page_data).encode(), b""))
+
+    with patch("asyncio.create_subprocess_exec", return_value=mock_process) as mock_exec:
+        params = ListIssuesParams(status="open", cursor="prev", page_size=1)
+        page = await bd_client.list_issues_page(params)
+
+    args = mock_exec.call_args[0]
+    assert "--page-size" in args and args[args.index("--page-size") + 1] == "1"
+    assert "--cursor" in args and args[args.index("--cursor") + 1] == "prev"
+    assert [issue.id for issue in page.issues] == ["bd-2"]
+    assert page.next_cursor == "abc123"
+
+
 @pytest.mark.asyncio
 async def test_show(bd_client, mock_process):
     """Test show method."""
@@ -5,7 +5,7 @@ from unittest.mock import AsyncMock, patch
 
 import pytest
 
-from beads_mcp.models import BlockedIssue, Issue, Stats
+from beads_mcp.models import BlockedIssue, Issue, IssuePage, Stats
 from beads_mcp.tools import (
     beads_add_dependency,
     beads_blocked,
@@ -13,6 +13,7 @@ from beads_mcp.tools import (
     beads_create_issue,
     beads_init,
     beads_list_issues,
+    beads_list_issues_page,
     beads_quickstart,
     beads_ready_work,
     beads_reopen_issue,
@@ -91,6 +92,22 @@ async def test_beads_list_issues(sample_issue):
     mock_client.list_issues.assert_called_once()
 
 
+@pytest.mark.asyncio
+async def test_beads_list_issues_page(sample_issue):
+    """Test beads_list_issues_page forwards the cursor."""
+    mock_client = AsyncMock()
+    mock_client.list_issues_page = AsyncMock(return_value=IssuePage(issues=[sample_issue], next_cursor="next"))
+
+    with patch("beads_mcp.tools._get_client", return_value=mock_client):
+        page = await beads_list_issues_page(status="open", cursor="prev", page_size=10)
+
+    assert page.issues[0].id == "bd-1"
+    assert page.next_cursor == "next"
+    params = mock_client.list_issues_page.call_args[0][0]
+    assert params.cursor == "prev"
+    assert params.page_size == 10
+
+
 @pytest.mark.asyncio
 async def test_beads_show_issue(sample_issue):
     """Test beads_show_issue tool."""
@@ -0,0 +1,140 @@
+// Package attachments stores issue attachments outside git.
+//
+// Providers are pluggable like embeddings providers: each registers a Factory
+// under a name and is selected per project with attachments.provider in
+// config.yaml. Only the URL and SHA-256 of an upload are recorded on the
+// issue, so blobs never enter the JSONL. The built-in providers are "local"
+// (a directory, e.g. a shared network mount), "s3" and "gcs".
+package attachments
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"net/url"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Provider uploads and downloads attachment content
+type Provider interface {
+	// Name is the registered provider name (e.g. "s3")
+	Name() string
+	// Put stores data under key and returns the URL to record for it
+	Put(ctx context.Context, key string, data []byte) (string, error)
+	// Get fetches the content at a URL previously returned by Put
+	Get(ctx context.Context, rawURL string) ([]byte, error)
+}
+
+// Options configure a provider. Providers ignore options they don't use.
+type Options struct {
+	Path     string // local: directory attachments are copied into
+	BlobDir  string // blobs: content-addressed store, normally .beads/blobs
+	Bucket   string // s3, gcs: bucket name
+	Prefix   string // s3, gcs: key prefix inside the bucket
+	Region   string // s3: bucket region
+	Endpoint string // s3, gcs: alternate endpoint (MinIO, emulators)
+}
+
+// Factory creates a provider from options
+type Factory func(opts Options) (Provider, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Factory{}
+	schemes    = map[string]string{} // URL scheme -> provider name
+)
+
+// Register makes a provider available by name and routes URLs with the given
+// scheme to it for downloads
+func Register(name, scheme string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[name] = factory
+	schemes[scheme] = name
+}
+
+// New creates the named provider
+func New(name string, opts Options) (Provider, error) {
+	registryMu.RLock()
+	factory, ok := registry[name]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown attachment provider %q (available: %s)", name, strings.Join(Providers(), ", "))
+	}
+	return factory(opts)
+}
+
+// ForURL creates the provider that handles rawURL, so attachments stay
+// downloadable after a project switches providers
+func ForURL(rawURL string, opts Options) (Provider, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid attachment URL %q: %w", rawURL, err)
+	}
+	registryMu.RLock()
+	name, ok := schemes[u.Scheme]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("no attachment provider handles %s:// URLs", u.Scheme)
+	}
+	return New(name, opts)
+}
+
+// Providers lists registered provider names
+func Providers() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Hash returns the hex SHA-256 of data
+func Hash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Key is the object key for an upload: content-addressed, keeping the file
+// name so downloads from the provider's own console stay recognizable
+func Key(prefix, sha, name string) string {
+	return path.Join(strings.Trim(prefix, "/"), sha, filepath.Base(name))
+}
+
+// ContentType is the MIME type uploads under key are stored with, so files
+// served straight from a bucket (images, published HTML) open in a browser
+func ContentType(key string) string {
+	if t := mime.TypeByExtension(path.Ext(key)); t != "" {
+		return t
+	}
+	return "application/octet-stream"
+}
+
+// Verify checks downloaded data against the recorded hash
+func Verify(data []byte, sha string) error {
+	if got := Hash(data); got != sha {
+		return fmt.Errorf("checksum mismatch: expected sha256 %s, got %s", sha, got)
+	}
+	return nil
+}
+
+// splitBucketURL splits scheme://bucket/key URLs used by the s3 and gcs providers
+func splitBucketURL(rawURL, scheme string) (bucket, key string, err error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid attachment URL %q: %w", rawURL, err)
+	}
+	if u.Scheme != scheme || u.Host == "" {
+		return "", "", fmt.Errorf("not a %s:// attachment URL: %s", scheme, rawURL)
+	}
+	return u.Host, strings.TrimPrefix(u.Path, "/"), nil
+}
@@ -0,0 +1,86 @@
+package attachments
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+)
+
+func init() {
+	Register("blobs", "blob", newBlobProvider)
+}
+
+// blobSHA matches the hex SHA-256 a blob is stored under
+var blobSHA = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// blobProvider stores attachments content-addressed in the workspace, under
+// .beads/blobs/<first two hex digits>/<sha256>. Identical files are stored
+// once, and the blob:<sha256>#<name> URLs it records resolve in any clone
+// that has the blobs, so committing the directory shares attachments through
+// git. The fragment keeps the file name, so the same content attached under
+// two names is two attachments sharing one blob.
+type blobProvider struct {
+	dir string
+}
+
+func newBlobProvider(opts Options) (Provider, error) {
+	return &blobProvider{dir: opts.BlobDir}, nil
+}
+
+func (p *blobProvider) Name() string { return "blobs" }
+
+// BlobPath is where the blob with the given hash lives under dir
+func BlobPath(dir, sha string) string {
+	return filepath.Join(dir, sha[:2], sha)
+}
+
+// Put stores data under the hash of its content; only the file name is
+// taken from key
+func (p *blobProvider) Put(_ context.Context, key string, data []byte) (string, error) {
+	if p.dir == "" {
+		return "", fmt.Errorf("blob store location unknown (no .beads directory)")
+	}
+	sha := Hash(data)
+	ref := (&url.URL{Scheme: "blob", Opaque: sha, Fragment: path.Base(key)}).String()
+	dest := BlobPath(p.dir, sha)
+	if _, err := os.Stat(dest); err == nil {
+		return ref, nil
+	}
+	// #nosec G301 - blobs are shared with the team like the JSONL
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return "", fmt.Errorf("failed to create blob directory: %w", err)
+	}
+	tmp := fmt.Sprintf("%s.tmp.%d", dest, os.Getpid())
+	// #nosec G306 - blobs are shared with the team like the JSONL
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write blob: %w", err)
+	}
+	if err := os.Rename(tmp, dest); err != nil {
+		_ = os.Remove(tmp)
+		return "", fmt.Errorf("failed to write blob: %w", err)
+	}
+	return ref, nil
+}
+
+func (p *blobProvider) Get(_ context.Context, rawURL string) ([]byte, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "blob" || !blobSHA.MatchString(u.Opaque) {
+		return nil, fmt.Errorf("not a blob: attachment URL: %s", rawURL)
+	}
+	if p.dir == "" {
+		return nil, fmt.Errorf("blob store location unknown (no .beads directory)")
+	}
+	// #nosec G304 - path built from a validated hash
+	data, err := os.ReadFile(BlobPath(p.dir, u.Opaque))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("blob %.12s is not in %s (pull the commit that added it)", u.Opaque, p.dir)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob: %w", err)
+	}
+	return data, nil
+}
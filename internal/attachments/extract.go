@@ -0,0 +1,255 @@
+package attachments
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxTextBytes caps the text kept from one attachment, so a huge log can't
+// dominate the search index
+const MaxTextBytes = 1 << 20
+
+// ErrUnsupported is returned by ExtractText for files it can't read text from
+type ErrUnsupported struct {
+	Name string
+}
+
+func (e *ErrUnsupported) Error() string {
+	return fmt.Sprintf("no text extractor for %s", e.Name)
+}
+
+// Extractable reports whether ExtractText understands files with this name
+func Extractable(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".txt", ".text", ".log", ".md", ".markdown", ".pdf":
+		return true
+	}
+	return false
+}
+
+// ExtractText returns the searchable text of an attachment, chosen by file
+// extension: plain text and markdown as-is, and the text drawn by a PDF's
+// content streams. Scanned PDFs (images only) and PDFs whose fonts use
+// custom encodings yield little or nothing. The result is valid UTF-8 and at
+// most MaxTextBytes long.
+func ExtractText(name string, data []byte) (string, error) {
+	var text string
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".txt", ".text", ".log", ".md", ".markdown":
+		text = string(data)
+	case ".pdf":
+		var err error
+		if text, err = extractPDFText(data); err != nil {
+			return "", err
+		}
+	default:
+		return "", &ErrUnsupported{Name: name}
+	}
+	return truncateText(strings.ToValidUTF8(text, "")), nil
+}
+
+func truncateText(text string) string {
+	if len(text) <= MaxTextBytes {
+		return text
+	}
+	cut := MaxTextBytes
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+	return text[:cut]
+}
+
+var (
+	pdfStreamRe = regexp.MustCompile(`(?s)<<(.*?)>>\s*stream\r?\n`)
+	pdfTextRe   = regexp.MustCompile(`(?s)BT(.*?)ET`)
+)
+
+// extractPDFText pulls the strings shown by text operators (Tj, TJ, ' and ")
+// out of a PDF's uncompressed and Flate-compressed content streams. It is not
+// a full PDF parser, but it covers the text-based PDFs tools and browsers
+// print.
+func extractPDFText(data []byte) (string, error) {
+	if !bytes.HasPrefix(data, []byte("%PDF-")) {
+		return "", fmt.Errorf("not a PDF file")
+	}
+	var out strings.Builder
+	for _, loc := range pdfStreamRe.FindAllSubmatchIndex(data, -1) {
+		dict := data[loc[2]:loc[3]]
+		start := loc[1]
+		end := bytes.Index(data[start:], []byte("endstream"))
+		if end < 0 {
+			break
+		}
+		stream := data[start : start+end]
+		if bytes.Contains(dict, []byte("/Filter")) {
+			if !bytes.Contains(dict, []byte("/FlateDecode")) {
+				continue // images and other encodings carry no text
+			}
+			inflated, err := io.ReadAll(io.LimitReader(zlibReader(stream), 16*MaxTextBytes))
+			if err != nil && len(inflated) == 0 {
+				continue
+			}
+			stream = inflated
+		}
+		for _, block := range pdfTextRe.FindAllSubmatch(stream, -1) {
+			writePDFTextBlock(&out, block[1])
+			if out.Len() > MaxTextBytes {
+				return out.String(), nil
+			}
+		}
+	}
+	return strings.TrimSpace(out.String()), nil
+}
+
+func zlibReader(stream []byte) io.Reader {
+	r, err := zlib.NewReader(bytes.NewReader(stream))
+	if err != nil {
+		return bytes.NewReader(nil)
+	}
+	return r
+}
+
+// writePDFTextBlock writes the strings in one BT..ET block, starting a new
+// line at line-moving operators
+func writePDFTextBlock(out *strings.Builder, block []byte) {
+	line, inArray := false, false
+	for i := 0; i < len(block); i++ {
+		switch c := block[i]; c {
+		case '[', ']':
+			inArray = c == '['
+		case '(':
+			s, n := readPDFString(block[i:])
+			out.WriteString(s)
+			line = true
+			i += n - 1
+		case '<':
+			if i+1 < len(block) && block[i+1] == '<' {
+				continue
+			}
+			end := bytes.IndexByte(block[i:], '>')
+			if end < 0 {
+				return
+			}
+			out.WriteString(decodePDFHex(block[i+1 : i+end]))
+			line = true
+			i += end
+		case '\'', '"':
+			out.WriteByte('\n')
+		case 'T':
+			if i+1 < len(block) && strings.IndexByte("*dDm", block[i+1]) >= 0 && line {
+				out.WriteByte('\n')
+				line = false
+			}
+		case '-':
+			// Large negative kerning inside a TJ array is a word gap
+			j := i + 1
+			for j < len(block) && (block[j] >= '0' && block[j] <= '9' || block[j] == '.') {
+				j++
+			}
+			if inArray && j-i > 3 {
+				out.WriteByte(' ')
+			}
+			i = j - 1
+		}
+	}
+	if line {
+		out.WriteByte('\n')
+	}
+}
+
+// readPDFString decodes a literal string starting at s[0] == '(' and
+// returns it with the number of bytes consumed
+func readPDFString(s []byte) (string, int) {
+	var b strings.Builder
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s):
+			i++
+			switch e := s[i]; e {
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case 'b', 'f':
+			case '\r', '\n':
+				// line continuation
+			default:
+				if e >= '0' && e <= '7' {
+					v := 0
+					j := i
+					for j < len(s) && j < i+3 && s[j] >= '0' && s[j] <= '7' {
+						v = v*8 + int(s[j]-'0')
+						j++
+					}
+					b.WriteRune(rune(v & 0xff))
+					i = j - 1
+				} else {
+					b.WriteByte(e)
+				}
+			}
+		case c == '(':
+			if depth > 0 {
+				b.WriteByte(c)
+			}
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return b.String(), i + 1
+			}
+			b.WriteByte(c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String(), len(s)
+}
+
+// decodePDFHex decodes a hex string, keeping it only if it looks like
+// single-byte text (two-byte CID strings need the font's map to read)
+func decodePDFHex(h []byte) string {
+	h = bytes.Join(bytes.Fields(h), nil)
+	if len(h)%2 == 1 {
+		h = append(h, '0')
+	}
+	var b strings.Builder
+	for i := 0; i+1 < len(h); i += 2 {
+		v, ok := hexByte(h[i], h[i+1])
+		if !ok {
+			return ""
+		}
+		if v < 0x20 && v != '\n' && v != '\t' {
+			return ""
+		}
+		b.WriteRune(rune(v))
+	}
+	return b.String()
+}
+
+func hexByte(hi, lo byte) (byte, bool) {
+	h, ok1 := hexDigit(hi)
+	l, ok2 := hexDigit(lo)
+	return h<<4 | l, ok1 && ok2
+}
+
+func hexDigit(c byte) (byte, bool) {
+	switch {
+	case c >= '0' && c <= '9':
+		return c - '0', true
+	case c >= 'a' && c <= 'f':
+		return c - 'a' + 10, true
+	case c >= 'A' && c <= 'F':
+		return c - 'A' + 10, true
+	}
+	return 0, false
+}
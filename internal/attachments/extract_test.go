@@ -0,0 +1,135 @@
+package attachments
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// testPDF builds a minimal PDF whose page content is stream, compressed
+// when flate is set
+func testPDF(stream string, flate bool) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n1 0 obj\n<< /Type /Catalog >>\nendobj\n")
+	body := []byte(stream)
+	dict := fmt.Sprintf("<< /Length %d >>", len(body))
+	if flate {
+		var z bytes.Buffer
+		w := zlib.NewWriter(&z)
+		_, _ = w.Write(body)
+		_ = w.Close()
+		body = z.Bytes()
+		dict = fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>", len(body))
+	}
+	fmt.Fprintf(&buf, "4 0 obj\n%s\nstream\n", dict)
+	buf.Write(body)
+	buf.WriteString("\nendstream\nendobj\n%%EOF\n")
+	return buf.Bytes()
+}
+
+func TestExtractText(t *testing.T) {
+	content := "BT /F1 12 Tf 72 712 Td (Root cause: cache \\(stale\\)) Tj 0 -14 Td [(Session)-250(Manager)] TJ ET"
+	tests := []struct {
+		name string
+		data []byte
+		want []string
+	}{
+		{"notes.md", []byte("# Design\n\nUse **WAL** mode."), []string{"# Design", "WAL"}},
+		{"crash.LOG", []byte("panic: nil map"), []string{"panic: nil map"}},
+		{"plain.pdf", testPDF(content, false), []string{"Root cause: cache (stale)", "Session Manager"}},
+		{"deflated.pdf", testPDF(content, true), []string{"Root cause: cache (stale)\nSession Manager"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExtractText(tt.name, tt.data)
+			if err != nil {
+				t.Fatalf("ExtractText: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("ExtractText = %q, missing %q", got, want)
+				}
+			}
+		})
+	}
+
+	if _, err := ExtractText("photo.png", []byte{0x89, 'P', 'N', 'G'}); err == nil {
+		t.Error("expected an error for an unsupported type")
+	}
+	if _, err := ExtractText("fake.pdf", []byte("not a pdf")); err == nil {
+		t.Error("expected an error for a file that isn't a PDF")
+	}
+
+	long := strings.Repeat("é", MaxTextBytes)
+	got, err := ExtractText("big.txt", []byte(long))
+	if err != nil || len(got) > MaxTextBytes || !strings.HasSuffix(got, "é") {
+		t.Errorf("long text not truncated on a rune boundary: len %d, err %v", len(got), err)
+	}
+}
+
+type memTextStore struct {
+	pending []*types.Attachment
+	text    map[int64]string
+	errs    map[int64]string
+}
+
+func (m *memTextStore) PendingAttachmentText(_ context.Context, limit int) ([]*types.Attachment, error) {
+	var out []*types.Attachment
+	for _, a := range m.pending {
+		if _, done := m.text[a.ID]; !done && len(out) < limit {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+func (m *memTextStore) SetAttachmentText(_ context.Context, id int64, content, indexErr string) error {
+	m.text[id] = content
+	m.errs[id] = indexErr
+	return nil
+}
+
+func TestIndexerIndexPending(t *testing.T) {
+	ctx := context.Background()
+	provider, err := New("local", Options{Path: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	upload := func(id int64, name string, data []byte) *types.Attachment {
+		sha := Hash(data)
+		url, err := provider.Put(ctx, Key("", sha, name), data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &types.Attachment{ID: id, IssueID: "bd-1", Name: name, URL: url, SHA256: sha, Size: int64(len(data))}
+	}
+
+	store := &memTextStore{text: map[int64]string{}, errs: map[int64]string{}}
+	store.pending = []*types.Attachment{
+		upload(1, "notes.md", []byte("deadlock in the scheduler")),
+		upload(2, "image.png", []byte("binary")),
+		upload(3, "huge.txt", bytes.Repeat([]byte("x"), 2<<20)),
+	}
+	tampered := upload(4, "tampered.txt", []byte("original"))
+	tampered.SHA256 = Hash([]byte("something else"))
+	store.pending = append(store.pending, tampered)
+
+	ix := &Indexer{Store: store, MaxMB: 1}
+	n, err := ix.IndexPending(ctx)
+	if err != nil || n != 4 {
+		t.Fatalf("IndexPending = %d, %v", n, err)
+	}
+	if store.text[1] != "deadlock in the scheduler" || store.errs[1] != "" {
+		t.Errorf("notes.md: text %q, error %q", store.text[1], store.errs[1])
+	}
+	for id, want := range map[int64]string{2: "no text extractor", 3: "indexing limit", 4: "checksum mismatch"} {
+		if !strings.Contains(store.errs[id], want) {
+			t.Errorf("attachment %d: error %q, want %q", id, store.errs[id], want)
+		}
+	}
+}
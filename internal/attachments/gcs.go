@@ -0,0 +1,110 @@
+package attachments
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+const defaultGCSEndpoint = "https://storage.googleapis.com"
+
+func init() {
+	Register("gcs", "gs", newGCSProvider)
+}
+
+// gcsProvider uses the Cloud Storage JSON API. The OAuth token comes from
+// GOOGLE_OAUTH_ACCESS_TOKEN or 'gcloud auth print-access-token'; with a custom
+// endpoint (or STORAGE_EMULATOR_HOST) no token is required.
+type gcsProvider struct {
+	bucket   string
+	endpoint string
+	token    string
+	client   *http.Client
+}
+
+func newGCSProvider(opts Options) (Provider, error) {
+	p := &gcsProvider{
+		bucket:   opts.Bucket,
+		endpoint: strings.TrimSuffix(opts.Endpoint, "/"),
+		token:    os.Getenv("GOOGLE_OAUTH_ACCESS_TOKEN"),
+		client:   &http.Client{Timeout: 5 * time.Minute},
+	}
+	if p.endpoint == "" {
+		if host := os.Getenv("STORAGE_EMULATOR_HOST"); host != "" {
+			if !strings.Contains(host, "://") {
+				host = "http://" + host
+			}
+			p.endpoint = strings.TrimSuffix(host, "/")
+		}
+	}
+	if p.endpoint == "" {
+		p.endpoint = defaultGCSEndpoint
+	}
+	if p.token == "" && p.endpoint == defaultGCSEndpoint {
+		// #nosec G204 - fixed command
+		out, err := exec.Command("gcloud", "auth", "print-access-token").Output()
+		if err != nil {
+			return nil, fmt.Errorf("gcs provider requires GOOGLE_OAUTH_ACCESS_TOKEN or an authenticated gcloud CLI")
+		}
+		p.token = strings.TrimSpace(string(out))
+	}
+	return p, nil
+}
+
+func (p *gcsProvider) Name() string { return "gcs" }
+
+func (p *gcsProvider) Put(ctx context.Context, key string, data []byte) (string, error) {
+	if p.bucket == "" {
+		return "", fmt.Errorf("gcs attachment provider requires attachments.bucket")
+	}
+	endpoint := fmt.Sprintf("%s/upload/storage/v1/b/%s/o?uploadType=media&name=%s",
+		p.endpoint, url.PathEscape(p.bucket), url.QueryEscape(key))
+	if _, err := p.do(ctx, http.MethodPost, endpoint, ContentType(key), data); err != nil {
+		return "", err
+	}
+	return "gs://" + p.bucket + "/" + key, nil
+}
+
+func (p *gcsProvider) Get(ctx context.Context, rawURL string) ([]byte, error) {
+	bucket, key, err := splitBucketURL(rawURL, "gs")
+	if err != nil {
+		return nil, err
+	}
+	endpoint := fmt.Sprintf("%s/storage/v1/b/%s/o/%s?alt=media",
+		p.endpoint, url.PathEscape(bucket), url.PathEscape(key))
+	return p.do(ctx, http.MethodGet, endpoint, "", nil)
+}
+
+func (p *gcsProvider) do(ctx context.Context, method, endpoint, contentType string, body []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if p.token != "" {
+		req.Header.Set("Authorization", "Bearer "+p.token)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("gcs request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read gcs response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("gcs request failed (HTTP %d): %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	return data, nil
+}
@@ -0,0 +1,133 @@
+package attachments
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Indexing batch size and polling interval
+const (
+	indexBatchSize    = 50
+	indexPollInterval = 30 * time.Second
+	bytesPerMB        = 1 << 20
+)
+
+// DefaultMaxIndexMB is the largest attachment indexed when no limit is set
+const DefaultMaxIndexMB = 10
+
+// TextStore is what an Indexer reads and writes; the SQLite storage
+// implements it
+type TextStore interface {
+	PendingAttachmentText(ctx context.Context, limit int) ([]*types.Attachment, error)
+	SetAttachmentText(ctx context.Context, attachmentID int64, content, indexErr string) error
+}
+
+// Indexer extracts the text of attachments so bd search can match it. Each
+// attachment is indexed once; one that can't be (too large, an unsupported
+// type, a failed download) is recorded with the reason and skipped until
+// the text is cleared.
+type Indexer struct {
+	Store TextStore
+	// Options locate the providers attachments are downloaded from
+	Options Options
+	// MaxMB skips attachments larger than this many megabytes; zero means
+	// DefaultMaxIndexMB
+	MaxMB int
+	// Interval between polls; zero means 30s
+	Interval time.Duration
+	// Logf receives indexing errors; may be nil
+	Logf func(format string, args ...interface{})
+}
+
+// Run indexes new attachments until ctx is cancelled
+func (ix *Indexer) Run(ctx context.Context) {
+	interval := ix.Interval
+	if interval <= 0 {
+		interval = indexPollInterval
+	}
+	for {
+		if _, err := ix.IndexPending(ctx); err != nil && ctx.Err() == nil {
+			ix.logf("attachment indexing: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+// IndexPending indexes every attachment without extracted text and returns
+// how many it processed
+func (ix *Indexer) IndexPending(ctx context.Context) (int, error) {
+	done := 0
+	for {
+		pending, err := ix.Store.PendingAttachmentText(ctx, indexBatchSize)
+		if err != nil {
+			return done, err
+		}
+		if len(pending) == 0 {
+			return done, nil
+		}
+		for _, a := range pending {
+			if err := ctx.Err(); err != nil {
+				return done, err
+			}
+			if err := ix.Index(ctx, a, nil); err != nil {
+				return done, err
+			}
+			done++
+		}
+	}
+}
+
+// Index extracts and stores the text of one attachment. data may hold the
+// content already in hand (bd attach has just uploaded it); otherwise it is
+// downloaded and verified. Only storage errors are returned: anything that
+// stops extraction is recorded against the attachment instead.
+func (ix *Indexer) Index(ctx context.Context, a *types.Attachment, data []byte) error {
+	content, reason := ix.extract(ctx, a, data)
+	if reason != "" {
+		ix.logf("attachment indexing: %s on %s: %s", a.Name, a.IssueID, reason)
+	}
+	return ix.Store.SetAttachmentText(ctx, a.ID, content, reason)
+}
+
+func (ix *Indexer) extract(ctx context.Context, a *types.Attachment, data []byte) (string, string) {
+	if !Extractable(a.Name) {
+		return "", (&ErrUnsupported{Name: a.Name}).Error()
+	}
+	maxMB := ix.MaxMB
+	if maxMB <= 0 {
+		maxMB = DefaultMaxIndexMB
+	}
+	if a.Size > int64(maxMB)*bytesPerMB {
+		return "", fmt.Sprintf("larger than the %d MB indexing limit", maxMB)
+	}
+	if data == nil {
+		provider, err := ForURL(a.URL, ix.Options)
+		if err != nil {
+			return "", err.Error()
+		}
+		if data, err = provider.Get(ctx, a.URL); err != nil {
+			return "", err.Error()
+		}
+		if err := Verify(data, a.SHA256); err != nil {
+			return "", err.Error()
+		}
+	}
+	text, err := ExtractText(a.Name, data)
+	if err != nil {
+		return "", err.Error()
+	}
+	return text, ""
+}
+
+func (ix *Indexer) logf(format string, args ...interface{}) {
+	if ix.Logf != nil {
+		ix.Logf(format, args...)
+	}
+}
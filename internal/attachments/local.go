@@ -0,0 +1,63 @@
+package attachments
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+)
+
+func init() {
+	Register("local", "file", newLocalProvider)
+}
+
+// localProvider copies attachments into a directory outside the repository,
+// typically a network share everyone on the team mounts
+type localProvider struct {
+	dir string
+}
+
+func newLocalProvider(opts Options) (Provider, error) {
+	return &localProvider{dir: opts.Path}, nil
+}
+
+func (p *localProvider) Name() string { return "local" }
+
+func (p *localProvider) Put(_ context.Context, key string, data []byte) (string, error) {
+	if p.dir == "" {
+		return "", fmt.Errorf("local attachment provider requires attachments.path")
+	}
+	dir, err := filepath.Abs(p.dir)
+	if err != nil {
+		return "", err
+	}
+	dest := filepath.Join(dir, filepath.FromSlash(key))
+	// #nosec G301 - attachments are shared with the team like the JSONL
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return "", fmt.Errorf("failed to create attachment directory: %w", err)
+	}
+	tmp := fmt.Sprintf("%s.tmp.%d", dest, os.Getpid())
+	// #nosec G306 - attachments are shared with the team like the JSONL
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write attachment: %w", err)
+	}
+	if err := os.Rename(tmp, dest); err != nil {
+		_ = os.Remove(tmp)
+		return "", fmt.Errorf("failed to write attachment: %w", err)
+	}
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(dest)}).String(), nil
+}
+
+func (p *localProvider) Get(_ context.Context, rawURL string) ([]byte, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "file" {
+		return nil, fmt.Errorf("not a file:// attachment URL: %s", rawURL)
+	}
+	// #nosec G304 - path comes from a recorded attachment URL
+	data, err := os.ReadFile(filepath.FromSlash(u.Path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read attachment: %w", err)
+	}
+	return data, nil
+}
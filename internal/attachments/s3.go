@@ -0,0 +1,204 @@
+package attachments
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+func init() {
+	Register("s3", "s3", newS3Provider)
+}
+
+// s3Provider talks to the S3 REST API directly with SigV4 signing, so it works
+// with AWS and S3-compatible stores (MinIO, R2) without an SDK dependency.
+// Credentials come from the standard AWS_* environment variables.
+type s3Provider struct {
+	bucket    string
+	region    string
+	endpoint  string // custom endpoint; uses path-style addressing
+	accessKey string
+	secretKey string
+	token     string
+	client    *http.Client
+	now       func() time.Time
+}
+
+func newS3Provider(opts Options) (Provider, error) {
+	p := &s3Provider{
+		bucket:    opts.Bucket,
+		region:    opts.Region,
+		endpoint:  strings.TrimSuffix(opts.Endpoint, "/"),
+		accessKey: os.Getenv("AWS_ACCESS_KEY_ID"),
+		secretKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		token:     os.Getenv("AWS_SESSION_TOKEN"),
+		client:    &http.Client{Timeout: 5 * time.Minute},
+		now:       time.Now,
+	}
+	if p.region == "" {
+		p.region = os.Getenv("AWS_REGION")
+	}
+	if p.region == "" {
+		p.region = os.Getenv("AWS_DEFAULT_REGION")
+	}
+	if p.region == "" {
+		p.region = "us-east-1"
+	}
+	if p.accessKey == "" || p.secretKey == "" {
+		return nil, fmt.Errorf("s3 provider requires AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY")
+	}
+	return p, nil
+}
+
+func (p *s3Provider) Name() string { return "s3" }
+
+func (p *s3Provider) Put(ctx context.Context, key string, data []byte) (string, error) {
+	if p.bucket == "" {
+		return "", fmt.Errorf("s3 attachment provider requires attachments.bucket")
+	}
+	if _, err := p.do(ctx, http.MethodPut, p.bucket, key, data); err != nil {
+		return "", err
+	}
+	return "s3://" + p.bucket + "/" + key, nil
+}
+
+func (p *s3Provider) Get(ctx context.Context, rawURL string) ([]byte, error) {
+	bucket, key, err := splitBucketURL(rawURL, "s3")
+	if err != nil {
+		return nil, err
+	}
+	return p.do(ctx, http.MethodGet, bucket, key, nil)
+}
+
+func (p *s3Provider) do(ctx context.Context, method, bucket, key string, body []byte) ([]byte, error) {
+	var endpoint, path string
+	if p.endpoint != "" {
+		endpoint = p.endpoint
+		path = "/" + bucket + "/" + awsURIEncode(key)
+	} else {
+		endpoint = fmt.Sprintf("https://%s.s3.%s.amazonaws.com", bucket, p.region)
+		path = "/" + awsURIEncode(key)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, endpoint+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", ContentType(key))
+	}
+	p.sign(req, path, body)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("s3 request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read s3 response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("s3 %s s3://%s/%s failed (HTTP %d): %s", method, bucket, key, resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	return data, nil
+}
+
+// sign adds AWS Signature Version 4 headers to req. path must be the
+// already-encoded request path.
+func (p *s3Provider) sign(req *http.Request, path string, body []byte) {
+	now := p.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := Hash(body)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if p.token != "" {
+		req.Header.Set("X-Amz-Security-Token", p.token)
+	}
+
+	scope := date + "/" + p.region + "/s3/aws4_request"
+	canonicalHeaders, signedHeaders := canonicalAWSHeaders(req)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		req.URL.RawQuery,
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		Hash([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+p.secretKey), date)
+	key = hmacSHA256(key, p.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		p.accessKey, scope, signedHeaders, signature))
+}
+
+// canonicalAWSHeaders returns the canonical header block and signed header
+// list for SigV4: host plus every header except those proxies may rewrite
+func canonicalAWSHeaders(req *http.Request) (string, string) {
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		lower := strings.ToLower(name)
+		switch lower {
+		case "authorization", "user-agent", "content-length", "accept-encoding":
+			continue
+		}
+		headers[lower] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonical strings.Builder
+	for _, name := range names {
+		canonical.WriteString(name + ":" + headers[name] + "\n")
+	}
+	return canonical.String(), strings.Join(names, ";")
+}
+
+// awsURIEncode percent-encodes a key path as SigV4 requires: everything but
+// RFC 3986 unreserved characters and the '/' separators
+func awsURIEncode(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z', c >= '0' && c <= '9',
+			c == '-', c == '.', c == '_', c == '~':
+			b.WriteByte(c)
+		case c == '/':
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
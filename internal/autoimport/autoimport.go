@@ -1,7 +1,6 @@
 package autoimport
 
 import (
-	"bufio"
 	"bytes"
 	"context"
 	"crypto/sha256"
@@ -15,6 +14,7 @@ import (
 	"github.com/steveyegge/beads/internal/debug"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/util"
 	"github.com/steveyegge/beads/internal/utils"
 )
 
@@ -218,8 +218,7 @@ func checkForMergeConflicts(jsonlData []byte, jsonlPath string) error {
 }
 
 func parseJSONL(jsonlData []byte, _ Notifier) ([]*types.Issue, error) {
-	scanner := bufio.NewScanner(bytes.NewReader(jsonlData))
-	scanner.Buffer(make([]byte, 0, 1024), 2*1024*1024)
+	scanner := util.NewJSONLScanner(bytes.NewReader(jsonlData))
 	var allIssues []*types.Issue
 	lineNo := 0
 
@@ -109,6 +109,11 @@ func findDatabaseInBeadsDir(beadsDir string, warnOnIssues bool) string {
 	// Check for config.json first (single source of truth)
 	if cfg, err := configfile.Load(beadsDir); err == nil && cfg != nil {
 		dbPath := cfg.DatabasePath(beadsDir)
+		// A PostgreSQL project has no database file; its path still names
+		// the .beads directory the config was read from
+		if cfg.GetBackend() == configfile.BackendPostgres {
+			return dbPath
+		}
 		if _, err := os.Stat(dbPath); err == nil {
 			return dbPath
 		}
@@ -5,6 +5,8 @@ import (
 	"os/exec"
 	"path/filepath"
 	"testing"
+
+	"github.com/steveyegge/beads/internal/configfile"
 )
 
 func TestFindDatabasePathEnvVar(t *testing.T) {
@@ -92,6 +94,25 @@ func TestFindDatabasePathInTree(t *testing.T) {
 	}
 }
 
+func TestFindDatabaseInBeadsDirPostgres(t *testing.T) {
+	beadsDir := filepath.Join(t.TempDir(), ".beads")
+	if err := os.MkdirAll(beadsDir, 0o750); err != nil {
+		t.Fatalf("Failed to create .beads dir: %v", err)
+	}
+	cfg := configfile.DefaultConfig()
+	cfg.Backend = configfile.BackendPostgres
+	cfg.PostgresDSN = "postgres://db.example/beads"
+	if err := cfg.Save(beadsDir); err != nil {
+		t.Fatalf("Failed to save config: %v", err)
+	}
+
+	// There is no database file, but the project still has a database
+	want := cfg.DatabasePath(beadsDir)
+	if got := findDatabaseInBeadsDir(beadsDir, false); got != want {
+		t.Errorf("findDatabaseInBeadsDir() = %q, want %q", got, want)
+	}
+}
+
 func TestFindDatabasePathNotFound(t *testing.T) {
 	// Save original env var
 	originalEnv := os.Getenv("BEADS_DB")
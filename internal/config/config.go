@@ -5,6 +5,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -85,17 +86,42 @@ func Initialize() error {
 	v.SetDefault("actor", "")
 	v.SetDefault("issue-prefix", "")
 	v.SetDefault("lock-timeout", "30s")
+
+	// Command journal for bd replay (opt-in; BD_HISTORY=1, BD_HISTORY_FILE)
+	v.SetDefault("history", false)
+	v.SetDefault("history-file", "")
 	
 	// Additional environment variables (not prefixed with BD_)
 	// These are bound explicitly for backward compatibility
 	_ = v.BindEnv("flush-debounce", "BEADS_FLUSH_DEBOUNCE")
+	_ = v.BindEnv("daemon-debounce", "BEADS_DAEMON_DEBOUNCE")
+	_ = v.BindEnv("daemon-idle-backoff", "BEADS_DAEMON_IDLE_BACKOFF")
 	_ = v.BindEnv("auto-start-daemon", "BEADS_AUTO_START_DAEMON")
 	_ = v.BindEnv("identity", "BEADS_IDENTITY")
+	_ = v.BindEnv("daemon-addr", "BEADS_DAEMON_ADDR")
+	_ = v.BindEnv("daemon-listen", "BEADS_DAEMON_LISTEN")
+	_ = v.BindEnv("daemon-token", "BEADS_DAEMON_TOKEN")
+	_ = v.BindEnv("daemon-tls-cert", "BEADS_DAEMON_TLS_CERT")
+	_ = v.BindEnv("daemon-tls-key", "BEADS_DAEMON_TLS_KEY")
+	_ = v.BindEnv("daemon-tls-ca", "BEADS_DAEMON_TLS_CA")
+	_ = v.BindEnv("daemon-metrics-addr", "BEADS_DAEMON_METRICS_ADDR")
 	
 	// Set defaults for additional settings
 	v.SetDefault("flush-debounce", "30s")
+	v.SetDefault("daemon-debounce", "500ms")
+	v.SetDefault("daemon-idle-backoff", "5m")
 	v.SetDefault("auto-start-daemon", true)
 	v.SetDefault("identity", "")
+
+	// Remote daemon access over TCP (bd daemon --listen, BEADS_DAEMON_ADDR);
+	// empty disables it. The token authenticates remote clients.
+	v.SetDefault("daemon-addr", "")
+	v.SetDefault("daemon-listen", "")
+	v.SetDefault("daemon-token", "")
+	v.SetDefault("daemon-tls-cert", "")
+	v.SetDefault("daemon-tls-key", "")
+	v.SetDefault("daemon-tls-ca", "")
+	v.SetDefault("daemon-metrics-addr", "")
 	
 	// Routing configuration defaults
 	v.SetDefault("routing.mode", "auto")
@@ -105,10 +131,89 @@ func Initialize() error {
 
 	// Sync configuration defaults (bd-4u8)
 	v.SetDefault("sync.require_confirmation_on_mass_delete", false)
+	v.SetDefault("sync.push_retries", 3)
+	// Repository sync commits to (work tree or git dir; empty: the one holding .beads)
+	v.SetDefault("sync.git_dir", "")
+
+	// bd self-update release feed and optional ed25519 key for checksums.txt
+	v.SetDefault("self-update.feed", "")
+	v.SetDefault("self-update.public_key", "")
 
 	// Push configuration defaults
 	v.SetDefault("no-push", false)
 
+	// Default bd list order, e.g. "priority,-updated_at" (empty: priority, newest first)
+	v.SetDefault("list.sort", "")
+
+	// Default lease for bd claim and bd ready --claim, e.g. "30m" (empty: claims don't expire)
+	v.SetDefault("claim.ttl", "")
+
+	// Export layout defaults (single issues.jsonl, or shards under issues.d/)
+	v.SetDefault("export.layout", "single")
+	v.SetDefault("export.shard_by", "status")
+
+	// Embeddings configuration defaults (bd index, bd search --semantic)
+	v.SetDefault("embeddings.provider", "local")
+	v.SetDefault("embeddings.model", "")
+	v.SetDefault("embeddings.base_url", "")
+
+	// Attachment provider defaults (bd attach); unset means attachments are disabled
+	v.SetDefault("attachments.provider", "")
+	v.SetDefault("attachments.path", "")
+	v.SetDefault("attachments.bucket", "")
+	v.SetDefault("attachments.prefix", "")
+	v.SetDefault("attachments.region", "")
+	v.SetDefault("attachments.endpoint", "")
+
+	// Attachment text extraction for bd search (bd index --attachments, daemon)
+	v.SetDefault("attachments.index", true)
+	v.SetDefault("attachments.index_max_mb", 10)
+
+	// Read-only snapshot publishing (bd publish, daemon); empty target disables it
+	v.SetDefault("publish.target", "")
+	v.SetDefault("publish.interval", "1h")
+	v.SetDefault("publish.remote", "origin")
+	v.SetDefault("publish.title", "")
+	v.SetDefault("publish.exclude_labels", []string{"confidential", "private"})
+	v.SetDefault("publish.include_closed", true)
+
+	// CI gates (bd gate, daemon); webhooks need both a listen address and a secret
+	v.SetDefault("gates.poll_interval", "2m")
+	v.SetDefault("gates.webhook_listen", "")
+	v.SetDefault("gates.webhook_secret", "")
+
+	// Issue intake endpoint (daemon); needs both a listen address and a token
+	v.SetDefault("intake.listen", "")
+	v.SetDefault("intake.token", "")
+	v.SetDefault("intake.hooks", []string{})
+	v.SetDefault("intake.dedupe_threshold", 0.8)
+
+	// Timestamp display (stored times are always UTC; see internal/timefmt)
+	v.SetDefault("time.zone", "local")
+	v.SetDefault("time.format", "absolute")
+
+	// Display locale for translated titles and descriptions (bd update --locale)
+	v.SetDefault("locale", "")
+
+	// Redaction defaults (see internal/redact); emails and custom patterns are opt-in
+	v.SetDefault("redaction.rules", []string{"aws-access-key", "github-token", "slack-token", "private-key", "generic-api-key"})
+	v.SetDefault("redaction.patterns", []string{})
+	v.SetDefault("redaction.replacement", "[REDACTED]")
+
+	// Field encryption (see internal/encryption); the key never goes in config.yaml
+	v.SetDefault("encryption.fields", []string{})
+	v.SetDefault("encryption.key", "")
+	v.SetDefault("encryption.key_file", "")
+
+	// OIDC login for bd serve --multi-tenant and bd auth (empty issuer disables)
+	v.SetDefault("oidc.issuer", "")
+	v.SetDefault("oidc.client_id", "")
+	v.SetDefault("oidc.audience", "")
+	v.SetDefault("oidc.scopes", []string{})
+	v.SetDefault("oidc.actor_claim", "email")
+	v.SetDefault("oidc.roles_claim", "groups")
+	v.SetDefault("oidc.roles", []string{})
+
 	// Read config file if it was found
 	if configFileSet {
 		if err := v.ReadInConfig(); err != nil {
@@ -123,6 +228,65 @@ func Initialize() error {
 	return nil
 }
 
+// File returns the config.yaml in use, or "" when none was found
+func File() string {
+	if v == nil {
+		return ""
+	}
+	return v.ConfigFileUsed()
+}
+
+// Change is a setting whose value differs after Reload
+type Change struct {
+	Key string
+	Old string
+	New string
+}
+
+// Reload re-reads the config file so a long-running process (the daemon)
+// picks up edits, and returns the settings whose values changed. path is
+// read when no config file was found at startup. Values set with Set, such
+// as command-line overrides, are kept.
+func Reload(path string) ([]Change, error) {
+	if v == nil {
+		return nil, fmt.Errorf("config not initialized")
+	}
+	if v.ConfigFileUsed() == "" {
+		if path == "" {
+			return nil, nil
+		}
+		v.SetConfigFile(path)
+	}
+	before := settingValues()
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("error reading config file: %w", err)
+	}
+	after := settingValues()
+
+	var changes []Change
+	for key, value := range after {
+		if before[key] != value {
+			changes = append(changes, Change{Key: key, Old: before[key], New: value})
+		}
+	}
+	for key, value := range before {
+		if _, ok := after[key]; !ok {
+			changes = append(changes, Change{Key: key, Old: value})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	return changes, nil
+}
+
+// settingValues renders every setting as a string, keyed by its dotted name
+func settingValues() map[string]string {
+	values := make(map[string]string)
+	for _, key := range v.AllKeys() {
+		values[key] = fmt.Sprint(v.Get(key))
+	}
+	return values
+}
+
 // GetString retrieves a string configuration value
 func GetString(key string) string {
 	if v == nil {
@@ -147,6 +311,14 @@ func GetInt(key string) int {
 	return v.GetInt(key)
 }
 
+// GetFloat64 retrieves a floating-point configuration value
+func GetFloat64(key string) float64 {
+	if v == nil {
+		return 0
+	}
+	return v.GetFloat64(key)
+}
+
 // GetDuration retrieves a duration configuration value
 func GetDuration(key string) time.Duration {
 	if v == nil {
@@ -189,6 +361,14 @@ func GetStringSlice(key string) []string {
 	return v.GetStringSlice(key)
 }
 
+// GetStringMap retrieves a map configuration value, such as plugins
+func GetStringMap(key string) map[string]interface{} {
+	if v == nil {
+		return map[string]interface{}{}
+	}
+	return v.GetStringMap(key)
+}
+
 // MultiRepoConfig contains configuration for multi-repo support
 type MultiRepoConfig struct {
 	Primary    string   // Primary repo path (where canonical issues live)
@@ -497,3 +497,49 @@ func TestGetIdentityFromConfig(t *testing.T) {
 		t.Errorf("GetIdentity(flag-override) = %q, want \"flag-override\"", got)
 	}
 }
+
+func TestReload(t *testing.T) {
+	tmpDir := t.TempDir()
+	beadsDir := filepath.Join(tmpDir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0750); err != nil {
+		t.Fatalf("failed to create .beads directory: %v", err)
+	}
+	configPath := filepath.Join(beadsDir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte("publish:\n  target: gh-pages\nsync:\n  push_retries: 5\n"), 0600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	t.Chdir(tmpDir)
+	if err := Initialize(); err != nil {
+		t.Fatalf("Initialize() returned error: %v", err)
+	}
+	Set("daemon-listen", ":9999")
+
+	if err := os.WriteFile(configPath, []byte("publish:\n  target: dir:/srv/www\n"), 0600); err != nil {
+		t.Fatalf("failed to rewrite config file: %v", err)
+	}
+	changes, err := Reload("")
+	if err != nil {
+		t.Fatalf("Reload() returned error: %v", err)
+	}
+
+	got := map[string]Change{}
+	for _, c := range changes {
+		got[c.Key] = c
+	}
+	if c := got["publish.target"]; c.Old != "gh-pages" || c.New != "dir:/srv/www" {
+		t.Errorf("publish.target change = %+v", c)
+	}
+	// A removed setting falls back to its default
+	if c := got["sync.push_retries"]; c.Old != "5" || c.New != "3" {
+		t.Errorf("sync.push_retries change = %+v", c)
+	}
+	if len(changes) != 2 {
+		t.Errorf("expected 2 changes, got %+v", changes)
+	}
+	if GetString("daemon-listen") != ":9999" {
+		t.Errorf("Set override lost on reload")
+	}
+	if File() != configPath {
+		t.Errorf("File() = %q, want %q", File(), configPath)
+	}
+}
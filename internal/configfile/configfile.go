@@ -4,15 +4,32 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"net/url"
 	"path/filepath"
+	"strings"
 )
 
 const ConfigFileName = "metadata.json"
 
+// Storage backends
+const (
+	BackendSQLite   = "sqlite"
+	BackendPostgres = "postgres"
+)
+
+// PostgresDSNEnvVar overrides the configured PostgreSQL DSN, so a DSN with
+// a password never has to be written to the committed metadata.json
+const PostgresDSNEnvVar = "BEADS_POSTGRES_DSN"
+
 type Config struct {
 	Database    string `json:"database"`
 	JSONLExport string `json:"jsonl_export,omitempty"`
 
+	// Backend is "sqlite" (the default when empty) for the database file
+	// named by Database, or "postgres" for the server named by PostgresDSN
+	Backend     string `json:"backend,omitempty"`
+	PostgresDSN string `json:"postgres_dsn,omitempty"`
+
 	// Deletions configuration
 	DeletionsRetentionDays int `json:"deletions_retention_days,omitempty"` // 0 means use default (3 days)
 
@@ -103,6 +120,44 @@ func (c *Config) JSONLPath(beadsDir string) string {
 	return filepath.Join(beadsDir, c.JSONLExport)
 }
 
+// GetBackend returns the storage backend, defaulting to sqlite
+func (c *Config) GetBackend() string {
+	if c.Backend == "" {
+		return BackendSQLite
+	}
+	return c.Backend
+}
+
+// GetPostgresDSN returns the PostgreSQL DSN, from BEADS_POSTGRES_DSN if set
+func (c *Config) GetPostgresDSN() string {
+	if dsn := os.Getenv(PostgresDSNEnvVar); dsn != "" {
+		return dsn
+	}
+	return c.PostgresDSN
+}
+
+// DSNHasPassword reports whether a PostgreSQL DSN, in URL or key=value
+// form, carries a password. Such a DSN must not be saved in metadata.json.
+func DSNHasPassword(dsn string) bool {
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		u, err := url.Parse(dsn)
+		if err != nil {
+			// Can't tell, so assume the worst
+			return true
+		}
+		if _, ok := u.User.Password(); ok {
+			return true
+		}
+		return u.Query().Has("password")
+	}
+	for _, field := range strings.Fields(dsn) {
+		if key, _, ok := strings.Cut(field, "="); ok && strings.TrimSpace(key) == "password" {
+			return true
+		}
+	}
+	return false
+}
+
 // DefaultDeletionsRetentionDays is the default retention period for deletion records.
 const DefaultDeletionsRetentionDays = 3
 
@@ -0,0 +1,480 @@
+package editorrpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/redact"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// ServerInfo identifies the server in the initialize result
+type ServerInfo struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// InitializeParams are the params for initialize
+type InitializeParams struct {
+	RootPath string `json:"rootPath,omitempty"` // Optional project to open immediately
+}
+
+// InitializeResult is the result of initialize
+type InitializeResult struct {
+	ServerInfo ServerInfo   `json:"serverInfo"`
+	Methods    []string     `json:"methods"`
+	Project    *ProjectInfo `json:"project,omitempty"`
+}
+
+// ProjectInfo describes the open project
+type ProjectInfo struct {
+	Root   string            `json:"root"`
+	DBPath string            `json:"dbPath"`
+	Prefix string            `json:"prefix"`
+	Stats  *types.Statistics `json:"stats,omitempty"`
+}
+
+// ProjectOpenParams are the params for project/open
+type ProjectOpenParams struct {
+	Path string `json:"path"`
+}
+
+// QueryParams are the params for issues/query
+type QueryParams struct {
+	Query     string   `json:"query,omitempty"`
+	Status    string   `json:"status,omitempty"`
+	Priority  *int     `json:"priority,omitempty"`
+	Type      string   `json:"type,omitempty"`
+	Assignee  string   `json:"assignee,omitempty"`
+	Labels    []string `json:"labels,omitempty"`    // Must have all
+	LabelsAny []string `json:"labelsAny,omitempty"` // Must have at least one
+	IDs       []string `json:"ids,omitempty"`
+	Ready     bool     `json:"ready,omitempty"` // Only unblocked open work
+	Limit     int      `json:"limit,omitempty"`
+	Cursor    string   `json:"cursor,omitempty"`   // nextCursor from the previous page
+	PageSize  int      `json:"pageSize,omitempty"` // Setting either returns a QueryPage
+}
+
+// QueryPage is the issues/query result when the client paginates
+type QueryPage struct {
+	Issues     []*types.Issue `json:"issues"`
+	NextCursor string         `json:"nextCursor,omitempty"`
+}
+
+// IDParams identify a single issue (partial IDs are resolved)
+type IDParams struct {
+	ID string `json:"id"`
+}
+
+// IssueDetails is the result of issues/get
+type IssueDetails struct {
+	*types.Issue
+	Dependencies []*types.Issue   `json:"dependencies,omitempty"`
+	Dependents   []*types.Issue   `json:"dependents,omitempty"`
+	Comments     []*types.Comment `json:"comments,omitempty"`
+}
+
+// CreateParams are the params for issues/create
+type CreateParams struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description,omitempty"`
+	Type        string   `json:"type,omitempty"`
+	Priority    *int     `json:"priority,omitempty"`
+	Assignee    string   `json:"assignee,omitempty"`
+	Labels      []string `json:"labels,omitempty"`
+}
+
+// UpdateParams are the params for issues/update. Nil fields are left unchanged.
+type UpdateParams struct {
+	ID                 string  `json:"id"`
+	Title              *string `json:"title,omitempty"`
+	Description        *string `json:"description,omitempty"`
+	Design             *string `json:"design,omitempty"`
+	AcceptanceCriteria *string `json:"acceptanceCriteria,omitempty"`
+	Notes              *string `json:"notes,omitempty"`
+	Status             *string `json:"status,omitempty"`
+	Priority           *int    `json:"priority,omitempty"`
+	Assignee           *string `json:"assignee,omitempty"`
+}
+
+// CloseParams are the params for issues/close
+type CloseParams struct {
+	ID     string `json:"id"`
+	Reason string `json:"reason,omitempty"`
+	Note   string `json:"note,omitempty"`
+}
+
+// CommentParams are the params for comments/add
+type CommentParams struct {
+	ID   string `json:"id"`
+	Text string `json:"text"`
+}
+
+func (s *Server) handleInitialize(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var p InitializeParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	result := InitializeResult{
+		ServerInfo: ServerInfo{Name: "bd", Version: s.cfg.Version},
+		Methods: []string{
+			MethodProjectOpen, MethodIssuesQuery, MethodIssuesGet,
+			MethodIssuesCreate, MethodIssuesUpdate, MethodIssuesClose,
+			MethodCommentsAdd, MethodLensResolve, MethodWatchStart, MethodWatchStop,
+		},
+	}
+
+	if p.RootPath != "" {
+		info, err := s.openProject(ctx, p.RootPath)
+		if err != nil {
+			return nil, err
+		}
+		result.Project = info
+	} else if project, err := s.currentProject(); err == nil {
+		result.Project = s.describeProject(ctx, project)
+	}
+	return result, nil
+}
+
+func (s *Server) handleShutdown(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	s.stopWatch()
+	s.shutdown = true
+	return nil, nil
+}
+
+func (s *Server) handleProjectOpen(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var p ProjectOpenParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	if p.Path == "" {
+		return nil, &Error{Code: CodeInvalidParams, Message: "path is required"}
+	}
+	return s.openProject(ctx, p.Path)
+}
+
+// openProject switches to the project containing path
+func (s *Server) openProject(ctx context.Context, path string) (*ProjectInfo, error) {
+	if s.cfg.OpenProject == nil {
+		return nil, &Error{Code: CodeInvalidRequest, Message: "opening projects is not supported"}
+	}
+
+	// A watch polls the old project's store, which OpenProject may close:
+	// stop it first and restart it on the new project at the same interval
+	var interval time.Duration
+	s.mu.Lock()
+	if s.watch != nil {
+		interval = s.watch.interval
+	}
+	s.mu.Unlock()
+	s.stopWatch()
+
+	project, err := s.cfg.OpenProject(ctx, path)
+	if err == nil {
+		s.mu.Lock()
+		s.project = project
+		s.mu.Unlock()
+	}
+	if interval > 0 {
+		s.startWatch(interval)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.describeProject(ctx, project), nil
+}
+
+func (s *Server) describeProject(ctx context.Context, project *Project) *ProjectInfo {
+	info := &ProjectInfo{Root: project.Root, DBPath: project.DBPath}
+	info.Prefix, _ = project.Store.GetConfig(ctx, "issue_prefix")
+	info.Stats, _ = project.Store.GetStatistics(ctx)
+	return info
+}
+
+func (s *Server) handleIssuesQuery(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	project, err := s.currentProject()
+	if err != nil {
+		return nil, err
+	}
+	var p QueryParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	paginated := p.Cursor != "" || p.PageSize > 0
+
+	if p.Ready {
+		if paginated {
+			return nil, &Error{Code: CodeInvalidParams, Message: "cursor pagination is not supported for ready work"}
+		}
+		filter := types.WorkFilter{Status: types.StatusOpen, Priority: p.Priority, Labels: p.Labels, LabelsAny: p.LabelsAny, Limit: p.Limit}
+		if p.Assignee != "" {
+			filter.Assignee = &p.Assignee
+		}
+		issues, err := project.Store.GetReadyWork(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		filtered := []*types.Issue{}
+		for _, issue := range issues {
+			if p.Type == "" || string(issue.IssueType) == p.Type {
+				filtered = append(filtered, issue)
+			}
+		}
+		return filtered, nil
+	}
+
+	filter := types.IssueFilter{
+		Priority:  p.Priority,
+		Labels:    p.Labels,
+		LabelsAny: p.LabelsAny,
+		IDs:       p.IDs,
+		Limit:     p.Limit,
+	}
+	if p.Status != "" {
+		status := types.Status(p.Status)
+		filter.Status = &status
+	}
+	if p.Type != "" {
+		issueType := types.IssueType(p.Type)
+		filter.IssueType = &issueType
+	}
+	if p.Assignee != "" {
+		filter.Assignee = &p.Assignee
+	}
+	if paginated {
+		if p.Cursor != "" {
+			if _, err := types.DecodePageCursor(p.Cursor); err != nil {
+				return nil, &Error{Code: CodeInvalidParams, Message: err.Error()}
+			}
+		}
+		issues, next, err := storage.SearchIssuesPage(ctx, project.Store, p.Query, filter, p.Cursor, p.PageSize)
+		if err != nil {
+			return nil, err
+		}
+		if issues == nil {
+			issues = []*types.Issue{}
+		}
+		return QueryPage{Issues: issues, NextCursor: next}, nil
+	}
+	issues, err := project.Store.SearchIssues(ctx, p.Query, filter)
+	if err != nil {
+		return nil, err
+	}
+	if issues == nil {
+		issues = []*types.Issue{}
+	}
+	return issues, nil
+}
+
+func (s *Server) handleIssuesGet(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	project, err := s.currentProject()
+	if err != nil {
+		return nil, err
+	}
+	var p IDParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	id, err := s.resolveID(ctx, project, p.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	issue, err := project.Store.GetIssue(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if issue == nil {
+		return nil, &Error{Code: CodeInvalidParams, Message: fmt.Sprintf("issue not found: %s", id)}
+	}
+
+	details := &IssueDetails{Issue: issue}
+	details.Labels, _ = project.Store.GetLabels(ctx, id)
+	details.Dependencies, _ = project.Store.GetDependencies(ctx, id)
+	details.Dependents, _ = project.Store.GetDependents(ctx, id)
+	details.Comments, _ = project.Store.GetIssueComments(ctx, id)
+	return details, nil
+}
+
+func (s *Server) handleIssuesCreate(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	project, err := s.currentProject()
+	if err != nil {
+		return nil, err
+	}
+	var p CreateParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	if p.Title == "" {
+		return nil, &Error{Code: CodeInvalidParams, Message: "title is required"}
+	}
+	// Strip secrets before they reach the database (redaction.* config)
+	redactor, err := redact.FromConfig()
+	if err != nil {
+		return nil, err
+	}
+	redactor.RedactTexts(&p.Title, &p.Description)
+
+	issue := &types.Issue{
+		Title:       p.Title,
+		Description: p.Description,
+		Status:      types.StatusOpen,
+		Priority:    2,
+		IssueType:   types.TypeTask,
+		Assignee:    p.Assignee,
+	}
+	if p.Priority != nil {
+		issue.Priority = *p.Priority
+	}
+	if p.Type != "" {
+		issue.IssueType = types.IssueType(p.Type)
+	}
+
+	if err := project.Store.CreateIssue(ctx, issue, s.cfg.Actor); err != nil {
+		return nil, err
+	}
+	for _, label := range p.Labels {
+		if err := project.Store.AddLabel(ctx, issue.ID, label, s.cfg.Actor); err != nil {
+			return nil, fmt.Errorf("created %s but failed to add label %q: %w", issue.ID, label, err)
+		}
+	}
+	issue.Labels = p.Labels
+	s.mutated()
+	return issue, nil
+}
+
+func (s *Server) handleIssuesUpdate(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	project, err := s.currentProject()
+	if err != nil {
+		return nil, err
+	}
+	var p UpdateParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	id, err := s.resolveID(ctx, project, p.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make(map[string]interface{})
+	setString := func(key string, v *string) {
+		if v != nil {
+			updates[key] = *v
+		}
+	}
+	setString("title", p.Title)
+	setString("description", p.Description)
+	setString("design", p.Design)
+	setString("acceptance_criteria", p.AcceptanceCriteria)
+	setString("notes", p.Notes)
+	setString("status", p.Status)
+	setString("assignee", p.Assignee)
+	if p.Priority != nil {
+		updates["priority"] = *p.Priority
+	}
+	if len(updates) == 0 {
+		return nil, &Error{Code: CodeInvalidParams, Message: "no fields to update"}
+	}
+	redactor, err := redact.FromConfig()
+	if err != nil {
+		return nil, err
+	}
+	redactor.RedactUpdates(updates)
+	if p.Status != nil && *p.Status == string(types.StatusClosed) {
+		if err := storage.CheckVerifiedClose(ctx, project.Store, id, s.cfg.Actor); err != nil {
+			return nil, err
+		}
+		if err := storage.CheckDefinitionOfDone(ctx, project.Store, id); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := project.Store.UpdateIssue(ctx, id, updates, s.cfg.Actor); err != nil {
+		return nil, err
+	}
+	s.mutated()
+	return project.Store.GetIssue(ctx, id)
+}
+
+func (s *Server) handleIssuesClose(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	project, err := s.currentProject()
+	if err != nil {
+		return nil, err
+	}
+	var p CloseParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	id, err := s.resolveID(ctx, project, p.ID)
+	if err != nil {
+		return nil, err
+	}
+	reason, err := storage.ResolveCloseReason(ctx, project.Store, p.Reason, p.Note)
+	if err != nil {
+		return nil, &Error{Code: CodeInvalidParams, Message: err.Error()}
+	}
+	if reason == "" {
+		reason = "Closed"
+	}
+	if err := storage.CheckVerifiedClose(ctx, project.Store, id, s.cfg.Actor); err != nil {
+		return nil, err
+	}
+	if err := storage.CheckDefinitionOfDone(ctx, project.Store, id); err != nil {
+		return nil, err
+	}
+
+	if err := project.Store.CloseIssue(ctx, id, reason, s.cfg.Actor); err != nil {
+		return nil, err
+	}
+	s.mutated()
+	return project.Store.GetIssue(ctx, id)
+}
+
+func (s *Server) handleCommentsAdd(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	project, err := s.currentProject()
+	if err != nil {
+		return nil, err
+	}
+	var p CommentParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	if p.Text == "" {
+		return nil, &Error{Code: CodeInvalidParams, Message: "text is required"}
+	}
+	id, err := s.resolveID(ctx, project, p.ID)
+	if err != nil {
+		return nil, err
+	}
+	redactor, err := redact.FromConfig()
+	if err != nil {
+		return nil, err
+	}
+	redactor.RedactTexts(&p.Text)
+
+	comment, err := project.Store.AddIssueComment(ctx, id, s.cfg.Actor, p.Text)
+	if err != nil {
+		return nil, err
+	}
+	s.mutated()
+	return comment, nil
+}
+
+// resolveID expands partial IDs the same way the CLI does
+func (s *Server) resolveID(ctx context.Context, project *Project, id string) (string, error) {
+	if id == "" {
+		return "", &Error{Code: CodeInvalidParams, Message: "id is required"}
+	}
+	resolved, err := utils.ResolvePartialID(ctx, project.Store, id)
+	if err != nil {
+		return "", &Error{Code: CodeInvalidParams, Message: err.Error()}
+	}
+	return resolved, nil
+}
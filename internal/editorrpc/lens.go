@@ -0,0 +1,111 @@
+package editorrpc
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// LensParams are the params for lens/resolve
+type LensParams struct {
+	Text string `json:"text"` // Document contents (typically a source file)
+}
+
+// Lens is an issue reference found in a document, resolved to live status.
+// Line and character offsets are zero-based; characters are counted in UTF-16
+// code units to match LSP positions.
+type Lens struct {
+	ID        string `json:"id"`
+	Line      int    `json:"line"`
+	StartChar int    `json:"startChar"`
+	EndChar   int    `json:"endChar"`
+	Found     bool   `json:"found"`
+	Title     string `json:"title,omitempty"`
+	Status    string `json:"status,omitempty"`
+	Priority  int    `json:"priority,omitempty"`
+	Type      string `json:"type,omitempty"`
+	Assignee  string `json:"assignee,omitempty"`
+}
+
+func (s *Server) handleLensResolve(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	project, err := s.currentProject()
+	if err != nil {
+		return nil, err
+	}
+	var p LensParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	prefix, err := project.Store.GetConfig(ctx, "issue_prefix")
+	if err != nil {
+		return nil, err
+	}
+	if prefix == "" {
+		prefix = "bd"
+	}
+
+	lenses := findIssueReferences(p.Text, prefix)
+	if len(lenses) == 0 {
+		return lenses, nil
+	}
+
+	seen := make(map[string]bool)
+	var ids []string
+	for _, lens := range lenses {
+		if !seen[lens.ID] {
+			seen[lens.ID] = true
+			ids = append(ids, lens.ID)
+		}
+	}
+	issues, err := project.Store.SearchIssues(ctx, "", types.IssueFilter{IDs: ids})
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*types.Issue, len(issues))
+	for _, issue := range issues {
+		byID[issue.ID] = issue
+	}
+
+	for i := range lenses {
+		issue, ok := byID[lenses[i].ID]
+		if !ok {
+			continue
+		}
+		lenses[i].Found = true
+		lenses[i].Title = issue.Title
+		lenses[i].Status = string(issue.Status)
+		lenses[i].Priority = issue.Priority
+		lenses[i].Type = string(issue.IssueType)
+		lenses[i].Assignee = issue.Assignee
+	}
+	return lenses, nil
+}
+
+// findIssueReferences locates issue IDs with the given prefix in text,
+// including hierarchical children like "bd-a3f8.1"
+func findIssueReferences(text, prefix string) []Lens {
+	prefix = strings.TrimSuffix(prefix, "-")
+	re := regexp.MustCompile(`\b` + regexp.QuoteMeta(prefix) + `-[0-9a-z]+(?:\.[0-9]+)*\b`)
+
+	lenses := []Lens{}
+	for lineNum, line := range strings.Split(text, "\n") {
+		for _, loc := range re.FindAllStringIndex(line, -1) {
+			lenses = append(lenses, Lens{
+				ID:        line[loc[0]:loc[1]],
+				Line:      lineNum,
+				StartChar: utf16Len(line[:loc[0]]),
+				EndChar:   utf16Len(line[:loc[1]]),
+			})
+		}
+	}
+	return lenses
+}
+
+func utf16Len(s string) int {
+	return len(utf16.Encode([]rune(s)))
+}
@@ -0,0 +1,124 @@
+// Package editorrpc implements the stdio JSON-RPC 2.0 server behind
+// 'bd serve --lsp-like', a long-lived backend for editor extensions.
+//
+// Messages use LSP-style framing: a "Content-Length: N" header, a blank line,
+// and N bytes of JSON. Requests carry an id and receive exactly one response;
+// notifications (no id) are fire-and-forget. The server pushes
+// "issues/changed" notifications while a watch is active.
+package editorrpc
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Method names understood by the server
+const (
+	MethodInitialize    = "initialize"
+	MethodShutdown      = "shutdown"
+	MethodExit          = "exit"
+	MethodProjectOpen   = "project/open"
+	MethodIssuesQuery   = "issues/query"
+	MethodIssuesGet     = "issues/get"
+	MethodIssuesCreate  = "issues/create"
+	MethodIssuesUpdate  = "issues/update"
+	MethodIssuesClose   = "issues/close"
+	MethodCommentsAdd   = "comments/add"
+	MethodLensResolve   = "lens/resolve"
+	MethodWatchStart    = "watch/start"
+	MethodWatchStop     = "watch/stop"
+	NotifyIssuesChanged = "issues/changed"
+)
+
+// Standard JSON-RPC 2.0 error codes
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+	// CodeNoProject is returned when a method needs an open project
+	CodeNoProject = -32001
+)
+
+// Message is a JSON-RPC 2.0 request, response, or notification
+type Message struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+// Error is a JSON-RPC 2.0 error object
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s (code %d)", e.Message, e.Code)
+}
+
+// isNotification reports whether the message expects no response
+func (m *Message) isNotification() bool {
+	return len(m.ID) == 0 || string(m.ID) == "null"
+}
+
+// conn reads and writes framed messages. Writes are serialized so watch
+// notifications never interleave with responses.
+type conn struct {
+	r  *bufio.Reader
+	w  io.Writer
+	mu sync.Mutex
+}
+
+func newConn(r io.Reader, w io.Writer) *conn {
+	return &conn{r: bufio.NewReader(r), w: w}
+}
+
+// read returns the next message body. It returns io.EOF when the stream ends.
+func (c *conn) read() ([]byte, error) {
+	headers, err := textproto.NewReader(c.r).ReadMIMEHeader()
+	if err != nil {
+		if err == io.EOF || strings.Contains(err.Error(), "EOF") {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("failed to read headers: %w", err)
+	}
+
+	length, err := strconv.Atoi(headers.Get("Content-Length"))
+	if err != nil || length < 0 {
+		return nil, fmt.Errorf("invalid Content-Length header %q", headers.Get("Content-Length"))
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(c.r, body); err != nil {
+		return nil, fmt.Errorf("failed to read body: %w", err)
+	}
+	return body, nil
+}
+
+// write frames and sends a message
+func (c *conn) write(msg *Message) error {
+	msg.JSONRPC = "2.0"
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, err := fmt.Fprintf(c.w, "Content-Length: %d\r\n\r\n", len(data)); err != nil {
+		return err
+	}
+	_, err = c.w.Write(data)
+	return err
+}
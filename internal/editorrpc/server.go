@@ -0,0 +1,187 @@
+package editorrpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// Project is an open beads project
+type Project struct {
+	Root   string // Workspace root (parent of .beads)
+	DBPath string
+	Store  storage.Storage
+}
+
+// Config wires the server to the host process
+type Config struct {
+	Version string
+	Actor   string
+
+	// Project is the initially open project (may be nil)
+	Project *Project
+
+	// OpenProject opens the project containing path. The server calls it for
+	// project/open and replaces its current project with the result.
+	OpenProject func(ctx context.Context, path string) (*Project, error)
+
+	// OnMutate is called after every successful write so the host can
+	// schedule a JSONL flush
+	OnMutate func()
+
+	// WatchInterval is the default polling interval for watch/start
+	WatchInterval time.Duration
+}
+
+// Server handles editor requests over a single stdio connection
+type Server struct {
+	cfg  Config
+	conn *conn
+
+	mu      sync.Mutex // Protects project and watch state
+	project *Project
+	watch   *watcher
+
+	shutdown bool
+}
+
+type handlerFunc func(ctx context.Context, params json.RawMessage) (interface{}, error)
+
+// NewServer creates a server reading from r and writing to w
+func NewServer(cfg Config, r io.Reader, w io.Writer) *Server {
+	if cfg.WatchInterval <= 0 {
+		cfg.WatchInterval = time.Second
+	}
+	return &Server{
+		cfg:     cfg,
+		conn:    newConn(r, w),
+		project: cfg.Project,
+	}
+}
+
+// Serve processes messages until the client sends "exit", the input closes,
+// or ctx is canceled. Requests are handled one at a time in arrival order.
+func (s *Server) Serve(ctx context.Context) error {
+	defer s.stopWatch()
+
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		body, err := s.conn.read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var msg Message
+		if err := json.Unmarshal(body, &msg); err != nil {
+			_ = s.conn.write(&Message{ID: json.RawMessage("null"), Error: &Error{Code: CodeParseError, Message: err.Error()}})
+			continue
+		}
+
+		if msg.Method == MethodExit {
+			return nil
+		}
+
+		result, rpcErr := s.dispatch(ctx, &msg)
+		if msg.isNotification() {
+			continue
+		}
+		resp := &Message{ID: msg.ID}
+		if rpcErr != nil {
+			resp.Error = rpcErr
+		} else {
+			// JSON-RPC requires a result member on success, even if null
+			if result == nil {
+				result = struct{}{}
+			}
+			resp.Result = result
+		}
+		if err := s.conn.write(resp); err != nil {
+			return fmt.Errorf("failed to write response: %w", err)
+		}
+	}
+}
+
+func (s *Server) dispatch(ctx context.Context, msg *Message) (interface{}, *Error) {
+	if msg.Method == "" {
+		return nil, &Error{Code: CodeInvalidRequest, Message: "missing method"}
+	}
+	if s.shutdown && msg.Method != MethodExit {
+		return nil, &Error{Code: CodeInvalidRequest, Message: "server is shutting down"}
+	}
+
+	handlers := map[string]handlerFunc{
+		MethodInitialize:   s.handleInitialize,
+		MethodShutdown:     s.handleShutdown,
+		MethodProjectOpen:  s.handleProjectOpen,
+		MethodIssuesQuery:  s.handleIssuesQuery,
+		MethodIssuesGet:    s.handleIssuesGet,
+		MethodIssuesCreate: s.handleIssuesCreate,
+		MethodIssuesUpdate: s.handleIssuesUpdate,
+		MethodIssuesClose:  s.handleIssuesClose,
+		MethodCommentsAdd:  s.handleCommentsAdd,
+		MethodLensResolve:  s.handleLensResolve,
+		MethodWatchStart:   s.handleWatchStart,
+		MethodWatchStop:    s.handleWatchStop,
+	}
+
+	handler, ok := handlers[msg.Method]
+	if !ok {
+		return nil, &Error{Code: CodeMethodNotFound, Message: fmt.Sprintf("unknown method: %s", msg.Method)}
+	}
+
+	result, err := handler(ctx, msg.Params)
+	if err != nil {
+		if rpcErr, ok := err.(*Error); ok {
+			return nil, rpcErr
+		}
+		return nil, &Error{Code: CodeInternalError, Message: err.Error()}
+	}
+	return result, nil
+}
+
+// currentProject returns the open project or a CodeNoProject error
+func (s *Server) currentProject() (*Project, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.project == nil || s.project.Store == nil {
+		return nil, &Error{Code: CodeNoProject, Message: "no project open (call project/open first)"}
+	}
+	return s.project, nil
+}
+
+// notify sends a server-initiated notification
+func (s *Server) notify(method string, params interface{}) error {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	return s.conn.write(&Message{Method: method, Params: data})
+}
+
+func (s *Server) mutated() {
+	if s.cfg.OnMutate != nil {
+		s.cfg.OnMutate()
+	}
+}
+
+// decodeParams unmarshals params into v, mapping failures to CodeInvalidParams
+func decodeParams(params json.RawMessage, v interface{}) error {
+	if len(params) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(params, v); err != nil {
+		return &Error{Code: CodeInvalidParams, Message: fmt.Sprintf("invalid params: %v", err)}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package editorrpc
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// WatchParams are the params for watch/start
+type WatchParams struct {
+	IntervalMs int `json:"intervalMs,omitempty"`
+}
+
+// IssuesChangedParams is the payload of the issues/changed notification
+type IssuesChangedParams struct {
+	Issues []*types.Issue `json:"issues"`
+}
+
+// watcher polls the project store for issues updated since the last poll.
+// Polling catches writes from any process (CLI, daemon, git pulls) without
+// depending on platform file-watching support.
+type watcher struct {
+	interval time.Duration
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+func (s *Server) handleWatchStart(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	if _, err := s.currentProject(); err != nil {
+		return nil, err
+	}
+	var p WatchParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	interval := s.cfg.WatchInterval
+	if p.IntervalMs > 0 {
+		interval = time.Duration(p.IntervalMs) * time.Millisecond
+	}
+
+	s.stopWatch()
+	s.startWatch(interval)
+	return nil, nil
+}
+
+func (s *Server) handleWatchStop(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	s.stopWatch()
+	return nil, nil
+}
+
+func (s *Server) startWatch(interval time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.project == nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &watcher{interval: interval, cancel: cancel, done: make(chan struct{})}
+	s.watch = w
+	go s.pollChanges(ctx, s.project, interval, w.done)
+}
+
+func (s *Server) stopWatch() {
+	s.mu.Lock()
+	w := s.watch
+	s.watch = nil
+	s.mu.Unlock()
+
+	if w != nil {
+		w.cancel()
+		<-w.done
+	}
+}
+
+func (s *Server) pollChanges(ctx context.Context, project *Project, interval time.Duration, done chan struct{}) {
+	defer close(done)
+
+	since := time.Now()
+	// Timestamps can collide within the overlap window, so remember what was
+	// already reported instead of relying on a strict "after" comparison
+	reported := make(map[string]time.Time)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		after := since.Add(-time.Second)
+		issues, err := project.Store.SearchIssues(ctx, "", types.IssueFilter{
+			UpdatedAfter:      &after,
+			IncludeTombstones: true,
+		})
+		if err != nil {
+			continue
+		}
+
+		var changed []*types.Issue
+		for _, issue := range issues {
+			if last, ok := reported[issue.ID]; ok && last.Equal(issue.UpdatedAt) {
+				continue
+			}
+			reported[issue.ID] = issue.UpdatedAt
+			if issue.UpdatedAt.After(since) {
+				since = issue.UpdatedAt
+			}
+			changed = append(changed, issue)
+		}
+
+		if len(changed) > 0 {
+			_ = s.notify(NotifyIssuesChanged, IssuesChangedParams{Issues: changed})
+		}
+	}
+}
@@ -0,0 +1,145 @@
+// Package embeddings computes vector embeddings of issues for semantic search.
+//
+// Providers are pluggable: each registers a Factory under a name, and bd index
+// and bd search --semantic look providers up by that name. The built-in
+// "local" provider runs fully offline; "openai" calls an OpenAI-compatible
+// /embeddings endpoint (which also covers self-hosted servers such as Ollama).
+package embeddings
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Provider turns text into vectors. Implementations must return one vector per
+// input text, in order, all with the same dimensionality for a given model.
+type Provider interface {
+	// Name is the registered provider name (e.g. "local")
+	Name() string
+	// Model identifies the model; vectors from different models are never compared
+	Model() string
+	// Embed computes embeddings for texts
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// Options configure a provider. Providers ignore options they don't use.
+type Options struct {
+	Model   string
+	APIKey  string
+	BaseURL string
+}
+
+// Factory creates a provider from options
+type Factory func(opts Options) (Provider, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Factory{}
+)
+
+// Register makes a provider available by name. Registering a name twice
+// replaces the earlier factory.
+func Register(name string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[name] = factory
+}
+
+// New creates the named provider
+func New(name string, opts Options) (Provider, error) {
+	registryMu.RLock()
+	factory, ok := registry[name]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown embeddings provider %q (available: %s)", name, strings.Join(Providers(), ", "))
+	}
+	return factory(opts)
+}
+
+// Providers lists registered provider names
+func Providers() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IssueText is the text embedded for an issue: the fields a person would
+// search by meaning, not metadata like status or assignee
+func IssueText(issue *types.Issue) string {
+	parts := []string{issue.Title}
+	for _, field := range []string{issue.Description, issue.Design, issue.AcceptanceCriteria, issue.Notes} {
+		if field != "" {
+			parts = append(parts, field)
+		}
+	}
+	if len(issue.Labels) > 0 {
+		parts = append(parts, strings.Join(issue.Labels, " "))
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// TextHash identifies embedded text so unchanged issues are not re-embedded
+func TextHash(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
+
+// Cosine returns the cosine similarity of a and b, or 0 if their
+// dimensions differ or either is a zero vector
+func Cosine(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
+
+// Match is a ranked semantic search result
+type Match struct {
+	IssueID string  `json:"issue_id"`
+	Score   float64 `json:"score"`
+}
+
+// Rank orders candidates by similarity to query, best first. A limit of 0
+// returns every candidate; matches scoring at or below minScore are dropped.
+func Rank(query []float32, candidates map[string][]float32, limit int, minScore float64) []Match {
+	matches := make([]Match, 0, len(candidates))
+	for id, vec := range candidates {
+		score := Cosine(query, vec)
+		if score <= minScore {
+			continue
+		}
+		matches = append(matches, Match{IssueID: id, Score: score})
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].IssueID < matches[j].IssueID
+	})
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
@@ -0,0 +1,93 @@
+package embeddings
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLocalProviderRanksRelatedText(t *testing.T) {
+	provider, err := New("local", Options{})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	ctx := context.Background()
+
+	docs := map[string]string{
+		"bd-1": "Auth token refresh fails intermittently after expiry",
+		"bd-2": "Add dark mode to the settings page",
+		"bd-3": "Database migration drops index on upgrade",
+	}
+	ids := []string{"bd-1", "bd-2", "bd-3"}
+	texts := make([]string, len(ids))
+	for i, id := range ids {
+		texts[i] = docs[id]
+	}
+	vectors, err := provider.Embed(ctx, texts)
+	if err != nil {
+		t.Fatalf("Embed: %v", err)
+	}
+	candidates := make(map[string][]float32)
+	for i, id := range ids {
+		candidates[id] = vectors[i]
+	}
+
+	query, err := provider.Embed(ctx, []string{"flaky auth token refreshing"})
+	if err != nil {
+		t.Fatalf("Embed query: %v", err)
+	}
+	matches := Rank(query[0], candidates, 2, 0)
+	if len(matches) == 0 || matches[0].IssueID != "bd-1" {
+		t.Fatalf("matches = %+v, want bd-1 first", matches)
+	}
+
+	// Embeddings are deterministic so stored vectors stay comparable across runs
+	again, _ := provider.Embed(ctx, texts[:1])
+	if Cosine(again[0], vectors[0]) < 0.9999 {
+		t.Error("local embeddings are not deterministic")
+	}
+}
+
+func TestUnknownProvider(t *testing.T) {
+	if _, err := New("nope", Options{}); err == nil {
+		t.Error("expected error for unknown provider")
+	}
+}
+
+func TestOpenAIProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/embeddings" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		var req openAIRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req.Model != "test-model" {
+			t.Errorf("model = %s", req.Model)
+		}
+		// Respond out of order to check index handling
+		type item struct {
+			Index     int       `json:"index"`
+			Embedding []float32 `json:"embedding"`
+		}
+		var data []item
+		for i := len(req.Input) - 1; i >= 0; i-- {
+			data = append(data, item{Index: i, Embedding: []float32{float32(i), 1}})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+	}))
+	defer server.Close()
+
+	provider, err := New("openai", Options{Model: "test-model", BaseURL: server.URL + "/"})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	vectors, err := provider.Embed(context.Background(), []string{"a", "b", "c"})
+	if err != nil {
+		t.Fatalf("Embed: %v", err)
+	}
+	if len(vectors) != 3 || vectors[2][0] != 2 {
+		t.Errorf("vectors = %v", vectors)
+	}
+}
@@ -0,0 +1,104 @@
+package embeddings
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// LocalModel is the model name of the built-in offline provider. Bump the
+// version suffix whenever tokenization or hashing changes so stale vectors
+// are recomputed rather than compared against incompatible ones.
+const LocalModel = "hashed-ngrams-v1"
+
+// localDimensions balances collision rate against storage (1 KiB per issue)
+const localDimensions = 256
+
+// stopWords carry no meaning for matching issues against each other
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true,
+	"be": true, "by": true, "for": true, "from": true, "in": true, "is": true,
+	"it": true, "of": true, "on": true, "or": true, "that": true, "the": true,
+	"this": true, "to": true, "was": true, "when": true, "with": true,
+}
+
+func init() {
+	Register("local", func(opts Options) (Provider, error) {
+		return localProvider{}, nil
+	})
+}
+
+// localProvider embeds text with feature hashing over words and character
+// trigrams. It needs no network or model files, so it works offline and in
+// CI. It captures lexical overlap (including word variants such as
+// "refresh"/"refreshing") rather than true meaning; use a model-backed
+// provider for synonym-level matching.
+type localProvider struct{}
+
+func (localProvider) Name() string  { return "local" }
+func (localProvider) Model() string { return LocalModel }
+
+func (localProvider) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		vectors[i] = hashEmbed(text)
+	}
+	return vectors, nil
+}
+
+func hashEmbed(text string) []float32 {
+	counts := make(map[string]float64)
+	for _, word := range tokenize(text) {
+		counts["w:"+word]++
+		padded := "^" + word + "$"
+		runes := []rune(padded)
+		for i := 0; i+3 <= len(runes); i++ {
+			// Trigrams get less weight than whole words so exact terms dominate
+			counts["g:"+string(runes[i:i+3])] += 0.25
+		}
+	}
+
+	vec := make([]float32, localDimensions)
+	for feature, count := range counts {
+		h := fnv.New32a()
+		_, _ = h.Write([]byte(feature))
+		sum := h.Sum32()
+		weight := 1 + math.Log(1+count)
+		// The sign bit spreads collisions so they cancel out instead of accumulating
+		if sum&(1<<31) != 0 {
+			weight = -weight
+		}
+		vec[sum%localDimensions] += float32(weight)
+	}
+
+	var norm float64
+	for _, v := range vec {
+		norm += float64(v) * float64(v)
+	}
+	if norm > 0 {
+		scale := float32(1 / math.Sqrt(norm))
+		for i := range vec {
+			vec[i] *= scale
+		}
+	}
+	return vec
+}
+
+func tokenize(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	words := fields[:0]
+	for _, f := range fields {
+		if len(f) < 2 || stopWords[f] {
+			continue
+		}
+		words = append(words, f)
+	}
+	return words
+}
@@ -0,0 +1,140 @@
+package embeddings
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	defaultOpenAIBaseURL = "https://api.openai.com/v1"
+	defaultOpenAIModel   = "text-embedding-3-small"
+	// openAIBatchSize stays well under the API's per-request input limit
+	openAIBatchSize = 96
+)
+
+func init() {
+	Register("openai", newOpenAIProvider)
+}
+
+// openAIProvider calls an OpenAI-compatible /embeddings endpoint. Pointing
+// BaseURL at a local server (e.g. Ollama's /v1) keeps it offline-capable.
+type openAIProvider struct {
+	model   string
+	apiKey  string
+	baseURL string
+	client  *http.Client
+}
+
+func newOpenAIProvider(opts Options) (Provider, error) {
+	p := &openAIProvider{
+		model:   opts.Model,
+		apiKey:  opts.APIKey,
+		baseURL: opts.BaseURL,
+		client:  &http.Client{Timeout: 60 * time.Second},
+	}
+	if p.model == "" {
+		p.model = defaultOpenAIModel
+	}
+	if p.apiKey == "" {
+		p.apiKey = os.Getenv("OPENAI_API_KEY")
+	}
+	if p.baseURL == "" {
+		p.baseURL = os.Getenv("OPENAI_BASE_URL")
+	}
+	if p.baseURL == "" {
+		p.baseURL = defaultOpenAIBaseURL
+	}
+	p.baseURL = strings.TrimSuffix(p.baseURL, "/")
+
+	// Self-hosted endpoints commonly run without auth; only the hosted API requires a key
+	if p.apiKey == "" && p.baseURL == defaultOpenAIBaseURL {
+		return nil, fmt.Errorf("openai provider requires OPENAI_API_KEY (or set embeddings.base_url to a local OpenAI-compatible server)")
+	}
+	return p, nil
+}
+
+func (p *openAIProvider) Name() string  { return "openai" }
+func (p *openAIProvider) Model() string { return p.model }
+
+type openAIRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+type openAIResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (p *openAIProvider) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, 0, len(texts))
+	for start := 0; start < len(texts); start += openAIBatchSize {
+		end := min(start+openAIBatchSize, len(texts))
+		batch, err := p.embedBatch(ctx, texts[start:end])
+		if err != nil {
+			return nil, err
+		}
+		vectors = append(vectors, batch...)
+	}
+	return vectors, nil
+}
+
+func (p *openAIProvider) embedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	body, err := json.Marshal(openAIRequest{Model: p.model, Input: texts})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/embeddings", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("embeddings request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read embeddings response: %w", err)
+	}
+	var parsed openAIResponse
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, fmt.Errorf("invalid embeddings response (HTTP %d): %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		if parsed.Error != nil && parsed.Error.Message != "" {
+			return nil, fmt.Errorf("embeddings API error (HTTP %d): %s", resp.StatusCode, parsed.Error.Message)
+		}
+		return nil, fmt.Errorf("embeddings API error: HTTP %d", resp.StatusCode)
+	}
+	if len(parsed.Data) != len(texts) {
+		return nil, fmt.Errorf("embeddings API returned %d vectors for %d inputs", len(parsed.Data), len(texts))
+	}
+
+	vectors := make([][]float32, len(texts))
+	for _, d := range parsed.Data {
+		if d.Index < 0 || d.Index >= len(texts) {
+			return nil, fmt.Errorf("embeddings API returned out-of-range index %d", d.Index)
+		}
+		vectors[d.Index] = d.Embedding
+	}
+	return vectors, nil
+}
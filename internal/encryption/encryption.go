@@ -0,0 +1,484 @@
+// Package encryption encrypts selected issue text fields client-side, so
+// the database and the JSONL committed to git only ever hold ciphertext.
+//
+// The fields are listed in encryption.fields in config.yaml, which is shared
+// through git: built-in text fields or custom fields (bd field define); the key is per project and never committed. It is read from
+// BD_ENCRYPTION_KEY (encryption.key) or from the file encryption.key_file,
+// .beads/encryption.key by default. bd encrypts field values before they are
+// stored and decrypts them for display; exports encrypt anything still in
+// plaintext and refuse to write it when no key is available.
+//
+// Encrypted values look like enc:v1:<key id>:<base64 nonce+ciphertext> and
+// are sealed with AES-256-GCM, using the field name as associated data so a
+// value cannot be moved to another field. Clones without the key see and
+// round-trip the ciphertext unchanged.
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Prefix marks an encrypted value
+const Prefix = "enc:v1:"
+
+// KeyFileName is the default key file in .beads
+const KeyFileName = "encryption.key"
+
+// KeySize is the AES-256 key length in bytes
+const KeySize = 32
+
+// ErrNoKey is returned when a value must be encrypted or decrypted but no
+// key is configured
+var ErrNoKey = errors.New("no encryption key (set BD_ENCRYPTION_KEY or run 'bd encryption init')")
+
+// textFields lists the issue fields that can be encrypted, keyed by their
+// update/JSON name. Titles stay readable so lists, dependency trees and
+// search keep working.
+func textFields(issue *types.Issue) map[string]*string {
+	return map[string]*string{
+		"description":         &issue.Description,
+		"design":              &issue.Design,
+		"acceptance_criteria": &issue.AcceptanceCriteria,
+		"notes":               &issue.Notes,
+	}
+}
+
+// isTextField reports whether name is a built-in text field rather than a
+// custom field
+func isTextField(name string) bool {
+	var probe types.Issue
+	_, ok := textFields(&probe)[name]
+	return ok
+}
+
+// FieldNames returns the built-in fields accepted in encryption.fields.
+// Any other valid field name is taken to be a custom field.
+func FieldNames() []string {
+	var probe types.Issue
+	names := make([]string, 0, 4)
+	for name := range textFields(&probe) {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Cipher encrypts and decrypts the configured fields. A nil Cipher encrypts
+// nothing; one without a key passes ciphertext through but cannot encrypt.
+type Cipher struct {
+	fields []string
+	aead   cipher.AEAD
+	keyID  string
+}
+
+// New returns a Cipher for fields, built-in text fields or custom field
+// names. key may be nil when it is not available.
+func New(fields []string, key []byte) (*Cipher, error) {
+	c := &Cipher{}
+	for _, field := range fields {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if field == "title" {
+			return nil, fmt.Errorf("cannot encrypt the title: titles stay readable so lists and dependency trees keep working")
+		}
+		if !isTextField(field) && types.ValidateFieldName(field) != nil {
+			return nil, fmt.Errorf("cannot encrypt field %q: not one of %s or a custom field name", field, strings.Join(FieldNames(), ", "))
+		}
+		c.fields = append(c.fields, field)
+	}
+	sort.Strings(c.fields)
+	if key == nil {
+		return c, nil
+	}
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("encryption key must be %d bytes, got %d", KeySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if c.aead, err = cipher.NewGCM(block); err != nil {
+		return nil, err
+	}
+	c.keyID = KeyID(key)
+	return c, nil
+}
+
+// FromConfig builds the Cipher configured by encryption.* for the project in
+// beadsDir. It returns nil when no fields are encrypted and there is no key;
+// a key alone still decrypts values from before a field was dropped.
+func FromConfig(beadsDir string) (*Cipher, error) {
+	key, err := configuredKey(beadsDir)
+	if err != nil {
+		return nil, err
+	}
+	fields := config.GetStringSlice("encryption.fields")
+	if len(fields) == 0 && key == nil {
+		return nil, nil
+	}
+	return New(fields, key)
+}
+
+// KeyPath returns the key file for the project in beadsDir
+func KeyPath(beadsDir string) string {
+	if path := config.GetString("encryption.key_file"); path != "" {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(beadsDir, path)
+		}
+		return path
+	}
+	return filepath.Join(beadsDir, KeyFileName)
+}
+
+// configuredKey returns the key from BD_ENCRYPTION_KEY or the key file, or
+// nil when neither exists
+func configuredKey(beadsDir string) ([]byte, error) {
+	if encoded := config.GetString("encryption.key"); encoded != "" {
+		return ParseKey(encoded)
+	}
+	// #nosec G304 - the key file is inside the beads directory or configured by the user
+	data, err := os.ReadFile(KeyPath(beadsDir))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read encryption key: %w", err)
+	}
+	return ParseKey(string(data))
+}
+
+// GenerateKey returns a new random key
+func GenerateKey() ([]byte, error) {
+	key := make([]byte, KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// EncodeKey returns the text form of a key, as stored in the key file
+func EncodeKey(key []byte) string {
+	return base64.StdEncoding.EncodeToString(key)
+}
+
+// ParseKey decodes a key in the form EncodeKey writes
+func ParseKey(s string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption key: %w", err)
+	}
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("invalid encryption key: must be %d bytes, got %d", KeySize, len(key))
+	}
+	return key, nil
+}
+
+// KeyID identifies a key without revealing it
+func KeyID(key []byte) string {
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:4])
+}
+
+// IsEncrypted reports whether s is an encrypted value
+func IsEncrypted(s string) bool {
+	return strings.HasPrefix(s, Prefix)
+}
+
+// HasEncrypted reports whether any text or custom field of issue is
+// encrypted
+func HasEncrypted(issue *types.Issue) bool {
+	if issue == nil {
+		return false
+	}
+	for _, ptr := range textFields(issue) {
+		if IsEncrypted(*ptr) {
+			return true
+		}
+	}
+	for _, value := range issue.Fields {
+		if IsEncrypted(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Enabled reports whether any field is encrypted
+func (c *Cipher) Enabled() bool {
+	return c != nil && len(c.fields) > 0
+}
+
+// HasKey reports whether values can be encrypted and decrypted
+func (c *Cipher) HasKey() bool {
+	return c != nil && c.aead != nil
+}
+
+// Fields returns the encrypted fields
+func (c *Cipher) Fields() []string {
+	if c == nil {
+		return nil
+	}
+	return c.fields
+}
+
+// KeyID returns the ID of the configured key, or "" without one
+func (c *Cipher) KeyID() string {
+	if c == nil {
+		return ""
+	}
+	return c.keyID
+}
+
+// Encrypt seals the value of field. Empty and already encrypted values are
+// returned unchanged.
+func (c *Cipher) Encrypt(field, s string) (string, error) {
+	if s == "" || IsEncrypted(s) {
+		return s, nil
+	}
+	if !c.HasKey() {
+		return "", ErrNoKey
+	}
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := c.aead.Seal(nonce, nonce, []byte(s), []byte(field))
+	return Prefix + c.keyID + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt opens the value of field. Values that are not encrypted are
+// returned unchanged.
+func (c *Cipher) Decrypt(field, s string) (string, error) {
+	if !IsEncrypted(s) {
+		return s, nil
+	}
+	if !c.HasKey() {
+		return "", ErrNoKey
+	}
+	keyID, encoded, ok := strings.Cut(strings.TrimPrefix(s, Prefix), ":")
+	if !ok {
+		return "", fmt.Errorf("malformed encrypted %s", field)
+	}
+	if keyID != c.keyID {
+		return "", fmt.Errorf("%s was encrypted with key %s, but the configured key is %s", field, keyID, c.keyID)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < c.aead.NonceSize() {
+		return "", fmt.Errorf("malformed encrypted %s", field)
+	}
+	nonce, ciphertext := sealed[:c.aead.NonceSize()], sealed[c.aead.NonceSize():]
+	plain, err := c.aead.Open(nil, nonce, ciphertext, []byte(field))
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt %s: %w", field, err)
+	}
+	return string(plain), nil
+}
+
+// EncryptIssue encrypts the configured fields of issue in place. Custom
+// field values are encrypted in a new map, as copies of an issue share one.
+func (c *Cipher) EncryptIssue(issue *types.Issue) error {
+	if !c.Enabled() || issue == nil {
+		return nil
+	}
+	fields := textFields(issue)
+	for _, field := range c.fields {
+		ptr, ok := fields[field]
+		if !ok {
+			continue
+		}
+		out, err := c.Encrypt(field, *ptr)
+		if err != nil {
+			return fmt.Errorf("%s: %w", issue.ID, err)
+		}
+		*ptr = out
+	}
+	custom, err := c.EncryptFields(issue.Fields)
+	if err != nil {
+		return fmt.Errorf("%s: %w", issue.ID, err)
+	}
+	issue.Fields = custom
+	return nil
+}
+
+// EncryptFields returns custom field values with the configured fields
+// encrypted. values itself is not modified.
+func (c *Cipher) EncryptFields(values types.CustomFields) (types.CustomFields, error) {
+	if !c.Enabled() {
+		return values, nil
+	}
+	var result types.CustomFields
+	for _, field := range c.fields {
+		value, ok := values[field]
+		if !ok || isTextField(field) {
+			continue
+		}
+		out, err := c.Encrypt(field, value)
+		if err != nil {
+			return nil, err
+		}
+		if out == value {
+			continue
+		}
+		if result == nil {
+			result = maps.Clone(values)
+		}
+		result[field] = out
+	}
+	if result == nil {
+		return values, nil
+	}
+	return result, nil
+}
+
+// DecryptIssue decrypts every encrypted field of issue in place, including
+// fields that are no longer configured for encryption. Custom field values
+// are decrypted into a new map.
+func (c *Cipher) DecryptIssue(issue *types.Issue) error {
+	if issue == nil {
+		return nil
+	}
+	for field, ptr := range textFields(issue) {
+		out, err := c.Decrypt(field, *ptr)
+		if err != nil {
+			return fmt.Errorf("%s: %w", issue.ID, err)
+		}
+		*ptr = out
+	}
+	var custom types.CustomFields
+	for field, value := range issue.Fields {
+		if !IsEncrypted(value) {
+			continue
+		}
+		out, err := c.Decrypt(field, value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", issue.ID, err)
+		}
+		if custom == nil {
+			custom = maps.Clone(issue.Fields)
+		}
+		custom[field] = out
+	}
+	if custom != nil {
+		issue.Fields = custom
+	}
+	return nil
+}
+
+// EncryptUpdates encrypts string values of the configured fields in an
+// UpdateIssue map, and custom field values under "fields"
+func (c *Cipher) EncryptUpdates(updates map[string]interface{}) error {
+	if !c.Enabled() {
+		return nil
+	}
+	for _, field := range c.fields {
+		s, ok := updates[field].(string)
+		if !ok || !isTextField(field) {
+			continue
+		}
+		out, err := c.Encrypt(field, s)
+		if err != nil {
+			return err
+		}
+		updates[field] = out
+	}
+	if values, ok := updates["fields"].(types.CustomFields); ok {
+		custom, err := c.EncryptFields(values)
+		if err != nil {
+			return err
+		}
+		updates["fields"] = custom
+	}
+	return nil
+}
+
+// Plaintext returns the configured fields of issue that are not encrypted
+func (c *Cipher) Plaintext(issue *types.Issue) []string {
+	if !c.Enabled() || issue == nil {
+		return nil
+	}
+	fields := textFields(issue)
+	var plain []string
+	for _, field := range c.fields {
+		s := issue.Fields[field]
+		if ptr, ok := fields[field]; ok {
+			s = *ptr
+		}
+		if s != "" && !IsEncrypted(s) {
+			plain = append(plain, field)
+		}
+	}
+	return plain
+}
+
+// SealUpdates returns the UpdateIssue map that encrypts the configured text
+// fields issue still stores in plaintext, or nil when there are none.
+// SealFields does the same for custom fields.
+func (c *Cipher) SealUpdates(issue *types.Issue) (map[string]interface{}, error) {
+	fields := textFields(issue)
+	var updates map[string]interface{}
+	for _, field := range c.Plaintext(issue) {
+		if _, ok := fields[field]; !ok {
+			continue
+		}
+		if updates == nil {
+			updates = make(map[string]interface{})
+		}
+		out, err := c.Encrypt(field, *fields[field])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", issue.ID, err)
+		}
+		updates[field] = out
+	}
+	return updates, nil
+}
+
+// SealFields returns the encrypted values of the configured custom fields
+// issue still stores in plaintext, or nil when there are none
+func (c *Cipher) SealFields(issue *types.Issue) (types.CustomFields, error) {
+	var sealed types.CustomFields
+	for _, field := range c.Plaintext(issue) {
+		if isTextField(field) {
+			continue
+		}
+		out, err := c.Encrypt(field, issue.Fields[field])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", issue.ID, err)
+		}
+		if sealed == nil {
+			sealed = make(types.CustomFields)
+		}
+		sealed[field] = out
+	}
+	return sealed, nil
+}
+
+// ForExport returns a copy of issue with the configured fields encrypted, or
+// issue itself when they already are. Without a key, plaintext in an
+// encrypted field is an error rather than a leak.
+func (c *Cipher) ForExport(issue *types.Issue) (*types.Issue, error) {
+	if len(c.Plaintext(issue)) == 0 {
+		return issue, nil
+	}
+	clone := *issue
+	if err := c.EncryptIssue(&clone); err != nil {
+		return nil, err
+	}
+	return &clone, nil
+}
@@ -0,0 +1,151 @@
+package encryption
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func newTestCipher(t *testing.T, fields ...string) *Cipher {
+	t.Helper()
+	key, err := GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := New(fields, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestEncryptIssueRoundTrip(t *testing.T) {
+	c := newTestCipher(t, "description", "notes")
+	issue := &types.Issue{ID: "bd-1", Title: "Refund for ACME", Description: "customer: Jane Roe", Notes: "call back"}
+
+	if err := c.EncryptIssue(issue); err != nil {
+		t.Fatal(err)
+	}
+	if issue.Title != "Refund for ACME" {
+		t.Errorf("title was encrypted: %q", issue.Title)
+	}
+	if !IsEncrypted(issue.Description) || strings.Contains(issue.Description, "Jane") || !IsEncrypted(issue.Notes) {
+		t.Fatalf("fields not encrypted: %+v", issue)
+	}
+	sealed := issue.Description
+	if err := c.EncryptIssue(issue); err != nil || issue.Description != sealed {
+		t.Errorf("encrypting twice changed the value: %v", err)
+	}
+
+	if err := c.DecryptIssue(issue); err != nil {
+		t.Fatal(err)
+	}
+	if issue.Description != "customer: Jane Roe" || issue.Notes != "call back" {
+		t.Errorf("round trip = %+v", issue)
+	}
+}
+
+func TestDecryptRejectsWrongKeyAndField(t *testing.T) {
+	c := newTestCipher(t, "description")
+	sealed, err := c.Encrypt("description", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Decrypt("notes", sealed); err == nil {
+		t.Error("a value moved to another field should not decrypt")
+	}
+	other := newTestCipher(t, "description")
+	if _, err := other.Decrypt("description", sealed); err == nil || !strings.Contains(err.Error(), c.KeyID()) {
+		t.Errorf("wrong key: %v", err)
+	}
+	keyless, _ := New([]string{"description"}, nil)
+	if _, err := keyless.Decrypt("description", sealed); !errors.Is(err, ErrNoKey) {
+		t.Errorf("keyless decrypt: %v", err)
+	}
+}
+
+func TestForExport(t *testing.T) {
+	c := newTestCipher(t, "description")
+	issue := &types.Issue{ID: "bd-1", Description: "plain"}
+	exported, err := c.ForExport(issue)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if issue.Description != "plain" || !IsEncrypted(exported.Description) {
+		t.Errorf("ForExport mutated the issue or left plaintext: %q, %q", issue.Description, exported.Description)
+	}
+
+	// Without the key ciphertext passes through and plaintext is refused
+	keyless, _ := New([]string{"description"}, nil)
+	if out, err := keyless.ForExport(exported); err != nil || out != exported {
+		t.Errorf("keyless export of ciphertext: %v", err)
+	}
+	if _, err := keyless.ForExport(issue); !errors.Is(err, ErrNoKey) {
+		t.Errorf("keyless export of plaintext: %v", err)
+	}
+
+	var none *Cipher
+	if out, err := none.ForExport(issue); err != nil || out != issue {
+		t.Error("nil cipher should export issues unchanged")
+	}
+}
+
+func TestNewRejectsUnknownField(t *testing.T) {
+	for _, field := range []string{"Customer Name", "title"} {
+		if _, err := New([]string{field}, nil); err == nil {
+			t.Errorf("expected an error for field %q", field)
+		}
+	}
+	if _, err := New([]string{"customer_name"}, nil); err != nil {
+		t.Errorf("custom field: %v", err)
+	}
+	if _, err := ParseKey("c2hvcnQ="); err == nil {
+		t.Error("expected an error for a short key")
+	}
+}
+
+func TestEncryptCustomFields(t *testing.T) {
+	c := newTestCipher(t, "description", "customer_name")
+	shared := types.CustomFields{"customer_name": "Jane Roe", "severity": "high"}
+	issue := &types.Issue{ID: "bd-1", Description: "refund", Fields: shared}
+
+	if got := c.Plaintext(issue); len(got) != 2 {
+		t.Errorf("Plaintext = %v, want description and customer_name", got)
+	}
+	sealed, err := c.SealFields(issue)
+	if err != nil || len(sealed) != 1 || !IsEncrypted(sealed["customer_name"]) {
+		t.Fatalf("SealFields = %v, %v", sealed, err)
+	}
+
+	exported, err := c.ForExport(issue)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsEncrypted(exported.Fields["customer_name"]) || exported.Fields["severity"] != "high" {
+		t.Fatalf("exported fields = %v", exported.Fields)
+	}
+	if shared["customer_name"] != "Jane Roe" {
+		t.Error("ForExport changed the fields of the original issue")
+	}
+	if !HasEncrypted(&types.Issue{Fields: exported.Fields}) {
+		t.Error("HasEncrypted missed an encrypted custom field")
+	}
+
+	plain := *exported
+	if err := c.DecryptIssue(&plain); err != nil {
+		t.Fatal(err)
+	}
+	if plain.Fields["customer_name"] != "Jane Roe" || !IsEncrypted(exported.Fields["customer_name"]) {
+		t.Errorf("decrypted fields = %v, exported = %v", plain.Fields, exported.Fields)
+	}
+
+	updates := map[string]interface{}{"fields": types.CustomFields{"customer_name": "John Doe"}}
+	if err := c.EncryptUpdates(updates); err != nil {
+		t.Fatal(err)
+	}
+	if !IsEncrypted(updates["fields"].(types.CustomFields)["customer_name"]) {
+		t.Errorf("EncryptUpdates left the custom field in plaintext: %v", updates)
+	}
+}
@@ -0,0 +1,386 @@
+package eventbus
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// AMQP 0-9-1 frame types and the methods used by the publisher
+const (
+	amqpFrameMethod    = 1
+	amqpFrameHeader    = 2
+	amqpFrameBody      = 3
+	amqpFrameHeartbeat = 8
+	amqpFrameEnd       = 0xCE
+
+	amqpDefaultFrameMax = 131072
+)
+
+type amqpMethod struct{ class, method uint16 }
+
+var (
+	amqpConnectionStart   = amqpMethod{10, 10}
+	amqpConnectionStartOk = amqpMethod{10, 11}
+	amqpConnectionTune    = amqpMethod{10, 30}
+	amqpConnectionTuneOk  = amqpMethod{10, 31}
+	amqpConnectionOpen    = amqpMethod{10, 40}
+	amqpConnectionOpenOk  = amqpMethod{10, 41}
+	amqpConnectionClose   = amqpMethod{10, 50}
+	amqpConnectionCloseOk = amqpMethod{10, 51}
+	amqpChannelOpen       = amqpMethod{20, 10}
+	amqpChannelOpenOk     = amqpMethod{20, 11}
+	amqpChannelClose      = amqpMethod{20, 40}
+	amqpExchangeDeclare   = amqpMethod{40, 10}
+	amqpExchangeDeclareOk = amqpMethod{40, 11}
+	amqpBasicPublish      = amqpMethod{60, 40}
+	amqpBasicAck          = amqpMethod{60, 80}
+	amqpBasicNack         = amqpMethod{60, 120}
+	amqpConfirmSelect     = amqpMethod{85, 10}
+	amqpConfirmSelectOk   = amqpMethod{85, 11}
+)
+
+// amqpConn reads and writes AMQP frames on a connection
+type amqpConn struct {
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+func newAMQPConn(conn net.Conn) *amqpConn {
+	return &amqpConn{conn: conn, r: bufio.NewReader(conn)}
+}
+
+func (c *amqpConn) writeFrame(frameType byte, channel uint16, payload []byte) error {
+	frame := make([]byte, 0, len(payload)+8)
+	frame = append(frame, frameType)
+	frame = binary.BigEndian.AppendUint16(frame, channel)
+	frame = binary.BigEndian.AppendUint32(frame, uint32(len(payload))) // #nosec G115 - payloads are bounded by frame-max
+	frame = append(frame, payload...)
+	frame = append(frame, amqpFrameEnd)
+	_, err := c.conn.Write(frame)
+	return err
+}
+
+func (c *amqpConn) readFrame() (frameType byte, channel uint16, payload []byte, err error) {
+	var header [7]byte
+	if _, err := io.ReadFull(c.r, header[:]); err != nil {
+		return 0, 0, nil, err
+	}
+	size := binary.BigEndian.Uint32(header[3:])
+	if size > 16<<20 {
+		return 0, 0, nil, fmt.Errorf("amqp: frame of %d bytes is too large", size)
+	}
+	payload = make([]byte, size+1)
+	if _, err := io.ReadFull(c.r, payload); err != nil {
+		return 0, 0, nil, err
+	}
+	if payload[size] != amqpFrameEnd {
+		return 0, 0, nil, fmt.Errorf("amqp: malformed frame")
+	}
+	return header[0], binary.BigEndian.Uint16(header[1:3]), payload[:size], nil
+}
+
+func (c *amqpConn) writeMethod(channel uint16, m amqpMethod, args []byte) error {
+	payload := binary.BigEndian.AppendUint16(nil, m.class)
+	payload = binary.BigEndian.AppendUint16(payload, m.method)
+	return c.writeFrame(amqpFrameMethod, channel, append(payload, args...))
+}
+
+// readMethod returns the next method frame, skipping heartbeats. A close
+// from the broker is turned into an error carrying its reply text.
+func (c *amqpConn) readMethod() (amqpMethod, []byte, error) {
+	for {
+		frameType, _, payload, err := c.readFrame()
+		if err != nil {
+			return amqpMethod{}, nil, err
+		}
+		if frameType == amqpFrameHeartbeat {
+			continue
+		}
+		if frameType != amqpFrameMethod || len(payload) < 4 {
+			return amqpMethod{}, nil, fmt.Errorf("amqp: unexpected frame type %d", frameType)
+		}
+		m := amqpMethod{binary.BigEndian.Uint16(payload), binary.BigEndian.Uint16(payload[2:])}
+		args := payload[4:]
+		if m == amqpConnectionClose || m == amqpChannelClose {
+			scope := "channel"
+			if m == amqpConnectionClose {
+				scope = "connection"
+			}
+			d := amqpDecoder{b: args}
+			code, text := d.short(), d.shortstr()
+			return m, nil, fmt.Errorf("amqp: broker closed the %s: %d %s", scope, code, text)
+		}
+		return m, args, nil
+	}
+}
+
+func (c *amqpConn) expect(want amqpMethod) ([]byte, error) {
+	m, args, err := c.readMethod()
+	if err != nil {
+		return nil, err
+	}
+	if m != want {
+		return nil, fmt.Errorf("amqp: expected method %d.%d, got %d.%d", want.class, want.method, m.class, m.method)
+	}
+	return args, nil
+}
+
+// amqpEncoder builds method arguments
+type amqpEncoder struct{ bytes.Buffer }
+
+func (e *amqpEncoder) octet(v byte)      { e.WriteByte(v) }
+func (e *amqpEncoder) short(v uint16)    { _ = binary.Write(e, binary.BigEndian, v) }
+func (e *amqpEncoder) long(v uint32)     { _ = binary.Write(e, binary.BigEndian, v) }
+func (e *amqpEncoder) longlong(v uint64) { _ = binary.Write(e, binary.BigEndian, v) }
+
+func (e *amqpEncoder) shortstr(s string) {
+	if len(s) > 255 {
+		s = s[:255]
+	}
+	e.octet(byte(len(s)))
+	e.WriteString(s)
+}
+
+func (e *amqpEncoder) longstr(s string) {
+	e.long(uint32(len(s))) // #nosec G115 - strings here are short
+	e.WriteString(s)
+}
+
+// table writes a field table of string values
+func (e *amqpEncoder) table(fields map[string]string) {
+	var t amqpEncoder
+	for k, v := range fields {
+		t.shortstr(k)
+		t.octet('S')
+		t.longstr(v)
+	}
+	e.long(uint32(t.Len())) // #nosec G115 - tables here are small
+	e.Write(t.Bytes())
+}
+
+// amqpDecoder reads method arguments. Reads past the end yield zero numbers
+// and empty strings.
+type amqpDecoder struct {
+	b   []byte
+	off int
+}
+
+func (d *amqpDecoder) take(n int) []byte {
+	if n < 0 || d.off+n > len(d.b) {
+		d.off = len(d.b)
+		if n > 8 || n < 0 {
+			return nil
+		}
+		return make([]byte, n)
+	}
+	v := d.b[d.off : d.off+n]
+	d.off += n
+	return v
+}
+
+func (d *amqpDecoder) octet() byte      { return d.take(1)[0] }
+func (d *amqpDecoder) short() uint16    { return binary.BigEndian.Uint16(d.take(2)) }
+func (d *amqpDecoder) long() uint32     { return binary.BigEndian.Uint32(d.take(4)) }
+func (d *amqpDecoder) longlong() uint64 { return binary.BigEndian.Uint64(d.take(8)) }
+func (d *amqpDecoder) shortstr() string { return string(d.take(int(d.octet()))) }
+func (d *amqpDecoder) longstr() string  { return string(d.take(int(d.long()))) }
+
+// amqpPublisher publishes to a durable topic exchange on channel 1 with
+// publisher confirms, waiting for the broker's ack after each message
+type amqpPublisher struct {
+	*amqpConn
+	exchange    string
+	frameMax    uint32
+	deliveryTag uint64
+}
+
+func dialAMQP(ctx context.Context, u *url.URL, exchange string) (Publisher, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", hostPort(u, "5672"))
+	if err != nil {
+		return nil, fmt.Errorf("amqp connect: %w", err)
+	}
+	p := &amqpPublisher{amqpConn: newAMQPConn(conn), exchange: exchange}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	if err := p.handshake(u); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	_ = conn.SetDeadline(time.Time{})
+	return p, nil
+}
+
+func (p *amqpPublisher) handshake(u *url.URL) error {
+	if _, err := p.conn.Write([]byte("AMQP\x00\x00\x09\x01")); err != nil {
+		return fmt.Errorf("amqp: %w", err)
+	}
+	args, err := p.expect(amqpConnectionStart)
+	if err != nil {
+		return err
+	}
+	d := amqpDecoder{b: args}
+	d.take(2)             // protocol version
+	d.take(int(d.long())) // server properties
+	if mechanisms := d.longstr(); !strings.Contains(" "+mechanisms+" ", " PLAIN ") {
+		return fmt.Errorf("amqp: broker does not offer PLAIN auth (offers %q)", mechanisms)
+	}
+
+	user, pass := "guest", "guest"
+	if u.User != nil {
+		user = u.User.Username()
+		pass, _ = u.User.Password()
+	}
+	var startOk amqpEncoder
+	startOk.table(map[string]string{"product": "beads"})
+	startOk.shortstr("PLAIN")
+	startOk.longstr("\x00" + user + "\x00" + pass)
+	startOk.shortstr("en_US")
+	if err := p.writeMethod(0, amqpConnectionStartOk, startOk.Bytes()); err != nil {
+		return fmt.Errorf("amqp: %w", err)
+	}
+
+	if args, err = p.expect(amqpConnectionTune); err != nil {
+		return err
+	}
+	d = amqpDecoder{b: args}
+	channelMax, frameMax := d.short(), d.long()
+	if frameMax == 0 || frameMax > amqpDefaultFrameMax {
+		frameMax = amqpDefaultFrameMax
+	}
+	p.frameMax = frameMax
+	var tuneOk amqpEncoder
+	tuneOk.short(channelMax)
+	tuneOk.long(frameMax)
+	tuneOk.short(0) // no heartbeats: publishes are short request/acks
+	if err := p.writeMethod(0, amqpConnectionTuneOk, tuneOk.Bytes()); err != nil {
+		return fmt.Errorf("amqp: %w", err)
+	}
+
+	var open amqpEncoder
+	open.shortstr(amqpVHost(u))
+	open.shortstr("")
+	open.octet(0)
+	if err := p.writeMethod(0, amqpConnectionOpen, open.Bytes()); err != nil {
+		return fmt.Errorf("amqp: %w", err)
+	}
+	if _, err := p.expect(amqpConnectionOpenOk); err != nil {
+		return err
+	}
+
+	var channelOpen amqpEncoder
+	channelOpen.shortstr("")
+	if err := p.writeMethod(1, amqpChannelOpen, channelOpen.Bytes()); err != nil {
+		return fmt.Errorf("amqp: %w", err)
+	}
+	if _, err := p.expect(amqpChannelOpenOk); err != nil {
+		return err
+	}
+
+	var declare amqpEncoder
+	declare.short(0)
+	declare.shortstr(p.exchange)
+	declare.shortstr("topic")
+	declare.octet(0x02) // durable
+	declare.table(nil)
+	if err := p.writeMethod(1, amqpExchangeDeclare, declare.Bytes()); err != nil {
+		return fmt.Errorf("amqp: %w", err)
+	}
+	if _, err := p.expect(amqpExchangeDeclareOk); err != nil {
+		return err
+	}
+
+	if err := p.writeMethod(1, amqpConfirmSelect, []byte{0}); err != nil {
+		return fmt.Errorf("amqp: %w", err)
+	}
+	_, err = p.expect(amqpConfirmSelectOk)
+	return err
+}
+
+// amqpVHost maps the URL path to a virtual host: none means "/", and the
+// rest is the unescaped path ("amqp://host/%2f" is "/" too)
+func amqpVHost(u *url.URL) string {
+	if u.Path == "" || u.Path == "/" {
+		return "/"
+	}
+	return strings.TrimPrefix(u.Path, "/")
+}
+
+// Publish sends a persistent JSON message with routing key key and waits
+// for the broker's confirm
+func (p *amqpPublisher) Publish(ctx context.Context, key string, payload []byte) error {
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = p.conn.SetDeadline(deadline)
+		defer func() { _ = p.conn.SetDeadline(time.Time{}) }()
+	}
+
+	var publish amqpEncoder
+	publish.short(0)
+	publish.shortstr(p.exchange)
+	publish.shortstr(key)
+	publish.octet(0)
+	if err := p.writeMethod(1, amqpBasicPublish, publish.Bytes()); err != nil {
+		return fmt.Errorf("amqp publish: %w", err)
+	}
+
+	var header amqpEncoder
+	header.short(amqpBasicPublish.class)
+	header.short(0) // weight
+	header.longlong(uint64(len(payload)))
+	header.short(0x8000 | 0x1000) // content-type, delivery-mode
+	header.shortstr("application/json")
+	header.octet(2) // persistent
+	if err := p.writeFrame(amqpFrameHeader, 1, header.Bytes()); err != nil {
+		return fmt.Errorf("amqp publish: %w", err)
+	}
+	chunk := int(p.frameMax) - 8
+	for len(payload) > 0 {
+		n := min(chunk, len(payload))
+		if err := p.writeFrame(amqpFrameBody, 1, payload[:n]); err != nil {
+			return fmt.Errorf("amqp publish: %w", err)
+		}
+		payload = payload[n:]
+	}
+	p.deliveryTag++
+
+	for {
+		m, args, err := p.readMethod()
+		if err != nil {
+			return err
+		}
+		if m != amqpBasicAck && m != amqpBasicNack {
+			continue
+		}
+		d := amqpDecoder{b: args}
+		tag, multiple := d.longlong(), d.octet()&1 == 1
+		if tag != p.deliveryTag && !(multiple && tag >= p.deliveryTag) {
+			continue
+		}
+		if m == amqpBasicNack {
+			return fmt.Errorf("amqp: broker rejected message %d", tag)
+		}
+		return nil
+	}
+}
+
+func (p *amqpPublisher) Close() error {
+	var closeArgs amqpEncoder
+	closeArgs.short(200)
+	closeArgs.shortstr("bye")
+	closeArgs.short(0)
+	closeArgs.short(0)
+	_ = p.conn.SetDeadline(time.Now().Add(time.Second))
+	if err := p.writeMethod(0, amqpConnectionClose, closeArgs.Bytes()); err == nil {
+		_, _ = p.expect(amqpConnectionCloseOk)
+	}
+	return p.conn.Close()
+}
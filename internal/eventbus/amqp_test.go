@@ -0,0 +1,159 @@
+package eventbus
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+)
+
+// fakeAMQP plays the broker side of the publisher's handshake and confirms
+// each message, reporting the exchange, routing key and body it received
+func fakeAMQP(t *testing.T, nack bool) (addr string, published chan [3]string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+	published = make(chan [3]string, 10)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+		c := newAMQPConn(conn)
+		header := make([]byte, 8)
+		if _, err := io.ReadFull(c.r, header); err != nil || string(header) != "AMQP\x00\x00\x09\x01" {
+			return
+		}
+
+		var start amqpEncoder
+		start.octet(0)
+		start.octet(9)
+		start.table(map[string]string{"product": "fake"})
+		start.longstr("AMQPLAIN PLAIN")
+		start.longstr("en_US")
+		_ = c.writeMethod(0, amqpConnectionStart, start.Bytes())
+		args, err := c.expect(amqpConnectionStartOk)
+		if err != nil {
+			return
+		}
+		d := amqpDecoder{b: args}
+		d.take(int(d.long()))
+		if d.shortstr() != "PLAIN" || d.longstr() != "\x00bd\x00pw" {
+			return
+		}
+
+		var tune amqpEncoder
+		tune.short(2047)
+		tune.long(4096)
+		tune.short(60)
+		_ = c.writeMethod(0, amqpConnectionTune, tune.Bytes())
+		if _, err := c.expect(amqpConnectionTuneOk); err != nil {
+			return
+		}
+		if args, err = c.expect(amqpConnectionOpen); err != nil {
+			return
+		}
+		if d := (amqpDecoder{b: args}); d.shortstr() != "ops" {
+			return
+		}
+		_ = c.writeMethod(0, amqpConnectionOpenOk, []byte{0})
+		if _, err := c.expect(amqpChannelOpen); err != nil {
+			return
+		}
+		_ = c.writeMethod(1, amqpChannelOpenOk, []byte{0, 0, 0, 0})
+		if args, err = c.expect(amqpExchangeDeclare); err != nil {
+			return
+		}
+		d = amqpDecoder{b: args}
+		d.short()
+		exchange := d.shortstr()
+		_ = c.writeMethod(1, amqpExchangeDeclareOk, nil)
+		if _, err := c.expect(amqpConfirmSelect); err != nil {
+			return
+		}
+		_ = c.writeMethod(1, amqpConfirmSelectOk, nil)
+
+		for tag := uint64(1); ; tag++ {
+			m, args, err := c.readMethod()
+			if err != nil || m != amqpBasicPublish {
+				return
+			}
+			d := amqpDecoder{b: args}
+			d.short()
+			if d.shortstr() != exchange {
+				return
+			}
+			key := d.shortstr()
+			_, _, headerFrame, err := c.readFrame()
+			if err != nil {
+				return
+			}
+			hd := amqpDecoder{b: headerFrame}
+			hd.short()
+			hd.short()
+			size := hd.longlong()
+			var body []byte
+			for uint64(len(body)) < size {
+				_, _, chunk, err := c.readFrame()
+				if err != nil {
+					return
+				}
+				body = append(body, chunk...)
+			}
+			published <- [3]string{exchange, key, string(body)}
+
+			var ack amqpEncoder
+			ack.longlong(tag)
+			ack.octet(0)
+			reply := amqpBasicAck
+			if nack {
+				reply = amqpBasicNack
+			}
+			_ = c.writeMethod(1, reply, ack.Bytes())
+		}
+	}()
+	return ln.Addr().String(), published
+}
+
+func TestAMQPPublish(t *testing.T) {
+	addr, published := fakeAMQP(t, false)
+	ctx := context.Background()
+
+	pub, err := Dial(ctx, Config{Kind: KindAMQP, URL: "amqp://bd:pw@" + addr + "/ops", Topic: "beads"})
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer func() { _ = pub.Close() }()
+
+	// Larger than one 4096-byte frame, so the body is split
+	body := make([]byte, 10000)
+	for i := range body {
+		body[i] = 'x'
+	}
+	for _, payload := range []string{`{"id":1}`, string(body)} {
+		if err := pub.Publish(ctx, "created", []byte(payload)); err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+		got := <-published
+		if got[0] != "beads" || got[1] != "created" || got[2] != payload {
+			t.Errorf("broker got exchange %q key %q body of %d bytes", got[0], got[1], len(got[2]))
+		}
+	}
+}
+
+func TestAMQPNackIsAnError(t *testing.T) {
+	addr, _ := fakeAMQP(t, true)
+	ctx := context.Background()
+	pub, err := Dial(ctx, Config{Kind: KindAMQP, URL: "amqp://bd:pw@" + addr + "/ops"})
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer func() { _ = pub.Close() }()
+	if err := pub.Publish(ctx, "created", []byte(`{}`)); err == nil {
+		t.Fatal("expected a nacked publish to fail")
+	}
+}
@@ -0,0 +1,96 @@
+// Package eventbus publishes issue events from the events table to message
+// buses (NATS, AMQP 0-9-1 such as RabbitMQ) with at-least-once delivery.
+//
+// The events table is the buffer: a Relay publishes events in ID order and
+// only advances its persisted cursor after the broker has acknowledged an
+// event, so events written while the broker is down (or while the daemon is
+// stopped) are delivered once it is reachable again. Consumers should
+// deduplicate on the event ID, since a crash between the acknowledgement and
+// the cursor write republishes the last event.
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Bus kinds
+const (
+	KindNATS = "nats"
+	KindAMQP = "amqp"
+)
+
+// Default subject (NATS) and exchange (AMQP) when none is configured
+const DefaultTopic = "beads.events"
+
+// dialTimeout bounds connecting and handshaking with a broker
+const dialTimeout = 10 * time.Second
+
+// Publisher delivers one message and returns once the broker has accepted it
+type Publisher interface {
+	// Publish sends payload under key: appended to the subject for NATS,
+	// the routing key for AMQP
+	Publish(ctx context.Context, key string, payload []byte) error
+	Close() error
+}
+
+// Config describes one bus, read from bd config (events.<kind>.url and
+// events.nats.subject / events.amqp.exchange)
+type Config struct {
+	Kind  string
+	URL   string
+	Topic string // NATS subject prefix or AMQP exchange
+}
+
+// ConfigKeys returns the bd config keys for a bus kind: its URL and its
+// subject or exchange
+func ConfigKeys(kind string) (urlKey, topicKey string) {
+	if kind == KindAMQP {
+		return "events.amqp.url", "events.amqp.exchange"
+	}
+	return "events.nats.url", "events.nats.subject"
+}
+
+// CursorKey is the metadata key holding the last event ID delivered to a bus
+func CursorKey(kind string) string {
+	return "events." + kind + ".cursor"
+}
+
+// Dial connects to the bus described by cfg
+func Dial(ctx context.Context, cfg Config) (Publisher, error) {
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s url: %w", cfg.Kind, err)
+	}
+	topic := cfg.Topic
+	if topic == "" {
+		topic = DefaultTopic
+	}
+	ctx, cancel := context.WithTimeout(ctx, dialTimeout)
+	defer cancel()
+	switch cfg.Kind {
+	case KindNATS:
+		if u.Scheme != "nats" {
+			return nil, fmt.Errorf("unsupported nats url scheme %q (want nats://)", u.Scheme)
+		}
+		return dialNATS(ctx, u, topic)
+	case KindAMQP:
+		if u.Scheme != "amqp" {
+			return nil, fmt.Errorf("unsupported amqp url scheme %q (want amqp://)", u.Scheme)
+		}
+		return dialAMQP(ctx, u, topic)
+	default:
+		return nil, fmt.Errorf("unknown event bus %q (want nats or amqp)", cfg.Kind)
+	}
+}
+
+// hostPort adds the default port when the URL has none
+func hostPort(u *url.URL, defaultPort string) string {
+	if u.Port() != "" {
+		return u.Host
+	}
+	return strings.TrimSuffix(u.Host, ":") + ":" + defaultPort
+}
@@ -0,0 +1,142 @@
+package eventbus
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// natsPublisher speaks the NATS client protocol. Core NATS has no publish
+// acknowledgement, so every PUB is followed by a PING: the server answers
+// PONG only after processing everything before it.
+type natsPublisher struct {
+	conn    net.Conn
+	r       *bufio.Reader
+	subject string
+}
+
+// natsInfo is the part of the server's INFO we look at
+type natsInfo struct {
+	TLSRequired  bool `json:"tls_required"`
+	AuthRequired bool `json:"auth_required"`
+}
+
+// natsConnect is the CONNECT payload
+type natsConnect struct {
+	Verbose   bool   `json:"verbose"`
+	Pedantic  bool   `json:"pedantic"`
+	Name      string `json:"name"`
+	Lang      string `json:"lang"`
+	Version   string `json:"version"`
+	User      string `json:"user,omitempty"`
+	Pass      string `json:"pass,omitempty"`
+	AuthToken string `json:"auth_token,omitempty"`
+}
+
+func dialNATS(ctx context.Context, u *url.URL, subject string) (Publisher, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", hostPort(u, "4222"))
+	if err != nil {
+		return nil, fmt.Errorf("nats connect: %w", err)
+	}
+	p := &natsPublisher{conn: conn, r: bufio.NewReader(conn), subject: subject}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	if err := p.handshake(u); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	_ = conn.SetDeadline(time.Time{})
+	return p, nil
+}
+
+func (p *natsPublisher) handshake(u *url.URL) error {
+	line, err := p.readLine()
+	if err != nil {
+		return fmt.Errorf("nats: reading INFO: %w", err)
+	}
+	if !strings.HasPrefix(line, "INFO ") {
+		return fmt.Errorf("nats: expected INFO, got %q", line)
+	}
+	var info natsInfo
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "INFO ")), &info); err != nil {
+		return fmt.Errorf("nats: bad INFO: %w", err)
+	}
+	if info.TLSRequired {
+		return fmt.Errorf("nats: server requires TLS, which is not supported")
+	}
+
+	connect := natsConnect{Name: "beads", Lang: "go", Version: "1"}
+	if u.User != nil {
+		if pass, ok := u.User.Password(); ok {
+			connect.User, connect.Pass = u.User.Username(), pass
+		} else {
+			connect.AuthToken = u.User.Username()
+		}
+	}
+	data, err := json.Marshal(connect)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(p.conn, "CONNECT %s\r\nPING\r\n", data); err != nil {
+		return fmt.Errorf("nats: sending CONNECT: %w", err)
+	}
+	return p.awaitPong()
+}
+
+// Publish sends payload on <subject>.<key> and waits for the server to
+// process it
+func (p *natsPublisher) Publish(ctx context.Context, key string, payload []byte) error {
+	subject := p.subject
+	if key != "" {
+		subject += "." + key
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = p.conn.SetDeadline(deadline)
+		defer func() { _ = p.conn.SetDeadline(time.Time{}) }()
+	}
+	if _, err := fmt.Fprintf(p.conn, "PUB %s %d\r\n%s\r\nPING\r\n", subject, len(payload), payload); err != nil {
+		return fmt.Errorf("nats publish: %w", err)
+	}
+	return p.awaitPong()
+}
+
+// awaitPong reads until the server's PONG, answering its PINGs and failing
+// on -ERR
+func (p *natsPublisher) awaitPong() error {
+	for {
+		line, err := p.readLine()
+		if err != nil {
+			return fmt.Errorf("nats: %w", err)
+		}
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err := p.conn.Write([]byte("PONG\r\n")); err != nil {
+				return fmt.Errorf("nats: %w", err)
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return fmt.Errorf("nats: server error: %s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		}
+		// +OK and INFO updates need no answer
+	}
+}
+
+func (p *natsPublisher) readLine() (string, error) {
+	line, err := p.r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func (p *natsPublisher) Close() error {
+	return p.conn.Close()
+}
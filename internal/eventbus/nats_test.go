@@ -0,0 +1,83 @@
+package eventbus
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"testing"
+)
+
+// fakeNATS accepts one client and records the subjects and payloads it
+// publishes
+func fakeNATS(t *testing.T) (addr string, published chan string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+	published = make(chan string, 10)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+		r := bufio.NewReader(conn)
+		_, _ = fmt.Fprintf(conn, "INFO {\"server_id\":\"test\",\"auth_required\":true}\r\n")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimRight(line, "\r\n")
+			switch {
+			case strings.HasPrefix(line, "CONNECT "):
+				if !strings.Contains(line, `"auth_token":"s3cret"`) {
+					_, _ = fmt.Fprintf(conn, "-ERR 'Authorization Violation'\r\n")
+					return
+				}
+			case strings.HasPrefix(line, "PUB "):
+				var subject string
+				var size int
+				_, _ = fmt.Sscanf(line, "PUB %s %d", &subject, &size)
+				payload := make([]byte, size+2)
+				if _, err := io.ReadFull(r, payload); err != nil {
+					return
+				}
+				published <- subject + " " + string(payload[:size])
+			case line == "PING":
+				_, _ = fmt.Fprintf(conn, "PONG\r\n")
+			}
+		}
+	}()
+	return ln.Addr().String(), published
+}
+
+func TestNATSPublish(t *testing.T) {
+	addr, published := fakeNATS(t)
+	ctx := context.Background()
+
+	pub, err := Dial(ctx, Config{Kind: KindNATS, URL: "nats://s3cret@" + addr, Topic: "acme.beads"})
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer func() { _ = pub.Close() }()
+
+	if err := pub.Publish(ctx, "closed", []byte(`{"id":7}`)); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	if got, want := <-published, `acme.beads.closed {"id":7}`; got != want {
+		t.Errorf("published %q, want %q", got, want)
+	}
+}
+
+func TestNATSRejectsBadAuth(t *testing.T) {
+	addr, _ := fakeNATS(t)
+	if _, err := Dial(context.Background(), Config{Kind: KindNATS, URL: "nats://wrong@" + addr}); err == nil {
+		t.Fatal("expected an authorization error")
+	}
+}
@@ -0,0 +1,106 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/events"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Source is where a Relay reads events and keeps its cursor; the SQLite
+// storage implements it
+type Source interface {
+	events.Store
+}
+
+// Relay timing
+const (
+	relayPollInterval   = 2 * time.Second
+	relayPublishTimeout = 15 * time.Second
+	relayMaxBackoff     = time.Minute
+)
+
+// Relay forwards new events from a Source to one bus
+type Relay struct {
+	Config Config
+	Source Source
+	// Dial connects to the bus; nil means the package-level Dial
+	Dial func(ctx context.Context, cfg Config) (Publisher, error)
+	// Interval between polls of the events table; zero means 2s
+	Interval time.Duration
+	// Logf receives connection and delivery errors; may be nil
+	Logf func(format string, args ...interface{})
+
+	relay *events.Relay
+	pub   Publisher
+	now   func() time.Time
+}
+
+// Run delivers events until ctx is cancelled, reconnecting with
+// exponential backoff after failures
+func (r *Relay) Run(ctx context.Context) {
+	interval := r.Interval
+	if interval <= 0 {
+		interval = relayPollInterval
+	}
+	defer r.disconnect()
+	events.Run(ctx, interval, r.Deliver, func(err error) {
+		r.logf("event bus %s: %v", r.Config.Kind, err)
+	})
+}
+
+// Deliver publishes every event after the cursor and returns how many were
+// delivered. The cursor advances after each acknowledged event, so a
+// failure leaves the rest for the next call once the backoff has passed.
+// Without a cursor the relay starts at the newest event rather than
+// replaying the whole history.
+func (r *Relay) Deliver(ctx context.Context) (int, error) {
+	if r.relay == nil {
+		r.relay = &events.Relay{Store: r.Source, Key: CursorKey(r.Config.Kind), MaxRetryDelay: relayMaxBackoff, Now: r.now}
+	}
+	return r.relay.Deliver(ctx, r.publish)
+}
+
+// publish sends one event, connecting first if needed. A failed connection
+// is dropped so the next attempt reconnects.
+func (r *Relay) publish(ctx context.Context, event *types.Event) error {
+	if r.pub == nil {
+		dial := r.Dial
+		if dial == nil {
+			dial = Dial
+		}
+		pub, err := dial(ctx, r.Config)
+		if err != nil {
+			return err
+		}
+		r.pub = pub
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("encoding event %d: %w", event.ID, err)
+	}
+	pubCtx, cancel := context.WithTimeout(ctx, relayPublishTimeout)
+	err = r.pub.Publish(pubCtx, string(event.EventType), payload)
+	cancel()
+	if err != nil {
+		r.disconnect()
+		return fmt.Errorf("publishing event %d: %w", event.ID, err)
+	}
+	return nil
+}
+
+func (r *Relay) disconnect() {
+	if r.pub != nil {
+		_ = r.pub.Close()
+		r.pub = nil
+	}
+}
+
+func (r *Relay) logf(format string, args ...interface{}) {
+	if r.Logf != nil {
+		r.Logf(format, args...)
+	}
+}
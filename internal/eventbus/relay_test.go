@@ -0,0 +1,116 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+type memorySource struct {
+	events   []*types.Event
+	metadata map[string]string
+}
+
+func (m *memorySource) GetEventsSince(_ context.Context, afterID int64, limit int) ([]*types.Event, error) {
+	var result []*types.Event
+	for _, e := range m.events {
+		if e.ID > afterID && len(result) < limit {
+			result = append(result, e)
+		}
+	}
+	return result, nil
+}
+
+func (m *memorySource) LatestEventID(context.Context) (int64, error) {
+	if len(m.events) == 0 {
+		return 0, nil
+	}
+	return m.events[len(m.events)-1].ID, nil
+}
+
+func (m *memorySource) GetMetadata(_ context.Context, key string) (string, error) {
+	return m.metadata[key], nil
+}
+
+func (m *memorySource) SetMetadata(_ context.Context, key, value string) error {
+	m.metadata[key] = value
+	return nil
+}
+
+func (m *memorySource) add(id int64, eventType types.EventType) {
+	m.events = append(m.events, &types.Event{ID: id, IssueID: "bd-1", EventType: eventType})
+}
+
+type recordingPublisher struct {
+	keys   []string
+	failAt int // fail the publish with this 1-based index; 0 never fails
+	calls  int
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, key string, _ []byte) error {
+	p.calls++
+	if p.calls == p.failAt {
+		return errors.New("broker unavailable")
+	}
+	p.keys = append(p.keys, key)
+	return nil
+}
+
+func (p *recordingPublisher) Close() error { return nil }
+
+func TestRelayDeliversAtLeastOnce(t *testing.T) {
+	ctx := context.Background()
+	src := &memorySource{metadata: map[string]string{}}
+	src.add(1, types.EventCreated) // history before the relay existed
+	pub := &recordingPublisher{failAt: 2}
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	relay := &Relay{
+		Config: Config{Kind: KindNATS},
+		Source: src,
+		Dial:   func(context.Context, Config) (Publisher, error) { return pub, nil },
+		now:    func() time.Time { return now },
+	}
+
+	// First run only records the starting point
+	if n, err := relay.Deliver(ctx); err != nil || n != 0 {
+		t.Fatalf("first Deliver = %d, %v; want 0, nil", n, err)
+	}
+	if got := src.metadata[CursorKey(KindNATS)]; got != "1" {
+		t.Fatalf("cursor = %q, want 1", got)
+	}
+
+	src.add(2, types.EventStatusChanged)
+	src.add(3, types.EventClosed)
+	src.add(4, types.EventCommented)
+
+	// The second publish fails: event 2 is delivered, 3 and 4 wait
+	n, err := relay.Deliver(ctx)
+	if err == nil || n != 1 {
+		t.Fatalf("Deliver = %d, %v; want 1 and an error", n, err)
+	}
+	if got := src.metadata[CursorKey(KindNATS)]; got != "2" {
+		t.Errorf("cursor after failure = %q, want 2", got)
+	}
+
+	// The relay backs off before retrying
+	if n, err := relay.Deliver(ctx); err != nil || n != 0 {
+		t.Fatalf("Deliver during backoff = %d, %v; want 0, nil", n, err)
+	}
+	now = now.Add(time.Second)
+	if n, err := relay.Deliver(ctx); err != nil || n != 2 {
+		t.Fatalf("retry Deliver = %d, %v; want 2, nil", n, err)
+	}
+	want := []string{"status_changed", "closed", "commented"}
+	if len(pub.keys) != len(want) {
+		t.Fatalf("published %v, want %v", pub.keys, want)
+	}
+	for i := range want {
+		if pub.keys[i] != want[i] {
+			t.Errorf("published %v, want %v", pub.keys, want)
+			break
+		}
+	}
+}
@@ -0,0 +1,186 @@
+// Package events follows the events table for everything that reacts to
+// issue changes after the fact: the event bus relay, watch notifications,
+// webhooks and event streams (bd watch).
+//
+// Readers keep a cursor, the ID of the last event they handled, and read
+// the events after it in ID order. A new reader starts at the newest event
+// rather than replaying the whole history. A Relay keeps its cursor in
+// metadata and only moves it past an event once its handler has accepted
+// it, so events written while a consumer is down (or while the daemon is
+// stopped) are handled once it is back. Delivery is at-least-once: a crash
+// between handling an event and saving the cursor repeats that event.
+package events
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Source is the events table; the SQLite storage implements it
+type Source interface {
+	GetEventsSince(ctx context.Context, afterID int64, limit int) ([]*types.Event, error)
+	LatestEventID(ctx context.Context) (int64, error)
+}
+
+// Store is a Source that also keeps relay cursors, in metadata
+type Store interface {
+	Source
+	GetMetadata(ctx context.Context, key string) (string, error)
+	SetMetadata(ctx context.Context, key, value string) error
+}
+
+// Handler processes one event. Returning nil moves the cursor past the
+// event; an error stops reading with the cursor before it.
+type Handler func(ctx context.Context, event *types.Event) error
+
+// batchSize bounds the events read per query while catching up
+const batchSize = 100
+
+// Relay retry timing defaults
+const (
+	defaultRetryDelay    = time.Second
+	defaultMaxRetryDelay = time.Minute
+)
+
+// Start returns the cursor a new reader begins at: since if given,
+// otherwise the newest event
+func Start(ctx context.Context, src Source, since *int64) (int64, error) {
+	if since != nil {
+		return *since, nil
+	}
+	return src.LatestEventID(ctx)
+}
+
+// Read passes the events after cursor to handle in ID order and returns the
+// cursor after the last event handled
+func Read(ctx context.Context, src Source, cursor int64, handle Handler) (int64, error) {
+	for {
+		events, err := src.GetEventsSince(ctx, cursor, batchSize)
+		if err != nil {
+			return cursor, err
+		}
+		for _, event := range events {
+			if err := handle(ctx, event); err != nil {
+				return cursor, err
+			}
+			cursor = event.ID
+		}
+		if len(events) < batchSize {
+			return cursor, nil
+		}
+	}
+}
+
+// Run calls deliver every interval until ctx is cancelled, passing its
+// errors to logf
+func Run(ctx context.Context, interval time.Duration, deliver func(context.Context) (int, error), logf func(error)) {
+	for {
+		if _, err := deliver(ctx); err != nil && ctx.Err() == nil {
+			logf(err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Relay reads events from a cursor kept in metadata under Key. After a
+// failure it backs off: Deliver does nothing until RetryDelay has passed,
+// doubling with each further failure up to MaxRetryDelay.
+type Relay struct {
+	Store Store
+	Key   string
+	// RetryDelay is the wait after the first failure; zero means 1s
+	RetryDelay time.Duration
+	// MaxRetryDelay caps the wait after repeated failures; zero means a minute
+	MaxRetryDelay time.Duration
+	// Now is the clock retries are scheduled on; nil means time.Now
+	Now func() time.Time
+
+	failures int
+	next     time.Time
+}
+
+// Deliver passes every event after the cursor to handle and returns how
+// many it accepted. The cursor is saved after each accepted event, so a
+// failure leaves the rest for a later call.
+func (r *Relay) Deliver(ctx context.Context, handle Handler) (int, error) {
+	if r.failures > 0 && r.clock().Before(r.next) {
+		return 0, nil
+	}
+
+	handled := 0
+	cursor, err := r.cursor(ctx)
+	if err == nil {
+		_, err = Read(ctx, r.Store, cursor, func(ctx context.Context, event *types.Event) error {
+			if err := handle(ctx, event); err != nil {
+				return err
+			}
+			handled++
+			if err := r.Store.SetMetadata(ctx, r.Key, strconv.FormatInt(event.ID, 10)); err != nil {
+				return fmt.Errorf("saving cursor: %w", err)
+			}
+			return nil
+		})
+	}
+	if err == nil {
+		r.failures = 0
+		return handled, nil
+	}
+	if ctx.Err() != nil {
+		return handled, err
+	}
+
+	r.failures++
+	delay := r.RetryDelay
+	if delay <= 0 {
+		delay = defaultRetryDelay
+	}
+	maxDelay := r.MaxRetryDelay
+	if maxDelay <= 0 {
+		maxDelay = defaultMaxRetryDelay
+	}
+	for i := 1; i < r.failures && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	delay = min(delay, maxDelay)
+	r.next = r.clock().Add(delay)
+	return handled, fmt.Errorf("%w (retrying in %v)", err, delay)
+}
+
+// cursor returns the last event ID handled, initializing it to the newest
+// event on first use
+func (r *Relay) cursor(ctx context.Context) (int64, error) {
+	value, err := r.Store.GetMetadata(ctx, r.Key)
+	if err != nil {
+		return 0, err
+	}
+	if value != "" {
+		cursor, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s %q: %w", r.Key, value, err)
+		}
+		return cursor, nil
+	}
+	latest, err := Start(ctx, r.Store, nil)
+	if err != nil {
+		return 0, err
+	}
+	if err := r.Store.SetMetadata(ctx, r.Key, strconv.FormatInt(latest, 10)); err != nil {
+		return 0, err
+	}
+	return latest, nil
+}
+
+func (r *Relay) clock() time.Time {
+	if r.Now != nil {
+		return r.Now()
+	}
+	return time.Now()
+}
@@ -0,0 +1,146 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+type memoryStore struct {
+	events   []*types.Event
+	metadata map[string]string
+}
+
+func (m *memoryStore) GetEventsSince(_ context.Context, afterID int64, limit int) ([]*types.Event, error) {
+	var result []*types.Event
+	for _, e := range m.events {
+		if e.ID > afterID && len(result) < limit {
+			result = append(result, e)
+		}
+	}
+	return result, nil
+}
+
+func (m *memoryStore) LatestEventID(context.Context) (int64, error) {
+	if len(m.events) == 0 {
+		return 0, nil
+	}
+	return m.events[len(m.events)-1].ID, nil
+}
+
+func (m *memoryStore) GetMetadata(_ context.Context, key string) (string, error) {
+	return m.metadata[key], nil
+}
+
+func (m *memoryStore) SetMetadata(_ context.Context, key, value string) error {
+	m.metadata[key] = value
+	return nil
+}
+
+func (m *memoryStore) add(n int) {
+	for i := 0; i < n; i++ {
+		m.events = append(m.events, &types.Event{ID: int64(len(m.events) + 1), IssueID: "bd-1", EventType: types.EventUpdated})
+	}
+}
+
+func TestRead(t *testing.T) {
+	ctx := context.Background()
+	src := &memoryStore{}
+	src.add(2*batchSize + 5)
+
+	var seen []int64
+	cursor, err := Read(ctx, src, 3, func(_ context.Context, e *types.Event) error {
+		seen = append(seen, e.ID)
+		return nil
+	})
+	if err != nil || cursor != int64(2*batchSize+5) {
+		t.Fatalf("Read = %d, %v; want %d, nil", cursor, err, 2*batchSize+5)
+	}
+	if len(seen) != 2*batchSize+2 || seen[0] != 4 {
+		t.Errorf("read %d events starting at %d, want %d starting at 4", len(seen), seen[0], 2*batchSize+2)
+	}
+
+	// A failing handler leaves the cursor before its event
+	cursor, err = Read(ctx, src, 0, func(_ context.Context, e *types.Event) error {
+		if e.ID == 7 {
+			return errors.New("boom")
+		}
+		return nil
+	})
+	if err == nil || cursor != 6 {
+		t.Errorf("Read with a failure = %d, %v; want 6 and an error", cursor, err)
+	}
+
+	since := int64(2)
+	if start, _ := Start(ctx, src, &since); start != 2 {
+		t.Errorf("Start(since 2) = %d", start)
+	}
+	if start, _ := Start(ctx, src, nil); start != int64(2*batchSize+5) {
+		t.Errorf("Start(nil) = %d, want the newest event", start)
+	}
+}
+
+func TestRelay(t *testing.T) {
+	ctx := context.Background()
+	src := &memoryStore{metadata: map[string]string{}}
+	src.add(3) // history before the relay existed
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	relay := &Relay{Store: src, Key: "test.cursor", RetryDelay: time.Second, MaxRetryDelay: 3 * time.Second, Now: func() time.Time { return now }}
+
+	var handled []int64
+	failAt := int64(0)
+	handle := func(_ context.Context, e *types.Event) error {
+		if e.ID == failAt {
+			return errors.New("unavailable")
+		}
+		handled = append(handled, e.ID)
+		return nil
+	}
+
+	if n, err := relay.Deliver(ctx, handle); err != nil || n != 0 {
+		t.Fatalf("first Deliver = %d, %v; want 0, nil", n, err)
+	}
+	if got := src.metadata["test.cursor"]; got != "3" {
+		t.Fatalf("cursor = %q, want 3 (the newest event)", got)
+	}
+
+	src.add(3)
+	failAt = 5
+	n, err := relay.Deliver(ctx, handle)
+	if err == nil || n != 1 || !strings.Contains(err.Error(), "retrying in 1s") {
+		t.Fatalf("Deliver = %d, %v; want 1 and an error retrying in 1s", n, err)
+	}
+	if got := src.metadata["test.cursor"]; got != "4" {
+		t.Errorf("cursor after failure = %q, want 4", got)
+	}
+
+	// Retries wait 1s, then 2s, then stay at the 3s cap
+	for _, want := range []string{"2s", "3s", "3s"} {
+		if n, err := relay.Deliver(ctx, handle); err != nil || n != 0 {
+			t.Fatalf("Deliver during backoff = %d, %v; want 0, nil", n, err)
+		}
+		now = now.Add(3 * time.Second)
+		if _, err := relay.Deliver(ctx, handle); err == nil || !strings.Contains(err.Error(), "retrying in "+want) {
+			t.Fatalf("retry = %v, want retrying in %s", err, want)
+		}
+	}
+
+	failAt = 0
+	now = now.Add(3 * time.Second)
+	if n, err := relay.Deliver(ctx, handle); err != nil || n != 2 {
+		t.Fatalf("Deliver after recovering = %d, %v; want 2, nil", n, err)
+	}
+	if len(handled) != 3 || handled[1] != 5 || handled[2] != 6 {
+		t.Errorf("handled %v, want [4 5 6]", handled)
+	}
+
+	// Recovering resets the backoff
+	src.add(1)
+	if n, err := relay.Deliver(ctx, handle); err != nil || n != 1 {
+		t.Errorf("Deliver = %d, %v; want 1, nil", n, err)
+	}
+}
@@ -0,0 +1,176 @@
+// Package eventstream turns the events table into a stream of changes:
+// issues created, updated, closed and reopened, dependency, label and
+// comment changes. The daemon serves it to subscribers (bd watch); without a
+// daemon, bd polls the database the same way.
+package eventstream
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/events"
+	"github.com/steveyegge/beads/internal/filterexpr"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Source is where events and issue snapshots come from; the SQLite storage
+// implements it
+type Source interface {
+	events.Source
+	GetIssue(ctx context.Context, id string) (*types.Issue, error)
+	GetLabels(ctx context.Context, issueID string) ([]string, error)
+}
+
+// Message is one streamed event with the issue as it is now. The event ID
+// is the cursor to resume from. Issue is nil once an issue is deleted.
+type Message struct {
+	*types.Event
+	Issue *types.Issue `json:"issue,omitempty"`
+}
+
+// typeAliases are the short names accepted for type= filters
+var typeAliases = map[string][]types.EventType{
+	"create":  {types.EventCreated},
+	"update":  {types.EventUpdated, types.EventStatusChanged},
+	"status":  {types.EventStatusChanged},
+	"close":   {types.EventClosed},
+	"reopen":  {types.EventReopened},
+	"dep":     {types.EventDependencyAdded, types.EventDependencyRemoved},
+	"comment": {types.EventCommented, types.EventCommentEdited, types.EventCommentDeleted},
+	"label":   {types.EventLabelAdded, types.EventLabelRemoved},
+	"timebox": {types.EventTimeboxElapsed},
+}
+
+// Filter selects events. Each field matches any of its values, and an
+// event must match every non-empty field.
+type Filter struct {
+	Types    []types.EventType `json:"types,omitempty"`
+	IssueIDs []string          `json:"issue_ids,omitempty"` // also matches hierarchical children (bd-42.1)
+	Actors   []string          `json:"actors,omitempty"`
+	Labels   []string          `json:"labels,omitempty"` // the issue's current labels
+	Query    string            `json:"query,omitempty"`  // saved filter expression the issue must match now
+}
+
+// ParseFilter reads key=value filter terms such as type=close, issue=bd-42,
+// actor=alice and label=frontend. Repeating a key matches any of the values.
+func ParseFilter(terms []string) (*Filter, error) {
+	f := &Filter{}
+	for _, term := range terms {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(term, "=")
+		value = strings.TrimSpace(value)
+		if !ok || value == "" {
+			return nil, fmt.Errorf("invalid filter %q (want key=value, e.g. type=close)", term)
+		}
+		switch strings.TrimSpace(key) {
+		case "type":
+			eventTypes, err := parseType(value)
+			if err != nil {
+				return nil, err
+			}
+			f.Types = append(f.Types, eventTypes...)
+		case "issue", "id":
+			f.IssueIDs = append(f.IssueIDs, value)
+		case "actor":
+			f.Actors = append(f.Actors, value)
+		case "label":
+			f.Labels = append(f.Labels, value)
+		default:
+			return nil, fmt.Errorf("unknown filter %q (use type, issue, actor or label)", key)
+		}
+	}
+	return f, nil
+}
+
+func parseType(value string) ([]types.EventType, error) {
+	if aliased, ok := typeAliases[value]; ok {
+		return aliased, nil
+	}
+	for _, aliased := range typeAliases {
+		if slices.Contains(aliased, types.EventType(value)) {
+			return []types.EventType{types.EventType(value)}, nil
+		}
+	}
+	if value == string(types.EventCompacted) {
+		return []types.EventType{types.EventCompacted}, nil
+	}
+	return nil, fmt.Errorf("unknown event type %q (use create, update, status, close, reopen, dep, comment, label or timebox)", value)
+}
+
+// matchEvent checks everything but labels, which need the issue
+func (f *Filter) matchEvent(event *types.Event) bool {
+	if f == nil {
+		return true
+	}
+	if len(f.Types) > 0 && !slices.Contains(f.Types, event.EventType) {
+		return false
+	}
+	if len(f.Actors) > 0 && !slices.Contains(f.Actors, event.Actor) {
+		return false
+	}
+	if len(f.IssueIDs) > 0 && !slices.ContainsFunc(f.IssueIDs, func(id string) bool {
+		return event.IssueID == id || strings.HasPrefix(event.IssueID, id+".")
+	}) {
+		return false
+	}
+	return true
+}
+
+// query parses the saved filter expression, if there is one
+func (f *Filter) query() (*filterexpr.Expr, error) {
+	if f == nil || f.Query == "" {
+		return nil, nil
+	}
+	return filterexpr.Parse(f.Query)
+}
+
+func (f *Filter) matchLabels(labels []string) bool {
+	if f == nil || len(f.Labels) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(f.Labels, func(label string) bool {
+		return slices.Contains(labels, label)
+	})
+}
+
+// Poll returns the events after cursor that match the filter, with issue
+// snapshots, and the cursor to poll from next. The cursor moves past
+// events the filter skips too; after an error it stays before the event
+// that failed. The cursor belongs to the subscriber, so unlike an
+// events.Relay it isn't persisted; start one with events.Start.
+func Poll(ctx context.Context, src Source, cursor int64, filter *Filter) ([]*Message, int64, error) {
+	query, err := filter.query()
+	if err != nil {
+		return nil, cursor, err
+	}
+	var messages []*Message
+	cursor, err = events.Read(ctx, src, cursor, func(ctx context.Context, event *types.Event) error {
+		if !filter.matchEvent(event) {
+			return nil
+		}
+		issue, err := src.GetIssue(ctx, event.IssueID)
+		if err != nil {
+			return err
+		}
+		if filter != nil && (len(filter.Labels) > 0 || query != nil) {
+			labels, err := src.GetLabels(ctx, event.IssueID)
+			if err != nil {
+				return err
+			}
+			if !filter.matchLabels(labels) || (query != nil && (issue == nil || !query.Match(issue, labels))) {
+				return nil
+			}
+			if issue != nil {
+				issue.Labels = labels
+			}
+		}
+		messages = append(messages, &Message{Event: event, Issue: issue})
+		return nil
+	})
+	return messages, cursor, err
+}
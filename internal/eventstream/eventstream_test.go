@@ -0,0 +1,108 @@
+package eventstream
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestParseFilter(t *testing.T) {
+	f, err := ParseFilter([]string{"type=close", "type=dep", "issue=bd-1", "actor=alice", "label=ui"})
+	if err != nil {
+		t.Fatalf("ParseFilter: %v", err)
+	}
+	want := []types.EventType{types.EventClosed, types.EventDependencyAdded, types.EventDependencyRemoved}
+	if len(f.Types) != len(want) {
+		t.Fatalf("Types = %v, want %v", f.Types, want)
+	}
+	for i := range want {
+		if f.Types[i] != want[i] {
+			t.Errorf("Types[%d] = %s, want %s", i, f.Types[i], want[i])
+		}
+	}
+	if f.IssueIDs[0] != "bd-1" || f.Actors[0] != "alice" || f.Labels[0] != "ui" {
+		t.Errorf("unexpected filter %+v", f)
+	}
+
+	if f, err := ParseFilter([]string{"type=label_added"}); err != nil || len(f.Types) != 1 {
+		t.Errorf("event type names should be accepted: %+v, %v", f, err)
+	}
+	for _, bad := range []string{"type=explode", "color=red", "type", "issue="} {
+		if _, err := ParseFilter([]string{bad}); err == nil {
+			t.Errorf("ParseFilter(%q) should fail", bad)
+		}
+	}
+}
+
+func TestPoll(t *testing.T) {
+	ctx := context.Background()
+	s, err := sqlite.New(ctx, filepath.Join(t.TempDir(), "beads.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	if err := s.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, id := range []string{"bd-1", "bd-1.1", "bd-2"} {
+		issue := &types.Issue{ID: id, Title: id, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := s.CreateIssue(ctx, issue, "alice"); err != nil {
+			t.Fatalf("CreateIssue: %v", err)
+		}
+	}
+	if err := s.AddLabel(ctx, "bd-2", "ui", "bob"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.CloseIssue(ctx, "bd-1.1", "done", "bob"); err != nil {
+		t.Fatal(err)
+	}
+
+	all, cursor, err := Poll(ctx, s, 0, nil)
+	if err != nil {
+		t.Fatalf("Poll: %v", err)
+	}
+	if len(all) != 5 {
+		t.Fatalf("got %d events, want 5", len(all))
+	}
+	if latest, _ := s.LatestEventID(ctx); cursor != latest {
+		t.Errorf("cursor = %d, want %d", cursor, latest)
+	}
+	if again, _, _ := Poll(ctx, s, cursor, nil); len(again) != 0 {
+		t.Errorf("polling from the cursor returned %d old events", len(again))
+	}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   []string
+	}{
+		{"issue matches children", Filter{IssueIDs: []string{"bd-1"}}, []string{"bd-1", "bd-1.1", "bd-1.1"}},
+		{"type and actor", Filter{Types: []types.EventType{types.EventClosed}, Actors: []string{"bob"}}, []string{"bd-1.1"}},
+		{"label", Filter{Labels: []string{"ui"}}, []string{"bd-2", "bd-2"}},
+		{"saved filter query", Filter{Query: "status=open label=ui"}, []string{"bd-2", "bd-2"}},
+		{"query on the issue as it is now", Filter{Query: "status=closed"}, []string{"bd-1.1", "bd-1.1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, next, err := Poll(ctx, s, 0, &tt.filter)
+			if err != nil {
+				t.Fatalf("Poll: %v", err)
+			}
+			if next != cursor {
+				t.Errorf("cursor = %d, want %d even when events are skipped", next, cursor)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d events, want %d", len(got), len(tt.want))
+			}
+			for i, m := range got {
+				if m.IssueID != tt.want[i] || m.Issue == nil || m.Issue.ID != tt.want[i] {
+					t.Errorf("event %d: %s with issue %+v, want %s", i, m.IssueID, m.Issue, tt.want[i])
+				}
+			}
+		})
+	}
+}
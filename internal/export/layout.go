@@ -0,0 +1,255 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Export layouts (export.layout in config.yaml)
+//
+// With the sharded layout the JSONL is split into files under a sibling
+// "<name>.d" directory (e.g. .beads/issues.d/) and those shards are what gets
+// committed. The single JSONL is still written as a local working copy, so
+// everything that reads it keeps working; AssembleShards rebuilds it from the
+// shards after a pull.
+const (
+	LayoutSingle  = "single"
+	LayoutSharded = "sharded"
+)
+
+// Shard strategies (export.shard_by in config.yaml)
+const (
+	// ShardByStatus writes open.jsonl and closed.jsonl (closed and tombstoned issues)
+	ShardByStatus = "status"
+	// ShardByID writes one file per leading character of the ID suffix, so
+	// bd-a1b2 and bd-a9zz both land in a.jsonl
+	ShardByID = "id"
+)
+
+// ValidateLayout checks export.layout and export.shard_by values
+func ValidateLayout(layout, shardBy string) error {
+	switch layout {
+	case "", LayoutSingle, LayoutSharded:
+	default:
+		return fmt.Errorf("invalid export.layout %q (valid: %s, %s)", layout, LayoutSingle, LayoutSharded)
+	}
+	switch shardBy {
+	case "", ShardByStatus, ShardByID:
+	default:
+		return fmt.Errorf("invalid export.shard_by %q (valid: %s, %s)", shardBy, ShardByStatus, ShardByID)
+	}
+	return nil
+}
+
+// ShardDir returns the shard directory for a JSONL path: issues.jsonl -> issues.d
+func ShardDir(jsonlPath string) string {
+	return strings.TrimSuffix(jsonlPath, filepath.Ext(jsonlPath)) + ".d"
+}
+
+// shardLine is the part of an exported issue needed to place and order it
+type shardLine struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	data   []byte
+}
+
+// WriteShards splits the JSONL at jsonlPath into shard files. Shards that no
+// longer receive any issue are removed, so the directory always mirrors the
+// JSONL exactly. Returns the shard paths written.
+func WriteShards(jsonlPath, shardBy string) ([]string, error) {
+	// #nosec G304 - controlled path from config
+	data, err := os.ReadFile(jsonlPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JSONL: %w", err)
+	}
+	lines, err := parseShardLines(data, jsonlPath)
+	if err != nil {
+		return nil, err
+	}
+
+	shards := make(map[string]*bytes.Buffer)
+	for _, line := range lines {
+		name := shardName(line, shardBy) + ".jsonl"
+		if shards[name] == nil {
+			shards[name] = &bytes.Buffer{}
+		}
+		shards[name].Write(line.data)
+		shards[name].WriteByte('\n')
+	}
+
+	dir := ShardDir(jsonlPath)
+	// #nosec G301 - shards are committed to git like the JSONL itself
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create shard directory: %w", err)
+	}
+
+	var written []string
+	for name, buf := range shards {
+		path := filepath.Join(dir, name)
+		if existing, err := os.ReadFile(path); err == nil && bytes.Equal(existing, buf.Bytes()) { // #nosec G304 - path within shard dir
+			written = append(written, path)
+			continue
+		}
+		if err := writeFileAtomic(path, buf.Bytes()); err != nil {
+			return nil, err
+		}
+		written = append(written, path)
+	}
+
+	existing, err := shardFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range existing {
+		if shards[filepath.Base(path)] == nil {
+			if err := os.Remove(path); err != nil {
+				return nil, fmt.Errorf("failed to remove stale shard: %w", err)
+			}
+		}
+	}
+
+	sort.Strings(written)
+	return written, nil
+}
+
+// RemoveShards deletes the shard directory for jsonlPath, if any. Used when
+// switching back to the single layout.
+func RemoveShards(jsonlPath string) error {
+	dir := ShardDir(jsonlPath)
+	files, err := shardFiles(dir)
+	if err != nil {
+		return err
+	}
+	for _, path := range files {
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("failed to remove shard: %w", err)
+		}
+	}
+	// Only removes the directory if nothing else lives in it
+	_ = os.Remove(dir)
+	return nil
+}
+
+// HasShards reports whether a shard directory with at least one shard exists
+func HasShards(jsonlPath string) bool {
+	files, err := shardFiles(ShardDir(jsonlPath))
+	return err == nil && len(files) > 0
+}
+
+// AssembleShards rebuilds the JSONL at jsonlPath from its shards, sorted by
+// ID like a regular export. It is a no-op without a shard directory or when
+// the JSONL already matches, so callers can run it before every import.
+// Returns true if the JSONL was rewritten.
+func AssembleShards(jsonlPath string) (bool, error) {
+	files, err := shardFiles(ShardDir(jsonlPath))
+	if err != nil || len(files) == 0 {
+		return false, err
+	}
+
+	var lines []shardLine
+	for _, path := range files {
+		// #nosec G304 - path within shard dir
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return false, fmt.Errorf("failed to read shard: %w", err)
+		}
+		parsed, err := parseShardLines(data, path)
+		if err != nil {
+			return false, err
+		}
+		lines = append(lines, parsed...)
+	}
+	sort.SliceStable(lines, func(i, j int) bool { return lines[i].ID < lines[j].ID })
+
+	var buf bytes.Buffer
+	for _, line := range lines {
+		buf.Write(line.data)
+		buf.WriteByte('\n')
+	}
+
+	// #nosec G304 - controlled path from config
+	if existing, err := os.ReadFile(jsonlPath); err == nil && bytes.Equal(existing, buf.Bytes()) {
+		return false, nil
+	}
+	if err := writeFileAtomic(jsonlPath, buf.Bytes()); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func parseShardLines(data []byte, path string) ([]shardLine, error) {
+	var lines []shardLine
+	for i, raw := range bytes.Split(data, []byte("\n")) {
+		raw = bytes.TrimSpace(raw)
+		if len(raw) == 0 {
+			continue
+		}
+		var line shardLine
+		if err := json.Unmarshal(raw, &line); err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid JSON: %w", path, i+1, err)
+		}
+		if line.ID == "" {
+			return nil, fmt.Errorf("%s:%d: issue has no id", path, i+1)
+		}
+		line.data = raw
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
+
+func shardName(line shardLine, shardBy string) string {
+	if shardBy == ShardByID {
+		suffix := line.ID
+		if idx := strings.LastIndex(suffix, "-"); idx >= 0 && idx < len(suffix)-1 {
+			suffix = suffix[idx+1:]
+		}
+		c := strings.ToLower(suffix[:1])[0]
+		if (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') {
+			return string(c)
+		}
+		return "_"
+	}
+	switch line.Status {
+	case "closed", "tombstone":
+		return "closed"
+	default:
+		return "open"
+	}
+}
+
+// shardFiles lists the *.jsonl files in dir, sorted; a missing dir has none
+func shardFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read shard directory: %w", err)
+	}
+	var files []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".jsonl") {
+			files = append(files, filepath.Join(dir, e.Name()))
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	tempPath := fmt.Sprintf("%s.tmp.%d", path, os.Getpid())
+	// #nosec G306 - JSONL needs to be readable by other tools
+	if err := os.WriteFile(tempPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		_ = os.Remove(tempPath)
+		return fmt.Errorf("failed to rename %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testJSONL = `{"id":"bd-a1","title":"One","status":"open"}
+{"id":"bd-b2","title":"Two","status":"closed"}
+{"id":"bd-c3","title":"Three","status":"in_progress"}
+{"id":"bd-a4","title":"Four","status":"tombstone"}
+`
+
+func TestWriteAndAssembleShards(t *testing.T) {
+	dir := t.TempDir()
+	jsonlPath := filepath.Join(dir, "issues.jsonl")
+	if err := os.WriteFile(jsonlPath, []byte(testJSONL), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	written, err := WriteShards(jsonlPath, ShardByStatus)
+	if err != nil {
+		t.Fatalf("WriteShards failed: %v", err)
+	}
+	shardDir := filepath.Join(dir, "issues.d")
+	want := []string{filepath.Join(shardDir, "closed.jsonl"), filepath.Join(shardDir, "open.jsonl")}
+	if strings.Join(written, ",") != strings.Join(want, ",") {
+		t.Fatalf("written = %v, want %v", written, want)
+	}
+	closed, _ := os.ReadFile(want[0])
+	if !strings.Contains(string(closed), "bd-b2") || !strings.Contains(string(closed), "bd-a4") || strings.Contains(string(closed), "bd-c3") {
+		t.Errorf("closed shard has wrong issues:\n%s", closed)
+	}
+
+	// A fresh clone has only the shards; assembling sorts lines by ID
+	if err := os.Remove(jsonlPath); err != nil {
+		t.Fatal(err)
+	}
+	changed, err := AssembleShards(jsonlPath)
+	if err != nil || !changed {
+		t.Fatalf("AssembleShards = %v, %v; want true, nil", changed, err)
+	}
+	got, _ := os.ReadFile(jsonlPath)
+	var ids []string
+	for _, line := range strings.Split(strings.TrimSpace(string(got)), "\n") {
+		ids = append(ids, line[7:12])
+	}
+	if strings.Join(ids, ",") != "bd-a1,bd-a4,bd-b2,bd-c3" {
+		t.Errorf("assembled order = %v", ids)
+	}
+
+	changed, err = AssembleShards(jsonlPath)
+	if err != nil || changed {
+		t.Errorf("second AssembleShards = %v, %v; want false, nil", changed, err)
+	}
+
+	// Re-sharding by ID replaces the status shards
+	written, err = WriteShards(jsonlPath, ShardByID)
+	if err != nil {
+		t.Fatalf("WriteShards by id failed: %v", err)
+	}
+	var names []string
+	for _, p := range written {
+		names = append(names, filepath.Base(p))
+	}
+	if strings.Join(names, ",") != "a.jsonl,b.jsonl,c.jsonl" {
+		t.Errorf("id shards = %v", names)
+	}
+	if _, err := os.Stat(filepath.Join(shardDir, "open.jsonl")); !os.IsNotExist(err) {
+		t.Error("stale status shard was not removed")
+	}
+
+	if err := RemoveShards(jsonlPath); err != nil {
+		t.Fatalf("RemoveShards failed: %v", err)
+	}
+	if HasShards(jsonlPath) {
+		t.Error("shards remain after RemoveShards")
+	}
+	if changed, err := AssembleShards(jsonlPath); err != nil || changed {
+		t.Errorf("AssembleShards without shards = %v, %v; want false, nil", changed, err)
+	}
+}
+
+func TestAssembleShardsRejectsConflicts(t *testing.T) {
+	dir := t.TempDir()
+	jsonlPath := filepath.Join(dir, "issues.jsonl")
+	shardDir := ShardDir(jsonlPath)
+	if err := os.MkdirAll(shardDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	conflicted := "<<<<<<< HEAD\n{\"id\":\"bd-a1\",\"status\":\"open\"}\n=======\n"
+	if err := os.WriteFile(filepath.Join(shardDir, "open.jsonl"), []byte(conflicted), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(jsonlPath, []byte(testJSONL), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := AssembleShards(jsonlPath); err == nil {
+		t.Fatal("expected error for conflicted shard")
+	}
+	got, _ := os.ReadFile(jsonlPath)
+	if string(got) != testJSONL {
+		t.Error("JSONL was modified despite the error")
+	}
+}
+
+func TestValidateLayout(t *testing.T) {
+	if err := ValidateLayout(LayoutSharded, ShardByID); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := ValidateLayout("", ""); err != nil {
+		t.Errorf("unexpected error for defaults: %v", err)
+	}
+	if err := ValidateLayout("split", ""); err == nil {
+		t.Error("expected error for invalid layout")
+	}
+	if err := ValidateLayout(LayoutSharded, "priority"); err == nil {
+		t.Error("expected error for invalid shard_by")
+	}
+}
@@ -0,0 +1,255 @@
+// Package filterexpr parses the issue filter expressions kept as saved
+// filters (bd filter save), such as "status=open priority<=1 label=backend",
+// into a storage filter or an in-memory match.
+package filterexpr
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/validation"
+)
+
+// Term is one key<op>value condition of an expression
+type Term struct {
+	Key   string `json:"key"`
+	Op    string `json:"op"`
+	Value string `json:"value"`
+}
+
+// Expr is a parsed expression. Every term must match.
+type Expr struct {
+	Terms []Term `json:"terms"`
+}
+
+// operators in the order they are tried, so "<=" wins over "<"
+var operators = []string{"<=", ">=", "=", "<", ">"}
+
+// singleKeys may appear only once in an expression
+var singleKeys = []string{"status", "type", "assignee", "title", "complexity"}
+
+// Parse reads a space-separated list of terms. Keys are status, type,
+// assignee, label (repeatable, all must match), priority (with =, <, <=, >
+// or >=), title (substring), complexity (comma-separated, any matches) and
+// field.<name> for custom fields. Values containing spaces are quoted:
+// title="login page".
+func Parse(s string) (*Expr, error) {
+	tokens, err := tokenize(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty filter (want terms such as status=open priority<=1)")
+	}
+	expr := &Expr{}
+	seen := make(map[string]bool)
+	for _, token := range tokens {
+		term, err := parseTerm(token)
+		if err != nil {
+			return nil, err
+		}
+		if slices.Contains(singleKeys, term.Key) || strings.HasPrefix(term.Key, "field.") {
+			if seen[term.Key] {
+				return nil, fmt.Errorf("%s appears more than once in %q", term.Key, s)
+			}
+			seen[term.Key] = true
+		}
+		expr.Terms = append(expr.Terms, term)
+	}
+	return expr, nil
+}
+
+func parseTerm(token string) (Term, error) {
+	i := strings.IndexAny(token, "<>=")
+	if i <= 0 {
+		return Term{}, fmt.Errorf("invalid filter term %q (want key=value, e.g. status=open)", token)
+	}
+	term := Term{Key: token[:i]}
+	for _, op := range operators {
+		if strings.HasPrefix(token[i:], op) {
+			term.Op, term.Value = op, token[i+len(op):]
+			break
+		}
+	}
+	if term.Value == "" {
+		return Term{}, fmt.Errorf("filter term %q has no value", token)
+	}
+	if term.Key != "priority" && term.Op != "=" {
+		return Term{}, fmt.Errorf("%s only supports = (comparisons work on priority)", term.Key)
+	}
+
+	switch {
+	case term.Key == "priority":
+		p, err := validation.ValidatePriority(term.Value)
+		if err != nil {
+			return Term{}, err
+		}
+		if (term.Op == "<" && p == 0) || (term.Op == ">" && p == 4) {
+			return Term{}, fmt.Errorf("%q matches no priority", token)
+		}
+		term.Value = fmt.Sprint(p)
+	case term.Key == "complexity":
+		for _, c := range strings.Split(term.Value, ",") {
+			if !types.Complexity(c).IsValid() {
+				return Term{}, fmt.Errorf("invalid complexity %q (use trivial, standard, complex or research)", c)
+			}
+		}
+	case strings.HasPrefix(term.Key, "field."):
+		if strings.TrimPrefix(term.Key, "field.") == "" {
+			return Term{}, fmt.Errorf("%q names no field (want field.<name>=value)", token)
+		}
+	case slices.Contains(singleKeys, term.Key), term.Key == "label":
+	default:
+		return Term{}, fmt.Errorf("unknown filter key %q (use status, type, assignee, label, priority, title, complexity or field.<name>)", term.Key)
+	}
+	return term, nil
+}
+
+// tokenize splits on whitespace outside double or single quotes and drops
+// the quotes
+func tokenize(s string) ([]string, error) {
+	var tokens []string
+	var current strings.Builder
+	var quote rune
+	inToken := false
+	for _, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote, inToken = r, true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inToken {
+				tokens = append(tokens, current.String())
+				current.Reset()
+				inToken = false
+			}
+		default:
+			current.WriteRune(r)
+			inToken = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote in %q", s)
+	}
+	if inToken {
+		tokens = append(tokens, current.String())
+	}
+	return tokens, nil
+}
+
+// String formats the expression so that Parse reads it back unchanged
+func (e *Expr) String() string {
+	parts := make([]string, len(e.Terms))
+	for i, t := range e.Terms {
+		value := t.Value
+		if strings.ContainsAny(value, " \t\n'\"") {
+			if strings.Contains(value, `"`) {
+				value = "'" + value + "'"
+			} else {
+				value = `"` + value + `"`
+			}
+		}
+		parts[i] = t.Key + t.Op + value
+	}
+	return strings.Join(parts, " ")
+}
+
+// IssueFilter converts the expression for SearchIssues
+func (e *Expr) IssueFilter() types.IssueFilter {
+	var f types.IssueFilter
+	for _, t := range e.Terms {
+		switch {
+		case t.Key == "status":
+			status := types.Status(t.Value)
+			f.Status = &status
+		case t.Key == "type":
+			issueType := types.IssueType(t.Value)
+			f.IssueType = &issueType
+		case t.Key == "assignee":
+			assignee := t.Value
+			f.Assignee = &assignee
+		case t.Key == "label":
+			f.Labels = append(f.Labels, t.Value)
+		case t.Key == "title":
+			f.TitleContains = t.Value
+		case t.Key == "complexity":
+			for _, c := range strings.Split(t.Value, ",") {
+				f.Complexity = append(f.Complexity, types.Complexity(c))
+			}
+		case t.Key == "priority":
+			lo, hi := t.priorityRange()
+			f.PriorityMin = tighter(f.PriorityMin, lo, func(a, b int) bool { return a > b })
+			f.PriorityMax = tighter(f.PriorityMax, hi, func(a, b int) bool { return a < b })
+		case strings.HasPrefix(t.Key, "field."):
+			if f.Fields == nil {
+				f.Fields = make(types.CustomFields)
+			}
+			f.Fields[strings.TrimPrefix(t.Key, "field.")] = t.Value
+		}
+	}
+	return f
+}
+
+// Match reports whether issue, with the given labels, satisfies every term
+func (e *Expr) Match(issue *types.Issue, labels []string) bool {
+	for _, t := range e.Terms {
+		var ok bool
+		switch {
+		case t.Key == "status":
+			ok = string(issue.Status) == t.Value
+		case t.Key == "type":
+			ok = string(issue.IssueType) == t.Value
+		case t.Key == "assignee":
+			ok = issue.Assignee == t.Value
+		case t.Key == "label":
+			ok = slices.Contains(labels, t.Value)
+		case t.Key == "title":
+			ok = strings.Contains(strings.ToLower(issue.Title), strings.ToLower(t.Value))
+		case t.Key == "complexity":
+			ok = slices.Contains(strings.Split(t.Value, ","), string(issue.Complexity))
+		case t.Key == "priority":
+			lo, hi := t.priorityRange()
+			ok = (lo == nil || issue.Priority >= *lo) && (hi == nil || issue.Priority <= *hi)
+		case strings.HasPrefix(t.Key, "field."):
+			ok = issue.Fields[strings.TrimPrefix(t.Key, "field.")] == t.Value
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// priorityRange returns the inclusive bounds of a priority term
+func (t Term) priorityRange() (lo, hi *int) {
+	var p int
+	_, _ = fmt.Sscan(t.Value, &p)
+	switch t.Op {
+	case "<":
+		return nil, intPtr(p - 1)
+	case "<=":
+		return nil, intPtr(p)
+	case ">":
+		return intPtr(p + 1), nil
+	case ">=":
+		return intPtr(p), nil
+	}
+	return intPtr(p), intPtr(p)
+}
+
+// tighter keeps the stricter of two optional bounds
+func tighter(current, next *int, stricter func(a, b int) bool) *int {
+	if next == nil || (current != nil && !stricter(*next, *current)) {
+		return current
+	}
+	return next
+}
+
+func intPtr(n int) *int { return &n }
@@ -0,0 +1,75 @@
+package filterexpr
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestParse(t *testing.T) {
+	expr, err := Parse(`status=open  priority<=P1 label=backend label=api title="login page"`)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(expr.Terms) != 5 || expr.Terms[1] != (Term{Key: "priority", Op: "<=", Value: "1"}) || expr.Terms[4].Value != "login page" {
+		t.Errorf("terms = %+v", expr.Terms)
+	}
+	want := `status=open priority<=1 label=backend label=api title="login page"`
+	if got := expr.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if again, err := Parse(expr.String()); err != nil || again.String() != want {
+		t.Errorf("round trip = %v, %v", again, err)
+	}
+
+	for input, wantErr := range map[string]string{
+		"":                          "empty filter",
+		"status":                    "want key=value",
+		"status=":                   "has no value",
+		"status=open status=closed": "more than once",
+		"owner=alice":               "unknown filter key",
+		"status<=open":              "only supports =",
+		"priority<0":                "matches no priority",
+		"priority=high":             "invalid priority",
+		"complexity=huge":           "invalid complexity",
+		`title="open`:               "unterminated quote",
+		"field.=x":                  "names no field",
+	} {
+		if _, err := Parse(input); err == nil || !strings.Contains(err.Error(), wantErr) {
+			t.Errorf("Parse(%q) error = %v, want %q", input, err, wantErr)
+		}
+	}
+}
+
+func TestIssueFilterAndMatch(t *testing.T) {
+	expr, err := Parse("status=open priority>0 priority<3 priority<=1 label=backend complexity=trivial,standard field.team=core")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	f := expr.IssueFilter()
+	if f.Status == nil || *f.Status != types.StatusOpen || *f.PriorityMin != 1 || *f.PriorityMax != 1 ||
+		len(f.Labels) != 1 || len(f.Complexity) != 2 || f.Fields["team"] != "core" {
+		t.Errorf("IssueFilter = %+v", f)
+	}
+
+	issue := &types.Issue{Status: types.StatusOpen, Priority: 1, Complexity: types.ComplexityTrivial, Fields: types.CustomFields{"team": "core"}}
+	if !expr.Match(issue, []string{"backend", "ui"}) {
+		t.Error("matching issue did not match")
+	}
+	for name, change := range map[string]func(*types.Issue){
+		"priority":   func(i *types.Issue) { i.Priority = 0 },
+		"status":     func(i *types.Issue) { i.Status = types.StatusClosed },
+		"complexity": func(i *types.Issue) { i.Complexity = types.ComplexityResearch },
+		"field":      func(i *types.Issue) { i.Fields = nil },
+	} {
+		other := *issue
+		change(&other)
+		if expr.Match(&other, []string{"backend"}) {
+			t.Errorf("issue with a different %s matched", name)
+		}
+	}
+	if expr.Match(issue, []string{"ui"}) {
+		t.Error("issue without the label matched")
+	}
+}
@@ -97,12 +97,57 @@ func GetMainRepoRoot() (string, error) {
 		return "", fmt.Errorf("failed to resolve common dir path: %w", err)
 	}
 
+	// A submodule's git directory lives in the superproject's
+	// .git/modules/<name>, so its parent isn't the work tree
+	if filepath.Base(absCommonDir) != ".git" {
+		if root, err := WorkTreeForGitDir(absCommonDir); err == nil {
+			return root, nil
+		}
+		if !IsWorktree() {
+			if root := getGitDirNoError("--show-toplevel"); root != "" {
+				return root, nil
+			}
+		}
+	}
+
 	// The main repo root is the parent of the .git directory
 	mainRepoRoot := filepath.Dir(absCommonDir)
 
 	return mainRepoRoot, nil
 }
 
+// WorkTreeForGitDir returns the work tree of a repository given its git
+// directory: the core.worktree setting git records for submodules (and
+// --separate-git-dir clones), else the parent of a ".git" directory.
+func WorkTreeForGitDir(gitDir string) (string, error) {
+	absGitDir, err := filepath.Abs(gitDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve git dir path: %w", err)
+	}
+	out, err := exec.Command("git", "--git-dir", absGitDir, "config", "--get", "core.worktree").Output() // #nosec G204 - absGitDir is a local path
+	if err == nil {
+		workTree := strings.TrimSpace(string(out))
+		if !filepath.IsAbs(workTree) {
+			workTree = filepath.Join(absGitDir, workTree)
+		}
+		return filepath.Clean(workTree), nil
+	}
+	if filepath.Base(absGitDir) == ".git" {
+		return filepath.Dir(absGitDir), nil
+	}
+	return "", fmt.Errorf("%s has no work tree", gitDir)
+}
+
+// GetSuperprojectRoot returns the work tree of the repository that has the
+// repository at dir as a submodule, or "" when it isn't a submodule.
+func GetSuperprojectRoot(dir string) string {
+	out, err := exec.Command("git", "-C", dir, "rev-parse", "--show-superproject-working-tree").Output() // #nosec G204 - dir is a local path
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
 // getGitDirNoError is a helper that returns empty string on error
 // to avoid cluttering code with error handling for simple checks.
 func getGitDirNoError(flag string) string {
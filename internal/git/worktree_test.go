@@ -1007,6 +1007,67 @@ func TestGetMainRepoRoot(t *testing.T) {
 			t.Errorf("GetMainRepoRoot() = %s, want %s (main repo)", actualRoot, expectedRoot)
 		}
 	})
+
+	t.Run("returns the submodule's work tree from a submodule", func(t *testing.T) {
+		parentPath, subPath := setupTestSubmodule(t)
+
+		originalDir, err := os.Getwd()
+		if err != nil {
+			t.Fatalf("Failed to get current dir: %v", err)
+		}
+		defer func() { _ = os.Chdir(originalDir) }()
+
+		if err := os.Chdir(filepath.Join(subPath, ".beads")); err != nil {
+			t.Fatalf("Failed to chdir to submodule: %v", err)
+		}
+
+		root, err := GetMainRepoRoot()
+		if err != nil {
+			t.Fatalf("GetMainRepoRoot failed: %v", err)
+		}
+
+		// The git dir is <parent>/.git/modules/sub, whose parent isn't a work tree
+		expectedRoot, _ := filepath.EvalSymlinks(subPath)
+		actualRoot, _ := filepath.EvalSymlinks(root)
+		if actualRoot != expectedRoot {
+			t.Errorf("GetMainRepoRoot() = %s, want %s (the submodule)", actualRoot, expectedRoot)
+		}
+
+		superRoot, _ := filepath.EvalSymlinks(GetSuperprojectRoot(subPath))
+		expectedSuper, _ := filepath.EvalSymlinks(parentPath)
+		if superRoot != expectedSuper {
+			t.Errorf("GetSuperprojectRoot() = %s, want %s", superRoot, expectedSuper)
+		}
+		if got := GetSuperprojectRoot(parentPath); got != "" {
+			t.Errorf("GetSuperprojectRoot(parent) = %s, want none", got)
+		}
+
+		workTree, err := WorkTreeForGitDir(filepath.Join(parentPath, ".git", "modules", "sub"))
+		if err != nil {
+			t.Fatalf("WorkTreeForGitDir failed: %v", err)
+		}
+		if resolved, _ := filepath.EvalSymlinks(workTree); resolved != expectedRoot {
+			t.Errorf("WorkTreeForGitDir(.git/modules/sub) = %s, want %s", resolved, expectedRoot)
+		}
+	})
+}
+
+// setupTestSubmodule creates a parent repository with a test repository
+// checked out as its submodule "sub", and returns both work trees
+func setupTestSubmodule(t *testing.T) (parentPath, subPath string) {
+	t.Helper()
+
+	origin, cleanup := setupTestRepo(t)
+	t.Cleanup(cleanup)
+	parentPath, cleanupParent := setupTestRepo(t)
+	t.Cleanup(cleanupParent)
+
+	cmd := exec.Command("git", "-c", "protocol.file.allow=always", "submodule", "add", origin, "sub")
+	cmd.Dir = parentPath
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("Failed to add submodule: %v\nOutput: %s", err, string(output))
+	}
+	return parentPath, filepath.Join(parentPath, "sub")
 }
 
 // TestIsWorktree tests the IsWorktree function
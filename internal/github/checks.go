@@ -0,0 +1,230 @@
+package github
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Check states, as bd gates record them
+const (
+	CheckPending = "pending"
+	CheckSuccess = "success"
+	CheckFailure = "failure"
+)
+
+// CheckResult is the latest outcome of a named check on a commit
+type CheckResult struct {
+	State  string `json:"state"`
+	Detail string `json:"detail,omitempty"`
+	URL    string `json:"url,omitempty"`
+	SHA    string `json:"sha"`
+}
+
+type checkRun struct {
+	Name       string `json:"name"`
+	HeadSHA    string `json:"head_sha"`
+	Status     string `json:"status"`     // queued, in_progress, completed
+	Conclusion string `json:"conclusion"` // success, failure, neutral, cancelled, skipped, timed_out, action_required
+	HTMLURL    string `json:"html_url"`
+	CheckSuite struct {
+		HeadBranch string `json:"head_branch"`
+	} `json:"check_suite"`
+	PullRequests []struct {
+		Number int `json:"number"`
+	} `json:"pull_requests"`
+}
+
+type commitStatus struct {
+	Context     string `json:"context"`
+	State       string `json:"state"` // pending, success, failure, error
+	Description string `json:"description"`
+	TargetURL   string `json:"target_url"`
+}
+
+// checkRunState maps a check run to a gate state. Neutral and skipped runs
+// count as passing, as they do for GitHub's required checks.
+func checkRunState(run *checkRun) (state, detail string) {
+	if run.Status != "completed" {
+		return CheckPending, run.Status
+	}
+	switch run.Conclusion {
+	case "success", "neutral", "skipped":
+		return CheckSuccess, run.Conclusion
+	}
+	return CheckFailure, run.Conclusion
+}
+
+func commitStatusState(status *commitStatus) string {
+	switch status.State {
+	case "success":
+		return CheckSuccess
+	case "pending":
+		return CheckPending
+	}
+	return CheckFailure
+}
+
+// refSHA resolves a branch name, or "pull/<n>" for a pull request's head,
+// to a commit SHA
+func (c *Client) refSHA(ctx context.Context, ref string) (string, error) {
+	if rest, ok := strings.CutPrefix(ref, "pull/"); ok {
+		var pr struct {
+			Head struct {
+				SHA string `json:"sha"`
+			} `json:"head"`
+		}
+		if _, err := c.do(ctx, http.MethodGet, c.repoURL("/pulls/"+url.PathEscape(rest)), nil, &pr); err != nil {
+			return "", err
+		}
+		return pr.Head.SHA, nil
+	}
+	var branch struct {
+		Commit struct {
+			SHA string `json:"sha"`
+		} `json:"commit"`
+	}
+	// Branch names may contain slashes, which GitHub takes unescaped
+	segments := strings.Split(ref, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	if _, err := c.do(ctx, http.MethodGet, c.repoURL("/branches/"+strings.Join(segments, "/")), nil, &branch); err != nil {
+		return "", err
+	}
+	return branch.Commit.SHA, nil
+}
+
+// CheckStatus returns the latest result of the check run or commit status
+// named name on the head of ref (a branch, or "pull/<n>"). A check that
+// hasn't reported on that commit yet is pending.
+func (c *Client) CheckStatus(ctx context.Context, ref, name string) (*CheckResult, error) {
+	sha, err := c.refSHA(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	result := &CheckResult{SHA: sha}
+
+	q := url.Values{}
+	q.Set("check_name", name)
+	q.Set("filter", "latest")
+	var runs struct {
+		CheckRuns []*checkRun `json:"check_runs"`
+	}
+	if _, err := c.do(ctx, http.MethodGet, c.repoURL("/commits/"+sha+"/check-runs?"+q.Encode()), nil, &runs); err != nil {
+		return nil, err
+	}
+	if len(runs.CheckRuns) > 0 {
+		run := runs.CheckRuns[0]
+		result.State, result.Detail = checkRunState(run)
+		result.URL = run.HTMLURL
+		return result, nil
+	}
+
+	// Fall back to commit statuses, which older CI systems report
+	var combined struct {
+		Statuses []*commitStatus `json:"statuses"`
+	}
+	if _, err := c.do(ctx, http.MethodGet, c.repoURL("/commits/"+sha+"/status"), nil, &combined); err != nil {
+		return nil, err
+	}
+	for _, status := range combined.Statuses {
+		if status.Context == name {
+			result.State = commitStatusState(status)
+			result.Detail = status.Description
+			result.URL = status.TargetURL
+			return result, nil
+		}
+	}
+	result.State = CheckPending
+	result.Detail = fmt.Sprintf("no check named %q on %.12s yet", name, sha)
+	return result, nil
+}
+
+// CheckEvent is a check result delivered by a webhook
+type CheckEvent struct {
+	Repo   string // owner/repo
+	Name   string
+	State  string
+	Detail string
+	URL    string
+	// Refs are the gate refs the result applies to: branch names and
+	// "pull/<n>" for pull requests
+	Refs []string
+}
+
+// ParseCheckEvent decodes a check_run or status webhook delivery; eventType
+// is the X-GitHub-Event header. It returns nil for events that carry no
+// check result.
+func ParseCheckEvent(eventType string, body []byte) (*CheckEvent, error) {
+	var payload struct {
+		CheckRun   *checkRun `json:"check_run"`
+		Repository struct {
+			FullName string `json:"full_name"`
+		} `json:"repository"`
+		// status events
+		Context     string `json:"context"`
+		State       string `json:"state"`
+		Description string `json:"description"`
+		TargetURL   string `json:"target_url"`
+		Branches    []struct {
+			Name string `json:"name"`
+		} `json:"branches"`
+	}
+	switch eventType {
+	case "check_run", "status":
+	default:
+		return nil, nil
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("github: invalid %s event: %w", eventType, err)
+	}
+
+	event := &CheckEvent{Repo: payload.Repository.FullName}
+	if eventType == "check_run" {
+		run := payload.CheckRun
+		if run == nil {
+			return nil, nil
+		}
+		event.Name, event.URL = run.Name, run.HTMLURL
+		event.State, event.Detail = checkRunState(run)
+		if run.CheckSuite.HeadBranch != "" {
+			event.Refs = append(event.Refs, run.CheckSuite.HeadBranch)
+		}
+		for _, pr := range run.PullRequests {
+			event.Refs = append(event.Refs, "pull/"+strconv.Itoa(pr.Number))
+		}
+		return event, nil
+	}
+
+	status := &commitStatus{Context: payload.Context, State: payload.State}
+	event.Name, event.URL, event.Detail = payload.Context, payload.TargetURL, payload.Description
+	event.State = commitStatusState(status)
+	for _, branch := range payload.Branches {
+		event.Refs = append(event.Refs, branch.Name)
+	}
+	return event, nil
+}
+
+// VerifySignature checks a webhook's X-Hub-Signature-256 header against the
+// shared secret
+func VerifySignature(secret string, body []byte, header string) bool {
+	sig, ok := strings.CutPrefix(header, "sha256=")
+	if !ok {
+		return false
+	}
+	got, err := hex.DecodeString(sig)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
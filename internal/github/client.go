@@ -0,0 +1,230 @@
+// Package github talks to the GitHub Issues REST API for bd github sync and
+// maps issues between GitHub and beads.
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultAPIURL is the GitHub REST API. GitHub Enterprise serves the same
+// API under https://HOST/api/v3.
+const DefaultAPIURL = "https://api.github.com"
+
+// maxResponseBytes bounds any single API response
+const maxResponseBytes = 32 << 20
+
+// Label is a GitHub issue label
+type Label struct {
+	Name string `json:"name"`
+}
+
+// User is a GitHub account
+type User struct {
+	Login string `json:"login"`
+}
+
+// Issue is a GitHub issue as returned by the REST API
+type Issue struct {
+	Number      int             `json:"number"`
+	Title       string          `json:"title"`
+	Body        string          `json:"body"`
+	State       string          `json:"state"` // "open" or "closed"
+	StateReason string          `json:"state_reason,omitempty"`
+	HTMLURL     string          `json:"html_url"`
+	Labels      []Label         `json:"labels"`
+	Assignees   []User          `json:"assignees"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+	PullRequest json.RawMessage `json:"pull_request,omitempty"` // Set when the "issue" is a pull request
+}
+
+// IsPullRequest reports whether the issues API returned a pull request
+func (i *Issue) IsPullRequest() bool {
+	return len(i.PullRequest) > 0 && string(i.PullRequest) != "null"
+}
+
+// IssueRequest is the body of a create or update call. Labels and
+// assignees are always sent, so an empty list clears them.
+type IssueRequest struct {
+	Title       string   `json:"title"`
+	Body        string   `json:"body"`
+	State       string   `json:"state,omitempty"`
+	StateReason string   `json:"state_reason,omitempty"`
+	Labels      []string `json:"labels"`
+	Assignees   []string `json:"assignees"`
+}
+
+// APIError is a non-2xx response from GitHub
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("github: %d %s", e.StatusCode, e.Message)
+}
+
+// Client calls the issues API of one repository
+type Client struct {
+	APIURL     string // DefaultAPIURL if empty
+	Token      string
+	Owner      string
+	Repo       string
+	HTTPClient *http.Client
+	UserAgent  string
+}
+
+// repoPattern accepts "owner/repo" and GitHub URLs of a repository
+var repoPattern = regexp.MustCompile(`^(?:(?:https?://|git@)[^/:]+[/:])?([A-Za-z0-9_.-]+)/([A-Za-z0-9_.-]+?)(?:\.git)?/?$`)
+
+// ParseRepo splits "owner/repo" (or a repository URL) into its parts
+func ParseRepo(s string) (owner, repo string, err error) {
+	m := repoPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return "", "", fmt.Errorf("invalid GitHub repository %q (want owner/repo)", s)
+	}
+	return m[1], m[2], nil
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return &http.Client{Timeout: time.Minute}
+}
+
+func (c *Client) repoURL(path string) string {
+	base := c.APIURL
+	if base == "" {
+		base = DefaultAPIURL
+	}
+	return fmt.Sprintf("%s/repos/%s/%s%s", strings.TrimSuffix(base, "/"), url.PathEscape(c.Owner), url.PathEscape(c.Repo), path)
+}
+
+// do sends a request and decodes a JSON response into out. It returns the
+// URL of the next page, if the response has one.
+func (c *Client) do(ctx context.Context, method, rawURL string, body, out interface{}) (string, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return "", err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, reader)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	ua := c.UserAgent
+	if ua == "" {
+		ua = "beads-github-sync"
+	}
+	req.Header.Set("User-Agent", ua)
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		msg := strings.TrimSpace(string(data))
+		if json.Unmarshal(data, &apiErr) == nil && apiErr.Message != "" {
+			msg = apiErr.Message
+		}
+		return "", &APIError{StatusCode: resp.StatusCode, Message: msg}
+	}
+	if out != nil {
+		if err := json.Unmarshal(data, out); err != nil {
+			return "", fmt.Errorf("github: decoding %s response: %w", rawURL, err)
+		}
+	}
+	return nextPage(resp.Header.Get("Link")), nil
+}
+
+// linkNextPattern finds the rel="next" URL in a Link header
+var linkNextPattern = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+func nextPage(link string) string {
+	if m := linkNextPattern.FindStringSubmatch(link); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// ListIssues returns every issue (open and closed, not pull requests)
+// updated at or after since; a zero since returns all of them
+func (c *Client) ListIssues(ctx context.Context, since time.Time) ([]*Issue, error) {
+	q := url.Values{}
+	q.Set("state", "all")
+	q.Set("per_page", "100")
+	q.Set("sort", "updated")
+	q.Set("direction", "asc")
+	if !since.IsZero() {
+		q.Set("since", since.UTC().Format(time.RFC3339))
+	}
+	next := c.repoURL("/issues?" + q.Encode())
+
+	var issues []*Issue
+	for next != "" {
+		var page []*Issue
+		var err error
+		if next, err = c.do(ctx, http.MethodGet, next, nil, &page); err != nil {
+			return nil, err
+		}
+		for _, issue := range page {
+			if !issue.IsPullRequest() {
+				issues = append(issues, issue)
+			}
+		}
+	}
+	return issues, nil
+}
+
+// CreateIssue opens a new issue. GitHub ignores state on create, so a
+// closed issue is created open and then closed.
+func (c *Client) CreateIssue(ctx context.Context, req *IssueRequest) (*Issue, error) {
+	create := *req
+	create.State, create.StateReason = "", ""
+	var issue Issue
+	if _, err := c.do(ctx, http.MethodPost, c.repoURL("/issues"), &create, &issue); err != nil {
+		return nil, err
+	}
+	if req.State == "closed" {
+		return c.UpdateIssue(ctx, issue.Number, req)
+	}
+	return &issue, nil
+}
+
+// UpdateIssue replaces an issue's title, body, state, labels and assignees
+func (c *Client) UpdateIssue(ctx context.Context, number int, req *IssueRequest) (*Issue, error) {
+	var issue Issue
+	if _, err := c.do(ctx, http.MethodPatch, c.repoURL("/issues/"+strconv.Itoa(number)), req, &issue); err != nil {
+		return nil, err
+	}
+	return &issue, nil
+}
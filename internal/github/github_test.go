@@ -0,0 +1,217 @@
+package github
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestParseRepo(t *testing.T) {
+	tests := []struct {
+		in, owner, repo string
+	}{
+		{"steveyegge/beads", "steveyegge", "beads"},
+		{"https://github.com/steveyegge/beads", "steveyegge", "beads"},
+		{"https://github.com/steveyegge/beads.git", "steveyegge", "beads"},
+		{"git@github.com:steveyegge/beads.git", "steveyegge", "beads"},
+	}
+	for _, tt := range tests {
+		owner, repo, err := ParseRepo(tt.in)
+		if err != nil || owner != tt.owner || repo != tt.repo {
+			t.Errorf("ParseRepo(%q) = %q, %q, %v", tt.in, owner, repo, err)
+		}
+	}
+	for _, bad := range []string{"", "beads", "a/b/c d"} {
+		if _, _, err := ParseRepo(bad); err == nil {
+			t.Errorf("ParseRepo(%q) should fail", bad)
+		}
+	}
+}
+
+func TestRequestRoundTrip(t *testing.T) {
+	issue := &types.Issue{ID: "bd-7", Title: " Fix login ", Description: "Steps:\n1. log in", Status: types.StatusClosed, Assignee: "octocat"}
+	local := LocalFields(issue, []string{"ui", "bug"})
+	req := Request(local, issue.ID, []string{"Blocked by #3"})
+	if req.State != "closed" || req.StateReason != "completed" || !reflect.DeepEqual(req.Assignees, []string{"octocat"}) {
+		t.Errorf("request = %+v", req)
+	}
+
+	// GitHub hands the body back with CRLF endings and labels in its own order
+	remote := &Issue{
+		Title:     req.Title,
+		Body:      "Steps:\r\n1. log in\r\n\r\n<!-- beads:bd-7 -->\r\nBlocked by #3",
+		State:     "closed",
+		Labels:    []Label{{Name: "bug"}, {Name: "ui"}},
+		Assignees: []User{{Login: "octocat"}, {Login: "hubot"}},
+	}
+	if got := RemoteFields(remote); got.Hash() != local.Hash() {
+		t.Errorf("remote fields %+v don't match local %+v", got, local)
+	}
+	description, beadsID := SplitBody(remote.Body)
+	if description != "Steps:\r\n1. log in" || beadsID != "bd-7" {
+		t.Errorf("SplitBody = %q, %q", description, beadsID)
+	}
+	if _, beadsID := SplitBody("no footer"); beadsID != "" {
+		t.Errorf("unexpected beads ID %q", beadsID)
+	}
+}
+
+func TestMentions(t *testing.T) {
+	got := Mentions("See #12 and (#4), again #12; not a#5, x/#6, &#39; or https://x.io/#7")
+	if !reflect.DeepEqual(got, []int{12, 4}) {
+		t.Errorf("Mentions = %v, want [12 4]", got)
+	}
+}
+
+func TestClientListAndCreate(t *testing.T) {
+	var patched []int
+	mux := http.NewServeMux()
+	var srv *httptest.Server
+	mux.HandleFunc("GET /repos/o/r/issues", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer tok" {
+			http.Error(w, `{"message":"Bad credentials"}`, http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Query().Get("since") != "2026-01-02T03:04:05Z" {
+			t.Errorf("since = %q", r.URL.Query().Get("since"))
+		}
+		if r.URL.Query().Get("page") == "" {
+			w.Header().Set("Link", fmt.Sprintf(`<%s/repos/o/r/issues?page=2&since=2026-01-02T03:04:05Z>; rel="next", <x>; rel="last"`, srv.URL))
+			_, _ = w.Write([]byte(`[{"number":1,"title":"one"},{"number":2,"title":"a PR","pull_request":{"url":"x"}}]`))
+			return
+		}
+		_, _ = w.Write([]byte(`[{"number":3,"title":"three"}]`))
+	})
+	mux.HandleFunc("POST /repos/o/r/issues", func(w http.ResponseWriter, r *http.Request) {
+		var req IssueRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req.State != "" {
+			t.Errorf("create sent state %q", req.State)
+		}
+		_, _ = w.Write([]byte(`{"number":9,"title":"` + req.Title + `","state":"open"}`))
+	})
+	mux.HandleFunc("PATCH /repos/o/r/issues/{n}", func(w http.ResponseWriter, r *http.Request) {
+		var n int
+		_, _ = fmt.Sscan(r.PathValue("n"), &n)
+		patched = append(patched, n)
+		_, _ = w.Write([]byte(`{"number":9,"state":"closed"}`))
+	})
+	srv = httptest.NewServer(mux)
+	defer srv.Close()
+
+	ctx := context.Background()
+	c := &Client{APIURL: srv.URL, Token: "tok", Owner: "o", Repo: "r"}
+	issues, err := c.ListIssues(ctx, time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("ListIssues: %v", err)
+	}
+	if len(issues) != 2 || issues[0].Number != 1 || issues[1].Number != 3 {
+		t.Errorf("issues = %+v, want #1 and #3 without the pull request", issues)
+	}
+
+	created, err := c.CreateIssue(ctx, &IssueRequest{Title: "done", State: "closed"})
+	if err != nil {
+		t.Fatalf("CreateIssue: %v", err)
+	}
+	if created.State != "closed" || !reflect.DeepEqual(patched, []int{9}) {
+		t.Errorf("closed create should be followed by a close, got %+v, patched %v", created, patched)
+	}
+
+	c.Token = "wrong"
+	_, err = c.ListIssues(ctx, time.Time{})
+	apiErr, ok := err.(*APIError)
+	if !ok || apiErr.StatusCode != http.StatusUnauthorized || apiErr.Message != "Bad credentials" {
+		t.Errorf("err = %v, want 401 Bad credentials", err)
+	}
+}
+
+func TestCheckStatus(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/o/r/branches/release/2.0", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"commit":{"sha":"aaa"}}`))
+	})
+	mux.HandleFunc("GET /repos/o/r/pulls/7", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"head":{"sha":"bbb"}}`))
+	})
+	mux.HandleFunc("GET /repos/o/r/commits/{sha}/check-runs", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("sha") == "aaa" && r.URL.Query().Get("check_name") == "build" {
+			_, _ = w.Write([]byte(`{"check_runs":[{"name":"build","status":"completed","conclusion":"timed_out","html_url":"https://ci/1"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"check_runs":[]}`))
+	})
+	mux.HandleFunc("GET /repos/o/r/commits/{sha}/status", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"statuses":[{"context":"ci/jenkins","state":"success","description":"Build passed"}]}`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	ctx := context.Background()
+	c := &Client{APIURL: srv.URL, Owner: "o", Repo: "r"}
+	tests := []struct {
+		ref, name string
+		want      string
+	}{
+		{"release/2.0", "build", CheckFailure},
+		{"pull/7", "ci/jenkins", CheckSuccess},
+		{"pull/7", "deploy", CheckPending},
+	}
+	for _, tt := range tests {
+		result, err := c.CheckStatus(ctx, tt.ref, tt.name)
+		if err != nil {
+			t.Fatalf("CheckStatus(%s, %s): %v", tt.ref, tt.name, err)
+		}
+		if result.State != tt.want {
+			t.Errorf("CheckStatus(%s, %s) = %+v, want %s", tt.ref, tt.name, result, tt.want)
+		}
+	}
+}
+
+func TestParseCheckEvent(t *testing.T) {
+	event, err := ParseCheckEvent("check_run", []byte(`{"action":"completed","repository":{"full_name":"o/r"},
+		"check_run":{"name":"build","status":"completed","conclusion":"success","html_url":"u",
+		"check_suite":{"head_branch":"main"},"pull_requests":[{"number":4}]}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event.Repo != "o/r" || event.Name != "build" || event.State != CheckSuccess || !reflect.DeepEqual(event.Refs, []string{"main", "pull/4"}) {
+		t.Errorf("check_run event = %+v", event)
+	}
+
+	event, err = ParseCheckEvent("status", []byte(`{"context":"ci/jenkins","state":"error","repository":{"full_name":"o/r"},"branches":[{"name":"main"}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event.Name != "ci/jenkins" || event.State != CheckFailure || !reflect.DeepEqual(event.Refs, []string{"main"}) {
+		t.Errorf("status event = %+v", event)
+	}
+
+	if event, err := ParseCheckEvent("push", []byte(`{}`)); event != nil || err != nil {
+		t.Errorf("push event = %+v, %v", event, err)
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	body := []byte(`{"zen":"Keep it logically awesome."}`)
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write(body)
+	good := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	if !VerifySignature("s3cret", body, good) {
+		t.Errorf("valid signature rejected")
+	}
+	for _, bad := range []string{"", "sha1=abc", "sha256=zz", good[:len(good)-2] + "00"} {
+		if VerifySignature("s3cret", body, bad) {
+			t.Errorf("signature %q accepted", bad)
+		}
+	}
+}
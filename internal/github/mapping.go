@@ -0,0 +1,142 @@
+package github
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Fields are the parts of an issue kept in step between beads and GitHub.
+// Comparing their hash with the one recorded at the last sync tells which
+// side changed.
+type Fields struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Closed      bool     `json:"closed"`
+	Labels      []string `json:"labels"`
+	Assignee    string   `json:"assignee"`
+}
+
+// normalize makes equal content compare equal whichever side it came from:
+// GitHub's web editor saves CRLF line endings, and label order is
+// meaningless
+func (f Fields) normalize() Fields {
+	f.Title = strings.TrimSpace(f.Title)
+	f.Description = strings.TrimSpace(strings.ReplaceAll(f.Description, "\r\n", "\n"))
+	labels := append([]string{}, f.Labels...)
+	sort.Strings(labels)
+	f.Labels = labels
+	return f
+}
+
+// Hash returns a stable hash of the fields
+func (f Fields) Hash() string {
+	data, _ := json.Marshal(f.normalize())
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// LocalFields returns the synced fields of a beads issue
+func LocalFields(issue *types.Issue, labels []string) Fields {
+	return Fields{
+		Title:       issue.Title,
+		Description: issue.Description,
+		Closed:      issue.Status == types.StatusClosed,
+		Labels:      labels,
+		Assignee:    issue.Assignee,
+	}.normalize()
+}
+
+// RemoteFields returns the synced fields of a GitHub issue. Only the first
+// assignee is kept, since a beads issue has one.
+func RemoteFields(issue *Issue) Fields {
+	description, _ := SplitBody(issue.Body)
+	f := Fields{
+		Title:       issue.Title,
+		Description: description,
+		Closed:      issue.State == "closed",
+		Labels:      []string{},
+	}
+	for _, l := range issue.Labels {
+		f.Labels = append(f.Labels, l.Name)
+	}
+	if len(issue.Assignees) > 0 {
+		f.Assignee = issue.Assignees[0].Login
+	}
+	return f.normalize()
+}
+
+// footerMarker starts the part of a GitHub issue body that bd writes: the
+// beads ID and cross-references to other synced issues. It is stripped
+// when the body is read back.
+const footerMarker = "<!-- beads:"
+
+var footerPattern = regexp.MustCompile(`<!-- beads:(\S+) -->`)
+
+// Request builds the create/update body for f. refs are cross-reference
+// lines such as "Blocked by #12", written under the description.
+func Request(f Fields, beadsID string, refs []string) *IssueRequest {
+	f = f.normalize()
+	var body strings.Builder
+	if f.Description != "" {
+		body.WriteString(f.Description)
+		body.WriteString("\n\n")
+	}
+	body.WriteString(footerMarker + beadsID + " -->")
+	for _, ref := range refs {
+		body.WriteString("\n" + ref)
+	}
+
+	req := &IssueRequest{
+		Title:     f.Title,
+		Body:      body.String(),
+		State:     "open",
+		Labels:    f.Labels,
+		Assignees: []string{},
+	}
+	if f.Closed {
+		req.State, req.StateReason = "closed", "completed"
+	}
+	if f.Assignee != "" {
+		req.Assignees = []string{f.Assignee}
+	}
+	return req
+}
+
+// SplitBody separates a GitHub issue body into the description and the
+// beads ID recorded in its footer, if any
+func SplitBody(body string) (description, beadsID string) {
+	i := strings.Index(body, footerMarker)
+	if i < 0 {
+		return body, ""
+	}
+	if m := footerPattern.FindStringSubmatch(body[i:]); m != nil {
+		beadsID = m[1]
+	}
+	return strings.TrimSpace(body[:i]), beadsID
+}
+
+// mentionPattern matches "#123" references, but not "a#1" or URL fragments
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w/&#])#(\d+)\b`)
+
+// Mentions returns the issue numbers referenced as #N in text, in order of
+// first appearance
+func Mentions(text string) []int {
+	var numbers []int
+	seen := make(map[int]bool)
+	for _, m := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		n, err := strconv.Atoi(m[1])
+		if err != nil || seen[n] {
+			continue
+		}
+		seen[n] = true
+		numbers = append(numbers, n)
+	}
+	return numbers
+}
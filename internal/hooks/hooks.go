@@ -16,6 +16,7 @@ const (
 	EventUpdate  = "update"
 	EventClose   = "close"
 	EventMessage = "message"
+	EventTimebox = "timebox"
 )
 
 // Hook file names
@@ -24,6 +25,7 @@ const (
 	HookOnUpdate  = "on_update"
 	HookOnClose   = "on_close"
 	HookOnMessage = "on_message"
+	HookOnTimebox = "on_timebox"
 )
 
 // Runner handles hook execution
@@ -122,6 +124,8 @@ func eventToHook(event string) string {
 		return HookOnClose
 	case EventMessage:
 		return HookOnMessage
+	case EventTimebox:
+		return HookOnTimebox
 	default:
 		return ""
 	}
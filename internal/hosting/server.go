@@ -0,0 +1,800 @@
+package hosting
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/beads/internal/oidc"
+	"github.com/steveyegge/beads/internal/redact"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// ActorHeader optionally names the person acting on behalf of a tenant.
+// Without it, changes are attributed to the tenant name.
+const ActorHeader = "X-Beads-Actor"
+
+// IdentityVerifier checks bearer tokens issued by an external identity
+// provider and decides which role an identity holds for a tenant
+type IdentityVerifier interface {
+	Verify(ctx context.Context, token string) (*oidc.Identity, error)
+	RoleFor(id *oidc.Identity, tenant string) string
+}
+
+// maxBodyBytes bounds request bodies so a tenant can't exhaust server memory
+const maxBodyBytes = 1 << 20
+
+// Host serves many tenants from one data directory. Each tenant's database is
+// opened on first use and kept open until the tenant is removed or the host
+// is closed.
+type Host struct {
+	dataDir string
+
+	mu          sync.Mutex
+	registry    *Registry
+	registryMod time.Time
+	registrySz  int64
+	stores      map[string]*sqlite.SQLiteStorage
+	opening     map[string]chan struct{} // Closed once the tenant's store is opened
+	writeLocks  map[string]*sync.Mutex   // Serialize each tenant's quota-checked writes
+	mux         *http.ServeMux
+	verifier    IdentityVerifier
+}
+
+// NewHost creates a host for dataDir
+func NewHost(dataDir string) (*Host, error) {
+	info, err := os.Stat(dataDir)
+	if err != nil {
+		return nil, fmt.Errorf("data directory: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("data directory %s is not a directory", dataDir)
+	}
+
+	h := &Host{
+		dataDir:    dataDir,
+		registry:   &Registry{},
+		stores:     make(map[string]*sqlite.SQLiteStorage),
+		opening:    make(map[string]chan struct{}),
+		writeLocks: make(map[string]*sync.Mutex),
+	}
+	if err := h.reloadRegistry(); err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	})
+	mux.HandleFunc("GET /t/{tenant}/issues", h.tenantHandler(h.handleList))
+	mux.HandleFunc("POST /t/{tenant}/issues", h.tenantHandler(h.handleCreate))
+	mux.HandleFunc("GET /t/{tenant}/issues/{id}", h.tenantHandler(h.handleGet))
+	mux.HandleFunc("PATCH /t/{tenant}/issues/{id}", h.tenantHandler(h.handleUpdate))
+	mux.HandleFunc("POST /t/{tenant}/issues/{id}/close", h.tenantHandler(h.handleClose))
+	mux.HandleFunc("POST /t/{tenant}/issues/{id}/comments", h.tenantHandler(h.handleComment))
+	mux.HandleFunc("GET /t/{tenant}/ready", h.tenantHandler(h.handleReady))
+	mux.HandleFunc("GET /t/{tenant}/stats", h.tenantHandler(h.handleStats))
+	h.mux = mux
+	return h, nil
+}
+
+// SetIdentityVerifier additionally accepts identity provider tokens (OIDC)
+// wherever a tenant token is accepted. Must be called before serving.
+func (h *Host) SetIdentityVerifier(v IdentityVerifier) {
+	h.verifier = v
+}
+
+// ServeHTTP implements http.Handler
+func (h *Host) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// Close closes all open tenant databases
+func (h *Host) Close() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var firstErr error
+	for name, store := range h.stores {
+		if err := store.Close(); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("closing tenant %s: %w", name, err)
+		}
+		delete(h.stores, name)
+	}
+	return firstErr
+}
+
+// tenantRequest carries the authenticated tenant and its store to handlers
+type tenantRequest struct {
+	tenant *Tenant
+	store  *sqlite.SQLiteStorage
+	dbPath string
+	actor  string
+
+	// writeMu is held from a quota check through the write it allows, so
+	// concurrent requests can't all pass the check and overshoot the quota
+	writeMu *sync.Mutex
+}
+
+type tenantHandlerFunc func(w http.ResponseWriter, r *http.Request, tr *tenantRequest)
+
+// tenantHandler authenticates the bearer token against the tenant named in
+// the path and opens that tenant's store. Tokens that aren't the tenant's own
+// are tried against the identity verifier, if one is set.
+func (h *Host) tenantHandler(fn tenantHandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := r.PathValue("tenant")
+		token, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		token = strings.TrimSpace(token)
+
+		tenant, store, err := h.authenticate(r.Context(), name, token)
+		var identity *oidc.Identity
+		if err != nil && h.verifier != nil && token != "" {
+			identity, err = h.verifier.Verify(r.Context(), token)
+		}
+		if err != nil {
+			// Unknown tenants and bad tokens look the same so tenant names can't be probed
+			w.Header().Set("WWW-Authenticate", `Bearer realm="bd"`)
+			writeError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+
+		actor := r.Header.Get(ActorHeader)
+		if identity != nil {
+			// Verified identities without a role get the same answer for
+			// unknown tenants, again so tenant names can't be probed
+			role := h.verifier.RoleFor(identity, name)
+			if role != "" {
+				tenant, store, err = h.openTenant(r.Context(), name)
+			}
+			if role == "" || err != nil {
+				writeError(w, http.StatusForbidden, "forbidden")
+				return
+			}
+			if role == oidc.RoleReader && r.Method != http.MethodGet {
+				writeError(w, http.StatusForbidden, "read-only access")
+				return
+			}
+			// The identity provider is authoritative for who is acting
+			actor = identity.Actor
+		}
+		if actor == "" {
+			actor = tenant.Name
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, maxBodyBytes)
+		fn(w, r, &tenantRequest{tenant: tenant, store: store, dbPath: TenantDBPath(h.dataDir, tenant.Name), actor: actor, writeMu: h.writeLock(tenant.Name)})
+	}
+}
+
+func (h *Host) authenticate(ctx context.Context, name, token string) (*Tenant, *sqlite.SQLiteStorage, error) {
+	return h.lookupTenant(ctx, name, func(tenant *Tenant) error {
+		if !tenant.CheckToken(token) {
+			return errors.New("invalid token")
+		}
+		return nil
+	})
+}
+
+// openTenant opens a tenant's store for a caller authorized some other way
+func (h *Host) openTenant(ctx context.Context, name string) (*Tenant, *sqlite.SQLiteStorage, error) {
+	return h.lookupTenant(ctx, name, func(*Tenant) error { return nil })
+}
+
+// lookupTenant finds the tenant, runs check under the registry lock and
+// opens the tenant's store on first use. The store is opened without holding
+// the lock, so a slow open (migrations on a large database) doesn't stall
+// requests for other tenants; requests for the same tenant wait for it.
+func (h *Host) lookupTenant(ctx context.Context, name string, check func(*Tenant) error) (*Tenant, *sqlite.SQLiteStorage, error) {
+	if err := h.reloadRegistry(); err != nil {
+		return nil, nil, err
+	}
+
+	for {
+		h.mu.Lock()
+		tenant := h.registry.Get(name)
+		if tenant == nil {
+			h.mu.Unlock()
+			return nil, nil, ErrTenantNotFound
+		}
+		if err := check(tenant); err != nil {
+			h.mu.Unlock()
+			return nil, nil, err
+		}
+		if store, ok := h.stores[name]; ok {
+			h.mu.Unlock()
+			return tenant, store, nil
+		}
+		if opening, ok := h.opening[name]; ok {
+			h.mu.Unlock()
+			select {
+			case <-opening:
+				continue
+			case <-ctx.Done():
+				return nil, nil, ctx.Err()
+			}
+		}
+		opened := make(chan struct{})
+		h.opening[name] = opened
+		h.mu.Unlock()
+
+		store, err := sqlite.New(ctx, TenantDBPath(h.dataDir, name))
+
+		h.mu.Lock()
+		delete(h.opening, name)
+		close(opened)
+		// The tenant may have been removed while its store was opening
+		if err == nil && h.registry.Get(name) == nil {
+			_ = store.Close()
+			err = ErrTenantNotFound
+		}
+		if err == nil {
+			h.stores[name] = store
+		}
+		h.mu.Unlock()
+		if err != nil {
+			return nil, nil, err
+		}
+		return tenant, store, nil
+	}
+}
+
+// writeLock returns the lock serializing the tenant's quota-checked writes
+func (h *Host) writeLock(name string) *sync.Mutex {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	mu, ok := h.writeLocks[name]
+	if !ok {
+		mu = &sync.Mutex{}
+		h.writeLocks[name] = mu
+	}
+	return mu
+}
+
+// reloadRegistry picks up tenants added, removed, or rotated by
+// 'bd tenant' commands while the server is running
+func (h *Host) reloadRegistry() error {
+	info, err := os.Stat(RegistryPath(h.dataDir))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if info.ModTime().Equal(h.registryMod) && info.Size() == h.registrySz {
+		return nil
+	}
+
+	reg, err := LoadRegistry(h.dataDir)
+	if err != nil {
+		return err
+	}
+	for name, store := range h.stores {
+		if reg.Get(name) == nil {
+			_ = store.Close()
+			delete(h.stores, name)
+			delete(h.writeLocks, name)
+		}
+	}
+	h.registry = reg
+	h.registryMod = info.ModTime()
+	h.registrySz = info.Size()
+	return nil
+}
+
+// checkWriteQuota rejects writes once the tenant's database reaches its size
+// limit. Callers hold tr.writeMu through the write.
+func checkWriteQuota(tr *tenantRequest) error {
+	if tr.tenant.Quota.MaxDBBytes <= 0 {
+		return nil
+	}
+	size := databaseSize(tr.dbPath)
+	if size >= tr.tenant.Quota.MaxDBBytes {
+		return fmt.Errorf("database size quota exceeded (%d of %d bytes)", size, tr.tenant.Quota.MaxDBBytes)
+	}
+	return nil
+}
+
+// checkCreateQuota additionally enforces the tenant's issue limit
+func checkCreateQuota(ctx context.Context, tr *tenantRequest) error {
+	if err := checkWriteQuota(tr); err != nil {
+		return err
+	}
+	if tr.tenant.Quota.MaxIssues <= 0 {
+		return nil
+	}
+	stats, err := tr.store.GetStatistics(ctx)
+	if err != nil {
+		return err
+	}
+	if stats.TotalIssues >= tr.tenant.Quota.MaxIssues {
+		return fmt.Errorf("issue quota exceeded (%d of %d issues)", stats.TotalIssues, tr.tenant.Quota.MaxIssues)
+	}
+	return nil
+}
+
+// databaseSize includes the WAL, which holds recent writes until checkpoint
+func databaseSize(dbPath string) int64 {
+	var total int64
+	for _, path := range []string{dbPath, dbPath + "-wal"} {
+		if info, err := os.Stat(path); err == nil {
+			total += info.Size()
+		}
+	}
+	return total
+}
+
+// CreateRequest is the body of POST /t/{tenant}/issues
+type CreateRequest struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description,omitempty"`
+	Type        string   `json:"type,omitempty"`
+	Priority    *int     `json:"priority,omitempty"`
+	Assignee    string   `json:"assignee,omitempty"`
+	Labels      []string `json:"labels,omitempty"`
+}
+
+// UpdateRequest is the body of PATCH /t/{tenant}/issues/{id}. Nil fields are left unchanged.
+type UpdateRequest struct {
+	Title              *string `json:"title,omitempty"`
+	Description        *string `json:"description,omitempty"`
+	Design             *string `json:"design,omitempty"`
+	AcceptanceCriteria *string `json:"acceptance_criteria,omitempty"`
+	Notes              *string `json:"notes,omitempty"`
+	Status             *string `json:"status,omitempty"`
+	Priority           *int    `json:"priority,omitempty"`
+	Assignee           *string `json:"assignee,omitempty"`
+}
+
+// StatsResponse is the body of GET /t/{tenant}/stats
+type StatsResponse struct {
+	Tenant     string            `json:"tenant"`
+	Prefix     string            `json:"prefix"`
+	Statistics *types.Statistics `json:"statistics"`
+	Quota      Quota             `json:"quota"`
+	DBBytes    int64             `json:"db_bytes"`
+}
+
+func (h *Host) handleList(w http.ResponseWriter, r *http.Request, tr *tenantRequest) {
+	q := r.URL.Query()
+	var filter types.IssueFilter
+	if v := q.Get("status"); v != "" {
+		status := types.Status(v)
+		filter.Status = &status
+	}
+	if v := q.Get("type"); v != "" {
+		issueType := types.IssueType(v)
+		filter.IssueType = &issueType
+	}
+	if v := q.Get("assignee"); v != "" {
+		filter.Assignee = &v
+	}
+	if v := q.Get("priority"); v != "" {
+		p, err := strconv.Atoi(v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid priority")
+			return
+		}
+		filter.Priority = &p
+	}
+	filter.Labels = q["label"]
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeError(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+		filter.Limit = n
+	}
+
+	// ?page_size= or ?cursor= switch the response to one page plus next_cursor
+	cursor := q.Get("cursor")
+	pageSize := 0
+	if v := q.Get("page_size"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			writeError(w, http.StatusBadRequest, "invalid page_size")
+			return
+		}
+		pageSize = n
+	}
+	if cursor != "" {
+		if _, err := types.DecodePageCursor(cursor); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	if wantsStream(r) {
+		if cursor != "" || pageSize > 0 {
+			writeError(w, http.StatusBadRequest, "streaming can't be combined with page_size or cursor")
+			return
+		}
+		streamIssues(w, r, tr, filter)
+		return
+	}
+	if cursor != "" || pageSize > 0 {
+		issues, next, err := storage.SearchIssuesPage(r.Context(), tr.store, q.Get("q"), filter, cursor, pageSize)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if issues == nil {
+			issues = []*types.Issue{}
+		}
+		writeJSON(w, http.StatusOK, issuePage{Issues: issues, NextCursor: next})
+		return
+	}
+
+	issues, err := tr.store.SearchIssues(r.Context(), q.Get("q"), filter)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if issues == nil {
+		issues = []*types.Issue{}
+	}
+	writeJSON(w, http.StatusOK, issues)
+}
+
+// issuePage is the paginated form of the issue list response
+type issuePage struct {
+	Issues     []*types.Issue `json:"issues"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
+
+// ndjsonType is the media type of streamed lists: one JSON issue per line
+const ndjsonType = "application/x-ndjson"
+
+// wantsStream reports whether a list request asked for NDJSON, with
+// ?stream=1 or an Accept header
+func wantsStream(r *http.Request) bool {
+	if v := r.URL.Query().Get("stream"); v != "" {
+		stream, _ := strconv.ParseBool(v)
+		return stream
+	}
+	for _, accept := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, _ := strings.Cut(accept, ";")
+		if strings.TrimSpace(mediaType) == ndjsonType {
+			return true
+		}
+	}
+	return false
+}
+
+// streamIssues writes the matching issues as NDJSON, flushing after each
+// page so the response is chunked and the server holds one page at a time.
+// An error after the first page can't change the status, so it ends the
+// stream as a final {"error": ...} line.
+func streamIssues(w http.ResponseWriter, r *http.Request, tr *tenantRequest, filter types.IssueFilter) {
+	flusher, _ := w.(http.Flusher)
+	enc := json.NewEncoder(w)
+	started := false
+	err := storage.ForEachIssuePage(r.Context(), tr.store, r.URL.Query().Get("q"), filter, storage.MaxPageSize, func(issues []*types.Issue) error {
+		if !started {
+			w.Header().Set("Content-Type", ndjsonType)
+			w.WriteHeader(http.StatusOK)
+			started = true
+		}
+		for _, issue := range issues {
+			if err := enc.Encode(issue); err != nil {
+				return err
+			}
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		return nil
+	})
+	switch {
+	case err != nil && !started:
+		writeError(w, http.StatusInternalServerError, err.Error())
+	case err != nil:
+		_ = enc.Encode(map[string]string{"error": err.Error()})
+	case !started:
+		w.Header().Set("Content-Type", ndjsonType)
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func (h *Host) handleReady(w http.ResponseWriter, r *http.Request, tr *tenantRequest) {
+	filter := types.WorkFilter{Status: types.StatusOpen}
+	if v := r.URL.Query().Get("assignee"); v != "" {
+		filter.Assignee = &v
+	}
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeError(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+		filter.Limit = n
+	}
+	issues, err := tr.store.GetReadyWork(r.Context(), filter)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if issues == nil {
+		issues = []*types.Issue{}
+	}
+	writeJSON(w, http.StatusOK, issues)
+}
+
+func (h *Host) handleGet(w http.ResponseWriter, r *http.Request, tr *tenantRequest) {
+	issue, ok := h.lookupIssue(w, r, tr)
+	if !ok {
+		return
+	}
+	issue.Labels, _ = tr.store.GetLabels(r.Context(), issue.ID)
+	writeJSON(w, http.StatusOK, issue)
+}
+
+func (h *Host) handleCreate(w http.ResponseWriter, r *http.Request, tr *tenantRequest) {
+	ctx := r.Context()
+	var req CreateRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if req.Title == "" {
+		writeError(w, http.StatusBadRequest, "title is required")
+		return
+	}
+	tr.writeMu.Lock()
+	defer tr.writeMu.Unlock()
+	if err := checkCreateQuota(ctx, tr); err != nil {
+		writeError(w, http.StatusForbidden, err.Error())
+		return
+	}
+	// Strip secrets before they reach the database (redaction.* config)
+	redactor, err := redact.FromConfig()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	redactor.RedactTexts(&req.Title, &req.Description)
+
+	issue := &types.Issue{
+		Title:       req.Title,
+		Description: req.Description,
+		Status:      types.StatusOpen,
+		Priority:    2,
+		IssueType:   types.TypeTask,
+		Assignee:    req.Assignee,
+	}
+	if req.Priority != nil {
+		issue.Priority = *req.Priority
+	}
+	if req.Type != "" {
+		issue.IssueType = types.IssueType(req.Type)
+	}
+
+	if err := tr.store.CreateIssue(ctx, issue, tr.actor); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	for _, label := range req.Labels {
+		if err := tr.store.AddLabel(ctx, issue.ID, label, tr.actor); err != nil {
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("created %s but failed to add label %q: %v", issue.ID, label, err))
+			return
+		}
+	}
+	issue.Labels = req.Labels
+	writeJSON(w, http.StatusCreated, issue)
+}
+
+func (h *Host) handleUpdate(w http.ResponseWriter, r *http.Request, tr *tenantRequest) {
+	ctx := r.Context()
+	issue, ok := h.lookupIssue(w, r, tr)
+	if !ok {
+		return
+	}
+	var req UpdateRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	updates := make(map[string]interface{})
+	setString := func(key string, v *string) {
+		if v != nil {
+			updates[key] = *v
+		}
+	}
+	setString("title", req.Title)
+	setString("description", req.Description)
+	setString("design", req.Design)
+	setString("acceptance_criteria", req.AcceptanceCriteria)
+	setString("notes", req.Notes)
+	setString("status", req.Status)
+	setString("assignee", req.Assignee)
+	if req.Priority != nil {
+		updates["priority"] = *req.Priority
+	}
+	if len(updates) == 0 {
+		writeError(w, http.StatusBadRequest, "no fields to update")
+		return
+	}
+	redactor, err := redact.FromConfig()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	redactor.RedactUpdates(updates)
+	tr.writeMu.Lock()
+	defer tr.writeMu.Unlock()
+	if err := checkWriteQuota(tr); err != nil {
+		writeError(w, http.StatusForbidden, err.Error())
+		return
+	}
+	if req.Status != nil && *req.Status == string(types.StatusClosed) {
+		if err := storage.CheckVerifiedClose(ctx, tr.store, issue.ID, tr.actor); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if err := storage.CheckDefinitionOfDone(ctx, tr.store, issue.ID); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	if err := tr.store.UpdateIssue(ctx, issue.ID, updates, tr.actor); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	updated, err := tr.store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, updated)
+}
+
+func (h *Host) handleClose(w http.ResponseWriter, r *http.Request, tr *tenantRequest) {
+	ctx := r.Context()
+	issue, ok := h.lookupIssue(w, r, tr)
+	if !ok {
+		return
+	}
+	var req struct {
+		Reason string `json:"reason"`
+		Note   string `json:"note"`
+	}
+	if r.ContentLength != 0 && !decodeBody(w, r, &req) {
+		return
+	}
+	tr.writeMu.Lock()
+	defer tr.writeMu.Unlock()
+	if err := checkWriteQuota(tr); err != nil {
+		writeError(w, http.StatusForbidden, err.Error())
+		return
+	}
+
+	reason, err := storage.ResolveCloseReason(ctx, tr.store, req.Reason, req.Note)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if reason == "" {
+		reason = "Closed"
+	}
+	if err := storage.CheckVerifiedClose(ctx, tr.store, issue.ID, tr.actor); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := storage.CheckDefinitionOfDone(ctx, tr.store, issue.ID); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := tr.store.CloseIssue(ctx, issue.ID, reason, tr.actor); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	closed, err := tr.store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, closed)
+}
+
+func (h *Host) handleComment(w http.ResponseWriter, r *http.Request, tr *tenantRequest) {
+	issue, ok := h.lookupIssue(w, r, tr)
+	if !ok {
+		return
+	}
+	var req struct {
+		Text string `json:"text"`
+	}
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if req.Text == "" {
+		writeError(w, http.StatusBadRequest, "text is required")
+		return
+	}
+	redactor, err := redact.FromConfig()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	redactor.RedactTexts(&req.Text)
+	tr.writeMu.Lock()
+	defer tr.writeMu.Unlock()
+	if err := checkWriteQuota(tr); err != nil {
+		writeError(w, http.StatusForbidden, err.Error())
+		return
+	}
+
+	comment, err := tr.store.AddIssueComment(r.Context(), issue.ID, tr.actor, req.Text)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, comment)
+}
+
+func (h *Host) handleStats(w http.ResponseWriter, r *http.Request, tr *tenantRequest) {
+	stats, err := tr.store.GetStatistics(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, StatsResponse{
+		Tenant:     tr.tenant.Name,
+		Prefix:     tr.tenant.Prefix,
+		Statistics: stats,
+		Quota:      tr.tenant.Quota,
+		DBBytes:    databaseSize(tr.dbPath),
+	})
+}
+
+// lookupIssue resolves the {id} path value (partial IDs allowed) and writes
+// a 404 if the issue doesn't exist
+func (h *Host) lookupIssue(w http.ResponseWriter, r *http.Request, tr *tenantRequest) (*types.Issue, bool) {
+	id, err := utils.ResolvePartialID(r.Context(), tr.store, r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return nil, false
+	}
+	issue, err := tr.store.GetIssue(r.Context(), id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return nil, false
+	}
+	if issue == nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("issue not found: %s", id))
+		return nil, false
+	}
+	return issue, true
+}
+
+func decodeBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
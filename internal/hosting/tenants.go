@@ -0,0 +1,254 @@
+// Package hosting implements multi-tenant hosting for bd serve --multi-tenant.
+//
+// A data directory holds one isolated SQLite database per tenant plus a
+// registry of tenants, their token hashes, and quotas:
+//
+//	<data-dir>/tenants.json
+//	<data-dir>/tenants/<name>/beads.db
+package hosting
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+)
+
+// RegistryFileName is the tenant registry file inside the data directory
+const RegistryFileName = "tenants.json"
+
+// tokenPrefix makes tenant tokens easy to recognize in logs and secret scanners
+const tokenPrefix = "bdt_"
+
+var tenantNameRe = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,62}$`)
+
+// ErrTenantNotFound is returned when a tenant is not in the registry
+var ErrTenantNotFound = errors.New("tenant not found")
+
+// Quota limits a tenant's usage. Zero values mean unlimited.
+type Quota struct {
+	MaxIssues  int   `json:"max_issues,omitempty"`
+	MaxDBBytes int64 `json:"max_db_bytes,omitempty"`
+}
+
+// Tenant is a hosted project with its own database and access token
+type Tenant struct {
+	Name      string    `json:"name"`
+	Prefix    string    `json:"prefix"`
+	TokenHash string    `json:"token_hash"`
+	Quota     Quota     `json:"quota"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Registry is the set of tenants hosted in a data directory
+type Registry struct {
+	Tenants []*Tenant `json:"tenants"`
+}
+
+// RegistryPath returns the registry file path for a data directory
+func RegistryPath(dataDir string) string {
+	return filepath.Join(dataDir, RegistryFileName)
+}
+
+// TenantDBPath returns the database path for a tenant
+func TenantDBPath(dataDir, name string) string {
+	return filepath.Join(dataDir, "tenants", name, "beads.db")
+}
+
+// ValidateTenantName checks that name is safe to use as a URL path segment
+// and directory name
+func ValidateTenantName(name string) error {
+	if !tenantNameRe.MatchString(name) {
+		return fmt.Errorf("invalid tenant name %q: use lowercase letters, digits, '-' and '_' (max 63 chars)", name)
+	}
+	return nil
+}
+
+// LoadRegistry reads the registry for dataDir. A missing file is an empty registry.
+func LoadRegistry(dataDir string) (*Registry, error) {
+	data, err := os.ReadFile(RegistryPath(dataDir)) // #nosec G304 - path derived from operator-supplied data dir
+	if os.IsNotExist(err) {
+		return &Registry{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tenant registry: %w", err)
+	}
+	var reg Registry
+	if err := json.Unmarshal(data, &reg); err != nil {
+		return nil, fmt.Errorf("failed to parse tenant registry %s: %w", RegistryPath(dataDir), err)
+	}
+	return &reg, nil
+}
+
+// Save writes the registry atomically with owner-only permissions
+func (r *Registry) Save(dataDir string) error {
+	if err := os.MkdirAll(dataDir, 0o750); err != nil {
+		return fmt.Errorf("failed to create data directory: %w", err)
+	}
+	sort.Slice(r.Tenants, func(i, j int) bool { return r.Tenants[i].Name < r.Tenants[j].Name })
+
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := RegistryPath(dataDir) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write tenant registry: %w", err)
+	}
+	if err := os.Rename(tmp, RegistryPath(dataDir)); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to write tenant registry: %w", err)
+	}
+	return nil
+}
+
+// Get returns the named tenant, or nil
+func (r *Registry) Get(name string) *Tenant {
+	for _, t := range r.Tenants {
+		if t.Name == name {
+			return t
+		}
+	}
+	return nil
+}
+
+// CheckToken reports whether token is the tenant's current token
+func (t *Tenant) CheckToken(token string) bool {
+	if token == "" || t.TokenHash == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(hashToken(token)), []byte(t.TokenHash)) == 1
+}
+
+// CreateTenant registers a tenant, initializes its database with the issue
+// prefix, and returns the tenant's token. The token is only available here;
+// the registry stores its hash.
+func CreateTenant(ctx context.Context, dataDir, name, prefix string, quota Quota) (string, error) {
+	if err := ValidateTenantName(name); err != nil {
+		return "", err
+	}
+	reg, err := LoadRegistry(dataDir)
+	if err != nil {
+		return "", err
+	}
+	if reg.Get(name) != nil {
+		return "", fmt.Errorf("tenant %q already exists", name)
+	}
+	if prefix == "" {
+		prefix = name
+	}
+
+	store, err := sqlite.New(ctx, TenantDBPath(dataDir, name))
+	if err != nil {
+		return "", fmt.Errorf("failed to create tenant database: %w", err)
+	}
+	err = store.SetConfig(ctx, "issue_prefix", prefix)
+	_ = store.Close()
+	if err != nil {
+		return "", fmt.Errorf("failed to set issue prefix: %w", err)
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return "", err
+	}
+	reg.Tenants = append(reg.Tenants, &Tenant{
+		Name:      name,
+		Prefix:    prefix,
+		TokenHash: hashToken(token),
+		Quota:     quota,
+		CreatedAt: time.Now().UTC(),
+	})
+	if err := reg.Save(dataDir); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// RotateToken replaces a tenant's token and returns the new one.
+// The old token stops working immediately.
+func RotateToken(dataDir, name string) (string, error) {
+	reg, err := LoadRegistry(dataDir)
+	if err != nil {
+		return "", err
+	}
+	tenant := reg.Get(name)
+	if tenant == nil {
+		return "", fmt.Errorf("%w: %s", ErrTenantNotFound, name)
+	}
+	token, err := newToken()
+	if err != nil {
+		return "", err
+	}
+	tenant.TokenHash = hashToken(token)
+	return token, reg.Save(dataDir)
+}
+
+// SetQuota replaces a tenant's quota
+func SetQuota(dataDir, name string, quota Quota) error {
+	reg, err := LoadRegistry(dataDir)
+	if err != nil {
+		return err
+	}
+	tenant := reg.Get(name)
+	if tenant == nil {
+		return fmt.Errorf("%w: %s", ErrTenantNotFound, name)
+	}
+	tenant.Quota = quota
+	return reg.Save(dataDir)
+}
+
+// RemoveTenant unregisters a tenant. With purge, its database directory is
+// deleted as well; otherwise the data is left on disk for archival.
+func RemoveTenant(dataDir, name string, purge bool) error {
+	reg, err := LoadRegistry(dataDir)
+	if err != nil {
+		return err
+	}
+	kept := reg.Tenants[:0]
+	found := false
+	for _, t := range reg.Tenants {
+		if t.Name == name {
+			found = true
+			continue
+		}
+		kept = append(kept, t)
+	}
+	if !found {
+		return fmt.Errorf("%w: %s", ErrTenantNotFound, name)
+	}
+	reg.Tenants = kept
+	if err := reg.Save(dataDir); err != nil {
+		return err
+	}
+	if purge {
+		if err := os.RemoveAll(filepath.Dir(TenantDBPath(dataDir, name))); err != nil {
+			return fmt.Errorf("failed to remove tenant data: %w", err)
+		}
+	}
+	return nil
+}
+
+func newToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	return tokenPrefix + hex.EncodeToString(buf), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -35,6 +35,7 @@ type Options struct {
 	RenameOnImport             bool           // Rename imported issues to match database prefix
 	SkipPrefixValidation       bool           // Skip prefix validation (for auto-import)
 	OrphanHandling             OrphanHandling // How to handle missing parent issues (default: allow)
+	StrictIntegrity            bool           // Fail on references to missing issues (integrity.strict)
 	ClearDuplicateExternalRefs bool            // Clear duplicate external_ref values instead of erroring
 	ProtectLocalExportIDs      map[string]bool // IDs from left snapshot to protect from deletion (bd-sync-deletion fix)
 }
@@ -106,6 +107,15 @@ func ImportIssues(ctx context.Context, dbPath string, store storage.Storage, iss
 		opts.OrphanHandling = sqliteStore.GetOrphanHandling(ctx)
 	}
 
+	// Strict integrity mode rejects orphans and dangling dependencies
+	// instead of importing or skipping them
+	if !opts.StrictIntegrity {
+		opts.StrictIntegrity = sqliteStore.IsStrictIntegrity(ctx)
+	}
+	if opts.StrictIntegrity && opts.OrphanHandling == OrphanAllow {
+		opts.OrphanHandling = OrphanStrict
+	}
+
 	// Check and handle prefix mismatches
 	issues, err = handlePrefixMismatch(ctx, sqliteStore, issues, opts, result)
 	if err != nil {
@@ -146,6 +156,36 @@ func ImportIssues(ctx context.Context, dbPath string, store storage.Storage, iss
 		return nil, err
 	}
 
+	// Import attachments
+	if err := importAttachments(ctx, sqliteStore, issues, opts); err != nil {
+		return nil, err
+	}
+
+	// Import work log
+	if err := importWorkLog(ctx, sqliteStore, issues, opts); err != nil {
+		return nil, err
+	}
+
+	// Record cross-references for recognized external_ref values
+	if err := importExternalRefs(ctx, sqliteStore, issues, opts); err != nil {
+		return nil, err
+	}
+
+	// Import aliases
+	if err := importAliases(ctx, sqliteStore, issues, opts); err != nil {
+		return nil, err
+	}
+
+	// Import translations
+	if err := importTranslations(ctx, sqliteStore, issues, opts); err != nil {
+		return nil, err
+	}
+
+	// Import custom field values
+	if err := importFields(ctx, sqliteStore, issues, opts); err != nil {
+		return nil, err
+	}
+
 	// Checkpoint WAL to ensure data persistence and reduce WAL file size
 	if err := sqliteStore.CheckpointWAL(ctx); err != nil {
 		// Non-fatal - just log warning
@@ -352,6 +392,9 @@ func handleRename(ctx context.Context, s *sqlite.SQLiteStorage, existing *types.
 				"priority":            incoming.Priority,
 				"issue_type":          incoming.IssueType,
 				"assignee":            incoming.Assignee,
+				"complexity":          string(incoming.Complexity),
+				"source":              incoming.Source,
+				"timebox_minutes":     incoming.TimeboxMinutes,
 			}
 			if err := s.UpdateIssue(ctx, existing.ID, updates, "importer"); err != nil {
 				return "", fmt.Errorf("failed to update issue %s: %w", existing.ID, err)
@@ -536,6 +579,9 @@ func upsertIssues(ctx context.Context, sqliteStore *sqlite.SQLiteStorage, issues
 					updates["acceptance_criteria"] = incoming.AcceptanceCriteria
 					updates["notes"] = incoming.Notes
 					updates["closed_at"] = incoming.ClosedAt
+					updates["complexity"] = string(incoming.Complexity)
+					updates["source"] = incoming.Source
+					updates["timebox_minutes"] = incoming.TimeboxMinutes
 					
 					if incoming.Assignee != "" {
 					 updates["assignee"] = incoming.Assignee
@@ -629,6 +675,9 @@ func upsertIssues(ctx context.Context, sqliteStore *sqlite.SQLiteStorage, issues
 				updates["acceptance_criteria"] = incoming.AcceptanceCriteria
 				updates["notes"] = incoming.Notes
 			updates["closed_at"] = incoming.ClosedAt
+			updates["complexity"] = string(incoming.Complexity)
+			updates["source"] = incoming.Source
+			updates["timebox_minutes"] = incoming.TimeboxMinutes
 
 				if incoming.Assignee != "" {
 				 updates["assignee"] = incoming.Assignee
@@ -767,8 +816,11 @@ func importDependencies(ctx context.Context, sqliteStore *sqlite.SQLiteStorage,
 			if err := sqliteStore.AddDependency(ctx, dep, "import"); err != nil {
 				// Check for FOREIGN KEY constraint violation
 				if sqlite.IsForeignKeyConstraintError(err) {
-					// Log warning and track skipped dependency
 					depDesc := fmt.Sprintf("%s → %s (%s)", dep.IssueID, dep.DependsOnID, dep.Type)
+					if opts.StrictIntegrity {
+						return fmt.Errorf("dependency %s references a missing issue (integrity.strict is enabled): %w", depDesc, err)
+					}
+					// Log warning and track skipped dependency
 					fmt.Fprintf(os.Stderr, "Warning: Skipping dependency due to missing reference: %s\n", depDesc)
 					if result != nil {
 						result.SkippedDependencies = append(result.SkippedDependencies, depDesc)
@@ -776,8 +828,9 @@ func importDependencies(ctx context.Context, sqliteStore *sqlite.SQLiteStorage,
 					continue
 				}
 
-				// For non-FK errors, respect strict mode
-				if opts.Strict {
+				// For non-FK errors (including missing issues caught before
+				// the insert), respect strict mode
+				if opts.Strict || opts.StrictIntegrity {
 					return fmt.Errorf("error adding dependency %s → %s: %w", dep.IssueID, dep.DependsOnID, err)
 				}
 				continue
@@ -810,7 +863,7 @@ func importLabels(ctx context.Context, sqliteStore *sqlite.SQLiteStorage, issues
 		for _, label := range issue.Labels {
 			if !currentLabelSet[label] {
 				if err := sqliteStore.AddLabel(ctx, issue.ID, label, "import"); err != nil {
-					if opts.Strict {
+					if opts.Strict || opts.StrictIntegrity {
 						return fmt.Errorf("error adding label %s to %s: %w", label, issue.ID, err)
 					}
 					continue
@@ -835,23 +888,65 @@ func importComments(ctx context.Context, sqliteStore *sqlite.SQLiteStorage, issu
 			return fmt.Errorf("error getting comments for %s: %w", issue.ID, err)
 		}
 
-		// Build a set of existing comments (by author+normalized text)
-		existingComments := make(map[string]bool)
+		// Count existing comments (by author+normalized text). Counting rather
+		// than a set keeps repeated identical comments on a fresh import while
+		// re-imports still add nothing.
+		existingComments := make(map[string]int)
+		// An edited comment keeps its author and creation time, which is
+		// how the local copy of a comment edited elsewhere is found
+		byOrigin := make(map[string]*types.Comment)
 		for _, c := range currentComments {
 			key := fmt.Sprintf("%s:%s", c.Author, strings.TrimSpace(c.Text))
-			existingComments[key] = true
+			existingComments[key]++
+			byOrigin[commentOrigin(c)] = c
 		}
 
-		// Add missing comments
+		// Add missing comments, keeping their original timestamps
 		for _, comment := range issue.Comments {
 			key := fmt.Sprintf("%s:%s", comment.Author, strings.TrimSpace(comment.Text))
-			if !existingComments[key] {
-				if _, err := sqliteStore.AddIssueComment(ctx, issue.ID, comment.Author, comment.Text); err != nil {
-					if opts.Strict {
-						return fmt.Errorf("error adding comment to %s: %w", issue.ID, err)
+			if existingComments[key] > 0 {
+				existingComments[key]--
+				continue
+			}
+			if local := byOrigin[commentOrigin(comment)]; local != nil {
+				// The same comment with other text: the newer edit wins
+				if comment.UpdatedAt != nil && (local.UpdatedAt == nil || comment.UpdatedAt.After(*local.UpdatedAt)) {
+					if _, err := sqliteStore.ImportIssueCommentEdit(ctx, local.ID, comment.Text, *comment.UpdatedAt); err != nil && opts.Strict {
+						return fmt.Errorf("error updating comment on %s: %w", issue.ID, err)
 					}
-					continue
 				}
+				continue
+			}
+			if _, err := sqliteStore.ImportIssueComment(ctx, issue.ID, comment.Author, comment.Text, comment.CreatedAt); err != nil {
+				if opts.Strict {
+					return fmt.Errorf("error adding comment to %s: %w", issue.ID, err)
+				}
+				continue
+			}
+		}
+	}
+
+	return nil
+}
+
+// commentOrigin identifies a comment across clones by its author and
+// creation time, which editing leaves unchanged
+func commentOrigin(c *types.Comment) string {
+	return fmt.Sprintf("%s@%d", c.Author, c.CreatedAt.Unix())
+}
+
+// importAttachments imports attachment records for issues. AddAttachment
+// ignores URLs the issue already has, so re-importing is harmless.
+func importAttachments(ctx context.Context, sqliteStore *sqlite.SQLiteStorage, issues []*types.Issue, opts Options) error {
+	for _, issue := range issues {
+		for _, attachment := range issue.Attachments {
+			a := *attachment
+			a.IssueID = issue.ID
+			if err := sqliteStore.AddAttachment(ctx, &a); err != nil {
+				if opts.Strict {
+					return fmt.Errorf("error adding attachment to %s: %w", issue.ID, err)
+				}
+				continue
 			}
 		}
 	}
@@ -859,6 +954,53 @@ func importComments(ctx context.Context, sqliteStore *sqlite.SQLiteStorage, issu
 	return nil
 }
 
+// importWorkLog imports work log entries for issues. AddWorkEntry ignores
+// entries the issue already has, so re-importing is harmless.
+func importWorkLog(ctx context.Context, sqliteStore *sqlite.SQLiteStorage, issues []*types.Issue, opts Options) error {
+	for _, issue := range issues {
+		for _, entry := range issue.WorkLog {
+			e := *entry
+			e.IssueID = issue.ID
+			if err := sqliteStore.AddWorkEntry(ctx, &e); err != nil {
+				if opts.Strict {
+					return fmt.Errorf("error logging time on %s: %w", issue.ID, err)
+				}
+				continue
+			}
+		}
+	}
+
+	return nil
+}
+
+// importExternalRefs links issues whose external_ref names an issue in a
+// known tracker (a GitHub or Jira URL, a Jira key, ...) in the cross-reference
+// table, so bd xref can find them. A remote ID already linked to another issue
+// is skipped unless importing strictly.
+func importExternalRefs(ctx context.Context, sqliteStore *sqlite.SQLiteStorage, issues []*types.Issue, opts Options) error {
+	for _, issue := range issues {
+		if issue.ExternalRef == nil {
+			continue
+		}
+		system, remoteID, ok := sqlite.ParseExternalRef(*issue.ExternalRef)
+		if !ok {
+			continue
+		}
+		ref := &sqlite.ExternalRef{IssueID: issue.ID, System: system, RemoteID: remoteID}
+		if strings.HasPrefix(*issue.ExternalRef, "http") {
+			ref.URL = *issue.ExternalRef
+		}
+		if err := sqliteStore.LinkExternalRef(ctx, ref); err != nil {
+			if opts.Strict {
+				return fmt.Errorf("error linking %s to %s %s: %w", issue.ID, system, remoteID, err)
+			}
+			continue
+		}
+	}
+
+	return nil
+}
+
 func GetPrefixList(prefixes map[string]int) []string {
 	var result []string
 	keys := make([]string, 0, len(prefixes))
@@ -917,3 +1059,58 @@ func validateNoDuplicateExternalRefs(issues []*types.Issue, clearDuplicates bool
 
 	return nil
 }
+
+// importAliases adds aliases from JSONL. Like labels, aliases missing from
+// the JSONL are kept; an alias another issue already holds is skipped unless
+// importing strictly.
+func importAliases(ctx context.Context, sqliteStore *sqlite.SQLiteStorage, issues []*types.Issue, opts Options) error {
+	for _, issue := range issues {
+		for _, alias := range issue.Aliases {
+			if err := sqliteStore.SetIssueAlias(ctx, issue.ID, alias, "import"); err != nil {
+				if opts.Strict {
+					return fmt.Errorf("error adding alias %s to %s: %w", alias, issue.ID, err)
+				}
+				continue
+			}
+		}
+	}
+
+	return nil
+}
+
+// importTranslations sets translations from JSONL, replacing the local text
+// for each locale present. Like labels, locales missing from the JSONL are
+// kept.
+func importTranslations(ctx context.Context, sqliteStore *sqlite.SQLiteStorage, issues []*types.Issue, opts Options) error {
+	for _, issue := range issues {
+		for _, t := range issue.Translations {
+			if err := sqliteStore.SetTranslation(ctx, issue.ID, t, "import"); err != nil {
+				if opts.Strict {
+					return fmt.Errorf("error setting %s translation of %s: %w", t.Locale, issue.ID, err)
+				}
+				continue
+			}
+		}
+	}
+
+	return nil
+}
+
+// importFields sets custom field values from JSONL, replacing the local
+// value of each field present. Values are taken as exported, even for
+// fields this clone hasn't defined; like labels, fields missing from the
+// JSONL are kept.
+func importFields(ctx context.Context, sqliteStore *sqlite.SQLiteStorage, issues []*types.Issue, opts Options) error {
+	for _, issue := range issues {
+		for name, value := range issue.Fields {
+			if err := sqliteStore.SetIssueField(ctx, issue.ID, name, value, "import"); err != nil {
+				if opts.Strict {
+					return fmt.Errorf("error setting field %s of %s: %w", name, issue.ID, err)
+				}
+				continue
+			}
+		}
+	}
+
+	return nil
+}
@@ -1477,3 +1477,182 @@ func TestImportMixedPrefixMismatch(t *testing.T) {
 		t.Errorf("Error should mention prefix mismatch, got: %v", err)
 	}
 }
+
+func TestImportIssues_CommentsKeepTimestampsAndDuplicates(t *testing.T) {
+	ctx := context.Background()
+
+	tmpDB := t.TempDir() + "/test.db"
+	store, err := sqlite.New(context.Background(), tmpDB)
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer store.Close()
+
+	if err := store.SetConfig(ctx, "issue_prefix", "test"); err != nil {
+		t.Fatalf("Failed to set prefix: %v", err)
+	}
+
+	written := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+	issues := []*types.Issue{{
+		ID:        "test-abc123",
+		Title:     "Commented",
+		Status:    types.StatusOpen,
+		Priority:  1,
+		IssueType: types.TypeTask,
+		Comments: []*types.Comment{
+			{Author: "alice", Text: "+1", CreatedAt: written},
+			{Author: "alice", Text: "+1", CreatedAt: written.Add(time.Hour)},
+		},
+	}}
+
+	// Importing twice must not add the comments again
+	for i := 0; i < 2; i++ {
+		if _, err := ImportIssues(ctx, tmpDB, store, issues, Options{}); err != nil {
+			t.Fatalf("Import %d failed: %v", i+1, err)
+		}
+	}
+
+	comments, err := store.GetIssueComments(ctx, "test-abc123")
+	if err != nil {
+		t.Fatalf("GetIssueComments failed: %v", err)
+	}
+	if len(comments) != 2 {
+		t.Fatalf("Expected 2 comments (identical text kept), got %d", len(comments))
+	}
+	if !comments[0].CreatedAt.Equal(written) || !comments[1].CreatedAt.Equal(written.Add(time.Hour)) {
+		t.Errorf("comment times = %v, %v; want the exported timestamps", comments[0].CreatedAt, comments[1].CreatedAt)
+	}
+}
+
+func TestImportIssues_CommentEditsNewerWins(t *testing.T) {
+	ctx := context.Background()
+
+	tmpDB := t.TempDir() + "/test.db"
+	store, err := sqlite.New(context.Background(), tmpDB)
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer store.Close()
+
+	if err := store.SetConfig(ctx, "issue_prefix", "test"); err != nil {
+		t.Fatalf("Failed to set prefix: %v", err)
+	}
+
+	written := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+	issueWith := func(c *types.Comment) []*types.Issue {
+		return []*types.Issue{{
+			ID: "test-abc123", Title: "Commented", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask,
+			Comments: []*types.Comment{c},
+		}}
+	}
+	if _, err := ImportIssues(ctx, tmpDB, store, issueWith(&types.Comment{Author: "alice", Text: "Teh fix", CreatedAt: written}), Options{}); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+
+	// An edit made in another clone replaces the text instead of adding a comment
+	editedAt := written.Add(time.Hour)
+	if _, err := ImportIssues(ctx, tmpDB, store, issueWith(&types.Comment{Author: "alice", Text: "The fix", CreatedAt: written, UpdatedAt: &editedAt}), Options{}); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	// and a stale copy of the comment doesn't undo it
+	if _, err := ImportIssues(ctx, tmpDB, store, issueWith(&types.Comment{Author: "alice", Text: "Teh fix", CreatedAt: written}), Options{}); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+
+	comments, err := store.GetIssueComments(ctx, "test-abc123")
+	if err != nil {
+		t.Fatalf("GetIssueComments failed: %v", err)
+	}
+	if len(comments) != 1 || comments[0].Text != "The fix" {
+		t.Fatalf("comments = %+v, want the single edited comment", comments)
+	}
+	if comments[0].UpdatedAt == nil || !comments[0].UpdatedAt.Equal(editedAt) {
+		t.Errorf("updated_at = %v, want the imported edit time %v", comments[0].UpdatedAt, editedAt)
+	}
+}
+
+func TestImportIssues_Aliases(t *testing.T) {
+	ctx := context.Background()
+
+	tmpDB := t.TempDir() + "/test.db"
+	store, err := sqlite.New(context.Background(), tmpDB)
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer store.Close()
+
+	if err := store.SetConfig(ctx, "issue_prefix", "test"); err != nil {
+		t.Fatalf("Failed to set prefix: %v", err)
+	}
+
+	issues := []*types.Issue{
+		{ID: "test-abc123", Title: "Aliased", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask,
+			Aliases: []string{"auth-refactor", "login"}},
+		// A conflicting alias is skipped outside strict mode
+		{ID: "test-def456", Title: "Conflict", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask,
+			Aliases: []string{"login"}},
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := ImportIssues(ctx, tmpDB, store, issues, Options{}); err != nil {
+			t.Fatalf("Import %d failed: %v", i+1, err)
+		}
+	}
+
+	aliases, err := store.GetAliasesForIssues(ctx, []string{"test-abc123", "test-def456"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := aliases["test-abc123"]; len(got) != 2 || got[0] != "auth-refactor" || got[1] != "login" {
+		t.Errorf("aliases = %v", got)
+	}
+	if got := aliases["test-def456"]; len(got) != 0 {
+		t.Errorf("conflicting alias imported: %v", got)
+	}
+
+	if _, err := ImportIssues(ctx, tmpDB, store, issues, Options{Strict: true}); err == nil {
+		t.Error("expected a strict import with a conflicting alias to fail")
+	}
+}
+
+func TestImportIssues_LinksExternalRefs(t *testing.T) {
+	ctx := context.Background()
+
+	tmpDB := t.TempDir() + "/test.db"
+	store, err := sqlite.New(context.Background(), tmpDB)
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer store.Close()
+
+	if err := store.SetConfig(ctx, "issue_prefix", "test"); err != nil {
+		t.Fatalf("Failed to set prefix: %v", err)
+	}
+
+	githubURL := "https://github.com/acme/app/issues/12"
+	jiraKey := "PROJ-7"
+	other := "see the wiki"
+	issues := []*types.Issue{
+		{ID: "test-gh", Title: "From GitHub", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeBug, ExternalRef: &githubURL},
+		{ID: "test-jira", Title: "From Jira", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, ExternalRef: &jiraKey},
+		{ID: "test-other", Title: "Unrecognized", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, ExternalRef: &other},
+	}
+	if _, err := ImportIssues(ctx, tmpDB, store, issues, Options{}); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+
+	refs, err := store.GetExternalRefsForIssue(ctx, "test-gh")
+	if err != nil {
+		t.Fatalf("GetExternalRefsForIssue failed: %v", err)
+	}
+	if len(refs) != 1 || refs[0].System != "github:acme/app" || refs[0].RemoteID != "12" || refs[0].URL != githubURL {
+		t.Errorf("refs for test-gh = %+v, want github:acme/app 12", refs)
+	}
+	refs, _ = store.GetExternalRefsForIssue(ctx, "test-jira")
+	if len(refs) != 1 || refs[0].System != "jira" || refs[0].RemoteID != "PROJ-7" {
+		t.Errorf("refs for test-jira = %+v, want jira PROJ-7", refs)
+	}
+	refs, _ = store.GetExternalRefsForIssue(ctx, "test-other")
+	if len(refs) != 0 {
+		t.Errorf("refs for test-other = %+v, want none", refs)
+	}
+}
@@ -85,6 +85,21 @@ func (fc *fieldComparator) equalPtrStr(existing *string, newVal interface{}) boo
 	return *existing == s
 }
 
+func (fc *fieldComparator) equalPtrInt(existing *int, newVal interface{}) bool {
+	if p, ok := newVal.(*int); ok {
+		if p == nil {
+			newVal = nil
+		} else {
+			newVal = *p
+		}
+	}
+	if newVal == nil {
+		return existing == nil
+	}
+	n, ok := fc.intFrom(newVal)
+	return ok && existing != nil && int64(*existing) == n
+}
+
 func (fc *fieldComparator) equalStatus(existing types.Status, newVal interface{}) bool {
 	switch t := newVal.(type) {
 	case types.Status:
@@ -134,6 +149,12 @@ func (fc *fieldComparator) checkFieldChanged(key string, existing *types.Issue,
 		return !fc.equalStr(existing.Assignee, newVal)
 	case "external_ref":
 		return !fc.equalPtrStr(existing.ExternalRef, newVal)
+	case "complexity":
+		return !fc.equalStr(string(existing.Complexity), newVal)
+	case "source":
+		return !fc.equalStr(existing.Source, newVal)
+	case "timebox_minutes":
+		return !fc.equalPtrInt(existing.TimeboxMinutes, newVal)
 	default:
 		return false
 	}
@@ -0,0 +1,197 @@
+// Package intake turns bug reports filed from outside bd, such as by a crash
+// reporter or a web form posting to the daemon's intake endpoint, into
+// issues. Each report passes through the hooks configured under
+// intake.hooks, which can reject it or rewrite it, before it is filed.
+package intake
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// DefaultSource is the source recorded on issues whose report names none
+const DefaultSource = "intake"
+
+// HookTimeout bounds each hook run
+const HookTimeout = 10 * time.Second
+
+// Report is a bug report as posted to the intake endpoint, and as hooks
+// read it on stdin and print it back
+type Report struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description,omitempty"`
+	Type        string   `json:"type,omitempty"`     // Issue type; bug if empty
+	Priority    *int     `json:"priority,omitempty"` // 0-4; 2 if unset
+	Labels      []string `json:"labels,omitempty"`
+	Source      string   `json:"source,omitempty"`       // Where the report came from (sentry, form); intake if empty
+	ExternalRef string   `json:"external_ref,omitempty"` // The reporter's own ID; reports with the same one are duplicates
+	Reporter    string   `json:"reporter,omitempty"`     // Who reported it, noted in the description
+}
+
+// Parse decodes a report posted to the intake endpoint
+func Parse(body []byte) (*Report, error) {
+	var r Report
+	if err := json.Unmarshal(body, &r); err != nil {
+		return nil, fmt.Errorf("invalid report: %w", err)
+	}
+	return &r, nil
+}
+
+// Issue returns the issue to file for the report, with its defaults
+// filled in, or an error saying why the report can't be filed
+func (r *Report) Issue() (*types.Issue, error) {
+	issue := &types.Issue{
+		Title:       strings.TrimSpace(r.Title),
+		Description: r.Description,
+		Status:      types.StatusOpen,
+		Priority:    2,
+		IssueType:   types.TypeBug,
+		Source:      strings.TrimSpace(r.Source),
+	}
+	if r.Priority != nil {
+		issue.Priority = *r.Priority
+	}
+	if r.Type != "" {
+		issue.IssueType = types.IssueType(r.Type)
+	}
+	if issue.Source == "" {
+		issue.Source = DefaultSource
+	}
+	if ref := strings.TrimSpace(r.ExternalRef); ref != "" {
+		issue.ExternalRef = &ref
+	}
+	if reporter := strings.TrimSpace(r.Reporter); reporter != "" {
+		if issue.Description != "" {
+			issue.Description += "\n\n"
+		}
+		issue.Description += "Reported by " + reporter
+	}
+	if err := issue.Validate(); err != nil {
+		return nil, err
+	}
+	for _, label := range r.Labels {
+		if strings.TrimSpace(label) == "" {
+			return nil, fmt.Errorf("labels must not be empty")
+		}
+	}
+	return issue, nil
+}
+
+// Hook is a command a report is run past before it is filed
+type Hook struct {
+	Path string
+	Args []string
+}
+
+// String returns the hook's command line
+func (h Hook) String() string {
+	return strings.Join(append([]string{h.Path}, h.Args...), " ")
+}
+
+// ParseHooks splits the commands configured under intake.hooks on
+// whitespace, resolving a relative executable path against baseDir
+func ParseHooks(commands []string, baseDir string) []Hook {
+	var hooks []Hook
+	for _, command := range commands {
+		fields := strings.Fields(command)
+		if len(fields) == 0 {
+			continue
+		}
+		exe := fields[0]
+		if strings.ContainsRune(exe, filepath.Separator) || strings.ContainsRune(exe, '/') {
+			if !filepath.IsAbs(exe) {
+				exe = filepath.Join(baseDir, exe)
+			}
+		}
+		hooks = append(hooks, Hook{Path: exe, Args: fields[1:]})
+	}
+	return hooks
+}
+
+// RejectedError is returned when a hook turns a report down
+type RejectedError struct {
+	Hook   string
+	Reason string
+}
+
+func (e *RejectedError) Error() string {
+	return fmt.Sprintf("rejected by %s: %s", e.Hook, e.Reason)
+}
+
+// RunHooks runs the report past each hook in turn, in dir. A hook reads the
+// report as JSON on stdin. It accepts the report by exiting 0, and may
+// enrich it by printing a replacement report on stdout; it rejects the
+// report by exiting non-zero, with the reason on the first line of stderr.
+// A hook that can't be run, times out or prints something other than a
+// report fails the run with an error that isn't a RejectedError.
+func RunHooks(ctx context.Context, hooks []Hook, dir string, report *Report) (*Report, error) {
+	for _, hook := range hooks {
+		next, err := runHook(ctx, hook, dir, report)
+		if err != nil {
+			return nil, err
+		}
+		report = next
+	}
+	return report, nil
+}
+
+func runHook(ctx context.Context, hook Hook, dir string, report *Report) (*Report, error) {
+	input, err := json.Marshal(report)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode report: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, HookTimeout)
+	defer cancel()
+	// #nosec G204 - hooks are configured by the project, like git hooks
+	cmd := exec.CommandContext(ctx, hook.Path, hook.Args...)
+	cmd.Dir = dir
+	cmd.Stdin = bytes.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// Don't wait on children the hook left holding its output open
+	cmd.WaitDelay = time.Second
+
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && ctx.Err() == nil {
+			return nil, &RejectedError{Hook: hook.String(), Reason: firstLine(stderr.String(), exitErr)}
+		}
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("intake hook %s timed out after %v", hook, HookTimeout)
+		}
+		return nil, fmt.Errorf("intake hook %s failed: %w", hook, err)
+	}
+
+	if len(bytes.TrimSpace(stdout.Bytes())) == 0 {
+		return report, nil
+	}
+	var enriched Report
+	if err := json.Unmarshal(stdout.Bytes(), &enriched); err != nil {
+		return nil, fmt.Errorf("intake hook %s printed an invalid report: %w", hook, err)
+	}
+	return &enriched, nil
+}
+
+// firstLine returns the first non-blank line of a hook's stderr, or its
+// exit status if it printed nothing
+func firstLine(stderr string, exitErr *exec.ExitError) string {
+	scanner := bufio.NewScanner(strings.NewReader(stderr))
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			return line
+		}
+	}
+	return exitErr.Error()
+}
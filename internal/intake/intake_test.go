@@ -0,0 +1,110 @@
+package intake
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestReportIssue(t *testing.T) {
+	report, err := Parse([]byte(`{"title":"  crash on save ","description":"stack trace","external_ref":"sentry-1","reporter":"ops@example.com"}`))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	issue, err := report.Issue()
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+	if issue.Title != "crash on save" || issue.IssueType != types.TypeBug || issue.Priority != 2 || issue.Source != DefaultSource {
+		t.Errorf("issue = %+v, want defaults filled in", issue)
+	}
+	if issue.ExternalRef == nil || *issue.ExternalRef != "sentry-1" {
+		t.Errorf("external_ref = %v, want sentry-1", issue.ExternalRef)
+	}
+	if issue.Description != "stack trace\n\nReported by ops@example.com" {
+		t.Errorf("description = %q", issue.Description)
+	}
+
+	zero := 0
+	issue, err = (&Report{Title: "x", Type: "task", Priority: &zero, Source: "form"}).Issue()
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+	if issue.IssueType != types.TypeTask || issue.Priority != 0 || issue.Source != "form" {
+		t.Errorf("issue = %+v, want the report's type, priority and source", issue)
+	}
+
+	five := 5
+	for name, bad := range map[string]*Report{
+		"no title":     {Title: "  "},
+		"bad type":     {Title: "x", Type: "incident"},
+		"bad priority": {Title: "x", Priority: &five},
+		"empty label":  {Title: "x", Labels: []string{""}},
+	} {
+		if _, err := bad.Issue(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	if _, err := Parse([]byte(`{"title":`)); err == nil {
+		t.Error("expected invalid JSON to be rejected")
+	}
+}
+
+func TestParseHooks(t *testing.T) {
+	hooks := ParseHooks([]string{"scripts/enrich.sh --team web", "triage", "  ", "/usr/bin/check"}, "/repo")
+	var got []string
+	for _, h := range hooks {
+		got = append(got, h.String())
+	}
+	want := []string{filepath.Join("/repo", "scripts/enrich.sh") + " --team web", "triage", "/usr/bin/check"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("hooks = %q, want %q", got, want)
+	}
+}
+
+func TestRunHooks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hooks are shell scripts")
+	}
+	dir := t.TempDir()
+	write := func(name, script string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"+script), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("pass.sh", "cat >/dev/null\n")
+	write("enrich.sh", `sed 's/"title":"/"labels":["triaged"],"title":"[web] /'`+"\n")
+	write("reject.sh", "cat >/dev/null\necho\necho 'missing stack trace' >&2\necho 'details' >&2\nexit 1\n")
+	write("garbage.sh", "cat >/dev/null\necho 'not json'\n")
+
+	ctx := context.Background()
+	report := &Report{Title: "crash", Source: "sentry"}
+	got, err := RunHooks(ctx, ParseHooks([]string{"./pass.sh", "./enrich.sh"}, dir), dir, report)
+	if err != nil {
+		t.Fatalf("RunHooks failed: %v", err)
+	}
+	if got.Title != "[web] crash" || len(got.Labels) != 1 || got.Labels[0] != "triaged" || got.Source != "sentry" {
+		t.Errorf("enriched report = %+v", got)
+	}
+
+	_, err = RunHooks(ctx, ParseHooks([]string{"./pass.sh", "./reject.sh", "./enrich.sh"}, dir), dir, report)
+	var rejected *RejectedError
+	if !errors.As(err, &rejected) || rejected.Reason != "missing stack trace" {
+		t.Errorf("expected a rejection with the first stderr line, got %v", err)
+	}
+
+	for _, hook := range []string{"./garbage.sh", "./missing.sh"} {
+		_, err := RunHooks(ctx, ParseHooks([]string{hook}, dir), dir, report)
+		if err == nil || errors.As(err, &rejected) {
+			t.Errorf("%s: expected a hook failure, got %v", hook, err)
+		}
+	}
+}
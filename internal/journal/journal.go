@@ -0,0 +1,161 @@
+// Package journal records bd CLI invocations in an append-only JSONL file,
+// ~/.beads/history.jsonl by default, so a sequence of commands can be
+// inspected or replayed on another project.
+//
+// Each invocation writes two lines sharing an ID: one when it starts (args,
+// directory, actor) and one when it finishes (exit code, duration, error).
+// bd exits from many places, so the second line can be missing; Read merges
+// the pairs and leaves ExitCode nil when the result was not recorded.
+package journal
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileName is the journal's name in ~/.beads
+const FileName = "history.jsonl"
+
+// Entry is one recorded invocation
+type Entry struct {
+	ID      string    `json:"id"`
+	Time    time.Time `json:"time"`
+	Args    []string  `json:"args,omitempty"` // without the program name
+	Dir     string    `json:"dir,omitempty"`
+	Actor   string    `json:"actor,omitempty"`
+	Version string    `json:"version,omitempty"`
+
+	// Result, from the invocation's second line
+	ExitCode   *int   `json:"exit_code,omitempty"`
+	DurationMs int64  `json:"duration_ms,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// Succeeded reports whether the invocation is known to have exited 0
+func (e *Entry) Succeeded() bool {
+	return e.ExitCode != nil && *e.ExitCode == 0
+}
+
+// DefaultPath returns ~/.beads/history.jsonl
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, ".beads", FileName), nil
+}
+
+// Journal appends entries to one file
+type Journal struct {
+	path string
+}
+
+// Open returns a journal writing to path, creating its directory
+func Open(path string) (*Journal, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create journal directory: %w", err)
+	}
+	return &Journal{path: path}, nil
+}
+
+// Path returns the journal file
+func (j *Journal) Path() string { return j.path }
+
+// Start records the beginning of an invocation and returns its entry, which
+// Finish completes
+func (j *Journal) Start(args []string, dir, actor, version string) (*Entry, error) {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	entry := &Entry{
+		ID:      hex.EncodeToString(id),
+		Time:    time.Now().UTC(),
+		Args:    args,
+		Dir:     dir,
+		Actor:   actor,
+		Version: version,
+	}
+	return entry, j.append(entry)
+}
+
+// Finish records the result of a started invocation
+func (j *Journal) Finish(started *Entry, exitCode int, errMsg string) error {
+	return j.append(&result{
+		ID:         started.ID,
+		ExitCode:   exitCode,
+		DurationMs: time.Since(started.Time).Milliseconds(),
+		Error:      errMsg,
+	})
+}
+
+// result is the second line of an invocation
+type result struct {
+	ID         string `json:"id"`
+	ExitCode   int    `json:"exit_code"`
+	DurationMs int64  `json:"duration_ms"`
+	Error      string `json:"error,omitempty"`
+}
+
+// append writes one line with a single write, which O_APPEND keeps whole
+// when several bd processes record at once
+func (j *Journal) append(line any) error {
+	data, err := json.Marshal(line)
+	if err != nil {
+		return err
+	}
+	// #nosec G304 - the journal path comes from the user's own config
+	f, err := os.OpenFile(j.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Read returns the invocations in a journal file, oldest first, with each
+// result merged into its start entry. Result lines without a start entry
+// and unparseable lines are skipped.
+func Read(path string) ([]*Entry, error) {
+	// #nosec G304 - reading a journal the user pointed at
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	var entries []*Entry
+	byID := make(map[string]*Entry)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil || entry.ID == "" {
+			continue
+		}
+		if started, ok := byID[entry.ID]; ok {
+			started.ExitCode = entry.ExitCode
+			started.DurationMs = entry.DurationMs
+			started.Error = entry.Error
+			continue
+		}
+		if entry.Args == nil {
+			continue
+		}
+		byID[entry.ID] = &entry
+		entries = append(entries, &entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return entries, nil
+}
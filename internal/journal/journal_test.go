@@ -0,0 +1,53 @@
+package journal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestJournalRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", FileName)
+	j, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	create, err := j.Start([]string{"create", "Fix login", "-p", "1"}, "/work/api", "alice", "0.30.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// An invocation that exits without recording a result
+	if _, err := j.Start([]string{"sync"}, "/work/api", "alice", "0.30.0"); err != nil {
+		t.Fatal(err)
+	}
+	show, _ := j.Start([]string{"show", "bd-1"}, "/work/api", "alice", "0.30.0")
+	if err := j.Finish(show, 1, "issue bd-1 not found"); err != nil {
+		t.Fatal(err)
+	}
+	if err := j.Finish(create, 0, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	// Garbage and orphaned results are skipped
+	f, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	_, _ = f.WriteString("not json\n{\"id\":\"orphan\",\"exit_code\":0}\n")
+	_ = f.Close()
+
+	entries, err := Read(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3", len(entries))
+	}
+	if e := entries[0]; e.Args[1] != "Fix login" || e.Actor != "alice" || !e.Succeeded() {
+		t.Errorf("create entry = %+v", e)
+	}
+	if e := entries[1]; e.ExitCode != nil || e.Succeeded() {
+		t.Errorf("sync entry should have no result: %+v", e)
+	}
+	if e := entries[2]; e.ExitCode == nil || *e.ExitCode != 1 || e.Error != "issue bd-1 not found" {
+		t.Errorf("show entry = %+v", e)
+	}
+}
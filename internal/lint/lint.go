@@ -0,0 +1,251 @@
+// Package lint checks a backlog against hygiene rules: over-long titles,
+// urgent work without estimates, unlabeled issues, empty epics and
+// dependencies that cross milestones.
+//
+// Every rule has a default severity that projects can change or switch off
+// (lint.<rule>.severity), and single issues opt out of a rule with a
+// lint-ignore:<rule> label (lint-ignore:all silences every rule).
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Severity of a finding. Off disables a rule.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+	SeverityOff     Severity = "off"
+)
+
+// rank orders severities so a threshold can be applied
+func (s Severity) rank() int {
+	switch s {
+	case SeverityError:
+		return 3
+	case SeverityWarning:
+		return 2
+	case SeverityInfo:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// AtLeast reports whether s is as severe as threshold
+func (s Severity) AtLeast(threshold Severity) bool {
+	return s.rank() > 0 && s.rank() >= threshold.rank()
+}
+
+// ParseSeverity validates a severity name
+func ParseSeverity(value string) (Severity, error) {
+	s := Severity(strings.ToLower(strings.TrimSpace(value)))
+	switch s {
+	case SeverityError, SeverityWarning, SeverityInfo, SeverityOff:
+		return s, nil
+	}
+	return "", fmt.Errorf("invalid severity %q (want error, warning, info or off)", value)
+}
+
+// SuppressLabelPrefix starts a label that silences a rule for one issue
+const SuppressLabelPrefix = "lint-ignore:"
+
+// Defaults for the tunable rules
+const (
+	DefaultTitleMaxLength  = 80
+	DefaultMilestonePrefix = "milestone:"
+)
+
+// Finding is one rule violation
+type Finding struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	IssueID  string   `json:"issue_id"`
+	Message  string   `json:"message"`
+}
+
+// Backlog is what the rules look at
+type Backlog struct {
+	Issues []*types.Issue
+	Labels map[string][]string            // issue ID -> labels
+	Deps   map[string][]*types.Dependency // issue ID -> its dependency records
+}
+
+// Config tunes the rules. The zero value uses every default.
+type Config struct {
+	Severity        map[string]Severity // per-rule overrides
+	TitleMaxLength  int
+	MilestonePrefix string
+}
+
+// Rule is one hygiene check
+type Rule struct {
+	Name        string
+	Description string
+	Default     Severity
+	check       func(b *Backlog, cfg *Config, open map[string]*types.Issue) []Finding
+}
+
+// Rules returns every rule, sorted by name
+func Rules() []Rule {
+	rules := []Rule{
+		{"title-length", "Title longer than lint.title_max_length characters (default 80)", SeverityWarning, checkTitleLength},
+		{"missing-estimate", "P0/P1 issue without an estimate", SeverityWarning, checkMissingEstimate},
+		{"unlabeled", "Issue without any label", SeverityInfo, checkUnlabeled},
+		{"empty-epic", "Epic without child issues", SeverityWarning, checkEmptyEpic},
+		{"milestone-crossing", "Blocking dependency between issues in different milestones (milestone:<name> labels)", SeverityWarning, checkMilestoneCrossing},
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Name < rules[j].Name })
+	return rules
+}
+
+// Run checks every issue that is not closed against every enabled rule and
+// returns the unsuppressed findings ordered by issue ID and rule
+func Run(b *Backlog, cfg *Config) []Finding {
+	if cfg == nil {
+		cfg = &Config{}
+	}
+	open := make(map[string]*types.Issue)
+	for _, issue := range b.Issues {
+		if issue.Status != types.StatusClosed && issue.Status != types.StatusTombstone {
+			open[issue.ID] = issue
+		}
+	}
+
+	findings := []Finding{}
+	for _, rule := range Rules() {
+		severity := rule.Default
+		if s, ok := cfg.Severity[rule.Name]; ok {
+			severity = s
+		}
+		if severity == SeverityOff {
+			continue
+		}
+		for _, f := range rule.check(b, cfg, open) {
+			if suppressed(b.Labels[f.IssueID], rule.Name) {
+				continue
+			}
+			f.Rule = rule.Name
+			f.Severity = severity
+			findings = append(findings, f)
+		}
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].IssueID != findings[j].IssueID {
+			return findings[i].IssueID < findings[j].IssueID
+		}
+		return findings[i].Rule < findings[j].Rule
+	})
+	return findings
+}
+
+func suppressed(labels []string, rule string) bool {
+	for _, label := range labels {
+		if label == SuppressLabelPrefix+rule || label == SuppressLabelPrefix+"all" {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedOpen returns the open issues in ID order so findings are stable
+func sortedOpen(open map[string]*types.Issue) []*types.Issue {
+	issues := make([]*types.Issue, 0, len(open))
+	for _, issue := range open {
+		issues = append(issues, issue)
+	}
+	sort.Slice(issues, func(i, j int) bool { return issues[i].ID < issues[j].ID })
+	return issues
+}
+
+func checkTitleLength(_ *Backlog, cfg *Config, open map[string]*types.Issue) []Finding {
+	limit := cfg.TitleMaxLength
+	if limit <= 0 {
+		limit = DefaultTitleMaxLength
+	}
+	var findings []Finding
+	for _, issue := range sortedOpen(open) {
+		if n := len([]rune(issue.Title)); n > limit {
+			findings = append(findings, Finding{IssueID: issue.ID, Message: fmt.Sprintf("title is %d characters (max %d)", n, limit)})
+		}
+	}
+	return findings
+}
+
+func checkMissingEstimate(_ *Backlog, _ *Config, open map[string]*types.Issue) []Finding {
+	var findings []Finding
+	for _, issue := range sortedOpen(open) {
+		if issue.Priority <= 1 && issue.EstimatedMinutes == nil && issue.IssueType != types.TypeEpic {
+			findings = append(findings, Finding{IssueID: issue.ID, Message: fmt.Sprintf("P%d issue has no estimate", issue.Priority)})
+		}
+	}
+	return findings
+}
+
+func checkUnlabeled(b *Backlog, _ *Config, open map[string]*types.Issue) []Finding {
+	var findings []Finding
+	for _, issue := range sortedOpen(open) {
+		if len(b.Labels[issue.ID]) == 0 {
+			findings = append(findings, Finding{IssueID: issue.ID, Message: "issue has no labels"})
+		}
+	}
+	return findings
+}
+
+func checkEmptyEpic(b *Backlog, _ *Config, open map[string]*types.Issue) []Finding {
+	hasChildren := make(map[string]bool)
+	for _, deps := range b.Deps {
+		for _, dep := range deps {
+			if dep.Type == types.DepParentChild {
+				hasChildren[dep.DependsOnID] = true
+			}
+		}
+	}
+	var findings []Finding
+	for _, issue := range sortedOpen(open) {
+		if issue.IssueType == types.TypeEpic && !hasChildren[issue.ID] {
+			findings = append(findings, Finding{IssueID: issue.ID, Message: "epic has no child issues"})
+		}
+	}
+	return findings
+}
+
+func checkMilestoneCrossing(b *Backlog, cfg *Config, open map[string]*types.Issue) []Finding {
+	prefix := cfg.MilestonePrefix
+	if prefix == "" {
+		prefix = DefaultMilestonePrefix
+	}
+	milestone := func(id string) string {
+		for _, label := range b.Labels[id] {
+			if strings.HasPrefix(label, prefix) {
+				return strings.TrimPrefix(label, prefix)
+			}
+		}
+		return ""
+	}
+
+	var findings []Finding
+	for _, issue := range sortedOpen(open) {
+		mine := milestone(issue.ID)
+		if mine == "" {
+			continue
+		}
+		for _, dep := range b.Deps[issue.ID] {
+			if dep.Type != types.DepBlocks || open[dep.DependsOnID] == nil {
+				continue
+			}
+			if theirs := milestone(dep.DependsOnID); theirs != "" && theirs != mine {
+				findings = append(findings, Finding{IssueID: issue.ID, Message: fmt.Sprintf(
+					"in milestone %s but blocked by %s in milestone %s", mine, dep.DependsOnID, theirs)})
+			}
+		}
+	}
+	return findings
+}
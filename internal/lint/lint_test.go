@@ -0,0 +1,75 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestRun(t *testing.T) {
+	estimate := 60
+	b := &Backlog{
+		Issues: []*types.Issue{
+			{ID: "bd-1", Title: strings.Repeat("x", 90), Priority: 2, Status: types.StatusOpen, IssueType: types.TypeTask},
+			{ID: "bd-2", Title: "Urgent", Priority: 0, Status: types.StatusOpen, IssueType: types.TypeBug},
+			{ID: "bd-3", Title: "Estimated", Priority: 1, Status: types.StatusOpen, IssueType: types.TypeTask, EstimatedMinutes: &estimate},
+			{ID: "bd-4", Title: "Empty epic", Priority: 2, Status: types.StatusOpen, IssueType: types.TypeEpic},
+			{ID: "bd-5", Title: "Epic", Priority: 2, Status: types.StatusOpen, IssueType: types.TypeEpic},
+			{ID: "bd-6", Title: "Closed and unlabeled", Priority: 0, Status: types.StatusClosed, IssueType: types.TypeTask},
+		},
+		Labels: map[string][]string{
+			"bd-1": {"backend", SuppressLabelPrefix + "title-length"},
+			"bd-2": {"milestone:v1"},
+			"bd-3": {"milestone:v2"},
+			"bd-4": {"planning"},
+			"bd-5": {"planning"},
+		},
+		Deps: map[string][]*types.Dependency{
+			"bd-2": {{IssueID: "bd-2", DependsOnID: "bd-3", Type: types.DepBlocks}},
+			"bd-3": {{IssueID: "bd-3", DependsOnID: "bd-5", Type: types.DepParentChild}},
+		},
+	}
+
+	cfg := &Config{Severity: map[string]Severity{"unlabeled": SeverityOff}}
+	got := map[string]Severity{}
+	for _, f := range Run(b, cfg) {
+		got[f.IssueID+" "+f.Rule] = f.Severity
+	}
+	want := map[string]Severity{
+		"bd-2 missing-estimate":   SeverityWarning,
+		"bd-2 milestone-crossing": SeverityWarning,
+		"bd-4 empty-epic":         SeverityWarning,
+	}
+	if len(got) != len(want) {
+		t.Errorf("findings = %v, want %v", got, want)
+	}
+	for key, severity := range want {
+		if got[key] != severity {
+			t.Errorf("%s = %q, want %q", key, got[key], severity)
+		}
+	}
+
+	// Without the override, unlabeled reports at its default severity
+	found := false
+	for _, f := range Run(b, nil) {
+		if f.Rule == "unlabeled" {
+			found = found || f.IssueID == "bd-6"
+			if f.Severity != SeverityInfo {
+				t.Errorf("unlabeled severity = %q, want info", f.Severity)
+			}
+		}
+	}
+	if found {
+		t.Error("closed issues must not be linted")
+	}
+}
+
+func TestSeverityAtLeast(t *testing.T) {
+	if !SeverityError.AtLeast(SeverityWarning) || SeverityInfo.AtLeast(SeverityWarning) || SeverityOff.AtLeast(SeverityInfo) {
+		t.Error("severity ordering is wrong")
+	}
+	if _, err := ParseSeverity("fatal"); err == nil {
+		t.Error("expected an invalid severity error")
+	}
+}
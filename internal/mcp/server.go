@@ -0,0 +1,214 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// Config wires the server to the host process
+type Config struct {
+	Version string
+	Actor   string
+	Store   storage.Storage
+
+	// BeadsDir is the project's .beads directory, where the key for
+	// encryption.fields is looked up
+	BeadsDir string
+
+	// OnMutate is called after every successful write so the host can
+	// schedule a JSONL flush
+	OnMutate func()
+}
+
+// Server answers one MCP client over a single stdio connection
+type Server struct {
+	cfg      Config
+	conn     *conn
+	tools    map[string]*Tool
+	toolList []*Tool
+}
+
+// ServerInfo identifies the server in the initialize result
+type ServerInfo struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// InitializeParams are the params for initialize
+type InitializeParams struct {
+	ProtocolVersion string `json:"protocolVersion"`
+}
+
+// InitializeResult is the result of initialize
+type InitializeResult struct {
+	ProtocolVersion string                 `json:"protocolVersion"`
+	Capabilities    map[string]interface{} `json:"capabilities"`
+	ServerInfo      ServerInfo             `json:"serverInfo"`
+	Instructions    string                 `json:"instructions,omitempty"`
+}
+
+// ToolsListResult is the result of tools/list. All tools fit on one page.
+type ToolsListResult struct {
+	Tools []*Tool `json:"tools"`
+}
+
+// CallParams are the params for tools/call
+type CallParams struct {
+	Name      string          `json:"name"`
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+}
+
+// CallResult is the result of tools/call
+type CallResult struct {
+	Content []Content `json:"content"`
+	IsError bool      `json:"isError,omitempty"`
+}
+
+// Content is a block of tool output
+type Content struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// instructions tell the model how the tools fit together
+const instructions = `beads (bd) is the project's issue tracker. Call ready to find unblocked
+work, update with status in_progress to claim it, and close it when done.
+File work you discover with create, linking it with dep (type
+discovered-from). Issue IDs look like bd-a1b2; a unique prefix is enough.`
+
+// NewServer creates a server reading from r and writing to w
+func NewServer(cfg Config, r io.Reader, w io.Writer) *Server {
+	s := &Server{cfg: cfg, conn: newConn(r, w), tools: make(map[string]*Tool), toolList: tools()}
+	for _, tool := range s.toolList {
+		s.tools[tool.Name] = tool
+	}
+	return s
+}
+
+// Serve processes messages until the input closes or ctx is canceled.
+// Requests are handled one at a time in arrival order.
+func (s *Server) Serve(ctx context.Context) error {
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		body, err := s.conn.read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var msg Message
+		if err := json.Unmarshal(body, &msg); err != nil {
+			_ = s.conn.write(&Message{ID: json.RawMessage("null"), Error: &Error{Code: CodeParseError, Message: err.Error()}})
+			continue
+		}
+		// Responses to requests we never send need no answer
+		if msg.Method == "" && (msg.Result != nil || msg.Error != nil) {
+			continue
+		}
+
+		result, rpcErr := s.dispatch(ctx, &msg)
+		if msg.isNotification() {
+			continue
+		}
+		resp := &Message{ID: msg.ID}
+		if rpcErr != nil {
+			resp.Error = rpcErr
+		} else {
+			// JSON-RPC requires a result member on success, even if empty
+			if result == nil {
+				result = struct{}{}
+			}
+			resp.Result = result
+		}
+		if err := s.conn.write(resp); err != nil {
+			return fmt.Errorf("failed to write response: %w", err)
+		}
+	}
+}
+
+func (s *Server) dispatch(ctx context.Context, msg *Message) (interface{}, *Error) {
+	switch msg.Method {
+	case MethodInitialize:
+		return s.handleInitialize(msg.Params)
+	case MethodPing, MethodInitialized:
+		return nil, nil
+	case MethodToolsList:
+		return s.handleToolsList(), nil
+	case MethodToolsCall:
+		return s.handleToolsCall(ctx, msg.Params)
+	case "":
+		return nil, &Error{Code: CodeInvalidRequest, Message: "missing method"}
+	}
+	return nil, &Error{Code: CodeMethodNotFound, Message: fmt.Sprintf("unknown method: %s", msg.Method)}
+}
+
+func (s *Server) handleInitialize(params json.RawMessage) (interface{}, *Error) {
+	var p InitializeParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	// Answer with the client's revision if we speak it, else our newest
+	version := ProtocolVersions[0]
+	if slices.Contains(ProtocolVersions, p.ProtocolVersion) {
+		version = p.ProtocolVersion
+	}
+	return &InitializeResult{
+		ProtocolVersion: version,
+		Capabilities:    map[string]interface{}{"tools": map[string]interface{}{"listChanged": false}},
+		ServerInfo:      ServerInfo{Name: "bd", Version: s.cfg.Version},
+		Instructions:    instructions,
+	}, nil
+}
+
+func (s *Server) handleToolsList() *ToolsListResult {
+	return &ToolsListResult{Tools: s.toolList}
+}
+
+func (s *Server) handleToolsCall(ctx context.Context, params json.RawMessage) (interface{}, *Error) {
+	var p CallParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	tool, ok := s.tools[p.Name]
+	if !ok {
+		return nil, &Error{Code: CodeInvalidParams, Message: fmt.Sprintf("unknown tool: %s", p.Name)}
+	}
+
+	result, err := tool.handler(ctx, s, p.Arguments)
+	if err != nil {
+		// The model sees tool errors and can retry with other arguments
+		return &CallResult{Content: []Content{{Type: "text", Text: err.Error()}}, IsError: true}, nil
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, &Error{Code: CodeInternalError, Message: err.Error()}
+	}
+	return &CallResult{Content: []Content{{Type: "text", Text: string(data)}}}, nil
+}
+
+func (s *Server) mutated() {
+	if s.cfg.OnMutate != nil {
+		s.cfg.OnMutate()
+	}
+}
+
+// decodeParams unmarshals params into v, mapping failures to CodeInvalidParams
+func decodeParams(params json.RawMessage, v interface{}) *Error {
+	if len(params) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(params, v); err != nil {
+		return &Error{Code: CodeInvalidParams, Message: fmt.Sprintf("invalid params: %v", err)}
+	}
+	return nil
+}
@@ -0,0 +1,591 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/encryption"
+	"github.com/steveyegge/beads/internal/redact"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// Tool is an operation the model can call. Names and arguments follow the
+// Python beads-mcp server, so prompts written for one work with the other.
+type Tool struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	InputSchema json.RawMessage `json:"inputSchema"`
+
+	handler func(ctx context.Context, s *Server, args json.RawMessage) (interface{}, error)
+}
+
+// IssueSummary is the compact issue form ready and list return, to keep
+// tool results small; show returns the full issue
+type IssueSummary struct {
+	ID        string          `json:"id"`
+	Title     string          `json:"title"`
+	Status    types.Status    `json:"status"`
+	Priority  int             `json:"priority"`
+	IssueType types.IssueType `json:"issue_type"`
+	Assignee  string          `json:"assignee,omitempty"`
+}
+
+// IssueDetails is the result of show
+type IssueDetails struct {
+	*types.Issue
+	Dependencies []*types.Issue   `json:"dependencies,omitempty"`
+	Dependents   []*types.Issue   `json:"dependents,omitempty"`
+	Comments     []*types.Comment `json:"comments,omitempty"`
+}
+
+// ReadyArgs are the arguments of ready
+type ReadyArgs struct {
+	Limit      int      `json:"limit,omitempty"`
+	Priority   *int     `json:"priority,omitempty"`
+	Assignee   string   `json:"assignee,omitempty"`
+	Unassigned bool     `json:"unassigned,omitempty"`
+	Labels     []string `json:"labels,omitempty"`
+}
+
+// ListArgs are the arguments of list
+type ListArgs struct {
+	Query     string   `json:"query,omitempty"`
+	Status    string   `json:"status,omitempty"`
+	Priority  *int     `json:"priority,omitempty"`
+	IssueType string   `json:"issue_type,omitempty"`
+	Assignee  string   `json:"assignee,omitempty"`
+	Labels    []string `json:"labels,omitempty"`
+	Limit     int      `json:"limit,omitempty"`
+}
+
+// IssueArgs identify one issue (partial IDs are resolved)
+type IssueArgs struct {
+	IssueID string `json:"issue_id"`
+}
+
+// CreateArgs are the arguments of create
+type CreateArgs struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description,omitempty"`
+	Design      string   `json:"design,omitempty"`
+	Acceptance  string   `json:"acceptance,omitempty"`
+	ExternalRef string   `json:"external_ref,omitempty"`
+	Priority    *int     `json:"priority,omitempty"`
+	IssueType   string   `json:"issue_type,omitempty"`
+	Assignee    string   `json:"assignee,omitempty"`
+	Labels      []string `json:"labels,omitempty"`
+	ID          string   `json:"id,omitempty"`
+	Deps        []string `json:"deps,omitempty"` // "bd-1" (blocks) or "type:bd-1"
+}
+
+// UpdateArgs are the arguments of update. Omitted fields are unchanged.
+type UpdateArgs struct {
+	IssueID            string  `json:"issue_id"`
+	Status             *string `json:"status,omitempty"`
+	Priority           *int    `json:"priority,omitempty"`
+	Assignee           *string `json:"assignee,omitempty"`
+	Title              *string `json:"title,omitempty"`
+	Description        *string `json:"description,omitempty"`
+	Design             *string `json:"design,omitempty"`
+	AcceptanceCriteria *string `json:"acceptance_criteria,omitempty"`
+	Notes              *string `json:"notes,omitempty"`
+	ExternalRef        *string `json:"external_ref,omitempty"`
+}
+
+// CloseArgs are the arguments of close
+type CloseArgs struct {
+	IssueID string `json:"issue_id"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// DepArgs are the arguments of dep
+type DepArgs struct {
+	IssueID     string `json:"issue_id"`
+	DependsOnID string `json:"depends_on_id"`
+	DepType     string `json:"dep_type,omitempty"`
+}
+
+// tools returns the tool set in the order tools/list reports it
+func tools() []*Tool {
+	return []*Tool{
+		{
+			Name:        "ready",
+			Description: "Find issues with no open blockers that are ready to be worked on, highest priority first.",
+			InputSchema: json.RawMessage(`{
+				"type": "object",
+				"properties": {
+					"limit": {"type": "integer", "minimum": 1, "description": "Maximum issues to return (default 10)"},
+					"priority": {"type": "integer", "minimum": 0, "maximum": 4, "description": "Only this priority (0 = critical)"},
+					"assignee": {"type": "string", "description": "Only issues assigned to this actor"},
+					"unassigned": {"type": "boolean", "description": "Only unassigned issues"},
+					"labels": {"type": "array", "items": {"type": "string"}, "description": "Only issues with all of these labels"}
+				},
+				"additionalProperties": false
+			}`),
+			handler: toolReady,
+		},
+		{
+			Name:        "list",
+			Description: "List or search issues, optionally filtered by status, priority, type, assignee and labels.",
+			InputSchema: json.RawMessage(`{
+				"type": "object",
+				"properties": {
+					"query": {"type": "string", "description": "Text to search for in titles, descriptions and IDs"},
+					"status": {"type": "string", "description": "open, in_progress, blocked, deferred or closed"},
+					"priority": {"type": "integer", "minimum": 0, "maximum": 4},
+					"issue_type": {"type": "string", "description": "bug, feature, task, epic or chore"},
+					"assignee": {"type": "string"},
+					"labels": {"type": "array", "items": {"type": "string"}, "description": "Only issues with all of these labels"},
+					"limit": {"type": "integer", "minimum": 1, "description": "Maximum issues to return (default 20)"}
+				},
+				"additionalProperties": false
+			}`),
+			handler: toolList,
+		},
+		{
+			Name:        "show",
+			Description: "Show an issue in full, with its labels, dependencies, dependents and comments.",
+			InputSchema: json.RawMessage(`{
+				"type": "object",
+				"properties": {
+					"issue_id": {"type": "string", "description": "Issue ID, e.g. bd-a1b2 (a unique prefix is enough)"}
+				},
+				"required": ["issue_id"],
+				"additionalProperties": false
+			}`),
+			handler: toolShow,
+		},
+		{
+			Name:        "create",
+			Description: "Create an issue (bug, feature, task, epic or chore), optionally with labels and dependencies.",
+			InputSchema: json.RawMessage(`{
+				"type": "object",
+				"properties": {
+					"title": {"type": "string"},
+					"description": {"type": "string", "description": "Why the issue exists and what needs to be done"},
+					"design": {"type": "string"},
+					"acceptance": {"type": "string", "description": "Acceptance criteria"},
+					"external_ref": {"type": "string", "description": "External reference such as gh-9"},
+					"priority": {"type": "integer", "minimum": 0, "maximum": 4, "description": "0 = critical, 4 = backlog (default 2)"},
+					"issue_type": {"type": "string", "description": "bug, feature, task, epic or chore (default task)"},
+					"assignee": {"type": "string"},
+					"labels": {"type": "array", "items": {"type": "string"}},
+					"id": {"type": "string", "description": "Explicit issue ID (normally generated)"},
+					"deps": {"type": "array", "items": {"type": "string"}, "description": "Issues this depends on: \"bd-1\" (blocks) or \"type:bd-1\", e.g. \"discovered-from:bd-1\""}
+				},
+				"required": ["title"],
+				"additionalProperties": false
+			}`),
+			handler: toolCreate,
+		},
+		{
+			Name:        "update",
+			Description: "Update an issue's status, priority, assignee or text. Set status to in_progress to claim work; status closed closes the issue.",
+			InputSchema: json.RawMessage(`{
+				"type": "object",
+				"properties": {
+					"issue_id": {"type": "string"},
+					"status": {"type": "string", "description": "open, in_progress, blocked, deferred or closed"},
+					"priority": {"type": "integer", "minimum": 0, "maximum": 4},
+					"assignee": {"type": "string"},
+					"title": {"type": "string"},
+					"description": {"type": "string"},
+					"design": {"type": "string"},
+					"acceptance_criteria": {"type": "string"},
+					"notes": {"type": "string"},
+					"external_ref": {"type": "string"}
+				},
+				"required": ["issue_id"],
+				"additionalProperties": false
+			}`),
+			handler: toolUpdate,
+		},
+		{
+			Name:        "close",
+			Description: "Close an issue once the work is done.",
+			InputSchema: json.RawMessage(`{
+				"type": "object",
+				"properties": {
+					"issue_id": {"type": "string"},
+					"reason": {"type": "string", "description": "Why it was closed (default Completed)"}
+				},
+				"required": ["issue_id"],
+				"additionalProperties": false
+			}`),
+			handler: toolClose,
+		},
+		{
+			Name:        "dep",
+			Description: "Add a dependency: issue_id depends on depends_on_id. Types: blocks (hard blocker), related, parent-child (epic/subtask), discovered-from (found while working on).",
+			InputSchema: json.RawMessage(`{
+				"type": "object",
+				"properties": {
+					"issue_id": {"type": "string"},
+					"depends_on_id": {"type": "string"},
+					"dep_type": {"type": "string", "description": "blocks (default), related, parent-child, discovered-from, or a custom type"}
+				},
+				"required": ["issue_id", "depends_on_id"],
+				"additionalProperties": false
+			}`),
+			handler: toolDep,
+		},
+	}
+}
+
+func toolReady(ctx context.Context, s *Server, raw json.RawMessage) (interface{}, error) {
+	var args ReadyArgs
+	if err := decodeArgs(raw, &args); err != nil {
+		return nil, err
+	}
+	filter := types.WorkFilter{Limit: 10, Priority: args.Priority, Unassigned: args.Unassigned, Labels: args.Labels}
+	if args.Limit > 0 {
+		filter.Limit = args.Limit
+	}
+	if args.Assignee != "" && !args.Unassigned {
+		filter.Assignee = &args.Assignee
+	}
+	issues, err := s.cfg.Store.GetReadyWork(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return summarize(issues), nil
+}
+
+func toolList(ctx context.Context, s *Server, raw json.RawMessage) (interface{}, error) {
+	var args ListArgs
+	if err := decodeArgs(raw, &args); err != nil {
+		return nil, err
+	}
+	filter := types.IssueFilter{Priority: args.Priority, Labels: args.Labels, Limit: 20}
+	if args.Limit > 0 {
+		filter.Limit = args.Limit
+	}
+	if args.Status != "" {
+		status := types.Status(args.Status)
+		filter.Status = &status
+	}
+	if args.IssueType != "" {
+		issueType := types.IssueType(args.IssueType)
+		filter.IssueType = &issueType
+	}
+	if args.Assignee != "" {
+		filter.Assignee = &args.Assignee
+	}
+	issues, err := s.cfg.Store.SearchIssues(ctx, args.Query, filter)
+	if err != nil {
+		return nil, err
+	}
+	return summarize(issues), nil
+}
+
+func toolShow(ctx context.Context, s *Server, raw json.RawMessage) (interface{}, error) {
+	var args IssueArgs
+	if err := decodeArgs(raw, &args); err != nil {
+		return nil, err
+	}
+	id, err := s.resolveID(ctx, args.IssueID)
+	if err != nil {
+		return nil, err
+	}
+	return s.issueDetails(ctx, id)
+}
+
+func toolCreate(ctx context.Context, s *Server, raw json.RawMessage) (interface{}, error) {
+	var args CreateArgs
+	if err := decodeArgs(raw, &args); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(args.Title) == "" {
+		return nil, fmt.Errorf("title is required")
+	}
+	deps, err := parseDeps(args.Deps)
+	if err != nil {
+		return nil, err
+	}
+
+	issue := &types.Issue{
+		ID:                 args.ID,
+		Title:              args.Title,
+		Description:        args.Description,
+		Design:             args.Design,
+		AcceptanceCriteria: args.Acceptance,
+		Status:             types.StatusOpen,
+		Priority:           2,
+		IssueType:          types.TypeTask,
+		Assignee:           args.Assignee,
+	}
+	if args.Priority != nil {
+		issue.Priority = *args.Priority
+	}
+	if args.IssueType != "" {
+		issue.IssueType = types.IssueType(args.IssueType)
+	}
+	if args.ExternalRef != "" {
+		issue.ExternalRef = &args.ExternalRef
+	}
+	if err := s.protectIssue(issue); err != nil {
+		return nil, err
+	}
+	store := s.cfg.Store
+	if err := store.CreateIssue(ctx, issue, s.cfg.Actor); err != nil {
+		return nil, err
+	}
+	s.mutated()
+
+	for _, label := range args.Labels {
+		if err := store.AddLabel(ctx, issue.ID, label, s.cfg.Actor); err != nil {
+			return nil, fmt.Errorf("created %s but failed to add label %q: %w", issue.ID, label, err)
+		}
+	}
+	for _, dep := range deps {
+		dep.IssueID = issue.ID
+		if dep.DependsOnID, err = s.resolveID(ctx, dep.DependsOnID); err == nil {
+			err = store.AddDependency(ctx, dep, s.cfg.Actor)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("created %s but failed to add dependency: %w", issue.ID, err)
+		}
+	}
+	return s.issueDetails(ctx, issue.ID)
+}
+
+func toolUpdate(ctx context.Context, s *Server, raw json.RawMessage) (interface{}, error) {
+	var args UpdateArgs
+	if err := decodeArgs(raw, &args); err != nil {
+		return nil, err
+	}
+	id, err := s.resolveID(ctx, args.IssueID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Closing goes through close, so close reasons and verification apply
+	closing := args.Status != nil && *args.Status == string(types.StatusClosed)
+	updates := make(map[string]interface{})
+	setString := func(key string, v *string) {
+		if v != nil {
+			updates[key] = *v
+		}
+	}
+	if !closing {
+		setString("status", args.Status)
+	}
+	setString("assignee", args.Assignee)
+	setString("title", args.Title)
+	setString("description", args.Description)
+	setString("design", args.Design)
+	setString("acceptance_criteria", args.AcceptanceCriteria)
+	setString("notes", args.Notes)
+	setString("external_ref", args.ExternalRef)
+	if args.Priority != nil {
+		updates["priority"] = *args.Priority
+	}
+	if len(updates) == 0 && !closing {
+		return nil, fmt.Errorf("no fields to update")
+	}
+
+	if len(updates) > 0 {
+		if err := s.protectUpdates(updates); err != nil {
+			return nil, err
+		}
+		if err := s.cfg.Store.UpdateIssue(ctx, id, updates, s.cfg.Actor); err != nil {
+			return nil, err
+		}
+		s.mutated()
+	}
+	if closing {
+		return s.closeIssue(ctx, id, "")
+	}
+	return s.getIssue(ctx, id)
+}
+
+func toolClose(ctx context.Context, s *Server, raw json.RawMessage) (interface{}, error) {
+	var args CloseArgs
+	if err := decodeArgs(raw, &args); err != nil {
+		return nil, err
+	}
+	id, err := s.resolveID(ctx, args.IssueID)
+	if err != nil {
+		return nil, err
+	}
+	return s.closeIssue(ctx, id, args.Reason)
+}
+
+func toolDep(ctx context.Context, s *Server, raw json.RawMessage) (interface{}, error) {
+	var args DepArgs
+	if err := decodeArgs(raw, &args); err != nil {
+		return nil, err
+	}
+	dep := &types.Dependency{Type: types.DepBlocks}
+	if args.DepType != "" {
+		dep.Type = types.DependencyType(args.DepType)
+	}
+	if !dep.Type.IsValid() {
+		return nil, fmt.Errorf("invalid dep_type %q", args.DepType)
+	}
+	var err error
+	if dep.IssueID, err = s.resolveID(ctx, args.IssueID); err != nil {
+		return nil, err
+	}
+	if dep.DependsOnID, err = s.resolveID(ctx, args.DependsOnID); err != nil {
+		return nil, err
+	}
+	if err := s.cfg.Store.AddDependency(ctx, dep, s.cfg.Actor); err != nil {
+		return nil, err
+	}
+	s.mutated()
+	return dep, nil
+}
+
+// closeIssue closes an issue the way bd close does: the project's close
+// reasons and verification rules apply
+func (s *Server) closeIssue(ctx context.Context, id, reason string) (*types.Issue, error) {
+	store := s.cfg.Store
+	if reason == "" {
+		reason = "Completed"
+	}
+	reason, err := storage.ResolveCloseReason(ctx, store, reason, "")
+	if err != nil {
+		return nil, err
+	}
+	if err := storage.CheckVerifiedClose(ctx, store, id, s.cfg.Actor); err != nil {
+		return nil, err
+	}
+	if err := storage.CheckDefinitionOfDone(ctx, store, id); err != nil {
+		return nil, err
+	}
+	if err := store.CloseIssue(ctx, id, reason, s.cfg.Actor); err != nil {
+		return nil, err
+	}
+	s.mutated()
+	return s.getIssue(ctx, id)
+}
+
+// protectIssue strips secrets from a new issue's text (redaction.*) and
+// encrypts its encrypted fields (encryption.fields), as bd create does
+func (s *Server) protectIssue(issue *types.Issue) error {
+	redactor, err := redact.FromConfig()
+	if err != nil {
+		return err
+	}
+	redactor.RedactTexts(&issue.Title, &issue.Description, &issue.Design, &issue.AcceptanceCriteria, &issue.Notes)
+	fieldCipher, err := encryption.FromConfig(s.cfg.BeadsDir)
+	if err != nil {
+		return err
+	}
+	return fieldCipher.EncryptIssue(issue)
+}
+
+// protectUpdates redacts and encrypts the text fields of an update, as bd
+// update does
+func (s *Server) protectUpdates(updates map[string]interface{}) error {
+	redactor, err := redact.FromConfig()
+	if err != nil {
+		return err
+	}
+	redactor.RedactUpdates(updates)
+	fieldCipher, err := encryption.FromConfig(s.cfg.BeadsDir)
+	if err != nil {
+		return err
+	}
+	return fieldCipher.EncryptUpdates(updates)
+}
+
+// getIssue returns an issue with its encrypted fields decrypted when the
+// key is available; without it the model sees the ciphertext, like bd show
+func (s *Server) getIssue(ctx context.Context, id string) (*types.Issue, error) {
+	issue, err := s.cfg.Store.GetIssue(ctx, id)
+	if err != nil || !encryption.HasEncrypted(issue) {
+		return issue, err
+	}
+	if fieldCipher, err := encryption.FromConfig(s.cfg.BeadsDir); err == nil {
+		plain := *issue
+		if fieldCipher.DecryptIssue(&plain) == nil {
+			return &plain, nil
+		}
+	}
+	return issue, nil
+}
+
+func (s *Server) issueDetails(ctx context.Context, id string) (*IssueDetails, error) {
+	store := s.cfg.Store
+	issue, err := s.getIssue(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if issue == nil {
+		return nil, fmt.Errorf("issue not found: %s", id)
+	}
+	details := &IssueDetails{Issue: issue}
+	if details.Labels, err = store.GetLabels(ctx, id); err != nil {
+		return nil, err
+	}
+	if details.Dependencies, err = store.GetDependencies(ctx, id); err != nil {
+		return nil, err
+	}
+	if details.Dependents, err = store.GetDependents(ctx, id); err != nil {
+		return nil, err
+	}
+	if details.Comments, err = store.GetIssueComments(ctx, id); err != nil {
+		return nil, err
+	}
+	return details, nil
+}
+
+// resolveID expands partial IDs the same way the CLI does
+func (s *Server) resolveID(ctx context.Context, id string) (string, error) {
+	if id == "" {
+		return "", fmt.Errorf("issue_id is required")
+	}
+	return utils.ResolvePartialID(ctx, s.cfg.Store, id)
+}
+
+// decodeArgs unmarshals tool arguments, rejecting unknown ones so a
+// misspelled argument is reported instead of silently ignored
+func decodeArgs(raw json.RawMessage, v interface{}) error {
+	if len(raw) == 0 || string(raw) == "null" {
+		raw = json.RawMessage("{}")
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("invalid arguments: %v", err)
+	}
+	return nil
+}
+
+// parseDeps reads create's deps: "bd-1" (blocks) or "type:bd-1"
+func parseDeps(specs []string) ([]*types.Dependency, error) {
+	var deps []*types.Dependency
+	for _, spec := range specs {
+		dep := &types.Dependency{Type: types.DepBlocks, DependsOnID: strings.TrimSpace(spec)}
+		if depType, id, ok := strings.Cut(spec, ":"); ok {
+			dep.Type, dep.DependsOnID = types.DependencyType(strings.TrimSpace(depType)), strings.TrimSpace(id)
+		}
+		if !dep.Type.IsValid() || dep.DependsOnID == "" {
+			return nil, fmt.Errorf("invalid dependency %q (use bd-1 or type:bd-1)", spec)
+		}
+		deps = append(deps, dep)
+	}
+	return deps, nil
+}
+
+func summarize(issues []*types.Issue) []*IssueSummary {
+	summaries := make([]*IssueSummary, len(issues))
+	for i, issue := range issues {
+		summaries[i] = &IssueSummary{
+			ID:        issue.ID,
+			Title:     issue.Title,
+			Status:    issue.Status,
+			Priority:  issue.Priority,
+			IssueType: issue.IssueType,
+			Assignee:  issue.Assignee,
+		}
+	}
+	return summaries
+}
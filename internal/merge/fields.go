@@ -0,0 +1,312 @@
+package merge
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// conflictMarkerSize is the length of the conflict markers written around
+// issues that need manual resolution, as in git
+const conflictMarkerSize = 7
+
+// issueFields are the JSON keys Issue models and mergeIssue has rules for;
+// every other key of a record is kept in Issue.Extra
+var issueFields = func() map[string]bool {
+	fields := make(map[string]bool)
+	t := reflect.TypeOf(Issue{})
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			fields[name] = true
+		}
+	}
+	return fields
+}()
+
+// extraFields returns the keys of a JSONL record that Issue doesn't model
+func extraFields(line []byte) (map[string]json.RawMessage, error) {
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(line, &all); err != nil {
+		return nil, err
+	}
+	for key := range all {
+		if issueFields[key] {
+			delete(all, key)
+		}
+	}
+	if len(all) == 0 {
+		return nil, nil
+	}
+	return all, nil
+}
+
+// marshalIssue encodes an issue as one JSONL line: the modeled fields in
+// struct order, then its extra fields sorted by key
+func marshalIssue(issue Issue) ([]byte, error) {
+	line, err := json.Marshal(issue)
+	if err != nil || len(issue.Extra) == 0 {
+		return line, err
+	}
+	keys := make([]string, 0, len(issue.Extra))
+	for key := range issue.Extra {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b bytes.Buffer
+	b.Write(line[:len(line)-1])
+	for _, key := range keys {
+		k, _ := json.Marshal(key)
+		b.WriteByte(',')
+		b.Write(k)
+		b.WriteByte(':')
+		if err := json.Compact(&b, issue.Extra[key]); err != nil {
+			return nil, fmt.Errorf("field %s: %w", key, err)
+		}
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// mergeExtraFields merges the fields mergeIssue has no rule for into result.
+// A field changed on one side takes that side's value and array fields merge
+// element by element. A scalar field both sides changed takes the value of
+// the side updated (or closed) later, like title and description. Only an
+// array element both sides changed differently is returned as a conflict,
+// with ours (left) kept in result.
+func mergeExtraFields(result *Issue, base, left, right Issue) (theirs map[string]json.RawMessage, conflicts []string) {
+	keys := make(map[string]bool)
+	for key := range left.Extra {
+		keys[key] = true
+	}
+	for key := range right.Extra {
+		keys[key] = true
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+
+	leftLater := laterSide(left, right)
+	result.Extra = make(map[string]json.RawMessage)
+	theirs = make(map[string]json.RawMessage)
+	for _, key := range sorted {
+		l, r := left.Extra[key], right.Extra[key]
+		value, ok := mergeValue(key, base.Extra[key], l, r)
+		if _, isSet := elementKeys[key]; !ok && !isSet {
+			value, ok = r, true
+			if leftLater {
+				value = l
+			}
+		}
+		if !ok {
+			conflicts = append(conflicts, key)
+			value = l
+		}
+		if value != nil {
+			result.Extra[key] = value
+		}
+		if !ok {
+			value = r
+		}
+		if value != nil {
+			theirs[key] = value
+		}
+	}
+	return theirs, conflicts
+}
+
+// laterSide reports whether left was updated after right, comparing
+// closed_at when updated_at is the same. Ties go to right, as in
+// mergeFieldByUpdatedAt.
+func laterSide(left, right Issue) bool {
+	if left.UpdatedAt != right.UpdatedAt {
+		return isTimeAfter(left.UpdatedAt, right.UpdatedAt)
+	}
+	return isTimeAfter(left.ClosedAt, right.ClosedAt)
+}
+
+// conflictBlock writes an issue both sides changed in the same fields as two
+// lines between conflict markers, ours first. Each line carries every field
+// that merged cleanly, so keeping either one resolves the conflict.
+func conflictBlock(ours Issue, theirsExtra map[string]json.RawMessage, fields []string) string {
+	theirs := ours
+	theirs.Extra = theirsExtra
+	oursLine, err := marshalIssue(ours)
+	if err != nil {
+		oursLine = []byte(ours.RawLine)
+	}
+	theirsLine, err := marshalIssue(theirs)
+	if err != nil {
+		theirsLine = []byte(theirs.RawLine)
+	}
+	return strings.Join([]string{
+		strings.Repeat("<", conflictMarkerSize) + " ours: " + ours.ID + " (" + strings.Join(fields, ", ") + ")",
+		string(oursLine),
+		strings.Repeat("=", conflictMarkerSize),
+		string(theirsLine),
+		strings.Repeat(">", conflictMarkerSize) + " theirs: " + ours.ID,
+	}, "\n")
+}
+
+// sameJSON compares two values ignoring formatting; nil is an absent field
+func sameJSON(a, b json.RawMessage) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	var ca, cb bytes.Buffer
+	if json.Compact(&ca, a) != nil || json.Compact(&cb, b) != nil {
+		return bytes.Equal(a, b)
+	}
+	return bytes.Equal(ca.Bytes(), cb.Bytes())
+}
+
+// mergeValue is the 3-way merge of one field. ok is false when both sides
+// changed it incompatibly; mergeExtraFields then resolves scalars by time.
+func mergeValue(field string, b, l, r json.RawMessage) (json.RawMessage, bool) {
+	switch {
+	case sameJSON(l, r), sameJSON(b, r):
+		return l, true
+	case sameJSON(b, l):
+		return r, true
+	}
+	if _, isSet := elementKeys[field]; isSet {
+		return mergeElements(field, b, l, r)
+	}
+	return nil, false
+}
+
+// elementKeys identify the elements of the array fields merged element by
+// element. Comments are matched by author and creation time, which survive
+// edits and differ from the local comment IDs in each clone.
+var elementKeys = map[string]func(json.RawMessage) string{
+	"labels":          stringElement,
+	"aliases":         stringElement,
+	"soft_blocked_by": stringElement,
+	"attachments":     objectElement("url"),
+	"translations":    objectElement("locale"),
+	"comments":        commentElement,
+}
+
+func stringElement(v json.RawMessage) string {
+	var s string
+	if json.Unmarshal(v, &s) != nil {
+		return string(v)
+	}
+	return s
+}
+
+func objectElement(keys ...string) func(json.RawMessage) string {
+	return func(v json.RawMessage) string {
+		var obj map[string]interface{}
+		if json.Unmarshal(v, &obj) != nil {
+			return string(v)
+		}
+		parts := make([]string, len(keys))
+		for i, k := range keys {
+			parts[i] = fmt.Sprint(obj[k])
+		}
+		return strings.Join(parts, "\x00")
+	}
+}
+
+func commentElement(v json.RawMessage) string {
+	var c struct {
+		Author    string `json:"author"`
+		CreatedAt string `json:"created_at"`
+	}
+	if json.Unmarshal(v, &c) != nil {
+		return string(v)
+	}
+	if t, err := time.Parse(time.RFC3339Nano, c.CreatedAt); err == nil {
+		return fmt.Sprintf("%s@%d", c.Author, t.Unix())
+	}
+	return c.Author + "@" + c.CreatedAt
+}
+
+// mergeElements merges an array field element by element: additions from
+// either side are kept, removals from either side win, and an element changed
+// on both sides conflicts unless it is a comment, where the later edit wins
+func mergeElements(field string, b, l, r json.RawMessage) (json.RawMessage, bool) {
+	key := elementKeys[field]
+	var be, le, re []json.RawMessage
+	if (b != nil && json.Unmarshal(b, &be) != nil) || (l != nil && json.Unmarshal(l, &le) != nil) || (r != nil && json.Unmarshal(r, &re) != nil) {
+		return nil, false
+	}
+	index := func(elems []json.RawMessage) map[string]json.RawMessage {
+		m := make(map[string]json.RawMessage, len(elems))
+		for _, e := range elems {
+			m[key(e)] = e
+		}
+		return m
+	}
+	bm, lm, rm := index(be), index(le), index(re)
+
+	var merged []json.RawMessage
+	seen := make(map[string]bool)
+	for _, e := range append(append([]json.RawMessage{}, le...), re...) {
+		k := key(e)
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		bv, inBase := bm[k]
+		lv, inLeft := lm[k]
+		rv, inRight := rm[k]
+		switch {
+		case inLeft && inRight:
+			v, ok := mergeElement(field, bv, lv, rv, inBase)
+			if !ok {
+				return nil, false
+			}
+			merged = append(merged, v)
+		case inBase:
+			// Removed on one side; removal wins over an edit on the other
+		case inLeft:
+			merged = append(merged, lv)
+		default:
+			merged = append(merged, rv)
+		}
+	}
+	if len(merged) == 0 {
+		return nil, true
+	}
+	out, err := json.Marshal(merged)
+	if err != nil {
+		return nil, false
+	}
+	return out, true
+}
+
+func mergeElement(field string, b, l, r json.RawMessage, inBase bool) (json.RawMessage, bool) {
+	if !inBase {
+		b = nil
+	}
+	switch {
+	case sameJSON(l, r), sameJSON(b, r):
+		return l, true
+	case sameJSON(b, l):
+		return r, true
+	}
+	if field != "comments" {
+		return nil, false
+	}
+	var lc, rc struct {
+		UpdatedAt string `json:"updated_at"`
+	}
+	_ = json.Unmarshal(l, &lc)
+	_ = json.Unmarshal(r, &rc)
+	if isTimeAfter(lc.UpdatedAt, rc.UpdatedAt) {
+		return l, true
+	}
+	return r, true
+}
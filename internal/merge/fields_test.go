@@ -0,0 +1,170 @@
+package merge
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// mergeFilesForTest runs Merge3Way on three JSONL documents and returns the
+// output lines and whether the merge reported conflicts
+func mergeFilesForTest(t *testing.T, base, left, right string) ([]string, bool) {
+	t.Helper()
+	dir := t.TempDir()
+	paths := make([]string, 3)
+	for i, content := range []string{base, left, right} {
+		paths[i] = filepath.Join(dir, []string{"base", "left", "right"}[i]+".jsonl")
+		if err := os.WriteFile(paths[i], []byte(content+"\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	output := filepath.Join(dir, "output.jsonl")
+	err := Merge3Way(output, paths[0], paths[1], paths[2], false)
+	if err != nil && !strings.HasPrefix(err.Error(), "merge completed with") {
+		t.Fatalf("Merge3Way failed: %v", err)
+	}
+	content, readErr := os.ReadFile(output)
+	if readErr != nil {
+		t.Fatal(readErr)
+	}
+	return splitLines(string(content)), err != nil
+}
+
+func decodeLine(t *testing.T, line string) map[string]interface{} {
+	t.Helper()
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(line), &m); err != nil {
+		t.Fatalf("merged line is not JSON: %v\n%s", err, line)
+	}
+	return m
+}
+
+func TestMergeExtraFields_DisjointChanges(t *testing.T) {
+	base := `{"id":"bd-1","title":"Old","priority":2,"assignee":"alice","labels":["a"],"updated_at":"2025-01-01T00:00:00Z","design":"keep me"}`
+	left := `{"id":"bd-1","title":"New title","priority":2,"assignee":"alice","labels":["a","left"],"updated_at":"2025-01-02T00:00:00Z","design":"keep me"}`
+	right := `{"id":"bd-1","title":"Old","priority":0,"assignee":"bob","labels":["right"],"updated_at":"2025-01-03T00:00:00Z","design":"keep me"}`
+
+	lines, conflicted := mergeFilesForTest(t, base, left, right)
+	if conflicted || len(lines) != 1 {
+		t.Fatalf("expected a clean merge, got:\n%s", strings.Join(lines, "\n"))
+	}
+	got := decodeLine(t, lines[0])
+	if got["title"] != "New title" || got["assignee"] != "bob" {
+		t.Errorf("fields not merged from both sides: %v", got)
+	}
+	if got["updated_at"] != "2025-01-03T00:00:00Z" {
+		t.Errorf("updated_at = %v, want the later time", got["updated_at"])
+	}
+	// Fields without a merge rule survive
+	if got["design"] != "keep me" {
+		t.Errorf("design lost: %v", got)
+	}
+	// "a" was removed on the right, "left" and "right" added
+	labels, _ := json.Marshal(got["labels"])
+	if string(labels) != `["left","right"]` {
+		t.Errorf("labels = %s, want [\"left\",\"right\"]", labels)
+	}
+}
+
+func TestMergeExtraFields_SameFieldLaterWins(t *testing.T) {
+	base := `{"id":"bd-1","title":"Old","assignee":"alice","design":"v1","updated_at":"2025-01-01T00:00:00Z"}`
+	left := `{"id":"bd-1","title":"Old","assignee":"bob","design":"v2","updated_at":"2025-01-03T00:00:00Z"}`
+	right := `{"id":"bd-1","title":"Old","assignee":"carol","updated_at":"2025-01-02T00:00:00Z"}`
+
+	lines, conflicted := mergeFilesForTest(t, base, left, right)
+	if conflicted || len(lines) != 1 {
+		t.Fatalf("expected a clean merge, got:\n%s", strings.Join(lines, "\n"))
+	}
+	got := decodeLine(t, lines[0])
+	if got["assignee"] != "bob" || got["design"] != "v2" {
+		t.Errorf("later side (ours) should win both fields: %v", got)
+	}
+
+	// Same updated_at: the later closed_at wins
+	left = `{"id":"bd-1","status":"closed","assignee":"bob","updated_at":"2025-01-03T00:00:00Z","closed_at":"2025-01-02T00:00:00Z"}`
+	right = `{"id":"bd-1","status":"closed","assignee":"carol","updated_at":"2025-01-03T00:00:00Z","closed_at":"2025-01-03T00:00:00Z"}`
+	lines, conflicted = mergeFilesForTest(t, base, left, right)
+	if conflicted || len(lines) != 1 || decodeLine(t, lines[0])["assignee"] != "carol" {
+		t.Errorf("expected theirs (closed later) to win, got:\n%s", strings.Join(lines, "\n"))
+	}
+}
+
+func TestMergeExtraFields_ElementConflict(t *testing.T) {
+	base := `{"id":"bd-1","title":"Old","priority":2,"attachments":[{"url":"a.log","size":1}],"updated_at":"2025-01-01T00:00:00Z"}`
+	left := `{"id":"bd-1","title":"Ours","priority":1,"attachments":[{"url":"a.log","size":2}],"updated_at":"2025-01-02T00:00:00Z"}`
+	right := `{"id":"bd-1","title":"Theirs","priority":2,"attachments":[{"url":"a.log","size":3}],"updated_at":"2025-01-03T00:00:00Z"}`
+
+	lines, conflicted := mergeFilesForTest(t, base, left, right)
+	if !conflicted {
+		t.Fatalf("expected a conflict, got:\n%s", strings.Join(lines, "\n"))
+	}
+	if len(lines) != 5 || lines[0] != "<<<<<<< ours: bd-1 (attachments)" || lines[2] != "=======" || !strings.HasPrefix(lines[4], ">>>>>>> ") {
+		t.Fatalf("expected a conflict block, got:\n%s", strings.Join(lines, "\n"))
+	}
+	ours, theirs := decodeLine(t, lines[1]), decodeLine(t, lines[3])
+	if ours["attachments"] == nil || theirs["attachments"] == nil {
+		t.Errorf("conflict sides lost the attachments: %v / %v", ours, theirs)
+	}
+	// Fields with a rule are resolved in both copies: the later title, the
+	// higher priority
+	for _, side := range []map[string]interface{}{ours, theirs} {
+		if side["title"] != "Theirs" || side["priority"] != float64(1) {
+			t.Errorf("resolved fields missing from conflict copy: %v", side)
+		}
+	}
+}
+
+func TestMergeExtraFields_Comments(t *testing.T) {
+	base := `{"id":"bd-1","comments":[{"id":1,"author":"alice","text":"Teh fix","created_at":"2025-01-01T00:00:00Z"}]}`
+	left := `{"id":"bd-1","comments":[{"id":1,"author":"alice","text":"The fix","created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-02T00:00:00Z"},{"id":2,"author":"bob","text":"ours","created_at":"2025-01-03T00:00:00Z"}]}`
+	right := `{"id":"bd-1","comments":[{"id":7,"author":"alice","text":"The fix!","created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-04T00:00:00Z"},{"id":8,"author":"carol","text":"theirs","created_at":"2025-01-03T00:00:00Z"}]}`
+
+	lines, conflicted := mergeFilesForTest(t, base, left, right)
+	if conflicted || len(lines) != 1 {
+		t.Fatalf("expected a clean merge, got:\n%s", strings.Join(lines, "\n"))
+	}
+	var issue struct {
+		Comments []struct {
+			Author, Text string
+		} `json:"comments"`
+	}
+	_ = json.Unmarshal([]byte(lines[0]), &issue)
+	var texts []string
+	for _, c := range issue.Comments {
+		texts = append(texts, c.Author+":"+c.Text)
+	}
+	if strings.Join(texts, "|") != "alice:The fix!|bob:ours|carol:theirs" {
+		t.Errorf("comments = %v", texts)
+	}
+}
+
+func TestMergeExtraFields_KeptWholeIssues(t *testing.T) {
+	recent := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	base := `{"id":"bd-tomb","title":"Tombstoned ours","assignee":"alice"}`
+	left := strings.Join([]string{
+		`{"id":"bd-tomb","title":"Tombstoned ours","status":"tombstone","deleted_at":"` + recent + `","assignee":"alice"}`,
+		`{"id":"bd-new","title":"Added ours","labels":["ui"],"external_ref":"gh-12"}`,
+	}, "\n")
+	right := `{"id":"bd-tomb","title":"Edited theirs","status":"open","assignee":"bob"}`
+
+	lines, conflicted := mergeFilesForTest(t, base, left, right)
+	if conflicted || len(lines) != 2 {
+		t.Fatalf("expected a clean merge, got:\n%s", strings.Join(lines, "\n"))
+	}
+	for _, line := range lines {
+		m := decodeLine(t, line)
+		switch m["id"] {
+		case "bd-tomb":
+			if m["status"] != "tombstone" || m["assignee"] != "alice" {
+				t.Errorf("tombstone lost to a live edit: %v", m)
+			}
+		case "bd-new":
+			if m["external_ref"] != "gh-12" || m["labels"] == nil {
+				t.Errorf("added issue lost fields: %v", m)
+			}
+		}
+	}
+}
@@ -28,13 +28,13 @@
 package merge
 
 import (
-	"bufio"
 	"encoding/json"
 	"fmt"
 	"os"
 	"time"
 
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/util"
 )
 
 // Issue represents a beads issue with all possible fields
@@ -57,6 +57,9 @@ type Issue struct {
 	DeletedBy    string `json:"deleted_by,omitempty"`    // Who deleted the issue
 	DeleteReason string `json:"delete_reason,omitempty"` // Why the issue was deleted
 	OriginalType string `json:"original_type,omitempty"` // Issue type before deletion
+	// Extra holds the fields not modeled above (assignee, labels, comments,
+	// ...), kept verbatim so they survive the merge
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // Dependency represents an issue dependency
@@ -131,7 +134,7 @@ func Merge3Way(outputPath, basePath, leftPath, rightPath string, debug bool) err
 
 	// Write merged result to output file
 	for _, issue := range result {
-		line, err := json.Marshal(issue)
+		line, err := marshalIssue(issue)
 		if err != nil {
 			return fmt.Errorf("error marshaling issue %s: %w", issue.ID, err)
 		}
@@ -208,7 +211,7 @@ func readIssues(path string) ([]Issue, error) {
 	defer file.Close()
 
 	var issues []Issue
-	scanner := bufio.NewScanner(file)
+	scanner := util.NewJSONLScanner(file)
 	lineNum := 0
 	for scanner.Scan() {
 		lineNum++
@@ -221,6 +224,11 @@ func readIssues(path string) ([]Issue, error) {
 		if err := json.Unmarshal([]byte(line), &issue); err != nil {
 			return nil, fmt.Errorf("failed to parse line %d: %w", lineNum, err)
 		}
+		extra, err := extraFields([]byte(line))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse line %d: %w", lineNum, err)
+		}
+		issue.Extra = extra
 		issue.RawLine = line
 		issues = append(issues, issue)
 	}
@@ -468,7 +476,8 @@ func merge3WayWithTTL(base, left, right []Issue, ttl time.Duration) ([]Issue, []
 				continue
 			}
 
-			// CASE: Both are live - merge using deterministic rules with empty base
+			// CASE: Both are live - merge using deterministic rules with empty base;
+			// fields without a rule that differ keep the left value
 			emptyBase := Issue{
 				ID:        leftIssue.ID,
 				CreatedAt: leftIssue.CreatedAt,
@@ -602,7 +611,12 @@ func mergeIssue(base, left, right Issue) (Issue, string) {
 		// This represents invalid data that validation should catch
 	}
 
-	// All field conflicts are now auto-resolved deterministically
+	// Fields without a rule above merge field by field; only a list element
+	// both sides changed to different values needs manual resolution
+	theirs, conflictFields := mergeExtraFields(&result, base, left, right)
+	if len(conflictFields) > 0 {
+		return result, conflictBlock(result, theirs, conflictFields)
+	}
 	return result, ""
 }
 
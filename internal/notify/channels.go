@@ -0,0 +1,145 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Channel names
+const (
+	ChannelMail    = "mail"
+	ChannelWebhook = "webhook"
+)
+
+// ConfigPrefix starts the per-watcher config keys: notify.<watcher>.channels
+// (comma-separated, default "mail") and notify.<watcher>.webhook (URL)
+const ConfigPrefix = "notify."
+
+// Sender is the sender of notification messages
+const Sender = "beads"
+
+const webhookTimeout = 10 * time.Second
+
+// ChannelsKey and WebhookKey are the config keys for a watcher's channels
+func ChannelsKey(watcher string) string { return ConfigPrefix + watcher + ".channels" }
+func WebhookKey(watcher string) string  { return ConfigPrefix + watcher + ".webhook" }
+
+// Channels builds a watcher's channels from config. createMessage stores a
+// bd mail message and backs the mail channel.
+func Channels(config map[string]string, watcher string, createMessage func(ctx context.Context, msg *types.Issue) error) ([]Channel, error) {
+	names := config[ChannelsKey(watcher)]
+	if strings.TrimSpace(names) == "" {
+		names = ChannelMail
+	}
+	var channels []Channel
+	for _, name := range strings.Split(names, ",") {
+		switch name = strings.TrimSpace(name); name {
+		case "":
+		case ChannelMail:
+			channels = append(channels, &MailChannel{Create: createMessage})
+		case ChannelWebhook:
+			target := config[WebhookKey(watcher)]
+			if target == "" {
+				return nil, fmt.Errorf("%s includes webhook but %s is not set", ChannelsKey(watcher), WebhookKey(watcher))
+			}
+			if u, err := url.Parse(target); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return nil, fmt.Errorf("invalid %s %q: want an http(s) URL", WebhookKey(watcher), target)
+			}
+			channels = append(channels, &WebhookChannel{URL: target})
+		default:
+			return nil, fmt.Errorf("unknown channel %q in %s (want mail or webhook)", name, ChannelsKey(watcher))
+		}
+	}
+	return channels, nil
+}
+
+// MailChannel delivers notifications as bd mail messages to the watcher,
+// so they show up in bd mail inbox and fire the on_message hook
+type MailChannel struct {
+	Create func(ctx context.Context, msg *types.Issue) error
+}
+
+// Name implements Channel
+func (c *MailChannel) Name() string { return ChannelMail }
+
+// Send implements Channel
+func (c *MailChannel) Send(ctx context.Context, n *Notification) error {
+	title := n.Event.IssueID
+	if n.Issue != nil {
+		title += " " + n.Issue.Title
+	}
+	var body strings.Builder
+	fmt.Fprintf(&body, "%s: %s", n.Event.IssueID, n.Summary)
+	if n.Event.Actor != "" {
+		fmt.Fprintf(&body, " (by %s)", n.Event.Actor)
+	}
+	body.WriteString("\n\n")
+	if strings.HasPrefix(n.Reason, "label:") {
+		fmt.Fprintf(&body, "You are watching label %s.", strings.TrimPrefix(n.Reason, "label:"))
+	} else {
+		fmt.Fprintf(&body, "You are watching %s.", n.Event.IssueID)
+	}
+
+	now := time.Now()
+	return c.Create(ctx, &types.Issue{
+		Title:       "[watch] " + firstLine(title, 100) + ": " + n.Summary,
+		Description: body.String(),
+		Status:      types.StatusOpen,
+		Priority:    3,
+		IssueType:   types.TypeMessage,
+		Assignee:    n.Watcher,
+		Sender:      Sender,
+		Ephemeral:   true,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	})
+}
+
+// WebhookChannel POSTs the Notification as JSON
+type WebhookChannel struct {
+	URL string
+	// Client sends the requests; nil means a client with a 10s timeout
+	Client *http.Client
+}
+
+// Name implements Channel
+func (c *WebhookChannel) Name() string { return ChannelWebhook }
+
+// Send implements Channel
+func (c *WebhookChannel) Send(ctx context.Context, n *Notification) error {
+	body, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "beads-notify")
+	req.Header.Set("X-Beads-Event", "issue.watch")
+
+	client := c.Client
+	if client == nil {
+		client = &http.Client{Timeout: webhookTimeout}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s returned %s", c.URL, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,224 @@
+// Package notify delivers personal notifications about watched issues.
+//
+// A Notifier follows the events table with an events.Relay, like the event
+// bus relay and webhooks. For each event it finds everyone watching the issue,
+// directly or through one of its labels, and sends them a Notification over
+// their configured channels. Delivery is best effort: a channel that fails
+// is logged and skipped rather than holding back everyone else's
+// notifications.
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/events"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// CursorKey is the metadata key holding the last event ID processed
+const CursorKey = "notify.cursor"
+
+// pollInterval is how often the events table is read
+const pollInterval = 5 * time.Second
+
+// Source is what a Notifier reads; the SQLite storage implements it
+type Source interface {
+	events.Store
+	GetWatches(ctx context.Context, watcher string) ([]*types.Watch, error)
+	GetIssue(ctx context.Context, id string) (*types.Issue, error)
+	GetLabels(ctx context.Context, issueID string) ([]string, error)
+}
+
+// Notification tells one watcher about one event
+type Notification struct {
+	Watcher string       `json:"watcher"`
+	Reason  string       `json:"reason"` // "issue" or "label:<name>"
+	Summary string       `json:"summary"`
+	Event   *types.Event `json:"event"`
+	Issue   *types.Issue `json:"issue,omitempty"` // nil if the issue is gone
+}
+
+// Channel delivers notifications somewhere the watcher will see them
+type Channel interface {
+	Name() string
+	Send(ctx context.Context, n *Notification) error
+}
+
+// Notifier turns new events into notifications for watchers
+type Notifier struct {
+	Source Source
+	// Channels returns the channels of a watcher
+	Channels func(watcher string) ([]Channel, error)
+	// Interval between polls; zero means 5s
+	Interval time.Duration
+	// Logf receives delivery errors; may be nil
+	Logf func(format string, args ...interface{})
+
+	relay *events.Relay
+}
+
+// Run delivers notifications until ctx is cancelled
+func (n *Notifier) Run(ctx context.Context) {
+	interval := n.Interval
+	if interval <= 0 {
+		interval = pollInterval
+	}
+	events.Run(ctx, interval, n.Deliver, func(err error) {
+		n.logf("watch notifications: %v", err)
+	})
+}
+
+// Deliver sends notifications for every event after the cursor and returns
+// how many were sent. Without a cursor it starts at the newest event rather
+// than notifying about the whole history.
+func (n *Notifier) Deliver(ctx context.Context) (int, error) {
+	if n.relay == nil {
+		n.relay = &events.Relay{Store: n.Source, Key: CursorKey}
+	}
+	watches, err := n.Source.GetWatches(ctx, "")
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	var errs []error
+	channels := make(map[string][]Channel)
+	_, err = n.relay.Deliver(ctx, func(ctx context.Context, event *types.Event) error {
+		notifications, err := n.notificationsFor(ctx, event, watches)
+		if err != nil {
+			return err
+		}
+		for _, note := range notifications {
+			chans, ok := channels[note.Watcher]
+			if !ok {
+				if chans, err = n.Channels(note.Watcher); err != nil {
+					errs = append(errs, fmt.Errorf("%s: %w", note.Watcher, err))
+				}
+				channels[note.Watcher] = chans
+			}
+			for _, ch := range chans {
+				if err := ch.Send(ctx, note); err != nil {
+					errs = append(errs, fmt.Errorf("%s via %s: %w", note.Watcher, ch.Name(), err))
+					continue
+				}
+				sent++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		errs = append(errs, err)
+	}
+	return sent, errors.Join(errs...)
+}
+
+// notificationsFor matches an event against the watches: one notification
+// per watcher, who is not told about their own changes
+func (n *Notifier) notificationsFor(ctx context.Context, event *types.Event, watches []*types.Watch) ([]*Notification, error) {
+	var labels []string
+	labelsLoaded := false
+	reasons := make(map[string]string)
+	for _, w := range watches {
+		if w.Watcher == event.Actor || reasons[w.Watcher] == "issue" {
+			continue
+		}
+		switch {
+		case w.IssueID != "":
+			if w.IssueID == event.IssueID {
+				reasons[w.Watcher] = "issue"
+			}
+		case reasons[w.Watcher] == "":
+			if !labelsLoaded {
+				var err error
+				if labels, err = n.Source.GetLabels(ctx, event.IssueID); err != nil {
+					return nil, err
+				}
+				labelsLoaded = true
+			}
+			if slices.Contains(labels, w.Label) {
+				reasons[w.Watcher] = "label:" + w.Label
+			}
+		}
+	}
+	if len(reasons) == 0 {
+		return nil, nil
+	}
+
+	issue, err := n.Source.GetIssue(ctx, event.IssueID)
+	if err != nil {
+		return nil, err
+	}
+	// Notifications are themselves messages; don't notify about them
+	if issue != nil && issue.IssueType == types.TypeMessage {
+		return nil, nil
+	}
+	watchers := make([]string, 0, len(reasons))
+	for watcher := range reasons {
+		watchers = append(watchers, watcher)
+	}
+	sort.Strings(watchers)
+	notes := make([]*Notification, len(watchers))
+	for i, watcher := range watchers {
+		notes[i] = &Notification{
+			Watcher: watcher,
+			Reason:  reasons[watcher],
+			Summary: Describe(event),
+			Event:   event,
+			Issue:   issue,
+		}
+	}
+	return notes, nil
+}
+
+func (n *Notifier) logf(format string, args ...interface{}) {
+	if n.Logf != nil {
+		n.Logf(format, args...)
+	}
+}
+
+// Describe summarizes an event in a few words, e.g. "status → in_progress"
+// or "updated priority, title"
+func Describe(event *types.Event) string {
+	switch event.EventType {
+	case types.EventStatusChanged, types.EventUpdated:
+		var updates map[string]interface{}
+		if event.NewValue == nil || json.Unmarshal([]byte(*event.NewValue), &updates) != nil {
+			return string(event.EventType)
+		}
+		if status, ok := updates["status"].(string); ok {
+			return "status → " + status
+		}
+		fields := make([]string, 0, len(updates))
+		for field := range updates {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		return "updated " + strings.Join(fields, ", ")
+	case types.EventCommented:
+		if event.Comment != nil {
+			return "commented: " + firstLine(*event.Comment, 80)
+		}
+	case types.EventLabelAdded, types.EventLabelRemoved, types.EventDependencyAdded, types.EventDependencyRemoved:
+		if event.Comment != nil && *event.Comment != "" {
+			return strings.ToLower((*event.Comment)[:1]) + (*event.Comment)[1:]
+		}
+	}
+	return strings.ReplaceAll(string(event.EventType), "_", " ")
+}
+
+func firstLine(s string, limit int) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		s = s[:i] + " …"
+	}
+	if runes := []rune(s); len(runes) > limit {
+		s = string(runes[:limit-1]) + "…"
+	}
+	return s
+}
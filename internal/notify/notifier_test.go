@@ -0,0 +1,207 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+type memorySource struct {
+	events   []*types.Event
+	watches  []*types.Watch
+	issues   map[string]*types.Issue
+	labels   map[string][]string
+	metadata map[string]string
+}
+
+func (m *memorySource) GetEventsSince(_ context.Context, afterID int64, limit int) ([]*types.Event, error) {
+	var result []*types.Event
+	for _, e := range m.events {
+		if e.ID > afterID && len(result) < limit {
+			result = append(result, e)
+		}
+	}
+	return result, nil
+}
+
+func (m *memorySource) LatestEventID(context.Context) (int64, error) {
+	if len(m.events) == 0 {
+		return 0, nil
+	}
+	return m.events[len(m.events)-1].ID, nil
+}
+
+func (m *memorySource) GetMetadata(_ context.Context, key string) (string, error) {
+	return m.metadata[key], nil
+}
+
+func (m *memorySource) SetMetadata(_ context.Context, key, value string) error {
+	m.metadata[key] = value
+	return nil
+}
+
+func (m *memorySource) GetWatches(context.Context, string) ([]*types.Watch, error) {
+	return m.watches, nil
+}
+
+func (m *memorySource) GetIssue(_ context.Context, id string) (*types.Issue, error) {
+	return m.issues[id], nil
+}
+
+func (m *memorySource) GetLabels(_ context.Context, id string) ([]string, error) {
+	return m.labels[id], nil
+}
+
+func (m *memorySource) emit(issueID string, eventType types.EventType, actor, newValue string) {
+	event := &types.Event{ID: int64(len(m.events) + 1), IssueID: issueID, EventType: eventType, Actor: actor}
+	if newValue != "" {
+		event.NewValue = &newValue
+	}
+	m.events = append(m.events, event)
+}
+
+// recorder is a Channel remembering what it was sent
+type recorder struct{ sent []*Notification }
+
+func (r *recorder) Name() string { return "test" }
+
+func (r *recorder) Send(_ context.Context, n *Notification) error {
+	r.sent = append(r.sent, n)
+	return nil
+}
+
+func TestNotifierDeliver(t *testing.T) {
+	source := &memorySource{
+		issues: map[string]*types.Issue{
+			"bd-1": {ID: "bd-1", Title: "Login page"},
+			"bd-2": {ID: "bd-2", Title: "Navbar"},
+			"bd-3": {ID: "bd-3", Title: "Backend"},
+			"bd-9": {ID: "bd-9", Title: "[watch] bd-1", IssueType: types.TypeMessage},
+		},
+		labels:   map[string][]string{"bd-1": {"frontend"}, "bd-2": {"frontend"}},
+		metadata: make(map[string]string),
+		watches: []*types.Watch{
+			{Watcher: "alice", IssueID: "bd-1"},
+			{Watcher: "alice", Label: "frontend"},
+			{Watcher: "bob", Label: "frontend"},
+			{Watcher: "carol", IssueID: "bd-3"},
+		},
+	}
+	source.emit("bd-1", types.EventCreated, "carol", "")
+	recorders := make(map[string]*recorder)
+	notifier := &Notifier{
+		Source: source,
+		Channels: func(watcher string) ([]Channel, error) {
+			if recorders[watcher] == nil {
+				recorders[watcher] = &recorder{}
+			}
+			return []Channel{recorders[watcher]}, nil
+		},
+	}
+	ctx := context.Background()
+
+	// The first run starts at the newest event
+	if sent, err := notifier.Deliver(ctx); err != nil || sent != 0 {
+		t.Fatalf("first deliver: sent %d, %v", sent, err)
+	}
+
+	source.emit("bd-1", types.EventStatusChanged, "carol", `{"status":"in_progress"}`)
+	source.emit("bd-2", types.EventUpdated, "alice", `{"title":"Nav","priority":1}`)
+	source.emit("bd-3", types.EventUpdated, "carol", `{"priority":0}`)
+	source.emit("bd-9", types.EventCreated, "beads", "")
+	sent, err := notifier.Deliver(ctx)
+	if err != nil || sent != 3 {
+		t.Fatalf("deliver: sent %d, %v", sent, err)
+	}
+
+	// alice: bd-1 via the issue watch (once, not again for the label); her
+	// own bd-2 change is skipped. bob: both via the label. carol: nothing,
+	// since she made the bd-3 change herself.
+	alice := recorders["alice"].sent
+	if len(alice) != 1 || alice[0].Reason != "issue" || alice[0].Summary != "status → in_progress" {
+		t.Errorf("alice got %+v", alice)
+	}
+	bob := recorders["bob"].sent
+	if len(bob) != 2 || bob[0].Reason != "label:frontend" || bob[1].Summary != "updated priority, title" {
+		t.Errorf("bob got %+v", bob)
+	}
+	if recorders["carol"] != nil {
+		t.Errorf("carol got %+v", recorders["carol"].sent)
+	}
+
+	if sent, _ := notifier.Deliver(ctx); sent != 0 {
+		t.Errorf("repeat deliver sent %d", sent)
+	}
+}
+
+func TestChannels(t *testing.T) {
+	create := func(context.Context, *types.Issue) error { return nil }
+	channels, err := Channels(map[string]string{}, "alice", create)
+	if err != nil || len(channels) != 1 || channels[0].Name() != ChannelMail {
+		t.Errorf("default channels = %v, %v", channels, err)
+	}
+	channels, err = Channels(map[string]string{
+		"notify.alice.channels": "mail, webhook",
+		"notify.alice.webhook":  "https://hooks.example.com/alice",
+	}, "alice", create)
+	if err != nil || len(channels) != 2 || channels[1].Name() != ChannelWebhook {
+		t.Errorf("channels = %v, %v", channels, err)
+	}
+	for config, want := range map[string]string{
+		"webhook": "is not set",
+		"sms":     "unknown channel",
+	} {
+		if _, err := Channels(map[string]string{"notify.alice.channels": config}, "alice", create); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: error = %v, want %q", config, err, want)
+		}
+	}
+}
+
+func TestChannelSend(t *testing.T) {
+	var received Notification
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Beads-Event") != "issue.watch" {
+			http.Error(w, "bad event", http.StatusBadRequest)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&received)
+	}))
+	defer server.Close()
+
+	comment := "Looks good\nbut one nit"
+	note := &Notification{
+		Watcher: "bob",
+		Reason:  "label:frontend",
+		Event:   &types.Event{ID: 7, IssueID: "bd-2", EventType: types.EventCommented, Actor: "alice", Comment: &comment},
+		Issue:   &types.Issue{ID: "bd-2", Title: "Navbar"},
+	}
+	note.Summary = Describe(note.Event)
+	if note.Summary != "commented: Looks good …" {
+		t.Errorf("summary = %q", note.Summary)
+	}
+
+	if err := (&WebhookChannel{URL: server.URL}).Send(context.Background(), note); err != nil {
+		t.Fatal(err)
+	}
+	if received.Watcher != "bob" || received.Event.ID != 7 {
+		t.Errorf("webhook received %+v", received)
+	}
+
+	var msg *types.Issue
+	mail := &MailChannel{Create: func(_ context.Context, issue *types.Issue) error {
+		msg = issue
+		return nil
+	}}
+	if err := mail.Send(context.Background(), note); err != nil {
+		t.Fatal(err)
+	}
+	if msg.Assignee != "bob" || msg.IssueType != types.TypeMessage || msg.Title != "[watch] bd-2 Navbar: commented: Looks good …" ||
+		!strings.Contains(msg.Description, "watching label frontend") {
+		t.Errorf("mail message = %+v", msg)
+	}
+}
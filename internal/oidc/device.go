@@ -0,0 +1,236 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultScopes are requested when oidc.scopes is unset. offline_access asks
+// for a refresh token so 'bd auth token' can renew without a new login.
+var DefaultScopes = []string{"openid", "email", "profile", "offline_access"}
+
+// DeviceAuthorization is the provider's answer to a device flow request
+type DeviceAuthorization struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete,omitempty"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval,omitempty"`
+}
+
+// Token is the result of a device flow or a refresh. IDToken is what bd
+// serve verifies.
+type Token struct {
+	IDToken      string    `json:"id_token"`
+	AccessToken  string    `json:"access_token,omitempty"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	Expiry       time.Time `json:"expiry"`
+}
+
+// tokenResponse is the token endpoint's JSON, success or error
+type tokenResponse struct {
+	IDToken          string `json:"id_token"`
+	AccessToken      string `json:"access_token"`
+	RefreshToken     string `json:"refresh_token"`
+	ExpiresIn        int    `json:"expires_in"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// Client runs the device authorization grant against a provider
+type Client struct {
+	cfg       *Config
+	http      *http.Client
+	discovery *Discovery
+	now       func() time.Time
+	sleep     func(ctx context.Context, d time.Duration) error
+}
+
+// NewClient discovers the provider's endpoints
+func NewClient(ctx context.Context, cfg *Config, httpClient *http.Client) (*Client, error) {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	d, err := Discover(ctx, httpClient, cfg.Issuer)
+	if err != nil {
+		return nil, err
+	}
+	if d.DeviceAuthorizationEndpoint == "" {
+		return nil, fmt.Errorf("provider %s does not support the device authorization grant", cfg.Issuer)
+	}
+	return &Client{cfg: cfg, http: httpClient, discovery: d, now: time.Now, sleep: sleepContext}, nil
+}
+
+// StartDeviceFlow asks the provider for a user code to show to the user
+func (c *Client) StartDeviceFlow(ctx context.Context) (*DeviceAuthorization, error) {
+	scopes := c.cfg.Scopes
+	if len(scopes) == 0 {
+		scopes = DefaultScopes
+	}
+	form := url.Values{
+		"client_id": {c.cfg.ClientID},
+		"scope":     {strings.Join(scopes, " ")},
+	}
+	var auth DeviceAuthorization
+	if err := c.postForm(ctx, c.discovery.DeviceAuthorizationEndpoint, form, &auth); err != nil {
+		return nil, fmt.Errorf("device authorization: %w", err)
+	}
+	if auth.DeviceCode == "" || auth.UserCode == "" {
+		return nil, errors.New("device authorization: provider returned no device code")
+	}
+	return &auth, nil
+}
+
+// PollToken waits for the user to approve the device flow, honoring the
+// provider's polling interval and slow_down requests
+func (c *Client) PollToken(ctx context.Context, auth *DeviceAuthorization) (*Token, error) {
+	interval := time.Duration(auth.Interval) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	deadline := c.now().Add(time.Duration(auth.ExpiresIn) * time.Second)
+
+	form := url.Values{
+		"grant_type":  {"urn:ietf:params:oauth:grant-type:device_code"},
+		"device_code": {auth.DeviceCode},
+		"client_id":   {c.cfg.ClientID},
+	}
+	for {
+		if auth.ExpiresIn > 0 && c.now().After(deadline) {
+			return nil, errors.New("device code expired before it was approved")
+		}
+		if err := c.sleep(ctx, interval); err != nil {
+			return nil, err
+		}
+
+		resp, err := c.requestToken(ctx, form)
+		if err != nil {
+			return nil, err
+		}
+		switch resp.Error {
+		case "":
+			return c.tokenFrom(resp, "")
+		case "authorization_pending":
+			continue
+		case "slow_down":
+			interval += 5 * time.Second
+			continue
+		case "access_denied":
+			return nil, errors.New("login was denied")
+		case "expired_token":
+			return nil, errors.New("device code expired before it was approved")
+		default:
+			return nil, fmt.Errorf("token request failed: %s %s", resp.Error, resp.ErrorDescription)
+		}
+	}
+}
+
+// Refresh exchanges a refresh token for a fresh ID token
+func (c *Client) Refresh(ctx context.Context, refreshToken string) (*Token, error) {
+	resp, err := c.requestToken(ctx, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+		"client_id":     {c.cfg.ClientID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("refresh failed: %s %s", resp.Error, resp.ErrorDescription)
+	}
+	// Providers may omit the refresh token when it doesn't rotate
+	return c.tokenFrom(resp, refreshToken)
+}
+
+func (c *Client) tokenFrom(resp *tokenResponse, previousRefresh string) (*Token, error) {
+	if resp.IDToken == "" {
+		return nil, errors.New("provider returned no id_token (is the openid scope allowed for this client?)")
+	}
+	tok := &Token{
+		IDToken:      resp.IDToken,
+		AccessToken:  resp.AccessToken,
+		RefreshToken: resp.RefreshToken,
+		Expiry:       c.now().Add(time.Duration(resp.ExpiresIn) * time.Second),
+	}
+	// expires_in describes the access token; the ID token carries its own exp
+	if exp, ok := tokenExpiry(resp.IDToken); ok {
+		tok.Expiry = exp
+	}
+	if tok.RefreshToken == "" {
+		tok.RefreshToken = previousRefresh
+	}
+	return tok, nil
+}
+
+// requestToken posts to the token endpoint. OAuth errors come back as 400
+// with a JSON body, so they are returned in the response rather than as err.
+func (c *Client) requestToken(ctx context.Context, form url.Values) (*tokenResponse, error) {
+	var resp tokenResponse
+	err := c.postForm(ctx, c.discovery.TokenEndpoint, form, &resp)
+	if err != nil && resp.Error == "" {
+		return nil, fmt.Errorf("token request: %w", err)
+	}
+	return &resp, nil
+}
+
+func (c *Client) postForm(ctx context.Context, endpoint string, form url.Values, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	decodeErr := json.Unmarshal(body, v)
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("POST %s: %s", endpoint, resp.Status)
+	}
+	return decodeErr
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// tokenExpiry reads the exp claim without verifying the token. The server
+// verifies it; the client only needs to know when to refresh.
+func tokenExpiry(rawToken string) (time.Time, bool) {
+	parts := strings.Split(rawToken, ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+	var c claims
+	if err := decodeSegment(parts[1], &c); err != nil || c.ExpiresAt == nil {
+		return time.Time{}, false
+	}
+	return time.Unix(*c.ExpiresAt, 0), true
+}
+
+// Expired reports whether the ID token is within margin of expiring
+func (t *Token) Expired(now time.Time, margin time.Duration) bool {
+	return t.Expiry.IsZero() || now.Add(margin).After(t.Expiry)
+}
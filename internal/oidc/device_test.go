@@ -0,0 +1,96 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestDeviceFlow(t *testing.T) {
+	p := newFakeProvider(t)
+	idToken := p.sign(t, nil)
+
+	polls := 0
+	p.tokenResp = func(form map[string]string) (int, map[string]interface{}) {
+		if form["grant_type"] != "urn:ietf:params:oauth:grant-type:device_code" || form["device_code"] != "dev-123" {
+			return http.StatusBadRequest, map[string]interface{}{"error": "invalid_grant"}
+		}
+		polls++
+		switch polls {
+		case 1:
+			return http.StatusBadRequest, map[string]interface{}{"error": "authorization_pending"}
+		case 2:
+			return http.StatusBadRequest, map[string]interface{}{"error": "slow_down"}
+		}
+		return http.StatusOK, map[string]interface{}{"id_token": idToken, "refresh_token": "r1", "expires_in": 300}
+	}
+
+	ctx := context.Background()
+	c, err := NewClient(ctx, &Config{Issuer: p.URL, ClientID: "bd-cli"}, p.Client())
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	var waits []time.Duration
+	c.sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+
+	auth, err := c.StartDeviceFlow(ctx)
+	if err != nil {
+		t.Fatalf("StartDeviceFlow: %v", err)
+	}
+	if auth.UserCode != "ABCD-EFGH" {
+		t.Errorf("user code = %q", auth.UserCode)
+	}
+
+	tok, err := c.PollToken(ctx, auth)
+	if err != nil {
+		t.Fatalf("PollToken: %v", err)
+	}
+	if tok.IDToken != idToken || tok.RefreshToken != "r1" {
+		t.Errorf("token = %+v", tok)
+	}
+	// Expiry comes from the ID token (an hour), not expires_in (5 minutes)
+	if tok.Expiry.Before(time.Now().Add(50 * time.Minute)) {
+		t.Errorf("expiry = %v, want the ID token's exp", tok.Expiry)
+	}
+	if len(waits) != 3 || waits[0] != time.Second || waits[2] != 6*time.Second {
+		t.Errorf("poll waits = %v, want 1s 1s 6s (slow_down adds 5s)", waits)
+	}
+}
+
+func TestDeviceFlowDeniedAndRefresh(t *testing.T) {
+	p := newFakeProvider(t)
+	idToken := p.sign(t, nil)
+	p.tokenResp = func(form map[string]string) (int, map[string]interface{}) {
+		if form["grant_type"] == "refresh_token" && form["refresh_token"] == "r1" {
+			// No refresh_token in the response: the old one stays valid
+			return http.StatusOK, map[string]interface{}{"id_token": idToken}
+		}
+		return http.StatusBadRequest, map[string]interface{}{"error": "access_denied"}
+	}
+
+	ctx := context.Background()
+	c, err := NewClient(ctx, &Config{Issuer: p.URL, ClientID: "bd-cli"}, p.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.sleep = func(context.Context, time.Duration) error { return nil }
+
+	if _, err := c.PollToken(ctx, &DeviceAuthorization{DeviceCode: "dev-123", Interval: 1}); err == nil {
+		t.Error("expected denied login to fail")
+	}
+
+	tok, err := c.Refresh(ctx, "r1")
+	if err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	if tok.RefreshToken != "r1" || tok.IDToken != idToken {
+		t.Errorf("refreshed token = %+v", tok)
+	}
+	if _, err := c.Refresh(ctx, "revoked"); err == nil {
+		t.Error("expected refresh with a revoked token to fail")
+	}
+}
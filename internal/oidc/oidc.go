@@ -0,0 +1,533 @@
+// Package oidc lets bd serve accept identities from an OpenID Connect
+// provider instead of static tenant tokens, and lets CLI clients obtain those
+// identities with the device authorization grant (RFC 8628).
+//
+// The server verifies ID tokens against the provider's published keys, maps
+// a claim (email by default) to the beads actor and another claim (groups by
+// default) to roles through oidc.roles rules:
+//
+//	oidc:
+//	  issuer: https://login.example.com
+//	  client_id: bd-cli
+//	  roles:
+//	    - beads-admins=writer        # every tenant
+//	    - support=acme:reader        # only tenant acme
+//	    - "*=reader"                 # any verified identity
+//
+// Identities that match no rule are rejected.
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+)
+
+// Roles granted by oidc.roles rules. Readers may only use read endpoints.
+const (
+	RoleReader = "reader"
+	RoleWriter = "writer"
+)
+
+// Defaults for the claims mapped to actors and roles
+const (
+	DefaultActorClaim = "email"
+	DefaultRolesClaim = "groups"
+)
+
+// clockSkew tolerates small differences between the provider's clock and ours
+const clockSkew = time.Minute
+
+// jwksRefreshInterval bounds how often an unknown key ID refetches the key set
+const jwksRefreshInterval = time.Minute
+
+// Config describes the identity provider and how its claims map to beads
+type Config struct {
+	Issuer     string
+	ClientID   string
+	Audience   string   // Expected aud claim; defaults to ClientID
+	Scopes     []string // Requested by the device flow
+	ActorClaim string
+	RolesClaim string
+	Roles      []RoleRule
+}
+
+// RoleRule grants Role to identities whose roles claim contains Value. An
+// empty Tenant applies to every tenant; Value "*" matches any identity.
+type RoleRule struct {
+	Value  string
+	Tenant string
+	Role   string
+}
+
+// ParseRoleRule parses "value=role" or "value=tenant:role"
+func ParseRoleRule(s string) (RoleRule, error) {
+	value, grant, ok := strings.Cut(s, "=")
+	value = strings.TrimSpace(value)
+	if !ok || value == "" {
+		return RoleRule{}, fmt.Errorf("invalid oidc.roles rule %q (want value=role or value=tenant:role)", s)
+	}
+	rule := RoleRule{Value: value, Role: strings.TrimSpace(grant)}
+	if tenant, role, scoped := strings.Cut(rule.Role, ":"); scoped {
+		rule.Tenant = strings.TrimSpace(tenant)
+		rule.Role = strings.TrimSpace(role)
+	}
+	if rule.Role != RoleReader && rule.Role != RoleWriter {
+		return RoleRule{}, fmt.Errorf("invalid role %q in oidc.roles rule %q (valid: %s, %s)", rule.Role, s, RoleReader, RoleWriter)
+	}
+	return rule, nil
+}
+
+// Enabled reports whether an issuer is configured
+func (c *Config) Enabled() bool {
+	return c.Issuer != ""
+}
+
+// FromConfig reads the oidc.* settings. The result is disabled (not an
+// error) when oidc.issuer is unset.
+func FromConfig() (*Config, error) {
+	cfg := &Config{
+		Issuer:     strings.TrimRight(config.GetString("oidc.issuer"), "/"),
+		ClientID:   config.GetString("oidc.client_id"),
+		Audience:   config.GetString("oidc.audience"),
+		Scopes:     config.GetStringSlice("oidc.scopes"),
+		ActorClaim: config.GetString("oidc.actor_claim"),
+		RolesClaim: config.GetString("oidc.roles_claim"),
+	}
+	for _, s := range config.GetStringSlice("oidc.roles") {
+		rule, err := ParseRoleRule(s)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Roles = append(cfg.Roles, rule)
+	}
+	if cfg.Enabled() && cfg.ClientID == "" {
+		return nil, errors.New("oidc.client_id is required when oidc.issuer is set")
+	}
+	return cfg, nil
+}
+
+func (c *Config) audience() string {
+	if c.Audience != "" {
+		return c.Audience
+	}
+	return c.ClientID
+}
+
+func (c *Config) actorClaim() string {
+	if c.ActorClaim != "" {
+		return c.ActorClaim
+	}
+	return DefaultActorClaim
+}
+
+func (c *Config) rolesClaim() string {
+	if c.RolesClaim != "" {
+		return c.RolesClaim
+	}
+	return DefaultRolesClaim
+}
+
+// Identity is a verified caller
+type Identity struct {
+	Subject string
+	Actor   string   // Value of the actor claim, falling back to preferred_username and sub
+	Groups  []string // Values of the roles claim
+}
+
+// RoleFor returns the strongest role the identity holds for tenant, or ""
+// when no rule grants access
+func (c *Config) RoleFor(id *Identity, tenant string) string {
+	role := ""
+	for _, rule := range c.Roles {
+		if rule.Tenant != "" && rule.Tenant != tenant {
+			continue
+		}
+		if rule.Value != "*" && !contains(id.Groups, rule.Value) {
+			continue
+		}
+		if rule.Role == RoleWriter {
+			return RoleWriter
+		}
+		role = RoleReader
+	}
+	return role
+}
+
+func contains(values []string, want string) bool {
+	for _, v := range values {
+		if v == want {
+			return true
+		}
+	}
+	return false
+}
+
+// Discovery is the subset of the provider metadata document bd uses
+type Discovery struct {
+	Issuer                      string `json:"issuer"`
+	JWKSURI                     string `json:"jwks_uri"`
+	TokenEndpoint               string `json:"token_endpoint"`
+	DeviceAuthorizationEndpoint string `json:"device_authorization_endpoint"`
+}
+
+// Discover fetches <issuer>/.well-known/openid-configuration
+func Discover(ctx context.Context, client *http.Client, issuer string) (*Discovery, error) {
+	var d Discovery
+	if err := getJSON(ctx, client, strings.TrimRight(issuer, "/")+"/.well-known/openid-configuration", &d); err != nil {
+		return nil, fmt.Errorf("oidc discovery: %w", err)
+	}
+	if strings.TrimRight(d.Issuer, "/") != strings.TrimRight(issuer, "/") {
+		return nil, fmt.Errorf("oidc discovery: issuer mismatch (configured %s, provider says %s)", issuer, d.Issuer)
+	}
+	return &d, nil
+}
+
+// Verifier checks ID tokens signed by the configured provider
+type Verifier struct {
+	cfg    *Config
+	client *http.Client
+	now    func() time.Time
+
+	mu        sync.Mutex
+	discovery *Discovery
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+}
+
+// NewVerifier creates a verifier. Provider metadata and keys are fetched
+// lazily on the first token.
+func NewVerifier(cfg *Config, client *http.Client) *Verifier {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &Verifier{cfg: cfg, client: client, now: time.Now}
+}
+
+// RoleFor applies the configured role rules to a verified identity
+func (v *Verifier) RoleFor(id *Identity, tenant string) string {
+	return v.cfg.RoleFor(id, tenant)
+}
+
+// claims are the registered claims checked during verification
+type claims struct {
+	Issuer    string          `json:"iss"`
+	Subject   string          `json:"sub"`
+	Audience  json.RawMessage `json:"aud"`
+	ExpiresAt *int64          `json:"exp"`
+	NotBefore *int64          `json:"nbf"`
+}
+
+// Verify checks the token's signature, issuer, audience and lifetime and
+// returns the identity it carries
+func (v *Verifier) Verify(ctx context.Context, rawToken string) (*Identity, error) {
+	parts := strings.Split(rawToken, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed token")
+	}
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("malformed token header: %w", err)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("malformed token signature: %w", err)
+	}
+
+	key, err := v.key(ctx, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifySignature(header.Alg, key, []byte(parts[0]+"."+parts[1]), signature); err != nil {
+		return nil, err
+	}
+
+	var raw map[string]interface{}
+	if err := decodeSegment(parts[1], &raw); err != nil {
+		return nil, fmt.Errorf("malformed token payload: %w", err)
+	}
+	var c claims
+	if err := decodeSegment(parts[1], &c); err != nil {
+		return nil, fmt.Errorf("malformed token payload: %w", err)
+	}
+	if strings.TrimRight(c.Issuer, "/") != strings.TrimRight(v.cfg.Issuer, "/") {
+		return nil, fmt.Errorf("token issuer %q is not %q", c.Issuer, v.cfg.Issuer)
+	}
+	if !audienceContains(c.Audience, v.cfg.audience()) {
+		return nil, fmt.Errorf("token audience does not include %q", v.cfg.audience())
+	}
+	now := v.now()
+	if c.ExpiresAt == nil || now.After(time.Unix(*c.ExpiresAt, 0).Add(clockSkew)) {
+		return nil, errors.New("token expired")
+	}
+	if c.NotBefore != nil && now.Add(clockSkew).Before(time.Unix(*c.NotBefore, 0)) {
+		return nil, errors.New("token not valid yet")
+	}
+
+	id := &Identity{Subject: c.Subject, Groups: stringsClaim(raw[v.cfg.rolesClaim()])}
+	for _, name := range []string{v.cfg.actorClaim(), "preferred_username", "sub"} {
+		if s, ok := raw[name].(string); ok && s != "" {
+			id.Actor = s
+			break
+		}
+	}
+	if id.Actor == "" {
+		return nil, errors.New("token has no usable actor claim")
+	}
+	return id, nil
+}
+
+// key returns the signing key with the given ID, refetching the key set when
+// the ID is unknown (providers rotate keys) at most once per interval
+func (v *Verifier) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if key, ok := v.lookupKey(kid); ok {
+		return key, nil
+	}
+	if !v.fetchedAt.IsZero() && v.now().Sub(v.fetchedAt) < jwksRefreshInterval {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+
+	if v.discovery == nil {
+		d, err := Discover(ctx, v.client, v.cfg.Issuer)
+		if err != nil {
+			return nil, err
+		}
+		v.discovery = d
+	}
+	keys, err := fetchKeys(ctx, v.client, v.discovery.JWKSURI)
+	v.fetchedAt = v.now()
+	if err != nil {
+		return nil, err
+	}
+	v.keys = keys
+
+	if key, ok := v.lookupKey(kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+// lookupKey finds a key by ID. A token without kid is accepted only when the
+// provider publishes exactly one key.
+func (v *Verifier) lookupKey(kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(v.keys) == 1 {
+		for _, key := range v.keys {
+			return key, true
+		}
+	}
+	key, ok := v.keys[kid]
+	return key, ok
+}
+
+// jwk is one entry of a JSON Web Key Set (RSA and EC signing keys only)
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func fetchKeys(ctx context.Context, client *http.Client, uri string) (map[string]crypto.PublicKey, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := getJSON(ctx, client, uri, &set); err != nil {
+		return nil, fmt.Errorf("oidc keys: %w", err)
+	}
+	keys := make(map[string]crypto.PublicKey)
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.publicKey()
+		if err != nil {
+			continue // Skip key types we don't support rather than failing the set
+		}
+		keys[k.Kid] = key
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("oidc keys: provider published no usable signing keys")
+	}
+	return keys, nil
+}
+
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	}
+	return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+}
+
+// verifySignature checks a JWS signature. Only asymmetric algorithms are
+// accepted, so a token can't downgrade to "none" or an HMAC keyed with the
+// public key.
+func verifySignature(alg string, key crypto.PublicKey, signed, sig []byte) error {
+	var hash crypto.Hash
+	switch alg {
+	case "RS256", "ES256":
+		hash = crypto.SHA256
+	case "RS384", "ES384":
+		hash = crypto.SHA384
+	case "RS512":
+		hash = crypto.SHA512
+	default:
+		return fmt.Errorf("unsupported token algorithm %q", alg)
+	}
+	digest := hashBytes(hash, signed)
+
+	switch pub := key.(type) {
+	case *rsa.PublicKey:
+		if !strings.HasPrefix(alg, "RS") {
+			return fmt.Errorf("algorithm %s does not match RSA key", alg)
+		}
+		if err := rsa.VerifyPKCS1v15(pub, hash, digest, sig); err != nil {
+			return errors.New("invalid token signature")
+		}
+		return nil
+	case *ecdsa.PublicKey:
+		if !strings.HasPrefix(alg, "ES") {
+			return fmt.Errorf("algorithm %s does not match EC key", alg)
+		}
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if len(sig) != 2*size {
+			return errors.New("invalid token signature")
+		}
+		r := new(big.Int).SetBytes(sig[:size])
+		s := new(big.Int).SetBytes(sig[size:])
+		if !ecdsa.Verify(pub, digest, r, s) {
+			return errors.New("invalid token signature")
+		}
+		return nil
+	}
+	return errors.New("unsupported signing key")
+}
+
+func hashBytes(hash crypto.Hash, data []byte) []byte {
+	switch hash {
+	case crypto.SHA384:
+		sum := sha512.Sum384(data)
+		return sum[:]
+	case crypto.SHA512:
+		sum := sha512.Sum512(data)
+		return sum[:]
+	}
+	sum := sha256.Sum256(data)
+	return sum[:]
+}
+
+// audienceContains handles aud as either a string or an array of strings
+func audienceContains(raw json.RawMessage, want string) bool {
+	var single string
+	if err := json.Unmarshal(raw, &single); err == nil {
+		return single == want
+	}
+	var list []string
+	if err := json.Unmarshal(raw, &list); err == nil {
+		return contains(list, want)
+	}
+	return false
+}
+
+// stringsClaim accepts a claim holding a string or an array of strings
+func stringsClaim(v interface{}) []string {
+	switch val := v.(type) {
+	case string:
+		return []string{val}
+	case []interface{}:
+		out := make([]string, 0, len(val))
+		for _, item := range val {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+func decodeSegment(seg string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(data), nil
+}
+
+func getJSON(ctx context.Context, client *http.Client, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("GET %s: %s: %s", url, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(v)
+}
@@ -0,0 +1,222 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeProvider serves discovery, JWKS, device and token endpoints and signs
+// ID tokens with an RSA key
+type fakeProvider struct {
+	*httptest.Server
+	key       *rsa.PrivateKey
+	kid       string
+	jwksHits  int32
+	tokenResp func(form map[string]string) (int, map[string]interface{})
+}
+
+func newFakeProvider(t *testing.T) *fakeProvider {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := &fakeProvider{key: key, kid: "k1"}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(Discovery{
+			Issuer:                      p.URL,
+			JWKSURI:                     p.URL + "/jwks",
+			TokenEndpoint:               p.URL + "/token",
+			DeviceAuthorizationEndpoint: p.URL + "/device",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&p.jwksHits, 1)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"keys": []map[string]string{{
+			"kty": "RSA", "kid": p.kid, "use": "sig",
+			"n": base64.RawURLEncoding.EncodeToString(p.key.N.Bytes()),
+			"e": base64.RawURLEncoding.EncodeToString(big.NewInt(int64(p.key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/device", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(DeviceAuthorization{
+			DeviceCode: "dev-123", UserCode: "ABCD-EFGH",
+			VerificationURI: p.URL + "/activate", ExpiresIn: 600, Interval: 1,
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		form := make(map[string]string)
+		for k := range r.PostForm {
+			form[k] = r.PostForm.Get(k)
+		}
+		status, body := p.tokenResp(form)
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(body)
+	})
+	p.Server = httptest.NewServer(mux)
+	t.Cleanup(p.Close)
+	return p
+}
+
+// sign builds an RS256 token with the given claims merged over valid defaults
+func (p *fakeProvider) sign(t *testing.T, overrides map[string]interface{}) string {
+	t.Helper()
+	payload := map[string]interface{}{
+		"iss":    p.URL,
+		"sub":    "user-1",
+		"aud":    "bd-cli",
+		"exp":    time.Now().Add(time.Hour).Unix(),
+		"email":  "ada@example.com",
+		"groups": []string{"eng"},
+	}
+	for k, v := range overrides {
+		if v == nil {
+			delete(payload, k)
+		} else {
+			payload[k] = v
+		}
+	}
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "kid": p.kid, "typ": "JWT"})
+	body, _ := json.Marshal(payload)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(body)
+	digest := sha256.Sum256([]byte(signed))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, p.key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func TestVerify(t *testing.T) {
+	p := newFakeProvider(t)
+	v := NewVerifier(&Config{Issuer: p.URL, ClientID: "bd-cli"}, p.Client())
+	ctx := context.Background()
+
+	id, err := v.Verify(ctx, p.sign(t, nil))
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if id.Actor != "ada@example.com" || id.Subject != "user-1" || len(id.Groups) != 1 || id.Groups[0] != "eng" {
+		t.Errorf("identity = %+v", id)
+	}
+
+	// Actor falls back to preferred_username when email is missing
+	id, err = v.Verify(ctx, p.sign(t, map[string]interface{}{"email": nil, "preferred_username": "ada"}))
+	if err != nil || id.Actor != "ada" {
+		t.Errorf("fallback actor = %+v, %v", id, err)
+	}
+
+	// An array audience containing the client ID is accepted
+	if _, err := v.Verify(ctx, p.sign(t, map[string]interface{}{"aud": []string{"other", "bd-cli"}})); err != nil {
+		t.Errorf("array audience: %v", err)
+	}
+
+	rejected := map[string]string{
+		"expired":       p.sign(t, map[string]interface{}{"exp": time.Now().Add(-time.Hour).Unix()}),
+		"no exp":        p.sign(t, map[string]interface{}{"exp": nil}),
+		"wrong issuer":  p.sign(t, map[string]interface{}{"iss": "https://evil.example.com"}),
+		"wrong aud":     p.sign(t, map[string]interface{}{"aud": "someone-else"}),
+		"not yet valid": p.sign(t, map[string]interface{}{"nbf": time.Now().Add(time.Hour).Unix()}),
+		"tampered":      tamper(p.sign(t, nil)),
+		"alg none":      noneToken(),
+		"not a jwt":     "static-tenant-token",
+	}
+	for name, token := range rejected {
+		if _, err := v.Verify(ctx, token); err == nil {
+			t.Errorf("%s: expected rejection", name)
+		}
+	}
+}
+
+func TestVerifyRefetchesRotatedKeys(t *testing.T) {
+	p := newFakeProvider(t)
+	v := NewVerifier(&Config{Issuer: p.URL, ClientID: "bd-cli"}, p.Client())
+	ctx := context.Background()
+
+	if _, err := v.Verify(ctx, p.sign(t, nil)); err != nil {
+		t.Fatal(err)
+	}
+
+	// The provider rotates to a new key ID; the verifier refetches once the
+	// refresh interval has passed
+	p.kid = "k2"
+	if _, err := v.Verify(ctx, p.sign(t, nil)); err == nil {
+		t.Error("expected unknown key within the refresh interval")
+	}
+	v.now = func() time.Time { return time.Now().Add(2 * jwksRefreshInterval) }
+	if _, err := v.Verify(ctx, p.sign(t, map[string]interface{}{"exp": time.Now().Add(3 * time.Hour).Unix()})); err != nil {
+		t.Errorf("after rotation: %v", err)
+	}
+	if hits := atomic.LoadInt32(&p.jwksHits); hits != 2 {
+		t.Errorf("JWKS fetched %d times, want 2", hits)
+	}
+}
+
+func TestRoleFor(t *testing.T) {
+	var rules []RoleRule
+	for _, s := range []string{"eng=writer", "support=acme:reader", "contractors=acme:writer"} {
+		rule, err := ParseRoleRule(s)
+		if err != nil {
+			t.Fatalf("ParseRoleRule(%q): %v", s, err)
+		}
+		rules = append(rules, rule)
+	}
+	cfg := &Config{Roles: rules}
+
+	tests := []struct {
+		groups []string
+		tenant string
+		want   string
+	}{
+		{[]string{"eng"}, "acme", RoleWriter},
+		{[]string{"support"}, "acme", RoleReader},
+		{[]string{"support"}, "globex", ""},
+		{[]string{"support", "contractors"}, "acme", RoleWriter},
+		{nil, "acme", ""},
+	}
+	for _, tt := range tests {
+		if got := cfg.RoleFor(&Identity{Groups: tt.groups}, tt.tenant); got != tt.want {
+			t.Errorf("RoleFor(%v, %s) = %q, want %q", tt.groups, tt.tenant, got, tt.want)
+		}
+	}
+
+	everyone := &Config{Roles: []RoleRule{{Value: "*", Role: RoleReader}}}
+	if got := everyone.RoleFor(&Identity{}, "any"); got != RoleReader {
+		t.Errorf("wildcard = %q, want reader", got)
+	}
+
+	for _, bad := range []string{"eng", "=writer", "eng=admin", "eng=acme:owner"} {
+		if _, err := ParseRoleRule(bad); err == nil {
+			t.Errorf("ParseRoleRule(%q): expected error", bad)
+		}
+	}
+}
+
+// tamper flips the payload so the signature no longer matches
+func tamper(token string) string {
+	parts := strings.Split(token, ".")
+	payload, _ := base64.RawURLEncoding.DecodeString(parts[1])
+	payload = []byte(strings.Replace(string(payload), "ada@", "eve@", 1))
+	parts[1] = base64.RawURLEncoding.EncodeToString(payload)
+	return strings.Join(parts, ".")
+}
+
+func noneToken() string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`))
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"iss":"x","aud":"bd-cli","exp":9999999999,"email":"eve@example.com"}`))
+	return header + "." + payload + "."
+}
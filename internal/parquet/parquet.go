@@ -0,0 +1,278 @@
+// Package parquet writes Apache Parquet files for analytics exports.
+//
+// It implements only what flat tables of backlog data need: UTF-8 string,
+// int64 and timestamp columns, optionally nullable, in a single row group
+// with one uncompressed PLAIN-encoded data page per column. DuckDB, Spark,
+// pandas and other Parquet readers accept the output; nothing in bd reads it
+// back.
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Type is a column's value type
+type Type int
+
+const (
+	// String columns hold UTF-8 text (BYTE_ARRAY, converted type UTF8)
+	String Type = iota
+	// Int64 columns hold integers (INT64)
+	Int64
+	// Timestamp columns hold UTC instants with microsecond precision (INT64,
+	// converted type TIMESTAMP_MICROS)
+	Timestamp
+)
+
+// Column describes one column of a table
+type Column struct {
+	Name     string
+	Type     Type
+	Optional bool // values may be nil
+}
+
+// Parquet enum values (parquet.thrift)
+const (
+	physicalInt64     = 2
+	physicalByteArray = 6
+
+	convertedUTF8            = 0
+	convertedTimestampMicros = 10
+
+	repetitionRequired = 0
+	repetitionOptional = 1
+
+	encodingPlain = 0
+	encodingRLE   = 3
+
+	codecUncompressed = 0
+	pageTypeData      = 0
+)
+
+const magic = "PAR1"
+
+// Writer buffers rows for one table and writes them as a Parquet file
+type Writer struct {
+	columns   []Column
+	values    [][]interface{} // per column, nil for nulls
+	rows      int
+	createdBy string
+}
+
+// NewWriter returns a writer for a table with the given columns.
+// createdBy is recorded in the file metadata, e.g. "bd version 0.30.0".
+func NewWriter(createdBy string, columns ...Column) *Writer {
+	return &Writer{columns: columns, values: make([][]interface{}, len(columns)), createdBy: createdBy}
+}
+
+// Rows returns the number of rows appended so far
+func (w *Writer) Rows() int {
+	return w.rows
+}
+
+// Append adds a row with one value per column, in column order. String
+// columns take string, Int64 columns int or int64, Timestamp columns
+// time.Time; nil stands for null in optional columns.
+func (w *Writer) Append(values ...interface{}) error {
+	if len(values) != len(w.columns) {
+		return fmt.Errorf("got %d values for %d columns", len(values), len(w.columns))
+	}
+	for i, v := range values {
+		col := w.columns[i]
+		if v == nil {
+			if !col.Optional {
+				return fmt.Errorf("column %s is required", col.Name)
+			}
+			continue
+		}
+		switch col.Type {
+		case String:
+			if _, ok := v.(string); !ok {
+				return fmt.Errorf("column %s: want string, got %T", col.Name, v)
+			}
+		case Int64:
+			switch n := v.(type) {
+			case int:
+				values[i] = int64(n)
+			case int64:
+			default:
+				return fmt.Errorf("column %s: want int64, got %T", col.Name, v)
+			}
+		case Timestamp:
+			if _, ok := v.(time.Time); !ok {
+				return fmt.Errorf("column %s: want time.Time, got %T", col.Name, v)
+			}
+		}
+	}
+	for i, v := range values {
+		w.values[i] = append(w.values[i], v)
+	}
+	w.rows++
+	return nil
+}
+
+// WriteTo writes the complete file to out
+func (w *Writer) WriteTo(out io.Writer) (int64, error) {
+	var file bytes.Buffer
+	file.WriteString(magic)
+
+	type chunk struct {
+		offset, size int64
+	}
+	chunks := make([]chunk, len(w.columns))
+	if w.rows > 0 {
+		for i, col := range w.columns {
+			page := encodePage(col, w.values[i])
+
+			var header compactWriter
+			header.begin()
+			header.i32(1, pageTypeData)
+			header.i32(2, int32(len(page)))
+			header.i32(3, int32(len(page)))
+			header.structField(5)
+			header.i32(1, int32(w.rows))
+			header.i32(2, encodingPlain)
+			header.i32(3, encodingRLE)
+			header.i32(4, encodingRLE)
+			header.end()
+			header.end()
+
+			chunks[i].offset = int64(file.Len())
+			file.Write(header.buf.Bytes())
+			file.Write(page)
+			chunks[i].size = int64(file.Len()) - chunks[i].offset
+		}
+	}
+
+	var meta compactWriter
+	meta.begin()
+	meta.i32(1, 1) // version
+	meta.listHeader(2, tStruct, len(w.columns)+1)
+	meta.begin()
+	meta.binary(4, "schema")
+	meta.i32(5, int32(len(w.columns)))
+	meta.end()
+	for _, col := range w.columns {
+		meta.begin()
+		physical, converted := physicalType(col.Type)
+		meta.i32(1, physical)
+		repetition := int32(repetitionRequired)
+		if col.Optional {
+			repetition = repetitionOptional
+		}
+		meta.i32(3, repetition)
+		meta.binary(4, col.Name)
+		if converted >= 0 {
+			meta.i32(6, converted)
+		}
+		meta.end()
+	}
+	meta.i64(3, int64(w.rows))
+	if w.rows > 0 {
+		meta.listHeader(4, tStruct, 1)
+		meta.begin()
+		meta.listHeader(1, tStruct, len(w.columns))
+		var total int64
+		for i, col := range w.columns {
+			physical, _ := physicalType(col.Type)
+			meta.begin()
+			meta.i64(2, chunks[i].offset)
+			meta.structField(3)
+			meta.i32(1, physical)
+			meta.i32List(2, []int32{encodingPlain, encodingRLE})
+			meta.stringList(3, []string{col.Name})
+			meta.i32(4, codecUncompressed)
+			meta.i64(5, int64(w.rows))
+			meta.i64(6, chunks[i].size)
+			meta.i64(7, chunks[i].size)
+			meta.i64(9, chunks[i].offset)
+			meta.end()
+			meta.end()
+			total += chunks[i].size
+		}
+		meta.i64(2, total)
+		meta.i64(3, int64(w.rows))
+		meta.end()
+	} else {
+		meta.listHeader(4, tStruct, 0)
+	}
+	if w.createdBy != "" {
+		meta.binary(6, w.createdBy)
+	}
+	meta.end()
+
+	file.Write(meta.buf.Bytes())
+	_ = binary.Write(&file, binary.LittleEndian, uint32(meta.buf.Len()))
+	file.WriteString(magic)
+
+	n, err := out.Write(file.Bytes())
+	return int64(n), err
+}
+
+// physicalType maps a column type to its Parquet physical and converted
+// types; converted is -1 when there is none
+func physicalType(t Type) (physical, converted int32) {
+	switch t {
+	case String:
+		return physicalByteArray, convertedUTF8
+	case Timestamp:
+		return physicalInt64, convertedTimestampMicros
+	default:
+		return physicalInt64, -1
+	}
+}
+
+// encodePage builds a v1 data page body: definition levels for optional
+// columns, then the non-null values in PLAIN encoding
+func encodePage(col Column, values []interface{}) []byte {
+	var page bytes.Buffer
+	if col.Optional {
+		levels := encodeDefinitionLevels(values)
+		_ = binary.Write(&page, binary.LittleEndian, uint32(len(levels)))
+		page.Write(levels)
+	}
+	var tmp [8]byte
+	for _, v := range values {
+		switch v := v.(type) {
+		case nil:
+		case string:
+			binary.LittleEndian.PutUint32(tmp[:4], uint32(len(v)))
+			page.Write(tmp[:4])
+			page.WriteString(v)
+		case int64:
+			binary.LittleEndian.PutUint64(tmp[:], uint64(v))
+			page.Write(tmp[:])
+		case time.Time:
+			binary.LittleEndian.PutUint64(tmp[:], uint64(v.UnixMicro()))
+			page.Write(tmp[:])
+		}
+	}
+	return page.Bytes()
+}
+
+// encodeDefinitionLevels writes 1 (present) or 0 (null) per value as RLE
+// runs of the RLE/bit-packing hybrid encoding with bit width 1
+func encodeDefinitionLevels(values []interface{}) []byte {
+	var out []byte
+	var tmp [binary.MaxVarintLen64]byte
+	for i := 0; i < len(values); {
+		present := values[i] != nil
+		j := i + 1
+		for j < len(values) && (values[j] != nil) == present {
+			j++
+		}
+		n := binary.PutUvarint(tmp[:], uint64(j-i)<<1)
+		out = append(out, tmp[:n]...)
+		if present {
+			out = append(out, 1)
+		} else {
+			out = append(out, 0)
+		}
+		i = j
+	}
+	return out
+}
@@ -0,0 +1,275 @@
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// The tests decode files with a minimal reader written against the format
+// spec, independent of the writer's encoding helpers
+
+type thriftReader struct {
+	data []byte
+	pos  int
+	t    *testing.T
+}
+
+func (r *thriftReader) byte() byte {
+	if r.pos >= len(r.data) {
+		r.t.Fatalf("thrift: unexpected end of data at %d", r.pos)
+	}
+	b := r.data[r.pos]
+	r.pos++
+	return b
+}
+
+func (r *thriftReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.data[r.pos:])
+	if n <= 0 {
+		r.t.Fatalf("thrift: bad varint at %d", r.pos)
+	}
+	r.pos += n
+	return v
+}
+
+func (r *thriftReader) zigzag() int64 {
+	v := r.uvarint()
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+func (r *thriftReader) value(typ byte) interface{} {
+	switch typ {
+	case 1:
+		return true
+	case 2:
+		return false
+	case 4, tI32, tI64:
+		return r.zigzag()
+	case tBinary:
+		n := int(r.uvarint())
+		s := string(r.data[r.pos : r.pos+n])
+		r.pos += n
+		return s
+	case tList:
+		h := r.byte()
+		n, elem := int(h>>4), h&0x0f
+		if n == 15 {
+			n = int(r.uvarint())
+		}
+		list := make([]interface{}, n)
+		for i := range list {
+			list[i] = r.value(elem)
+		}
+		return list
+	case tStruct:
+		return r.structure()
+	}
+	r.t.Fatalf("thrift: unsupported type %d", typ)
+	return nil
+}
+
+func (r *thriftReader) structure() map[int]interface{} {
+	fields := make(map[int]interface{})
+	last := 0
+	for {
+		h := r.byte()
+		if h == 0 {
+			return fields
+		}
+		typ, id := h&0x0f, last+int(h>>4)
+		if h>>4 == 0 {
+			id = int(r.zigzag())
+		}
+		fields[id] = r.value(typ)
+		last = id
+	}
+}
+
+// readTable decodes a file written by Writer into rows of column name → value
+func readTable(t *testing.T, data []byte) (map[int]interface{}, []map[string]interface{}) {
+	t.Helper()
+	if !bytes.HasPrefix(data, []byte(magic)) || !bytes.HasSuffix(data, []byte(magic)) {
+		t.Fatal("missing PAR1 magic")
+	}
+	metaLen := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	footer := &thriftReader{data: data[len(data)-8-metaLen : len(data)-8], t: t}
+	meta := footer.structure()
+
+	numRows := int(meta[3].(int64))
+	rows := make([]map[string]interface{}, numRows)
+	for i := range rows {
+		rows[i] = make(map[string]interface{})
+	}
+	schema := meta[2].([]interface{})
+	groups := meta[4].([]interface{})
+	if numRows == 0 {
+		return meta, rows
+	}
+	chunks := groups[0].(map[int]interface{})[1].([]interface{})
+	for c, chunk := range chunks {
+		elem := schema[c+1].(map[int]interface{})
+		name := elem[4].(string)
+		optional := elem[3].(int64) == repetitionOptional
+		cm := chunk.(map[int]interface{})[3].(map[int]interface{})
+		if got := cm[3].([]interface{})[0].(string); got != name {
+			t.Fatalf("chunk %d path %q, want %q", c, got, name)
+		}
+
+		r := &thriftReader{data: data, pos: int(cm[9].(int64)), t: t}
+		header := r.structure()
+		page := data[r.pos : r.pos+int(header[3].(int64))]
+		if int(header[5].(map[int]interface{})[1].(int64)) != numRows {
+			t.Fatalf("column %s page num_values mismatch", name)
+		}
+
+		present := make([]bool, numRows)
+		for i := range present {
+			present[i] = true
+		}
+		if optional {
+			levelsLen := int(binary.LittleEndian.Uint32(page))
+			lr := &thriftReader{data: page[4 : 4+levelsLen], t: t}
+			row := 0
+			for lr.pos < len(lr.data) {
+				h := lr.uvarint()
+				if h&1 != 0 {
+					t.Fatalf("column %s: unexpected bit-packed run", name)
+				}
+				v := lr.byte()
+				for k := 0; k < int(h>>1); k++ {
+					present[row] = v == 1
+					row++
+				}
+			}
+			page = page[4+levelsLen:]
+		}
+
+		for i := range rows {
+			if !present[i] {
+				rows[i][name] = nil
+				continue
+			}
+			switch elem[1].(int64) {
+			case physicalByteArray:
+				n := int(binary.LittleEndian.Uint32(page))
+				rows[i][name] = string(page[4 : 4+n])
+				page = page[4+n:]
+			case physicalInt64:
+				v := int64(binary.LittleEndian.Uint64(page))
+				page = page[8:]
+				if ct, ok := elem[6]; ok && ct.(int64) == convertedTimestampMicros {
+					rows[i][name] = time.UnixMicro(v).UTC()
+				} else {
+					rows[i][name] = v
+				}
+			}
+		}
+		if len(page) != 0 {
+			t.Fatalf("column %s: %d trailing bytes", name, len(page))
+		}
+	}
+	return meta, rows
+}
+
+func TestWriterRoundTrip(t *testing.T) {
+	w := NewWriter("bd test",
+		Column{Name: "id", Type: String},
+		Column{Name: "priority", Type: Int64},
+		Column{Name: "assignee", Type: String, Optional: true},
+		Column{Name: "closed_at", Type: Timestamp, Optional: true},
+	)
+	closed := time.Date(2025, 6, 1, 12, 30, 45, 123456000, time.UTC)
+	rows := [][]interface{}{
+		{"bd-1", 0, "alice", closed},
+		{"bd-2", int64(2), nil, nil},
+		{"bd-3", 4, nil, nil},
+		{"日本語 🚀", 1, "", closed.Add(time.Hour)},
+	}
+	for _, row := range rows {
+		if err := w.Append(row...); err != nil {
+			t.Fatalf("Append(%v): %v", row, err)
+		}
+	}
+
+	var buf bytes.Buffer
+	n, err := w.WriteTo(&buf)
+	if err != nil || n != int64(buf.Len()) {
+		t.Fatalf("WriteTo = %d, %v", n, err)
+	}
+	meta, got := readTable(t, buf.Bytes())
+	if meta[6] != "bd test" {
+		t.Errorf("created_by = %v", meta[6])
+	}
+
+	want := []map[string]interface{}{
+		{"id": "bd-1", "priority": int64(0), "assignee": "alice", "closed_at": closed},
+		{"id": "bd-2", "priority": int64(2), "assignee": nil, "closed_at": nil},
+		{"id": "bd-3", "priority": int64(4), "assignee": nil, "closed_at": nil},
+		{"id": "日本語 🚀", "priority": int64(1), "assignee": "", "closed_at": closed.Add(time.Hour)},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("rows = %v\nwant %v", got, want)
+	}
+}
+
+func TestWriterManyColumnsAndRows(t *testing.T) {
+	// More than 14 schema elements and long strings exercise the long list
+	// header and multi-byte varints
+	var cols []Column
+	for i := 0; i < 20; i++ {
+		cols = append(cols, Column{Name: "c" + strings.Repeat("x", i), Type: String, Optional: i%2 == 1})
+	}
+	w := NewWriter("", cols...)
+	for r := 0; r < 300; r++ {
+		row := make([]interface{}, len(cols))
+		for i := range row {
+			if i%2 == 1 && r%3 == 0 {
+				continue
+			}
+			row[i] = strings.Repeat("v", r)
+		}
+		if err := w.Append(row...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var buf bytes.Buffer
+	if _, err := w.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	_, got := readTable(t, buf.Bytes())
+	if len(got) != 300 {
+		t.Fatalf("got %d rows", len(got))
+	}
+	if got[299]["c"] != strings.Repeat("v", 299) || got[3]["cx"] != nil || got[4]["cx"] != "vvvv" {
+		t.Errorf("unexpected values: %v / %v / %v", got[299]["c"], got[3]["cx"], got[4]["cx"])
+	}
+}
+
+func TestWriterEmptyAndErrors(t *testing.T) {
+	w := NewWriter("", Column{Name: "id", Type: String})
+	var buf bytes.Buffer
+	if _, err := w.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	meta, rows := readTable(t, buf.Bytes())
+	if len(rows) != 0 || len(meta[4].([]interface{})) != 0 {
+		t.Errorf("empty table: rows %v, row groups %v", rows, meta[4])
+	}
+
+	if err := w.Append(nil); err == nil {
+		t.Error("expected null in a required column to fail")
+	}
+	if err := w.Append(42); err == nil {
+		t.Error("expected an int in a string column to fail")
+	}
+	if err := w.Append("a", "b"); err == nil {
+		t.Error("expected a wrong value count to fail")
+	}
+	if w.Rows() != 0 {
+		t.Errorf("rejected rows were appended: %d", w.Rows())
+	}
+}
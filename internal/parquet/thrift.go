@@ -0,0 +1,104 @@
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// Thrift compact protocol type codes used in the Parquet footer
+const (
+	tI32    = 5
+	tI64    = 6
+	tBinary = 8
+	tList   = 9
+	tStruct = 12
+)
+
+// compactWriter encodes the small subset of the Thrift compact protocol the
+// Parquet metadata structures need: i32, i64, binary, lists and structs
+type compactWriter struct {
+	buf     bytes.Buffer
+	lastID  int16
+	idStack []int16
+}
+
+func (c *compactWriter) uvarint(v uint64) {
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(tmp[:], v)
+	c.buf.Write(tmp[:n])
+}
+
+func (c *compactWriter) zigzag(v int64) {
+	c.uvarint(uint64((v << 1) ^ (v >> 63)))
+}
+
+func (c *compactWriter) fieldHeader(typ byte, id int16) {
+	if delta := id - c.lastID; delta > 0 && delta <= 15 {
+		c.buf.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		c.buf.WriteByte(typ)
+		c.zigzag(int64(id))
+	}
+	c.lastID = id
+}
+
+func (c *compactWriter) i32(id int16, v int32) {
+	c.fieldHeader(tI32, id)
+	c.zigzag(int64(v))
+}
+
+func (c *compactWriter) i64(id int16, v int64) {
+	c.fieldHeader(tI64, id)
+	c.zigzag(v)
+}
+
+func (c *compactWriter) binary(id int16, s string) {
+	c.fieldHeader(tBinary, id)
+	c.uvarint(uint64(len(s)))
+	c.buf.WriteString(s)
+}
+
+// listHeader starts a list field; the caller writes n elements after it
+func (c *compactWriter) listHeader(id int16, elemType byte, n int) {
+	c.fieldHeader(tList, id)
+	if n < 15 {
+		c.buf.WriteByte(byte(n)<<4 | elemType)
+	} else {
+		c.buf.WriteByte(0xf0 | elemType)
+		c.uvarint(uint64(n))
+	}
+}
+
+func (c *compactWriter) i32List(id int16, vs []int32) {
+	c.listHeader(id, tI32, len(vs))
+	for _, v := range vs {
+		c.zigzag(int64(v))
+	}
+}
+
+func (c *compactWriter) stringList(id int16, vs []string) {
+	c.listHeader(id, tBinary, len(vs))
+	for _, v := range vs {
+		c.uvarint(uint64(len(v)))
+		c.buf.WriteString(v)
+	}
+}
+
+// structField starts a nested struct field; close it with end
+func (c *compactWriter) structField(id int16) {
+	c.fieldHeader(tStruct, id)
+	c.begin()
+}
+
+// begin starts a struct without a field header: the top-level struct or a
+// list element
+func (c *compactWriter) begin() {
+	c.idStack = append(c.idStack, c.lastID)
+	c.lastID = 0
+}
+
+func (c *compactWriter) end() {
+	c.buf.WriteByte(0) // field stop
+	c.lastID = c.idStack[len(c.idStack)-1]
+	c.idStack = c.idStack[:len(c.idStack)-1]
+}
@@ -0,0 +1,250 @@
+// Package plugins finds bd plugins: executables named bd-<name> on PATH,
+// and commands declared under plugins: in config.yaml. bd runs each as
+// "bd <name>", passing the project it was run in through the environment
+// (see Context).
+package plugins
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// Prefix is the file name prefix of plugins found on PATH
+const Prefix = "bd-"
+
+// ContextEnv holds the JSON-encoded Context in a plugin's environment
+const ContextEnv = "BD_PLUGIN_CONTEXT"
+
+// Sources a plugin can come from
+const (
+	SourcePath   = "path"
+	SourceConfig = "config"
+)
+
+// StdinContext makes bd write the JSON Context to a declared plugin's stdin
+// instead of passing its own stdin through
+const StdinContext = "context"
+
+var validName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// ValidName reports whether name can be used as a bd subcommand
+func ValidName(name string) bool {
+	return validName.MatchString(name)
+}
+
+// Plugin is an external command run as "bd <Name>"
+type Plugin struct {
+	Name        string   `json:"name"`
+	Path        string   `json:"path"`           // executable
+	Args        []string `json:"args,omitempty"` // run before the user's arguments
+	Description string   `json:"description,omitempty"`
+	Source      string   `json:"source"` // SourcePath or SourceConfig
+	Stdin       string   `json:"stdin,omitempty"`
+	// Shadowed is set when a built-in command or another plugin has the
+	// same name, so this one never runs
+	Shadowed bool `json:"shadowed,omitempty"`
+}
+
+// Declared is a plugin declared in config.yaml, either as a command line
+// or with its options:
+//
+//	plugins:
+//	  deploy: ./scripts/deploy.sh --env prod
+//	  triage:
+//	    command: python3 tools/triage.py
+//	    description: Triage new bugs
+//	    stdin: context
+type Declared struct {
+	Name        string
+	Command     string
+	Description string
+	Stdin       string
+}
+
+// ParseDeclared reads the plugins: config value
+func ParseDeclared(raw map[string]interface{}) ([]Declared, error) {
+	var declared []Declared
+	for name, value := range raw {
+		d := Declared{Name: name}
+		switch v := value.(type) {
+		case string:
+			d.Command = v
+		case map[string]interface{}:
+			for key, field := range v {
+				s := fmt.Sprint(field)
+				switch key {
+				case "command":
+					d.Command = s
+				case "description":
+					d.Description = s
+				case "stdin":
+					d.Stdin = s
+				default:
+					return nil, fmt.Errorf("plugins.%s: unknown option %q", name, key)
+				}
+			}
+		default:
+			return nil, fmt.Errorf("plugins.%s: want a command or a map with command:", name)
+		}
+		if !ValidName(name) {
+			return nil, fmt.Errorf("plugins.%s: names are lowercase letters, digits, - and _", name)
+		}
+		if strings.TrimSpace(d.Command) == "" {
+			return nil, fmt.Errorf("plugins.%s: command is empty", name)
+		}
+		if d.Stdin != "" && d.Stdin != StdinContext {
+			return nil, fmt.Errorf("plugins.%s: stdin must be %q", name, StdinContext)
+		}
+		declared = append(declared, d)
+	}
+	sort.Slice(declared, func(i, j int) bool { return declared[i].Name < declared[j].Name })
+	return declared, nil
+}
+
+// Discover returns the plugins declared in config and found on the
+// directories of pathList (a PATH value), sorted by name. Declared plugins
+// come before PATH plugins of the same name, and earlier PATH directories
+// before later ones; the losers are returned with Shadowed set, as are
+// plugins named after a built-in command. Declared commands are split on
+// whitespace, and a relative executable path is resolved against baseDir.
+func Discover(pathList string, declared []Declared, baseDir string, builtin func(name string) bool) []*Plugin {
+	var found []*Plugin
+	for _, d := range declared {
+		fields := strings.Fields(d.Command)
+		exe := fields[0]
+		if strings.ContainsRune(exe, filepath.Separator) || strings.ContainsRune(exe, '/') {
+			if !filepath.IsAbs(exe) {
+				exe = filepath.Join(baseDir, exe)
+			}
+		}
+		found = append(found, &Plugin{
+			Name:        d.Name,
+			Path:        exe,
+			Args:        fields[1:],
+			Description: d.Description,
+			Source:      SourceConfig,
+			Stdin:       d.Stdin,
+		})
+	}
+	for _, dir := range filepath.SplitList(pathList) {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name, ok := pathPluginName(entry.Name())
+			if !ok {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			if !isExecutable(path) {
+				continue
+			}
+			found = append(found, &Plugin{Name: name, Path: path, Source: SourcePath})
+		}
+	}
+
+	// Stable, so declared and earlier PATH entries stay first per name
+	sort.SliceStable(found, func(i, j int) bool { return found[i].Name < found[j].Name })
+	seen := make(map[string]bool)
+	for _, p := range found {
+		p.Shadowed = seen[p.Name] || (builtin != nil && builtin(p.Name))
+		seen[p.Name] = true
+	}
+	return found
+}
+
+// pathPluginName returns the subcommand name of a bd-<name> file
+func pathPluginName(file string) (string, bool) {
+	name, ok := strings.CutPrefix(file, Prefix)
+	if !ok {
+		return "", false
+	}
+	if runtime.GOOS == "windows" {
+		ext := strings.ToLower(filepath.Ext(name))
+		if ext != ".exe" && ext != ".bat" && ext != ".cmd" {
+			return "", false
+		}
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+	return name, ValidName(name)
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		return true
+	}
+	return info.Mode().Perm()&0o111 != 0
+}
+
+// Context is the project a plugin was run in. bd passes it JSON-encoded in
+// BD_PLUGIN_CONTEXT (and on stdin to declared plugins with stdin: context),
+// and sets BEADS_DB, BEADS_DIR and BD_ACTOR so bd commands the plugin runs
+// use the same database and actor.
+type Context struct {
+	Plugin     string   `json:"plugin"`
+	Args       []string `json:"args"`
+	Version    string   `json:"version"`    // bd version
+	Executable string   `json:"executable"` // the bd binary, for calling back
+	WorkDir    string   `json:"work_dir"`
+	RepoRoot   string   `json:"repo_root,omitempty"`
+	BeadsDir   string   `json:"beads_dir,omitempty"`
+	DBPath     string   `json:"db_path,omitempty"`
+	JSONLPath  string   `json:"jsonl_path,omitempty"`
+	Actor      string   `json:"actor,omitempty"`
+	JSON       bool     `json:"json"`     // --json was given
+	Readonly   bool     `json:"readonly"` // writes must be refused
+}
+
+// Env returns the environment variables that carry the context
+func (c *Context) Env() ([]string, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	env := []string{
+		ContextEnv + "=" + string(data),
+		"BD_PLUGIN=" + c.Plugin,
+		"BD_BIN=" + c.Executable,
+	}
+	if c.DBPath != "" {
+		env = append(env, "BEADS_DB="+c.DBPath)
+	}
+	if c.BeadsDir != "" {
+		env = append(env, "BEADS_DIR="+c.BeadsDir)
+	}
+	if c.Actor != "" {
+		env = append(env, "BD_ACTOR="+c.Actor)
+	}
+	if c.Readonly {
+		// Read as the readonly setting, so bd refuses writes for the plugin
+		env = append(env, "BD_READONLY=true")
+	}
+	return env, nil
+}
+
+// LoadContext reads the context bd passed to the running plugin
+func LoadContext() (*Context, error) {
+	data := os.Getenv(ContextEnv)
+	if data == "" {
+		return nil, fmt.Errorf("%s is not set; run this program as a bd plugin", ContextEnv)
+	}
+	var c Context
+	if err := json.Unmarshal([]byte(data), &c); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", ContextEnv, err)
+	}
+	return &c, nil
+}
@@ -0,0 +1,106 @@
+package plugins
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestParseDeclared(t *testing.T) {
+	declared, err := ParseDeclared(map[string]interface{}{
+		"deploy": "./scripts/deploy.sh --env prod",
+		"triage": map[string]interface{}{"command": "python3 triage.py", "description": "Triage", "stdin": "context"},
+	})
+	if err != nil {
+		t.Fatalf("ParseDeclared failed: %v", err)
+	}
+	if len(declared) != 2 || declared[0].Name != "deploy" || declared[1].Stdin != StdinContext || declared[1].Description != "Triage" {
+		t.Errorf("declared = %+v", declared)
+	}
+
+	for name, raw := range map[string]map[string]interface{}{
+		"bad name":      {"Deploy!": "x"},
+		"empty command": {"x": ""},
+		"unknown key":   {"x": map[string]interface{}{"command": "y", "shell": true}},
+		"bad stdin":     {"x": map[string]interface{}{"command": "y", "stdin": "args"}},
+		"wrong type":    {"x": 3},
+	} {
+		if _, err := ParseDeclared(raw); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("PATH plugins are matched by extension on Windows")
+	}
+	first, second := t.TempDir(), t.TempDir()
+	write := func(dir, name string, mode os.FileMode) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"), mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(first, "bd-lint", 0o755)
+	write(first, "bd-notes.txt", 0o644) // not executable
+	write(first, "bd-ready", 0o755)     // built-in
+	write(second, "bd-lint", 0o755)     // later on PATH
+	write(second, "bd-deploy", 0o755)   // declared in config
+	write(second, "other", 0o755)
+
+	declared := []Declared{{Name: "deploy", Command: "scripts/deploy.sh --env prod"}}
+	builtin := func(name string) bool { return name == "ready" }
+	found := Discover(first+string(os.PathListSeparator)+second, declared, "/repo", builtin)
+
+	var got []string
+	for _, p := range found {
+		entry := p.Name + ":" + p.Source
+		if p.Shadowed {
+			entry += ":shadowed"
+		}
+		got = append(got, entry)
+	}
+	want := "deploy:config,deploy:path:shadowed,lint:path,lint:path:shadowed,ready:path:shadowed"
+	if strings.Join(got, ",") != want {
+		t.Errorf("found %s, want %s", strings.Join(got, ","), want)
+	}
+	if found[0].Path != filepath.Join("/repo", "scripts/deploy.sh") || strings.Join(found[0].Args, " ") != "--env prod" {
+		t.Errorf("declared plugin = %+v", found[0])
+	}
+	if found[2].Path != filepath.Join(first, "bd-lint") {
+		t.Errorf("lint should come from the first PATH directory, got %s", found[2].Path)
+	}
+}
+
+func TestContextRoundTrip(t *testing.T) {
+	c := &Context{Plugin: "deploy", Args: []string{"--env", "prod"}, DBPath: "/repo/.beads/beads.db", BeadsDir: "/repo/.beads", Actor: "alice", Readonly: true}
+	env, err := c.Env()
+	if err != nil {
+		t.Fatal(err)
+	}
+	vars := make(map[string]string)
+	for _, kv := range env {
+		key, value, _ := strings.Cut(kv, "=")
+		vars[key] = value
+	}
+	if vars["BEADS_DB"] != c.DBPath || vars["BD_ACTOR"] != "alice" || vars["BD_PLUGIN"] != "deploy" || vars["BD_READONLY"] != "true" {
+		t.Errorf("env = %v", env)
+	}
+
+	t.Setenv(ContextEnv, vars[ContextEnv])
+	loaded, err := LoadContext()
+	if err != nil {
+		t.Fatalf("LoadContext failed: %v", err)
+	}
+	if loaded.Plugin != "deploy" || strings.Join(loaded.Args, " ") != "--env prod" || !loaded.Readonly {
+		t.Errorf("loaded = %+v", loaded)
+	}
+
+	t.Setenv(ContextEnv, "")
+	if _, err := LoadContext(); err == nil {
+		t.Errorf("LoadContext should fail outside a plugin")
+	}
+}
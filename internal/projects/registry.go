@@ -0,0 +1,276 @@
+// Package projects keeps the user-level registry of known beads projects,
+// so commands can target a project by name from any directory.
+package projects
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/beads/internal/lockfile"
+)
+
+// Project is a registered project: a directory containing .beads
+type Project struct {
+	Name    string    `json:"name"`
+	Path    string    `json:"path"`
+	AddedAt time.Time `json:"added_at"`
+}
+
+// Registry manages the projects file, ~/.beads/projects.json by default
+type Registry struct {
+	path     string
+	lockPath string
+	mu       sync.Mutex // in-process mutex (cross-process uses file lock)
+}
+
+// NewRegistry returns the registry in the user's ~/.beads directory
+func NewRegistry() (*Registry, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return NewRegistryAt(filepath.Join(home, ".beads")), nil
+}
+
+// NewRegistryAt returns a registry stored in dir
+func NewRegistryAt(dir string) *Registry {
+	return &Registry{
+		path:     filepath.Join(dir, "projects.json"),
+		lockPath: filepath.Join(dir, "projects.lock"),
+	}
+}
+
+// withFileLock runs fn holding an exclusive lock on the registry
+func (r *Registry) withFileLock(fn func() error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0750); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(r.path), err)
+	}
+	// nolint:gosec // G304: fixed path under the user's home
+	lockFile, err := os.OpenFile(r.lockPath, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open lock file: %w", err)
+	}
+	defer func() { _ = lockFile.Close() }()
+
+	if err := lockfile.FlockExclusiveBlocking(lockFile); err != nil {
+		return fmt.Errorf("failed to acquire lock: %w", err)
+	}
+	defer func() { _ = lockfile.FlockUnlock(lockFile) }()
+
+	return fn()
+}
+
+func (r *Registry) readLocked() ([]Project, error) {
+	data, err := os.ReadFile(r.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", r.path, err)
+	}
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return nil, nil
+	}
+	var list []Project
+	if err := json.Unmarshal(data, &list); err != nil {
+		// Unlike the daemon registry this file is user-curated, so don't
+		// silently start over
+		return nil, fmt.Errorf("failed to parse %s: %w", r.path, err)
+	}
+	return list, nil
+}
+
+func (r *Registry) writeLocked(list []Project) error {
+	if list == nil {
+		list = []Project{}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal projects: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(r.path), "projects-*.json.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to write temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to close temp file: %w", err)
+	}
+	if err := os.Rename(tmpPath, r.path); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to rename temp file: %w", err)
+	}
+	return nil
+}
+
+// List returns the registered projects sorted by name
+func (r *Registry) List() ([]Project, error) {
+	var list []Project
+	err := r.withFileLock(func() error {
+		var err error
+		list, err = r.readLocked()
+		return err
+	})
+	return list, err
+}
+
+// Add registers a project. Re-adding a name with the same path is a no-op;
+// a name or path that is already registered otherwise is an error.
+func (r *Registry) Add(p Project) error {
+	if err := ValidateName(p.Name); err != nil {
+		return err
+	}
+	return r.withFileLock(func() error {
+		list, err := r.readLocked()
+		if err != nil {
+			return err
+		}
+		for _, existing := range list {
+			switch {
+			case existing.Name == p.Name && existing.Path == p.Path:
+				return nil
+			case existing.Name == p.Name:
+				return fmt.Errorf("project %q is already registered for %s", p.Name, existing.Path)
+			case existing.Path == p.Path:
+				return fmt.Errorf("%s is already registered as %q", p.Path, existing.Name)
+			}
+		}
+		if p.AddedAt.IsZero() {
+			p.AddedAt = time.Now().UTC()
+		}
+		return r.writeLocked(append(list, p))
+	})
+}
+
+// Remove unregisters a project by name
+func (r *Registry) Remove(name string) error {
+	return r.withFileLock(func() error {
+		list, err := r.readLocked()
+		if err != nil {
+			return err
+		}
+		kept := list[:0]
+		for _, p := range list {
+			if p.Name != name {
+				kept = append(kept, p)
+			}
+		}
+		if len(kept) == len(list) {
+			return fmt.Errorf("no project named %q", name)
+		}
+		return r.writeLocked(kept)
+	})
+}
+
+// Lookup returns the project with the given name, or nil
+func (r *Registry) Lookup(name string) (*Project, error) {
+	list, err := r.List()
+	if err != nil {
+		return nil, err
+	}
+	for i := range list {
+		if list[i].Name == name {
+			return &list[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// ValidateName checks a project name: a non-empty word that can't be
+// mistaken for a path
+func ValidateName(name string) error {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, "~") {
+		return fmt.Errorf("invalid project name %q: names can't be empty or contain path separators", name)
+	}
+	return nil
+}
+
+// Resolve turns a --project value into a project directory. Anything that
+// looks like a path (contains a separator or starts with . or ~) is a path;
+// otherwise the registry is consulted first, then the current directory.
+func (r *Registry) Resolve(value string) (string, error) {
+	looksLikePath := strings.ContainsAny(value, `/\`) || strings.HasPrefix(value, ".") || strings.HasPrefix(value, "~")
+	if !looksLikePath {
+		p, err := r.Lookup(value)
+		if err != nil {
+			return "", err
+		}
+		if p != nil {
+			return checkProjectDir(p.Path, p.Name)
+		}
+	}
+
+	path, err := expandHome(value)
+	if err != nil {
+		return "", err
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	if _, statErr := os.Stat(abs); statErr != nil && !looksLikePath {
+		return "", fmt.Errorf("unknown project %q (not registered and not a directory)", value)
+	}
+	return checkProjectDir(abs, "")
+}
+
+// checkProjectDir verifies dir exists and holds a .beads directory. name is
+// the registered name, if any, for error messages.
+func checkProjectDir(dir, name string) (string, error) {
+	label := dir
+	if name != "" {
+		label = fmt.Sprintf("project %q (%s)", name, dir)
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", label, err)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("%s is not a directory", label)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".beads")); err != nil {
+		return "", fmt.Errorf("%s has no .beads directory", label)
+	}
+	return dir, nil
+}
+
+func expandHome(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~")), nil
+}
+
+// Normalize returns the absolute form of a project path for registration
+func Normalize(path string) (string, error) {
+	expanded, err := expandHome(path)
+	if err != nil {
+		return "", err
+	}
+	abs, err := filepath.Abs(expanded)
+	if err != nil {
+		return "", err
+	}
+	return checkProjectDir(abs, "")
+}
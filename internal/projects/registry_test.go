@@ -0,0 +1,119 @@
+package projects
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func makeProject(t *testing.T, parent, name string) string {
+	t.Helper()
+	dir := filepath.Join(parent, name)
+	if err := os.MkdirAll(filepath.Join(dir, ".beads"), 0750); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestRegistryAddListRemove(t *testing.T) {
+	reg := NewRegistryAt(t.TempDir())
+	root := t.TempDir()
+	api := makeProject(t, root, "api")
+	web := makeProject(t, root, "web")
+
+	if err := reg.Add(Project{Name: "web", Path: web}); err != nil {
+		t.Fatal(err)
+	}
+	if err := reg.Add(Project{Name: "api", Path: api}); err != nil {
+		t.Fatal(err)
+	}
+	// Re-adding the same registration is a no-op
+	if err := reg.Add(Project{Name: "api", Path: api}); err != nil {
+		t.Fatalf("re-add: %v", err)
+	}
+	if err := reg.Add(Project{Name: "api", Path: web}); err == nil {
+		t.Error("expected error reusing a name for another path")
+	}
+	if err := reg.Add(Project{Name: "other", Path: api}); err == nil {
+		t.Error("expected error registering a path twice")
+	}
+
+	list, err := reg.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 2 || list[0].Name != "api" || list[1].Name != "web" {
+		t.Fatalf("list = %+v, want api and web sorted", list)
+	}
+	if list[0].AddedAt.IsZero() {
+		t.Error("AddedAt not set")
+	}
+
+	if err := reg.Remove("web"); err != nil {
+		t.Fatal(err)
+	}
+	if err := reg.Remove("web"); err == nil {
+		t.Error("expected error removing an unknown project")
+	}
+	if p, err := reg.Lookup("web"); err != nil || p != nil {
+		t.Errorf("Lookup(web) = %v, %v after removal", p, err)
+	}
+}
+
+func TestRegistryResolve(t *testing.T) {
+	reg := NewRegistryAt(t.TempDir())
+	root := t.TempDir()
+	api := makeProject(t, root, "api")
+	plain := filepath.Join(root, "plain")
+	if err := os.MkdirAll(plain, 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := reg.Add(Project{Name: "backend", Path: api}); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := reg.Resolve("backend"); err != nil || got != api {
+		t.Errorf("Resolve(backend) = %q, %v; want %q", got, err, api)
+	}
+	if got, err := reg.Resolve(api); err != nil || got != api {
+		t.Errorf("Resolve(path) = %q, %v; want %q", got, err, api)
+	}
+	if _, err := reg.Resolve("nope"); err == nil || !strings.Contains(err.Error(), "unknown project") {
+		t.Errorf("Resolve(nope) error = %v", err)
+	}
+	if _, err := reg.Resolve(plain); err == nil || !strings.Contains(err.Error(), "no .beads") {
+		t.Errorf("Resolve(plain) error = %v", err)
+	}
+
+	// A registered project whose directory has gone away names the project
+	if err := os.RemoveAll(api); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reg.Resolve("backend"); err == nil || !strings.Contains(err.Error(), `"backend"`) {
+		t.Errorf("Resolve(stale) error = %v", err)
+	}
+}
+
+func TestRegistryCorruptFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "projects.json"), []byte("{not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewRegistryAt(dir).List(); err == nil {
+		t.Error("expected error for a corrupt registry")
+	}
+}
+
+func TestValidateName(t *testing.T) {
+	for _, name := range []string{"api", "my-app", "web_2"} {
+		if err := ValidateName(name); err != nil {
+			t.Errorf("ValidateName(%q) = %v", name, err)
+		}
+	}
+	for _, name := range []string{"", ".", "..", "a/b", `a\b`, "~api"} {
+		if err := ValidateName(name); err == nil {
+			t.Errorf("ValidateName(%q) accepted", name)
+		}
+	}
+}
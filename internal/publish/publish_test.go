@@ -0,0 +1,224 @@
+package publish
+
+import (
+	"context"
+	"encoding/json"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/redact"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+type fakeSource struct {
+	issues []*types.Issue
+	labels map[string][]string
+	deps   map[string][]*types.Dependency
+}
+
+func (f *fakeSource) SearchIssues(ctx context.Context, query string, filter types.IssueFilter) ([]*types.Issue, error) {
+	return f.issues, nil
+}
+
+func (f *fakeSource) GetAllDependencyRecords(ctx context.Context) (map[string][]*types.Dependency, error) {
+	return f.deps, nil
+}
+
+func (f *fakeSource) GetLabelsForIssues(ctx context.Context, issueIDs []string) (map[string][]string, error) {
+	return f.labels, nil
+}
+
+func testSource() *fakeSource {
+	t0 := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	issue := func(id, title string, status types.Status, updated int) *types.Issue {
+		return &types.Issue{ID: id, Title: title, Status: status, Priority: 2, IssueType: types.TypeTask,
+			Assignee: "alice", Notes: "internal notes", CreatedAt: t0, UpdatedAt: t0.Add(time.Duration(updated) * time.Hour)}
+	}
+	return &fakeSource{
+		issues: []*types.Issue{
+			issue("bd-2", "Roadmap <item>", types.StatusOpen, 1),
+			issue("bd-1", "Secret deal", types.StatusInProgress, 5),
+			issue("bd-3", "Done", types.StatusClosed, 2),
+			issue("bd-4", "Old", types.StatusTombstone, 9),
+			{ID: "bd-5", Title: "hi", Status: types.StatusOpen, IssueType: types.TypeMessage, UpdatedAt: t0.Add(9 * time.Hour)},
+		},
+		labels: map[string][]string{"bd-1": {"confidential"}, "bd-2": {"roadmap"}},
+		deps: map[string][]*types.Dependency{
+			"bd-2": {
+				{IssueID: "bd-2", DependsOnID: "bd-1", Type: types.DepBlocks},
+				{IssueID: "bd-2", DependsOnID: "bd-3", Type: types.DepBlocks},
+			},
+		},
+	}
+}
+
+func TestBuild_FiltersConfidential(t *testing.T) {
+	redactor, err := redact.New([]string{"generic-api-key"}, nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	source := testSource()
+	source.issues[0].Description = "token=abcdefghijklmnopqrstuv"
+	snapshot, err := Build(context.Background(), source, Options{
+		Title: "Demo", ExcludeLabels: DefaultExcludeLabels, IncludeClosed: true, Redactor: redactor,
+	})
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	var ids []string
+	for _, issue := range snapshot.Issues {
+		ids = append(ids, issue.ID)
+	}
+	if strings.Join(ids, ",") != "bd-2,bd-3" {
+		t.Fatalf("published %v, want bd-2,bd-3", ids)
+	}
+	if snapshot.Withheld != 1 {
+		t.Errorf("Withheld = %d, want 1", snapshot.Withheld)
+	}
+	// The dependency on the confidential issue is dropped
+	if deps := snapshot.Issues[0].Dependencies; len(deps) != 1 || deps[0].ID != "bd-3" {
+		t.Errorf("dependencies = %+v, want only bd-3", deps)
+	}
+	if !strings.Contains(snapshot.Issues[0].Description, redact.DefaultReplacement) {
+		t.Errorf("description not redacted: %q", snapshot.Issues[0].Description)
+	}
+	// The last change among published issues, not the withheld one
+	if want := time.Date(2025, 3, 1, 14, 0, 0, 0, time.UTC); !snapshot.UpdatedAt.Equal(want) {
+		t.Errorf("UpdatedAt = %v, want %v", snapshot.UpdatedAt, want)
+	}
+
+	closedOff, err := Build(context.Background(), testSource(), Options{ExcludeLabels: DefaultExcludeLabels})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(closedOff.Issues) != 1 || len(closedOff.Issues[0].Dependencies) != 0 {
+		t.Errorf("without closed issues got %+v", closedOff.Issues)
+	}
+}
+
+func TestSnapshotFiles(t *testing.T) {
+	snapshot, err := Build(context.Background(), testSource(), Options{Title: "Demo <status>", IncludeClosed: true, ExcludeLabels: DefaultExcludeLabels})
+	if err != nil {
+		t.Fatal(err)
+	}
+	files, err := snapshot.Files()
+	if err != nil {
+		t.Fatalf("Files failed: %v", err)
+	}
+
+	html := string(files[HTMLFile])
+	if !strings.Contains(html, "Roadmap &lt;item&gt;") || strings.Contains(html, "<item>") {
+		t.Errorf("title not escaped in HTML")
+	}
+	if strings.Contains(html, "Secret") || strings.Contains(html, "alice") || strings.Contains(html, "internal notes") {
+		t.Errorf("private data in HTML:\n%s", html)
+	}
+	if strings.Index(html, `id="open"`) > strings.Index(html, `id="closed"`) {
+		t.Errorf("open section should come before closed")
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(files[JSONFile], &decoded); err != nil {
+		t.Fatalf("issues.json is not JSON: %v", err)
+	}
+	if strings.Contains(string(files[JSONFile]), "assignee") || !strings.Contains(string(files[JSONFile]), "Roadmap <item>") {
+		t.Errorf("unexpected issues.json:\n%s", files[JSONFile])
+	}
+	if _, ok := files[NoJekyll]; !ok {
+		t.Errorf("missing %s", NoJekyll)
+	}
+
+	again, _ := snapshot.Files()
+	if string(again[HTMLFile]) != html {
+		t.Errorf("rendering is not deterministic")
+	}
+}
+
+func TestParseTarget(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Target
+	}{
+		{"gh-pages", Target{Kind: TargetBranch, Branch: "gh-pages"}},
+		{"branch:status/site", Target{Kind: TargetBranch, Branch: "status/site"}},
+		{"dir:/var/www/beads", Target{Kind: TargetDir, Path: "/var/www/beads"}},
+		{"s3://acme-status/beads/", Target{Kind: TargetS3, Bucket: "acme-status", Prefix: "beads"}},
+		{"gs://acme-status", Target{Kind: TargetGCS, Bucket: "acme-status"}},
+	}
+	for _, tt := range tests {
+		got, err := ParseTarget(tt.in)
+		if err != nil {
+			t.Errorf("ParseTarget(%q) failed: %v", tt.in, err)
+			continue
+		}
+		if *got != tt.want {
+			t.Errorf("ParseTarget(%q) = %+v, want %+v", tt.in, *got, tt.want)
+		}
+	}
+	for _, bad := range []string{"", "ftp://x", "branch:", "branch:-f", "branch:a b", "dir:", "s3:///prefix"} {
+		if _, err := ParseTarget(bad); err == nil {
+			t.Errorf("ParseTarget(%q) should fail", bad)
+		}
+	}
+}
+
+func TestCommitBranch(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	ctx := context.Background()
+	root := t.TempDir()
+	repo := root + "/work"
+	bare := root + "/remote.git"
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "main", repo},
+		{"init", "-q", "--bare", bare},
+		{"-C", repo, "remote", "add", "origin", bare},
+		{"-C", repo, "commit", "-q", "--allow-empty", "-m", "init"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Env = append(cmd.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@t", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@t")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	opts := BranchOptions{RepoDir: repo, Branch: "gh-pages", Remote: "origin", Message: "Publish"}
+	files := map[string][]byte{HTMLFile: []byte("<p>v1</p>"), JSONFile: []byte("{}")}
+	first, err := CommitBranch(ctx, opts, files)
+	if err != nil {
+		t.Fatalf("CommitBranch failed: %v", err)
+	}
+	if !first.Changed || !first.Pushed || first.Commit == "" {
+		t.Fatalf("first publish = %+v", first)
+	}
+	if head, _ := runGit(ctx, repo, nil, "symbolic-ref", "--short", "HEAD"); head != "main" {
+		t.Errorf("checkout moved to %q", head)
+	}
+	remoteHead, _ := runGit(ctx, bare, nil, "rev-parse", "gh-pages")
+	if remoteHead != first.Commit {
+		t.Errorf("remote gh-pages = %s, want %s", remoteHead, first.Commit)
+	}
+
+	same, err := CommitBranch(ctx, opts, files)
+	if err != nil || same.Changed || same.Pushed || same.Commit != first.Commit {
+		t.Errorf("unchanged publish = %+v, %v", same, err)
+	}
+
+	files[HTMLFile] = []byte("<p>v2</p>")
+	second, err := CommitBranch(ctx, opts, files)
+	if err != nil || !second.Changed || !second.Pushed {
+		t.Fatalf("second publish = %+v, %v", second, err)
+	}
+	if parent, _ := runGit(ctx, repo, nil, "rev-parse", second.Commit+"^"); parent != first.Commit {
+		t.Errorf("second commit's parent = %s, want %s", parent, first.Commit)
+	}
+
+	opts.Branch = "main"
+	if _, err := CommitBranch(ctx, opts, files); err == nil {
+		t.Errorf("publishing to the checked-out branch should fail")
+	}
+}
@@ -0,0 +1,129 @@
+package publish
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"sort"
+	"time"
+)
+
+// File names written for every snapshot
+const (
+	JSONFile = "issues.json"
+	HTMLFile = "index.html"
+	NoJekyll = ".nojekyll" // stops GitHub Pages from running Jekyll over the files
+)
+
+// statusOrder is the order status sections appear in on the page
+var statusOrder = []string{"in_progress", "blocked", "open", "resolved", "closed"}
+
+type section struct {
+	Status string
+	Issues []*Issue
+}
+
+var page = template.Must(template.New("index").Funcs(template.FuncMap{
+	"date": func(t time.Time) string { return t.UTC().Format("2006-01-02") },
+	"ts":   func(t time.Time) string { return t.UTC().Format("2006-01-02 15:04 UTC") },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { font: 14px/1.5 -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em auto; max-width: 60em; padding: 0 1em; color: #1f2328; }
+h1 { margin-bottom: 0; }
+.meta { color: #59636e; margin-top: .25em; }
+.counts span { display: inline-block; margin-right: 1em; }
+table { border-collapse: collapse; width: 100%; margin-bottom: 2em; }
+th, td { text-align: left; padding: .35em .5em; border-bottom: 1px solid #d1d9e0; vertical-align: top; }
+th { font-weight: 600; }
+td.id { white-space: nowrap; font-family: ui-monospace, monospace; }
+.label { display: inline-block; background: #eef1f4; border-radius: 1em; padding: 0 .6em; margin: 0 .2em .2em 0; font-size: 12px; }
+details pre { white-space: pre-wrap; font: inherit; margin: .5em 0; }
+.deps { color: #59636e; font-size: 12px; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p class="meta">Read-only snapshot{{if not .UpdatedAt.IsZero}}, last change {{ts .UpdatedAt}}{{end}} &middot; <a href="issues.json">issues.json</a></p>
+<p class="counts">{{range .Sections}}<span><a href="#{{.Status}}">{{.Status}}</a>: {{len .Issues}}</span>{{end}}</p>
+{{range .Sections}}
+<h2 id="{{.Status}}">{{.Status}} ({{len .Issues}})</h2>
+<table>
+<tr><th>ID</th><th>P</th><th>Type</th><th>Title</th><th>Updated</th></tr>
+{{range .Issues}}<tr id="{{.ID}}">
+<td class="id">{{.ID}}</td><td>P{{.Priority}}</td><td>{{.IssueType}}</td>
+<td>{{if .Description}}<details><summary>{{.Title}}</summary><pre>{{.Description}}</pre></details>{{else}}{{.Title}}{{end}}
+{{range .Labels}}<span class="label">{{.}}</span>{{end}}
+{{if .Dependencies}}<div class="deps">depends on {{range $i, $d := .Dependencies}}{{if $i}}, {{end}}<a href="#{{$d.ID}}">{{$d.ID}}</a>{{if ne $d.Type "blocks"}} ({{$d.Type}}){{end}}{{end}}</div>{{end}}</td>
+<td>{{date .UpdatedAt}}</td>
+</tr>
+{{end}}</table>
+{{else}}
+<p>No published issues.</p>
+{{end}}
+</body>
+</html>
+`))
+
+// Files renders the snapshot into the files to publish, keyed by name
+func (s *Snapshot) Files() (map[string][]byte, error) {
+	var data bytes.Buffer
+	enc := json.NewEncoder(&data)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(s); err != nil {
+		return nil, fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+
+	bySection := map[string][]*Issue{}
+	for _, issue := range s.Issues {
+		bySection[issue.Status] = append(bySection[issue.Status], issue)
+	}
+	var sections []section
+	for _, status := range statusOrder {
+		if issues := bySection[status]; len(issues) > 0 {
+			sections = append(sections, section{Status: status, Issues: sortForPage(issues)})
+			delete(bySection, status)
+		}
+	}
+	// Custom statuses follow the built-in ones
+	var rest []string
+	for status := range bySection {
+		rest = append(rest, status)
+	}
+	sort.Strings(rest)
+	for _, status := range rest {
+		sections = append(sections, section{Status: status, Issues: sortForPage(bySection[status])})
+	}
+
+	var html bytes.Buffer
+	err := page.Execute(&html, struct {
+		*Snapshot
+		Sections []section
+	}{s, sections})
+	if err != nil {
+		return nil, fmt.Errorf("failed to render snapshot: %w", err)
+	}
+
+	return map[string][]byte{
+		JSONFile: data.Bytes(),
+		HTMLFile: html.Bytes(),
+		NoJekyll: {},
+	}, nil
+}
+
+// sortForPage orders a section by priority, then most recently updated
+func sortForPage(issues []*Issue) []*Issue {
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Priority != issues[j].Priority {
+			return issues[i].Priority < issues[j].Priority
+		}
+		return issues[i].UpdatedAt.After(issues[j].UpdatedAt)
+	})
+	return issues
+}
@@ -0,0 +1,172 @@
+// Package publish builds read-only snapshots of the issue database for
+// people who don't run bd: a JSON file and a static HTML page that can be
+// served from a gh-pages branch or a storage bucket.
+//
+// A snapshot is filtered for an outside audience. Issues carrying an
+// excluded label (confidential and private by default), issues with
+// encrypted fields, messages, ephemeral issues and tombstones are left out,
+// and dependencies on issues that were left out are dropped so their IDs
+// don't leak. Only the title, description, status, priority, type, labels,
+// dependencies and timestamps are published; assignees, design, notes,
+// acceptance criteria and comments stay private. Text passes through the
+// configured redaction rules.
+package publish
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/steveyegge/beads/internal/encryption"
+	"github.com/steveyegge/beads/internal/redact"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// DefaultExcludeLabels mirrors the publish.exclude_labels default in
+// internal/config
+var DefaultExcludeLabels = []string{"confidential", "private"}
+
+// Source is the part of the storage interface a snapshot is built from
+type Source interface {
+	SearchIssues(ctx context.Context, query string, filter types.IssueFilter) ([]*types.Issue, error)
+	GetAllDependencyRecords(ctx context.Context) (map[string][]*types.Dependency, error)
+	GetLabelsForIssues(ctx context.Context, issueIDs []string) (map[string][]string, error)
+}
+
+// Options control what goes into a snapshot
+type Options struct {
+	// Title heads the HTML page
+	Title string
+	// ExcludeLabels keeps issues with any of these labels out
+	ExcludeLabels []string
+	// IncludeClosed publishes closed issues too
+	IncludeClosed bool
+	// Redactor scrubs published text; nil publishes it unchanged
+	Redactor *redact.Redactor
+}
+
+// Dependency is a published edge between two published issues
+type Dependency struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// Issue is the public view of an issue
+type Issue struct {
+	ID           string       `json:"id"`
+	Title        string       `json:"title"`
+	Description  string       `json:"description,omitempty"`
+	Status       string       `json:"status"`
+	Priority     int          `json:"priority"`
+	IssueType    string       `json:"issue_type"`
+	Labels       []string     `json:"labels,omitempty"`
+	Dependencies []Dependency `json:"dependencies,omitempty"`
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+	ClosedAt     *time.Time   `json:"closed_at,omitempty"`
+}
+
+// Snapshot is everything that gets published
+type Snapshot struct {
+	Title string `json:"title"`
+	// UpdatedAt is the latest change among the published issues rather than
+	// the build time, so an unchanged database produces identical files and
+	// scheduled publishing doesn't commit when nothing happened
+	UpdatedAt time.Time      `json:"updated_at"`
+	Counts    map[string]int `json:"counts"`
+	Issues    []*Issue       `json:"issues"`
+	// Withheld counts the issues that matched the status filter but were
+	// kept out; it is reported to the publisher, never published
+	Withheld int `json:"-"`
+}
+
+// Build collects the issues opts allow into a snapshot, sorted by ID
+func Build(ctx context.Context, source Source, opts Options) (*Snapshot, error) {
+	notEphemeral := false
+	all, err := source.SearchIssues(ctx, "", types.IssueFilter{Ephemeral: &notEphemeral})
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(all))
+	for i, issue := range all {
+		ids[i] = issue.ID
+	}
+	labels, err := source.GetLabelsForIssues(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	deps, err := source.GetAllDependencyRecords(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	excluded := make(map[string]bool, len(opts.ExcludeLabels))
+	for _, label := range opts.ExcludeLabels {
+		excluded[label] = true
+	}
+	snapshot := &Snapshot{Title: opts.Title, Counts: map[string]int{}}
+	published := make(map[string]*Issue)
+	for _, issue := range all {
+		if issue.Status == types.StatusTombstone || issue.IssueType == types.TypeMessage {
+			continue
+		}
+		if issue.Status == types.StatusClosed && !opts.IncludeClosed {
+			continue
+		}
+		if hasAny(labels[issue.ID], excluded) || encryption.HasEncrypted(issue) {
+			snapshot.Withheld++
+			continue
+		}
+		pub := &Issue{
+			ID:          issue.ID,
+			Title:       scrub(opts.Redactor, issue.Title),
+			Description: scrub(opts.Redactor, issue.Description),
+			Status:      string(issue.Status),
+			Priority:    issue.Priority,
+			IssueType:   string(issue.IssueType),
+			Labels:      labels[issue.ID],
+			CreatedAt:   issue.CreatedAt.UTC(),
+			UpdatedAt:   issue.UpdatedAt.UTC(),
+		}
+		if issue.ClosedAt != nil {
+			closed := issue.ClosedAt.UTC()
+			pub.ClosedAt = &closed
+		}
+		published[issue.ID] = pub
+		snapshot.Issues = append(snapshot.Issues, pub)
+		snapshot.Counts[pub.Status]++
+		if pub.UpdatedAt.After(snapshot.UpdatedAt) {
+			snapshot.UpdatedAt = pub.UpdatedAt
+		}
+	}
+
+	for _, pub := range snapshot.Issues {
+		for _, dep := range deps[pub.ID] {
+			if published[dep.DependsOnID] == nil {
+				continue
+			}
+			pub.Dependencies = append(pub.Dependencies, Dependency{ID: dep.DependsOnID, Type: string(dep.Type)})
+		}
+	}
+	sort.Slice(snapshot.Issues, func(i, j int) bool {
+		return snapshot.Issues[i].ID < snapshot.Issues[j].ID
+	})
+	if snapshot.Issues == nil {
+		snapshot.Issues = []*Issue{}
+	}
+	return snapshot, nil
+}
+
+func hasAny(labels []string, set map[string]bool) bool {
+	for _, label := range labels {
+		if set[label] {
+			return true
+		}
+	}
+	return false
+}
+
+func scrub(r *redact.Redactor, s string) string {
+	s, _ = r.Redact(s)
+	return s
+}
@@ -0,0 +1,237 @@
+package publish
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/attachments"
+)
+
+// Target kinds
+const (
+	TargetBranch = "branch" // a git branch, committed without touching the checkout
+	TargetDir    = "dir"    // a local directory, e.g. one a web server serves
+	TargetS3     = "s3"
+	TargetGCS    = "gs"
+)
+
+// Target is where snapshots are published
+type Target struct {
+	Kind   string
+	Branch string // branch
+	Path   string // dir
+	Bucket string // s3, gs
+	Prefix string // s3, gs: key prefix inside the bucket
+}
+
+// ParseTarget parses publish.target / --target: "gh-pages", "branch:<name>",
+// "dir:<path>", "s3://bucket/prefix" or "gs://bucket/prefix"
+func ParseTarget(s string) (*Target, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case s == "":
+		return nil, fmt.Errorf("no publish target")
+	case s == "gh-pages":
+		return &Target{Kind: TargetBranch, Branch: "gh-pages"}, nil
+	case strings.HasPrefix(s, "branch:"):
+		branch := strings.TrimPrefix(s, "branch:")
+		if branch == "" || strings.ContainsAny(branch, " ~^:?*[\\") || strings.HasPrefix(branch, "-") {
+			return nil, fmt.Errorf("invalid branch in publish target %q", s)
+		}
+		return &Target{Kind: TargetBranch, Branch: branch}, nil
+	case strings.HasPrefix(s, "dir:"):
+		dir := strings.TrimPrefix(s, "dir:")
+		if dir == "" {
+			return nil, fmt.Errorf("missing directory in publish target %q", s)
+		}
+		return &Target{Kind: TargetDir, Path: dir}, nil
+	case strings.HasPrefix(s, "s3://"), strings.HasPrefix(s, "gs://"):
+		u, err := url.Parse(s)
+		if err != nil || u.Host == "" {
+			return nil, fmt.Errorf("invalid bucket in publish target %q", s)
+		}
+		return &Target{Kind: u.Scheme, Bucket: u.Host, Prefix: strings.Trim(u.Path, "/")}, nil
+	}
+	return nil, fmt.Errorf("unknown publish target %q (use gh-pages, branch:<name>, dir:<path>, s3://bucket/prefix or gs://bucket/prefix)", s)
+}
+
+func (t *Target) String() string {
+	switch t.Kind {
+	case TargetBranch:
+		return "branch " + t.Branch
+	case TargetDir:
+		return t.Path
+	default:
+		return t.Kind + "://" + path.Join(t.Bucket, t.Prefix)
+	}
+}
+
+// Result describes one publish
+type Result struct {
+	Target  string   `json:"target"`
+	Files   []string `json:"files"`
+	Changed bool     `json:"changed"`
+	Commit  string   `json:"commit,omitempty"`
+	Pushed  bool     `json:"pushed,omitempty"`
+}
+
+// fileNames returns the names of files in a stable order
+func fileNames(files map[string][]byte) []string {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// WriteDir writes files into dir, leaving files whose content is unchanged alone
+func WriteDir(dir string, files map[string][]byte) (*Result, error) {
+	result := &Result{Target: dir, Files: fileNames(files)}
+	// #nosec G301 - published files are public by definition
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	for _, name := range result.Files {
+		dest := filepath.Join(dir, name)
+		// #nosec G304 - dest is inside the publish directory
+		if old, err := os.ReadFile(dest); err == nil && bytes.Equal(old, files[name]) {
+			continue
+		}
+		// #nosec G306 - published files are public by definition
+		if err := os.WriteFile(dest, files[name], 0644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", dest, err)
+		}
+		result.Changed = true
+	}
+	return result, nil
+}
+
+// Upload puts files into a bucket under prefix. Buckets have no cheap
+// compare, so every publish uploads.
+func Upload(ctx context.Context, provider attachments.Provider, prefix string, files map[string][]byte) (*Result, error) {
+	result := &Result{Files: fileNames(files), Changed: true}
+	for _, name := range result.Files {
+		if _, err := provider.Put(ctx, path.Join(prefix, name), files[name]); err != nil {
+			return nil, fmt.Errorf("failed to upload %s: %w", name, err)
+		}
+	}
+	return result, nil
+}
+
+// BranchOptions configure publishing to a git branch
+type BranchOptions struct {
+	RepoDir string // any directory inside the repository
+	Branch  string
+	// Remote is pushed to after committing; empty only commits locally
+	Remote  string
+	Message string
+}
+
+// CommitBranch commits files as the whole tree of a new commit on the
+// branch, leaving the working tree, index and current branch untouched, and
+// pushes the branch to the remote. The remote branch is fetched first so
+// snapshots published from other clones are built upon instead of rejected.
+// Nothing is committed when the tree is unchanged.
+func CommitBranch(ctx context.Context, opts BranchOptions, files map[string][]byte) (*Result, error) {
+	result := &Result{Target: "branch " + opts.Branch, Files: fileNames(files)}
+	ref := "refs/heads/" + opts.Branch
+	if current, err := runGit(ctx, opts.RepoDir, nil, "symbolic-ref", "-q", "HEAD"); err == nil && current == ref {
+		return nil, fmt.Errorf("refusing to publish to %s: it is the checked-out branch", opts.Branch)
+	}
+
+	local, _ := runGit(ctx, opts.RepoDir, nil, "rev-parse", "-q", "--verify", ref+"^{commit}")
+	remote := ""
+	if opts.Remote != "" {
+		tracking := "refs/remotes/" + opts.Remote + "/" + opts.Branch
+		if _, err := runGit(ctx, opts.RepoDir, nil, "fetch", "-q", opts.Remote, "+"+ref+":"+tracking); err == nil {
+			remote, _ = runGit(ctx, opts.RepoDir, nil, "rev-parse", "-q", "--verify", tracking+"^{commit}")
+		}
+	}
+	parent := local
+	if remote != "" && (local == "" || isAncestor(ctx, opts.RepoDir, local, remote)) {
+		parent = remote
+	}
+
+	var entries strings.Builder
+	for _, name := range result.Files {
+		sha, err := runGit(ctx, opts.RepoDir, files[name], "hash-object", "-w", "--stdin")
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&entries, "100644 blob %s\t%s\n", sha, name)
+	}
+	tree, err := runGit(ctx, opts.RepoDir, []byte(entries.String()), "mktree")
+	if err != nil {
+		return nil, err
+	}
+
+	result.Changed = parent == ""
+	if parent != "" {
+		parentTree, _ := runGit(ctx, opts.RepoDir, nil, "rev-parse", "-q", "--verify", parent+"^{tree}")
+		result.Changed = parentTree != tree
+	}
+	commit := parent
+	if result.Changed {
+		args := []string{"commit-tree", tree, "-m", opts.Message}
+		if parent != "" {
+			args = append(args, "-p", parent)
+		}
+		if commit, err = runGit(ctx, opts.RepoDir, nil, args...); err != nil {
+			return nil, err
+		}
+	}
+	if commit != local {
+		// The old value makes the update fail if another publish moved the
+		// branch in the meantime
+		if _, err := runGit(ctx, opts.RepoDir, nil, "update-ref", "-m", "bd publish", ref, commit, local); err != nil {
+			return nil, err
+		}
+	}
+	result.Commit = commit
+
+	if opts.Remote != "" && commit != remote {
+		if _, err := runGit(ctx, opts.RepoDir, nil, "push", "-q", opts.Remote, ref+":"+ref); err != nil {
+			return result, fmt.Errorf("committed %.12s to %s but push failed: %w", commit, opts.Branch, err)
+		}
+		result.Pushed = true
+	}
+	return result, nil
+}
+
+func isAncestor(ctx context.Context, dir, ancestor, commit string) bool {
+	_, err := runGit(ctx, dir, nil, "merge-base", "--is-ancestor", ancestor, commit)
+	return err == nil
+}
+
+// runGit runs git in dir and returns its trimmed stdout. Commits made for a
+// snapshot fall back to a bd identity when git has none configured.
+func runGit(ctx context.Context, dir string, stdin []byte, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...) // #nosec G204 - fixed git subcommands; refs are validated by ParseTarget
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+	if args[0] == "commit-tree" {
+		cmd.Env = os.Environ()
+		if _, err := exec.CommandContext(ctx, "git", "-C", dir, "var", "GIT_COMMITTER_IDENT").Output(); err != nil { // #nosec G204 - fixed arguments
+			cmd.Env = append(cmd.Env,
+				"GIT_AUTHOR_NAME=bd publish", "GIT_AUTHOR_EMAIL=bd-publish@localhost",
+				"GIT_COMMITTER_NAME=bd publish", "GIT_COMMITTER_EMAIL=bd-publish@localhost")
+		}
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s failed: %w\n%s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
@@ -0,0 +1,265 @@
+// Package redact strips secrets and other sensitive text from issues.
+//
+// A Redactor holds an ordered list of regex rules. Built-in rules cover common
+// credential formats and are selected by name with redaction.rules in
+// config.yaml; redaction.patterns adds project-specific regexes such as
+// internal hostnames. Redaction runs when issue text is written (create,
+// update, comment) and again on every JSONL export, so a secret that slipped
+// into the database before a rule existed still never reaches the git remote.
+// bd doctor --scan-secrets uses Scan to report what is already stored.
+package redact
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// DefaultReplacement is substituted for each match unless
+// redaction.replacement is set
+const DefaultReplacement = "[REDACTED]"
+
+// Rule is a named pattern whose matches are redacted
+type Rule struct {
+	Name    string
+	Pattern *regexp.Regexp
+}
+
+// builtinRules are the named rules selectable via redaction.rules
+var builtinRules = map[string]*regexp.Regexp{
+	"aws-access-key":  regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`),
+	"github-token":    regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})\b`),
+	"slack-token":     regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}`),
+	"private-key":     regexp.MustCompile(`-----BEGIN (?:[A-Z]+ )?PRIVATE KEY-----[\s\S]*?(?:-----END (?:[A-Z]+ )?PRIVATE KEY-----|\z)`),
+	"generic-api-key": regexp.MustCompile(`(?i)\b(?:api[_-]?key|secret|token|password|passwd)\b["']?\s*[:=]\s*["']?[A-Za-z0-9_\-./+=]{16,}`),
+	"email":           regexp.MustCompile(`\b[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}\b`),
+}
+
+// DefaultRules mirrors the redaction.rules default in internal/config. Emails
+// are opt-in because issue text legitimately mentions people.
+var DefaultRules = []string{"aws-access-key", "github-token", "slack-token", "private-key", "generic-api-key"}
+
+// BuiltinRuleNames returns the names accepted in redaction.rules
+func BuiltinRuleNames() []string {
+	names := make([]string, 0, len(builtinRules))
+	for name := range builtinRules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Redactor applies redaction rules to text
+type Redactor struct {
+	rules       []Rule
+	replacement string
+}
+
+// New builds a Redactor from built-in rule names and custom regexes. The
+// name "none" disables all built-in rules. Custom patterns are named
+// "pattern:<regex>" in findings.
+func New(ruleNames, patterns []string, replacement string) (*Redactor, error) {
+	if replacement == "" {
+		replacement = DefaultReplacement
+	}
+	r := &Redactor{replacement: replacement}
+	for _, name := range ruleNames {
+		name = strings.TrimSpace(name)
+		if name == "" || name == "none" {
+			continue
+		}
+		re, ok := builtinRules[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown redaction rule %q (available: %s)", name, strings.Join(BuiltinRuleNames(), ", "))
+		}
+		r.rules = append(r.rules, Rule{Name: name, Pattern: re})
+	}
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %w", pattern, err)
+		}
+		r.rules = append(r.rules, Rule{Name: "pattern:" + pattern, Pattern: re})
+	}
+	return r, nil
+}
+
+// FromConfig builds the Redactor configured by redaction.rules,
+// redaction.patterns and redaction.replacement
+func FromConfig() (*Redactor, error) {
+	return New(config.GetStringSlice("redaction.rules"), config.GetStringSlice("redaction.patterns"), config.GetString("redaction.replacement"))
+}
+
+// Enabled reports whether any rule is active
+func (r *Redactor) Enabled() bool {
+	return r != nil && len(r.rules) > 0
+}
+
+// Rules returns the active rules in the order they are applied
+func (r *Redactor) Rules() []Rule {
+	if r == nil {
+		return nil
+	}
+	return r.rules
+}
+
+// Redact replaces every match in s and returns the result together with the
+// names of the rules that matched
+func (r *Redactor) Redact(s string) (string, []string) {
+	if !r.Enabled() || s == "" {
+		return s, nil
+	}
+	var matched []string
+	for _, rule := range r.rules {
+		if !rule.Pattern.MatchString(s) {
+			continue
+		}
+		s = rule.Pattern.ReplaceAllLiteralString(s, r.replacement)
+		matched = append(matched, rule.Name)
+	}
+	return s, matched
+}
+
+// RedactTexts redacts each string in place and returns the names of the
+// rules that matched. Create and comment handlers run it on their input.
+func (r *Redactor) RedactTexts(texts ...*string) []string {
+	var matched []string
+	for _, text := range texts {
+		var rules []string
+		*text, rules = r.Redact(*text)
+		matched = append(matched, rules...)
+	}
+	return matched
+}
+
+// textFields lists the free-text issue fields subject to redaction, keyed by
+// their update/JSON name
+func textFields(issue *types.Issue) map[string]*string {
+	return map[string]*string{
+		"title":               &issue.Title,
+		"description":         &issue.Description,
+		"design":              &issue.Design,
+		"acceptance_criteria": &issue.AcceptanceCriteria,
+		"notes":               &issue.Notes,
+	}
+}
+
+// RedactIssue redacts the issue's text fields and comments in place and
+// returns the names of the fields that changed (comments as "comment:<id>")
+func (r *Redactor) RedactIssue(issue *types.Issue) []string {
+	if !r.Enabled() || issue == nil {
+		return nil
+	}
+	var changed []string
+	for field, ptr := range textFields(issue) {
+		if out, matched := r.Redact(*ptr); len(matched) > 0 {
+			*ptr = out
+			changed = append(changed, field)
+		}
+	}
+	sort.Strings(changed)
+	for _, c := range issue.Comments {
+		if out, matched := r.Redact(c.Text); len(matched) > 0 {
+			c.Text = out
+			changed = append(changed, fmt.Sprintf("comment:%d", c.ID))
+		}
+	}
+	return changed
+}
+
+// RedactUpdates redacts string values of text fields in an UpdateIssue map
+// and returns the names of the rules that matched
+func (r *Redactor) RedactUpdates(updates map[string]interface{}) []string {
+	if !r.Enabled() {
+		return nil
+	}
+	var probe types.Issue
+	fields := textFields(&probe)
+	var matched []string
+	for key, value := range updates {
+		s, ok := value.(string)
+		if _, text := fields[key]; !ok || !text {
+			continue
+		}
+		out, rules := r.Redact(s)
+		updates[key] = out
+		matched = append(matched, rules...)
+	}
+	return matched
+}
+
+// ForExport returns a copy of issue with text redacted, or issue itself when
+// nothing matched. The caller's issue and comments are never mutated.
+func (r *Redactor) ForExport(issue *types.Issue) *types.Issue {
+	if !r.Enabled() || len(r.Scan(issue)) == 0 {
+		return issue
+	}
+	clone := *issue
+	clone.Comments = make([]*types.Comment, len(issue.Comments))
+	for i, c := range issue.Comments {
+		cc := *c
+		clone.Comments[i] = &cc
+	}
+	r.RedactIssue(&clone)
+	return &clone
+}
+
+// Finding is one match of a rule in stored issue text
+type Finding struct {
+	IssueID string `json:"issue_id"`
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Excerpt string `json:"excerpt"`
+}
+
+// Scan reports every rule match in the issue's text fields and comments
+// without modifying it. Excerpts are masked so the report itself is safe to
+// paste.
+func (r *Redactor) Scan(issue *types.Issue) []Finding {
+	if !r.Enabled() || issue == nil {
+		return nil
+	}
+	var findings []Finding
+	scan := func(field, text string) {
+		for _, rule := range r.rules {
+			for _, m := range rule.Pattern.FindAllString(text, -1) {
+				findings = append(findings, Finding{IssueID: issue.ID, Field: field, Rule: rule.Name, Excerpt: Mask(m)})
+			}
+		}
+	}
+	fields := textFields(issue)
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		scan(name, *fields[name])
+	}
+	for _, c := range issue.Comments {
+		scan(fmt.Sprintf("comment:%d", c.ID), c.Text)
+	}
+	return findings
+}
+
+// Mask keeps the first four characters of a match and hides the rest
+func Mask(s string) string {
+	s = strings.SplitN(s, "\n", 2)[0]
+	runes := []rune(s)
+	if len(runes) <= 4 {
+		return strings.Repeat("*", len(runes))
+	}
+	hidden := len(runes) - 4
+	if hidden > 12 {
+		hidden = 12
+	}
+	return string(runes[:4]) + strings.Repeat("*", hidden)
+}
@@ -0,0 +1,1389 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: beads.proto
+
+package beadspb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Issue struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Id                 string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title              string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description        string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Design             string                 `protobuf:"bytes,4,opt,name=design,proto3" json:"design,omitempty"`
+	AcceptanceCriteria string                 `protobuf:"bytes,5,opt,name=acceptance_criteria,json=acceptanceCriteria,proto3" json:"acceptance_criteria,omitempty"`
+	Notes              string                 `protobuf:"bytes,6,opt,name=notes,proto3" json:"notes,omitempty"`
+	Status             string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	Priority           int32                  `protobuf:"varint,8,opt,name=priority,proto3" json:"priority,omitempty"`
+	IssueType          string                 `protobuf:"bytes,9,opt,name=issue_type,json=issueType,proto3" json:"issue_type,omitempty"`
+	Assignee           string                 `protobuf:"bytes,10,opt,name=assignee,proto3" json:"assignee,omitempty"`
+	EstimatedMinutes   *int32                 `protobuf:"varint,11,opt,name=estimated_minutes,json=estimatedMinutes,proto3,oneof" json:"estimated_minutes,omitempty"`
+	Complexity         string                 `protobuf:"bytes,12,opt,name=complexity,proto3" json:"complexity,omitempty"`
+	CreatedAt          *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt          *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	ClosedAt           *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=closed_at,json=closedAt,proto3" json:"closed_at,omitempty"`
+	CloseReason        string                 `protobuf:"bytes,16,opt,name=close_reason,json=closeReason,proto3" json:"close_reason,omitempty"`
+	ExternalRef        string                 `protobuf:"bytes,17,opt,name=external_ref,json=externalRef,proto3" json:"external_ref,omitempty"`
+	Labels             []string               `protobuf:"bytes,18,rep,name=labels,proto3" json:"labels,omitempty"`
+	// Set by ListIssues only
+	DependencyCount int32 `protobuf:"varint,19,opt,name=dependency_count,json=dependencyCount,proto3" json:"dependency_count,omitempty"`
+	DependentCount  int32 `protobuf:"varint,20,opt,name=dependent_count,json=dependentCount,proto3" json:"dependent_count,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Issue) Reset() {
+	*x = Issue{}
+	mi := &file_beads_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Issue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Issue) ProtoMessage() {}
+
+func (x *Issue) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Issue.ProtoReflect.Descriptor instead.
+func (*Issue) Descriptor() ([]byte, []int) {
+	return file_beads_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Issue) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Issue) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Issue) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Issue) GetDesign() string {
+	if x != nil {
+		return x.Design
+	}
+	return ""
+}
+
+func (x *Issue) GetAcceptanceCriteria() string {
+	if x != nil {
+		return x.AcceptanceCriteria
+	}
+	return ""
+}
+
+func (x *Issue) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *Issue) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Issue) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *Issue) GetIssueType() string {
+	if x != nil {
+		return x.IssueType
+	}
+	return ""
+}
+
+func (x *Issue) GetAssignee() string {
+	if x != nil {
+		return x.Assignee
+	}
+	return ""
+}
+
+func (x *Issue) GetEstimatedMinutes() int32 {
+	if x != nil && x.EstimatedMinutes != nil {
+		return *x.EstimatedMinutes
+	}
+	return 0
+}
+
+func (x *Issue) GetComplexity() string {
+	if x != nil {
+		return x.Complexity
+	}
+	return ""
+}
+
+func (x *Issue) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Issue) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Issue) GetClosedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ClosedAt
+	}
+	return nil
+}
+
+func (x *Issue) GetCloseReason() string {
+	if x != nil {
+		return x.CloseReason
+	}
+	return ""
+}
+
+func (x *Issue) GetExternalRef() string {
+	if x != nil {
+		return x.ExternalRef
+	}
+	return ""
+}
+
+func (x *Issue) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Issue) GetDependencyCount() int32 {
+	if x != nil {
+		return x.DependencyCount
+	}
+	return 0
+}
+
+func (x *Issue) GetDependentCount() int32 {
+	if x != nil {
+		return x.DependentCount
+	}
+	return 0
+}
+
+type CreateIssueRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Id                 string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`         // Generated when empty
+	Parent             string                 `protobuf:"bytes,2,opt,name=parent,proto3" json:"parent,omitempty"` // Creates a hierarchical child (bd-a3f8.1) of this issue
+	Title              string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Description        string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	IssueType          string                 `protobuf:"bytes,5,opt,name=issue_type,json=issueType,proto3" json:"issue_type,omitempty"` // Default: task
+	Priority           *int32                 `protobuf:"varint,6,opt,name=priority,proto3,oneof" json:"priority,omitempty"`             // Default: 2
+	Design             string                 `protobuf:"bytes,7,opt,name=design,proto3" json:"design,omitempty"`
+	AcceptanceCriteria string                 `protobuf:"bytes,8,opt,name=acceptance_criteria,json=acceptanceCriteria,proto3" json:"acceptance_criteria,omitempty"`
+	Assignee           string                 `protobuf:"bytes,9,opt,name=assignee,proto3" json:"assignee,omitempty"`
+	ExternalRef        string                 `protobuf:"bytes,10,opt,name=external_ref,json=externalRef,proto3" json:"external_ref,omitempty"`
+	EstimatedMinutes   *int32                 `protobuf:"varint,11,opt,name=estimated_minutes,json=estimatedMinutes,proto3,oneof" json:"estimated_minutes,omitempty"`
+	Complexity         string                 `protobuf:"bytes,12,opt,name=complexity,proto3" json:"complexity,omitempty"`
+	Labels             []string               `protobuf:"bytes,13,rep,name=labels,proto3" json:"labels,omitempty"`
+	Dependencies       []string               `protobuf:"bytes,14,rep,name=dependencies,proto3" json:"dependencies,omitempty"` // "id" or "type:id", as in bd create --deps
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *CreateIssueRequest) Reset() {
+	*x = CreateIssueRequest{}
+	mi := &file_beads_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateIssueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateIssueRequest) ProtoMessage() {}
+
+func (x *CreateIssueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateIssueRequest.ProtoReflect.Descriptor instead.
+func (*CreateIssueRequest) Descriptor() ([]byte, []int) {
+	return file_beads_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateIssueRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CreateIssueRequest) GetParent() string {
+	if x != nil {
+		return x.Parent
+	}
+	return ""
+}
+
+func (x *CreateIssueRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateIssueRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateIssueRequest) GetIssueType() string {
+	if x != nil {
+		return x.IssueType
+	}
+	return ""
+}
+
+func (x *CreateIssueRequest) GetPriority() int32 {
+	if x != nil && x.Priority != nil {
+		return *x.Priority
+	}
+	return 0
+}
+
+func (x *CreateIssueRequest) GetDesign() string {
+	if x != nil {
+		return x.Design
+	}
+	return ""
+}
+
+func (x *CreateIssueRequest) GetAcceptanceCriteria() string {
+	if x != nil {
+		return x.AcceptanceCriteria
+	}
+	return ""
+}
+
+func (x *CreateIssueRequest) GetAssignee() string {
+	if x != nil {
+		return x.Assignee
+	}
+	return ""
+}
+
+func (x *CreateIssueRequest) GetExternalRef() string {
+	if x != nil {
+		return x.ExternalRef
+	}
+	return ""
+}
+
+func (x *CreateIssueRequest) GetEstimatedMinutes() int32 {
+	if x != nil && x.EstimatedMinutes != nil {
+		return *x.EstimatedMinutes
+	}
+	return 0
+}
+
+func (x *CreateIssueRequest) GetComplexity() string {
+	if x != nil {
+		return x.Complexity
+	}
+	return ""
+}
+
+func (x *CreateIssueRequest) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *CreateIssueRequest) GetDependencies() []string {
+	if x != nil {
+		return x.Dependencies
+	}
+	return nil
+}
+
+// UpdateIssueRequest changes the fields that are set
+type UpdateIssueRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Id                 string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title              *string                `protobuf:"bytes,2,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	Description        *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Status             *string                `protobuf:"bytes,4,opt,name=status,proto3,oneof" json:"status,omitempty"`
+	Priority           *int32                 `protobuf:"varint,5,opt,name=priority,proto3,oneof" json:"priority,omitempty"`
+	Design             *string                `protobuf:"bytes,6,opt,name=design,proto3,oneof" json:"design,omitempty"`
+	AcceptanceCriteria *string                `protobuf:"bytes,7,opt,name=acceptance_criteria,json=acceptanceCriteria,proto3,oneof" json:"acceptance_criteria,omitempty"`
+	Notes              *string                `protobuf:"bytes,8,opt,name=notes,proto3,oneof" json:"notes,omitempty"`
+	Assignee           *string                `protobuf:"bytes,9,opt,name=assignee,proto3,oneof" json:"assignee,omitempty"`
+	ExternalRef        *string                `protobuf:"bytes,10,opt,name=external_ref,json=externalRef,proto3,oneof" json:"external_ref,omitempty"`
+	EstimatedMinutes   *int32                 `protobuf:"varint,11,opt,name=estimated_minutes,json=estimatedMinutes,proto3,oneof" json:"estimated_minutes,omitempty"`
+	IssueType          *string                `protobuf:"bytes,12,opt,name=issue_type,json=issueType,proto3,oneof" json:"issue_type,omitempty"`
+	Complexity         *string                `protobuf:"bytes,13,opt,name=complexity,proto3,oneof" json:"complexity,omitempty"`
+	AddLabels          []string               `protobuf:"bytes,14,rep,name=add_labels,json=addLabels,proto3" json:"add_labels,omitempty"`
+	RemoveLabels       []string               `protobuf:"bytes,15,rep,name=remove_labels,json=removeLabels,proto3" json:"remove_labels,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *UpdateIssueRequest) Reset() {
+	*x = UpdateIssueRequest{}
+	mi := &file_beads_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateIssueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateIssueRequest) ProtoMessage() {}
+
+func (x *UpdateIssueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateIssueRequest.ProtoReflect.Descriptor instead.
+func (*UpdateIssueRequest) Descriptor() ([]byte, []int) {
+	return file_beads_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *UpdateIssueRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateIssueRequest) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
+	}
+	return ""
+}
+
+func (x *UpdateIssueRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *UpdateIssueRequest) GetStatus() string {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return ""
+}
+
+func (x *UpdateIssueRequest) GetPriority() int32 {
+	if x != nil && x.Priority != nil {
+		return *x.Priority
+	}
+	return 0
+}
+
+func (x *UpdateIssueRequest) GetDesign() string {
+	if x != nil && x.Design != nil {
+		return *x.Design
+	}
+	return ""
+}
+
+func (x *UpdateIssueRequest) GetAcceptanceCriteria() string {
+	if x != nil && x.AcceptanceCriteria != nil {
+		return *x.AcceptanceCriteria
+	}
+	return ""
+}
+
+func (x *UpdateIssueRequest) GetNotes() string {
+	if x != nil && x.Notes != nil {
+		return *x.Notes
+	}
+	return ""
+}
+
+func (x *UpdateIssueRequest) GetAssignee() string {
+	if x != nil && x.Assignee != nil {
+		return *x.Assignee
+	}
+	return ""
+}
+
+func (x *UpdateIssueRequest) GetExternalRef() string {
+	if x != nil && x.ExternalRef != nil {
+		return *x.ExternalRef
+	}
+	return ""
+}
+
+func (x *UpdateIssueRequest) GetEstimatedMinutes() int32 {
+	if x != nil && x.EstimatedMinutes != nil {
+		return *x.EstimatedMinutes
+	}
+	return 0
+}
+
+func (x *UpdateIssueRequest) GetIssueType() string {
+	if x != nil && x.IssueType != nil {
+		return *x.IssueType
+	}
+	return ""
+}
+
+func (x *UpdateIssueRequest) GetComplexity() string {
+	if x != nil && x.Complexity != nil {
+		return *x.Complexity
+	}
+	return ""
+}
+
+func (x *UpdateIssueRequest) GetAddLabels() []string {
+	if x != nil {
+		return x.AddLabels
+	}
+	return nil
+}
+
+func (x *UpdateIssueRequest) GetRemoveLabels() []string {
+	if x != nil {
+		return x.RemoveLabels
+	}
+	return nil
+}
+
+type ListIssuesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Priority      *int32                 `protobuf:"varint,3,opt,name=priority,proto3,oneof" json:"priority,omitempty"`
+	IssueType     string                 `protobuf:"bytes,4,opt,name=issue_type,json=issueType,proto3" json:"issue_type,omitempty"`
+	Assignee      string                 `protobuf:"bytes,5,opt,name=assignee,proto3" json:"assignee,omitempty"`
+	Labels        []string               `protobuf:"bytes,6,rep,name=labels,proto3" json:"labels,omitempty"`                        // All of these
+	LabelsAny     []string               `protobuf:"bytes,7,rep,name=labels_any,json=labelsAny,proto3" json:"labels_any,omitempty"` // Any of these
+	Ids           []string               `protobuf:"bytes,8,rep,name=ids,proto3" json:"ids,omitempty"`
+	Limit         int32                  `protobuf:"varint,9,opt,name=limit,proto3" json:"limit,omitempty"`
+	Sort          string                 `protobuf:"bytes,10,opt,name=sort,proto3" json:"sort,omitempty"` // e.g. "priority,-updated_at"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListIssuesRequest) Reset() {
+	*x = ListIssuesRequest{}
+	mi := &file_beads_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListIssuesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListIssuesRequest) ProtoMessage() {}
+
+func (x *ListIssuesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListIssuesRequest.ProtoReflect.Descriptor instead.
+func (*ListIssuesRequest) Descriptor() ([]byte, []int) {
+	return file_beads_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListIssuesRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *ListIssuesRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListIssuesRequest) GetPriority() int32 {
+	if x != nil && x.Priority != nil {
+		return *x.Priority
+	}
+	return 0
+}
+
+func (x *ListIssuesRequest) GetIssueType() string {
+	if x != nil {
+		return x.IssueType
+	}
+	return ""
+}
+
+func (x *ListIssuesRequest) GetAssignee() string {
+	if x != nil {
+		return x.Assignee
+	}
+	return ""
+}
+
+func (x *ListIssuesRequest) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *ListIssuesRequest) GetLabelsAny() []string {
+	if x != nil {
+		return x.LabelsAny
+	}
+	return nil
+}
+
+func (x *ListIssuesRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *ListIssuesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListIssuesRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+type ListIssuesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Issues        []*Issue               `protobuf:"bytes,1,rep,name=issues,proto3" json:"issues,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListIssuesResponse) Reset() {
+	*x = ListIssuesResponse{}
+	mi := &file_beads_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListIssuesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListIssuesResponse) ProtoMessage() {}
+
+func (x *ListIssuesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListIssuesResponse.ProtoReflect.Descriptor instead.
+func (*ListIssuesResponse) Descriptor() ([]byte, []int) {
+	return file_beads_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListIssuesResponse) GetIssues() []*Issue {
+	if x != nil {
+		return x.Issues
+	}
+	return nil
+}
+
+type ReadyWorkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Assignee      string                 `protobuf:"bytes,1,opt,name=assignee,proto3" json:"assignee,omitempty"`
+	Unassigned    bool                   `protobuf:"varint,2,opt,name=unassigned,proto3" json:"unassigned,omitempty"`
+	Priority      *int32                 `protobuf:"varint,3,opt,name=priority,proto3,oneof" json:"priority,omitempty"`
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	SortPolicy    string                 `protobuf:"bytes,5,opt,name=sort_policy,json=sortPolicy,proto3" json:"sort_policy,omitempty"` // hybrid, priority or oldest
+	Labels        []string               `protobuf:"bytes,6,rep,name=labels,proto3" json:"labels,omitempty"`
+	LabelsAny     []string               `protobuf:"bytes,7,rep,name=labels_any,json=labelsAny,proto3" json:"labels_any,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReadyWorkRequest) Reset() {
+	*x = ReadyWorkRequest{}
+	mi := &file_beads_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadyWorkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadyWorkRequest) ProtoMessage() {}
+
+func (x *ReadyWorkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadyWorkRequest.ProtoReflect.Descriptor instead.
+func (*ReadyWorkRequest) Descriptor() ([]byte, []int) {
+	return file_beads_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ReadyWorkRequest) GetAssignee() string {
+	if x != nil {
+		return x.Assignee
+	}
+	return ""
+}
+
+func (x *ReadyWorkRequest) GetUnassigned() bool {
+	if x != nil {
+		return x.Unassigned
+	}
+	return false
+}
+
+func (x *ReadyWorkRequest) GetPriority() int32 {
+	if x != nil && x.Priority != nil {
+		return *x.Priority
+	}
+	return 0
+}
+
+func (x *ReadyWorkRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ReadyWorkRequest) GetSortPolicy() string {
+	if x != nil {
+		return x.SortPolicy
+	}
+	return ""
+}
+
+func (x *ReadyWorkRequest) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *ReadyWorkRequest) GetLabelsAny() []string {
+	if x != nil {
+		return x.LabelsAny
+	}
+	return nil
+}
+
+type DependencyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IssueId       string                 `protobuf:"bytes,1,opt,name=issue_id,json=issueId,proto3" json:"issue_id,omitempty"`
+	DependsOnId   string                 `protobuf:"bytes,2,opt,name=depends_on_id,json=dependsOnId,proto3" json:"depends_on_id,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"` // Default: blocks
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DependencyRequest) Reset() {
+	*x = DependencyRequest{}
+	mi := &file_beads_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DependencyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DependencyRequest) ProtoMessage() {}
+
+func (x *DependencyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DependencyRequest.ProtoReflect.Descriptor instead.
+func (*DependencyRequest) Descriptor() ([]byte, []int) {
+	return file_beads_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DependencyRequest) GetIssueId() string {
+	if x != nil {
+		return x.IssueId
+	}
+	return ""
+}
+
+func (x *DependencyRequest) GetDependsOnId() string {
+	if x != nil {
+		return x.DependsOnId
+	}
+	return ""
+}
+
+func (x *DependencyRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+type ExecuteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Operation     string                 `protobuf:"bytes,1,opt,name=operation,proto3" json:"operation,omitempty"`
+	Args          []byte                 `protobuf:"bytes,2,opt,name=args,proto3" json:"args,omitempty"` // JSON
+	ClientVersion string                 `protobuf:"bytes,3,opt,name=client_version,json=clientVersion,proto3" json:"client_version,omitempty"`
+	// Groups the requests of one bd command for bd undo
+	OperationKey  string `protobuf:"bytes,4,opt,name=operation_key,json=operationKey,proto3" json:"operation_key,omitempty"`
+	Command       string `protobuf:"bytes,5,opt,name=command,proto3" json:"command,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecuteRequest) Reset() {
+	*x = ExecuteRequest{}
+	mi := &file_beads_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecuteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecuteRequest) ProtoMessage() {}
+
+func (x *ExecuteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecuteRequest.ProtoReflect.Descriptor instead.
+func (*ExecuteRequest) Descriptor() ([]byte, []int) {
+	return file_beads_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ExecuteRequest) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *ExecuteRequest) GetArgs() []byte {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+func (x *ExecuteRequest) GetClientVersion() string {
+	if x != nil {
+		return x.ClientVersion
+	}
+	return ""
+}
+
+func (x *ExecuteRequest) GetOperationKey() string {
+	if x != nil {
+		return x.OperationKey
+	}
+	return ""
+}
+
+func (x *ExecuteRequest) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+type ExecuteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"` // JSON
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecuteResponse) Reset() {
+	*x = ExecuteResponse{}
+	mi := &file_beads_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecuteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecuteResponse) ProtoMessage() {}
+
+func (x *ExecuteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecuteResponse.ProtoReflect.Descriptor instead.
+func (*ExecuteResponse) Descriptor() ([]byte, []int) {
+	return file_beads_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ExecuteResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ExecuteResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ExecuteResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type SubscribeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Since         *int64                 `protobuf:"varint,1,opt,name=since,proto3,oneof" json:"since,omitempty"` // Resume after this event ID; unset for new events only
+	Types         []string               `protobuf:"bytes,2,rep,name=types,proto3" json:"types,omitempty"`
+	IssueIds      []string               `protobuf:"bytes,3,rep,name=issue_ids,json=issueIds,proto3" json:"issue_ids,omitempty"`
+	Actors        []string               `protobuf:"bytes,4,rep,name=actors,proto3" json:"actors,omitempty"`
+	Labels        []string               `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty"`
+	Query         string                 `protobuf:"bytes,6,opt,name=query,proto3" json:"query,omitempty"` // Saved filter expression the issue must match
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeRequest) Reset() {
+	*x = SubscribeRequest{}
+	mi := &file_beads_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeRequest) ProtoMessage() {}
+
+func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return file_beads_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SubscribeRequest) GetSince() int64 {
+	if x != nil && x.Since != nil {
+		return *x.Since
+	}
+	return 0
+}
+
+func (x *SubscribeRequest) GetTypes() []string {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *SubscribeRequest) GetIssueIds() []string {
+	if x != nil {
+		return x.IssueIds
+	}
+	return nil
+}
+
+func (x *SubscribeRequest) GetActors() []string {
+	if x != nil {
+		return x.Actors
+	}
+	return nil
+}
+
+func (x *SubscribeRequest) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *SubscribeRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+type Event struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	IssueId       string                 `protobuf:"bytes,2,opt,name=issue_id,json=issueId,proto3" json:"issue_id,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Actor         string                 `protobuf:"bytes,4,opt,name=actor,proto3" json:"actor,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Json          []byte                 `protobuf:"bytes,6,opt,name=json,proto3" json:"json,omitempty"` // The event with its issue, as bd watch --stream prints it
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_beads_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_beads_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Event) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Event) GetIssueId() string {
+	if x != nil {
+		return x.IssueId
+	}
+	return ""
+}
+
+func (x *Event) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Event) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *Event) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Event) GetJson() []byte {
+	if x != nil {
+		return x.Json
+	}
+	return nil
+}
+
+var File_beads_proto protoreflect.FileDescriptor
+
+const file_beads_proto_rawDesc = "" +
+	"\n" +
+	"\vbeads.proto\x12\bbeads.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xe6\x05\n" +
+	"\x05Issue\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06design\x18\x04 \x01(\tR\x06design\x12/\n" +
+	"\x13acceptance_criteria\x18\x05 \x01(\tR\x12acceptanceCriteria\x12\x14\n" +
+	"\x05notes\x18\x06 \x01(\tR\x05notes\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x12\x1a\n" +
+	"\bpriority\x18\b \x01(\x05R\bpriority\x12\x1d\n" +
+	"\n" +
+	"issue_type\x18\t \x01(\tR\tissueType\x12\x1a\n" +
+	"\bassignee\x18\n" +
+	" \x01(\tR\bassignee\x120\n" +
+	"\x11estimated_minutes\x18\v \x01(\x05H\x00R\x10estimatedMinutes\x88\x01\x01\x12\x1e\n" +
+	"\n" +
+	"complexity\x18\f \x01(\tR\n" +
+	"complexity\x129\n" +
+	"\n" +
+	"created_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x127\n" +
+	"\tclosed_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\bclosedAt\x12!\n" +
+	"\fclose_reason\x18\x10 \x01(\tR\vcloseReason\x12!\n" +
+	"\fexternal_ref\x18\x11 \x01(\tR\vexternalRef\x12\x16\n" +
+	"\x06labels\x18\x12 \x03(\tR\x06labels\x12)\n" +
+	"\x10dependency_count\x18\x13 \x01(\x05R\x0fdependencyCount\x12'\n" +
+	"\x0fdependent_count\x18\x14 \x01(\x05R\x0edependentCountB\x14\n" +
+	"\x12_estimated_minutes\"\xed\x03\n" +
+	"\x12CreateIssueRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06parent\x18\x02 \x01(\tR\x06parent\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x1d\n" +
+	"\n" +
+	"issue_type\x18\x05 \x01(\tR\tissueType\x12\x1f\n" +
+	"\bpriority\x18\x06 \x01(\x05H\x00R\bpriority\x88\x01\x01\x12\x16\n" +
+	"\x06design\x18\a \x01(\tR\x06design\x12/\n" +
+	"\x13acceptance_criteria\x18\b \x01(\tR\x12acceptanceCriteria\x12\x1a\n" +
+	"\bassignee\x18\t \x01(\tR\bassignee\x12!\n" +
+	"\fexternal_ref\x18\n" +
+	" \x01(\tR\vexternalRef\x120\n" +
+	"\x11estimated_minutes\x18\v \x01(\x05H\x01R\x10estimatedMinutes\x88\x01\x01\x12\x1e\n" +
+	"\n" +
+	"complexity\x18\f \x01(\tR\n" +
+	"complexity\x12\x16\n" +
+	"\x06labels\x18\r \x03(\tR\x06labels\x12\"\n" +
+	"\fdependencies\x18\x0e \x03(\tR\fdependenciesB\v\n" +
+	"\t_priorityB\x14\n" +
+	"\x12_estimated_minutes\"\xcb\x05\n" +
+	"\x12UpdateIssueRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\x05title\x18\x02 \x01(\tH\x00R\x05title\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x01R\vdescription\x88\x01\x01\x12\x1b\n" +
+	"\x06status\x18\x04 \x01(\tH\x02R\x06status\x88\x01\x01\x12\x1f\n" +
+	"\bpriority\x18\x05 \x01(\x05H\x03R\bpriority\x88\x01\x01\x12\x1b\n" +
+	"\x06design\x18\x06 \x01(\tH\x04R\x06design\x88\x01\x01\x124\n" +
+	"\x13acceptance_criteria\x18\a \x01(\tH\x05R\x12acceptanceCriteria\x88\x01\x01\x12\x19\n" +
+	"\x05notes\x18\b \x01(\tH\x06R\x05notes\x88\x01\x01\x12\x1f\n" +
+	"\bassignee\x18\t \x01(\tH\aR\bassignee\x88\x01\x01\x12&\n" +
+	"\fexternal_ref\x18\n" +
+	" \x01(\tH\bR\vexternalRef\x88\x01\x01\x120\n" +
+	"\x11estimated_minutes\x18\v \x01(\x05H\tR\x10estimatedMinutes\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"issue_type\x18\f \x01(\tH\n" +
+	"R\tissueType\x88\x01\x01\x12#\n" +
+	"\n" +
+	"complexity\x18\r \x01(\tH\vR\n" +
+	"complexity\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"add_labels\x18\x0e \x03(\tR\taddLabels\x12#\n" +
+	"\rremove_labels\x18\x0f \x03(\tR\fremoveLabelsB\b\n" +
+	"\x06_titleB\x0e\n" +
+	"\f_descriptionB\t\n" +
+	"\a_statusB\v\n" +
+	"\t_priorityB\t\n" +
+	"\a_designB\x16\n" +
+	"\x14_acceptance_criteriaB\b\n" +
+	"\x06_notesB\v\n" +
+	"\t_assigneeB\x0f\n" +
+	"\r_external_refB\x14\n" +
+	"\x12_estimated_minutesB\r\n" +
+	"\v_issue_typeB\r\n" +
+	"\v_complexity\"\x9d\x02\n" +
+	"\x11ListIssuesRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1f\n" +
+	"\bpriority\x18\x03 \x01(\x05H\x00R\bpriority\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"issue_type\x18\x04 \x01(\tR\tissueType\x12\x1a\n" +
+	"\bassignee\x18\x05 \x01(\tR\bassignee\x12\x16\n" +
+	"\x06labels\x18\x06 \x03(\tR\x06labels\x12\x1d\n" +
+	"\n" +
+	"labels_any\x18\a \x03(\tR\tlabelsAny\x12\x10\n" +
+	"\x03ids\x18\b \x03(\tR\x03ids\x12\x14\n" +
+	"\x05limit\x18\t \x01(\x05R\x05limit\x12\x12\n" +
+	"\x04sort\x18\n" +
+	" \x01(\tR\x04sortB\v\n" +
+	"\t_priority\"=\n" +
+	"\x12ListIssuesResponse\x12'\n" +
+	"\x06issues\x18\x01 \x03(\v2\x0f.beads.v1.IssueR\x06issues\"\xea\x01\n" +
+	"\x10ReadyWorkRequest\x12\x1a\n" +
+	"\bassignee\x18\x01 \x01(\tR\bassignee\x12\x1e\n" +
+	"\n" +
+	"unassigned\x18\x02 \x01(\bR\n" +
+	"unassigned\x12\x1f\n" +
+	"\bpriority\x18\x03 \x01(\x05H\x00R\bpriority\x88\x01\x01\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x1f\n" +
+	"\vsort_policy\x18\x05 \x01(\tR\n" +
+	"sortPolicy\x12\x16\n" +
+	"\x06labels\x18\x06 \x03(\tR\x06labels\x12\x1d\n" +
+	"\n" +
+	"labels_any\x18\a \x03(\tR\tlabelsAnyB\v\n" +
+	"\t_priority\"f\n" +
+	"\x11DependencyRequest\x12\x19\n" +
+	"\bissue_id\x18\x01 \x01(\tR\aissueId\x12\"\n" +
+	"\rdepends_on_id\x18\x02 \x01(\tR\vdependsOnId\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\"\xa8\x01\n" +
+	"\x0eExecuteRequest\x12\x1c\n" +
+	"\toperation\x18\x01 \x01(\tR\toperation\x12\x12\n" +
+	"\x04args\x18\x02 \x01(\fR\x04args\x12%\n" +
+	"\x0eclient_version\x18\x03 \x01(\tR\rclientVersion\x12#\n" +
+	"\roperation_key\x18\x04 \x01(\tR\foperationKey\x12\x18\n" +
+	"\acommand\x18\x05 \x01(\tR\acommand\"U\n" +
+	"\x0fExecuteResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"\xb0\x01\n" +
+	"\x10SubscribeRequest\x12\x19\n" +
+	"\x05since\x18\x01 \x01(\x03H\x00R\x05since\x88\x01\x01\x12\x14\n" +
+	"\x05types\x18\x02 \x03(\tR\x05types\x12\x1b\n" +
+	"\tissue_ids\x18\x03 \x03(\tR\bissueIds\x12\x16\n" +
+	"\x06actors\x18\x04 \x03(\tR\x06actors\x12\x16\n" +
+	"\x06labels\x18\x05 \x03(\tR\x06labels\x12\x14\n" +
+	"\x05query\x18\x06 \x01(\tR\x05queryB\b\n" +
+	"\x06_since\"\xab\x01\n" +
+	"\x05Event\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x19\n" +
+	"\bissue_id\x18\x02 \x01(\tR\aissueId\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x14\n" +
+	"\x05actor\x18\x04 \x01(\tR\x05actor\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x12\n" +
+	"\x04json\x18\x06 \x01(\fR\x04json2\x9e\x04\n" +
+	"\x05Beads\x12<\n" +
+	"\vCreateIssue\x12\x1c.beads.v1.CreateIssueRequest\x1a\x0f.beads.v1.Issue\x12<\n" +
+	"\vUpdateIssue\x12\x1c.beads.v1.UpdateIssueRequest\x1a\x0f.beads.v1.Issue\x12G\n" +
+	"\n" +
+	"ListIssues\x12\x1b.beads.v1.ListIssuesRequest\x1a\x1c.beads.v1.ListIssuesResponse\x12E\n" +
+	"\tReadyWork\x12\x1a.beads.v1.ReadyWorkRequest\x1a\x1c.beads.v1.ListIssuesResponse\x12D\n" +
+	"\rAddDependency\x12\x1b.beads.v1.DependencyRequest\x1a\x16.google.protobuf.Empty\x12G\n" +
+	"\x10RemoveDependency\x12\x1b.beads.v1.DependencyRequest\x1a\x16.google.protobuf.Empty\x12>\n" +
+	"\aExecute\x12\x18.beads.v1.ExecuteRequest\x1a\x19.beads.v1.ExecuteResponse\x12:\n" +
+	"\tSubscribe\x12\x1a.beads.v1.SubscribeRequest\x1a\x0f.beads.v1.Event0\x01B2Z0github.com/steveyegge/beads/internal/rpc/beadspbb\x06proto3"
+
+var (
+	file_beads_proto_rawDescOnce sync.Once
+	file_beads_proto_rawDescData []byte
+)
+
+func file_beads_proto_rawDescGZIP() []byte {
+	file_beads_proto_rawDescOnce.Do(func() {
+		file_beads_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_beads_proto_rawDesc), len(file_beads_proto_rawDesc)))
+	})
+	return file_beads_proto_rawDescData
+}
+
+var file_beads_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_beads_proto_goTypes = []any{
+	(*Issue)(nil),                 // 0: beads.v1.Issue
+	(*CreateIssueRequest)(nil),    // 1: beads.v1.CreateIssueRequest
+	(*UpdateIssueRequest)(nil),    // 2: beads.v1.UpdateIssueRequest
+	(*ListIssuesRequest)(nil),     // 3: beads.v1.ListIssuesRequest
+	(*ListIssuesResponse)(nil),    // 4: beads.v1.ListIssuesResponse
+	(*ReadyWorkRequest)(nil),      // 5: beads.v1.ReadyWorkRequest
+	(*DependencyRequest)(nil),     // 6: beads.v1.DependencyRequest
+	(*ExecuteRequest)(nil),        // 7: beads.v1.ExecuteRequest
+	(*ExecuteResponse)(nil),       // 8: beads.v1.ExecuteResponse
+	(*SubscribeRequest)(nil),      // 9: beads.v1.SubscribeRequest
+	(*Event)(nil),                 // 10: beads.v1.Event
+	(*timestamppb.Timestamp)(nil), // 11: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),         // 12: google.protobuf.Empty
+}
+var file_beads_proto_depIdxs = []int32{
+	11, // 0: beads.v1.Issue.created_at:type_name -> google.protobuf.Timestamp
+	11, // 1: beads.v1.Issue.updated_at:type_name -> google.protobuf.Timestamp
+	11, // 2: beads.v1.Issue.closed_at:type_name -> google.protobuf.Timestamp
+	0,  // 3: beads.v1.ListIssuesResponse.issues:type_name -> beads.v1.Issue
+	11, // 4: beads.v1.Event.created_at:type_name -> google.protobuf.Timestamp
+	1,  // 5: beads.v1.Beads.CreateIssue:input_type -> beads.v1.CreateIssueRequest
+	2,  // 6: beads.v1.Beads.UpdateIssue:input_type -> beads.v1.UpdateIssueRequest
+	3,  // 7: beads.v1.Beads.ListIssues:input_type -> beads.v1.ListIssuesRequest
+	5,  // 8: beads.v1.Beads.ReadyWork:input_type -> beads.v1.ReadyWorkRequest
+	6,  // 9: beads.v1.Beads.AddDependency:input_type -> beads.v1.DependencyRequest
+	6,  // 10: beads.v1.Beads.RemoveDependency:input_type -> beads.v1.DependencyRequest
+	7,  // 11: beads.v1.Beads.Execute:input_type -> beads.v1.ExecuteRequest
+	9,  // 12: beads.v1.Beads.Subscribe:input_type -> beads.v1.SubscribeRequest
+	0,  // 13: beads.v1.Beads.CreateIssue:output_type -> beads.v1.Issue
+	0,  // 14: beads.v1.Beads.UpdateIssue:output_type -> beads.v1.Issue
+	4,  // 15: beads.v1.Beads.ListIssues:output_type -> beads.v1.ListIssuesResponse
+	4,  // 16: beads.v1.Beads.ReadyWork:output_type -> beads.v1.ListIssuesResponse
+	12, // 17: beads.v1.Beads.AddDependency:output_type -> google.protobuf.Empty
+	12, // 18: beads.v1.Beads.RemoveDependency:output_type -> google.protobuf.Empty
+	8,  // 19: beads.v1.Beads.Execute:output_type -> beads.v1.ExecuteResponse
+	10, // 20: beads.v1.Beads.Subscribe:output_type -> beads.v1.Event
+	13, // [13:21] is the sub-list for method output_type
+	5,  // [5:13] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_beads_proto_init() }
+func file_beads_proto_init() {
+	if File_beads_proto != nil {
+		return
+	}
+	file_beads_proto_msgTypes[0].OneofWrappers = []any{}
+	file_beads_proto_msgTypes[1].OneofWrappers = []any{}
+	file_beads_proto_msgTypes[2].OneofWrappers = []any{}
+	file_beads_proto_msgTypes[3].OneofWrappers = []any{}
+	file_beads_proto_msgTypes[5].OneofWrappers = []any{}
+	file_beads_proto_msgTypes[9].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_beads_proto_rawDesc), len(file_beads_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_beads_proto_goTypes,
+		DependencyIndexes: file_beads_proto_depIdxs,
+		MessageInfos:      file_beads_proto_msgTypes,
+	}.Build()
+	File_beads_proto = out.File
+	file_beads_proto_goTypes = nil
+	file_beads_proto_depIdxs = nil
+}
@@ -0,0 +1,7 @@
+// Package beadspb holds the gRPC service a bd daemon serves to remote
+// clients (bd daemon --listen), generated from beads.proto. Regenerate
+// after editing the .proto with protoc-gen-go v1.36.6 and
+// protoc-gen-go-grpc v1.5.1 on the PATH.
+package beadspb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative beads.proto
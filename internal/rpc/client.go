@@ -36,7 +36,10 @@ type Client struct {
 	conn       net.Conn
 	socketPath string
 	timeout    time.Duration
-	dbPath     string // Expected database path for validation
+	dbPath     string      // Expected database path for validation
+	remote     *remoteConn // Set for remote daemons (see ConnectRemote)
+	actor      string      // Attributes changes when set (see SetActor)
+	op         *OperationInfo
 }
 
 // TryConnect attempts to connect to the daemon socket
@@ -131,6 +134,9 @@ func TryConnectWithTimeout(socketPath string, dialTimeout time.Duration) (*Clien
 
 // Close closes the connection to the daemon
 func (c *Client) Close() error {
+	if c.remote != nil {
+		return c.remote.conn.Close()
+	}
 	if c.conn != nil {
 		return c.conn.Close()
 	}
@@ -142,6 +148,19 @@ func (c *Client) SetTimeout(timeout time.Duration) {
 	c.timeout = timeout
 }
 
+// SetActor attributes the client's changes to actor instead of the daemon.
+// Remote clients set it, since the daemon can't tell who is on the other
+// end of a remote connection.
+func (c *Client) SetActor(actor string) {
+	c.actor = actor
+}
+
+// SetOperation sends op with every request, so the daemon logs them as one
+// operation for bd undo
+func (c *Client) SetOperation(op *OperationInfo) {
+	c.op = op
+}
+
 // SetDatabasePath sets the expected database path for validation
 func (c *Client) SetDatabasePath(dbPath string) {
 	c.dbPath = dbPath
@@ -154,9 +173,42 @@ func (c *Client) Execute(operation string, args interface{}) (*Response, error)
 
 // ExecuteWithCwd sends an RPC request with an explicit cwd (or current dir if empty string)
 func (c *Client) ExecuteWithCwd(operation string, args interface{}, cwd string) (*Response, error) {
+	if c.remote != nil {
+		return c.executeRemote(operation, args)
+	}
+	if c.timeout > 0 {
+		deadline := time.Now().Add(c.timeout)
+		if err := c.conn.SetDeadline(deadline); err != nil {
+			return nil, fmt.Errorf("failed to set deadline: %w", err)
+		}
+	}
+	if err := c.writeRequest(operation, args, cwd); err != nil {
+		return nil, err
+	}
+
+	reader := bufio.NewReader(c.conn)
+	respLine, err := reader.ReadBytes('\n')
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var resp Response
+	if err := json.Unmarshal(respLine, &resp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	if !resp.Success {
+		return &resp, fmt.Errorf("operation failed: %s", resp.Error)
+	}
+
+	return &resp, nil
+}
+
+// writeRequest sends one request line
+func (c *Client) writeRequest(operation string, args interface{}, cwd string) error {
 	argsJSON, err := json.Marshal(args)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal args: %w", err)
+		return fmt.Errorf("failed to marshal args: %w", err)
 	}
 
 	// Use provided cwd, or get current working directory for database routing
@@ -170,47 +222,26 @@ func (c *Client) ExecuteWithCwd(operation string, args interface{}, cwd string)
 		ClientVersion: ClientVersion,
 		Cwd:           cwd,
 		ExpectedDB:    c.dbPath, // Send expected database path for validation
+		Actor:         c.actor,
+		Op:            c.op,
 	}
 
 	reqJSON, err := json.Marshal(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
-	}
-
-	if c.timeout > 0 {
-		deadline := time.Now().Add(c.timeout)
-		if err := c.conn.SetDeadline(deadline); err != nil {
-			return nil, fmt.Errorf("failed to set deadline: %w", err)
-		}
+		return fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	writer := bufio.NewWriter(c.conn)
 	if _, err := writer.Write(reqJSON); err != nil {
-		return nil, fmt.Errorf("failed to write request: %w", err)
+		return fmt.Errorf("failed to write request: %w", err)
 	}
 	if err := writer.WriteByte('\n'); err != nil {
-		return nil, fmt.Errorf("failed to write newline: %w", err)
+		return fmt.Errorf("failed to write newline: %w", err)
 	}
 	if err := writer.Flush(); err != nil {
-		return nil, fmt.Errorf("failed to flush: %w", err)
-	}
-
-	reader := bufio.NewReader(c.conn)
-	respLine, err := reader.ReadBytes('\n')
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
-	var resp Response
-	if err := json.Unmarshal(respLine, &resp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
-	}
-
-	if !resp.Success {
-		return &resp, fmt.Errorf("operation failed: %s", resp.Error)
+		return fmt.Errorf("failed to flush: %w", err)
 	}
-
-	return &resp, nil
+	return nil
 }
 
 // Ping sends a ping request to verify the daemon is alive
@@ -333,6 +364,11 @@ func (c *Client) Stats() (*Response, error) {
 	return c.Execute(OpStats, nil)
 }
 
+// StatsWithArgs gets statistics via the daemon with options such as Exact
+func (c *Client) StatsWithArgs(args *StatsArgs) (*Response, error) {
+	return c.Execute(OpStats, args)
+}
+
 // GetMutations retrieves recent mutations from the daemon
 func (c *Client) GetMutations(args *GetMutationsArgs) (*Response, error) {
 	return c.Execute(OpGetMutations, args)
@@ -368,6 +404,16 @@ func (c *Client) AddComment(args *CommentAddArgs) (*Response, error) {
 	return c.Execute(OpCommentAdd, args)
 }
 
+// UpdateComment edits a comment via the daemon
+func (c *Client) UpdateComment(args *CommentUpdateArgs) (*Response, error) {
+	return c.Execute(OpCommentUpdate, args)
+}
+
+// DeleteComment deletes a comment via the daemon
+func (c *Client) DeleteComment(args *CommentDeleteArgs) (*Response, error) {
+	return c.Execute(OpCommentDelete, args)
+}
+
 // Batch executes multiple operations atomically
 func (c *Client) Batch(args *BatchArgs) (*Response, error) {
 	return c.Execute(OpBatch, args)
@@ -672,6 +672,46 @@ func TestListFiltersBackwardCompat(t *testing.T) {
 	}
 }
 
+func TestListPagination(t *testing.T) {
+	_, client, _, cleanup := setupTestServerWithStore(t)
+	defer cleanup()
+
+	for i := 0; i < 5; i++ {
+		if _, err := client.Create(&CreateArgs{Title: "Paged issue", IssueType: "task", Priority: 2}); err != nil {
+			t.Fatalf("Failed to create issue: %v", err)
+		}
+	}
+
+	seen := map[string]bool{}
+	args := &ListArgs{PageSize: 2}
+	for pages := 1; ; pages++ {
+		resp, err := client.List(args)
+		if err != nil {
+			t.Fatalf("List page %d failed: %v", pages, err)
+		}
+		var page ListPage
+		if err := json.Unmarshal(resp.Data, &page); err != nil {
+			t.Fatalf("Failed to unmarshal page: %v", err)
+		}
+		for _, issue := range page.Issues {
+			if seen[issue.ID] {
+				t.Errorf("Issue %s returned on more than one page", issue.ID)
+			}
+			seen[issue.ID] = true
+		}
+		if page.NextCursor == "" {
+			if pages != 3 {
+				t.Errorf("Expected 3 pages, got %d", pages)
+			}
+			break
+		}
+		args = &ListArgs{PageSize: 2, Cursor: page.NextCursor}
+	}
+	if len(seen) != 5 {
+		t.Errorf("Expected 5 issues across pages, got %d", len(seen))
+	}
+}
+
 // Helper functions
 
 func ptrInt(i int) *int {
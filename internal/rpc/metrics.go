@@ -7,6 +7,8 @@ import (
 	"sync"
 	"sync/atomic"
 	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
 )
 
 // Metrics holds all telemetry data for the daemon
@@ -18,6 +20,13 @@ type Metrics struct {
 	requestErrors  map[string]int64           // operation -> error count
 	requestLatency map[string][]time.Duration // operation -> latency samples (bounded slice)
 	maxSamples     int
+	requestHist    map[string]*histogram // operation -> latency histogram (all requests, for /metrics)
+
+	// Daemon sync metrics, recorded by the sync loop (see prometheus.go)
+	syncRuns        map[[2]string]int64 // {kind, result} -> count
+	lastSyncSuccess map[string]time.Time
+	gitOps          map[[2]string]int64 // {op, result} -> count
+	dbLatency       map[string]*histogram
 
 	// Connection metrics
 	totalConns    int64
@@ -34,7 +43,14 @@ func NewMetrics() *Metrics {
 		requestErrors:  make(map[string]int64),
 		requestLatency: make(map[string][]time.Duration),
 		maxSamples:     1000, // Keep last 1000 samples per operation
-		startTime:      time.Now(),
+		requestHist:    make(map[string]*histogram),
+
+		syncRuns:        make(map[[2]string]int64),
+		lastSyncSuccess: make(map[string]time.Time),
+		gitOps:          make(map[[2]string]int64),
+		dbLatency:       make(map[string]*histogram),
+
+		startTime: time.Now(),
 	}
 }
 
@@ -53,6 +69,8 @@ func (m *Metrics) RecordRequest(operation string, latency time.Duration) {
 	}
 	samples = append(samples, latency)
 	m.requestLatency[operation] = samples
+
+	observe(m.requestHist, operation, latency)
 }
 
 // RecordError records a failed request
@@ -173,6 +191,7 @@ type MetricsSnapshot struct {
 	MemoryAllocMB  uint64             `json:"memory_alloc_mb"`
 	MemorySysMB    uint64             `json:"memory_sys_mb"`
 	GoroutineCount int                `json:"goroutine_count"`
+	DBPool         *storage.PoolStats `json:"db_pool,omitempty"`
 }
 
 // OperationMetrics holds metrics for a single operation type
@@ -0,0 +1,294 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"runtime"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Sync run results for RecordSyncRun
+const (
+	SyncSucceeded = "success"
+	SyncFailed    = "failure"
+	SyncSkipped   = "skipped"
+)
+
+// latencyBuckets are the histogram bucket upper bounds in seconds
+// (Prometheus' defaults)
+var latencyBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// histogram counts durations into latencyBuckets
+type histogram struct {
+	buckets []int64 // per bucket, not cumulative; the last counts +Inf
+	count   int64
+	sum     float64 // seconds
+}
+
+// observe adds d to the histogram for key; the caller holds m.mu
+func observe(hists map[string]*histogram, key string, d time.Duration) {
+	h := hists[key]
+	if h == nil {
+		h = &histogram{buckets: make([]int64, len(latencyBuckets)+1)}
+		hists[key] = h
+	}
+	seconds := d.Seconds()
+	i := sort.SearchFloat64s(latencyBuckets, seconds)
+	h.buckets[i]++
+	h.count++
+	h.sum += seconds
+}
+
+// RecordSyncRun records one run of a daemon sync step (kind is export,
+// import or sync) with result SyncSucceeded, SyncFailed or SyncSkipped
+func (m *Metrics) RecordSyncRun(kind, result string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.syncRuns[[2]string{kind, result}]++
+	if result == SyncSucceeded {
+		m.lastSyncSuccess[kind] = time.Now()
+	}
+}
+
+// RecordGitOp records the outcome of a git commit, pull or push made by
+// the daemon
+func (m *Metrics) RecordGitOp(op string, err error) {
+	result := SyncSucceeded
+	if err != nil {
+		result = SyncFailed
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.gitOps[[2]string{op, result}]++
+}
+
+// ObserveDBLatency records how long a database query took
+func (m *Metrics) ObserveDBLatency(query string, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	observe(m.dbLatency, query, d)
+}
+
+// Metrics returns the server's metrics, for the daemon's sync loop to
+// record into
+func (s *Server) Metrics() *Metrics {
+	return s.metrics
+}
+
+// ServeMetrics serves the daemon's metrics in the Prometheus text format
+// at http://addr/metrics until Stop. The endpoint has no authentication;
+// bind it to localhost or a private network.
+func (s *Server) ServeMetrics(addr string) (net.Addr, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		s.writePrometheus(r.Context(), w)
+	})
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	s.mu.Lock()
+	if s.metricsServer != nil {
+		s.mu.Unlock()
+		_ = listener.Close()
+		return nil, errors.New("already serving metrics")
+	}
+	s.metricsServer = server
+	s.mu.Unlock()
+
+	go func() { _ = server.Serve(listener) }()
+	return listener.Addr(), nil
+}
+
+func (s *Server) closeMetricsServer() {
+	s.mu.Lock()
+	server := s.metricsServer
+	s.metricsServer = nil
+	s.mu.Unlock()
+	if server != nil {
+		_ = server.Close()
+	}
+}
+
+// writePrometheus writes every metric: RPC requests and connections, sync
+// runs and git operations, issue counts, database latency and pools, and
+// the process
+func (s *Server) writePrometheus(ctx context.Context, w io.Writer) {
+	p := &promWriter{w: w}
+	m := s.metrics
+
+	// Issue counts first, so the statistics query shows up in the latency
+	// histogram below
+	var stats *types.Statistics
+	if s.storage != nil {
+		statsCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		start := time.Now()
+		var err error
+		stats, err = s.storage.GetStatistics(statsCtx)
+		cancel()
+		if err == nil {
+			m.ObserveDBLatency("statistics", time.Since(start))
+		} else {
+			stats = nil
+		}
+	}
+
+	m.mu.RLock()
+	p.header("bd_rpc_requests_total", "counter", "RPC requests handled, by operation")
+	for _, op := range sortedKeys(m.requestCounts) {
+		p.sample("bd_rpc_requests_total", labels("operation", op), float64(m.requestCounts[op]))
+	}
+	p.header("bd_rpc_errors_total", "counter", "RPC requests that failed, by operation")
+	for _, op := range sortedKeys(m.requestErrors) {
+		p.sample("bd_rpc_errors_total", labels("operation", op), float64(m.requestErrors[op]))
+	}
+	p.histograms("bd_rpc_request_duration_seconds", "RPC request latency, by operation", "operation", m.requestHist)
+
+	p.header("bd_sync_runs_total", "counter", "Daemon sync runs by kind (export, import, sync) and result (success, failure, skipped)")
+	for _, key := range sortedPairs(m.syncRuns) {
+		p.sample("bd_sync_runs_total", labels("kind", key[0], "result", key[1]), float64(m.syncRuns[key]))
+	}
+	p.header("bd_sync_last_success_timestamp_seconds", "gauge", "Unix time of the last successful sync run, by kind")
+	for _, kind := range sortedKeys(m.lastSyncSuccess) {
+		p.sample("bd_sync_last_success_timestamp_seconds", labels("kind", kind), float64(m.lastSyncSuccess[kind].UnixNano())/1e9)
+	}
+	p.header("bd_git_operations_total", "counter", "Git commits, pulls and pushes made by the daemon, by result")
+	for _, key := range sortedPairs(m.gitOps) {
+		p.sample("bd_git_operations_total", labels("op", key[0], "result", key[1]), float64(m.gitOps[key]))
+	}
+	p.histograms("bd_db_query_duration_seconds", "Database query latency, by query", "query", m.dbLatency)
+	m.mu.RUnlock()
+
+	p.header("bd_rpc_connections_total", "counter", "Connections accepted")
+	p.sample("bd_rpc_connections_total", "", float64(atomic.LoadInt64(&m.totalConns)))
+	p.header("bd_rpc_rejected_connections_total", "counter", "Connections rejected at the connection limit")
+	p.sample("bd_rpc_rejected_connections_total", "", float64(atomic.LoadInt64(&m.rejectedConns)))
+	p.header("bd_rpc_active_connections", "gauge", "Connections open now")
+	p.sample("bd_rpc_active_connections", "", float64(atomic.LoadInt32(&s.activeConns)))
+
+	if stats != nil {
+		p.header("bd_issues", "gauge", "Issues by status")
+		for _, c := range []struct {
+			status string
+			n      int
+		}{
+			{string(types.StatusOpen), stats.OpenIssues},
+			{string(types.StatusInProgress), stats.InProgressIssues},
+			{string(types.StatusResolved), stats.ResolvedIssues},
+			{string(types.StatusClosed), stats.ClosedIssues},
+			{string(types.StatusTombstone), stats.TombstoneIssues},
+		} {
+			p.sample("bd_issues", labels("status", c.status), float64(c.n))
+		}
+		p.header("bd_issues_ready", "gauge", "Open issues with no open blockers")
+		p.sample("bd_issues_ready", "", float64(stats.ReadyIssues))
+		p.header("bd_issues_blocked", "gauge", "Issues with open blockers")
+		p.sample("bd_issues_blocked", "", float64(stats.BlockedIssues))
+	}
+
+	if pooled, ok := s.storage.(interface{ PoolStats() storage.PoolStats }); ok {
+		pools := pooled.PoolStats()
+		p.header("bd_db_pool_connections", "gauge", "Database connections by pool and state")
+		for _, pool := range []struct {
+			name  string
+			stats storage.ConnPoolStats
+		}{{"read", pools.Read}, {"write", pools.Write}} {
+			p.sample("bd_db_pool_connections", labels("pool", pool.name, "state", "in_use"), float64(pool.stats.InUse))
+			p.sample("bd_db_pool_connections", labels("pool", pool.name, "state", "idle"), float64(pool.stats.Idle))
+		}
+		p.header("bd_db_pool_wait_seconds_total", "counter", "Time spent waiting for a database connection, by pool")
+		p.sample("bd_db_pool_wait_seconds_total", labels("pool", "read"), pools.Read.WaitMS/1000)
+		p.sample("bd_db_pool_wait_seconds_total", labels("pool", "write"), pools.Write.WaitMS/1000)
+	}
+
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+	p.header("bd_uptime_seconds", "gauge", "Seconds since the daemon started")
+	p.sample("bd_uptime_seconds", "", time.Since(m.startTime).Seconds())
+	p.header("bd_goroutines", "gauge", "Goroutines in the daemon")
+	p.sample("bd_goroutines", "", float64(runtime.NumGoroutine()))
+	p.header("bd_memory_alloc_bytes", "gauge", "Heap bytes allocated")
+	p.sample("bd_memory_alloc_bytes", "", float64(memStats.Alloc))
+}
+
+// promWriter writes the Prometheus text exposition format
+type promWriter struct {
+	w io.Writer
+}
+
+func (p *promWriter) header(name, kind, help string) {
+	fmt.Fprintf(p.w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+func (p *promWriter) sample(name, labels string, value float64) {
+	fmt.Fprintf(p.w, "%s%s %g\n", name, labels, value)
+}
+
+// histograms writes one histogram per key, labelled label=key
+func (p *promWriter) histograms(name, help, label string, hists map[string]*histogram) {
+	p.header(name, "histogram", help)
+	for _, key := range sortedKeys(hists) {
+		h := hists[key]
+		var cumulative int64
+		for i, le := range latencyBuckets {
+			cumulative += h.buckets[i]
+			p.sample(name+"_bucket", labels(label, key, "le", fmt.Sprintf("%g", le)), float64(cumulative))
+		}
+		p.sample(name+"_bucket", labels(label, key, "le", "+Inf"), float64(h.count))
+		p.sample(name+"_sum", labels(label, key), h.sum)
+		p.sample(name+"_count", labels(label, key), float64(h.count))
+	}
+}
+
+// labels formats name/value pairs as {a="x",b="y"}
+func labels(pairs ...string) string {
+	var b strings.Builder
+	b.WriteByte('{')
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, "%s=\"%s\"", pairs[i], labelEscaper.Replace(pairs[i+1]))
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedPairs(m map[[2]string]int64) [][2]string {
+	keys := make([][2]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] != keys[j][0] {
+			return keys[i][0] < keys[j][0]
+		}
+		return keys[i][1] < keys[j][1]
+	})
+	return keys
+}
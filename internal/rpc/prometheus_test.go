@@ -0,0 +1,69 @@
+package rpc
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestServeMetrics(t *testing.T) {
+	server, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	if _, err := client.Create(&CreateArgs{Title: "Counted", IssueType: "task", Priority: 2}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	m := server.Metrics()
+	m.RecordSyncRun("sync", SyncSucceeded)
+	m.RecordSyncRun("sync", SyncFailed)
+	m.RecordGitOp("push", errors.New("rejected"))
+	m.ObserveDBLatency("export", 30*time.Millisecond)
+
+	addr, err := server.ServeMetrics("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("ServeMetrics: %v", err)
+	}
+	resp, err := http.Get("http://" + addr.String() + "/metrics")
+	if err != nil {
+		t.Fatalf("GET /metrics: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/plain") {
+		t.Errorf("Content-Type = %q", resp.Header.Get("Content-Type"))
+	}
+
+	for _, want := range []string{
+		`bd_rpc_requests_total{operation="create"} 1`,
+		`bd_rpc_request_duration_seconds_count{operation="create"} 1`,
+		`bd_sync_runs_total{kind="sync",result="failure"} 1`,
+		`bd_sync_runs_total{kind="sync",result="success"} 1`,
+		`bd_sync_last_success_timestamp_seconds{kind="sync"}`,
+		`bd_git_operations_total{op="push",result="failure"} 1`,
+		`bd_issues{status="open"} 1`,
+		`bd_db_query_duration_seconds_bucket{query="export",le="0.025"} 0`,
+		`bd_db_query_duration_seconds_bucket{query="export",le="0.05"} 1`,
+		`bd_db_query_duration_seconds_count{query="statistics"} 1`,
+		"# TYPE bd_rpc_request_duration_seconds histogram",
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("metrics missing %q", want)
+		}
+	}
+	if t.Failed() {
+		t.Logf("metrics:\n%s", body)
+	}
+
+	if _, err := server.ServeMetrics("127.0.0.1:0"); err == nil {
+		t.Error("serving metrics twice should fail")
+	}
+}
+
+func TestPrometheusLabels(t *testing.T) {
+	if got := labels("op", `a"b\c`); got != `{op="a\"b\\c"}` {
+		t.Errorf("labels = %s", got)
+	}
+}
@@ -2,6 +2,9 @@ package rpc
 
 import (
 	"encoding/json"
+
+	"github.com/steveyegge/beads/internal/eventstream"
+	"github.com/steveyegge/beads/internal/types"
 )
 
 // Operation constants for all bd commands
@@ -26,6 +29,8 @@ const (
 	OpLabelRemove     = "label_remove"
 	OpCommentList     = "comment_list"
 	OpCommentAdd      = "comment_add"
+	OpCommentUpdate   = "comment_update"
+	OpCommentDelete   = "comment_delete"
 	OpBatch           = "batch"
 	OpResolveID       = "resolve_id"
 
@@ -35,6 +40,7 @@ const (
 	OpImport          = "import"
 	OpEpicStatus      = "epic_status"
 	OpGetMutations    = "get_mutations"
+	OpSubscribe       = "subscribe"
 	OpShutdown        = "shutdown"
 	OpDelete          = "delete"
 )
@@ -48,6 +54,16 @@ type Request struct {
 	Cwd           string          `json:"cwd,omitempty"`            // Working directory for database discovery
 	ClientVersion string          `json:"client_version,omitempty"` // Client version for compatibility checks
 	ExpectedDB    string          `json:"expected_db,omitempty"`    // Expected database path for validation (absolute)
+	Op            *OperationInfo  `json:"op,omitempty"`             // Groups the requests of one bd command for bd undo
+}
+
+// OperationInfo names the bd command a request is part of. The daemon logs
+// all writes with the same key as one operation; requests without one are
+// logged as an operation each.
+type OperationInfo struct {
+	Key     string `json:"key"`
+	Actor   string `json:"actor,omitempty"`
+	Command string `json:"command,omitempty"`
 }
 
 // Response represents an RPC response from daemon to client
@@ -70,8 +86,11 @@ type CreateArgs struct {
 	Assignee           string   `json:"assignee,omitempty"`
 	ExternalRef        string   `json:"external_ref,omitempty"`  // Link to external issue trackers
 	EstimatedMinutes   *int     `json:"estimated_minutes,omitempty"` // Time estimate in minutes
+	TimeboxMinutes     *int     `json:"timebox_minutes,omitempty"`   // Spike timebox in minutes
+	Complexity         string   `json:"complexity,omitempty"`        // trivial|standard|complex|research
 	Labels             []string `json:"labels,omitempty"`
 	Dependencies       []string `json:"dependencies,omitempty"`
+	Fields             map[string]string `json:"fields,omitempty"` // Custom field values, validated by the server
 	// Messaging fields (bd-kwro)
 	Sender    string `json:"sender,omitempty"`     // Who sent this (for messages)
 	Ephemeral bool   `json:"ephemeral,omitempty"`  // Can be bulk-deleted when closed
@@ -91,10 +110,14 @@ type UpdateArgs struct {
 	Assignee           *string  `json:"assignee,omitempty"`
 	ExternalRef        *string  `json:"external_ref,omitempty"` // Link to external issue trackers
 	EstimatedMinutes   *int     `json:"estimated_minutes,omitempty"` // Time estimate in minutes
-	IssueType          *string  `json:"issue_type,omitempty"`        // Issue type (bug|feature|task|epic|chore)
+	TimeboxMinutes     *int     `json:"timebox_minutes,omitempty"`   // Spike timebox in minutes
+	IssueType          *string  `json:"issue_type,omitempty"`        // Issue type (bug|feature|task|epic|chore|spike)
+	Complexity         *string  `json:"complexity,omitempty"`        // trivial|standard|complex|research; "" clears
 	AddLabels          []string `json:"add_labels,omitempty"`
 	RemoveLabels       []string `json:"remove_labels,omitempty"`
 	SetLabels          []string `json:"set_labels,omitempty"`
+	Fields             map[string]string `json:"fields,omitempty"` // Custom field values; "" removes a field
+	Locale             string   `json:"locale,omitempty"` // Title/Description update this locale's translation
 	// Messaging fields (bd-kwro)
 	Sender    *string `json:"sender,omitempty"`     // Who sent this (for messages)
 	Ephemeral *bool   `json:"ephemeral,omitempty"`  // Can be bulk-deleted when closed
@@ -109,6 +132,7 @@ type UpdateArgs struct {
 type CloseArgs struct {
 	ID     string `json:"id"`
 	Reason string `json:"reason,omitempty"`
+	Note   string `json:"note,omitempty"` // Free-text detail appended to a taxonomy reason
 }
 
 // DeleteArgs represents arguments for the delete operation
@@ -131,6 +155,8 @@ type ListArgs struct {
 	Labels    []string `json:"labels,omitempty"`     // AND semantics
 	LabelsAny []string `json:"labels_any,omitempty"` // OR semantics
 	IDs       []string `json:"ids,omitempty"`        // Filter by specific issue IDs
+	Complexity []string `json:"complexity,omitempty"` // Any of these complexities
+	Fields    map[string]string `json:"fields,omitempty"` // Custom field values, all required
 	Limit     int      `json:"limit,omitempty"`
 	
 	// Pattern matching
@@ -145,6 +171,9 @@ type ListArgs struct {
 	UpdatedBefore string `json:"updated_before,omitempty"`
 	ClosedAfter   string `json:"closed_after,omitempty"`
 	ClosedBefore  string `json:"closed_before,omitempty"`
+
+	// Close reason from the taxonomy (e.g. "wontfix")
+	CloseReason string `json:"close_reason,omitempty"`
 	
 	// Empty/null checks
 	EmptyDescription bool `json:"empty_description,omitempty"`
@@ -154,6 +183,31 @@ type ListArgs struct {
 	// Priority range
 	PriorityMin *int `json:"priority_min,omitempty"`
 	PriorityMax *int `json:"priority_max,omitempty"`
+
+	// Cursor pagination. Setting either switches the response to a ListPage.
+	Cursor   string `json:"cursor,omitempty"`    // next_cursor from the previous page
+	PageSize int    `json:"page_size,omitempty"` // 0 = storage.DefaultPageSize
+
+	// RollUp sums estimates over each epic's descendants into its progress
+	RollUp bool `json:"roll_up,omitempty"`
+
+	// Sort is a sort spec such as "priority,-updated_at" (see types.ParseSortKeys)
+	Sort string `json:"sort,omitempty"`
+
+	// Locale shows titles and descriptions translated to this locale
+	Locale string `json:"locale,omitempty"`
+}
+
+// ListPage is the list response when ListArgs requests cursor pagination.
+// NextCursor is empty on the last page.
+type ListPage struct {
+	Issues     []*types.IssueWithCounts `json:"issues"`
+	NextCursor string                   `json:"next_cursor,omitempty"`
+}
+
+// Paginated reports whether the caller asked for cursor pagination
+func (a *ListArgs) Paginated() bool {
+	return a.Cursor != "" || a.PageSize > 0
 }
 
 // CountArgs represents arguments for the count operation
@@ -213,6 +267,10 @@ type ReadyArgs struct {
 	SortPolicy string   `json:"sort_policy,omitempty"`
 	Labels     []string `json:"labels,omitempty"`
 	LabelsAny  []string `json:"labels_any,omitempty"`
+	Complexity []string `json:"complexity,omitempty"` // Any of these complexities (route work by difficulty)
+	Claim      bool     `json:"claim,omitempty"` // Atomically claim the top issue; Data is the issue or null
+	ClaimTTL   string   `json:"claim_ttl,omitempty"` // Lease on the claimed issue, e.g. "30m" (empty: no expiry)
+	Locale     string   `json:"locale,omitempty"` // Show titles/descriptions translated to this locale
 }
 
 // StaleArgs represents arguments for the stale command
@@ -266,6 +324,22 @@ type CommentAddArgs struct {
 	Text   string `json:"text"`
 }
 
+// CommentUpdateArgs represents arguments for editing a comment
+type CommentUpdateArgs struct {
+	CommentID int64  `json:"comment_id"`
+	Text      string `json:"text"`
+}
+
+// CommentDeleteArgs represents arguments for deleting a comment
+type CommentDeleteArgs struct {
+	CommentID int64 `json:"comment_id"`
+}
+
+// StatsArgs represents arguments for the stats operation
+type StatsArgs struct {
+	Exact bool `json:"exact,omitempty"` // Compute from the issues instead of the summary tables
+}
+
 // EpicStatusArgs represents arguments for the epic status operation
 type EpicStatusArgs struct {
 	EligibleOnly bool `json:"eligible_only,omitempty"`
@@ -397,3 +471,15 @@ type ImportArgs struct {
 type GetMutationsArgs struct {
 	Since int64 `json:"since"` // Unix timestamp in milliseconds (0 for all recent)
 }
+
+// SubscribeArgs represents arguments for the subscribe operation. The
+// connection then streams events instead of answering further requests.
+type SubscribeArgs struct {
+	Since  *int64              `json:"since,omitempty"` // Resume after this event ID (nil for new events only)
+	Filter *eventstream.Filter `json:"filter,omitempty"`
+}
+
+// SubscribeResponse acknowledges a subscription before events are streamed
+type SubscribeResponse struct {
+	Cursor int64 `json:"cursor"` // Events after this ID will be streamed
+}
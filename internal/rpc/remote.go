@@ -0,0 +1,414 @@
+package rpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/eventstream"
+	"github.com/steveyegge/beads/internal/rpc/beadspb"
+	"github.com/steveyegge/beads/internal/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Metadata keys of remote calls (see beadspb/beads.proto)
+const (
+	authorizationKey = "authorization"
+	actorKey         = "bd-actor"
+)
+
+// ListenRemote serves the Beads gRPC service (beadspb) on a TCP address in
+// addition to the local socket, so agents on other machines can use this
+// daemon. Connections must use TLS, every call must carry token, and
+// remote clients can't shut the daemon down.
+func (s *Server) ListenRemote(addr, token string, tlsConfig *tls.Config) (net.Addr, error) {
+	if token == "" {
+		return nil, errors.New("a token is required to accept remote connections")
+	}
+	if tlsConfig == nil {
+		return nil, errors.New("a TLS certificate is required to accept remote connections")
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	server := grpc.NewServer(
+		grpc.Creds(credentials.NewTLS(tlsConfig)),
+		grpc.MaxConcurrentStreams(uint32(s.maxConns)), // #nosec G115 - maxConns is small and positive
+		grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			if err := s.authorizeRemote(ctx); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}),
+		grpc.StreamInterceptor(func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if err := s.authorizeRemote(ss.Context()); err != nil {
+				return err
+			}
+			return handler(srv, ss)
+		}),
+	)
+	beadspb.RegisterBeadsServer(server, &remoteService{s: s})
+
+	s.mu.Lock()
+	if s.remoteServer != nil {
+		s.mu.Unlock()
+		_ = listener.Close()
+		return nil, errors.New("already accepting remote connections")
+	}
+	s.remoteServer = server
+	s.remoteToken = token
+	s.mu.Unlock()
+
+	go func() {
+		// Returns when Stop stops the gRPC server
+		_ = server.Serve(listener)
+	}()
+	return listener.Addr(), nil
+}
+
+func (s *Server) stopRemote() {
+	s.mu.Lock()
+	server := s.remoteServer
+	s.remoteServer = nil
+	s.mu.Unlock()
+	if server != nil {
+		server.Stop()
+	}
+}
+
+// authorizeRemote checks the bearer token of a remote call
+func (s *Server) authorizeRemote(ctx context.Context) error {
+	s.mu.RLock()
+	token := s.remoteToken
+	s.mu.RUnlock()
+
+	presented := strings.TrimPrefix(metadataValue(ctx, authorizationKey), "Bearer ")
+	if subtle.ConstantTimeCompare([]byte(presented), []byte(token)) != 1 {
+		// Slow down token guessing
+		time.Sleep(100 * time.Millisecond)
+		return status.Error(codes.Unauthenticated, "invalid or missing daemon token")
+	}
+	return nil
+}
+
+func metadataValue(ctx context.Context, key string) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// remoteService implements beadspb.BeadsServer on top of the handlers of
+// the local socket protocol, so both behave the same
+type remoteService struct {
+	beadspb.UnimplementedBeadsServer
+	s *Server
+}
+
+// remoteRequest builds the request a remote call runs as. Remote clients
+// have no local database to bind to; the token already ties them to this
+// daemon.
+func (r *remoteService) remoteRequest(ctx context.Context, operation string, args interface{}) (*Request, error) {
+	argsJSON, err := json.Marshal(args)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to marshal args: %v", err)
+	}
+	return &Request{
+		Operation:  operation,
+		Args:       argsJSON,
+		Actor:      metadataValue(ctx, actorKey),
+		ExpectedDB: r.s.storage.Path(),
+	}, nil
+}
+
+// call runs operation and decodes its data into result (unless nil)
+func (r *remoteService) call(ctx context.Context, operation string, args, result interface{}) error {
+	req, err := r.remoteRequest(ctx, operation, args)
+	if err != nil {
+		return err
+	}
+	resp := r.s.handleRequest(req)
+	if !resp.Success {
+		return status.Error(codes.FailedPrecondition, resp.Error)
+	}
+	if result == nil {
+		return nil
+	}
+	if err := json.Unmarshal(resp.Data, result); err != nil {
+		return status.Errorf(codes.Internal, "failed to decode %s response: %v", operation, err)
+	}
+	return nil
+}
+
+func (r *remoteService) CreateIssue(ctx context.Context, in *beadspb.CreateIssueRequest) (*beadspb.Issue, error) {
+	args := &CreateArgs{
+		ID:                 in.Id,
+		Parent:             in.Parent,
+		Title:              in.Title,
+		Description:        in.Description,
+		IssueType:          in.IssueType,
+		Priority:           2,
+		Design:             in.Design,
+		AcceptanceCriteria: in.AcceptanceCriteria,
+		Assignee:           in.Assignee,
+		ExternalRef:        in.ExternalRef,
+		EstimatedMinutes:   intPtr(in.EstimatedMinutes),
+		Complexity:         in.Complexity,
+		Labels:             in.Labels,
+		Dependencies:       in.Dependencies,
+	}
+	if args.IssueType == "" {
+		args.IssueType = string(types.TypeTask)
+	}
+	if in.Priority != nil {
+		args.Priority = int(*in.Priority)
+	}
+	var issue types.Issue
+	if err := r.call(ctx, OpCreate, args, &issue); err != nil {
+		return nil, err
+	}
+	return r.issueWithLabels(ctx, &issue)
+}
+
+func (r *remoteService) UpdateIssue(ctx context.Context, in *beadspb.UpdateIssueRequest) (*beadspb.Issue, error) {
+	args := &UpdateArgs{
+		ID:                 in.Id,
+		Title:              in.Title,
+		Description:        in.Description,
+		Status:             in.Status,
+		Priority:           intPtr(in.Priority),
+		Design:             in.Design,
+		AcceptanceCriteria: in.AcceptanceCriteria,
+		Notes:              in.Notes,
+		Assignee:           in.Assignee,
+		ExternalRef:        in.ExternalRef,
+		EstimatedMinutes:   intPtr(in.EstimatedMinutes),
+		IssueType:          in.IssueType,
+		Complexity:         in.Complexity,
+		AddLabels:          in.AddLabels,
+		RemoveLabels:       in.RemoveLabels,
+	}
+	var issue types.Issue
+	if err := r.call(ctx, OpUpdate, args, &issue); err != nil {
+		return nil, err
+	}
+	return r.issueWithLabels(ctx, &issue)
+}
+
+// issueWithLabels converts issue, whose labels handlers don't return
+func (r *remoteService) issueWithLabels(ctx context.Context, issue *types.Issue) (*beadspb.Issue, error) {
+	labels, err := r.s.storage.GetLabels(ctx, issue.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get labels: %v", err)
+	}
+	return issueToProto(issue, labels), nil
+}
+
+func (r *remoteService) ListIssues(ctx context.Context, in *beadspb.ListIssuesRequest) (*beadspb.ListIssuesResponse, error) {
+	args := &ListArgs{
+		Query:     in.Query,
+		Status:    in.Status,
+		Priority:  intPtr(in.Priority),
+		IssueType: in.IssueType,
+		Assignee:  in.Assignee,
+		Labels:    in.Labels,
+		LabelsAny: in.LabelsAny,
+		IDs:       in.Ids,
+		Limit:     int(in.Limit),
+		Sort:      in.Sort,
+	}
+	var issues []*types.IssueWithCounts
+	if err := r.call(ctx, OpList, args, &issues); err != nil {
+		return nil, err
+	}
+	resp := &beadspb.ListIssuesResponse{}
+	for _, issue := range issues {
+		p := issueToProto(issue.Issue, issue.Labels)
+		p.DependencyCount = int32(issue.DependencyCount) // #nosec G115 - counts fit in int32
+		p.DependentCount = int32(issue.DependentCount)   // #nosec G115 - counts fit in int32
+		resp.Issues = append(resp.Issues, p)
+	}
+	return resp, nil
+}
+
+func (r *remoteService) ReadyWork(ctx context.Context, in *beadspb.ReadyWorkRequest) (*beadspb.ListIssuesResponse, error) {
+	args := &ReadyArgs{
+		Assignee:   in.Assignee,
+		Unassigned: in.Unassigned,
+		Priority:   intPtr(in.Priority),
+		Limit:      int(in.Limit),
+		SortPolicy: in.SortPolicy,
+		Labels:     in.Labels,
+		LabelsAny:  in.LabelsAny,
+	}
+	var issues []*types.Issue
+	if err := r.call(ctx, OpReady, args, &issues); err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	labels, err := r.s.storage.GetLabelsForIssues(ctx, ids)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get labels: %v", err)
+	}
+	resp := &beadspb.ListIssuesResponse{}
+	for _, issue := range issues {
+		resp.Issues = append(resp.Issues, issueToProto(issue, labels[issue.ID]))
+	}
+	return resp, nil
+}
+
+func (r *remoteService) AddDependency(ctx context.Context, in *beadspb.DependencyRequest) (*emptypb.Empty, error) {
+	depType := in.Type
+	if depType == "" {
+		depType = string(types.DepBlocks)
+	}
+	args := &DepAddArgs{FromID: in.IssueId, ToID: in.DependsOnId, DepType: depType}
+	if err := r.call(ctx, OpDepAdd, args, nil); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (r *remoteService) RemoveDependency(ctx context.Context, in *beadspb.DependencyRequest) (*emptypb.Empty, error) {
+	args := &DepRemoveArgs{FromID: in.IssueId, ToID: in.DependsOnId, DepType: in.Type}
+	if err := r.call(ctx, OpDepRemove, args, nil); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (r *remoteService) Execute(ctx context.Context, in *beadspb.ExecuteRequest) (*beadspb.ExecuteResponse, error) {
+	switch in.Operation {
+	case OpShutdown:
+		return nil, status.Error(codes.PermissionDenied, "shutdown is only available on the local socket")
+	case OpSubscribe:
+		return nil, status.Error(codes.InvalidArgument, "use the Subscribe call to stream events")
+	}
+	req := &Request{
+		Operation:     in.Operation,
+		Args:          in.Args,
+		Actor:         metadataValue(ctx, actorKey),
+		ClientVersion: in.ClientVersion,
+		ExpectedDB:    r.s.storage.Path(),
+	}
+	if in.OperationKey != "" {
+		req.Op = &OperationInfo{Key: in.OperationKey, Actor: req.Actor, Command: in.Command}
+	}
+	resp := r.s.handleRequest(req)
+	return &beadspb.ExecuteResponse{Success: resp.Success, Data: resp.Data, Error: resp.Error}, nil
+}
+
+func (r *remoteService) Subscribe(in *beadspb.SubscribeRequest, stream beadspb.Beads_SubscribeServer) error {
+	args := &SubscribeArgs{Since: in.Since}
+	if len(in.Types)+len(in.IssueIds)+len(in.Actors)+len(in.Labels) > 0 || in.Query != "" {
+		args.Filter = &eventstream.Filter{
+			IssueIDs: in.IssueIds,
+			Actors:   in.Actors,
+			Labels:   in.Labels,
+			Query:    in.Query,
+		}
+		for _, t := range in.Types {
+			args.Filter.Types = append(args.Filter.Types, types.EventType(t))
+		}
+	}
+
+	start := time.Now()
+	started := func(int64) error {
+		r.s.metrics.RecordRequest(OpSubscribe, time.Since(start))
+		return nil
+	}
+	send := func(messages []*eventstream.Message) error {
+		for _, m := range messages {
+			data, err := json.Marshal(m)
+			if err != nil {
+				return fmt.Errorf("failed to marshal event %d: %w", m.ID, err)
+			}
+			if err := stream.Send(&beadspb.Event{
+				Id:        m.ID,
+				IssueId:   m.IssueID,
+				Type:      string(m.EventType),
+				Actor:     m.Actor,
+				CreatedAt: timestamppb.New(m.CreatedAt),
+				Json:      data,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := r.s.streamEvents(stream.Context(), args, started, send); err != nil {
+		r.s.metrics.RecordError(OpSubscribe)
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return nil
+}
+
+// issueToProto converts an issue and its labels for the gRPC service
+func issueToProto(issue *types.Issue, labels []string) *beadspb.Issue {
+	p := &beadspb.Issue{
+		Id:                 issue.ID,
+		Title:              issue.Title,
+		Description:        issue.Description,
+		Design:             issue.Design,
+		AcceptanceCriteria: issue.AcceptanceCriteria,
+		Notes:              issue.Notes,
+		Status:             string(issue.Status),
+		Priority:           int32(issue.Priority), // #nosec G115 - priorities are 0-4
+		IssueType:          string(issue.IssueType),
+		Assignee:           issue.Assignee,
+		Complexity:         string(issue.Complexity),
+		CreatedAt:          timestamppb.New(issue.CreatedAt),
+		UpdatedAt:          timestamppb.New(issue.UpdatedAt),
+		CloseReason:        issue.CloseReason,
+		Labels:             labels,
+	}
+	if issue.EstimatedMinutes != nil {
+		minutes := int32(*issue.EstimatedMinutes) // #nosec G115 - estimates fit in int32
+		p.EstimatedMinutes = &minutes
+	}
+	if issue.ClosedAt != nil {
+		p.ClosedAt = timestamppb.New(*issue.ClosedAt)
+	}
+	if issue.ExternalRef != nil {
+		p.ExternalRef = *issue.ExternalRef
+	}
+	return p
+}
+
+func intPtr(v *int32) *int {
+	if v == nil {
+		return nil
+	}
+	i := int(*v)
+	return &i
+}
+
+// RemoteServerTLS loads the certificate ListenRemote serves
+func RemoteServerTLS(certFile, keyFile string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
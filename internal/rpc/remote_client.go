@@ -0,0 +1,183 @@
+package rpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/steveyegge/beads/internal/eventstream"
+	"github.com/steveyegge/beads/internal/rpc/beadspb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// remoteConn is a client's connection to the gRPC service of a remote
+// daemon (see ListenRemote)
+type remoteConn struct {
+	conn *grpc.ClientConn
+	api  beadspb.BeadsClient
+}
+
+// tokenCredentials sends the daemon token with every call, and only over TLS
+type tokenCredentials string
+
+func (t tokenCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{authorizationKey: "Bearer " + string(t)}, nil
+}
+
+func (tokenCredentials) RequireTransportSecurity() bool {
+	return true
+}
+
+// RemoteClientTLS returns the TLS config for connecting to a remote daemon.
+// The daemon's certificate is verified against the CA in caFile, or against
+// the system roots when caFile is empty.
+func RemoteClientTLS(caFile string) (*tls.Config, error) {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caFile == "" {
+		return config, nil
+	}
+	pem, err := os.ReadFile(caFile) // #nosec G304 - CA path from user config
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+	}
+	config.RootCAs = x509.NewCertPool()
+	if !config.RootCAs.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in %s", caFile)
+	}
+	return config, nil
+}
+
+// ConnectRemote connects to the gRPC service of a daemon started with
+// --listen and checks its health. Unlike TryConnect, failures are errors:
+// a remote client has no local database to fall back to. Requests go
+// through the service's Execute call, so the client works as it does on
+// the local socket.
+func ConnectRemote(addr, token string, tlsConfig *tls.Config, dialTimeout time.Duration) (*Client, error) {
+	rpcDebugLog("dialing remote daemon at %s (timeout: %v)", addr, dialTimeout)
+	conn, err := grpc.NewClient(addr,
+		grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)),
+		grpc.WithPerRPCCredentials(tokenCredentials(token)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot reach daemon at %s: %w", addr, err)
+	}
+	client := &Client{
+		socketPath: addr,
+		timeout:    dialTimeout,
+		remote:     &remoteConn{conn: conn, api: beadspb.NewBeadsClient(conn)},
+	}
+	health, err := client.Health()
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("daemon at %s: %w", addr, err)
+	}
+	if health.Status == statusUnhealthy {
+		_ = conn.Close()
+		return nil, fmt.Errorf("daemon at %s is unhealthy: %s", addr, health.Error)
+	}
+	if !health.Compatible {
+		_ = conn.Close()
+		return nil, fmt.Errorf("daemon at %s runs bd %s, which is incompatible with this client (%s)", addr, health.Version, ClientVersion)
+	}
+	client.timeout = 30 * time.Second
+	return client, nil
+}
+
+// Remote reports whether the client is connected to a remote daemon rather
+// than the local socket
+func (c *Client) Remote() bool {
+	return c.remote != nil
+}
+
+// remoteContext returns the context of a remote call, carrying the actor
+func (c *Client) remoteContext(ctx context.Context) context.Context {
+	if c.actor != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, actorKey, c.actor)
+	}
+	return ctx
+}
+
+// executeRemote is ExecuteWithCwd for remote daemons
+func (c *Client) executeRemote(operation string, args interface{}) (*Response, error) {
+	argsJSON, err := json.Marshal(args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal args: %w", err)
+	}
+	ctx := c.remoteContext(context.Background())
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	in := &beadspb.ExecuteRequest{
+		Operation:     operation,
+		Args:          argsJSON,
+		ClientVersion: ClientVersion,
+	}
+	if c.op != nil {
+		in.OperationKey = c.op.Key
+		in.Command = c.op.Command
+	}
+	out, err := c.remote.api.Execute(ctx, in)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %s", status.Convert(err).Message())
+	}
+
+	resp := &Response{Success: out.Success, Data: out.Data, Error: out.Error}
+	if !resp.Success {
+		return resp, fmt.Errorf("operation failed: %s", resp.Error)
+	}
+	return resp, nil
+}
+
+// subscribeRemote is Subscribe for remote daemons
+func (c *Client) subscribeRemote(ctx context.Context, args *SubscribeArgs, fn func(*eventstream.Message) error) error {
+	in := &beadspb.SubscribeRequest{Since: args.Since}
+	if f := args.Filter; f != nil {
+		for _, t := range f.Types {
+			in.Types = append(in.Types, string(t))
+		}
+		in.IssueIds = f.IssueIDs
+		in.Actors = f.Actors
+		in.Labels = f.Labels
+		in.Query = f.Query
+	}
+	stream, err := c.remote.api.Subscribe(c.remoteContext(ctx), in)
+	if err != nil {
+		return fmt.Errorf("request failed: %s", status.Convert(err).Message())
+	}
+
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			if errors.Is(err, io.EOF) {
+				return errors.New("daemon closed the event stream")
+			}
+			if st := status.Convert(err); st.Code() == codes.FailedPrecondition {
+				return fmt.Errorf("operation failed: %s", st.Message())
+			}
+			return fmt.Errorf("failed to read event: %s", status.Convert(err).Message())
+		}
+		var m eventstream.Message
+		if err := json.Unmarshal(event.Json, &m); err != nil {
+			return fmt.Errorf("failed to unmarshal event: %w", err)
+		}
+		if err := fn(&m); err != nil {
+			return err
+		}
+	}
+}
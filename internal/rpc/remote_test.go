@@ -0,0 +1,294 @@
+package rpc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/eventstream"
+	"github.com/steveyegge/beads/internal/rpc/beadspb"
+	"github.com/steveyegge/beads/internal/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+// writeTestCert writes a self-signed certificate for 127.0.0.1 and its key
+// to dir, returning their paths
+func writeTestCert(t *testing.T, dir string) (certFile, keyFile string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "bd test daemon"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certFile = filepath.Join(dir, "daemon.crt")
+	keyFile = filepath.Join(dir, "daemon.key")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
+
+// listenRemoteForTest serves the remote API with a test certificate and
+// returns its address and the client TLS config that trusts it
+func listenRemoteForTest(t *testing.T, server *Server, token string) (string, *tls.Config) {
+	t.Helper()
+	certFile, keyFile := writeTestCert(t, t.TempDir())
+	serverTLS, err := RemoteServerTLS(certFile, keyFile)
+	if err != nil {
+		t.Fatalf("RemoteServerTLS: %v", err)
+	}
+	addr, err := server.ListenRemote("127.0.0.1:0", token, serverTLS)
+	if err != nil {
+		t.Fatalf("ListenRemote: %v", err)
+	}
+	clientTLS, err := RemoteClientTLS(certFile)
+	if err != nil {
+		t.Fatalf("RemoteClientTLS: %v", err)
+	}
+	return addr.String(), clientTLS
+}
+
+func TestRemoteConnections(t *testing.T) {
+	server, _, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	if _, err := server.ListenRemote("127.0.0.1:0", "", &tls.Config{}); err == nil {
+		t.Fatal("ListenRemote without a token should fail")
+	}
+	if _, err := server.ListenRemote("127.0.0.1:0", "s3cret", nil); err == nil {
+		t.Fatal("ListenRemote without TLS should fail")
+	}
+	addr, clientTLS := listenRemoteForTest(t, server, "s3cret")
+
+	if _, err := ConnectRemote(addr, "wrong", clientTLS, time.Second); err == nil || !strings.Contains(err.Error(), "invalid or missing daemon token") {
+		t.Errorf("wrong token: err = %v", err)
+	}
+	if _, err := ConnectRemote(addr, "s3cret", &tls.Config{MinVersion: tls.VersionTLS12}, time.Second); err == nil {
+		t.Error("a client that doesn't trust the daemon's certificate should fail")
+	}
+
+	client, err := ConnectRemote(addr, "s3cret", clientTLS, time.Second)
+	if err != nil {
+		t.Fatalf("ConnectRemote: %v", err)
+	}
+	defer client.Close()
+	client.SetActor("remote-agent")
+	if !client.Remote() {
+		t.Error("client should report a remote connection")
+	}
+
+	resp, err := client.Create(&CreateArgs{Title: "From afar", IssueType: "task", Priority: 2})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	var issue types.Issue
+	if err := json.Unmarshal(resp.Data, &issue); err != nil {
+		t.Fatal(err)
+	}
+	resp, err = client.Ready(&ReadyArgs{})
+	if err != nil {
+		t.Fatalf("Ready: %v", err)
+	}
+	var ready []*types.Issue
+	if err := json.Unmarshal(resp.Data, &ready); err != nil || len(ready) != 1 || ready[0].ID != issue.ID {
+		t.Errorf("ready = %+v, %v", ready, err)
+	}
+
+	events, err := server.storage.GetEvents(server.reqCtx(nil), issue.ID, 1)
+	if err != nil || len(events) == 0 || events[0].Actor != "remote-agent" {
+		t.Errorf("create not attributed to the remote actor: %+v, %v", events, err)
+	}
+
+	if err := client.Shutdown(); err == nil {
+		t.Error("remote clients must not be able to shut the daemon down")
+	}
+}
+
+func TestRemoteRequiresTLS(t *testing.T) {
+	server, _, cleanup := setupTestServer(t)
+	defer cleanup()
+	addr, _ := listenRemoteForTest(t, server, "s3cret")
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(metadata.AppendToOutgoingContext(context.Background(), authorizationKey, "Bearer s3cret"), 2*time.Second)
+	defer cancel()
+	if _, err := beadspb.NewBeadsClient(conn).Execute(ctx, &beadspb.ExecuteRequest{Operation: OpHealth}); err == nil {
+		t.Error("a plaintext connection should be refused")
+	}
+}
+
+func TestRemoteTypedService(t *testing.T) {
+	server, _, cleanup := setupTestServer(t)
+	defer cleanup()
+	addr, clientTLS := listenRemoteForTest(t, server, "s3cret")
+
+	conn, err := grpc.NewClient(addr,
+		grpc.WithTransportCredentials(credentials.NewTLS(clientTLS)),
+		grpc.WithPerRPCCredentials(tokenCredentials("s3cret")),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	api := beadspb.NewBeadsClient(conn)
+	ctx, cancel := context.WithTimeout(metadata.AppendToOutgoingContext(context.Background(), actorKey, "typed-agent"), 10*time.Second)
+	defer cancel()
+
+	blocker, err := api.CreateIssue(ctx, &beadspb.CreateIssueRequest{Title: "Blocker", Labels: []string{"backend"}})
+	if err != nil {
+		t.Fatalf("CreateIssue: %v", err)
+	}
+	if blocker.Priority != 2 || blocker.IssueType != "task" || blocker.Status != "open" {
+		t.Errorf("defaults not applied: %+v", blocker)
+	}
+	if len(blocker.Labels) != 1 || blocker.Labels[0] != "backend" {
+		t.Errorf("labels = %v", blocker.Labels)
+	}
+	priority := int32(0)
+	blocked, err := api.CreateIssue(ctx, &beadspb.CreateIssueRequest{Title: "Blocked", Priority: &priority})
+	if err != nil {
+		t.Fatalf("CreateIssue: %v", err)
+	}
+
+	if _, err := api.AddDependency(ctx, &beadspb.DependencyRequest{IssueId: blocked.Id, DependsOnId: blocker.Id}); err != nil {
+		t.Fatalf("AddDependency: %v", err)
+	}
+	ready, err := api.ReadyWork(ctx, &beadspb.ReadyWorkRequest{})
+	if err != nil {
+		t.Fatalf("ReadyWork: %v", err)
+	}
+	if len(ready.Issues) != 1 || ready.Issues[0].Id != blocker.Id || len(ready.Issues[0].Labels) != 1 {
+		t.Errorf("ready = %+v", ready.Issues)
+	}
+
+	list, err := api.ListIssues(ctx, &beadspb.ListIssuesRequest{Ids: []string{blocked.Id}})
+	if err != nil {
+		t.Fatalf("ListIssues: %v", err)
+	}
+	if len(list.Issues) != 1 || list.Issues[0].DependencyCount != 1 {
+		t.Errorf("list = %+v", list.Issues)
+	}
+
+	inProgress := "in_progress"
+	updated, err := api.UpdateIssue(ctx, &beadspb.UpdateIssueRequest{Id: blocker.Id, Status: &inProgress, AddLabels: []string{"urgent"}})
+	if err != nil {
+		t.Fatalf("UpdateIssue: %v", err)
+	}
+	if updated.Status != inProgress || len(updated.Labels) != 2 {
+		t.Errorf("updated = %+v", updated)
+	}
+
+	if _, err := api.RemoveDependency(ctx, &beadspb.DependencyRequest{IssueId: blocked.Id, DependsOnId: blocker.Id}); err != nil {
+		t.Fatalf("RemoveDependency: %v", err)
+	}
+	// The blocker is in progress now, so only the formerly blocked issue is ready
+	ready, err = api.ReadyWork(ctx, &beadspb.ReadyWorkRequest{})
+	if err != nil || len(ready.Issues) != 1 || ready.Issues[0].Id != blocked.Id {
+		t.Errorf("ready after removing the dependency = %+v, %v", ready, err)
+	}
+
+	if _, err := api.UpdateIssue(ctx, &beadspb.UpdateIssueRequest{Id: "bd-missing", Status: &inProgress}); err == nil {
+		t.Error("updating a missing issue should fail")
+	}
+	events, err := server.storage.GetEvents(server.reqCtx(nil), blocked.Id, 0)
+	if err != nil || len(events) == 0 || events[len(events)-1].Actor != "typed-agent" {
+		t.Errorf("create not attributed to the metadata actor: %+v, %v", events, err)
+	}
+}
+
+func TestRemoteSubscribe(t *testing.T) {
+	server, client, cleanup := setupTestServer(t)
+	defer cleanup()
+	addr, clientTLS := listenRemoteForTest(t, server, "s3cret")
+
+	subscriber, err := ConnectRemote(addr, "s3cret", clientTLS, time.Second)
+	if err != nil {
+		t.Fatalf("ConnectRemote: %v", err)
+	}
+	defer subscriber.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	received := make(chan *eventstream.Message, 10)
+	done := make(chan error, 1)
+	filter := &eventstream.Filter{Types: []types.EventType{types.EventCreated}}
+	go func() {
+		done <- subscriber.Subscribe(ctx, &SubscribeArgs{Filter: filter}, func(m *eventstream.Message) error {
+			received <- m
+			return nil
+		})
+	}()
+	// Let the subscription start before making changes
+	time.Sleep(100 * time.Millisecond)
+
+	resp, err := client.Create(&CreateArgs{Title: "Streamed remotely", IssueType: "task", Priority: 2})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	var issue types.Issue
+	if err := json.Unmarshal(resp.Data, &issue); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case m := <-received:
+		if m.EventType != types.EventCreated || m.IssueID != issue.ID || m.Issue == nil {
+			t.Errorf("got %+v", m)
+		}
+	case err := <-done:
+		t.Fatalf("stream ended early: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the event")
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Subscribe after cancel: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Subscribe did not return after cancel")
+	}
+}
@@ -4,12 +4,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"net/http"
 	"os"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/steveyegge/beads/internal/storage"
+	"google.golang.org/grpc"
 )
 
 // ServerVersion is the version of this RPC server
@@ -28,6 +30,12 @@ type Server struct {
 	dbPath        string          // Absolute path to database file
 	storage       storage.Storage // Default storage (for backward compat)
 	listener      net.Listener
+	// gRPC service for remote clients (ListenRemote); token authenticates
+	// every call
+	remoteServer *grpc.Server
+	remoteToken  string
+	// Prometheus endpoint (ServeMetrics)
+	metricsServer *http.Server
 	mu            sync.RWMutex
 	shutdown      bool
 	shutdownChan  chan struct{}
@@ -54,6 +62,9 @@ type Server struct {
 	recentMutations   []MutationEvent
 	recentMutationsMu sync.RWMutex
 	maxMutationBuffer int
+	// Event stream subscribers, woken on every mutation (see subscribe.go)
+	subscribers   map[chan struct{}]struct{}
+	subscribersMu sync.Mutex
 	// Daemon configuration (set via SetConfig after creation)
 	autoCommit   bool
 	autoPush     bool
@@ -119,6 +130,7 @@ func NewServer(socketPath string, store storage.Storage, workspacePath string, d
 		mutationChan:      make(chan MutationEvent, mutationBufferSize), // Configurable buffer
 		recentMutations:   make([]MutationEvent, 0, 100),
 		maxMutationBuffer: 100,
+		subscribers:       make(map[chan struct{}]struct{}),
 	}
 	s.lastActivityTime.Store(time.Now())
 	return s
@@ -151,6 +163,8 @@ func (s *Server) emitMutation(eventType, issueID string) {
 		s.recentMutations = s.recentMutations[1:]
 	}
 	s.recentMutationsMu.Unlock()
+
+	s.wakeSubscribers()
 }
 
 // MutationChan returns the mutation event channel for the daemon to consume
@@ -15,6 +15,8 @@ import (
 	"github.com/steveyegge/beads/internal/debug"
 	"github.com/steveyegge/beads/internal/export"
 	"github.com/steveyegge/beads/internal/importer"
+	"github.com/steveyegge/beads/internal/encryption"
+	"github.com/steveyegge/beads/internal/redact"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
@@ -137,6 +139,66 @@ func (s *Server) handleExport(req *Request) Response {
 		issue.Comments = allComments[issue.ID]
 	}
 
+	// Populate attachments for all issues
+	allAttachments, err := store.GetAttachmentsForIssues(ctx, issueIDs)
+	if err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to get attachments: %v", err),
+		}
+	}
+	for _, issue := range issues {
+		issue.Attachments = allAttachments[issue.ID]
+	}
+
+	// Populate work log for all issues
+	allWorkLog, err := store.GetWorkLogForIssues(ctx, issueIDs)
+	if err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to get work log: %v", err),
+		}
+	}
+	for _, issue := range issues {
+		issue.WorkLog = allWorkLog[issue.ID]
+	}
+
+	// Populate aliases for all issues
+	allAliases, err := store.GetAliasesForIssues(ctx, issueIDs)
+	if err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to get aliases: %v", err),
+		}
+	}
+	for _, issue := range issues {
+		issue.Aliases = allAliases[issue.ID]
+	}
+
+	// Populate translations for all issues
+	allTranslations, err := store.GetTranslationsForIssues(ctx, issueIDs)
+	if err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to get translations: %v", err),
+		}
+	}
+	for _, issue := range issues {
+		issue.Translations = allTranslations[issue.ID]
+	}
+
+	// Populate custom field values for all issues
+	allFields, err := store.GetFieldsForIssues(ctx, issueIDs)
+	if err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to get fields: %v", err),
+		}
+	}
+	for _, issue := range issues {
+		issue.Fields = allFields[issue.ID]
+	}
+
 	// Create temp file for atomic write
 	dir := filepath.Dir(exportArgs.JSONLPath)
 	base := filepath.Base(exportArgs.JSONLPath)
@@ -153,12 +215,33 @@ func (s *Server) handleExport(req *Request) Response {
 		_ = os.Remove(tempPath)
 	}()
 
-	// Write JSONL
+	// Write JSONL, redacting secrets that are stored in the DB
+	redactor, err := redact.FromConfig()
+	if err != nil {
+		return Response{
+			Success: false,
+			Error:   err.Error(),
+		}
+	}
+	fieldCipher, err := encryption.FromConfig(dir)
+	if err != nil {
+		return Response{
+			Success: false,
+			Error:   err.Error(),
+		}
+	}
 	encoder := json.NewEncoder(tempFile)
 	exportedIDs := make([]string, 0, len(issues))
 	var encodingWarnings []string
 	for _, issue := range issues {
-		if err := encoder.Encode(issue); err != nil {
+		exported, err := fieldCipher.ForExport(redactor.ForExport(issue))
+		if err != nil {
+			return Response{
+				Success: false,
+				Error:   err.Error(),
+			}
+		}
+		if err := encoder.Encode(exported); err != nil {
 			if cfg.SkipEncodingErrors {
 				// Skip this issue and continue
 				warning := fmt.Sprintf("skipped encoding issue %s: %v", issue.ID, err)
@@ -533,6 +616,51 @@ func (s *Server) triggerExport(ctx context.Context, store storage.Storage, dbPat
 		issue.Comments = allComments[issue.ID]
 	}
 
+	// Populate attachments for all issues
+	allAttachments, err := store.GetAttachmentsForIssues(ctx, issueIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get attachments: %w", err)
+	}
+	for _, issue := range allIssues {
+		issue.Attachments = allAttachments[issue.ID]
+	}
+
+	// Populate work log for all issues
+	allWorkLog, err := store.GetWorkLogForIssues(ctx, issueIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get work log: %w", err)
+	}
+	for _, issue := range allIssues {
+		issue.WorkLog = allWorkLog[issue.ID]
+	}
+
+	// Populate aliases for all issues
+	allAliases, err := store.GetAliasesForIssues(ctx, issueIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get aliases: %w", err)
+	}
+	for _, issue := range allIssues {
+		issue.Aliases = allAliases[issue.ID]
+	}
+
+	// Populate translations for all issues
+	allTranslations, err := store.GetTranslationsForIssues(ctx, issueIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get translations: %w", err)
+	}
+	for _, issue := range allIssues {
+		issue.Translations = allTranslations[issue.ID]
+	}
+
+	// Populate custom field values for all issues
+	allFields, err := store.GetFieldsForIssues(ctx, issueIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get fields: %w", err)
+	}
+	for _, issue := range allIssues {
+		issue.Fields = allFields[issue.ID]
+	}
+
 	// Write to JSONL file with atomic replace (temp file + rename)
 	dir := filepath.Dir(jsonlPath)
 	base := filepath.Base(jsonlPath)
@@ -546,9 +674,21 @@ func (s *Server) triggerExport(ctx context.Context, store storage.Storage, dbPat
 		_ = os.Remove(tempPath)
 	}()
 
+	redactor, err := redact.FromConfig()
+	if err != nil {
+		return err
+	}
+	fieldCipher, err := encryption.FromConfig(dir)
+	if err != nil {
+		return err
+	}
 	encoder := json.NewEncoder(tempFile)
 	for _, issue := range allIssues {
-		if err := encoder.Encode(issue); err != nil {
+		exported, err := fieldCipher.ForExport(redactor.ForExport(issue))
+		if err != nil {
+			return err
+		}
+		if err := encoder.Encode(exported); err != nil {
 			return fmt.Errorf("failed to encode issue %s: %w", issue.ID, err)
 		}
 	}
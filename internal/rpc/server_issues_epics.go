@@ -8,6 +8,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/steveyegge/beads/internal/redact"
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/util"
@@ -74,9 +76,15 @@ func updatesFromArgs(a UpdateArgs) map[string]interface{} {
 	if a.EstimatedMinutes != nil {
 		u["estimated_minutes"] = *a.EstimatedMinutes
 	}
+	if a.TimeboxMinutes != nil {
+		u["timebox_minutes"] = *a.TimeboxMinutes
+	}
 	if a.IssueType != nil {
 		u["issue_type"] = *a.IssueType
 	}
+	if a.Complexity != nil {
+		u["complexity"] = *a.Complexity
+	}
 	// Messaging fields (bd-kwro)
 	if a.Sender != nil {
 		u["sender"] = *a.Sender
@@ -117,6 +125,16 @@ func (s *Server) handleCreate(req *Request) Response {
 		}
 	}
 
+	// Strip secrets before they reach the database (redaction.* config)
+	redactor, err := redact.FromConfig()
+	if err != nil {
+		return Response{
+			Success: false,
+			Error:   err.Error(),
+		}
+	}
+	redactor.RedactTexts(&createArgs.Title, &createArgs.Description, &createArgs.Design, &createArgs.AcceptanceCriteria)
+
 	// Warn if creating an issue without a description (unless it's a test issue)
 	if createArgs.Description == "" && !strings.Contains(strings.ToLower(createArgs.Title), "test") {
 		// Log warning to daemon logs (stderr goes to daemon logs)
@@ -170,6 +188,8 @@ func (s *Server) handleCreate(req *Request) Response {
 		Assignee:           strValue(assignee),
 		ExternalRef:        externalRef,
 		EstimatedMinutes:   createArgs.EstimatedMinutes,
+		TimeboxMinutes:     createArgs.TimeboxMinutes,
+		Complexity:         types.Complexity(createArgs.Complexity),
 		Status:             types.StatusOpen,
 		// Messaging fields (bd-kwro)
 		Sender:    createArgs.Sender,
@@ -212,6 +232,14 @@ func (s *Server) handleCreate(req *Request) Response {
 		// If error getting parent or parent has no source_repo, continue with default
 	}
 	
+	fields, err := storage.NormalizeFields(ctx, store, createArgs.Fields, false)
+	if err != nil {
+		return Response{
+			Success: false,
+			Error:   err.Error(),
+		}
+	}
+
 	if err := store.CreateIssue(ctx, issue, s.reqActor(req)); err != nil {
 		return Response{
 			Success: false,
@@ -260,6 +288,17 @@ func (s *Server) handleCreate(req *Request) Response {
 		}
 	}
 
+	// Set custom fields if specified
+	if len(fields) > 0 {
+		if err := storage.SetIssueFields(ctx, store, issue.ID, fields, s.reqActor(req)); err != nil {
+			return Response{
+				Success: false,
+				Error:   fmt.Sprintf("failed to set fields: %v", err),
+			}
+		}
+		issue.Fields = fields
+	}
+
 	// Add dependencies if specified
 	for _, depSpec := range createArgs.Dependencies {
 		depSpec = strings.TrimSpace(depSpec)
@@ -305,6 +344,14 @@ func (s *Server) handleCreate(req *Request) Response {
 		}
 	}
 
+	// Checklist items referencing other issues become soft dependencies
+	if _, err := storage.SyncChecklistDependencies(ctx, store, issue.ID, issue.Description, s.reqActor(req)); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to sync checklist dependencies: %v", err),
+		}
+	}
+
 	// Emit mutation event for event-driven daemon
 	s.emitMutation(MutationCreate, issue.ID)
 
@@ -336,6 +383,53 @@ func (s *Server) handleUpdate(req *Request) Response {
 	updates := updatesFromArgs(updateArgs)
 	actor := s.reqActor(req)
 
+	redactor, err := redact.FromConfig()
+	if err != nil {
+		return Response{
+			Success: false,
+			Error:   err.Error(),
+		}
+	}
+	redactor.RedactUpdates(updates)
+
+	// With a locale, title and description update that translation instead
+	translated := false
+	if updateArgs.Locale != "" {
+		var title, description *string
+		if v, ok := updates["title"].(string); ok {
+			title = &v
+		}
+		if v, ok := updates["description"].(string); ok {
+			description = &v
+		}
+		delete(updates, "title")
+		delete(updates, "description")
+		if title != nil || description != nil {
+			if err := storage.UpdateTranslation(ctx, store, updateArgs.ID, updateArgs.Locale, title, description, actor); err != nil {
+				return Response{
+					Success: false,
+					Error:   fmt.Sprintf("failed to update translation: %v", err),
+				}
+			}
+			translated = true
+		}
+	}
+
+	if status, ok := updates["status"].(string); ok && status == string(types.StatusClosed) {
+		if err := storage.CheckVerifiedClose(ctx, store, updateArgs.ID, actor); err != nil {
+			return Response{
+				Success: false,
+				Error:   err.Error(),
+			}
+		}
+		if err := storage.CheckDefinitionOfDone(ctx, store, updateArgs.ID); err != nil {
+			return Response{
+				Success: false,
+				Error:   err.Error(),
+			}
+		}
+	}
+
 	// Apply regular field updates if any
 	if len(updates) > 0 {
 		if err := store.UpdateIssue(ctx, updateArgs.ID, updates, actor); err != nil {
@@ -344,6 +438,14 @@ func (s *Server) handleUpdate(req *Request) Response {
 				Error:   fmt.Sprintf("failed to update issue: %v", err),
 			}
 		}
+		if description, ok := updates["description"].(string); ok {
+			if _, err := storage.SyncChecklistDependencies(ctx, store, updateArgs.ID, description, actor); err != nil {
+				return Response{
+					Success: false,
+					Error:   fmt.Sprintf("failed to sync checklist dependencies: %v", err),
+				}
+			}
+		}
 	}
 
 	// Handle label operations
@@ -397,8 +499,18 @@ func (s *Server) handleUpdate(req *Request) Response {
 		}
 	}
 
+	// Set custom fields
+	if len(updateArgs.Fields) > 0 {
+		if err := storage.SetIssueFields(ctx, store, updateArgs.ID, updateArgs.Fields, actor); err != nil {
+			return Response{
+				Success: false,
+				Error:   fmt.Sprintf("failed to set fields: %v", err),
+			}
+		}
+	}
+
 	// Emit mutation event for event-driven daemon (only if any updates or label operations were performed)
-	if len(updates) > 0 || len(updateArgs.SetLabels) > 0 || len(updateArgs.AddLabels) > 0 || len(updateArgs.RemoveLabels) > 0 {
+	if len(updates) > 0 || translated || len(updateArgs.SetLabels) > 0 || len(updateArgs.AddLabels) > 0 || len(updateArgs.RemoveLabels) > 0 || len(updateArgs.Fields) > 0 {
 		s.emitMutation(MutationUpdate, updateArgs.ID)
 	}
 
@@ -435,7 +547,29 @@ func (s *Server) handleClose(req *Request) Response {
 	}
 
 	ctx := s.reqCtx(req)
-	if err := store.CloseIssue(ctx, closeArgs.ID, closeArgs.Reason, s.reqActor(req)); err != nil {
+	reason, err := storage.ResolveCloseReason(ctx, store, closeArgs.Reason, closeArgs.Note)
+	if err != nil {
+		return Response{
+			Success: false,
+			Error:   err.Error(),
+		}
+	}
+	if reason == "" {
+		reason = "Closed"
+	}
+	if err := storage.CheckVerifiedClose(ctx, store, closeArgs.ID, s.reqActor(req)); err != nil {
+		return Response{
+			Success: false,
+			Error:   err.Error(),
+		}
+	}
+	if err := storage.CheckDefinitionOfDone(ctx, store, closeArgs.ID); err != nil {
+		return Response{
+			Success: false,
+			Error:   err.Error(),
+		}
+	}
+	if err := store.CloseIssue(ctx, closeArgs.ID, reason, s.reqActor(req)); err != nil {
 		return Response{
 			Success: false,
 			Error:   fmt.Sprintf("failed to close issue: %v", err),
@@ -611,6 +745,22 @@ func (s *Server) handleList(req *Request) Response {
 	if len(labelsAny) > 0 {
 		filter.LabelsAny = labelsAny
 	}
+	complexity, parseErr := types.ParseComplexities(listArgs.Complexity)
+	if parseErr != nil {
+		return Response{
+			Success: false,
+			Error:   parseErr.Error(),
+		}
+	}
+	filter.Complexity = complexity
+	fields, fieldsErr := storage.NormalizeFields(s.reqCtx(req), store, listArgs.Fields, false)
+	if fieldsErr != nil {
+		return Response{
+			Success: false,
+			Error:   fieldsErr.Error(),
+		}
+	}
+	filter.Fields = fields
 	if len(listArgs.IDs) > 0 {
 		ids := util.NormalizeLabels(listArgs.IDs)
 		if len(ids) > 0 {
@@ -622,6 +772,7 @@ func (s *Server) handleList(req *Request) Response {
 	filter.TitleContains = listArgs.TitleContains
 	filter.DescriptionContains = listArgs.DescriptionContains
 	filter.NotesContains = listArgs.NotesContains
+	filter.CloseReason = listArgs.CloseReason
 	
 	// Date ranges - use parseTimeRPC helper for flexible formats
 	if listArgs.CreatedAfter != "" {
@@ -694,6 +845,17 @@ func (s *Server) handleList(req *Request) Response {
 	filter.PriorityMin = listArgs.PriorityMin
 	filter.PriorityMax = listArgs.PriorityMax
 
+	if listArgs.Sort != "" {
+		keys, err := types.ParseSortKeys(listArgs.Sort)
+		if err != nil {
+			return Response{
+				Success: false,
+				Error:   err.Error(),
+			}
+		}
+		filter.Sort = keys
+	}
+
 	// Guard against excessive ID lists to avoid SQLite parameter limits
 	const maxIDs = 1000
 	if len(filter.IDs) > maxIDs {
@@ -704,7 +866,14 @@ func (s *Server) handleList(req *Request) Response {
 	}
 
 	ctx := s.reqCtx(req)
-	issues, err := store.SearchIssues(ctx, listArgs.Query, filter)
+	var issues []*types.Issue
+	var nextCursor string
+	var err error
+	if listArgs.Paginated() {
+		issues, nextCursor, err = storage.SearchIssuesPage(ctx, store, listArgs.Query, filter, listArgs.Cursor, listArgs.PageSize)
+	} else {
+		issues, err = store.SearchIssues(ctx, listArgs.Query, filter)
+	}
 	if err != nil {
 		return Response{
 			Success: false,
@@ -717,6 +886,12 @@ func (s *Server) handleList(req *Request) Response {
 		labels, _ := store.GetLabels(ctx, issue.ID)
 		issue.Labels = labels
 	}
+	if err := storage.Localize(ctx, store, issues, listArgs.Locale); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to get translations: %v", err),
+		}
+	}
 
 	// Get dependency counts in bulk (single query instead of N queries)
 	issueIDs := make([]string, len(issues))
@@ -724,6 +899,13 @@ func (s *Server) handleList(req *Request) Response {
 		issueIDs[i] = issue.ID
 	}
 	depCounts, _ := store.GetDependencyCounts(ctx, issueIDs)
+	fieldsMap, _ := store.GetFieldsForIssues(ctx, issueIDs)
+	for _, issue := range issues {
+		issue.Fields = fieldsMap[issue.ID]
+	}
+
+	// Child completion for epics, with estimates when rolled up
+	progress, _ := storage.ChildProgress(ctx, store, storage.EpicIDs(issues), listArgs.RollUp)
 
 	// Build response with counts
 	issuesWithCounts := make([]*types.IssueWithCounts, len(issues))
@@ -736,10 +918,16 @@ func (s *Server) handleList(req *Request) Response {
 			Issue:           issue,
 			DependencyCount: counts.DependencyCount,
 			DependentCount:  counts.DependentCount,
+			Progress:        progress[issue.ID],
 		}
 	}
 
-	data, _ := json.Marshal(issuesWithCounts)
+	var data []byte
+	if listArgs.Paginated() {
+		data, _ = json.Marshal(ListPage{Issues: issuesWithCounts, NextCursor: nextCursor})
+	} else {
+		data, _ = json.Marshal(issuesWithCounts)
+	}
 	return Response{
 		Success: true,
 		Data:    data,
@@ -1048,9 +1236,17 @@ func (s *Server) handleShow(req *Request) Response {
 	// Get dependencies and dependents with metadata (including dependency type)
 	var deps []*types.IssueWithDependencyMetadata
 	var dependents []*types.IssueWithDependencyMetadata
+	var stateTime *types.StateTime
+	var lease *types.Lease
+	var externalDeps []*types.ExternalDependency
 	if sqliteStore, ok := store.(*sqlite.SQLiteStorage); ok {
 		deps, _ = sqliteStore.GetDependenciesWithMetadata(ctx, issue.ID)
+		externalDeps, _ = sqliteStore.GetExternalDependencies(ctx, issue.ID)
 		dependents, _ = sqliteStore.GetDependentsWithMetadata(ctx, issue.ID)
+		if times, err := sqliteStore.GetStateTimes(ctx, time.Now()); err == nil {
+			stateTime = times[issue.ID]
+		}
+		lease, _ = sqliteStore.GetLease(ctx, issue.ID)
 	} else {
 		// Fallback for non-SQLite storage (won't have dependency type metadata)
 		regularDeps, _ := store.GetDependencies(ctx, issue.ID)
@@ -1072,17 +1268,36 @@ func (s *Server) handleShow(req *Request) Response {
 	// Create detailed response with related data
 	type IssueDetails struct {
 		*types.Issue
-		Labels       []string                              `json:"labels,omitempty"`
-		Dependencies []*types.IssueWithDependencyMetadata `json:"dependencies,omitempty"`
-		Dependents   []*types.IssueWithDependencyMetadata `json:"dependents,omitempty"`
+		Labels               []string                             `json:"labels,omitempty"`
+		Dependencies         []*types.IssueWithDependencyMetadata `json:"dependencies,omitempty"`
+		Dependents           []*types.IssueWithDependencyMetadata `json:"dependents,omitempty"`
+		ExternalDependencies []*types.ExternalDependency          `json:"external_dependencies,omitempty"`
+		StateTime            *types.StateTime                     `json:"state_time,omitempty"`
+		TimeLogged           *types.WorkLogTotals                 `json:"time_logged,omitempty"`
+		Lease                *types.Lease                         `json:"lease,omitempty"`
+		Timebox              *types.Timebox                       `json:"timebox,omitempty"`
+		CommentCount         int                                  `json:"comment_count,omitempty"`
+		DefinitionOfDone     []types.DoneCriterion                `json:"definition_of_done,omitempty"`
+	}
+
+	comments, _ := store.GetIssueComments(ctx, issue.ID)
+	var timeLogged *types.WorkLogTotals
+	if entries, err := store.GetWorkLog(ctx, issue.ID); err == nil && len(entries) > 0 {
+		timeLogged = types.SumWorkLog(entries)
 	}
-
 	details := &IssueDetails{
-		Issue:        issue,
-		Labels:       labels,
-		Dependencies: deps,
-		Dependents:   dependents,
-	}
+		Issue:                issue,
+		Labels:               labels,
+		Dependencies:         deps,
+		Dependents:           dependents,
+		ExternalDependencies: externalDeps,
+		StateTime:            stateTime,
+		TimeLogged:           timeLogged,
+		Lease:                lease,
+		CommentCount:         len(comments),
+	}
+	details.DefinitionOfDone, _ = storage.GetDoneCriteria(ctx, store, issue.ID)
+	details.Timebox, _ = storage.GetTimebox(ctx, store, issue)
 
 	data, _ := json.Marshal(details)
 	return Response{
@@ -1117,11 +1332,52 @@ func (s *Server) handleReady(req *Request) Response {
 		Labels:     util.NormalizeLabels(readyArgs.Labels),
 		LabelsAny:  util.NormalizeLabels(readyArgs.LabelsAny),
 	}
+	complexity, err := types.ParseComplexities(readyArgs.Complexity)
+	if err != nil {
+		return Response{
+			Success: false,
+			Error:   err.Error(),
+		}
+	}
+	wf.Complexity = complexity
 	if readyArgs.Assignee != "" && !readyArgs.Unassigned {
 		wf.Assignee = &readyArgs.Assignee
 	}
 
 	ctx := s.reqCtx(req)
+	if readyArgs.Claim {
+		var claimed *types.Issue
+		var err error
+		var ttl time.Duration
+		if readyArgs.ClaimTTL != "" {
+			if ttl, err = time.ParseDuration(readyArgs.ClaimTTL); err != nil {
+				return Response{
+					Success: false,
+					Error:   fmt.Sprintf("invalid claim ttl: %v", err),
+				}
+			}
+		}
+		if sqliteStore, ok := store.(*sqlite.SQLiteStorage); ok {
+			claimed, err = sqliteStore.ClaimReadyWorkWithLease(ctx, wf, s.reqActor(req), ttl)
+		} else {
+			claimed, err = store.ClaimReadyWork(ctx, wf, s.reqActor(req))
+		}
+		if err != nil {
+			return Response{
+				Success: false,
+				Error:   err.Error(),
+			}
+		}
+		if claimed != nil {
+			s.emitMutation(MutationUpdate, claimed.ID)
+		}
+		data, _ := json.Marshal(claimed)
+		return Response{
+			Success: true,
+			Data:    data,
+		}
+	}
+
 	issues, err := store.GetReadyWork(ctx, wf)
 	if err != nil {
 		return Response{
@@ -1129,6 +1385,12 @@ func (s *Server) handleReady(req *Request) Response {
 			Error:   fmt.Sprintf("failed to get ready work: %v", err),
 		}
 	}
+	if err := storage.Localize(ctx, store, issues, readyArgs.Locale); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to get translations: %v", err),
+		}
+	}
 
 	data, _ := json.Marshal(issues)
 	return Response{
@@ -1185,8 +1447,22 @@ func (s *Server) handleStats(req *Request) Response {
 		}
 	}
 
+	var statsArgs StatsArgs
+	if len(req.Args) > 0 {
+		if err := json.Unmarshal(req.Args, &statsArgs); err != nil {
+			return Response{
+				Success: false,
+				Error:   fmt.Sprintf("invalid stats args: %v", err),
+			}
+		}
+	}
+
 	ctx := s.reqCtx(req)
-	stats, err := store.GetStatistics(ctx)
+	getStats := store.GetStatistics
+	if sqliteStore, ok := store.(*sqlite.SQLiteStorage); ok && statsArgs.Exact {
+		getStats = sqliteStore.GetExactStatistics
+	}
+	stats, err := getStats(ctx)
 	if err != nil {
 		return Response{
 			Success: false,
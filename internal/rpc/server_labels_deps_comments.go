@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/steveyegge/beads/internal/redact"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -137,6 +138,15 @@ func (s *Server) handleCommentAdd(req *Request) Response {
 
 	store := s.storage
 
+	redactor, err := redact.FromConfig()
+	if err != nil {
+		return Response{
+			Success: false,
+			Error:   err.Error(),
+		}
+	}
+	commentArgs.Text, _ = redactor.Redact(commentArgs.Text)
+
 	ctx := s.reqCtx(req)
 	comment, err := store.AddIssueComment(ctx, commentArgs.ID, commentArgs.Author, commentArgs.Text)
 	if err != nil {
@@ -156,6 +166,69 @@ func (s *Server) handleCommentAdd(req *Request) Response {
 	}
 }
 
+func (s *Server) handleCommentUpdate(req *Request) Response {
+	var commentArgs CommentUpdateArgs
+	if err := json.Unmarshal(req.Args, &commentArgs); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("invalid comment update args: %v", err),
+		}
+	}
+
+	redactor, err := redact.FromConfig()
+	if err != nil {
+		return Response{
+			Success: false,
+			Error:   err.Error(),
+		}
+	}
+	commentArgs.Text, _ = redactor.Redact(commentArgs.Text)
+
+	ctx := s.reqCtx(req)
+	comment, err := s.storage.UpdateIssueComment(ctx, commentArgs.CommentID, commentArgs.Text, s.reqActor(req))
+	if err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to update comment: %v", err),
+		}
+	}
+
+	s.emitMutation(MutationComment, comment.IssueID)
+
+	data, _ := json.Marshal(comment)
+	return Response{
+		Success: true,
+		Data:    data,
+	}
+}
+
+func (s *Server) handleCommentDelete(req *Request) Response {
+	var commentArgs CommentDeleteArgs
+	if err := json.Unmarshal(req.Args, &commentArgs); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("invalid comment delete args: %v", err),
+		}
+	}
+
+	ctx := s.reqCtx(req)
+	comment, err := s.storage.DeleteIssueComment(ctx, commentArgs.CommentID, s.reqActor(req))
+	if err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to delete comment: %v", err),
+		}
+	}
+
+	s.emitMutation(MutationComment, comment.IssueID)
+
+	data, _ := json.Marshal(comment)
+	return Response{
+		Success: true,
+		Data:    data,
+	}
+}
+
 func (s *Server) handleBatch(req *Request) Response {
 	var batchArgs BatchArgs
 	if err := json.Unmarshal(req.Args, &batchArgs); err != nil {
@@ -118,6 +118,9 @@ func (s *Server) Stop() error {
 		s.listener = nil
 		s.mu.Unlock()
 
+		s.stopRemote()
+		s.closeMetricsServer()
+
 		if listener != nil {
 			if closeErr := listener.Close(); closeErr != nil {
 				err = fmt.Errorf("failed to close listener: %w", closeErr)
@@ -217,6 +220,12 @@ func (s *Server) handleConnection(conn net.Conn) {
 			continue
 		}
 
+		// A subscription takes over the connection until the client leaves
+		if req.Operation == OpSubscribe {
+			s.handleSubscribe(conn, reader, writer, &req)
+			return
+		}
+
 		// Set write deadline for the response
 		if err := conn.SetWriteDeadline(time.Now().Add(s.requestTimeout)); err != nil {
 			return
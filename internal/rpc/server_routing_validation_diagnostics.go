@@ -11,6 +11,8 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
 	"golang.org/x/mod/semver"
 )
@@ -204,6 +206,10 @@ func (s *Server) handleRequest(req *Request) Response {
 		resp = s.handleCommentList(req)
 	case OpCommentAdd:
 		resp = s.handleCommentAdd(req)
+	case OpCommentUpdate:
+		resp = s.handleCommentUpdate(req)
+	case OpCommentDelete:
+		resp = s.handleCommentDelete(req)
 	case OpBatch:
 		resp = s.handleBatch(req)
 	
@@ -238,8 +244,22 @@ func (s *Server) handleRequest(req *Request) Response {
 }
 
 // Adapter helpers
-func (s *Server) reqCtx(_ *Request) context.Context {
-	return context.Background()
+
+// reqCtx returns the context a request's writes run under: part of the
+// client's operation if it sent one, or an operation of its own
+func (s *Server) reqCtx(req *Request) context.Context {
+	ctx := context.Background()
+	if req == nil {
+		return ctx
+	}
+	if req.Op != nil && req.Op.Key != "" {
+		actor := req.Op.Actor
+		if actor == "" {
+			actor = s.reqActor(req)
+		}
+		return sqlite.WithOperation(ctx, req.Op.Key, actor, req.Op.Command)
+	}
+	return sqlite.WithOperation(ctx, sqlite.NewOperationKey(), s.reqActor(req), req.Operation)
 }
 
 func (s *Server) reqActor(req *Request) string {
@@ -370,6 +390,10 @@ func (s *Server) handleMetrics(_ *Request) Response {
 	snapshot := s.metrics.Snapshot(
 		int(atomic.LoadInt32(&s.activeConns)),
 	)
+	if pooled, ok := s.storage.(interface{ PoolStats() storage.PoolStats }); ok {
+		stats := pooled.PoolStats()
+		snapshot.DBPool = &stats
+	}
 
 	data, _ := json.Marshal(snapshot)
 	return Response{
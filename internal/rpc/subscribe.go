@@ -0,0 +1,222 @@
+package rpc
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"time"
+
+	"github.com/steveyegge/beads/internal/events"
+	"github.com/steveyegge/beads/internal/eventstream"
+)
+
+// subscribePollInterval bounds how late a subscriber sees changes the daemon
+// didn't make itself (e.g. direct-mode writes by another process)
+const subscribePollInterval = time.Second
+
+// handleSubscribe streams events to the connection as JSON lines, one
+// eventstream.Message each, after acknowledging with a SubscribeResponse.
+// It returns when the client disconnects or the server stops.
+func (s *Server) handleSubscribe(conn net.Conn, reader *bufio.Reader, writer *bufio.Writer, req *Request) {
+	start := time.Now()
+	fail := func(err error) {
+		s.metrics.RecordError(req.Operation)
+		_ = conn.SetWriteDeadline(time.Now().Add(s.requestTimeout))
+		_ = s.writeResponse(writer, Response{Success: false, Error: err.Error()})
+	}
+
+	if err := s.validateDatabaseBinding(req); err != nil {
+		fail(err)
+		return
+	}
+	if err := s.checkVersionCompatibility(req.ClientVersion); err != nil {
+		fail(err)
+		return
+	}
+	var args SubscribeArgs
+	if err := json.Unmarshal(req.Args, &args); err != nil {
+		fail(fmt.Errorf("invalid subscribe args: %w", err))
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	started := func(cursor int64) error {
+		data, _ := json.Marshal(SubscribeResponse{Cursor: cursor})
+		_ = conn.SetWriteDeadline(time.Now().Add(s.requestTimeout))
+		if err := s.writeResponse(writer, Response{Success: true, Data: data}); err != nil {
+			return err
+		}
+		s.metrics.RecordRequest(req.Operation, time.Since(start))
+
+		// The client sends nothing more; a read returning means it went away
+		_ = conn.SetReadDeadline(time.Time{})
+		go func() {
+			_, _ = io.Copy(io.Discard, reader)
+			cancel()
+		}()
+		return nil
+	}
+	send := func(messages []*eventstream.Message) error {
+		return s.writeMessages(conn, writer, messages)
+	}
+	if err := s.streamEvents(ctx, &args, started, send); err != nil {
+		fail(err)
+	}
+}
+
+// streamEvents runs a subscription for the socket and gRPC transports. It
+// calls started with the cursor once events are being followed, then send
+// with each batch of new events, until ctx is cancelled, the server stops
+// or a callback fails. It returns an error only if the subscription
+// couldn't start.
+func (s *Server) streamEvents(ctx context.Context, args *SubscribeArgs, started func(cursor int64) error, send func([]*eventstream.Message) error) error {
+	src, ok := s.storage.(eventstream.Source)
+	if !ok {
+		return errors.New("event streams require SQLite storage")
+	}
+	cursor, err := events.Start(ctx, src, args.Since)
+	if err != nil {
+		return err
+	}
+
+	wake := s.addSubscriber()
+	defer s.removeSubscriber(wake)
+	if err := started(cursor); err != nil {
+		return nil
+	}
+
+	ticker := time.NewTicker(subscribePollInterval)
+	defer ticker.Stop()
+	for {
+		messages, next, err := eventstream.Poll(ctx, src, cursor, args.Filter)
+		cursor = next
+		if err != nil && ctx.Err() == nil {
+			fmt.Fprintf(os.Stderr, "Warning: event stream: %v\n", err)
+		}
+		if len(messages) > 0 {
+			if err := send(messages); err != nil {
+				return nil
+			}
+			s.lastActivityTime.Store(time.Now())
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.shutdownChan:
+			return nil
+		case <-wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Server) writeMessages(conn net.Conn, writer *bufio.Writer, messages []*eventstream.Message) error {
+	if err := conn.SetWriteDeadline(time.Now().Add(s.requestTimeout)); err != nil {
+		return err
+	}
+	for _, m := range messages {
+		data, err := json.Marshal(m)
+		if err != nil {
+			return fmt.Errorf("failed to marshal event %d: %w", m.ID, err)
+		}
+		if _, err := writer.Write(data); err != nil {
+			return err
+		}
+		if err := writer.WriteByte('\n'); err != nil {
+			return err
+		}
+	}
+	return writer.Flush()
+}
+
+func (s *Server) addSubscriber() chan struct{} {
+	wake := make(chan struct{}, 1)
+	s.subscribersMu.Lock()
+	s.subscribers[wake] = struct{}{}
+	s.subscribersMu.Unlock()
+	return wake
+}
+
+func (s *Server) removeSubscriber(wake chan struct{}) {
+	s.subscribersMu.Lock()
+	delete(s.subscribers, wake)
+	s.subscribersMu.Unlock()
+}
+
+// wakeSubscribers tells every subscriber to check for new events. It never
+// blocks: a subscriber that hasn't caught up yet is already due to check.
+func (s *Server) wakeSubscribers() {
+	s.subscribersMu.Lock()
+	defer s.subscribersMu.Unlock()
+	for wake := range s.subscribers {
+		select {
+		case wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Subscribe streams events from the daemon, calling fn with each, until ctx
+// is cancelled (returning nil), fn returns an error, or the daemon goes
+// away. The subscription takes over the connection, so the client can't
+// send other requests afterwards.
+func (c *Client) Subscribe(ctx context.Context, args *SubscribeArgs, fn func(*eventstream.Message) error) error {
+	if c.remote != nil {
+		return c.subscribeRemote(ctx, args, fn)
+	}
+	if c.timeout > 0 {
+		if err := c.conn.SetDeadline(time.Now().Add(c.timeout)); err != nil {
+			return fmt.Errorf("failed to set deadline: %w", err)
+		}
+	}
+	if err := c.writeRequest(OpSubscribe, args, ""); err != nil {
+		return err
+	}
+	reader := bufio.NewReader(c.conn)
+	line, err := reader.ReadBytes('\n')
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	var resp Response
+	if err := json.Unmarshal(line, &resp); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	if !resp.Success {
+		return fmt.Errorf("operation failed: %s", resp.Error)
+	}
+
+	// Events may be hours apart
+	if err := c.conn.SetDeadline(time.Time{}); err != nil {
+		return fmt.Errorf("failed to clear deadline: %w", err)
+	}
+	stop := context.AfterFunc(ctx, func() { _ = c.conn.Close() })
+	defer stop()
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			if errors.Is(err, io.EOF) {
+				return errors.New("daemon closed the event stream")
+			}
+			return fmt.Errorf("failed to read event: %w", err)
+		}
+		var m eventstream.Message
+		if err := json.Unmarshal(line, &m); err != nil {
+			return fmt.Errorf("failed to unmarshal event: %w", err)
+		}
+		if err := fn(&m); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/eventstream"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSubscribe(t *testing.T) {
+	server, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	subscriber, err := TryConnect(server.socketPath)
+	if err != nil || subscriber == nil {
+		t.Fatalf("TryConnect: %v", err)
+	}
+	defer subscriber.Close()
+	subscriber.dbPath = client.dbPath
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	received := make(chan *eventstream.Message, 10)
+	done := make(chan error, 1)
+	filter := &eventstream.Filter{Types: []types.EventType{types.EventCreated, types.EventClosed}}
+	go func() {
+		done <- subscriber.Subscribe(ctx, &SubscribeArgs{Filter: filter}, func(m *eventstream.Message) error {
+			received <- m
+			return nil
+		})
+	}()
+	// Let the subscription start before making changes
+	time.Sleep(100 * time.Millisecond)
+
+	resp, err := client.Create(&CreateArgs{Title: "Streamed", IssueType: "task", Priority: 2})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	var issue types.Issue
+	if err := json.Unmarshal(resp.Data, &issue); err != nil {
+		t.Fatal(err)
+	}
+	title := "Renamed"
+	if _, err := client.Update(&UpdateArgs{ID: issue.ID, Title: &title}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if _, err := client.CloseIssue(&CloseArgs{ID: issue.ID}); err != nil {
+		t.Fatalf("CloseIssue: %v", err)
+	}
+
+	for _, want := range []types.EventType{types.EventCreated, types.EventClosed} {
+		select {
+		case m := <-received:
+			if m.EventType != want || m.IssueID != issue.ID {
+				t.Errorf("got %s %s, want %s %s", m.EventType, m.IssueID, want, issue.ID)
+			}
+			if m.Issue == nil || m.Issue.ID != issue.ID {
+				t.Errorf("event %d should carry the issue, got %+v", m.ID, m.Issue)
+			}
+		case err := <-done:
+			t.Fatalf("stream ended early: %v", err)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for %s", want)
+		}
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Subscribe after cancel: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Subscribe did not return after cancel")
+	}
+}
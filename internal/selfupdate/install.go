@@ -0,0 +1,73 @@
+package selfupdate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// BackupPath is where Install keeps the binary it replaced
+func BackupPath(exe string) string {
+	return exe + ".old"
+}
+
+// Install replaces the executable at exe with binary. The new binary is
+// written next to exe and must pass check (typically: it runs and reports
+// its version) before anything is replaced; the old binary is kept at
+// BackupPath(exe) for Rollback. If the swap fails halfway, the old binary
+// is put back.
+func Install(exe string, binary []byte, check func(path string) error) error {
+	info, err := os.Stat(exe)
+	if err != nil {
+		return err
+	}
+	staged := exe + ".new"
+	// #nosec G306 -- executables must be world-executable
+	if err := os.WriteFile(staged, binary, info.Mode().Perm()|0o111); err != nil {
+		return fmt.Errorf("cannot write next to %s: %w", exe, err)
+	}
+	if check != nil {
+		if err := check(staged); err != nil {
+			_ = os.Remove(staged)
+			return fmt.Errorf("new binary failed its check: %w", err)
+		}
+	}
+	return swap(exe, staged)
+}
+
+// Rollback puts back the binary Install replaced. The binary it replaces
+// becomes the backup, so a second Rollback undoes the first.
+func Rollback(exe string) error {
+	backup := BackupPath(exe)
+	if _, err := os.Stat(backup); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("no previous binary at %s", backup)
+		}
+		return err
+	}
+	staged := exe + ".new"
+	if err := os.Rename(backup, staged); err != nil {
+		return err
+	}
+	return swap(exe, staged)
+}
+
+// swap moves exe to its backup path and staged to exe, restoring exe if
+// the second rename fails. Renaming works on a running executable on all
+// platforms bd supports, where deleting it does not on Windows.
+func swap(exe, staged string) error {
+	backup := BackupPath(exe)
+	_ = os.Remove(backup)
+	if err := os.Rename(exe, backup); err != nil {
+		_ = os.Remove(staged)
+		return fmt.Errorf("cannot move %s aside: %w", exe, err)
+	}
+	if err := os.Rename(staged, exe); err != nil {
+		if restoreErr := os.Rename(backup, exe); restoreErr != nil {
+			return fmt.Errorf("cannot install new binary (%v) or restore the old one from %s: %w", err, backup, restoreErr)
+		}
+		_ = os.Remove(staged)
+		return fmt.Errorf("cannot install new binary, kept the old one: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,280 @@
+// Package selfupdate finds bd releases in a release feed, verifies the
+// downloaded archive against the release's signed checksums, and replaces
+// the running binary with a copy of the old one kept for rollback.
+package selfupdate
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+)
+
+// DefaultFeed is the GitHub releases API for bd. Mirrors serve the same
+// JSON at their own URL.
+const DefaultFeed = "https://api.github.com/repos/steveyegge/beads"
+
+// Assets every release carries next to the archives
+const (
+	ChecksumsAsset = "checksums.txt"
+	SignatureAsset = "checksums.txt.sig" // ed25519 signature of checksums.txt
+)
+
+// maxDownloadBytes bounds any single download
+const maxDownloadBytes = 256 << 20
+
+// Asset is a file attached to a release
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+// Release is a published bd release
+type Release struct {
+	Tag        string  `json:"tag_name"`
+	Prerelease bool    `json:"prerelease"`
+	Assets     []Asset `json:"assets"`
+}
+
+// Version is the release tag without its "v" prefix
+func (r *Release) Version() string {
+	return strings.TrimPrefix(r.Tag, "v")
+}
+
+// Asset returns the named asset, or nil
+func (r *Release) Asset(name string) *Asset {
+	for i := range r.Assets {
+		if r.Assets[i].Name == name {
+			return &r.Assets[i]
+		}
+	}
+	return nil
+}
+
+// ArchiveName is the name of the release archive for a platform, matching
+// the archive name_template in .goreleaser.yml
+func ArchiveName(version, goos, goarch string) string {
+	ext := ".tar.gz"
+	if goos == "windows" {
+		ext = ".zip"
+	}
+	return fmt.Sprintf("beads_%s_%s_%s%s", strings.TrimPrefix(version, "v"), goos, goarch, ext)
+}
+
+// ParsePublicKey decodes a base64 ed25519 public key
+func ParsePublicKey(s string) (ed25519.PublicKey, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	if len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid public key: want %d bytes, got %d", ed25519.PublicKeySize, len(key))
+	}
+	return ed25519.PublicKey(key), nil
+}
+
+// Updater fetches releases from a feed
+type Updater struct {
+	Feed      string            // releases API base URL; DefaultFeed if empty
+	PublicKey ed25519.PublicKey // when set, checksums.txt must carry a valid signature
+	Client    *http.Client
+	UserAgent string
+}
+
+func (u *Updater) client() *http.Client {
+	if u.Client != nil {
+		return u.Client
+	}
+	return &http.Client{Timeout: 2 * time.Minute}
+}
+
+func (u *Updater) get(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	// GitHub's API requires a User-Agent
+	ua := u.UserAgent
+	if ua == "" {
+		ua = "beads-cli-self-update"
+	}
+	req.Header.Set("User-Agent", ua)
+	resp, err := u.client().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxDownloadBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("GET %s: %w", url, err)
+	}
+	if len(data) > maxDownloadBytes {
+		return nil, fmt.Errorf("GET %s: response larger than %d MB", url, maxDownloadBytes>>20)
+	}
+	return data, nil
+}
+
+func (u *Updater) release(ctx context.Context, suffix string) (*Release, error) {
+	feed := strings.TrimSuffix(u.Feed, "/")
+	if feed == "" {
+		feed = DefaultFeed
+	}
+	data, err := u.get(ctx, feed+"/releases/"+suffix)
+	if err != nil {
+		return nil, err
+	}
+	var rel Release
+	if err := json.Unmarshal(data, &rel); err != nil {
+		return nil, fmt.Errorf("invalid release feed response: %w", err)
+	}
+	if rel.Tag == "" {
+		return nil, fmt.Errorf("release feed returned no tag")
+	}
+	return &rel, nil
+}
+
+// Latest returns the newest stable release
+func (u *Updater) Latest(ctx context.Context) (*Release, error) {
+	return u.release(ctx, "latest")
+}
+
+// Release returns the release tagged with version ("0.31.0" or "v0.31.0")
+func (u *Updater) Release(ctx context.Context, version string) (*Release, error) {
+	return u.release(ctx, "tags/v"+strings.TrimPrefix(version, "v"))
+}
+
+// Download fetches rel's archive for a platform, verifies it against the
+// release checksums (and their signature when a public key is set), and
+// returns the bd binary inside it. signed reports whether the checksums
+// were signature-checked.
+func (u *Updater) Download(ctx context.Context, rel *Release, goos, goarch string) (binary []byte, signed bool, err error) {
+	name := ArchiveName(rel.Version(), goos, goarch)
+	archive := rel.Asset(name)
+	if archive == nil {
+		return nil, false, fmt.Errorf("release %s has no build for %s/%s (%s)", rel.Tag, goos, goarch, name)
+	}
+	sumsAsset := rel.Asset(ChecksumsAsset)
+	if sumsAsset == nil {
+		return nil, false, fmt.Errorf("release %s has no %s; refusing to install an unverified binary", rel.Tag, ChecksumsAsset)
+	}
+	sums, err := u.get(ctx, sumsAsset.URL)
+	if err != nil {
+		return nil, false, err
+	}
+	if u.PublicKey != nil {
+		sigAsset := rel.Asset(SignatureAsset)
+		if sigAsset == nil {
+			return nil, false, fmt.Errorf("release %s has no %s but a signing key is configured", rel.Tag, SignatureAsset)
+		}
+		sig, err := u.get(ctx, sigAsset.URL)
+		if err != nil {
+			return nil, false, err
+		}
+		if err := VerifySignature(u.PublicKey, sums, sig); err != nil {
+			return nil, false, fmt.Errorf("%s: %w", ChecksumsAsset, err)
+		}
+		signed = true
+	}
+	want, err := LookupChecksum(sums, name)
+	if err != nil {
+		return nil, signed, err
+	}
+
+	data, err := u.get(ctx, archive.URL)
+	if err != nil {
+		return nil, signed, err
+	}
+	if got := sha256.Sum256(data); hex.EncodeToString(got[:]) != want {
+		return nil, signed, fmt.Errorf("checksum mismatch for %s: download corrupted or tampered with", name)
+	}
+	binary, err = ExtractBinary(data, name, goos)
+	return binary, signed, err
+}
+
+// VerifySignature checks an ed25519 signature, given raw or base64-encoded
+func VerifySignature(key ed25519.PublicKey, message, sig []byte) error {
+	if len(sig) != ed25519.SignatureSize {
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(sig)))
+		if err != nil || len(decoded) != ed25519.SignatureSize {
+			return fmt.Errorf("malformed signature")
+		}
+		sig = decoded
+	}
+	if !ed25519.Verify(key, message, sig) {
+		return fmt.Errorf("signature does not match the configured public key")
+	}
+	return nil
+}
+
+// LookupChecksum finds the sha256 of name in a checksums.txt
+// ("<hex>  <name>" per line)
+func LookupChecksum(sums []byte, name string) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(sums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+	return "", fmt.Errorf("%s lists no checksum for %s", ChecksumsAsset, name)
+}
+
+// ExtractBinary returns the bd executable from a release archive
+func ExtractBinary(archive []byte, name, goos string) ([]byte, error) {
+	want := "bd"
+	if goos == "windows" {
+		want = "bd.exe"
+	}
+	if strings.HasSuffix(name, ".zip") {
+		zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", name, err)
+		}
+		for _, f := range zr.File {
+			if path.Base(f.Name) != want || f.FileInfo().IsDir() {
+				continue
+			}
+			rc, err := f.Open()
+			if err != nil {
+				return nil, err
+			}
+			defer func() { _ = rc.Close() }()
+			return io.ReadAll(io.LimitReader(rc, maxDownloadBytes))
+		}
+		return nil, fmt.Errorf("%s contains no %s", name, want)
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", name, err)
+	}
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("%s contains no %s", name, want)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", name, err)
+		}
+		if hdr.Typeflag == tar.TypeReg && path.Base(hdr.Name) == want {
+			return io.ReadAll(io.LimitReader(tr, maxDownloadBytes))
+		}
+	}
+}
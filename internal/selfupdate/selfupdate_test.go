@@ -0,0 +1,169 @@
+package selfupdate
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func tarGz(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, body := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(body)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// releaseFeed serves a one-release feed; tamper swaps the archive after
+// its checksum was published
+func releaseFeed(t *testing.T, priv ed25519.PrivateKey, tamper bool) *httptest.Server {
+	t.Helper()
+	name := ArchiveName("0.31.0", "linux", "amd64")
+	archive := tarGz(t, map[string]string{"LICENSE": "MIT", "bd": "new binary"})
+	sum := sha256.Sum256(archive)
+	sums := fmt.Sprintf("%s  %s\n", hex.EncodeToString(sum[:]), name)
+	if tamper {
+		archive = tarGz(t, map[string]string{"bd": "evil binary"})
+	}
+
+	mux := http.NewServeMux()
+	var srv *httptest.Server
+	mux.HandleFunc("/releases/latest", func(w http.ResponseWriter, r *http.Request) {
+		rel := Release{Tag: "v0.31.0", Assets: []Asset{
+			{Name: name, URL: srv.URL + "/dl/archive"},
+			{Name: ChecksumsAsset, URL: srv.URL + "/dl/sums"},
+		}}
+		if priv != nil {
+			rel.Assets = append(rel.Assets, Asset{Name: SignatureAsset, URL: srv.URL + "/dl/sig"})
+		}
+		_ = json.NewEncoder(w).Encode(rel)
+	})
+	mux.HandleFunc("/dl/archive", func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write(archive) })
+	mux.HandleFunc("/dl/sums", func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write([]byte(sums)) })
+	mux.HandleFunc("/dl/sig", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(base64.StdEncoding.EncodeToString(ed25519.Sign(priv, []byte(sums)))))
+	})
+	srv = httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestDownload(t *testing.T) {
+	ctx := context.Background()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := releaseFeed(t, priv, false)
+	u := &Updater{Feed: srv.URL, PublicKey: pub}
+	rel, err := u.Latest(ctx)
+	if err != nil || rel.Version() != "0.31.0" {
+		t.Fatalf("Latest = %+v, %v", rel, err)
+	}
+	binary, signed, err := u.Download(ctx, rel, "linux", "amd64")
+	if err != nil || !signed || string(binary) != "new binary" {
+		t.Fatalf("Download = %q, %v, %v", binary, signed, err)
+	}
+	if _, _, err := u.Download(ctx, rel, "plan9", "amd64"); err == nil {
+		t.Error("expected an error for a platform without a build")
+	}
+
+	// Signed by someone else
+	otherPub, _, _ := ed25519.GenerateKey(rand.Reader)
+	u.PublicKey = otherPub
+	if _, _, err := u.Download(ctx, rel, "linux", "amd64"); err == nil || !strings.Contains(err.Error(), "signature") {
+		t.Errorf("wrong key: err = %v", err)
+	}
+
+	// Archive doesn't match the published checksum
+	srv = releaseFeed(t, nil, true)
+	u = &Updater{Feed: srv.URL}
+	if rel, err = u.Latest(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := u.Download(ctx, rel, "linux", "amd64"); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Errorf("tampered archive: err = %v", err)
+	}
+}
+
+func TestInstallAndRollback(t *testing.T) {
+	exe := filepath.Join(t.TempDir(), "bd")
+	if err := os.WriteFile(exe, []byte("old"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	read := func(path string) string {
+		data, _ := os.ReadFile(path)
+		return string(data)
+	}
+
+	// A binary that fails its check changes nothing
+	err := Install(exe, []byte("broken"), func(string) error { return errors.New("exit status 1") })
+	if err == nil || read(exe) != "old" {
+		t.Fatalf("failed check: err = %v, exe = %q", err, read(exe))
+	}
+	if _, err := os.Stat(exe + ".new"); !os.IsNotExist(err) {
+		t.Error("staged binary left behind")
+	}
+
+	if err := Install(exe, []byte("new"), func(path string) error {
+		if read(path) != "new" {
+			return errors.New("check ran on the wrong file")
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if read(exe) != "new" || read(BackupPath(exe)) != "old" {
+		t.Fatalf("after install: exe = %q, backup = %q", read(exe), read(BackupPath(exe)))
+	}
+	if info, err := os.Stat(exe); err != nil || info.Mode().Perm()&0o100 == 0 {
+		t.Errorf("installed binary not executable: %v", err)
+	}
+
+	if err := Rollback(exe); err != nil {
+		t.Fatal(err)
+	}
+	if read(exe) != "old" || read(BackupPath(exe)) != "new" {
+		t.Errorf("after rollback: exe = %q, backup = %q", read(exe), read(BackupPath(exe)))
+	}
+}
+
+func TestLookupChecksum(t *testing.T) {
+	sums := []byte("abc123  beads_0.31.0_darwin_arm64.tar.gz\nDEF456 *beads_0.31.0_windows_amd64.zip\n")
+	if got, err := LookupChecksum(sums, "beads_0.31.0_windows_amd64.zip"); err != nil || got != "def456" {
+		t.Errorf("LookupChecksum = %q, %v", got, err)
+	}
+	if _, err := LookupChecksum(sums, "beads_0.31.0_linux_amd64.tar.gz"); err == nil {
+		t.Error("expected an error for a missing entry")
+	}
+}
@@ -0,0 +1,153 @@
+package storage
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ChecklistMetadata marks soft-blocks dependencies that were created from a
+// checklist item in the issue's description. Only dependencies carrying it
+// are removed again when the checklist changes.
+const ChecklistMetadata = `{"source":"checklist"}`
+
+var (
+	checklistItemPattern = regexp.MustCompile(`^\s*[-*+]\s+\[[ xX]\]\s+(.*)$`)
+	checklistIDPattern   = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*-[a-z0-9]+(\.[0-9]+)*$`)
+)
+
+// ChecklistReferences returns the issue-ID-shaped tokens in the markdown
+// checklist items ("- [ ] bd-12 wire up auth") of text, in order of first
+// appearance. Callers decide which of them are real issues.
+func ChecklistReferences(text string) []string {
+	var refs []string
+	seen := make(map[string]bool)
+	inFence := false
+	for _, line := range strings.Split(text, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		m := checklistItemPattern.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		for _, word := range strings.Fields(m[1]) {
+			word = strings.Trim(word, "`*_()[]{}<>,;:!?\"'")
+			word = strings.TrimRight(word, ".")
+			if checklistIDPattern.MatchString(word) && !seen[word] {
+				seen[word] = true
+				refs = append(refs, word)
+			}
+		}
+	}
+	return refs
+}
+
+// IsChecklistLine reports whether line is a markdown checklist item
+func IsChecklistLine(line string) bool {
+	return checklistItemPattern.MatchString(line)
+}
+
+// IsChecklistDependency reports whether dep was created from a checklist
+func IsChecklistDependency(dep *types.Dependency) bool {
+	return dep.Type == types.DepSoftBlocks && dep.Metadata == ChecklistMetadata
+}
+
+// ChecklistDrift compares an issue's checklist references with its
+// dependencies. missing are referenced issues (per exists) with no
+// dependency in either direction yet; stale are checklist dependencies whose
+// item is gone. current holds the issue's own dependency records and
+// reverse reports whether an issue depends on issueID.
+func ChecklistDrift(issueID, description string, exists func(id string) bool, current []*types.Dependency, reverse func(id string) bool) (missing, stale []string) {
+	referenced := make(map[string]bool)
+	for _, ref := range ChecklistReferences(description) {
+		if ref != issueID && exists(ref) {
+			referenced[ref] = true
+		}
+	}
+
+	linked := make(map[string]bool, len(current))
+	for _, dep := range current {
+		linked[dep.DependsOnID] = true
+		if IsChecklistDependency(dep) && !referenced[dep.DependsOnID] {
+			stale = append(stale, dep.DependsOnID)
+		}
+	}
+	for _, ref := range ChecklistReferences(description) {
+		if referenced[ref] && !linked[ref] && !reverse(ref) {
+			missing = append(missing, ref)
+		}
+	}
+	return missing, stale
+}
+
+// ChecklistSync reports what SyncChecklistDependencies changed
+type ChecklistSync struct {
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+}
+
+// Changed reports whether any dependency was added or removed
+func (c ChecklistSync) Changed() bool {
+	return len(c.Added) > 0 || len(c.Removed) > 0
+}
+
+// SyncChecklistDependencies makes issueID's checklist dependencies match the
+// issue IDs referenced from checklist items in description: each referenced
+// issue gets a soft-blocks dependency, and checklist dependencies whose item
+// was removed are dropped. Dependencies added by hand are never touched, and
+// a reference that would create a cycle is left alone.
+func SyncChecklistDependencies(ctx context.Context, s Storage, issueID, description, actor string) (ChecklistSync, error) {
+	var result ChecklistSync
+
+	current, err := s.GetDependencyRecords(ctx, issueID)
+	if err != nil {
+		return result, err
+	}
+	exists := func(id string) bool {
+		issue, err := s.GetIssue(ctx, id)
+		return err == nil && issue != nil && issue.Status != types.StatusTombstone
+	}
+	reverse := func(id string) bool {
+		records, err := s.GetDependencyRecords(ctx, id)
+		if err != nil {
+			return false
+		}
+		for _, dep := range records {
+			if dep.DependsOnID == issueID {
+				return true
+			}
+		}
+		return false
+	}
+
+	missing, stale := ChecklistDrift(issueID, description, exists, current, reverse)
+	for _, id := range stale {
+		if err := s.RemoveDependency(ctx, issueID, id, actor); err != nil {
+			return result, err
+		}
+		result.Removed = append(result.Removed, id)
+	}
+	for _, id := range missing {
+		dep := &types.Dependency{
+			IssueID:     issueID,
+			DependsOnID: id,
+			Type:        types.DepSoftBlocks,
+			Metadata:    ChecklistMetadata,
+		}
+		if err := s.AddDependency(ctx, dep, actor); err != nil {
+			if strings.Contains(err.Error(), "cycle") {
+				continue
+			}
+			return result, err
+		}
+		result.Added = append(result.Added, id)
+	}
+	return result, nil
+}
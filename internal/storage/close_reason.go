@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ResolveCloseReason applies the project's close reason taxonomy to a close
+// request: custom reasons come from close.reasons, and close.require_reason
+// makes a reason from the taxonomy mandatory. Returns the value to store in
+// close_reason, which is "" when neither reason nor note was given.
+func ResolveCloseReason(ctx context.Context, s Storage, reason, note string) (string, error) {
+	custom, err := s.GetCustomCloseReasons(ctx)
+	if err != nil {
+		return "", err
+	}
+	required, err := s.GetConfig(ctx, "close.require_reason")
+	if err != nil {
+		return "", err
+	}
+	return types.ResolveCloseReason(reason, note, custom, required == "true")
+}
+
+// CheckVerifiedClose enforces the two-phase close configured by
+// close.verify_labels and close.verify_priority: a matching issue must be
+// resolved before it is closed, and the final close must come from someone
+// other than whoever resolved it. Issues outside the policy close freely.
+func CheckVerifiedClose(ctx context.Context, s Storage, issueID, actor string) error {
+	labels, err := s.GetConfig(ctx, "close.verify_labels")
+	if err != nil {
+		return err
+	}
+	priority, err := s.GetConfig(ctx, "close.verify_priority")
+	if err != nil {
+		return err
+	}
+	if labels == "" && priority == "" {
+		return nil
+	}
+	policy, err := types.ParseVerificationPolicy(labels, priority)
+	if err != nil {
+		return err
+	}
+
+	issue, err := s.GetIssue(ctx, issueID)
+	if err != nil {
+		return err
+	}
+	if issue == nil {
+		return fmt.Errorf("issue %s not found", issueID)
+	}
+	issueLabels, err := s.GetLabels(ctx, issueID)
+	if err != nil {
+		return err
+	}
+	if !policy.Requires(issue, issueLabels) {
+		return nil
+	}
+
+	if issue.Status != types.StatusResolved {
+		return fmt.Errorf("%s requires verification: mark it resolved with evidence first (bd resolve %s --evidence ...)", issueID, issueID)
+	}
+	comments, err := s.GetIssueComments(ctx, issueID)
+	if err != nil {
+		return err
+	}
+	if resolver := types.Resolver(comments); resolver != "" && resolver == actor {
+		return fmt.Errorf("%s was resolved by %s; the final close must come from a different reviewer", issueID, resolver)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// LoadDoneDefinition reads the done.<label> criteria and whether done.strict
+// enforces them
+func LoadDoneDefinition(ctx context.Context, s Storage) (types.DoneDefinition, bool, error) {
+	config, err := s.GetAllConfig(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	return types.ParseDoneDefinition(config), config[types.DoneStrictKey] == "true", nil
+}
+
+// GetDoneCriteria returns an issue's definition of done with the criteria
+// confirmed so far, or nil if none of its labels has criteria
+func GetDoneCriteria(ctx context.Context, s Storage, issueID string) ([]types.DoneCriterion, error) {
+	def, _, err := LoadDoneDefinition(ctx, s)
+	if err != nil || len(def) == 0 {
+		return nil, err
+	}
+	return doneCriteria(ctx, s, def, issueID)
+}
+
+// CheckDefinitionOfDone enforces done.strict: an issue can only be closed
+// once every criterion of its labels' definition of done is confirmed.
+// Without done.strict, criteria are a reminder and issues close freely.
+func CheckDefinitionOfDone(ctx context.Context, s Storage, issueID string) error {
+	def, strict, err := LoadDoneDefinition(ctx, s)
+	if err != nil || !strict || len(def) == 0 {
+		return err
+	}
+	criteria, err := doneCriteria(ctx, s, def, issueID)
+	if err != nil {
+		return err
+	}
+	if pending := types.PendingCriteria(criteria); len(pending) > 0 {
+		return fmt.Errorf("%s is not done: %s not confirmed (bd close %s --done %q, or --done all)",
+			issueID, strings.Join(pending, ", "), issueID, pending[0])
+	}
+	return nil
+}
+
+func doneCriteria(ctx context.Context, s Storage, def types.DoneDefinition, issueID string) ([]types.DoneCriterion, error) {
+	labels, err := s.GetLabels(ctx, issueID)
+	if err != nil {
+		return nil, err
+	}
+	if len(def.Criteria(labels, nil)) == 0 {
+		return nil, nil
+	}
+	comments, err := s.GetIssueComments(ctx, issueID)
+	if err != nil {
+		return nil, err
+	}
+	return def.Criteria(labels, comments), nil
+}
@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/encryption"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// FieldConfigPrefix starts the config keys holding custom field
+// definitions: field.<name> = "<type>" or "enum:<value>,<value>,..."
+const FieldConfigPrefix = "field."
+
+// GetFieldDefs returns the project's custom field definitions by name
+func GetFieldDefs(ctx context.Context, s Storage) (map[string]*types.FieldDef, error) {
+	cfg, err := s.GetAllConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defs := make(map[string]*types.FieldDef)
+	for key, spec := range cfg {
+		name, ok := strings.CutPrefix(key, FieldConfigPrefix)
+		if !ok {
+			continue
+		}
+		def, err := types.ParseFieldDef(name, spec)
+		if err != nil {
+			return nil, fmt.Errorf("config %s: %w", key, err)
+		}
+		defs[name] = def
+	}
+	return defs, nil
+}
+
+// DefineField adds or replaces a custom field definition. Values issues
+// already have are left as they are.
+func DefineField(ctx context.Context, s Storage, def *types.FieldDef) error {
+	return s.SetConfig(ctx, FieldConfigPrefix+def.Name, def.Spec())
+}
+
+// NormalizeFields checks field values against the project's definitions
+// and returns them in canonical form. Every field must be defined, except
+// that with allowClear an empty value (which removes the field) is passed
+// through. Values encrypted client-side (encryption.fields) cannot be
+// checked and are kept as given.
+func NormalizeFields(ctx context.Context, s Storage, fields types.CustomFields, allowClear bool) (types.CustomFields, error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	defs, err := GetFieldDefs(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	result := make(types.CustomFields, len(fields))
+	for name, value := range fields {
+		// Clearing needs no definition, so values of removed fields can go too
+		if allowClear && strings.TrimSpace(value) == "" {
+			result[name] = ""
+			continue
+		}
+		def, ok := defs[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown field %q (define it with bd field define %s --type ...)", name, name)
+		}
+		if encryption.IsEncrypted(value) {
+			result[name] = value
+			continue
+		}
+		if result[name], err = def.Normalize(value); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// SetIssueFields validates custom field values and stores them on an issue.
+// An empty value removes the field. Nothing is stored unless every value is
+// valid.
+func SetIssueFields(ctx context.Context, s Storage, issueID string, fields types.CustomFields, actor string) error {
+	normalized, err := NormalizeFields(ctx, s, fields, true)
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(normalized))
+	for name := range normalized {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := s.SetIssueField(ctx, issueID, name, normalized[name], actor); err != nil {
+			return err
+		}
+	}
+	return nil
+}
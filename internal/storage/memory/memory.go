@@ -20,12 +20,19 @@ import (
 type MemoryStorage struct {
 	mu sync.RWMutex // Protects all maps
 
+	claimMu sync.Mutex // Serializes ClaimReadyWork's select-then-update
+
 	// Core data
 	issues       map[string]*types.Issue       // ID -> Issue
 	dependencies map[string][]*types.Dependency // IssueID -> Dependencies
 	labels       map[string][]string           // IssueID -> Labels
 	events       map[string][]*types.Event     // IssueID -> Events
 	comments     map[string][]*types.Comment   // IssueID -> Comments
+	attachments  map[string][]*types.Attachment // IssueID -> Attachments
+	workLog      map[string][]*types.WorkEntry  // IssueID -> Work log entries
+	aliases      map[string]string             // Alias -> IssueID
+	translations map[string][]*types.Translation // IssueID -> Translations, sorted by locale
+	fields       map[string]types.CustomFields  // IssueID -> Custom field values
 	config       map[string]string             // Config key-value pairs
 	metadata     map[string]string             // Metadata key-value pairs
 	counters     map[string]int                // Prefix -> Last ID
@@ -48,6 +55,11 @@ func New(jsonlPath string) *MemoryStorage {
 		labels:          make(map[string][]string),
 		events:          make(map[string][]*types.Event),
 		comments:        make(map[string][]*types.Comment),
+		attachments:     make(map[string][]*types.Attachment),
+		workLog:         make(map[string][]*types.WorkEntry),
+		aliases:         make(map[string]string),
+		translations:    make(map[string][]*types.Translation),
+		fields:          make(map[string]types.CustomFields),
 		config:          make(map[string]string),
 		metadata:        make(map[string]string),
 		counters:        make(map[string]int),
@@ -91,6 +103,31 @@ func (m *MemoryStorage) LoadFromIssues(issues []*types.Issue) error {
 			m.comments[issue.ID] = issue.Comments
 		}
 
+		// Store attachments
+		if len(issue.Attachments) > 0 {
+			m.attachments[issue.ID] = issue.Attachments
+		}
+
+		// Store work log
+		if len(issue.WorkLog) > 0 {
+			m.workLog[issue.ID] = issue.WorkLog
+		}
+
+		// Index aliases
+		for _, alias := range issue.Aliases {
+			m.aliases[alias] = issue.ID
+		}
+
+		// Store translations
+		if len(issue.Translations) > 0 {
+			m.translations[issue.ID] = issue.Translations
+		}
+
+		// Store custom field values
+		if len(issue.Fields) > 0 {
+			m.fields[issue.ID] = issue.Fields
+		}
+
 		// Update counter based on issue ID
 		prefix, num := extractPrefixAndNumber(issue.ID)
 		if prefix != "" && num > 0 {
@@ -136,6 +173,20 @@ func (m *MemoryStorage) GetAllIssues() []*types.Issue {
 			issueCopy.Comments = comments
 		}
 
+		// Attach attachments
+		if attachments, ok := m.attachments[issue.ID]; ok {
+			issueCopy.Attachments = attachments
+		}
+
+		// Attach work log
+		if entries, ok := m.workLog[issue.ID]; ok {
+			issueCopy.WorkLog = entries
+		}
+
+		issueCopy.Aliases = m.aliasesFor(issue.ID)
+		issueCopy.Translations = m.translations[issue.ID]
+		issueCopy.Fields = m.fields[issue.ID]
+
 		issues = append(issues, &issueCopy)
 	}
 
@@ -195,7 +246,7 @@ func (m *MemoryStorage) CreateIssue(ctx context.Context, issue *types.Issue, act
 	}
 
 	// Set timestamps
-	now := time.Now()
+	now := time.Now().UTC()
 	issue.CreatedAt = now
 	issue.UpdatedAt = now
 
@@ -249,7 +300,7 @@ func (m *MemoryStorage) CreateIssues(ctx context.Context, issues []*types.Issue,
 		}
 	}
 
-	now := time.Now()
+	now := time.Now().UTC()
 	prefix := m.config["issue_prefix"]
 	if prefix == "" {
 		prefix = "bd"
@@ -326,6 +377,10 @@ func (m *MemoryStorage) GetIssue(ctx context.Context, id string) (*types.Issue,
 		issueCopy.Labels = labels
 	}
 
+	issueCopy.Aliases = m.aliasesFor(id)
+	issueCopy.Translations = m.translations[id]
+	issueCopy.Fields = m.fields[id]
+
 	return &issueCopy, nil
 }
 
@@ -371,7 +426,7 @@ func (m *MemoryStorage) UpdateIssue(ctx context.Context, id string, updates map[
 		return fmt.Errorf("issue %s not found", id)
 	}
 
-	now := time.Now()
+	now := time.Now().UTC()
 	issue.UpdatedAt = now
 
 	// Apply updates
@@ -407,8 +462,13 @@ func (m *MemoryStorage) UpdateIssue(ctx context.Context, id string, updates map[
 					issue.ClosedAt = &now
 				} else if issue.Status != types.StatusClosed && oldStatus == types.StatusClosed {
 					issue.ClosedAt = nil
+					issue.CloseReason = ""
 				}
 			}
+		case "close_reason":
+			if v, ok := value.(string); ok {
+				issue.CloseReason = v
+			}
 		case "priority":
 			if v, ok := value.(int); ok {
 				issue.Priority = v
@@ -423,6 +483,27 @@ func (m *MemoryStorage) UpdateIssue(ctx context.Context, id string, updates map[
 			} else if value == nil {
 				issue.Assignee = ""
 			}
+		case "complexity":
+			if v, ok := value.(string); ok {
+				issue.Complexity = types.Complexity(v)
+			} else if value == nil {
+				issue.Complexity = ""
+			}
+		case "source":
+			if v, ok := value.(string); ok {
+				issue.Source = v
+			} else if value == nil {
+				issue.Source = ""
+			}
+		case "timebox_minutes":
+			switch v := value.(type) {
+			case int:
+				issue.TimeboxMinutes = &v
+			case *int:
+				issue.TimeboxMinutes = v
+			case nil:
+				issue.TimeboxMinutes = nil
+			}
 		case "external_ref":
 			// Update external ref index
 			oldRef := issue.ExternalRef
@@ -470,7 +551,8 @@ func (m *MemoryStorage) UpdateIssue(ctx context.Context, id string, updates map[
 // CloseIssue closes an issue with a reason
 func (m *MemoryStorage) CloseIssue(ctx context.Context, id string, reason string, actor string) error {
 	return m.UpdateIssue(ctx, id, map[string]interface{}{
-		"status": string(types.StatusClosed),
+		"status":       string(types.StatusClosed),
+		"close_reason": reason,
 	}, actor)
 }
 
@@ -498,7 +580,15 @@ func (m *MemoryStorage) DeleteIssue(ctx context.Context, id string) error {
 	delete(m.labels, id)
 	delete(m.events, id)
 	delete(m.comments, id)
+	delete(m.attachments, id)
+	delete(m.translations, id)
+	delete(m.fields, id)
 	delete(m.dirty, id)
+	for alias, issueID := range m.aliases {
+		if issueID == id {
+			delete(m.aliases, alias)
+		}
+	}
 
 	return nil
 }
@@ -518,12 +608,21 @@ func (m *MemoryStorage) SearchIssues(ctx context.Context, query string, filter t
 		if filter.Priority != nil && issue.Priority != *filter.Priority {
 			continue
 		}
+		if filter.After != nil && !filter.After.Precedes(issue) {
+			continue
+		}
 		if filter.IssueType != nil && issue.IssueType != *filter.IssueType {
 			continue
 		}
 		if filter.Assignee != nil && issue.Assignee != *filter.Assignee {
 			continue
 		}
+		if !matchesComplexity(issue, filter.Complexity) {
+			continue
+		}
+		if !m.matchesFields(issue.ID, filter.Fields) {
+			continue
+		}
 
 		// Query search (title, description, or ID)
 		if query != "" {
@@ -557,6 +656,12 @@ func (m *MemoryStorage) SearchIssues(ctx context.Context, query string, filter t
 			}
 		}
 
+		// Close reason filtering
+		if filter.CloseReason != "" && issue.CloseReason != filter.CloseReason &&
+			!strings.HasPrefix(issue.CloseReason, filter.CloseReason+":") {
+			continue
+		}
+
 		// ID filtering
 		if len(filter.IDs) > 0 {
 			found := false
@@ -583,13 +688,26 @@ func (m *MemoryStorage) SearchIssues(ctx context.Context, query string, filter t
 		results = append(results, &issueCopy)
 	}
 
-	// Sort by priority, then by created_at
-	sort.Slice(results, func(i, j int) bool {
-		if results[i].Priority != results[j].Priority {
-			return results[i].Priority < results[j].Priority
+	// Sort by priority, then newest first, then ID for a stable page order,
+	// unless the filter asks for another order
+	if len(filter.Sort) > 0 {
+		if filter.After != nil {
+			return nil, fmt.Errorf("cursor pagination requires the default sort order")
 		}
-		return results[i].CreatedAt.After(results[j].CreatedAt)
-	})
+		sort.Slice(results, func(i, j int) bool {
+			return filter.Sort.Compare(results[i], results[j]) < 0
+		})
+	} else {
+		sort.Slice(results, func(i, j int) bool {
+			if results[i].Priority != results[j].Priority {
+				return results[i].Priority < results[j].Priority
+			}
+			if !results[i].CreatedAt.Equal(results[j].CreatedAt) {
+				return results[i].CreatedAt.After(results[j].CreatedAt)
+			}
+			return results[i].ID < results[j].ID
+		})
+	}
 
 	// Apply limit
 	if filter.Limit > 0 && len(results) > filter.Limit {
@@ -894,6 +1012,30 @@ func (m *MemoryStorage) GetIssuesByLabel(ctx context.Context, label string) ([]*
 	return results, nil
 }
 
+// matchesComplexity reports whether issue has one of the wanted
+// complexities; no wanted values matches everything
+// matchesFields reports whether an issue has all the wanted field values
+func (m *MemoryStorage) matchesFields(issueID string, wanted types.CustomFields) bool {
+	for name, value := range wanted {
+		if m.fields[issueID][name] != value {
+			return false
+		}
+	}
+	return true
+}
+
+func matchesComplexity(issue *types.Issue, wanted []types.Complexity) bool {
+	if len(wanted) == 0 {
+		return true
+	}
+	for _, c := range wanted {
+		if issue.Complexity == c {
+			return true
+		}
+	}
+	return false
+}
+
 // GetReadyWork returns issues that are ready to work on (no open blockers)
 func (m *MemoryStorage) GetReadyWork(ctx context.Context, filter types.WorkFilter) ([]*types.Issue, error) {
 	m.mu.RLock()
@@ -926,6 +1068,9 @@ func (m *MemoryStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 				continue
 			}
 		}
+		if !matchesComplexity(issue, filter.Complexity) {
+			continue
+		}
 
 		// Label filtering (AND semantics)
 		if len(filter.Labels) > 0 {
@@ -984,6 +1129,7 @@ func (m *MemoryStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 		if comments, ok := m.comments[issue.ID]; ok {
 			issueCopy.Comments = comments
 		}
+		issueCopy.SoftBlockedBy = m.getOpenSoftBlockers(issue.ID)
 
 		results = append(results, &issueCopy)
 	}
@@ -1009,7 +1155,7 @@ func (m *MemoryStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 	case types.SortPolicyHybrid:
 		fallthrough
 	default:
-		cutoff := time.Now().Add(-48 * time.Hour)
+		cutoff := time.Now().UTC().Add(-48 * time.Hour)
 		sort.Slice(results, func(i, j int) bool {
 			iRecent := results[i].CreatedAt.After(cutoff)
 			jRecent := results[j].CreatedAt.After(cutoff)
@@ -1025,6 +1171,11 @@ func (m *MemoryStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 		})
 	}
 
+	// Soft-blocked issues go last regardless of policy
+	sort.SliceStable(results, func(i, j int) bool {
+		return len(results[i].SoftBlockedBy) == 0 && len(results[j].SoftBlockedBy) > 0
+	})
+
 	// Apply limit
 	if filter.Limit > 0 && len(results) > filter.Limit {
 		results = results[:filter.Limit]
@@ -1033,6 +1184,38 @@ func (m *MemoryStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 	return results, nil
 }
 
+// ClaimReadyWork picks the top ready issue and claims it for actor. Only open
+// issues that are unassigned or already assigned to actor are candidates.
+// Returns nil if there is nothing to claim.
+func (m *MemoryStorage) ClaimReadyWork(ctx context.Context, filter types.WorkFilter, actor string) (*types.Issue, error) {
+	if actor == "" {
+		return nil, fmt.Errorf("actor is required to claim work")
+	}
+	m.claimMu.Lock()
+	defer m.claimMu.Unlock()
+
+	filter.Status = types.StatusOpen
+	filter.Limit = 0
+	ready, err := m.GetReadyWork(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	for _, issue := range ready {
+		if issue.Assignee != "" && issue.Assignee != actor {
+			continue
+		}
+		updates := map[string]interface{}{
+			"status":   string(types.StatusInProgress),
+			"assignee": actor,
+		}
+		if err := m.UpdateIssue(ctx, issue.ID, updates, actor); err != nil {
+			return nil, err
+		}
+		return m.GetIssue(ctx, issue.ID)
+	}
+	return nil, nil
+}
+
 // getOpenBlockers returns the IDs of blockers that are currently open/in_progress/blocked.
 // The caller must hold at least a read lock.
 func (m *MemoryStorage) getOpenBlockers(issueID string) []string {
@@ -1062,6 +1245,25 @@ func (m *MemoryStorage) getOpenBlockers(issueID string) []string {
 	return blockers
 }
 
+// getOpenSoftBlockers returns the open issues issueID soft-depends on.
+// Unlike blocks, a missing target doesn't count.
+func (m *MemoryStorage) getOpenSoftBlockers(issueID string) []string {
+	var blockers []string
+	for _, dep := range m.dependencies[issueID] {
+		if dep.Type != types.DepSoftBlocks {
+			continue
+		}
+		if blocker, ok := m.issues[dep.DependsOnID]; ok {
+			switch blocker.Status {
+			case types.StatusOpen, types.StatusInProgress, types.StatusBlocked:
+				blockers = append(blockers, blocker.ID)
+			}
+		}
+	}
+	sort.Strings(blockers)
+	return blockers
+}
+
 // GetBlockedIssues returns issues that are blocked by other issues
 func (m *MemoryStorage) GetBlockedIssues(ctx context.Context) ([]*types.BlockedIssue, error) {
 	m.mu.RLock()
@@ -1117,7 +1319,7 @@ func (m *MemoryStorage) GetStaleIssues(ctx context.Context, filter types.StaleFi
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	cutoff := time.Now().AddDate(0, 0, -filter.Days)
+	cutoff := time.Now().UTC().AddDate(0, 0, -filter.Days)
 	var stale []*types.Issue
 
 	for _, issue := range m.issues {
@@ -1165,11 +1367,11 @@ func (m *MemoryStorage) AddIssueComment(ctx context.Context, issueID, author, te
 	defer m.mu.Unlock()
 
 	comment := &types.Comment{
-		ID:        int64(len(m.comments[issueID]) + 1),
+		ID:        m.nextCommentID(),
 		IssueID:   issueID,
 		Author:    author,
 		Text:      text,
-		CreatedAt: time.Now(),
+		CreatedAt: time.Now().UTC(),
 	}
 
 	m.comments[issueID] = append(m.comments[issueID], comment)
@@ -1178,6 +1380,54 @@ func (m *MemoryStorage) AddIssueComment(ctx context.Context, issueID, author, te
 	return comment, nil
 }
 
+// nextCommentID returns an ID above every loaded comment's, so comment IDs
+// stay unique across issues as they are in SQLite. Caller must hold m.mu.
+func (m *MemoryStorage) nextCommentID() int64 {
+	var maxID int64
+	for _, comments := range m.comments {
+		for _, comment := range comments {
+			if comment.ID > maxID {
+				maxID = comment.ID
+			}
+		}
+	}
+	return maxID + 1
+}
+
+func (m *MemoryStorage) UpdateIssueComment(ctx context.Context, commentID int64, text, actor string) (*types.Comment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for issueID, comments := range m.comments {
+		for _, comment := range comments {
+			if comment.ID == commentID {
+				now := time.Now().UTC()
+				comment.Text = text
+				comment.UpdatedAt = &now
+				m.dirty[issueID] = true
+				return comment, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("comment %d not found", commentID)
+}
+
+func (m *MemoryStorage) DeleteIssueComment(ctx context.Context, commentID int64, actor string) (*types.Comment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for issueID, comments := range m.comments {
+		for i, comment := range comments {
+			if comment.ID == commentID {
+				m.comments[issueID] = append(comments[:i:i], comments[i+1:]...)
+				m.dirty[issueID] = true
+				return comment, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("comment %d not found", commentID)
+}
+
 func (m *MemoryStorage) GetIssueComments(ctx context.Context, issueID string) ([]*types.Comment, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -1198,6 +1448,247 @@ func (m *MemoryStorage) GetCommentsForIssues(ctx context.Context, issueIDs []str
 	return result, nil
 }
 
+func (m *MemoryStorage) AddAttachment(ctx context.Context, attachment *types.Attachment) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.issues[attachment.IssueID]; !ok {
+		return fmt.Errorf("issue %s not found", attachment.IssueID)
+	}
+	for _, existing := range m.attachments[attachment.IssueID] {
+		if existing.URL == attachment.URL {
+			attachment.ID = existing.ID
+			return nil
+		}
+	}
+	attachment.ID = int64(len(m.attachments[attachment.IssueID]) + 1)
+	if attachment.CreatedAt.IsZero() {
+		attachment.CreatedAt = time.Now().UTC()
+	}
+	m.attachments[attachment.IssueID] = append(m.attachments[attachment.IssueID], attachment)
+	m.dirty[attachment.IssueID] = true
+	return nil
+}
+
+func (m *MemoryStorage) GetAttachments(ctx context.Context, issueID string) ([]*types.Attachment, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.attachments[issueID], nil
+}
+
+func (m *MemoryStorage) GetAttachmentsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.Attachment, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make(map[string][]*types.Attachment)
+	for _, issueID := range issueIDs {
+		if attachments, exists := m.attachments[issueID]; exists {
+			result[issueID] = attachments
+		}
+	}
+	return result, nil
+}
+
+func (m *MemoryStorage) AddWorkEntry(ctx context.Context, entry *types.WorkEntry) error {
+	if entry.Minutes <= 0 {
+		return fmt.Errorf("logged time must be positive, got %d minutes", entry.Minutes)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.issues[entry.IssueID]; !ok {
+		return fmt.Errorf("issue %s not found", entry.IssueID)
+	}
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now().UTC()
+	}
+	for _, existing := range m.workLog[entry.IssueID] {
+		if existing.Actor == entry.Actor && existing.CreatedAt.Equal(entry.CreatedAt) {
+			entry.ID = existing.ID
+			return nil
+		}
+	}
+	entry.ID = int64(len(m.workLog[entry.IssueID]) + 1)
+	m.workLog[entry.IssueID] = append(m.workLog[entry.IssueID], entry)
+	m.dirty[entry.IssueID] = true
+	return nil
+}
+
+func (m *MemoryStorage) GetWorkLog(ctx context.Context, issueID string) ([]*types.WorkEntry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.workLog[issueID], nil
+}
+
+func (m *MemoryStorage) GetWorkLogForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.WorkEntry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make(map[string][]*types.WorkEntry)
+	for _, issueID := range issueIDs {
+		if entries, exists := m.workLog[issueID]; exists {
+			result[issueID] = entries
+		}
+	}
+	return result, nil
+}
+
+func (m *MemoryStorage) SetIssueAlias(ctx context.Context, issueID, alias, actor string) error {
+	if err := types.ValidateAlias(alias); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.issues[issueID]; !ok {
+		return fmt.Errorf("issue %s not found", issueID)
+	}
+	if _, ok := m.issues[alias]; ok {
+		return fmt.Errorf("alias %q is already an issue ID", alias)
+	}
+	if current, ok := m.aliases[alias]; ok {
+		if current == issueID {
+			return nil
+		}
+		return fmt.Errorf("alias %q already refers to %s", alias, current)
+	}
+	m.aliases[alias] = issueID
+	m.dirty[issueID] = true
+	return nil
+}
+
+func (m *MemoryStorage) RemoveIssueAlias(ctx context.Context, alias, actor string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	issueID, ok := m.aliases[alias]
+	if !ok {
+		return fmt.Errorf("no issue has alias %q", alias)
+	}
+	delete(m.aliases, alias)
+	m.dirty[issueID] = true
+	return nil
+}
+
+func (m *MemoryStorage) ResolveIssueAlias(ctx context.Context, alias string) (string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.aliases[alias], nil
+}
+
+func (m *MemoryStorage) GetAliasesForIssues(ctx context.Context, issueIDs []string) (map[string][]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make(map[string][]string)
+	for _, issueID := range issueIDs {
+		if aliases := m.aliasesFor(issueID); len(aliases) > 0 {
+			result[issueID] = aliases
+		}
+	}
+	return result, nil
+}
+
+// aliasesFor returns an issue's aliases, sorted. Callers hold m.mu.
+func (m *MemoryStorage) aliasesFor(issueID string) []string {
+	var aliases []string
+	for alias, id := range m.aliases {
+		if id == issueID {
+			aliases = append(aliases, alias)
+		}
+	}
+	sort.Strings(aliases)
+	return aliases
+}
+
+func (m *MemoryStorage) SetTranslation(ctx context.Context, issueID string, t *types.Translation, actor string) error {
+	locale, err := types.NormalizeLocale(t.Locale)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.issues[issueID]; !ok {
+		return fmt.Errorf("issue %s not found", issueID)
+	}
+	var kept []*types.Translation
+	for _, existing := range m.translations[issueID] {
+		if existing.Locale != locale {
+			kept = append(kept, existing)
+		}
+	}
+	if t.Title != "" || t.Description != "" {
+		kept = append(kept, &types.Translation{Locale: locale, Title: t.Title, Description: t.Description})
+		sort.Slice(kept, func(i, j int) bool { return kept[i].Locale < kept[j].Locale })
+	}
+	if len(kept) > 0 {
+		m.translations[issueID] = kept
+	} else {
+		delete(m.translations, issueID)
+	}
+	m.dirty[issueID] = true
+	return nil
+}
+
+func (m *MemoryStorage) GetTranslationsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.Translation, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make(map[string][]*types.Translation)
+	for _, issueID := range issueIDs {
+		if translations, ok := m.translations[issueID]; ok {
+			result[issueID] = translations
+		}
+	}
+	return result, nil
+}
+
+func (m *MemoryStorage) SetIssueField(ctx context.Context, issueID, name, value, actor string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.issues[issueID]; !ok {
+		return fmt.Errorf("issue %s not found", issueID)
+	}
+	if m.fields[issueID][name] == value {
+		return nil
+	}
+	// Copy on write: issues handed out share the map
+	fields := make(types.CustomFields, len(m.fields[issueID])+1)
+	for k, v := range m.fields[issueID] {
+		fields[k] = v
+	}
+	if value == "" {
+		delete(fields, name)
+	} else {
+		fields[name] = value
+	}
+	if len(fields) > 0 {
+		m.fields[issueID] = fields
+	} else {
+		delete(m.fields, issueID)
+	}
+	m.dirty[issueID] = true
+	return nil
+}
+
+func (m *MemoryStorage) GetFieldsForIssues(ctx context.Context, issueIDs []string) (map[string]types.CustomFields, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make(map[string]types.CustomFields)
+	for _, issueID := range issueIDs {
+		if fields, ok := m.fields[issueID]; ok {
+			result[issueID] = fields
+		}
+	}
+	return result, nil
+}
+
 func (m *MemoryStorage) GetStatistics(ctx context.Context) (*types.Statistics, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -1211,6 +1702,8 @@ func (m *MemoryStorage) GetStatistics(ctx context.Context) (*types.Statistics, e
 			stats.OpenIssues++
 		case types.StatusInProgress:
 			stats.InProgressIssues++
+		case types.StatusResolved:
+			stats.ResolvedIssues++
 		case types.StatusClosed:
 			stats.ClosedIssues++
 		case types.StatusTombstone:
@@ -1219,7 +1712,7 @@ func (m *MemoryStorage) GetStatistics(ctx context.Context) (*types.Statistics, e
 	}
 
 	// TotalIssues excludes tombstones (matches SQLite behavior)
-	stats.TotalIssues = stats.OpenIssues + stats.InProgressIssues + stats.ClosedIssues
+	stats.TotalIssues = stats.OpenIssues + stats.InProgressIssues + stats.ResolvedIssues + stats.ClosedIssues
 
 	// Second pass: calculate blocked and ready issues based on dependencies
 	// An issue is blocked if it has open blockers (uses same logic as GetBlockedIssues)
@@ -1255,6 +1748,26 @@ func (m *MemoryStorage) GetStatistics(ctx context.Context) (*types.Statistics, e
 	// Calculate epics eligible for closure
 	stats.EpicsEligibleForClosure = m.countEpicsEligibleForClosure()
 
+	// Count closed issues per close reason category
+	customReasons := parseCustomStatuses(m.config["close.reasons"])
+	stats.ClosedByReason = make(map[string]int)
+	for _, issue := range m.issues {
+		if issue.Status == types.StatusClosed {
+			stats.ClosedByReason[types.CloseReasonCategory(issue.CloseReason, customReasons)]++
+		}
+	}
+
+	// Total the work log per actor
+	stats.MinutesLoggedByActor = make(map[string]int)
+	for issueID, entries := range m.workLog {
+		if issue, ok := m.issues[issueID]; !ok || issue.Status == types.StatusTombstone {
+			continue
+		}
+		for actor, minutes := range types.SumWorkLog(entries).ByActor {
+			stats.MinutesLoggedByActor[actor] += minutes
+		}
+	}
+
 	return stats, nil
 }
 
@@ -1399,6 +1912,15 @@ func (m *MemoryStorage) GetCustomStatuses(ctx context.Context) ([]string, error)
 	return parseCustomStatuses(value), nil
 }
 
+// GetCustomCloseReasons retrieves the custom close reasons from config.
+func (m *MemoryStorage) GetCustomCloseReasons(ctx context.Context) ([]string, error) {
+	value, err := m.GetConfig(ctx, "close.reasons")
+	if err != nil {
+		return nil, err
+	}
+	return parseCustomStatuses(value), nil
+}
+
 // parseCustomStatuses splits a comma-separated string into a slice of trimmed status names.
 func parseCustomStatuses(value string) []string {
 	if value == "" {
@@ -1213,3 +1213,40 @@ func TestGetIssueByExternalRefLoadFromIssues(t *testing.T) {
 		t.Errorf("Expected to find bd-2 by external ref jira#200")
 	}
 }
+
+func TestSearchIssuesAfterCursor(t *testing.T) {
+	store := setupTestMemory(t)
+	defer store.Close()
+	ctx := context.Background()
+
+	created := time.Now()
+	for i := 0; i < 5; i++ {
+		issue := &types.Issue{
+			Title:     "Issue",
+			Status:    types.StatusOpen,
+			Priority:  1,
+			IssueType: types.TypeTask,
+			CreatedAt: created,
+		}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue: %v", err)
+		}
+	}
+
+	all, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rest, err := store.SearchIssues(ctx, "", types.IssueFilter{After: types.CursorAfter(all[1])})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rest) != 3 {
+		t.Fatalf("got %d issues after cursor, want 3", len(rest))
+	}
+	for i, issue := range rest {
+		if issue.ID != all[i+2].ID {
+			t.Errorf("position %d: got %s, want %s", i, issue.ID, all[i+2].ID)
+		}
+	}
+}
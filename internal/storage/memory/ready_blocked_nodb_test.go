@@ -152,3 +152,38 @@ func TestGetBlockedIssues_IncludesExplicitlyBlockedStatus(t *testing.T) {
 		t.Fatalf("expected implicitly blocked issue %s", implicitlyBlocked.ID)
 	}
 }
+
+func TestGetReadyWork_SoftBlockedIssuesSortLast(t *testing.T) {
+	store := setupTestMemory(t)
+	defer store.Close()
+
+	ctx := context.Background()
+
+	urgent := &types.Issue{ID: "bd-1", Title: "Urgent", Status: types.StatusOpen, Priority: 0, IssueType: types.TypeTask}
+	first := &types.Issue{ID: "bd-2", Title: "Preferably first", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{urgent, first} {
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if err := store.AddDependency(ctx, &types.Dependency{
+		IssueID:     urgent.ID,
+		DependsOnID: first.ID,
+		Type:        types.DepSoftBlocks,
+		CreatedAt:   time.Now(),
+		CreatedBy:   "test",
+	}, "test"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+
+	ready, err := store.GetReadyWork(ctx, types.WorkFilter{SortPolicy: types.SortPolicyPriority})
+	if err != nil {
+		t.Fatalf("GetReadyWork failed: %v", err)
+	}
+	if len(ready) != 2 || ready[0].ID != first.ID || ready[1].ID != urgent.ID {
+		t.Fatalf("ready = %v, want bd-2 then soft-blocked bd-1", ready)
+	}
+	if len(ready[1].SoftBlockedBy) != 1 || ready[1].SoftBlockedBy[0] != first.ID {
+		t.Errorf("SoftBlockedBy = %v, want [bd-2]", ready[1].SoftBlockedBy)
+	}
+}
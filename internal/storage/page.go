@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Page size bounds shared by every paginated list endpoint
+const (
+	DefaultPageSize = 100
+	MaxPageSize     = 1000
+)
+
+// SearchIssuesPage runs SearchIssues one page at a time. cursor is the
+// next_cursor token from the previous page ("" for the first page). Returns
+// the page and the token for the next one, which is "" after the last page.
+func SearchIssuesPage(ctx context.Context, s Storage, query string, filter types.IssueFilter, cursor string, pageSize int) ([]*types.Issue, string, error) {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+	if cursor != "" {
+		after, err := types.DecodePageCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		filter.After = after
+	}
+
+	// Fetch one extra row to learn whether another page exists
+	filter.Limit = pageSize + 1
+	issues, err := s.SearchIssues(ctx, query, filter)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(issues) <= pageSize {
+		return issues, "", nil
+	}
+	issues = issues[:pageSize]
+	return issues, types.CursorAfter(issues[pageSize-1]).Encode(), nil
+}
+
+// ForEachIssuePage runs SearchIssues a page at a time, passing each page to
+// fn, so callers can stream a large result with bounded memory. Pages follow
+// the cursor order, so filter.Sort must be empty; filter.Limit, if set, caps
+// the total. Stops at the first error from fn.
+func ForEachIssuePage(ctx context.Context, s Storage, query string, filter types.IssueFilter, pageSize int, fn func([]*types.Issue) error) error {
+	if len(filter.Sort) > 0 {
+		return fmt.Errorf("streamed results follow the page order and can't be sorted")
+	}
+	remaining := filter.Limit
+	filter.Limit = 0
+	cursor := ""
+	for {
+		size := pageSize
+		if remaining > 0 && (size <= 0 || size > remaining) {
+			size = remaining
+		}
+		issues, next, err := SearchIssuesPage(ctx, s, query, filter, cursor, size)
+		if err != nil {
+			return err
+		}
+		if len(issues) > 0 {
+			if err := fn(issues); err != nil {
+				return err
+			}
+		}
+		if remaining > 0 {
+			if remaining -= len(issues); remaining <= 0 {
+				return nil
+			}
+		}
+		if next == "" {
+			return nil
+		}
+		cursor = next
+	}
+}
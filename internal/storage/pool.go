@@ -0,0 +1,21 @@
+package storage
+
+// PoolStats describes a backend's database connection pools, for
+// bd daemon --metrics. Backends without pools don't report it.
+type PoolStats struct {
+	Read  ConnPoolStats `json:"read"`
+	Write ConnPoolStats `json:"write"`
+}
+
+// ConnPoolStats is one pool's size and how long callers have waited for a
+// connection from it
+type ConnPoolStats struct {
+	MaxOpen   int     `json:"max_open"`
+	Open      int     `json:"open"`
+	InUse     int     `json:"in_use"`
+	Idle      int     `json:"idle"`
+	WaitCount int64   `json:"wait_count"`
+	WaitMS    float64 `json:"wait_ms"`
+	MaxWaitMS float64 `json:"max_wait_ms,omitempty"`
+	Timeouts  int64   `json:"timeouts,omitempty"`
+}
@@ -0,0 +1,161 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/lib/pq"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// commentColumns are the columns scanComment reads
+const commentColumns = `id, issue_id, author, text, created_at, updated_at`
+
+func scanComment(row scanner) (*types.Comment, error) {
+	comment := &types.Comment{}
+	var updatedAt sql.NullTime
+	if err := row.Scan(&comment.ID, &comment.IssueID, &comment.Author, &comment.Text, &comment.CreatedAt, &updatedAt); err != nil {
+		return nil, err
+	}
+	comment.CreatedAt = comment.CreatedAt.UTC()
+	comment.UpdatedAt = nullTime(updatedAt)
+	return comment, nil
+}
+
+// getComment returns a comment, or an error wrapping sqlite.ErrNotFound
+func (c *conn) getComment(ctx context.Context, commentID int64) (*types.Comment, error) {
+	comment, err := scanComment(c.queryRow(ctx, `SELECT `+commentColumns+` FROM comments WHERE id = ?`, commentID))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("get comment %d: %w", commentID, sqlite.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get comment %d: %w", commentID, err)
+	}
+	return comment, nil
+}
+
+// AddIssueComment adds a comment to an issue and records a commented event
+func (s *PostgresStorage) AddIssueComment(ctx context.Context, issueID, author, text string) (*types.Comment, error) {
+	if !utf8.ValidString(author) {
+		return nil, fmt.Errorf("comment author is not valid UTF-8")
+	}
+	if !utf8.ValidString(text) {
+		return nil, fmt.Errorf("comment text is not valid UTF-8")
+	}
+	var comment *types.Comment
+	err := s.withTx(ctx, func(c *conn) error {
+		var exists bool
+		if err := c.queryRow(ctx, `SELECT EXISTS(SELECT 1 FROM issues WHERE id = ?)`, issueID).Scan(&exists); err != nil {
+			return fmt.Errorf("failed to check issue existence: %w", err)
+		}
+		if !exists {
+			return fmt.Errorf("issue %s not found", issueID)
+		}
+		var err error
+		comment, err = scanComment(c.queryRow(ctx, `
+			INSERT INTO comments (issue_id, author, text) VALUES (?, ?, ?)
+			RETURNING `+commentColumns, issueID, author, text))
+		if err != nil {
+			return fmt.Errorf("failed to insert comment: %w", err)
+		}
+		if err := c.recordEvent(ctx, issueID, types.EventCommented, author, nil, nil, text); err != nil {
+			return err
+		}
+		return c.markDirty(ctx, issueID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+// UpdateIssueComment replaces a comment's text and records a comment_edited
+// event. Returns an error wrapping sqlite.ErrNotFound if there is no such
+// comment.
+func (s *PostgresStorage) UpdateIssueComment(ctx context.Context, commentID int64, text, actor string) (*types.Comment, error) {
+	if !utf8.ValidString(text) {
+		return nil, fmt.Errorf("comment text is not valid UTF-8")
+	}
+	var comment *types.Comment
+	err := s.withTx(ctx, func(c *conn) error {
+		var err error
+		if comment, err = c.getComment(ctx, commentID); err != nil {
+			return err
+		}
+		updatedAt := time.Now().UTC()
+		if _, err := c.exec(ctx, `UPDATE comments SET text = ?, updated_at = ? WHERE id = ?`, text, updatedAt, commentID); err != nil {
+			return fmt.Errorf("failed to update comment: %w", err)
+		}
+		comment.Text = text
+		comment.UpdatedAt = &updatedAt
+		if err := c.recordEvent(ctx, comment.IssueID, types.EventCommentEdited, actor, nil, nil, text); err != nil {
+			return err
+		}
+		return c.markDirty(ctx, comment.IssueID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+// DeleteIssueComment removes a comment, records a comment_deleted event and
+// returns the deleted comment
+func (s *PostgresStorage) DeleteIssueComment(ctx context.Context, commentID int64, actor string) (*types.Comment, error) {
+	var comment *types.Comment
+	err := s.withTx(ctx, func(c *conn) error {
+		var err error
+		if comment, err = c.getComment(ctx, commentID); err != nil {
+			return err
+		}
+		if _, err := c.exec(ctx, `DELETE FROM comments WHERE id = ?`, commentID); err != nil {
+			return fmt.Errorf("failed to delete comment: %w", err)
+		}
+		if err := c.recordEvent(ctx, comment.IssueID, types.EventCommentDeleted, actor, nil, nil, comment.Text); err != nil {
+			return err
+		}
+		return c.markDirty(ctx, comment.IssueID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+// GetIssueComments returns the comments of an issue, oldest first
+func (s *PostgresStorage) GetIssueComments(ctx context.Context, issueID string) ([]*types.Comment, error) {
+	comments, err := s.GetCommentsForIssues(ctx, []string{issueID})
+	if err != nil {
+		return nil, err
+	}
+	return comments[issueID], nil
+}
+
+// GetCommentsForIssues returns the comments of several issues in one query
+func (s *PostgresStorage) GetCommentsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.Comment, error) {
+	result := make(map[string][]*types.Comment)
+	if len(issueIDs) == 0 {
+		return result, nil
+	}
+	rows, err := s.read().query(ctx, `
+		SELECT `+commentColumns+` FROM comments
+		WHERE issue_id = ANY(?)
+		ORDER BY issue_id, created_at ASC, id ASC
+	`, pq.Array(issueIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to batch get comments: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+		comment, err := scanComment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan comment: %w", err)
+		}
+		result[comment.IssueID] = append(result[comment.IssueID], comment)
+	}
+	return result, rows.Err()
+}
@@ -0,0 +1,220 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+)
+
+// jsonlFileHashKey is the metadata key holding the hash of the last
+// exported JSONL file
+const jsonlFileHashKey = "jsonl_file_hash"
+
+func (c *conn) setKeyValue(ctx context.Context, table, key, value string) error {
+	// #nosec G201 - table is one of the constants below
+	_, err := c.exec(ctx, fmt.Sprintf(`
+		INSERT INTO %s (key, value) VALUES (?, ?)
+		ON CONFLICT (key) DO UPDATE SET value = excluded.value
+	`, table), key, value)
+	if err != nil {
+		return fmt.Errorf("set %s: %w", table, err)
+	}
+	return nil
+}
+
+func (c *conn) getKeyValue(ctx context.Context, table, key string) (string, error) {
+	var value string
+	// #nosec G201 - table is one of the constants below
+	err := c.queryRow(ctx, fmt.Sprintf(`SELECT value FROM %s WHERE key = ?`, table), key).Scan(&value)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("get %s: %w", table, err)
+	}
+	return value, nil
+}
+
+// getConfig returns a config value, or "" if it isn't set
+func (c *conn) getConfig(ctx context.Context, key string) (string, error) {
+	return c.getKeyValue(ctx, "config", key)
+}
+
+// getCustomStatuses returns the statuses configured in status.custom
+func (c *conn) getCustomStatuses(ctx context.Context) ([]string, error) {
+	value, err := c.getConfig(ctx, sqlite.CustomStatusConfigKey)
+	if err != nil {
+		return nil, err
+	}
+	return sqlite.ParseConfigList(value), nil
+}
+
+// SetConfig sets a configuration value
+func (s *PostgresStorage) SetConfig(ctx context.Context, key, value string) error {
+	return s.read().setKeyValue(ctx, "config", key, value)
+}
+
+// GetConfig gets a configuration value; "" if it isn't set
+func (s *PostgresStorage) GetConfig(ctx context.Context, key string) (string, error) {
+	return s.read().getConfig(ctx, key)
+}
+
+// GetAllConfig gets all configuration key-value pairs
+func (s *PostgresStorage) GetAllConfig(ctx context.Context) (map[string]string, error) {
+	rows, err := s.read().query(ctx, `SELECT key, value FROM config ORDER BY key`)
+	if err != nil {
+		return nil, fmt.Errorf("query all config: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	config := make(map[string]string)
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, fmt.Errorf("scan config row: %w", err)
+		}
+		config[key] = value
+	}
+	return config, rows.Err()
+}
+
+// DeleteConfig deletes a configuration value
+func (s *PostgresStorage) DeleteConfig(ctx context.Context, key string) error {
+	if _, err := s.read().exec(ctx, `DELETE FROM config WHERE key = ?`, key); err != nil {
+		return fmt.Errorf("delete config: %w", err)
+	}
+	return nil
+}
+
+// GetCustomStatuses returns the custom statuses from the status.custom config
+func (s *PostgresStorage) GetCustomStatuses(ctx context.Context) ([]string, error) {
+	return s.read().getCustomStatuses(ctx)
+}
+
+// GetCustomCloseReasons returns the custom close reasons from the
+// close.reasons config
+func (s *PostgresStorage) GetCustomCloseReasons(ctx context.Context) ([]string, error) {
+	value, err := s.GetConfig(ctx, sqlite.CloseReasonsConfigKey)
+	if err != nil {
+		return nil, err
+	}
+	return sqlite.ParseConfigList(value), nil
+}
+
+// SetMetadata sets a metadata value (for internal state like import hashes)
+func (s *PostgresStorage) SetMetadata(ctx context.Context, key, value string) error {
+	return s.read().setKeyValue(ctx, "metadata", key, value)
+}
+
+// GetMetadata gets a metadata value; "" if it isn't set
+func (s *PostgresStorage) GetMetadata(ctx context.Context, key string) (string, error) {
+	return s.read().getKeyValue(ctx, "metadata", key)
+}
+
+// markDirty marks issues for incremental export. IDs of issues that aren't
+// in this database, such as dependencies on a linked workspace, are skipped.
+func (c *conn) markDirty(ctx context.Context, issueIDs ...string) error {
+	_, err := c.exec(ctx, `
+		INSERT INTO dirty_issues (issue_id, marked_at)
+		SELECT id, now() FROM issues WHERE id = ANY(?)
+		ON CONFLICT (issue_id) DO UPDATE SET marked_at = excluded.marked_at
+	`, pq.Array(issueIDs))
+	if err != nil {
+		return fmt.Errorf("failed to mark issue dirty: %w", err)
+	}
+	return nil
+}
+
+// GetDirtyIssues returns the IDs of the issues changed since their last
+// export, oldest change first
+func (s *PostgresStorage) GetDirtyIssues(ctx context.Context) ([]string, error) {
+	rows, err := s.read().query(ctx, `SELECT issue_id FROM dirty_issues ORDER BY marked_at ASC, issue_id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dirty issues: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var issueIDs []string
+	for rows.Next() {
+		var issueID string
+		if err := rows.Scan(&issueID); err != nil {
+			return nil, fmt.Errorf("failed to scan issue ID: %w", err)
+		}
+		issueIDs = append(issueIDs, issueID)
+	}
+	return issueIDs, rows.Err()
+}
+
+// GetDirtyIssueHash returns the stored content hash of a dirty issue, or ""
+func (s *PostgresStorage) GetDirtyIssueHash(ctx context.Context, issueID string) (string, error) {
+	var hash sql.NullString
+	err := s.read().queryRow(ctx, `SELECT content_hash FROM dirty_issues WHERE issue_id = ?`, issueID).Scan(&hash)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("get dirty issue hash for %s: %w", issueID, err)
+	}
+	return hash.String, nil
+}
+
+// ClearDirtyIssuesByID removes the given issues from the dirty set, so only
+// the issues actually exported are cleared
+func (s *PostgresStorage) ClearDirtyIssuesByID(ctx context.Context, issueIDs []string) error {
+	if len(issueIDs) == 0 {
+		return nil
+	}
+	if _, err := s.read().exec(ctx, `DELETE FROM dirty_issues WHERE issue_id = ANY(?)`, pq.Array(issueIDs)); err != nil {
+		return fmt.Errorf("failed to clear dirty issues: %w", err)
+	}
+	return nil
+}
+
+// GetExportHash returns the content hash of an issue at its last export
+func (s *PostgresStorage) GetExportHash(ctx context.Context, issueID string) (string, error) {
+	var hash string
+	err := s.read().queryRow(ctx, `SELECT content_hash FROM export_hashes WHERE issue_id = ?`, issueID).Scan(&hash)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get export hash for %s: %w", issueID, err)
+	}
+	return hash, nil
+}
+
+// SetExportHash stores the content hash of an issue after export
+func (s *PostgresStorage) SetExportHash(ctx context.Context, issueID, contentHash string) error {
+	_, err := s.read().exec(ctx, `
+		INSERT INTO export_hashes (issue_id, content_hash, exported_at)
+		VALUES (?, ?, now())
+		ON CONFLICT (issue_id) DO UPDATE SET
+			content_hash = excluded.content_hash,
+			exported_at = excluded.exported_at
+	`, issueID, contentHash)
+	if err != nil {
+		return fmt.Errorf("failed to set export hash for %s: %w", issueID, err)
+	}
+	return nil
+}
+
+// ClearAllExportHashes removes all export hashes, forcing a full re-export
+func (s *PostgresStorage) ClearAllExportHashes(ctx context.Context) error {
+	if _, err := s.read().exec(ctx, `DELETE FROM export_hashes`); err != nil {
+		return fmt.Errorf("failed to clear export hashes: %w", err)
+	}
+	return nil
+}
+
+// GetJSONLFileHash returns the hash of the last exported JSONL file, or ""
+func (s *PostgresStorage) GetJSONLFileHash(ctx context.Context) (string, error) {
+	return s.GetMetadata(ctx, jsonlFileHashKey)
+}
+
+// SetJSONLFileHash stores the hash of the JSONL file after export
+func (s *PostgresStorage) SetJSONLFileHash(ctx context.Context, fileHash string) error {
+	return s.SetMetadata(ctx, jsonlFileHashKey, fileHash)
+}
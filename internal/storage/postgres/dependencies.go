@@ -0,0 +1,346 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// maxDependencyDepth bounds the graph walks, as in the sqlite backend
+const maxDependencyDepth = 100
+
+func (c *conn) addDependency(ctx context.Context, dep *types.Dependency, actor string) error {
+	if !dep.Type.IsValid() {
+		return fmt.Errorf("invalid dependency type: %q (must be non-empty string, max 50 chars)", dep.Type)
+	}
+	issue, err := c.getIssue(ctx, dep.IssueID)
+	if err != nil {
+		return fmt.Errorf("failed to check issue %s: %w", dep.IssueID, err)
+	}
+	if issue == nil {
+		return fmt.Errorf("issue %s not found", dep.IssueID)
+	}
+
+	// A qualified ID (api/bd-42) names an issue in a linked workspace, which
+	// isn't in this database and can't be part of a cycle here
+	external := types.IsQualifiedID(dep.DependsOnID)
+	target := &types.Issue{ID: dep.DependsOnID}
+	if external {
+		if dep.Type == types.DepParentChild {
+			return fmt.Errorf("an issue in another workspace (%s) can't be a parent", dep.DependsOnID)
+		}
+	} else {
+		target, err = c.getIssue(ctx, dep.DependsOnID)
+		if err != nil {
+			return fmt.Errorf("failed to check dependency %s: %w", dep.DependsOnID, err)
+		}
+		if target == nil {
+			return fmt.Errorf("dependency target %s not found", dep.DependsOnID)
+		}
+	}
+	if dep.IssueID == dep.DependsOnID {
+		return fmt.Errorf("issue cannot depend on itself")
+	}
+	// The child depends on its parent, never the other way round
+	if dep.Type == types.DepParentChild && issue.IssueType == types.TypeEpic && target.IssueType != types.TypeEpic {
+		return fmt.Errorf("invalid parent-child dependency: parent (%s) cannot depend on child (%s). Use: bd dep add %s %s --type parent-child",
+			dep.IssueID, dep.DependsOnID, dep.DependsOnID, dep.IssueID)
+	}
+
+	if dep.CreatedAt.IsZero() {
+		dep.CreatedAt = time.Now().UTC()
+	}
+	if dep.CreatedBy == "" {
+		dep.CreatedBy = actor
+	}
+
+	// relates-to links are symmetric "see also" edges and don't order work
+	if dep.Type != types.DepRelatesTo && !external {
+		var cycle bool
+		err := c.queryRow(ctx, `
+			WITH RECURSIVE paths AS (
+				SELECT depends_on_id, 1 AS depth
+				FROM dependencies
+				WHERE issue_id = ?
+				UNION ALL
+				SELECT d.depends_on_id, p.depth + 1
+				FROM dependencies d
+				JOIN paths p ON d.issue_id = p.depends_on_id
+				WHERE p.depth < ?
+			)
+			SELECT EXISTS (SELECT 1 FROM paths WHERE depends_on_id = ?)
+		`, dep.DependsOnID, maxDependencyDepth, dep.IssueID).Scan(&cycle)
+		if err != nil {
+			return fmt.Errorf("failed to check for cycles: %w", err)
+		}
+		if cycle {
+			return fmt.Errorf("cannot add dependency: would create a cycle (%s → %s → ... → %s)",
+				dep.IssueID, dep.DependsOnID, dep.IssueID)
+		}
+	}
+
+	metadata := dep.Metadata
+	if metadata == "" {
+		metadata = "{}"
+	}
+	if _, err := c.exec(ctx, `
+		INSERT INTO dependencies (issue_id, depends_on_id, type, created_at, created_by, metadata, thread_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, dep.IssueID, dep.DependsOnID, dep.Type, dep.CreatedAt.UTC(), dep.CreatedBy, metadata, dep.ThreadID); err != nil {
+		return fmt.Errorf("failed to add dependency: %w", err)
+	}
+	if err := c.recordEvent(ctx, dep.IssueID, types.EventDependencyAdded, actor, nil, nil,
+		fmt.Sprintf("Added dependency: %s %s %s", dep.IssueID, dep.Type, dep.DependsOnID)); err != nil {
+		return err
+	}
+	return c.markDirty(ctx, dep.IssueID, dep.DependsOnID)
+}
+
+// AddDependency adds a dependency between issues with cycle prevention
+func (s *PostgresStorage) AddDependency(ctx context.Context, dep *types.Dependency, actor string) error {
+	return s.withTx(ctx, func(c *conn) error {
+		return c.addDependency(ctx, dep, actor)
+	})
+}
+
+func (c *conn) removeDependency(ctx context.Context, issueID, dependsOnID, actor string) error {
+	result, err := c.exec(ctx, `DELETE FROM dependencies WHERE issue_id = ? AND depends_on_id = ?`, issueID, dependsOnID)
+	if err != nil {
+		return fmt.Errorf("failed to remove dependency: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("dependency from %s to %s does not exist", issueID, dependsOnID)
+	}
+	if err := c.recordEvent(ctx, issueID, types.EventDependencyRemoved, actor, nil, nil,
+		fmt.Sprintf("Removed dependency on %s", dependsOnID)); err != nil {
+		return err
+	}
+	return c.markDirty(ctx, issueID, dependsOnID)
+}
+
+// RemoveDependency removes a dependency
+func (s *PostgresStorage) RemoveDependency(ctx context.Context, issueID, dependsOnID string, actor string) error {
+	return s.withTx(ctx, func(c *conn) error {
+		return c.removeDependency(ctx, issueID, dependsOnID, actor)
+	})
+}
+
+// GetDependencies returns the issues this issue depends on
+func (s *PostgresStorage) GetDependencies(ctx context.Context, issueID string) ([]*types.Issue, error) {
+	c := s.read()
+	rows, err := c.query(ctx, `
+		SELECT `+prefixedColumns("i")+`
+		FROM issues i
+		JOIN dependencies d ON i.id = d.depends_on_id
+		WHERE d.issue_id = ?
+		ORDER BY i.priority ASC, i.id
+	`, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dependencies: %w", err)
+	}
+	return c.scanIssues(ctx, rows)
+}
+
+// GetDependents returns the issues that depend on this issue
+func (s *PostgresStorage) GetDependents(ctx context.Context, issueID string) ([]*types.Issue, error) {
+	c := s.read()
+	rows, err := c.query(ctx, `
+		SELECT `+prefixedColumns("i")+`
+		FROM issues i
+		JOIN dependencies d ON i.id = d.issue_id
+		WHERE d.depends_on_id = ?
+		ORDER BY i.priority ASC, i.id
+	`, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dependents: %w", err)
+	}
+	return c.scanIssues(ctx, rows)
+}
+
+// GetDependencyCounts returns dependency and dependent counts for multiple
+// issues in a single query
+func (s *PostgresStorage) GetDependencyCounts(ctx context.Context, issueIDs []string) (map[string]*types.DependencyCounts, error) {
+	result := make(map[string]*types.DependencyCounts, len(issueIDs))
+	if len(issueIDs) == 0 {
+		return result, nil
+	}
+	rows, err := s.read().query(ctx, `
+		SELECT id,
+		       (SELECT COUNT(*) FROM dependencies WHERE issue_id = id),
+		       (SELECT COUNT(*) FROM dependencies WHERE depends_on_id = id)
+		FROM unnest(?::text[]) AS id
+	`, pq.Array(issueIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dependency counts: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+		var id string
+		var counts types.DependencyCounts
+		if err := rows.Scan(&id, &counts.DependencyCount, &counts.DependentCount); err != nil {
+			return nil, fmt.Errorf("failed to scan dependency counts: %w", err)
+		}
+		result[id] = &counts
+	}
+	return result, rows.Err()
+}
+
+// dependencyColumns are the columns scanDependencies reads
+const dependencyColumns = `issue_id, depends_on_id, type, created_at, created_by, metadata, thread_id`
+
+func (c *conn) scanDependencies(ctx context.Context, query string, args ...interface{}) ([]*types.Dependency, error) {
+	rows, err := c.query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dependency records: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	var deps []*types.Dependency
+	for rows.Next() {
+		var dep types.Dependency
+		if err := rows.Scan(&dep.IssueID, &dep.DependsOnID, &dep.Type, &dep.CreatedAt, &dep.CreatedBy, &dep.Metadata, &dep.ThreadID); err != nil {
+			return nil, fmt.Errorf("failed to scan dependency: %w", err)
+		}
+		dep.CreatedAt = dep.CreatedAt.UTC()
+		deps = append(deps, &dep)
+	}
+	return deps, rows.Err()
+}
+
+// GetDependencyRecords returns raw dependency records for an issue
+func (s *PostgresStorage) GetDependencyRecords(ctx context.Context, issueID string) ([]*types.Dependency, error) {
+	return s.read().scanDependencies(ctx, `
+		SELECT `+dependencyColumns+` FROM dependencies
+		WHERE issue_id = ?
+		ORDER BY created_at ASC, depends_on_id
+	`, issueID)
+}
+
+// GetAllDependencyRecords returns all dependency records grouped by issue ID
+func (s *PostgresStorage) GetAllDependencyRecords(ctx context.Context) (map[string][]*types.Dependency, error) {
+	deps, err := s.read().scanDependencies(ctx, `
+		SELECT `+dependencyColumns+` FROM dependencies
+		ORDER BY issue_id, created_at ASC, depends_on_id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	byIssue := make(map[string][]*types.Dependency)
+	for _, dep := range deps {
+		byIssue[dep.IssueID] = append(byIssue[dep.IssueID], dep)
+	}
+	return byIssue, nil
+}
+
+// GetDependencyTree returns the dependency tree of issueID (or its
+// dependents when reverse is set). Unless showAllPaths is set, an issue
+// reached along several paths appears once, at its shallowest depth.
+func (s *PostgresStorage) GetDependencyTree(ctx context.Context, issueID string, maxDepth int, showAllPaths bool, reverse bool) ([]*types.TreeNode, error) {
+	if maxDepth <= 0 {
+		maxDepth = 50
+	}
+	next, from := "d.depends_on_id", "d.issue_id"
+	if reverse {
+		next, from = "d.issue_id", "d.depends_on_id"
+	}
+	// #nosec G201 - the joined columns are fixed above
+	query := fmt.Sprintf(`
+		WITH RECURSIVE tree AS (
+			SELECT id, 0 AS depth, ARRAY[id] AS path, id AS parent_id
+			FROM issues WHERE id = ?
+			UNION ALL
+			SELECT %[1]s, t.depth + 1, t.path || %[1]s, t.id
+			FROM dependencies d
+			JOIN tree t ON %[2]s = t.id
+			WHERE t.depth < ? AND NOT %[1]s = ANY(t.path)
+		)
+		SELECT %[3]s, t.depth, t.parent_id
+		FROM tree t JOIN issues i ON i.id = t.id
+		ORDER BY t.depth, i.priority, i.id
+	`, next, from, prefixedColumns("i"))
+
+	rows, err := s.read().query(ctx, query, issueID, maxDepth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dependency tree: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	seen := make(map[string]bool)
+	var nodes []*types.TreeNode
+	for rows.Next() {
+		var node types.TreeNode
+		issue, err := scanIssue(rows, &node.Depth, &node.ParentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan tree node: %w", err)
+		}
+		node.Issue = *issue
+		node.Truncated = node.Depth == maxDepth
+		if !showAllPaths {
+			if seen[node.ID] {
+				continue
+			}
+			seen[node.ID] = true
+		}
+		nodes = append(nodes, &node)
+	}
+	return nodes, rows.Err()
+}
+
+// DetectCycles finds circular dependencies and returns the issues on each
+// cycle
+func (s *PostgresStorage) DetectCycles(ctx context.Context) ([][]*types.Issue, error) {
+	c := s.read()
+	rows, err := c.query(ctx, `
+		WITH RECURSIVE paths AS (
+			SELECT issue_id AS start_id, depends_on_id, ARRAY[issue_id, depends_on_id] AS path, 0 AS depth
+			FROM dependencies
+			UNION ALL
+			SELECT p.start_id, d.depends_on_id, p.path || d.depends_on_id, p.depth + 1
+			FROM dependencies d
+			JOIN paths p ON d.issue_id = p.depends_on_id
+			WHERE p.depth < ?
+			  AND p.depends_on_id != p.start_id
+			  AND (d.depends_on_id = p.start_id OR NOT d.depends_on_id = ANY(p.path))
+		)
+		SELECT DISTINCT path FROM paths
+		WHERE depends_on_id = start_id
+		ORDER BY path
+	`, maxDependencyDepth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect cycles: %w", err)
+	}
+	var paths [][]string
+	for rows.Next() {
+		var path []string
+		if err := rows.Scan(pq.Array(&path)); err != nil {
+			_ = rows.Close()
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var cycles [][]*types.Issue
+	for _, path := range paths {
+		// The path closes back at its start
+		var cycle []*types.Issue
+		for _, id := range path[:len(path)-1] {
+			issue, err := c.getIssue(ctx, id)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get issue %s: %w", id, err)
+			}
+			if issue != nil {
+				cycle = append(cycle, issue)
+			}
+		}
+		if len(cycle) > 0 {
+			cycles = append(cycles, cycle)
+		}
+	}
+	return cycles, nil
+}
@@ -0,0 +1,393 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// requireIssue returns an error if there is no issue with the given ID
+func (c *conn) requireIssue(ctx context.Context, issueID string) error {
+	var exists bool
+	if err := c.queryRow(ctx, `SELECT EXISTS(SELECT 1 FROM issues WHERE id = ?)`, issueID).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to check issue existence: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("issue %s not found", issueID)
+	}
+	return nil
+}
+
+// SetIssueAlias gives an issue a human-friendly alias. Setting an alias the
+// issue already has is a no-op. An alias can't name an existing issue ID or
+// another issue's alias.
+func (s *PostgresStorage) SetIssueAlias(ctx context.Context, issueID, alias, actor string) error {
+	if err := types.ValidateAlias(alias); err != nil {
+		return err
+	}
+	return s.withTx(ctx, func(c *conn) error {
+		if err := c.requireIssue(ctx, issueID); err != nil {
+			return err
+		}
+		var exists bool
+		if err := c.queryRow(ctx, `SELECT EXISTS(SELECT 1 FROM issues WHERE id = ?)`, alias).Scan(&exists); err != nil {
+			return fmt.Errorf("failed to check issue existence: %w", err)
+		}
+		if exists {
+			return fmt.Errorf("alias %q is already an issue ID", alias)
+		}
+
+		var current string
+		err := c.queryRow(ctx, `SELECT issue_id FROM issue_aliases WHERE alias = ?`, alias).Scan(&current)
+		switch {
+		case err == sql.ErrNoRows:
+		case err != nil:
+			return fmt.Errorf("failed to look up alias: %w", err)
+		case current == issueID:
+			return nil
+		default:
+			return fmt.Errorf("alias %q already refers to %s", alias, current)
+		}
+
+		if _, err := c.exec(ctx, `INSERT INTO issue_aliases (alias, issue_id) VALUES (?, ?)`, alias, issueID); err != nil {
+			return fmt.Errorf("failed to add alias: %w", err)
+		}
+		return c.markDirty(ctx, issueID)
+	})
+}
+
+// RemoveIssueAlias deletes an alias; removing an unknown alias is an error
+func (s *PostgresStorage) RemoveIssueAlias(ctx context.Context, alias, actor string) error {
+	return s.withTx(ctx, func(c *conn) error {
+		var issueID string
+		err := c.queryRow(ctx, `DELETE FROM issue_aliases WHERE alias = ? RETURNING issue_id`, alias).Scan(&issueID)
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("no issue has alias %q", alias)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to remove alias: %w", err)
+		}
+		return c.markDirty(ctx, issueID)
+	})
+}
+
+// ResolveIssueAlias returns the ID of the issue with the given alias, or ""
+// if no issue has it
+func (s *PostgresStorage) ResolveIssueAlias(ctx context.Context, alias string) (string, error) {
+	var issueID string
+	err := s.read().queryRow(ctx, `SELECT issue_id FROM issue_aliases WHERE alias = ?`, alias).Scan(&issueID)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve alias: %w", err)
+	}
+	return issueID, nil
+}
+
+// GetAliasesForIssues returns the sorted aliases of several issues
+func (s *PostgresStorage) GetAliasesForIssues(ctx context.Context, issueIDs []string) (map[string][]string, error) {
+	return s.read().getAliasesForIssues(ctx, issueIDs)
+}
+
+func (c *conn) getAliasesForIssues(ctx context.Context, issueIDs []string) (map[string][]string, error) {
+	result := make(map[string][]string)
+	if len(issueIDs) == 0 {
+		return result, nil
+	}
+	rows, err := c.query(ctx, `
+		SELECT issue_id, alias FROM issue_aliases
+		WHERE issue_id = ANY(?)
+		ORDER BY issue_id, alias
+	`, pq.Array(issueIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query aliases: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+		var issueID, alias string
+		if err := rows.Scan(&issueID, &alias); err != nil {
+			return nil, fmt.Errorf("failed to scan alias: %w", err)
+		}
+		result[issueID] = append(result[issueID], alias)
+	}
+	return result, rows.Err()
+}
+
+// AddAttachment records an attachment on an issue. Adding the same URL to an
+// issue twice is a no-op. ID and CreatedAt are filled in on the passed
+// attachment.
+func (s *PostgresStorage) AddAttachment(ctx context.Context, attachment *types.Attachment) error {
+	return s.withTx(ctx, func(c *conn) error {
+		if err := c.requireIssue(ctx, attachment.IssueID); err != nil {
+			return err
+		}
+		if attachment.CreatedAt.IsZero() {
+			attachment.CreatedAt = time.Now().UTC()
+		}
+		err := c.queryRow(ctx, `
+			INSERT INTO attachments (issue_id, name, url, sha256, size, created_by, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (issue_id, url) DO NOTHING
+			RETURNING id
+		`, attachment.IssueID, attachment.Name, attachment.URL, attachment.SHA256, attachment.Size,
+			attachment.CreatedBy, attachment.CreatedAt).Scan(&attachment.ID)
+		if err == sql.ErrNoRows {
+			return c.queryRow(ctx, `
+				SELECT id FROM attachments WHERE issue_id = ? AND url = ?
+			`, attachment.IssueID, attachment.URL).Scan(&attachment.ID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to insert attachment: %w", err)
+		}
+		return c.markDirty(ctx, attachment.IssueID)
+	})
+}
+
+// GetAttachments returns the attachments of an issue, oldest first
+func (s *PostgresStorage) GetAttachments(ctx context.Context, issueID string) ([]*types.Attachment, error) {
+	result, err := s.GetAttachmentsForIssues(ctx, []string{issueID})
+	if err != nil {
+		return nil, err
+	}
+	return result[issueID], nil
+}
+
+// GetAttachmentsForIssues returns the attachments of several issues
+func (s *PostgresStorage) GetAttachmentsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.Attachment, error) {
+	result := make(map[string][]*types.Attachment)
+	if len(issueIDs) == 0 {
+		return result, nil
+	}
+	rows, err := s.read().query(ctx, `
+		SELECT id, issue_id, name, url, sha256, size, created_by, created_at
+		FROM attachments
+		WHERE issue_id = ANY(?)
+		ORDER BY issue_id, created_at ASC, id ASC
+	`, pq.Array(issueIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query attachments: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+		a := &types.Attachment{}
+		if err := rows.Scan(&a.ID, &a.IssueID, &a.Name, &a.URL, &a.SHA256, &a.Size, &a.CreatedBy, &a.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan attachment: %w", err)
+		}
+		a.CreatedAt = a.CreatedAt.UTC()
+		result[a.IssueID] = append(result[a.IssueID], a)
+	}
+	return result, rows.Err()
+}
+
+// AddWorkEntry logs time against an issue. An entry the issue already has
+// (same actor and time) is a no-op. ID and CreatedAt are filled in on the
+// passed entry.
+func (s *PostgresStorage) AddWorkEntry(ctx context.Context, entry *types.WorkEntry) error {
+	if entry.Minutes <= 0 {
+		return fmt.Errorf("logged time must be positive, got %d minutes", entry.Minutes)
+	}
+	return s.withTx(ctx, func(c *conn) error {
+		if err := c.requireIssue(ctx, entry.IssueID); err != nil {
+			return err
+		}
+		if entry.CreatedAt.IsZero() {
+			entry.CreatedAt = time.Now().UTC()
+		}
+		err := c.queryRow(ctx, `
+			INSERT INTO work_log (issue_id, actor, minutes, note, created_at)
+			VALUES (?, ?, ?, ?, ?)
+			ON CONFLICT (issue_id, actor, created_at) DO NOTHING
+			RETURNING id
+		`, entry.IssueID, entry.Actor, entry.Minutes, entry.Note, entry.CreatedAt).Scan(&entry.ID)
+		if err == sql.ErrNoRows {
+			return c.queryRow(ctx, `
+				SELECT id FROM work_log WHERE issue_id = ? AND actor = ? AND created_at = ?
+			`, entry.IssueID, entry.Actor, entry.CreatedAt).Scan(&entry.ID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to insert work log entry: %w", err)
+		}
+		return c.markDirty(ctx, entry.IssueID)
+	})
+}
+
+// GetWorkLog returns an issue's work log, oldest first
+func (s *PostgresStorage) GetWorkLog(ctx context.Context, issueID string) ([]*types.WorkEntry, error) {
+	result, err := s.GetWorkLogForIssues(ctx, []string{issueID})
+	if err != nil {
+		return nil, err
+	}
+	return result[issueID], nil
+}
+
+// GetWorkLogForIssues returns the work logs of several issues
+func (s *PostgresStorage) GetWorkLogForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.WorkEntry, error) {
+	result := make(map[string][]*types.WorkEntry)
+	if len(issueIDs) == 0 {
+		return result, nil
+	}
+	rows, err := s.read().query(ctx, `
+		SELECT id, issue_id, actor, minutes, note, created_at
+		FROM work_log
+		WHERE issue_id = ANY(?)
+		ORDER BY issue_id, created_at ASC, id ASC
+	`, pq.Array(issueIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query work log: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+		e := &types.WorkEntry{}
+		if err := rows.Scan(&e.ID, &e.IssueID, &e.Actor, &e.Minutes, &e.Note, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan work log entry: %w", err)
+		}
+		e.CreatedAt = e.CreatedAt.UTC()
+		result[e.IssueID] = append(result[e.IssueID], e)
+	}
+	return result, rows.Err()
+}
+
+// SetTranslation stores an issue's title and description for one locale,
+// replacing any earlier translation for it. A translation with neither a
+// title nor a description removes the locale.
+func (s *PostgresStorage) SetTranslation(ctx context.Context, issueID string, t *types.Translation, actor string) error {
+	locale, err := types.NormalizeLocale(t.Locale)
+	if err != nil {
+		return err
+	}
+	return s.withTx(ctx, func(c *conn) error {
+		if err := c.requireIssue(ctx, issueID); err != nil {
+			return err
+		}
+		var title, description string
+		err := c.queryRow(ctx, `SELECT title, description FROM issue_translations WHERE issue_id = ? AND locale = ?`, issueID, locale).Scan(&title, &description)
+		switch {
+		case err == sql.ErrNoRows:
+			if t.Title == "" && t.Description == "" {
+				return nil
+			}
+		case err != nil:
+			return fmt.Errorf("failed to look up translation: %w", err)
+		case title == t.Title && description == t.Description:
+			return nil
+		}
+		if t.Title == "" && t.Description == "" {
+			_, err = c.exec(ctx, `DELETE FROM issue_translations WHERE issue_id = ? AND locale = ?`, issueID, locale)
+		} else {
+			_, err = c.exec(ctx, `
+				INSERT INTO issue_translations (issue_id, locale, title, description, updated_at)
+				VALUES (?, ?, ?, ?, now())
+				ON CONFLICT (issue_id, locale) DO UPDATE SET
+					title = excluded.title,
+					description = excluded.description,
+					updated_at = excluded.updated_at
+			`, issueID, locale, t.Title, t.Description)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to set translation: %w", err)
+		}
+		return c.markDirty(ctx, issueID)
+	})
+}
+
+// GetTranslationsForIssues returns the translations of several issues,
+// sorted by locale
+func (s *PostgresStorage) GetTranslationsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.Translation, error) {
+	return s.read().getTranslationsForIssues(ctx, issueIDs)
+}
+
+func (c *conn) getTranslationsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.Translation, error) {
+	result := make(map[string][]*types.Translation)
+	if len(issueIDs) == 0 {
+		return result, nil
+	}
+	rows, err := c.query(ctx, `
+		SELECT issue_id, locale, title, description FROM issue_translations
+		WHERE issue_id = ANY(?)
+		ORDER BY issue_id, locale
+	`, pq.Array(issueIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query translations: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+		var issueID string
+		var t types.Translation
+		if err := rows.Scan(&issueID, &t.Locale, &t.Title, &t.Description); err != nil {
+			return nil, fmt.Errorf("failed to scan translation: %w", err)
+		}
+		result[issueID] = append(result[issueID], &t)
+	}
+	return result, rows.Err()
+}
+
+// SetIssueField stores an issue's value for a custom field, replacing any
+// earlier value; an empty value removes the field from the issue. Values
+// are stored as given: storage.SetIssueFields checks them first.
+func (s *PostgresStorage) SetIssueField(ctx context.Context, issueID, name, value, actor string) error {
+	return s.withTx(ctx, func(c *conn) error {
+		if err := c.requireIssue(ctx, issueID); err != nil {
+			return err
+		}
+		var current string
+		err := c.queryRow(ctx, `SELECT value FROM issue_fields WHERE issue_id = ? AND name = ?`, issueID, name).Scan(&current)
+		switch {
+		case err == sql.ErrNoRows:
+			if value == "" {
+				return nil
+			}
+		case err != nil:
+			return fmt.Errorf("failed to look up field %s: %w", name, err)
+		case current == value:
+			return nil
+		}
+		if value == "" {
+			_, err = c.exec(ctx, `DELETE FROM issue_fields WHERE issue_id = ? AND name = ?`, issueID, name)
+		} else {
+			_, err = c.exec(ctx, `
+				INSERT INTO issue_fields (issue_id, name, value) VALUES (?, ?, ?)
+				ON CONFLICT (issue_id, name) DO UPDATE SET value = excluded.value
+			`, issueID, name, value)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to set field %s: %w", name, err)
+		}
+		return c.markDirty(ctx, issueID)
+	})
+}
+
+// GetFieldsForIssues returns the custom field values of several issues
+func (s *PostgresStorage) GetFieldsForIssues(ctx context.Context, issueIDs []string) (map[string]types.CustomFields, error) {
+	return s.read().getFieldsForIssues(ctx, issueIDs)
+}
+
+func (c *conn) getFieldsForIssues(ctx context.Context, issueIDs []string) (map[string]types.CustomFields, error) {
+	result := make(map[string]types.CustomFields)
+	if len(issueIDs) == 0 {
+		return result, nil
+	}
+	rows, err := c.query(ctx, `
+		SELECT issue_id, name, value FROM issue_fields
+		WHERE issue_id = ANY(?)
+	`, pq.Array(issueIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query fields: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+		var issueID, name, value string
+		if err := rows.Scan(&issueID, &name, &value); err != nil {
+			return nil, fmt.Errorf("failed to scan field: %w", err)
+		}
+		if result[issueID] == nil {
+			result[issueID] = make(types.CustomFields)
+		}
+		result[issueID][name] = value
+	}
+	return result, rows.Err()
+}
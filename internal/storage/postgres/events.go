@@ -0,0 +1,116 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// recordEvent appends an event to the issue's audit trail. oldValue,
+// newValue and comment are strings or nil.
+func (c *conn) recordEvent(ctx context.Context, issueID string, eventType types.EventType, actor string, oldValue, newValue, comment interface{}) error {
+	_, err := c.exec(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, old_value, new_value, comment)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, issueID, string(eventType), actor, oldValue, newValue, comment)
+	if err != nil {
+		return fmt.Errorf("failed to record %s event: %w", eventType, err)
+	}
+	return nil
+}
+
+// AddComment adds a comment to an issue's event history
+func (s *PostgresStorage) AddComment(ctx context.Context, issueID, actor, comment string) error {
+	return s.withTx(ctx, func(c *conn) error {
+		return c.addComment(ctx, issueID, actor, comment)
+	})
+}
+
+func (c *conn) addComment(ctx context.Context, issueID, actor, comment string) error {
+	res, err := c.exec(ctx, `UPDATE issues SET updated_at = ? WHERE id = ?`, time.Now().UTC(), issueID)
+	if err != nil {
+		return fmt.Errorf("failed to update timestamp: %w", err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	} else if n == 0 {
+		return fmt.Errorf("issue %s not found", issueID)
+	}
+	if err := c.recordEvent(ctx, issueID, types.EventCommented, actor, nil, nil, comment); err != nil {
+		return err
+	}
+	return c.markDirty(ctx, issueID)
+}
+
+// eventColumns are the columns scanEvents reads
+const eventColumns = `id, issue_id, event_type, actor, old_value, new_value, comment, created_at`
+
+// scanEvents reads the events selected by query
+func (c *conn) scanEvents(ctx context.Context, query string, args ...interface{}) ([]*types.Event, error) {
+	rows, err := c.query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var events []*types.Event
+	for rows.Next() {
+		var event types.Event
+		var oldValue, newValue, comment sql.NullString
+		if err := rows.Scan(
+			&event.ID, &event.IssueID, &event.EventType, &event.Actor,
+			&oldValue, &newValue, &comment, &event.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+		if oldValue.Valid {
+			event.OldValue = &oldValue.String
+		}
+		if newValue.Valid {
+			event.NewValue = &newValue.String
+		}
+		if comment.Valid {
+			event.Comment = &comment.String
+		}
+		event.CreatedAt = event.CreatedAt.UTC()
+		events = append(events, &event)
+	}
+	return events, rows.Err()
+}
+
+// GetEvents returns the event history of an issue, newest first
+func (s *PostgresStorage) GetEvents(ctx context.Context, issueID string, limit int) ([]*types.Event, error) {
+	query := `SELECT ` + eventColumns + ` FROM events WHERE issue_id = ? ORDER BY created_at DESC, id DESC`
+	args := []interface{}{issueID}
+	if limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, limit)
+	}
+	return s.read().scanEvents(ctx, query, args...)
+}
+
+// GetEventsSince returns events of all issues with an ID greater than
+// afterID, oldest first. Writers hold the write lock until they commit, so
+// events become visible in ID order and the last ID returned is a cursor
+// for the next call.
+func (s *PostgresStorage) GetEventsSince(ctx context.Context, afterID int64, limit int) ([]*types.Event, error) {
+	query := `SELECT ` + eventColumns + ` FROM events WHERE id > ? ORDER BY id ASC`
+	args := []interface{}{afterID}
+	if limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, limit)
+	}
+	return s.read().scanEvents(ctx, query, args...)
+}
+
+// LatestEventID returns the highest event ID, or 0 when there are no events
+func (s *PostgresStorage) LatestEventID(ctx context.Context) (int64, error) {
+	var id int64
+	if err := s.read().queryRow(ctx, `SELECT COALESCE(MAX(id), 0) FROM events`).Scan(&id); err != nil {
+		return 0, fmt.Errorf("failed to get latest event: %w", err)
+	}
+	return id, nil
+}
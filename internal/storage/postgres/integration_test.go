@@ -0,0 +1,311 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// testDSNEnvVar names the database the integration tests run against. The
+// tests are skipped when it is unset; each one works in a schema of its own,
+// dropped afterwards.
+const testDSNEnvVar = "BEADS_TEST_POSTGRES_DSN"
+
+// withSearchPath adds a search_path parameter to a URL or key=value DSN
+func withSearchPath(dsn, schema string) string {
+	if strings.Contains(dsn, "://") {
+		sep := "?"
+		if strings.Contains(dsn, "?") {
+			sep = "&"
+		}
+		return dsn + sep + "search_path=" + schema
+	}
+	return dsn + " search_path=" + schema
+}
+
+func setupTestDB(t *testing.T) *PostgresStorage {
+	t.Helper()
+	dsn := os.Getenv(testDSNEnvVar)
+	if dsn == "" {
+		t.Skipf("%s not set", testDSNEnvVar)
+	}
+	ctx := context.Background()
+
+	admin, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to open %s: %v", RedactDSN(dsn), err)
+	}
+	schema := fmt.Sprintf("bd_test_%d", time.Now().UnixNano())
+	if _, err := admin.ExecContext(ctx, "CREATE SCHEMA "+schema); err != nil {
+		_ = admin.Close()
+		t.Fatalf("failed to create schema: %v", err)
+	}
+
+	store, err := New(ctx, withSearchPath(dsn, schema))
+	if err != nil {
+		_, _ = admin.ExecContext(ctx, "DROP SCHEMA "+schema+" CASCADE")
+		_ = admin.Close()
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = store.Close()
+		_, _ = admin.ExecContext(ctx, "DROP SCHEMA "+schema+" CASCADE")
+		_ = admin.Close()
+	})
+
+	if err := store.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+		t.Fatalf("failed to set issue_prefix: %v", err)
+	}
+	return store
+}
+
+func createTestIssue(t *testing.T, store *PostgresStorage, title string, priority int) *types.Issue {
+	t.Helper()
+	issue := &types.Issue{
+		Title:     title,
+		Status:    types.StatusOpen,
+		Priority:  priority,
+		IssueType: types.TypeTask,
+	}
+	if err := store.CreateIssue(context.Background(), issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue(%q) failed: %v", title, err)
+	}
+	return issue
+}
+
+func TestIntegrationIssueLifecycle(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	issue := createTestIssue(t, store, "Write the schema", 1)
+	if !strings.HasPrefix(issue.ID, "bd-") {
+		t.Errorf("ID %q doesn't use the configured prefix", issue.ID)
+	}
+
+	got, err := store.GetIssue(ctx, issue.ID)
+	if err != nil || got == nil {
+		t.Fatalf("GetIssue = %v, %v", got, err)
+	}
+	if got.Title != issue.Title || got.Priority != 1 || got.ContentHash == "" {
+		t.Errorf("GetIssue = %+v", got)
+	}
+
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"assignee": "alice", "priority": 0}, "test-user"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	got, _ = store.GetIssue(ctx, issue.ID)
+	if got.Assignee != "alice" || got.Priority != 0 {
+		t.Errorf("after update: assignee=%q priority=%d", got.Assignee, got.Priority)
+	}
+
+	if err := store.CloseIssue(ctx, issue.ID, "done", "test-user"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	got, _ = store.GetIssue(ctx, issue.ID)
+	if got.Status != types.StatusClosed || got.ClosedAt == nil || got.CloseReason != "done" {
+		t.Errorf("after close: status=%s closed_at=%v reason=%q", got.Status, got.ClosedAt, got.CloseReason)
+	}
+
+	events, err := store.GetEvents(ctx, issue.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	if len(events) < 3 {
+		t.Errorf("got %d events, want created, updated and closed", len(events))
+	}
+
+	missing, err := store.GetIssue(ctx, "bd-missing")
+	if err != nil || missing != nil {
+		t.Errorf("GetIssue(missing) = %v, %v; want nil, nil", missing, err)
+	}
+}
+
+func TestIntegrationSearch(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	createTestIssue(t, store, "Fix 100% CPU in the daemon", 0)
+	createTestIssue(t, store, "Document the daemon", 2)
+	createTestIssue(t, store, "Unrelated", 1)
+
+	results, err := store.SearchIssues(ctx, "daemon", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(results) != 2 || results[0].Priority != 0 {
+		t.Errorf("search for daemon = %v", issueTitles(results))
+	}
+
+	results, err = store.SearchIssues(ctx, "100%", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(results) != 1 {
+		t.Errorf("search for 100%% = %v", issueTitles(results))
+	}
+}
+
+func TestIntegrationDependenciesAndReadyWork(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	blocker := createTestIssue(t, store, "Blocker", 1)
+	blocked := createTestIssue(t, store, "Blocked", 0)
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: blocked.ID, DependsOnID: blocker.ID, Type: types.DepBlocks}, "test-user"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+
+	deps, err := store.GetDependencies(ctx, blocked.ID)
+	if err != nil || len(deps) != 1 || deps[0].ID != blocker.ID {
+		t.Errorf("GetDependencies = %v, %v", issueTitles(deps), err)
+	}
+	dependents, err := store.GetDependents(ctx, blocker.ID)
+	if err != nil || len(dependents) != 1 || dependents[0].ID != blocked.ID {
+		t.Errorf("GetDependents = %v, %v", issueTitles(dependents), err)
+	}
+
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: blocker.ID, DependsOnID: blocked.ID, Type: types.DepBlocks}, "test-user"); err == nil {
+		t.Error("expected a cycle error")
+	}
+
+	ready, err := store.GetReadyWork(ctx, types.WorkFilter{})
+	if err != nil {
+		t.Fatalf("GetReadyWork failed: %v", err)
+	}
+	if len(ready) != 1 || ready[0].ID != blocker.ID {
+		t.Errorf("ready = %v, want only the blocker", issueTitles(ready))
+	}
+
+	blockedIssues, err := store.GetBlockedIssues(ctx)
+	if err != nil {
+		t.Fatalf("GetBlockedIssues failed: %v", err)
+	}
+	if len(blockedIssues) != 1 || blockedIssues[0].ID != blocked.ID || blockedIssues[0].BlockedByCount != 1 {
+		t.Errorf("blocked = %+v", blockedIssues)
+	}
+
+	if err := store.CloseIssue(ctx, blocker.ID, "done", "test-user"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	claimed, err := store.ClaimReadyWork(ctx, types.WorkFilter{}, "bob")
+	if err != nil {
+		t.Fatalf("ClaimReadyWork failed: %v", err)
+	}
+	if claimed == nil || claimed.ID != blocked.ID || claimed.Assignee != "bob" || claimed.Status != types.StatusInProgress {
+		t.Errorf("claimed = %+v", claimed)
+	}
+
+	if err := store.RemoveDependency(ctx, blocked.ID, blocker.ID, "test-user"); err != nil {
+		t.Fatalf("RemoveDependency failed: %v", err)
+	}
+	if deps, _ := store.GetDependencies(ctx, blocked.ID); len(deps) != 0 {
+		t.Errorf("dependencies after remove = %v", issueTitles(deps))
+	}
+}
+
+func TestIntegrationLabelsAndComments(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+	issue := createTestIssue(t, store, "Labelled", 2)
+
+	for _, label := range []string{"backend", "api", "backend"} {
+		if err := store.AddLabel(ctx, issue.ID, label, "test-user"); err != nil {
+			t.Fatalf("AddLabel(%q) failed: %v", label, err)
+		}
+	}
+	labels, err := store.GetLabels(ctx, issue.ID)
+	if err != nil || strings.Join(labels, ",") != "api,backend" {
+		t.Errorf("GetLabels = %v, %v", labels, err)
+	}
+	if err := store.RemoveLabel(ctx, issue.ID, "api", "test-user"); err != nil {
+		t.Fatalf("RemoveLabel failed: %v", err)
+	}
+	byLabel, err := store.GetIssuesByLabel(ctx, "backend")
+	if err != nil || len(byLabel) != 1 {
+		t.Errorf("GetIssuesByLabel = %v, %v", issueTitles(byLabel), err)
+	}
+
+	comment, err := store.AddIssueComment(ctx, issue.ID, "alice", "Looks good")
+	if err != nil {
+		t.Fatalf("AddIssueComment failed: %v", err)
+	}
+	if comment.ID == 0 || comment.Author != "alice" {
+		t.Errorf("comment = %+v", comment)
+	}
+	comments, err := store.GetIssueComments(ctx, issue.ID)
+	if err != nil || len(comments) != 1 || comments[0].Text != "Looks good" {
+		t.Errorf("GetIssueComments = %+v, %v", comments, err)
+	}
+}
+
+func TestIntegrationConfigAndMetadata(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	if err := store.SetConfig(ctx, "status.custom", "review"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	if err := store.SetConfig(ctx, "status.custom", "review,qa"); err != nil {
+		t.Fatalf("SetConfig (overwrite) failed: %v", err)
+	}
+	if v, err := store.GetConfig(ctx, "status.custom"); err != nil || v != "review,qa" {
+		t.Errorf("GetConfig = %q, %v", v, err)
+	}
+	if v, err := store.GetConfig(ctx, "no.such.key"); err != nil || v != "" {
+		t.Errorf("GetConfig(missing) = %q, %v", v, err)
+	}
+	if err := store.SetMetadata(ctx, "last_import", "abc"); err != nil {
+		t.Fatalf("SetMetadata failed: %v", err)
+	}
+	if v, err := store.GetMetadata(ctx, "last_import"); err != nil || v != "abc" {
+		t.Errorf("GetMetadata = %q, %v", v, err)
+	}
+}
+
+func TestIntegrationTransactionRollback(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	var createdID string
+	errBoom := errors.New("boom")
+	err := store.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		issue := &types.Issue{Title: "Rolled back", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := tx.CreateIssue(ctx, issue, "test-user"); err != nil {
+			return err
+		}
+		createdID = issue.ID
+		if got, err := tx.GetIssue(ctx, issue.ID); err != nil || got == nil {
+			t.Errorf("read-your-writes GetIssue = %v, %v", got, err)
+		}
+		return errBoom
+	})
+	if !errors.Is(err, errBoom) {
+		t.Fatalf("RunInTransaction = %v, want %v", err, errBoom)
+	}
+	if got, _ := store.GetIssue(ctx, createdID); got != nil {
+		t.Errorf("issue %s survived the rollback", createdID)
+	}
+}
+
+func TestIntegrationMigrateIsIdempotent(t *testing.T) {
+	store := setupTestDB(t)
+	if err := migrate(context.Background(), store.db); err != nil {
+		t.Fatalf("second migrate failed: %v", err)
+	}
+}
+
+func issueTitles(issues []*types.Issue) []string {
+	titles := make([]string, len(issues))
+	for i, issue := range issues {
+		titles[i] = issue.Title
+	}
+	return titles
+}
@@ -0,0 +1,625 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// issueColumns are the issue columns scanIssue reads, in order
+const issueColumns = `id, content_hash, title, description, design, acceptance_criteria, notes,
+	status, priority, issue_type, assignee, estimated_minutes,
+	created_at, updated_at, closed_at, external_ref,
+	compaction_level, compacted_at, compacted_at_commit, original_size, source_repo, close_reason,
+	deleted_at, deleted_by, delete_reason, original_type,
+	sender, ephemeral, complexity, source, timebox_minutes`
+
+// prefixedColumns returns issueColumns qualified with a table alias
+func prefixedColumns(alias string) string {
+	cols := strings.Split(issueColumns, ",")
+	for i, col := range cols {
+		cols[i] = alias + "." + strings.TrimSpace(col)
+	}
+	return strings.Join(cols, ", ")
+}
+
+// scanner is a *sql.Row or *sql.Rows
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanIssue reads the issueColumns of one row, followed by extra
+func scanIssue(row scanner, extra ...interface{}) (*types.Issue, error) {
+	var issue types.Issue
+	var contentHash, assignee, externalRef, compactedAtCommit sql.NullString
+	var estimatedMinutes, originalSize, timeboxMinutes sql.NullInt64
+	var closedAt, compactedAt, deletedAt sql.NullTime
+
+	dest := []interface{}{
+		&issue.ID, &contentHash, &issue.Title, &issue.Description, &issue.Design,
+		&issue.AcceptanceCriteria, &issue.Notes, &issue.Status,
+		&issue.Priority, &issue.IssueType, &assignee, &estimatedMinutes,
+		&issue.CreatedAt, &issue.UpdatedAt, &closedAt, &externalRef,
+		&issue.CompactionLevel, &compactedAt, &compactedAtCommit, &originalSize, &issue.SourceRepo, &issue.CloseReason,
+		&deletedAt, &issue.DeletedBy, &issue.DeleteReason, &issue.OriginalType,
+		&issue.Sender, &issue.Ephemeral, &issue.Complexity, &issue.Source, &timeboxMinutes,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+
+	issue.ContentHash = contentHash.String
+	issue.Assignee = assignee.String
+	issue.CreatedAt = issue.CreatedAt.UTC()
+	issue.UpdatedAt = issue.UpdatedAt.UTC()
+	issue.ClosedAt = nullTime(closedAt)
+	issue.CompactedAt = nullTime(compactedAt)
+	issue.DeletedAt = nullTime(deletedAt)
+	issue.EstimatedMinutes = nullInt(estimatedMinutes)
+	issue.TimeboxMinutes = nullInt(timeboxMinutes)
+	issue.OriginalSize = int(originalSize.Int64)
+	if externalRef.Valid {
+		issue.ExternalRef = &externalRef.String
+	}
+	if compactedAtCommit.Valid {
+		issue.CompactedAtCommit = &compactedAtCommit.String
+	}
+	return &issue, nil
+}
+
+func nullTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	utc := t.Time.UTC()
+	return &utc
+}
+
+func nullInt(n sql.NullInt64) *int {
+	if !n.Valid {
+		return nil
+	}
+	v := int(n.Int64)
+	return &v
+}
+
+// scanIssues reads the issues in rows and attaches their labels
+func (c *conn) scanIssues(ctx context.Context, rows *sql.Rows) ([]*types.Issue, error) {
+	defer func() { _ = rows.Close() }()
+	var issues []*types.Issue
+	for rows.Next() {
+		issue, err := scanIssue(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan issue: %w", err)
+		}
+		issues = append(issues, issue)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	_ = rows.Close()
+
+	if len(issues) == 0 {
+		return issues, nil
+	}
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	labels, err := c.getLabelsForIssues(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, issue := range issues {
+		issue.Labels = labels[issue.ID]
+	}
+	return issues, nil
+}
+
+// getIssue returns the issue with its labels, aliases, translations and
+// custom fields, or nil if there is no such issue
+func (c *conn) getIssue(ctx context.Context, id string) (*types.Issue, error) {
+	issue, err := scanIssue(c.queryRow(ctx, `SELECT `+issueColumns+` FROM issues WHERE id = ?`, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue: %w", err)
+	}
+
+	ids := []string{issue.ID}
+	labels, err := c.getLabelsForIssues(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get labels: %w", err)
+	}
+	issue.Labels = labels[issue.ID]
+	aliases, err := c.getAliasesForIssues(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get aliases: %w", err)
+	}
+	issue.Aliases = aliases[issue.ID]
+	translations, err := c.getTranslationsForIssues(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get translations: %w", err)
+	}
+	issue.Translations = translations[issue.ID]
+	fields, err := c.getFieldsForIssues(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fields: %w", err)
+	}
+	issue.Fields = fields[issue.ID]
+	return issue, nil
+}
+
+// GetIssue retrieves an issue by ID; nil if there is no such issue
+func (s *PostgresStorage) GetIssue(ctx context.Context, id string) (*types.Issue, error) {
+	return s.read().getIssue(ctx, id)
+}
+
+// GetIssueByExternalRef retrieves an issue by its external reference; nil
+// if no issue has it
+func (s *PostgresStorage) GetIssueByExternalRef(ctx context.Context, externalRef string) (*types.Issue, error) {
+	var id string
+	err := s.read().queryRow(ctx, `SELECT id FROM issues WHERE external_ref = ? LIMIT 1`, externalRef).Scan(&id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue by external_ref: %w", err)
+	}
+	return s.GetIssue(ctx, id)
+}
+
+// prepareIssue fills in the timestamps of a new issue and repairs the
+// closed_at and deleted_at invariants, as the sqlite backend does
+func prepareIssue(issue *types.Issue) {
+	now := time.Now().UTC()
+	if issue.CreatedAt.IsZero() {
+		issue.CreatedAt = now
+	}
+	if issue.UpdatedAt.IsZero() {
+		issue.UpdatedAt = now
+	}
+	latest := issue.CreatedAt
+	if issue.UpdatedAt.After(latest) {
+		latest = issue.UpdatedAt
+	}
+	if issue.Status == types.StatusClosed && issue.ClosedAt == nil {
+		closedAt := latest.Add(time.Second)
+		issue.ClosedAt = &closedAt
+	}
+	if issue.Status == types.StatusTombstone && issue.DeletedAt == nil {
+		deletedAt := latest.Add(time.Second)
+		issue.DeletedAt = &deletedAt
+	}
+}
+
+// issuePrefix returns the configured issue_prefix
+func (c *conn) issuePrefix(ctx context.Context) (string, error) {
+	prefix, err := c.getConfig(ctx, "issue_prefix")
+	if err != nil {
+		return "", fmt.Errorf("failed to get config: %w", err)
+	}
+	if prefix == "" {
+		return "", fmt.Errorf("database not initialized: issue_prefix config is missing (run 'bd init --prefix <prefix>' first)")
+	}
+	return prefix, nil
+}
+
+// generateID picks an unused hash ID for issue, with the adaptive length
+// the sqlite backend would use for a database of this size
+func (c *conn) generateID(ctx context.Context, prefix string, issue *types.Issue, actor string) (string, error) {
+	var topLevel int
+	err := c.queryRow(ctx, `
+		SELECT COUNT(*) FROM issues
+		WHERE id LIKE ? AND position('.' IN substr(id, length(?) + 2)) = 0
+	`, prefix+"-%", prefix).Scan(&topLevel)
+	baseLength := 6
+	if err == nil {
+		baseLength = sqlite.AdaptiveLength(topLevel, sqlite.LoadAdaptiveConfig(ctx, c.getConfig))
+	}
+	maxLength := 8
+	if baseLength > maxLength {
+		baseLength = maxLength
+	}
+
+	for length := baseLength; length <= maxLength; length++ {
+		for nonce := 0; nonce < 10; nonce++ {
+			candidate := sqlite.GenerateHashID(prefix, issue.Title, issue.Description, actor, issue.CreatedAt, length, nonce)
+			var exists bool
+			if err := c.queryRow(ctx, `SELECT EXISTS (SELECT 1 FROM issues WHERE id = ?)`, candidate).Scan(&exists); err != nil {
+				return "", fmt.Errorf("failed to check for ID collision: %w", err)
+			}
+			if !exists {
+				return candidate, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("failed to generate unique ID after trying lengths %d-%d with 10 nonces each", baseLength, maxLength)
+}
+
+// createIssue validates issue, gives it an ID if it has none and inserts it.
+// An issue whose ID is already taken is skipped, as in the sqlite backend.
+func (c *conn) createIssue(ctx context.Context, issue *types.Issue, actor string) error {
+	if issue == nil {
+		return fmt.Errorf("issue is nil")
+	}
+	customStatuses, err := c.getCustomStatuses(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get custom statuses: %w", err)
+	}
+	prepareIssue(issue)
+	if err := issue.ValidateWithCustomStatuses(customStatuses); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+	roster, err := sqlite.AssigneeRoster(ctx, c.getConfig)
+	if err != nil {
+		return fmt.Errorf("failed to get assignee roster: %w", err)
+	}
+	if err := sqlite.ValidateAssigneeInRoster(issue.Assignee, roster); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+	limits, err := sqlite.LoadContentLimits(ctx, c.getConfig)
+	if err != nil {
+		return fmt.Errorf("failed to get content limits: %w", err)
+	}
+	if err := limits.CheckIssue(issue); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+	if issue.ContentHash == "" {
+		issue.ContentHash = issue.ComputeContentHash()
+	}
+
+	prefix, err := c.issuePrefix(ctx)
+	if err != nil {
+		return err
+	}
+	if issue.ID == "" {
+		id, err := c.generateID(ctx, prefix, issue, actor)
+		if err != nil {
+			return fmt.Errorf("generate issue ID: %w", err)
+		}
+		issue.ID = id
+	} else {
+		if err := sqlite.ValidateIssueIDPrefix(issue.ID, prefix); err != nil {
+			return fmt.Errorf("validate issue ID prefix: %w", err)
+		}
+		if isHierarchical, parentID := sqlite.IsHierarchicalID(issue.ID); isHierarchical {
+			var exists bool
+			if err := c.queryRow(ctx, `SELECT EXISTS (SELECT 1 FROM issues WHERE id = ?)`, parentID).Scan(&exists); err != nil {
+				return fmt.Errorf("failed to check parent issue: %w", err)
+			}
+			if !exists {
+				return fmt.Errorf("parent issue %s does not exist", parentID)
+			}
+		}
+	}
+
+	sourceRepo := issue.SourceRepo
+	if sourceRepo == "" {
+		sourceRepo = "."
+	}
+	result, err := c.exec(ctx, `
+		INSERT INTO issues (
+			id, content_hash, title, description, design, acceptance_criteria, notes,
+			status, priority, issue_type, assignee, estimated_minutes,
+			created_at, updated_at, closed_at, external_ref, source_repo, close_reason,
+			deleted_at, deleted_by, delete_reason, original_type,
+			sender, ephemeral, complexity, source, timebox_minutes
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO NOTHING
+	`,
+		issue.ID, issue.ContentHash, issue.Title, issue.Description, issue.Design,
+		issue.AcceptanceCriteria, issue.Notes, issue.Status,
+		issue.Priority, issue.IssueType, nullString(issue.Assignee),
+		issue.EstimatedMinutes, issue.CreatedAt.UTC(), issue.UpdatedAt.UTC(),
+		issue.ClosedAt, issue.ExternalRef, sourceRepo, issue.CloseReason,
+		issue.DeletedAt, issue.DeletedBy, issue.DeleteReason, issue.OriginalType,
+		issue.Sender, issue.Ephemeral, issue.Complexity, issue.Source, issue.TimeboxMinutes,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert issue: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return nil // Duplicate ID, skipped
+	}
+
+	eventData, err := json.Marshal(issue)
+	if err != nil {
+		eventData = []byte(fmt.Sprintf(`{"id":"%s","title":"%s"}`, issue.ID, issue.Title))
+	}
+	if err := c.recordEvent(ctx, issue.ID, types.EventCreated, actor, nil, string(eventData), nil); err != nil {
+		return err
+	}
+	return c.markDirty(ctx, issue.ID)
+}
+
+// nullString stores "" as NULL, for the nullable text columns
+func nullString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// CreateIssue creates a new issue
+func (s *PostgresStorage) CreateIssue(ctx context.Context, issue *types.Issue, actor string) error {
+	return s.withTx(ctx, func(c *conn) error {
+		return c.createIssue(ctx, issue, actor)
+	})
+}
+
+// CreateIssues creates multiple issues atomically
+func (s *PostgresStorage) CreateIssues(ctx context.Context, issues []*types.Issue, actor string) error {
+	if len(issues) == 0 {
+		return nil
+	}
+	return s.withTx(ctx, func(c *conn) error {
+		return c.createIssues(ctx, issues, actor)
+	})
+}
+
+func (c *conn) createIssues(ctx context.Context, issues []*types.Issue, actor string) error {
+	for i, issue := range issues {
+		if issue == nil {
+			return fmt.Errorf("issue %d is nil", i)
+		}
+		if err := c.createIssue(ctx, issue, actor); err != nil {
+			return fmt.Errorf("issue %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// updateEvent returns the event type of an update, accepting typed statuses
+func updateEvent(oldIssue *types.Issue, updates map[string]interface{}) types.EventType {
+	if status, ok := updates["status"].(types.Status); ok {
+		normalized := make(map[string]interface{}, len(updates))
+		for k, v := range updates {
+			normalized[k] = v
+		}
+		normalized["status"] = string(status)
+		return sqlite.UpdateEventType(oldIssue, normalized)
+	}
+	return sqlite.UpdateEventType(oldIssue, updates)
+}
+
+// statusValue returns a status update as a string
+func statusValue(v interface{}) (string, bool) {
+	switch s := v.(type) {
+	case string:
+		return s, true
+	case types.Status:
+		return string(s), true
+	}
+	return "", false
+}
+
+func (c *conn) updateIssue(ctx context.Context, id string, updates map[string]interface{}, actor string) error {
+	oldIssue, err := c.getIssue(ctx, id)
+	if err != nil {
+		return fmt.Errorf("get issue for update: %w", err)
+	}
+	if oldIssue == nil {
+		return fmt.Errorf("issue %s not found", id)
+	}
+	customStatuses, err := c.getCustomStatuses(ctx)
+	if err != nil {
+		return fmt.Errorf("get custom statuses: %w", err)
+	}
+	roster, err := sqlite.AssigneeRoster(ctx, c.getConfig)
+	if err != nil {
+		return fmt.Errorf("get assignee roster: %w", err)
+	}
+	limits, err := sqlite.LoadContentLimits(ctx, c.getConfig)
+	if err != nil {
+		return fmt.Errorf("get content limits: %w", err)
+	}
+	if err := sqlite.ValidateSpikeTimebox(oldIssue, updates); err != nil {
+		return err
+	}
+
+	setClauses := []string{"updated_at = ?"}
+	args := []interface{}{time.Now().UTC()}
+	for key, value := range updates {
+		if !sqlite.IsAllowedUpdateField(key) {
+			return fmt.Errorf("invalid field for update: %s", key)
+		}
+		if err := sqlite.ValidateFieldUpdate(key, value, customStatuses); err != nil {
+			return fmt.Errorf("validate field update: %w", err)
+		}
+		if assignee, ok := value.(string); ok && key == "assignee" {
+			if err := sqlite.ValidateAssigneeInRoster(assignee, roster); err != nil {
+				return err
+			}
+		}
+		if err := limits.CheckUpdate(key, value); err != nil {
+			return err
+		}
+		setClauses = append(setClauses, key+" = ?")
+		args = append(args, value)
+	}
+
+	// Closing sets closed_at and reopening clears it, unless the caller
+	// passed closed_at itself (imports preserve timestamps)
+	if _, explicit := updates["closed_at"]; !explicit {
+		if status, ok := statusValue(updates["status"]); ok {
+			if status == string(types.StatusClosed) {
+				now := time.Now().UTC()
+				updates["closed_at"] = now
+				setClauses = append(setClauses, "closed_at = ?")
+				args = append(args, now)
+			} else if oldIssue.Status == types.StatusClosed {
+				updates["closed_at"] = nil
+				updates["close_reason"] = ""
+				setClauses = append(setClauses, "closed_at = NULL", "close_reason = ''")
+			}
+		}
+	}
+
+	for _, field := range []string{"title", "description", "design", "acceptance_criteria", "notes", "status", "priority", "issue_type", "assignee", "external_ref", "complexity", "source", "timebox_minutes"} {
+		if _, ok := updates[field]; ok {
+			updated := *oldIssue
+			if err := sqlite.ApplyContentUpdates(&updated, updates); err != nil {
+				return err
+			}
+			setClauses = append(setClauses, "content_hash = ?")
+			args = append(args, updated.ComputeContentHash())
+			break
+		}
+	}
+
+	args = append(args, id)
+	// #nosec G202 - keys are checked against the allowed update fields
+	if _, err := c.exec(ctx, `UPDATE issues SET `+strings.Join(setClauses, ", ")+` WHERE id = ?`, args...); err != nil {
+		return fmt.Errorf("failed to update issue: %w", err)
+	}
+
+	oldData, err := json.Marshal(oldIssue)
+	if err != nil {
+		oldData = []byte(fmt.Sprintf(`{"id":"%s"}`, id))
+	}
+	newData, err := json.Marshal(updates)
+	if err != nil {
+		newData = []byte(`{}`)
+	}
+	if err := c.recordEvent(ctx, id, updateEvent(oldIssue, updates), actor, string(oldData), string(newData), nil); err != nil {
+		return err
+	}
+	return c.markDirty(ctx, id)
+}
+
+// UpdateIssue updates fields on an issue
+func (s *PostgresStorage) UpdateIssue(ctx context.Context, id string, updates map[string]interface{}, actor string) error {
+	return s.withTx(ctx, func(c *conn) error {
+		return c.updateIssue(ctx, id, updates, actor)
+	})
+}
+
+func (c *conn) closeIssue(ctx context.Context, id, reason, actor string) error {
+	now := time.Now().UTC()
+	result, err := c.exec(ctx, `
+		UPDATE issues SET status = ?, closed_at = ?, updated_at = ?, close_reason = ?
+		WHERE id = ?
+	`, types.StatusClosed, now, now, reason, id)
+	if err != nil {
+		return fmt.Errorf("failed to close issue: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("issue not found: %s", id)
+	}
+	issue, err := c.getIssue(ctx, id)
+	if err != nil {
+		return err
+	}
+	if _, err := c.exec(ctx, `UPDATE issues SET content_hash = ? WHERE id = ?`, issue.ComputeContentHash(), id); err != nil {
+		return fmt.Errorf("failed to update content hash: %w", err)
+	}
+	if err := c.recordEvent(ctx, id, types.EventClosed, actor, nil, nil, reason); err != nil {
+		return err
+	}
+	return c.markDirty(ctx, id)
+}
+
+// CloseIssue closes an issue with a reason
+func (s *PostgresStorage) CloseIssue(ctx context.Context, id string, reason string, actor string) error {
+	return s.withTx(ctx, func(c *conn) error {
+		return c.closeIssue(ctx, id, reason, actor)
+	})
+}
+
+// deleteIssue removes an issue and everything attached to it. Rows keyed
+// by the issue go with it (ON DELETE CASCADE); dependencies on it are
+// removed here since depends_on_id has no foreign key.
+func (c *conn) deleteIssue(ctx context.Context, id string) error {
+	if _, err := c.exec(ctx, `DELETE FROM dependencies WHERE depends_on_id = ?`, id); err != nil {
+		return fmt.Errorf("failed to delete dependencies: %w", err)
+	}
+	result, err := c.exec(ctx, `DELETE FROM issues WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete issue: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("issue not found: %s", id)
+	}
+	return nil
+}
+
+// DeleteIssue permanently removes an issue
+func (s *PostgresStorage) DeleteIssue(ctx context.Context, id string) error {
+	return s.withTx(ctx, func(c *conn) error {
+		return c.deleteIssue(ctx, id)
+	})
+}
+
+// UpdateIssueID renames an issue, rewriting every reference to it
+func (s *PostgresStorage) UpdateIssueID(ctx context.Context, oldID, newID string, issue *types.Issue, actor string) error {
+	return s.withTx(ctx, func(c *conn) error {
+		// Rows keyed by the issue follow it through ON UPDATE CASCADE
+		result, err := c.exec(ctx, `
+			UPDATE issues
+			SET id = ?, title = ?, description = ?, design = ?, acceptance_criteria = ?, notes = ?, updated_at = ?
+			WHERE id = ?
+		`, newID, issue.Title, issue.Description, issue.Design, issue.AcceptanceCriteria, issue.Notes, time.Now().UTC(), oldID)
+		if err != nil {
+			return fmt.Errorf("failed to update issue ID: %w", err)
+		}
+		if n, _ := result.RowsAffected(); n == 0 {
+			return fmt.Errorf("issue not found: %s", oldID)
+		}
+		if _, err := c.exec(ctx, `UPDATE dependencies SET depends_on_id = ? WHERE depends_on_id = ?`, newID, oldID); err != nil {
+			return fmt.Errorf("failed to update dependencies: %w", err)
+		}
+		if err := c.recordEvent(ctx, newID, eventRenamed, actor, oldID, newID, nil); err != nil {
+			return err
+		}
+		return c.markDirty(ctx, newID)
+	})
+}
+
+// eventRenamed is recorded by UpdateIssueID, as in the sqlite backend
+const eventRenamed types.EventType = "renamed"
+
+// RenameDependencyPrefix is a no-op; UpdateIssueID renames dependencies
+func (s *PostgresStorage) RenameDependencyPrefix(ctx context.Context, oldPrefix, newPrefix string) error {
+	return nil
+}
+
+// RenameCounterPrefix is a no-op; UpdateIssueID renames child counters
+func (s *PostgresStorage) RenameCounterPrefix(ctx context.Context, oldPrefix, newPrefix string) error {
+	return nil
+}
+
+// GetNextChildID returns the next hierarchical child ID of parentID
+func (s *PostgresStorage) GetNextChildID(ctx context.Context, parentID string) (string, error) {
+	var childID string
+	err := s.withTx(ctx, func(c *conn) error {
+		var exists bool
+		if err := c.queryRow(ctx, `SELECT EXISTS (SELECT 1 FROM issues WHERE id = ?)`, parentID).Scan(&exists); err != nil {
+			return fmt.Errorf("failed to check parent existence: %w", err)
+		}
+		if !exists {
+			return fmt.Errorf("parent issue %s does not exist", parentID)
+		}
+		if strings.Count(parentID, ".") >= 3 {
+			return fmt.Errorf("maximum hierarchy depth (3) exceeded for parent %s", parentID)
+		}
+		var next int
+		if err := c.queryRow(ctx, `
+			INSERT INTO child_counters (parent_id, last_child) VALUES (?, 1)
+			ON CONFLICT (parent_id) DO UPDATE SET last_child = child_counters.last_child + 1
+			RETURNING last_child
+		`, parentID).Scan(&next); err != nil {
+			return fmt.Errorf("failed to generate next child number for parent %s: %w", parentID, err)
+		}
+		childID = fmt.Sprintf("%s.%d", parentID, next)
+		return nil
+	})
+	return childID, err
+}
@@ -0,0 +1,109 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/lib/pq"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// labelOperation runs an insert or delete on labels and, if it changed a
+// row, records the event and marks the issue dirty
+func (c *conn) labelOperation(ctx context.Context, issueID, actor, labelSQL, label string, eventType types.EventType, comment, operationError string) error {
+	result, err := c.exec(ctx, labelSQL, issueID, label)
+	if err != nil {
+		return fmt.Errorf("%s: %w", operationError, err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return nil
+	}
+	if err := c.recordEvent(ctx, issueID, eventType, actor, nil, nil, comment); err != nil {
+		return err
+	}
+	return c.markDirty(ctx, issueID)
+}
+
+func (c *conn) addLabel(ctx context.Context, issueID, label, actor string) error {
+	if !utf8.ValidString(label) {
+		return fmt.Errorf("label is not valid UTF-8")
+	}
+	return c.labelOperation(ctx, issueID, actor,
+		`INSERT INTO labels (issue_id, label) VALUES (?, ?) ON CONFLICT DO NOTHING`, label,
+		types.EventLabelAdded, fmt.Sprintf("Added label: %s", label), "failed to add label")
+}
+
+func (c *conn) removeLabel(ctx context.Context, issueID, label, actor string) error {
+	return c.labelOperation(ctx, issueID, actor,
+		`DELETE FROM labels WHERE issue_id = ? AND label = ?`, label,
+		types.EventLabelRemoved, fmt.Sprintf("Removed label: %s", label), "failed to remove label")
+}
+
+// AddLabel adds a label to an issue
+func (s *PostgresStorage) AddLabel(ctx context.Context, issueID, label, actor string) error {
+	return s.withTx(ctx, func(c *conn) error {
+		return c.addLabel(ctx, issueID, label, actor)
+	})
+}
+
+// RemoveLabel removes a label from an issue
+func (s *PostgresStorage) RemoveLabel(ctx context.Context, issueID, label, actor string) error {
+	return s.withTx(ctx, func(c *conn) error {
+		return c.removeLabel(ctx, issueID, label, actor)
+	})
+}
+
+// GetLabels returns the labels of an issue, sorted
+func (s *PostgresStorage) GetLabels(ctx context.Context, issueID string) ([]string, error) {
+	labels, err := s.read().getLabelsForIssues(ctx, []string{issueID})
+	if err != nil {
+		return nil, err
+	}
+	return labels[issueID], nil
+}
+
+// GetLabelsForIssues returns the labels of several issues in one query
+func (s *PostgresStorage) GetLabelsForIssues(ctx context.Context, issueIDs []string) (map[string][]string, error) {
+	return s.read().getLabelsForIssues(ctx, issueIDs)
+}
+
+func (c *conn) getLabelsForIssues(ctx context.Context, issueIDs []string) (map[string][]string, error) {
+	result := make(map[string][]string)
+	if len(issueIDs) == 0 {
+		return result, nil
+	}
+	rows, err := c.query(ctx, `
+		SELECT issue_id, label FROM labels
+		WHERE issue_id = ANY(?)
+		ORDER BY issue_id, label
+	`, pq.Array(issueIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to batch get labels: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+		var issueID, label string
+		if err := rows.Scan(&issueID, &label); err != nil {
+			return nil, err
+		}
+		result[issueID] = append(result[issueID], label)
+	}
+	return result, rows.Err()
+}
+
+// GetIssuesByLabel returns the issues with a label
+func (s *PostgresStorage) GetIssuesByLabel(ctx context.Context, label string) ([]*types.Issue, error) {
+	c := s.read()
+	rows, err := c.query(ctx, `
+		SELECT `+prefixedColumns("i")+`
+		FROM issues i
+		JOIN labels l ON i.id = l.issue_id
+		WHERE l.label = ?
+		ORDER BY i.priority ASC, i.created_at DESC
+	`, label)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issues by label: %w", err)
+	}
+	return c.scanIssues(ctx, rows)
+}
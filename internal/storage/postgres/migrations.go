@@ -0,0 +1,251 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// migrationLockKey is the session advisory lock held while migrating, so two
+// clients opening a new database don't both build the schema
+const migrationLockKey = 0x62656164_6d6967 // "bead" "mig"
+
+// migration is one step of the schema. Steps are applied in order, each in
+// its own transaction, and recorded in schema_migrations; never edit a
+// released step, add a new one.
+type migration struct {
+	Version int
+	Name    string
+	SQL     string
+}
+
+// migrations builds the schema of the sqlite backend, minus the tables of
+// the features this backend doesn't support (see the package comment)
+var migrations = []migration{
+	{1, "issues and dependencies", `
+CREATE TABLE issues (
+    id TEXT PRIMARY KEY,
+    content_hash TEXT,
+    title TEXT NOT NULL CHECK (length(title) <= 500),
+    description TEXT NOT NULL DEFAULT '',
+    design TEXT NOT NULL DEFAULT '',
+    acceptance_criteria TEXT NOT NULL DEFAULT '',
+    notes TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'open',
+    priority INTEGER NOT NULL DEFAULT 2 CHECK (priority >= 0 AND priority <= 4),
+    issue_type TEXT NOT NULL DEFAULT 'task',
+    assignee TEXT,
+    estimated_minutes INTEGER,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    closed_at TIMESTAMPTZ,
+    external_ref TEXT,
+    compaction_level INTEGER NOT NULL DEFAULT 0,
+    compacted_at TIMESTAMPTZ,
+    compacted_at_commit TEXT,
+    original_size INTEGER,
+    source_repo TEXT NOT NULL DEFAULT '.',
+    close_reason TEXT NOT NULL DEFAULT '',
+    deleted_at TIMESTAMPTZ,
+    deleted_by TEXT NOT NULL DEFAULT '',
+    delete_reason TEXT NOT NULL DEFAULT '',
+    original_type TEXT NOT NULL DEFAULT '',
+    sender TEXT NOT NULL DEFAULT '',
+    ephemeral BOOLEAN NOT NULL DEFAULT false,
+    complexity TEXT NOT NULL DEFAULT '',
+    source TEXT NOT NULL DEFAULT '',
+    timebox_minutes INTEGER,
+    CHECK ((status = 'closed') = (closed_at IS NOT NULL))
+);
+CREATE INDEX idx_issues_status ON issues(status);
+CREATE INDEX idx_issues_priority ON issues(priority);
+CREATE INDEX idx_issues_assignee ON issues(assignee);
+CREATE INDEX idx_issues_created_at ON issues(created_at);
+CREATE INDEX idx_issues_content_hash ON issues(content_hash);
+CREATE INDEX idx_issues_external_ref ON issues(external_ref) WHERE external_ref IS NOT NULL;
+
+-- depends_on_id has no foreign key: it may name an issue in a linked
+-- workspace (api/bd-42)
+CREATE TABLE dependencies (
+    issue_id TEXT NOT NULL REFERENCES issues(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    depends_on_id TEXT NOT NULL,
+    type TEXT NOT NULL DEFAULT 'blocks',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    created_by TEXT NOT NULL DEFAULT '',
+    metadata TEXT NOT NULL DEFAULT '{}',
+    thread_id TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (issue_id, depends_on_id)
+);
+CREATE INDEX idx_dependencies_depends_on_type_issue ON dependencies(depends_on_id, type, issue_id);
+CREATE INDEX idx_dependencies_thread ON dependencies(thread_id) WHERE thread_id != '';
+
+CREATE TABLE labels (
+    issue_id TEXT NOT NULL REFERENCES issues(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    label TEXT NOT NULL,
+    PRIMARY KEY (issue_id, label)
+);
+CREATE INDEX idx_labels_label ON labels(label);
+
+CREATE TABLE child_counters (
+    parent_id TEXT PRIMARY KEY REFERENCES issues(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    last_child INTEGER NOT NULL DEFAULT 0
+);
+`},
+	{2, "events and comments", `
+CREATE TABLE events (
+    id BIGSERIAL PRIMARY KEY,
+    issue_id TEXT NOT NULL REFERENCES issues(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    event_type TEXT NOT NULL,
+    actor TEXT NOT NULL,
+    old_value TEXT,
+    new_value TEXT,
+    comment TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX idx_events_issue ON events(issue_id);
+CREATE INDEX idx_events_created_at ON events(created_at);
+
+CREATE TABLE comments (
+    id BIGSERIAL PRIMARY KEY,
+    issue_id TEXT NOT NULL REFERENCES issues(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    author TEXT NOT NULL,
+    text TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ
+);
+CREATE INDEX idx_comments_issue ON comments(issue_id);
+`},
+	{3, "config, metadata and export tracking", `
+CREATE TABLE config (
+    key TEXT PRIMARY KEY,
+    value TEXT NOT NULL
+);
+INSERT INTO config (key, value) VALUES
+    ('compaction_enabled', 'false'),
+    ('compact_tier1_days', '30'),
+    ('compact_tier1_dep_levels', '2'),
+    ('compact_tier2_days', '90'),
+    ('compact_tier2_dep_levels', '5'),
+    ('compact_tier2_commits', '100'),
+    ('compact_batch_size', '50'),
+    ('compact_parallel_workers', '5'),
+    ('auto_compact_enabled', 'false');
+
+CREATE TABLE metadata (
+    key TEXT PRIMARY KEY,
+    value TEXT NOT NULL
+);
+
+CREATE TABLE dirty_issues (
+    issue_id TEXT PRIMARY KEY REFERENCES issues(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    marked_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    content_hash TEXT
+);
+CREATE INDEX idx_dirty_issues_marked_at ON dirty_issues(marked_at);
+
+CREATE TABLE export_hashes (
+    issue_id TEXT PRIMARY KEY REFERENCES issues(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    content_hash TEXT NOT NULL,
+    exported_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+`},
+	{4, "aliases, attachments, translations, work log and fields", `
+CREATE TABLE issue_aliases (
+    alias TEXT PRIMARY KEY,
+    issue_id TEXT NOT NULL REFERENCES issues(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX idx_issue_aliases_issue ON issue_aliases(issue_id);
+
+CREATE TABLE attachments (
+    id BIGSERIAL PRIMARY KEY,
+    issue_id TEXT NOT NULL REFERENCES issues(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    name TEXT NOT NULL,
+    url TEXT NOT NULL,
+    sha256 TEXT NOT NULL DEFAULT '',
+    size BIGINT NOT NULL DEFAULT 0,
+    created_by TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (issue_id, url)
+);
+
+CREATE TABLE issue_translations (
+    issue_id TEXT NOT NULL REFERENCES issues(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    locale TEXT NOT NULL,
+    title TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (issue_id, locale)
+);
+
+CREATE TABLE work_log (
+    id BIGSERIAL PRIMARY KEY,
+    issue_id TEXT NOT NULL REFERENCES issues(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    actor TEXT NOT NULL,
+    minutes INTEGER NOT NULL CHECK (minutes > 0),
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (issue_id, actor, created_at)
+);
+CREATE INDEX idx_work_log_issue ON work_log(issue_id);
+
+CREATE TABLE issue_fields (
+    issue_id TEXT NOT NULL REFERENCES issues(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    name TEXT NOT NULL,
+    value TEXT NOT NULL,
+    PRIMARY KEY (issue_id, name)
+);
+CREATE INDEX idx_issue_fields_name_value ON issue_fields(name, value);
+`},
+}
+
+// migrate applies the migrations the database hasn't seen yet
+func migrate(ctx context.Context, db *sql.DB) error {
+	c, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get connection for migrations: %w", err)
+	}
+	defer func() { _ = c.Close() }()
+
+	if _, err := c.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, migrationLockKey); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer func() { _, _ = c.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, migrationLockKey) }()
+
+	if _, err := c.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			name TEXT NOT NULL,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+		)
+	`); err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	var current int
+	if err := c.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&current); err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+	if current > len(migrations) {
+		return fmt.Errorf("database schema version %d is newer than this bd supports (%d); upgrade bd", current, len(migrations))
+	}
+
+	for _, m := range migrations[current:] {
+		tx, err := c.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("migration %d (%s): %w", m.Version, m.Name, err)
+		}
+		if _, err := tx.ExecContext(ctx, m.SQL); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("migration %d (%s): %w", m.Version, m.Name, err)
+		}
+		if _, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`, m.Version, m.Name); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("migration %d (%s): %w", m.Version, m.Name, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("migration %d (%s): %w", m.Version, m.Name, err)
+		}
+	}
+	return nil
+}
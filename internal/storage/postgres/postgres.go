@@ -0,0 +1,193 @@
+// Package postgres implements the storage interface using PostgreSQL, for
+// teams that share one issue database instead of syncing a SQLite file per
+// clone through git.
+//
+// The package mirrors the layout of the sqlite package:
+//   - postgres.go: PostgresStorage, New() and the connection helpers
+//   - migrations.go: the schema and the numbered migrations that build it
+//   - issues.go: issue CRUD and SearchIssues
+//   - dependencies.go, labels.go, ready.go: the issue graph and ready work
+//   - events.go, comments.go: the event log and issue comments
+//   - details.go: aliases, attachments, translations, work log and fields
+//   - config.go: config, metadata, dirty tracking and export hashes
+//   - stats.go: GetStatistics
+//   - transaction.go: RunInTransaction
+//
+// IDs, validation and content hashes come from the sqlite package, so an
+// issue gets the same ID and the same errors whichever backend stores it.
+//
+// Writers are serialized with a transaction-level advisory lock, the
+// counterpart of SQLite's BEGIN IMMEDIATE: hash ID generation checks for
+// collisions before inserting, and event IDs must commit in ID order for
+// readers that follow the events table (see the events package).
+//
+// Features that depend on SQLite-only tables are not available on this
+// backend: CI gates, leases, dependencies on linked workspaces resolved
+// through external_deps, full-text search (search falls back to substring
+// matching), the operation log behind bd undo, and compaction.
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	_ "github.com/lib/pq" // registers the "postgres" driver
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// writeLockKey is the advisory lock every write transaction takes
+const writeLockKey = 0x62656164 // "bead"
+
+// PostgresStorage implements the Storage interface using PostgreSQL
+type PostgresStorage struct {
+	db  *sql.DB
+	dsn string
+}
+
+var _ storage.Storage = (*PostgresStorage)(nil)
+
+// New connects to the database named by dsn and brings its schema up to
+// date. dsn is a lib/pq connection string or URL, e.g.
+// postgres://beads@db.internal/beads?sslmode=require; passwords are best
+// left to PGPASSWORD or ~/.pgpass.
+func New(ctx context.Context, dsn string) (*PostgresStorage, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to connect to %s: %w", RedactDSN(dsn), err)
+	}
+	if err := migrate(ctx, db); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return &PostgresStorage{db: db, dsn: dsn}, nil
+}
+
+// Close closes the connection pool
+func (s *PostgresStorage) Close() error {
+	return s.db.Close()
+}
+
+// Path returns the DSN with any password removed, identifying the database
+// in messages and daemon checks
+func (s *PostgresStorage) Path() string {
+	return RedactDSN(s.dsn)
+}
+
+// UnderlyingDB returns the connection pool
+func (s *PostgresStorage) UnderlyingDB() *sql.DB {
+	return s.db
+}
+
+// UnderlyingConn returns a single connection from the pool; the caller must
+// close it
+func (s *PostgresStorage) UnderlyingConn(ctx context.Context) (*sql.Conn, error) {
+	return s.db.Conn(ctx)
+}
+
+// passwordPattern matches the password in URL and key=value DSNs
+var passwordPattern = regexp.MustCompile(`(://[^:/@]*:)[^@]*@|(password\s*=\s*)('[^']*'|\S+)`)
+
+// RedactDSN removes the password from a DSN
+func RedactDSN(dsn string) string {
+	return passwordPattern.ReplaceAllStringFunc(dsn, func(m string) string {
+		sub := passwordPattern.FindStringSubmatch(m)
+		if sub[1] != "" {
+			return sub[1] + "***@"
+		}
+		return sub[2] + "***"
+	})
+}
+
+// querier is the part of *sql.DB and *sql.Tx the queries run on
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// conn runs the storage queries on the pool or on one transaction. Queries
+// are written with ? placeholders, as in the sqlite package, and rebound to
+// PostgreSQL's numbered ones.
+type conn struct {
+	q querier
+}
+
+func (c *conn) exec(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return c.q.ExecContext(ctx, rebind(query), args...)
+}
+
+func (c *conn) query(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return c.q.QueryContext(ctx, rebind(query), args...)
+}
+
+func (c *conn) queryRow(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return c.q.QueryRowContext(ctx, rebind(query), args...)
+}
+
+// rebind replaces the ? placeholders in query with $1, $2, ...
+func rebind(query string) string {
+	if !strings.Contains(query, "?") {
+		return query
+	}
+	var b strings.Builder
+	b.Grow(len(query) + 8)
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteByte('$')
+			b.WriteString(strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// placeholders returns n comma-separated ? placeholders
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+// stringArgs converts ids to query arguments
+func stringArgs(ids []string) []interface{} {
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	return args
+}
+
+// read returns a conn on the pool, for queries outside a transaction
+func (s *PostgresStorage) read() *conn {
+	return &conn{q: s.db}
+}
+
+// withTx runs fn in a write transaction, committing if it returns nil
+func (s *PostgresStorage) withTx(ctx context.Context, fn func(c *conn) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, writeLockKey); err != nil {
+		return fmt.Errorf("failed to acquire write lock: %w", err)
+	}
+	if err := fn(&conn{q: tx}); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
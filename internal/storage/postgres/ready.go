@@ -0,0 +1,303 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// blockedIssuesCTE lists the issues that can't be worked on: those with an
+// open 'blocks' dependency, and their parent-child descendants. The sqlite
+// backend keeps this set in blocked_issues_cache; here it is computed per
+// query.
+const blockedIssuesCTE = `
+	blocked_directly AS (
+		SELECT DISTINCT d.issue_id
+		FROM dependencies d
+		JOIN issues blocker ON d.depends_on_id = blocker.id
+		WHERE d.type = 'blocks'
+		  AND blocker.status IN ('open', 'in_progress', 'blocked')
+	),
+	blocked_transitively AS (
+		SELECT issue_id, 0 AS depth FROM blocked_directly
+		UNION ALL
+		SELECT d.issue_id, bt.depth + 1
+		FROM blocked_transitively bt
+		JOIN dependencies d ON d.depends_on_id = bt.issue_id
+		WHERE d.type = 'parent-child' AND bt.depth < 50
+	),
+	blocked AS (
+		SELECT DISTINCT issue_id FROM blocked_transitively
+	)`
+
+// softBlockedSQL is true for issues with a 'soft-blocks' dependency on an
+// issue that is still open. Such issues stay ready but sort last.
+const softBlockedSQL = `EXISTS (
+		SELECT 1 FROM dependencies sd
+		JOIN issues sb ON sb.id = sd.depends_on_id
+		WHERE sd.issue_id = i.id AND sd.type = 'soft-blocks'
+		  AND sb.status IN ('open', 'in_progress', 'blocked')
+	)`
+
+// readyOrder returns the ORDER BY terms of a sort policy
+func readyOrder(policy types.SortPolicy) string {
+	switch policy {
+	case types.SortPolicyPriority:
+		return `i.priority ASC, i.created_at ASC`
+	case types.SortPolicyOldest:
+		return `i.created_at ASC`
+	default:
+		// Issues from the last 48 hours by priority, then older ones by age
+		return `CASE WHEN i.created_at >= now() - interval '48 hours' THEN 0 ELSE 1 END ASC,
+			CASE WHEN i.created_at >= now() - interval '48 hours' THEN i.priority END ASC,
+			CASE WHEN i.created_at < now() - interval '48 hours' THEN i.created_at END ASC,
+			i.created_at ASC`
+	}
+}
+
+// readyWorkQuery builds the ready-work query selecting columns from issues i
+func readyWorkQuery(filter types.WorkFilter, columns string) (string, []interface{}) {
+	var where []string
+	var args []interface{}
+	add := func(clause string, clauseArgs ...interface{}) {
+		where = append(where, clause)
+		args = append(args, clauseArgs...)
+	}
+
+	if filter.Status == "" {
+		add("i.status IN ('open', 'in_progress')")
+	} else {
+		add("i.status = ?", filter.Status)
+	}
+	if filter.Priority != nil {
+		add("i.priority = ?", *filter.Priority)
+	}
+	if filter.Unassigned {
+		add("(i.assignee IS NULL OR i.assignee = '')")
+	} else if filter.Assignee != nil {
+		add("i.assignee = ?", *filter.Assignee)
+	} else if filter.AvailableTo != nil {
+		add("(i.assignee IS NULL OR i.assignee = '' OR i.assignee = ?)", *filter.AvailableTo)
+	}
+	for _, label := range filter.Labels {
+		add("EXISTS (SELECT 1 FROM labels WHERE issue_id = i.id AND label = ?)", label)
+	}
+	if len(filter.LabelsAny) > 0 {
+		add("EXISTS (SELECT 1 FROM labels WHERE issue_id = i.id AND label = ANY(?))", pq.Array(filter.LabelsAny))
+	}
+	if len(filter.Complexity) > 0 {
+		add(complexityClause("i.complexity", filter.Complexity))
+	}
+	add("i.id NOT IN (SELECT issue_id FROM blocked)")
+
+	limitSQL := ""
+	if filter.Limit > 0 {
+		limitSQL = "LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+	policy := filter.SortPolicy
+	if policy == "" {
+		policy = types.SortPolicyHybrid
+	}
+
+	// #nosec G201 - safe SQL with controlled formatting
+	query := fmt.Sprintf(`
+		WITH RECURSIVE %s
+		SELECT %s
+		FROM issues i
+		WHERE %s
+		ORDER BY %s ASC, %s
+		%s
+	`, blockedIssuesCTE, columns, strings.Join(where, " AND "), softBlockedSQL, readyOrder(policy), limitSQL)
+	return query, args
+}
+
+func (c *conn) getReadyWork(ctx context.Context, filter types.WorkFilter) ([]*types.Issue, error) {
+	query, args := readyWorkQuery(filter, prefixedColumns("i"))
+	rows, err := c.query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ready work: %w", err)
+	}
+	issues, err := c.scanIssues(ctx, rows)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.attachSoftBlockers(ctx, issues); err != nil {
+		return nil, err
+	}
+	return issues, nil
+}
+
+// GetReadyWork returns issues with no open blockers. By default both open
+// and in_progress issues are included.
+func (s *PostgresStorage) GetReadyWork(ctx context.Context, filter types.WorkFilter) ([]*types.Issue, error) {
+	return s.read().getReadyWork(ctx, filter)
+}
+
+// attachSoftBlockers fills SoftBlockedBy with each issue's open soft blockers
+func (c *conn) attachSoftBlockers(ctx context.Context, issues []*types.Issue) error {
+	if len(issues) == 0 {
+		return nil
+	}
+	byID := make(map[string]*types.Issue, len(issues))
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		byID[issue.ID] = issue
+		ids[i] = issue.ID
+	}
+	rows, err := c.query(ctx, `
+		SELECT sd.issue_id, sd.depends_on_id
+		FROM dependencies sd
+		JOIN issues sb ON sb.id = sd.depends_on_id
+		WHERE sd.type = 'soft-blocks'
+		  AND sb.status IN ('open', 'in_progress', 'blocked')
+		  AND sd.issue_id = ANY(?)
+		ORDER BY sd.depends_on_id
+	`, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("failed to get soft blockers: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+		var issueID, blockerID string
+		if err := rows.Scan(&issueID, &blockerID); err != nil {
+			return fmt.Errorf("failed to scan soft blocker: %w", err)
+		}
+		if issue := byID[issueID]; issue != nil {
+			issue.SoftBlockedBy = append(issue.SoftBlockedBy, blockerID)
+		}
+	}
+	return rows.Err()
+}
+
+// ClaimReadyWork atomically picks the top ready open issue that is
+// unassigned or already assigned to actor, and claims it (status
+// in_progress, assignee actor). Returns nil if there is nothing to claim.
+func (s *PostgresStorage) ClaimReadyWork(ctx context.Context, filter types.WorkFilter, actor string) (*types.Issue, error) {
+	if actor == "" {
+		return nil, fmt.Errorf("actor is required to claim work")
+	}
+	var claimed *types.Issue
+	err := s.withTx(ctx, func(c *conn) error {
+		filter.Status = types.StatusOpen
+		filter.Limit = 0
+		filter.Unassigned, filter.Assignee, filter.AvailableTo = false, nil, &actor
+		query, args := readyWorkQuery(filter, "i.id")
+		var id string
+		err := c.queryRow(ctx, query+" LIMIT 1", args...).Scan(&id)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil
+			}
+			return fmt.Errorf("failed to get ready work: %w", err)
+		}
+		if err := c.updateIssue(ctx, id, map[string]interface{}{
+			"status":   string(types.StatusInProgress),
+			"assignee": actor,
+		}, actor); err != nil {
+			return err
+		}
+		claimed, err = c.getIssue(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim ready work: %w", err)
+	}
+	return claimed, nil
+}
+
+// GetBlockedIssues returns issues blocked by open dependencies or marked
+// blocked, with the IDs of their blockers
+func (s *PostgresStorage) GetBlockedIssues(ctx context.Context) ([]*types.BlockedIssue, error) {
+	rows, err := s.read().query(ctx, `
+		SELECT `+prefixedColumns("i")+`,
+		       COUNT(blocker.id),
+		       COALESCE(array_agg(blocker.id ORDER BY blocker.id) FILTER (WHERE blocker.id IS NOT NULL), '{}')
+		FROM issues i
+		LEFT JOIN dependencies d ON d.issue_id = i.id AND d.type = 'blocks'
+		LEFT JOIN issues blocker ON blocker.id = d.depends_on_id
+		     AND blocker.status IN ('open', 'in_progress', 'blocked')
+		WHERE i.status IN ('open', 'in_progress', 'blocked')
+		GROUP BY i.id
+		HAVING i.status = 'blocked' OR COUNT(blocker.id) > 0
+		ORDER BY i.priority ASC, i.id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blocked issues: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var blocked []*types.BlockedIssue
+	for rows.Next() {
+		var b types.BlockedIssue
+		issue, err := scanIssue(rows, &b.BlockedByCount, pq.Array(&b.BlockedBy))
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan blocked issue: %w", err)
+		}
+		b.Issue = *issue
+		blocked = append(blocked, &b)
+	}
+	return blocked, rows.Err()
+}
+
+// GetEpicsEligibleForClosure returns the open epics with their child counts;
+// an epic is eligible once all of its children are closed
+func (s *PostgresStorage) GetEpicsEligibleForClosure(ctx context.Context) ([]*types.EpicStatus, error) {
+	rows, err := s.read().query(ctx, `
+		SELECT `+prefixedColumns("i")+`,
+		       COUNT(child.id),
+		       COUNT(child.id) FILTER (WHERE child.status = 'closed')
+		FROM issues i
+		LEFT JOIN dependencies d ON d.depends_on_id = i.id AND d.type = 'parent-child'
+		LEFT JOIN issues child ON child.id = d.issue_id
+		WHERE i.issue_type = 'epic' AND i.status != 'closed'
+		GROUP BY i.id
+		ORDER BY i.priority ASC, i.created_at ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get epics: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var results []*types.EpicStatus
+	for rows.Next() {
+		var total, closed int
+		epic, err := scanIssue(rows, &total, &closed)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan epic: %w", err)
+		}
+		results = append(results, &types.EpicStatus{
+			Epic:             epic,
+			TotalChildren:    total,
+			ClosedChildren:   closed,
+			EligibleForClose: total > 0 && closed == total,
+		})
+	}
+	return results, rows.Err()
+}
+
+// GetStaleIssues returns issues that haven't been updated recently
+func (s *PostgresStorage) GetStaleIssues(ctx context.Context, filter types.StaleFilter) ([]*types.Issue, error) {
+	query := `SELECT ` + issueColumns + ` FROM issues
+		WHERE status != 'closed' AND updated_at < now() - make_interval(days => ?)`
+	args := []interface{}{filter.Days}
+	if filter.Status != "" {
+		query += ` AND status = ?`
+		args = append(args, filter.Status)
+	}
+	query += ` ORDER BY updated_at ASC`
+	if filter.Limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, filter.Limit)
+	}
+	c := s.read()
+	rows, err := c.query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query stale issues: %w", err)
+	}
+	return c.scanIssues(ctx, rows)
+}
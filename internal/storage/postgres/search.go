@@ -0,0 +1,238 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// defaultIssueOrder is the order of SearchIssues results and the order
+// page cursors follow
+const defaultIssueOrder = "priority ASC, created_at DESC, id ASC"
+
+// sortColumns maps sort fields to the expressions they order by, as in the
+// sqlite backend. Nullable fields sort their missing values last in either
+// direction.
+var sortColumns = map[string]struct{ nullsLast, expr string }{
+	"priority":   {expr: "priority"},
+	"created_at": {expr: "created_at"},
+	"updated_at": {expr: "updated_at"},
+	"closed_at":  {nullsLast: "closed_at IS NULL", expr: "closed_at"},
+	"status":     {expr: "status"},
+	"issue_type": {expr: "issue_type"},
+	"assignee":   {nullsLast: "COALESCE(assignee, '') = ''", expr: "assignee"},
+	"title":      {expr: "lower(title)"},
+	"estimate":   {nullsLast: "estimated_minutes IS NULL", expr: "estimated_minutes"},
+	"id":         {expr: "id"},
+}
+
+// orderByClause builds the ORDER BY list for filter.Sort, ending with id so
+// that equal keys keep a stable order
+func orderByClause(filter types.IssueFilter) (string, error) {
+	if len(filter.Sort) == 0 {
+		return defaultIssueOrder, nil
+	}
+	if filter.After != nil {
+		return "", fmt.Errorf("cursor pagination requires the default sort order")
+	}
+	var terms []string
+	for _, k := range filter.Sort {
+		col, ok := sortColumns[k.Field]
+		if !ok {
+			return "", fmt.Errorf("unknown sort field %q", k.Field)
+		}
+		if col.nullsLast != "" {
+			terms = append(terms, col.nullsLast)
+		}
+		dir := " ASC"
+		if k.Desc {
+			dir = " DESC"
+		}
+		terms = append(terms, col.expr+dir)
+	}
+	terms = append(terms, "id ASC")
+	return strings.Join(terms, ", "), nil
+}
+
+// pageCursorClause matches the issues after c in the default order. The
+// cursor issue's stored created_at is used when it still exists, so a
+// cursor survives the timestamp precision lost in encoding it.
+func pageCursorClause(c *types.PageCursor) (string, []interface{}) {
+	createdAt := "COALESCE((SELECT created_at FROM issues WHERE id = ?), ?)"
+	clause := fmt.Sprintf("(priority > ? OR (priority = ? AND (created_at < %s OR (created_at = %s AND id > ?))))", createdAt, createdAt)
+	stamp := c.CreatedAt.UTC()
+	return clause, []interface{}{c.Priority, c.Priority, c.ID, stamp, c.ID, stamp, c.ID}
+}
+
+// likePattern returns a substring pattern for s with LIKE wildcards escaped
+func likePattern(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return "%" + r.Replace(s) + "%"
+}
+
+// searchIssues finds issues matching query and filter. There is no
+// full-text index: query matches substrings of the ID, title and
+// description.
+func (c *conn) searchIssues(ctx context.Context, query string, filter types.IssueFilter) ([]*types.Issue, error) {
+	var where []string
+	var args []interface{}
+	add := func(clause string, clauseArgs ...interface{}) {
+		where = append(where, clause)
+		args = append(args, clauseArgs...)
+	}
+
+	if query != "" {
+		pattern := likePattern(query)
+		add("(id ILIKE ? OR title ILIKE ? OR description ILIKE ?)", pattern, pattern, pattern)
+	}
+	if filter.TitleSearch != "" {
+		add("title ILIKE ?", likePattern(filter.TitleSearch))
+	}
+	if filter.TitleContains != "" {
+		add("title ILIKE ?", likePattern(filter.TitleContains))
+	}
+	if filter.DescriptionContains != "" {
+		add("description ILIKE ?", likePattern(filter.DescriptionContains))
+	}
+	if filter.NotesContains != "" {
+		add("notes ILIKE ?", likePattern(filter.NotesContains))
+	}
+
+	if filter.Status != nil {
+		add("status = ?", *filter.Status)
+	} else if !filter.IncludeTombstones {
+		add("status != ?", types.StatusTombstone)
+	}
+	if filter.Priority != nil {
+		add("priority = ?", *filter.Priority)
+	}
+	if filter.PriorityMin != nil {
+		add("priority >= ?", *filter.PriorityMin)
+	}
+	if filter.PriorityMax != nil {
+		add("priority <= ?", *filter.PriorityMax)
+	}
+	if filter.IssueType != nil {
+		add("issue_type = ?", *filter.IssueType)
+	}
+	if filter.Assignee != nil {
+		add("assignee = ?", *filter.Assignee)
+	}
+
+	if filter.CreatedAfter != nil {
+		add("created_at > ?", filter.CreatedAfter.UTC())
+	}
+	if filter.CreatedBefore != nil {
+		add("created_at < ?", filter.CreatedBefore.UTC())
+	}
+	if filter.UpdatedAfter != nil {
+		add("updated_at > ?", filter.UpdatedAfter.UTC())
+	}
+	if filter.UpdatedBefore != nil {
+		add("updated_at < ?", filter.UpdatedBefore.UTC())
+	}
+	if filter.ClosedAfter != nil {
+		add("closed_at > ?", filter.ClosedAfter.UTC())
+	}
+	if filter.ClosedBefore != nil {
+		add("closed_at < ?", filter.ClosedBefore.UTC())
+	}
+	if filter.CloseReason != "" {
+		add("(close_reason = ? OR starts_with(close_reason, ?))", filter.CloseReason, filter.CloseReason+":")
+	}
+
+	if filter.EmptyDescription {
+		add("description = ''")
+	}
+	if filter.NoAssignee {
+		add("(assignee IS NULL OR assignee = '')")
+	}
+	if filter.NoLabels {
+		add("NOT EXISTS (SELECT 1 FROM labels WHERE labels.issue_id = issues.id)")
+	}
+	for _, label := range filter.Labels {
+		add("id IN (SELECT issue_id FROM labels WHERE label = ?)", label)
+	}
+	if len(filter.LabelsAny) > 0 {
+		add(fmt.Sprintf("id IN (SELECT issue_id FROM labels WHERE label IN (%s))", placeholders(len(filter.LabelsAny))), stringArgs(filter.LabelsAny)...)
+	}
+	if len(filter.IDs) > 0 {
+		add(fmt.Sprintf("id IN (%s)", placeholders(len(filter.IDs))), stringArgs(filter.IDs)...)
+	}
+	if filter.Ephemeral != nil {
+		add("ephemeral = ?", *filter.Ephemeral)
+	}
+	if len(filter.Complexity) > 0 {
+		add(complexityClause("complexity", filter.Complexity))
+	}
+	for _, clause := range fieldClauses("id", filter.Fields) {
+		add(clause.sql, clause.args...)
+	}
+	if filter.After != nil {
+		clause, cursorArgs := pageCursorClause(filter.After)
+		add(clause, cursorArgs...)
+	}
+
+	orderSQL, err := orderByClause(filter)
+	if err != nil {
+		return nil, err
+	}
+	whereSQL := ""
+	if len(where) > 0 {
+		whereSQL = "WHERE " + strings.Join(where, " AND ")
+	}
+	limitSQL := ""
+	if filter.Limit > 0 {
+		limitSQL = "LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+
+	// #nosec G201 - safe SQL with controlled formatting
+	rows, err := c.query(ctx, fmt.Sprintf(`SELECT %s FROM issues %s ORDER BY %s %s`, issueColumns, whereSQL, orderSQL, limitSQL), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search issues: %w", err)
+	}
+	return c.scanIssues(ctx, rows)
+}
+
+// SearchIssues finds issues matching query and filters
+func (s *PostgresStorage) SearchIssues(ctx context.Context, query string, filter types.IssueFilter) ([]*types.Issue, error) {
+	return s.read().searchIssues(ctx, query, filter)
+}
+
+// complexityClause matches issues whose complexity is one of values
+func complexityClause(column string, values []types.Complexity) (string, interface{}) {
+	list := make([]string, len(values))
+	for i, c := range values {
+		list[i] = string(c)
+	}
+	return column + " = ANY(?)", pq.Array(list)
+}
+
+// whereClause is one condition with its arguments
+type whereClause struct {
+	sql  string
+	args []interface{}
+}
+
+// fieldClauses matches issues (by the column holding their ID) that have
+// every value in fields
+func fieldClauses(column string, fields types.CustomFields) []whereClause {
+	var clauses []whereClause
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		clauses = append(clauses, whereClause{
+			sql:  column + " IN (SELECT issue_id FROM issue_fields WHERE name = ? AND value = ?)",
+			args: []interface{}{name, fields[name]},
+		})
+	}
+	return clauses
+}
@@ -0,0 +1,247 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// activityDays is how many days of activity statistics report, today included
+const activityDays = 7
+
+// GetStatistics returns aggregate statistics, computed from the issues,
+// labels, events and work log (the sqlite backend's summary tables have no
+// counterpart here)
+func (s *PostgresStorage) GetStatistics(ctx context.Context) (*types.Statistics, error) {
+	c := s.read()
+	var stats types.Statistics
+	if err := c.issueCounts(ctx, &stats); err != nil {
+		return nil, err
+	}
+	if err := c.labelCounts(ctx, &stats); err != nil {
+		return nil, err
+	}
+	if err := c.recentActivity(ctx, &stats); err != nil {
+		return nil, err
+	}
+
+	err := c.queryRow(ctx, `
+		SELECT COUNT(DISTINCT i.id)
+		FROM issues i
+		JOIN dependencies d ON i.id = d.issue_id
+		JOIN issues blocker ON d.depends_on_id = blocker.id
+		WHERE i.status IN ('open', 'in_progress', 'blocked')
+		  AND d.type = 'blocks'
+		  AND blocker.status IN ('open', 'in_progress', 'blocked')
+	`).Scan(&stats.BlockedIssues)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blocked count: %w", err)
+	}
+
+	err = c.queryRow(ctx, `
+		SELECT COUNT(*)
+		FROM issues i
+		WHERE i.status = 'open'
+		  AND NOT EXISTS (
+		    SELECT 1 FROM dependencies d
+		    JOIN issues blocker ON d.depends_on_id = blocker.id
+		    WHERE d.issue_id = i.id
+		      AND d.type = 'blocks'
+		      AND blocker.status IN ('open', 'in_progress', 'blocked')
+		  )
+	`).Scan(&stats.ReadyIssues)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ready count: %w", err)
+	}
+
+	err = c.queryRow(ctx, `
+		SELECT COUNT(*) FROM (
+			SELECT i.id
+			FROM issues i
+			JOIN dependencies d ON d.depends_on_id = i.id AND d.type = 'parent-child'
+			JOIN issues child ON child.id = d.issue_id
+			WHERE i.issue_type = 'epic' AND i.status != 'closed'
+			GROUP BY i.id
+			HAVING COUNT(*) = COUNT(*) FILTER (WHERE child.status = 'closed')
+		) eligible
+	`).Scan(&stats.EpicsEligibleForClosure)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get eligible epics count: %w", err)
+	}
+
+	if err := c.closedByReason(ctx, &stats); err != nil {
+		return nil, err
+	}
+	if err := c.minutesLoggedByActor(ctx, &stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// issueCounts fills the counts by status, open issues by priority and the
+// average lead time
+func (c *conn) issueCounts(ctx context.Context, stats *types.Statistics) error {
+	rows, err := c.query(ctx, `
+		SELECT status, priority, COUNT(*),
+		       COALESCE(SUM(EXTRACT(EPOCH FROM closed_at - created_at) / 3600), 0),
+		       COUNT(closed_at)
+		FROM issues GROUP BY status, priority
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to get issue counts: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var leadHours float64
+	var leadIssues int
+	for rows.Next() {
+		var status string
+		var priority, issues, lead int
+		var hours float64
+		if err := rows.Scan(&status, &priority, &issues, &hours, &lead); err != nil {
+			return fmt.Errorf("failed to scan issue counts: %w", err)
+		}
+		leadHours += hours
+		leadIssues += lead
+		switch types.Status(status) {
+		case types.StatusTombstone:
+			stats.TombstoneIssues += issues
+			continue
+		case types.StatusOpen:
+			stats.OpenIssues += issues
+		case types.StatusInProgress:
+			stats.InProgressIssues += issues
+		case types.StatusResolved:
+			stats.ResolvedIssues += issues
+		case types.StatusClosed:
+			stats.ClosedIssues += issues
+		}
+		stats.TotalIssues += issues
+		if types.Status(status) != types.StatusClosed {
+			if stats.OpenByPriority == nil {
+				stats.OpenByPriority = make(map[string]int)
+			}
+			stats.OpenByPriority[fmt.Sprintf("P%d", priority)] += issues
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read issue counts: %w", err)
+	}
+	if leadIssues > 0 {
+		stats.AverageLeadTime = leadHours / float64(leadIssues)
+	}
+	return nil
+}
+
+// labelCounts fills the open issues by label
+func (c *conn) labelCounts(ctx context.Context, stats *types.Statistics) error {
+	rows, err := c.query(ctx, `
+		SELECT l.label, COUNT(*)
+		FROM labels l JOIN issues i ON i.id = l.issue_id
+		WHERE i.status NOT IN ('closed', 'tombstone')
+		GROUP BY l.label
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to get label counts: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+		var label string
+		var issues int
+		if err := rows.Scan(&label, &issues); err != nil {
+			return fmt.Errorf("failed to scan label counts: %w", err)
+		}
+		if stats.OpenByLabel == nil {
+			stats.OpenByLabel = make(map[string]int)
+		}
+		stats.OpenByLabel[label] = issues
+	}
+	return rows.Err()
+}
+
+// recentActivity fills the event counts per UTC day over the last week
+func (c *conn) recentActivity(ctx context.Context, stats *types.Statistics) error {
+	since := time.Now().UTC().AddDate(0, 0, -(activityDays - 1)).Format("2006-01-02")
+	rows, err := c.query(ctx, `
+		SELECT to_char(created_at AT TIME ZONE 'UTC', 'YYYY-MM-DD') AS day,
+		       COUNT(*),
+		       COUNT(*) FILTER (WHERE event_type = 'created'),
+		       COUNT(*) FILTER (WHERE event_type = 'closed')
+		FROM events
+		WHERE created_at >= ?::date AT TIME ZONE 'UTC'
+		GROUP BY day
+		ORDER BY day
+	`, since)
+	if err != nil {
+		return fmt.Errorf("failed to get daily activity: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+		var a types.DailyActivity
+		if err := rows.Scan(&a.Date, &a.Events, &a.Created, &a.Closed); err != nil {
+			return fmt.Errorf("failed to scan daily activity: %w", err)
+		}
+		stats.RecentActivity = append(stats.RecentActivity, a)
+	}
+	return rows.Err()
+}
+
+// closedByReason counts closed issues per close reason category
+func (c *conn) closedByReason(ctx context.Context, stats *types.Statistics) error {
+	value, err := c.getConfig(ctx, sqlite.CloseReasonsConfigKey)
+	if err != nil {
+		return err
+	}
+	custom := sqlite.ParseConfigList(value)
+	rows, err := c.query(ctx, `
+		SELECT close_reason, COUNT(*)
+		FROM issues
+		WHERE status = 'closed'
+		GROUP BY close_reason
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to get close reason counts: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	stats.ClosedByReason = make(map[string]int)
+	for rows.Next() {
+		var reason string
+		var count int
+		if err := rows.Scan(&reason, &count); err != nil {
+			return fmt.Errorf("failed to scan close reason count: %w", err)
+		}
+		stats.ClosedByReason[types.CloseReasonCategory(reason, custom)] += count
+	}
+	return rows.Err()
+}
+
+// minutesLoggedByActor totals the work log per actor, leaving out deleted
+// issues
+func (c *conn) minutesLoggedByActor(ctx context.Context, stats *types.Statistics) error {
+	rows, err := c.query(ctx, `
+		SELECT w.actor, SUM(w.minutes)
+		FROM work_log w
+		JOIN issues i ON i.id = w.issue_id
+		WHERE i.status != 'tombstone'
+		GROUP BY w.actor
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to total work log: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	stats.MinutesLoggedByActor = make(map[string]int)
+	for rows.Next() {
+		var actor string
+		var minutes int
+		if err := rows.Scan(&actor, &minutes); err != nil {
+			return fmt.Errorf("failed to scan work log total: %w", err)
+		}
+		stats.MinutesLoggedByActor[actor] = minutes
+	}
+	return rows.Err()
+}
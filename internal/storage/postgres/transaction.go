@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Verify pgTransaction implements storage.Transaction at compile time
+var _ storage.Transaction = (*pgTransaction)(nil)
+
+// pgTransaction implements storage.Transaction on one database transaction
+type pgTransaction struct {
+	c *conn
+}
+
+// RunInTransaction runs fn in a write transaction. The transaction commits
+// if fn returns nil and rolls back if it returns an error or panics; a panic
+// is re-raised after the rollback.
+func (s *PostgresStorage) RunInTransaction(ctx context.Context, fn func(tx storage.Transaction) error) error {
+	return s.withTx(ctx, func(c *conn) error {
+		return fn(&pgTransaction{c: c})
+	})
+}
+
+// CreateIssue creates an issue within the transaction
+func (t *pgTransaction) CreateIssue(ctx context.Context, issue *types.Issue, actor string) error {
+	return t.c.createIssue(ctx, issue, actor)
+}
+
+// CreateIssues creates several issues within the transaction
+func (t *pgTransaction) CreateIssues(ctx context.Context, issues []*types.Issue, actor string) error {
+	return t.c.createIssues(ctx, issues, actor)
+}
+
+// UpdateIssue updates an issue within the transaction
+func (t *pgTransaction) UpdateIssue(ctx context.Context, id string, updates map[string]interface{}, actor string) error {
+	return t.c.updateIssue(ctx, id, updates, actor)
+}
+
+// CloseIssue closes an issue within the transaction
+func (t *pgTransaction) CloseIssue(ctx context.Context, id string, reason string, actor string) error {
+	return t.c.closeIssue(ctx, id, reason, actor)
+}
+
+// DeleteIssue deletes an issue within the transaction
+func (t *pgTransaction) DeleteIssue(ctx context.Context, id string) error {
+	return t.c.deleteIssue(ctx, id)
+}
+
+// GetIssue reads an issue, seeing the transaction's own writes
+func (t *pgTransaction) GetIssue(ctx context.Context, id string) (*types.Issue, error) {
+	return t.c.getIssue(ctx, id)
+}
+
+// SearchIssues searches issues, seeing the transaction's own writes
+func (t *pgTransaction) SearchIssues(ctx context.Context, query string, filter types.IssueFilter) ([]*types.Issue, error) {
+	return t.c.searchIssues(ctx, query, filter)
+}
+
+// AddDependency adds a dependency within the transaction
+func (t *pgTransaction) AddDependency(ctx context.Context, dep *types.Dependency, actor string) error {
+	return t.c.addDependency(ctx, dep, actor)
+}
+
+// RemoveDependency removes a dependency within the transaction
+func (t *pgTransaction) RemoveDependency(ctx context.Context, issueID, dependsOnID string, actor string) error {
+	return t.c.removeDependency(ctx, issueID, dependsOnID, actor)
+}
+
+// AddLabel adds a label within the transaction
+func (t *pgTransaction) AddLabel(ctx context.Context, issueID, label, actor string) error {
+	return t.c.addLabel(ctx, issueID, label, actor)
+}
+
+// RemoveLabel removes a label within the transaction
+func (t *pgTransaction) RemoveLabel(ctx context.Context, issueID, label, actor string) error {
+	return t.c.removeLabel(ctx, issueID, label, actor)
+}
+
+// SetConfig sets a config value within the transaction
+func (t *pgTransaction) SetConfig(ctx context.Context, key, value string) error {
+	return t.c.setKeyValue(ctx, "config", key, value)
+}
+
+// GetConfig reads a config value within the transaction
+func (t *pgTransaction) GetConfig(ctx context.Context, key string) (string, error) {
+	return t.c.getConfig(ctx, key)
+}
+
+// SetMetadata sets a metadata value within the transaction
+func (t *pgTransaction) SetMetadata(ctx context.Context, key, value string) error {
+	return t.c.setKeyValue(ctx, "metadata", key, value)
+}
+
+// GetMetadata reads a metadata value within the transaction
+func (t *pgTransaction) GetMetadata(ctx context.Context, key string) (string, error) {
+	return t.c.getKeyValue(ctx, "metadata", key)
+}
+
+// AddComment adds a comment event within the transaction
+func (t *pgTransaction) AddComment(ctx context.Context, issueID, actor, comment string) error {
+	return t.c.addComment(ctx, issueID, actor, comment)
+}
@@ -0,0 +1,122 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ChildProgress computes child completion for each of parentIDs from the
+// parent-child hierarchy. Total and Closed count direct children. With
+// rollUp, estimated_minutes is also summed over all descendants, both in
+// total and for the descendants that are not closed yet.
+func ChildProgress(ctx context.Context, s Storage, parentIDs []string, rollUp bool) (map[string]*types.ChildProgress, error) {
+	result := make(map[string]*types.ChildProgress, len(parentIDs))
+	if len(parentIDs) == 0 {
+		return result, nil
+	}
+
+	deps, err := s.GetAllDependencyRecords(ctx)
+	if err != nil {
+		return nil, err
+	}
+	children := make(map[string][]string)
+	for childID, records := range deps {
+		for _, dep := range records {
+			if dep.Type == types.DepParentChild {
+				children[dep.DependsOnID] = append(children[dep.DependsOnID], childID)
+			}
+		}
+	}
+
+	// Collect every issue we need to look at: direct children, or all
+	// descendants when rolling up estimates
+	descendants := make(map[string][]string, len(parentIDs))
+	needed := make(map[string]bool)
+	for _, parentID := range parentIDs {
+		if rollUp {
+			descendants[parentID] = CollectDescendants(parentID, children)
+		} else {
+			descendants[parentID] = children[parentID]
+		}
+		for _, id := range descendants[parentID] {
+			needed[id] = true
+		}
+	}
+
+	issues := make(map[string]*types.Issue, len(needed))
+	if len(needed) > 0 {
+		ids := make([]string, 0, len(needed))
+		for id := range needed {
+			ids = append(ids, id)
+		}
+		found, err := s.SearchIssues(ctx, "", types.IssueFilter{IDs: ids})
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range found {
+			issues[issue.ID] = issue
+		}
+	}
+
+	for _, parentID := range parentIDs {
+		progress := &types.ChildProgress{}
+		for _, childID := range children[parentID] {
+			child, ok := issues[childID]
+			if !ok {
+				continue
+			}
+			progress.Total++
+			if child.Status == types.StatusClosed {
+				progress.Closed++
+			}
+		}
+		if rollUp {
+			estimated, remaining := 0, 0
+			for _, id := range descendants[parentID] {
+				issue, ok := issues[id]
+				if !ok || issue.EstimatedMinutes == nil {
+					continue
+				}
+				estimated += *issue.EstimatedMinutes
+				if issue.Status != types.StatusClosed {
+					remaining += *issue.EstimatedMinutes
+				}
+			}
+			progress.EstimatedMinutes = &estimated
+			progress.RemainingMinutes = &remaining
+		}
+		result[parentID] = progress
+	}
+	return result, nil
+}
+
+// CollectDescendants walks a parent → children index breadth-first. Cycles in a
+// corrupted hierarchy are tolerated; each issue is visited once.
+func CollectDescendants(rootID string, children map[string][]string) []string {
+	visited := map[string]bool{rootID: true}
+	var out []string
+	queue := append([]string(nil), children[rootID]...)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+		out = append(out, id)
+		queue = append(queue, children[id]...)
+	}
+	return out
+}
+
+// EpicIDs returns the IDs of the epics among issues, in order
+func EpicIDs(issues []*types.Issue) []string {
+	var ids []string
+	for _, issue := range issues {
+		if issue.IssueType == types.TypeEpic {
+			ids = append(ids, issue.ID)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,152 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// GroupByEpic groups ready work under the nearest epic above each issue in
+// the parent-child hierarchy, with each epic's child progress and estimate
+// roll-up. Groups follow the order of their first issue, so the epic of the
+// top-ranked work comes first; work outside any epic is grouped last.
+func GroupByEpic(ctx context.Context, s Storage, issues []*types.Issue) ([]*types.ReadyGroup, error) {
+	deps, err := s.GetAllDependencyRecords(ctx)
+	if err != nil {
+		return nil, err
+	}
+	parents := make(map[string][]string)
+	for childID, records := range deps {
+		for _, dep := range records {
+			if dep.Type == types.DepParentChild {
+				parents[childID] = append(parents[childID], dep.DependsOnID)
+			}
+		}
+	}
+
+	// Every ancestor could be an epic, so load them all at once
+	ancestors := make(map[string][]string, len(issues))
+	needed := make(map[string]bool)
+	for _, issue := range issues {
+		ancestors[issue.ID] = CollectDescendants(issue.ID, parents)
+		for _, id := range ancestors[issue.ID] {
+			needed[id] = true
+		}
+	}
+	epics := make(map[string]*types.Issue)
+	if len(needed) > 0 {
+		ids := make([]string, 0, len(needed))
+		for id := range needed {
+			ids = append(ids, id)
+		}
+		found, err := s.SearchIssues(ctx, "", types.IssueFilter{IDs: ids})
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range found {
+			if issue.IssueType == types.TypeEpic {
+				epics[issue.ID] = issue
+			}
+		}
+	}
+
+	byEpic := make(map[string]*types.ReadyGroup)
+	var groups []*types.ReadyGroup
+	none := &types.ReadyGroup{}
+	for _, issue := range issues {
+		group := none
+		// Ancestors come nearest first
+		for _, id := range ancestors[issue.ID] {
+			epic, ok := epics[id]
+			if !ok {
+				continue
+			}
+			if group = byEpic[id]; group == nil {
+				group = &types.ReadyGroup{Epic: epic}
+				byEpic[id] = group
+				groups = append(groups, group)
+			}
+			break
+		}
+		group.Issues = append(group.Issues, issue)
+	}
+
+	epicIDs := make([]string, len(groups))
+	for i, group := range groups {
+		epicIDs[i] = group.Epic.ID
+	}
+	progress, err := ChildProgress(ctx, s, epicIDs, true)
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range groups {
+		group.Progress = progress[group.Epic.ID]
+	}
+	if len(none.Issues) > 0 {
+		groups = append(groups, none)
+	}
+	return groups, nil
+}
+
+// GroupByLabel groups ready work under each label it carries (an issue
+// with several labels appears in each group), with completion across every
+// issue carrying the label. Groups follow the order of their first issue;
+// unlabeled work is grouped last.
+func GroupByLabel(ctx context.Context, s Storage, issues []*types.Issue) ([]*types.ReadyGroup, error) {
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	labels, err := s.GetLabelsForIssues(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	byLabel := make(map[string]*types.ReadyGroup)
+	var groups []*types.ReadyGroup
+	none := &types.ReadyGroup{}
+	for _, issue := range issues {
+		if len(labels[issue.ID]) == 0 {
+			none.Issues = append(none.Issues, issue)
+			continue
+		}
+		for _, label := range labels[issue.ID] {
+			group := byLabel[label]
+			if group == nil {
+				group = &types.ReadyGroup{Label: label}
+				byLabel[label] = group
+				groups = append(groups, group)
+			}
+			group.Issues = append(group.Issues, issue)
+		}
+	}
+
+	for _, group := range groups {
+		labeled, err := s.SearchIssues(ctx, "", types.IssueFilter{Labels: []string{group.Label}})
+		if err != nil {
+			return nil, err
+		}
+		progress := &types.ChildProgress{}
+		estimated, remaining := 0, 0
+		for _, issue := range labeled {
+			progress.Total++
+			closed := issue.Status == types.StatusClosed
+			if closed {
+				progress.Closed++
+			}
+			if issue.EstimatedMinutes != nil {
+				estimated += *issue.EstimatedMinutes
+				if !closed {
+					remaining += *issue.EstimatedMinutes
+				}
+			}
+		}
+		progress.EstimatedMinutes = &estimated
+		progress.RemainingMinutes = &remaining
+		group.Progress = progress
+	}
+	if len(none.Issues) > 0 {
+		groups = append(groups, none)
+	}
+	return groups, nil
+}
@@ -0,0 +1,141 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// SetIssueAlias gives an issue a human-friendly alias. Setting an alias the
+// issue already has is a no-op, which keeps JSONL imports idempotent. An
+// alias can't name an existing issue ID or another issue's alias.
+func (s *SQLiteStorage) SetIssueAlias(ctx context.Context, issueID, alias, actor string) error {
+	if err := types.ValidateAlias(alias); err != nil {
+		return err
+	}
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		var exists bool
+		if err := tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM issues WHERE id = ?)`, issueID).Scan(&exists); err != nil {
+			return fmt.Errorf("failed to check issue existence: %w", err)
+		}
+		if !exists {
+			return fmt.Errorf("issue %s not found", issueID)
+		}
+		if err := tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM issues WHERE id = ?)`, alias).Scan(&exists); err != nil {
+			return fmt.Errorf("failed to check issue existence: %w", err)
+		}
+		if exists {
+			return fmt.Errorf("alias %q is already an issue ID", alias)
+		}
+
+		var current string
+		err := tx.QueryRowContext(ctx, `SELECT issue_id FROM issue_aliases WHERE alias = ?`, alias).Scan(&current)
+		switch {
+		case err == sql.ErrNoRows:
+		case err != nil:
+			return fmt.Errorf("failed to look up alias: %w", err)
+		case current == issueID:
+			return nil
+		default:
+			return fmt.Errorf("alias %q already refers to %s", alias, current)
+		}
+
+		if _, err := tx.ExecContext(ctx, `INSERT INTO issue_aliases (alias, issue_id) VALUES (?, ?)`, alias, issueID); err != nil {
+			return fmt.Errorf("failed to add alias: %w", err)
+		}
+		return markIssueDirtyInTx(ctx, tx, issueID)
+	})
+}
+
+// RemoveIssueAlias deletes an alias. Removing an unknown alias is an error so
+// typos don't pass silently.
+func (s *SQLiteStorage) RemoveIssueAlias(ctx context.Context, alias, actor string) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		var issueID string
+		err := tx.QueryRowContext(ctx, `SELECT issue_id FROM issue_aliases WHERE alias = ?`, alias).Scan(&issueID)
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("no issue has alias %q", alias)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to look up alias: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM issue_aliases WHERE alias = ?`, alias); err != nil {
+			return fmt.Errorf("failed to remove alias: %w", err)
+		}
+		return markIssueDirtyInTx(ctx, tx, issueID)
+	})
+}
+
+func markIssueDirtyInTx(ctx context.Context, tx *sql.Tx, issueID string) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO dirty_issues (issue_id, marked_at)
+		VALUES (?, CURRENT_TIMESTAMP)
+		ON CONFLICT (issue_id) DO UPDATE SET marked_at = excluded.marked_at
+	`, issueID)
+	if err != nil {
+		return fmt.Errorf("failed to mark issue dirty: %w", err)
+	}
+	return nil
+}
+
+// ResolveIssueAlias returns the ID of the issue with the given alias, or ""
+// if no issue has it
+func (s *SQLiteStorage) ResolveIssueAlias(ctx context.Context, alias string) (string, error) {
+	var issueID string
+	err := s.db.QueryRowContext(ctx, `SELECT issue_id FROM issue_aliases WHERE alias = ?`, alias).Scan(&issueID)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve alias: %w", err)
+	}
+	return issueID, nil
+}
+
+// GetAliasesForIssues fetches aliases for multiple issues in a single query
+// Returns a map of issue_id -> sorted aliases
+func (s *SQLiteStorage) GetAliasesForIssues(ctx context.Context, issueIDs []string) (map[string][]string, error) {
+	result := make(map[string][]string)
+	if len(issueIDs) == 0 {
+		return result, nil
+	}
+
+	placeholders := make([]interface{}, len(issueIDs))
+	for i, id := range issueIDs {
+		placeholders[i] = id
+	}
+	query := fmt.Sprintf(`
+		SELECT issue_id, alias FROM issue_aliases
+		WHERE issue_id IN (%s)
+		ORDER BY issue_id, alias
+	`, buildPlaceholders(len(issueIDs))) // #nosec G201 -- placeholders are generated internally
+
+	rows, err := s.db.QueryContext(ctx, query, placeholders...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query aliases: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var issueID, alias string
+		if err := rows.Scan(&issueID, &alias); err != nil {
+			return nil, fmt.Errorf("failed to scan alias: %w", err)
+		}
+		result[issueID] = append(result[issueID], alias)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating aliases: %w", err)
+	}
+	return result, nil
+}
+
+// getIssueAliases returns one issue's aliases for GetIssue
+func (s *SQLiteStorage) getIssueAliases(ctx context.Context, issueID string) ([]string, error) {
+	result, err := s.GetAliasesForIssues(ctx, []string{issueID})
+	if err != nil {
+		return nil, err
+	}
+	return result[issueID], nil
+}
@@ -0,0 +1,90 @@
+package sqlite
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestIssueAliases(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	for _, id := range []string{"bd-1", "bd-2"} {
+		issue := &types.Issue{ID: id, Title: id, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.ClearDirtyIssuesByID(ctx, []string{"bd-1", "bd-2"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.SetIssueAlias(ctx, "bd-1", "auth-refactor", "alice"); err != nil {
+		t.Fatalf("SetIssueAlias: %v", err)
+	}
+	if err := store.SetIssueAlias(ctx, "bd-1", "login", "alice"); err != nil {
+		t.Fatal(err)
+	}
+	// Setting the same alias again is a no-op
+	if err := store.SetIssueAlias(ctx, "bd-1", "login", "alice"); err != nil {
+		t.Errorf("re-adding an alias: %v", err)
+	}
+
+	issue, err := store.GetIssue(ctx, "bd-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(issue.Aliases, []string{"auth-refactor", "login"}) {
+		t.Errorf("aliases = %v", issue.Aliases)
+	}
+	dirty, _ := store.GetDirtyIssues(ctx)
+	if !reflect.DeepEqual(dirty, []string{"bd-1"}) {
+		t.Errorf("dirty issues = %v, want bd-1 so the alias is exported", dirty)
+	}
+
+	if id, err := store.ResolveIssueAlias(ctx, "login"); err != nil || id != "bd-1" {
+		t.Errorf("ResolveIssueAlias(login) = %q, %v", id, err)
+	}
+	if id, err := store.ResolveIssueAlias(ctx, "unknown"); err != nil || id != "" {
+		t.Errorf("ResolveIssueAlias(unknown) = %q, %v", id, err)
+	}
+
+	for _, tc := range []struct{ issueID, alias string }{
+		{"bd-2", "login"},      // taken by bd-1
+		{"bd-2", "bd-1"},       // an issue ID
+		{"bd-99", "other"},     // missing issue
+		{"bd-2", "Has Spaces"}, // invalid
+		{"bd-2", "child.1"},    // looks hierarchical
+	} {
+		if err := store.SetIssueAlias(ctx, tc.issueID, tc.alias, "alice"); err == nil {
+			t.Errorf("SetIssueAlias(%s, %q) succeeded, want error", tc.issueID, tc.alias)
+		}
+	}
+
+	if err := store.RemoveIssueAlias(ctx, "login", "alice"); err != nil {
+		t.Fatalf("RemoveIssueAlias: %v", err)
+	}
+	if err := store.RemoveIssueAlias(ctx, "login", "alice"); err == nil {
+		t.Error("removing an unknown alias should fail")
+	}
+
+	// Renaming the issue keeps its aliases; deleting it frees them
+	renamed := *issue
+	renamed.ID = "bd-100"
+	if err := store.UpdateIssueID(ctx, "bd-1", "bd-100", &renamed, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if id, _ := store.ResolveIssueAlias(ctx, "auth-refactor"); id != "bd-100" {
+		t.Errorf("after rename, alias resolves to %q", id)
+	}
+	if err := store.DeleteIssue(ctx, "bd-100"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SetIssueAlias(ctx, "bd-2", "auth-refactor", "alice"); err != nil {
+		t.Errorf("reusing a deleted issue's alias: %v", err)
+	}
+}
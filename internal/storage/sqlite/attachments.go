@@ -0,0 +1,153 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// AddAttachment records an attachment on an issue. Adding the same URL to an
+// issue twice is a no-op, which keeps JSONL imports idempotent. ID and
+// CreatedAt are filled in on the passed attachment.
+func (s *SQLiteStorage) AddAttachment(ctx context.Context, attachment *types.Attachment) error {
+	var exists bool
+	err := s.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM issues WHERE id = ?)`, attachment.IssueID).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to check issue existence: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("issue %s not found", attachment.IssueID)
+	}
+
+	if attachment.CreatedAt.IsZero() {
+		attachment.CreatedAt = time.Now().UTC()
+	}
+	result, err := s.db.ExecContext(ctx, `
+		INSERT INTO attachments (issue_id, name, url, sha256, size, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (issue_id, url) DO NOTHING
+	`, attachment.IssueID, attachment.Name, attachment.URL, attachment.SHA256, attachment.Size,
+		attachment.CreatedBy, attachment.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert attachment: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return s.db.QueryRowContext(ctx, `
+			SELECT id FROM attachments WHERE issue_id = ? AND url = ?
+		`, attachment.IssueID, attachment.URL).Scan(&attachment.ID)
+	}
+	attachment.ID, err = result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get attachment ID: %w", err)
+	}
+
+	// Mark issue as dirty for JSONL export
+	if err := s.MarkIssueDirty(ctx, attachment.IssueID); err != nil {
+		return fmt.Errorf("failed to mark issue dirty: %w", err)
+	}
+	return nil
+}
+
+// GetAttachments retrieves all attachments for an issue, oldest first
+func (s *SQLiteStorage) GetAttachments(ctx context.Context, issueID string) ([]*types.Attachment, error) {
+	result, err := s.GetAttachmentsForIssues(ctx, []string{issueID})
+	if err != nil {
+		return nil, err
+	}
+	return result[issueID], nil
+}
+
+// GetAttachmentsForIssues fetches attachments for multiple issues in a single query
+// Returns a map of issue_id -> []*Attachment
+func (s *SQLiteStorage) GetAttachmentsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.Attachment, error) {
+	result := make(map[string][]*types.Attachment)
+	if len(issueIDs) == 0 {
+		return result, nil
+	}
+
+	placeholders := make([]interface{}, len(issueIDs))
+	for i, id := range issueIDs {
+		placeholders[i] = id
+	}
+	query := fmt.Sprintf(`
+		SELECT id, issue_id, name, url, sha256, size, created_by, created_at
+		FROM attachments
+		WHERE issue_id IN (%s)
+		ORDER BY issue_id, created_at ASC, id ASC
+	`, buildPlaceholders(len(issueIDs))) // #nosec G201 -- placeholders are generated internally
+
+	rows, err := s.db.QueryContext(ctx, query, placeholders...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query attachments: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		a := &types.Attachment{}
+		if err := rows.Scan(&a.ID, &a.IssueID, &a.Name, &a.URL, &a.SHA256, &a.Size, &a.CreatedBy, &a.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan attachment: %w", err)
+		}
+		result[a.IssueID] = append(result[a.IssueID], a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating attachments: %w", err)
+	}
+	return result, nil
+}
+
+// PendingAttachmentText returns up to limit attachments that have no
+// extracted text yet (or whose text was cleared), oldest first
+func (s *SQLiteStorage) PendingAttachmentText(ctx context.Context, limit int) ([]*types.Attachment, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT a.id, a.issue_id, a.name, a.url, a.sha256, a.size, a.created_by, a.created_at
+		FROM attachments a
+		LEFT JOIN attachment_text t ON t.attachment_id = a.id
+		WHERE t.attachment_id IS NULL
+		ORDER BY a.created_at ASC, a.id ASC
+		LIMIT ?
+	`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query unindexed attachments: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var result []*types.Attachment
+	for rows.Next() {
+		a := &types.Attachment{}
+		if err := rows.Scan(&a.ID, &a.IssueID, &a.Name, &a.URL, &a.SHA256, &a.Size, &a.CreatedBy, &a.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan attachment: %w", err)
+		}
+		result = append(result, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating attachments: %w", err)
+	}
+	return result, nil
+}
+
+// SetAttachmentText records the text extracted from an attachment, or why
+// none could be, replacing any earlier result. The text is a local search
+// cache: it isn't exported and doesn't mark the issue dirty.
+func (s *SQLiteStorage) SetAttachmentText(ctx context.Context, attachmentID int64, content, indexErr string) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO attachment_text (attachment_id, content, error, indexed_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT (attachment_id) DO UPDATE SET
+			content = excluded.content, error = excluded.error, indexed_at = excluded.indexed_at
+	`, attachmentID, content, indexErr, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("failed to store attachment text: %w", err)
+	}
+	return nil
+}
+
+// ClearAttachmentText forgets all extracted text so the indexer extracts it
+// again, e.g. after raising the size limit
+func (s *SQLiteStorage) ClearAttachmentText(ctx context.Context) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM attachment_text`); err != nil {
+		return fmt.Errorf("failed to clear attachment text: %w", err)
+	}
+	return nil
+}
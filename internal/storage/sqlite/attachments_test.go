@@ -0,0 +1,103 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestAddAttachment(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Crash", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeBug}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	a := &types.Attachment{IssueID: issue.ID, Name: "crash.log", URL: "s3://bucket/abc/crash.log", SHA256: "abc", Size: 3, CreatedBy: "alice"}
+	if err := store.AddAttachment(ctx, a); err != nil {
+		t.Fatalf("AddAttachment failed: %v", err)
+	}
+	if a.ID == 0 || a.CreatedAt.IsZero() {
+		t.Errorf("expected ID and CreatedAt to be set, got %+v", a)
+	}
+
+	// Same URL again (e.g. a re-import) keeps a single row
+	dup := &types.Attachment{IssueID: issue.ID, Name: "crash.log", URL: a.URL, SHA256: "abc", Size: 3}
+	if err := store.AddAttachment(ctx, dup); err != nil {
+		t.Fatalf("AddAttachment (duplicate) failed: %v", err)
+	}
+	if dup.ID != a.ID {
+		t.Errorf("duplicate got ID %d, want %d", dup.ID, a.ID)
+	}
+
+	list, err := store.GetAttachments(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetAttachments failed: %v", err)
+	}
+	if len(list) != 1 || list[0].URL != a.URL || list[0].CreatedBy != "alice" {
+		t.Errorf("unexpected attachments: %+v", list)
+	}
+
+	byIssue, err := store.GetAttachmentsForIssues(ctx, []string{issue.ID, "bd-missing"})
+	if err != nil {
+		t.Fatalf("GetAttachmentsForIssues failed: %v", err)
+	}
+	if len(byIssue[issue.ID]) != 1 || len(byIssue["bd-missing"]) != 0 {
+		t.Errorf("unexpected batch result: %+v", byIssue)
+	}
+
+	if err := store.AddAttachment(ctx, &types.Attachment{IssueID: "bd-missing", URL: "s3://b/k"}); err == nil {
+		t.Error("expected error attaching to a missing issue")
+	}
+}
+
+func TestAttachmentTextSearch(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Login fails", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeBug}
+	other := &types.Issue{Title: "Unrelated", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, i := range []*types.Issue{issue, other} {
+		if err := store.CreateIssue(ctx, i, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	a := &types.Attachment{IssueID: issue.ID, Name: "trace.txt", URL: "file:///tmp/trace.txt", SHA256: "abc", Size: 10}
+	if err := store.AddAttachment(ctx, a); err != nil {
+		t.Fatalf("AddAttachment failed: %v", err)
+	}
+
+	pending, err := store.PendingAttachmentText(ctx, 10)
+	if err != nil || len(pending) != 1 || pending[0].ID != a.ID {
+		t.Fatalf("PendingAttachmentText = %+v, %v", pending, err)
+	}
+	if err := store.SetAttachmentText(ctx, a.ID, "NullPointerException in SessionManager", ""); err != nil {
+		t.Fatalf("SetAttachmentText failed: %v", err)
+	}
+	if pending, _ := store.PendingAttachmentText(ctx, 10); len(pending) != 0 {
+		t.Errorf("indexed attachment still pending: %+v", pending)
+	}
+
+	found, err := store.SearchIssues(ctx, "SessionManager", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(found) != 1 || found[0].ID != issue.ID {
+		t.Errorf("search by attachment text = %v, want [%s]", found, issue.ID)
+	}
+
+	if err := store.ClearAttachmentText(ctx); err != nil {
+		t.Fatalf("ClearAttachmentText failed: %v", err)
+	}
+	if pending, _ := store.PendingAttachmentText(ctx, 10); len(pending) != 1 {
+		t.Errorf("cleared attachment should be pending again, got %+v", pending)
+	}
+	if found, _ := store.SearchIssues(ctx, "SessionManager", types.IssueFilter{}); len(found) != 0 {
+		t.Errorf("cleared text still searchable: %v", found)
+	}
+}
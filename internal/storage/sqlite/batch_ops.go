@@ -18,7 +18,7 @@ func validateBatchIssues(issues []*types.Issue) error {
 // validateBatchIssuesWithCustomStatuses validates all issues in a batch,
 // allowing custom statuses in addition to built-in ones (bd-1pj6).
 func validateBatchIssuesWithCustomStatuses(issues []*types.Issue, customStatuses []string) error {
-	now := time.Now()
+	now := time.Now().UTC()
 	for i, issue := range issues {
 		if issue == nil {
 			return fmt.Errorf("issue %d is nil", i)
@@ -184,9 +184,25 @@ func (s *SQLiteStorage) CreateIssuesWithFullOptions(ctx context.Context, issues
 	if err := validateBatchIssuesWithCustomStatuses(issues, customStatuses); err != nil {
 		return err
 	}
+	roster, err := assigneeRoster(ctx, s.GetConfig)
+	if err != nil {
+		return fmt.Errorf("failed to get assignee roster: %w", err)
+	}
+	limits, err := LoadContentLimits(ctx, s.GetConfig)
+	if err != nil {
+		return fmt.Errorf("failed to get content limits: %w", err)
+	}
+	for i, issue := range issues {
+		if err := validateAssigneeInRoster(issue.Assignee, roster); err != nil {
+			return fmt.Errorf("validation failed for issue %d: %w", i, err)
+		}
+		if err := limits.checkIssue(issue); err != nil {
+			return fmt.Errorf("validation failed for issue %d: %w", i, err)
+		}
+	}
 
 	// Phase 2: Acquire connection and start transaction
-	conn, err := s.db.Conn(ctx)
+	conn, err := s.writeConn(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to acquire connection: %w", err)
 	}
@@ -204,6 +220,10 @@ func (s *SQLiteStorage) CreateIssuesWithFullOptions(ctx context.Context, issues
 		}
 	}()
 
+	if err := tagOperation(ctx, conn); err != nil {
+		return err
+	}
+
 	// Phase 3: Generate IDs for issues that need them
 	if err := s.generateBatchIDs(ctx, conn, issues, actor, opts.OrphanHandling, opts.SkipPrefixValidation); err != nil {
 		return wrapDBError("generate batch IDs", err)
@@ -11,12 +11,17 @@
 // The blocked_issues_cache table stores issue_id values for all issues that are currently
 // blocked. An issue is blocked if:
 //   - It has a 'blocks' dependency on an open/in_progress/blocked issue (direct blocking)
+//   - It has a gate whose CI check hasn't passed (see gates.go)
+//   - It has a 'blocks' dependency on an issue in a linked workspace that isn't
+//     known to be done (see external_deps.go)
 //   - Its parent is blocked and it's connected via 'parent-child' dependency (transitive blocking)
 //
 // The cache is maintained automatically by invalidating and rebuilding whenever:
 //   - A 'blocks' or 'parent-child' dependency is added or removed
 //   - Any issue's status changes (affects whether it blocks others)
 //   - An issue is closed (closed issues don't block others)
+//   - A gate is added, removed, or changes state
+//   - The known status of an issue in a linked workspace changes
 //
 // Related and discovered-from dependencies do NOT trigger cache invalidation since they
 // don't affect blocking semantics.
@@ -112,6 +117,7 @@ func (s *SQLiteStorage) rebuildBlockedCache(ctx context.Context, exec execer) er
 	}
 
 	// Rebuild using the recursive CTE logic
+	// #nosec G202 - unresolvedExternalSQL only formats a fixed alias
 	query := `
 		INSERT INTO blocked_issues_cache (issue_id)
 		WITH RECURSIVE
@@ -122,6 +128,17 @@ func (s *SQLiteStorage) rebuildBlockedCache(ctx context.Context, exec execer) er
 		    JOIN issues blocker ON d.depends_on_id = blocker.id
 		    WHERE d.type = 'blocks'
 		      AND blocker.status IN ('open', 'in_progress', 'blocked')
+
+		    UNION
+
+		    -- Issues waiting on a CI check that hasn't passed
+		    SELECT issue_id FROM gates WHERE state != 'success'
+
+		    UNION
+
+		    -- Blockers in linked workspaces that aren't known to be done
+		    SELECT d.issue_id FROM dependencies d
+		    WHERE d.type = 'blocks' AND ` + unresolvedExternalSQL("d") + `
 		  ),
 
 		  -- Step 2: Propagate blockage to all descendants via parent-child
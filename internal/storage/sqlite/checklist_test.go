@@ -0,0 +1,96 @@
+package sqlite
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestChecklistReferences(t *testing.T) {
+	text := "Plan:\n" +
+		"- [ ] bd-12 wire up auth\n" +
+		"- [x] finish `bd-a3f8e9.1`, then bd-12 again\n" +
+		"* [ ] (bd-7) and a follow-up\n" +
+		"bd-99 outside a checklist\n" +
+		"```\n- [ ] bd-50 in a code block\n```\n" +
+		"- [] bd-51 malformed box\n"
+	got := storage.ChecklistReferences(text)
+	want := []string{"bd-12", "bd-a3f8e9.1", "bd-7", "follow-up"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ChecklistReferences = %v, want %v", got, want)
+	}
+}
+
+func TestSyncChecklistDependencies(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	newIssue := func(title string) *types.Issue {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue(%s): %v", title, err)
+		}
+		return issue
+	}
+	parent := newIssue("Parent")
+	a := newIssue("A")
+	b := newIssue("B")
+	c := newIssue("C")
+
+	// c is already a hand-made blocker of parent; the checklist must not
+	// duplicate or later remove it
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: parent.ID, DependsOnID: c.ID, Type: types.DepBlocks}, "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	description := "- [ ] " + a.ID + " first\n- [ ] " + b.ID + "\n- [ ] " + c.ID + "\n- [ ] bd-nope\n- [ ] " + parent.ID
+	result, err := storage.SyncChecklistDependencies(ctx, store, parent.ID, description, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{a.ID, b.ID}; !reflect.DeepEqual(result.Added, want) || len(result.Removed) != 0 {
+		t.Fatalf("first sync = %+v, want added %v", result, want)
+	}
+
+	deps, err := store.GetDependencyRecords(ctx, parent.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checklistDeps := 0
+	for _, dep := range deps {
+		if storage.IsChecklistDependency(dep) {
+			checklistDeps++
+		}
+	}
+	if checklistDeps != 2 || len(deps) != 3 {
+		t.Errorf("deps = %d (%d from checklist), want 3 (2)", len(deps), checklistDeps)
+	}
+
+	// Syncing again is a no-op
+	if result, err = storage.SyncChecklistDependencies(ctx, store, parent.ID, description, "test"); err != nil || result.Changed() {
+		t.Errorf("resync = %+v, %v; want no change", result, err)
+	}
+
+	// Dropping b and c from the checklist removes only b's checklist dependency
+	result, err = storage.SyncChecklistDependencies(ctx, store, parent.ID, "- [x] "+a.ID, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(result.Removed, []string{b.ID}) || len(result.Added) != 0 {
+		t.Errorf("after edit = %+v, want removed [%s]", result, b.ID)
+	}
+	deps, _ = store.GetDependencyRecords(ctx, parent.ID)
+	if len(deps) != 2 {
+		t.Errorf("deps after edit = %d, want 2 (a from checklist, c by hand)", len(deps))
+	}
+
+	// A reference back to an issue that already depends on this one is skipped
+	result, err = storage.SyncChecklistDependencies(ctx, store, c.ID, "- [ ] "+parent.ID, "test")
+	if err != nil || result.Changed() {
+		t.Errorf("reverse reference = %+v, %v; want no change", result, err)
+	}
+}
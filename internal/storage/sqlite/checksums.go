@@ -0,0 +1,98 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// An issue's content_hash doubles as its checksum: every write that changes
+// a hashed field stores the new hash, so a row whose fields no longer hash
+// to the stored value was changed outside bd or only partly written.
+
+// queryExecer is an execer that can also read, for writes that recompute
+// derived columns inside the caller's transaction
+type queryExecer interface {
+	execer
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// contentHashQuery selects the fields ComputeContentHash covers
+const contentHashQuery = `
+	SELECT id, content_hash, title, description, design, acceptance_criteria, notes,
+	       status, priority, issue_type, assignee, external_ref, complexity, source, timebox_minutes
+	FROM issues`
+
+// ChecksumMismatch is an issue whose fields don't hash to its stored checksum
+type ChecksumMismatch struct {
+	IssueID  string `json:"issue_id"`
+	Stored   string `json:"stored"`
+	Computed string `json:"computed"`
+}
+
+func scanContentHashRow(row interface{ Scan(...interface{}) error }) (*types.Issue, string, error) {
+	var issue types.Issue
+	var stored, assignee, externalRef, complexity, source sql.NullString
+	var timeboxMinutes sql.NullInt64
+	err := row.Scan(&issue.ID, &stored, &issue.Title, &issue.Description, &issue.Design,
+		&issue.AcceptanceCriteria, &issue.Notes, &issue.Status, &issue.Priority,
+		&issue.IssueType, &assignee, &externalRef, &complexity, &source, &timeboxMinutes)
+	if err != nil {
+		return nil, "", err
+	}
+	issue.Assignee = assignee.String
+	issue.Complexity = types.Complexity(complexity.String)
+	issue.Source = source.String
+	if timeboxMinutes.Valid {
+		mins := int(timeboxMinutes.Int64)
+		issue.TimeboxMinutes = &mins
+	}
+	if externalRef.Valid {
+		issue.ExternalRef = &externalRef.String
+	}
+	return &issue, stored.String, nil
+}
+
+// refreshContentHash recomputes the checksum of one issue from its current
+// row. Writes that set hashed fields with raw SQL (closing, tombstoning,
+// renaming) call it in the same transaction.
+func refreshContentHash(ctx context.Context, q queryExecer, id string) error {
+	issue, _, err := scanContentHashRow(q.QueryRowContext(ctx, contentHashQuery+` WHERE id = ?`, id))
+	if err != nil {
+		return fmt.Errorf("failed to read %s for checksum: %w", id, err)
+	}
+	if _, err := q.ExecContext(ctx, `UPDATE issues SET content_hash = ? WHERE id = ?`, issue.ComputeContentHash(), id); err != nil {
+		return fmt.Errorf("failed to update checksum of %s: %w", id, err)
+	}
+	return nil
+}
+
+// VerifyContentHashes recomputes every issue's checksum and returns those
+// that don't match the stored value
+func (s *SQLiteStorage) VerifyContentHashes(ctx context.Context) ([]ChecksumMismatch, error) {
+	rows, err := s.db.QueryContext(ctx, contentHashQuery+` ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read issues: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var mismatches []ChecksumMismatch
+	for rows.Next() {
+		issue, stored, err := scanContentHashRow(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan issue: %w", err)
+		}
+		if computed := issue.ComputeContentHash(); computed != stored {
+			mismatches = append(mismatches, ChecksumMismatch{IssueID: issue.ID, Stored: stored, Computed: computed})
+		}
+	}
+	return mismatches, rows.Err()
+}
+
+// RefreshContentHash accepts an issue's current fields as correct and
+// stores their checksum
+func (s *SQLiteStorage) RefreshContentHash(ctx context.Context, id string) error {
+	return refreshContentHash(ctx, s.db, id)
+}
@@ -0,0 +1,66 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestVerifyContentHashes(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Checksummed", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatal(err)
+	}
+	other := &types.Issue{Title: "Renamed later", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, other, "test"); err != nil {
+		t.Fatal(err)
+	}
+	// Fields hashed only when set are checked too
+	routed := &types.Issue{Title: "Routed", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeBug,
+		Complexity: types.ComplexityTrivial, Source: "sentry"}
+	if err := store.CreateIssue(ctx, routed, "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Writes that set hashed columns with raw SQL keep the checksum current
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"title": "Checksummed, edited"}, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.CloseIssue(ctx, issue.ID, "done", "test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.UpdateIssueID(ctx, other.ID, "bd-renamed", other, "test"); err != nil {
+		t.Fatal(err)
+	}
+	mismatches, err := store.VerifyContentHashes(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mismatches) != 0 {
+		t.Fatalf("mismatches after bd writes = %+v", mismatches)
+	}
+
+	// An edit behind bd's back is caught
+	if _, err := store.db.ExecContext(ctx, `UPDATE issues SET title = 'tampered' WHERE id = ?`, issue.ID); err != nil {
+		t.Fatal(err)
+	}
+	mismatches, err = store.VerifyContentHashes(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mismatches) != 1 || mismatches[0].IssueID != issue.ID || mismatches[0].Stored == mismatches[0].Computed {
+		t.Fatalf("mismatches after tampering = %+v", mismatches)
+	}
+
+	if err := store.RefreshContentHash(ctx, issue.ID); err != nil {
+		t.Fatal(err)
+	}
+	if mismatches, err = store.VerifyContentHashes(ctx); err != nil || len(mismatches) != 0 {
+		t.Errorf("mismatches after refresh = %+v, %v", mismatches, err)
+	}
+}
@@ -0,0 +1,86 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ClaimReadyWork atomically picks the top ready issue and claims it for actor
+// (status in_progress, assignee actor). Selection and update run in a single
+// IMMEDIATE transaction, so concurrent claimers never receive the same issue.
+// Only open issues that are unassigned or already assigned to actor are
+// candidates. Returns nil if there is nothing to claim.
+func (s *SQLiteStorage) ClaimReadyWork(ctx context.Context, filter types.WorkFilter, actor string) (*types.Issue, error) {
+	return s.ClaimReadyWorkWithLease(ctx, filter, actor, 0)
+}
+
+// ClaimReadyWorkWithLease is ClaimReadyWork recording a lease on the claimed
+// issue that expires after ttl (0 for no expiry). Expired leases are
+// released first, so work abandoned by a crashed agent is claimable again.
+func (s *SQLiteStorage) ClaimReadyWorkWithLease(ctx context.Context, filter types.WorkFilter, actor string, ttl time.Duration) (*types.Issue, error) {
+	if actor == "" {
+		return nil, fmt.Errorf("actor is required to claim work")
+	}
+
+	now := time.Now().UTC()
+	var claimed *types.Issue
+	err := s.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		t := tx.(*sqliteTxStorage)
+		if _, err := t.expireLeases(ctx, now); err != nil {
+			return err
+		}
+		id, err := t.nextClaimable(ctx, filter, actor)
+		if err != nil || id == "" {
+			return err
+		}
+		issue, err := t.GetIssue(ctx, id)
+		if err != nil {
+			return err
+		}
+		lease := &types.Lease{IssueID: id, Holder: actor, ClaimedAt: now}
+		if ttl > 0 {
+			expires := now.Add(ttl)
+			lease.ExpiresAt = &expires
+		}
+		if err := t.takeLease(ctx, issue, lease); err != nil {
+			return err
+		}
+		claimed, err = t.GetIssue(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim ready work: %w", err)
+	}
+	return claimed, nil
+}
+
+// nextClaimable returns the ID of the first ready issue actor may claim, in
+// the filter's sort order, or "" if there is none
+func (t *sqliteTxStorage) nextClaimable(ctx context.Context, filter types.WorkFilter, actor string) (string, error) {
+	filter.Status = types.StatusOpen
+	filter.Limit = 0
+	query, args := buildReadyWorkQuery(filter, "i.id, i.assignee")
+
+	rows, err := t.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to get ready work: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var id string
+		var assignee sql.NullString
+		if err := rows.Scan(&id, &assignee); err != nil {
+			return "", fmt.Errorf("failed to scan ready work: %w", err)
+		}
+		if assignee.String == "" || assignee.String == actor {
+			return id, nil
+		}
+	}
+	return "", rows.Err()
+}
@@ -0,0 +1,131 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestClaimReadyWork(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	blocker := &types.Issue{Title: "Blocker", Status: types.StatusOpen, Priority: 0, IssueType: types.TypeTask}
+	blocked := &types.Issue{Title: "Blocked", Status: types.StatusOpen, Priority: 0, IssueType: types.TypeTask}
+	theirs := &types.Issue{Title: "Theirs", Status: types.StatusOpen, Priority: 0, IssueType: types.TypeTask, Assignee: "bob"}
+	low := &types.Issue{Title: "Low", Status: types.StatusOpen, Priority: 3, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{blocker, blocked, theirs, low} {
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: blocked.ID, DependsOnID: blocker.ID, Type: types.DepBlocks}, "test-user"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+
+	filter := types.WorkFilter{SortPolicy: types.SortPolicyPriority}
+	var got []string
+	for {
+		claimed, err := store.ClaimReadyWork(ctx, filter, "alice")
+		if err != nil {
+			t.Fatalf("ClaimReadyWork failed: %v", err)
+		}
+		if claimed == nil {
+			break
+		}
+		if claimed.Status != types.StatusInProgress || claimed.Assignee != "alice" {
+			t.Errorf("claimed %s has status=%s assignee=%q", claimed.ID, claimed.Status, claimed.Assignee)
+		}
+		got = append(got, claimed.ID)
+	}
+
+	// The blocked issue stays blocked while its blocker is in progress, and
+	// bob's issue is never taken
+	want := []string{blocker.ID, low.ID}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("claimed %v, want %v", got, want)
+	}
+
+	events, err := store.GetEvents(ctx, blocker.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	foundClaim := false
+	for _, e := range events {
+		if e.Actor == "alice" {
+			foundClaim = true
+		}
+	}
+	if !foundClaim {
+		t.Error("expected a claim event by alice")
+	}
+
+	if _, err := store.ClaimReadyWork(ctx, filter, ""); err == nil {
+		t.Error("expected error for empty actor")
+	}
+}
+
+func TestClaimReadyWorkConcurrent(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	const numIssues = 10
+	const numAgents = 8
+	for i := 0; i < numIssues; i++ {
+		issue := &types.Issue{Title: fmt.Sprintf("Task %d", i), Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	var mu sync.Mutex
+	claimedBy := make(map[string]string)
+	var wg sync.WaitGroup
+	errs := make(chan error, numAgents)
+	for a := 0; a < numAgents; a++ {
+		agent := fmt.Sprintf("agent:%d", a)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				claimed, err := store.ClaimReadyWork(ctx, types.WorkFilter{}, agent)
+				if err != nil {
+					errs <- err
+					return
+				}
+				if claimed == nil {
+					return
+				}
+				mu.Lock()
+				if prev, ok := claimedBy[claimed.ID]; ok {
+					errs <- fmt.Errorf("%s claimed by both %s and %s", claimed.ID, prev, agent)
+				}
+				claimedBy[claimed.ID] = agent
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	if len(claimedBy) != numIssues {
+		t.Errorf("claimed %d issues, want %d", len(claimedBy), numIssues)
+	}
+	for id, agent := range claimedBy {
+		issue, err := store.GetIssue(ctx, id)
+		if err != nil {
+			t.Fatalf("GetIssue failed: %v", err)
+		}
+		if issue.Assignee != agent {
+			t.Errorf("%s assigned to %q, but claimed by %s", id, issue.Assignee, agent)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestCloseReasonFilterAndStats(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	if err := store.SetConfig(ctx, CloseReasonsConfigKey, "moved_upstream"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+
+	reasons := []string{"wontfix: out of scope", "wontfix", "fixed", "moved_upstream", "Done", "wontfixable"}
+	ids := make([]string, len(reasons))
+	for i, reason := range reasons {
+		issue := &types.Issue{Title: reason, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		if err := store.CloseIssue(ctx, issue.ID, reason, "test-user"); err != nil {
+			t.Fatalf("CloseIssue failed: %v", err)
+		}
+		ids[i] = issue.ID
+	}
+
+	found, err := store.SearchIssues(ctx, "", types.IssueFilter{CloseReason: types.CloseReasonWontfix})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	got := map[string]bool{}
+	for _, issue := range found {
+		got[issue.ID] = true
+	}
+	if len(got) != 2 || !got[ids[0]] || !got[ids[1]] {
+		t.Errorf("expected %s and %s for reason wontfix, got %v", ids[0], ids[1], got)
+	}
+
+	stats, err := store.GetStatistics(ctx)
+	if err != nil {
+		t.Fatalf("GetStatistics failed: %v", err)
+	}
+	want := map[string]int{"wontfix": 2, "fixed": 1, "moved_upstream": 1, types.CloseReasonOther: 2}
+	for reason, count := range want {
+		if stats.ClosedByReason[reason] != count {
+			t.Errorf("ClosedByReason[%q] = %d, want %d", reason, stats.ClosedByReason[reason], count)
+		}
+	}
+	if len(stats.ClosedByReason) != len(want) {
+		t.Errorf("unexpected reasons in %v", stats.ClosedByReason)
+	}
+}
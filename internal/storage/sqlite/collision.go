@@ -139,6 +139,15 @@ func compareIssues(existing, incoming *types.Issue) []string {
 		(existing.ExternalRef != nil && incoming.ExternalRef != nil && *existing.ExternalRef != *incoming.ExternalRef) {
 		conflicts = append(conflicts, "external_ref")
 	}
+	if existing.Complexity != incoming.Complexity {
+		conflicts = append(conflicts, "complexity")
+	}
+	if existing.Source != incoming.Source {
+		conflicts = append(conflicts, "source")
+	}
+	if !equalIntPtr(existing.TimeboxMinutes, incoming.TimeboxMinutes) {
+		conflicts = append(conflicts, "timebox_minutes")
+	}
 
 	return conflicts
 }
@@ -172,5 +181,21 @@ func hashIssueContent(issue *types.Issue) string {
 	if issue.ExternalRef != nil {
 		_, _ = fmt.Fprintf(h, "external_ref:%s\n", *issue.ExternalRef)
 	}
+	if issue.Complexity != "" {
+		_, _ = fmt.Fprintf(h, "complexity:%s\n", issue.Complexity)
+	}
+	if issue.Source != "" {
+		_, _ = fmt.Fprintf(h, "source:%s\n", issue.Source)
+	}
+	if issue.TimeboxMinutes != nil {
+		_, _ = fmt.Fprintf(h, "timebox:%d\n", *issue.TimeboxMinutes)
+	}
 	return fmt.Sprintf("%x", h.Sum(nil))
 }
+
+func equalIntPtr(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
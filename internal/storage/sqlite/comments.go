@@ -2,13 +2,39 @@ package sqlite
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/steveyegge/beads/internal/types"
 )
 
-// AddIssueComment adds a comment to an issue
+// AddIssueComment adds a comment to an issue and records a commented event
+// so watchers and event consumers see it
 func (s *SQLiteStorage) AddIssueComment(ctx context.Context, issueID, author, text string) (*types.Comment, error) {
+	comment, err := s.insertIssueComment(ctx, issueID, author, text, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.recordCommentEvent(ctx, issueID, types.EventCommented, author, text); err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+// ImportIssueComment adds a comment keeping its original creation time, so
+// comments survive a JSONL round trip unchanged. A zero createdAt means now.
+func (s *SQLiteStorage) ImportIssueComment(ctx context.Context, issueID, author, text string, createdAt time.Time) (*types.Comment, error) {
+	return s.insertIssueComment(ctx, issueID, author, text, createdAt)
+}
+
+func (s *SQLiteStorage) insertIssueComment(ctx context.Context, issueID, author, text string, createdAt time.Time) (*types.Comment, error) {
+	if err := validateUTF8("comment author", author); err != nil {
+		return nil, err
+	}
+	if err := validateUTF8("comment text", text); err != nil {
+		return nil, err
+	}
 	// Verify issue exists
 	var exists bool
 	err := s.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM issues WHERE id = ?)`, issueID).Scan(&exists)
@@ -20,10 +46,14 @@ func (s *SQLiteStorage) AddIssueComment(ctx context.Context, issueID, author, te
 	}
 
 	// Insert comment
+	var createdAtArg interface{}
+	if !createdAt.IsZero() {
+		createdAtArg = createdAt.UTC()
+	}
 	result, err := s.db.ExecContext(ctx, `
 		INSERT INTO comments (issue_id, author, text, created_at)
-		VALUES (?, ?, ?, CURRENT_TIMESTAMP)
-	`, issueID, author, text)
+		VALUES (?, ?, ?, COALESCE(?, CURRENT_TIMESTAMP))
+	`, issueID, author, text, createdAtArg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to insert comment: %w", err)
 	}
@@ -35,13 +65,9 @@ func (s *SQLiteStorage) AddIssueComment(ctx context.Context, issueID, author, te
 	}
 
 	// Fetch the complete comment
-	comment := &types.Comment{}
-	err = s.db.QueryRowContext(ctx, `
-		SELECT id, issue_id, author, text, created_at
-		FROM comments WHERE id = ?
-	`, commentID).Scan(&comment.ID, &comment.IssueID, &comment.Author, &comment.Text, &comment.CreatedAt)
+	comment, err := s.getIssueComment(ctx, commentID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch comment: %w", err)
+		return nil, err
 	}
 
 	// Mark issue as dirty for JSONL export
@@ -55,7 +81,7 @@ func (s *SQLiteStorage) AddIssueComment(ctx context.Context, issueID, author, te
 // GetIssueComments retrieves all comments for an issue
 func (s *SQLiteStorage) GetIssueComments(ctx context.Context, issueID string) ([]*types.Comment, error) {
 	rows, err := s.db.QueryContext(ctx, `
-		SELECT id, issue_id, author, text, created_at
+		SELECT id, issue_id, author, text, created_at, updated_at
 		FROM comments
 		WHERE issue_id = ?
 		ORDER BY created_at ASC
@@ -67,8 +93,7 @@ func (s *SQLiteStorage) GetIssueComments(ctx context.Context, issueID string) ([
 
 	var comments []*types.Comment
 	for rows.Next() {
-		comment := &types.Comment{}
-		err := rows.Scan(&comment.ID, &comment.IssueID, &comment.Author, &comment.Text, &comment.CreatedAt)
+		comment, err := scanComment(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan comment: %w", err)
 		}
@@ -96,7 +121,7 @@ func (s *SQLiteStorage) GetCommentsForIssues(ctx context.Context, issueIDs []str
 	}
 
 	query := fmt.Sprintf(`
-		SELECT id, issue_id, author, text, created_at
+		SELECT id, issue_id, author, text, created_at, updated_at
 		FROM comments
 		WHERE issue_id IN (%s)
 		ORDER BY issue_id, created_at ASC
@@ -110,8 +135,7 @@ func (s *SQLiteStorage) GetCommentsForIssues(ctx context.Context, issueIDs []str
 
 	result := make(map[string][]*types.Comment)
 	for rows.Next() {
-		comment := &types.Comment{}
-		err := rows.Scan(&comment.ID, &comment.IssueID, &comment.Author, &comment.Text, &comment.CreatedAt)
+		comment, err := scanComment(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan comment: %w", err)
 		}
@@ -124,3 +148,97 @@ func (s *SQLiteStorage) GetCommentsForIssues(ctx context.Context, issueIDs []str
 
 	return result, nil
 }
+
+// UpdateIssueComment replaces a comment's text and records a comment_edited
+// event. Returns ErrNotFound if there is no such comment.
+func (s *SQLiteStorage) UpdateIssueComment(ctx context.Context, commentID int64, text, actor string) (*types.Comment, error) {
+	return s.editIssueComment(ctx, commentID, text, actor, time.Now().UTC())
+}
+
+// ImportIssueCommentEdit applies an edit made in another clone, keeping its
+// edit time so the newer text wins on later imports. No event is recorded.
+func (s *SQLiteStorage) ImportIssueCommentEdit(ctx context.Context, commentID int64, text string, updatedAt time.Time) (*types.Comment, error) {
+	return s.editIssueComment(ctx, commentID, text, "", updatedAt.UTC())
+}
+
+func (s *SQLiteStorage) editIssueComment(ctx context.Context, commentID int64, text, actor string, updatedAt time.Time) (*types.Comment, error) {
+	if err := validateUTF8("comment text", text); err != nil {
+		return nil, err
+	}
+	comment, err := s.getIssueComment(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.db.ExecContext(ctx, `UPDATE comments SET text = ?, updated_at = ? WHERE id = ?`, text, updatedAt, commentID); err != nil {
+		return nil, fmt.Errorf("failed to update comment: %w", err)
+	}
+	if actor != "" {
+		if err := s.recordCommentEvent(ctx, comment.IssueID, types.EventCommentEdited, actor, text); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.MarkIssueDirty(ctx, comment.IssueID); err != nil {
+		return nil, fmt.Errorf("failed to mark issue dirty: %w", err)
+	}
+	comment.Text = text
+	comment.UpdatedAt = &updatedAt
+	return comment, nil
+}
+
+// DeleteIssueComment removes a comment, records a comment_deleted event and
+// returns the deleted comment. Returns ErrNotFound if there is no such
+// comment.
+func (s *SQLiteStorage) DeleteIssueComment(ctx context.Context, commentID int64, actor string) (*types.Comment, error) {
+	comment, err := s.getIssueComment(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM comments WHERE id = ?`, commentID); err != nil {
+		return nil, fmt.Errorf("failed to delete comment: %w", err)
+	}
+	if err := s.recordCommentEvent(ctx, comment.IssueID, types.EventCommentDeleted, actor, comment.Text); err != nil {
+		return nil, err
+	}
+	if err := s.MarkIssueDirty(ctx, comment.IssueID); err != nil {
+		return nil, fmt.Errorf("failed to mark issue dirty: %w", err)
+	}
+	return comment, nil
+}
+
+func (s *SQLiteStorage) recordCommentEvent(ctx context.Context, issueID string, eventType types.EventType, actor, text string) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, comment)
+		VALUES (?, ?, ?, ?)
+	`, issueID, eventType, actor, text)
+	if err != nil {
+		return fmt.Errorf("failed to record %s event: %w", eventType, err)
+	}
+	return nil
+}
+
+func (s *SQLiteStorage) getIssueComment(ctx context.Context, commentID int64) (*types.Comment, error) {
+	row := s.db.QueryRowContext(ctx, `
+		SELECT id, issue_id, author, text, created_at, updated_at
+		FROM comments WHERE id = ?
+	`, commentID)
+	comment, err := scanComment(row)
+	if err != nil {
+		return nil, wrapDBErrorf(err, "get comment %d", commentID)
+	}
+	return comment, nil
+}
+
+// scanComment reads a comment selected as
+// id, issue_id, author, text, created_at, updated_at
+func scanComment(row interface{ Scan(dest ...interface{}) error }) (*types.Comment, error) {
+	comment := &types.Comment{}
+	var updatedAt sql.NullTime
+	if err := row.Scan(&comment.ID, &comment.IssueID, &comment.Author, &comment.Text, &comment.CreatedAt, &updatedAt); err != nil {
+		return nil, err
+	}
+	if updatedAt.Valid {
+		t := updatedAt.Time
+		comment.UpdatedAt = &t
+	}
+	return comment, nil
+}
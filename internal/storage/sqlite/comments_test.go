@@ -367,3 +367,67 @@ func TestGetIssueCommentsMultipleIssues(t *testing.T) {
 		}
 	}
 }
+
+func TestUpdateAndDeleteIssueComment(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Discussed", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	first, err := store.AddIssueComment(ctx, issue.ID, "alice", "Teh fix is in")
+	if err != nil {
+		t.Fatalf("AddIssueComment failed: %v", err)
+	}
+	second, err := store.AddIssueComment(ctx, issue.ID, "bob", "Thanks")
+	if err != nil {
+		t.Fatalf("AddIssueComment failed: %v", err)
+	}
+	if first.UpdatedAt != nil {
+		t.Errorf("new comment should have no updated_at, got %v", first.UpdatedAt)
+	}
+
+	edited, err := store.UpdateIssueComment(ctx, first.ID, "The fix is in", "alice")
+	if err != nil {
+		t.Fatalf("UpdateIssueComment failed: %v", err)
+	}
+	if edited.Text != "The fix is in" || edited.UpdatedAt == nil || !edited.CreatedAt.Equal(first.CreatedAt) {
+		t.Errorf("edited comment = %+v, want new text, updated_at set and created_at kept", edited)
+	}
+
+	deleted, err := store.DeleteIssueComment(ctx, second.ID, "bob")
+	if err != nil {
+		t.Fatalf("DeleteIssueComment failed: %v", err)
+	}
+	if deleted.Text != "Thanks" {
+		t.Errorf("DeleteIssueComment returned %q, want the deleted comment", deleted.Text)
+	}
+	if _, err := store.DeleteIssueComment(ctx, second.ID, "bob"); !IsNotFound(err) {
+		t.Errorf("deleting twice: expected ErrNotFound, got %v", err)
+	}
+	if _, err := store.UpdateIssueComment(ctx, 9999, "x", "bob"); !IsNotFound(err) {
+		t.Errorf("editing a missing comment: expected ErrNotFound, got %v", err)
+	}
+
+	comments, err := store.GetIssueComments(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssueComments failed: %v", err)
+	}
+	if len(comments) != 1 || comments[0].Text != "The fix is in" || comments[0].UpdatedAt == nil {
+		t.Fatalf("comments = %+v, want only the edited one", comments)
+	}
+
+	events, err := store.GetEvents(ctx, issue.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	seen := make(map[types.EventType]bool)
+	for _, e := range events {
+		seen[e.EventType] = true
+	}
+	if !seen[types.EventCommentEdited] || !seen[types.EventCommentDeleted] {
+		t.Errorf("expected comment_edited and comment_deleted events, got %v", seen)
+	}
+}
@@ -138,8 +138,12 @@ func (s *SQLiteStorage) GetTier2Candidates(ctx context.Context) ([]*CompactionCa
 
 	query := `
 		WITH event_counts AS (
-		  SELECT issue_id, COUNT(*) as event_count
-		  FROM events
+		  SELECT issue_id, SUM(n) as event_count
+		  FROM (
+		    SELECT issue_id, COUNT(*) as n FROM events GROUP BY issue_id
+		    UNION ALL
+		    SELECT issue_id, event_count FROM event_summaries
+		  )
 		  GROUP BY issue_id
 		)
 		SELECT 
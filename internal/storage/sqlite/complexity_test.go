@@ -0,0 +1,68 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestComplexityFilterAndPersistence(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	complexities := []types.Complexity{types.ComplexityTrivial, types.ComplexityComplex, types.ComplexityResearch, ""}
+	ids := make([]string, len(complexities))
+	for i, c := range complexities {
+		issue := &types.Issue{Title: "issue " + string(c), Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, Complexity: c}
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		ids[i] = issue.ID
+	}
+
+	if err := store.UpdateIssue(ctx, ids[3], map[string]interface{}{"complexity": "standard"}, "test-user"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, ids[3], map[string]interface{}{"complexity": "huge"}, "test-user"); err == nil {
+		t.Error("expected an invalid complexity to be rejected")
+	}
+
+	found, err := store.SearchIssues(ctx, "", types.IssueFilter{Complexity: []types.Complexity{types.ComplexityComplex, types.ComplexityResearch}})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(found) != 2 {
+		t.Errorf("expected 2 complex or research issues, got %d", len(found))
+	}
+
+	ready, err := store.GetReadyWork(ctx, types.WorkFilter{Status: types.StatusOpen, Complexity: []types.Complexity{types.ComplexityTrivial}})
+	if err != nil {
+		t.Fatalf("GetReadyWork failed: %v", err)
+	}
+	if len(ready) != 1 || ready[0].ID != ids[0] {
+		t.Errorf("expected only %s as trivial ready work, got %v", ids[0], ready)
+	}
+
+	// Reopening runs the migrations again, which rebuild the issues table
+	path := store.Path()
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	store, err = New(ctx, path)
+	if err != nil {
+		t.Fatalf("failed to reopen storage: %v", err)
+	}
+	defer store.Close()
+
+	for i, want := range []types.Complexity{types.ComplexityTrivial, types.ComplexityComplex, types.ComplexityResearch, types.ComplexityStandard} {
+		issue, err := store.GetIssue(ctx, ids[i])
+		if err != nil {
+			t.Fatalf("GetIssue failed: %v", err)
+		}
+		if issue.Complexity != want {
+			t.Errorf("%s complexity = %q after reopen, want %q", ids[i], issue.Complexity, want)
+		}
+	}
+}
@@ -112,6 +112,24 @@ func (s *SQLiteStorage) GetCustomStatuses(ctx context.Context) ([]string, error)
 	return parseCustomStatuses(value), nil
 }
 
+// Close reason taxonomy config keys
+const (
+	// CloseReasonsConfigKey lists custom close reasons beyond the built-in ones
+	CloseReasonsConfigKey = "close.reasons"
+	// CloseRequireReasonConfigKey makes 'bd close' require a reason from the taxonomy
+	CloseRequireReasonConfigKey = "close.require_reason"
+)
+
+// GetCustomCloseReasons retrieves the custom close reasons from config.
+// Returns an empty slice if none are configured.
+func (s *SQLiteStorage) GetCustomCloseReasons(ctx context.Context) ([]string, error) {
+	value, err := s.GetConfig(ctx, CloseReasonsConfigKey)
+	if err != nil {
+		return nil, err
+	}
+	return parseCustomStatuses(value), nil
+}
+
 // parseCustomStatuses splits a comma-separated string into a slice of trimmed status names.
 // Empty entries are filtered out.
 func parseCustomStatuses(value string) []string {
@@ -0,0 +1,311 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Timestamp problem kinds reported by FindTimestampProblems
+const (
+	TimestampUnreadable    = "unreadable"     // Not a date; reading the issue fails
+	TimestampOutOfRange    = "out_of_range"   // Before 1970 or in the future
+	TimestampBeforeCreated = "before_created" // updated_at earlier than created_at
+	TimestampMissing       = "missing"        // Closed without closed_at
+	TimestampNotClosed     = "not_closed"     // closed_at on an issue that isn't closed
+)
+
+// TimestampProblem is an issue timestamp that is corrupt or inconsistent
+type TimestampProblem struct {
+	IssueID string `json:"issue_id"`
+	Column  string `json:"column"`
+	Kind    string `json:"kind"`
+	Value   string `json:"value,omitempty"`
+}
+
+// timestampSlack is how far in the future a timestamp may be before it
+// counts as corrupt, allowing for clock skew between machines
+const timestampSlack = 24 * time.Hour
+
+// unixEpochJulianDay is 1970-01-01 as a Julian day number
+const unixEpochJulianDay = 2440587.5
+
+// timestampQuery reads each issue's timestamps as text with SQLite's reading
+// of them as Julian days (NULL when SQLite can't read one either), and the
+// earliest readable event, which is when the issue was really created
+const timestampQuery = `
+	SELECT i.id, i.status,
+	       CAST(i.created_at AS TEXT), julianday(i.created_at),
+	       CAST(i.updated_at AS TEXT), julianday(i.updated_at),
+	       CAST(i.closed_at AS TEXT), julianday(i.closed_at),
+	       (SELECT CAST(e.created_at AS TEXT) FROM events e
+	        WHERE e.issue_id = i.id AND julianday(e.created_at) >= ?
+	        ORDER BY julianday(e.created_at) LIMIT 1)
+	FROM issues i ORDER BY i.id`
+
+// issueTimestamps is one row of timestampQuery
+type issueTimestamps struct {
+	id, status                     string
+	created, updated, closed       sql.NullString
+	createdJD, updatedJD, closedJD sql.NullFloat64
+	firstEvent                     sql.NullString
+}
+
+// FindTimestampProblems reports issue timestamps that can't be read, are
+// implausible, or contradict the issue's status. It reads the columns
+// without scanning them into issues, so it works when that would fail.
+func FindTimestampProblems(ctx context.Context, db *sql.DB) ([]TimestampProblem, error) {
+	rows, err := readIssueTimestamps(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	latest := julianDay(time.Now().Add(timestampSlack))
+	var problems []TimestampProblem
+	for _, r := range rows {
+		problems = append(problems, r.problems(latest)...)
+	}
+	return problems, nil
+}
+
+// RepairTimestamps fixes the timestamps FindTimestampProblems reports from
+// the issue's other timestamps and its event history: created_at from the
+// first event, updated_at from closed_at or created_at, closed_at from
+// updated_at, and closed_at cleared on issues that aren't closed. Issues
+// without a readable timestamp to take one from are left alone. Repaired
+// issues are marked dirty so the next export writes them. It returns the
+// number of issues changed.
+func RepairTimestamps(ctx context.Context, db *sql.DB) (int, error) {
+	rows, err := readIssueTimestamps(ctx, db)
+	if err != nil {
+		return 0, err
+	}
+	latest := julianDay(time.Now().Add(timestampSlack))
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	repaired := 0
+	for _, r := range rows {
+		updates := r.repairs(latest)
+		if len(updates) == 0 {
+			continue
+		}
+		for _, column := range []string{"created_at", "updated_at", "closed_at"} {
+			value, ok := updates[column]
+			if !ok {
+				continue
+			}
+			// #nosec G201 - column is one of the fixed names above
+			if _, err := tx.ExecContext(ctx, fmt.Sprintf(`UPDATE issues SET %s = ? WHERE id = ?`, column), value, r.id); err != nil {
+				return 0, fmt.Errorf("failed to repair %s of %s: %w", column, r.id, err)
+			}
+		}
+		if _, err := tx.ExecContext(ctx, `INSERT OR IGNORE INTO dirty_issues (issue_id, marked_at) VALUES (?, CURRENT_TIMESTAMP)`, r.id); err != nil {
+			return 0, fmt.Errorf("failed to mark %s dirty: %w", r.id, err)
+		}
+		repaired++
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit repair: %w", err)
+	}
+	return repaired, nil
+}
+
+func readIssueTimestamps(ctx context.Context, db *sql.DB) ([]issueTimestamps, error) {
+	rows, err := db.QueryContext(ctx, timestampQuery, unixEpochJulianDay)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read timestamps: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	var result []issueTimestamps
+	for rows.Next() {
+		var r issueTimestamps
+		if err := rows.Scan(&r.id, &r.status, &r.created, &r.createdJD, &r.updated, &r.updatedJD,
+			&r.closed, &r.closedJD, &r.firstEvent); err != nil {
+			return nil, fmt.Errorf("failed to scan timestamps: %w", err)
+		}
+		result = append(result, r)
+	}
+	return result, rows.Err()
+}
+
+// checkTimestamp classifies one timestamp, returning "" when it is fine
+func checkTimestamp(value sql.NullString, jd sql.NullFloat64, latest float64) string {
+	switch {
+	case !jd.Valid:
+		return TimestampUnreadable
+	case jd.Float64 < unixEpochJulianDay || jd.Float64 > latest:
+		return TimestampOutOfRange
+	}
+	return ""
+}
+
+func (r *issueTimestamps) problems(latest float64) []TimestampProblem {
+	var problems []TimestampProblem
+	add := func(column, kind string, value sql.NullString) {
+		problems = append(problems, TimestampProblem{IssueID: r.id, Column: column, Kind: kind, Value: value.String})
+	}
+	createdOK, updatedOK := false, false
+	if kind := checkTimestamp(r.created, r.createdJD, latest); kind != "" {
+		add("created_at", kind, r.created)
+	} else {
+		createdOK = true
+	}
+	if kind := checkTimestamp(r.updated, r.updatedJD, latest); kind != "" {
+		add("updated_at", kind, r.updated)
+	} else {
+		updatedOK = true
+	}
+	if createdOK && updatedOK && r.updatedJD.Float64 < r.createdJD.Float64 {
+		add("updated_at", TimestampBeforeCreated, r.updated)
+	}
+
+	closed := r.status == "closed"
+	switch {
+	case closed && !r.closed.Valid:
+		add("closed_at", TimestampMissing, r.closed)
+	case !closed && r.closed.Valid:
+		add("closed_at", TimestampNotClosed, r.closed)
+	case closed:
+		if kind := checkTimestamp(r.closed, r.closedJD, latest); kind != "" {
+			add("closed_at", kind, r.closed)
+		}
+	}
+	return problems
+}
+
+// repairs returns the new value of each column to repair. A nil value
+// clears the column.
+func (r *issueTimestamps) repairs(latest float64) map[string]interface{} {
+	updates := make(map[string]interface{})
+	created, createdJD := r.created, r.createdJD
+	if checkTimestamp(created, createdJD, latest) != "" {
+		switch {
+		case r.firstEvent.Valid:
+			created, createdJD = r.firstEvent, sql.NullFloat64{}
+		case checkTimestamp(r.updated, r.updatedJD, latest) == "":
+			created, createdJD = r.updated, r.updatedJD
+		default:
+			// Nothing to take it from; leave the whole row alone
+			return nil
+		}
+		updates["created_at"] = created.String
+	}
+
+	closed := r.status == "closed"
+	closedOK := closed && r.closed.Valid && checkTimestamp(r.closed, r.closedJD, latest) == ""
+	updatedOK := checkTimestamp(r.updated, r.updatedJD, latest) == ""
+	if !updatedOK || (createdJD.Valid && r.updatedJD.Float64 < createdJD.Float64) {
+		if closedOK {
+			updates["updated_at"] = r.closed.String
+		} else {
+			updates["updated_at"] = created.String
+		}
+	}
+
+	switch {
+	case !closed && r.closed.Valid:
+		updates["closed_at"] = nil
+	case closed && !closedOK:
+		if value, ok := updates["updated_at"]; ok {
+			updates["closed_at"] = value
+		} else {
+			updates["closed_at"] = r.updated.String
+		}
+	}
+	return updates
+}
+
+// julianDay converts t to a Julian day number as SQLite's julianday() does
+func julianDay(t time.Time) float64 {
+	return float64(t.UnixMilli())/float64(24*time.Hour/time.Millisecond) + unixEpochJulianDay
+}
+
+// Dangling parent kinds reported by FindDanglingParents
+const (
+	ParentMissing = "missing" // The parent the child's ID or parent-child link names doesn't exist
+	ParentDeleted = "deleted" // The parent is a tombstone but the child isn't
+)
+
+// DanglingParent is a child issue whose parent is gone
+type DanglingParent struct {
+	IssueID  string `json:"issue_id"`
+	ParentID string `json:"parent_id"`
+	Kind     string `json:"kind"`
+}
+
+// FindDanglingParents reports live issues whose parent, named by a
+// hierarchical ID (bd-a3f8.1) or a parent-child dependency, is missing or
+// deleted. Interrupted syncs that import children without their parent
+// leave these behind.
+func FindDanglingParents(ctx context.Context, db *sql.DB) ([]DanglingParent, error) {
+	status := make(map[string]string)
+	rows, err := db.QueryContext(ctx, `SELECT id, status FROM issues ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read issues: %w", err)
+	}
+	var ids []string
+	for rows.Next() {
+		var id, st string
+		if err := rows.Scan(&id, &st); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("failed to scan issue: %w", err)
+		}
+		status[id] = st
+		ids = append(ids, id)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	parents := make(map[string][]string) // child -> parents
+	for _, id := range ids {
+		if ok, parent := IsHierarchicalID(id); ok {
+			parents[id] = append(parents[id], parent)
+		}
+	}
+	rows, err = db.QueryContext(ctx, `
+		SELECT issue_id, depends_on_id FROM dependencies
+		WHERE type = 'parent-child' AND instr(depends_on_id, '/') = 0
+		ORDER BY issue_id, depends_on_id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read parent-child links: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+		var child, parent string
+		if err := rows.Scan(&child, &parent); err != nil {
+			return nil, fmt.Errorf("failed to scan parent-child link: %w", err)
+		}
+		parents[child] = append(parents[child], parent)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var dangling []DanglingParent
+	for _, id := range ids {
+		if status[id] == "tombstone" {
+			continue
+		}
+		seen := make(map[string]bool)
+		for _, parent := range parents[id] {
+			if seen[parent] {
+				continue
+			}
+			seen[parent] = true
+			switch st, ok := status[parent]; {
+			case !ok:
+				dangling = append(dangling, DanglingParent{IssueID: id, ParentID: parent, Kind: ParentMissing})
+			case st == "tombstone":
+				dangling = append(dangling, DanglingParent{IssueID: id, ParentID: parent, Kind: ParentDeleted})
+			}
+		}
+	}
+	return dangling, nil
+}
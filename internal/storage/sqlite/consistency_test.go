@@ -0,0 +1,138 @@
+package sqlite
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestFindAndRepairTimestamps(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+	db := store.UnderlyingDB()
+
+	var ids []string
+	for _, title := range []string{"Fine", "Garbled", "Backwards", "Future"} {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, issue.ID)
+	}
+	future := time.Now().Add(30 * 24 * time.Hour).UTC().Format(time.RFC3339Nano)
+	for _, stmt := range []struct {
+		query string
+		args  []interface{}
+	}{
+		{`UPDATE issues SET created_at = 'garbage' WHERE id = ?`, []interface{}{ids[1]}},
+		{`UPDATE issues SET updated_at = '2001-01-01T00:00:00Z' WHERE id = ?`, []interface{}{ids[2]}},
+		{`UPDATE issues SET updated_at = ? WHERE id = ?`, []interface{}{future, ids[3]}},
+	} {
+		if _, err := db.ExecContext(ctx, stmt.query, stmt.args...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := store.GetIssue(ctx, ids[1]); err == nil {
+		t.Fatal("expected reading the garbled issue to fail")
+	}
+
+	problems, err := FindTimestampProblems(ctx, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]string)
+	for _, p := range problems {
+		got[p.IssueID] += p.Column + ":" + p.Kind + " "
+	}
+	want := map[string]string{
+		ids[1]: "created_at:unreadable ",
+		ids[2]: "updated_at:before_created ",
+		ids[3]: "updated_at:out_of_range ",
+	}
+	for id, kinds := range want {
+		if got[id] != kinds {
+			t.Errorf("%s: problems %q, want %q", id, got[id], kinds)
+		}
+	}
+	if got[ids[0]] != "" {
+		t.Errorf("fine issue reported: %q", got[ids[0]])
+	}
+
+	repaired, err := RepairTimestamps(ctx, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if repaired != 3 {
+		t.Errorf("repaired %d issues, want 3", repaired)
+	}
+	if problems, err := FindTimestampProblems(ctx, db); err != nil || len(problems) != 0 {
+		t.Errorf("problems after repair = %+v, %v", problems, err)
+	}
+	garbled, err := store.GetIssue(ctx, ids[1])
+	if err != nil {
+		t.Fatalf("garbled issue still unreadable: %v", err)
+	}
+	if time.Since(garbled.CreatedAt) > time.Hour {
+		t.Errorf("created_at repaired to %v, want the creation event's time", garbled.CreatedAt)
+	}
+	dirty, err := store.GetDirtyIssues(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dirty) < 3 {
+		t.Errorf("dirty issues = %v, want the repaired ones", dirty)
+	}
+}
+
+func TestFindDanglingParents(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+	db := store.UnderlyingDB()
+
+	var ids []string
+	for _, title := range []string{"Parent", "Child", "Orphan"} {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, issue.ID)
+	}
+	dep := &types.Dependency{IssueID: ids[1], DependsOnID: ids[0], Type: types.DepParentChild}
+	if err := store.AddDependency(ctx, dep, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if dangling, err := FindDanglingParents(ctx, db); err != nil || len(dangling) != 0 {
+		t.Fatalf("dangling = %+v, %v, want none", dangling, err)
+	}
+
+	// The parent is deleted under its child, and an issue is imported as
+	// the child of one that never arrived
+	if _, err := db.ExecContext(ctx, `UPDATE issues SET status = 'tombstone' WHERE id = ?`, ids[0]); err != nil {
+		t.Fatal(err)
+	}
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if _, err := conn.ExecContext(ctx, `PRAGMA foreign_keys = OFF`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := conn.ExecContext(ctx, `UPDATE issues SET id = 'bd-gone.1' WHERE id = ?`, ids[2]); err != nil {
+		t.Fatal(err)
+	}
+	dangling, err := FindDanglingParents(ctx, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []DanglingParent{
+		{IssueID: "bd-gone.1", ParentID: "bd-gone", Kind: ParentMissing},
+		{IssueID: ids[1], ParentID: ids[0], Kind: ParentDeleted},
+	}
+	if len(dangling) != len(want) || !slices.Contains(dangling, want[0]) || !slices.Contains(dangling, want[1]) {
+		t.Errorf("dangling = %+v, want %+v", dangling, want)
+	}
+}
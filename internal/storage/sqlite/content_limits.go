@@ -0,0 +1,102 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Config keys for the guard rails on long text fields
+const (
+	// ContentMaxBytesConfigKey is the largest description, design, acceptance
+	// criteria or notes value accepted, in bytes ("0" disables the limit)
+	ContentMaxBytesConfigKey = "content.max_bytes"
+	// ContentWarnBytesConfigKey is the size at which the CLI warns that a field
+	// would be better kept as an attachment ("0" disables the warning)
+	ContentWarnBytesConfigKey = "content.warn_bytes"
+)
+
+// Default content limits, used when the config keys are unset
+const (
+	DefaultContentMaxBytes  = 1 << 20
+	DefaultContentWarnBytes = 64 << 10
+)
+
+// ContentFields are the free-text issue fields the content limits apply to
+var ContentFields = []string{"description", "design", "acceptance_criteria", "notes"}
+
+// ContentLimits are the guard rails on long text fields
+type ContentLimits struct {
+	MaxBytes  int // Reject larger values; 0 means unlimited
+	WarnBytes int // Warn about larger values; 0 means never
+}
+
+// LoadContentLimits reads the content.* config keys, falling back to the
+// defaults for unset or unparseable values
+func LoadContentLimits(ctx context.Context, getConfig func(context.Context, string) (string, error)) (ContentLimits, error) {
+	limits := ContentLimits{MaxBytes: DefaultContentMaxBytes, WarnBytes: DefaultContentWarnBytes}
+	for key, dst := range map[string]*int{
+		ContentMaxBytesConfigKey:  &limits.MaxBytes,
+		ContentWarnBytesConfigKey: &limits.WarnBytes,
+	} {
+		value, err := getConfig(ctx, key)
+		if err != nil {
+			return limits, err
+		}
+		if n, err := strconv.Atoi(strings.TrimSpace(value)); err == nil && n >= 0 {
+			*dst = n
+		}
+	}
+	return limits, nil
+}
+
+// Check rejects a field value that is over the size limit or looks like
+// binary data
+func (l ContentLimits) Check(field, value string) error {
+	if l.MaxBytes > 0 && len(value) > l.MaxBytes {
+		return fmt.Errorf("%w: %s is %d bytes, over the %d-byte limit (%s)", ErrContentTooLarge, field, len(value), l.MaxBytes, ContentMaxBytesConfigKey)
+	}
+	if LooksBinary(value) {
+		return fmt.Errorf("%w: %s contains NUL bytes or invalid UTF-8", ErrBinaryContent, field)
+	}
+	return nil
+}
+
+// checkIssue applies Check to each text field of issue
+func (l ContentLimits) checkIssue(issue *types.Issue) error {
+	for _, f := range []struct{ name, value string }{
+		{"description", issue.Description},
+		{"design", issue.Design},
+		{"acceptance_criteria", issue.AcceptanceCriteria},
+		{"notes", issue.Notes},
+	} {
+		if err := l.Check(f.name, f.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkUpdate applies Check to a text field in an update map
+func (l ContentLimits) checkUpdate(key string, value interface{}) error {
+	s, ok := value.(string)
+	if !ok {
+		return nil
+	}
+	for _, field := range ContentFields {
+		if key == field {
+			return l.Check(key, s)
+		}
+	}
+	return nil
+}
+
+// LooksBinary reports whether s holds NUL bytes or invalid UTF-8, the marks
+// of a binary file pasted into a text field
+func LooksBinary(s string) bool {
+	return strings.IndexByte(s, 0) >= 0 || !utf8.ValidString(s)
+}
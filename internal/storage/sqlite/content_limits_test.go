@@ -0,0 +1,52 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestContentLimits(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	newIssue := func(description string) *types.Issue {
+		return &types.Issue{Title: "log dump", Description: description, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	}
+
+	huge := strings.Repeat("x", DefaultContentMaxBytes+1)
+	if err := store.CreateIssue(ctx, newIssue(huge), "test-user"); !IsContentTooLarge(err) {
+		t.Errorf("expected ErrContentTooLarge for an oversized description, got %v", err)
+	}
+	if err := store.CreateIssue(ctx, newIssue("PNG\x00\x01\x02"), "test-user"); !IsBinaryContent(err) {
+		t.Errorf("expected ErrBinaryContent for a binary description, got %v", err)
+	}
+	if err := store.CreateIssuesWithOptions(ctx, []*types.Issue{newIssue(huge)}, "test-user", OrphanAllow); !IsContentTooLarge(err) {
+		t.Errorf("expected batch create to apply the limit, got %v", err)
+	}
+
+	issue := newIssue("fine")
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"notes": "ELF\x00\x00"}, "test-user"); !IsBinaryContent(err) {
+		t.Errorf("expected ErrBinaryContent for binary notes, got %v", err)
+	}
+
+	// Limits come from config
+	if err := store.SetConfig(ctx, ContentMaxBytesConfigKey, "10"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"design": "more than ten bytes"}, "test-user"); !IsContentTooLarge(err) {
+		t.Errorf("expected content.max_bytes=10 to reject the design, got %v", err)
+	}
+	if err := store.SetConfig(ctx, ContentMaxBytesConfigKey, "0"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"description": huge}, "test-user"); err != nil {
+		t.Errorf("expected content.max_bytes=0 to disable the limit, got %v", err)
+	}
+}
@@ -0,0 +1,157 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// CycleTimeSample captures the lifecycle timestamps of a single closed issue.
+// StartedAt is nil when the issue was never moved to in_progress.
+type CycleTimeSample struct {
+	IssueID   string
+	Priority  int
+	Assignee  string
+	Actor     string // Actor who closed the issue (falls back to assignee)
+	Labels    []string
+	CreatedAt time.Time
+	StartedAt *time.Time
+	ClosedAt  time.Time
+}
+
+// GetCycleTimeSamples returns lifecycle samples for closed issues, derived from
+// the events table and the summaries of pruned events. If closedSince is
+// non-zero, only issues closed at or after that time are included.
+func (s *SQLiteStorage) GetCycleTimeSamples(ctx context.Context, closedSince time.Time) ([]*CycleTimeSample, error) {
+	query := `
+		SELECT id, priority, assignee, created_at, closed_at
+		FROM issues
+		WHERE status = 'closed' AND closed_at IS NOT NULL
+	`
+	var args []interface{}
+	if !closedSince.IsZero() {
+		query += " AND closed_at >= ?"
+		args = append(args, closedSince)
+	}
+	query += " ORDER BY closed_at ASC"
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query closed issues: %w", err)
+	}
+
+	var samples []*CycleTimeSample
+	byID := make(map[string]*CycleTimeSample)
+	for rows.Next() {
+		var sample CycleTimeSample
+		var assignee sql.NullString
+		var closedAt sql.NullTime
+		if err := rows.Scan(&sample.IssueID, &sample.Priority, &assignee, &sample.CreatedAt, &closedAt); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("failed to scan closed issue: %w", err)
+		}
+		if assignee.Valid {
+			sample.Assignee = assignee.String
+		}
+		if closedAt.Valid {
+			sample.ClosedAt = closedAt.Time
+		}
+		samples = append(samples, &sample)
+		byID[sample.IssueID] = &sample
+	}
+	if err := rows.Err(); err != nil {
+		_ = rows.Close()
+		return nil, err
+	}
+	_ = rows.Close()
+
+	if len(samples) == 0 {
+		return samples, nil
+	}
+
+	// Pruned events came first: their summary starts the walk
+	summaries, err := s.eventSummaries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for id, sum := range summaries {
+		if sample, ok := byID[id]; ok {
+			sample.StartedAt, sample.Actor = sum.startedAt, sum.closedBy
+		}
+	}
+
+	// Walk status transitions in order so the first in_progress transition and
+	// the last closing actor win.
+	eventRows, err := s.db.QueryContext(ctx, `
+		SELECT e.issue_id, e.event_type, e.actor, e.new_value, e.created_at
+		FROM events e
+		JOIN issues i ON i.id = e.issue_id
+		WHERE i.status = 'closed'
+		  AND e.event_type IN (?, ?)
+		ORDER BY e.created_at ASC, e.id ASC
+	`, types.EventStatusChanged, types.EventClosed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query status events: %w", err)
+	}
+	defer func() { _ = eventRows.Close() }()
+
+	for eventRows.Next() {
+		var issueID, eventType, eventActor string
+		var newValue sql.NullString
+		var createdAt time.Time
+		if err := eventRows.Scan(&issueID, &eventType, &eventActor, &newValue, &createdAt); err != nil {
+			return nil, fmt.Errorf("failed to scan status event: %w", err)
+		}
+		sample, ok := byID[issueID]
+		if !ok {
+			continue
+		}
+		switch types.EventType(eventType) {
+		case types.EventClosed:
+			sample.Actor = eventActor
+		case types.EventStatusChanged:
+			if sample.StartedAt == nil && statusFromEventValue(newValue) == types.StatusInProgress {
+				started := createdAt
+				sample.StartedAt = &started
+			}
+		}
+	}
+	if err := eventRows.Err(); err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(samples))
+	for _, sample := range samples {
+		if sample.Actor == "" {
+			sample.Actor = sample.Assignee
+		}
+		ids = append(ids, sample.IssueID)
+	}
+	labels, err := s.GetLabelsForIssues(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get labels: %w", err)
+	}
+	for _, sample := range samples {
+		sample.Labels = labels[sample.IssueID]
+	}
+
+	return samples, nil
+}
+
+// statusFromEventValue extracts the status from an update event's new_value,
+// which holds the JSON-encoded updates map. Returns "" if absent or malformed.
+func statusFromEventValue(v sql.NullString) types.Status {
+	if !v.Valid || v.String == "" {
+		return ""
+	}
+	var updates map[string]interface{}
+	if err := json.Unmarshal([]byte(v.String), &updates); err != nil {
+		return ""
+	}
+	status, _ := updates["status"].(string)
+	return types.Status(status)
+}
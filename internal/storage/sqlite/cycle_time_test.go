@@ -0,0 +1,82 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestGetCycleTimeSamples(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	started := &types.Issue{Title: "Started then closed", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	direct := &types.Issue{Title: "Closed directly", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeBug}
+	open := &types.Issue{Title: "Still open", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{started, direct, open} {
+		if err := store.CreateIssue(ctx, issue, "creator"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if err := store.AddLabel(ctx, started.ID, "backend", "creator"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+
+	if err := store.UpdateIssue(ctx, started.ID, map[string]interface{}{"status": string(types.StatusInProgress)}, "agent-1"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if err := store.CloseIssue(ctx, started.ID, "done", "agent-1"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	if err := store.CloseIssue(ctx, direct.ID, "done", "human"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+
+	samples, err := store.GetCycleTimeSamples(ctx, time.Time{})
+	if err != nil {
+		t.Fatalf("GetCycleTimeSamples failed: %v", err)
+	}
+	if len(samples) != 2 {
+		t.Fatalf("expected 2 samples (closed issues only), got %d", len(samples))
+	}
+
+	byID := map[string]*CycleTimeSample{}
+	for _, s := range samples {
+		byID[s.IssueID] = s
+	}
+
+	s := byID[started.ID]
+	if s == nil {
+		t.Fatalf("missing sample for %s", started.ID)
+	}
+	if s.StartedAt == nil {
+		t.Error("expected StartedAt for issue moved to in_progress")
+	}
+	if s.Actor != "agent-1" {
+		t.Errorf("expected closing actor agent-1, got %q", s.Actor)
+	}
+	if len(s.Labels) != 1 || s.Labels[0] != "backend" {
+		t.Errorf("expected labels [backend], got %v", s.Labels)
+	}
+
+	d := byID[direct.ID]
+	if d == nil {
+		t.Fatalf("missing sample for %s", direct.ID)
+	}
+	if d.StartedAt != nil {
+		t.Error("expected nil StartedAt for issue closed without starting")
+	}
+	if d.Actor != "human" {
+		t.Errorf("expected closing actor human, got %q", d.Actor)
+	}
+
+	future, err := store.GetCycleTimeSamples(ctx, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("GetCycleTimeSamples with since failed: %v", err)
+	}
+	if len(future) != 0 {
+		t.Errorf("expected no samples closed in the future, got %d", len(future))
+	}
+}
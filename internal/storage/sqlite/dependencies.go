@@ -33,12 +33,22 @@ func (s *SQLiteStorage) AddDependency(ctx context.Context, dep *types.Dependency
 		return fmt.Errorf("issue %s not found", dep.IssueID)
 	}
 
-	dependsOnExists, err := s.GetIssue(ctx, dep.DependsOnID)
-	if err != nil {
-		return fmt.Errorf("failed to check dependency %s: %w", dep.DependsOnID, err)
-	}
-	if dependsOnExists == nil {
-		return fmt.Errorf("dependency target %s not found", dep.DependsOnID)
+	// A qualified ID (api/bd-42) names an issue in a linked workspace, which
+	// isn't in this database and can't be part of a cycle here
+	external := types.IsQualifiedID(dep.DependsOnID)
+	dependsOnExists := &types.Issue{ID: dep.DependsOnID}
+	if external {
+		if dep.Type == types.DepParentChild {
+			return fmt.Errorf("an issue in another workspace (%s) can't be a parent", dep.DependsOnID)
+		}
+	} else {
+		dependsOnExists, err = s.GetIssue(ctx, dep.DependsOnID)
+		if err != nil {
+			return fmt.Errorf("failed to check dependency %s: %w", dep.DependsOnID, err)
+		}
+		if dependsOnExists == nil {
+			return fmt.Errorf("dependency target %s not found", dep.DependsOnID)
+		}
 	}
 
 	// Prevent self-dependency
@@ -62,7 +72,7 @@ func (s *SQLiteStorage) AddDependency(ctx context.Context, dep *types.Dependency
 	}
 
 	if dep.CreatedAt.IsZero() {
-		dep.CreatedAt = time.Now()
+		dep.CreatedAt = time.Now().UTC()
 	}
 	if dep.CreatedBy == "" {
 		dep.CreatedBy = actor
@@ -96,7 +106,7 @@ func (s *SQLiteStorage) AddDependency(ctx context.Context, dep *types.Dependency
 		// and excessive query cost. We check before inserting to avoid unnecessary write on failure.
 
 		// Skip cycle detection for relates-to (inherently bidirectional)
-		if dep.Type != types.DepRelatesTo {
+		if dep.Type != types.DepRelatesTo && !external {
 			var cycleExists bool
 			err = tx.QueryRowContext(ctx, `
 				WITH RECURSIVE paths AS (
@@ -233,7 +243,7 @@ func (s *SQLiteStorage) GetDependenciesWithMetadata(ctx context.Context, issueID
 		       i.status, i.priority, i.issue_type, i.assignee, i.estimated_minutes,
 		       i.created_at, i.updated_at, i.closed_at, i.external_ref, i.source_repo,
 		       i.deleted_at, i.deleted_by, i.delete_reason, i.original_type,
-		       i.sender, i.ephemeral,
+		       i.sender, i.ephemeral, i.complexity, i.source, i.timebox_minutes,
 		       d.type
 		FROM issues i
 		JOIN dependencies d ON i.id = d.depends_on_id
@@ -255,7 +265,7 @@ func (s *SQLiteStorage) GetDependentsWithMetadata(ctx context.Context, issueID s
 		       i.status, i.priority, i.issue_type, i.assignee, i.estimated_minutes,
 		       i.created_at, i.updated_at, i.closed_at, i.external_ref, i.source_repo,
 		       i.deleted_at, i.deleted_by, i.delete_reason, i.original_type,
-		       i.sender, i.ephemeral,
+		       i.sender, i.ephemeral, i.complexity, i.source, i.timebox_minutes,
 		       d.type
 		FROM issues i
 		JOIN dependencies d ON i.id = d.issue_id
@@ -714,6 +724,9 @@ func (s *SQLiteStorage) scanIssues(ctx context.Context, rows *sql.Rows) ([]*type
 		// Messaging fields (bd-kwro)
 		var sender sql.NullString
 		var ephemeral sql.NullInt64
+		var complexity sql.NullString
+		var source sql.NullString
+		var timeboxMinutes sql.NullInt64
 
 		err := rows.Scan(
 			&issue.ID, &contentHash, &issue.Title, &issue.Description, &issue.Design,
@@ -721,7 +734,7 @@ func (s *SQLiteStorage) scanIssues(ctx context.Context, rows *sql.Rows) ([]*type
 			&issue.Priority, &issue.IssueType, &assignee, &estimatedMinutes,
 			&issue.CreatedAt, &issue.UpdatedAt, &closedAt, &externalRef, &sourceRepo, &closeReason,
 			&deletedAt, &deletedBy, &deleteReason, &originalType,
-			&sender, &ephemeral,
+			&sender, &ephemeral, &complexity, &source, &timeboxMinutes,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan issue: %w", err)
@@ -766,6 +779,16 @@ func (s *SQLiteStorage) scanIssues(ctx context.Context, rows *sql.Rows) ([]*type
 		if ephemeral.Valid && ephemeral.Int64 != 0 {
 			issue.Ephemeral = true
 		}
+		if complexity.Valid {
+			issue.Complexity = types.Complexity(complexity.String)
+		}
+		if source.Valid {
+			issue.Source = source.String
+		}
+		if timeboxMinutes.Valid {
+			mins := int(timeboxMinutes.Int64)
+			issue.TimeboxMinutes = &mins
+		}
 
 		issues = append(issues, &issue)
 		issueIDs = append(issueIDs, issue.ID)
@@ -805,6 +828,9 @@ func (s *SQLiteStorage) scanIssuesWithDependencyType(ctx context.Context, rows *
 		// Messaging fields (bd-kwro)
 		var sender sql.NullString
 		var ephemeral sql.NullInt64
+		var complexity sql.NullString
+		var source sql.NullString
+		var timeboxMinutes sql.NullInt64
 		var depType types.DependencyType
 
 		err := rows.Scan(
@@ -813,7 +839,7 @@ func (s *SQLiteStorage) scanIssuesWithDependencyType(ctx context.Context, rows *
 			&issue.Priority, &issue.IssueType, &assignee, &estimatedMinutes,
 			&issue.CreatedAt, &issue.UpdatedAt, &closedAt, &externalRef, &sourceRepo,
 			&deletedAt, &deletedBy, &deleteReason, &originalType,
-			&sender, &ephemeral,
+			&sender, &ephemeral, &complexity, &source, &timeboxMinutes,
 			&depType,
 		)
 		if err != nil {
@@ -856,6 +882,16 @@ func (s *SQLiteStorage) scanIssuesWithDependencyType(ctx context.Context, rows *
 		if ephemeral.Valid && ephemeral.Int64 != 0 {
 			issue.Ephemeral = true
 		}
+		if complexity.Valid {
+			issue.Complexity = types.Complexity(complexity.String)
+		}
+		if source.Valid {
+			issue.Source = source.String
+		}
+		if timeboxMinutes.Valid {
+			mins := int(timeboxMinutes.Int64)
+			issue.TimeboxMinutes = &mins
+		}
 
 		// Fetch labels for this issue
 		labels, err := s.GetLabels(ctx, issue.ID)
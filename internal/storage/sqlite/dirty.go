@@ -6,6 +6,8 @@ import (
 	"database/sql"
 	"fmt"
 	"time"
+
+	"github.com/steveyegge/beads/internal/types"
 )
 
 // MarkIssueDirty marks an issue as dirty (needs to be exported to JSONL)
@@ -15,7 +17,7 @@ func (s *SQLiteStorage) MarkIssueDirty(ctx context.Context, issueID string) erro
 		INSERT INTO dirty_issues (issue_id, marked_at)
 		VALUES (?, ?)
 		ON CONFLICT (issue_id) DO UPDATE SET marked_at = excluded.marked_at
-	`, issueID, time.Now())
+	`, issueID, time.Now().UTC())
 	return wrapDBErrorf(err, "mark issue %s dirty", issueID)
 }
 
@@ -27,7 +29,7 @@ func (s *SQLiteStorage) MarkIssuesDirty(ctx context.Context, issueIDs []string)
 	}
 
 	return s.withTx(ctx, func(tx *sql.Tx) error {
-		now := time.Now()
+		now := time.Now().UTC()
 		stmt, err := tx.PrepareContext(ctx, `
 			INSERT INTO dirty_issues (issue_id, marked_at)
 			VALUES (?, ?)
@@ -139,7 +141,7 @@ func markIssuesDirtyTx(ctx context.Context, tx *sql.Tx, issueIDs []string) error
 		return nil
 	}
 
-	now := time.Now()
+	now := time.Now().UTC()
 	stmt, err := tx.PrepareContext(ctx, `
 		INSERT INTO dirty_issues (issue_id, marked_at)
 		VALUES (?, ?)
@@ -151,6 +153,9 @@ func markIssuesDirtyTx(ctx context.Context, tx *sql.Tx, issueIDs []string) error
 	defer func() { _ = stmt.Close() }()
 
 	for _, issueID := range issueIDs {
+		if types.IsQualifiedID(issueID) {
+			continue // A dependency target in another workspace
+		}
 		if _, err := stmt.ExecContext(ctx, issueID, now); err != nil {
 			return fmt.Errorf("failed to mark issue %s dirty: %w", issueID, err)
 		}
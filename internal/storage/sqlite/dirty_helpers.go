@@ -11,11 +11,14 @@ import (
 
 // markDirty marks a single issue as dirty for incremental export
 func markDirty(ctx context.Context, conn *sql.Conn, issueID string) error {
+	if types.IsQualifiedID(issueID) {
+		return nil // A dependency target in another workspace
+	}
 	_, err := conn.ExecContext(ctx, `
 		INSERT INTO dirty_issues (issue_id, marked_at)
 		VALUES (?, ?)
 		ON CONFLICT (issue_id) DO UPDATE SET marked_at = excluded.marked_at
-	`, issueID, time.Now())
+	`, issueID, time.Now().UTC())
 	if err != nil {
 		return fmt.Errorf("failed to mark issue dirty: %w", err)
 	}
@@ -34,7 +37,7 @@ func markDirtyBatch(ctx context.Context, conn *sql.Conn, issues []*types.Issue)
 	}
 	defer func() { _ = stmt.Close() }()
 
-	dirtyTime := time.Now()
+	dirtyTime := time.Now().UTC()
 	for _, issue := range issues {
 		_, err = stmt.ExecContext(ctx, issue.ID, dirtyTime)
 		if err != nil {
@@ -0,0 +1,58 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestCheckDefinitionOfDone(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Add endpoint", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.AddLabel(ctx, issue.ID, "backend", "test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SetConfig(ctx, "done.backend", "tests added, docs updated"); err != nil {
+		t.Fatal(err)
+	}
+
+	criteria, err := storage.GetDoneCriteria(ctx, store, issue.ID)
+	if err != nil || len(criteria) != 2 || criteria[0].Done {
+		t.Fatalf("criteria = %+v, %v", criteria, err)
+	}
+	// Criteria are a reminder until done.strict is set
+	if err := storage.CheckDefinitionOfDone(ctx, store, issue.ID); err != nil {
+		t.Errorf("not strict: %v", err)
+	}
+
+	if err := store.SetConfig(ctx, types.DoneStrictKey, "true"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.AddIssueComment(ctx, issue.ID, "alice", types.DoneComment([]string{"tests added"})); err != nil {
+		t.Fatal(err)
+	}
+	err = storage.CheckDefinitionOfDone(ctx, store, issue.ID)
+	if err == nil || !strings.Contains(err.Error(), "docs updated not confirmed") {
+		t.Errorf("strict with a criterion pending: %v", err)
+	}
+
+	if _, err := store.AddIssueComment(ctx, issue.ID, "bob", types.DoneComment([]string{"docs updated"})); err != nil {
+		t.Fatal(err)
+	}
+	if err := storage.CheckDefinitionOfDone(ctx, store, issue.ID); err != nil {
+		t.Errorf("all confirmed: %v", err)
+	}
+	criteria, _ = storage.GetDoneCriteria(ctx, store, issue.ID)
+	if criteria[1].ConfirmedBy != "bob" {
+		t.Errorf("criteria = %+v", criteria)
+	}
+}
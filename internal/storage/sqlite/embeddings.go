@@ -0,0 +1,138 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+)
+
+// IssueEmbedding is a stored vector for one issue under one provider/model
+type IssueEmbedding struct {
+	IssueID     string
+	Provider    string
+	Model       string
+	ContentHash string // Hash of the embedded text, used to skip unchanged issues
+	Vector      []float32
+	UpdatedAt   time.Time
+}
+
+// GetEmbeddingHashes returns the content hash of every stored embedding for
+// provider/model, keyed by issue ID
+func (s *SQLiteStorage) GetEmbeddingHashes(ctx context.Context, provider, model string) (map[string]string, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT issue_id, content_hash FROM issue_embeddings
+		WHERE provider = ? AND model = ?
+	`, provider, model)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query embedding hashes: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	hashes := make(map[string]string)
+	for rows.Next() {
+		var id, hash string
+		if err := rows.Scan(&id, &hash); err != nil {
+			return nil, fmt.Errorf("failed to scan embedding hash: %w", err)
+		}
+		hashes[id] = hash
+	}
+	return hashes, rows.Err()
+}
+
+// GetEmbeddings returns all stored vectors for provider/model
+func (s *SQLiteStorage) GetEmbeddings(ctx context.Context, provider, model string) ([]*IssueEmbedding, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT issue_id, content_hash, vector, updated_at FROM issue_embeddings
+		WHERE provider = ? AND model = ?
+	`, provider, model)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query embeddings: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var embeddings []*IssueEmbedding
+	for rows.Next() {
+		e := &IssueEmbedding{Provider: provider, Model: model}
+		var blob []byte
+		if err := rows.Scan(&e.IssueID, &e.ContentHash, &blob, &e.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan embedding: %w", err)
+		}
+		e.Vector = decodeVector(blob)
+		embeddings = append(embeddings, e)
+	}
+	return embeddings, rows.Err()
+}
+
+// UpsertEmbeddings stores vectors, replacing any existing vector for the same
+// issue/provider/model
+func (s *SQLiteStorage) UpsertEmbeddings(ctx context.Context, embeddings []*IssueEmbedding) error {
+	if len(embeddings) == 0 {
+		return nil
+	}
+	conn, err := s.writeConn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO issue_embeddings (issue_id, provider, model, dimensions, content_hash, vector, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(issue_id, provider, model) DO UPDATE SET
+			dimensions = excluded.dimensions,
+			content_hash = excluded.content_hash,
+			vector = excluded.vector,
+			updated_at = excluded.updated_at
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare embedding insert: %w", err)
+	}
+	defer func() { _ = stmt.Close() }()
+
+	now := time.Now().UTC()
+	for _, e := range embeddings {
+		if _, err := stmt.ExecContext(ctx, e.IssueID, e.Provider, e.Model, len(e.Vector), e.ContentHash, encodeVector(e.Vector), now); err != nil {
+			return fmt.Errorf("failed to store embedding for %s: %w", e.IssueID, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// DeleteEmbeddings removes stored vectors. An empty provider removes all of them.
+func (s *SQLiteStorage) DeleteEmbeddings(ctx context.Context, provider, model string) (int64, error) {
+	query := `DELETE FROM issue_embeddings`
+	var args []interface{}
+	if provider != "" {
+		query += ` WHERE provider = ? AND model = ?`
+		args = append(args, provider, model)
+	}
+	result, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete embeddings: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// encodeVector packs a vector as little-endian float32s
+func encodeVector(vec []float32) []byte {
+	buf := make([]byte, 4*len(vec))
+	for i, v := range vec {
+		binary.LittleEndian.PutUint32(buf[4*i:], math.Float32bits(v))
+	}
+	return buf
+}
+
+func decodeVector(buf []byte) []float32 {
+	vec := make([]float32, len(buf)/4)
+	for i := range vec {
+		vec[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[4*i:]))
+	}
+	return vec
+}
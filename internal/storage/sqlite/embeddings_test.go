@@ -0,0 +1,55 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestEmbeddingsRoundTrip(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Embed me", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue: %v", err)
+	}
+
+	vec := []float32{0.25, -1.5, 3}
+	err := store.UpsertEmbeddings(ctx, []*IssueEmbedding{{IssueID: issue.ID, Provider: "local", Model: "m1", ContentHash: "h1", Vector: vec}})
+	if err != nil {
+		t.Fatalf("UpsertEmbeddings: %v", err)
+	}
+	// Re-upserting replaces rather than duplicating
+	err = store.UpsertEmbeddings(ctx, []*IssueEmbedding{{IssueID: issue.ID, Provider: "local", Model: "m1", ContentHash: "h2", Vector: vec}})
+	if err != nil {
+		t.Fatalf("UpsertEmbeddings again: %v", err)
+	}
+
+	hashes, err := store.GetEmbeddingHashes(ctx, "local", "m1")
+	if err != nil || hashes[issue.ID] != "h2" || len(hashes) != 1 {
+		t.Fatalf("hashes = %v, %v", hashes, err)
+	}
+	got, err := store.GetEmbeddings(ctx, "local", "m1")
+	if err != nil || len(got) != 1 {
+		t.Fatalf("GetEmbeddings = %v, %v", got, err)
+	}
+	for i := range vec {
+		if got[0].Vector[i] != vec[i] {
+			t.Fatalf("vector = %v, want %v", got[0].Vector, vec)
+		}
+	}
+	if other, _ := store.GetEmbeddings(ctx, "local", "m2"); len(other) != 0 {
+		t.Errorf("other model returned %d embeddings", len(other))
+	}
+
+	// Embeddings are sidecar data and go away with their issue
+	if err := store.DeleteIssue(ctx, issue.ID); err != nil {
+		t.Fatalf("DeleteIssue: %v", err)
+	}
+	if hashes, _ := store.GetEmbeddingHashes(ctx, "local", "m1"); len(hashes) != 0 {
+		t.Errorf("embeddings survived issue deletion: %v", hashes)
+	}
+}
@@ -19,6 +19,15 @@ var (
 
 	// ErrCycle indicates a dependency cycle would be created
 	ErrCycle = errors.New("dependency cycle detected")
+
+	// ErrContentTooLarge indicates a text field is over content.max_bytes
+	ErrContentTooLarge = errors.New("content too large")
+
+	// ErrBinaryContent indicates a text field holds binary data
+	ErrBinaryContent = errors.New("binary content")
+
+	// ErrReadOnly indicates a write to a database opened with NewReadOnly
+	ErrReadOnly = errors.New("database is read-only")
 )
 
 // wrapDBError wraps a database error with operation context
@@ -60,3 +69,18 @@ func IsConflict(err error) bool {
 func IsCycle(err error) bool {
 	return errors.Is(err, ErrCycle)
 }
+
+// IsContentTooLarge checks if an error is or wraps ErrContentTooLarge
+func IsContentTooLarge(err error) bool {
+	return errors.Is(err, ErrContentTooLarge)
+}
+
+// IsReadOnly checks if an error is or wraps ErrReadOnly
+func IsReadOnly(err error) bool {
+	return errors.Is(err, ErrReadOnly)
+}
+
+// IsBinaryContent checks if an error is or wraps ErrBinaryContent
+func IsBinaryContent(err error) bool {
+	return errors.Is(err, ErrBinaryContent)
+}
@@ -0,0 +1,272 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// EventPruneResult describes a PruneEvents run
+type EventPruneResult struct {
+	Before           time.Time `json:"before"`
+	EventsPruned     int       `json:"events_pruned"`
+	EventsKept       int       `json:"events_kept"`
+	IssuesSummarized int       `json:"issues_summarized"`
+	OperationsPruned int       `json:"operations_pruned"`
+	DryRun           bool      `json:"dry_run,omitempty"`
+}
+
+// eventSummary rolls up the pruned events of one issue: its status and
+// accumulated state times at pruned_before, plus what cycle time and close
+// reasons would otherwise have read from those events
+type eventSummary struct {
+	prunedBefore time.Time
+	eventCount   int
+	status       types.Status
+	hours        types.StateTime
+	startedAt    *time.Time
+	closedBy     string
+	closeReason  string
+}
+
+// pruneDeleteBatch bounds the event IDs deleted per statement
+const pruneDeleteBatch = 500
+
+// PruneEvents deletes events created before before, first rolling them up
+// into one event_summaries row per issue so GetStateTimes, GetCycleTimeSamples
+// and close reasons report the same numbers afterwards. Every issue created
+// before the cutoff gets a summary, with or without events, so that all
+// reconstructed timelines restart at the same point. Events adding blocks
+// dependencies that were still in place at the cutoff are kept: a later
+// removal needs them to close the blocking interval. A cutoff at or before
+// the previous one prunes nothing. The operation log behind bd undo is
+// pruned to the same cutoff. With dryRun nothing is written.
+func (s *SQLiteStorage) PruneEvents(ctx context.Context, before time.Time, dryRun bool) (*EventPruneResult, error) {
+	before = before.UTC().Truncate(time.Second)
+	result := &EventPruneResult{Before: before, DryRun: dryRun}
+	pruned, err := s.historyPrunedBefore(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !before.After(pruned) {
+		return result, nil
+	}
+
+	// Nothing before the cutoff changes any more (events are stamped when
+	// written), so reading outside the write transaction is safe
+	timelines, err := s.statusTimelines(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stateTimes, err := s.GetStateTimes(ctx, before)
+	if err != nil {
+		return nil, err
+	}
+	summaries, err := s.eventSummaries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var prune []int64
+	kept := make(map[[2]string]int64)
+	counts := make(map[string]int)
+	for id, timeline := range timelines {
+		if !timeline[0].at.Before(before) {
+			continue
+		}
+		sum, ok := summaries[id]
+		if !ok {
+			sum = &eventSummary{}
+			summaries[id] = sum
+		}
+		sum.prunedBefore = before
+		sum.status = statusAt(timeline, before)
+		if st := stateTimes[id]; st != nil {
+			sum.hours = *st
+		}
+	}
+
+	err = s.scanEventsBefore(ctx, before, func(id int64, issueID, eventType, actor string, newValue sql.NullString, comment string, at time.Time) {
+		prune = append(prune, id)
+		counts[issueID]++
+		switch types.EventType(eventType) {
+		case types.EventStatusChanged:
+			if sum := summaries[issueID]; sum != nil && sum.startedAt == nil && statusFromEventValue(newValue) == types.StatusInProgress {
+				sum.startedAt = &at
+			}
+		case types.EventClosed:
+			if sum := summaries[issueID]; sum != nil {
+				sum.closedBy, sum.closeReason = actor, comment
+			}
+		case types.EventDependencyAdded:
+			fields := strings.Fields(strings.TrimPrefix(comment, "Added dependency:"))
+			if len(fields) == 3 && fields[1] == string(types.DepBlocks) {
+				kept[[2]string{fields[0], fields[2]}] = id
+			}
+		case types.EventDependencyRemoved:
+			target := strings.TrimSpace(strings.TrimPrefix(comment, "Removed dependency on"))
+			delete(kept, [2]string{issueID, target})
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	keep := make(map[int64]bool, len(kept))
+	for key, id := range kept {
+		keep[id] = true
+		counts[key[0]]--
+	}
+	ids := prune[:0]
+	for _, id := range prune {
+		if !keep[id] {
+			ids = append(ids, id)
+		}
+	}
+	result.EventsPruned, result.EventsKept = len(ids), len(keep)
+
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connection: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for id, sum := range summaries {
+		if !sum.prunedBefore.Equal(before) {
+			continue
+		}
+		sum.eventCount += counts[id]
+		var startedAt interface{}
+		if sum.startedAt != nil {
+			startedAt = *sum.startedAt
+		}
+		_, err := tx.ExecContext(ctx, `
+			INSERT OR REPLACE INTO event_summaries (
+				issue_id, pruned_before, event_count, status,
+				ready_hours, blocked_hours, in_progress_hours, blocking_hours,
+				started_at, closed_by, close_reason
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`, id, sum.prunedBefore, sum.eventCount, string(sum.status),
+			sum.hours.ReadyHours, sum.hours.BlockedHours, sum.hours.InProgressHours, sum.hours.BlockingHours,
+			startedAt, sum.closedBy, sum.closeReason)
+		if err != nil {
+			return nil, fmt.Errorf("failed to write event summary for %s: %w", id, err)
+		}
+		result.IssuesSummarized++
+	}
+
+	for start := 0; start < len(ids); start += pruneDeleteBatch {
+		batch := ids[start:min(start+pruneDeleteBatch, len(ids))]
+		placeholders := make([]string, len(batch))
+		args := make([]interface{}, len(batch))
+		for i, id := range batch {
+			placeholders[i] = "?"
+			args[i] = id
+		}
+		// #nosec G201 - safe SQL with controlled formatting
+		query := fmt.Sprintf(`DELETE FROM events WHERE id IN (%s)`, strings.Join(placeholders, ","))
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return nil, fmt.Errorf("failed to delete events: %w", err)
+		}
+	}
+
+	if result.OperationsPruned, err = s.pruneOperations(ctx, tx, before); err != nil {
+		return nil, err
+	}
+
+	if dryRun {
+		return result, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit event pruning: %w", err)
+	}
+	return result, nil
+}
+
+// scanEventsBefore calls fn for every event created before t, oldest first.
+// Events are stamped either by SQLite ("2006-01-02 15:04:05") or, after the
+// UTC migration, as RFC 3339, which don't compare as text within a day, so
+// the query cuts off at the next day and the rest is filtered here.
+func (s *SQLiteStorage) scanEventsBefore(ctx context.Context, t time.Time, fn func(id int64, issueID, eventType, actor string, newValue sql.NullString, comment string, at time.Time)) error {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, issue_id, event_type, actor, new_value, COALESCE(comment, ''), created_at
+		FROM events
+		WHERE created_at < ?
+		ORDER BY created_at ASC, id ASC
+	`, t.AddDate(0, 0, 1).Format("2006-01-02"))
+	if err != nil {
+		return fmt.Errorf("failed to query events: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var id int64
+		var issueID, eventType, actor, comment string
+		var newValue sql.NullString
+		var createdAt time.Time
+		if err := rows.Scan(&id, &issueID, &eventType, &actor, &newValue, &comment, &createdAt); err != nil {
+			return fmt.Errorf("failed to scan event: %w", err)
+		}
+		if createdAt.Before(t) {
+			fn(id, issueID, eventType, actor, newValue, comment, createdAt)
+		}
+	}
+	return rows.Err()
+}
+
+// eventSummaries returns the rollups of pruned events by issue
+func (s *SQLiteStorage) eventSummaries(ctx context.Context) (map[string]*eventSummary, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT issue_id, pruned_before, event_count, status,
+		       ready_hours, blocked_hours, in_progress_hours, blocking_hours,
+		       started_at, closed_by, close_reason
+		FROM event_summaries
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query event summaries: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	summaries := make(map[string]*eventSummary)
+	for rows.Next() {
+		var id, status string
+		var startedAt sql.NullTime
+		sum := &eventSummary{}
+		if err := rows.Scan(&id, &sum.prunedBefore, &sum.eventCount, &status,
+			&sum.hours.ReadyHours, &sum.hours.BlockedHours, &sum.hours.InProgressHours, &sum.hours.BlockingHours,
+			&startedAt, &sum.closedBy, &sum.closeReason); err != nil {
+			return nil, fmt.Errorf("failed to scan event summary: %w", err)
+		}
+		sum.status = types.Status(status)
+		if startedAt.Valid {
+			sum.startedAt = &startedAt.Time
+		}
+		summaries[id] = sum
+	}
+	return summaries, rows.Err()
+}
+
+// historyPrunedBefore returns the cutoff of the latest PruneEvents, or the
+// zero time if events were never pruned
+func (s *SQLiteStorage) historyPrunedBefore(ctx context.Context) (time.Time, error) {
+	var before time.Time
+	err := s.db.QueryRowContext(ctx, `
+		SELECT pruned_before FROM event_summaries ORDER BY pruned_before DESC LIMIT 1
+	`).Scan(&before)
+	if err == sql.ErrNoRows {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get pruned history cutoff: %w", err)
+	}
+	return before, nil
+}
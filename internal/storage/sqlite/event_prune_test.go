@@ -0,0 +1,141 @@
+package sqlite
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestPruneEvents(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	newIssue := func(title string) *types.Issue {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue(%s): %v", title, err)
+		}
+		return issue
+	}
+	blocker := newIssue("Blocker")
+	worker := newIssue("Worker")
+	done := newIssue("Done")
+
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: worker.ID, DependsOnID: blocker.ID, Type: types.DepBlocks}, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.UpdateIssue(ctx, done.ID, map[string]interface{}{"status": string(types.StatusInProgress)}, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.CloseIssue(ctx, done.ID, "shipped", "alice"); err != nil {
+		t.Fatal(err)
+	}
+
+	// History, pruned at t0+5h:
+	//   t0      everything created
+	//   t0+1h   done started
+	//   t0+2h   worker blocked by blocker
+	//   t0+3h   done closed by alice
+	//   t0+6h   worker unblocked (after the prune)
+	//   t0+8h   now
+	t0 := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	at := func(h int) time.Time { return t0.Add(time.Duration(h) * time.Hour) }
+	exec := func(query string, args ...interface{}) {
+		t.Helper()
+		if _, err := store.db.ExecContext(ctx, query, args...); err != nil {
+			t.Fatalf("%s: %v", query, err)
+		}
+	}
+	exec(`UPDATE issues SET created_at = ?`, t0)
+	exec(`UPDATE events SET created_at = ?`, t0)
+	exec(`UPDATE issues SET closed_at = ? WHERE id = ?`, at(3), done.ID)
+	exec(`UPDATE dependencies SET created_at = ? WHERE issue_id = ?`, at(2), worker.ID)
+	exec(`UPDATE events SET created_at = ? WHERE issue_id = ? AND event_type = ?`, at(1), done.ID, types.EventStatusChanged)
+	exec(`UPDATE events SET created_at = ? WHERE issue_id = ? AND event_type = ?`, at(3), done.ID, types.EventClosed)
+	exec(`UPDATE events SET created_at = ? WHERE issue_id = ? AND event_type = ?`, at(2), worker.ID, types.EventDependencyAdded)
+
+	result, err := store.PruneEvents(ctx, at(5), true)
+	if err != nil {
+		t.Fatalf("PruneEvents dry run: %v", err)
+	}
+	if result.EventsPruned == 0 || result.EventsKept != 1 || result.IssuesSummarized != 3 {
+		t.Errorf("dry run = %+v, want events pruned, worker's dependency kept and 3 summaries", result)
+	}
+	if n := countEvents(t, store); n <= 1 {
+		t.Fatalf("dry run left %d events", n)
+	}
+
+	if _, err := store.PruneEvents(ctx, at(5), false); err != nil {
+		t.Fatalf("PruneEvents: %v", err)
+	}
+	if n := countEvents(t, store); n != 1 {
+		t.Errorf("%d events left, want only worker's dependency_added", n)
+	}
+	if again, err := store.PruneEvents(ctx, at(4), false); err != nil || again.EventsPruned != 0 || again.IssuesSummarized != 0 {
+		t.Errorf("pruning at an earlier cutoff = %+v, %v; want a no-op", again, err)
+	}
+
+	// The kept event still closes the blocking interval removed after the prune
+	if err := store.RemoveDependency(ctx, worker.ID, blocker.ID, "test"); err != nil {
+		t.Fatal(err)
+	}
+	exec(`UPDATE events SET created_at = ? WHERE issue_id = ? AND event_type = ?`, at(6), worker.ID, types.EventDependencyRemoved)
+
+	times, err := store.GetStateTimes(ctx, at(8))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		id                                   string
+		ready, blocked, inProgress, blocking float64
+	}{
+		{blocker.ID, 8, 0, 0, 4},
+		{worker.ID, 4, 4, 0, 0},
+		{done.ID, 1, 0, 2, 0},
+	}
+	near := func(a, b float64) bool { return math.Abs(a-b) < 0.01 }
+	for _, tt := range tests {
+		got := times[tt.id]
+		if got == nil || !near(got.ReadyHours, tt.ready) || !near(got.BlockedHours, tt.blocked) ||
+			!near(got.InProgressHours, tt.inProgress) || !near(got.BlockingHours, tt.blocking) {
+			t.Errorf("%s: state times = %+v, want ready %v blocked %v in progress %v blocking %v",
+				tt.id, got, tt.ready, tt.blocked, tt.inProgress, tt.blocking)
+		}
+	}
+
+	samples, err := store.GetCycleTimeSamples(ctx, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(samples) != 1 || samples[0].StartedAt == nil || !samples[0].StartedAt.Equal(at(1)) || samples[0].Actor != "alice" {
+		t.Errorf("cycle time samples = %+v, want done started at t0+1h and closed by alice", samples)
+	}
+
+	if reason, err := store.GetCloseReason(ctx, done.ID); err != nil || reason != "shipped" {
+		t.Errorf("GetCloseReason = %q, %v; want shipped", reason, err)
+	}
+	if reasons, err := store.GetCloseReasonsForIssues(ctx, []string{done.ID, worker.ID}); err != nil || len(reasons) != 1 || reasons[done.ID] != "shipped" {
+		t.Errorf("GetCloseReasonsForIssues = %v, %v; want shipped for %s", reasons, err, done.ID)
+	}
+
+	if _, err := store.GetReadyDiff(ctx, at(4), at(8)); err == nil || !strings.Contains(err.Error(), "pruned") {
+		t.Errorf("ready diff from before the cutoff: error = %v, want pruned history", err)
+	}
+	if _, err := store.GetReadyDiff(ctx, at(5), at(8)); err != nil {
+		t.Errorf("ready diff from the cutoff: %v", err)
+	}
+}
+
+func countEvents(t *testing.T, store *SQLiteStorage) int {
+	t.Helper()
+	var n int
+	if err := store.db.QueryRow(`SELECT COUNT(*) FROM events`).Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/steveyegge/beads/internal/types"
@@ -15,7 +16,7 @@ const limitClause = " LIMIT ?"
 func (s *SQLiteStorage) AddComment(ctx context.Context, issueID, actor, comment string) error {
 	return s.withTx(ctx, func(tx *sql.Tx) error {
 		// Update issue updated_at timestamp first to verify issue exists
-		now := time.Now()
+		now := time.Now().UTC()
 		res, err := tx.ExecContext(ctx, `
 			UPDATE issues SET updated_at = ? WHERE id = ?
 		`, now, issueID)
@@ -106,26 +107,88 @@ func (s *SQLiteStorage) GetEvents(ctx context.Context, issueID string, limit int
 	return events, nil
 }
 
-// GetStatistics returns aggregate statistics
+// GetEventsSince returns events of all issues with an ID greater than
+// afterID, oldest first. Event IDs only grow, so the last ID returned is a
+// cursor for the next call.
+func (s *SQLiteStorage) GetEventsSince(ctx context.Context, afterID int64, limit int) ([]*types.Event, error) {
+	args := []interface{}{afterID}
+	limitSQL := ""
+	if limit > 0 {
+		limitSQL = limitClause
+		args = append(args, limit)
+	}
+
+	// #nosec G201 - safe SQL with controlled formatting
+	query := fmt.Sprintf(`
+		SELECT id, issue_id, event_type, actor, old_value, new_value, comment, created_at
+		FROM events
+		WHERE id > ?
+		ORDER BY id ASC
+		%s
+	`, limitSQL)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var events []*types.Event
+	for rows.Next() {
+		var event types.Event
+		var oldValue, newValue, comment sql.NullString
+		if err := rows.Scan(
+			&event.ID, &event.IssueID, &event.EventType, &event.Actor,
+			&oldValue, &newValue, &comment, &event.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+		if oldValue.Valid {
+			event.OldValue = &oldValue.String
+		}
+		if newValue.Valid {
+			event.NewValue = &newValue.String
+		}
+		if comment.Valid {
+			event.Comment = &comment.String
+		}
+		events = append(events, &event)
+	}
+	return events, rows.Err()
+}
+
+// LatestEventID returns the highest event ID, or 0 when there are no events
+func (s *SQLiteStorage) LatestEventID(ctx context.Context) (int64, error) {
+	var id sql.NullInt64
+	if err := s.db.QueryRowContext(ctx, `SELECT MAX(id) FROM events`).Scan(&id); err != nil {
+		return 0, fmt.Errorf("failed to get latest event: %w", err)
+	}
+	return id.Int64, nil
+}
+
+// GetStatistics returns aggregate statistics. Issue counts, lead time and
+// the breakdowns come from the stats summary tables, which triggers keep
+// current on every write; GetExactStatistics computes them from the issues.
 func (s *SQLiteStorage) GetStatistics(ctx context.Context) (*types.Statistics, error) {
+	return s.statistics(ctx, false)
+}
+
+// GetExactStatistics returns the same statistics as GetStatistics computed
+// from the issues, labels and events tables rather than the summaries
+func (s *SQLiteStorage) GetExactStatistics(ctx context.Context) (*types.Statistics, error) {
+	return s.statistics(ctx, true)
+}
+
+func (s *SQLiteStorage) statistics(ctx context.Context, exact bool) (*types.Statistics, error) {
 	var stats types.Statistics
 
 	// Get counts (bd-nyt: exclude tombstones from TotalIssues, report separately)
-	err := s.db.QueryRowContext(ctx, `
-		SELECT
-			COALESCE(SUM(CASE WHEN status != 'tombstone' THEN 1 ELSE 0 END), 0) as total,
-			COALESCE(SUM(CASE WHEN status = 'open' THEN 1 ELSE 0 END), 0) as open,
-			COALESCE(SUM(CASE WHEN status = 'in_progress' THEN 1 ELSE 0 END), 0) as in_progress,
-			COALESCE(SUM(CASE WHEN status = 'closed' THEN 1 ELSE 0 END), 0) as closed,
-			COALESCE(SUM(CASE WHEN status = 'tombstone' THEN 1 ELSE 0 END), 0) as tombstone
-		FROM issues
-	`).Scan(&stats.TotalIssues, &stats.OpenIssues, &stats.InProgressIssues, &stats.ClosedIssues, &stats.TombstoneIssues)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get issue counts: %w", err)
+	if err := s.summaryStatistics(ctx, &stats, exact); err != nil {
+		return nil, err
 	}
 
 	// Get blocked count
-	err = s.db.QueryRowContext(ctx, `
+	err := s.db.QueryRowContext(ctx, `
 		SELECT COUNT(DISTINCT i.id)
 		FROM issues i
 		JOIN dependencies d ON i.id = d.issue_id
@@ -155,22 +218,6 @@ func (s *SQLiteStorage) GetStatistics(ctx context.Context) (*types.Statistics, e
 		return nil, fmt.Errorf("failed to get ready count: %w", err)
 	}
 
-	// Get average lead time (hours from created to closed)
-	var avgLeadTime sql.NullFloat64
-	err = s.db.QueryRowContext(ctx, `
-		SELECT AVG(
-			(julianday(closed_at) - julianday(created_at)) * 24
-		)
-		FROM issues
-		WHERE closed_at IS NOT NULL
-	`).Scan(&avgLeadTime)
-	if err != nil && err != sql.ErrNoRows {
-		return nil, fmt.Errorf("failed to get lead time: %w", err)
-	}
-	if avgLeadTime.Valid {
-		stats.AverageLeadTime = avgLeadTime.Float64
-	}
-
 	// Get epics eligible for closure count
 	err = s.db.QueryRowContext(ctx, `
 		WITH epic_children AS (
@@ -201,5 +248,177 @@ func (s *SQLiteStorage) GetStatistics(ctx context.Context) (*types.Statistics, e
 		return nil, fmt.Errorf("failed to get eligible epics count: %w", err)
 	}
 
+	stats.ClosedByReason, err = s.closedByReason(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stats.MinutesLoggedByActor, err = s.minutesLoggedByActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	return &stats, nil
 }
+
+// GetFilteredStatistics returns the statistics of GetStatistics for the
+// issues matching filter only. Blocked and ready count issues by their
+// blockers whether or not the blockers match. Time logged is not broken
+// down.
+func (s *SQLiteStorage) GetFilteredStatistics(ctx context.Context, filter types.IssueFilter) (*types.Statistics, error) {
+	issues, err := s.SearchIssues(ctx, "", filter)
+	if err != nil {
+		return nil, err
+	}
+	custom, err := s.GetCustomCloseReasons(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT DISTINCT d.issue_id
+		FROM dependencies d
+		JOIN issues blocker ON d.depends_on_id = blocker.id
+		WHERE d.type = 'blocks'
+		  AND blocker.status IN ('open', 'in_progress', 'blocked')
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blocked issues: %w", err)
+	}
+	hasBlockers := make(map[string]bool)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("failed to scan blocked issue: %w", err)
+		}
+		hasBlockers[id] = true
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	stats := &types.Statistics{ClosedByReason: make(map[string]int)}
+	var leadHours float64
+	var leadCount int
+	for _, issue := range issues {
+		stats.TotalIssues++
+		switch issue.Status {
+		case types.StatusOpen:
+			stats.OpenIssues++
+			if !hasBlockers[issue.ID] {
+				stats.ReadyIssues++
+			}
+		case types.StatusInProgress:
+			stats.InProgressIssues++
+		case types.StatusResolved:
+			stats.ResolvedIssues++
+		case types.StatusClosed:
+			stats.ClosedIssues++
+			stats.ClosedByReason[types.CloseReasonCategory(issue.CloseReason, custom)]++
+		}
+		if hasBlockers[issue.ID] && isBlockingStatus(issue.Status) {
+			stats.BlockedIssues++
+		}
+		if issue.ClosedAt != nil {
+			leadHours += issue.ClosedAt.Sub(issue.CreatedAt).Hours()
+			leadCount++
+		}
+	}
+	if leadCount > 0 {
+		stats.AverageLeadTime = leadHours / float64(leadCount)
+	}
+	return stats, nil
+}
+
+// closedByReason counts closed issues per close reason category
+func (s *SQLiteStorage) closedByReason(ctx context.Context) (map[string]int, error) {
+	custom, err := s.GetCustomCloseReasons(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT COALESCE(close_reason, ''), COUNT(*)
+		FROM issues
+		WHERE status = 'closed'
+		GROUP BY close_reason
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get close reason counts: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var reason string
+		var count int
+		if err := rows.Scan(&reason, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan close reason count: %w", err)
+		}
+		counts[types.CloseReasonCategory(reason, custom)] += count
+	}
+	return counts, rows.Err()
+}
+
+// ActivityEvent is an event together with the title of its issue, for
+// activity logs
+type ActivityEvent struct {
+	types.Event
+	Title string
+}
+
+// GetActivity returns the events created at or after since and before until
+// (no upper bound when until is zero), oldest first, with their issues'
+// titles. Like scanEventsBefore, it widens the text comparison by a day on
+// each side to cover both timestamp formats and filters the rest here.
+func (s *SQLiteStorage) GetActivity(ctx context.Context, since, until time.Time) ([]*ActivityEvent, error) {
+	query := `
+		SELECT e.id, e.issue_id, e.event_type, e.actor, e.old_value, e.new_value, e.comment, e.created_at,
+		       COALESCE(i.title, '')
+		FROM events e
+		LEFT JOIN issues i ON i.id = e.issue_id
+		WHERE e.created_at >= ?`
+	args := []interface{}{since.UTC().AddDate(0, 0, -1).Format("2006-01-02")}
+	if !until.IsZero() {
+		query += ` AND e.created_at < ?`
+		args = append(args, until.UTC().AddDate(0, 0, 1).Format("2006-01-02"))
+	}
+	query += ` ORDER BY e.id ASC`
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get activity: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var events []*ActivityEvent
+	for rows.Next() {
+		var event ActivityEvent
+		var oldValue, newValue, comment sql.NullString
+		if err := rows.Scan(
+			&event.ID, &event.IssueID, &event.EventType, &event.Actor,
+			&oldValue, &newValue, &comment, &event.CreatedAt, &event.Title,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+		if event.CreatedAt.Before(since) || (!until.IsZero() && !event.CreatedAt.Before(until)) {
+			continue
+		}
+		if oldValue.Valid {
+			event.OldValue = &oldValue.String
+		}
+		if newValue.Valid {
+			event.NewValue = &newValue.String
+		}
+		if comment.Valid {
+			event.Comment = &comment.String
+		}
+		events = append(events, &event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].CreatedAt.Before(events[j].CreatedAt) })
+	return events, nil
+}
@@ -363,3 +363,52 @@ func TestAddCommentNotFound(t *testing.T) {
 		t.Errorf("Expected error to contain %q, got %q", expectedError, err.Error())
 	}
 }
+
+func TestGetActivity(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Login broken", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeBug}
+	if err := store.CreateIssue(ctx, issue, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.AddComment(ctx, issue.ID, "bob", "Looking into it"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.CloseIssue(ctx, issue.ID, "fixed", "bob"); err != nil {
+		t.Fatal(err)
+	}
+
+	// One event per hour, stamped in both formats events have been written in
+	t0 := time.Date(2025, 3, 1, 22, 0, 0, 0, time.UTC)
+	for i, stamp := range []string{
+		t0.Format("2006-01-02 15:04:05"),
+		t0.Add(time.Hour).Format(time.RFC3339),
+		t0.Add(2 * time.Hour).Format("2006-01-02 15:04:05"),
+	} {
+		if _, err := store.db.ExecContext(ctx, `UPDATE events SET created_at = ? WHERE id = (SELECT id FROM events ORDER BY id LIMIT 1 OFFSET ?)`, stamp, i); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	activity, err := store.GetActivity(ctx, t0.Add(30*time.Minute), t0.Add(2*time.Hour))
+	if err != nil {
+		t.Fatalf("GetActivity failed: %v", err)
+	}
+	if len(activity) != 1 || activity[0].EventType != types.EventCommented || activity[0].Actor != "bob" || activity[0].Title != "Login broken" {
+		t.Fatalf("activity = %+v, want only bob's comment", activity)
+	}
+
+	activity, err = store.GetActivity(ctx, t0, time.Time{})
+	if err != nil {
+		t.Fatalf("GetActivity failed: %v", err)
+	}
+	var got []types.EventType
+	for _, a := range activity {
+		got = append(got, a.EventType)
+	}
+	if len(got) != 3 || got[0] != types.EventCreated || got[2] != types.EventClosed {
+		t.Errorf("activity since t0 = %v, want created, commented, closed", got)
+	}
+}
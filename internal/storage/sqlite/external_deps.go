@@ -0,0 +1,131 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// unresolvedExternalSQL is true when the dependency row alias points at an
+// issue in a linked workspace (api/bd-42) that isn't known to be done: it
+// hasn't been found there yet, or it is still open. Such a blocker holds
+// its issue back like an open local one.
+func unresolvedExternalSQL(alias string) string {
+	return fmt.Sprintf(`(instr(%[1]s.depends_on_id, '/') > 0 AND NOT EXISTS (
+			SELECT 1 FROM external_issue_status x
+			WHERE x.id = %[1]s.depends_on_id AND x.status NOT IN ('open', 'in_progress', 'blocked')
+		))`, alias)
+}
+
+// ExternalDependencyTargets returns the issues in linked workspaces that
+// local dependencies point at
+func (s *SQLiteStorage) ExternalDependencyTargets(ctx context.Context) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT DISTINCT depends_on_id FROM dependencies
+		WHERE instr(depends_on_id, '/') > 0
+		ORDER BY depends_on_id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get external dependencies: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan external dependency: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// GetExternalDependencies returns an issue's dependencies on issues in
+// linked workspaces, with their last known state
+func (s *SQLiteStorage) GetExternalDependencies(ctx context.Context, issueID string) ([]*types.ExternalDependency, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT d.depends_on_id, d.type, x.status, x.title, x.checked_at
+		FROM dependencies d
+		LEFT JOIN external_issue_status x ON x.id = d.depends_on_id
+		WHERE d.issue_id = ? AND instr(d.depends_on_id, '/') > 0
+		ORDER BY d.depends_on_id
+	`, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get external dependencies: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var deps []*types.ExternalDependency
+	for rows.Next() {
+		dep := &types.ExternalDependency{}
+		var status, title sql.NullString
+		var checkedAt sql.NullTime
+		if err := rows.Scan(&dep.DependsOnID, &dep.Type, &status, &title, &checkedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan external dependency: %w", err)
+		}
+		if status.Valid {
+			dep.Issue = &types.ExternalIssue{
+				ID:        dep.DependsOnID,
+				Status:    types.Status(status.String),
+				Title:     title.String,
+				CheckedAt: checkedAt.Time,
+			}
+		}
+		deps = append(deps, dep)
+	}
+	return deps, rows.Err()
+}
+
+// SetExternalIssues records the state of the external issues found in their
+// workspaces, replacing what was known before: an issue left out counts as
+// unresolved, so it blocks. The blocked cache is rebuilt when any status
+// changed.
+func (s *SQLiteStorage) SetExternalIssues(ctx context.Context, issues []*types.ExternalIssue) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		previous := make(map[string]types.Status)
+		rows, err := tx.QueryContext(ctx, `SELECT id, status FROM external_issue_status`)
+		if err != nil {
+			return fmt.Errorf("failed to read external issue status: %w", err)
+		}
+		for rows.Next() {
+			var id string
+			var status types.Status
+			if err := rows.Scan(&id, &status); err != nil {
+				_ = rows.Close()
+				return fmt.Errorf("failed to scan external issue status: %w", err)
+			}
+			previous[id] = status
+		}
+		_ = rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("failed to read external issue status: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM external_issue_status`); err != nil {
+			return fmt.Errorf("failed to clear external issue status: %w", err)
+		}
+		changed := len(previous) != len(issues)
+		for _, issue := range issues {
+			checkedAt := issue.CheckedAt
+			if checkedAt.IsZero() {
+				checkedAt = time.Now()
+			}
+			if _, err := tx.ExecContext(ctx, `
+				INSERT INTO external_issue_status (id, status, title, checked_at) VALUES (?, ?, ?, ?)
+			`, issue.ID, string(issue.Status), issue.Title, checkedAt.UTC()); err != nil {
+				return fmt.Errorf("failed to record external issue %s: %w", issue.ID, err)
+			}
+			if status, ok := previous[issue.ID]; !ok || status != issue.Status {
+				changed = true
+			}
+		}
+		if !changed {
+			return nil
+		}
+		return s.invalidateBlockedCache(ctx, tx)
+	})
+}
@@ -0,0 +1,100 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestExternalDependenciesBlockReadyWork(t *testing.T) {
+	ctx := context.Background()
+	s, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	for _, issue := range []*types.Issue{
+		{ID: "bd-1", Title: "Use the new endpoint", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask},
+		{ID: "bd-2", Title: "Subtask", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+		{ID: "bd-3", Title: "See also", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+	} {
+		if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	for _, dep := range []*types.Dependency{
+		{IssueID: "bd-1", DependsOnID: "api/bd-42", Type: types.DepBlocks},
+		{IssueID: "bd-2", DependsOnID: "bd-1", Type: types.DepParentChild},
+		{IssueID: "bd-3", DependsOnID: "api/bd-7", Type: types.DepRelated},
+	} {
+		if err := s.AddDependency(ctx, dep, "test"); err != nil {
+			t.Fatalf("AddDependency(%s) failed: %v", dep.DependsOnID, err)
+		}
+	}
+	if err := s.AddDependency(ctx, &types.Dependency{IssueID: "bd-3", DependsOnID: "api/bd-1", Type: types.DepParentChild}, "test"); err == nil {
+		t.Errorf("a parent in another workspace should be rejected")
+	}
+
+	readyIDs := func() map[string]bool {
+		t.Helper()
+		issues, err := s.GetReadyWork(ctx, types.WorkFilter{Status: types.StatusOpen})
+		if err != nil {
+			t.Fatalf("GetReadyWork failed: %v", err)
+		}
+		ids := make(map[string]bool)
+		for _, issue := range issues {
+			ids[issue.ID] = true
+		}
+		return ids
+	}
+
+	// Not found in its workspace yet: blocks, and so does the parent's block
+	if ready := readyIDs(); ready["bd-1"] || ready["bd-2"] || !ready["bd-3"] {
+		t.Errorf("with an unresolved blocker, ready = %v; want only bd-3", ready)
+	}
+	blocked, err := s.GetBlockedIssues(ctx)
+	if err != nil {
+		t.Fatalf("GetBlockedIssues failed: %v", err)
+	}
+	if len(blocked) != 1 || blocked[0].ID != "bd-1" || len(blocked[0].BlockedBy) != 1 || blocked[0].BlockedBy[0] != "api/bd-42" {
+		t.Errorf("blocked = %+v", blocked)
+	}
+
+	targets, err := s.ExternalDependencyTargets(ctx)
+	if err != nil || strings.Join(targets, ",") != "api/bd-42,api/bd-7" {
+		t.Errorf("ExternalDependencyTargets = %v, %v", targets, err)
+	}
+
+	if err := s.SetExternalIssues(ctx, []*types.ExternalIssue{{ID: "api/bd-42", Status: types.StatusInProgress, Title: "New endpoint"}}); err != nil {
+		t.Fatal(err)
+	}
+	if ready := readyIDs(); ready["bd-1"] {
+		t.Errorf("an in-progress blocker should still block bd-1")
+	}
+
+	if err := s.SetExternalIssues(ctx, []*types.ExternalIssue{{ID: "api/bd-42", Status: types.StatusClosed, Title: "New endpoint"}}); err != nil {
+		t.Fatal(err)
+	}
+	if ready := readyIDs(); !ready["bd-1"] || !ready["bd-2"] {
+		t.Errorf("after the blocker closed, ready = %v", ready)
+	}
+	deps, err := s.GetExternalDependencies(ctx, "bd-1")
+	if err != nil || len(deps) != 1 || deps[0].Issue == nil || deps[0].Issue.Title != "New endpoint" || deps[0].Blocking() {
+		t.Errorf("GetExternalDependencies = %+v, %v", deps, err)
+	}
+
+	// An issue no longer found (its workspace was unlinked, say) blocks again
+	if err := s.SetExternalIssues(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	if ready := readyIDs(); ready["bd-1"] {
+		t.Errorf("an issue missing from the status cache should block bd-1")
+	}
+
+	if err := s.RemoveDependency(ctx, "bd-1", "api/bd-42", "test"); err != nil {
+		t.Fatalf("RemoveDependency failed: %v", err)
+	}
+	if ready := readyIDs(); !ready["bd-1"] {
+		t.Errorf("removing the dependency should unblock bd-1")
+	}
+}
@@ -2,6 +2,7 @@ package sqlite
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -344,3 +345,116 @@ func TestExternalRefIndexUsage(t *testing.T) {
 		t.Error("Expected query planner to use idx_issues_external_ref index, but it didn't")
 	}
 }
+
+func TestLinkAndFindExternalRefs(t *testing.T) {
+	ctx := context.Background()
+	s, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	for _, id := range []string{"bd-1", "bd-2"} {
+		issue := &types.Issue{ID: id, Title: id, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	if err := s.SetExternalRef(ctx, &ExternalRef{IssueID: "bd-1", System: "github:acme/app", RemoteID: "12", SyncHash: "h1", SyncedAt: time.Now()}); err != nil {
+		t.Fatalf("SetExternalRef failed: %v", err)
+	}
+	// Re-linking the same remote ID keeps the sync state
+	if err := s.LinkExternalRef(ctx, &ExternalRef{IssueID: "bd-1", System: "github:acme/app", RemoteID: "12"}); err != nil {
+		t.Fatalf("LinkExternalRef failed: %v", err)
+	}
+	if err := s.LinkExternalRef(ctx, &ExternalRef{IssueID: "bd-1", System: "jira", RemoteID: "PROJ-7", URL: "https://acme.atlassian.net/browse/PROJ-7"}); err != nil {
+		t.Fatalf("LinkExternalRef failed: %v", err)
+	}
+	if err := s.LinkExternalRef(ctx, &ExternalRef{IssueID: "bd-2", System: "pagerduty", RemoteID: "12"}); err != nil {
+		t.Fatalf("LinkExternalRef failed: %v", err)
+	}
+
+	refs, err := s.GetExternalRefsForIssue(ctx, "bd-1")
+	if err != nil {
+		t.Fatalf("GetExternalRefsForIssue failed: %v", err)
+	}
+	if len(refs) != 2 || refs[0].System != "github:acme/app" || refs[1].System != "jira" {
+		t.Fatalf("unexpected refs for bd-1: %+v", refs)
+	}
+	if refs[0].SyncHash != "h1" {
+		t.Errorf("re-linking the same remote ID reset the sync hash to %q", refs[0].SyncHash)
+	}
+
+	tests := []struct {
+		remoteID, system string
+		want             []string
+	}{
+		{"proj-7", "", []string{"bd-1"}},
+		{"12", "", []string{"bd-1", "bd-2"}},
+		{"12", "github", []string{"bd-1"}},
+		{"12", "github:acme/app", []string{"bd-1"}},
+		{"12", "git", nil},
+		{"12", "pagerduty", []string{"bd-2"}},
+	}
+	for _, tt := range tests {
+		found, err := s.FindExternalRefs(ctx, tt.remoteID, tt.system)
+		if err != nil {
+			t.Fatalf("FindExternalRefs(%q, %q) failed: %v", tt.remoteID, tt.system, err)
+		}
+		var got []string
+		for _, ref := range found {
+			got = append(got, ref.IssueID)
+		}
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("FindExternalRefs(%q, %q) = %v, want %v", tt.remoteID, tt.system, got, tt.want)
+		}
+	}
+
+	// Issues whose external_ref was never recorded in the table are found too
+	linearRef := "https://linear.app/acme/issue/ENG-12/fix-login"
+	issue := &types.Issue{ID: "bd-3", Title: "bd-3", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, ExternalRef: &linearRef}
+	if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	found, err := s.FindExternalRefs(ctx, "eng-12", "linear")
+	if err != nil {
+		t.Fatalf("FindExternalRefs failed: %v", err)
+	}
+	if len(found) != 1 || found[0].IssueID != "bd-3" || found[0].URL != linearRef {
+		t.Errorf("FindExternalRefs(eng-12, linear) = %+v, want bd-3 via external_ref", found)
+	}
+
+	// Pointing the link at a different remote issue resets the sync state
+	if err := s.LinkExternalRef(ctx, &ExternalRef{IssueID: "bd-1", System: "github:acme/app", RemoteID: "13"}); err != nil {
+		t.Fatalf("LinkExternalRef failed: %v", err)
+	}
+	refs, _ = s.GetExternalRefsForIssue(ctx, "bd-1")
+	if refs[0].RemoteID != "13" || refs[0].SyncHash != "" {
+		t.Errorf("relinked ref = %+v, want remote 13 with no sync hash", refs[0])
+	}
+}
+
+func TestParseExternalRef(t *testing.T) {
+	tests := []struct {
+		value, system, remoteID string
+		ok                      bool
+	}{
+		{"https://github.com/acme/app/issues/42", "github:acme/app", "42", true},
+		{"https://github.com/acme/app/pull/7", "github:acme/app", "7", true},
+		{"gh-42", "github", "42", true},
+		{"https://acme.atlassian.net/browse/PROJ-123", "jira", "PROJ-123", true},
+		{"PROJ-123", "jira", "PROJ-123", true},
+		{"https://linear.app/acme/issue/ENG-12/fix-login", "linear", "ENG-12", true},
+		{"https://acme.pagerduty.com/incidents/Q1ABC2", "pagerduty", "Q1ABC2", true},
+		{"linear:ENG-5", "linear", "ENG-5", true},
+		{"github:acme/app:9", "github:acme/app", "9", true},
+		{"https://example.com/ticket/1", "", "", false},
+		{"bd-42", "", "", false},
+		{"", "", "", false},
+	}
+	for _, tt := range tests {
+		system, remoteID, ok := ParseExternalRef(tt.value)
+		if system != tt.system || remoteID != tt.remoteID || ok != tt.ok {
+			t.Errorf("ParseExternalRef(%q) = (%q, %q, %v), want (%q, %q, %v)",
+				tt.value, system, remoteID, ok, tt.system, tt.remoteID, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ExternalRef links an issue to its copy in another tracker
+type ExternalRef struct {
+	IssueID         string     `json:"issue_id"`
+	System          string     `json:"system"`    // e.g. "github:owner/repo", "jira", "pagerduty"
+	RemoteID        string     `json:"remote_id"` // The remote tracker's ID, e.g. the GitHub issue number
+	URL             string     `json:"url,omitempty"`
+	SyncHash        string     `json:"-"` // Hash of the synced fields when both sides last agreed
+	RemoteUpdatedAt *time.Time `json:"remote_updated_at,omitempty"`
+	SyncedAt        time.Time  `json:"synced_at,omitzero"`
+}
+
+// GetExternalRefs returns every link to system, ordered by issue ID
+func (s *SQLiteStorage) GetExternalRefs(ctx context.Context, system string) ([]*ExternalRef, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT issue_id, remote_id, url, sync_hash, remote_updated_at, synced_at
+		FROM external_refs WHERE system = ?
+		ORDER BY issue_id
+	`, system)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query external refs: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var refs []*ExternalRef
+	for rows.Next() {
+		ref := &ExternalRef{System: system}
+		var remoteUpdated sql.NullTime
+		if err := rows.Scan(&ref.IssueID, &ref.RemoteID, &ref.URL, &ref.SyncHash, &remoteUpdated, &ref.SyncedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan external ref: %w", err)
+		}
+		if remoteUpdated.Valid {
+			t := remoteUpdated.Time
+			ref.RemoteUpdatedAt = &t
+		}
+		refs = append(refs, ref)
+	}
+	return refs, rows.Err()
+}
+
+// SetExternalRef records or replaces the link between ref.IssueID and
+// ref.System. An issue has at most one link per system, and a remote ID
+// belongs to at most one issue.
+func (s *SQLiteStorage) SetExternalRef(ctx context.Context, ref *ExternalRef) error {
+	if ref.IssueID == "" || ref.System == "" || ref.RemoteID == "" {
+		return fmt.Errorf("external ref needs an issue ID, system and remote ID")
+	}
+	var remoteUpdated interface{}
+	if ref.RemoteUpdatedAt != nil {
+		remoteUpdated = ref.RemoteUpdatedAt.UTC()
+	}
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO external_refs (issue_id, system, remote_id, url, sync_hash, remote_updated_at, synced_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(issue_id, system) DO UPDATE SET
+			remote_id = excluded.remote_id,
+			url = excluded.url,
+			sync_hash = excluded.sync_hash,
+			remote_updated_at = excluded.remote_updated_at,
+			synced_at = excluded.synced_at
+	`, ref.IssueID, ref.System, ref.RemoteID, ref.URL, ref.SyncHash, remoteUpdated, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("failed to set external ref for %s: %w", ref.IssueID, err)
+	}
+	return nil
+}
+
+// DeleteExternalRef removes an issue's link to system and reports whether
+// there was one
+func (s *SQLiteStorage) DeleteExternalRef(ctx context.Context, issueID, system string) (bool, error) {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM external_refs WHERE issue_id = ? AND system = ?`, issueID, system)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete external ref: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// LinkExternalRef records that ref.IssueID is ref.RemoteID in ref.System
+// without disturbing sync state: an existing link to the same remote ID keeps
+// its sync hash. It is how importers and manual links maintain the table;
+// sync engines use SetExternalRef.
+func (s *SQLiteStorage) LinkExternalRef(ctx context.Context, ref *ExternalRef) error {
+	if ref.IssueID == "" || ref.System == "" || ref.RemoteID == "" {
+		return fmt.Errorf("external ref needs an issue ID, system and remote ID")
+	}
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO external_refs (issue_id, system, remote_id, url, sync_hash, synced_at)
+		VALUES (?, ?, ?, ?, '', ?)
+		ON CONFLICT(issue_id, system) DO UPDATE SET
+			sync_hash = CASE WHEN remote_id = excluded.remote_id THEN sync_hash ELSE '' END,
+			remote_id = excluded.remote_id,
+			url = CASE WHEN excluded.url != '' THEN excluded.url ELSE url END
+	`, ref.IssueID, ref.System, ref.RemoteID, ref.URL, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("failed to link %s to %s %s: %w", ref.IssueID, ref.System, ref.RemoteID, err)
+	}
+	return nil
+}
+
+// GetExternalRefsForIssue returns every link of one issue, ordered by system
+func (s *SQLiteStorage) GetExternalRefsForIssue(ctx context.Context, issueID string) ([]*ExternalRef, error) {
+	return s.queryExternalRefs(ctx, `WHERE issue_id = ? ORDER BY system`, issueID)
+}
+
+// FindExternalRefs returns the links to remoteID (compared case-insensitively,
+// as Jira keys are). A non-empty system narrows the search to that system or,
+// for a bare name such as "github", to any "github:..." system. Issues whose
+// external_ref field parses to a matching link count too, so issues created
+// with --external-ref are found before any import or sync records them.
+func (s *SQLiteStorage) FindExternalRefs(ctx context.Context, remoteID, system string) ([]*ExternalRef, error) {
+	var refs []*ExternalRef
+	var err error
+	if system == "" {
+		refs, err = s.queryExternalRefs(ctx, `WHERE remote_id = ? COLLATE NOCASE ORDER BY system, issue_id`, remoteID)
+	} else {
+		refs, err = s.queryExternalRefs(ctx, `WHERE remote_id = ? COLLATE NOCASE AND (system = ? OR system LIKE ? ESCAPE '\') ORDER BY system, issue_id`,
+			remoteID, system, escapeLike(system)+":%")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	linked := make(map[string]bool, len(refs))
+	for _, ref := range refs {
+		linked[ref.IssueID] = true
+	}
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, external_ref FROM issues
+		WHERE external_ref LIKE ? ESCAPE '\' AND status != 'tombstone'
+		ORDER BY id`, "%"+escapeLike(remoteID)+"%")
+	if err != nil {
+		return nil, fmt.Errorf("failed to search external_ref: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+		var issueID, value string
+		if err := rows.Scan(&issueID, &value); err != nil {
+			return nil, fmt.Errorf("failed to scan external_ref: %w", err)
+		}
+		refSystem, refID, ok := ParseExternalRef(value)
+		if !ok || !strings.EqualFold(refID, remoteID) || linked[issueID] {
+			continue
+		}
+		if system != "" && refSystem != system && !strings.HasPrefix(refSystem, system+":") {
+			continue
+		}
+		ref := &ExternalRef{IssueID: issueID, System: refSystem, RemoteID: refID}
+		if strings.HasPrefix(value, "http") {
+			ref.URL = value
+		}
+		refs = append(refs, ref)
+	}
+	return refs, rows.Err()
+}
+
+func (s *SQLiteStorage) queryExternalRefs(ctx context.Context, where string, args ...interface{}) ([]*ExternalRef, error) {
+	// #nosec G202 -- where is one of the fixed clauses above
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT issue_id, system, remote_id, url, sync_hash, remote_updated_at, synced_at
+		FROM external_refs `+where, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query external refs: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var refs []*ExternalRef
+	for rows.Next() {
+		ref := &ExternalRef{}
+		var remoteUpdated sql.NullTime
+		if err := rows.Scan(&ref.IssueID, &ref.System, &ref.RemoteID, &ref.URL, &ref.SyncHash, &remoteUpdated, &ref.SyncedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan external ref: %w", err)
+		}
+		if remoteUpdated.Valid {
+			t := remoteUpdated.Time
+			ref.RemoteUpdatedAt = &t
+		}
+		refs = append(refs, ref)
+	}
+	return refs, rows.Err()
+}
+
+// escapeLike escapes the LIKE wildcards in s (with \ as the escape character)
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+var (
+	githubIssueURL     = regexp.MustCompile(`^https?://github\.com/([^/\s]+/[^/\s]+)/(?:issues|pull)/(\d+)`)
+	jiraBrowseURL      = regexp.MustCompile(`^https?://[^/\s]+/browse/([A-Za-z][A-Za-z0-9_]*-\d+)`)
+	linearIssueURL     = regexp.MustCompile(`^https?://linear\.app/[^/\s]+/issue/([A-Za-z][A-Za-z0-9]*-\d+)`)
+	pagerdutyURL       = regexp.MustCompile(`^https?://[^/\s]+\.pagerduty\.com/incidents/([A-Za-z0-9]+)`)
+	githubShortRef     = regexp.MustCompile(`^gh-(\d+)$`)
+	jiraKey            = regexp.MustCompile(`^[A-Z][A-Z0-9_]+-\d+$`)
+	systemQualifiedRef = regexp.MustCompile(`^([a-z][a-z0-9_-]*(?::[^\s:]+)?):([^\s:/][^\s]*)$`)
+)
+
+// ParseExternalRef recognizes the external_ref values the importers write
+// (GitHub, Jira, Linear and PagerDuty URLs, "gh-N" and bare Jira keys) and
+// explicit "system:remote-id" pairs, returning the system and remote ID to
+// record in the cross-reference table
+func ParseExternalRef(value string) (system, remoteID string, ok bool) {
+	value = strings.TrimSpace(value)
+	if m := githubIssueURL.FindStringSubmatch(value); m != nil {
+		return "github:" + m[1], m[2], true
+	}
+	if m := linearIssueURL.FindStringSubmatch(value); m != nil {
+		return "linear", strings.ToUpper(m[1]), true
+	}
+	if m := pagerdutyURL.FindStringSubmatch(value); m != nil {
+		return "pagerduty", m[1], true
+	}
+	if m := jiraBrowseURL.FindStringSubmatch(value); m != nil {
+		return "jira", strings.ToUpper(m[1]), true
+	}
+	if m := githubShortRef.FindStringSubmatch(value); m != nil {
+		return "github", m[1], true
+	}
+	if jiraKey.MatchString(value) {
+		return "jira", value, true
+	}
+	if strings.HasPrefix(value, "http:") || strings.HasPrefix(value, "https:") {
+		return "", "", false
+	}
+	if m := systemQualifiedRef.FindStringSubmatch(value); m != nil {
+		return m[1], m[2], true
+	}
+	return "", "", false
+}
@@ -0,0 +1,120 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// SetIssueField stores an issue's value for a custom field, replacing any
+// earlier value; an empty value removes the field from the issue. Values
+// are stored as given: storage.SetIssueFields checks them against the
+// project's field definitions first.
+func (s *SQLiteStorage) SetIssueField(ctx context.Context, issueID, name, value, actor string) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		var exists bool
+		if err := tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM issues WHERE id = ?)`, issueID).Scan(&exists); err != nil {
+			return fmt.Errorf("failed to check issue existence: %w", err)
+		}
+		if !exists {
+			return fmt.Errorf("issue %s not found", issueID)
+		}
+
+		// Setting the value a field already has is a no-op, which keeps
+		// JSONL imports from marking every issue with fields dirty
+		var current string
+		err := tx.QueryRowContext(ctx, `SELECT value FROM issue_fields WHERE issue_id = ? AND name = ?`, issueID, name).Scan(&current)
+		switch {
+		case err == sql.ErrNoRows:
+			if value == "" {
+				return nil
+			}
+		case err != nil:
+			return fmt.Errorf("failed to look up field %s: %w", name, err)
+		case current == value:
+			return nil
+		}
+
+		if value == "" {
+			_, err = tx.ExecContext(ctx, `DELETE FROM issue_fields WHERE issue_id = ? AND name = ?`, issueID, name)
+		} else {
+			_, err = tx.ExecContext(ctx, `
+				INSERT INTO issue_fields (issue_id, name, value) VALUES (?, ?, ?)
+				ON CONFLICT (issue_id, name) DO UPDATE SET value = excluded.value
+			`, issueID, name, value)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to set field %s: %w", name, err)
+		}
+		return markIssueDirtyInTx(ctx, tx, issueID)
+	})
+}
+
+// GetFieldsForIssues fetches custom field values for multiple issues in a
+// single query. Returns a map of issue_id -> field values
+func (s *SQLiteStorage) GetFieldsForIssues(ctx context.Context, issueIDs []string) (map[string]types.CustomFields, error) {
+	result := make(map[string]types.CustomFields)
+	if len(issueIDs) == 0 {
+		return result, nil
+	}
+
+	placeholders := make([]interface{}, len(issueIDs))
+	for i, id := range issueIDs {
+		placeholders[i] = id
+	}
+	query := fmt.Sprintf(`
+		SELECT issue_id, name, value FROM issue_fields
+		WHERE issue_id IN (%s)
+	`, buildPlaceholders(len(issueIDs))) // #nosec G201 -- placeholders are generated internally
+
+	rows, err := s.db.QueryContext(ctx, query, placeholders...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query fields: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var issueID, name, value string
+		if err := rows.Scan(&issueID, &name, &value); err != nil {
+			return nil, fmt.Errorf("failed to scan field: %w", err)
+		}
+		if result[issueID] == nil {
+			result[issueID] = make(types.CustomFields)
+		}
+		result[issueID][name] = value
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating fields: %w", err)
+	}
+	return result, nil
+}
+
+// getIssueFields returns one issue's custom field values for GetIssue
+func (s *SQLiteStorage) getIssueFields(ctx context.Context, issueID string) (types.CustomFields, error) {
+	result, err := s.GetFieldsForIssues(ctx, []string{issueID})
+	if err != nil {
+		return nil, err
+	}
+	return result[issueID], nil
+}
+
+// fieldClauses builds WHERE clauses requiring every given field value of
+// the issue whose ID is in column
+func fieldClauses(column string, fields types.CustomFields) ([]string, []interface{}) {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var clauses []string
+	var args []interface{}
+	for _, name := range names {
+		clauses = append(clauses, column+" IN (SELECT issue_id FROM issue_fields WHERE name = ? AND value = ?)")
+		args = append(args, name, fields[name])
+	}
+	return clauses, args
+}
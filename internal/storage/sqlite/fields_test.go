@@ -0,0 +1,112 @@
+package sqlite
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestIssueFields(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	for _, spec := range []struct{ name, kind string }{{"severity", "enum:low,med,high"}, {"points", "int"}} {
+		def, err := types.ParseFieldDef(spec.name, spec.kind)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := storage.DefineField(ctx, store, def); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, id := range []string{"bd-1", "bd-2"} {
+		issue := &types.Issue{ID: id, Title: "Issue " + id, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.ClearDirtyIssuesByID(ctx, []string{"bd-1", "bd-2"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := storage.SetIssueFields(ctx, store, "bd-1", types.CustomFields{"severity": "HIGH", "points": "03"}, "alice"); err != nil {
+		t.Fatalf("SetIssueFields: %v", err)
+	}
+	if err := storage.SetIssueFields(ctx, store, "bd-2", types.CustomFields{"severity": "low"}, "alice"); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := store.GetIssue(ctx, "bd-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (types.CustomFields{"severity": "high", "points": "3"}); !reflect.DeepEqual(got.Fields, want) {
+		t.Errorf("fields = %v, want %v", got.Fields, want)
+	}
+	dirty, _ := store.GetDirtyIssues(ctx)
+	if len(dirty) != 2 {
+		t.Errorf("dirty issues = %v, want both so the fields are exported", dirty)
+	}
+
+	// Invalid or undefined values are rejected and nothing is stored
+	for _, fields := range []types.CustomFields{
+		{"severity": "urgent"},
+		{"points": "many"},
+		{"owner": "alice"},
+		{"points": "5", "severity": "urgent"},
+	} {
+		if err := storage.SetIssueFields(ctx, store, "bd-1", fields, "alice"); err == nil {
+			t.Errorf("SetIssueFields(%v) succeeded, want error", fields)
+		}
+	}
+	if got, _ := store.GetIssue(ctx, "bd-1"); got.Fields["points"] != "3" {
+		t.Errorf("points changed to %q by a rejected write", got.Fields["points"])
+	}
+
+	// Re-setting a value doesn't mark the issue dirty again
+	if err := store.ClearDirtyIssuesByID(ctx, []string{"bd-1", "bd-2"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SetIssueField(ctx, "bd-1", "severity", "high", "import"); err != nil {
+		t.Fatal(err)
+	}
+	if dirty, _ := store.GetDirtyIssues(ctx); len(dirty) != 0 {
+		t.Errorf("unchanged field marked %v dirty", dirty)
+	}
+
+	results, err := store.SearchIssues(ctx, "", types.IssueFilter{Fields: types.CustomFields{"severity": "high"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].ID != "bd-1" {
+		t.Errorf("severity=high matched %d issues", len(results))
+	}
+	results, err = store.SearchIssues(ctx, "", types.IssueFilter{Fields: types.CustomFields{"severity": "high", "points": "4"}})
+	if err != nil || len(results) != 0 {
+		t.Errorf("severity=high points=4 matched %d issues, %v", len(results), err)
+	}
+
+	// An empty value clears the field, even once its definition is gone
+	if err := store.DeleteConfig(ctx, storage.FieldConfigPrefix+"points"); err != nil {
+		t.Fatal(err)
+	}
+	if err := storage.SetIssueFields(ctx, store, "bd-1", types.CustomFields{"points": ""}, "alice"); err != nil {
+		t.Fatalf("clearing points: %v", err)
+	}
+	all, err := store.GetFieldsForIssues(ctx, []string{"bd-1", "bd-2", "bd-99"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]types.CustomFields{"bd-1": {"severity": "high"}, "bd-2": {"severity": "low"}}
+	if !reflect.DeepEqual(all, want) {
+		t.Errorf("GetFieldsForIssues = %v, want %v", all, want)
+	}
+
+	if err := store.SetIssueField(ctx, "bd-99", "severity", "low", "alice"); err == nil {
+		t.Error("SetIssueField on a missing issue succeeded, want error")
+	}
+}
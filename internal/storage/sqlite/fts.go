@@ -0,0 +1,130 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite/migrations"
+)
+
+// ftsFields maps the field prefixes accepted in search queries to issues_fts
+// columns
+var ftsFields = map[string]string{
+	"title":       "title",
+	"desc":        "description",
+	"description": "description",
+	"comment":     "comments",
+	"comments":    "comments",
+	"attach":      "attachments",
+	"attachment":  "attachments",
+	"attachments": "attachments",
+}
+
+// ftsRank weights bm25 by column (issue_id, title, description, comments,
+// attachments) so a title hit outranks the same word buried in a comment
+const ftsRank = "bm25(issues_fts, 0, 10, 4, 2, 1)"
+
+// ftsMatchQuery translates a bd search query into an FTS5 MATCH expression.
+// Words match as prefixes ("time" finds "timeout"), a trailing * is
+// accepted for the same, "quoted phrases" match exactly, and a field prefix
+// (title:, desc:, comment:, attach:) limits a word or phrase to one field.
+// Terms are ANDed; OR between two terms matches either. Everything else is
+// quoted, so user input can't produce an FTS syntax error. Returns "" when
+// the query has no searchable words.
+func ftsMatchQuery(query string) string {
+	var terms []string
+	pendingOr := false
+	for _, tok := range splitSearchQuery(query) {
+		if tok == "OR" {
+			pendingOr = len(terms) > 0
+			continue
+		}
+		column := ""
+		if i := strings.Index(tok, ":"); i > 0 {
+			if col, ok := ftsFields[strings.ToLower(tok[:i])]; ok {
+				column = col
+				tok = tok[i+1:]
+			}
+		}
+
+		var term string
+		if len(tok) >= 2 && tok[0] == '"' {
+			phrase := strings.TrimSuffix(tok[1:], `"`)
+			if !hasSearchableRune(phrase) {
+				continue
+			}
+			term = `"` + strings.ReplaceAll(phrase, `"`, `""`) + `"`
+		} else {
+			word := strings.TrimRight(tok, "*")
+			if !hasSearchableRune(word) {
+				continue
+			}
+			term = `"` + strings.ReplaceAll(word, `"`, `""`) + `"*`
+		}
+		if column != "" {
+			term = column + " : " + term
+		}
+		if pendingOr {
+			terms = append(terms, "OR")
+			pendingOr = false
+		}
+		terms = append(terms, term)
+	}
+	return strings.Join(terms, " ")
+}
+
+// splitSearchQuery splits a query on whitespace, keeping "quoted phrases"
+// (optionally after a field prefix) together
+func splitSearchQuery(query string) []string {
+	var tokens []string
+	var cur strings.Builder
+	inQuote := false
+	for _, r := range query {
+		switch {
+		case r == '"':
+			inQuote = !inQuote
+			cur.WriteRune(r)
+		case unicode.IsSpace(r) && !inQuote:
+			if cur.Len() > 0 {
+				tokens = append(tokens, cur.String())
+				cur.Reset()
+			}
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	if cur.Len() > 0 {
+		tokens = append(tokens, cur.String())
+	}
+	return tokens
+}
+
+func hasSearchableRune(s string) bool {
+	for _, r := range s {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// RebuildSearchIndex repopulates the full-text index from issues, comments,
+// and attachment text. Triggers keep it current, so this is only needed if
+// the index was damaged or a table rebuild dropped the triggers.
+func (s *SQLiteStorage) RebuildSearchIndex(ctx context.Context) error {
+	if err := migrations.MigrateIssuesFTS(s.db); err != nil {
+		return err
+	}
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM issues_fts`); err != nil {
+			return fmt.Errorf("failed to clear search index: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, migrations.IssuesFTSPopulate); err != nil {
+			return fmt.Errorf("failed to rebuild search index: %w", err)
+		}
+		return nil
+	})
+}
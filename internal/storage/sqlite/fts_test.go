@@ -0,0 +1,105 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestFTSMatchQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"flaky test timeout", `"flaky"* "test"* "timeout"*`},
+		{"time*", `"time"*`},
+		{`"test timeout"`, `"test timeout"`},
+		{"title:flaky desc:timeout", `title : "flaky"* description : "timeout"*`},
+		{`title:"flaky test"`, `title : "flaky test"`},
+		{"comment:retry attach:stacktrace", `comments : "retry"* attachments : "stacktrace"*`},
+		{"login OR auth", `"login"* OR "auth"*`},
+		{"OR login OR", `"login"*`},
+		{"url:http x", `"url:http"* "x"*`},
+		{`a"b - ( )`, `"a""b - ( )"*`},
+		{"-- !!", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := ftsMatchQuery(tt.query); got != tt.want {
+			t.Errorf("ftsMatchQuery(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestFullTextSearch(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	titleHit := &types.Issue{Title: "Flaky test timeout in CI", Status: types.StatusOpen, Priority: 3, IssueType: types.TypeBug}
+	descHit := &types.Issue{Title: "Speed up the suite", Description: "The integration test sometimes hits a timeout; looks flaky.", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	commentHit := &types.Issue{Title: "Investigate CI", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, i := range []*types.Issue{titleHit, descHit, commentHit} {
+		if err := store.CreateIssue(ctx, i, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if _, err := store.AddIssueComment(ctx, commentHit.ID, "alice", "Saw the websocket reconnect loop again"); err != nil {
+		t.Fatalf("AddIssueComment failed: %v", err)
+	}
+
+	ids := func(query string) []string {
+		t.Helper()
+		found, err := store.SearchIssues(ctx, query, types.IssueFilter{})
+		if err != nil {
+			t.Fatalf("SearchIssues(%q) failed: %v", query, err)
+		}
+		var out []string
+		for _, issue := range found {
+			out = append(out, issue.ID)
+		}
+		return out
+	}
+	expect := func(query string, want ...string) {
+		t.Helper()
+		got := ids(query)
+		if len(got) != len(want) {
+			t.Errorf("search %q = %v, want %v", query, got, want)
+			return
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("search %q = %v, want %v", query, got, want)
+				return
+			}
+		}
+	}
+
+	// The title match ranks first despite its lower priority
+	expect("flaky test timeout", titleHit.ID, descHit.ID)
+	expect("time", titleHit.ID, descHit.ID)
+	expect("title:flaky", titleHit.ID)
+	expect("desc:flaky", descHit.ID)
+	expect(`"test timeout"`, titleHit.ID)
+	expect("websock", commentHit.ID)
+	expect("comment:reconnect OR title:suite", descHit.ID, commentHit.ID)
+	expect(commentHit.ID[:len(commentHit.ID)-1], commentHit.ID)
+
+	// Triggers follow updates and deletes
+	if err := store.UpdateIssue(ctx, commentHit.ID, map[string]interface{}{"title": "Investigate quarantine"}, "test-user"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	expect("quarantine", commentHit.ID)
+	expect("title:ci", titleHit.ID)
+	if err := store.DeleteIssue(ctx, titleHit.ID); err != nil {
+		t.Fatalf("DeleteIssue failed: %v", err)
+	}
+	expect("flaky", descHit.ID)
+
+	if err := store.RebuildSearchIndex(ctx); err != nil {
+		t.Fatalf("RebuildSearchIndex failed: %v", err)
+	}
+	expect("websocket", commentHit.ID)
+	expect("flaky", descHit.ID)
+}
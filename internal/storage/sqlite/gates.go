@@ -0,0 +1,214 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Gate states
+const (
+	GatePending = "pending" // not run yet, running, or not reported
+	GateSuccess = "success"
+	GateFailure = "failure"
+)
+
+// Gate holds an issue back from ready work until a CI check passes
+type Gate struct {
+	IssueID   string     `json:"issue_id"`
+	Check     string     `json:"check"`          // check run or commit status name, e.g. "build"
+	Ref       string     `json:"ref"`            // a branch name, or "pull/<n>" for a pull request
+	Repo      string     `json:"repo,omitempty"` // owner/repo; empty means github.repo
+	State     string     `json:"state"`
+	Detail    string     `json:"detail,omitempty"`
+	URL       string     `json:"url,omitempty"`
+	CheckedAt *time.Time `json:"checked_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	CreatedBy string     `json:"created_by,omitempty"`
+}
+
+// PullRequestRef is the gate ref for pull request n
+func PullRequestRef(n int) string {
+	return "pull/" + strconv.Itoa(n)
+}
+
+// PullRequest returns the pull request number of a "pull/<n>" ref
+func (g *Gate) PullRequest() (int, bool) {
+	rest, ok := strings.CutPrefix(g.Ref, "pull/")
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.Atoi(rest)
+	return n, err == nil && n > 0
+}
+
+// String describes the gate as "build on main" or "build on PR #12"
+func (g *Gate) String() string {
+	where := g.Ref
+	if n, ok := g.PullRequest(); ok {
+		where = fmt.Sprintf("PR #%d", n)
+	}
+	if g.Repo != "" {
+		where = g.Repo + " " + where
+	}
+	return g.Check + " on " + where
+}
+
+// AddGate adds a gate to an issue, or resets an existing gate with the same
+// check and ref to pending, and rebuilds the blocked cache so the issue
+// leaves ready work
+func (s *SQLiteStorage) AddGate(ctx context.Context, gate *Gate, actor string) error {
+	if gate.IssueID == "" || gate.Check == "" || gate.Ref == "" {
+		return fmt.Errorf("gate needs an issue ID, check name and ref")
+	}
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		var exists int
+		if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM issues WHERE id = ?`, gate.IssueID).Scan(&exists); err != nil {
+			return fmt.Errorf("failed to check issue: %w", err)
+		}
+		if exists == 0 {
+			return fmt.Errorf("issue %s not found", gate.IssueID)
+		}
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO gates (issue_id, check_name, ref, repo, state, created_at, created_by)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT(issue_id, check_name, ref) DO UPDATE SET
+				repo = excluded.repo, state = excluded.state, detail = '', url = '', checked_at = NULL
+		`, gate.IssueID, gate.Check, gate.Ref, gate.Repo, GatePending, time.Now().UTC(), actor)
+		if err != nil {
+			return fmt.Errorf("failed to add gate to %s: %w", gate.IssueID, err)
+		}
+		return s.invalidateBlockedCache(ctx, tx)
+	})
+}
+
+// RemoveGate removes an issue's gates on check, only the one on ref when
+// ref is set, and reports how many were removed
+func (s *SQLiteStorage) RemoveGate(ctx context.Context, issueID, check, ref string) (int, error) {
+	var removed int64
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		query := `DELETE FROM gates WHERE issue_id = ? AND check_name = ?`
+		args := []interface{}{issueID, check}
+		if ref != "" {
+			query += ` AND ref = ?`
+			args = append(args, ref)
+		}
+		result, err := tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("failed to remove gate: %w", err)
+		}
+		if removed, err = result.RowsAffected(); err != nil || removed == 0 {
+			return err
+		}
+		return s.invalidateBlockedCache(ctx, tx)
+	})
+	return int(removed), err
+}
+
+// GetGates returns an issue's gates, ordered by check and ref
+func (s *SQLiteStorage) GetGates(ctx context.Context, issueID string) ([]*Gate, error) {
+	return s.queryGates(ctx, `WHERE issue_id = ? ORDER BY check_name, ref`, issueID)
+}
+
+// ListGates returns every gate, or with unmetOnly the gates that aren't
+// green on issues that aren't closed: the ones worth polling
+func (s *SQLiteStorage) ListGates(ctx context.Context, unmetOnly bool) ([]*Gate, error) {
+	if unmetOnly {
+		return s.queryGates(ctx, `
+			WHERE state != 'success'
+			  AND issue_id IN (SELECT id FROM issues WHERE status NOT IN ('closed', 'tombstone'))
+			ORDER BY issue_id, check_name, ref`)
+	}
+	return s.queryGates(ctx, `ORDER BY issue_id, check_name, ref`)
+}
+
+func (s *SQLiteStorage) queryGates(ctx context.Context, where string, args ...interface{}) ([]*Gate, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT issue_id, check_name, ref, repo, state, detail, url, checked_at, created_at, created_by
+		FROM gates `+where, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query gates: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var gates []*Gate
+	for rows.Next() {
+		gate := &Gate{}
+		var checked sql.NullTime
+		if err := rows.Scan(&gate.IssueID, &gate.Check, &gate.Ref, &gate.Repo, &gate.State, &gate.Detail,
+			&gate.URL, &checked, &gate.CreatedAt, &gate.CreatedBy); err != nil {
+			return nil, fmt.Errorf("failed to scan gate: %w", err)
+		}
+		if checked.Valid {
+			t := checked.Time
+			gate.CheckedAt = &t
+		}
+		gates = append(gates, gate)
+	}
+	return gates, rows.Err()
+}
+
+// SetGateState records a CI result for every gate on check and ref. A
+// non-empty repo only updates gates on that repository or on the default
+// one. It returns the gates whose state changed, and rebuilds the blocked
+// cache when any did.
+func (s *SQLiteStorage) SetGateState(ctx context.Context, repo, check, ref, state, detail, url string) ([]*Gate, error) {
+	switch state {
+	case GatePending, GateSuccess, GateFailure:
+	default:
+		return nil, fmt.Errorf("invalid gate state %q (want pending, success or failure)", state)
+	}
+	query := `WHERE check_name = ? AND ref = ?`
+	args := []interface{}{check, ref}
+	if repo != "" {
+		query += ` AND (repo = '' OR repo = ?)`
+		args = append(args, repo)
+	}
+	gates, err := s.queryGates(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []*Gate
+	now := time.Now().UTC()
+	err = s.withTx(ctx, func(tx *sql.Tx) error {
+		for _, gate := range gates {
+			if _, err := tx.ExecContext(ctx, `
+				UPDATE gates SET state = ?, detail = ?, url = ?, checked_at = ?
+				WHERE issue_id = ? AND check_name = ? AND ref = ?
+			`, state, detail, url, now, gate.IssueID, gate.Check, gate.Ref); err != nil {
+				return fmt.Errorf("failed to update gate: %w", err)
+			}
+			if gate.State != state {
+				gate.State = state
+				changed = append(changed, gate)
+			}
+			gate.Detail, gate.URL, gate.CheckedAt = detail, url, &now
+		}
+		if len(changed) == 0 {
+			return nil
+		}
+		return s.invalidateBlockedCache(ctx, tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changed, nil
+}
+
+// unmetGates returns, per issue, the descriptions of its gates that aren't
+// green
+func (s *SQLiteStorage) unmetGates(ctx context.Context) (map[string][]string, error) {
+	gates, err := s.queryGates(ctx, `WHERE state != 'success' ORDER BY issue_id, check_name, ref`)
+	if err != nil {
+		return nil, err
+	}
+	unmet := make(map[string][]string)
+	for _, gate := range gates {
+		unmet[gate.IssueID] = append(unmet[gate.IssueID], fmt.Sprintf("%s (%s)", gate, gate.State))
+	}
+	return unmet, nil
+}
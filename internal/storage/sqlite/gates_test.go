@@ -0,0 +1,116 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestGatesBlockReadyWork(t *testing.T) {
+	ctx := context.Background()
+	s, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	for _, issue := range []*types.Issue{
+		{ID: "bd-1", Title: "Deploy", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask},
+		{ID: "bd-2", Title: "Announce", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+		{ID: "bd-3", Title: "Unrelated", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+	} {
+		if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	// bd-2 waits on bd-1 through a parent-child link, so it inherits the gate
+	if err := s.AddDependency(ctx, &types.Dependency{IssueID: "bd-2", DependsOnID: "bd-1", Type: types.DepParentChild}, "test"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+
+	readyIDs := func() map[string]bool {
+		t.Helper()
+		issues, err := s.GetReadyWork(ctx, types.WorkFilter{Status: types.StatusOpen})
+		if err != nil {
+			t.Fatalf("GetReadyWork failed: %v", err)
+		}
+		ids := make(map[string]bool)
+		for _, issue := range issues {
+			ids[issue.ID] = true
+		}
+		return ids
+	}
+
+	if err := s.AddGate(ctx, &Gate{IssueID: "bd-1", Check: "build", Ref: "main"}, "test"); err != nil {
+		t.Fatalf("AddGate failed: %v", err)
+	}
+	if err := s.AddGate(ctx, &Gate{IssueID: "bd-99", Check: "build", Ref: "main"}, "test"); err == nil {
+		t.Errorf("AddGate on a missing issue should fail")
+	}
+	if ready := readyIDs(); ready["bd-1"] || ready["bd-2"] || !ready["bd-3"] {
+		t.Errorf("with a pending gate, ready = %v; want only bd-3", ready)
+	}
+
+	blocked, err := s.GetBlockedIssues(ctx)
+	if err != nil {
+		t.Fatalf("GetBlockedIssues failed: %v", err)
+	}
+	if len(blocked) != 1 || blocked[0].ID != "bd-1" || len(blocked[0].BlockedByGates) != 1 || blocked[0].BlockedByGates[0] != "build on main (pending)" {
+		t.Errorf("blocked = %+v", blocked)
+	}
+
+	// Results for another check, ref or repository don't apply
+	if changed, err := s.SetGateState(ctx, "", "lint", "main", GateSuccess, "", ""); err != nil || len(changed) != 0 {
+		t.Errorf("SetGateState(lint) = %v, %v", changed, err)
+	}
+	if changed, err := s.SetGateState(ctx, "", "build", PullRequestRef(7), GateSuccess, "", ""); err != nil || len(changed) != 0 {
+		t.Errorf("SetGateState(pull/7) = %v, %v", changed, err)
+	}
+	if _, err := s.SetGateState(ctx, "", "build", "main", "green", "", ""); err == nil {
+		t.Errorf("SetGateState should reject unknown states")
+	}
+
+	changed, err := s.SetGateState(ctx, "acme/app", "build", "main", GateSuccess, "passed", "https://ci/1")
+	if err != nil || len(changed) != 1 || changed[0].IssueID != "bd-1" {
+		t.Fatalf("SetGateState(build) = %v, %v", changed, err)
+	}
+	if ready := readyIDs(); !ready["bd-1"] || !ready["bd-2"] {
+		t.Errorf("after the check passed, ready = %v", ready)
+	}
+	if unmet, err := s.ListGates(ctx, true); err != nil || len(unmet) != 0 {
+		t.Errorf("ListGates(unmet) = %v, %v", unmet, err)
+	}
+	gates, err := s.GetGates(ctx, "bd-1")
+	if err != nil || len(gates) != 1 || gates[0].State != GateSuccess || gates[0].URL != "https://ci/1" || gates[0].CheckedAt == nil {
+		t.Errorf("GetGates = %+v, %v", gates, err)
+	}
+
+	// A failure on a later run blocks again
+	if _, err := s.SetGateState(ctx, "", "build", "main", GateFailure, "", ""); err != nil {
+		t.Fatal(err)
+	}
+	if ready := readyIDs(); ready["bd-1"] {
+		t.Errorf("failed gate should block bd-1")
+	}
+
+	if n, err := s.RemoveGate(ctx, "bd-1", "build", ""); err != nil || n != 1 {
+		t.Fatalf("RemoveGate = %d, %v", n, err)
+	}
+	if ready := readyIDs(); !ready["bd-1"] {
+		t.Errorf("removing the gate should unblock bd-1")
+	}
+}
+
+func TestGateString(t *testing.T) {
+	tests := []struct {
+		gate Gate
+		want string
+	}{
+		{Gate{Check: "build", Ref: "main"}, "build on main"},
+		{Gate{Check: "ci/test", Ref: PullRequestRef(12)}, "ci/test on PR #12"},
+		{Gate{Check: "build", Ref: "release", Repo: "acme/app"}, "build on acme/app release"},
+	}
+	for _, tt := range tests {
+		if got := tt.gate.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
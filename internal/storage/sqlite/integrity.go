@@ -0,0 +1,261 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Config keys for strict referential integrity mode
+const (
+	// IntegrityStrictConfigKey enables strict referential integrity ("true"/"false")
+	IntegrityStrictConfigKey = "integrity.strict"
+	// IntegrityAssigneesConfigKey is an optional comma-separated roster of valid
+	// assignees. Only enforced when strict mode is enabled.
+	IntegrityAssigneesConfigKey = "integrity.assignees"
+)
+
+// Integrity violation kinds reported by FindIntegrityViolations
+const (
+	ViolationMissingIssue     = "missing_issue"      // Row's issue_id references a missing issue
+	ViolationMissingDependsOn = "missing_depends_on" // Dependency target references a missing issue
+	ViolationUnknownAssignee  = "unknown_assignee"   // Assignee is not in the configured roster
+)
+
+// IntegrityViolation describes a single row that breaks referential integrity
+type IntegrityViolation struct {
+	Table   string `json:"table"`
+	Kind    string `json:"kind"`
+	IssueID string `json:"issue_id"`
+	Ref     string `json:"ref"` // The dangling reference (missing ID or unknown assignee)
+}
+
+// danglingRefQueries find rows referencing issues that no longer exist.
+// Each query returns (issue_id, ref) pairs.
+var danglingRefQueries = []struct {
+	table string
+	kind  string
+	query string
+}{
+	{"dependencies", ViolationMissingIssue, `
+		SELECT d.issue_id, d.issue_id FROM dependencies d
+		WHERE NOT EXISTS (SELECT 1 FROM issues i WHERE i.id = d.issue_id)`},
+	{"dependencies", ViolationMissingDependsOn, `
+		SELECT d.issue_id, d.depends_on_id FROM dependencies d
+		WHERE instr(d.depends_on_id, '/') = 0
+		  AND NOT EXISTS (SELECT 1 FROM issues i WHERE i.id = d.depends_on_id)`},
+	{"labels", ViolationMissingIssue, `
+		SELECT l.issue_id, l.label FROM labels l
+		WHERE NOT EXISTS (SELECT 1 FROM issues i WHERE i.id = l.issue_id)`},
+	{"comments", ViolationMissingIssue, `
+		SELECT c.issue_id, CAST(c.id AS TEXT) FROM comments c
+		WHERE NOT EXISTS (SELECT 1 FROM issues i WHERE i.id = c.issue_id)`},
+	{"events", ViolationMissingIssue, `
+		SELECT e.issue_id, CAST(e.id AS TEXT) FROM events e
+		WHERE NOT EXISTS (SELECT 1 FROM issues i WHERE i.id = e.issue_id)`},
+	{"issue_aliases", ViolationMissingIssue, `
+		SELECT a.issue_id, a.alias FROM issue_aliases a
+		WHERE NOT EXISTS (SELECT 1 FROM issues i WHERE i.id = a.issue_id)`},
+	{"watches", ViolationMissingIssue, `
+		SELECT w.issue_id, w.watcher FROM watches w
+		WHERE w.issue_id != '' AND NOT EXISTS (SELECT 1 FROM issues i WHERE i.id = w.issue_id)`},
+	{"external_refs", ViolationMissingIssue, `
+		SELECT r.issue_id, r.system || ' ' || r.remote_id FROM external_refs r
+		WHERE NOT EXISTS (SELECT 1 FROM issues i WHERE i.id = r.issue_id)`},
+	{"work_log", ViolationMissingIssue, `
+		SELECT w.issue_id, CAST(w.id AS TEXT) FROM work_log w
+		WHERE NOT EXISTS (SELECT 1 FROM issues i WHERE i.id = w.issue_id)`},
+	{"issue_fields", ViolationMissingIssue, `
+		SELECT f.issue_id, f.name FROM issue_fields f
+		WHERE NOT EXISTS (SELECT 1 FROM issues i WHERE i.id = f.issue_id)`},
+	{"event_summaries", ViolationMissingIssue, `
+		SELECT s.issue_id, s.issue_id FROM event_summaries s
+		WHERE NOT EXISTS (SELECT 1 FROM issues i WHERE i.id = s.issue_id)`},
+	{"issue_leases", ViolationMissingIssue, `
+		SELECT l.issue_id, l.holder FROM issue_leases l
+		WHERE NOT EXISTS (SELECT 1 FROM issues i WHERE i.id = l.issue_id)`},
+	{"status_transitions", ViolationMissingIssue, `
+		SELECT t.issue_id, CAST(t.id AS TEXT) FROM status_transitions t
+		WHERE NOT EXISTS (SELECT 1 FROM issues i WHERE i.id = t.issue_id)`},
+}
+
+// danglingRefRepairs delete the rows found by danglingRefQueries. Surviving
+// issues that lose a dependency are marked dirty first so the next export
+// drops the dangling edge from JSONL too.
+var danglingRefRepairs = []string{
+	`INSERT OR IGNORE INTO dirty_issues (issue_id, marked_at)
+	 SELECT DISTINCT issue_id, CURRENT_TIMESTAMP FROM dependencies
+	 WHERE depends_on_id NOT IN (SELECT id FROM issues) AND instr(depends_on_id, '/') = 0
+	   AND issue_id IN (SELECT id FROM issues)`,
+	`DELETE FROM dependencies WHERE issue_id NOT IN (SELECT id FROM issues)
+	   OR (depends_on_id NOT IN (SELECT id FROM issues) AND instr(depends_on_id, '/') = 0)`,
+	`DELETE FROM labels WHERE issue_id NOT IN (SELECT id FROM issues)`,
+	`DELETE FROM comments WHERE issue_id NOT IN (SELECT id FROM issues)`,
+	`DELETE FROM attachments WHERE issue_id NOT IN (SELECT id FROM issues)`,
+	`DELETE FROM issue_aliases WHERE issue_id NOT IN (SELECT id FROM issues)`,
+	`DELETE FROM watches WHERE issue_id != '' AND issue_id NOT IN (SELECT id FROM issues)`,
+	`DELETE FROM external_refs WHERE issue_id NOT IN (SELECT id FROM issues)`,
+	`DELETE FROM work_log WHERE issue_id NOT IN (SELECT id FROM issues)`,
+	`DELETE FROM issue_fields WHERE issue_id NOT IN (SELECT id FROM issues)`,
+	`DELETE FROM events WHERE issue_id NOT IN (SELECT id FROM issues)`,
+	`DELETE FROM event_summaries WHERE issue_id NOT IN (SELECT id FROM issues)`,
+	`DELETE FROM issue_leases WHERE issue_id NOT IN (SELECT id FROM issues)`,
+	`DELETE FROM status_transitions WHERE issue_id NOT IN (SELECT id FROM issues)`,
+}
+
+// IsStrictIntegrity reports whether integrity.strict is enabled
+func (s *SQLiteStorage) IsStrictIntegrity(ctx context.Context) bool {
+	value, err := s.GetConfig(ctx, IntegrityStrictConfigKey)
+	if err != nil {
+		return false
+	}
+	return parseBoolConfig(value)
+}
+
+// FindIntegrityViolations returns all referential integrity violations in the database
+func (s *SQLiteStorage) FindIntegrityViolations(ctx context.Context) ([]IntegrityViolation, error) {
+	return FindIntegrityViolations(ctx, s.db)
+}
+
+// RepairIntegrityViolations deletes rows that reference missing issues
+func (s *SQLiteStorage) RepairIntegrityViolations(ctx context.Context) (int64, error) {
+	return RepairIntegrityViolations(ctx, s.db)
+}
+
+// FindIntegrityViolations scans db for rows that reference missing issues and,
+// when an assignee roster is configured, for assignees outside the roster.
+// It takes a raw *sql.DB so bd doctor can run it against a read-only handle.
+func FindIntegrityViolations(ctx context.Context, db *sql.DB) ([]IntegrityViolation, error) {
+	var violations []IntegrityViolation
+
+	for _, q := range danglingRefQueries {
+		rows, err := db.QueryContext(ctx, q.query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check %s: %w", q.table, err)
+		}
+		for rows.Next() {
+			v := IntegrityViolation{Table: q.table, Kind: q.kind}
+			if err := rows.Scan(&v.IssueID, &v.Ref); err != nil {
+				_ = rows.Close()
+				return nil, fmt.Errorf("failed to scan %s violation: %w", q.table, err)
+			}
+			violations = append(violations, v)
+		}
+		if err := rows.Err(); err != nil {
+			_ = rows.Close()
+			return nil, err
+		}
+		_ = rows.Close()
+	}
+
+	var rosterValue string
+	err := db.QueryRowContext(ctx, `SELECT value FROM config WHERE key = ?`, IntegrityAssigneesConfigKey).Scan(&rosterValue)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to read %s: %w", IntegrityAssigneesConfigKey, err)
+	}
+	roster := parseConfigList(rosterValue)
+	if len(roster) == 0 {
+		return violations, nil
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, assignee FROM issues
+		WHERE assignee IS NOT NULL AND assignee != '' AND status != 'tombstone'
+		ORDER BY id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check assignees: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+		var id, assignee string
+		if err := rows.Scan(&id, &assignee); err != nil {
+			return nil, fmt.Errorf("failed to scan assignee: %w", err)
+		}
+		if !slices.Contains(roster, assignee) {
+			violations = append(violations, IntegrityViolation{
+				Table:   "issues",
+				Kind:    ViolationUnknownAssignee,
+				IssueID: id,
+				Ref:     assignee,
+			})
+		}
+	}
+	return violations, rows.Err()
+}
+
+// RepairIntegrityViolations deletes dependencies, labels, comments, and events
+// that reference missing issues, returning the number of rows removed.
+// Unknown assignees are left alone; they need a human decision.
+func RepairIntegrityViolations(ctx context.Context, db *sql.DB) (int64, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var removed int64
+	for _, stmt := range danglingRefRepairs {
+		result, err := tx.ExecContext(ctx, stmt)
+		if err != nil {
+			return 0, fmt.Errorf("failed to remove dangling rows: %w", err)
+		}
+		if strings.HasPrefix(stmt, "DELETE") {
+			n, _ := result.RowsAffected()
+			removed += n
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit repair: %w", err)
+	}
+	return removed, nil
+}
+
+// assigneeRoster returns the roster of allowed assignees when strict integrity
+// is enabled and a roster is configured, or nil when assignees are unrestricted.
+func assigneeRoster(ctx context.Context, getConfig func(context.Context, string) (string, error)) ([]string, error) {
+	strict, err := getConfig(ctx, IntegrityStrictConfigKey)
+	if err != nil {
+		return nil, err
+	}
+	if !parseBoolConfig(strict) {
+		return nil, nil
+	}
+	value, err := getConfig(ctx, IntegrityAssigneesConfigKey)
+	if err != nil {
+		return nil, err
+	}
+	return parseConfigList(value), nil
+}
+
+// validateAssigneeInRoster rejects assignees outside a non-empty roster
+func validateAssigneeInRoster(assignee string, roster []string) error {
+	if assignee == "" || len(roster) == 0 || slices.Contains(roster, assignee) {
+		return nil
+	}
+	return fmt.Errorf("assignee %q is not in %s (strict integrity mode)", assignee, IntegrityAssigneesConfigKey)
+}
+
+// parseConfigList splits a comma-separated config value such as
+// integrity.assignees into its trimmed, non-empty entries
+func parseConfigList(value string) []string {
+	var result []string
+	for _, part := range strings.Split(value, ",") {
+		if trimmed := strings.TrimSpace(part); trimmed != "" {
+			result = append(result, trimmed)
+		}
+	}
+	return result
+}
+
+// parseBoolConfig interprets a config string as a boolean
+func parseBoolConfig(value string) bool {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "true", "1", "yes", "on":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,149 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestStrictIntegrityAssigneeRoster(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	if err := store.SetConfig(ctx, IntegrityAssigneesConfigKey, "alice, bob"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+
+	// Roster is ignored until strict mode is on
+	loose := &types.Issue{Title: "Loose", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, Assignee: "mallory"}
+	if err := store.CreateIssue(ctx, loose, "test"); err != nil {
+		t.Fatalf("CreateIssue without strict mode failed: %v", err)
+	}
+
+	if err := store.SetConfig(ctx, IntegrityStrictConfigKey, "true"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	if !store.IsStrictIntegrity(ctx) {
+		t.Fatal("IsStrictIntegrity() = false, want true")
+	}
+
+	rejected := &types.Issue{Title: "Rejected", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, Assignee: "mallory"}
+	err := store.CreateIssue(ctx, rejected, "test")
+	if err == nil || !strings.Contains(err.Error(), IntegrityAssigneesConfigKey) {
+		t.Fatalf("CreateIssue with unknown assignee: got %v, want roster error", err)
+	}
+
+	accepted := &types.Issue{Title: "Accepted", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, Assignee: "alice"}
+	if err := store.CreateIssue(ctx, accepted, "test"); err != nil {
+		t.Fatalf("CreateIssue with rostered assignee failed: %v", err)
+	}
+
+	if err := store.UpdateIssue(ctx, accepted.ID, map[string]interface{}{"assignee": "mallory"}, "test"); err == nil || !strings.HasPrefix(err.Error(), "validate assignee: ") {
+		t.Errorf("UpdateIssue to unknown assignee: got %v, want wrapped roster error", err)
+	}
+	if err := store.UpdateIssue(ctx, accepted.ID, map[string]interface{}{"assignee": "bob"}, "test"); err != nil {
+		t.Errorf("UpdateIssue to rostered assignee failed: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, accepted.ID, map[string]interface{}{"assignee": ""}, "test"); err != nil {
+		t.Errorf("UpdateIssue clearing assignee failed: %v", err)
+	}
+
+	// The pre-existing issue is reported as a legacy violation
+	violations, err := store.FindIntegrityViolations(ctx)
+	if err != nil {
+		t.Fatalf("FindIntegrityViolations failed: %v", err)
+	}
+	if len(violations) != 1 || violations[0].Kind != ViolationUnknownAssignee || violations[0].IssueID != loose.ID {
+		t.Errorf("violations = %+v, want one unknown assignee on %s", violations, loose.ID)
+	}
+}
+
+func TestFindAndRepairIntegrityViolations(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Survivor", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	// Simulate a legacy database written without foreign key enforcement
+	conn, err := store.db.Conn(ctx)
+	if err != nil {
+		t.Fatalf("Conn failed: %v", err)
+	}
+	for _, stmt := range []string{
+		`PRAGMA foreign_keys = OFF`,
+		`INSERT INTO dependencies (issue_id, depends_on_id, type, created_by) VALUES ('` + issue.ID + `', 'bd-gone', 'blocks', 'test')`,
+		`INSERT INTO labels (issue_id, label) VALUES ('bd-ghost', 'stale')`,
+		`INSERT INTO comments (issue_id, author, text) VALUES ('bd-ghost', 'test', 'orphaned')`,
+		`PRAGMA foreign_keys = ON`,
+	} {
+		if _, err := conn.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("exec %q failed: %v", stmt, err)
+		}
+	}
+	_ = conn.Close()
+
+	violations, err := store.FindIntegrityViolations(ctx)
+	if err != nil {
+		t.Fatalf("FindIntegrityViolations failed: %v", err)
+	}
+	kinds := make(map[string]int)
+	for _, v := range violations {
+		kinds[v.Table+"/"+v.Kind]++
+	}
+	want := map[string]int{
+		"dependencies/" + ViolationMissingDependsOn: 1,
+		"labels/" + ViolationMissingIssue:           1,
+		"comments/" + ViolationMissingIssue:         1,
+	}
+	for key, n := range want {
+		if kinds[key] != n {
+			t.Errorf("violations[%s] = %d, want %d (all: %+v)", key, kinds[key], n, violations)
+		}
+	}
+
+	removed, err := store.RepairIntegrityViolations(ctx)
+	if err != nil {
+		t.Fatalf("RepairIntegrityViolations failed: %v", err)
+	}
+	if removed != 3 {
+		t.Errorf("removed = %d, want 3", removed)
+	}
+
+	violations, err = store.FindIntegrityViolations(ctx)
+	if err != nil {
+		t.Fatalf("FindIntegrityViolations after repair failed: %v", err)
+	}
+	if len(violations) != 0 {
+		t.Errorf("violations after repair = %+v, want none", violations)
+	}
+
+	// The surviving issue lost an edge, so it must be re-exported
+	dirty, err := store.GetDirtyIssues(ctx)
+	if err != nil {
+		t.Fatalf("GetDirtyIssues failed: %v", err)
+	}
+	found := false
+	for _, id := range dirty {
+		if id == issue.ID {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("dirty issues = %v, want %s marked dirty", dirty, issue.ID)
+	}
+}
+
+func TestParseConfigList(t *testing.T) {
+	got := parseConfigList(" alice, ,bob ,")
+	if len(got) != 2 || got[0] != "alice" || got[1] != "bob" {
+		t.Errorf("parseConfigList = %q, want [alice bob]", got)
+	}
+	if got := parseConfigList(""); got != nil {
+		t.Errorf("parseConfigList(\"\") = %q, want nil", got)
+	}
+}
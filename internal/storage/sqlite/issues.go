@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -20,8 +21,23 @@ func isUniqueConstraintError(err error) bool {
 		strings.Contains(errMsg, "constraint failed: UNIQUE")
 }
 
+// normalizeTimestamps converts the issue's timestamps to UTC before they are
+// stored. Times are stored as text, so mixing offsets would break ordering
+// and range filters; display code converts back to the user's zone.
+func normalizeTimestamps(issue *types.Issue) {
+	issue.CreatedAt = issue.CreatedAt.UTC()
+	issue.UpdatedAt = issue.UpdatedAt.UTC()
+	for _, t := range []*time.Time{issue.ClosedAt, issue.DeletedAt} {
+		if t != nil {
+			*t = t.UTC()
+		}
+	}
+}
+
 // insertIssue inserts a single issue into the database
 func insertIssue(ctx context.Context, conn *sql.Conn, issue *types.Issue) error {
+	normalizeTimestamps(issue)
+
 	sourceRepo := issue.SourceRepo
 	if sourceRepo == "" {
 		sourceRepo = "." // Default to primary repo
@@ -38,8 +54,8 @@ func insertIssue(ctx context.Context, conn *sql.Conn, issue *types.Issue) error
 			status, priority, issue_type, assignee, estimated_minutes,
 			created_at, updated_at, closed_at, external_ref, source_repo, close_reason,
 			deleted_at, deleted_by, delete_reason, original_type,
-			sender, ephemeral
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			sender, ephemeral, complexity, source, timebox_minutes
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`,
 		issue.ID, issue.ContentHash, issue.Title, issue.Description, issue.Design,
 		issue.AcceptanceCriteria, issue.Notes, issue.Status,
@@ -47,7 +63,7 @@ func insertIssue(ctx context.Context, conn *sql.Conn, issue *types.Issue) error
 		issue.EstimatedMinutes, issue.CreatedAt, issue.UpdatedAt,
 		issue.ClosedAt, issue.ExternalRef, sourceRepo, issue.CloseReason,
 		issue.DeletedAt, issue.DeletedBy, issue.DeleteReason, issue.OriginalType,
-		issue.Sender, ephemeral,
+		issue.Sender, ephemeral, issue.Complexity, issue.Source, issue.TimeboxMinutes,
 	)
 	if err != nil {
 		// INSERT OR IGNORE should handle duplicates, but driver may still return error
@@ -68,8 +84,8 @@ func insertIssues(ctx context.Context, conn *sql.Conn, issues []*types.Issue) er
 			status, priority, issue_type, assignee, estimated_minutes,
 			created_at, updated_at, closed_at, external_ref, source_repo, close_reason,
 			deleted_at, deleted_by, delete_reason, original_type,
-			sender, ephemeral
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			sender, ephemeral, complexity, source, timebox_minutes
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`)
 	if err != nil {
 		return fmt.Errorf("failed to prepare statement: %w", err)
@@ -77,6 +93,8 @@ func insertIssues(ctx context.Context, conn *sql.Conn, issues []*types.Issue) er
 	defer func() { _ = stmt.Close() }()
 
 	for _, issue := range issues {
+		normalizeTimestamps(issue)
+
 		sourceRepo := issue.SourceRepo
 		if sourceRepo == "" {
 			sourceRepo = "." // Default to primary repo
@@ -94,7 +112,7 @@ func insertIssues(ctx context.Context, conn *sql.Conn, issues []*types.Issue) er
 			issue.EstimatedMinutes, issue.CreatedAt, issue.UpdatedAt,
 			issue.ClosedAt, issue.ExternalRef, sourceRepo, issue.CloseReason,
 			issue.DeletedAt, issue.DeletedBy, issue.DeleteReason, issue.OriginalType,
-			issue.Sender, ephemeral,
+			issue.Sender, ephemeral, issue.Complexity, issue.Source, issue.TimeboxMinutes,
 		)
 		if err != nil {
 			// INSERT OR IGNORE should handle duplicates, but driver may still return error
@@ -47,7 +47,7 @@ func (s *SQLiteStorage) executeLabelOperation(
 			INSERT INTO dirty_issues (issue_id, marked_at)
 			VALUES (?, ?)
 			ON CONFLICT (issue_id) DO UPDATE SET marked_at = excluded.marked_at
-		`, issueID, time.Now())
+		`, issueID, time.Now().UTC())
 		if err != nil {
 			return fmt.Errorf("failed to mark issue dirty: %w", err)
 		}
@@ -58,6 +58,9 @@ func (s *SQLiteStorage) executeLabelOperation(
 
 // AddLabel adds a label to an issue
 func (s *SQLiteStorage) AddLabel(ctx context.Context, issueID, label, actor string) error {
+	if err := validateUTF8("label", label); err != nil {
+		return err
+	}
 	return s.executeLabelOperation(
 		ctx, issueID, actor,
 		`INSERT OR IGNORE INTO labels (issue_id, label) VALUES (?, ?)`,
@@ -159,7 +162,7 @@ func (s *SQLiteStorage) GetIssuesByLabel(ctx context.Context, label string) ([]*
 		       i.status, i.priority, i.issue_type, i.assignee, i.estimated_minutes,
 		       i.created_at, i.updated_at, i.closed_at, i.external_ref, i.source_repo, i.close_reason,
 		       i.deleted_at, i.deleted_by, i.delete_reason, i.original_type,
-		       i.sender, i.ephemeral
+		       i.sender, i.ephemeral, i.complexity, i.source, i.timebox_minutes
 		FROM issues i
 		JOIN labels l ON i.id = l.issue_id
 		WHERE l.label = ?
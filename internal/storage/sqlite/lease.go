@@ -0,0 +1,254 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/timefmt"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// LeaseExpiryActor is the actor recorded when an expired lease hands its
+// issue back
+const LeaseExpiryActor = "lease-expiry"
+
+// LeaseConflictError reports that an issue is claimed, or in progress
+// without a lease, by someone else. It wraps ErrConflict.
+type LeaseConflictError struct {
+	IssueID   string
+	Holder    string
+	ExpiresAt *time.Time // nil if the claim doesn't expire
+}
+
+func (e *LeaseConflictError) Error() string {
+	if e.ExpiresAt == nil {
+		return fmt.Sprintf("%s is claimed by %s", e.IssueID, e.Holder)
+	}
+	// The message reaches people (also through the daemon), so the expiry is
+	// shown per time.zone and time.format like other CLI output
+	f, err := timefmt.FromConfig()
+	if err != nil {
+		f, _ = timefmt.New("", "")
+	}
+	return fmt.Sprintf("%s is claimed by %s until %s", e.IssueID, e.Holder, f.Format(*e.ExpiresAt))
+}
+
+func (e *LeaseConflictError) Unwrap() error { return ErrConflict }
+
+// ClaimIssue claims an issue for actor: it becomes in progress and assigned
+// to actor, with a lease expiring after ttl (0 for no expiry). The check and
+// the claim happen in one IMMEDIATE transaction, so of two agents claiming
+// the same issue exactly one succeeds; the other gets a LeaseConflictError.
+// Claiming an issue actor already holds renews the lease. force takes the
+// issue from another holder.
+func (s *SQLiteStorage) ClaimIssue(ctx context.Context, id, actor string, ttl time.Duration, force bool) (*types.Lease, error) {
+	if actor == "" {
+		return nil, fmt.Errorf("actor is required to claim work")
+	}
+	now := time.Now().UTC()
+	var lease *types.Lease
+	err := s.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		t := tx.(*sqliteTxStorage)
+		issue, err := t.GetIssue(ctx, id)
+		if err != nil {
+			return err
+		}
+		if issue == nil {
+			return fmt.Errorf("issue %s: %w", id, ErrNotFound)
+		}
+		switch issue.Status {
+		case types.StatusClosed, types.StatusResolved, types.StatusTombstone:
+			return fmt.Errorf("cannot claim %s: it is %s", id, issue.Status)
+		}
+		current, err := getLease(ctx, t.conn, id)
+		if err != nil {
+			return err
+		}
+		if !force {
+			if current != nil && current.Holder != actor && !current.Expired(now) {
+				return &LeaseConflictError{IssueID: id, Holder: current.Holder, ExpiresAt: current.ExpiresAt}
+			}
+			if current == nil && issue.Status == types.StatusInProgress && issue.Assignee != "" && issue.Assignee != actor {
+				return &LeaseConflictError{IssueID: id, Holder: issue.Assignee}
+			}
+		}
+
+		lease = &types.Lease{IssueID: id, Holder: actor, ClaimedAt: now}
+		if current != nil && current.Holder == actor && !current.Expired(now) {
+			lease.ClaimedAt = current.ClaimedAt
+		}
+		if ttl > 0 {
+			expires := now.Add(ttl)
+			lease.ExpiresAt = &expires
+		}
+		return t.takeLease(ctx, issue, lease)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim %s: %w", id, err)
+	}
+	return lease, nil
+}
+
+// takeLease puts issue in progress for the lease holder and records the
+// lease. Changing the assignee drops any other holder's lease (see the
+// issue_leases_table migration).
+func (t *sqliteTxStorage) takeLease(ctx context.Context, issue *types.Issue, lease *types.Lease) error {
+	updates := make(map[string]interface{})
+	if issue.Status != types.StatusInProgress {
+		updates["status"] = string(types.StatusInProgress)
+	}
+	if issue.Assignee != lease.Holder {
+		updates["assignee"] = lease.Holder
+	}
+	if len(updates) > 0 {
+		if err := t.UpdateIssue(ctx, issue.ID, updates, lease.Holder); err != nil {
+			return err
+		}
+	}
+	_, err := t.conn.ExecContext(ctx, `
+		INSERT INTO issue_leases (issue_id, holder, claimed_at, expires_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT (issue_id) DO UPDATE SET
+			holder = excluded.holder,
+			claimed_at = excluded.claimed_at,
+			expires_at = excluded.expires_at
+	`, lease.IssueID, lease.Holder, lease.ClaimedAt, lease.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to record lease: %w", err)
+	}
+	return nil
+}
+
+// ReleaseClaim gives up actor's claim on an issue, which goes back to open
+// and unassigned. Releasing someone else's claim takes force.
+func (s *SQLiteStorage) ReleaseClaim(ctx context.Context, id, actor string, force bool) error {
+	err := s.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		t := tx.(*sqliteTxStorage)
+		issue, err := t.GetIssue(ctx, id)
+		if err != nil {
+			return err
+		}
+		if issue == nil {
+			return fmt.Errorf("issue %s: %w", id, ErrNotFound)
+		}
+		current, err := getLease(ctx, t.conn, id)
+		if err != nil {
+			return err
+		}
+		holder := issue.Assignee
+		if current != nil {
+			holder = current.Holder
+		}
+		if current == nil && issue.Status != types.StatusInProgress {
+			return fmt.Errorf("%s is not claimed", id)
+		}
+		if holder != actor && !force {
+			return &LeaseConflictError{IssueID: id, Holder: holder}
+		}
+		return t.releaseLease(ctx, issue, actor)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to release %s: %w", id, err)
+	}
+	return nil
+}
+
+// releaseLease puts an in-progress issue back to open and unassigned and
+// drops its lease
+func (t *sqliteTxStorage) releaseLease(ctx context.Context, issue *types.Issue, actor string) error {
+	if issue.Status == types.StatusInProgress {
+		updates := map[string]interface{}{
+			"status":   string(types.StatusOpen),
+			"assignee": "",
+		}
+		if err := t.UpdateIssue(ctx, issue.ID, updates, actor); err != nil {
+			return err
+		}
+	}
+	if _, err := t.conn.ExecContext(ctx, `DELETE FROM issue_leases WHERE issue_id = ?`, issue.ID); err != nil {
+		return fmt.Errorf("failed to delete lease: %w", err)
+	}
+	return nil
+}
+
+// GetLease returns the lease on an issue, or nil if it has none. The lease
+// may have expired without being released yet; check Expired.
+func (s *SQLiteStorage) GetLease(ctx context.Context, id string) (*types.Lease, error) {
+	return getLease(ctx, s.db, id)
+}
+
+func getLease(ctx context.Context, q queryExecer, id string) (*types.Lease, error) {
+	lease := &types.Lease{}
+	var expiresAt sql.NullTime
+	err := q.QueryRowContext(ctx, `
+		SELECT issue_id, holder, claimed_at, expires_at FROM issue_leases WHERE issue_id = ?
+	`, id).Scan(&lease.IssueID, &lease.Holder, &lease.ClaimedAt, &expiresAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lease: %w", err)
+	}
+	if expiresAt.Valid {
+		lease.ExpiresAt = &expiresAt.Time
+	}
+	return lease, nil
+}
+
+// ExpireLeases releases every lease that has run out at now, putting its
+// issue back to open and unassigned, and returns the leases released
+func (s *SQLiteStorage) ExpireLeases(ctx context.Context, now time.Time) ([]*types.Lease, error) {
+	var expired []*types.Lease
+	err := s.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		var err error
+		expired, err = tx.(*sqliteTxStorage).expireLeases(ctx, now)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to expire leases: %w", err)
+	}
+	return expired, nil
+}
+
+func (t *sqliteTxStorage) expireLeases(ctx context.Context, now time.Time) ([]*types.Lease, error) {
+	rows, err := t.conn.QueryContext(ctx, `
+		SELECT issue_id, holder, claimed_at, expires_at FROM issue_leases WHERE expires_at IS NOT NULL
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query leases: %w", err)
+	}
+	var expired []*types.Lease
+	for rows.Next() {
+		lease := &types.Lease{}
+		var expiresAt time.Time
+		if err := rows.Scan(&lease.IssueID, &lease.Holder, &lease.ClaimedAt, &expiresAt); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("failed to scan lease: %w", err)
+		}
+		lease.ExpiresAt = &expiresAt
+		if lease.Expired(now) {
+			expired = append(expired, lease)
+		}
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read leases: %w", err)
+	}
+
+	for _, lease := range expired {
+		issue, err := t.GetIssue(ctx, lease.IssueID)
+		if err != nil {
+			return nil, err
+		}
+		if issue == nil {
+			continue
+		}
+		if err := t.releaseLease(ctx, issue, LeaseExpiryActor); err != nil {
+			return nil, err
+		}
+	}
+	return expired, nil
+}
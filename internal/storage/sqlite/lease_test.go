@@ -0,0 +1,189 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestClaimIssueLeases(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Work", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	lease, err := store.ClaimIssue(ctx, issue.ID, "alice", 30*time.Minute, false)
+	if err != nil {
+		t.Fatalf("ClaimIssue: %v", err)
+	}
+	if lease.Holder != "alice" || lease.ExpiresAt == nil {
+		t.Errorf("lease = %+v", lease)
+	}
+	got, _ := store.GetIssue(ctx, issue.ID)
+	if got.Status != types.StatusInProgress || got.Assignee != "alice" {
+		t.Errorf("claimed issue status=%s assignee=%q", got.Status, got.Assignee)
+	}
+
+	_, err = store.ClaimIssue(ctx, issue.ID, "bob", time.Hour, false)
+	var conflict *LeaseConflictError
+	if !errors.As(err, &conflict) || conflict.Holder != "alice" || !errors.Is(err, ErrConflict) {
+		t.Fatalf("second claim error = %v, want a conflict with alice", err)
+	}
+
+	renewed, err := store.ClaimIssue(ctx, issue.ID, "alice", time.Hour, false)
+	if err != nil {
+		t.Fatalf("renewing: %v", err)
+	}
+	if !renewed.ClaimedAt.Equal(lease.ClaimedAt) || !renewed.ExpiresAt.After(*lease.ExpiresAt) {
+		t.Errorf("renewed lease = %+v, want the original claim time and a later expiry", renewed)
+	}
+
+	// Nothing expires early; after the TTL the issue goes back to open
+	if expired, err := store.ExpireLeases(ctx, time.Now()); err != nil || len(expired) != 0 {
+		t.Fatalf("ExpireLeases(now) = %v, %v", expired, err)
+	}
+	expired, err := store.ExpireLeases(ctx, time.Now().Add(2*time.Hour))
+	if err != nil || len(expired) != 1 {
+		t.Fatalf("ExpireLeases(+2h) = %v, %v", expired, err)
+	}
+	got, _ = store.GetIssue(ctx, issue.ID)
+	if got.Status != types.StatusOpen || got.Assignee != "" {
+		t.Errorf("expired issue status=%s assignee=%q, want open and unassigned", got.Status, got.Assignee)
+	}
+	if l, _ := store.GetLease(ctx, issue.ID); l != nil {
+		t.Errorf("lease after expiry = %+v", l)
+	}
+	events, _ := store.GetEvents(ctx, issue.ID, 0)
+	released := false
+	for _, e := range events {
+		released = released || (e.EventType == types.EventStatusChanged && e.Actor == LeaseExpiryActor)
+	}
+	if !released {
+		t.Errorf("no status change by %s in %d events", LeaseExpiryActor, len(events))
+	}
+
+	// A lease ends with the issue leaving in_progress or its holder
+	if _, err := store.ClaimIssue(ctx, issue.ID, "bob", 0, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"assignee": "carol"}, "carol"); err != nil {
+		t.Fatal(err)
+	}
+	if l, _ := store.GetLease(ctx, issue.ID); l != nil {
+		t.Errorf("lease after reassignment = %+v", l)
+	}
+	// carol has it in progress without a lease
+	if _, err := store.ClaimIssue(ctx, issue.ID, "alice", 0, false); !errors.As(err, &conflict) || conflict.Holder != "carol" {
+		t.Errorf("claiming carol's issue: %v", err)
+	}
+	if _, err := store.ClaimIssue(ctx, issue.ID, "alice", 0, true); err != nil {
+		t.Errorf("forced claim: %v", err)
+	}
+
+	if err := store.ReleaseClaim(ctx, issue.ID, "bob", false); !errors.As(err, &conflict) {
+		t.Errorf("releasing alice's claim as bob: %v", err)
+	}
+	if err := store.ReleaseClaim(ctx, issue.ID, "alice", false); err != nil {
+		t.Fatalf("ReleaseClaim: %v", err)
+	}
+	got, _ = store.GetIssue(ctx, issue.ID)
+	if got.Status != types.StatusOpen || got.Assignee != "" {
+		t.Errorf("released issue status=%s assignee=%q", got.Status, got.Assignee)
+	}
+	if err := store.ReleaseClaim(ctx, issue.ID, "alice", false); err == nil {
+		t.Error("releasing an unclaimed issue should fail")
+	}
+
+	if err := store.CloseIssue(ctx, issue.ID, "done", "test"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.ClaimIssue(ctx, issue.ID, "alice", 0, false); err == nil {
+		t.Error("claiming a closed issue should fail")
+	}
+}
+
+func TestClaimReadyWorkWithLease(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Work", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatal(err)
+	}
+	claimed, err := store.ClaimReadyWorkWithLease(ctx, types.WorkFilter{}, "alice", time.Millisecond)
+	if err != nil || claimed == nil || claimed.ID != issue.ID {
+		t.Fatalf("ClaimReadyWorkWithLease = %v, %v", claimed, err)
+	}
+	if lease, _ := store.GetLease(ctx, issue.ID); lease == nil || lease.Holder != "alice" {
+		t.Fatalf("lease = %+v", lease)
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	// alice's lease ran out, so bob gets the issue
+	claimed, err = store.ClaimReadyWorkWithLease(ctx, types.WorkFilter{}, "bob", time.Hour)
+	if err != nil || claimed == nil || claimed.Assignee != "bob" {
+		t.Fatalf("claim after expiry = %+v, %v", claimed, err)
+	}
+}
+
+func TestClaimIssueConcurrent(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Contended", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	const agents = 8
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var winners []string
+	for i := 0; i < agents; i++ {
+		wg.Add(1)
+		go func(agent string) {
+			defer wg.Done()
+			if _, err := store.ClaimIssue(ctx, issue.ID, agent, time.Hour, false); err == nil {
+				mu.Lock()
+				winners = append(winners, agent)
+				mu.Unlock()
+			} else if !errors.Is(err, ErrConflict) {
+				t.Errorf("%s: %v", agent, err)
+			}
+		}(fmt.Sprintf("agent-%d", i))
+	}
+	wg.Wait()
+	if len(winners) != 1 {
+		t.Fatalf("%d agents claimed the issue (%v), want exactly one", len(winners), winners)
+	}
+}
+
+func TestLeaseConflictErrorUsesDisplaySettings(t *testing.T) {
+	if err := config.Initialize(); err != nil {
+		t.Fatalf("config.Initialize: %v", err)
+	}
+	config.Set("time.zone", "UTC")
+	config.Set("time.format", "rfc3339")
+	defer func() {
+		config.Set("time.zone", "")
+		config.Set("time.format", "")
+	}()
+
+	expires := time.Date(2026, 3, 4, 5, 6, 0, 0, time.UTC)
+	err := &LeaseConflictError{IssueID: "bd-1", Holder: "alice", ExpiresAt: &expires}
+	if want := "bd-1 is claimed by alice until 2026-03-04T05:06:00Z"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
@@ -0,0 +1,321 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// PrefixMigration reports what MigratePrefix changed (or would change)
+type PrefixMigration struct {
+	OldPrefix string            `json:"old_prefix"`
+	NewPrefix string            `json:"new_prefix"`
+	Renamed   map[string]string `json:"renamed"`       // old ID -> new ID
+	TextEdits int               `json:"text_rewrites"` // issue fields and comments whose references were rewritten
+}
+
+// OldIDs returns the renamed IDs in order
+func (m *PrefixMigration) OldIDs() []string {
+	ids := make([]string, 0, len(m.Renamed))
+	for id := range m.Renamed {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// issueIDTables lists every column that stores an issue ID
+var issueIDTables = []struct{ table, column string }{
+	{"dependencies", "issue_id"},
+	{"dependencies", "depends_on_id"},
+	{"events", "issue_id"},
+	{"labels", "issue_id"},
+	{"comments", "issue_id"},
+	{"attachments", "issue_id"},
+	{"issue_aliases", "issue_id"},
+	{"issue_translations", "issue_id"},
+	{"dirty_issues", "issue_id"},
+	{"export_hashes", "issue_id"},
+	{"child_counters", "parent_id"},
+	{"issue_snapshots", "issue_id"},
+	{"compaction_snapshots", "issue_id"},
+	{"issue_embeddings", "issue_id"},
+	{"watches", "issue_id"},
+	{"external_refs", "issue_id"},
+	{"work_log", "issue_id"},
+	{"issue_fields", "issue_id"},
+	{"event_summaries", "issue_id"},
+	{"issue_leases", "issue_id"},
+	{"status_transitions", "issue_id"},
+}
+
+// MigratePrefix renames every issue from oldPrefix to newPrefix in a single
+// transaction: issue IDs, every table that refers to them, references in
+// titles, descriptions, design, acceptance criteria, notes and comments,
+// and the issue_prefix config. Either all of it happens or none of it does.
+// With dryRun the transaction is rolled back and the report describes what
+// would have changed.
+func (s *SQLiteStorage) MigratePrefix(ctx context.Context, oldPrefix, newPrefix, actor string, dryRun bool) (*PrefixMigration, error) {
+	oldPrefix = strings.TrimRight(oldPrefix, "-")
+	newPrefix = strings.TrimRight(newPrefix, "-")
+	if oldPrefix == "" || newPrefix == "" {
+		return nil, fmt.Errorf("prefixes cannot be empty")
+	}
+	if oldPrefix == newPrefix {
+		return nil, fmt.Errorf("new prefix is the same as current prefix: %s", oldPrefix)
+	}
+
+	// Get exclusive connection to ensure PRAGMA applies
+	conn, err := s.writeConn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connection: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	// Disable foreign keys on this connection while IDs change
+	if _, err := conn.ExecContext(ctx, `PRAGMA foreign_keys = OFF`); err != nil {
+		return nil, fmt.Errorf("failed to disable foreign keys: %w", err)
+	}
+	defer func() { _, _ = conn.ExecContext(context.Background(), `PRAGMA foreign_keys = ON`) }()
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := tagOperation(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	result := &PrefixMigration{OldPrefix: oldPrefix, NewPrefix: newPrefix, Renamed: make(map[string]string)}
+	if err := planPrefixMigration(ctx, tx, result); err != nil {
+		return nil, err
+	}
+
+	for _, oldID := range result.OldIDs() {
+		newID := result.Renamed[oldID]
+		if _, err := tx.ExecContext(ctx, `UPDATE issues SET id = ? WHERE id = ?`, newID, oldID); err != nil {
+			return nil, fmt.Errorf("failed to rename %s: %w", oldID, err)
+		}
+		for _, ref := range issueIDTables {
+			// #nosec G201 - table and column names come from issueIDTables
+			query := fmt.Sprintf(`UPDATE %s SET %s = ? WHERE %s = ?`, ref.table, ref.column, ref.column)
+			if _, err := tx.ExecContext(ctx, query, newID, oldID); err != nil {
+				return nil, fmt.Errorf("failed to update %s.%s for %s: %w", ref.table, ref.column, oldID, err)
+			}
+		}
+	}
+
+	edits, err := rewriteIssueReferences(ctx, tx, oldPrefix, result.Renamed)
+	if err != nil {
+		return nil, err
+	}
+	result.TextEdits = edits
+
+	newIDs := make([]string, 0, len(result.Renamed))
+	for _, oldID := range result.OldIDs() {
+		newID := result.Renamed[oldID]
+		newIDs = append(newIDs, newID)
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO events (issue_id, event_type, actor, old_value, new_value)
+			VALUES (?, 'renamed', ?, ?, ?)
+		`, newID, actor, oldID, newID); err != nil {
+			return nil, fmt.Errorf("failed to record rename event: %w", err)
+		}
+	}
+	if err := markIssuesDirtyTx(ctx, tx, newIDs); err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO config (key, value) VALUES ('issue_prefix', ?)
+		ON CONFLICT (key) DO UPDATE SET value = excluded.value
+	`, newPrefix); err != nil {
+		return nil, fmt.Errorf("failed to update issue_prefix: %w", err)
+	}
+
+	if err := s.invalidateBlockedCache(ctx, tx); err != nil {
+		return nil, fmt.Errorf("failed to invalidate blocked cache: %w", err)
+	}
+
+	if dryRun {
+		return result, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit prefix migration: %w", err)
+	}
+	return result, nil
+}
+
+// planPrefixMigration fills in the rename map and refuses renames that
+// would collide with an existing ID
+func planPrefixMigration(ctx context.Context, tx *sql.Tx, m *PrefixMigration) error {
+	rows, err := tx.QueryContext(ctx, `SELECT id FROM issues`)
+	if err != nil {
+		return fmt.Errorf("failed to list issues: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	existing := make(map[string]bool)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return fmt.Errorf("failed to scan issue ID: %w", err)
+		}
+		existing[id] = true
+		if newID, ok := migratedID(id, m.OldPrefix, m.NewPrefix); ok {
+			m.Renamed[id] = newID
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to list issues: %w", err)
+	}
+
+	for _, oldID := range m.OldIDs() {
+		if newID := m.Renamed[oldID]; existing[newID] {
+			return fmt.Errorf("cannot rename %s: %s already exists", oldID, newID)
+		}
+	}
+	return nil
+}
+
+// migratedID maps an ID with oldPrefix to newPrefix. IDs of a longer prefix
+// that merely starts with oldPrefix (bd-web-a1 when renaming bd) are left alone.
+func migratedID(id, oldPrefix, newPrefix string) (string, bool) {
+	suffix, ok := strings.CutPrefix(id, oldPrefix+"-")
+	if !ok || suffix == "" || strings.Contains(suffix, "-") {
+		return "", false
+	}
+	return newPrefix + "-" + suffix, true
+}
+
+// issueReferencePattern matches anything shaped like an ID with prefix,
+// including hierarchical children (bd-a3f8.1.2)
+func issueReferencePattern(prefix string) *regexp.Regexp {
+	return regexp.MustCompile(`\b` + regexp.QuoteMeta(prefix) + `-[a-z0-9]+(?:\.[0-9]+)*\b`)
+}
+
+// replaceIDReferences replaces every reference to a renamed issue in
+// text. A reference to a child that no longer exists keeps its suffix on
+// the renamed parent (bd-a3f8.9 -> web-a3f8.9); unknown IDs are untouched.
+func replaceIDReferences(text string, pattern *regexp.Regexp, renamed map[string]string) string {
+	return pattern.ReplaceAllStringFunc(text, func(match string) string {
+		for candidate, rest := match, ""; ; {
+			if newID, ok := renamed[candidate]; ok {
+				return newID + rest
+			}
+			dot := strings.LastIndex(candidate, ".")
+			if dot < 0 {
+				return match
+			}
+			candidate, rest = candidate[:dot], candidate[dot:]+rest
+		}
+	})
+}
+
+// rewriteIssueReferences updates references in issue text fields and
+// comments, recomputing content hashes of the issues it touches, and
+// returns how many fields and comments changed
+func rewriteIssueReferences(ctx context.Context, tx *sql.Tx, oldPrefix string, renamed map[string]string) (int, error) {
+	if len(renamed) == 0 {
+		return 0, nil
+	}
+	pattern := issueReferencePattern(oldPrefix)
+	like := "%" + oldPrefix + "-%"
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, title, description, design, acceptance_criteria, notes,
+		       status, priority, issue_type, COALESCE(assignee, ''), external_ref
+		FROM issues
+		WHERE title LIKE ? OR description LIKE ? OR design LIKE ? OR acceptance_criteria LIKE ? OR notes LIKE ?
+	`, like, like, like, like, like)
+	if err != nil {
+		return 0, fmt.Errorf("failed to scan issue text: %w", err)
+	}
+	var changed []*types.Issue
+	edits := 0
+	for rows.Next() {
+		var issue types.Issue
+		var externalRef sql.NullString
+		if err := rows.Scan(&issue.ID, &issue.Title, &issue.Description, &issue.Design, &issue.AcceptanceCriteria,
+			&issue.Notes, &issue.Status, &issue.Priority, &issue.IssueType, &issue.Assignee, &externalRef); err != nil {
+			_ = rows.Close()
+			return 0, fmt.Errorf("failed to scan issue text: %w", err)
+		}
+		if externalRef.Valid {
+			issue.ExternalRef = &externalRef.String
+		}
+		fieldEdits := 0
+		for _, field := range []*string{&issue.Title, &issue.Description, &issue.Design, &issue.AcceptanceCriteria, &issue.Notes} {
+			if rewritten := replaceIDReferences(*field, pattern, renamed); rewritten != *field {
+				*field = rewritten
+				fieldEdits++
+			}
+		}
+		if fieldEdits > 0 {
+			edits += fieldEdits
+			changed = append(changed, &issue)
+		}
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to scan issue text: %w", err)
+	}
+
+	now := time.Now().UTC()
+	for _, issue := range changed {
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE issues
+			SET title = ?, description = ?, design = ?, acceptance_criteria = ?, notes = ?, content_hash = ?, updated_at = ?
+			WHERE id = ?
+		`, issue.Title, issue.Description, issue.Design, issue.AcceptanceCriteria, issue.Notes,
+			issue.ComputeContentHash(), now, issue.ID); err != nil {
+			return 0, fmt.Errorf("failed to rewrite references in %s: %w", issue.ID, err)
+		}
+		if err := markIssuesDirtyTx(ctx, tx, []string{issue.ID}); err != nil {
+			return 0, err
+		}
+	}
+
+	commentRows, err := tx.QueryContext(ctx, `SELECT id, issue_id, text FROM comments WHERE text LIKE ?`, like)
+	if err != nil {
+		return 0, fmt.Errorf("failed to scan comments: %w", err)
+	}
+	type commentEdit struct {
+		id      int64
+		issueID string
+		text    string
+	}
+	var commentEdits []commentEdit
+	for commentRows.Next() {
+		var c commentEdit
+		if err := commentRows.Scan(&c.id, &c.issueID, &c.text); err != nil {
+			_ = commentRows.Close()
+			return 0, fmt.Errorf("failed to scan comments: %w", err)
+		}
+		if rewritten := replaceIDReferences(c.text, pattern, renamed); rewritten != c.text {
+			c.text = rewritten
+			commentEdits = append(commentEdits, c)
+		}
+	}
+	_ = commentRows.Close()
+	if err := commentRows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to scan comments: %w", err)
+	}
+	for _, c := range commentEdits {
+		if _, err := tx.ExecContext(ctx, `UPDATE comments SET text = ? WHERE id = ?`, c.text, c.id); err != nil {
+			return 0, fmt.Errorf("failed to rewrite references in comment %d: %w", c.id, err)
+		}
+		if err := markIssuesDirtyTx(ctx, tx, []string{c.issueID}); err != nil {
+			return 0, err
+		}
+	}
+	return edits + len(commentEdits), nil
+}
@@ -0,0 +1,160 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestMigratePrefix(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	newIssue := func(id, title, description string) {
+		t.Helper()
+		issue := &types.Issue{ID: id, Title: title, Description: description, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	newIssue("bd-a3f8", "Auth epic", "Tracks bd-a3f8.1 and bd-a3f8.9, see also bd-zz99.")
+	newIssue("bd-a3f8.1", "Login form", "Part of bd-a3f8.")
+	newIssue("bd-c2", "Blocked by bd-a3f8.1", "")
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: "bd-a3f8.1", DependsOnID: "bd-a3f8", Type: types.DepParentChild}, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: "bd-c2", DependsOnID: "bd-a3f8.1", Type: types.DepBlocks}, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.AddLabel(ctx, "bd-c2", "frontend", "test"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.AddIssueComment(ctx, "bd-c2", "alice", "Waiting on bd-a3f8.1 (not abd-c2)"); err != nil {
+		t.Fatal(err)
+	}
+	before, err := store.GetIssue(ctx, "bd-a3f8")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A dry run reports the plan and changes nothing
+	plan, err := store.MigratePrefix(ctx, "bd", "web", "test", true)
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if len(plan.Renamed) != 3 || plan.Renamed["bd-a3f8.1"] != "web-a3f8.1" {
+		t.Errorf("plan = %v", plan.Renamed)
+	}
+	if issue, _ := store.GetIssue(ctx, "bd-a3f8"); issue == nil {
+		t.Fatal("dry run renamed bd-a3f8")
+	}
+
+	result, err := store.MigratePrefix(ctx, "bd-", "web-", "test", false)
+	if err != nil {
+		t.Fatalf("MigratePrefix: %v", err)
+	}
+	// Descriptions of bd-a3f8 and bd-a3f8.1, title of bd-c2, one comment
+	if result.TextEdits != 4 {
+		t.Errorf("text rewrites = %d, want 4", result.TextEdits)
+	}
+
+	if issue, _ := store.GetIssue(ctx, "bd-a3f8"); issue != nil {
+		t.Error("bd-a3f8 still exists")
+	}
+	epic, err := store.GetIssue(ctx, "web-a3f8")
+	if err != nil || epic == nil {
+		t.Fatalf("web-a3f8 missing: %v", err)
+	}
+	if want := "Tracks web-a3f8.1 and web-a3f8.9, see also bd-zz99."; epic.Description != want {
+		t.Errorf("description = %q, want %q", epic.Description, want)
+	}
+	if epic.ContentHash == before.ContentHash {
+		t.Error("content hash not recomputed after rewriting the description")
+	}
+
+	deps, err := store.GetDependencyRecords(ctx, "web-c2")
+	if err != nil || len(deps) != 1 || deps[0].DependsOnID != "web-a3f8.1" {
+		t.Errorf("web-c2 dependencies = %v, %v", deps, err)
+	}
+	children, err := store.GetDependents(ctx, "web-a3f8")
+	if err != nil || len(children) != 1 || children[0].ID != "web-a3f8.1" {
+		t.Errorf("web-a3f8 dependents = %v, %v", children, err)
+	}
+	if labels, _ := store.GetLabels(ctx, "web-c2"); len(labels) != 1 {
+		t.Errorf("labels = %v", labels)
+	}
+	comments, err := store.GetIssueComments(ctx, "web-c2")
+	if err != nil || len(comments) != 1 || comments[0].Text != "Waiting on web-a3f8.1 (not abd-c2)" {
+		t.Errorf("comments = %v, %v", comments, err)
+	}
+	if prefix, _ := store.GetConfig(ctx, "issue_prefix"); prefix != "web" {
+		t.Errorf("issue_prefix = %q", prefix)
+	}
+	dirty, _ := store.GetDirtyIssues(ctx)
+	for _, id := range dirty {
+		if strings.HasPrefix(id, "bd-") {
+			t.Errorf("old ID %s left in dirty_issues", id)
+		}
+	}
+	if len(dirty) != 3 {
+		t.Errorf("dirty issues = %v", dirty)
+	}
+
+	events, err := store.GetEvents(ctx, "web-c2", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	renamed := false
+	for _, e := range events {
+		if e.EventType == "renamed" && e.OldValue != nil && *e.OldValue == "bd-c2" {
+			renamed = true
+		}
+	}
+	if !renamed {
+		t.Error("no renamed event for web-c2")
+	}
+}
+
+func TestMigratePrefixCollision(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	for _, id := range []string{"bd-1", "bd-2"} {
+		issue := &types.Issue{ID: id, Title: id, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// A stray issue already using the new prefix
+	if _, err := store.db.Exec(`INSERT INTO issues (id, title) VALUES ('web-2', 'stray')`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.MigratePrefix(ctx, "bd", "web", "test", false); err == nil || !strings.Contains(err.Error(), "web-2 already exists") {
+		t.Fatalf("expected collision error, got %v", err)
+	}
+	// Nothing was renamed
+	if issue, _ := store.GetIssue(ctx, "bd-1"); issue == nil {
+		t.Error("bd-1 renamed despite the failed migration")
+	}
+}
+
+func TestReplaceIDReferences(t *testing.T) {
+	renamed := map[string]string{"bd-1": "web-1", "bd-a3f8": "web-a3f8", "bd-a3f8.1": "web-a3f8.1"}
+	pattern := issueReferencePattern("bd")
+	tests := map[string]string{
+		"fixes bd-1.":                   "fixes web-1.",
+		"bd-a3f8.1 and bd-a3f8.2":       "web-a3f8.1 and web-a3f8.2",
+		"bd-10 is not bd-1":             "bd-10 is not web-1",
+		"abd-1, bd-1x, (bd-1)":          "abd-1, bd-1x, (web-1)",
+		"bd-web-1 keeps its own prefix": "bd-web-1 keeps its own prefix",
+	}
+	for in, want := range tests {
+		if got := replaceIDReferences(in, pattern, renamed); got != want {
+			t.Errorf("replaceIDReferences(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -147,12 +147,14 @@ func checkForeignKeys(db *sql.DB, snapshot *Snapshot) error {
 		return fmt.Errorf("found %d orphaned dependencies (issue_id not in issues)", orphanedDepsIssue)
 	}
 
-	// Check for orphaned dependencies (depends_on_id not in issues)
+	// Check for orphaned dependencies (depends_on_id not in issues), except
+	// those on issues in linked workspaces (api/bd-42)
 	var orphanedDepsDependsOn int
 	err = db.QueryRow(`
 		SELECT COUNT(*)
 		FROM dependencies d
 		WHERE NOT EXISTS (SELECT 1 FROM issues WHERE id = d.depends_on_id)
+		  AND instr(d.depends_on_id, '/') = 0
 	`).Scan(&orphanedDepsDependsOn)
 	if err != nil {
 		return fmt.Errorf("failed to check orphaned dependencies (depends_on_id): %w", err)
@@ -39,6 +39,31 @@ var migrationsList = []Migration{
 	{"edge_consolidation", migrations.MigrateEdgeConsolidation},
 	{"migrate_edge_fields", migrations.MigrateEdgeFields},
 	{"drop_edge_columns", migrations.MigrateDropEdgeColumns},
+	{"issue_embeddings_table", migrations.MigrateIssueEmbeddingsTable},
+	{"attachments_table", migrations.MigrateAttachmentsTable},
+	{"utc_timestamps", migrations.MigrateUTCTimestamps},
+	{"issue_aliases_table", migrations.MigrateIssueAliasesTable},
+	{"watches_table", migrations.MigrateWatchesTable},
+	{"refresh_content_hashes", migrations.MigrateRefreshContentHashes},
+	{"issue_translations_table", migrations.MigrateIssueTranslationsTable},
+	{"attachment_text_table", migrations.MigrateAttachmentTextTable},
+	{"issues_fts", migrations.MigrateIssuesFTS},
+	{"complexity_column", migrations.MigrateComplexityColumn},
+	{"external_refs_table", migrations.MigrateExternalRefsTable},
+	{"comment_updated_at", migrations.MigrateCommentUpdatedAt},
+	{"gates_table", migrations.MigrateGatesTable},
+	{"sync_log_table", migrations.MigrateSyncLogTable},
+	{"work_log_table", migrations.MigrateWorkLogTable},
+	{"issue_fields_table", migrations.MigrateIssueFieldsTable},
+	{"event_summaries_table", migrations.MigrateEventSummariesTable},
+	{"saved_filters_table", migrations.MigrateSavedFiltersTable},
+	{"stats_summaries", migrations.MigrateStatsSummaries},
+	{"issue_leases_table", migrations.MigrateIssueLeasesTable},
+	{"external_dependencies", migrations.MigrateExternalDependencies},
+	{"source_column", migrations.MigrateSourceColumn},
+	{"status_transitions", migrations.MigrateStatusTransitions},
+	{"timebox_column", migrations.MigrateTimeboxColumn},
+	{"operation_log", migrations.MigrateOperationLog},
 }
 
 // MigrationInfo contains metadata about a migration for inspection
@@ -85,6 +110,31 @@ func getMigrationDescription(name string) string {
 		"edge_consolidation":           "Adds metadata and thread_id columns to dependencies table for edge schema consolidation (Decision 004)",
 		"migrate_edge_fields":          "Migrates existing issue fields (replies_to, relates_to, duplicate_of, superseded_by) to dependency edges (Decision 004 Phase 3)",
 		"drop_edge_columns":            "Drops deprecated edge columns (replies_to, relates_to, duplicate_of, superseded_by) from issues table (Decision 004 Phase 4)",
+		"issue_embeddings_table":       "Adds issue_embeddings sidecar table for semantic search vectors",
+		"attachments_table":            "Adds attachments table for files kept with a remote attachment provider",
+		"utc_timestamps":               "Rewrites timestamps stored with a local offset as UTC",
+		"issue_aliases_table":          "Adds issue_aliases table for human-friendly issue ID aliases",
+		"watches_table":                "Adds watches table for personal issue and label notification subscriptions",
+		"refresh_content_hashes":       "Recomputes content hashes once so they can serve as per-issue checksums",
+		"issue_translations_table":     "Adds issue_translations table for per-locale issue titles and descriptions",
+		"attachment_text_table":        "Adds attachment_text table caching text extracted from attachments for search",
+		"issues_fts":                   "Adds issues_fts full-text index over titles, descriptions, comments, and attachment text",
+		"complexity_column":            "Adds complexity column to issues table for routing work by difficulty",
+		"external_refs_table":          "Adds external_refs table linking issues to GitHub issues for bd github sync",
+		"comment_updated_at":           "Adds updated_at column to comments so edits survive sync",
+		"gates_table":                  "Adds gates table holding issues back until a CI check passes",
+		"sync_log_table":               "Adds append-only sync_log table auditing git, GitHub and Jira syncs",
+		"work_log_table":               "Adds work_log table for time logged against issues (bd log-time)",
+		"issue_fields_table":           "Adds issue_fields table for custom field values (bd field define)",
+		"event_summaries_table":        "Adds event_summaries table rolling up events pruned by history.retention",
+		"saved_filters_table":          "Adds saved_filters table for named issue filters (bd filter save)",
+		"stats_summaries":              "Adds stats summary tables maintained by triggers so bd stats avoids scanning issues",
+		"issue_leases_table":           "Adds issue_leases table for expiring work claims (bd claim --ttl)",
+		"external_dependencies":        "Lets dependencies point at issues in linked workspaces and adds external_issue_status",
+		"source_column":                "Adds source column to issues table for reports filed through the daemon's intake endpoint",
+		"status_transitions":           "Adds status_transitions table recording every status change for bd stats velocity, burndown and lead-time",
+		"timebox_column":               "Adds timebox_minutes column to issues table for time-boxed spikes",
+		"operation_log":                "Adds operations and operation_changes tables logging every issue, label and dependency write for bd undo and bd history",
 	}
 	
 	if desc, ok := descriptions[name]; ok {
@@ -57,6 +57,20 @@ func MigrateDropEdgeColumns(db *sql.DB) error {
 		return nil
 	}
 
+	// Columns added by later migrations must survive the table rebuild
+	hasComplexity, err := checkCol("complexity")
+	if err != nil {
+		return fmt.Errorf("failed to check complexity column: %w", err)
+	}
+	hasSource, err := checkCol("source")
+	if err != nil {
+		return fmt.Errorf("failed to check source column: %w", err)
+	}
+	hasTimebox, err := checkCol("timebox_minutes")
+	if err != nil {
+		return fmt.Errorf("failed to check timebox_minutes column: %w", err)
+	}
+
 	// SQLite 3.35.0+ supports DROP COLUMN, but we use table recreation for compatibility
 	// This is idempotent - we recreate the table without the deprecated columns
 
@@ -82,6 +96,13 @@ func MigrateDropEdgeColumns(db *sql.DB) error {
 	if err != nil {
 		return fmt.Errorf("failed to drop blocked_issues view: %w", err)
 	}
+	// Stats summary triggers on other tables read issues and fail the
+	// rename the same way; the stats_summaries migration recreates them
+	for _, trigger := range []string{"stats_label_insert", "stats_label_delete", "stats_label_rename"} {
+		if _, err := db.Exec(`DROP TRIGGER IF EXISTS ` + trigger); err != nil {
+			return fmt.Errorf("failed to drop %s trigger: %w", trigger, err)
+		}
+	}
 
 	// Start a transaction for atomicity
 	tx, err := db.Begin()
@@ -121,6 +142,9 @@ func MigrateDropEdgeColumns(db *sql.DB) error {
 			sender TEXT DEFAULT '',
 			ephemeral INTEGER DEFAULT 0,
 			close_reason TEXT DEFAULT '',
+			complexity TEXT DEFAULT '',
+			source TEXT DEFAULT '',
+			timebox_minutes INTEGER,
 			CHECK ((status = 'closed') = (closed_at IS NOT NULL))
 		)
 	`)
@@ -129,13 +153,27 @@ func MigrateDropEdgeColumns(db *sql.DB) error {
 	}
 
 	// Copy data from old table to new table (excluding deprecated columns)
-	_, err = tx.Exec(`
+	complexity := "''"
+	if hasComplexity {
+		complexity = "COALESCE(complexity, '')"
+	}
+	source := "''"
+	if hasSource {
+		source = "COALESCE(source, '')"
+	}
+	timebox := "NULL"
+	if hasTimebox {
+		timebox = "timebox_minutes"
+	}
+	// #nosec G201 - complexity, source and timebox are each one of two fixed expressions
+	_, err = tx.Exec(fmt.Sprintf(`
 		INSERT INTO issues_new (
 			id, content_hash, title, description, design, acceptance_criteria,
 			notes, status, priority, issue_type, assignee, estimated_minutes,
 			created_at, updated_at, closed_at, external_ref, source_repo, compaction_level,
 			compacted_at, compacted_at_commit, original_size, deleted_at,
-			deleted_by, delete_reason, original_type, sender, ephemeral, close_reason
+			deleted_by, delete_reason, original_type, sender, ephemeral, close_reason,
+			complexity, source, timebox_minutes
 		)
 		SELECT
 			id, content_hash, title, description, design, acceptance_criteria,
@@ -143,9 +181,9 @@ func MigrateDropEdgeColumns(db *sql.DB) error {
 			created_at, updated_at, closed_at, external_ref, COALESCE(source_repo, ''), compaction_level,
 			compacted_at, compacted_at_commit, original_size, deleted_at,
 			deleted_by, delete_reason, original_type, sender, ephemeral,
-			COALESCE(close_reason, '')
+			COALESCE(close_reason, ''), %s, %s, %s
 		FROM issues
-	`)
+	`, complexity, source, timebox))
 	if err != nil {
 		return fmt.Errorf("failed to copy issues data: %w", err)
 	}
@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateIssueEmbeddingsTable adds the issue_embeddings sidecar table used by
+// bd index and bd search --semantic. Vectors are derived data: they are never
+// exported to JSONL and can be rebuilt at any time.
+func MigrateIssueEmbeddingsTable(db *sql.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS issue_embeddings (
+			issue_id TEXT NOT NULL,
+			provider TEXT NOT NULL,
+			model TEXT NOT NULL,
+			dimensions INTEGER NOT NULL,
+			content_hash TEXT NOT NULL,
+			vector BLOB NOT NULL,
+			updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (issue_id, provider, model),
+			FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create issue_embeddings table: %w", err)
+	}
+
+	_, err = db.Exec(`CREATE INDEX IF NOT EXISTS idx_issue_embeddings_model ON issue_embeddings(provider, model)`)
+	if err != nil {
+		return fmt.Errorf("failed to create issue_embeddings index: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// utcTimestampColumns lists the timestamp columns rewritten by
+// MigrateUTCTimestamps
+var utcTimestampColumns = []struct{ table, column string }{
+	{"issues", "created_at"},
+	{"issues", "updated_at"},
+	{"issues", "closed_at"},
+	{"issues", "compacted_at"},
+	{"issues", "deleted_at"},
+	{"dependencies", "created_at"},
+	{"comments", "created_at"},
+	{"events", "created_at"},
+}
+
+// MigrateUTCTimestamps rewrites timestamps stored with a local UTC offset as
+// UTC. Older versions stored time.Now() in the writer's zone; since the
+// values are compared as text, mixed offsets sorted and filtered wrongly.
+// Values already in UTC or written by SQLite's CURRENT_TIMESTAMP (no offset)
+// are left alone, so the migration is idempotent.
+func MigrateUTCTimestamps(db *sql.DB) error {
+	for _, c := range utcTimestampColumns {
+		// #nosec G201 - table and column names come from the fixed list above
+		query := fmt.Sprintf(`SELECT rowid, %s FROM %s WHERE typeof(%s) = 'text' AND %s GLOB '*[+-][0-9][0-9]:[0-9][0-9]'`,
+			c.column, c.table, c.column, c.column)
+		rows, err := db.Query(query)
+		if err != nil {
+			return fmt.Errorf("failed to scan %s.%s: %w", c.table, c.column, err)
+		}
+		updates := make(map[int64]string)
+		for rows.Next() {
+			var rowid int64
+			var value string
+			if err := rows.Scan(&rowid, &value); err != nil {
+				_ = rows.Close()
+				return fmt.Errorf("failed to read %s.%s: %w", c.table, c.column, err)
+			}
+			t, err := time.Parse(time.RFC3339Nano, value)
+			if err != nil {
+				continue // not a format bd writes; leave it untouched
+			}
+			updates[rowid] = t.UTC().Format(time.RFC3339Nano)
+		}
+		if err := rows.Err(); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("failed to read %s.%s: %w", c.table, c.column, err)
+		}
+		_ = rows.Close()
+
+		// #nosec G201 - table and column names come from the fixed list above
+		update := fmt.Sprintf(`UPDATE %s SET %s = ? WHERE rowid = ?`, c.table, c.column)
+		for rowid, value := range updates {
+			if _, err := db.Exec(update, value, rowid); err != nil {
+				return fmt.Errorf("failed to update %s.%s: %w", c.table, c.column, err)
+			}
+		}
+	}
+	return nil
+}
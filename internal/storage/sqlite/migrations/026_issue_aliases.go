@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateIssueAliasesTable adds the issue_aliases table mapping human-friendly
+// aliases to issue IDs. Each alias names one issue; an issue may have several.
+func MigrateIssueAliasesTable(db *sql.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS issue_aliases (
+			alias TEXT PRIMARY KEY,
+			issue_id TEXT NOT NULL,
+			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create issue_aliases table: %w", err)
+	}
+
+	_, err = db.Exec(`CREATE INDEX IF NOT EXISTS idx_issue_aliases_issue ON issue_aliases(issue_id)`)
+	if err != nil {
+		return fmt.Errorf("failed to create issue_aliases index: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateWatchesTable adds the watches table holding personal notification
+// subscriptions. A row watches either one issue (issue_id) or every issue
+// with a label (label); the other column is empty. Watches are local to the
+// database and are not exported to JSONL.
+func MigrateWatchesTable(db *sql.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS watches (
+			watcher TEXT NOT NULL,
+			issue_id TEXT NOT NULL DEFAULT '',
+			label TEXT NOT NULL DEFAULT '',
+			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (watcher, issue_id, label)
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create watches table: %w", err)
+	}
+
+	_, err = db.Exec(`CREATE INDEX IF NOT EXISTS idx_watches_issue ON watches(issue_id)`)
+	if err != nil {
+		return fmt.Errorf("failed to create watches index: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// contentHashVersionKey marks databases whose content hashes were last
+// recomputed by MigrateRefreshContentHashes
+const contentHashVersionKey = "content_hash_version"
+
+// MigrateRefreshContentHashes recomputes every issue's content_hash once.
+// Closing and tombstoning used to change status without updating the hash,
+// which now serves as the issue's checksum; without this pass bd doctor
+// --verify would flag every issue closed by an older version. A metadata
+// marker keeps it from running again and masking real corruption.
+func MigrateRefreshContentHashes(db *sql.DB) error {
+	var version string
+	err := db.QueryRow(`SELECT value FROM metadata WHERE key = ?`, contentHashVersionKey).Scan(&version)
+	if err == nil && version == "2" {
+		return nil
+	}
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("failed to read %s: %w", contentHashVersionKey, err)
+	}
+
+	rows, err := db.Query(`
+		SELECT id, title, description, design, acceptance_criteria, notes,
+		       status, priority, issue_type, assignee, external_ref
+		FROM issues
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to query issues: %w", err)
+	}
+	hashes := make(map[string]string)
+	for rows.Next() {
+		var issue types.Issue
+		var assignee, externalRef sql.NullString
+		if err := rows.Scan(&issue.ID, &issue.Title, &issue.Description, &issue.Design,
+			&issue.AcceptanceCriteria, &issue.Notes, &issue.Status, &issue.Priority,
+			&issue.IssueType, &assignee, &externalRef); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("failed to scan issue: %w", err)
+		}
+		issue.Assignee = assignee.String
+		if externalRef.Valid {
+			issue.ExternalRef = &externalRef.String
+		}
+		hashes[issue.ID] = issue.ComputeContentHash()
+	}
+	if err := rows.Err(); err != nil {
+		_ = rows.Close()
+		return fmt.Errorf("error iterating issues: %w", err)
+	}
+	_ = rows.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	for id, hash := range hashes {
+		if _, err := tx.Exec(`UPDATE issues SET content_hash = ? WHERE id = ? AND content_hash IS NOT ?`, hash, id, hash); err != nil {
+			return fmt.Errorf("failed to update content_hash for %s: %w", id, err)
+		}
+	}
+	if _, err := tx.Exec(`INSERT OR REPLACE INTO metadata (key, value) VALUES (?, '2')`, contentHashVersionKey); err != nil {
+		return fmt.Errorf("failed to record %s: %w", contentHashVersionKey, err)
+	}
+	return tx.Commit()
+}
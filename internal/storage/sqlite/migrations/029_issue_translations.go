@@ -0,0 +1,27 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateIssueTranslationsTable adds the issue_translations table holding
+// per-locale titles and descriptions. An issue has at most one translation
+// per locale.
+func MigrateIssueTranslationsTable(db *sql.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS issue_translations (
+			issue_id TEXT NOT NULL,
+			locale TEXT NOT NULL,
+			title TEXT NOT NULL DEFAULT '',
+			description TEXT NOT NULL DEFAULT '',
+			updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (issue_id, locale),
+			FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create issue_translations table: %w", err)
+	}
+	return nil
+}
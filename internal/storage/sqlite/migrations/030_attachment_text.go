@@ -0,0 +1,27 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateAttachmentTextTable adds the attachment_text table holding text
+// extracted from attachments for search. It is a local cache rebuilt from
+// the attachment provider, so it is never exported. A row with an error
+// records an attachment that couldn't be indexed, so it isn't retried
+// forever.
+func MigrateAttachmentTextTable(db *sql.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS attachment_text (
+			attachment_id INTEGER PRIMARY KEY,
+			content TEXT NOT NULL DEFAULT '',
+			error TEXT NOT NULL DEFAULT '',
+			indexed_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (attachment_id) REFERENCES attachments(id) ON DELETE CASCADE
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create attachment_text table: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// IssuesFTSPopulate fills issues_fts from scratch: one row per issue with
+// its comments and extracted attachment text folded into their own columns
+const IssuesFTSPopulate = `
+	INSERT INTO issues_fts (issue_id, title, description, comments, attachments)
+	SELECT i.id, i.title, i.description,
+	       COALESCE((SELECT group_concat(c.text, char(10)) FROM comments c WHERE c.issue_id = i.id), ''),
+	       COALESCE((SELECT group_concat(t.content, char(10)) FROM attachments a
+	                 JOIN attachment_text t ON t.attachment_id = a.id WHERE a.issue_id = i.id), '')
+	FROM issues i
+`
+
+// issuesFTSTriggers keep issues_fts in step with issues, comments, and
+// attachment text. Comment and attachment columns are recomputed for the
+// whole issue, which keeps the triggers simple at the cost of rewriting a
+// row per comment.
+var issuesFTSTriggers = []string{
+	`CREATE TRIGGER IF NOT EXISTS issues_fts_insert AFTER INSERT ON issues BEGIN
+		INSERT INTO issues_fts (issue_id, title, description, comments, attachments)
+		VALUES (new.id, new.title, new.description, '', '');
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS issues_fts_delete AFTER DELETE ON issues BEGIN
+		DELETE FROM issues_fts WHERE issue_id = old.id;
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS issues_fts_update AFTER UPDATE OF id, title, description ON issues BEGIN
+		UPDATE issues_fts SET issue_id = new.id, title = new.title, description = new.description
+		WHERE issue_id = old.id;
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS issues_fts_comment_insert AFTER INSERT ON comments BEGIN
+		UPDATE issues_fts SET comments = COALESCE((SELECT group_concat(text, char(10)) FROM comments WHERE issue_id = new.issue_id), '')
+		WHERE issue_id = new.issue_id;
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS issues_fts_comment_update AFTER UPDATE ON comments BEGIN
+		UPDATE issues_fts SET comments = COALESCE((SELECT group_concat(text, char(10)) FROM comments WHERE issue_id = old.issue_id), '')
+		WHERE issue_id = old.issue_id;
+		UPDATE issues_fts SET comments = COALESCE((SELECT group_concat(text, char(10)) FROM comments WHERE issue_id = new.issue_id), '')
+		WHERE issue_id = new.issue_id;
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS issues_fts_comment_delete AFTER DELETE ON comments BEGIN
+		UPDATE issues_fts SET comments = COALESCE((SELECT group_concat(text, char(10)) FROM comments WHERE issue_id = old.issue_id), '')
+		WHERE issue_id = old.issue_id;
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS issues_fts_attachment_text AFTER INSERT ON attachment_text BEGIN
+		UPDATE issues_fts SET attachments = COALESCE((SELECT group_concat(t.content, char(10)) FROM attachments a
+			JOIN attachment_text t ON t.attachment_id = a.id
+			WHERE a.issue_id = (SELECT issue_id FROM attachments WHERE id = new.attachment_id)), '')
+		WHERE issue_id = (SELECT issue_id FROM attachments WHERE id = new.attachment_id);
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS issues_fts_attachment_text_update AFTER UPDATE ON attachment_text BEGIN
+		UPDATE issues_fts SET attachments = COALESCE((SELECT group_concat(t.content, char(10)) FROM attachments a
+			JOIN attachment_text t ON t.attachment_id = a.id
+			WHERE a.issue_id = (SELECT issue_id FROM attachments WHERE id = new.attachment_id)), '')
+		WHERE issue_id = (SELECT issue_id FROM attachments WHERE id = new.attachment_id);
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS issues_fts_attachment_text_delete AFTER DELETE ON attachment_text BEGIN
+		UPDATE issues_fts SET attachments = COALESCE((SELECT group_concat(t.content, char(10)) FROM attachments a
+			JOIN attachment_text t ON t.attachment_id = a.id
+			WHERE a.issue_id = (SELECT issue_id FROM attachments WHERE id = old.attachment_id)), '')
+		WHERE issue_id = (SELECT issue_id FROM attachments WHERE id = old.attachment_id);
+	END`,
+	// Deleting an attachment cascades to its text after the attachment row
+	// is gone, so the trigger above can't find the issue
+	`CREATE TRIGGER IF NOT EXISTS issues_fts_attachment_delete AFTER DELETE ON attachments BEGIN
+		UPDATE issues_fts SET attachments = COALESCE((SELECT group_concat(t.content, char(10)) FROM attachments a
+			JOIN attachment_text t ON t.attachment_id = a.id WHERE a.issue_id = old.issue_id), '')
+		WHERE issue_id = old.issue_id;
+	END`,
+}
+
+// MigrateIssuesFTS adds the issues_fts full-text index used by bd search,
+// with triggers that maintain it. Like attachment_text it is derived data
+// and never exported. The index is populated only when the table is first
+// created; triggers are recreated on every run in case a table rebuild
+// dropped them.
+func MigrateIssuesFTS(db *sql.DB) error {
+	var exists int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'issues_fts'`).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to check for issues_fts: %w", err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if exists == 0 {
+		if _, err := tx.Exec(`
+			CREATE VIRTUAL TABLE issues_fts USING fts5(
+				issue_id UNINDEXED, title, description, comments, attachments,
+				tokenize = 'unicode61 remove_diacritics 2'
+			)
+		`); err != nil {
+			return fmt.Errorf("failed to create issues_fts: %w", err)
+		}
+		if _, err := tx.Exec(IssuesFTSPopulate); err != nil {
+			return fmt.Errorf("failed to populate issues_fts: %w", err)
+		}
+	}
+	for _, trigger := range issuesFTSTriggers {
+		if _, err := tx.Exec(trigger); err != nil {
+			return fmt.Errorf("failed to create issues_fts trigger: %w", err)
+		}
+	}
+	return tx.Commit()
+}
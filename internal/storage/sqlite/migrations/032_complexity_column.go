@@ -0,0 +1,32 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateComplexityColumn adds the complexity column to the issues table.
+// It classifies issues (trivial, standard, complex, research) so
+// orchestrators can route work to a suitable agent; empty is unclassified.
+func MigrateComplexityColumn(db *sql.DB) error {
+	var columnExists bool
+	err := db.QueryRow(`
+		SELECT COUNT(*) > 0
+		FROM pragma_table_info('issues')
+		WHERE name = 'complexity'
+	`).Scan(&columnExists)
+	if err != nil {
+		return fmt.Errorf("failed to check complexity column: %w", err)
+	}
+
+	if columnExists {
+		return nil
+	}
+
+	_, err = db.Exec(`ALTER TABLE issues ADD COLUMN complexity TEXT DEFAULT ''`)
+	if err != nil {
+		return fmt.Errorf("failed to add complexity column: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateExternalRefsTable adds the external_refs table linking issues to
+// their copies in other trackers (bd github sync). Each row records the
+// remote ID and the hash of the synced fields when both sides last agreed,
+// which is how sync tells a local edit from a remote one. Links are local
+// to the database and are not exported to JSONL.
+func MigrateExternalRefsTable(db *sql.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS external_refs (
+			issue_id TEXT NOT NULL,
+			system TEXT NOT NULL,
+			remote_id TEXT NOT NULL,
+			url TEXT NOT NULL DEFAULT '',
+			sync_hash TEXT NOT NULL DEFAULT '',
+			remote_updated_at DATETIME,
+			synced_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (issue_id, system),
+			UNIQUE (system, remote_id)
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create external_refs table: %w", err)
+	}
+	return nil
+}
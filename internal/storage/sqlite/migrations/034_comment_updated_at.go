@@ -0,0 +1,32 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateCommentUpdatedAt adds the updated_at column to the comments table.
+// It is NULL until a comment is edited, and lets imports tell which copy of
+// an edited comment is newer.
+func MigrateCommentUpdatedAt(db *sql.DB) error {
+	var columnExists bool
+	err := db.QueryRow(`
+		SELECT COUNT(*) > 0
+		FROM pragma_table_info('comments')
+		WHERE name = 'updated_at'
+	`).Scan(&columnExists)
+	if err != nil {
+		return fmt.Errorf("failed to check comments updated_at column: %w", err)
+	}
+
+	if columnExists {
+		return nil
+	}
+
+	_, err = db.Exec(`ALTER TABLE comments ADD COLUMN updated_at DATETIME`)
+	if err != nil {
+		return fmt.Errorf("failed to add comments updated_at column: %w", err)
+	}
+
+	return nil
+}
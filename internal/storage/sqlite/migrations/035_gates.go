@@ -0,0 +1,36 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateGatesTable adds the gates table. A gate holds an issue back from
+// ready work until a named CI check on a branch or pull request passes; the
+// daemon polls CI or takes webhooks to update its state. Gates are local to
+// the database and are not exported to JSONL.
+func MigrateGatesTable(db *sql.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS gates (
+			issue_id TEXT NOT NULL,
+			check_name TEXT NOT NULL,
+			ref TEXT NOT NULL,
+			repo TEXT NOT NULL DEFAULT '',
+			state TEXT NOT NULL DEFAULT 'pending',
+			detail TEXT NOT NULL DEFAULT '',
+			url TEXT NOT NULL DEFAULT '',
+			checked_at DATETIME,
+			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			created_by TEXT NOT NULL DEFAULT '',
+			PRIMARY KEY (issue_id, check_name, ref),
+			FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create gates table: %w", err)
+	}
+	if _, err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_gates_check ON gates(check_name, ref)`); err != nil {
+		return fmt.Errorf("failed to create gates index: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateSyncLogTable adds the sync_log table, the audit trail of every sync
+// with git, GitHub or Jira. Records are chained by hash, and triggers
+// refuse updates and deletes, so the log can only be appended to. It is
+// local to the database and is not exported to JSONL.
+func MigrateSyncLogTable(db *sql.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS sync_log (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			run_id TEXT NOT NULL,
+			record TEXT NOT NULL,
+			provider TEXT NOT NULL,
+			target TEXT NOT NULL DEFAULT '',
+			dry_run INTEGER NOT NULL DEFAULT 0,
+			status TEXT NOT NULL DEFAULT '',
+			error TEXT NOT NULL DEFAULT '',
+			actor TEXT NOT NULL DEFAULT '',
+			changes TEXT NOT NULL DEFAULT '[]',
+			created_at TEXT NOT NULL,
+			prev_hash TEXT NOT NULL DEFAULT '',
+			hash TEXT NOT NULL
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create sync_log table: %w", err)
+	}
+	for _, stmt := range []string{
+		`CREATE INDEX IF NOT EXISTS idx_sync_log_run ON sync_log(run_id)`,
+		`CREATE TRIGGER IF NOT EXISTS sync_log_no_update BEFORE UPDATE ON sync_log
+		BEGIN SELECT RAISE(ABORT, 'sync_log is append-only'); END`,
+		`CREATE TRIGGER IF NOT EXISTS sync_log_no_delete BEFORE DELETE ON sync_log
+		BEGIN SELECT RAISE(ABORT, 'sync_log is append-only'); END`,
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			return fmt.Errorf("failed to set up sync_log: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateWorkLogTable adds the work_log table for time logged against issues
+// (bd log-time). An entry is identified across clones by its issue, actor and
+// time, which keeps JSONL imports idempotent.
+func MigrateWorkLogTable(db *sql.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS work_log (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			issue_id TEXT NOT NULL,
+			actor TEXT NOT NULL,
+			minutes INTEGER NOT NULL CHECK (minutes > 0),
+			note TEXT NOT NULL DEFAULT '',
+			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE (issue_id, actor, created_at),
+			FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create work_log table: %w", err)
+	}
+
+	_, err = db.Exec(`CREATE INDEX IF NOT EXISTS idx_work_log_issue ON work_log(issue_id)`)
+	if err != nil {
+		return fmt.Errorf("failed to create work_log index: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateIssueFieldsTable adds the issue_fields table holding custom field
+// values (bd field define). An issue has at most one value per field; the
+// field definitions themselves live in config.
+func MigrateIssueFieldsTable(db *sql.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS issue_fields (
+			issue_id TEXT NOT NULL,
+			name TEXT NOT NULL,
+			value TEXT NOT NULL,
+			PRIMARY KEY (issue_id, name),
+			FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create issue_fields table: %w", err)
+	}
+
+	_, err = db.Exec(`CREATE INDEX IF NOT EXISTS idx_issue_fields_name_value ON issue_fields(name, value)`)
+	if err != nil {
+		return fmt.Errorf("failed to create issue_fields index: %w", err)
+	}
+	return nil
+}
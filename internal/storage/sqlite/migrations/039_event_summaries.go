@@ -0,0 +1,32 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateEventSummariesTable adds the event_summaries table holding one
+// rollup per issue of the events pruned by history.retention, so state
+// times, cycle times and close reasons survive the events they came from.
+func MigrateEventSummariesTable(db *sql.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS event_summaries (
+			issue_id TEXT PRIMARY KEY,
+			pruned_before DATETIME NOT NULL,
+			event_count INTEGER NOT NULL DEFAULT 0,
+			status TEXT NOT NULL,
+			ready_hours REAL NOT NULL DEFAULT 0,
+			blocked_hours REAL NOT NULL DEFAULT 0,
+			in_progress_hours REAL NOT NULL DEFAULT 0,
+			blocking_hours REAL NOT NULL DEFAULT 0,
+			started_at DATETIME,
+			closed_by TEXT NOT NULL DEFAULT '',
+			close_reason TEXT NOT NULL DEFAULT '',
+			FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create event_summaries table: %w", err)
+	}
+	return nil
+}
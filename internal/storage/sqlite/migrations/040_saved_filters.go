@@ -0,0 +1,26 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateSavedFiltersTable adds the saved_filters table holding named issue
+// filter expressions (bd filter save) for bd list --filter, bd watch and
+// bd stats.
+func MigrateSavedFiltersTable(db *sql.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS saved_filters (
+			name TEXT PRIMARY KEY,
+			query TEXT NOT NULL,
+			description TEXT NOT NULL DEFAULT '',
+			created_by TEXT NOT NULL DEFAULT '',
+			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create saved_filters table: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// leadHours is an issue's created-to-closed time in hours, NULL while open.
+// %[1]s is old or new.
+const leadHours = `(julianday(%[1]s.closed_at) - julianday(%[1]s.created_at)) * 24`
+
+// StatsSummariesPopulate fills the stats summary tables from scratch. Daily
+// activity comes from the events still in the table.
+var StatsSummariesPopulate = []string{
+	`DELETE FROM stats_issue_counts`,
+	`INSERT INTO stats_issue_counts (status, priority, issues, lead_hours, lead_issues)
+	SELECT status, priority, COUNT(*),
+	       COALESCE(SUM(` + fmt.Sprintf(leadHours, "issues") + `), 0),
+	       COUNT(` + fmt.Sprintf(leadHours, "issues") + `)
+	FROM issues GROUP BY status, priority`,
+	`DELETE FROM stats_label_counts`,
+	`INSERT INTO stats_label_counts (label, status, issues)
+	SELECT l.label, i.status, COUNT(*)
+	FROM labels l JOIN issues i ON i.id = l.issue_id
+	GROUP BY l.label, i.status`,
+	`DELETE FROM stats_daily_activity`,
+	`INSERT INTO stats_daily_activity (day, event_type, events)
+	SELECT COALESCE(date(created_at), date('now')), event_type, COUNT(*)
+	FROM events GROUP BY 1, 2`,
+}
+
+// issueCountsAdd and issueCountsRemove count an issue row (new or old) in
+// or out of stats_issue_counts
+func issueCountsAdd(row string) string {
+	return fmt.Sprintf(`INSERT INTO stats_issue_counts (status, priority, issues, lead_hours, lead_issues)
+		VALUES (%[1]s.status, %[1]s.priority, 1, COALESCE(%[2]s, 0), %[2]s IS NOT NULL)
+		ON CONFLICT (status, priority) DO UPDATE SET
+			issues = issues + 1,
+			lead_hours = lead_hours + excluded.lead_hours,
+			lead_issues = lead_issues + excluded.lead_issues;`, row, fmt.Sprintf(leadHours, row))
+}
+
+func issueCountsRemove(row string) string {
+	return fmt.Sprintf(`UPDATE stats_issue_counts SET
+			issues = issues - 1,
+			lead_hours = lead_hours - COALESCE(%[2]s, 0),
+			lead_issues = lead_issues - (%[2]s IS NOT NULL)
+		WHERE status = %[1]s.status AND priority = %[1]s.priority;`, row, fmt.Sprintf(leadHours, row))
+}
+
+// statsSummaryTriggers keep the summary tables in step with issues, labels
+// and events inside the writing transaction. Label counts follow the
+// issue's status, so they move when it changes. Deleting an issue cascades
+// to its labels after the issue row is gone, where the label trigger can't
+// see its status, so the issue's BEFORE DELETE trigger counts them out.
+// Events are only ever added to daily activity: pruning old events keeps
+// the days they were counted in.
+var statsSummaryTriggers = []string{
+	`CREATE TRIGGER IF NOT EXISTS stats_issue_insert AFTER INSERT ON issues BEGIN
+		` + issueCountsAdd("new") + `
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS stats_issue_update AFTER UPDATE OF status, priority, created_at, closed_at ON issues BEGIN
+		` + issueCountsRemove("old") + `
+		` + issueCountsAdd("new") + `
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS stats_issue_delete AFTER DELETE ON issues BEGIN
+		` + issueCountsRemove("old") + `
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS stats_issue_status_labels AFTER UPDATE OF status ON issues
+	WHEN old.status != new.status BEGIN
+		UPDATE stats_label_counts SET issues = issues - 1
+		WHERE status = old.status AND label IN (SELECT label FROM labels WHERE issue_id = new.id);
+		INSERT INTO stats_label_counts (label, status, issues)
+		SELECT label, new.status, 1 FROM labels WHERE issue_id = new.id
+		ON CONFLICT (label, status) DO UPDATE SET issues = issues + 1;
+		DELETE FROM stats_label_counts WHERE issues <= 0;
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS stats_issue_delete_labels BEFORE DELETE ON issues BEGIN
+		UPDATE stats_label_counts SET issues = issues - 1
+		WHERE status = old.status AND label IN (SELECT label FROM labels WHERE issue_id = old.id);
+		DELETE FROM stats_label_counts WHERE issues <= 0;
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS stats_label_insert AFTER INSERT ON labels BEGIN
+		INSERT INTO stats_label_counts (label, status, issues)
+		SELECT new.label, status, 1 FROM issues WHERE id = new.issue_id
+		ON CONFLICT (label, status) DO UPDATE SET issues = issues + 1;
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS stats_label_delete AFTER DELETE ON labels BEGIN
+		UPDATE stats_label_counts SET issues = issues - 1
+		WHERE label = old.label AND status = (SELECT status FROM issues WHERE id = old.issue_id);
+		DELETE FROM stats_label_counts WHERE issues <= 0;
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS stats_label_rename AFTER UPDATE OF label ON labels
+	WHEN old.label != new.label BEGIN
+		UPDATE stats_label_counts SET issues = issues - 1
+		WHERE label = old.label AND status = (SELECT status FROM issues WHERE id = new.issue_id);
+		INSERT INTO stats_label_counts (label, status, issues)
+		SELECT new.label, status, 1 FROM issues WHERE id = new.issue_id
+		ON CONFLICT (label, status) DO UPDATE SET issues = issues + 1;
+		DELETE FROM stats_label_counts WHERE issues <= 0;
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS stats_event_insert AFTER INSERT ON events BEGIN
+		INSERT INTO stats_daily_activity (day, event_type, events)
+		VALUES (COALESCE(date(new.created_at), date('now')), new.event_type, 1)
+		ON CONFLICT (day, event_type) DO UPDATE SET events = events + 1;
+	END`,
+}
+
+// MigrateStatsSummaries adds the summary tables bd stats reads instead of
+// scanning issues: issue counts and lead time by status and priority, label
+// counts by status, and events per day and type. Like issues_fts they are
+// derived data, filled when first created and kept current by triggers,
+// which are recreated on every run in case a table rebuild dropped them.
+func MigrateStatsSummaries(db *sql.DB) error {
+	var exists int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'stats_issue_counts'`).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to check for stats_issue_counts: %w", err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if exists == 0 {
+		for _, stmt := range []string{
+			`CREATE TABLE stats_issue_counts (
+				status TEXT NOT NULL,
+				priority INTEGER NOT NULL,
+				issues INTEGER NOT NULL DEFAULT 0,
+				lead_hours REAL NOT NULL DEFAULT 0,
+				lead_issues INTEGER NOT NULL DEFAULT 0,
+				PRIMARY KEY (status, priority)
+			)`,
+			`CREATE TABLE stats_label_counts (
+				label TEXT NOT NULL,
+				status TEXT NOT NULL,
+				issues INTEGER NOT NULL DEFAULT 0,
+				PRIMARY KEY (label, status)
+			)`,
+			`CREATE TABLE stats_daily_activity (
+				day TEXT NOT NULL,
+				event_type TEXT NOT NULL,
+				events INTEGER NOT NULL DEFAULT 0,
+				PRIMARY KEY (day, event_type)
+			)`,
+		} {
+			if _, err := tx.Exec(stmt); err != nil {
+				return fmt.Errorf("failed to create stats summary table: %w", err)
+			}
+		}
+		for _, stmt := range StatsSummariesPopulate {
+			if _, err := tx.Exec(stmt); err != nil {
+				return fmt.Errorf("failed to populate stats summaries: %w", err)
+			}
+		}
+	}
+	for _, trigger := range statsSummaryTriggers {
+		if _, err := tx.Exec(trigger); err != nil {
+			return fmt.Errorf("failed to create stats summary trigger: %w", err)
+		}
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,40 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateIssueLeasesTable adds the issue_leases table recording who holds a
+// claim on an issue (bd claim, bd ready --claim) and until when. A lease
+// only stands while its issue is in progress and assigned to the holder, so
+// a trigger drops it when either changes; the trigger lives on issues and
+// is recreated on every run in case a table rebuild dropped it.
+func MigrateIssueLeasesTable(db *sql.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS issue_leases (
+			issue_id TEXT PRIMARY KEY,
+			holder TEXT NOT NULL,
+			claimed_at DATETIME NOT NULL,
+			expires_at DATETIME,
+			FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create issue_leases table: %w", err)
+	}
+	_, err = db.Exec(`CREATE INDEX IF NOT EXISTS idx_issue_leases_expires_at ON issue_leases(expires_at)`)
+	if err != nil {
+		return fmt.Errorf("failed to create issue_leases index: %w", err)
+	}
+	_, err = db.Exec(`
+		CREATE TRIGGER IF NOT EXISTS issue_leases_end AFTER UPDATE OF status, assignee ON issues BEGIN
+			DELETE FROM issue_leases
+			WHERE issue_id = new.id AND (new.status != 'in_progress' OR COALESCE(new.assignee, '') != holder);
+		END
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create issue_leases trigger: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateExternalDependencies lets a dependency point at an issue in a
+// linked workspace by its qualified ID (api/bd-42). Such an ID has no row in
+// issues, so the table is rebuilt without the foreign key on depends_on_id;
+// a trigger keeps deleting the edges to a deleted local issue, which the
+// key's ON DELETE CASCADE used to do. It also adds external_issue_status,
+// the last known status of each external issue, which the blocked cache
+// consults. The trigger lives on issues and is recreated on every run in
+// case a table rebuild dropped it.
+func MigrateExternalDependencies(db *sql.DB) error {
+	var hasDependsOnFK bool
+	err := db.QueryRow(`
+		SELECT COUNT(*) > 0 FROM pragma_foreign_key_list('dependencies')
+		WHERE "from" = 'depends_on_id'
+	`).Scan(&hasDependsOnFK)
+	if err != nil {
+		return fmt.Errorf("failed to check dependencies foreign keys: %w", err)
+	}
+	if hasDependsOnFK {
+		if err := rebuildDependenciesTable(db); err != nil {
+			return err
+		}
+	}
+
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS external_issue_status (
+			id TEXT PRIMARY KEY,
+			status TEXT NOT NULL,
+			title TEXT NOT NULL DEFAULT '',
+			checked_at DATETIME NOT NULL
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create external_issue_status table: %w", err)
+	}
+	_, err = db.Exec(`
+		CREATE TRIGGER IF NOT EXISTS dependencies_target_delete AFTER DELETE ON issues BEGIN
+			DELETE FROM dependencies WHERE depends_on_id = old.id;
+		END
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create dependencies_target_delete trigger: %w", err)
+	}
+	return nil
+}
+
+func rebuildDependenciesTable(db *sql.DB) error {
+	// Keep the issue_id cascade from firing while the old table is dropped
+	if _, err := db.Exec(`PRAGMA foreign_keys = OFF`); err != nil {
+		return fmt.Errorf("failed to disable foreign keys: %w", err)
+	}
+	defer func() { _, _ = db.Exec(`PRAGMA foreign_keys = ON`) }()
+
+	// The views and the target trigger read dependencies, and SQLite
+	// validates them on the rename; both are recreated below
+	for _, stmt := range []string{
+		`DROP VIEW IF EXISTS ready_issues`,
+		`DROP VIEW IF EXISTS blocked_issues`,
+		`DROP TRIGGER IF EXISTS dependencies_target_delete`,
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			return fmt.Errorf("failed to rebuild dependencies table: %w", err)
+		}
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	statements := []string{
+		`CREATE TABLE dependencies_new (
+			issue_id TEXT NOT NULL,
+			depends_on_id TEXT NOT NULL,
+			type TEXT NOT NULL DEFAULT 'blocks',
+			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			created_by TEXT NOT NULL,
+			metadata TEXT DEFAULT '{}',
+			thread_id TEXT DEFAULT '',
+			PRIMARY KEY (issue_id, depends_on_id),
+			FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+		)`,
+		`INSERT INTO dependencies_new (issue_id, depends_on_id, type, created_at, created_by, metadata, thread_id)
+		 SELECT issue_id, depends_on_id, type, created_at, created_by, COALESCE(metadata, '{}'), COALESCE(thread_id, '')
+		 FROM dependencies`,
+		`DROP TABLE dependencies`,
+		`ALTER TABLE dependencies_new RENAME TO dependencies`,
+		`CREATE INDEX IF NOT EXISTS idx_dependencies_issue ON dependencies(issue_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_dependencies_depends_on ON dependencies(depends_on_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_dependencies_depends_on_type ON dependencies(depends_on_id, type)`,
+		`CREATE INDEX IF NOT EXISTS idx_dependencies_depends_on_type_issue ON dependencies(depends_on_id, type, issue_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_dependencies_thread ON dependencies(thread_id) WHERE thread_id != ''`,
+		`CREATE INDEX IF NOT EXISTS idx_dependencies_thread_type ON dependencies(thread_id, type) WHERE thread_id != ''`,
+	}
+	for _, stmt := range statements {
+		if _, err := tx.Exec(stmt); err != nil {
+			return fmt.Errorf("failed to rebuild dependencies table: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit dependencies rebuild: %w", err)
+	}
+
+	_, err = db.Exec(`
+		CREATE VIEW IF NOT EXISTS ready_issues AS
+		WITH RECURSIVE
+		  blocked_directly AS (
+		    SELECT DISTINCT d.issue_id
+		    FROM dependencies d
+		    JOIN issues blocker ON d.depends_on_id = blocker.id
+		    WHERE d.type = 'blocks'
+		      AND blocker.status IN ('open', 'in_progress', 'blocked')
+		  ),
+		  blocked_transitively AS (
+		    SELECT issue_id, 0 as depth
+		    FROM blocked_directly
+		    UNION ALL
+		    SELECT d.issue_id, bt.depth + 1
+		    FROM blocked_transitively bt
+		    JOIN dependencies d ON d.depends_on_id = bt.issue_id
+		    WHERE d.type = 'parent-child'
+		      AND bt.depth < 50
+		  )
+		SELECT i.*
+		FROM issues i
+		WHERE i.status = 'open'
+		  AND NOT EXISTS (
+		    SELECT 1 FROM blocked_transitively WHERE issue_id = i.id
+		  )
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to recreate ready_issues view: %w", err)
+	}
+	_, err = db.Exec(`
+		CREATE VIEW IF NOT EXISTS blocked_issues AS
+		SELECT
+		    i.*,
+		    COUNT(d.depends_on_id) as blocked_by_count
+		FROM issues i
+		JOIN dependencies d ON i.id = d.issue_id
+		JOIN issues blocker ON d.depends_on_id = blocker.id
+		WHERE i.status IN ('open', 'in_progress', 'blocked')
+		  AND d.type = 'blocks'
+		  AND blocker.status IN ('open', 'in_progress', 'blocked')
+		GROUP BY i.id
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to recreate blocked_issues view: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateSourceColumn adds the source column to the issues table. It
+// records where an issue was reported from when it didn't come from bd
+// itself, such as a crash reporter filing through the daemon's intake
+// endpoint; empty for issues created with bd.
+func MigrateSourceColumn(db *sql.DB) error {
+	var columnExists bool
+	err := db.QueryRow(`
+		SELECT COUNT(*) > 0
+		FROM pragma_table_info('issues')
+		WHERE name = 'source'
+	`).Scan(&columnExists)
+	if err != nil {
+		return fmt.Errorf("failed to check source column: %w", err)
+	}
+
+	if columnExists {
+		return nil
+	}
+
+	_, err = db.Exec(`ALTER TABLE issues ADD COLUMN source TEXT DEFAULT ''`)
+	if err != nil {
+		return fmt.Errorf("failed to add source column: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// StatusTransitionsBackfill rebuilds status_transitions for existing issues
+// the way status timelines are read from events: open at creation, then the
+// status events (or the summary of pruned ones), and finally the current
+// status if the history doesn't end there, dated when the issue was closed
+// or last updated.
+var StatusTransitionsBackfill = []string{
+	`DELETE FROM status_transitions`,
+	`INSERT INTO status_transitions (issue_id, from_status, to_status, at)
+	WITH points AS (
+		SELECT id AS issue_id, 'open' AS status, datetime(created_at) AS at, 0 AS ord, 0 AS seq FROM issues
+		UNION ALL
+		SELECT issue_id, status, datetime(pruned_before), 1, 0 FROM event_summaries
+		UNION ALL
+		SELECT issue_id,
+		       CASE WHEN event_type = 'closed' THEN 'closed'
+		            WHEN json_valid(new_value) THEN json_extract(new_value, '$.status') END,
+		       datetime(created_at), 2, id
+		FROM events
+		WHERE event_type IN ('status_changed', 'closed', 'reopened')
+	),
+	ordered AS (
+		SELECT issue_id, status, at, ord, seq,
+		       LAG(status) OVER (PARTITION BY issue_id ORDER BY at, ord, seq) AS prev
+		FROM points
+		WHERE status IS NOT NULL AND status != '' AND at IS NOT NULL
+		  AND issue_id IN (SELECT id FROM issues)
+	)
+	SELECT issue_id, prev, status, at FROM ordered
+	WHERE prev IS NULL OR prev != status
+	ORDER BY at, ord, seq`,
+	`INSERT INTO status_transitions (issue_id, from_status, to_status, at)
+	SELECT i.id, t.to_status, i.status,
+	       max(t.at, COALESCE(CASE WHEN i.status = 'closed' THEN datetime(i.closed_at) END, datetime(i.updated_at), t.at))
+	FROM issues i
+	JOIN status_transitions t ON t.id = (
+		SELECT id FROM status_transitions WHERE issue_id = i.id ORDER BY at DESC, id DESC LIMIT 1)
+	WHERE t.to_status != i.status`,
+}
+
+// statusTransitionTriggers record every status change as it is written.
+// A new issue starts open, followed by its status if it was created in
+// another (an imported closed issue, say). Closing is dated by closed_at so
+// imports keep the original time; other changes happen now.
+var statusTransitionTriggers = []string{
+	`CREATE TRIGGER IF NOT EXISTS status_transitions_insert AFTER INSERT ON issues BEGIN
+		INSERT INTO status_transitions (issue_id, from_status, to_status, at)
+		VALUES (new.id, NULL, 'open', COALESCE(datetime(new.created_at), datetime('now')));
+		INSERT INTO status_transitions (issue_id, from_status, to_status, at)
+		SELECT new.id, 'open', new.status,
+		       max(COALESCE(datetime(new.created_at), datetime('now')),
+		           COALESCE(CASE WHEN new.status = 'closed' THEN datetime(new.closed_at) END, datetime(new.updated_at), datetime('now')))
+		WHERE new.status != 'open';
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS status_transitions_update AFTER UPDATE OF status ON issues
+	WHEN old.status != new.status BEGIN
+		INSERT INTO status_transitions (issue_id, from_status, to_status, at)
+		VALUES (new.id, old.status, new.status,
+		        COALESCE(CASE WHEN new.status = 'closed' THEN datetime(new.closed_at) END, datetime('now')));
+	END`,
+}
+
+// MigrateStatusTransitions adds the status_transitions table: one row per
+// change of an issue's status, kept by triggers and never pruned with the
+// events table, for flow analytics (bd stats velocity, burndown,
+// lead-time). It is filled from the event history when first created;
+// triggers are recreated on every run in case a table rebuild dropped them.
+func MigrateStatusTransitions(db *sql.DB) error {
+	var exists int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'status_transitions'`).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to check for status_transitions: %w", err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if exists == 0 {
+		for _, stmt := range []string{
+			`CREATE TABLE status_transitions (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				issue_id TEXT NOT NULL,
+				from_status TEXT,
+				to_status TEXT NOT NULL,
+				at DATETIME NOT NULL,
+				FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+			)`,
+			`CREATE INDEX idx_status_transitions_issue ON status_transitions(issue_id)`,
+			`CREATE INDEX idx_status_transitions_at ON status_transitions(at)`,
+		} {
+			if _, err := tx.Exec(stmt); err != nil {
+				return fmt.Errorf("failed to create status_transitions table: %w", err)
+			}
+		}
+		for _, stmt := range StatusTransitionsBackfill {
+			if _, err := tx.Exec(stmt); err != nil {
+				return fmt.Errorf("failed to backfill status_transitions: %w", err)
+			}
+		}
+	}
+	for _, trigger := range statusTransitionTriggers {
+		if _, err := tx.Exec(trigger); err != nil {
+			return fmt.Errorf("failed to create status_transitions trigger: %w", err)
+		}
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,32 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateTimeboxColumn adds the timebox_minutes column to the issues table.
+// It bounds how long a spike may run once started; NULL for issues without
+// a timebox.
+func MigrateTimeboxColumn(db *sql.DB) error {
+	var columnExists bool
+	err := db.QueryRow(`
+		SELECT COUNT(*) > 0
+		FROM pragma_table_info('issues')
+		WHERE name = 'timebox_minutes'
+	`).Scan(&columnExists)
+	if err != nil {
+		return fmt.Errorf("failed to check timebox_minutes column: %w", err)
+	}
+
+	if columnExists {
+		return nil
+	}
+
+	_, err = db.Exec(`ALTER TABLE issues ADD COLUMN timebox_minutes INTEGER`)
+	if err != nil {
+		return fmt.Errorf("failed to add timebox_minutes column: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// operationLogTables lists the tables whose rows the operation log records,
+// with the column that names the issue a row belongs to
+var operationLogTables = []struct {
+	table, kind, issueColumn string
+}{
+	{"issues", "issue", "id"},
+	{"labels", "label", "issue_id"},
+	{"dependencies", "dependency", "issue_id"},
+}
+
+// operationLogIgnoredColumns change without anything else changing (a
+// comment touches updated_at) or follow from the other columns, so updates
+// of only these aren't logged
+var operationLogIgnoredColumns = map[string]bool{"updated_at": true, "content_hash": true}
+
+// MigrateOperationLog adds the append-only operation log behind bd undo and
+// bd history: operations groups the writes of one bd command (or one daemon
+// request), and operation_changes holds a before and after image of every
+// issue, label and dependency row it inserted, updated or deleted. Triggers
+// write the changes and read the current operation from the single
+// operation_context row, which each write transaction sets first; the
+// operation itself is created with its first change, so transactions that
+// change nothing logged leave no trace.
+//
+// The triggers copy every column, so they are generated from the table
+// definitions and recreated on every run: a later migration adding a column
+// is picked up the next time the database is opened.
+func MigrateOperationLog(db *sql.DB) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for _, stmt := range []string{
+		`CREATE TABLE IF NOT EXISTS operations (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			op_key TEXT NOT NULL UNIQUE,
+			actor TEXT NOT NULL DEFAULT '',
+			command TEXT NOT NULL DEFAULT '',
+			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			undone_by INTEGER
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_operations_created_at ON operations(created_at)`,
+		`CREATE INDEX IF NOT EXISTS idx_operations_undone_by ON operations(undone_by)`,
+		`CREATE TABLE IF NOT EXISTS operation_changes (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			op_id INTEGER,
+			issue_id TEXT NOT NULL,
+			kind TEXT NOT NULL,
+			action TEXT NOT NULL,
+			before TEXT,
+			after TEXT,
+			at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_operation_changes_op ON operation_changes(op_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_operation_changes_issue ON operation_changes(issue_id)`,
+		`CREATE TABLE IF NOT EXISTS operation_context (
+			id INTEGER PRIMARY KEY CHECK (id = 1),
+			op_key TEXT,
+			actor TEXT NOT NULL DEFAULT '',
+			command TEXT NOT NULL DEFAULT ''
+		)`,
+		`INSERT OR IGNORE INTO operation_context (id) VALUES (1)`,
+	} {
+		if _, err := tx.Exec(stmt); err != nil {
+			return fmt.Errorf("failed to create operation log: %w", err)
+		}
+	}
+
+	for _, t := range operationLogTables {
+		columns, err := tableColumns(tx, t.table)
+		if err != nil {
+			return err
+		}
+		for _, trigger := range operationLogTriggers(t.table, t.kind, t.issueColumn, columns) {
+			if _, err := tx.Exec(trigger); err != nil {
+				return fmt.Errorf("failed to create operation log trigger on %s: %w", t.table, err)
+			}
+		}
+	}
+	return tx.Commit()
+}
+
+// tableColumns returns the column names of table in order
+func tableColumns(tx *sql.Tx, table string) ([]string, error) {
+	rows, err := tx.Query(`SELECT name FROM pragma_table_info(?)`, table)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s columns: %w", table, err)
+	}
+	defer func() { _ = rows.Close() }()
+	var columns []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to read %s columns: %w", table, err)
+		}
+		columns = append(columns, name)
+	}
+	return columns, rows.Err()
+}
+
+// operationLogTriggers returns the statements (re)creating the insert,
+// update and delete triggers that log table's rows
+func operationLogTriggers(table, kind, issueColumn string, columns []string) []string {
+	image := func(row string) string {
+		pairs := make([]string, len(columns))
+		for i, c := range columns {
+			pairs[i] = fmt.Sprintf("'%s', %s.%s", c, row, c)
+		}
+		return "json_object(" + strings.Join(pairs, ", ") + ")"
+	}
+	var changed []string
+	for _, c := range columns {
+		if !operationLogIgnoredColumns[c] {
+			changed = append(changed, fmt.Sprintf("old.%s IS NOT new.%s", c, c))
+		}
+	}
+	// NOT EXISTS rather than INSERT OR IGNORE, which would use up an
+	// AUTOINCREMENT id on every change and leave gaps in the numbering
+	insert := `INSERT INTO operations (op_key, actor, command)
+		SELECT c.op_key, c.actor, c.command FROM operation_context c
+		WHERE c.id = 1 AND c.op_key IS NOT NULL
+		  AND NOT EXISTS (SELECT 1 FROM operations o WHERE o.op_key = c.op_key);
+		INSERT INTO operation_changes (op_id, issue_id, kind, action, before, after)
+		VALUES ((SELECT o.id FROM operations o JOIN operation_context c ON c.op_key = o.op_key WHERE c.id = 1),
+		        %s.%s, '%s', '%s', %s, %s);`
+
+	// #nosec G201 - table and column names come from the schema, not input
+	return []string{
+		fmt.Sprintf(`DROP TRIGGER IF EXISTS oplog_%s_insert`, table),
+		fmt.Sprintf(`DROP TRIGGER IF EXISTS oplog_%s_update`, table),
+		fmt.Sprintf(`DROP TRIGGER IF EXISTS oplog_%s_delete`, table),
+		fmt.Sprintf(`CREATE TRIGGER oplog_%s_insert AFTER INSERT ON %s BEGIN
+		`+insert+`
+	END`, table, table, "new", issueColumn, kind, "insert", "NULL", image("new")),
+		fmt.Sprintf(`CREATE TRIGGER oplog_%s_update AFTER UPDATE ON %s
+	WHEN %s BEGIN
+		`+insert+`
+	END`, table, table, strings.Join(changed, " OR "), "new", issueColumn, kind, "update", image("old"), image("new")),
+		fmt.Sprintf(`CREATE TRIGGER oplog_%s_delete AFTER DELETE ON %s BEGIN
+		`+insert+`
+	END`, table, table, "old", issueColumn, kind, "delete", image("old"), "NULL"),
+	}
+}
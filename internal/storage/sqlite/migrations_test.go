@@ -489,9 +489,12 @@ func TestMigrateContentHashColumn(t *testing.T) {
 				relates_to TEXT DEFAULT '',
 				duplicate_of TEXT DEFAULT '',
 				superseded_by TEXT DEFAULT '',
+				complexity TEXT DEFAULT '',
+				source TEXT DEFAULT '',
+				timebox_minutes INTEGER,
 				CHECK ((status = 'closed') = (closed_at IS NOT NULL))
 			);
-			INSERT INTO issues SELECT id, title, description, design, acceptance_criteria, notes, status, priority, issue_type, assignee, estimated_minutes, created_at, updated_at, closed_at, external_ref, compaction_level, compacted_at, original_size, compacted_at_commit, source_repo, '', NULL, '', '', '', '', 0, '', '', '', '' FROM issues_backup;
+			INSERT INTO issues SELECT id, title, description, design, acceptance_criteria, notes, status, priority, issue_type, assignee, estimated_minutes, created_at, updated_at, closed_at, external_ref, compaction_level, compacted_at, original_size, compacted_at_commit, source_repo, '', NULL, '', '', '', '', 0, '', '', '', '', '', '', NULL FROM issues_backup;
 			DROP TABLE issues_backup;
 		`)
 		if err != nil {
@@ -706,3 +709,132 @@ func TestMigrateOrphanDetection(t *testing.T) {
 		}
 	})
 }
+
+func TestMigrateUTCTimestamps(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	db := store.db
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Written in Berlin", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatal(err)
+	}
+	// Simulate a row written by an older version in a +02:00 zone, plus a
+	// CURRENT_TIMESTAMP default that has no offset
+	if _, err := db.Exec(`UPDATE issues SET created_at = ?, updated_at = ? WHERE id = ?`,
+		"2025-06-01T14:30:00.5+02:00", "2025-06-01 12:00:00", issue.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ { // idempotent
+		if err := migrations.MigrateUTCTimestamps(db); err != nil {
+			t.Fatalf("MigrateUTCTimestamps: %v", err)
+		}
+	}
+
+	var created, updated string
+	// Concatenate to read the stored text rather than a driver-parsed time
+	if err := db.QueryRow(`SELECT created_at || '', updated_at || '' FROM issues WHERE id = ?`, issue.ID).Scan(&created, &updated); err != nil {
+		t.Fatal(err)
+	}
+	if created != "2025-06-01T12:30:00.5Z" {
+		t.Errorf("created_at = %q, want UTC", created)
+	}
+	if updated != "2025-06-01 12:00:00" {
+		t.Errorf("updated_at = %q, want CURRENT_TIMESTAMP value untouched", updated)
+	}
+}
+
+func TestMigrateExternalDependencies(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	db := store.db
+	ctx := context.Background()
+
+	blocker := &types.Issue{Title: "Blocker", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	blocked := &types.Issue{Title: "Blocked", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{blocker, blocked} {
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: blocked.ID, DependsOnID: blocker.ID, Type: types.DepBlocks}, "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Put back the dependencies table of older versions, with its foreign
+	// key on depends_on_id
+	if _, err := db.Exec(`PRAGMA foreign_keys = OFF`); err != nil {
+		t.Fatal(err)
+	}
+	for _, stmt := range []string{
+		`DROP TRIGGER IF EXISTS dependencies_target_delete`,
+		`CREATE TABLE dependencies_old (
+			issue_id TEXT NOT NULL,
+			depends_on_id TEXT NOT NULL,
+			type TEXT NOT NULL DEFAULT 'blocks',
+			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			created_by TEXT NOT NULL,
+			metadata TEXT DEFAULT '{}',
+			thread_id TEXT DEFAULT '',
+			PRIMARY KEY (issue_id, depends_on_id),
+			FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE,
+			FOREIGN KEY (depends_on_id) REFERENCES issues(id) ON DELETE CASCADE
+		)`,
+		`INSERT INTO dependencies_old SELECT issue_id, depends_on_id, type, created_at, created_by, metadata, thread_id FROM dependencies`,
+		`DROP VIEW IF EXISTS ready_issues`,
+		`DROP VIEW IF EXISTS blocked_issues`,
+		`DROP TABLE dependencies`,
+		`ALTER TABLE dependencies_old RENAME TO dependencies`,
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+	if _, err := db.Exec(`PRAGMA foreign_keys = ON`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(schema); err != nil { // views
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ { // idempotent
+		if err := migrations.MigrateExternalDependencies(db); err != nil {
+			t.Fatalf("MigrateExternalDependencies: %v", err)
+		}
+	}
+
+	var fks int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM pragma_foreign_key_list('dependencies') WHERE "from" = 'depends_on_id'`).Scan(&fks); err != nil {
+		t.Fatal(err)
+	}
+	if fks != 0 {
+		t.Error("depends_on_id foreign key still present")
+	}
+	var n int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM dependencies WHERE issue_id = ? AND depends_on_id = ?`, blocked.ID, blocker.ID).Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Fatalf("dependency lost in rebuild")
+	}
+	if err := db.QueryRow(`SELECT COUNT(*) FROM blocked_issues`).Scan(&n); err != nil {
+		t.Fatalf("blocked_issues view broken: %v", err)
+	}
+
+	// An external target is accepted now, and deleting a local target still
+	// removes the edges to it
+	if _, err := db.Exec(`INSERT INTO dependencies (issue_id, depends_on_id, type, created_by) VALUES (?, 'api/bd-42', 'blocks', 'test')`, blocked.ID); err != nil {
+		t.Fatalf("external dependency rejected: %v", err)
+	}
+	if _, err := db.Exec(`DELETE FROM issues WHERE id = ?`, blocker.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.QueryRow(`SELECT COUNT(*) FROM dependencies WHERE depends_on_id = ?`, blocker.ID).Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	if n != 0 {
+		t.Errorf("dependencies on a deleted issue not removed")
+	}
+}
@@ -2,7 +2,6 @@
 package sqlite
 
 import (
-	"bufio"
 	"context"
 	"database/sql"
 	"encoding/json"
@@ -14,6 +13,7 @@ import (
 
 	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/util"
 )
 
 // HydrateFromMultiRepo loads issues from all configured repositories into the database.
@@ -109,7 +109,7 @@ func (s *SQLiteStorage) hydrateFromRepo(ctx context.Context, repoPath, sourceRep
 	_, err = s.db.ExecContext(ctx, `
 		INSERT OR REPLACE INTO repo_mtimes (repo_path, jsonl_path, mtime_ns, last_checked)
 		VALUES (?, ?, ?, ?)
-	`, absRepoPath, jsonlPath, currentMtime, time.Now())
+	`, absRepoPath, jsonlPath, currentMtime, time.Now().UTC())
 	if err != nil {
 		return 0, fmt.Errorf("failed to update mtime cache: %w", err)
 	}
@@ -132,16 +132,13 @@ func (s *SQLiteStorage) importJSONLFile(ctx context.Context, jsonlPath, sourceRe
 		return 0, fmt.Errorf("failed to get custom statuses: %w", err)
 	}
 
-	scanner := bufio.NewScanner(file)
-	// Increase buffer size for large issues
-	buf := make([]byte, 0, 64*1024)
-	scanner.Buffer(buf, 10*1024*1024) // 10MB max line size
+	scanner := util.NewJSONLScanner(file)
 
 	count := 0
 	lineNum := 0
 
 	// Get exclusive connection to ensure PRAGMA applies
-	conn, err := s.db.Conn(ctx)
+	conn, err := s.writeConn(ctx)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get connection: %w", err)
 	}
@@ -161,6 +158,10 @@ func (s *SQLiteStorage) importJSONLFile(ctx context.Context, jsonlPath, sourceRe
 	}
 	defer tx.Rollback()
 
+	if err := tagOperation(ctx, tx); err != nil {
+		return 0, err
+	}
+
 	for scanner.Scan() {
 		lineNum++
 		line := scanner.Text()
@@ -217,6 +218,27 @@ func (s *SQLiteStorage) importJSONLFile(ctx context.Context, jsonlPath, sourceRe
 		)
 	}
 
+	_ = rows.Close()
+
+	// depends_on_id has no foreign key (it may name an issue in a linked
+	// workspace), so check local targets by hand
+	var issueID, dependsOnID string
+	err = tx.QueryRowContext(ctx, `
+		SELECT d.issue_id, d.depends_on_id FROM dependencies d
+		WHERE instr(d.depends_on_id, '/') = 0
+		  AND NOT EXISTS (SELECT 1 FROM issues i WHERE i.id = d.depends_on_id)
+		LIMIT 1
+	`).Scan(&issueID, &dependsOnID)
+	if err == nil {
+		return 0, fmt.Errorf(
+			"foreign key violation in imported data: table=dependencies issue=%s parent=issues depends_on=%s",
+			issueID, dependsOnID,
+		)
+	}
+	if err != sql.ErrNoRows {
+		return 0, fmt.Errorf("failed to check dependency targets: %w", err)
+	}
+
 	if err := tx.Commit(); err != nil {
 		return 0, fmt.Errorf("failed to commit transaction: %w", err)
 	}
@@ -270,8 +292,8 @@ func (s *SQLiteStorage) upsertIssueInTx(ctx context.Context, tx *sql.Tx, issue *
 				status, priority, issue_type, assignee, estimated_minutes,
 				created_at, updated_at, closed_at, external_ref, source_repo, close_reason,
 				deleted_at, deleted_by, delete_reason, original_type,
-				sender, ephemeral
-			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+				sender, ephemeral, complexity, source, timebox_minutes
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		`,
 			issue.ID, issue.ContentHash, issue.Title, issue.Description, issue.Design,
 			issue.AcceptanceCriteria, issue.Notes, issue.Status,
@@ -279,7 +301,7 @@ func (s *SQLiteStorage) upsertIssueInTx(ctx context.Context, tx *sql.Tx, issue *
 			issue.EstimatedMinutes, issue.CreatedAt, issue.UpdatedAt,
 			issue.ClosedAt, issue.ExternalRef, issue.SourceRepo, issue.CloseReason,
 			issue.DeletedAt, issue.DeletedBy, issue.DeleteReason, issue.OriginalType,
-			issue.Sender, ephemeral,
+			issue.Sender, ephemeral, issue.Complexity, issue.Source, issue.TimeboxMinutes,
 		)
 		if err != nil {
 			return fmt.Errorf("failed to insert issue: %w", err)
@@ -303,7 +325,7 @@ func (s *SQLiteStorage) upsertIssueInTx(ctx context.Context, tx *sql.Tx, issue *
 					issue_type = ?, assignee = ?, estimated_minutes = ?,
 					updated_at = ?, closed_at = ?, external_ref = ?, source_repo = ?,
 					deleted_at = ?, deleted_by = ?, delete_reason = ?, original_type = ?,
-					sender = ?, ephemeral = ?
+					sender = ?, ephemeral = ?, complexity = ?, source = ?, timebox_minutes = ?
 				WHERE id = ?
 			`,
 				issue.ContentHash, issue.Title, issue.Description, issue.Design,
@@ -311,7 +333,7 @@ func (s *SQLiteStorage) upsertIssueInTx(ctx context.Context, tx *sql.Tx, issue *
 				issue.IssueType, issue.Assignee, issue.EstimatedMinutes,
 				issue.UpdatedAt, issue.ClosedAt, issue.ExternalRef, issue.SourceRepo,
 				issue.DeletedAt, issue.DeletedBy, issue.DeleteReason, issue.OriginalType,
-				issue.Sender, ephemeral,
+				issue.Sender, ephemeral, issue.Complexity, issue.Source, issue.TimeboxMinutes,
 				issue.ID,
 			)
 			if err != nil {
@@ -353,6 +375,58 @@ func (s *SQLiteStorage) upsertIssueInTx(ctx context.Context, tx *sql.Tx, issue *
 		}
 	}
 
+	// Import attachments if present
+	for _, a := range issue.Attachments {
+		_, err = tx.ExecContext(ctx, `
+			INSERT OR IGNORE INTO attachments (issue_id, name, url, sha256, size, created_by, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+		`, issue.ID, a.Name, a.URL, a.SHA256, a.Size, a.CreatedBy, a.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to import attachment: %w", err)
+		}
+	}
+
+	// Import work log if present
+	for _, e := range issue.WorkLog {
+		_, err = tx.ExecContext(ctx, `
+			INSERT OR IGNORE INTO work_log (issue_id, actor, minutes, note, created_at)
+			VALUES (?, ?, ?, ?, ?)
+		`, issue.ID, e.Actor, e.Minutes, e.Note, e.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to import work log entry: %w", err)
+		}
+	}
+
+	// Import aliases if present; one already taken by another issue is skipped
+	for _, alias := range issue.Aliases {
+		_, err = tx.ExecContext(ctx, `
+			INSERT OR IGNORE INTO issue_aliases (alias, issue_id) VALUES (?, ?)
+		`, alias, issue.ID)
+		if err != nil {
+			return fmt.Errorf("failed to import alias: %w", err)
+		}
+	}
+
+	// Import translations if present
+	for _, t := range issue.Translations {
+		_, err = tx.ExecContext(ctx, `
+			INSERT OR REPLACE INTO issue_translations (issue_id, locale, title, description) VALUES (?, ?, ?, ?)
+		`, issue.ID, t.Locale, t.Title, t.Description)
+		if err != nil {
+			return fmt.Errorf("failed to import translation: %w", err)
+		}
+	}
+
+	// Import custom field values if present
+	for name, value := range issue.Fields {
+		_, err = tx.ExecContext(ctx, `
+			INSERT OR REPLACE INTO issue_fields (issue_id, name, value) VALUES (?, ?, ?)
+		`, issue.ID, name, value)
+		if err != nil {
+			return fmt.Errorf("failed to import field %s: %w", name, err)
+		}
+	}
+
 	return nil
 }
 
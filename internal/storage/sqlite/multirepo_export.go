@@ -11,6 +11,8 @@ import (
 
 	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/debug"
+	"github.com/steveyegge/beads/internal/encryption"
+	"github.com/steveyegge/beads/internal/redact"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -141,10 +143,22 @@ func (s *SQLiteStorage) exportToRepo(ctx context.Context, repoPath string, issue
 		}
 	}()
 
-	// Write JSONL
+	// Write JSONL, redacting secrets that are stored in the DB
+	redactor, err := redact.FromConfig()
+	if err != nil {
+		return 0, err
+	}
+	fieldCipher, err := encryption.FromConfig(filepath.Dir(jsonlPath))
+	if err != nil {
+		return 0, err
+	}
 	encoder := json.NewEncoder(f)
 	for _, issue := range issues {
-		if err := encoder.Encode(issue); err != nil {
+		exported, err := fieldCipher.ForExport(redactor.ForExport(issue))
+		if err != nil {
+			return 0, err
+		}
+		if err := encoder.Encode(exported); err != nil {
 			return 0, fmt.Errorf("failed to encode issue %s: %w", issue.ID, err)
 		}
 	}
@@ -0,0 +1,557 @@
+package sqlite
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// operationContextKey carries the operation writes are logged under
+type operationContextKey struct{}
+
+type operationInfo struct {
+	key, actor, command string
+}
+
+// WithOperation returns a context whose writes are logged as one operation,
+// the unit bd undo reverts. Writes with the same key, in any process, join
+// the same operation; without one each write transaction is its own.
+func WithOperation(ctx context.Context, key, actor, command string) context.Context {
+	return context.WithValue(ctx, operationContextKey{}, operationInfo{key: key, actor: actor, command: command})
+}
+
+// NewOperationKey returns a fresh key for WithOperation
+func NewOperationKey() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
+
+// tagOperation points the operation log triggers at ctx's operation for the
+// rest of q's write transaction. It must run inside the transaction, which
+// serializes it with every other writer.
+func tagOperation(ctx context.Context, q execer) error {
+	op, ok := ctx.Value(operationContextKey{}).(operationInfo)
+	if !ok || op.key == "" {
+		op = operationInfo{key: NewOperationKey()}
+	}
+	_, err := q.ExecContext(ctx, `UPDATE operation_context SET op_key = ?, actor = ?, command = ? WHERE id = 1`,
+		op.key, op.actor, op.command)
+	if err != nil {
+		return fmt.Errorf("failed to tag operation: %w", err)
+	}
+	return nil
+}
+
+// Operation is one logged bd command or daemon request and what it changed
+type Operation struct {
+	ID        int64     `json:"id"`
+	Actor     string    `json:"actor,omitempty"`
+	Command   string    `json:"command,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	// UndoneBy is the operation that undid this one
+	UndoneBy *int64 `json:"undone_by,omitempty"`
+	// Undoes lists the operations this one undid
+	Undoes  []int64  `json:"undoes,omitempty"`
+	Changes int      `json:"changes"`
+	Issues  []string `json:"issues"`
+}
+
+// OperationChange is one row an operation inserted, updated or deleted.
+// Before and After hold the row's columns; Before is nil for inserts and
+// After for deletes.
+type OperationChange struct {
+	ID       int64                  `json:"id"`
+	OpID     *int64                 `json:"op_id,omitempty"`
+	Actor    string                 `json:"actor,omitempty"`
+	Command  string                 `json:"command,omitempty"`
+	UndoneBy *int64                 `json:"undone_by,omitempty"`
+	IssueID  string                 `json:"issue_id"`
+	Kind     string                 `json:"kind"`   // issue, label or dependency
+	Action   string                 `json:"action"` // insert, update or delete
+	Before   map[string]interface{} `json:"before,omitempty"`
+	After    map[string]interface{} `json:"after,omitempty"`
+	At       time.Time              `json:"at"`
+}
+
+// operationSelect reads operations newest first, with what they changed
+const operationSelect = `
+	SELECT o.id, o.actor, o.command, o.created_at, o.undone_by,
+	       COALESCE((SELECT group_concat(u.id) FROM operations u WHERE u.undone_by = o.id), ''),
+	       (SELECT COUNT(*) FROM operation_changes c WHERE c.op_id = o.id),
+	       COALESCE((SELECT group_concat(issue_id) FROM (
+	           SELECT DISTINCT issue_id FROM operation_changes c WHERE c.op_id = o.id ORDER BY issue_id)), '')
+	FROM operations o`
+
+// ListOperations returns the latest operations, newest first
+func (s *SQLiteStorage) ListOperations(ctx context.Context, limit int) ([]*Operation, error) {
+	return s.queryOperations(ctx, operationSelect+` ORDER BY o.id DESC LIMIT ?`, limit)
+}
+
+// GetOperation returns an operation, or nil if there is none with that ID
+func (s *SQLiteStorage) GetOperation(ctx context.Context, id int64) (*Operation, error) {
+	ops, err := s.queryOperations(ctx, operationSelect+` WHERE o.id = ?`, id)
+	if err != nil || len(ops) == 0 {
+		return nil, err
+	}
+	return ops[0], nil
+}
+
+// GetUndoableOperations returns the latest n operations bd undo would take
+// back, newest first: those not undone already, and not undos themselves
+func (s *SQLiteStorage) GetUndoableOperations(ctx context.Context, n int) ([]*Operation, error) {
+	return s.queryOperations(ctx, operationSelect+`
+		WHERE o.undone_by IS NULL AND NOT EXISTS (SELECT 1 FROM operations u WHERE u.undone_by = o.id)
+		ORDER BY o.id DESC LIMIT ?`, n)
+}
+
+func (s *SQLiteStorage) queryOperations(ctx context.Context, query string, args ...interface{}) ([]*Operation, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query operations: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var ops []*Operation
+	for rows.Next() {
+		var op Operation
+		var createdAt, undoes, issues string
+		var undoneBy sql.NullInt64
+		if err := rows.Scan(&op.ID, &op.Actor, &op.Command, &createdAt, &undoneBy, &undoes, &op.Changes, &issues); err != nil {
+			return nil, fmt.Errorf("failed to scan operation: %w", err)
+		}
+		if op.CreatedAt, err = parseTransitionTime(createdAt); err != nil {
+			return nil, err
+		}
+		if undoneBy.Valid {
+			op.UndoneBy = &undoneBy.Int64
+		}
+		for _, id := range splitList(undoes) {
+			var n int64
+			if _, err := fmt.Sscan(id, &n); err == nil {
+				op.Undoes = append(op.Undoes, n)
+			}
+		}
+		op.Issues = splitList(issues)
+		ops = append(ops, &op)
+	}
+	return ops, rows.Err()
+}
+
+// changeSelect reads logged changes with the operation they belong to
+const changeSelect = `
+	SELECT c.id, c.op_id, COALESCE(o.actor, ''), COALESCE(o.command, ''), o.undone_by,
+	       c.issue_id, c.kind, c.action, c.before, c.after, c.at
+	FROM operation_changes c
+	LEFT JOIN operations o ON o.id = c.op_id`
+
+// GetOperationChanges returns what an operation changed, in order
+func (s *SQLiteStorage) GetOperationChanges(ctx context.Context, opID int64) ([]*OperationChange, error) {
+	return s.queryChanges(ctx, s.db, changeSelect+` WHERE c.op_id = ? ORDER BY c.id`, opID)
+}
+
+// GetIssueHistory returns every logged change to an issue and its labels
+// and dependencies, oldest first
+func (s *SQLiteStorage) GetIssueHistory(ctx context.Context, issueID string) ([]*OperationChange, error) {
+	return s.queryChanges(ctx, s.db, changeSelect+` WHERE c.issue_id = ? ORDER BY c.id`, issueID)
+}
+
+type rowQuerier interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+func (s *SQLiteStorage) queryChanges(ctx context.Context, q rowQuerier, query string, args ...interface{}) ([]*OperationChange, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query operation changes: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var changes []*OperationChange
+	for rows.Next() {
+		var c OperationChange
+		var opID, undoneBy sql.NullInt64
+		var before, after sql.NullString
+		var at string
+		if err := rows.Scan(&c.ID, &opID, &c.Actor, &c.Command, &undoneBy, &c.IssueID, &c.Kind, &c.Action, &before, &after, &at); err != nil {
+			return nil, fmt.Errorf("failed to scan operation change: %w", err)
+		}
+		if opID.Valid {
+			c.OpID = &opID.Int64
+		}
+		if undoneBy.Valid {
+			c.UndoneBy = &undoneBy.Int64
+		}
+		for _, image := range []struct {
+			raw sql.NullString
+			dst *map[string]interface{}
+		}{{before, &c.Before}, {after, &c.After}} {
+			if !image.raw.Valid {
+				continue
+			}
+			if err := json.Unmarshal([]byte(image.raw.String), image.dst); err != nil {
+				return nil, fmt.Errorf("failed to read change %d: %w", c.ID, err)
+			}
+		}
+		if c.At, err = parseTransitionTime(at); err != nil {
+			return nil, err
+		}
+		changes = append(changes, &c)
+	}
+	return changes, rows.Err()
+}
+
+// UndoResult describes how UndoOperations took back one operation
+type UndoResult struct {
+	Operation *Operation `json:"operation"`
+	Reverted  int        `json:"reverted"`
+	Issues    []string   `json:"issues"`
+	// Conflicts lists later changes to the same issues by operations that
+	// are still in effect
+	Conflicts []string `json:"conflicts,omitempty"`
+	DryRun    bool     `json:"dry_run,omitempty"`
+}
+
+// operationLogKeys are the columns identifying a logged row
+var operationLogKeys = map[string]struct {
+	table string
+	keys  []string
+}{
+	"issue":      {"issues", []string{"id"}},
+	"label":      {"labels", []string{"issue_id", "label"}},
+	"dependency": {"dependencies", []string{"issue_id", "depends_on_id"}},
+}
+
+// UndoOperations reverts what the given operations changed, in the order
+// given and each newest change first, all in one transaction that is logged
+// as ctx's operation. Issues an operation created become tombstones, so the
+// removal reaches other clones through the JSONL; deleted rows come back,
+// and updated ones get their earlier values. Undoing an undo redoes the
+// operations it took back. It refuses when later operations that are still
+// in effect changed the same issues, unless force is set, returning the
+// results so far with the conflicts of the last. With dryRun the changes
+// are made and rolled back.
+func (s *SQLiteStorage) UndoOperations(ctx context.Context, opIDs []int64, actor string, force, dryRun bool) ([]*UndoResult, error) {
+	ops := make([]*Operation, len(opIDs))
+	for i, id := range opIDs {
+		op, err := s.GetOperation(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if op == nil {
+			return nil, fmt.Errorf("%w: operation #%d", ErrNotFound, id)
+		}
+		if op.UndoneBy != nil {
+			return nil, fmt.Errorf("operation #%d was already undone by #%d", id, *op.UndoneBy)
+		}
+		ops[i] = op
+	}
+
+	conn, err := s.writeConn(ctx)
+	if err != nil {
+		return nil, wrapDBError("begin transaction", err)
+	}
+	defer func() { _ = conn.Close() }()
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, wrapDBError("begin transaction", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	if err := tagOperation(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	var results []*UndoResult
+	for _, op := range ops {
+		result, err := s.undoOperation(ctx, tx, op, actor, force)
+		if result != nil {
+			result.DryRun = dryRun
+			results = append(results, result)
+		}
+		if err != nil {
+			return results, err
+		}
+	}
+
+	if dryRun {
+		return results, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, wrapDBError("commit undo", err)
+	}
+	return results, nil
+}
+
+// undoOperation reverts one operation inside tx
+func (s *SQLiteStorage) undoOperation(ctx context.Context, tx *sql.Tx, op *Operation, actor string, force bool) (*UndoResult, error) {
+	opID := op.ID
+	result := &UndoResult{Operation: op, Issues: op.Issues}
+	var err error
+	if result.Conflicts, err = undoConflicts(ctx, tx, opID); err != nil {
+		return nil, err
+	}
+	if len(result.Conflicts) > 0 && !force {
+		return result, fmt.Errorf("%w: later operations changed the same issues", ErrConflict)
+	}
+
+	changes, err := s.queryChanges(ctx, tx, changeSelect+` WHERE c.op_id = ? ORDER BY c.id DESC`, opID)
+	if err != nil {
+		return nil, err
+	}
+	columns := make(map[string]map[string]bool)
+	for _, c := range changes {
+		spec, ok := operationLogKeys[c.Kind]
+		if !ok {
+			return nil, fmt.Errorf("change %d: unknown kind %q", c.ID, c.Kind)
+		}
+		if columns[spec.table] == nil {
+			if columns[spec.table], err = liveColumns(ctx, tx, spec.table); err != nil {
+				return nil, err
+			}
+		}
+		if err := revertChange(ctx, tx, c, spec.table, spec.keys, columns[spec.table], opID, actor); err != nil {
+			return nil, err
+		}
+		result.Reverted++
+	}
+
+	now := time.Now().UTC()
+	for _, id := range op.Issues {
+		var exists int
+		if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM issues WHERE id = ?`, id).Scan(&exists); err != nil {
+			return nil, fmt.Errorf("failed to check %s: %w", id, err)
+		}
+		if exists == 0 {
+			continue
+		}
+		if err := refreshContentHash(ctx, tx, id); err != nil {
+			return nil, err
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO dirty_issues (issue_id, marked_at) VALUES (?, ?)
+			ON CONFLICT (issue_id) DO UPDATE SET marked_at = excluded.marked_at
+		`, id, now); err != nil {
+			return nil, fmt.Errorf("failed to mark issue dirty: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO events (issue_id, event_type, actor, comment) VALUES (?, ?, ?, ?)
+		`, id, types.EventUpdated, actor, fmt.Sprintf("Undid operation #%d (%s)", opID, op.Command)); err != nil {
+			return nil, fmt.Errorf("failed to record undo event: %w", err)
+		}
+	}
+	if err := s.invalidateBlockedCache(ctx, tx); err != nil {
+		return nil, fmt.Errorf("failed to invalidate blocked cache: %w", err)
+	}
+
+	// The undo is an operation of its own even if reverting changed nothing,
+	// so that it can be undone in turn. Undoing an undo puts the operations
+	// it took back into effect again.
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO operations (op_key, actor, command)
+		SELECT c.op_key, c.actor, c.command FROM operation_context c
+		WHERE c.id = 1 AND NOT EXISTS (SELECT 1 FROM operations o WHERE o.op_key = c.op_key)
+	`); err != nil {
+		return nil, fmt.Errorf("failed to record undo: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE operations SET undone_by = NULL WHERE undone_by = ?`, opID); err != nil {
+		return nil, fmt.Errorf("failed to record undo: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE operations SET undone_by = (
+			SELECT o.id FROM operations o JOIN operation_context c ON c.op_key = o.op_key WHERE c.id = 1)
+		WHERE id = ?
+	`, opID); err != nil {
+		return nil, fmt.Errorf("failed to record undo: %w", err)
+	}
+	return result, nil
+}
+
+// undoConflicts describes the changes made after opID to the issues it
+// changed, by operations still in effect: not undone, and not undos
+func undoConflicts(ctx context.Context, tx *sql.Tx, opID int64) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT c.issue_id, c.op_id, COALESCE(o.command, ''), COUNT(*)
+		FROM operation_changes c
+		LEFT JOIN operations o ON o.id = c.op_id
+		WHERE c.id > (SELECT MAX(id) FROM operation_changes WHERE op_id = ?)
+		  AND c.issue_id IN (SELECT issue_id FROM operation_changes WHERE op_id = ?)
+		  AND (c.op_id IS NULL OR (o.undone_by IS NULL
+		       AND NOT EXISTS (SELECT 1 FROM operations u WHERE u.undone_by = o.id)))
+		GROUP BY c.issue_id, c.op_id
+		ORDER BY MIN(c.id)
+	`, opID, opID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check later changes: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var conflicts []string
+	for rows.Next() {
+		var issueID, command string
+		var laterOp sql.NullInt64
+		var n int
+		if err := rows.Scan(&issueID, &laterOp, &command, &n); err != nil {
+			return nil, fmt.Errorf("failed to check later changes: %w", err)
+		}
+		switch {
+		case !laterOp.Valid:
+			conflicts = append(conflicts, fmt.Sprintf("%s was changed outside bd", issueID))
+		case command != "":
+			conflicts = append(conflicts, fmt.Sprintf("%s was changed by #%d (%s)", issueID, laterOp.Int64, command))
+		default:
+			conflicts = append(conflicts, fmt.Sprintf("%s was changed by #%d", issueID, laterOp.Int64))
+		}
+	}
+	return conflicts, rows.Err()
+}
+
+// liveColumns returns the columns table has now
+func liveColumns(ctx context.Context, tx *sql.Tx, table string) (map[string]bool, error) {
+	rows, err := tx.QueryContext(ctx, `SELECT name FROM pragma_table_info(?)`, table)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s columns: %w", table, err)
+	}
+	defer func() { _ = rows.Close() }()
+	columns := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to read %s columns: %w", table, err)
+		}
+		columns[name] = true
+	}
+	return columns, rows.Err()
+}
+
+// revertChange takes back one logged change inside tx
+func revertChange(ctx context.Context, tx *sql.Tx, c *OperationChange, table string, keys []string, columns map[string]bool, opID int64, actor string) error {
+	switch c.Action {
+	case "insert":
+		where, args := keyClause(keys, c.After)
+		if table == "issues" {
+			// A tombstone rather than a deletion, so that clones which
+			// already imported the issue drop it too
+			now := time.Now().UTC()
+			// #nosec G202 - keyClause builds the clause from fixed column names
+			_, err := tx.ExecContext(ctx, `
+				UPDATE issues
+				SET status = ?, closed_at = NULL, deleted_at = ?, deleted_by = ?,
+				    delete_reason = ?, original_type = issue_type, updated_at = ?
+				WHERE status != ? AND `+where,
+				append([]interface{}{types.StatusTombstone, now, actor,
+					fmt.Sprintf("undo of operation #%d", opID), now, types.StatusTombstone}, args...)...)
+			if err != nil {
+				return fmt.Errorf("failed to remove %s: %w", c.IssueID, err)
+			}
+			return nil
+		}
+		// #nosec G202 - keyClause builds the clause from fixed column names
+		if _, err := tx.ExecContext(ctx, `DELETE FROM `+table+` WHERE `+where, args...); err != nil {
+			return fmt.Errorf("failed to remove %s %s: %w", c.IssueID, c.Kind, err)
+		}
+		return nil
+	case "update":
+		for _, k := range keys {
+			if fmt.Sprint(c.Before[k]) != fmt.Sprint(c.After[k]) {
+				return fmt.Errorf("operation #%d renamed %v to %v, which undo can't take back; rename it back instead", opID, c.Before[k], c.After[k])
+			}
+		}
+		return restoreRow(ctx, tx, c, table, keys, columns)
+	case "delete":
+		return restoreRow(ctx, tx, c, table, keys, columns)
+	default:
+		return fmt.Errorf("change %d: unknown action %q", c.ID, c.Action)
+	}
+}
+
+// restoreRow writes a change's before image back: over the row if it is
+// there, or as a new row if it isn't. Columns dropped since are skipped.
+func restoreRow(ctx context.Context, tx *sql.Tx, c *OperationChange, table string, keys []string, columns map[string]bool) error {
+	var names []string
+	for name := range c.Before {
+		if columns[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	raw, err := json.Marshal(c.Before)
+	if err != nil {
+		return err
+	}
+
+	sets := make([]string, len(names))
+	values := make([]string, len(names))
+	args := make([]interface{}, len(names))
+	for i, name := range names {
+		sets[i] = fmt.Sprintf("%s = json_extract(?, '$.%s')", name, name)
+		values[i] = fmt.Sprintf("json_extract(?, '$.%s')", name)
+		args[i] = string(raw)
+	}
+	where, keyArgs := keyClause(keys, c.Before)
+
+	// #nosec G201 G202 - column names come from the schema, values are bound
+	res, err := tx.ExecContext(ctx, `UPDATE `+table+` SET `+strings.Join(sets, ", ")+` WHERE `+where, append(args, keyArgs...)...)
+	if err != nil {
+		return fmt.Errorf("failed to restore %s %s: %w", c.IssueID, c.Kind, err)
+	}
+	if n, _ := res.RowsAffected(); n > 0 {
+		return nil
+	}
+	// #nosec G201 - column names come from the schema, values are bound
+	_, err = tx.ExecContext(ctx, fmt.Sprintf(`INSERT INTO %s (%s) VALUES (%s)`,
+		table, strings.Join(names, ", "), strings.Join(values, ", ")), args...)
+	if err != nil {
+		return fmt.Errorf("failed to restore %s %s: %w", c.IssueID, c.Kind, err)
+	}
+	return nil
+}
+
+// keyClause matches the row a change image identifies
+func keyClause(keys []string, image map[string]interface{}) (string, []interface{}) {
+	parts := make([]string, len(keys))
+	args := make([]interface{}, len(keys))
+	for i, k := range keys {
+		parts[i] = k + " = ?"
+		args[i] = image[k]
+	}
+	return strings.Join(parts, " AND "), args
+}
+
+// pruneOperations deletes the operations logged before before inside tx,
+// with their changes and any unattributed changes as old, and returns how
+// many operations there were
+func (s *SQLiteStorage) pruneOperations(ctx context.Context, tx *sql.Tx, before time.Time) (int, error) {
+	cutoff := before.UTC().Format("2006-01-02 15:04:05")
+	var n int
+	if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM operations WHERE created_at < ?`, cutoff).Scan(&n); err != nil {
+		return 0, fmt.Errorf("failed to count operations: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `
+		DELETE FROM operation_changes
+		WHERE op_id IN (SELECT id FROM operations WHERE created_at < ?) OR (op_id IS NULL AND at < ?)
+	`, cutoff, cutoff); err != nil {
+		return 0, fmt.Errorf("failed to prune operation changes: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM operations WHERE created_at < ?`, cutoff); err != nil {
+		return 0, fmt.Errorf("failed to prune operations: %w", err)
+	}
+	return n, nil
+}
+
+// splitList splits a group_concat result
+func splitList(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
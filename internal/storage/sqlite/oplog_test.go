@@ -0,0 +1,194 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestOperationLogUndo(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	base := context.Background()
+
+	// One command: create an issue and label it
+	create := WithOperation(base, NewOperationKey(), "alice", "bd create")
+	issue := &types.Issue{Title: "Fix login", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeBug}
+	if err := store.CreateIssue(create, issue, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.AddLabel(create, issue.ID, "auth", "alice"); err != nil {
+		t.Fatal(err)
+	}
+
+	// A comment only touches updated_at, which isn't logged
+	if err := store.AddComment(WithOperation(base, NewOperationKey(), "bob", "bd comment"), issue.ID, "bob", "on it"); err != nil {
+		t.Fatal(err)
+	}
+
+	closeCtx := WithOperation(base, NewOperationKey(), "bob", "bd close")
+	if err := store.CloseIssue(closeCtx, issue.ID, "done", "bob"); err != nil {
+		t.Fatal(err)
+	}
+
+	ops, err := store.ListOperations(base, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ops) != 2 {
+		t.Fatalf("ListOperations = %d operations, want 2 (create, close)", len(ops))
+	}
+	closeOp, createOp := ops[0], ops[1]
+	if closeOp.Command != "bd close" || closeOp.Actor != "bob" || closeOp.Changes != 1 {
+		t.Errorf("close operation = %+v", closeOp)
+	}
+	if createOp.Command != "bd create" || createOp.Changes != 2 || len(createOp.Issues) != 1 || createOp.Issues[0] != issue.ID {
+		t.Errorf("create operation = %+v", createOp)
+	}
+
+	history, err := store.GetIssueHistory(base, issue.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 3 || history[0].Action != "insert" || history[1].Kind != "label" || history[2].Action != "update" {
+		t.Fatalf("GetIssueHistory = %+v", history)
+	}
+	if history[2].Before["status"] != "open" || history[2].After["status"] != "closed" {
+		t.Errorf("close change = %v -> %v", history[2].Before["status"], history[2].After["status"])
+	}
+
+	undoCtx := WithOperation(base, NewOperationKey(), "bob", "bd undo")
+
+	// A dry run changes nothing
+	if _, err := store.UndoOperations(undoCtx, []int64{closeOp.ID}, "bob", false, true); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := store.GetIssue(base, issue.ID); got.Status != types.StatusClosed {
+		t.Fatalf("status after dry run = %s, want closed", got.Status)
+	}
+
+	// Undoing the close reopens the issue
+	results, err := store.UndoOperations(undoCtx, []int64{closeOp.ID}, "bob", false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].Reverted != 1 {
+		t.Errorf("UndoOperations = %+v, want 1 change reverted", results)
+	}
+	got, err := store.GetIssue(base, issue.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != types.StatusOpen || got.ClosedAt != nil || got.CloseReason != "" {
+		t.Errorf("after undo: status %s, closed_at %v, reason %q; want open", got.Status, got.ClosedAt, got.CloseReason)
+	}
+	if _, err := store.UndoOperations(undoCtx, []int64{closeOp.ID}, "bob", false, false); err == nil {
+		t.Error("undoing an operation twice should fail")
+	}
+
+	// The undo itself is not offered to bd undo; the create is next
+	undoable, err := store.GetUndoableOperations(base, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(undoable) != 1 || undoable[0].ID != createOp.ID {
+		t.Fatalf("GetUndoableOperations = %+v, want the create", undoable)
+	}
+
+	// Undoing the undo closes the issue again
+	op, err := store.GetOperation(base, closeOp.ID)
+	if err != nil || op.UndoneBy == nil {
+		t.Fatalf("close operation after undo = %+v, %v", op, err)
+	}
+	if _, err := store.UndoOperations(WithOperation(base, NewOperationKey(), "bob", "bd undo"), []int64{*op.UndoneBy}, "bob", false, false); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := store.GetIssue(base, issue.ID); got.Status != types.StatusClosed {
+		t.Fatalf("status after redo = %s, want closed", got.Status)
+	}
+
+	// The close is in effect again, so undoing the create conflicts with it
+	_, err = store.UndoOperations(WithOperation(base, NewOperationKey(), "bob", "bd undo"), []int64{createOp.ID}, "bob", false, false)
+	if !errors.Is(err, ErrConflict) {
+		t.Fatalf("undoing the create under a later close: err = %v, want ErrConflict", err)
+	}
+	if _, err := store.UndoOperations(WithOperation(base, NewOperationKey(), "bob", "bd undo"), []int64{createOp.ID}, "bob", true, false); err != nil {
+		t.Fatal(err)
+	}
+	got, err = store.GetIssue(base, issue.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != types.StatusTombstone {
+		t.Errorf("status after undoing the create = %s, want tombstone", got.Status)
+	}
+	if labels, _ := store.GetLabels(base, issue.ID); len(labels) != 0 {
+		t.Errorf("labels after undoing the create = %v, want none", labels)
+	}
+}
+
+func TestOperationLogUndoDelete(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	parent := &types.Issue{Title: "Parent", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	child := &types.Issue{Title: "Child", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{parent, child} {
+		if err := store.CreateIssue(ctx, issue, "alice"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.AddLabel(ctx, parent.ID, "backend", "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: child.ID, DependsOnID: parent.ID, Type: types.DepBlocks}, "alice"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.DeleteIssue(WithOperation(ctx, NewOperationKey(), "alice", "bd delete --hard"), parent.ID); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := store.GetIssue(ctx, parent.ID); got != nil {
+		t.Fatal("issue still there after DeleteIssue")
+	}
+
+	undoable, err := store.GetUndoableOperations(ctx, 1)
+	if err != nil || len(undoable) != 1 {
+		t.Fatalf("GetUndoableOperations = %v, %v", undoable, err)
+	}
+	if _, err := store.UndoOperations(ctx, []int64{undoable[0].ID}, "alice", false, false); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := store.GetIssue(ctx, parent.ID)
+	if err != nil || got == nil {
+		t.Fatalf("GetIssue after undo = %v, %v", got, err)
+	}
+	if got.Title != "Parent" || got.Priority != 1 {
+		t.Errorf("restored issue = %+v", got)
+	}
+	if labels, _ := store.GetLabels(ctx, parent.ID); len(labels) != 1 || labels[0] != "backend" {
+		t.Errorf("restored labels = %v, want [backend]", labels)
+	}
+	deps, err := store.GetDependencyRecords(ctx, child.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deps) != 1 || deps[0].DependsOnID != parent.ID {
+		t.Errorf("restored dependencies = %+v", deps)
+	}
+	dirty, err := store.GetDirtyIssues(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, id := range dirty {
+		found = found || id == parent.ID
+	}
+	if !found {
+		t.Errorf("restored issue not marked dirty: %v", dirty)
+	}
+}
@@ -0,0 +1,178 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSearchIssuesPage(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	// Several issues share a timestamp so the ID tiebreaker matters
+	base := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	for i := 0; i < 23; i++ {
+		issue := &types.Issue{
+			Title:     fmt.Sprintf("Issue %d", i),
+			Status:    types.StatusOpen,
+			Priority:  i % 3,
+			IssueType: types.TypeTask,
+			CreatedAt: base.Add(time.Duration(i/4) * time.Minute),
+		}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue: %v", err)
+		}
+	}
+
+	all, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var paged []*types.Issue
+	cursor := ""
+	for pages := 0; ; pages++ {
+		if pages > 10 {
+			t.Fatal("pagination did not terminate")
+		}
+		page, next, err := storage.SearchIssuesPage(ctx, store, "", types.IssueFilter{}, cursor, 5)
+		if err != nil {
+			t.Fatalf("SearchIssuesPage: %v", err)
+		}
+		if len(page) > 5 {
+			t.Fatalf("page has %d issues, want at most 5", len(page))
+		}
+		paged = append(paged, page...)
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	if len(paged) != len(all) {
+		t.Fatalf("paged %d issues, want %d", len(paged), len(all))
+	}
+	for i := range all {
+		if paged[i].ID != all[i].ID {
+			t.Errorf("position %d: paged %s, want %s", i, paged[i].ID, all[i].ID)
+		}
+	}
+}
+
+func TestSearchIssuesPageStableAcrossChanges(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	for i := 0; i < 6; i++ {
+		issue := &types.Issue{Title: fmt.Sprintf("Issue %d", i), Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue: %v", err)
+		}
+	}
+
+	first, next, err := storage.SearchIssuesPage(ctx, store, "", types.IssueFilter{}, "", 3)
+	if err != nil || next == "" {
+		t.Fatalf("first page: %v (next %q)", err, next)
+	}
+	seen := map[string]bool{}
+	for _, issue := range first {
+		seen[issue.ID] = true
+	}
+
+	// Issues created or deleted after the first page must not shift the second
+	newer := &types.Issue{Title: "Newer", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, newer, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.DeleteIssue(ctx, first[len(first)-1].ID); err != nil {
+		t.Fatal(err)
+	}
+
+	second, next, err := storage.SearchIssuesPage(ctx, store, "", types.IssueFilter{}, next, 3)
+	if err != nil {
+		t.Fatalf("second page: %v", err)
+	}
+	if len(second) != 3 || next != "" {
+		t.Fatalf("second page has %d issues (next %q), want the remaining 3", len(second), next)
+	}
+	for _, issue := range second {
+		if seen[issue.ID] || issue.ID == newer.ID {
+			t.Errorf("second page repeated or included new issue %s", issue.ID)
+		}
+	}
+
+	if _, _, err := storage.SearchIssuesPage(ctx, store, "", types.IssueFilter{}, "not-a-cursor", 3); err == nil {
+		t.Error("expected error for invalid cursor")
+	}
+}
+
+func TestForEachIssuePage(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	for i := 0; i < 12; i++ {
+		issue := &types.Issue{Title: fmt.Sprintf("Issue %d", i), Status: types.StatusOpen, Priority: i % 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue: %v", err)
+		}
+	}
+	all, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	collect := func(filter types.IssueFilter, pageSize int) ([]string, []int) {
+		t.Helper()
+		var ids []string
+		var sizes []int
+		err := storage.ForEachIssuePage(ctx, store, "", filter, pageSize, func(page []*types.Issue) error {
+			sizes = append(sizes, len(page))
+			for _, issue := range page {
+				ids = append(ids, issue.ID)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("ForEachIssuePage: %v", err)
+		}
+		return ids, sizes
+	}
+
+	ids, sizes := collect(types.IssueFilter{}, 5)
+	if fmt.Sprint(sizes) != "[5 5 2]" {
+		t.Errorf("page sizes = %v, want [5 5 2]", sizes)
+	}
+	for i := range all {
+		if ids[i] != all[i].ID {
+			t.Fatalf("position %d: streamed %s, want %s", i, ids[i], all[i].ID)
+		}
+	}
+
+	// The limit caps the total, shrinking the last page
+	if ids, sizes := collect(types.IssueFilter{Limit: 7}, 5); len(ids) != 7 || fmt.Sprint(sizes) != "[5 2]" {
+		t.Errorf("limit 7 streamed %d issues in pages %v", len(ids), sizes)
+	}
+
+	stop := fmt.Errorf("stop")
+	calls := 0
+	err = storage.ForEachIssuePage(ctx, store, "", types.IssueFilter{}, 5, func([]*types.Issue) error {
+		calls++
+		return stop
+	})
+	if err != stop || calls != 1 {
+		t.Errorf("callback error = %v after %d calls, want stop after 1", err, calls)
+	}
+
+	sorted := types.IssueFilter{Sort: types.SortKeys{{Field: "title"}}}
+	if err := storage.ForEachIssuePage(ctx, store, "", sorted, 5, func([]*types.Issue) error { return nil }); err == nil {
+		t.Error("expected error for a sorted stream")
+	}
+}
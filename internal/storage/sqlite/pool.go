@@ -0,0 +1,118 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync/atomic"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// Connection pools
+//
+// SQLite allows one writer at a time even in WAL mode. When every pooled
+// connection could start a write, concurrent agents filled the pool with
+// writers blocked on the database lock (each for up to the busy timeout)
+// while reads queued behind them with no connection to run on. File
+// databases therefore get two pools on the same file: readers share db, and
+// write transactions take the single connection in writeDB, so writers queue
+// in Go and reads keep flowing. In-memory databases have one connection, and
+// writeDB is the same pool.
+
+// readPoolSize is how many connections readers share
+func readPoolSize() int {
+	return runtime.NumCPU() + 1
+}
+
+// openWriteDB opens the single-connection pool write transactions use
+func openWriteDB(connStr string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite3", connStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open write connection: %w", err)
+	}
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+	db.SetConnMaxLifetime(0)
+	return db, nil
+}
+
+// writeWaitStats tracks waits for the write connection, which sql.DBStats
+// only reports as totals
+type writeWaitStats struct {
+	maxWait  atomic.Int64 // nanoseconds
+	timeouts atomic.Int64
+}
+
+func (w *writeWaitStats) record(wait time.Duration, timedOut bool) {
+	for {
+		cur := w.maxWait.Load()
+		if int64(wait) <= cur || w.maxWait.CompareAndSwap(cur, int64(wait)) {
+			break
+		}
+	}
+	if timedOut {
+		w.timeouts.Add(1)
+	}
+}
+
+// writeConn takes the write connection for a transaction. The caller must
+// Close it. If ctx has no deadline, the wait is bounded by the busy timeout,
+// so a stuck writer produces an error naming the cause rather than a
+// request that hangs until the client gives up.
+func (s *SQLiteStorage) writeConn(ctx context.Context) (*sql.Conn, error) {
+	if s.readOnly {
+		return nil, ErrReadOnly
+	}
+	s.reconnectMu.RLock()
+	writeDB := s.writeDB
+	s.reconnectMu.RUnlock()
+
+	waitCtx := ctx
+	if _, ok := ctx.Deadline(); !ok && s.busyTimeout > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, s.busyTimeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	conn, err := writeDB.Conn(waitCtx)
+	timedOut := errors.Is(err, context.DeadlineExceeded)
+	s.writeWaits.record(time.Since(start), timedOut)
+	if err != nil {
+		if timedOut && ctx.Err() == nil {
+			return nil, fmt.Errorf("timed out after %s waiting for the database write connection (another write is still running)", s.busyTimeout)
+		}
+		return nil, err
+	}
+	return conn, nil
+}
+
+// PoolStats reports connection pool usage and wait times
+func (s *SQLiteStorage) PoolStats() storage.PoolStats {
+	s.reconnectMu.RLock()
+	read, write := s.db.Stats(), s.writeDB.Stats()
+	s.reconnectMu.RUnlock()
+
+	stats := storage.PoolStats{
+		Read:  connPoolStats(read),
+		Write: connPoolStats(write),
+	}
+	stats.Write.MaxWaitMS = float64(s.writeWaits.maxWait.Load()) / float64(time.Millisecond)
+	stats.Write.Timeouts = s.writeWaits.timeouts.Load()
+	return stats
+}
+
+func connPoolStats(st sql.DBStats) storage.ConnPoolStats {
+	return storage.ConnPoolStats{
+		MaxOpen:   st.MaxOpenConnections,
+		Open:      st.OpenConnections,
+		InUse:     st.InUse,
+		Idle:      st.Idle,
+		WaitCount: st.WaitCount,
+		WaitMS:    float64(st.WaitDuration) / float64(time.Millisecond),
+	}
+}
@@ -0,0 +1,73 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestWritePoolDoesNotBlockReads(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewWithTimeout(ctx, t.TempDir()+"/test.db", 200*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = store.Close() }()
+	if err := store.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+		t.Fatal(err)
+	}
+	issue := &types.Issue{Title: "Held", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	held := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- store.RunInTransaction(ctx, func(tx storage.Transaction) error {
+			close(held)
+			<-release
+			return nil
+		})
+	}()
+	<-held
+
+	// Reads don't need the write connection
+	if got, err := store.GetIssue(ctx, issue.ID); err != nil || got == nil {
+		t.Fatalf("GetIssue while a write is open: %v, %v", got, err)
+	}
+
+	// A second writer gives up after the busy timeout with a clear error
+	err = store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"title": "Blocked"}, "test")
+	if err == nil || !strings.Contains(err.Error(), "waiting for the database write connection") {
+		t.Fatalf("UpdateIssue while the writer is held: err = %v", err)
+	}
+
+	// A caller's own deadline takes precedence
+	shortCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if err := store.UpdateIssue(shortCtx, issue.ID, map[string]interface{}{"title": "Blocked"}, "test"); err == nil {
+		t.Fatal("UpdateIssue with an expired context should fail")
+	}
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"title": "Free"}, "test"); err != nil {
+		t.Fatalf("UpdateIssue after release: %v", err)
+	}
+
+	stats := store.PoolStats()
+	if stats.Write.MaxOpen != 1 || stats.Read.MaxOpen != readPoolSize() {
+		t.Errorf("pool sizes = read %d, write %d", stats.Read.MaxOpen, stats.Write.MaxOpen)
+	}
+	if stats.Write.Timeouts != 2 || stats.Write.WaitCount < 2 || stats.Write.MaxWaitMS < 100 {
+		t.Errorf("write waits not recorded: %+v", stats.Write)
+	}
+}
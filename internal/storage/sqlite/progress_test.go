@@ -0,0 +1,76 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestChildProgress(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	newIssue := func(title string, issueType types.IssueType, status types.Status, estimate int) *types.Issue {
+		issue := &types.Issue{Title: title, Status: status, Priority: 2, IssueType: issueType}
+		if estimate > 0 {
+			issue.EstimatedMinutes = &estimate
+		}
+		if status == types.StatusClosed {
+			now := time.Now()
+			issue.ClosedAt = &now
+		}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue(%s): %v", title, err)
+		}
+		return issue
+	}
+	addChild := func(child, parent *types.Issue) {
+		dep := &types.Dependency{IssueID: child.ID, DependsOnID: parent.ID, Type: types.DepParentChild}
+		if err := store.AddDependency(ctx, dep, "test"); err != nil {
+			t.Fatalf("AddDependency: %v", err)
+		}
+	}
+
+	// epic -> {done (closed, 60), task (open, 30), sub (epic) -> leaf (open, 90)}
+	epic := newIssue("Epic", types.TypeEpic, types.StatusOpen, 0)
+	done := newIssue("Done", types.TypeTask, types.StatusClosed, 60)
+	task := newIssue("Task", types.TypeTask, types.StatusOpen, 30)
+	sub := newIssue("Sub", types.TypeEpic, types.StatusOpen, 0)
+	leaf := newIssue("Leaf", types.TypeTask, types.StatusOpen, 90)
+	empty := newIssue("Empty", types.TypeEpic, types.StatusOpen, 0)
+	addChild(done, epic)
+	addChild(task, epic)
+	addChild(sub, epic)
+	addChild(leaf, sub)
+
+	ids := []string{epic.ID, sub.ID, empty.ID}
+	progress, err := storage.ChildProgress(ctx, store, ids, false)
+	if err != nil {
+		t.Fatalf("ChildProgress: %v", err)
+	}
+	if p := progress[epic.ID]; p.Total != 3 || p.Closed != 1 || p.EstimatedMinutes != nil {
+		t.Errorf("epic progress = %+v, want 1/3 without estimates", p)
+	}
+	if p := progress[sub.ID]; p.Total != 1 || p.Closed != 0 {
+		t.Errorf("sub progress = %+v, want 0/1", p)
+	}
+	if p := progress[empty.ID]; p.Total != 0 {
+		t.Errorf("empty progress = %+v, want 0/0", p)
+	}
+
+	progress, err = storage.ChildProgress(ctx, store, ids, true)
+	if err != nil {
+		t.Fatalf("ChildProgress(rollUp): %v", err)
+	}
+	p := progress[epic.ID]
+	if p.EstimatedMinutes == nil || *p.EstimatedMinutes != 180 || *p.RemainingMinutes != 120 {
+		t.Errorf("epic roll-up = %+v, want 180 estimated, 120 remaining", p)
+	}
+	if p := progress[empty.ID]; *p.EstimatedMinutes != 0 {
+		t.Errorf("empty roll-up = %d, want 0", *p.EstimatedMinutes)
+	}
+}
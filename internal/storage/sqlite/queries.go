@@ -82,7 +82,7 @@ func (s *SQLiteStorage) CreateIssue(ctx context.Context, issue *types.Issue, act
 	}
 
 	// Set timestamps first so defensive fixes can use them
-	now := time.Now()
+	now := time.Now().UTC()
 	if issue.CreatedAt.IsZero() {
 		issue.CreatedAt = now
 	}
@@ -116,6 +116,24 @@ func (s *SQLiteStorage) CreateIssue(ctx context.Context, issue *types.Issue, act
 		return fmt.Errorf("validation failed: %w", err)
 	}
 
+	// Enforce assignee roster in strict integrity mode
+	roster, err := assigneeRoster(ctx, s.GetConfig)
+	if err != nil {
+		return fmt.Errorf("failed to get assignee roster: %w", err)
+	}
+	if err := validateAssigneeInRoster(issue.Assignee, roster); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	// Keep log dumps and binary pastes out of the text fields
+	limits, err := LoadContentLimits(ctx, s.GetConfig)
+	if err != nil {
+		return fmt.Errorf("failed to get content limits: %w", err)
+	}
+	if err := limits.checkIssue(issue); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
 	// Compute content hash (bd-95)
 	if issue.ContentHash == "" {
 		issue.ContentHash = issue.ComputeContentHash()
@@ -125,7 +143,7 @@ func (s *SQLiteStorage) CreateIssue(ctx context.Context, issue *types.Issue, act
 	// This is necessary because we need to execute raw SQL ("BEGIN IMMEDIATE", "COMMIT")
 	// on the same connection, and database/sql's connection pool would otherwise
 	// use different connections for different queries.
-	conn, err := s.db.Conn(ctx)
+	conn, err := s.writeConn(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to acquire connection: %w", err)
 	}
@@ -152,6 +170,10 @@ func (s *SQLiteStorage) CreateIssue(ctx context.Context, issue *types.Issue, act
 		}
 	}()
 
+	if err := tagOperation(ctx, conn); err != nil {
+		return err
+	}
+
 	// Get prefix from config (needed for both ID generation and validation)
 	var prefix string
 	err = conn.QueryRowContext(ctx, `SELECT value FROM config WHERE key = ?`, "issue_prefix").Scan(&prefix)
@@ -248,6 +270,9 @@ func (s *SQLiteStorage) GetIssue(ctx context.Context, id string) (*types.Issue,
 	// Messaging fields (bd-kwro)
 	var sender sql.NullString
 	var ephemeral sql.NullInt64
+	var complexity sql.NullString
+	var source sql.NullString
+	var timeboxMinutes sql.NullInt64
 
 	var contentHash sql.NullString
 	var compactedAtCommit sql.NullString
@@ -257,7 +282,7 @@ func (s *SQLiteStorage) GetIssue(ctx context.Context, id string) (*types.Issue,
 		       created_at, updated_at, closed_at, external_ref,
 		       compaction_level, compacted_at, compacted_at_commit, original_size, source_repo, close_reason,
 		       deleted_at, deleted_by, delete_reason, original_type,
-		       sender, ephemeral
+		       sender, ephemeral, complexity, source, timebox_minutes
 		FROM issues
 		WHERE id = ?
 	`, id).Scan(
@@ -267,7 +292,7 @@ func (s *SQLiteStorage) GetIssue(ctx context.Context, id string) (*types.Issue,
 		&issue.CreatedAt, &issue.UpdatedAt, &closedAt, &externalRef,
 		&issue.CompactionLevel, &compactedAt, &compactedAtCommit, &originalSize, &sourceRepo, &closeReason,
 		&deletedAt, &deletedBy, &deleteReason, &originalType,
-		&sender, &ephemeral,
+		&sender, &ephemeral, &complexity, &source, &timeboxMinutes,
 	)
 
 	if err == sql.ErrNoRows {
@@ -325,6 +350,16 @@ func (s *SQLiteStorage) GetIssue(ctx context.Context, id string) (*types.Issue,
 	if ephemeral.Valid && ephemeral.Int64 != 0 {
 		issue.Ephemeral = true
 	}
+	if complexity.Valid {
+		issue.Complexity = types.Complexity(complexity.String)
+	}
+	if source.Valid {
+		issue.Source = source.String
+	}
+	if timeboxMinutes.Valid {
+		mins := int(timeboxMinutes.Int64)
+		issue.TimeboxMinutes = &mins
+	}
 
 	// Fetch labels for this issue
 	labels, err := s.GetLabels(ctx, issue.ID)
@@ -333,6 +368,24 @@ func (s *SQLiteStorage) GetIssue(ctx context.Context, id string) (*types.Issue,
 	}
 	issue.Labels = labels
 
+	aliases, err := s.getIssueAliases(ctx, issue.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get aliases: %w", err)
+	}
+	issue.Aliases = aliases
+
+	translations, err := s.getIssueTranslations(ctx, issue.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get translations: %w", err)
+	}
+	issue.Translations = translations
+
+	fields, err := s.getIssueFields(ctx, issue.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fields: %w", err)
+	}
+	issue.Fields = fields
+
 	return &issue, nil
 }
 
@@ -347,7 +400,13 @@ func (s *SQLiteStorage) GetCloseReason(ctx context.Context, issueID string) (str
 	`, issueID, types.EventClosed).Scan(&comment)
 
 	if err == sql.ErrNoRows {
-		return "", nil
+		// The closing event may have been pruned into the issue's summary
+		err = s.db.QueryRowContext(ctx, `
+			SELECT close_reason FROM event_summaries WHERE issue_id = ?
+		`, issueID).Scan(&comment)
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
 	}
 	if err != nil {
 		return "", fmt.Errorf("failed to get close reason: %w", err)
@@ -407,8 +466,31 @@ func (s *SQLiteStorage) GetCloseReasonsForIssues(ctx context.Context, issueIDs [
 			result[issueID] = comment.String
 		}
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	_ = rows.Close()
 
-	return result, nil
+	// Fall back to the summaries of pruned closing events
+	// #nosec G201 - safe SQL with controlled formatting
+	summaryRows, err := s.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT issue_id, close_reason FROM event_summaries
+		WHERE close_reason != '' AND issue_id IN (%s)
+	`, strings.Join(placeholders, ", ")), args[1:len(issueIDs)+1]...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pruned close reasons: %w", err)
+	}
+	defer func() { _ = summaryRows.Close() }()
+	for summaryRows.Next() {
+		var issueID, reason string
+		if err := summaryRows.Scan(&issueID, &reason); err != nil {
+			return nil, fmt.Errorf("failed to scan close reason: %w", err)
+		}
+		if _, ok := result[issueID]; !ok {
+			result[issueID] = reason
+		}
+	}
+	return result, summaryRows.Err()
 }
 
 // GetIssueByExternalRef retrieves an issue by external reference
@@ -431,6 +513,9 @@ func (s *SQLiteStorage) GetIssueByExternalRef(ctx context.Context, externalRef s
 	// Messaging fields (bd-kwro)
 	var sender sql.NullString
 	var ephemeral sql.NullInt64
+	var complexity sql.NullString
+	var source sql.NullString
+	var timeboxMinutes sql.NullInt64
 
 	err := s.db.QueryRowContext(ctx, `
 		SELECT id, content_hash, title, description, design, acceptance_criteria, notes,
@@ -438,7 +523,7 @@ func (s *SQLiteStorage) GetIssueByExternalRef(ctx context.Context, externalRef s
 		       created_at, updated_at, closed_at, external_ref,
 		       compaction_level, compacted_at, compacted_at_commit, original_size, source_repo, close_reason,
 		       deleted_at, deleted_by, delete_reason, original_type,
-		       sender, ephemeral
+		       sender, ephemeral, complexity, source, timebox_minutes
 		FROM issues
 		WHERE external_ref = ?
 	`, externalRef).Scan(
@@ -448,7 +533,7 @@ func (s *SQLiteStorage) GetIssueByExternalRef(ctx context.Context, externalRef s
 		&issue.CreatedAt, &issue.UpdatedAt, &closedAt, &externalRefCol,
 		&issue.CompactionLevel, &compactedAt, &compactedAtCommit, &originalSize, &sourceRepo, &closeReason,
 		&deletedAt, &deletedBy, &deleteReason, &originalType,
-		&sender, &ephemeral,
+		&sender, &ephemeral, &complexity, &source, &timeboxMinutes,
 	)
 
 	if err == sql.ErrNoRows {
@@ -506,6 +591,16 @@ func (s *SQLiteStorage) GetIssueByExternalRef(ctx context.Context, externalRef s
 	if ephemeral.Valid && ephemeral.Int64 != 0 {
 		issue.Ephemeral = true
 	}
+	if complexity.Valid {
+		issue.Complexity = types.Complexity(complexity.String)
+	}
+	if source.Valid {
+		issue.Source = source.String
+	}
+	if timeboxMinutes.Valid {
+		mins := int(timeboxMinutes.Int64)
+		issue.TimeboxMinutes = &mins
+	}
 
 	// Fetch labels for this issue
 	labels, err := s.GetLabels(ctx, issue.ID)
@@ -514,6 +609,24 @@ func (s *SQLiteStorage) GetIssueByExternalRef(ctx context.Context, externalRef s
 	}
 	issue.Labels = labels
 
+	aliases, err := s.getIssueAliases(ctx, issue.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get aliases: %w", err)
+	}
+	issue.Aliases = aliases
+
+	translations, err := s.getIssueTranslations(ctx, issue.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get translations: %w", err)
+	}
+	issue.Translations = translations
+
+	fields, err := s.getIssueFields(ctx, issue.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fields: %w", err)
+	}
+	issue.Fields = fields
+
 	return &issue, nil
 }
 
@@ -534,6 +647,11 @@ var allowedUpdateFields = map[string]bool{
 	// Messaging fields (bd-kwro)
 	"sender":    true,
 	"ephemeral": true,
+	// Routing hint (trivial, standard, complex, research; empty clears)
+	"complexity": true,
+	"source":     true,
+	// Spike timebox in minutes; nil clears
+	"timebox_minutes": true,
 	// NOTE: replies_to, relates_to, duplicate_of, superseded_by removed per Decision 004
 	// Use AddDependency() to create graph edges instead
 }
@@ -590,7 +708,7 @@ func manageClosedAt(oldIssue *types.Issue, updates map[string]interface{}, setCl
 
 	if newStatus == string(types.StatusClosed) {
 		// Changing to closed: ensure closed_at is set
-		now := time.Now()
+		now := time.Now().UTC()
 		updates["closed_at"] = now
 		setClauses = append(setClauses, "closed_at = ?")
 		args = append(args, now)
@@ -607,6 +725,83 @@ func manageClosedAt(oldIssue *types.Issue, updates map[string]interface{}, setCl
 	return setClauses, args
 }
 
+// applyContentUpdates applies the content fields of an UpdateIssue call to
+// issue, so its new content hash can be computed
+func applyContentUpdates(updatedIssue *types.Issue, updates map[string]interface{}) error {
+	for key, value := range updates {
+		switch key {
+		case "title":
+			updatedIssue.Title = value.(string)
+		case "description":
+			updatedIssue.Description = value.(string)
+		case "design":
+			updatedIssue.Design = value.(string)
+		case "acceptance_criteria":
+			updatedIssue.AcceptanceCriteria = value.(string)
+		case "notes":
+			updatedIssue.Notes = value.(string)
+		case "status":
+			// Handle both string and types.Status
+			if s, ok := value.(types.Status); ok {
+				updatedIssue.Status = s
+			} else {
+				updatedIssue.Status = types.Status(value.(string))
+			}
+		case "priority":
+			updatedIssue.Priority = value.(int)
+		case "issue_type":
+			// Handle both string and types.IssueType
+			if t, ok := value.(types.IssueType); ok {
+				updatedIssue.IssueType = t
+			} else {
+				updatedIssue.IssueType = types.IssueType(value.(string))
+			}
+		case "assignee":
+			if value == nil {
+				updatedIssue.Assignee = ""
+			} else {
+				updatedIssue.Assignee = value.(string)
+			}
+		case "complexity":
+			if value == nil {
+				updatedIssue.Complexity = ""
+			} else {
+				updatedIssue.Complexity = types.Complexity(value.(string))
+			}
+		case "source":
+			if value == nil {
+				updatedIssue.Source = ""
+			} else {
+				updatedIssue.Source = value.(string)
+			}
+		case "timebox_minutes":
+			switch v := value.(type) {
+			case int:
+				updatedIssue.TimeboxMinutes = &v
+			case *int:
+				updatedIssue.TimeboxMinutes = v
+			default:
+				updatedIssue.TimeboxMinutes = nil
+			}
+		case "external_ref":
+			if value == nil {
+				updatedIssue.ExternalRef = nil
+			} else {
+				// Handle both string and *string
+				switch v := value.(type) {
+				case string:
+					updatedIssue.ExternalRef = &v
+				case *string:
+					updatedIssue.ExternalRef = v
+				default:
+					return fmt.Errorf("external_ref must be string or *string, got %T", value)
+				}
+			}
+		}
+	}
+	return nil
+}
+
 // UpdateIssue updates fields on an issue
 func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[string]interface{}, actor string) error {
 	// Get old issue for event
@@ -624,9 +819,22 @@ func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[
 		return wrapDBError("get custom statuses", err)
 	}
 
+	roster, err := assigneeRoster(ctx, s.GetConfig)
+	if err != nil {
+		return wrapDBError("get assignee roster", err)
+	}
+	limits, err := LoadContentLimits(ctx, s.GetConfig)
+	if err != nil {
+		return wrapDBError("get content limits", err)
+	}
+
+	if err := validateSpikeTimebox(oldIssue, updates); err != nil {
+		return err
+	}
+
 	// Build update query with validated field names
 	setClauses := []string{"updated_at = ?"}
-	args := []interface{}{time.Now()}
+	args := []interface{}{time.Now().UTC()}
 
 	for key, value := range updates {
 		// Prevent SQL injection by validating field names
@@ -638,6 +846,14 @@ func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[
 		if err := validateFieldUpdateWithCustomStatuses(key, value, customStatuses); err != nil {
 			return wrapDBError("validate field update", err)
 		}
+		if assignee, ok := value.(string); ok && key == "assignee" {
+			if err := validateAssigneeInRoster(assignee, roster); err != nil {
+				return wrapDBError("validate assignee", err)
+			}
+		}
+		if err := limits.checkUpdate(key, value); err != nil {
+			return err
+		}
 
 		setClauses = append(setClauses, fmt.Sprintf("%s = ?", key))
 		args = append(args, value)
@@ -648,7 +864,7 @@ func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[
 
 	// Recompute content_hash if any content fields changed (bd-95)
 	contentChanged := false
-	contentFields := []string{"title", "description", "design", "acceptance_criteria", "notes", "status", "priority", "issue_type", "assignee", "external_ref"}
+	contentFields := []string{"title", "description", "design", "acceptance_criteria", "notes", "status", "priority", "issue_type", "assignee", "external_ref", "complexity", "source", "timebox_minutes"}
 	for _, field := range contentFields {
 		if _, exists := updates[field]; exists {
 			contentChanged = true
@@ -658,55 +874,8 @@ func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[
 	if contentChanged {
 		// Get updated issue to compute hash
 		updatedIssue := *oldIssue
-		for key, value := range updates {
-			switch key {
-			case "title":
-				updatedIssue.Title = value.(string)
-			case "description":
-				updatedIssue.Description = value.(string)
-			case "design":
-				updatedIssue.Design = value.(string)
-			case "acceptance_criteria":
-				updatedIssue.AcceptanceCriteria = value.(string)
-			case "notes":
-				updatedIssue.Notes = value.(string)
-			case "status":
-				// Handle both string and types.Status
-				if s, ok := value.(types.Status); ok {
-					updatedIssue.Status = s
-				} else {
-					updatedIssue.Status = types.Status(value.(string))
-				}
-			case "priority":
-				updatedIssue.Priority = value.(int)
-			case "issue_type":
-				// Handle both string and types.IssueType
-				if t, ok := value.(types.IssueType); ok {
-					updatedIssue.IssueType = t
-				} else {
-					updatedIssue.IssueType = types.IssueType(value.(string))
-				}
-			case "assignee":
-				if value == nil {
-					updatedIssue.Assignee = ""
-				} else {
-					updatedIssue.Assignee = value.(string)
-				}
-			case "external_ref":
-				if value == nil {
-					updatedIssue.ExternalRef = nil
-				} else {
-					// Handle both string and *string
-					switch v := value.(type) {
-					case string:
-						updatedIssue.ExternalRef = &v
-					case *string:
-						updatedIssue.ExternalRef = v
-					default:
-						return fmt.Errorf("external_ref must be string or *string, got %T", value)
-					}
-				}
-			}
+		if err := applyContentUpdates(&updatedIssue, updates); err != nil {
+			return err
 		}
 		newHash := updatedIssue.ComputeContentHash()
 		setClauses = append(setClauses, "content_hash = ?")
@@ -716,12 +885,21 @@ func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[
 	args = append(args, id)
 
 	// Start transaction
-	tx, err := s.db.BeginTx(ctx, nil)
+	conn, err := s.writeConn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+	tx, err := conn.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer func() { _ = tx.Rollback() }()
 
+	if err := tagOperation(ctx, tx); err != nil {
+		return err
+	}
+
 	// Update issue
 	query := fmt.Sprintf("UPDATE issues SET %s WHERE id = ?", strings.Join(setClauses, ", ")) // #nosec G201 - safe SQL with controlled column names
 	_, err = tx.ExecContext(ctx, query, args...)
@@ -760,7 +938,7 @@ func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[
 		INSERT INTO dirty_issues (issue_id, marked_at)
 		VALUES (?, ?)
 		ON CONFLICT (issue_id) DO UPDATE SET marked_at = excluded.marked_at
-	`, id, time.Now())
+	`, id, time.Now().UTC())
 	if err != nil {
 		return fmt.Errorf("failed to mark issue dirty: %w", err)
 	}
@@ -779,7 +957,7 @@ func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[
 // UpdateIssueID updates an issue ID and all its text fields in a single transaction
 func (s *SQLiteStorage) UpdateIssueID(ctx context.Context, oldID, newID string, issue *types.Issue, actor string) error {
 	// Get exclusive connection to ensure PRAGMA applies
-	conn, err := s.db.Conn(ctx)
+	conn, err := s.writeConn(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get connection: %w", err)
 	}
@@ -797,11 +975,15 @@ func (s *SQLiteStorage) UpdateIssueID(ctx context.Context, oldID, newID string,
 	}
 	defer func() { _ = tx.Rollback() }()
 
+	if err := tagOperation(ctx, tx); err != nil {
+		return err
+	}
+
 	result, err := tx.ExecContext(ctx, `
 		UPDATE issues
 		SET id = ?, title = ?, description = ?, design = ?, acceptance_criteria = ?, notes = ?, updated_at = ?
 		WHERE id = ?
-	`, newID, issue.Title, issue.Description, issue.Design, issue.AcceptanceCriteria, issue.Notes, time.Now(), oldID)
+	`, newID, issue.Title, issue.Description, issue.Design, issue.AcceptanceCriteria, issue.Notes, time.Now().UTC(), oldID)
 	if err != nil {
 		return fmt.Errorf("failed to update issue ID: %w", err)
 	}
@@ -813,6 +995,9 @@ func (s *SQLiteStorage) UpdateIssueID(ctx context.Context, oldID, newID string,
 	if rows == 0 {
 		return fmt.Errorf("issue not found: %s", oldID)
 	}
+	if err := refreshContentHash(ctx, tx, newID); err != nil {
+		return err
+	}
 
 	_, err = tx.ExecContext(ctx, `UPDATE dependencies SET issue_id = ? WHERE issue_id = ?`, newID, oldID)
 	if err != nil {
@@ -839,6 +1024,56 @@ func (s *SQLiteStorage) UpdateIssueID(ctx context.Context, oldID, newID string,
 		return fmt.Errorf("failed to update comments: %w", err)
 	}
 
+	_, err = tx.ExecContext(ctx, `UPDATE attachments SET issue_id = ? WHERE issue_id = ?`, newID, oldID)
+	if err != nil {
+		return fmt.Errorf("failed to update attachments: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE work_log SET issue_id = ? WHERE issue_id = ?`, newID, oldID)
+	if err != nil {
+		return fmt.Errorf("failed to update work_log: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE event_summaries SET issue_id = ? WHERE issue_id = ?`, newID, oldID)
+	if err != nil {
+		return fmt.Errorf("failed to update event_summaries: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE issue_leases SET issue_id = ? WHERE issue_id = ?`, newID, oldID)
+	if err != nil {
+		return fmt.Errorf("failed to update issue_leases: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE status_transitions SET issue_id = ? WHERE issue_id = ?`, newID, oldID)
+	if err != nil {
+		return fmt.Errorf("failed to update status_transitions: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE issue_aliases SET issue_id = ? WHERE issue_id = ?`, newID, oldID)
+	if err != nil {
+		return fmt.Errorf("failed to update issue_aliases: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE issue_translations SET issue_id = ? WHERE issue_id = ?`, newID, oldID)
+	if err != nil {
+		return fmt.Errorf("failed to update issue_translations: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE issue_fields SET issue_id = ? WHERE issue_id = ?`, newID, oldID)
+	if err != nil {
+		return fmt.Errorf("failed to update issue_fields: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE watches SET issue_id = ? WHERE issue_id = ?`, newID, oldID)
+	if err != nil {
+		return fmt.Errorf("failed to update watches: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE external_refs SET issue_id = ? WHERE issue_id = ?`, newID, oldID)
+	if err != nil {
+		return fmt.Errorf("failed to update external_refs: %w", err)
+	}
+
 	_, err = tx.ExecContext(ctx, `
 		UPDATE dirty_issues SET issue_id = ? WHERE issue_id = ?
 	`, newID, oldID)
@@ -860,7 +1095,7 @@ func (s *SQLiteStorage) UpdateIssueID(ctx context.Context, oldID, newID string,
 		INSERT INTO dirty_issues (issue_id, marked_at)
 		VALUES (?, ?)
 		ON CONFLICT (issue_id) DO UPDATE SET marked_at = excluded.marked_at
-	`, newID, time.Now())
+	`, newID, time.Now().UTC())
 	if err != nil {
 		return fmt.Errorf("failed to mark issue dirty: %w", err)
 	}
@@ -897,15 +1132,24 @@ func (s *SQLiteStorage) ResetCounter(ctx context.Context, prefix string) error {
 
 // CloseIssue closes an issue with a reason
 func (s *SQLiteStorage) CloseIssue(ctx context.Context, id string, reason string, actor string) error {
-	now := time.Now()
+	now := time.Now().UTC()
 
 	// Update with special event handling
-	tx, err := s.db.BeginTx(ctx, nil)
+	conn, err := s.writeConn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+	tx, err := conn.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer func() { _ = tx.Rollback() }()
 
+	if err := tagOperation(ctx, tx); err != nil {
+		return err
+	}
+
 	// NOTE: close_reason is stored in two places:
 	// 1. issues.close_reason - for direct queries (bd show --json, exports)
 	// 2. events.comment - for audit history (when was it closed, by whom)
@@ -925,6 +1169,9 @@ func (s *SQLiteStorage) CloseIssue(ctx context.Context, id string, reason string
 	if rows == 0 {
 		return fmt.Errorf("issue not found: %s", id)
 	}
+	if err := refreshContentHash(ctx, tx, id); err != nil {
+		return err
+	}
 
 	_, err = tx.ExecContext(ctx, `
 		INSERT INTO events (issue_id, event_type, actor, comment)
@@ -939,7 +1186,7 @@ func (s *SQLiteStorage) CloseIssue(ctx context.Context, id string, reason string
 		INSERT INTO dirty_issues (issue_id, marked_at)
 		VALUES (?, ?)
 		ON CONFLICT (issue_id) DO UPDATE SET marked_at = excluded.marked_at
-	`, id, time.Now())
+	`, id, time.Now().UTC())
 	if err != nil {
 		return fmt.Errorf("failed to mark issue dirty: %w", err)
 	}
@@ -967,13 +1214,22 @@ func (s *SQLiteStorage) CreateTombstone(ctx context.Context, id string, actor st
 		return fmt.Errorf("issue not found: %s", id)
 	}
 
-	tx, err := s.db.BeginTx(ctx, nil)
+	conn, err := s.writeConn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+	tx, err := conn.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer func() { _ = tx.Rollback() }()
 
-	now := time.Now()
+	if err := tagOperation(ctx, tx); err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
 	originalType := string(issue.IssueType)
 
 	// Convert issue to tombstone
@@ -994,6 +1250,10 @@ func (s *SQLiteStorage) CreateTombstone(ctx context.Context, id string, actor st
 		return fmt.Errorf("failed to create tombstone: %w", err)
 	}
 
+	if err := refreshContentHash(ctx, tx, id); err != nil {
+		return err
+	}
+
 	// Record tombstone creation event
 	_, err = tx.ExecContext(ctx, `
 		INSERT INTO events (issue_id, event_type, actor, comment)
@@ -1028,12 +1288,21 @@ func (s *SQLiteStorage) CreateTombstone(ctx context.Context, id string, actor st
 
 // DeleteIssue permanently removes an issue from the database
 func (s *SQLiteStorage) DeleteIssue(ctx context.Context, id string) error {
-	tx, err := s.db.BeginTx(ctx, nil)
+	conn, err := s.writeConn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+	tx, err := conn.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer func() { _ = tx.Rollback() }()
 
+	if err := tagOperation(ctx, tx); err != nil {
+		return err
+	}
+
 	// Delete dependencies (both directions)
 	_, err = tx.ExecContext(ctx, `DELETE FROM dependencies WHERE issue_id = ? OR depends_on_id = ?`, id, id)
 	if err != nil {
@@ -1045,6 +1314,46 @@ func (s *SQLiteStorage) DeleteIssue(ctx context.Context, id string) error {
 	if err != nil {
 		return fmt.Errorf("failed to delete events: %w", err)
 	}
+	_, err = tx.ExecContext(ctx, `DELETE FROM event_summaries WHERE issue_id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete event summary: %w", err)
+	}
+	_, err = tx.ExecContext(ctx, `DELETE FROM issue_leases WHERE issue_id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete lease: %w", err)
+	}
+	_, err = tx.ExecContext(ctx, `DELETE FROM status_transitions WHERE issue_id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete status transitions: %w", err)
+	}
+
+	// Delete aliases so they can be reused
+	_, err = tx.ExecContext(ctx, `DELETE FROM issue_aliases WHERE issue_id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete aliases: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM issue_translations WHERE issue_id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete translations: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM issue_fields WHERE issue_id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete fields: %w", err)
+	}
+
+	// Delete watches; nobody can be notified about an issue that is gone
+	_, err = tx.ExecContext(ctx, `DELETE FROM watches WHERE issue_id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete watches: %w", err)
+	}
+
+	// Unlink from other trackers; the remote copy is left alone
+	_, err = tx.ExecContext(ctx, `DELETE FROM external_refs WHERE issue_id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete external refs: %w", err)
+	}
 
 	// Delete from dirty_issues
 	_, err = tx.ExecContext(ctx, `DELETE FROM dirty_issues WHERE issue_id = ?`, id)
@@ -1093,12 +1402,21 @@ func (s *SQLiteStorage) DeleteIssues(ctx context.Context, ids []string, cascade
 		return &DeleteIssuesResult{}, nil
 	}
 
-	tx, err := s.db.BeginTx(ctx, nil)
+	conn, err := s.writeConn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+	tx, err := conn.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer func() { _ = tx.Rollback() }()
 
+	if err := tagOperation(ctx, tx); err != nil {
+		return nil, err
+	}
+
 	idSet := buildIDSet(ids)
 	result := &DeleteIssuesResult{}
 
@@ -1308,7 +1626,7 @@ func (s *SQLiteStorage) executeDelete(ctx context.Context, tx *sql.Tx, inClause
 	// 3. Convert issues to tombstones (only for issues that exist)
 	// Note: closed_at must be set to NULL because of CHECK constraint:
 	// (status = 'closed') = (closed_at IS NOT NULL)
-	now := time.Now()
+	now := time.Now().UTC()
 	deletedCount := 0
 	for id, originalType := range issueTypes {
 		execResult, err := tx.ExecContext(ctx, `
@@ -1331,6 +1649,9 @@ func (s *SQLiteStorage) executeDelete(ctx context.Context, tx *sql.Tx, inClause
 			continue // Issue doesn't exist, skip
 		}
 		deletedCount++
+		if err := refreshContentHash(ctx, tx, id); err != nil {
+			return err
+		}
 
 		// Record tombstone creation event
 		_, err = tx.ExecContext(ctx, `
@@ -1400,6 +1721,64 @@ func (s *SQLiteStorage) findAllDependentsRecursive(ctx context.Context, tx *sql.
 	return result, nil
 }
 
+// pageCursorClause matches issues that sort after the cursor in the
+// SearchIssues ordering (priority ASC, created_at DESC, id ASC). created_at
+// is compared as stored, so it is read back from the cursor's issue; the
+// cursor's own timestamp is only used if that issue has been deleted.
+func pageCursorClause(c *types.PageCursor) (string, []interface{}) {
+	createdAt := "COALESCE((SELECT created_at FROM issues WHERE id = ?), ?)"
+	clause := fmt.Sprintf("(priority > ? OR (priority = ? AND (created_at < %s OR (created_at = %s AND id > ?))))", createdAt, createdAt)
+	stamp := c.CreatedAt.Format(time.RFC3339Nano)
+	return clause, []interface{}{c.Priority, c.Priority, c.ID, stamp, c.ID, stamp, c.ID}
+}
+
+// defaultIssueOrder is the order of SearchIssues results and the order
+// page cursors follow
+const defaultIssueOrder = "priority ASC, created_at DESC, id ASC"
+
+// sortColumns maps sort fields to the expressions they order by. Nullable
+// fields sort their missing values last in either direction.
+var sortColumns = map[string]struct{ nullsLast, expr string }{
+	"priority":   {expr: "priority"},
+	"created_at": {expr: "created_at"},
+	"updated_at": {expr: "updated_at"},
+	"closed_at":  {nullsLast: "closed_at IS NULL", expr: "closed_at"},
+	"status":     {expr: "status"},
+	"issue_type": {expr: "issue_type"},
+	"assignee":   {nullsLast: "COALESCE(assignee, '') = ''", expr: "assignee"},
+	"title":      {expr: "title COLLATE NOCASE"},
+	"estimate":   {nullsLast: "estimated_minutes IS NULL", expr: "estimated_minutes"},
+	"id":         {expr: "id"},
+}
+
+// orderByClause builds the ORDER BY list for filter.Sort, ending with id so
+// that equal keys keep a stable order
+func orderByClause(filter types.IssueFilter) (string, error) {
+	if len(filter.Sort) == 0 {
+		return defaultIssueOrder, nil
+	}
+	if filter.After != nil {
+		return "", fmt.Errorf("cursor pagination requires the default sort order")
+	}
+	var terms []string
+	for _, k := range filter.Sort {
+		col, ok := sortColumns[k.Field]
+		if !ok {
+			return "", fmt.Errorf("unknown sort field %q", k.Field)
+		}
+		if col.nullsLast != "" {
+			terms = append(terms, col.nullsLast)
+		}
+		dir := " ASC"
+		if k.Desc {
+			dir = " DESC"
+		}
+		terms = append(terms, col.expr+dir)
+	}
+	terms = append(terms, "id ASC")
+	return strings.Join(terms, ", "), nil
+}
+
 // SearchIssues finds issues matching query and filters
 func (s *SQLiteStorage) SearchIssues(ctx context.Context, query string, filter types.IssueFilter) ([]*types.Issue, error) {
 	// Check for external database file modifications (daemon mode)
@@ -1412,11 +1791,25 @@ func (s *SQLiteStorage) SearchIssues(ctx context.Context, query string, filter t
 
 	whereClauses := []string{}
 	args := []interface{}{}
+	fromSQL := "issues"
+	var fromArgs []interface{}
+	ranked := false
 
 	if query != "" {
-		whereClauses = append(whereClauses, "(title LIKE ? OR description LIKE ? OR id LIKE ?)")
-		pattern := "%" + query + "%"
-		args = append(args, pattern, pattern, pattern)
+		// Words go through the full-text index (titles, descriptions,
+		// comments, attachment text; see ftsMatchQuery); IDs still match as
+		// substrings so partial IDs keep working
+		if match := ftsMatchQuery(query); match != "" {
+			fromSQL = fmt.Sprintf(`issues LEFT JOIN (
+				SELECT issue_id, %s AS score FROM issues_fts WHERE issues_fts MATCH ?
+			) fts ON fts.issue_id = issues.id`, ftsRank)
+			fromArgs = append(fromArgs, match)
+			whereClauses = append(whereClauses, "(id LIKE ? OR fts.issue_id IS NOT NULL)")
+			ranked = true
+		} else {
+			whereClauses = append(whereClauses, "id LIKE ?")
+		}
+		args = append(args, "%"+query+"%")
 	}
 
 	if filter.TitleSearch != "" {
@@ -1498,6 +1891,11 @@ func (s *SQLiteStorage) SearchIssues(ctx context.Context, query string, filter t
 		whereClauses = append(whereClauses, "closed_at < ?")
 		args = append(args, filter.ClosedBefore.Format(time.RFC3339))
 	}
+	if filter.CloseReason != "" {
+		prefix := filter.CloseReason + ":"
+		whereClauses = append(whereClauses, "(close_reason = ? OR substr(close_reason, 1, ?) = ?)")
+		args = append(args, filter.CloseReason, len(prefix), prefix)
+	}
 
 	// Empty/null checks
 	if filter.EmptyDescription {
@@ -1547,6 +1945,24 @@ func (s *SQLiteStorage) SearchIssues(ctx context.Context, query string, filter t
 		}
 	}
 
+	if len(filter.Complexity) > 0 {
+		clause, complexityArgs := complexityClause("complexity", filter.Complexity)
+		whereClauses = append(whereClauses, clause)
+		args = append(args, complexityArgs...)
+	}
+
+	// Custom field filtering: issue must have ALL specified values
+	clauses, fieldArgs := fieldClauses("id", filter.Fields)
+	whereClauses = append(whereClauses, clauses...)
+	args = append(args, fieldArgs...)
+
+	// Pagination: resume after the cursor position
+	if filter.After != nil {
+		clause, cursorArgs := pageCursorClause(filter.After)
+		whereClauses = append(whereClauses, clause)
+		args = append(args, cursorArgs...)
+	}
+
 	whereSQL := ""
 	if len(whereClauses) > 0 {
 		whereSQL = "WHERE " + strings.Join(whereClauses, " AND ")
@@ -1558,20 +1974,30 @@ func (s *SQLiteStorage) SearchIssues(ctx context.Context, query string, filter t
 		args = append(args, filter.Limit)
 	}
 
+	orderSQL, err := orderByClause(filter)
+	if err != nil {
+		return nil, err
+	}
+	// Best text match first unless the caller asked for an order (cursor
+	// pages need the default one); ID-only matches score 0, after text hits
+	if ranked && len(filter.Sort) == 0 && filter.After == nil {
+		orderSQL = "COALESCE(fts.score, 0), " + orderSQL
+	}
+
 	// #nosec G201 - safe SQL with controlled formatting
 	querySQL := fmt.Sprintf(`
 		SELECT id, content_hash, title, description, design, acceptance_criteria, notes,
 		       status, priority, issue_type, assignee, estimated_minutes,
 		       created_at, updated_at, closed_at, external_ref, source_repo, close_reason,
 		       deleted_at, deleted_by, delete_reason, original_type,
-		       sender, ephemeral
-		FROM issues
+		       sender, ephemeral, complexity, source, timebox_minutes
+		FROM %s
 		%s
-		ORDER BY priority ASC, created_at DESC
+		ORDER BY %s
 		%s
-	`, whereSQL, limitSQL)
+	`, fromSQL, whereSQL, orderSQL, limitSQL)
 
-	rows, err := s.db.QueryContext(ctx, querySQL, args...)
+	rows, err := s.db.QueryContext(ctx, querySQL, append(fromArgs, args...)...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search issues: %w", err)
 	}
@@ -0,0 +1,89 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// NewReadOnly opens an existing database without writing to it or to its
+// directory, for repositories on read-only mounts (CI caches, snapshots).
+// Nothing is created or migrated, so the database must already have the
+// current schema.
+//
+// SQLite needs to create the -shm file to read a WAL database, which it
+// can't do here. A database closed cleanly has an empty WAL, so it is opened
+// immutable, without locking or the WAL; one with a WAL still to apply is
+// opened read-only with it, which works when the -shm file is there too.
+func NewReadOnly(ctx context.Context, path string, busyTimeout time.Duration) (*SQLiteStorage, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get absolute path: %w", err)
+	}
+
+	base := fmt.Sprintf("file:%s?mode=ro&_pragma=foreign_keys(ON)&_pragma=busy_timeout(%d)&_time_format=sqlite",
+		uriPathEscaper.Replace(absPath), int64(busyTimeout/time.Millisecond))
+	immutable := base + "&immutable=1"
+	connStr := immutable
+	if info, err := os.Stat(absPath + "-wal"); err == nil && info.Size() > 0 {
+		connStr = base
+	}
+
+	db, err := openReadOnlyDB(connStr)
+	if err != nil && connStr != immutable {
+		// Without the -shm file the WAL can't be read; what was
+		// checkpointed still can
+		db, err = openReadOnlyDB(immutable)
+		connStr = immutable
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := verifySchemaCompatibility(db); err != nil {
+		_ = db.Close()
+		if errors.Is(err, ErrSchemaIncompatible) {
+			return nil, fmt.Errorf("%w (the database needs migrating, which can't be done read-only)", err)
+		}
+		return nil, err
+	}
+
+	return &SQLiteStorage{
+		db:          db,
+		writeDB:     db,
+		dbPath:      absPath,
+		connStr:     connStr,
+		busyTimeout: busyTimeout,
+		readOnly:    true,
+	}, nil
+}
+
+// openReadOnlyDB opens and checks a read-only connection pool
+func openReadOnlyDB(connStr string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite3", connStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	db.SetMaxOpenConns(readPoolSize())
+	db.SetMaxIdleConns(2)
+	db.SetConnMaxLifetime(0)
+	// Ping doesn't read the file; this does
+	var n int
+	if err := db.QueryRow("SELECT count(*) FROM sqlite_master").Scan(&n); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to open database read-only: %w", err)
+	}
+	return db, nil
+}
+
+// ReadOnly reports whether the database was opened with NewReadOnly
+func (s *SQLiteStorage) ReadOnly() bool {
+	return s.readOnly
+}
@@ -0,0 +1,59 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestNewReadOnly(t *testing.T) {
+	ctx := context.Background()
+	dbPath := filepath.Join(t.TempDir(), "beads.db")
+
+	if _, err := NewReadOnly(ctx, dbPath, time.Second); err == nil {
+		t.Fatal("expected an error for a database that doesn't exist")
+	}
+
+	writer, err := New(ctx, dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+		t.Fatal(err)
+	}
+	issue := &types.Issue{Title: "Readable", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := writer.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	// While the writer is open the issue is still in the WAL, and once it
+	// has closed it is checkpointed into the database
+	for _, stage := range []string{"with a WAL", "after checkpoint"} {
+		if stage == "after checkpoint" {
+			if err := writer.Close(); err != nil {
+				t.Fatal(err)
+			}
+		}
+		ro, err := NewReadOnly(ctx, dbPath, time.Second)
+		if err != nil {
+			t.Fatalf("%s: NewReadOnly failed: %v", stage, err)
+		}
+		if !ro.ReadOnly() {
+			t.Errorf("%s: ReadOnly() = false", stage)
+		}
+		got, err := ro.GetIssue(ctx, issue.ID)
+		if err != nil || got == nil || got.Title != "Readable" {
+			t.Errorf("%s: GetIssue = %+v, %v", stage, got, err)
+		}
+		err = ro.CreateIssue(ctx, &types.Issue{Title: "Not written", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}, "test")
+		if !IsReadOnly(err) {
+			t.Errorf("%s: CreateIssue error = %v, want ErrReadOnly", stage, err)
+		}
+		if err := ro.Close(); err != nil {
+			t.Errorf("%s: Close failed: %v", stage, err)
+		}
+	}
+}
@@ -13,6 +13,128 @@ import (
 // By default, shows both 'open' and 'in_progress' issues so epics/tasks
 // ready to close are visible (bd-165)
 func (s *SQLiteStorage) GetReadyWork(ctx context.Context, filter types.WorkFilter) ([]*types.Issue, error) {
+	query, args := buildReadyWorkQuery(filter, `i.id, i.content_hash, i.title, i.description, i.design, i.acceptance_criteria, i.notes,
+		i.status, i.priority, i.issue_type, i.assignee, i.estimated_minutes,
+		i.created_at, i.updated_at, i.closed_at, i.external_ref, i.source_repo, i.close_reason,
+		i.deleted_at, i.deleted_by, i.delete_reason, i.original_type,
+		i.sender, i.ephemeral, i.complexity, i.source, i.timebox_minutes`)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ready work: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	issues, err := s.scanIssues(ctx, rows)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.attachSoftBlockers(ctx, issues); err != nil {
+		return nil, err
+	}
+	return issues, nil
+}
+
+// softBlockedSQL is true for issues with a 'soft-blocks' dependency on an
+// issue that is still open. Such issues stay ready but sort last.
+const softBlockedSQL = `EXISTS (
+			SELECT 1 FROM dependencies sd
+			JOIN issues sb ON sb.id = sd.depends_on_id
+			WHERE sd.issue_id = i.id AND sd.type = 'soft-blocks'
+			  AND sb.status IN ('open', 'in_progress', 'blocked')
+		)`
+
+// attachSoftBlockers fills SoftBlockedBy with each issue's open soft blockers
+func (s *SQLiteStorage) attachSoftBlockers(ctx context.Context, issues []*types.Issue) error {
+	if len(issues) == 0 {
+		return nil
+	}
+	byID := make(map[string]*types.Issue, len(issues))
+	placeholders := make([]string, len(issues))
+	args := make([]interface{}, len(issues))
+	for i, issue := range issues {
+		byID[issue.ID] = issue
+		placeholders[i] = "?"
+		args[i] = issue.ID
+	}
+
+	// #nosec G201 - safe SQL with controlled formatting
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT sd.issue_id, sd.depends_on_id
+		FROM dependencies sd
+		JOIN issues sb ON sb.id = sd.depends_on_id
+		WHERE sd.type = 'soft-blocks'
+		  AND sb.status IN ('open', 'in_progress', 'blocked')
+		  AND sd.issue_id IN (%s)
+		ORDER BY sd.depends_on_id
+	`, strings.Join(placeholders, ",")), args...)
+	if err != nil {
+		return fmt.Errorf("failed to get soft blockers: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var issueID, blockerID string
+		if err := rows.Scan(&issueID, &blockerID); err != nil {
+			return fmt.Errorf("failed to scan soft blocker: %w", err)
+		}
+		if issue := byID[issueID]; issue != nil {
+			issue.SoftBlockedBy = append(issue.SoftBlockedBy, blockerID)
+		}
+	}
+	return rows.Err()
+}
+
+// buildReadyWorkQuery builds the ready-work query selecting columns from issues i
+func buildReadyWorkQuery(filter types.WorkFilter, columns string) (string, []interface{}) {
+	whereClauses, args := workFilterClauses(filter)
+
+	// Build WHERE clause properly
+	whereSQL := strings.Join(whereClauses, " AND ")
+
+	// Build LIMIT clause using parameter
+	limitSQL := ""
+	if filter.Limit > 0 {
+		limitSQL = " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+
+	// Default to hybrid sort for backwards compatibility
+	sortPolicy := filter.SortPolicy
+	if sortPolicy == "" {
+		sortPolicy = types.SortPolicyHybrid
+	}
+	// Soft-blocked issues go last regardless of policy (ordering hint, not a gate)
+	orderBySQL := "ORDER BY " + softBlockedSQL + " ASC," + strings.TrimPrefix(buildOrderByClause(sortPolicy), "ORDER BY")
+
+	// Use blocked_issues_cache for performance (bd-5qim)
+	// This optimization replaces the recursive CTE that computed blocked issues on every query.
+	// Performance improvement: 752ms → 29ms on 10K issues (25x speedup).
+	//
+	// The cache is automatically maintained by invalidateBlockedCache() which is called:
+	//   - When adding/removing 'blocks' or 'parent-child' dependencies
+	//   - When any issue status changes
+	//   - When closing any issue
+	//
+	// Cache rebuild is fast (<50ms) and happens within the same transaction as the
+	// triggering change, ensuring consistency. See blocked_cache.go for full details.
+	// #nosec G201 - safe SQL with controlled formatting
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM issues i
+		WHERE %s
+		AND NOT EXISTS (
+		  SELECT 1 FROM blocked_issues_cache WHERE issue_id = i.id
+		)
+		%s
+		%s
+	`, columns, whereSQL, orderBySQL, limitSQL)
+	return query, args
+}
+
+// workFilterClauses returns the WHERE conditions on issues i for the status,
+// priority, assignee and label parts of filter
+func workFilterClauses(filter types.WorkFilter) ([]string, []interface{}) {
 	whereClauses := []string{}
 	args := []interface{}{}
 
@@ -35,6 +157,9 @@ func (s *SQLiteStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 	} else if filter.Assignee != nil {
 		whereClauses = append(whereClauses, "i.assignee = ?")
 		args = append(args, *filter.Assignee)
+	} else if filter.AvailableTo != nil {
+		whereClauses = append(whereClauses, "(i.assignee IS NULL OR i.assignee = '' OR i.assignee = ?)")
+		args = append(args, *filter.AvailableTo)
 	}
 
 	// Label filtering (AND semantics)
@@ -67,57 +192,23 @@ func (s *SQLiteStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 		}
 	}
 
-	// Build WHERE clause properly
-	whereSQL := strings.Join(whereClauses, " AND ")
-
-	// Build LIMIT clause using parameter
-	limitSQL := ""
-	if filter.Limit > 0 {
-		limitSQL = " LIMIT ?"
-		args = append(args, filter.Limit)
-	}
-
-	// Default to hybrid sort for backwards compatibility
-	sortPolicy := filter.SortPolicy
-	if sortPolicy == "" {
-		sortPolicy = types.SortPolicyHybrid
+	if len(filter.Complexity) > 0 {
+		clause, complexityArgs := complexityClause("i.complexity", filter.Complexity)
+		whereClauses = append(whereClauses, clause)
+		args = append(args, complexityArgs...)
 	}
-	orderBySQL := buildOrderByClause(sortPolicy)
-
-	// Use blocked_issues_cache for performance (bd-5qim)
-	// This optimization replaces the recursive CTE that computed blocked issues on every query.
-	// Performance improvement: 752ms → 29ms on 10K issues (25x speedup).
-	//
-	// The cache is automatically maintained by invalidateBlockedCache() which is called:
-	//   - When adding/removing 'blocks' or 'parent-child' dependencies
-	//   - When any issue status changes
-	//   - When closing any issue
-	//
-	// Cache rebuild is fast (<50ms) and happens within the same transaction as the
-	// triggering change, ensuring consistency. See blocked_cache.go for full details.
-	// #nosec G201 - safe SQL with controlled formatting
-	query := fmt.Sprintf(`
-		SELECT i.id, i.content_hash, i.title, i.description, i.design, i.acceptance_criteria, i.notes,
-		i.status, i.priority, i.issue_type, i.assignee, i.estimated_minutes,
-		i.created_at, i.updated_at, i.closed_at, i.external_ref, i.source_repo, i.close_reason,
-		i.deleted_at, i.deleted_by, i.delete_reason, i.original_type,
-		i.sender, i.ephemeral
-		FROM issues i
-		WHERE %s
-		AND NOT EXISTS (
-		  SELECT 1 FROM blocked_issues_cache WHERE issue_id = i.id
-		)
-		%s
-		%s
-	`, whereSQL, orderBySQL, limitSQL)
+	return whereClauses, args
+}
 
-	rows, err := s.db.QueryContext(ctx, query, args...)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get ready work: %w", err)
+// complexityClause matches issues whose complexity is one of values
+func complexityClause(column string, values []types.Complexity) (string, []interface{}) {
+	placeholders := make([]string, len(values))
+	args := make([]interface{}, len(values))
+	for i, c := range values {
+		placeholders[i] = "?"
+		args[i] = string(c)
 	}
-	defer func() { _ = rows.Close() }()
-
-	return s.scanIssues(ctx, rows)
+	return fmt.Sprintf("%s IN (%s)", column, strings.Join(placeholders, ", ")), args
 }
 
 // GetStaleIssues returns issues that haven't been updated recently
@@ -130,7 +221,7 @@ func (s *SQLiteStorage) GetStaleIssues(ctx context.Context, filter types.StaleFi
 			created_at, updated_at, closed_at, external_ref, source_repo,
 			compaction_level, compacted_at, compacted_at_commit, original_size, close_reason,
 			deleted_at, deleted_by, delete_reason, original_type,
-			sender, ephemeral
+			sender, ephemeral, complexity, source, timebox_minutes
 		FROM issues
 		WHERE status != 'closed'
 		  AND datetime(updated_at) < datetime('now', '-' || ? || ' days')
@@ -179,6 +270,9 @@ func (s *SQLiteStorage) GetStaleIssues(ctx context.Context, filter types.StaleFi
 		// Messaging fields (bd-kwro)
 		var sender sql.NullString
 		var ephemeral sql.NullInt64
+		var complexity sql.NullString
+		var source sql.NullString
+		var timeboxMinutes sql.NullInt64
 
 		err := rows.Scan(
 			&issue.ID, &contentHash, &issue.Title, &issue.Description, &issue.Design,
@@ -187,7 +281,7 @@ func (s *SQLiteStorage) GetStaleIssues(ctx context.Context, filter types.StaleFi
 			&issue.CreatedAt, &issue.UpdatedAt, &closedAt, &externalRef, &sourceRepo,
 			&compactionLevel, &compactedAt, &compactedAtCommit, &originalSize, &closeReason,
 			&deletedAt, &deletedBy, &deleteReason, &originalType,
-			&sender, &ephemeral,
+			&sender, &ephemeral, &complexity, &source, &timeboxMinutes,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan stale issue: %w", err)
@@ -244,6 +338,16 @@ func (s *SQLiteStorage) GetStaleIssues(ctx context.Context, filter types.StaleFi
 		if ephemeral.Valid && ephemeral.Int64 != 0 {
 			issue.Ephemeral = true
 		}
+		if complexity.Valid {
+			issue.Complexity = types.Complexity(complexity.String)
+		}
+		if source.Valid {
+			issue.Source = source.String
+		}
+		if timeboxMinutes.Valid {
+			mins := int(timeboxMinutes.Int64)
+			issue.TimeboxMinutes = &mins
+		}
 
 		issues = append(issues, &issue)
 	}
@@ -256,7 +360,10 @@ func (s *SQLiteStorage) GetBlockedIssues(ctx context.Context) ([]*types.BlockedI
 	// Use UNION to combine:
 	// 1. Issues with open/in_progress/blocked status that have dependency blockers
 	// 2. Issues with status=blocked (even if they have no dependency blockers)
+	// 3. Issues with a gate whose CI check hasn't passed
+	// 4. Issues with blockers in linked workspaces that aren't known to be done
 	// Use GROUP_CONCAT to get all blocker IDs in a single query (no N+1)
+	// #nosec G202 - unresolvedExternalSQL only formats a fixed alias
 	rows, err := s.db.QueryContext(ctx, `
 		SELECT
 		    i.id, i.title, i.description, i.design, i.acceptance_criteria, i.notes,
@@ -267,14 +374,15 @@ func (s *SQLiteStorage) GetBlockedIssues(ctx context.Context) ([]*types.BlockedI
 		FROM issues i
 		LEFT JOIN dependencies d ON i.id = d.issue_id
 		    AND d.type = 'blocks'
-		    AND EXISTS (
+		    AND (EXISTS (
 		        SELECT 1 FROM issues blocker
 		        WHERE blocker.id = d.depends_on_id
 		        AND blocker.status IN ('open', 'in_progress', 'blocked')
-		    )
+		    ) OR `+unresolvedExternalSQL("d")+`)
 		WHERE i.status IN ('open', 'in_progress', 'blocked')
 		  AND (
 		      i.status = 'blocked'
+		      OR EXISTS (SELECT 1 FROM gates g WHERE g.issue_id = i.id AND g.state != 'success')
 		      OR EXISTS (
 		          SELECT 1 FROM dependencies d2
 		          JOIN issues blocker ON d2.depends_on_id = blocker.id
@@ -282,6 +390,10 @@ func (s *SQLiteStorage) GetBlockedIssues(ctx context.Context) ([]*types.BlockedI
 		            AND d2.type = 'blocks'
 		            AND blocker.status IN ('open', 'in_progress', 'blocked')
 		      )
+		      OR EXISTS (
+		          SELECT 1 FROM dependencies d3
+		          WHERE d3.issue_id = i.id AND d3.type = 'blocks' AND `+unresolvedExternalSQL("d3")+`
+		      )
 		  )
 		GROUP BY i.id
 		ORDER BY i.priority ASC
@@ -338,7 +450,17 @@ func (s *SQLiteStorage) GetBlockedIssues(ctx context.Context) ([]*types.BlockedI
 
 		blocked = append(blocked, &issue)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 
+	unmet, err := s.unmetGates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, issue := range blocked {
+		issue.BlockedByGates = unmet[issue.ID]
+	}
 	return blocked, nil
 }
 
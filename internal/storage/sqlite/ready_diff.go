@@ -0,0 +1,144 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// GetReadyDiff reports which issues entered and left the ready queue between
+// since and until, and why. Both queues are reconstructed from the event
+// history the same way GetStateTimes reconstructs state: an issue is in the
+// queue while it is open and none of its blocks dependencies is open, in
+// progress or blocked. An issue that entered and left again in between is
+// not reported. since must not predate pruned history (PruneEvents).
+func (s *SQLiteStorage) GetReadyDiff(ctx context.Context, since, until time.Time) (*types.ReadyDiff, error) {
+	pruned, err := s.historyPrunedBefore(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if since.Before(pruned) {
+		return nil, fmt.Errorf("history before %s has been pruned (history.retention)", pruned.Local().Format("2006-01-02 15:04"))
+	}
+	timelines, err := s.statusTimelines(ctx)
+	if err != nil {
+		return nil, err
+	}
+	intervals, err := s.blockingIntervals(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byIssue := make(map[string][]blockingInterval)
+	for _, iv := range intervals {
+		if _, ok := timelines[iv.blockerID]; ok {
+			byIssue[iv.issueID] = append(byIssue[iv.issueID], iv)
+		}
+	}
+
+	diff := &types.ReadyDiff{Since: since, Until: until, Entered: []*types.ReadyChange{}, Left: []*types.ReadyChange{}}
+	changes := make(map[string]*types.ReadyChange)
+	for id, timeline := range timelines {
+		before, after := statusAt(timeline, since), statusAt(timeline, until)
+		blockersBefore := uniqueBlockers(openBlockersAt(byIssue[id], timelines, since))
+		blockersAfter := uniqueBlockers(openBlockersAt(byIssue[id], timelines, until))
+		wasReady := before == types.StatusOpen && len(blockersBefore) == 0
+		isReady := after == types.StatusOpen && len(blockersAfter) == 0
+
+		switch {
+		case isReady && !wasReady:
+			c := enteredReadyChange(id, before, blockersBefore, timelines, until)
+			diff.Entered = append(diff.Entered, c)
+			changes[id] = c
+		case wasReady && !isReady:
+			c := leftReadyChange(id, after, blockersAfter)
+			diff.Left = append(diff.Left, c)
+			changes[id] = c
+		}
+	}
+	if len(changes) == 0 {
+		return diff, nil
+	}
+
+	ids := make([]string, 0, len(changes))
+	for id := range changes {
+		ids = append(ids, id)
+	}
+	issues, err := s.SearchIssues(ctx, "", types.IssueFilter{IDs: ids})
+	if err != nil {
+		return nil, err
+	}
+	for _, issue := range issues {
+		c := changes[issue.ID]
+		c.Title, c.Priority = issue.Title, issue.Priority
+	}
+	sortReadyChanges(diff.Entered)
+	sortReadyChanges(diff.Left)
+	return diff, nil
+}
+
+// enteredReadyChange explains why an issue that wasn't ready in status
+// before (with blockers open then) is ready at until
+func enteredReadyChange(id string, before types.Status, blockers []string, timelines map[string][]statusChange, until time.Time) *types.ReadyChange {
+	c := &types.ReadyChange{ID: id}
+	switch before {
+	case "":
+		c.Reason = types.ReadyReasonNewIssue
+	case types.StatusInProgress:
+		c.Reason = types.ReadyReasonClaimReleased
+	case types.StatusClosed:
+		c.Reason = types.ReadyReasonReopened
+	case types.StatusOpen:
+		// Blocked then: either blockers closed or the dependencies went away
+		for _, b := range blockers {
+			if !isBlockingStatus(statusAt(timelines[b], until)) {
+				c.Blockers = append(c.Blockers, b)
+			}
+		}
+		c.Reason = types.ReadyReasonDependencyRemoved
+		if len(c.Blockers) > 0 {
+			c.Reason = types.ReadyReasonBlockerClosed
+		}
+	default:
+		c.Reason = types.ReadyReasonStatusChanged
+	}
+	return c
+}
+
+// leftReadyChange explains why a ready issue is no longer ready in status
+// after, with blockers open
+func leftReadyChange(id string, after types.Status, blockers []string) *types.ReadyChange {
+	c := &types.ReadyChange{ID: id}
+	switch after {
+	case types.StatusInProgress:
+		c.Reason = types.ReadyReasonClaimed
+	case types.StatusClosed:
+		c.Reason = types.ReadyReasonClosed
+	case types.StatusOpen:
+		c.Reason = types.ReadyReasonBlocked
+		c.Blockers = blockers
+	default:
+		c.Reason = types.ReadyReasonStatusChanged
+	}
+	return c
+}
+
+// uniqueBlockers sorts blocker IDs, dropping repeats from blockers that
+// were added more than once
+func uniqueBlockers(blockers []string) []string {
+	sort.Strings(blockers)
+	return slices.Compact(blockers)
+}
+
+// sortReadyChanges orders changes like the ready queue: priority, then ID
+func sortReadyChanges(changes []*types.ReadyChange) {
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Priority != changes[j].Priority {
+			return changes[i].Priority < changes[j].Priority
+		}
+		return changes[i].ID < changes[j].ID
+	})
+}
@@ -0,0 +1,115 @@
+package sqlite
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestGetReadyDiff(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	newIssue := func(title string, priority int) *types.Issue {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: priority, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue(%s): %v", title, err)
+		}
+		return issue
+	}
+	setStatus := func(id string, status types.Status) {
+		t.Helper()
+		if err := store.UpdateIssue(ctx, id, map[string]interface{}{"status": string(status)}, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	blocker := newIssue("Blocker", 2)
+	worker := newIssue("Worker", 1)
+	claimed := newIssue("Claimed", 0)
+	released := newIssue("Released", 3)
+	steady := newIssue("Steady", 4)
+	late := newIssue("Late", 2)
+
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: worker.ID, DependsOnID: blocker.ID, Type: types.DepBlocks}, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: steady.ID, DependsOnID: late.ID, Type: types.DepBlocks}, "test"); err != nil {
+		t.Fatal(err)
+	}
+	setStatus(released.ID, types.StatusInProgress)
+	if err := store.CloseIssue(ctx, blocker.ID, "done", "test"); err != nil {
+		t.Fatal(err)
+	}
+	setStatus(claimed.ID, types.StatusInProgress)
+	setStatus(released.ID, types.StatusOpen)
+
+	// Rewrite the timestamps into a known history:
+	//   t0     everything but late created, worker blocked by blocker
+	//   t0+1h  released claimed
+	//   t0+2h  since
+	//   t0+3h  blocker closed
+	//   t0+4h  claimed claimed, released released
+	//   t0+5h  late created and blocks steady
+	//   t0+6h  until
+	t0 := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	at := func(h int) time.Time { return t0.Add(time.Duration(h) * time.Hour) }
+	exec := func(query string, args ...interface{}) {
+		t.Helper()
+		if _, err := store.db.ExecContext(ctx, query, args...); err != nil {
+			t.Fatalf("%s: %v", query, err)
+		}
+	}
+	exec(`UPDATE issues SET created_at = ?`, t0)
+	exec(`UPDATE issues SET created_at = ? WHERE id = ?`, at(5), late.ID)
+	exec(`UPDATE issues SET closed_at = ? WHERE id = ?`, at(3), blocker.ID)
+	exec(`UPDATE dependencies SET created_at = ? WHERE issue_id = ?`, t0, worker.ID)
+	exec(`UPDATE dependencies SET created_at = ? WHERE issue_id = ?`, at(5), steady.ID)
+	exec(`UPDATE events SET created_at = ? WHERE issue_id = ? AND event_type = ?`, at(3), blocker.ID, types.EventClosed)
+	exec(`UPDATE events SET created_at = ? WHERE issue_id = ? AND event_type = ?`, at(4), claimed.ID, types.EventStatusChanged)
+	exec(`UPDATE events SET created_at = ? WHERE issue_id = ? AND event_type = ? AND new_value LIKE '%in_progress%'`, at(1), released.ID, types.EventStatusChanged)
+	exec(`UPDATE events SET created_at = ? WHERE issue_id = ? AND event_type = ? AND new_value NOT LIKE '%in_progress%'`, at(4), released.ID, types.EventStatusChanged)
+
+	diff, err := store.GetReadyDiff(ctx, at(2), at(6))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type change struct {
+		id     string
+		reason types.ReadyReason
+	}
+	summarize := func(changes []*types.ReadyChange) []change {
+		var out []change
+		for _, c := range changes {
+			out = append(out, change{c.ID, c.Reason})
+		}
+		return out
+	}
+	wantEntered := []change{{worker.ID, types.ReadyReasonBlockerClosed}, {late.ID, types.ReadyReasonNewIssue}, {released.ID, types.ReadyReasonClaimReleased}}
+	wantLeft := []change{{claimed.ID, types.ReadyReasonClaimed}, {blocker.ID, types.ReadyReasonClosed}, {steady.ID, types.ReadyReasonBlocked}}
+	if got := summarize(diff.Entered); !reflect.DeepEqual(got, wantEntered) {
+		t.Errorf("entered = %v, want %v", got, wantEntered)
+	}
+	if got := summarize(diff.Left); !reflect.DeepEqual(got, wantLeft) {
+		t.Errorf("left = %v, want %v", got, wantLeft)
+	}
+	if c := diff.Entered[0]; len(c.Blockers) != 1 || c.Blockers[0] != blocker.ID || c.Title != "Worker" {
+		t.Errorf("worker change = %+v, want blocker %s", c, blocker.ID)
+	}
+	if c := diff.Left[2]; len(c.Blockers) != 1 || c.Blockers[0] != late.ID {
+		t.Errorf("steady change = %+v, want blocker %s", c, late.ID)
+	}
+
+	// Nothing changes within the quiet hour before since
+	quiet, err := store.GetReadyDiff(ctx, at(1).Add(time.Minute), at(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(quiet.Entered) != 0 || len(quiet.Left) != 0 {
+		t.Errorf("quiet hour diff = %+v", quiet)
+	}
+}
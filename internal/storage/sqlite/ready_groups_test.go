@@ -0,0 +1,78 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestGroupReadyWork(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	newIssue := func(title string, issueType types.IssueType, priority int, labels ...string) *types.Issue {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: priority, IssueType: issueType}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue(%s): %v", title, err)
+		}
+		for _, label := range labels {
+			if err := store.AddLabel(ctx, issue.ID, label, "test"); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return issue
+	}
+	addChild := func(child, parent *types.Issue) {
+		dep := &types.Dependency{IssueID: child.ID, DependsOnID: parent.ID, Type: types.DepParentChild}
+		if err := store.AddDependency(ctx, dep, "test"); err != nil {
+			t.Fatalf("AddDependency: %v", err)
+		}
+	}
+
+	// auth (epic) -> feature -> login; billing (epic) -> invoice; loose
+	auth := newIssue("Auth", types.TypeEpic, 1)
+	feature := newIssue("Feature", types.TypeFeature, 1)
+	login := newIssue("Login", types.TypeTask, 2, "backend", "security")
+	billing := newIssue("Billing", types.TypeEpic, 1)
+	invoice := newIssue("Invoice", types.TypeTask, 0, "backend")
+	loose := newIssue("Loose", types.TypeTask, 3)
+	addChild(feature, auth)
+	addChild(login, feature)
+	addChild(invoice, billing)
+	if err := store.CloseIssue(ctx, feature.ID, "done", "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	ready := []*types.Issue{invoice, login, loose}
+	groups, err := storage.GroupByEpic(ctx, store, ready)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 3 {
+		t.Fatalf("got %d groups, want 3: %+v", len(groups), groups)
+	}
+	// The top-ranked item's epic comes first; login finds auth through feature
+	if groups[0].Epic.ID != billing.ID || groups[1].Epic.ID != auth.ID || groups[1].Issues[0].ID != login.ID {
+		t.Errorf("epic groups = %s, %s", groups[0].Epic.ID, groups[1].Epic.ID)
+	}
+	if p := groups[1].Progress; p == nil || p.Total != 1 || p.Closed != 1 {
+		t.Errorf("auth progress = %+v, want 1/1", p)
+	}
+	if groups[2].Epic != nil || len(groups[2].Issues) != 1 || groups[2].Issues[0].ID != loose.ID {
+		t.Errorf("ungrouped = %+v", groups[2])
+	}
+
+	groups, err = storage.GroupByLabel(ctx, store, ready)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 3 || groups[0].Label != "backend" || len(groups[0].Issues) != 2 || groups[1].Label != "security" {
+		t.Fatalf("label groups = %+v", groups)
+	}
+	if p := groups[0].Progress; p.Total != 2 || p.Closed != 0 {
+		t.Errorf("backend progress = %+v, want 0/2", p)
+	}
+}
@@ -0,0 +1,124 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// GetNextReadyWork looks one step ahead of GetReadyWork: it returns the open
+// issues that are blocked now but become ready once every in_progress issue
+// is closed. BlockedBy lists the in-progress issues each one waits on,
+// directly or through a parent. The filter's Status is ignored.
+func (s *SQLiteStorage) GetNextReadyWork(ctx context.Context, filter types.WorkFilter) ([]*types.BlockedIssue, error) {
+	filter.Status = types.StatusOpen
+	whereClauses, args := workFilterClauses(filter)
+
+	limitSQL := ""
+	if filter.Limit > 0 {
+		limitSQL = " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+	sortPolicy := filter.SortPolicy
+	if sortPolicy == "" {
+		sortPolicy = types.SortPolicyHybrid
+	}
+
+	// Same recursion as rebuildBlockedCache, with in_progress blockers
+	// treated as already closed (CI gates and blockers in linked workspaces
+	// stay unmet)
+	// #nosec G201 - safe SQL with controlled formatting
+	query := fmt.Sprintf(`
+		WITH RECURSIVE
+		  blocked_directly AS (
+		    SELECT DISTINCT d.issue_id
+		    FROM dependencies d
+		    JOIN issues blocker ON d.depends_on_id = blocker.id
+		    WHERE d.type = 'blocks'
+		      AND blocker.status IN ('open', 'blocked')
+		    UNION
+		    SELECT issue_id FROM gates WHERE state != 'success'
+		    UNION
+		    SELECT d.issue_id FROM dependencies d
+		    WHERE d.type = 'blocks' AND `+unresolvedExternalSQL("d")+`
+		  ),
+		  blocked_transitively AS (
+		    SELECT issue_id, 0 as depth
+		    FROM blocked_directly
+		    UNION ALL
+		    SELECT d.issue_id, bt.depth + 1
+		    FROM blocked_transitively bt
+		    JOIN dependencies d ON d.depends_on_id = bt.issue_id
+		    WHERE d.type = 'parent-child'
+		      AND bt.depth < 50
+		  )
+		SELECT i.id, i.content_hash, i.title, i.description, i.design, i.acceptance_criteria, i.notes,
+		       i.status, i.priority, i.issue_type, i.assignee, i.estimated_minutes,
+		       i.created_at, i.updated_at, i.closed_at, i.external_ref, i.source_repo, i.close_reason,
+		       i.deleted_at, i.deleted_by, i.delete_reason, i.original_type,
+		       i.sender, i.ephemeral, i.complexity, i.source, i.timebox_minutes
+		FROM issues i
+		WHERE %s
+		AND EXISTS (SELECT 1 FROM blocked_issues_cache WHERE issue_id = i.id)
+		AND i.id NOT IN (SELECT issue_id FROM blocked_transitively)
+		%s
+		%s
+	`, strings.Join(whereClauses, " AND "), buildOrderByClause(sortPolicy), limitSQL)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next ready work: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	issues, err := s.scanIssues(ctx, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	next := make([]*types.BlockedIssue, 0, len(issues))
+	for _, issue := range issues {
+		blockers, err := s.inProgressBlockers(ctx, issue.ID)
+		if err != nil {
+			return nil, err
+		}
+		next = append(next, &types.BlockedIssue{Issue: *issue, BlockedByCount: len(blockers), BlockedBy: blockers})
+	}
+	return next, nil
+}
+
+// inProgressBlockers returns the in_progress issues blocking issueID or one
+// of its ancestors
+func (s *SQLiteStorage) inProgressBlockers(ctx context.Context, issueID string) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		WITH RECURSIVE ancestors(id, depth) AS (
+		  SELECT ?, 0
+		  UNION
+		  SELECT d.depends_on_id, a.depth + 1
+		  FROM ancestors a
+		  JOIN dependencies d ON d.issue_id = a.id AND d.type = 'parent-child'
+		  WHERE a.depth < 50
+		)
+		SELECT DISTINCT d.depends_on_id
+		FROM ancestors a
+		JOIN dependencies d ON d.issue_id = a.id AND d.type = 'blocks'
+		JOIN issues blocker ON blocker.id = d.depends_on_id
+		WHERE blocker.status = 'in_progress'
+		ORDER BY d.depends_on_id
+	`, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blockers of %s: %w", issueID, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var blockers []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		blockers = append(blockers, id)
+	}
+	return blockers, rows.Err()
+}
@@ -252,6 +252,53 @@ func TestGetReadyWorkIgnoresRelatedDeps(t *testing.T) {
 	}
 }
 
+func TestGetReadyWorkSoftBlocks(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// P0 issue soft-blocked by a P2 issue: still ready, but sorts last
+	urgent := &types.Issue{Title: "Urgent", Status: types.StatusOpen, Priority: 0, IssueType: types.TypeTask}
+	first := &types.Issue{Title: "Preferably first", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	other := &types.Issue{Title: "Other", Status: types.StatusOpen, Priority: 3, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{urgent, first, other} {
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: urgent.ID, DependsOnID: first.ID, Type: types.DepSoftBlocks}, "test-user"); err != nil {
+		t.Fatal(err)
+	}
+
+	filter := types.WorkFilter{SortPolicy: types.SortPolicyPriority}
+	ready, err := store.GetReadyWork(ctx, filter)
+	if err != nil {
+		t.Fatalf("GetReadyWork failed: %v", err)
+	}
+	if len(ready) != 3 {
+		t.Fatalf("Expected 3 ready issues (soft deps don't gate), got %d", len(ready))
+	}
+	if ready[0].ID != first.ID || ready[2].ID != urgent.ID {
+		t.Errorf("order = %s %s %s, want soft-blocked %s last", ready[0].ID, ready[1].ID, ready[2].ID, urgent.ID)
+	}
+	if len(ready[2].SoftBlockedBy) != 1 || ready[2].SoftBlockedBy[0] != first.ID {
+		t.Errorf("SoftBlockedBy = %v, want [%s]", ready[2].SoftBlockedBy, first.ID)
+	}
+
+	// Once the soft blocker closes, normal priority order resumes
+	if err := store.CloseIssue(ctx, first.ID, "Done", "test-user"); err != nil {
+		t.Fatal(err)
+	}
+	ready, err = store.GetReadyWork(ctx, filter)
+	if err != nil {
+		t.Fatalf("GetReadyWork failed: %v", err)
+	}
+	if len(ready) != 2 || ready[0].ID != urgent.ID || len(ready[0].SoftBlockedBy) != 0 {
+		t.Errorf("after close: first = %s (soft blockers %v), want %s", ready[0].ID, ready[0].SoftBlockedBy, urgent.ID)
+	}
+}
+
 func TestGetBlockedIssues(t *testing.T) {
 	store, cleanup := setupTestDB(t)
 	defer cleanup()
@@ -1128,3 +1175,54 @@ func TestSortPolicyDefault(t *testing.T) {
 		t.Errorf("Expected P2 second, got P%d", ready[1].Priority)
 	}
 }
+
+func TestGetNextReadyWork(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	create := func(title string, status types.Status, assignee string, issueType types.IssueType) *types.Issue {
+		issue := &types.Issue{Title: title, Status: status, Priority: 2, IssueType: issueType, Assignee: assignee}
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatal(err)
+		}
+		return issue
+	}
+	depend := func(from, to *types.Issue, depType types.DependencyType) {
+		if err := store.AddDependency(ctx, &types.Dependency{IssueID: from.ID, DependsOnID: to.ID, Type: depType}, "test-user"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	active := create("In progress", types.StatusInProgress, "alice", types.TypeTask)
+	open := create("Open blocker", types.StatusOpen, "", types.TypeTask)
+	afterActive := create("After active", types.StatusOpen, "", types.TypeTask)
+	afterBoth := create("After active and open", types.StatusOpen, "", types.TypeTask)
+	epic := create("Epic after active", types.StatusOpen, "", types.TypeEpic)
+	child := create("Child of epic", types.StatusOpen, "", types.TypeTask)
+	carols := create("Carol's, after active", types.StatusOpen, "carol", types.TypeTask)
+	depend(afterActive, active, types.DepBlocks)
+	depend(afterBoth, active, types.DepBlocks)
+	depend(afterBoth, open, types.DepBlocks)
+	depend(epic, active, types.DepBlocks)
+	depend(child, epic, types.DepParentChild)
+	depend(carols, active, types.DepBlocks)
+
+	bob := "bob"
+	next, err := store.GetNextReadyWork(ctx, types.WorkFilter{AvailableTo: &bob})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string][]string)
+	for _, issue := range next {
+		got[issue.ID] = issue.BlockedBy
+	}
+	if len(got) != 3 {
+		t.Fatalf("expected 3 next issues, got %v", got)
+	}
+	for _, issue := range []*types.Issue{afterActive, epic, child} {
+		if blockers := got[issue.ID]; len(blockers) != 1 || blockers[0] != active.ID {
+			t.Errorf("%s (%s): blocked by %v, want [%s]", issue.ID, issue.Title, blockers, active.ID)
+		}
+	}
+}
@@ -1,7 +1,6 @@
 package sqlite
 
 import (
-	"bufio"
 	"context"
 	"database/sql"
 	"encoding/json"
@@ -12,6 +11,7 @@ import (
 	"time"
 
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/util"
 )
 
 // TryResurrectParent attempts to resurrect a deleted parent issue from JSONL history.
@@ -26,11 +26,14 @@ import (
 //   - error if resurrection failed for any other reason
 func (s *SQLiteStorage) TryResurrectParent(ctx context.Context, parentID string) (bool, error) {
 	// Get a connection for the entire resurrection operation
-	conn, err := s.db.Conn(ctx)
+	conn, err := s.writeConn(ctx)
 	if err != nil {
 		return false, fmt.Errorf("failed to get database connection: %w", err)
 	}
 	defer conn.Close()
+	if err := tagOperation(ctx, conn); err != nil {
+		return false, err
+	}
 	
 	return s.tryResurrectParentWithConn(ctx, conn, parentID)
 }
@@ -72,7 +75,7 @@ func (s *SQLiteStorage) tryResurrectParentWithConn(ctx context.Context, conn *sq
 	}
 	
 	// Create tombstone version of the parent
-	now := time.Now()
+	now := time.Now().UTC()
 	tombstone := &types.Issue{
 		ID:          parentIssue.ID,
 		ContentHash: parentIssue.ContentHash,
@@ -140,11 +143,7 @@ func (s *SQLiteStorage) findIssueInJSONL(issueID string) (*types.Issue, error) {
 	}
 	defer file.Close()
 	
-	scanner := bufio.NewScanner(file)
-	// Increase buffer size for large issues
-	const maxCapacity = 1024 * 1024 // 1MB
-	buf := make([]byte, maxCapacity)
-	scanner.Buffer(buf, maxCapacity)
+	scanner := util.NewJSONLScanner(file)
 	
 	lineNum := 0
 	var lastMatch *types.Issue
@@ -194,11 +193,14 @@ func (s *SQLiteStorage) findIssueInJSONL(issueID string) (*types.Issue, error) {
 //   - error if resurrection failed for any other reason
 func (s *SQLiteStorage) TryResurrectParentChain(ctx context.Context, childID string) (bool, error) {
 	// Get a connection for the entire chain resurrection
-	conn, err := s.db.Conn(ctx)
+	conn, err := s.writeConn(ctx)
 	if err != nil {
 		return false, fmt.Errorf("failed to get database connection: %w", err)
 	}
 	defer conn.Close()
+	if err := tagOperation(ctx, conn); err != nil {
+		return false, err
+	}
 	
 	return s.tryResurrectParentChainWithConn(ctx, conn, childID)
 }
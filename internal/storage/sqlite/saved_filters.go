@@ -0,0 +1,98 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/filterexpr"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// SaveFilter creates or replaces a named filter. The query is checked and
+// stored in its canonical form; replacing a filter keeps who created it
+// and when.
+func (s *SQLiteStorage) SaveFilter(ctx context.Context, filter *types.SavedFilter) error {
+	if err := types.ValidateFilterName(filter.Name); err != nil {
+		return err
+	}
+	expr, err := filterexpr.Parse(filter.Query)
+	if err != nil {
+		return err
+	}
+	filter.Query = expr.String()
+	now := time.Now().UTC()
+	if filter.CreatedAt.IsZero() {
+		filter.CreatedAt = now
+	}
+	if filter.UpdatedAt.IsZero() {
+		filter.UpdatedAt = now
+	}
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO saved_filters (name, query, description, created_by, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(name) DO UPDATE SET
+			query = excluded.query,
+			description = excluded.description,
+			updated_at = excluded.updated_at
+	`, filter.Name, filter.Query, filter.Description, filter.CreatedBy, filter.CreatedAt, filter.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save filter: %w", err)
+	}
+	return nil
+}
+
+// GetSavedFilter returns the named filter, or nil if there is none
+func (s *SQLiteStorage) GetSavedFilter(ctx context.Context, name string) (*types.SavedFilter, error) {
+	f := &types.SavedFilter{}
+	err := s.db.QueryRowContext(ctx, `
+		SELECT name, query, description, created_by, created_at, updated_at
+		FROM saved_filters WHERE name = ?
+	`, name).Scan(&f.Name, &f.Query, &f.Description, &f.CreatedBy, &f.CreatedAt, &f.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get filter: %w", err)
+	}
+	return f, nil
+}
+
+// ListSavedFilters returns every saved filter by name
+func (s *SQLiteStorage) ListSavedFilters(ctx context.Context) ([]*types.SavedFilter, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT name, query, description, created_by, created_at, updated_at
+		FROM saved_filters ORDER BY name
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query filters: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var filters []*types.SavedFilter
+	for rows.Next() {
+		f := &types.SavedFilter{}
+		if err := rows.Scan(&f.Name, &f.Query, &f.Description, &f.CreatedBy, &f.CreatedAt, &f.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan filter: %w", err)
+		}
+		filters = append(filters, f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating filters: %w", err)
+	}
+	return filters, nil
+}
+
+// DeleteSavedFilter removes a filter and reports whether there was one
+func (s *SQLiteStorage) DeleteSavedFilter(ctx context.Context, name string) (bool, error) {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM saved_filters WHERE name = ?`, name)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete filter: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
@@ -0,0 +1,95 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSavedFilters(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	backlog := &types.SavedFilter{Name: "my-backlog", Query: "status=open   priority<=P1", CreatedBy: "alice"}
+	if err := store.SaveFilter(ctx, backlog); err != nil {
+		t.Fatalf("SaveFilter: %v", err)
+	}
+	if err := store.SaveFilter(ctx, &types.SavedFilter{Name: "bugs", Query: "type=bug"}); err != nil {
+		t.Fatalf("SaveFilter: %v", err)
+	}
+	// Replacing keeps the creator
+	if err := store.SaveFilter(ctx, &types.SavedFilter{Name: "my-backlog", Query: "status=open label=backend", CreatedBy: "bob"}); err != nil {
+		t.Fatalf("SaveFilter (replace): %v", err)
+	}
+
+	got, err := store.GetSavedFilter(ctx, "my-backlog")
+	if err != nil || got == nil {
+		t.Fatalf("GetSavedFilter = %v, %v", got, err)
+	}
+	if got.Query != "status=open label=backend" || got.CreatedBy != "alice" {
+		t.Errorf("replaced filter = %+v, want the new query created by alice", got)
+	}
+	if missing, err := store.GetSavedFilter(ctx, "nope"); err != nil || missing != nil {
+		t.Errorf("GetSavedFilter(nope) = %v, %v; want nil", missing, err)
+	}
+
+	filters, err := store.ListSavedFilters(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(filters) != 2 || filters[0].Name != "bugs" {
+		t.Errorf("ListSavedFilters = %+v, want bugs then my-backlog", filters)
+	}
+
+	for _, bad := range []*types.SavedFilter{
+		{Name: "My Backlog", Query: "status=open"},
+		{Name: "ok", Query: "owner=alice"},
+	} {
+		if err := store.SaveFilter(ctx, bad); err == nil {
+			t.Errorf("SaveFilter(%+v) should fail", bad)
+		}
+	}
+
+	if deleted, err := store.DeleteSavedFilter(ctx, "bugs"); err != nil || !deleted {
+		t.Errorf("DeleteSavedFilter = %v, %v", deleted, err)
+	}
+	if deleted, _ := store.DeleteSavedFilter(ctx, "bugs"); deleted {
+		t.Error("deleting twice reported a deletion")
+	}
+}
+
+func TestGetFilteredStatistics(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	newIssue := func(title string, priority int) *types.Issue {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: priority, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue(%s): %v", title, err)
+		}
+		return issue
+	}
+	blocker := newIssue("Blocker", 3)
+	urgent := newIssue("Urgent", 1)
+	blocked := newIssue("Blocked urgent", 0)
+	done := newIssue("Done urgent", 1)
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: blocked.ID, DependsOnID: blocker.ID, Type: types.DepBlocks}, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.CloseIssue(ctx, done.ID, "fixed", "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	max := 1
+	stats, err := store.GetFilteredStatistics(ctx, types.IssueFilter{PriorityMax: &max})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.TotalIssues != 3 || stats.OpenIssues != 2 || stats.ClosedIssues != 1 ||
+		stats.BlockedIssues != 1 || stats.ReadyIssues != 1 || stats.ClosedByReason["fixed"] != 1 {
+		t.Errorf("stats for P0-P1 = %+v, want 3 issues: %s ready, %s blocked, %s closed", stats, urgent.ID, blocked.ID, done.ID)
+	}
+}
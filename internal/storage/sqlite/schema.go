@@ -51,8 +51,9 @@ CREATE TABLE IF NOT EXISTS dependencies (
     metadata TEXT DEFAULT '{}',    -- JSON blob for type-specific edge data
     thread_id TEXT DEFAULT '',     -- For efficient conversation threading queries
     PRIMARY KEY (issue_id, depends_on_id),
-    FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE,
-    FOREIGN KEY (depends_on_id) REFERENCES issues(id) ON DELETE CASCADE
+    -- depends_on_id may name an issue in a linked workspace (api/bd-42), so
+    -- it has no foreign key; see the external_dependencies migration
+    FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
 );
 
 CREATE INDEX IF NOT EXISTS idx_dependencies_issue ON dependencies(issue_id);
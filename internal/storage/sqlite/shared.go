@@ -0,0 +1,101 @@
+package sqlite
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// The functions in this file expose the ID generation and validation rules
+// to the other storage backends, so an issue gets the same ID and the same
+// errors whichever database holds it.
+
+// GenerateHashID creates the hash-based ID of a top-level issue
+func GenerateHashID(prefix, title, description, creator string, timestamp time.Time, length, nonce int) string {
+	return generateHashID(prefix, title, description, creator, timestamp, length, nonce)
+}
+
+// LoadAdaptiveConfig reads the adaptive ID length config keys, falling back
+// to the defaults for unset or unparseable values
+func LoadAdaptiveConfig(ctx context.Context, getConfig func(context.Context, string) (string, error)) AdaptiveIDConfig {
+	config := DefaultAdaptiveConfig()
+	if v, err := getConfig(ctx, "max_collision_prob"); err == nil && v != "" {
+		if prob, err := strconv.ParseFloat(v, 64); err == nil {
+			config.MaxCollisionProbability = prob
+		}
+	}
+	if v, err := getConfig(ctx, "min_hash_length"); err == nil && v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			config.MinLength = n
+		}
+	}
+	if v, err := getConfig(ctx, "max_hash_length"); err == nil && v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			config.MaxLength = n
+		}
+	}
+	return config
+}
+
+// AdaptiveLength returns the hash length to use for a database holding
+// numIssues top-level issues
+func AdaptiveLength(numIssues int, config AdaptiveIDConfig) int {
+	return computeAdaptiveLength(numIssues, config)
+}
+
+// ValidateFieldUpdate validates one value of an UpdateIssue call, allowing
+// the configured custom statuses
+func ValidateFieldUpdate(key string, value interface{}, customStatuses []string) error {
+	return validateFieldUpdateWithCustomStatuses(key, value, customStatuses)
+}
+
+// ValidateSpikeTimebox rejects updates that would leave a spike without a
+// timebox
+func ValidateSpikeTimebox(old *types.Issue, updates map[string]interface{}) error {
+	return validateSpikeTimebox(old, updates)
+}
+
+// AssigneeRoster returns the allowed assignees in strict integrity mode, or
+// nil when assignees are unrestricted
+func AssigneeRoster(ctx context.Context, getConfig func(context.Context, string) (string, error)) ([]string, error) {
+	return assigneeRoster(ctx, getConfig)
+}
+
+// ValidateAssigneeInRoster rejects assignees outside a non-empty roster
+func ValidateAssigneeInRoster(assignee string, roster []string) error {
+	return validateAssigneeInRoster(assignee, roster)
+}
+
+// IsAllowedUpdateField reports whether UpdateIssue accepts the field
+func IsAllowedUpdateField(key string) bool {
+	return allowedUpdateFields[key]
+}
+
+// ParseConfigList splits a comma-separated config value such as
+// status.custom into its trimmed, non-empty entries
+func ParseConfigList(value string) []string {
+	return parseConfigList(value)
+}
+
+// CheckIssue applies Check to each text field of issue
+func (l ContentLimits) CheckIssue(issue *types.Issue) error {
+	return l.checkIssue(issue)
+}
+
+// CheckUpdate applies Check to a text field in an update map
+func (l ContentLimits) CheckUpdate(key string, value interface{}) error {
+	return l.checkUpdate(key, value)
+}
+
+// ApplyContentUpdates applies the content fields of an UpdateIssue call to
+// issue, so its new content hash can be computed
+func ApplyContentUpdates(issue *types.Issue, updates map[string]interface{}) error {
+	return applyContentUpdates(issue, updates)
+}
+
+// UpdateEventType returns the event an UpdateIssue call records
+func UpdateEventType(oldIssue *types.Issue, updates map[string]interface{}) types.EventType {
+	return determineEventType(oldIssue, updates)
+}
@@ -0,0 +1,75 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSearchIssuesSort(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	est := func(n int) *int { return &n }
+	titles := map[string]string{}
+	for _, spec := range []struct {
+		title    string
+		priority int
+		estimate *int
+		assignee string
+	}{
+		{"alpha", 1, est(30), "zed"},
+		{"Bravo", 0, nil, ""},
+		{"charlie", 1, est(10), "amy"},
+		{"delta", 1, nil, "amy"},
+	} {
+		issue := &types.Issue{Title: spec.title, Status: types.StatusOpen, Priority: spec.priority,
+			IssueType: types.TypeTask, EstimatedMinutes: spec.estimate, Assignee: spec.assignee}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatal(err)
+		}
+		titles[issue.ID] = spec.title
+	}
+
+	order := func(spec string, limit int) string {
+		t.Helper()
+		keys, err := types.ParseSortKeys(spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		issues, err := store.SearchIssues(ctx, "", types.IssueFilter{Sort: keys, Limit: limit})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, issue := range issues {
+			got = append(got, titles[issue.ID])
+		}
+		return strings.Join(got, " ")
+	}
+
+	tests := []struct {
+		spec  string
+		limit int
+		want  string
+	}{
+		{"priority,estimate", 0, "Bravo charlie alpha delta"},
+		{"priority,-estimate", 0, "Bravo alpha charlie delta"}, // no estimate stays last
+		{"title", 0, "alpha Bravo charlie delta"},              // case-insensitive
+		{"assignee,-title", 0, "delta charlie alpha Bravo"},
+		{"-title", 2, "delta charlie"}, // limit applies after sorting
+	}
+	for _, tt := range tests {
+		if got := order(tt.spec, tt.limit); got != tt.want {
+			t.Errorf("sort %q limit %d = %q, want %q", tt.spec, tt.limit, got, tt.want)
+		}
+	}
+
+	keys, _ := types.ParseSortKeys("title")
+	if _, err := store.SearchIssues(ctx, "", types.IssueFilter{Sort: keys, After: &types.PageCursor{ID: "x"}}); err == nil {
+		t.Error("expected an error combining a sort order with a page cursor")
+	}
+}
@@ -0,0 +1,73 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSourcePersistence(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	ref := "sentry-4821"
+	reported := &types.Issue{Title: "crash on save", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeBug, Source: "sentry", ExternalRef: &ref}
+	if err := store.CreateIssue(ctx, reported, "intake"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	local := &types.Issue{Title: "refactor save", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, local, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	byRef, err := store.GetIssueByExternalRef(ctx, ref)
+	if err != nil {
+		t.Fatalf("GetIssueByExternalRef failed: %v", err)
+	}
+	if byRef == nil || byRef.Source != "sentry" {
+		t.Errorf("expected source sentry by external ref, got %+v", byRef)
+	}
+
+	ready, err := store.GetReadyWork(ctx, types.WorkFilter{Status: types.StatusOpen})
+	if err != nil {
+		t.Fatalf("GetReadyWork failed: %v", err)
+	}
+	for _, issue := range ready {
+		want := ""
+		if issue.ID == reported.ID {
+			want = "sentry"
+		}
+		if issue.Source != want {
+			t.Errorf("%s source = %q in ready work, want %q", issue.ID, issue.Source, want)
+		}
+	}
+
+	if err := store.UpdateIssue(ctx, local.ID, map[string]interface{}{"source": "form"}, "test-user"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+
+	// Reopening runs the migrations again, which rebuild the issues table
+	path := store.Path()
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	store, err = New(ctx, path)
+	if err != nil {
+		t.Fatalf("failed to reopen storage: %v", err)
+	}
+	defer store.Close()
+
+	found, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	sources := make(map[string]string)
+	for _, issue := range found {
+		sources[issue.ID] = issue.Source
+	}
+	if sources[reported.ID] != "sentry" || sources[local.ID] != "form" {
+		t.Errorf("sources after reopen = %v, want %s=sentry %s=form", sources, reported.ID, local.ID)
+	}
+}
@@ -0,0 +1,131 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ElapsedTimebox is an in-progress spike whose timebox has run out and
+// hasn't been reported yet
+type ElapsedTimebox struct {
+	Issue     *types.Issue
+	StartedAt time.Time
+	// StartedBy is who last moved the spike to in_progress, if recorded
+	StartedBy string
+}
+
+// timeboxStartSQL is when each issue last went in_progress
+const timeboxStartSQL = `
+	SELECT issue_id, MAX(at) AS at FROM status_transitions
+	WHERE to_status = 'in_progress'
+	GROUP BY issue_id`
+
+// GetTimeboxStart returns when an issue last went in_progress, which is
+// when a spike's timebox starts, or nil if it never has
+func (s *SQLiteStorage) GetTimeboxStart(ctx context.Context, issueID string) (*time.Time, error) {
+	var at sql.NullString
+	err := s.db.QueryRowContext(ctx, `
+		SELECT MAX(at) FROM status_transitions WHERE issue_id = ? AND to_status = 'in_progress'
+	`, issueID).Scan(&at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get timebox start: %w", err)
+	}
+	if !at.Valid {
+		return nil, nil
+	}
+	started, err := parseTransitionTime(at.String)
+	if err != nil {
+		return nil, err
+	}
+	return &started, nil
+}
+
+// GetElapsedTimeboxes returns the in-progress spikes whose timebox ran out
+// by now and that have no timebox_elapsed event since it did, oldest first.
+// Restarting a spike restarts its timebox, and extending the timebox
+// reports it again at the new end.
+func (s *SQLiteStorage) GetElapsedTimeboxes(ctx context.Context, now time.Time) ([]*ElapsedTimebox, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT i.id, start.at,
+		       COALESCE((SELECT e.actor FROM events e
+		                 WHERE e.issue_id = i.id AND e.event_type = ?
+		                   AND json_valid(e.new_value) AND json_extract(e.new_value, '$.status') = 'in_progress'
+		                 ORDER BY e.id DESC LIMIT 1), '')
+		FROM issues i
+		JOIN (`+timeboxStartSQL+`) start ON start.issue_id = i.id
+		WHERE i.issue_type = ? AND i.status = ? AND i.timebox_minutes > 0
+		  AND datetime(start.at, '+' || i.timebox_minutes || ' minutes') <= datetime(?)
+		  AND NOT EXISTS (
+		      SELECT 1 FROM events e
+		      WHERE e.issue_id = i.id AND e.event_type = ?
+		        AND datetime(e.created_at) >= datetime(start.at, '+' || i.timebox_minutes || ' minutes'))
+		ORDER BY start.at ASC, i.id ASC
+	`, types.EventStatusChanged, types.TypeSpike, types.StatusInProgress,
+		now.UTC().Format("2006-01-02 15:04:05"), types.EventTimeboxElapsed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query timeboxes: %w", err)
+	}
+
+	type row struct {
+		id, startedAt, startedBy string
+	}
+	var found []row
+	for rows.Next() {
+		var r row
+		if err := rows.Scan(&r.id, &r.startedAt, &r.startedBy); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("failed to scan timebox: %w", err)
+		}
+		found = append(found, r)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read timeboxes: %w", err)
+	}
+
+	elapsed := make([]*ElapsedTimebox, 0, len(found))
+	for _, r := range found {
+		issue, err := s.GetIssue(ctx, r.id)
+		if err != nil {
+			return nil, err
+		}
+		if issue == nil {
+			continue
+		}
+		started, err := parseTransitionTime(r.startedAt)
+		if err != nil {
+			return nil, err
+		}
+		elapsed = append(elapsed, &ElapsedTimebox{Issue: issue, StartedAt: started, StartedBy: r.startedBy})
+	}
+	return elapsed, nil
+}
+
+// RecordTimeboxElapsed records that a spike's timebox ran out, so that
+// GetElapsedTimeboxes stops returning it and watchers hear about it. The
+// event is dated now, so it must only be recorded once the timebox is over.
+func (s *SQLiteStorage) RecordTimeboxElapsed(ctx context.Context, issueID, actor, comment string) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, comment)
+		VALUES (?, ?, ?, ?)
+	`, issueID, types.EventTimeboxElapsed, actor, comment)
+	if err != nil {
+		return fmt.Errorf("failed to record elapsed timebox: %w", err)
+	}
+	return nil
+}
+
+// parseTransitionTime reads a status_transitions time, which the triggers
+// write with datetime() in UTC
+func parseTransitionTime(value string) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02 15:04:05", time.RFC3339Nano, "2006-01-02 15:04:05.999999999-07:00"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid transition time %q", value)
+}
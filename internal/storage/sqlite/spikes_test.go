@@ -0,0 +1,107 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSpikeTimebox(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	noTimebox := &types.Issue{Title: "Try caching", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeSpike}
+	if err := store.CreateIssue(ctx, noTimebox, "alice"); err == nil || !strings.Contains(err.Error(), "timebox") {
+		t.Fatalf("creating a spike without a timebox: err = %v, want a timebox error", err)
+	}
+
+	minutes := 2880
+	spike := &types.Issue{Title: "Try caching", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeSpike, TimeboxMinutes: &minutes}
+	if err := store.CreateIssue(ctx, spike, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	got, err := store.GetIssue(ctx, spike.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.TimeboxMinutes == nil || *got.TimeboxMinutes != minutes {
+		t.Fatalf("TimeboxMinutes = %v, want %d", got.TimeboxMinutes, minutes)
+	}
+
+	if err := store.UpdateIssue(ctx, spike.ID, map[string]interface{}{"timebox_minutes": nil}, "alice"); err == nil {
+		t.Error("clearing a spike's timebox should fail")
+	}
+	if err := store.UpdateIssue(ctx, spike.ID, map[string]interface{}{"timebox_minutes": -5}, "alice"); err == nil {
+		t.Error("a negative timebox should fail")
+	}
+	task := &types.Issue{Title: "Plain task", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, task, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.UpdateIssue(ctx, task.ID, map[string]interface{}{"issue_type": string(types.TypeSpike)}, "alice"); err == nil {
+		t.Error("turning an issue into a spike without a timebox should fail")
+	}
+
+	// Not started: the clock isn't running
+	if start, err := store.GetTimeboxStart(ctx, spike.ID); err != nil || start != nil {
+		t.Fatalf("GetTimeboxStart before starting = %v, %v; want nil", start, err)
+	}
+	if err := store.UpdateIssue(ctx, spike.ID, map[string]interface{}{"status": string(types.StatusInProgress)}, "bob"); err != nil {
+		t.Fatal(err)
+	}
+	start, err := store.GetTimeboxStart(ctx, spike.ID)
+	if err != nil || start == nil {
+		t.Fatalf("GetTimeboxStart = %v, %v", start, err)
+	}
+
+	elapsed, err := store.GetElapsedTimeboxes(ctx, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(elapsed) != 0 {
+		t.Fatalf("%d timeboxes elapsed right after starting, want 0", len(elapsed))
+	}
+
+	elapsed, err = store.GetElapsedTimeboxes(ctx, time.Now().Add(49*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(elapsed) != 1 || elapsed[0].Issue.ID != spike.ID {
+		t.Fatalf("GetElapsedTimeboxes = %v, want %s", elapsed, spike.ID)
+	}
+	if elapsed[0].StartedBy != "bob" {
+		t.Errorf("StartedBy = %q, want bob", elapsed[0].StartedBy)
+	}
+
+	// Started three days ago: elapsed now, and reported once
+	if _, err := store.db.ExecContext(ctx, `UPDATE status_transitions SET at = datetime('now', '-3 days') WHERE issue_id = ?`, spike.ID); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed, err = store.GetElapsedTimeboxes(ctx, time.Now()); err != nil || len(elapsed) != 1 {
+		t.Fatalf("GetElapsedTimeboxes = %v, %v; want %s", elapsed, err, spike.ID)
+	}
+	if tb := types.NewTimebox(minutes, &elapsed[0].StartedAt, time.Now()); !tb.Elapsed {
+		t.Errorf("NewTimebox = %+v, want elapsed", tb)
+	}
+	if err := store.RecordTimeboxElapsed(ctx, spike.ID, "daemon", "2d timebox elapsed"); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed, err = store.GetElapsedTimeboxes(ctx, time.Now()); err != nil || len(elapsed) != 0 {
+		t.Fatalf("after recording: GetElapsedTimeboxes = %v, %v; want none", elapsed, err)
+	}
+
+	// Extending the timebox reports it again at the new end
+	if err := store.UpdateIssue(ctx, spike.ID, map[string]interface{}{"timebox_minutes": 4 * 24 * 60}, "bob"); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed, err = store.GetElapsedTimeboxes(ctx, time.Now()); err != nil || len(elapsed) != 0 {
+		t.Fatalf("after extending: GetElapsedTimeboxes = %v, %v; want none", elapsed, err)
+	}
+	if elapsed, err = store.GetElapsedTimeboxes(ctx, time.Now().Add(25*time.Hour)); err != nil || len(elapsed) != 1 {
+		t.Fatalf("past the new end: GetElapsedTimeboxes = %v, %v; want %s", elapsed, err, spike.ID)
+	}
+}
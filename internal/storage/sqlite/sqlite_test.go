@@ -77,6 +77,27 @@ func TestCreateIssue(t *testing.T) {
 	}
 }
 
+// TestNewPathWithURICharacters checks '#' and '?' in the database path don't
+// truncate it (t.TempDir names for fuzz seeds contain '#')
+func TestNewPathWithURICharacters(t *testing.T) {
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), "a#b?c%d")
+	dbPath := filepath.Join(dir, "beads.db")
+
+	store, err := New(ctx, dbPath)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer store.Close()
+	if err := store.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+
+	if _, err := os.Stat(dbPath); err != nil {
+		t.Errorf("database not created at %s: %v", dbPath, err)
+	}
+}
+
 func TestCreateIssueValidation(t *testing.T) {
 	store, cleanup := setupTestDB(t)
 	defer cleanup()
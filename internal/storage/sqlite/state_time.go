@@ -0,0 +1,300 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// statusChange is one point of an issue's status timeline
+type statusChange struct {
+	at     time.Time
+	status types.Status
+}
+
+// blockingInterval is a span during which issueID had a blocks dependency
+// on blockerID. An open-ended interval has a zero until.
+type blockingInterval struct {
+	issueID   string
+	blockerID string
+	from      time.Time
+	until     time.Time
+}
+
+// GetStateTimes reconstructs, for every issue, the cumulative time spent
+// ready, blocked and in progress up to now, plus the blocked time each issue
+// caused as a blocker. Status history comes from the events table; issues
+// without status events are assumed to have been in their current status
+// since creation (or open until closed_at). Blocking comes from current
+// blocks dependencies and from added/removed dependency events. Time before
+// pruned events (PruneEvents) comes from their summaries.
+func (s *SQLiteStorage) GetStateTimes(ctx context.Context, now time.Time) (map[string]*types.StateTime, error) {
+	timelines, err := s.statusTimelines(ctx)
+	if err != nil {
+		return nil, err
+	}
+	intervals, err := s.blockingIntervals(ctx)
+	if err != nil {
+		return nil, err
+	}
+	summaries, err := s.eventSummaries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byIssue := make(map[string][]blockingInterval)
+	for _, iv := range intervals {
+		if _, ok := timelines[iv.blockerID]; ok {
+			byIssue[iv.issueID] = append(byIssue[iv.issueID], iv)
+		}
+	}
+
+	result := make(map[string]*types.StateTime, len(timelines))
+	for id := range timelines {
+		result[id] = &types.StateTime{}
+		if sum, ok := summaries[id]; ok {
+			*result[id] = sum.hours
+		}
+	}
+	for id, timeline := range timelines {
+		accumulateStateTime(id, timeline, byIssue[id], timelines, now, result)
+	}
+	return result, nil
+}
+
+// accumulateStateTime walks one issue's history segment by segment, adding
+// each segment to the issue's ready/blocked/in-progress time and, when it was
+// blocked by dependencies, to every open blocker's blocking time
+func accumulateStateTime(id string, timeline []statusChange, intervals []blockingInterval, timelines map[string][]statusChange, now time.Time, result map[string]*types.StateTime) {
+	start := timeline[0].at
+	points := []time.Time{now}
+	for _, c := range timeline {
+		points = append(points, c.at)
+	}
+	for _, iv := range intervals {
+		points = append(points, iv.from)
+		if !iv.until.IsZero() {
+			points = append(points, iv.until)
+		}
+		for _, c := range timelines[iv.blockerID] {
+			points = append(points, c.at)
+		}
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i].Before(points[j]) })
+
+	for i := 0; i+1 < len(points); i++ {
+		from, until := points[i], points[i+1]
+		if from.Before(start) || !until.After(from) || until.After(now) {
+			continue
+		}
+		hours := until.Sub(from).Hours()
+		st := result[id]
+		switch statusAt(timeline, from) {
+		case types.StatusInProgress:
+			st.InProgressHours += hours
+		case types.StatusBlocked:
+			st.BlockedHours += hours
+		case types.StatusOpen:
+			blockers := openBlockersAt(intervals, timelines, from)
+			if len(blockers) == 0 {
+				st.ReadyHours += hours
+				continue
+			}
+			st.BlockedHours += hours
+			for _, blocker := range blockers {
+				result[blocker].BlockingHours += hours
+			}
+		}
+	}
+}
+
+// openBlockersAt returns the blockers of intervals that existed at t and
+// were still in a blocking status then
+func openBlockersAt(intervals []blockingInterval, timelines map[string][]statusChange, t time.Time) []string {
+	var blockers []string
+	for _, iv := range intervals {
+		if iv.from.After(t) || (!iv.until.IsZero() && !iv.until.After(t)) {
+			continue
+		}
+		if isBlockingStatus(statusAt(timelines[iv.blockerID], t)) {
+			blockers = append(blockers, iv.blockerID)
+		}
+	}
+	return blockers
+}
+
+// statusAt returns the status in effect at t, or "" before the first change
+func statusAt(timeline []statusChange, t time.Time) types.Status {
+	var status types.Status
+	for _, c := range timeline {
+		if c.at.After(t) {
+			break
+		}
+		status = c.status
+	}
+	return status
+}
+
+// isBlockingStatus matches the blocked-issues view: only these statuses
+// hold up dependents
+func isBlockingStatus(status types.Status) bool {
+	return status == types.StatusOpen || status == types.StatusInProgress || status == types.StatusBlocked
+}
+
+// statusTimelines builds the status history of every non-deleted issue. An
+// issue whose early events were pruned starts at the cutoff in the status
+// its summary recorded.
+func (s *SQLiteStorage) statusTimelines(ctx context.Context) (map[string][]statusChange, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, status, created_at, closed_at FROM issues WHERE status != 'tombstone'
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query issues: %w", err)
+	}
+	timelines := make(map[string][]statusChange)
+	current := make(map[string]types.Status)
+	closedAt := make(map[string]time.Time)
+	for rows.Next() {
+		var id, status string
+		var createdAt time.Time
+		var closed sql.NullTime
+		if err := rows.Scan(&id, &status, &createdAt, &closed); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("failed to scan issue: %w", err)
+		}
+		timelines[id] = []statusChange{{at: createdAt, status: types.StatusOpen}}
+		current[id] = types.Status(status)
+		if closed.Valid {
+			closedAt[id] = closed.Time
+		}
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	summaries, err := s.eventSummaries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	hasEvents := make(map[string]bool)
+	for id, sum := range summaries {
+		if _, ok := timelines[id]; ok {
+			timelines[id] = []statusChange{{at: sum.prunedBefore, status: sum.status}}
+			hasEvents[id] = true
+		}
+	}
+
+	eventRows, err := s.db.QueryContext(ctx, `
+		SELECT issue_id, event_type, new_value, created_at
+		FROM events
+		WHERE event_type IN (?, ?, ?)
+		ORDER BY created_at ASC, id ASC
+	`, types.EventStatusChanged, types.EventClosed, types.EventReopened)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query status events: %w", err)
+	}
+	defer func() { _ = eventRows.Close() }()
+
+	for eventRows.Next() {
+		var issueID, eventType string
+		var newValue sql.NullString
+		var createdAt time.Time
+		if err := eventRows.Scan(&issueID, &eventType, &newValue, &createdAt); err != nil {
+			return nil, fmt.Errorf("failed to scan status event: %w", err)
+		}
+		if _, ok := timelines[issueID]; !ok {
+			continue
+		}
+		status := types.StatusClosed
+		if types.EventType(eventType) != types.EventClosed {
+			status = statusFromEventValue(newValue)
+		}
+		if status == "" {
+			continue
+		}
+		hasEvents[issueID] = true
+		timelines[issueID] = append(timelines[issueID], statusChange{at: createdAt, status: status})
+	}
+	if err := eventRows.Err(); err != nil {
+		return nil, err
+	}
+
+	for id, timeline := range timelines {
+		if hasEvents[id] || current[id] == types.StatusOpen {
+			continue
+		}
+		if at, ok := closedAt[id]; ok && current[id] == types.StatusClosed {
+			timelines[id] = append(timeline, statusChange{at: at, status: types.StatusClosed})
+		} else {
+			timelines[id] = []statusChange{{at: timeline[0].at, status: current[id]}}
+		}
+	}
+	return timelines, nil
+}
+
+// blockingIntervals returns when each blocks dependency existed: current
+// dependencies from their created_at on, removed ones from the events that
+// added and removed them
+func (s *SQLiteStorage) blockingIntervals(ctx context.Context) ([]blockingInterval, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT issue_id, depends_on_id, created_at FROM dependencies WHERE type = ?
+	`, types.DepBlocks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dependencies: %w", err)
+	}
+	var intervals []blockingInterval
+	for rows.Next() {
+		var iv blockingInterval
+		if err := rows.Scan(&iv.issueID, &iv.blockerID, &iv.from); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("failed to scan dependency: %w", err)
+		}
+		intervals = append(intervals, iv)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	eventRows, err := s.db.QueryContext(ctx, `
+		SELECT issue_id, event_type, COALESCE(comment, ''), created_at
+		FROM events
+		WHERE event_type IN (?, ?)
+		ORDER BY created_at ASC, id ASC
+	`, types.EventDependencyAdded, types.EventDependencyRemoved)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dependency events: %w", err)
+	}
+	defer func() { _ = eventRows.Close() }()
+
+	// Comments are "Added dependency: <issue> <type> <target>" and
+	// "Removed dependency on <target>"
+	added := make(map[[2]string]time.Time)
+	for eventRows.Next() {
+		var issueID, eventType, comment string
+		var createdAt time.Time
+		if err := eventRows.Scan(&issueID, &eventType, &comment, &createdAt); err != nil {
+			return nil, fmt.Errorf("failed to scan dependency event: %w", err)
+		}
+		if types.EventType(eventType) == types.EventDependencyAdded {
+			fields := strings.Fields(strings.TrimPrefix(comment, "Added dependency:"))
+			if len(fields) == 3 && fields[1] == string(types.DepBlocks) {
+				added[[2]string{fields[0], fields[2]}] = createdAt
+			}
+			continue
+		}
+		target := strings.TrimSpace(strings.TrimPrefix(comment, "Removed dependency on"))
+		key := [2]string{issueID, target}
+		if from, ok := added[key]; ok {
+			intervals = append(intervals, blockingInterval{issueID: issueID, blockerID: target, from: from, until: createdAt})
+			delete(added, key)
+		}
+	}
+	return intervals, eventRows.Err()
+}
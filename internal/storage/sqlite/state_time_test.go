@@ -0,0 +1,96 @@
+package sqlite
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestGetStateTimes(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	newIssue := func(title string, status types.Status) *types.Issue {
+		issue := &types.Issue{Title: title, Status: status, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue(%s): %v", title, err)
+		}
+		return issue
+	}
+	blocker := newIssue("Blocker", types.StatusOpen)
+	worker := newIssue("Worker", types.StatusOpen)
+	imported := newIssue("Imported blocked", types.StatusBlocked)
+	brief := newIssue("Briefly blocked", types.StatusOpen)
+
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: worker.ID, DependsOnID: blocker.ID, Type: types.DepBlocks}, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: brief.ID, DependsOnID: blocker.ID, Type: types.DepBlocks}, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.RemoveDependency(ctx, brief.ID, blocker.ID, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.CloseIssue(ctx, blocker.ID, "done", "test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.UpdateIssue(ctx, worker.ID, map[string]interface{}{"status": string(types.StatusInProgress)}, "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Rewrite the timestamps into a known history:
+	//   t0      everything created
+	//   t0+1h   brief blocked by blocker (removed at t0+3h)
+	//   t0+2h   worker blocked by blocker
+	//   t0+10h  blocker closed
+	//   t0+12h  worker started
+	//   t0+15h  now
+	t0 := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	at := func(h int) time.Time { return t0.Add(time.Duration(h) * time.Hour) }
+	exec := func(query string, args ...interface{}) {
+		t.Helper()
+		if _, err := store.db.ExecContext(ctx, query, args...); err != nil {
+			t.Fatalf("%s: %v", query, err)
+		}
+	}
+	exec(`UPDATE issues SET created_at = ?`, t0)
+	exec(`UPDATE issues SET closed_at = ? WHERE id = ?`, at(10), blocker.ID)
+	exec(`UPDATE dependencies SET created_at = ? WHERE issue_id = ?`, at(2), worker.ID)
+	exec(`UPDATE events SET created_at = ? WHERE issue_id = ? AND event_type = ?`, at(1), brief.ID, types.EventDependencyAdded)
+	exec(`UPDATE events SET created_at = ? WHERE issue_id = ? AND event_type = ?`, at(3), brief.ID, types.EventDependencyRemoved)
+	exec(`UPDATE events SET created_at = ? WHERE issue_id = ? AND event_type = ?`, at(2), worker.ID, types.EventDependencyAdded)
+	exec(`UPDATE events SET created_at = ? WHERE issue_id = ? AND event_type = ?`, at(10), blocker.ID, types.EventClosed)
+	exec(`UPDATE events SET created_at = ? WHERE issue_id = ? AND event_type = ?`, at(12), worker.ID, types.EventStatusChanged)
+
+	times, err := store.GetStateTimes(ctx, at(15))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		id                                   string
+		ready, blocked, inProgress, blocking float64
+	}{
+		{blocker.ID, 10, 0, 0, 10},
+		{worker.ID, 4, 8, 3, 0},
+		{imported.ID, 0, 15, 0, 0},
+		{brief.ID, 13, 2, 0, 0},
+	}
+	near := func(a, b float64) bool { return math.Abs(a-b) < 0.01 }
+	for _, tt := range tests {
+		got := times[tt.id]
+		if got == nil {
+			t.Errorf("%s: no state times", tt.id)
+			continue
+		}
+		if !near(got.ReadyHours, tt.ready) || !near(got.BlockedHours, tt.blocked) ||
+			!near(got.InProgressHours, tt.inProgress) || !near(got.BlockingHours, tt.blocking) {
+			t.Errorf("%s: got %+v, want ready %.0f blocked %.0f in progress %.0f blocking %.0f",
+				tt.id, *got, tt.ready, tt.blocked, tt.inProgress, tt.blocking)
+		}
+	}
+}
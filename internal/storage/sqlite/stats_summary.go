@@ -0,0 +1,154 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// activityDays is how many days of activity statistics report, today included
+const activityDays = 7
+
+// Each pair reads the same rows from the summary tables (maintained by the
+// stats_summaries migration's triggers) or computes them from scratch
+var (
+	summaryIssueCounts = `SELECT status, priority, issues, lead_hours, lead_issues FROM stats_issue_counts`
+	exactIssueCounts   = `
+		SELECT status, priority, COUNT(*),
+		       COALESCE(SUM((julianday(closed_at) - julianday(created_at)) * 24), 0),
+		       COUNT((julianday(closed_at) - julianday(created_at)) * 24)
+		FROM issues GROUP BY status, priority`
+
+	summaryLabelCounts = `SELECT label, status, issues FROM stats_label_counts`
+	exactLabelCounts   = `
+		SELECT l.label, i.status, COUNT(*)
+		FROM labels l JOIN issues i ON i.id = l.issue_id
+		GROUP BY l.label, i.status`
+
+	summaryActivity = `SELECT day, event_type, events FROM stats_daily_activity WHERE day >= ?`
+	exactActivity   = `
+		SELECT day, event_type, COUNT(*) FROM (
+			SELECT COALESCE(date(created_at), date('now')) AS day, event_type FROM events
+		) WHERE day >= ? GROUP BY day, event_type`
+)
+
+// summaryStatistics fills the issue counts, average lead time, open issues
+// by priority and label, and recent activity
+func (s *SQLiteStorage) summaryStatistics(ctx context.Context, stats *types.Statistics, exact bool) error {
+	issueQuery, labelQuery, activityQuery := summaryIssueCounts, summaryLabelCounts, summaryActivity
+	if exact {
+		issueQuery, labelQuery, activityQuery = exactIssueCounts, exactLabelCounts, exactActivity
+	}
+
+	rows, err := s.db.QueryContext(ctx, issueQuery)
+	if err != nil {
+		return fmt.Errorf("failed to get issue counts: %w", err)
+	}
+	var leadHours float64
+	var leadIssues int
+	for rows.Next() {
+		var status string
+		var priority, issues, lead int
+		var hours float64
+		if err := rows.Scan(&status, &priority, &issues, &hours, &lead); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("failed to scan issue counts: %w", err)
+		}
+		leadHours += hours
+		leadIssues += lead
+		if issues == 0 {
+			continue
+		}
+		switch types.Status(status) {
+		case types.StatusTombstone:
+			stats.TombstoneIssues += issues
+			continue
+		case types.StatusOpen:
+			stats.OpenIssues += issues
+		case types.StatusInProgress:
+			stats.InProgressIssues += issues
+		case types.StatusResolved:
+			stats.ResolvedIssues += issues
+		case types.StatusClosed:
+			stats.ClosedIssues += issues
+		}
+		stats.TotalIssues += issues
+		if types.Status(status) != types.StatusClosed {
+			if stats.OpenByPriority == nil {
+				stats.OpenByPriority = make(map[string]int)
+			}
+			stats.OpenByPriority[fmt.Sprintf("P%d", priority)] += issues
+		}
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read issue counts: %w", err)
+	}
+	if leadIssues > 0 {
+		stats.AverageLeadTime = leadHours / float64(leadIssues)
+	}
+
+	rows, err = s.db.QueryContext(ctx, labelQuery)
+	if err != nil {
+		return fmt.Errorf("failed to get label counts: %w", err)
+	}
+	for rows.Next() {
+		var label, status string
+		var issues int
+		if err := rows.Scan(&label, &status, &issues); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("failed to scan label counts: %w", err)
+		}
+		if issues == 0 || status == string(types.StatusClosed) || status == string(types.StatusTombstone) {
+			continue
+		}
+		if stats.OpenByLabel == nil {
+			stats.OpenByLabel = make(map[string]int)
+		}
+		stats.OpenByLabel[label] += issues
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read label counts: %w", err)
+	}
+
+	since := time.Now().UTC().AddDate(0, 0, -(activityDays - 1)).Format("2006-01-02")
+	rows, err = s.db.QueryContext(ctx, activityQuery, since)
+	if err != nil {
+		return fmt.Errorf("failed to get daily activity: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	byDay := make(map[string]*types.DailyActivity)
+	for rows.Next() {
+		var day, eventType string
+		var events int
+		if err := rows.Scan(&day, &eventType, &events); err != nil {
+			return fmt.Errorf("failed to scan daily activity: %w", err)
+		}
+		a := byDay[day]
+		if a == nil {
+			a = &types.DailyActivity{Date: day}
+			byDay[day] = a
+		}
+		a.Events += events
+		switch types.EventType(eventType) {
+		case types.EventCreated:
+			a.Created += events
+		case types.EventClosed:
+			a.Closed += events
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read daily activity: %w", err)
+	}
+	for _, a := range byDay {
+		stats.RecentActivity = append(stats.RecentActivity, *a)
+	}
+	sort.Slice(stats.RecentActivity, func(i, j int) bool {
+		return stats.RecentActivity[i].Date < stats.RecentActivity[j].Date
+	})
+	return nil
+}
@@ -0,0 +1,122 @@
+package sqlite
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite/migrations"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestStatsSummariesMatchExact(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	create := func(title string, priority int, labels ...string) *types.Issue {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: priority, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue(%s): %v", title, err)
+		}
+		for _, label := range labels {
+			if err := store.AddLabel(ctx, issue.ID, label, "test"); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return issue
+	}
+	// Daily activity keeps the events of deleted issues, which the exact
+	// count no longer sees
+	check := func(step string, compareActivity bool) *types.Statistics {
+		t.Helper()
+		summary, err := store.GetStatistics(ctx)
+		if err != nil {
+			t.Fatalf("%s: GetStatistics: %v", step, err)
+		}
+		exact, err := store.GetExactStatistics(ctx)
+		if err != nil {
+			t.Fatalf("%s: GetExactStatistics: %v", step, err)
+		}
+		if math.Abs(summary.AverageLeadTime-exact.AverageLeadTime) > 1e-6 {
+			t.Errorf("%s: lead time %v, exact %v", step, summary.AverageLeadTime, exact.AverageLeadTime)
+		}
+		summary.AverageLeadTime, exact.AverageLeadTime = 0, 0
+		activity := summary.RecentActivity
+		if !compareActivity {
+			summary.RecentActivity, exact.RecentActivity = nil, nil
+		}
+		if !reflect.DeepEqual(summary, exact) {
+			t.Errorf("%s: summary stats\n%+v\nexact\n%+v", step, summary, exact)
+		}
+		summary.RecentActivity = activity
+		return summary
+	}
+
+	a := create("A", 0, "backend", "api")
+	b := create("B", 1, "backend")
+	c := create("C", 2, "ui")
+	d := create("D", 3)
+	check("created", true)
+
+	if err := store.UpdateIssue(ctx, a.ID, map[string]interface{}{"status": string(types.StatusInProgress), "priority": 1}, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.CloseIssue(ctx, b.ID, "fixed", "test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.RemoveLabel(ctx, a.ID, "api", "test"); err != nil {
+		t.Fatal(err)
+	}
+	check("updated", true)
+
+	if err := store.UpdateIssueID(ctx, c.ID, c.ID+"x", c, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.CreateTombstone(ctx, d.ID, "test", "dup"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.DeleteIssue(ctx, a.ID); err != nil {
+		t.Fatal(err)
+	}
+	// A raw delete cascades to the labels after the issue row is gone
+	if _, err := store.db.ExecContext(ctx, `DELETE FROM issues WHERE id = ?`, c.ID+"x"); err != nil {
+		t.Fatal(err)
+	}
+	stats := check("deleted", false)
+
+	if stats.TotalIssues != 1 || stats.ClosedIssues != 1 || stats.TombstoneIssues != 1 || len(stats.OpenByLabel) != 0 {
+		t.Errorf("final stats = %+v, want only the closed issue and a tombstone", stats)
+	}
+	today := time.Now().UTC().Format("2006-01-02")
+	if len(stats.RecentActivity) != 1 || stats.RecentActivity[0].Date != today ||
+		stats.RecentActivity[0].Created != 4 || stats.RecentActivity[0].Closed != 1 {
+		t.Errorf("activity = %+v, want today's 4 created and 1 closed", stats.RecentActivity)
+	}
+
+	// Pruning events keeps the days they were counted in
+	if _, err := store.db.ExecContext(ctx, `DELETE FROM events`); err != nil {
+		t.Fatal(err)
+	}
+	summary, err := store.GetStatistics(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(summary.RecentActivity, stats.RecentActivity) {
+		t.Errorf("activity after deleting events = %+v, want %+v", summary.RecentActivity, stats.RecentActivity)
+	}
+
+	// An existing database gets its summaries filled by the migration
+	for _, table := range []string{"stats_issue_counts", "stats_label_counts", "stats_daily_activity"} {
+		if _, err := store.db.ExecContext(ctx, `DROP TABLE `+table); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := migrations.MigrateStatsSummaries(store.db); err != nil {
+		t.Fatalf("MigrateStatsSummaries: %v", err)
+	}
+	create("E", 2, "backend")
+	check("migrated", true)
+}
@@ -0,0 +1,45 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// StatusTransition is one change of an issue's status. Every issue's first
+// transition has an empty From: it was created, open, at At.
+type StatusTransition struct {
+	IssueID string
+	From    types.Status
+	To      types.Status
+	At      time.Time
+}
+
+// GetStatusTransitions returns the status history of every issue, oldest
+// first
+func (s *SQLiteStorage) GetStatusTransitions(ctx context.Context) ([]*StatusTransition, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT issue_id, from_status, to_status, at
+		FROM status_transitions
+		ORDER BY at ASC, id ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query status transitions: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var transitions []*StatusTransition
+	for rows.Next() {
+		var t StatusTransition
+		var from sql.NullString
+		if err := rows.Scan(&t.IssueID, &from, &t.To, &t.At); err != nil {
+			return nil, fmt.Errorf("failed to scan status transition: %w", err)
+		}
+		t.From = types.Status(from.String)
+		transitions = append(transitions, &t)
+	}
+	return transitions, rows.Err()
+}
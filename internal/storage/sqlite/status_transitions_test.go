@@ -0,0 +1,76 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite/migrations"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestStatusTransitions(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Fix login", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeBug}
+	if err := store.CreateIssue(ctx, issue, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	for _, status := range []types.Status{types.StatusInProgress, types.StatusClosed, types.StatusOpen} {
+		if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"status": string(status)}, "alice"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.CloseIssue(ctx, issue.ID, "fixed", "alice"); err != nil {
+		t.Fatal(err)
+	}
+	// Created closed, as an import does
+	imported := &types.Issue{Title: "Old bug", Status: types.StatusClosed, Priority: 2, IssueType: types.TypeBug}
+	now := issue.CreatedAt
+	imported.ClosedAt = &now
+	if err := store.CreateIssue(ctx, imported, "import"); err != nil {
+		t.Fatal(err)
+	}
+
+	history := func() map[string]string {
+		t.Helper()
+		transitions, err := store.GetStatusTransitions(ctx)
+		if err != nil {
+			t.Fatalf("GetStatusTransitions failed: %v", err)
+		}
+		steps := make(map[string][]string)
+		for _, tr := range transitions {
+			steps[tr.IssueID] = append(steps[tr.IssueID], string(tr.From)+">"+string(tr.To))
+		}
+		result := make(map[string]string)
+		for id, s := range steps {
+			result[id] = strings.Join(s, " ")
+		}
+		return result
+	}
+	want := map[string]string{
+		issue.ID:    ">open open>in_progress in_progress>closed closed>open open>closed",
+		imported.ID: ">open open>closed",
+	}
+	got := history()
+	for id, w := range want {
+		if got[id] != w {
+			t.Errorf("%s transitions = %q, want %q", id, got[id], w)
+		}
+	}
+
+	// Rebuilding from the events gives the same history
+	for _, stmt := range migrations.StatusTransitionsBackfill {
+		if _, err := store.db.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("backfill: %v", err)
+		}
+	}
+	got = history()
+	for id, w := range want {
+		if got[id] != w {
+			t.Errorf("backfilled %s transitions = %q, want %q", id, got[id], w)
+		}
+	}
+}
@@ -7,7 +7,6 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"runtime"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -23,11 +22,14 @@ import (
 // SQLiteStorage implements the Storage interface using SQLite
 type SQLiteStorage struct {
 	db          *sql.DB
+	writeDB     *sql.DB // Single connection for write transactions; db itself for in-memory databases (see pool.go)
+	writeWaits  writeWaitStats
 	dbPath      string
 	closed      atomic.Bool // Tracks whether Close() has been called
 	connStr     string      // Connection string for reconnection
 	busyTimeout time.Duration
 	freshness   *FreshnessChecker // Optional freshness checker for daemon mode
+	readOnly    bool              // Opened with NewReadOnly; write transactions fail with ErrReadOnly
 	reconnectMu sync.RWMutex      // Protects reconnection and db access (GH#607)
 }
 
@@ -83,6 +85,10 @@ func New(ctx context.Context, path string) (*SQLiteStorage, error) {
 	return NewWithTimeout(ctx, path, 30*time.Second)
 }
 
+// uriPathEscaper percent-encodes the characters SQLite treats specially in the
+// path part of a file: URI
+var uriPathEscaper = strings.NewReplacer("%", "%25", "?", "%3f", "#", "%23")
+
 // NewWithTimeout creates a new SQLite storage backend with configurable busy timeout.
 // A timeout of 0 means fail immediately if the database is locked.
 func NewWithTimeout(ctx context.Context, path string, busyTimeout time.Duration) (*SQLiteStorage, error) {
@@ -109,8 +115,9 @@ func NewWithTimeout(ctx context.Context, path string, busyTimeout time.Duration)
 		if err := os.MkdirAll(dir, 0o750); err != nil {
 			return nil, fmt.Errorf("failed to create directory: %w", err)
 		}
-		// Use file URI with pragmas
-		connStr = fmt.Sprintf("file:%s?_pragma=foreign_keys(ON)&_pragma=busy_timeout(%d)&_time_format=sqlite", path, timeoutMs)
+		// Use file URI with pragmas. Escape characters that would otherwise end
+		// the path early ('#' and '?' are legal in directory names).
+		connStr = fmt.Sprintf("file:%s?_pragma=foreign_keys(ON)&_pragma=busy_timeout(%d)&_time_format=sqlite", uriPathEscaper.Replace(path), timeoutMs)
 	}
 
 	db, err := sql.Open("sqlite3", connStr)
@@ -128,20 +135,23 @@ func NewWithTimeout(ctx context.Context, path string, busyTimeout time.Duration)
 		db.SetMaxIdleConns(1)
 	} else {
 		// For file-based databases in daemon mode, limit connection pool to prevent
-		// connection exhaustion under concurrent load. SQLite WAL mode supports
-		// 1 writer + unlimited readers, but we limit to prevent goroutine pile-up
-		// on write lock contention (bd-qhws).
-		maxConns := runtime.NumCPU() + 1 // 1 writer + N readers
-		db.SetMaxOpenConns(maxConns)
+		// connection exhaustion under concurrent load (bd-qhws). Write
+		// transactions use their own connection (see pool.go).
+		db.SetMaxOpenConns(readPoolSize())
 		db.SetMaxIdleConns(2)
 		db.SetConnMaxLifetime(0) // SQLite doesn't need connection recycling
 	}
 
 	// For file-based databases, enable WAL mode once after opening the connection.
+	writeDB := db
 	if !isInMemory {
 		if _, err := db.Exec("PRAGMA journal_mode=WAL"); err != nil {
 			return nil, fmt.Errorf("failed to enable WAL mode: %w", err)
 		}
+		if writeDB, err = openWriteDB(connStr); err != nil {
+			_ = db.Close()
+			return nil, err
+		}
 	}
 
 	// Test connection
@@ -186,6 +196,7 @@ func NewWithTimeout(ctx context.Context, path string, busyTimeout time.Duration)
 
 	storage := &SQLiteStorage{
 		db:          db,
+		writeDB:     writeDB,
 		dbPath:      absPath,
 		connStr:     connStr,
 		busyTimeout: busyTimeout,
@@ -213,6 +224,9 @@ func (s *SQLiteStorage) Close() error {
 	// Checkpoint WAL to ensure all writes are persisted to the main database file.
 	// Without this, writes may be stranded in the WAL and lost between CLI invocations.
 	_, _ = s.db.Exec("PRAGMA wal_checkpoint(TRUNCATE)")
+	if s.writeDB != s.db {
+		_ = s.writeDB.Close()
+	}
 	return s.db.Close()
 }
 
@@ -227,8 +241,7 @@ func (s *SQLiteStorage) configureConnectionPool(db *sql.DB) {
 		db.SetMaxIdleConns(1)
 	} else {
 		// SQLite WAL mode: 1 writer + N readers. Limit to prevent goroutine pile-up.
-		maxConns := runtime.NumCPU() + 1
-		db.SetMaxOpenConns(maxConns)
+		db.SetMaxOpenConns(readPoolSize())
 		db.SetMaxIdleConns(2)
 		db.SetConnMaxLifetime(0) // SQLite doesn't need connection recycling
 	}
@@ -389,6 +402,9 @@ func (s *SQLiteStorage) reconnect() error {
 	}
 
 	// Close the old connection - log but continue since connection may be stale/invalid
+	if s.writeDB != s.db {
+		_ = s.writeDB.Close()
+	}
 	if err := s.db.Close(); err != nil {
 		// Old connection might already be broken after file replacement - this is expected
 		debugPrintf("reconnect: close old connection: %v (continuing)\n", err)
@@ -403,10 +419,12 @@ func (s *SQLiteStorage) reconnect() error {
 	// Restore connection pool settings
 	s.configureConnectionPool(db)
 
-	// Re-enable WAL mode for file-based databases
+	// Re-enable WAL mode for file-based databases (a read-only one has a
+	// single pool and can't change mode)
 	isInMemory := s.dbPath == ":memory:" ||
 		(strings.HasPrefix(s.connStr, "file:") && strings.Contains(s.connStr, "mode=memory"))
-	if !isInMemory {
+	singlePool := isInMemory || s.readOnly
+	if !singlePool {
 		if _, err := db.Exec("PRAGMA journal_mode=WAL"); err != nil {
 			_ = db.Close()
 			return fmt.Errorf("failed to enable WAL mode on reconnect: %w", err)
@@ -419,8 +437,17 @@ func (s *SQLiteStorage) reconnect() error {
 		return fmt.Errorf("failed to ping on reconnect: %w", err)
 	}
 
+	writeDB := db
+	if !singlePool {
+		if writeDB, err = openWriteDB(s.connStr); err != nil {
+			_ = db.Close()
+			return err
+		}
+	}
+
 	// Swap in the new connection
 	s.db = db
+	s.writeDB = writeDB
 
 	// Update freshness checker state
 	if s.freshness != nil {
@@ -0,0 +1,271 @@
+package sqlite
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Sync providers recorded in the sync log
+const (
+	SyncProviderGit    = "git"
+	SyncProviderGitHub = "github"
+	SyncProviderJira   = "jira"
+)
+
+// Sync run outcomes. A run that started but never recorded its outcome
+// (bd exited on an error) is SyncIncomplete.
+const (
+	SyncSucceeded  = "succeeded"
+	SyncFailed     = "failed"
+	SyncIncomplete = "incomplete"
+)
+
+// Directions of a sync change
+const (
+	SyncInbound  = "inbound"  // from the remote into beads
+	SyncOutbound = "outbound" // from beads to the remote
+)
+
+// Sync log record kinds: every run writes a start record and, if it gets
+// that far, a finish record
+const (
+	syncRecordStart  = "start"
+	syncRecordFinish = "finish"
+)
+
+// SyncChange is one issue a sync moved, or would move, in one direction
+type SyncChange struct {
+	Direction string `json:"direction"`
+	Action    string `json:"action"` // create, update, delete, link or conflict
+	IssueID   string `json:"issue_id,omitempty"`
+	Remote    string `json:"remote,omitempty"` // the issue's name on the other side: #12, PROJ-7
+	Title     string `json:"title,omitempty"`
+	Detail    string `json:"detail,omitempty"`
+}
+
+// SyncRun is one sync (or dry run) as recorded in the sync log
+type SyncRun struct {
+	ID         string       `json:"id"`
+	Provider   string       `json:"provider"`
+	Target     string       `json:"target,omitempty"` // remote branch, owner/repo or Jira URL
+	DryRun     bool         `json:"dry_run,omitempty"`
+	Actor      string       `json:"actor,omitempty"`
+	StartedAt  time.Time    `json:"started_at"`
+	FinishedAt *time.Time   `json:"finished_at,omitempty"`
+	Status     string       `json:"status"`
+	Error      string       `json:"error,omitempty"`
+	Changes    []SyncChange `json:"changes"`
+}
+
+// SyncRunFilter narrows GetSyncRuns
+type SyncRunFilter struct {
+	RunID    string // prefix of a run ID
+	Provider string
+	IssueID  string // runs that changed this issue
+	Limit    int
+}
+
+// StartSyncRun records the start of a sync with the changes it plans to
+// make, and assigns the run its ID and start time
+func (s *SQLiteStorage) StartSyncRun(ctx context.Context, run *SyncRun) error {
+	if run.Provider == "" {
+		return fmt.Errorf("sync run needs a provider")
+	}
+	if run.ID == "" {
+		id := make([]byte, 8)
+		if _, err := rand.Read(id); err != nil {
+			return err
+		}
+		run.ID = hex.EncodeToString(id)
+	}
+	run.StartedAt = time.Now().UTC()
+	run.Status = SyncIncomplete
+	return s.appendSyncRecord(ctx, run, syncRecordStart, run.Changes, run.StartedAt)
+}
+
+// FinishSyncRun records a started run's outcome. Non-nil changes replace
+// the planned ones, for providers that only know what they did afterwards.
+func (s *SQLiteStorage) FinishSyncRun(ctx context.Context, run *SyncRun, runErr error, changes []SyncChange) error {
+	now := time.Now().UTC()
+	run.FinishedAt = &now
+	run.Status, run.Error = SyncSucceeded, ""
+	if runErr != nil {
+		run.Status, run.Error = SyncFailed, runErr.Error()
+	}
+	if changes != nil {
+		run.Changes = changes
+	}
+	return s.appendSyncRecord(ctx, run, syncRecordFinish, changes, now)
+}
+
+func (s *SQLiteStorage) appendSyncRecord(ctx context.Context, run *SyncRun, record string, changes []SyncChange, at time.Time) error {
+	changesJSON, err := json.Marshal(changes)
+	if err != nil {
+		return fmt.Errorf("failed to encode sync changes: %w", err)
+	}
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		var prevHash string
+		err := tx.QueryRowContext(ctx, `SELECT hash FROM sync_log ORDER BY id DESC LIMIT 1`).Scan(&prevHash)
+		if err != nil && err != sql.ErrNoRows {
+			return fmt.Errorf("failed to read sync log: %w", err)
+		}
+		rec := syncRecord{
+			RunID: run.ID, Record: record, Provider: run.Provider, Target: run.Target, DryRun: run.DryRun,
+			Status: run.Status, Error: run.Error, Actor: run.Actor, Changes: string(changesJSON),
+			CreatedAt: at.Format(time.RFC3339Nano), PrevHash: prevHash,
+		}
+		if record == syncRecordStart {
+			rec.Status = ""
+		}
+		rec.Hash = rec.computeHash()
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO sync_log (run_id, record, provider, target, dry_run, status, error, actor, changes, created_at, prev_hash, hash)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`, rec.RunID, rec.Record, rec.Provider, rec.Target, rec.DryRun, rec.Status, rec.Error, rec.Actor,
+			rec.Changes, rec.CreatedAt, rec.PrevHash, rec.Hash)
+		if err != nil {
+			return fmt.Errorf("failed to append to sync log: %w", err)
+		}
+		return nil
+	})
+}
+
+// syncRecord is one row of the sync_log table
+type syncRecord struct {
+	ID        int64
+	RunID     string
+	Record    string
+	Provider  string
+	Target    string
+	DryRun    bool
+	Status    string
+	Error     string
+	Actor     string
+	Changes   string
+	CreatedAt string
+	PrevHash  string
+	Hash      string
+}
+
+// computeHash covers every column but the row ID, chained to the previous
+// record's hash
+func (r *syncRecord) computeHash() string {
+	h := sha256.New()
+	for _, field := range []string{
+		r.PrevHash, r.RunID, r.Record, r.Provider, r.Target, fmt.Sprint(r.DryRun),
+		r.Status, r.Error, r.Actor, r.Changes, r.CreatedAt,
+	} {
+		h.Write([]byte(field))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (s *SQLiteStorage) syncRecords(ctx context.Context) ([]*syncRecord, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, run_id, record, provider, target, dry_run, status, error, actor, changes, created_at, prev_hash, hash
+		FROM sync_log ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sync log: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var records []*syncRecord
+	for rows.Next() {
+		r := &syncRecord{}
+		if err := rows.Scan(&r.ID, &r.RunID, &r.Record, &r.Provider, &r.Target, &r.DryRun, &r.Status,
+			&r.Error, &r.Actor, &r.Changes, &r.CreatedAt, &r.PrevHash, &r.Hash); err != nil {
+			return nil, fmt.Errorf("failed to scan sync log: %w", err)
+		}
+		records = append(records, r)
+	}
+	return records, rows.Err()
+}
+
+// GetSyncRuns returns the runs in the sync log, newest first
+func (s *SQLiteStorage) GetSyncRuns(ctx context.Context, filter SyncRunFilter) ([]*SyncRun, error) {
+	records, err := s.syncRecords(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*SyncRun)
+	var runs []*SyncRun
+	for _, r := range records {
+		at, _ := time.Parse(time.RFC3339Nano, r.CreatedAt)
+		var changes []SyncChange
+		if err := json.Unmarshal([]byte(r.Changes), &changes); err != nil {
+			return nil, fmt.Errorf("sync log record %d: invalid changes: %w", r.ID, err)
+		}
+		run := byID[r.RunID]
+		if run == nil {
+			run = &SyncRun{ID: r.RunID, Provider: r.Provider, Target: r.Target, DryRun: r.DryRun,
+				Actor: r.Actor, StartedAt: at, Status: SyncIncomplete, Changes: []SyncChange{}}
+			byID[r.RunID] = run
+			runs = append(runs, run)
+		}
+		if r.Record == syncRecordFinish {
+			run.FinishedAt = &at
+			run.Status, run.Error = r.Status, r.Error
+		}
+		if changes != nil {
+			run.Changes = changes
+		}
+	}
+
+	sort.SliceStable(runs, func(i, j int) bool { return runs[i].StartedAt.After(runs[j].StartedAt) })
+	var matched []*SyncRun
+	for _, run := range runs {
+		if filter.RunID != "" && !strings.HasPrefix(run.ID, filter.RunID) {
+			continue
+		}
+		if filter.Provider != "" && run.Provider != filter.Provider {
+			continue
+		}
+		if filter.IssueID != "" && !run.touches(filter.IssueID) {
+			continue
+		}
+		matched = append(matched, run)
+		if filter.Limit > 0 && len(matched) == filter.Limit {
+			break
+		}
+	}
+	return matched, nil
+}
+
+func (r *SyncRun) touches(issueID string) bool {
+	for _, c := range r.Changes {
+		if c.IssueID == issueID {
+			return true
+		}
+	}
+	return false
+}
+
+// VerifySyncLog checks the hash chain of the sync log and returns how many
+// records it covers. An error names the first record that was altered,
+// removed or inserted out of band.
+func (s *SQLiteStorage) VerifySyncLog(ctx context.Context) (int, error) {
+	records, err := s.syncRecords(ctx)
+	if err != nil {
+		return 0, err
+	}
+	prevHash := ""
+	for _, r := range records {
+		if r.PrevHash != prevHash {
+			return 0, fmt.Errorf("sync log record %d does not follow the record before it", r.ID)
+		}
+		if r.computeHash() != r.Hash {
+			return 0, fmt.Errorf("sync log record %d was modified", r.ID)
+		}
+		prevHash = r.Hash
+	}
+	return len(records), nil
+}
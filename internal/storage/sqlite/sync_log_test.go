@@ -0,0 +1,86 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSyncLog(t *testing.T) {
+	ctx := context.Background()
+	s, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	planned := []SyncChange{{Direction: SyncOutbound, Action: "update", IssueID: "bd-1", Title: "Fix login"}}
+	gitRun := &SyncRun{Provider: SyncProviderGit, Target: "origin/main", Actor: "alice", Changes: planned}
+	if err := s.StartSyncRun(ctx, gitRun); err != nil {
+		t.Fatalf("StartSyncRun failed: %v", err)
+	}
+	if err := s.FinishSyncRun(ctx, gitRun, nil, nil); err != nil {
+		t.Fatalf("FinishSyncRun failed: %v", err)
+	}
+
+	// GitHub only knows its changes afterwards
+	ghRun := &SyncRun{Provider: SyncProviderGitHub, Target: "o/r", DryRun: true}
+	if err := s.StartSyncRun(ctx, ghRun); err != nil {
+		t.Fatal(err)
+	}
+	done := []SyncChange{{Direction: SyncInbound, Action: "create", IssueID: "bd-2", Remote: "#7"}}
+	if err := s.FinishSyncRun(ctx, ghRun, errors.New("rate limited"), done); err != nil {
+		t.Fatal(err)
+	}
+
+	// A run that never finished
+	jiraRun := &SyncRun{Provider: SyncProviderJira}
+	if err := s.StartSyncRun(ctx, jiraRun); err != nil {
+		t.Fatal(err)
+	}
+
+	runs, err := s.GetSyncRuns(ctx, SyncRunFilter{})
+	if err != nil {
+		t.Fatalf("GetSyncRuns failed: %v", err)
+	}
+	if len(runs) != 3 || runs[0].ID != jiraRun.ID {
+		t.Fatalf("want 3 runs, newest first; got %+v", runs)
+	}
+	if runs[0].Status != SyncIncomplete || runs[0].FinishedAt != nil {
+		t.Errorf("unfinished run: %+v", runs[0])
+	}
+	if gh := runs[1]; gh.Status != SyncFailed || gh.Error != "rate limited" || !gh.DryRun || len(gh.Changes) != 1 || gh.Changes[0].Remote != "#7" {
+		t.Errorf("github run: %+v", gh)
+	}
+	if git := runs[2]; git.Status != SyncSucceeded || len(git.Changes) != 1 || git.Changes[0].IssueID != "bd-1" {
+		t.Errorf("git run: %+v", git)
+	}
+
+	if byIssue, _ := s.GetSyncRuns(ctx, SyncRunFilter{IssueID: "bd-2"}); len(byIssue) != 1 || byIssue[0].ID != ghRun.ID {
+		t.Errorf("runs touching bd-2: %+v", byIssue)
+	}
+	if byID, _ := s.GetSyncRuns(ctx, SyncRunFilter{RunID: gitRun.ID[:6], Provider: SyncProviderGit}); len(byID) != 1 {
+		t.Errorf("run by ID prefix: %+v", byID)
+	}
+
+	if n, err := s.VerifySyncLog(ctx); err != nil || n != 5 {
+		t.Errorf("VerifySyncLog = %d, %v; want 5 records", n, err)
+	}
+
+	// The log is append-only
+	if _, err := s.db.ExecContext(ctx, `UPDATE sync_log SET status = 'succeeded'`); err == nil || !strings.Contains(err.Error(), "append-only") {
+		t.Errorf("UPDATE should be refused, got %v", err)
+	}
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM sync_log`); err == nil {
+		t.Error("DELETE should be refused")
+	}
+
+	// Rewriting history around the triggers breaks the chain
+	if _, err := s.db.ExecContext(ctx, `DROP TRIGGER sync_log_no_update`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.db.ExecContext(ctx, `UPDATE sync_log SET error = '' WHERE run_id = ?`, ghRun.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.VerifySyncLog(ctx); err == nil {
+		t.Error("VerifySyncLog should detect the edited record")
+	}
+}
@@ -41,7 +41,7 @@ type sqliteTxStorage struct {
 func (s *SQLiteStorage) RunInTransaction(ctx context.Context, fn func(tx storage.Transaction) error) error {
 	// Acquire a dedicated connection for the transaction.
 	// This ensures all operations in the transaction use the same connection.
-	conn, err := s.db.Conn(ctx)
+	conn, err := s.writeConn(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to acquire connection for transaction: %w", err)
 	}
@@ -62,6 +62,10 @@ func (s *SQLiteStorage) RunInTransaction(ctx context.Context, fn func(tx storage
 		}
 	}()
 
+	if err := tagOperation(ctx, conn); err != nil {
+		return err
+	}
+
 	// Handle panics: rollback and re-raise
 	defer func() {
 		if r := recover(); r != nil {
@@ -98,7 +102,7 @@ func (t *sqliteTxStorage) CreateIssue(ctx context.Context, issue *types.Issue, a
 	}
 
 	// Set timestamps first so defensive fixes can use them
-	now := time.Now()
+	now := time.Now().UTC()
 	if issue.CreatedAt.IsZero() {
 		issue.CreatedAt = now
 	}
@@ -132,6 +136,24 @@ func (t *sqliteTxStorage) CreateIssue(ctx context.Context, issue *types.Issue, a
 		return fmt.Errorf("validation failed: %w", err)
 	}
 
+	// Enforce assignee roster in strict integrity mode
+	roster, err := assigneeRoster(ctx, t.GetConfig)
+	if err != nil {
+		return fmt.Errorf("failed to get assignee roster: %w", err)
+	}
+	if err := validateAssigneeInRoster(issue.Assignee, roster); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	// Keep log dumps and binary pastes out of the text fields
+	limits, err := LoadContentLimits(ctx, t.GetConfig)
+	if err != nil {
+		return fmt.Errorf("failed to get content limits: %w", err)
+	}
+	if err := limits.checkIssue(issue); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
 	// Compute content hash (bd-95)
 	if issue.ContentHash == "" {
 		issue.ContentHash = issue.ComputeContentHash()
@@ -206,8 +228,17 @@ func (t *sqliteTxStorage) CreateIssues(ctx context.Context, issues []*types.Issu
 		return fmt.Errorf("failed to get custom statuses: %w", err)
 	}
 
+	roster, err := assigneeRoster(ctx, t.GetConfig)
+	if err != nil {
+		return fmt.Errorf("failed to get assignee roster: %w", err)
+	}
+	limits, err := LoadContentLimits(ctx, t.GetConfig)
+	if err != nil {
+		return fmt.Errorf("failed to get content limits: %w", err)
+	}
+
 	// Validate and prepare all issues first (with custom status support)
-	now := time.Now()
+	now := time.Now().UTC()
 	for _, issue := range issues {
 		// Set timestamps first so defensive fixes can use them
 		if issue.CreatedAt.IsZero() {
@@ -241,6 +272,12 @@ func (t *sqliteTxStorage) CreateIssues(ctx context.Context, issues []*types.Issu
 		if err := issue.ValidateWithCustomStatuses(customStatuses); err != nil {
 			return fmt.Errorf("validation failed for issue: %w", err)
 		}
+		if err := validateAssigneeInRoster(issue.Assignee, roster); err != nil {
+			return fmt.Errorf("validation failed for issue: %w", err)
+		}
+		if err := limits.checkIssue(issue); err != nil {
+			return fmt.Errorf("validation failed for issue: %w", err)
+		}
 		if issue.ContentHash == "" {
 			issue.ContentHash = issue.ComputeContentHash()
 		}
@@ -306,7 +343,7 @@ func (t *sqliteTxStorage) GetIssue(ctx context.Context, id string) (*types.Issue
 		       created_at, updated_at, closed_at, external_ref,
 		       compaction_level, compacted_at, compacted_at_commit, original_size, source_repo, close_reason,
 		       deleted_at, deleted_by, delete_reason, original_type,
-		       sender, ephemeral
+		       sender, ephemeral, complexity, source, timebox_minutes
 		FROM issues
 		WHERE id = ?
 	`, id)
@@ -368,9 +405,22 @@ func (t *sqliteTxStorage) UpdateIssue(ctx context.Context, id string, updates ma
 		return fmt.Errorf("failed to get custom statuses: %w", err)
 	}
 
+	roster, err := assigneeRoster(ctx, t.GetConfig)
+	if err != nil {
+		return fmt.Errorf("failed to get assignee roster: %w", err)
+	}
+	limits, err := LoadContentLimits(ctx, t.GetConfig)
+	if err != nil {
+		return fmt.Errorf("failed to get content limits: %w", err)
+	}
+
+	if err := validateSpikeTimebox(oldIssue, updates); err != nil {
+		return err
+	}
+
 	// Build update query with validated field names
 	setClauses := []string{"updated_at = ?"}
-	args := []interface{}{time.Now()}
+	args := []interface{}{time.Now().UTC()}
 
 	for key, value := range updates {
 		// Prevent SQL injection by validating field names
@@ -382,6 +432,14 @@ func (t *sqliteTxStorage) UpdateIssue(ctx context.Context, id string, updates ma
 		if err := validateFieldUpdateWithCustomStatuses(key, value, customStatuses); err != nil {
 			return fmt.Errorf("failed to validate field update: %w", err)
 		}
+		if assignee, ok := value.(string); ok && key == "assignee" {
+			if err := validateAssigneeInRoster(assignee, roster); err != nil {
+				return fmt.Errorf("failed to validate assignee: %w", err)
+			}
+		}
+		if err := limits.checkUpdate(key, value); err != nil {
+			return err
+		}
 
 		setClauses = append(setClauses, fmt.Sprintf("%s = ?", key))
 		args = append(args, value)
@@ -392,7 +450,7 @@ func (t *sqliteTxStorage) UpdateIssue(ctx context.Context, id string, updates ma
 
 	// Recompute content_hash if any content fields changed (bd-95)
 	contentChanged := false
-	contentFields := []string{"title", "description", "design", "acceptance_criteria", "notes", "status", "priority", "issue_type", "assignee", "external_ref"}
+	contentFields := []string{"title", "description", "design", "acceptance_criteria", "notes", "status", "priority", "issue_type", "assignee", "external_ref", "complexity", "source", "timebox_minutes"}
 	for _, field := range contentFields {
 		if _, exists := updates[field]; exists {
 			contentChanged = true
@@ -498,6 +556,27 @@ func applyUpdatesToIssue(issue *types.Issue, updates map[string]interface{}) {
 			} else if s, ok := value.(string); ok {
 				issue.Assignee = s
 			}
+		case "complexity":
+			if value == nil {
+				issue.Complexity = ""
+			} else if s, ok := value.(string); ok {
+				issue.Complexity = types.Complexity(s)
+			}
+		case "source":
+			if value == nil {
+				issue.Source = ""
+			} else if s, ok := value.(string); ok {
+				issue.Source = s
+			}
+		case "timebox_minutes":
+			switch v := value.(type) {
+			case int:
+				issue.TimeboxMinutes = &v
+			case *int:
+				issue.TimeboxMinutes = v
+			default:
+				issue.TimeboxMinutes = nil
+			}
 		case "external_ref":
 			if value == nil {
 				issue.ExternalRef = nil
@@ -516,7 +595,7 @@ func applyUpdatesToIssue(issue *types.Issue, updates map[string]interface{}) {
 // CloseIssue closes an issue within the transaction.
 // NOTE: close_reason is stored in both issues table and events table - see SQLiteStorage.CloseIssue.
 func (t *sqliteTxStorage) CloseIssue(ctx context.Context, id string, reason string, actor string) error {
-	now := time.Now()
+	now := time.Now().UTC()
 
 	result, err := t.conn.ExecContext(ctx, `
 		UPDATE issues SET status = ?, closed_at = ?, updated_at = ?, close_reason = ?
@@ -533,6 +612,9 @@ func (t *sqliteTxStorage) CloseIssue(ctx context.Context, id string, reason stri
 	if rows == 0 {
 		return fmt.Errorf("issue not found: %s", id)
 	}
+	if err := refreshContentHash(ctx, t.conn, id); err != nil {
+		return err
+	}
 
 	_, err = t.conn.ExecContext(ctx, `
 		INSERT INTO events (issue_id, event_type, actor, comment)
@@ -569,6 +651,18 @@ func (t *sqliteTxStorage) DeleteIssue(ctx context.Context, id string) error {
 	if err != nil {
 		return fmt.Errorf("failed to delete events: %w", err)
 	}
+	_, err = t.conn.ExecContext(ctx, `DELETE FROM event_summaries WHERE issue_id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete event summary: %w", err)
+	}
+	_, err = t.conn.ExecContext(ctx, `DELETE FROM issue_leases WHERE issue_id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete lease: %w", err)
+	}
+	_, err = t.conn.ExecContext(ctx, `DELETE FROM status_transitions WHERE issue_id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete status transitions: %w", err)
+	}
 
 	// Delete from dirty_issues
 	_, err = t.conn.ExecContext(ctx, `DELETE FROM dirty_issues WHERE issue_id = ?`, id)
@@ -609,12 +703,22 @@ func (t *sqliteTxStorage) AddDependency(ctx context.Context, dep *types.Dependen
 		return fmt.Errorf("issue %s not found", dep.IssueID)
 	}
 
-	dependsOnExists, err := t.GetIssue(ctx, dep.DependsOnID)
-	if err != nil {
-		return fmt.Errorf("failed to check dependency %s: %w", dep.DependsOnID, err)
-	}
-	if dependsOnExists == nil {
-		return fmt.Errorf("dependency target %s not found", dep.DependsOnID)
+	// A qualified ID (api/bd-42) names an issue in a linked workspace, which
+	// isn't in this database and can't be part of a cycle here
+	external := types.IsQualifiedID(dep.DependsOnID)
+	dependsOnExists := &types.Issue{ID: dep.DependsOnID}
+	if external {
+		if dep.Type == types.DepParentChild {
+			return fmt.Errorf("an issue in another workspace (%s) can't be a parent", dep.DependsOnID)
+		}
+	} else {
+		dependsOnExists, err = t.GetIssue(ctx, dep.DependsOnID)
+		if err != nil {
+			return fmt.Errorf("failed to check dependency %s: %w", dep.DependsOnID, err)
+		}
+		if dependsOnExists == nil {
+			return fmt.Errorf("dependency target %s not found", dep.DependsOnID)
+		}
 	}
 
 	// Prevent self-dependency
@@ -631,7 +735,7 @@ func (t *sqliteTxStorage) AddDependency(ctx context.Context, dep *types.Dependen
 	}
 
 	if dep.CreatedAt.IsZero() {
-		dep.CreatedAt = time.Now()
+		dep.CreatedAt = time.Now().UTC()
 	}
 	if dep.CreatedBy == "" {
 		dep.CreatedBy = actor
@@ -639,7 +743,7 @@ func (t *sqliteTxStorage) AddDependency(ctx context.Context, dep *types.Dependen
 
 	// Cycle detection - skip for relates-to (inherently bidirectional)
 	// See dependencies.go for full rationale on cycle prevention
-	if dep.Type != types.DepRelatesTo {
+	if dep.Type != types.DepRelatesTo && !external {
 		var cycleExists bool
 		err = t.conn.QueryRowContext(ctx, `
 		WITH RECURSIVE paths AS (
@@ -772,6 +876,9 @@ func (t *sqliteTxStorage) RemoveDependency(ctx context.Context, issueID, depends
 
 // AddLabel adds a label to an issue within the transaction.
 func (t *sqliteTxStorage) AddLabel(ctx context.Context, issueID, label, actor string) error {
+	if err := validateUTF8("label", label); err != nil {
+		return err
+	}
 	result, err := t.conn.ExecContext(ctx, `
 		INSERT OR IGNORE INTO labels (issue_id, label) VALUES (?, ?)
 	`, issueID, label)
@@ -907,7 +1014,7 @@ func (t *sqliteTxStorage) GetMetadata(ctx context.Context, key string) (string,
 // AddComment adds a comment to an issue within the transaction.
 func (t *sqliteTxStorage) AddComment(ctx context.Context, issueID, actor, comment string) error {
 	// Update issue updated_at timestamp first to verify issue exists
-	now := time.Now()
+	now := time.Now().UTC()
 	res, err := t.conn.ExecContext(ctx, `
 		UPDATE issues SET updated_at = ? WHERE id = ?
 	`, now, issueID)
@@ -1031,6 +1138,11 @@ func (t *sqliteTxStorage) SearchIssues(ctx context.Context, query string, filter
 		whereClauses = append(whereClauses, "closed_at < ?")
 		args = append(args, filter.ClosedBefore.Format(time.RFC3339))
 	}
+	if filter.CloseReason != "" {
+		prefix := filter.CloseReason + ":"
+		whereClauses = append(whereClauses, "(close_reason = ? OR substr(close_reason, 1, ?) = ?)")
+		args = append(args, filter.CloseReason, len(prefix), prefix)
+	}
 
 	// Empty/null checks
 	if filter.EmptyDescription {
@@ -1080,6 +1192,24 @@ func (t *sqliteTxStorage) SearchIssues(ctx context.Context, query string, filter
 		}
 	}
 
+	if len(filter.Complexity) > 0 {
+		clause, complexityArgs := complexityClause("complexity", filter.Complexity)
+		whereClauses = append(whereClauses, clause)
+		args = append(args, complexityArgs...)
+	}
+
+	// Custom field filtering: issue must have ALL specified values
+	clauses, fieldArgs := fieldClauses("id", filter.Fields)
+	whereClauses = append(whereClauses, clauses...)
+	args = append(args, fieldArgs...)
+
+	// Pagination: resume after the cursor position
+	if filter.After != nil {
+		clause, cursorArgs := pageCursorClause(filter.After)
+		whereClauses = append(whereClauses, clause)
+		args = append(args, cursorArgs...)
+	}
+
 	whereSQL := ""
 	if len(whereClauses) > 0 {
 		whereSQL = "WHERE " + strings.Join(whereClauses, " AND ")
@@ -1091,6 +1221,11 @@ func (t *sqliteTxStorage) SearchIssues(ctx context.Context, query string, filter
 		args = append(args, filter.Limit)
 	}
 
+	orderSQL, err := orderByClause(filter)
+	if err != nil {
+		return nil, err
+	}
+
 	// #nosec G201 - safe SQL with controlled formatting
 	querySQL := fmt.Sprintf(`
 		SELECT id, content_hash, title, description, design, acceptance_criteria, notes,
@@ -1098,12 +1233,12 @@ func (t *sqliteTxStorage) SearchIssues(ctx context.Context, query string, filter
 		       created_at, updated_at, closed_at, external_ref,
 		       compaction_level, compacted_at, compacted_at_commit, original_size, source_repo, close_reason,
 		       deleted_at, deleted_by, delete_reason, original_type,
-		       sender, ephemeral
+		       sender, ephemeral, complexity, source, timebox_minutes
 		FROM issues
 		%s
-		ORDER BY priority ASC, created_at DESC
+		ORDER BY %s
 		%s
-	`, whereSQL, limitSQL)
+	`, whereSQL, orderSQL, limitSQL)
 
 	rows, err := t.conn.QueryContext(ctx, querySQL, args...)
 	if err != nil {
@@ -1141,6 +1276,9 @@ func scanIssueRow(row scanner) (*types.Issue, error) {
 	// Messaging fields (bd-kwro)
 	var sender sql.NullString
 	var ephemeral sql.NullInt64
+	var complexity sql.NullString
+	var source sql.NullString
+	var timeboxMinutes sql.NullInt64
 
 	err := row.Scan(
 		&issue.ID, &contentHash, &issue.Title, &issue.Description, &issue.Design,
@@ -1149,7 +1287,7 @@ func scanIssueRow(row scanner) (*types.Issue, error) {
 		&issue.CreatedAt, &issue.UpdatedAt, &closedAt, &externalRef,
 		&issue.CompactionLevel, &compactedAt, &compactedAtCommit, &originalSize, &sourceRepo, &closeReason,
 		&deletedAt, &deletedBy, &deleteReason, &originalType,
-		&sender, &ephemeral,
+		&sender, &ephemeral, &complexity, &source, &timeboxMinutes,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to scan issue: %w", err)
@@ -1203,6 +1341,16 @@ func scanIssueRow(row scanner) (*types.Issue, error) {
 	if ephemeral.Valid && ephemeral.Int64 != 0 {
 		issue.Ephemeral = true
 	}
+	if complexity.Valid {
+		issue.Complexity = types.Complexity(complexity.String)
+	}
+	if source.Valid {
+		issue.Source = source.String
+	}
+	if timeboxMinutes.Valid {
+		mins := int(timeboxMinutes.Int64)
+		issue.TimeboxMinutes = &mins
+	}
 
 	return &issue, nil
 }
@@ -0,0 +1,107 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// SetTranslation stores an issue's title and description for one locale,
+// replacing any earlier translation for it. A translation with neither a
+// title nor a description removes the locale.
+func (s *SQLiteStorage) SetTranslation(ctx context.Context, issueID string, t *types.Translation, actor string) error {
+	locale, err := types.NormalizeLocale(t.Locale)
+	if err != nil {
+		return err
+	}
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		var exists bool
+		if err := tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM issues WHERE id = ?)`, issueID).Scan(&exists); err != nil {
+			return fmt.Errorf("failed to check issue existence: %w", err)
+		}
+		if !exists {
+			return fmt.Errorf("issue %s not found", issueID)
+		}
+
+		// Setting the text a locale already has is a no-op, which keeps
+		// JSONL imports from marking every translated issue dirty
+		var title, description string
+		err := tx.QueryRowContext(ctx, `SELECT title, description FROM issue_translations WHERE issue_id = ? AND locale = ?`, issueID, locale).Scan(&title, &description)
+		switch {
+		case err == sql.ErrNoRows:
+			if t.Title == "" && t.Description == "" {
+				return nil
+			}
+		case err != nil:
+			return fmt.Errorf("failed to look up translation: %w", err)
+		case title == t.Title && description == t.Description:
+			return nil
+		}
+
+		if t.Title == "" && t.Description == "" {
+			_, err = tx.ExecContext(ctx, `DELETE FROM issue_translations WHERE issue_id = ? AND locale = ?`, issueID, locale)
+		} else {
+			_, err = tx.ExecContext(ctx, `
+				INSERT INTO issue_translations (issue_id, locale, title, description, updated_at)
+				VALUES (?, ?, ?, ?, CURRENT_TIMESTAMP)
+				ON CONFLICT (issue_id, locale) DO UPDATE SET
+					title = excluded.title,
+					description = excluded.description,
+					updated_at = excluded.updated_at
+			`, issueID, locale, t.Title, t.Description)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to set translation: %w", err)
+		}
+		return markIssueDirtyInTx(ctx, tx, issueID)
+	})
+}
+
+// GetTranslationsForIssues fetches translations for multiple issues in a
+// single query. Returns a map of issue_id -> translations sorted by locale
+func (s *SQLiteStorage) GetTranslationsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.Translation, error) {
+	result := make(map[string][]*types.Translation)
+	if len(issueIDs) == 0 {
+		return result, nil
+	}
+
+	placeholders := make([]interface{}, len(issueIDs))
+	for i, id := range issueIDs {
+		placeholders[i] = id
+	}
+	query := fmt.Sprintf(`
+		SELECT issue_id, locale, title, description FROM issue_translations
+		WHERE issue_id IN (%s)
+		ORDER BY issue_id, locale
+	`, buildPlaceholders(len(issueIDs))) // #nosec G201 -- placeholders are generated internally
+
+	rows, err := s.db.QueryContext(ctx, query, placeholders...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query translations: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var issueID string
+		var t types.Translation
+		if err := rows.Scan(&issueID, &t.Locale, &t.Title, &t.Description); err != nil {
+			return nil, fmt.Errorf("failed to scan translation: %w", err)
+		}
+		result[issueID] = append(result[issueID], &t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating translations: %w", err)
+	}
+	return result, nil
+}
+
+// getIssueTranslations returns one issue's translations for GetIssue
+func (s *SQLiteStorage) getIssueTranslations(ctx context.Context, issueID string) ([]*types.Translation, error) {
+	result, err := s.GetTranslationsForIssues(ctx, []string{issueID})
+	if err != nil {
+		return nil, err
+	}
+	return result[issueID], nil
+}
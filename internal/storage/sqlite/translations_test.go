@@ -0,0 +1,93 @@
+package sqlite
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestIssueTranslations(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	issue := &types.Issue{ID: "bd-1", Title: "Login", Description: "Users can't log in", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.ClearDirtyIssuesByID(ctx, []string{"bd-1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	title, description := "ログイン", "ログインできない"
+	if err := storage.UpdateTranslation(ctx, store, "bd-1", "ja_jp", &title, nil, "alice"); err != nil {
+		t.Fatalf("UpdateTranslation: %v", err)
+	}
+	// Setting only the description keeps the title
+	if err := storage.UpdateTranslation(ctx, store, "bd-1", "ja-JP", nil, &description, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SetTranslation(ctx, "bd-1", &types.Translation{Locale: "de", Title: "Anmeldung"}, "alice"); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := store.GetIssue(ctx, "bd-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*types.Translation{
+		{Locale: "de", Title: "Anmeldung"},
+		{Locale: "ja-JP", Title: title, Description: description},
+	}
+	if !reflect.DeepEqual(got.Translations, want) {
+		t.Errorf("translations = %+v, want %+v", got.Translations, want)
+	}
+	if got.Title != "Login" {
+		t.Errorf("original title changed to %q", got.Title)
+	}
+	dirty, _ := store.GetDirtyIssues(ctx)
+	if !reflect.DeepEqual(dirty, []string{"bd-1"}) {
+		t.Errorf("dirty issues = %v, want bd-1 so the translation is exported", dirty)
+	}
+
+	// Re-importing the same text doesn't mark the issue dirty again
+	if err := store.ClearDirtyIssuesByID(ctx, []string{"bd-1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SetTranslation(ctx, "bd-1", want[0], "import"); err != nil {
+		t.Fatal(err)
+	}
+	if dirty, _ := store.GetDirtyIssues(ctx); len(dirty) != 0 {
+		t.Errorf("unchanged translation marked %v dirty", dirty)
+	}
+
+	issues := []*types.Issue{{ID: "bd-1", Title: "Login", Description: "Users can't log in"}}
+	if err := storage.Localize(ctx, store, issues, "de-AT"); err != nil {
+		t.Fatal(err)
+	}
+	if issues[0].Title != "Anmeldung" || issues[0].Description != "Users can't log in" {
+		t.Errorf("localized = %q/%q", issues[0].Title, issues[0].Description)
+	}
+
+	// Clearing both fields removes the locale
+	empty := ""
+	if err := storage.UpdateTranslation(ctx, store, "bd-1", "de", &empty, &empty, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	all, err := store.GetTranslationsForIssues(ctx, []string{"bd-1"})
+	if err != nil || len(all["bd-1"]) != 1 || all["bd-1"][0].Locale != "ja-JP" {
+		t.Errorf("after removing de: %+v, %v", all["bd-1"], err)
+	}
+
+	for _, tc := range []struct{ issueID, locale string }{
+		{"bd-99", "fr"},     // missing issue
+		{"bd-1", "french!"}, // invalid locale
+	} {
+		if err := store.SetTranslation(ctx, tc.issueID, &types.Translation{Locale: tc.locale, Title: "x"}, "alice"); err == nil {
+			t.Errorf("SetTranslation(%s, %q) succeeded, want error", tc.issueID, tc.locale)
+		}
+	}
+}
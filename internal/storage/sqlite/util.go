@@ -22,12 +22,21 @@ func (s *SQLiteStorage) BeginTx(ctx context.Context) (*sql.Tx, error) {
 // If the function returns an error, the transaction is rolled back.
 // Otherwise, the transaction is committed.
 func (s *SQLiteStorage) withTx(ctx context.Context, fn func(*sql.Tx) error) error {
-	tx, err := s.db.BeginTx(ctx, nil)
+	conn, err := s.writeConn(ctx)
+	if err != nil {
+		return wrapDBError("begin transaction", err)
+	}
+	defer func() { _ = conn.Close() }()
+	tx, err := conn.BeginTx(ctx, nil)
 	if err != nil {
 		return wrapDBError("begin transaction", err)
 	}
 	defer func() { _ = tx.Rollback() }()
 
+	if err := tagOperation(ctx, tx); err != nil {
+		return err
+	}
+
 	if err := fn(tx); err != nil {
 		return err
 	}
@@ -2,6 +2,7 @@ package sqlite
 
 import (
 	"fmt"
+	"unicode/utf8"
 
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -67,6 +68,67 @@ func validateEstimatedMinutes(value interface{}) error {
 	return nil
 }
 
+// validateTimeboxMinutes validates a timebox_minutes value; nil clears it
+func validateTimeboxMinutes(value interface{}) error {
+	switch mins := value.(type) {
+	case int:
+		if mins < 0 {
+			return fmt.Errorf("timebox_minutes cannot be negative")
+		}
+	case *int:
+		if mins != nil && *mins < 0 {
+			return fmt.Errorf("timebox_minutes cannot be negative")
+		}
+	}
+	return nil
+}
+
+// validateSpikeTimebox rejects updates that would leave a spike without a
+// timebox: turning an issue into a spike, or clearing a spike's timebox
+func validateSpikeTimebox(old *types.Issue, updates map[string]interface{}) error {
+	issueType := old.IssueType
+	switch v := updates["issue_type"].(type) {
+	case string:
+		issueType = types.IssueType(v)
+	case types.IssueType:
+		issueType = v
+	}
+	if issueType != types.TypeSpike {
+		return nil
+	}
+	timebox := old.TimeboxMinutes != nil && *old.TimeboxMinutes > 0
+	if value, ok := updates["timebox_minutes"]; ok {
+		switch mins := value.(type) {
+		case int:
+			timebox = mins > 0
+		case *int:
+			timebox = mins != nil && *mins > 0
+		default:
+			timebox = false
+		}
+	}
+	if !timebox {
+		return fmt.Errorf("spikes need a timebox (e.g. --timebox 2d)")
+	}
+	return nil
+}
+
+// validateComplexity validates a complexity value; empty clears it
+func validateComplexity(value interface{}) error {
+	if c, ok := value.(string); ok && c != "" && !types.Complexity(c).IsValid() {
+		return fmt.Errorf("invalid complexity: %s (valid: trivial, standard, complex, research)", c)
+	}
+	return nil
+}
+
+// validateUTF8 rejects text that JSONL export couldn't reproduce exactly
+func validateUTF8(field string, value interface{}) error {
+	if s, ok := value.(string); ok && !utf8.ValidString(s) {
+		return fmt.Errorf("%s is not valid UTF-8", field)
+	}
+	return nil
+}
+
 // fieldValidators maps field names to their validation functions
 var fieldValidators = map[string]func(interface{}) error{
 	"priority":          validatePriority,
@@ -74,6 +136,8 @@ var fieldValidators = map[string]func(interface{}) error{
 	"issue_type":        validateIssueType,
 	"title":             validateTitle,
 	"estimated_minutes": validateEstimatedMinutes,
+	"complexity":        validateComplexity,
+	"timebox_minutes":   validateTimeboxMinutes,
 }
 
 // validateFieldUpdate validates a field update value (built-in statuses only)
@@ -84,6 +148,9 @@ func validateFieldUpdate(key string, value interface{}) error {
 // validateFieldUpdateWithCustomStatuses validates a field update value,
 // allowing custom statuses for status field validation.
 func validateFieldUpdateWithCustomStatuses(key string, value interface{}, customStatuses []string) error {
+	if err := validateUTF8(key, value); err != nil {
+		return err
+	}
 	// Special handling for status field to support custom statuses
 	if key == "status" {
 		return validateStatusWithCustom(value, customStatuses)
@@ -0,0 +1,62 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestCheckVerifiedClose(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	secure := &types.Issue{Title: "Rotate keys", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	plain := &types.Issue{Title: "Fix typo", Status: types.StatusOpen, Priority: 3, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{secure, plain} {
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.AddLabel(ctx, secure.ID, "security", "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Without a policy everything closes freely
+	if err := storage.CheckVerifiedClose(ctx, store, secure.ID, "alice"); err != nil {
+		t.Errorf("no policy: %v", err)
+	}
+
+	if err := store.SetConfig(ctx, "close.verify_labels", "security"); err != nil {
+		t.Fatal(err)
+	}
+	if err := storage.CheckVerifiedClose(ctx, store, plain.ID, "alice"); err != nil {
+		t.Errorf("unlabeled issue: %v", err)
+	}
+	if err := storage.CheckVerifiedClose(ctx, store, secure.ID, "alice"); err == nil || !strings.Contains(err.Error(), "resolved") {
+		t.Errorf("unresolved issue: %v", err)
+	}
+
+	if err := store.UpdateIssue(ctx, secure.ID, map[string]interface{}{"status": string(types.StatusResolved)}, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.AddIssueComment(ctx, secure.ID, "alice", types.ResolutionCommentPrefix+"PR #7"); err != nil {
+		t.Fatal(err)
+	}
+	if err := storage.CheckVerifiedClose(ctx, store, secure.ID, "alice"); err == nil || !strings.Contains(err.Error(), "different reviewer") {
+		t.Errorf("self-close: %v", err)
+	}
+	if err := storage.CheckVerifiedClose(ctx, store, secure.ID, "reviewer"); err != nil {
+		t.Errorf("reviewer close: %v", err)
+	}
+
+	if err := store.SetConfig(ctx, "close.verify_priority", "urgent"); err != nil {
+		t.Fatal(err)
+	}
+	if err := storage.CheckVerifiedClose(ctx, store, plain.ID, "alice"); err == nil {
+		t.Error("expected error for an invalid close.verify_priority")
+	}
+}
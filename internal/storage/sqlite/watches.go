@@ -0,0 +1,79 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// AddWatch subscribes watch.Watcher to an issue or a label. Watching
+// something already watched is a no-op. Issue watches must name an existing
+// issue; label watches may name a label nobody uses yet.
+func (s *SQLiteStorage) AddWatch(ctx context.Context, watch *types.Watch) error {
+	if watch.Watcher == "" {
+		return fmt.Errorf("watcher is required")
+	}
+	if (watch.IssueID == "") == (watch.Label == "") {
+		return fmt.Errorf("a watch needs exactly one of an issue ID or a label")
+	}
+	if watch.IssueID != "" {
+		var exists bool
+		if err := s.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM issues WHERE id = ?)`, watch.IssueID).Scan(&exists); err != nil {
+			return fmt.Errorf("failed to check issue existence: %w", err)
+		}
+		if !exists {
+			return fmt.Errorf("issue %s not found", watch.IssueID)
+		}
+	}
+	_, err := s.db.ExecContext(ctx, `
+		INSERT OR IGNORE INTO watches (watcher, issue_id, label) VALUES (?, ?, ?)
+	`, watch.Watcher, watch.IssueID, watch.Label)
+	if err != nil {
+		return fmt.Errorf("failed to add watch: %w", err)
+	}
+	return nil
+}
+
+// RemoveWatch ends a subscription and reports whether there was one
+func (s *SQLiteStorage) RemoveWatch(ctx context.Context, watch *types.Watch) (bool, error) {
+	result, err := s.db.ExecContext(ctx, `
+		DELETE FROM watches WHERE watcher = ? AND issue_id = ? AND label = ?
+	`, watch.Watcher, watch.IssueID, watch.Label)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove watch: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// GetWatches returns the subscriptions of watcher, or of everyone when
+// watcher is empty, ordered by watcher, then issue watches before label
+// watches
+func (s *SQLiteStorage) GetWatches(ctx context.Context, watcher string) ([]*types.Watch, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT watcher, issue_id, label, created_at FROM watches
+		WHERE ? = '' OR watcher = ?
+		ORDER BY watcher, label != '', issue_id, label
+	`, watcher, watcher)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query watches: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var watches []*types.Watch
+	for rows.Next() {
+		w := &types.Watch{}
+		if err := rows.Scan(&w.Watcher, &w.IssueID, &w.Label, &w.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan watch: %w", err)
+		}
+		watches = append(watches, w)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating watches: %w", err)
+	}
+	return watches, nil
+}
@@ -0,0 +1,68 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestWatches(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	for _, id := range []string{"bd-1", "bd-2"} {
+		issue := &types.Issue{ID: id, Title: id, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, w := range []*types.Watch{
+		{Watcher: "alice", Label: "frontend"},
+		{Watcher: "alice", IssueID: "bd-2"},
+		{Watcher: "alice", IssueID: "bd-2"}, // already watching: no-op
+		{Watcher: "bob", IssueID: "bd-1"},
+	} {
+		if err := store.AddWatch(ctx, w); err != nil {
+			t.Fatalf("AddWatch(%+v): %v", w, err)
+		}
+	}
+	for _, w := range []*types.Watch{
+		{Watcher: "alice", IssueID: "bd-99"},
+		{Watcher: "alice"},
+		{Watcher: "alice", IssueID: "bd-1", Label: "frontend"},
+		{IssueID: "bd-1"},
+	} {
+		if err := store.AddWatch(ctx, w); err == nil {
+			t.Errorf("AddWatch(%+v): expected an error", w)
+		}
+	}
+
+	watches, err := store.GetWatches(ctx, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(watches) != 2 || watches[0].IssueID != "bd-2" || watches[1].Label != "frontend" {
+		t.Errorf("alice's watches = %+v, %+v", watches[0], watches[1])
+	}
+	if all, _ := store.GetWatches(ctx, ""); len(all) != 3 {
+		t.Errorf("all watches = %d, want 3", len(all))
+	}
+
+	if removed, err := store.RemoveWatch(ctx, &types.Watch{Watcher: "alice", Label: "frontend"}); err != nil || !removed {
+		t.Errorf("RemoveWatch = %v, %v", removed, err)
+	}
+	if removed, _ := store.RemoveWatch(ctx, &types.Watch{Watcher: "alice", Label: "frontend"}); removed {
+		t.Error("removing a missing watch reported success")
+	}
+
+	// Deleting an issue drops its watches
+	if err := store.DeleteIssue(ctx, "bd-1"); err != nil {
+		t.Fatal(err)
+	}
+	if bob, _ := store.GetWatches(ctx, "bob"); len(bob) != 0 {
+		t.Errorf("bob still watches %+v", bob[0])
+	}
+}
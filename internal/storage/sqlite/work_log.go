@@ -0,0 +1,127 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// AddWorkEntry logs time against an issue. An entry the issue already has
+// (same actor and time) is a no-op, which keeps JSONL imports idempotent.
+// ID and CreatedAt are filled in on the passed entry.
+func (s *SQLiteStorage) AddWorkEntry(ctx context.Context, entry *types.WorkEntry) error {
+	if entry.Minutes <= 0 {
+		return fmt.Errorf("logged time must be positive, got %d minutes", entry.Minutes)
+	}
+	var exists bool
+	err := s.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM issues WHERE id = ?)`, entry.IssueID).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to check issue existence: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("issue %s not found", entry.IssueID)
+	}
+
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now().UTC()
+	}
+	result, err := s.db.ExecContext(ctx, `
+		INSERT INTO work_log (issue_id, actor, minutes, note, created_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (issue_id, actor, created_at) DO NOTHING
+	`, entry.IssueID, entry.Actor, entry.Minutes, entry.Note, entry.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert work log entry: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return s.db.QueryRowContext(ctx, `
+			SELECT id FROM work_log WHERE issue_id = ? AND actor = ? AND created_at = ?
+		`, entry.IssueID, entry.Actor, entry.CreatedAt).Scan(&entry.ID)
+	}
+	entry.ID, err = result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get work log entry ID: %w", err)
+	}
+
+	// Mark issue as dirty for JSONL export
+	if err := s.MarkIssueDirty(ctx, entry.IssueID); err != nil {
+		return fmt.Errorf("failed to mark issue dirty: %w", err)
+	}
+	return nil
+}
+
+// GetWorkLog retrieves an issue's work log, oldest first
+func (s *SQLiteStorage) GetWorkLog(ctx context.Context, issueID string) ([]*types.WorkEntry, error) {
+	result, err := s.GetWorkLogForIssues(ctx, []string{issueID})
+	if err != nil {
+		return nil, err
+	}
+	return result[issueID], nil
+}
+
+// GetWorkLogForIssues fetches the work logs of multiple issues in a single query
+// Returns a map of issue_id -> []*WorkEntry
+func (s *SQLiteStorage) GetWorkLogForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.WorkEntry, error) {
+	result := make(map[string][]*types.WorkEntry)
+	if len(issueIDs) == 0 {
+		return result, nil
+	}
+
+	placeholders := make([]interface{}, len(issueIDs))
+	for i, id := range issueIDs {
+		placeholders[i] = id
+	}
+	query := fmt.Sprintf(`
+		SELECT id, issue_id, actor, minutes, note, created_at
+		FROM work_log
+		WHERE issue_id IN (%s)
+		ORDER BY issue_id, created_at ASC, id ASC
+	`, buildPlaceholders(len(issueIDs))) // #nosec G201 -- placeholders are generated internally
+
+	rows, err := s.db.QueryContext(ctx, query, placeholders...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query work log: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		e := &types.WorkEntry{}
+		if err := rows.Scan(&e.ID, &e.IssueID, &e.Actor, &e.Minutes, &e.Note, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan work log entry: %w", err)
+		}
+		result[e.IssueID] = append(result[e.IssueID], e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating work log: %w", err)
+	}
+	return result, nil
+}
+
+// minutesLoggedByActor totals the work log per actor, leaving out deleted
+// issues
+func (s *SQLiteStorage) minutesLoggedByActor(ctx context.Context) (map[string]int, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT w.actor, SUM(w.minutes)
+		FROM work_log w
+		JOIN issues i ON i.id = w.issue_id
+		WHERE i.status != 'tombstone'
+		GROUP BY w.actor
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to total work log: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	totals := make(map[string]int)
+	for rows.Next() {
+		var actor string
+		var minutes int
+		if err := rows.Scan(&actor, &minutes); err != nil {
+			return nil, fmt.Errorf("failed to scan work log total: %w", err)
+		}
+		totals[actor] = minutes
+	}
+	return totals, rows.Err()
+}
@@ -0,0 +1,100 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestAddWorkEntry(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Flaky test", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeBug}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	e := &types.WorkEntry{IssueID: issue.ID, Actor: "alice", Minutes: 45, Note: "debugging"}
+	if err := store.AddWorkEntry(ctx, e); err != nil {
+		t.Fatalf("AddWorkEntry failed: %v", err)
+	}
+	if e.ID == 0 || e.CreatedAt.IsZero() {
+		t.Errorf("expected ID and CreatedAt to be set, got %+v", e)
+	}
+
+	// The same entry again (e.g. a re-import) keeps a single row
+	dup := &types.WorkEntry{IssueID: issue.ID, Actor: "alice", Minutes: 45, Note: "debugging", CreatedAt: e.CreatedAt}
+	if err := store.AddWorkEntry(ctx, dup); err != nil {
+		t.Fatalf("AddWorkEntry (duplicate) failed: %v", err)
+	}
+	if dup.ID != e.ID {
+		t.Errorf("duplicate got ID %d, want %d", dup.ID, e.ID)
+	}
+	if err := store.AddWorkEntry(ctx, &types.WorkEntry{IssueID: issue.ID, Actor: "claude", Minutes: 90}); err != nil {
+		t.Fatalf("AddWorkEntry failed: %v", err)
+	}
+
+	entries, err := store.GetWorkLog(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetWorkLog failed: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Note != "debugging" || entries[1].Actor != "claude" {
+		t.Errorf("unexpected work log: %+v", entries)
+	}
+	totals := types.SumWorkLog(entries)
+	if totals.Minutes != 135 || totals.ByActor["alice"] != 45 || totals.ByActor["claude"] != 90 {
+		t.Errorf("unexpected totals: %+v", totals)
+	}
+
+	byIssue, err := store.GetWorkLogForIssues(ctx, []string{issue.ID, "bd-missing"})
+	if err != nil {
+		t.Fatalf("GetWorkLogForIssues failed: %v", err)
+	}
+	if len(byIssue[issue.ID]) != 2 || len(byIssue["bd-missing"]) != 0 {
+		t.Errorf("unexpected batch result: %+v", byIssue)
+	}
+
+	if err := store.AddWorkEntry(ctx, &types.WorkEntry{IssueID: "bd-missing", Actor: "alice", Minutes: 5}); err == nil {
+		t.Error("expected error logging time on a missing issue")
+	}
+	if err := store.AddWorkEntry(ctx, &types.WorkEntry{IssueID: issue.ID, Actor: "alice", Minutes: 0}); err == nil {
+		t.Error("expected error logging zero minutes")
+	}
+}
+
+func TestStatisticsMinutesLoggedByActor(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	kept := &types.Issue{Title: "Kept", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	deleted := &types.Issue{Title: "Deleted", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, i := range []*types.Issue{kept, deleted} {
+		if err := store.CreateIssue(ctx, i, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	for _, e := range []*types.WorkEntry{
+		{IssueID: kept.ID, Actor: "alice", Minutes: 30},
+		{IssueID: kept.ID, Actor: "claude", Minutes: 20},
+		{IssueID: deleted.ID, Actor: "alice", Minutes: 60},
+	} {
+		if err := store.AddWorkEntry(ctx, e); err != nil {
+			t.Fatalf("AddWorkEntry failed: %v", err)
+		}
+	}
+	if err := store.CreateTombstone(ctx, deleted.ID, "test-user", "cleanup"); err != nil {
+		t.Fatalf("CreateTombstone failed: %v", err)
+	}
+
+	stats, err := store.GetStatistics(ctx)
+	if err != nil {
+		t.Fatalf("GetStatistics failed: %v", err)
+	}
+	if len(stats.MinutesLoggedByActor) != 2 || stats.MinutesLoggedByActor["alice"] != 30 || stats.MinutesLoggedByActor["claude"] != 20 {
+		t.Errorf("unexpected minutes by actor: %v", stats.MinutesLoggedByActor)
+	}
+}
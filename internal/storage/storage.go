@@ -107,6 +107,7 @@ type Storage interface {
 
 	// Ready Work & Blocking
 	GetReadyWork(ctx context.Context, filter types.WorkFilter) ([]*types.Issue, error)
+	ClaimReadyWork(ctx context.Context, filter types.WorkFilter, actor string) (*types.Issue, error) // Atomic select-and-claim; nil if nothing ready
 	GetBlockedIssues(ctx context.Context) ([]*types.BlockedIssue, error)
 	GetEpicsEligibleForClosure(ctx context.Context) ([]*types.EpicStatus, error)
 	GetStaleIssues(ctx context.Context, filter types.StaleFilter) ([]*types.Issue, error)
@@ -117,9 +118,35 @@ type Storage interface {
 
 	// Comments
 	AddIssueComment(ctx context.Context, issueID, author, text string) (*types.Comment, error)
+	UpdateIssueComment(ctx context.Context, commentID int64, text, actor string) (*types.Comment, error)
+	DeleteIssueComment(ctx context.Context, commentID int64, actor string) (*types.Comment, error) // Returns the deleted comment
 	GetIssueComments(ctx context.Context, issueID string) ([]*types.Comment, error)
 	GetCommentsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.Comment, error)
 
+	// Attachments (content lives with an attachment provider, not in the database)
+	AddAttachment(ctx context.Context, attachment *types.Attachment) error
+	GetAttachments(ctx context.Context, issueID string) ([]*types.Attachment, error)
+	GetAttachmentsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.Attachment, error)
+
+	// Work log (time logged against issues)
+	AddWorkEntry(ctx context.Context, entry *types.WorkEntry) error
+	GetWorkLog(ctx context.Context, issueID string) ([]*types.WorkEntry, error)
+	GetWorkLogForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.WorkEntry, error)
+
+	// Aliases (human-friendly names accepted wherever an issue ID is)
+	SetIssueAlias(ctx context.Context, issueID, alias, actor string) error
+	RemoveIssueAlias(ctx context.Context, alias, actor string) error
+	ResolveIssueAlias(ctx context.Context, alias string) (string, error) // "" if no issue has the alias
+	GetAliasesForIssues(ctx context.Context, issueIDs []string) (map[string][]string, error)
+
+	// Translations (per-locale titles and descriptions)
+	SetTranslation(ctx context.Context, issueID string, t *types.Translation, actor string) error
+	GetTranslationsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.Translation, error)
+
+	// Custom fields (values of the fields defined in field.* config)
+	SetIssueField(ctx context.Context, issueID, name, value, actor string) error
+	GetFieldsForIssues(ctx context.Context, issueIDs []string) (map[string]types.CustomFields, error)
+
 	// Statistics
 	GetStatistics(ctx context.Context) (*types.Statistics, error)
 
@@ -146,6 +173,7 @@ type Storage interface {
 	GetAllConfig(ctx context.Context) (map[string]string, error)
 	DeleteConfig(ctx context.Context, key string) error
 	GetCustomStatuses(ctx context.Context) ([]string, error) // Custom status states from status.custom config
+	GetCustomCloseReasons(ctx context.Context) ([]string, error) // Custom close reasons from close.reasons config
 
 	// Metadata (for internal state like import hashes)
 	SetMetadata(ctx context.Context, key, value string) error
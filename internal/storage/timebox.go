@@ -0,0 +1,30 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// timeboxStore is implemented by stores that know when issues were started
+type timeboxStore interface {
+	GetTimeboxStart(ctx context.Context, issueID string) (*time.Time, error)
+}
+
+// GetTimebox returns where an issue stands against its timebox, or nil if
+// it has none. The clock only runs while a spike is in progress; stores
+// without status history report the timebox as not started.
+func GetTimebox(ctx context.Context, s Storage, issue *types.Issue) (*types.Timebox, error) {
+	if issue.TimeboxMinutes == nil || *issue.TimeboxMinutes <= 0 {
+		return nil, nil
+	}
+	var started *time.Time
+	if ts, ok := s.(timeboxStore); ok && issue.Status == types.StatusInProgress {
+		var err error
+		if started, err = ts.GetTimeboxStart(ctx, issue.ID); err != nil {
+			return nil, err
+		}
+	}
+	return types.NewTimebox(*issue.TimeboxMinutes, started, time.Now()), nil
+}
@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// UpdateTranslation changes an issue's title and/or description for one
+// locale, keeping whichever of the two isn't given (nil). Clearing both
+// removes the translation.
+func UpdateTranslation(ctx context.Context, s Storage, issueID, locale string, title, description *string, actor string) error {
+	locale, err := types.NormalizeLocale(locale)
+	if err != nil {
+		return err
+	}
+	existing, err := s.GetTranslationsForIssues(ctx, []string{issueID})
+	if err != nil {
+		return err
+	}
+	t := &types.Translation{Locale: locale}
+	for _, current := range existing[issueID] {
+		if current.Locale == locale {
+			*t = *current
+		}
+	}
+	if title != nil {
+		t.Title = *title
+	}
+	if description != nil {
+		t.Description = *description
+	}
+	return s.SetTranslation(ctx, issueID, t, actor)
+}
+
+// Localize shows issues in locale: each title and description is replaced
+// by its translation, where one exists. A blank locale leaves issues as
+// they are.
+func Localize(ctx context.Context, s Storage, issues []*types.Issue, locale string) error {
+	if locale == "" || len(issues) == 0 {
+		return nil
+	}
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	translations, err := s.GetTranslationsForIssues(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, issue := range issues {
+		issue.ApplyTranslation(types.MatchTranslation(translations[issue.ID], locale))
+	}
+	return nil
+}
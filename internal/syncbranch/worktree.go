@@ -887,6 +887,13 @@ func GetRepoRoot(ctx context.Context) (string, error) {
 				if idx := strings.Index(gitDir, "/worktrees/"); idx > 0 {
 					gitDir = gitDir[:idx]
 				}
+				// A submodule's gitdir is the superproject's .git/modules/<name>;
+				// its work tree is recorded there rather than being the parent
+				if filepath.Base(gitDir) != ".git" {
+					if root, err := git.WorkTreeForGitDir(gitDir); err == nil {
+						return root, nil
+					}
+				}
 				return filepath.Dir(gitDir), nil
 			}
 		} else if info.IsDir() {
@@ -0,0 +1,138 @@
+// Package timefmt renders timestamps for human-readable CLI output.
+//
+// Timestamps are stored and exported in UTC; only display is localized. The
+// zone comes from time.zone in config.yaml (an IANA name, "local" or "UTC")
+// and the style from time.format:
+//
+//	absolute  2025-01-15 14:04 (the default)
+//	relative  2h ago, 3d ago, falling back to absolute after 30 days
+//	rfc3339   2025-01-15T14:04:05+01:00, stable for scripts parsing text output
+//
+// JSON output is unaffected and always carries RFC3339 UTC timestamps.
+package timefmt
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+)
+
+// Display styles accepted by time.format
+const (
+	StyleAbsolute = "absolute"
+	StyleRelative = "relative"
+	StyleRFC3339  = "rfc3339"
+)
+
+const (
+	absoluteLayout = "2006-01-02 15:04"
+	dateLayout     = "2006-01-02"
+	// relativeCutoff is how far back relative output is used before falling
+	// back to an absolute date, which is easier to read for old timestamps
+	relativeCutoff = 30 * 24 * time.Hour
+)
+
+// Formatter renders timestamps in a zone and style
+type Formatter struct {
+	Location *time.Location
+	Style    string
+	// Now returns the reference time for relative output (time.Now if nil)
+	Now func() time.Time
+}
+
+// New validates a zone name and style. An empty zone or "local" means the
+// machine's zone; an empty style means absolute.
+func New(zone, style string) (*Formatter, error) {
+	loc := time.Local
+	switch strings.ToLower(strings.TrimSpace(zone)) {
+	case "", "local":
+	case "utc":
+		loc = time.UTC
+	default:
+		var err error
+		if loc, err = time.LoadLocation(strings.TrimSpace(zone)); err != nil {
+			return nil, fmt.Errorf("invalid time.zone %q: %w", zone, err)
+		}
+	}
+
+	style = strings.ToLower(strings.TrimSpace(style))
+	switch style {
+	case "":
+		style = StyleAbsolute
+	case StyleAbsolute, StyleRelative, StyleRFC3339:
+	default:
+		return nil, fmt.Errorf("invalid time.format %q (valid: %s, %s, %s)", style, StyleAbsolute, StyleRelative, StyleRFC3339)
+	}
+	return &Formatter{Location: loc, Style: style}, nil
+}
+
+// FromConfig builds the Formatter configured by time.zone and time.format
+func FromConfig() (*Formatter, error) {
+	return New(config.GetString("time.zone"), config.GetString("time.format"))
+}
+
+func (f *Formatter) now() time.Time {
+	if f.Now != nil {
+		return f.Now()
+	}
+	return time.Now()
+}
+
+// Format renders a timestamp in the configured style
+func (f *Formatter) Format(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	local := t.In(f.Location)
+	switch f.Style {
+	case StyleRFC3339:
+		return local.Format(time.RFC3339)
+	case StyleRelative:
+		if rel, ok := relative(t, f.now()); ok {
+			return rel
+		}
+	}
+	return local.Format(absoluteLayout)
+}
+
+// FormatDate renders just the day in the configured zone. The rfc3339 style
+// keeps the full timestamp so text output stays machine-parseable.
+func (f *Formatter) FormatDate(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	if f.Style == StyleRFC3339 {
+		return t.In(f.Location).Format(time.RFC3339)
+	}
+	return t.In(f.Location).Format(dateLayout)
+}
+
+// relative renders t as "5m ago" (or "in 5m" for future times). ok is false
+// beyond relativeCutoff.
+func relative(t, now time.Time) (string, bool) {
+	d := now.Sub(t)
+	suffix := " ago"
+	prefix := ""
+	if d < 0 {
+		d = -d
+		suffix = ""
+		prefix = "in "
+	}
+	if d > relativeCutoff {
+		return "", false
+	}
+	var amount string
+	switch {
+	case d < time.Minute:
+		return "just now", true
+	case d < time.Hour:
+		amount = fmt.Sprintf("%dm", int(d/time.Minute))
+	case d < 24*time.Hour:
+		amount = fmt.Sprintf("%dh", int(d/time.Hour))
+	default:
+		amount = fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+	}
+	return prefix + amount + suffix, true
+}
@@ -0,0 +1,75 @@
+package timefmt
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatStyles(t *testing.T) {
+	ts := time.Date(2025, 1, 15, 13, 4, 5, 0, time.UTC)
+	now := ts.Add(2*time.Hour + 10*time.Minute)
+
+	tests := []struct {
+		zone, style string
+		want, date  string
+	}{
+		{"UTC", "", "2025-01-15 13:04", "2025-01-15"},
+		{"Europe/Berlin", "absolute", "2025-01-15 14:04", "2025-01-15"},
+		{"America/Los_Angeles", "rfc3339", "2025-01-15T05:04:05-08:00", "2025-01-15T05:04:05-08:00"},
+		{"Asia/Tokyo", "relative", "2h ago", "2025-01-15"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.zone+"/"+tt.style, func(t *testing.T) {
+			f, err := New(tt.zone, tt.style)
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+			f.Now = func() time.Time { return now }
+			if got := f.Format(ts); got != tt.want {
+				t.Errorf("Format = %q, want %q", got, tt.want)
+			}
+			if got := f.FormatDate(ts); got != tt.date {
+				t.Errorf("FormatDate = %q, want %q", got, tt.date)
+			}
+		})
+	}
+}
+
+func TestRelative(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	f, _ := New("UTC", "relative")
+	f.Now = func() time.Time { return now }
+
+	tests := []struct {
+		ago  time.Duration
+		want string
+	}{
+		{10 * time.Second, "just now"},
+		{5 * time.Minute, "5m ago"},
+		{26 * time.Hour, "1d ago"},
+		{-3 * time.Hour, "in 3h"},
+		{45 * 24 * time.Hour, "2025-01-15 12:00"}, // past the cutoff: absolute
+	}
+	for _, tt := range tests {
+		if got := f.Format(now.Add(-tt.ago)); got != tt.want {
+			t.Errorf("Format(now-%v) = %q, want %q", tt.ago, got, tt.want)
+		}
+	}
+}
+
+func TestNewRejectsInvalid(t *testing.T) {
+	if _, err := New("Mars/Olympus", ""); err == nil {
+		t.Error("expected error for unknown zone")
+	}
+	if _, err := New("", "fancy"); err == nil || !strings.Contains(err.Error(), "relative") {
+		t.Errorf("expected error listing styles, got %v", err)
+	}
+}
+
+func TestFormatZero(t *testing.T) {
+	f, _ := New("UTC", "")
+	if got := f.Format(time.Time{}); got != "-" {
+		t.Errorf("Format(zero) = %q", got)
+	}
+}
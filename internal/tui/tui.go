@@ -0,0 +1,413 @@
+// Package tui is the interactive terminal UI behind bd ui.
+//
+// The screen is a list of issues next to a detail pane that can switch to
+// the issue's dependency tree. Keys cover the edits a person supervising a
+// swarm of agents makes most: changing status and priority, claiming an
+// issue and closing it. The list refreshes on a timer, so work the agents
+// pick up or finish shows up without pressing a key.
+//
+// Reads go straight to storage; edits go through Actions, which the command
+// implements so they follow the same rules (hooks, close reasons, JSONL
+// flushing) as the equivalent bd commands.
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Reader is the part of the storage interface the UI reads from
+type Reader interface {
+	SearchIssues(ctx context.Context, query string, filter types.IssueFilter) ([]*types.Issue, error)
+	GetReadyWork(ctx context.Context, filter types.WorkFilter) ([]*types.Issue, error)
+	GetIssue(ctx context.Context, id string) (*types.Issue, error)
+	GetLabels(ctx context.Context, issueID string) ([]string, error)
+	GetDependencies(ctx context.Context, issueID string) ([]*types.Issue, error)
+	GetDependents(ctx context.Context, issueID string) ([]*types.Issue, error)
+	GetDependencyTree(ctx context.Context, issueID string, maxDepth int, showAllPaths bool, reverse bool) ([]*types.TreeNode, error)
+}
+
+// Actions are the edits the UI can make
+type Actions interface {
+	SetStatus(ctx context.Context, id string, status types.Status) error
+	SetPriority(ctx context.Context, id string, priority int) error
+	// Claim sets the issue in progress and assigns it to the current actor
+	Claim(ctx context.Context, id string) error
+	Close(ctx context.Context, id string) error
+}
+
+// Options configure the UI
+type Options struct {
+	// ReadOnly rejects every edit
+	ReadOnly bool
+	// Refresh reloads the list this often; 0 disables it
+	Refresh time.Duration
+}
+
+// View selects the issues listed
+type View int
+
+// Views, in the order tab cycles through them
+const (
+	ViewActive     View = iota // everything not closed
+	ViewReady                  // open and unblocked
+	ViewInProgress             // in progress
+	ViewBlocked                // blocked
+	ViewAll                    // including closed
+)
+
+var viewNames = []string{"active", "ready", "in progress", "blocked", "all"}
+
+func (v View) String() string { return viewNames[v] }
+
+// treeDepth limits the dependency tree view
+const treeDepth = 10
+
+var (
+	headerStyle   = lipgloss.NewStyle().Bold(true)
+	dimStyle      = lipgloss.NewStyle().Faint(true)
+	selectedStyle = lipgloss.NewStyle().Reverse(true)
+	errorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+	paneStyle     = lipgloss.NewStyle().BorderStyle(lipgloss.NormalBorder()).BorderLeft(true).PaddingLeft(1)
+
+	statusColors = map[types.Status]lipgloss.Color{
+		types.StatusOpen:       lipgloss.Color("7"),
+		types.StatusInProgress: lipgloss.Color("3"),
+		types.StatusBlocked:    lipgloss.Color("1"),
+		types.StatusResolved:   lipgloss.Color("6"),
+		types.StatusClosed:     lipgloss.Color("2"),
+	}
+)
+
+type refreshMsg struct{}
+
+// Model is the bubbletea model for bd ui
+type Model struct {
+	ctx     context.Context
+	reader  Reader
+	actions Actions
+	opts    Options
+
+	view     View
+	all      []*types.Issue // the view's issues before the text filter
+	issues   []*types.Issue
+	cursor   int
+	offset   int
+	filter   string
+	typing   bool // reading a filter after "/"
+	showTree bool
+	showHelp bool
+
+	detail     *types.Issue
+	dependsOn  []*types.Issue
+	dependents []*types.Issue
+	tree       []*types.TreeNode
+
+	message string
+	err     error
+	width   int
+	height  int
+}
+
+// New creates the model and loads the first view
+func New(ctx context.Context, reader Reader, actions Actions, opts Options) *Model {
+	m := &Model{ctx: ctx, reader: reader, actions: actions, opts: opts, width: 100, height: 30}
+	m.reload()
+	return m
+}
+
+// Init starts the refresh timer
+func (m *Model) Init() tea.Cmd {
+	return m.tick()
+}
+
+func (m *Model) tick() tea.Cmd {
+	if m.opts.Refresh <= 0 {
+		return nil
+	}
+	return tea.Tick(m.opts.Refresh, func(time.Time) tea.Msg { return refreshMsg{} })
+}
+
+// Update handles keys, resizes and refreshes
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.scroll()
+	case refreshMsg:
+		m.reload()
+		return m, m.tick()
+	case tea.KeyMsg:
+		if m.typing {
+			m.filterKey(msg)
+			return m, nil
+		}
+		return m, m.key(msg.String())
+	}
+	return m, nil
+}
+
+func (m *Model) key(key string) tea.Cmd {
+	m.message, m.err = "", nil
+	if m.showHelp && key != "ctrl+c" {
+		m.showHelp = false
+		return nil
+	}
+	switch key {
+	case "q", "ctrl+c":
+		return tea.Quit
+	case "?":
+		m.showHelp = true
+	case "j", "down":
+		m.move(1)
+	case "k", "up":
+		m.move(-1)
+	case "ctrl+d", "pgdown":
+		m.move(m.listHeight() / 2)
+	case "ctrl+u", "pgup":
+		m.move(-m.listHeight() / 2)
+	case "g", "home":
+		m.move(-len(m.issues))
+	case "G", "end":
+		m.move(len(m.issues))
+	case "tab":
+		m.view = (m.view + 1) % View(len(viewNames))
+		m.cursor = 0
+		m.reload()
+	case "shift+tab":
+		m.view = (m.view + View(len(viewNames)) - 1) % View(len(viewNames))
+		m.cursor = 0
+		m.reload()
+	case "/":
+		m.typing = true
+	case "esc":
+		m.filter = ""
+		m.applyFilter()
+	case "enter", "t":
+		m.showTree = !m.showTree
+		m.loadDetail()
+	case "r":
+		m.reload()
+		m.message = "Refreshed"
+	case "o":
+		m.edit("set to open", func(id string) error { return m.actions.SetStatus(m.ctx, id, types.StatusOpen) })
+	case "i":
+		m.edit("set in progress", func(id string) error { return m.actions.SetStatus(m.ctx, id, types.StatusInProgress) })
+	case "b":
+		m.edit("set to blocked", func(id string) error { return m.actions.SetStatus(m.ctx, id, types.StatusBlocked) })
+	case "x":
+		m.edit("closed", func(id string) error { return m.actions.Close(m.ctx, id) })
+	case "c":
+		m.edit("claimed", func(id string) error { return m.actions.Claim(m.ctx, id) })
+	case "0", "1", "2", "3", "4":
+		priority := int(key[0] - '0')
+		m.edit(fmt.Sprintf("set to P%d", priority), func(id string) error { return m.actions.SetPriority(m.ctx, id, priority) })
+	case "+", "-":
+		if issue := m.selected(); issue != nil {
+			priority := issue.Priority + 1
+			if key == "+" {
+				priority = issue.Priority - 1
+			}
+			if priority < 0 || priority > 4 {
+				m.message = fmt.Sprintf("%s is already P%d", issue.ID, issue.Priority)
+				return nil
+			}
+			m.edit(fmt.Sprintf("set to P%d", priority), func(id string) error { return m.actions.SetPriority(m.ctx, id, priority) })
+		}
+	}
+	return nil
+}
+
+func (m *Model) filterKey(msg tea.KeyMsg) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.typing = false
+	case tea.KeyEsc:
+		m.typing = false
+		m.filter = ""
+	case tea.KeyBackspace:
+		if runes := []rune(m.filter); len(runes) > 0 {
+			m.filter = string(runes[:len(runes)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.filter += string(msg.Runes)
+	}
+	m.cursor = 0
+	m.applyFilter()
+}
+
+// edit applies an action to the selected issue and reloads
+func (m *Model) edit(done string, action func(id string) error) {
+	issue := m.selected()
+	if issue == nil {
+		return
+	}
+	if m.opts.ReadOnly {
+		m.err = fmt.Errorf("read-only mode")
+		return
+	}
+	if err := action(issue.ID); err != nil {
+		m.err = err
+		return
+	}
+	m.message = fmt.Sprintf("%s %s", issue.ID, done)
+	m.reload()
+	// Keep the edited issue selected when it is still listed
+	for i, listed := range m.issues {
+		if listed.ID == issue.ID {
+			m.cursor = i
+			m.scroll()
+			m.loadDetail()
+		}
+	}
+}
+
+// reload fetches the current view and the selected issue's details
+func (m *Model) reload() {
+	var selectedID string
+	if issue := m.selected(); issue != nil {
+		selectedID = issue.ID
+	}
+	issues, err := m.load()
+	if err != nil {
+		m.err = err
+		return
+	}
+	m.all = issues
+	m.applyFilter()
+	for i, issue := range m.issues {
+		if issue.ID == selectedID {
+			m.cursor = i
+		}
+	}
+	m.scroll()
+	m.loadDetail()
+}
+
+func (m *Model) load() ([]*types.Issue, error) {
+	notEphemeral := false
+	filter := types.IssueFilter{Ephemeral: &notEphemeral}
+	switch m.view {
+	case ViewReady:
+		return m.reader.GetReadyWork(m.ctx, types.WorkFilter{Status: types.StatusOpen})
+	case ViewInProgress:
+		status := types.StatusInProgress
+		filter.Status = &status
+	case ViewBlocked:
+		status := types.StatusBlocked
+		filter.Status = &status
+	}
+	issues, err := m.reader.SearchIssues(m.ctx, "", filter)
+	if err != nil || m.view != ViewActive {
+		return issues, err
+	}
+	active := issues[:0]
+	for _, issue := range issues {
+		if issue.Status != types.StatusClosed {
+			active = append(active, issue)
+		}
+	}
+	return active, nil
+}
+
+func (m *Model) applyFilter() {
+	m.issues = m.all
+	if m.filter != "" {
+		needle := strings.ToLower(m.filter)
+		m.issues = nil
+		for _, issue := range m.all {
+			if strings.Contains(strings.ToLower(issue.ID+" "+issue.Title), needle) {
+				m.issues = append(m.issues, issue)
+			}
+		}
+	}
+	if m.cursor >= len(m.issues) {
+		m.cursor = len(m.issues) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+	m.scroll()
+	m.loadDetail()
+}
+
+func (m *Model) loadDetail() {
+	m.detail, m.dependsOn, m.dependents, m.tree = nil, nil, nil, nil
+	issue := m.selected()
+	if issue == nil {
+		return
+	}
+	var err error
+	if m.detail, err = m.reader.GetIssue(m.ctx, issue.ID); err != nil {
+		m.err = err
+		return
+	}
+	if m.detail == nil {
+		return
+	}
+	if m.detail.Labels, err = m.reader.GetLabels(m.ctx, issue.ID); err != nil {
+		m.err = err
+		return
+	}
+	if m.showTree {
+		m.tree, err = m.reader.GetDependencyTree(m.ctx, issue.ID, treeDepth, false, false)
+	} else {
+		m.dependsOn, err = m.reader.GetDependencies(m.ctx, issue.ID)
+		if err == nil {
+			m.dependents, err = m.reader.GetDependents(m.ctx, issue.ID)
+		}
+	}
+	if err != nil {
+		m.err = err
+	}
+}
+
+func (m *Model) selected() *types.Issue {
+	if m.cursor < 0 || m.cursor >= len(m.issues) {
+		return nil
+	}
+	return m.issues[m.cursor]
+}
+
+func (m *Model) move(delta int) {
+	if len(m.issues) == 0 {
+		return
+	}
+	m.cursor += delta
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+	if m.cursor >= len(m.issues) {
+		m.cursor = len(m.issues) - 1
+	}
+	m.scroll()
+	m.loadDetail()
+}
+
+// scroll keeps the cursor inside the visible part of the list
+func (m *Model) scroll() {
+	height := m.listHeight()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+height {
+		m.offset = m.cursor - height + 1
+	}
+	if m.offset < 0 {
+		m.offset = 0
+	}
+}
+
+// listHeight is the number of list rows between the header and status lines
+func (m *Model) listHeight() int {
+	if m.height < 4 {
+		return 1
+	}
+	return m.height - 3
+}
@@ -0,0 +1,198 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// storeActions applies edits straight to the store and records them
+type storeActions struct {
+	store *sqlite.SQLiteStorage
+	calls []string
+	fail  error
+}
+
+func (a *storeActions) update(ctx context.Context, id, call string, updates map[string]interface{}) error {
+	a.calls = append(a.calls, call)
+	if a.fail != nil {
+		return a.fail
+	}
+	return a.store.UpdateIssue(ctx, id, updates, "test")
+}
+
+func (a *storeActions) SetStatus(ctx context.Context, id string, status types.Status) error {
+	return a.update(ctx, id, "status "+id+" "+string(status), map[string]interface{}{"status": string(status)})
+}
+
+func (a *storeActions) SetPriority(ctx context.Context, id string, priority int) error {
+	return a.update(ctx, id, fmt.Sprintf("priority %s %d", id, priority), map[string]interface{}{"priority": priority})
+}
+
+func (a *storeActions) Claim(ctx context.Context, id string) error {
+	return a.update(ctx, id, "claim "+id, map[string]interface{}{"status": string(types.StatusInProgress), "assignee": "test"})
+}
+
+func (a *storeActions) Close(ctx context.Context, id string) error {
+	a.calls = append(a.calls, "close "+id)
+	if a.fail != nil {
+		return a.fail
+	}
+	return a.store.CloseIssue(ctx, id, "Closed", "test")
+}
+
+func newTestModel(t *testing.T, opts Options) (*Model, *storeActions) {
+	t.Helper()
+	ctx := context.Background()
+	store, err := sqlite.New(ctx, filepath.Join(t.TempDir(), "beads.db"))
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	if err := store.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+		t.Fatalf("failed to set prefix: %v", err)
+	}
+	for _, issue := range []*types.Issue{
+		{ID: "bd-1", Title: "Build the parser", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask},
+		{ID: "bd-2", Title: "Write the docs", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+		{ID: "bd-3", Title: "Old work", Status: types.StatusClosed, Priority: 2, IssueType: types.TypeTask},
+	} {
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: "bd-2", DependsOnID: "bd-1", Type: types.DepBlocks}, "test"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+	if err := store.AddLabel(ctx, "bd-1", "backend", "test"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+
+	actions := &storeActions{store: store}
+	m := New(ctx, store, actions, opts)
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	return m, actions
+}
+
+func press(m *Model, keys ...string) {
+	for _, key := range keys {
+		var msg tea.KeyMsg
+		switch key {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		case "tab":
+			msg = tea.KeyMsg{Type: tea.KeyTab}
+		default:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+		}
+		m.Update(msg)
+	}
+}
+
+func listed(m *Model) string {
+	var ids []string
+	for _, issue := range m.issues {
+		ids = append(ids, issue.ID)
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestViews(t *testing.T) {
+	m, _ := newTestModel(t, Options{})
+	if got := listed(m); got != "bd-1,bd-2" {
+		t.Fatalf("active view lists %s, want bd-1,bd-2", got)
+	}
+	press(m, "tab")
+	if m.view != ViewReady || listed(m) != "bd-1" {
+		t.Errorf("ready view lists %s, want bd-1", listed(m))
+	}
+	press(m, "tab", "tab", "tab")
+	if m.view != ViewAll || len(m.issues) != 3 {
+		t.Errorf("all view lists %s", listed(m))
+	}
+
+	press(m, "/", "d", "o", "c", "s", "enter")
+	if listed(m) != "bd-2" {
+		t.Errorf("filter lists %s, want bd-2", listed(m))
+	}
+	press(m, "esc")
+	if len(m.issues) != 3 {
+		t.Errorf("esc should clear the filter, got %s", listed(m))
+	}
+}
+
+func TestDetailAndTree(t *testing.T) {
+	m, _ := newTestModel(t, Options{})
+	view := m.View()
+	for _, want := range []string{"bd-1: Build the parser", "backend", "Blocks", "Write the docs"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("detail pane missing %q:\n%s", want, view)
+		}
+	}
+
+	press(m, "j", "enter")
+	if !m.showTree || len(m.tree) != 2 {
+		t.Fatalf("tree for bd-2 = %d nodes, want 2", len(m.tree))
+	}
+	if view := m.View(); !strings.Contains(view, "Dependency tree") || !strings.Contains(view, "└ ☐ bd-1") {
+		t.Errorf("tree not rendered:\n%s", view)
+	}
+}
+
+func TestEdits(t *testing.T) {
+	m, actions := newTestModel(t, Options{})
+
+	// "+" at P0 is refused without calling the action
+	press(m, "j", "i", "0", "+", "-", "c")
+	want := []string{"status bd-2 in_progress", "priority bd-2 0", "priority bd-2 1", "claim bd-2"}
+	if strings.Join(actions.calls, "; ") != strings.Join(want, "; ") {
+		t.Errorf("calls = %v, want %v", actions.calls, want)
+	}
+	if !strings.Contains(m.message, "bd-2 claimed") {
+		t.Errorf("message = %q", m.message)
+	}
+	if selected := m.selected(); selected == nil || selected.ID != "bd-2" || selected.Assignee != "test" {
+		t.Errorf("selection after claim = %+v", selected)
+	}
+
+	press(m, "x")
+	if listed(m) != "bd-1" {
+		t.Errorf("closed issue still listed in the active view: %s", listed(m))
+	}
+
+	actions.fail = fmt.Errorf("boom")
+	press(m, "b")
+	if m.err == nil || !strings.Contains(m.View(), "Error: boom") {
+		t.Errorf("action error not shown")
+	}
+}
+
+func TestReadOnly(t *testing.T) {
+	m, actions := newTestModel(t, Options{ReadOnly: true})
+	press(m, "x", "c", "3")
+	if len(actions.calls) != 0 {
+		t.Errorf("read-only UI made edits: %v", actions.calls)
+	}
+	if m.err == nil {
+		t.Errorf("expected a read-only error")
+	}
+}
+
+func TestQuit(t *testing.T) {
+	m, _ := newTestModel(t, Options{})
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	if cmd == nil {
+		t.Fatal("q should quit")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Errorf("q returned %T, want tea.QuitMsg", cmd())
+	}
+}
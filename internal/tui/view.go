@@ -0,0 +1,209 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+const helpText = `Keys
+
+  j/k, ↓/↑       move          g/G        first/last
+  pgdn/pgup      page          tab        next view (shift+tab: previous)
+  /              filter        esc        clear filter
+  enter, t       toggle dependency tree
+  r              refresh
+
+  o / i / b      set open / in progress / blocked
+  x              close
+  c              claim (in progress, assigned to you)
+  0-4            set priority  + / -      raise / lower priority
+
+  ?              help          q          quit
+
+Press any key to return.`
+
+// View renders the screen
+func (m *Model) View() string {
+	if m.showHelp {
+		return helpText
+	}
+	listWidth := m.width * 2 / 5
+	if listWidth < 30 {
+		listWidth = 30
+	}
+	detailWidth := m.width - listWidth - 2 // border and padding
+	if detailWidth < 20 {
+		detailWidth = 20
+	}
+
+	list := lipgloss.NewStyle().Width(listWidth).Height(m.listHeight()).Render(m.renderList(listWidth))
+	detail := paneStyle.Width(detailWidth).Height(m.listHeight()).Render(m.renderDetail(detailWidth))
+	return lipgloss.JoinVertical(lipgloss.Left,
+		m.renderHeader(),
+		lipgloss.JoinHorizontal(lipgloss.Top, list, detail),
+		m.renderStatus(),
+	)
+}
+
+func (m *Model) renderHeader() string {
+	header := headerStyle.Render(fmt.Sprintf("bd ui · %s (%d)", m.view, len(m.issues)))
+	if m.typing {
+		header += "  /" + m.filter + "█"
+	} else if m.filter != "" {
+		header += dimStyle.Render("  filter: " + m.filter)
+	}
+	if m.opts.ReadOnly {
+		header += dimStyle.Render("  read-only")
+	}
+	return header
+}
+
+func (m *Model) renderList(width int) string {
+	if len(m.issues) == 0 {
+		return dimStyle.Render("No issues")
+	}
+	var lines []string
+	end := m.offset + m.listHeight()
+	if end > len(m.issues) {
+		end = len(m.issues)
+	}
+	for i := m.offset; i < end; i++ {
+		issue := m.issues[i]
+		line := truncate(fmt.Sprintf("%s P%d %s %s", statusSymbol(issue.Status), issue.Priority, issue.ID, issue.Title), width)
+		if i == m.cursor {
+			line = selectedStyle.Render(pad(line, width))
+		} else {
+			line = statusStyle(issue.Status).Render(line)
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m *Model) renderDetail(width int) string {
+	issue := m.detail
+	if issue == nil {
+		return ""
+	}
+	if m.showTree {
+		return m.renderTree(width)
+	}
+
+	var b strings.Builder
+	b.WriteString(headerStyle.Render(truncate(issue.ID+": "+issue.Title, width)) + "\n\n")
+	field := func(name, value string) {
+		if value != "" {
+			fmt.Fprintf(&b, "%s %s\n", dimStyle.Render(fmt.Sprintf("%-9s", name)), value)
+		}
+	}
+	field("Status", statusStyle(issue.Status).Render(string(issue.Status)))
+	field("Priority", fmt.Sprintf("P%d", issue.Priority))
+	field("Type", string(issue.IssueType))
+	field("Assignee", issue.Assignee)
+	field("Labels", strings.Join(issue.Labels, ", "))
+	field("Updated", issue.UpdatedAt.Local().Format("2006-01-02 15:04"))
+	if issue.CloseReason != "" {
+		field("Closed", issue.CloseReason)
+	}
+
+	section := func(title, text string) {
+		if text = strings.TrimSpace(text); text != "" {
+			b.WriteString("\n" + headerStyle.Render(title) + "\n" + lipgloss.NewStyle().Width(width).Render(text) + "\n")
+		}
+	}
+	section("Description", issue.Description)
+	section("Acceptance", issue.AcceptanceCriteria)
+	section("Notes", issue.Notes)
+
+	deps := func(title string, issues []*types.Issue) {
+		if len(issues) == 0 {
+			return
+		}
+		b.WriteString("\n" + headerStyle.Render(title) + "\n")
+		for _, dep := range issues {
+			b.WriteString(statusStyle(dep.Status).Render(truncate(fmt.Sprintf("  %s %s %s", statusSymbol(dep.Status), dep.ID, dep.Title), width)) + "\n")
+		}
+	}
+	deps("Depends on", m.dependsOn)
+	deps("Blocks", m.dependents)
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// renderTree shows what the selected issue depends on, indented by depth
+func (m *Model) renderTree(width int) string {
+	var b strings.Builder
+	b.WriteString(headerStyle.Render("Dependency tree") + dimStyle.Render("  (enter: details)") + "\n\n")
+	if len(m.tree) <= 1 {
+		b.WriteString(dimStyle.Render(m.detail.ID + " has no dependencies"))
+		return b.String()
+	}
+	for _, node := range m.tree {
+		indent := strings.Repeat("  ", node.Depth)
+		if node.Depth > 0 {
+			indent = strings.Repeat("  ", node.Depth-1) + "└ "
+		}
+		line := truncate(fmt.Sprintf("%s%s %s %s", indent, statusSymbol(node.Status), node.ID, node.Title), width)
+		if node.Truncated {
+			line = truncate(line+" …", width)
+		}
+		b.WriteString(statusStyle(node.Status).Render(line) + "\n")
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func (m *Model) renderStatus() string {
+	switch {
+	case m.err != nil:
+		return errorStyle.Render("Error: " + m.err.Error())
+	case m.message != "":
+		return m.message
+	}
+	return dimStyle.Render("tab view · / filter · enter tree · o/i/b status · 0-4 priority · c claim · x close · ? help · q quit")
+}
+
+// statusSymbol matches the symbols bd dep tree uses
+func statusSymbol(status types.Status) string {
+	switch status {
+	case types.StatusOpen:
+		return "☐"
+	case types.StatusInProgress:
+		return "◧"
+	case types.StatusBlocked:
+		return "⚠"
+	case types.StatusResolved:
+		return "◨"
+	case types.StatusClosed:
+		return "☑"
+	default:
+		return "?"
+	}
+}
+
+func statusStyle(status types.Status) lipgloss.Style {
+	if c, ok := statusColors[status]; ok {
+		return lipgloss.NewStyle().Foreground(c)
+	}
+	return lipgloss.NewStyle()
+}
+
+// truncate cuts s to width columns, ending it with an ellipsis when cut
+func truncate(s string, width int) string {
+	if lipgloss.Width(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && lipgloss.Width(string(runes)) > width-1 {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
+
+func pad(s string, width int) string {
+	if n := width - lipgloss.Width(s); n > 0 {
+		return s + strings.Repeat(" ", n)
+	}
+	return s
+}
@@ -0,0 +1,25 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// MaxAliasLength bounds issue aliases so they stay short enough to type
+const MaxAliasLength = 64
+
+// aliasPattern allows lowercase words joined by single hyphens or
+// underscores, e.g. "auth-refactor". Dots are excluded so an alias never
+// looks like a hierarchical child ID.
+var aliasPattern = regexp.MustCompile(`^[a-z][a-z0-9]*([-_][a-z0-9]+)*$`)
+
+// ValidateAlias checks that alias is usable as a human-friendly issue ID
+func ValidateAlias(alias string) error {
+	if len(alias) > MaxAliasLength {
+		return fmt.Errorf("alias %q is longer than %d characters", alias, MaxAliasLength)
+	}
+	if !aliasPattern.MatchString(alias) {
+		return fmt.Errorf("invalid alias %q: use lowercase letters, digits and single hyphens or underscores, starting with a letter", alias)
+	}
+	return nil
+}
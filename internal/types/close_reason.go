@@ -0,0 +1,80 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Built-in close reasons. Projects can add their own via
+// bd config set close.reasons "reason1,reason2,..."
+const (
+	CloseReasonFixed     = "fixed"
+	CloseReasonWontfix   = "wontfix"
+	CloseReasonDuplicate = "duplicate"
+	CloseReasonObsolete  = "obsolete"
+)
+
+// CloseReasonOther groups closed issues whose reason is free text rather than
+// one of the configured reasons
+const CloseReasonOther = "other"
+
+// BuiltinCloseReasons lists the close reasons available in every project
+var BuiltinCloseReasons = []string{CloseReasonFixed, CloseReasonWontfix, CloseReasonDuplicate, CloseReasonObsolete}
+
+// CloseReasons returns the built-in reasons followed by any custom ones
+func CloseReasons(custom []string) []string {
+	reasons := append([]string{}, BuiltinCloseReasons...)
+	for _, r := range custom {
+		if !containsString(reasons, r) {
+			reasons = append(reasons, r)
+		}
+	}
+	return reasons
+}
+
+// CloseReasonCategory returns the taxonomy reason a stored close_reason
+// belongs to. Reasons are stored as "<reason>" or "<reason>: <note>"; anything
+// else is free text and reported as CloseReasonOther.
+func CloseReasonCategory(closeReason string, custom []string) string {
+	category := closeReason
+	if idx := strings.Index(closeReason, ":"); idx >= 0 {
+		category = closeReason[:idx]
+	}
+	if containsString(CloseReasons(custom), category) {
+		return category
+	}
+	return CloseReasonOther
+}
+
+// ResolveCloseReason validates reason against the taxonomy and combines it
+// with note into the value stored in close_reason. When required is false,
+// reasons outside the taxonomy are kept as free text for compatibility.
+// Returns "" if neither reason nor note is given.
+func ResolveCloseReason(reason, note string, custom []string, required bool) (string, error) {
+	reason = strings.TrimSpace(reason)
+	note = strings.TrimSpace(note)
+	valid := CloseReasons(custom)
+
+	if reason == "" {
+		if required {
+			return "", fmt.Errorf("a close reason is required (valid: %s)", strings.Join(valid, ", "))
+		}
+		return note, nil
+	}
+	if required && !containsString(valid, reason) {
+		return "", fmt.Errorf("invalid close reason %q (valid: %s)", reason, strings.Join(valid, ", "))
+	}
+	if note == "" {
+		return reason, nil
+	}
+	return reason + ": " + note, nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
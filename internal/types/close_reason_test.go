@@ -0,0 +1,52 @@
+package types
+
+import "testing"
+
+func TestResolveCloseReason(t *testing.T) {
+	custom := []string{"moved_upstream"}
+	tests := []struct {
+		name     string
+		reason   string
+		note     string
+		required bool
+		want     string
+		wantErr  bool
+	}{
+		{"empty", "", "", false, "", false},
+		{"note only", "", "done", false, "done", false},
+		{"builtin", "wontfix", "", false, "wontfix", false},
+		{"builtin with note", "duplicate", "see bd-1", false, "duplicate: see bd-1", false},
+		{"custom", "moved_upstream", "", true, "moved_upstream", false},
+		{"free text allowed", "done in abc123", "", false, "done in abc123", false},
+		{"free text rejected when required", "done in abc123", "", true, "", true},
+		{"missing when required", "", "note", true, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveCloseReason(tt.reason, tt.note, custom, tt.required)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveCloseReason() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveCloseReason() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCloseReasonCategory(t *testing.T) {
+	custom := []string{"moved_upstream"}
+	tests := map[string]string{
+		"fixed":                 CloseReasonFixed,
+		"wontfix: out of scope": CloseReasonWontfix,
+		"moved_upstream":        "moved_upstream",
+		"Closed":                CloseReasonOther,
+		"":                      CloseReasonOther,
+		"note: with colon":      CloseReasonOther,
+	}
+	for reason, want := range tests {
+		if got := CloseReasonCategory(reason, custom); got != want {
+			t.Errorf("CloseReasonCategory(%q) = %q, want %q", reason, got, want)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package types
+
+import (
+	"sort"
+	"strings"
+)
+
+// Definition of done: completion criteria attached to labels with
+// bd config set done.<label> "criterion, criterion, ...". done.strict makes
+// bd close refuse issues with unconfirmed criteria.
+const (
+	DoneConfigPrefix = "done."
+	DoneStrictKey    = "done.strict"
+)
+
+// DoneCommentPrefix starts the comment that records which criteria were
+// confirmed, and by whom (its author). Like the resolution comment it is
+// exported to JSONL, so confirmations travel with the issue across clones.
+const DoneCommentPrefix = "Done: "
+
+// DoneDefinition maps a label to its completion criteria, in config order
+type DoneDefinition map[string][]string
+
+// DoneCriterion is one completion criterion of an issue and whether it has
+// been confirmed
+type DoneCriterion struct {
+	Label       string `json:"label"`
+	Criterion   string `json:"criterion"`
+	Done        bool   `json:"done"`
+	ConfirmedBy string `json:"confirmed_by,omitempty"`
+}
+
+// ParseDoneDefinition reads the done.<label> keys of config
+func ParseDoneDefinition(config map[string]string) DoneDefinition {
+	def := make(DoneDefinition)
+	for key, value := range config {
+		label, ok := strings.CutPrefix(key, DoneConfigPrefix)
+		if !ok || key == DoneStrictKey || label == "" {
+			continue
+		}
+		var criteria []string
+		for _, c := range strings.Split(value, ",") {
+			if c = strings.TrimSpace(c); c != "" && !containsFold(criteria, c) {
+				criteria = append(criteria, c)
+			}
+		}
+		if len(criteria) > 0 {
+			def[label] = criteria
+		}
+	}
+	return def
+}
+
+// Criteria returns the completion criteria of an issue carrying labels,
+// ordered by label and then as configured, with their confirmations from
+// comments. A criterion shared by several labels is listed once, under the
+// first of them.
+func (d DoneDefinition) Criteria(labels []string, comments []*Comment) []DoneCriterion {
+	sorted := append([]string{}, labels...)
+	sort.Strings(sorted)
+	confirmed := DoneConfirmations(comments)
+
+	var result []DoneCriterion
+	seen := make(map[string]bool)
+	for _, label := range sorted {
+		for _, c := range d[label] {
+			key := strings.ToLower(c)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			by, done := confirmed[key]
+			result = append(result, DoneCriterion{Label: label, Criterion: c, Done: done, ConfirmedBy: by})
+		}
+	}
+	return result
+}
+
+// DoneConfirmations returns who confirmed each criterion, keyed by the
+// criterion in lower case. Later confirmations win.
+func DoneConfirmations(comments []*Comment) map[string]string {
+	confirmed := make(map[string]string)
+	for _, comment := range comments {
+		text, ok := strings.CutPrefix(comment.Text, DoneCommentPrefix)
+		if !ok {
+			continue
+		}
+		for _, c := range strings.Split(text, ",") {
+			if c = strings.TrimSpace(c); c != "" {
+				confirmed[strings.ToLower(c)] = comment.Author
+			}
+		}
+	}
+	return confirmed
+}
+
+// DoneComment returns the comment text confirming criteria
+func DoneComment(criteria []string) string {
+	return DoneCommentPrefix + strings.Join(criteria, ", ")
+}
+
+// PendingCriteria returns the criteria not yet confirmed
+func PendingCriteria(criteria []DoneCriterion) []string {
+	var pending []string
+	for _, c := range criteria {
+		if !c.Done {
+			pending = append(pending, c.Criterion)
+		}
+	}
+	return pending
+}
+
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,42 @@
+package types
+
+import "testing"
+
+func TestDoneDefinitionCriteria(t *testing.T) {
+	def := ParseDoneDefinition(map[string]string{
+		"done.backend":  "tests added, reviewer approved,",
+		"done.api":      "docs updated, Tests added",
+		"done.strict":   "true",
+		"done.":         "ignored",
+		"close.reasons": "moved",
+	})
+	if len(def) != 2 || len(def["backend"]) != 2 {
+		t.Fatalf("definition = %v", def)
+	}
+
+	comments := []*Comment{
+		{Author: "alice", Text: DoneComment([]string{"tests added"})},
+		{Author: "bob", Text: "Done: Docs Updated"},
+		{Author: "carol", Text: "done: reviewer approved"}, // Not the recorded prefix
+	}
+	criteria := def.Criteria([]string{"backend", "api", "ui"}, comments)
+	want := []DoneCriterion{
+		{Label: "api", Criterion: "docs updated", Done: true, ConfirmedBy: "bob"},
+		{Label: "api", Criterion: "Tests added", Done: true, ConfirmedBy: "alice"},
+		{Label: "backend", Criterion: "reviewer approved"},
+	}
+	if len(criteria) != len(want) {
+		t.Fatalf("criteria = %+v", criteria)
+	}
+	for i := range want {
+		if criteria[i] != want[i] {
+			t.Errorf("criteria[%d] = %+v, want %+v", i, criteria[i], want[i])
+		}
+	}
+	if pending := PendingCriteria(criteria); len(pending) != 1 || pending[0] != "reviewer approved" {
+		t.Errorf("pending = %v", pending)
+	}
+	if got := def.Criteria([]string{"ui"}, nil); len(got) != 0 {
+		t.Errorf("unconfigured label has criteria %v", got)
+	}
+}
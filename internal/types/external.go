@@ -0,0 +1,54 @@
+package types
+
+import (
+	"strings"
+	"time"
+)
+
+// ParseQualifiedID splits an ID qualified with a linked workspace's name
+// (api/bd-42) into the workspace and the issue's ID there. Local issue IDs
+// never contain a slash.
+func ParseQualifiedID(id string) (workspace, localID string, ok bool) {
+	workspace, localID, found := strings.Cut(id, "/")
+	if !found || workspace == "" || localID == "" || strings.ContainsAny(id, " \t\n") {
+		return "", "", false
+	}
+	return workspace, localID, true
+}
+
+// IsQualifiedID reports whether id names an issue in a linked workspace
+func IsQualifiedID(id string) bool {
+	_, _, ok := ParseQualifiedID(id)
+	return ok
+}
+
+// ExternalIssue is the last known state of an issue in a linked workspace
+type ExternalIssue struct {
+	ID        string    `json:"id"` // Qualified: workspace/local-id
+	Status    Status    `json:"status"`
+	Title     string    `json:"title"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// ExternalDependency is a dependency on an issue in a linked workspace
+type ExternalDependency struct {
+	DependsOnID string         `json:"depends_on_id"`
+	Type        DependencyType `json:"type"`
+	Issue       *ExternalIssue `json:"issue,omitempty"` // nil until found in the workspace
+}
+
+// Blocking reports whether the dependency holds its issue back: a blocks
+// dependency whose target is unresolved or not yet done
+func (d *ExternalDependency) Blocking() bool {
+	if d.Type != DepBlocks {
+		return false
+	}
+	if d.Issue == nil {
+		return true
+	}
+	switch d.Issue.Status {
+	case StatusOpen, StatusInProgress, StatusBlocked:
+		return true
+	}
+	return false
+}
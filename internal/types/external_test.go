@@ -0,0 +1,42 @@
+package types
+
+import "testing"
+
+func TestParseQualifiedID(t *testing.T) {
+	tests := []struct {
+		id, workspace, localID string
+		ok                     bool
+	}{
+		{"api/bd-42", "api", "bd-42", true},
+		{"my-service/ms-a3f.1", "my-service", "ms-a3f.1", true},
+		{"bd-42", "", "", false},
+		{"/bd-42", "", "", false},
+		{"api/", "", "", false},
+		{"api/bd 42", "", "", false},
+	}
+	for _, tt := range tests {
+		workspace, localID, ok := ParseQualifiedID(tt.id)
+		if workspace != tt.workspace || localID != tt.localID || ok != tt.ok {
+			t.Errorf("ParseQualifiedID(%q) = %q, %q, %v; want %q, %q, %v",
+				tt.id, workspace, localID, ok, tt.workspace, tt.localID, tt.ok)
+		}
+	}
+}
+
+func TestExternalDependencyBlocking(t *testing.T) {
+	tests := []struct {
+		name string
+		dep  ExternalDependency
+		want bool
+	}{
+		{"unresolved", ExternalDependency{Type: DepBlocks}, true},
+		{"open", ExternalDependency{Type: DepBlocks, Issue: &ExternalIssue{Status: StatusInProgress}}, true},
+		{"closed", ExternalDependency{Type: DepBlocks, Issue: &ExternalIssue{Status: StatusClosed}}, false},
+		{"related", ExternalDependency{Type: DepRelated}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.dep.Blocking(); got != tt.want {
+			t.Errorf("%s: Blocking() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
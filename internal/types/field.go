@@ -0,0 +1,147 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FieldType is the type of a custom field's values
+type FieldType string
+
+// Custom field types
+const (
+	FieldEnum   FieldType = "enum"   // One of a fixed list of values
+	FieldInt    FieldType = "int"    // Whole number
+	FieldString FieldType = "string" // Free text
+	FieldDate   FieldType = "date"   // Calendar date, stored as YYYY-MM-DD
+)
+
+// IsValid checks if the field type is one of the supported types
+func (t FieldType) IsValid() bool {
+	switch t {
+	case FieldEnum, FieldInt, FieldString, FieldDate:
+		return true
+	}
+	return false
+}
+
+// FieldDef is a project-defined custom field (bd field define). Projects
+// keep their definitions in config, as field.<name> = "<type>" or, for
+// enums, "enum:<value>,<value>,...".
+type FieldDef struct {
+	Name   string    `json:"name"`
+	Type   FieldType `json:"type"`
+	Values []string  `json:"values,omitempty"` // Allowed values of an enum
+}
+
+// CustomFields holds an issue's custom field values by field name, in the
+// canonical form FieldDef.Normalize returns
+type CustomFields map[string]string
+
+// fieldNamePattern allows lowercase words joined by single hyphens or
+// underscores, like aliases
+var fieldNamePattern = regexp.MustCompile(`^[a-z][a-z0-9]*([-_][a-z0-9]+)*$`)
+
+// ValidateFieldName checks that name is usable as a custom field name
+func ValidateFieldName(name string) error {
+	if !fieldNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid field name %q: use lowercase letters, digits and single hyphens or underscores, starting with a letter", name)
+	}
+	return nil
+}
+
+// NewFieldDef builds a definition, checking the name, the type and, for
+// enums, that there are values and none repeats
+func NewFieldDef(name string, fieldType FieldType, values []string) (*FieldDef, error) {
+	if err := ValidateFieldName(name); err != nil {
+		return nil, err
+	}
+	if !fieldType.IsValid() {
+		return nil, fmt.Errorf("invalid field type %q (valid: enum, int, string, date)", fieldType)
+	}
+	def := &FieldDef{Name: name, Type: fieldType}
+	if fieldType != FieldEnum {
+		if len(values) > 0 {
+			return nil, fmt.Errorf("only enum fields take values")
+		}
+		return def, nil
+	}
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		switch {
+		case v == "":
+			continue
+		case def.allows(v) != "":
+			return nil, fmt.Errorf("enum value %q is listed twice", v)
+		}
+		def.Values = append(def.Values, v)
+	}
+	if len(def.Values) == 0 {
+		return nil, fmt.Errorf("enum field %s needs values (--values a,b,c)", name)
+	}
+	return def, nil
+}
+
+// ParseFieldDef reads a definition back from its config value
+func ParseFieldDef(name, spec string) (*FieldDef, error) {
+	fieldType, values, _ := strings.Cut(spec, ":")
+	var list []string
+	if values != "" {
+		list = strings.Split(values, ",")
+	}
+	return NewFieldDef(name, FieldType(strings.TrimSpace(fieldType)), list)
+}
+
+// Spec is the definition's config value
+func (d *FieldDef) Spec() string {
+	if d.Type == FieldEnum {
+		return string(d.Type) + ":" + strings.Join(d.Values, ",")
+	}
+	return string(d.Type)
+}
+
+// Normalize validates a value for the field and returns it in canonical
+// form: enum values as defined, integers without leading zeros or sign
+// noise, dates as YYYY-MM-DD
+func (d *FieldDef) Normalize(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", fmt.Errorf("field %s: empty value", d.Name)
+	}
+	switch d.Type {
+	case FieldEnum:
+		if v := d.allows(value); v != "" {
+			return v, nil
+		}
+		return "", fmt.Errorf("field %s: invalid value %q (valid: %s)", d.Name, value, strings.Join(d.Values, ", "))
+	case FieldInt:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("field %s: %q is not a whole number", d.Name, value)
+		}
+		return strconv.FormatInt(n, 10), nil
+	case FieldDate:
+		if t, err := time.Parse("2006-01-02", value); err == nil {
+			return t.Format("2006-01-02"), nil
+		}
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			return t.Format("2006-01-02"), nil
+		}
+		return "", fmt.Errorf("field %s: %q is not a date (use YYYY-MM-DD)", d.Name, value)
+	default:
+		return value, nil
+	}
+}
+
+// allows returns the enum value matching v, ignoring case, or ""
+func (d *FieldDef) allows(v string) string {
+	for _, allowed := range d.Values {
+		if strings.EqualFold(allowed, v) {
+			return allowed
+		}
+	}
+	return ""
+}
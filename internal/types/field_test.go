@@ -0,0 +1,84 @@
+package types
+
+import "testing"
+
+func TestNewFieldDef(t *testing.T) {
+	tests := []struct {
+		name      string
+		fieldName string
+		fieldType FieldType
+		values    []string
+		wantErr   bool
+	}{
+		{"enum", "severity", FieldEnum, []string{"low", " med ", "high"}, false},
+		{"int", "points", FieldInt, nil, false},
+		{"date", "due_date", FieldDate, nil, false},
+		{"string", "customer", FieldString, nil, false},
+		{"bad name", "Severity!", FieldString, nil, true},
+		{"bad type", "severity", FieldType("float"), nil, true},
+		{"enum without values", "severity", FieldEnum, []string{"", " "}, true},
+		{"enum duplicate", "severity", FieldEnum, []string{"low", "LOW"}, true},
+		{"values on int", "points", FieldInt, []string{"1"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewFieldDef(tt.fieldName, tt.fieldType, tt.values)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewFieldDef() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFieldDefSpecRoundTrip(t *testing.T) {
+	def, err := NewFieldDef("severity", FieldEnum, []string{"low", "med", "high"})
+	if err != nil {
+		t.Fatalf("NewFieldDef failed: %v", err)
+	}
+	if def.Spec() != "enum:low,med,high" {
+		t.Errorf("Spec() = %q", def.Spec())
+	}
+	parsed, err := ParseFieldDef("severity", def.Spec())
+	if err != nil {
+		t.Fatalf("ParseFieldDef failed: %v", err)
+	}
+	if parsed.Type != FieldEnum || len(parsed.Values) != 3 || parsed.Values[2] != "high" {
+		t.Errorf("ParseFieldDef() = %+v", parsed)
+	}
+	if parsed, err := ParseFieldDef("points", "int"); err != nil || parsed.Type != FieldInt {
+		t.Errorf("ParseFieldDef(int) = %+v, %v", parsed, err)
+	}
+}
+
+func TestFieldDefNormalize(t *testing.T) {
+	enum := &FieldDef{Name: "severity", Type: FieldEnum, Values: []string{"low", "med", "high"}}
+	tests := []struct {
+		name    string
+		def     *FieldDef
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{"enum canonical case", enum, "HIGH", "high", false},
+		{"enum unknown", enum, "urgent", "", true},
+		{"int", &FieldDef{Name: "points", Type: FieldInt}, " 003 ", "3", false},
+		{"int negative", &FieldDef{Name: "points", Type: FieldInt}, "-2", "-2", false},
+		{"int invalid", &FieldDef{Name: "points", Type: FieldInt}, "1.5", "", true},
+		{"date", &FieldDef{Name: "due", Type: FieldDate}, "2026-03-01", "2026-03-01", false},
+		{"date rfc3339", &FieldDef{Name: "due", Type: FieldDate}, "2026-03-01T10:00:00Z", "2026-03-01", false},
+		{"date invalid", &FieldDef{Name: "due", Type: FieldDate}, "03/01/2026", "", true},
+		{"string trimmed", &FieldDef{Name: "customer", Type: FieldString}, " Acme ", "Acme", false},
+		{"empty", &FieldDef{Name: "customer", Type: FieldString}, "  ", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.def.Normalize(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Normalize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Normalize() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package types
+
+import "time"
+
+// Lease is a claim on an issue (bd claim, bd ready --claim): the holder
+// works on it, and nobody else may claim it until the lease is released or
+// expires. A lease without ExpiresAt lasts until released.
+type Lease struct {
+	IssueID   string     `json:"issue_id"`
+	Holder    string     `json:"holder"`
+	ClaimedAt time.Time  `json:"claimed_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// Expired reports whether the lease has run out at now
+func (l *Lease) Expired(now time.Time) bool {
+	return l.ExpiresAt != nil && !now.Before(*l.ExpiresAt)
+}
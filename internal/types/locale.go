@@ -0,0 +1,97 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Translation is an issue's title and description in another language.
+// Empty fields fall back to the issue's own text.
+type Translation struct {
+	Locale      string `json:"locale"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// localePattern accepts BCP 47 style tags such as "ja", "pt-BR" or
+// "zh-Hant-TW", with "_" allowed as the separator (as in LANG)
+var localePattern = regexp.MustCompile(`^[a-zA-Z]{2,3}([-_][a-zA-Z0-9]{2,8})*$`)
+
+// NormalizeLocale validates a locale tag and puts it in canonical form:
+// lowercase language, "-" separators, uppercase two-letter region
+// ("pt_br" becomes "pt-BR")
+func NormalizeLocale(locale string) (string, error) {
+	if !localePattern.MatchString(locale) {
+		return "", fmt.Errorf("invalid locale %q: use a language tag such as ja, de or pt-BR", locale)
+	}
+	parts := strings.Split(strings.ReplaceAll(locale, "_", "-"), "-")
+	parts[0] = strings.ToLower(parts[0])
+	for i := 1; i < len(parts); i++ {
+		switch len(parts[i]) {
+		case 2:
+			parts[i] = strings.ToUpper(parts[i])
+		case 4:
+			parts[i] = strings.ToUpper(parts[i][:1]) + strings.ToLower(parts[i][1:])
+		default:
+			parts[i] = strings.ToLower(parts[i])
+		}
+	}
+	return strings.Join(parts, "-"), nil
+}
+
+// MatchTranslation picks the translation for locale: an exact match first,
+// then one for the same language ("pt-BR" falls back to "pt", then to any
+// other "pt-*"). Fields the exact match leaves empty come from the
+// fallback. It returns nil if there is none.
+func MatchTranslation(translations []*Translation, locale string) *Translation {
+	if locale == "" {
+		return nil
+	}
+	language := strings.SplitN(locale, "-", 2)[0]
+	var exact, fallback *Translation
+	for _, t := range translations {
+		switch {
+		case t.Locale == locale:
+			exact = t
+		case t.Locale == language:
+			fallback = t
+		case fallback == nil && strings.SplitN(t.Locale, "-", 2)[0] == language:
+			fallback = t
+		}
+	}
+	if exact == nil || fallback == nil {
+		if exact != nil {
+			return exact
+		}
+		return fallback
+	}
+	merged := *exact
+	if merged.Title == "" {
+		merged.Title = fallback.Title
+	}
+	if merged.Description == "" {
+		merged.Description = fallback.Description
+	}
+	return &merged
+}
+
+// Localize replaces the issue's title and description with their
+// translation for locale, where one exists
+func (i *Issue) Localize(locale string) {
+	i.ApplyTranslation(MatchTranslation(i.Translations, locale))
+}
+
+// ApplyTranslation replaces the issue's title and description with the
+// non-empty fields of t, which may be nil
+func (i *Issue) ApplyTranslation(t *Translation) {
+	if t == nil {
+		return
+	}
+	if t.Title != "" {
+		i.Title = t.Title
+	}
+	if t.Description != "" {
+		i.Description = t.Description
+	}
+}
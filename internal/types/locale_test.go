@@ -0,0 +1,57 @@
+package types
+
+import "testing"
+
+func TestNormalizeLocale(t *testing.T) {
+	for in, want := range map[string]string{
+		"ja":         "ja",
+		"pt_br":      "pt-BR",
+		"EN-us":      "en-US",
+		"zh-hant-tw": "zh-Hant-TW",
+	} {
+		got, err := NormalizeLocale(in)
+		if err != nil || got != want {
+			t.Errorf("NormalizeLocale(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	for _, bad := range []string{"", "j", "english", "ja-", "ja JP"} {
+		if _, err := NormalizeLocale(bad); err == nil {
+			t.Errorf("NormalizeLocale(%q) should fail", bad)
+		}
+	}
+}
+
+func TestMatchTranslation(t *testing.T) {
+	translations := []*Translation{
+		{Locale: "de", Title: "Anmeldung", Description: "Beschreibung"},
+		{Locale: "pt-BR", Title: "Entrar"},
+		{Locale: "pt-PT", Title: "Iniciar sessão", Description: "Descrição"},
+	}
+	tests := []struct {
+		locale, title, description string
+	}{
+		{"de", "Anmeldung", "Beschreibung"},
+		{"de-AT", "Anmeldung", "Beschreibung"}, // same language
+		{"pt-BR", "Entrar", "Descrição"},       // exact, gaps filled from pt-PT
+		{"fr", "", ""},
+		{"", "", ""},
+	}
+	for _, tt := range tests {
+		got := MatchTranslation(translations, tt.locale)
+		if tt.title == "" {
+			if got != nil {
+				t.Errorf("MatchTranslation(%q) = %+v, want nil", tt.locale, got)
+			}
+			continue
+		}
+		if got == nil || got.Title != tt.title || got.Description != tt.description {
+			t.Errorf("MatchTranslation(%q) = %+v, want %q/%q", tt.locale, got, tt.title, tt.description)
+		}
+	}
+
+	issue := &Issue{Title: "Login", Description: "Original", Translations: translations[1:2]}
+	issue.Localize("pt-BR")
+	if issue.Title != "Entrar" || issue.Description != "Original" {
+		t.Errorf("Localize = %q/%q, want translated title and original description", issue.Title, issue.Description)
+	}
+}
@@ -0,0 +1,54 @@
+package types
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// PageCursor marks a position in the list ordering used by SearchIssues:
+// priority ascending, newest first, then ID. A cursor records the sort key of
+// the last issue a client saw, so the next page resumes after it even if that
+// issue has since been updated or deleted.
+type PageCursor struct {
+	Priority  int       `json:"p"`
+	CreatedAt time.Time `json:"c"`
+	ID        string    `json:"id"`
+}
+
+// CursorAfter returns the cursor positioned just after issue
+func CursorAfter(issue *Issue) *PageCursor {
+	return &PageCursor{Priority: issue.Priority, CreatedAt: issue.CreatedAt, ID: issue.ID}
+}
+
+// Encode returns the opaque next_cursor token handed to clients
+func (c *PageCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodePageCursor parses a token produced by Encode
+func DecodePageCursor(token string) (*PageCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor %q", token)
+	}
+	var c PageCursor
+	if err := json.Unmarshal(data, &c); err != nil || c.ID == "" {
+		return nil, fmt.Errorf("invalid cursor %q", token)
+	}
+	return &c, nil
+}
+
+// Precedes reports whether issue sorts after the cursor, i.e. belongs on a
+// later page
+func (c *PageCursor) Precedes(issue *Issue) bool {
+	if issue.Priority != c.Priority {
+		return issue.Priority > c.Priority
+	}
+	if !issue.CreatedAt.Equal(c.CreatedAt) {
+		return issue.CreatedAt.Before(c.CreatedAt)
+	}
+	return issue.ID > c.ID
+}
@@ -0,0 +1,48 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPageCursorRoundTrip(t *testing.T) {
+	issue := &Issue{ID: "bd-abc", Priority: 2, CreatedAt: time.Date(2025, 3, 4, 5, 6, 7, 890, time.UTC)}
+	token := CursorAfter(issue).Encode()
+
+	cursor, err := DecodePageCursor(token)
+	if err != nil {
+		t.Fatalf("DecodePageCursor: %v", err)
+	}
+	if cursor.ID != issue.ID || cursor.Priority != issue.Priority || !cursor.CreatedAt.Equal(issue.CreatedAt) {
+		t.Errorf("decoded %+v, want position of %+v", cursor, issue)
+	}
+
+	for _, bad := range []string{"", "!!!", "e30"} {
+		if _, err := DecodePageCursor(bad); err == nil {
+			t.Errorf("DecodePageCursor(%q) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestPageCursorPrecedes(t *testing.T) {
+	now := time.Now()
+	cursor := &PageCursor{Priority: 1, CreatedAt: now, ID: "bd-m"}
+	tests := []struct {
+		name  string
+		issue *Issue
+		want  bool
+	}{
+		{"lower priority number", &Issue{ID: "bd-z", Priority: 0, CreatedAt: now}, false},
+		{"higher priority number", &Issue{ID: "bd-a", Priority: 2, CreatedAt: now}, true},
+		{"newer", &Issue{ID: "bd-z", Priority: 1, CreatedAt: now.Add(time.Second)}, false},
+		{"older", &Issue{ID: "bd-a", Priority: 1, CreatedAt: now.Add(-time.Second)}, true},
+		{"same time, smaller ID", &Issue{ID: "bd-a", Priority: 1, CreatedAt: now}, false},
+		{"same time, larger ID", &Issue{ID: "bd-z", Priority: 1, CreatedAt: now}, true},
+		{"cursor issue itself", &Issue{ID: "bd-m", Priority: 1, CreatedAt: now}, false},
+	}
+	for _, tt := range tests {
+		if got := cursor.Precedes(tt.issue); got != tt.want {
+			t.Errorf("%s: Precedes = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+// SavedFilter is a named filter expression (bd filter save), such as
+// "status=open priority<=1 label=backend"
+type SavedFilter struct {
+	Name        string    `json:"name"`
+	Query       string    `json:"query"`
+	Description string    `json:"description,omitempty"`
+	CreatedBy   string    `json:"created_by,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ValidateFilterName checks that name is usable as a saved filter name,
+// which follows the same rules as aliases
+func ValidateFilterName(name string) error {
+	if !aliasPattern.MatchString(name) {
+		return fmt.Errorf("invalid filter name %q: use lowercase letters, digits and single hyphens or underscores, starting with a letter", name)
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SortKey orders issues by one field, ascending unless Desc is set
+type SortKey struct {
+	Field string // canonical field name, see SortFields
+	Desc  bool
+}
+
+// SortKeys is a multi-field ordering: later keys break ties in earlier
+// ones, and ID breaks any tie left so the order is stable
+type SortKeys []SortKey
+
+// SortFields are the fields issues can be sorted by
+var SortFields = []string{"priority", "created_at", "updated_at", "closed_at", "status", "issue_type", "assignee", "title", "estimate", "id"}
+
+// sortAliases maps accepted names to canonical fields. The short date
+// names predate multi-field sorting and keep sorting newest first.
+var sortAliases = map[string]SortKey{
+	"created":           {Field: "created_at", Desc: true},
+	"updated":           {Field: "updated_at", Desc: true},
+	"closed":            {Field: "closed_at", Desc: true},
+	"type":              {Field: "issue_type"},
+	"estimated_minutes": {Field: "estimate"},
+}
+
+// ParseSortKeys parses a comma-separated sort spec such as
+// "priority,-updated_at,estimate". A leading "-" sorts that field
+// descending, "+" (or nothing) ascending.
+func ParseSortKeys(spec string) (SortKeys, error) {
+	var keys SortKeys
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if part == "" {
+			continue
+		}
+		desc := false
+		switch part[0] {
+		case '-':
+			desc, part = true, part[1:]
+		case '+':
+			part = part[1:]
+		}
+		key, ok := sortAliases[part]
+		if ok {
+			key.Desc = key.Desc != desc
+		} else {
+			key = SortKey{Field: part, Desc: desc}
+			if !isSortField(part) {
+				return nil, fmt.Errorf("unknown sort field %q (valid: %s)", part, strings.Join(SortFields, ", "))
+			}
+		}
+		if seen[key.Field] {
+			return nil, fmt.Errorf("sort field %q given twice", key.Field)
+		}
+		seen[key.Field] = true
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func isSortField(name string) bool {
+	for _, f := range SortFields {
+		if f == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Reverse flips the direction of every key
+func (keys SortKeys) Reverse() SortKeys {
+	out := make(SortKeys, len(keys))
+	for i, k := range keys {
+		out[i] = SortKey{Field: k.Field, Desc: !k.Desc}
+	}
+	return out
+}
+
+// String formats keys back into a sort spec
+func (keys SortKeys) String() string {
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = k.Field
+		if k.Desc {
+			parts[i] = "-" + k.Field
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// Compare orders a and b by keys, then by ID. Missing values (no closed_at,
+// assignee or estimate) sort last in either direction, matching the SQL
+// ordering storage backends use.
+func (keys SortKeys) Compare(a, b *Issue) int {
+	for _, k := range keys {
+		c, missing := compareSortField(k.Field, a, b)
+		if c == 0 {
+			continue
+		}
+		if k.Desc && !missing {
+			c = -c
+		}
+		return c
+	}
+	return strings.Compare(a.ID, b.ID)
+}
+
+// compareSortField compares one field; missing reports that the result
+// comes from one side lacking a value, which direction doesn't flip
+func compareSortField(field string, a, b *Issue) (int, bool) {
+	switch field {
+	case "priority":
+		return compareInts(a.Priority, b.Priority), false
+	case "created_at":
+		return a.CreatedAt.Compare(b.CreatedAt), false
+	case "updated_at":
+		return a.UpdatedAt.Compare(b.UpdatedAt), false
+	case "closed_at":
+		if a.ClosedAt == nil || b.ClosedAt == nil {
+			return compareMissing(a.ClosedAt == nil, b.ClosedAt == nil), true
+		}
+		return a.ClosedAt.Compare(*b.ClosedAt), false
+	case "status":
+		return strings.Compare(string(a.Status), string(b.Status)), false
+	case "issue_type":
+		return strings.Compare(string(a.IssueType), string(b.IssueType)), false
+	case "assignee":
+		if a.Assignee == "" || b.Assignee == "" {
+			return compareMissing(a.Assignee == "", b.Assignee == ""), true
+		}
+		return strings.Compare(a.Assignee, b.Assignee), false
+	case "title":
+		return strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title)), false
+	case "estimate":
+		if a.EstimatedMinutes == nil || b.EstimatedMinutes == nil {
+			return compareMissing(a.EstimatedMinutes == nil, b.EstimatedMinutes == nil), true
+		}
+		return compareInts(*a.EstimatedMinutes, *b.EstimatedMinutes), false
+	case "id":
+		return strings.Compare(a.ID, b.ID), false
+	}
+	return 0, false
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// compareMissing sorts the side without a value after the one with it
+func compareMissing(aMissing, bMissing bool) int {
+	switch {
+	case aMissing == bMissing:
+		return 0
+	case aMissing:
+		return 1
+	}
+	return -1
+}
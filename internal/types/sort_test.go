@@ -0,0 +1,71 @@
+package types
+
+import (
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseSortKeys(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    string
+		wantErr bool
+	}{
+		{spec: "", want: ""},
+		{spec: "priority,-updated_at,estimate", want: "priority,-updated_at,estimate"},
+		{spec: " Priority , +title", want: "priority,title"},
+		{spec: "created", want: "-created_at"}, // legacy: newest first
+		{spec: "-created", want: "created_at"}, // legacy name, reversed
+		{spec: "type,estimated_minutes", want: "issue_type,estimate"},
+		{spec: "priority,bogus", wantErr: true},
+		{spec: "updated,updated_at", wantErr: true},
+		{spec: "-", wantErr: true},
+	}
+	for _, tt := range tests {
+		keys, err := ParseSortKeys(tt.spec)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseSortKeys(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			continue
+		}
+		if err == nil && keys.String() != tt.want {
+			t.Errorf("ParseSortKeys(%q) = %q, want %q", tt.spec, keys.String(), tt.want)
+		}
+	}
+}
+
+func TestSortKeysCompare(t *testing.T) {
+	est := func(n int) *int { return &n }
+	now := time.Now()
+	issues := []*Issue{
+		{ID: "a", Priority: 1, UpdatedAt: now.Add(-time.Hour)},
+		{ID: "b", Priority: 1, UpdatedAt: now, EstimatedMinutes: est(30)},
+		{ID: "c", Priority: 0, UpdatedAt: now.Add(-2 * time.Hour), EstimatedMinutes: est(60)},
+		{ID: "d", Priority: 1, UpdatedAt: now, EstimatedMinutes: est(10)},
+	}
+	order := func(spec string) string {
+		keys, err := ParseSortKeys(spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sorted := append([]*Issue(nil), issues...)
+		sort.Slice(sorted, func(i, j int) bool { return keys.Compare(sorted[i], sorted[j]) < 0 })
+		ids := make([]string, len(sorted))
+		for i, issue := range sorted {
+			ids[i] = issue.ID
+		}
+		return strings.Join(ids, "")
+	}
+
+	if got := order("priority,-updated_at,estimate"); got != "cdba" {
+		t.Errorf("priority,-updated_at,estimate = %s, want cdba", got)
+	}
+	// Missing estimates stay last whichever way the field sorts
+	if got := order("estimate"); got != "dbca" {
+		t.Errorf("estimate = %s, want dbca", got)
+	}
+	if got := order("-estimate"); got != "cbda" {
+		t.Errorf("-estimate = %s, want cbda", got)
+	}
+}
@@ -0,0 +1,24 @@
+package types
+
+import "time"
+
+// Timebox is where a spike stands against its timebox. The clock starts
+// when the spike goes in_progress and restarts if it is started again.
+type Timebox struct {
+	Minutes   int        `json:"minutes"`
+	StartedAt *time.Time `json:"started_at,omitempty"` // nil while the spike isn't in progress
+	EndsAt    *time.Time `json:"ends_at,omitempty"`
+	Elapsed   bool       `json:"elapsed"`
+}
+
+// NewTimebox places a spike started at startedAt (nil if it isn't in
+// progress) against a timebox of minutes, as of now
+func NewTimebox(minutes int, startedAt *time.Time, now time.Time) *Timebox {
+	tb := &Timebox{Minutes: minutes}
+	if startedAt != nil {
+		ends := startedAt.Add(time.Duration(minutes) * time.Minute)
+		tb.StartedAt, tb.EndsAt = startedAt, &ends
+		tb.Elapsed = !now.Before(ends)
+	}
+	return tb
+}
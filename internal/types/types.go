@@ -4,7 +4,9 @@ package types
 import (
 	"crypto/sha256"
 	"fmt"
+	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 // Issue represents a trackable work item
@@ -21,6 +23,9 @@ type Issue struct {
 	IssueType          IssueType      `json:"issue_type"`
 	Assignee           string         `json:"assignee,omitempty"`
 	EstimatedMinutes   *int           `json:"estimated_minutes,omitempty"`
+	TimeboxMinutes     *int           `json:"timebox_minutes,omitempty"` // How long a spike may run once started
+	Complexity         Complexity     `json:"complexity,omitempty"` // Routing hint for agents (bd complexity)
+	Source             string         `json:"source,omitempty"`     // Where the report came from (e.g. intake, sentry)
 	CreatedAt          time.Time      `json:"created_at"`
 	UpdatedAt          time.Time      `json:"updated_at"`
 	ClosedAt           *time.Time     `json:"closed_at,omitempty"`
@@ -34,6 +39,12 @@ type Issue struct {
 	Labels             []string       `json:"labels,omitempty"` // Populated only for export/import
 	Dependencies       []*Dependency  `json:"dependencies,omitempty"` // Populated only for export/import
 	Comments           []*Comment     `json:"comments,omitempty"`     // Populated only for export/import
+	Attachments        []*Attachment  `json:"attachments,omitempty"`  // Populated only for export/import
+	WorkLog            []*WorkEntry   `json:"work_log,omitempty"`     // Populated only for export/import
+	Aliases            []string       `json:"aliases,omitempty"`      // Human-friendly alternate IDs (bd alias-id)
+	Translations       []*Translation `json:"translations,omitempty"` // Per-locale titles/descriptions (bd update --locale)
+	Fields             CustomFields   `json:"fields,omitempty"`       // Custom field values (bd field define)
+	SoftBlockedBy      []string       `json:"soft_blocked_by,omitempty"` // Open soft blockers; populated only by ready work queries
 	// Tombstone fields (bd-vw8): inline soft-delete support
 	DeletedAt    *time.Time `json:"deleted_at,omitempty"`    // When the issue was deleted
 	DeletedBy    string     `json:"deleted_by,omitempty"`    // Who deleted the issue
@@ -76,6 +87,19 @@ func (i *Issue) ComputeContentHash() string {
 	if i.ExternalRef != nil {
 		h.Write([]byte(*i.ExternalRef))
 	}
+	// Appended only when set so issues without one keep their old hash
+	if i.Complexity != "" {
+		h.Write([]byte{0})
+		h.Write([]byte(i.Complexity))
+	}
+	if i.Source != "" {
+		h.Write([]byte{0})
+		h.Write([]byte(i.Source))
+	}
+	if i.TimeboxMinutes != nil {
+		h.Write([]byte{0})
+		h.Write([]byte(fmt.Sprintf("timebox:%d", *i.TimeboxMinutes)))
+	}
 	
 	return fmt.Sprintf("%x", h.Sum(nil))
 }
@@ -150,6 +174,11 @@ func (i *Issue) ValidateWithCustomStatuses(customStatuses []string) error {
 	if i.Priority < 0 || i.Priority > 4 {
 		return fmt.Errorf("priority must be between 0 and 4 (got %d)", i.Priority)
 	}
+	// JSONL export would replace invalid bytes with U+FFFD, so such text
+	// could never round-trip
+	if field := i.invalidUTF8Field(); field != "" {
+		return fmt.Errorf("%s is not valid UTF-8", field)
+	}
 	if !i.Status.IsValidWithCustom(customStatuses) {
 		return fmt.Errorf("invalid status: %s", i.Status)
 	}
@@ -159,6 +188,15 @@ func (i *Issue) ValidateWithCustomStatuses(customStatuses []string) error {
 	if i.EstimatedMinutes != nil && *i.EstimatedMinutes < 0 {
 		return fmt.Errorf("estimated_minutes cannot be negative")
 	}
+	if i.Complexity != "" && !i.Complexity.IsValid() {
+		return fmt.Errorf("invalid complexity: %s (valid: trivial, standard, complex, research)", i.Complexity)
+	}
+	if i.TimeboxMinutes != nil && *i.TimeboxMinutes < 0 {
+		return fmt.Errorf("timebox_minutes cannot be negative")
+	}
+	if i.IssueType == TypeSpike && (i.TimeboxMinutes == nil || *i.TimeboxMinutes == 0) {
+		return fmt.Errorf("spikes need a timebox (e.g. --timebox 2d)")
+	}
 	// Enforce closed_at invariant: closed_at should be set if and only if status is closed
 	if i.Status == StatusClosed && i.ClosedAt == nil {
 		return fmt.Errorf("closed issues must have closed_at timestamp")
@@ -176,6 +214,28 @@ func (i *Issue) ValidateWithCustomStatuses(customStatuses []string) error {
 	return nil
 }
 
+// invalidUTF8Field returns the name of the first text field that isn't valid UTF-8
+func (i *Issue) invalidUTF8Field() string {
+	fields := []struct{ name, value string }{
+		{"title", i.Title},
+		{"description", i.Description},
+		{"design", i.Design},
+		{"acceptance_criteria", i.AcceptanceCriteria},
+		{"notes", i.Notes},
+		{"assignee", i.Assignee},
+		{"close_reason", i.CloseReason},
+	}
+	if i.ExternalRef != nil {
+		fields = append(fields, struct{ name, value string }{"external_ref", *i.ExternalRef})
+	}
+	for _, f := range fields {
+		if !utf8.ValidString(f.value) {
+			return f.name
+		}
+	}
+	return ""
+}
+
 // Status represents the current state of an issue
 type Status string
 
@@ -184,6 +244,7 @@ const (
 	StatusOpen       Status = "open"
 	StatusInProgress Status = "in_progress"
 	StatusBlocked    Status = "blocked"
+	StatusResolved   Status = "resolved" // Work done with evidence, awaiting a reviewer's close
 	StatusClosed     Status = "closed"
 	StatusTombstone  Status = "tombstone" // Soft-deleted issue (bd-vw8)
 )
@@ -191,7 +252,7 @@ const (
 // IsValid checks if the status value is valid (built-in statuses only)
 func (s Status) IsValid() bool {
 	switch s {
-	case StatusOpen, StatusInProgress, StatusBlocked, StatusClosed, StatusTombstone:
+	case StatusOpen, StatusInProgress, StatusBlocked, StatusResolved, StatusClosed, StatusTombstone:
 		return true
 	}
 	return false
@@ -224,17 +285,60 @@ const (
 	TypeEpic    IssueType = "epic"
 	TypeChore   IssueType = "chore"
 	TypeMessage IssueType = "message" // Ephemeral communication between workers
+	TypeSpike   IssueType = "spike"   // Time-boxed investigation that ends in an outcome (bd spike)
 )
 
 // IsValid checks if the issue type value is valid
 func (t IssueType) IsValid() bool {
 	switch t {
-	case TypeBug, TypeFeature, TypeTask, TypeEpic, TypeChore, TypeMessage:
+	case TypeBug, TypeFeature, TypeTask, TypeEpic, TypeChore, TypeMessage, TypeSpike:
 		return true
 	}
 	return false
 }
 
+// Complexity classifies how demanding an issue is, so orchestrators can
+// route complex work to stronger models and trivial work to cheap agents.
+// Empty means unclassified.
+type Complexity string
+
+// Complexity constants, from least to most demanding
+const (
+	ComplexityTrivial  Complexity = "trivial"  // Mechanical change, no design needed
+	ComplexityStandard Complexity = "standard" // Ordinary task with a clear approach
+	ComplexityComplex  Complexity = "complex"  // Cross-cutting or subtle; needs careful design
+	ComplexityResearch Complexity = "research" // Open question; investigate before building
+)
+
+// IsValid checks if the complexity value is valid (empty is not)
+func (c Complexity) IsValid() bool {
+	switch c {
+	case ComplexityTrivial, ComplexityStandard, ComplexityComplex, ComplexityResearch:
+		return true
+	}
+	return false
+}
+
+// ParseComplexities parses complexity filter values such as "trivial" or
+// "complex,research"; each value may itself be comma-separated
+func ParseComplexities(values []string) ([]Complexity, error) {
+	var result []Complexity
+	for _, v := range values {
+		for _, part := range strings.Split(v, ",") {
+			part = strings.ToLower(strings.TrimSpace(part))
+			if part == "" {
+				continue
+			}
+			c := Complexity(part)
+			if !c.IsValid() {
+				return nil, fmt.Errorf("invalid complexity %q (valid: trivial, standard, complex, research)", part)
+			}
+			result = append(result, c)
+		}
+	}
+	return result, nil
+}
+
 // Dependency represents a relationship between issues
 type Dependency struct {
 	IssueID     string         `json:"issue_id"`
@@ -268,8 +372,82 @@ type IssueWithCounts struct {
 	*Issue
 	DependencyCount int `json:"dependency_count"`
 	DependentCount  int `json:"dependent_count"`
+	// Progress is set for epics when child progress was requested
+	Progress *ChildProgress `json:"progress,omitempty"`
+}
+
+// ChildProgress summarizes the parent-child children of an issue
+type ChildProgress struct {
+	Total  int `json:"total"`  // Direct children
+	Closed int `json:"closed"` // Direct children that are closed
+	// Estimate roll-up over all descendants, set only when requested
+	EstimatedMinutes *int `json:"estimated_minutes,omitempty"`
+	RemainingMinutes *int `json:"remaining_minutes,omitempty"`
+}
+
+// ReadyGroup is ready work grouped under the epic it advances or a label it
+// carries. Progress covers the whole epic or label, not just the ready part;
+// the group of work outside any epic or label has neither.
+type ReadyGroup struct {
+	Epic     *Issue         `json:"epic,omitempty"`
+	Label    string         `json:"label,omitempty"`
+	Progress *ChildProgress `json:"progress,omitempty"`
+	Issues   []*Issue       `json:"issues"`
+}
+
+// StateTime is the cumulative time an issue has spent in each workflow
+// state, reconstructed from the event history. An open issue counts as
+// blocked while one of its blocks dependencies is open.
+type StateTime struct {
+	ReadyHours      float64 `json:"ready_hours"`
+	BlockedHours    float64 `json:"blocked_hours"`
+	InProgressHours float64 `json:"in_progress_hours"`
+	// BlockingHours sums the blocked time this issue caused its dependents
+	BlockingHours float64 `json:"blocking_hours,omitempty"`
+}
+
+// ReadyDiff compares the ready queue (open issues with no open blockers) at
+// Since with the queue at Until, for orchestrators dispatching incrementally
+type ReadyDiff struct {
+	Since   time.Time      `json:"since"`
+	Until   time.Time      `json:"until"`
+	Entered []*ReadyChange `json:"entered"`
+	Left    []*ReadyChange `json:"left"`
+}
+
+// ReadyChange is an issue that entered or left the ready queue, and why
+type ReadyChange struct {
+	ID       string      `json:"id"`
+	Title    string      `json:"title"`
+	Priority int         `json:"priority"`
+	Reason   ReadyReason `json:"reason"`
+	// Blockers are the blockers that closed (entered) or now block (left)
+	Blockers []string `json:"blockers,omitempty"`
 }
 
+// ReadyReason explains a ReadyChange
+type ReadyReason string
+
+// Reasons an issue entered the ready queue
+const (
+	ReadyReasonNewIssue          ReadyReason = "new_issue"
+	ReadyReasonBlockerClosed     ReadyReason = "blocker_closed"
+	ReadyReasonDependencyRemoved ReadyReason = "dependency_removed"
+	ReadyReasonClaimReleased     ReadyReason = "claim_released" // in_progress back to open
+	ReadyReasonReopened          ReadyReason = "reopened"
+)
+
+// Reasons an issue left the ready queue
+const (
+	ReadyReasonClaimed ReadyReason = "claimed" // now in_progress
+	ReadyReasonClosed  ReadyReason = "closed"
+	ReadyReasonBlocked ReadyReason = "blocked"
+)
+
+// ReadyReasonStatusChanged covers any other status change, e.g. to or from
+// blocked or deferred
+const ReadyReasonStatusChanged ReadyReason = "status_changed"
+
 // DependencyType categorizes the relationship
 type DependencyType string
 
@@ -279,6 +457,10 @@ const (
 	DepBlocks      DependencyType = "blocks"
 	DepParentChild DependencyType = "parent-child"
 
+	// Ordering hint: the issue stays ready but sorts after other ready work
+	// while the issue it depends on is open ("preferably after bd-12")
+	DepSoftBlocks DependencyType = "soft-blocks"
+
 	// Association types
 	DepRelated        DependencyType = "related"
 	DepDiscoveredFrom DependencyType = "discovered-from"
@@ -306,7 +488,7 @@ func (d DependencyType) IsValid() bool {
 // Returns false for custom/user-defined types (which are still valid).
 func (d DependencyType) IsWellKnown() bool {
 	switch d {
-	case DepBlocks, DepParentChild, DepRelated, DepDiscoveredFrom,
+	case DepBlocks, DepParentChild, DepSoftBlocks, DepRelated, DepDiscoveredFrom,
 		DepRepliesTo, DepRelatesTo, DepDuplicates, DepSupersedes,
 		DepAuthoredBy, DepAssignedTo, DepApprovedBy:
 		return true
@@ -328,10 +510,59 @@ type Label struct {
 
 // Comment represents a comment on an issue
 type Comment struct {
+	ID        int64      `json:"id"`
+	IssueID   string     `json:"issue_id"`
+	Author    string     `json:"author"`
+	Text      string     `json:"text"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"` // Set once the comment is edited
+}
+
+// Attachment is a file stored outside git by an attachment provider. Only the
+// URL and content hash are tracked, so the JSONL stays small.
+type Attachment struct {
+	ID        int64     `json:"id"`
+	IssueID   string    `json:"issue_id"`
+	Name      string    `json:"name"`   // Original file name
+	URL       string    `json:"url"`    // Provider URL, e.g. s3://bucket/key
+	SHA256    string    `json:"sha256"` // Hex digest of the content, verified on download
+	Size      int64     `json:"size"`
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// WorkEntry is one work log record: time someone spent on an issue (bd log-time)
+type WorkEntry struct {
 	ID        int64     `json:"id"`
 	IssueID   string    `json:"issue_id"`
-	Author    string    `json:"author"`
-	Text      string    `json:"text"`
+	Actor     string    `json:"actor"`
+	Minutes   int       `json:"minutes"`
+	Note      string    `json:"note,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// WorkLogTotals sums logged time, overall and per actor
+type WorkLogTotals struct {
+	Minutes int            `json:"minutes"`
+	ByActor map[string]int `json:"by_actor"`
+}
+
+// SumWorkLog totals work log entries
+func SumWorkLog(entries []*WorkEntry) *WorkLogTotals {
+	totals := &WorkLogTotals{ByActor: make(map[string]int)}
+	for _, e := range entries {
+		totals.Minutes += e.Minutes
+		totals.ByActor[e.Actor] += e.Minutes
+	}
+	return totals
+}
+
+// Watch is a personal notification subscription: a watcher is notified about
+// changes to one issue or, with Label set, to every issue carrying the label
+type Watch struct {
+	Watcher   string    `json:"watcher"`
+	IssueID   string    `json:"issue_id,omitempty"`
+	Label     string    `json:"label,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
 }
 
@@ -356,6 +587,8 @@ const (
 	EventUpdated           EventType = "updated"
 	EventStatusChanged     EventType = "status_changed"
 	EventCommented         EventType = "commented"
+	EventCommentEdited     EventType = "comment_edited"
+	EventCommentDeleted    EventType = "comment_deleted"
 	EventClosed            EventType = "closed"
 	EventReopened          EventType = "reopened"
 	EventDependencyAdded   EventType = "dependency_added"
@@ -363,6 +596,7 @@ const (
 	EventLabelAdded        EventType = "label_added"
 	EventLabelRemoved      EventType = "label_removed"
 	EventCompacted         EventType = "compacted"
+	EventTimeboxElapsed    EventType = "timebox_elapsed" // A spike ran past its timebox
 )
 
 // BlockedIssue extends Issue with blocking information
@@ -370,6 +604,7 @@ type BlockedIssue struct {
 	Issue
 	BlockedByCount int      `json:"blocked_by_count"`
 	BlockedBy      []string `json:"blocked_by"`
+	BlockedByGates []string `json:"blocked_by_gates,omitempty"` // CI checks that haven't passed (sqlite only)
 }
 
 // TreeNode represents a node in a dependency tree
@@ -386,11 +621,25 @@ type Statistics struct {
 	OpenIssues               int     `json:"open_issues"`
 	InProgressIssues         int     `json:"in_progress_issues"`
 	ClosedIssues             int     `json:"closed_issues"`
+	ResolvedIssues           int     `json:"resolved_issues,omitempty"` // Awaiting a verifying close
 	BlockedIssues            int     `json:"blocked_issues"`
 	ReadyIssues              int     `json:"ready_issues"`
 	TombstoneIssues          int     `json:"tombstone_issues"` // Soft-deleted issues (bd-nyt)
 	EpicsEligibleForClosure  int     `json:"epics_eligible_for_closure"`
 	AverageLeadTime          float64 `json:"average_lead_time_hours"`
+	ClosedByReason           map[string]int `json:"closed_by_reason,omitempty"` // Closed issues per close reason category
+	MinutesLoggedByActor     map[string]int `json:"minutes_logged_by_actor,omitempty"` // Work log totals per actor (bd log-time)
+	OpenByPriority           map[string]int `json:"open_by_priority,omitempty"` // Issues not closed or deleted, by P0-P4
+	OpenByLabel              map[string]int `json:"open_by_label,omitempty"`    // Issues not closed or deleted, by label
+	RecentActivity           []DailyActivity `json:"recent_activity,omitempty"` // Events per day over the last week
+}
+
+// DailyActivity counts the events of one day (UTC)
+type DailyActivity struct {
+	Date    string `json:"date"` // YYYY-MM-DD
+	Created int    `json:"created"`
+	Closed  int    `json:"closed"`
+	Events  int    `json:"events"` // All events, including created and closed
 }
 
 // IssueFilter is used to filter issue queries
@@ -417,6 +666,9 @@ type IssueFilter struct {
 	UpdatedBefore *time.Time
 	ClosedAfter   *time.Time
 	ClosedBefore  *time.Time
+
+	// Close reason from the taxonomy; matches "<reason>" and "<reason>: <note>"
+	CloseReason string
 	
 	// Empty/null checks
 	EmptyDescription bool
@@ -432,6 +684,19 @@ type IssueFilter struct {
 
 	// Ephemeral filtering (bd-kwro.9)
 	Ephemeral *bool // Filter by ephemeral flag (nil = any, true = only ephemeral, false = only non-ephemeral)
+
+	// Complexity filtering: issue must have one of these (empty = any)
+	Complexity []Complexity
+
+	// Custom field filtering: issue must have all these values
+	Fields CustomFields
+
+	// Pagination: only return issues sorting after this cursor
+	After *PageCursor
+
+	// Ordering; empty means priority, then newest first, then ID. Cursor
+	// pagination only supports the default order.
+	Sort SortKeys
 }
 
 // SortPolicy determines how ready work is ordered
@@ -464,14 +729,16 @@ func (s SortPolicy) IsValid() bool {
 
 // WorkFilter is used to filter ready work queries
 type WorkFilter struct {
-	Status     Status
-	Priority   *int
-	Assignee   *string
-	Unassigned bool       // Filter for issues with no assignee
-	Labels     []string   // AND semantics: issue must have ALL these labels
-	LabelsAny  []string   // OR semantics: issue must have AT LEAST ONE of these labels
-	Limit      int
-	SortPolicy SortPolicy
+	Status      Status
+	Priority    *int
+	Assignee    *string
+	Unassigned  bool       // Filter for issues with no assignee
+	AvailableTo *string    // Issues assigned to this actor or to no one (bd ready --for)
+	Labels      []string   // AND semantics: issue must have ALL these labels
+	LabelsAny   []string   // OR semantics: issue must have AT LEAST ONE of these labels
+	Complexity  []Complexity // Issue must have one of these (bd ready --complexity)
+	Limit       int
+	SortPolicy  SortPolicy
 }
 
 // StaleFilter is used to filter stale issue queries
@@ -47,6 +47,19 @@ func TestIssueValidation(t *testing.T) {
 			wantErr: true,
 			errMsg:  "title must be 500 characters or less",
 		},
+		{
+			name: "invalid UTF-8 in description",
+			issue: Issue{
+				ID:          "test-1",
+				Title:       "Test",
+				Description: "bad \xff byte",
+				Status:      StatusOpen,
+				Priority:    2,
+				IssueType:   TypeFeature,
+			},
+			wantErr: true,
+			errMsg:  "description is not valid UTF-8",
+		},
 		{
 			name: "invalid priority too low",
 			issue: Issue{
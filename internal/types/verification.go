@@ -0,0 +1,66 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ResolutionCommentPrefix starts the comment that records the evidence given
+// when an issue is marked resolved. Its author is the resolver, which keeps
+// the two-phase close working across clones (comments are exported to JSONL;
+// events are not).
+const ResolutionCommentPrefix = "Resolved: "
+
+// VerificationPolicy decides which issues need a two-phase close: they must
+// be marked resolved with evidence, and someone other than the resolver
+// performs the final close. Configured with close.verify_labels and
+// close.verify_priority.
+type VerificationPolicy struct {
+	Labels      []string // Issues with any of these labels need verification
+	MaxPriority int      // Issues at this priority or more urgent need verification; -1 = none
+}
+
+// ParseVerificationPolicy builds a policy from the config values: a
+// comma-separated label list and a priority threshold (0-4), either of
+// which may be empty
+func ParseVerificationPolicy(labels, priority string) (VerificationPolicy, error) {
+	policy := VerificationPolicy{MaxPriority: -1}
+	for _, label := range strings.Split(labels, ",") {
+		if label = strings.TrimSpace(label); label != "" {
+			policy.Labels = append(policy.Labels, label)
+		}
+	}
+	if priority = strings.TrimSpace(strings.TrimPrefix(strings.ToUpper(priority), "P")); priority != "" {
+		p, err := strconv.Atoi(priority)
+		if err != nil || p < 0 || p > 4 {
+			return policy, fmt.Errorf("invalid close.verify_priority %q (must be 0-4)", priority)
+		}
+		policy.MaxPriority = p
+	}
+	return policy, nil
+}
+
+// Requires reports whether closing issue, which carries labels, needs a
+// prior resolution
+func (p VerificationPolicy) Requires(issue *Issue, labels []string) bool {
+	if p.MaxPriority >= 0 && issue.Priority <= p.MaxPriority {
+		return true
+	}
+	for _, label := range labels {
+		if containsString(p.Labels, label) {
+			return true
+		}
+	}
+	return false
+}
+
+// Resolver returns the author of the most recent resolution comment, or ""
+func Resolver(comments []*Comment) string {
+	for i := len(comments) - 1; i >= 0; i-- {
+		if strings.HasPrefix(comments[i].Text, ResolutionCommentPrefix) {
+			return comments[i].Author
+		}
+	}
+	return ""
+}
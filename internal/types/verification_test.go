@@ -0,0 +1,60 @@
+package types
+
+import "testing"
+
+func TestParseVerificationPolicy(t *testing.T) {
+	policy, err := ParseVerificationPolicy(" security, release ,", "P1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(policy.Labels) != 2 || policy.Labels[1] != "release" || policy.MaxPriority != 1 {
+		t.Errorf("policy = %+v", policy)
+	}
+
+	if policy, err = ParseVerificationPolicy("", ""); err != nil || policy.MaxPriority != -1 || len(policy.Labels) != 0 {
+		t.Errorf("empty policy = %+v, %v", policy, err)
+	}
+	for _, bad := range []string{"5", "high", "-1"} {
+		if _, err := ParseVerificationPolicy("", bad); err == nil {
+			t.Errorf("priority %q accepted", bad)
+		}
+	}
+}
+
+func TestVerificationPolicyRequires(t *testing.T) {
+	policy := VerificationPolicy{Labels: []string{"security"}, MaxPriority: 1}
+	tests := []struct {
+		priority int
+		labels   []string
+		want     bool
+	}{
+		{0, nil, true},
+		{1, nil, true},
+		{2, nil, false},
+		{3, []string{"ui", "security"}, true},
+		{3, []string{"ui"}, false},
+	}
+	for _, tt := range tests {
+		if got := policy.Requires(&Issue{Priority: tt.priority}, tt.labels); got != tt.want {
+			t.Errorf("Requires(P%d, %v) = %v, want %v", tt.priority, tt.labels, got, tt.want)
+		}
+	}
+	if (VerificationPolicy{MaxPriority: -1}).Requires(&Issue{Priority: 0}, nil) {
+		t.Error("empty policy should require nothing")
+	}
+}
+
+func TestResolver(t *testing.T) {
+	comments := []*Comment{
+		{Author: "alice", Text: ResolutionCommentPrefix + "PR #1"},
+		{Author: "bob", Text: "Rejected, tests fail"},
+		{Author: "carol", Text: ResolutionCommentPrefix + "PR #2"},
+		{Author: "dave", Text: "LGTM"},
+	}
+	if got := Resolver(comments); got != "carol" {
+		t.Errorf("Resolver = %q, want carol", got)
+	}
+	if got := Resolver(comments[1:2]); got != "" {
+		t.Errorf("Resolver without resolution = %q", got)
+	}
+}
@@ -0,0 +1,19 @@
+package util
+
+import (
+	"bufio"
+	"io"
+)
+
+// MaxJSONLLineSize is the longest JSONL line (one issue with its labels,
+// dependencies and comments) bd reads. Export never truncates, so every
+// reader must accept lines this long; bufio.Scanner alone stops at 64KB.
+const MaxJSONLLineSize = 64 * 1024 * 1024
+
+// NewJSONLScanner returns a line scanner for JSONL that accepts lines up to
+// MaxJSONLLineSize
+func NewJSONLScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), MaxJSONLLineSize)
+	return scanner
+}
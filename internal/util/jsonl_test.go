@@ -0,0 +1,22 @@
+package util
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewJSONLScannerLongLines(t *testing.T) {
+	long := `{"id":"bd-1","description":"` + strings.Repeat("x", 1<<20) + `"}`
+	scanner := NewJSONLScanner(strings.NewReader(long + "\n{}\n"))
+
+	var lines []int
+	for scanner.Scan() {
+		lines = append(lines, len(scanner.Text()))
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("scan failed: %v", err)
+	}
+	if len(lines) != 2 || lines[0] != len(long) {
+		t.Errorf("line lengths = %v, want [%d 2]", lines, len(long))
+	}
+}
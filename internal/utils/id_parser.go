@@ -32,6 +32,7 @@ func ParseIssueID(input string, prefix string) string {
 // - Without hyphen: "bda3f8e9" or "wya3f8e9" → "bd-a3f8e9"
 // - Partial IDs: "a3f8" → "bd-a3f8e9" (if unique match)
 // - Hierarchical: "a3f8e9.1" → "bd-a3f8e9.1"
+// - Aliases: "auth-refactor" → "bd-a3f8e9" (see bd alias-id)
 //
 // Returns an error if:
 // - No issue found matching the ID
@@ -44,6 +45,12 @@ func ResolvePartialID(ctx context.Context, store storage.Storage, input string)
 	if issue, err := store.GetIssue(ctx, input); err == nil && issue != nil {
 		return input, nil
 	}
+
+	// Aliases come next, so one that also happens to be a valid hash
+	// fragment still resolves to the issue it names
+	if id, err := store.ResolveIssueAlias(ctx, input); err == nil && id != "" {
+		return id, nil
+	}
 	
 	// Get the configured prefix
 	prefix, err := store.GetConfig(ctx, "issue_prefix")
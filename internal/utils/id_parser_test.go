@@ -288,6 +288,34 @@ func TestResolvePartialIDs(t *testing.T) {
 	}
 }
 
+func TestResolvePartialID_Alias(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New("")
+	for _, id := range []string{"bd-1", "bd-a3f8e9"} {
+		issue := &types.Issue{ID: id, Title: id, Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, alias := range []string{"auth-refactor", "a3f"} {
+		if err := store.SetIssueAlias(ctx, "bd-1", alias, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := map[string]string{
+		"auth-refactor": "bd-1",
+		"a3f":           "bd-1", // the alias wins over the hash fragment of bd-a3f8e9
+		"a3f8":          "bd-a3f8e9",
+	}
+	for input, want := range tests {
+		got, err := ResolvePartialID(ctx, store, input)
+		if err != nil || got != want {
+			t.Errorf("ResolvePartialID(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+}
+
 func TestResolvePartialID_NoConfig(t *testing.T) {
 	ctx := context.Background()
 	store := memory.New("")
@@ -37,6 +37,7 @@ func ParseIssueType(content string) (types.IssueType, error) {
 		types.TypeTask:    true,
 		types.TypeEpic:    true,
 		types.TypeChore:   true,
+		types.TypeSpike:   true,
 	}
 
 	if !validTypes[issueType] {
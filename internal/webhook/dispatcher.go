@@ -0,0 +1,231 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/steveyegge/beads/internal/events"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// EventSource is what a Dispatcher reads; the SQLite storage implements it
+type EventSource interface {
+	events.Store
+	GetIssue(ctx context.Context, id string) (*types.Issue, error)
+	GetLabels(ctx context.Context, issueID string) ([]string, error)
+}
+
+// Polling and retry timing
+const (
+	dispatchPollInterval = 2 * time.Second
+	retryBaseDelay       = 2 * time.Second
+	retryMaxDelay        = 5 * time.Minute
+	// maxDeliveryAttempts bounds retries of one event (about 15 minutes with
+	// backoff), so an endpoint that keeps rejecting it doesn't stall forever
+	maxDeliveryAttempts = 10
+)
+
+// SignatureHeader carries "sha256=" and the hex HMAC-SHA256 of the body,
+// keyed with the endpoint's secret
+const SignatureHeader = "X-Beads-Signature"
+
+// LifecycleEvent is the body POSTed for each event
+type LifecycleEvent struct {
+	ID        int64        `json:"id"`    // Event ID; delivery is at-least-once, so deduplicate on it
+	Event     string       `json:"event"` // issue.<event_type>, e.g. issue.closed
+	Webhook   string       `json:"webhook"`
+	IssueID   string       `json:"issue_id"`
+	Actor     string       `json:"actor"`
+	OldValue  *string      `json:"old_value,omitempty"`
+	NewValue  *string      `json:"new_value,omitempty"`
+	Comment   *string      `json:"comment,omitempty"`
+	Issue     *types.Issue `json:"issue,omitempty"` // The issue as it is now; absent once deleted
+	CreatedAt time.Time    `json:"created_at"`
+	SentAt    time.Time    `json:"sent_at"`
+}
+
+// Dispatcher sends issue lifecycle events from the events table to
+// endpoints. Each endpoint is an events.Relay with its own cursor (metadata
+// webhook.<name>.cursor) that only advances once the endpoint has accepted
+// an event, so one that is down gets the events it missed, in order, once
+// it is back, without holding up the others. Failed deliveries are retried
+// with exponential backoff and dropped after maxDeliveryAttempts.
+type Dispatcher struct {
+	Endpoints []Endpoint
+	Source    EventSource
+	// Client sends the requests; nil means a client with a 10s timeout
+	Client *http.Client
+	// Interval between polls of the events table; zero means 2s
+	Interval time.Duration
+	// Logf receives delivery errors; may be nil
+	Logf func(format string, args ...interface{})
+
+	now     func() time.Time
+	senders map[string]*sender
+}
+
+// sender is one endpoint's relay and its failed attempts at its next event
+type sender struct {
+	relay    *events.Relay
+	attempts int
+}
+
+// Run sends new events until ctx is cancelled
+func (d *Dispatcher) Run(ctx context.Context) {
+	interval := d.Interval
+	if interval <= 0 {
+		interval = dispatchPollInterval
+	}
+	events.Run(ctx, interval, d.Deliver, func(err error) {
+		d.logf("webhooks: %v", err)
+	})
+}
+
+// Deliver sends every endpoint that isn't waiting to retry the events after
+// its cursor, and returns how many were sent. Without a cursor an endpoint
+// starts at the newest event rather than replaying the whole history.
+func (d *Dispatcher) Deliver(ctx context.Context) (int, error) {
+	if d.senders == nil {
+		d.senders = make(map[string]*sender)
+	}
+	sent := 0
+	var errs []error
+	for i := range d.Endpoints {
+		ep := &d.Endpoints[i]
+		s := d.senders[ep.Name]
+		if s == nil {
+			s = &sender{relay: &events.Relay{
+				Store:         d.Source,
+				Key:           CursorKey(ep.Name),
+				RetryDelay:    retryBaseDelay,
+				MaxRetryDelay: retryMaxDelay,
+				Now:           d.clock,
+			}}
+			d.senders[ep.Name] = s
+		}
+		_, err := s.relay.Deliver(ctx, func(ctx context.Context, event *types.Event) error {
+			ok, err := d.send(ctx, ep, s, event)
+			if ok {
+				sent++
+			}
+			return err
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", ep.Name, err))
+		}
+	}
+	return sent, errors.Join(errs...)
+}
+
+// send delivers one event if the endpoint wants it and reports whether it
+// was sent. A failure is returned while the event still has attempts left;
+// after the last one the event is dropped.
+func (d *Dispatcher) send(ctx context.Context, ep *Endpoint, s *sender, event *types.Event) (bool, error) {
+	if !ep.Wants(event.EventType) {
+		return false, nil
+	}
+	issue, err := d.Source.GetIssue(ctx, event.IssueID)
+	if err != nil {
+		return false, fmt.Errorf("loading %s: %w", event.IssueID, err)
+	}
+	if issue != nil {
+		if issue.Labels, err = d.Source.GetLabels(ctx, issue.ID); err != nil {
+			return false, fmt.Errorf("loading labels of %s: %w", issue.ID, err)
+		}
+	}
+	if len(ep.Labels) > 0 && (issue == nil || !ep.MatchesLabels(issue.Labels)) {
+		return false, nil
+	}
+
+	err = d.post(ctx, ep, event, issue)
+	if err == nil {
+		s.attempts = 0
+		return true, nil
+	}
+	if ctx.Err() != nil {
+		return false, err
+	}
+	s.attempts++
+	if s.attempts >= maxDeliveryAttempts {
+		s.attempts = 0
+		d.logf("webhooks: %s: dropping event %d after %d attempts: %v", ep.Name, event.ID, maxDeliveryAttempts, err)
+		return false, nil
+	}
+	return false, fmt.Errorf("sending event %d: %w", event.ID, err)
+}
+
+func (d *Dispatcher) post(ctx context.Context, ep *Endpoint, event *types.Event, issue *types.Issue) error {
+	name := "issue." + string(event.EventType)
+	body, err := json.Marshal(LifecycleEvent{
+		ID:        event.ID,
+		Event:     name,
+		Webhook:   ep.Name,
+		IssueID:   event.IssueID,
+		Actor:     event.Actor,
+		OldValue:  event.OldValue,
+		NewValue:  event.NewValue,
+		Comment:   event.Comment,
+		Issue:     issue,
+		CreatedAt: event.CreatedAt,
+		SentAt:    d.clock().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ep.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "beads-webhook")
+	req.Header.Set("X-Beads-Event", name)
+	req.Header.Set("X-Beads-Delivery", strconv.FormatInt(event.ID, 10))
+	if ep.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(ep.Secret, body))
+	}
+
+	client := d.Client
+	if client == nil {
+		client = &http.Client{Timeout: requestTimeout}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s returned %s", ep.URL, resp.Status)
+	}
+	return nil
+}
+
+// Sign returns the X-Beads-Signature value for body
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (d *Dispatcher) clock() time.Time {
+	if d.now != nil {
+		return d.now()
+	}
+	return time.Now()
+}
+
+func (d *Dispatcher) logf(format string, args ...interface{}) {
+	if d.Logf != nil {
+		d.Logf(format, args...)
+	}
+}
@@ -0,0 +1,221 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+type eventSource struct {
+	memorySource
+	events []*types.Event
+	issues map[string]*types.Issue
+}
+
+func (s *eventSource) GetEventsSince(_ context.Context, afterID int64, limit int) ([]*types.Event, error) {
+	var result []*types.Event
+	for _, e := range s.events {
+		if e.ID > afterID && len(result) < limit {
+			result = append(result, e)
+		}
+	}
+	return result, nil
+}
+
+func (s *eventSource) LatestEventID(context.Context) (int64, error) {
+	if len(s.events) == 0 {
+		return 0, nil
+	}
+	return s.events[len(s.events)-1].ID, nil
+}
+
+func (s *eventSource) GetIssue(_ context.Context, id string) (*types.Issue, error) {
+	if issue, ok := s.issues[id]; ok {
+		copied := *issue
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+func (s *eventSource) GetLabels(_ context.Context, id string) ([]string, error) {
+	return s.labels[id], nil
+}
+
+func (s *eventSource) add(issueID string, eventType types.EventType) {
+	s.events = append(s.events, &types.Event{ID: int64(len(s.events) + 1), IssueID: issueID, EventType: eventType})
+}
+
+// endpoint records the events POSTed to it and checks their signature
+type endpoint struct {
+	mu       sync.Mutex
+	secret   string
+	received []string // <issue>:<event>
+	fail     bool
+}
+
+func (e *endpoint) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.fail {
+		http.Error(w, "down", http.StatusServiceUnavailable)
+		return
+	}
+	body, _ := io.ReadAll(req.Body)
+	if e.secret != "" && req.Header.Get(SignatureHeader) != Sign(e.secret, body) {
+		http.Error(w, "bad signature", http.StatusUnauthorized)
+		return
+	}
+	var event LifecycleEvent
+	if err := json.Unmarshal(body, &event); err != nil || req.Header.Get("X-Beads-Event") != event.Event {
+		http.Error(w, "bad event", http.StatusBadRequest)
+		return
+	}
+	e.received = append(e.received, event.IssueID+":"+event.Event)
+}
+
+func (e *endpoint) take() string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	got := strings.Join(e.received, " ")
+	e.received = nil
+	return got
+}
+
+func TestParseEndpoints(t *testing.T) {
+	endpoints, err := ParseEndpoints(map[string]string{
+		"issue_prefix":         "bd",
+		"webhook.url":          "https://hooks.slack.com/services/x",
+		"webhook.events":       "created, issue.closed",
+		"webhook.ci.url":       "http://localhost:9000/beads",
+		"webhook.ci.secret":    "s3cret",
+		"webhook.ci.labels":    "ci",
+		"webhook.all.url":      "http://localhost:9001/",
+		"webhook.all.events":   "*",
+		"ready_webhook.x.url":  "http://localhost:9002/",
+		"notify.alice.webhook": "http://localhost:9003/",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(endpoints) != 3 || endpoints[0].Name != "all" || endpoints[1].Name != "ci" || endpoints[2].Name != DefaultEndpoint {
+		t.Fatalf("endpoints = %+v", endpoints)
+	}
+	if endpoints[0].Events != nil || endpoints[1].Secret != "s3cret" || len(endpoints[1].Labels) != 1 {
+		t.Errorf("endpoints = %+v", endpoints)
+	}
+	def := endpoints[2]
+	if !def.Wants(types.EventCreated) || !def.Wants(types.EventClosed) || def.Wants(types.EventUpdated) {
+		t.Errorf("default endpoint events = %v", def.Events)
+	}
+
+	for config, want := range map[string]string{
+		"webhook.secret":   "has no webhook.url",
+		"webhook.x.labels": "has no webhook.x.url",
+		"webhook.x.colour": "unknown config key webhook.x.colour",
+		"webhook.events":   "unknown event type",
+		"webhook.url":      "must start with http",
+	} {
+		value := "x"
+		if strings.HasSuffix(config, ".events") {
+			value = "created,deployed"
+		}
+		if _, err := ParseEndpoints(map[string]string{config: value}); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: got %v, want an error containing %q", config, err, want)
+		}
+	}
+}
+
+func TestDispatcher(t *testing.T) {
+	slack := &endpoint{}
+	ci := &endpoint{secret: "s3cret"}
+	slackServer := httptest.NewServer(slack)
+	defer slackServer.Close()
+	ciServer := httptest.NewServer(ci)
+	defer ciServer.Close()
+
+	source := &eventSource{
+		memorySource: memorySource{
+			labels:   map[string][]string{"bd-2": {"ci"}},
+			metadata: make(map[string]string),
+		},
+		issues: map[string]*types.Issue{
+			"bd-1": {ID: "bd-1", Title: "Fix login"},
+			"bd-2": {ID: "bd-2", Title: "Flaky build"},
+		},
+	}
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	d := &Dispatcher{
+		Source: source,
+		Endpoints: []Endpoint{
+			{Name: "ci", URL: ciServer.URL, Secret: "s3cret", Labels: []string{"ci"}},
+			{Name: "slack", URL: slackServer.URL, Events: []types.EventType{types.EventCreated, types.EventClosed}},
+		},
+		now: func() time.Time { return now },
+	}
+	ctx := context.Background()
+
+	// History from before the webhooks existed isn't replayed
+	source.add("bd-1", types.EventCreated)
+	if sent, err := d.Deliver(ctx); err != nil || sent != 0 {
+		t.Fatalf("first Deliver = %d, %v; want 0, nil", sent, err)
+	}
+
+	source.add("bd-2", types.EventCreated)
+	source.add("bd-1", types.EventUpdated)
+	source.add("bd-2", types.EventClosed)
+	if sent, err := d.Deliver(ctx); err != nil || sent != 4 {
+		t.Fatalf("Deliver = %d, %v; want 4, nil", sent, err)
+	}
+	if got, want := slack.take(), "bd-2:issue.created bd-2:issue.closed"; got != want {
+		t.Errorf("slack received %q, want %q", got, want)
+	}
+	if got, want := ci.take(), "bd-2:issue.created bd-2:issue.closed"; got != want {
+		t.Errorf("ci received %q, want %q (signed, labeled ci only)", got, want)
+	}
+
+	// slack is down: it waits out its backoff while ci carries on
+	slack.fail = true
+	source.add("bd-1", types.EventClosed)
+	source.add("bd-2", types.EventReopened)
+	if sent, err := d.Deliver(ctx); err == nil || sent != 1 {
+		t.Fatalf("Deliver with slack down = %d, %v; want 1 and an error", sent, err)
+	}
+	slack.fail = false
+	if sent, err := d.Deliver(ctx); err != nil || sent != 0 {
+		t.Errorf("Deliver during backoff = %d, %v; want 0, nil", sent, err)
+	}
+	now = now.Add(retryBaseDelay)
+	if sent, err := d.Deliver(ctx); err != nil || sent != 1 {
+		t.Errorf("Deliver after backoff = %d, %v; want 1, nil", sent, err)
+	}
+	if got := slack.take(); got != "bd-1:issue.closed" {
+		t.Errorf("slack received %q after retry", got)
+	}
+	if got := ci.take(); got != "bd-2:issue.reopened" {
+		t.Errorf("ci received %q", got)
+	}
+
+	// An event the endpoint never accepts is dropped after the last attempt
+	slack.fail = true
+	source.add("bd-1", types.EventCreated)
+	for attempt := 1; attempt < maxDeliveryAttempts; attempt++ {
+		if _, err := d.Deliver(ctx); err == nil {
+			t.Fatalf("attempt %d succeeded", attempt)
+		}
+		now = now.Add(retryMaxDelay)
+	}
+	if _, err := d.Deliver(ctx); err != nil {
+		t.Errorf("last attempt = %v, want the event dropped", err)
+	}
+	if got := source.metadata[CursorKey("slack")]; got != "7" {
+		t.Errorf("slack cursor = %q, want 7", got)
+	}
+}
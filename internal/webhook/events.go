@@ -0,0 +1,165 @@
+package webhook
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// EventConfigPrefix starts every lifecycle webhook config key. The endpoint
+// set with webhook.url, webhook.secret, ... is named "default"; more
+// endpoints use webhook.<name>.url, webhook.<name>.secret, ...:
+//
+//	webhook.<name>.url     endpoint (required)
+//	webhook.<name>.events  comma-separated event types to send (created,
+//	                       closed, status_changed, ...); unset sends all
+//	webhook.<name>.labels  comma-separated labels the issue must all have
+//	webhook.<name>.secret  signs each body with HMAC-SHA256 (X-Beads-Signature)
+const EventConfigPrefix = "webhook."
+
+// DefaultEndpoint names the endpoint configured without a name
+const DefaultEndpoint = "default"
+
+// EventTypes are the event types an endpoint can filter on
+var EventTypes = []types.EventType{
+	types.EventCreated,
+	types.EventUpdated,
+	types.EventStatusChanged,
+	types.EventCommented,
+	types.EventCommentEdited,
+	types.EventCommentDeleted,
+	types.EventClosed,
+	types.EventReopened,
+	types.EventDependencyAdded,
+	types.EventDependencyRemoved,
+	types.EventLabelAdded,
+	types.EventLabelRemoved,
+	types.EventCompacted,
+	types.EventTimeboxElapsed,
+}
+
+// Endpoint is one lifecycle webhook and its filter
+type Endpoint struct {
+	Name   string
+	URL    string
+	Secret string
+	Events []types.EventType // nil sends every event type
+	Labels []string
+}
+
+// CursorKey is the metadata key holding the last event ID an endpoint has
+// been sent
+func CursorKey(name string) string {
+	return EventConfigPrefix + name + ".cursor"
+}
+
+// Wants reports whether the endpoint is sent events of this type
+func (e *Endpoint) Wants(eventType types.EventType) bool {
+	if e.Events == nil {
+		return true
+	}
+	for _, t := range e.Events {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchesLabels reports whether an issue with these labels passes the
+// endpoint's label filter
+func (e *Endpoint) MatchesLabels(labels []string) bool {
+	has := make(map[string]bool, len(labels))
+	for _, label := range labels {
+		has[label] = true
+	}
+	for _, label := range e.Labels {
+		if !has[label] {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseEndpoints reads the webhook.* keys out of a config map and returns
+// the endpoints ordered by name
+func ParseEndpoints(config map[string]string) ([]Endpoint, error) {
+	byName := make(map[string]*Endpoint)
+	for key, value := range config {
+		rest, ok := strings.CutPrefix(key, EventConfigPrefix)
+		if !ok {
+			continue
+		}
+		name, field := DefaultEndpoint, rest
+		if dot := strings.LastIndex(rest, "."); dot >= 0 {
+			name, field = rest[:dot], rest[dot+1:]
+		}
+		if name == "" {
+			return nil, fmt.Errorf("invalid config key %s (want %s<name>.<field>)", key, EventConfigPrefix)
+		}
+		ep := byName[name]
+		if ep == nil {
+			ep = &Endpoint{Name: name}
+			byName[name] = ep
+		}
+		value = strings.TrimSpace(value)
+		switch field {
+		case "url":
+			ep.URL = value
+		case "secret":
+			ep.Secret = value
+		case "labels":
+			ep.Labels = splitList(value)
+		case "events":
+			events, err := parseEventTypes(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", key, err)
+			}
+			ep.Events = events
+		default:
+			return nil, fmt.Errorf("unknown config key %s (want url, events, labels or secret)", key)
+		}
+	}
+
+	endpoints := make([]Endpoint, 0, len(byName))
+	for _, ep := range byName {
+		urlKey := EventConfigPrefix + ep.Name + ".url"
+		if ep.Name == DefaultEndpoint {
+			urlKey = EventConfigPrefix + "url"
+		}
+		if ep.URL == "" {
+			return nil, fmt.Errorf("webhook %q has no %s", ep.Name, urlKey)
+		}
+		if !strings.HasPrefix(ep.URL, "http://") && !strings.HasPrefix(ep.URL, "https://") {
+			return nil, fmt.Errorf("webhook %q: url must start with http:// or https://", ep.Name)
+		}
+		endpoints = append(endpoints, *ep)
+	}
+	sort.Slice(endpoints, func(i, j int) bool { return endpoints[i].Name < endpoints[j].Name })
+	return endpoints, nil
+}
+
+// parseEventTypes reads an events filter; "*" or an empty list means all
+func parseEventTypes(value string) ([]types.EventType, error) {
+	var events []types.EventType
+	for _, name := range splitList(value) {
+		if name == "*" {
+			return nil, nil
+		}
+		eventType := types.EventType(strings.TrimPrefix(name, "issue."))
+		known := false
+		for _, t := range EventTypes {
+			if t == eventType {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown event type %q", name)
+		}
+		events = append(events, eventType)
+	}
+	return events, nil
+}
@@ -0,0 +1,217 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Source is what a ReadyNotifier reads; the storage backends implement it
+type Source interface {
+	GetReadyWork(ctx context.Context, filter types.WorkFilter) ([]*types.Issue, error)
+	GetLabelsForIssues(ctx context.Context, issueIDs []string) (map[string][]string, error)
+	GetMetadata(ctx context.Context, key string) (string, error)
+	SetMetadata(ctx context.Context, key, value string) error
+}
+
+// Polling and request timing
+const (
+	readyPollInterval = 5 * time.Second
+	requestTimeout    = 10 * time.Second
+)
+
+// ReadyEvent is the body POSTed when an issue becomes ready
+type ReadyEvent struct {
+	Event        string       `json:"event"` // always "issue.ready"
+	Subscription string       `json:"subscription"`
+	Issue        *types.Issue `json:"issue"`
+	SentAt       time.Time    `json:"sent_at"`
+}
+
+// ReadyNotifier polls the ready set and notifies matching subscriptions
+// about issues that entered it. Each subscription remembers which issues it
+// has been told about (metadata ready_webhook.<name>.notified), so failed
+// deliveries are retried on the next poll and an issue that leaves the
+// ready set and comes back is announced again.
+type ReadyNotifier struct {
+	Subscriptions []Subscription
+	Source        Source
+	// Client sends the requests; nil means a client with a 10s timeout
+	Client *http.Client
+	// Interval between polls; zero means 5s
+	Interval time.Duration
+	// Logf receives delivery errors; may be nil
+	Logf func(format string, args ...interface{})
+}
+
+// NotifiedKey is the metadata key holding the issues a subscription has
+// already been notified about
+func NotifiedKey(name string) string {
+	return ReadyConfigPrefix + name + ".notified"
+}
+
+// Run checks for newly ready issues until ctx is cancelled
+func (n *ReadyNotifier) Run(ctx context.Context) {
+	interval := n.Interval
+	if interval <= 0 {
+		interval = readyPollInterval
+	}
+	for {
+		if _, err := n.Check(ctx); err != nil && ctx.Err() == nil {
+			n.logf("ready webhooks: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Check notifies every subscription about the matching issues that became
+// ready since its last check and returns how many notifications were sent.
+// A subscription seen for the first time only records the current ready
+// set, so adding one doesn't replay the whole backlog.
+func (n *ReadyNotifier) Check(ctx context.Context) (int, error) {
+	ready, err := n.Source.GetReadyWork(ctx, types.WorkFilter{})
+	if err != nil {
+		return 0, fmt.Errorf("listing ready work: %w", err)
+	}
+	ids := make([]string, len(ready))
+	for i, issue := range ready {
+		ids[i] = issue.ID
+	}
+	labels, err := n.Source.GetLabelsForIssues(ctx, ids)
+	if err != nil {
+		return 0, fmt.Errorf("loading labels: %w", err)
+	}
+
+	sent := 0
+	var errs []error
+	for i := range n.Subscriptions {
+		count, err := n.checkSubscription(ctx, &n.Subscriptions[i], ready, labels)
+		sent += count
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", n.Subscriptions[i].Name, err))
+		}
+	}
+	return sent, errors.Join(errs...)
+}
+
+func (n *ReadyNotifier) checkSubscription(ctx context.Context, sub *Subscription, ready []*types.Issue, labels map[string][]string) (int, error) {
+	key := NotifiedKey(sub.Name)
+	raw, err := n.Source.GetMetadata(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+
+	var matching []*types.Issue
+	for _, issue := range ready {
+		if sub.Matches(issue, labels[issue.ID]) {
+			matching = append(matching, issue)
+		}
+	}
+
+	if raw == "" {
+		// First run: everything currently ready counts as already announced
+		ids := make([]string, len(matching))
+		for i, issue := range matching {
+			ids[i] = issue.ID
+		}
+		return 0, n.saveNotified(ctx, key, ids)
+	}
+	var previous []string
+	if err := json.Unmarshal([]byte(raw), &previous); err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", key, err)
+	}
+	notified := make(map[string]bool, len(previous))
+	for _, id := range previous {
+		notified[id] = true
+	}
+
+	// Still-ready issues stay notified; issues that left the ready set drop out
+	var keep []string
+	sent := 0
+	var deliveryErr error
+	for _, issue := range matching {
+		if notified[issue.ID] {
+			keep = append(keep, issue.ID)
+			continue
+		}
+		if deliveryErr != nil {
+			continue
+		}
+		withLabels := *issue
+		withLabels.Labels = labels[issue.ID]
+		if err := n.post(ctx, sub, &withLabels); err != nil {
+			deliveryErr = fmt.Errorf("notifying about %s: %w", issue.ID, err)
+			continue
+		}
+		keep = append(keep, issue.ID)
+		sent++
+	}
+	if err := n.saveNotified(ctx, key, keep); err != nil {
+		return sent, err
+	}
+	return sent, deliveryErr
+}
+
+func (n *ReadyNotifier) saveNotified(ctx context.Context, key string, ids []string) error {
+	if ids == nil {
+		ids = []string{}
+	}
+	sort.Strings(ids)
+	data, err := json.Marshal(ids)
+	if err != nil {
+		return err
+	}
+	return n.Source.SetMetadata(ctx, key, string(data))
+}
+
+func (n *ReadyNotifier) post(ctx context.Context, sub *Subscription, issue *types.Issue) error {
+	body, err := json.Marshal(ReadyEvent{
+		Event:        "issue.ready",
+		Subscription: sub.Name,
+		Issue:        issue,
+		SentAt:       time.Now().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "beads-webhook")
+	req.Header.Set("X-Beads-Event", "issue.ready")
+
+	client := n.Client
+	if client == nil {
+		client = &http.Client{Timeout: requestTimeout}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s returned %s", sub.URL, resp.Status)
+	}
+	return nil
+}
+
+func (n *ReadyNotifier) logf(format string, args ...interface{}) {
+	if n.Logf != nil {
+		n.Logf(format, args...)
+	}
+}
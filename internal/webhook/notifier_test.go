@@ -0,0 +1,146 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+type memorySource struct {
+	ready    []*types.Issue
+	labels   map[string][]string
+	metadata map[string]string
+}
+
+func (m *memorySource) GetReadyWork(context.Context, types.WorkFilter) ([]*types.Issue, error) {
+	return m.ready, nil
+}
+
+func (m *memorySource) GetLabelsForIssues(_ context.Context, ids []string) (map[string][]string, error) {
+	result := make(map[string][]string)
+	for _, id := range ids {
+		result[id] = m.labels[id]
+	}
+	return result, nil
+}
+
+func (m *memorySource) GetMetadata(_ context.Context, key string) (string, error) {
+	return m.metadata[key], nil
+}
+
+func (m *memorySource) SetMetadata(_ context.Context, key, value string) error {
+	m.metadata[key] = value
+	return nil
+}
+
+func (m *memorySource) setReady(ids ...string) {
+	m.ready = nil
+	for _, id := range ids {
+		m.ready = append(m.ready, &types.Issue{ID: id, Title: id, Priority: 2})
+	}
+}
+
+// receiver records the issue IDs POSTed to it, per subscription
+type receiver struct {
+	mu       sync.Mutex
+	received map[string][]string
+	fail     bool
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.fail {
+		http.Error(w, "down", http.StatusServiceUnavailable)
+		return
+	}
+	var event ReadyEvent
+	if err := json.NewDecoder(req.Body).Decode(&event); err != nil || event.Event != "issue.ready" {
+		http.Error(w, "bad event", http.StatusBadRequest)
+		return
+	}
+	r.received[event.Subscription] = append(r.received[event.Subscription], event.Issue.ID)
+}
+
+func (r *receiver) take(name string) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ids := r.received[name]
+	delete(r.received, name)
+	return ids
+}
+
+func TestReadyNotifier(t *testing.T) {
+	recv := &receiver{received: make(map[string][]string)}
+	server := httptest.NewServer(recv)
+	defer server.Close()
+
+	source := &memorySource{
+		labels: map[string][]string{
+			"bd-2": {"requires:gpu"},
+			"bd-3": {"docs"},
+			"bd-4": {"requires:gpu", "docs"},
+		},
+		metadata: make(map[string]string),
+	}
+	notifier := &ReadyNotifier{
+		Source: source,
+		Subscriptions: []Subscription{
+			{Name: "cpu", URL: server.URL, Requires: []string{}},
+			{Name: "gpu", URL: server.URL, Requires: []string{"gpu"}},
+			{Name: "docs", URL: server.URL, Labels: []string{"docs"}},
+		},
+	}
+	ctx := context.Background()
+
+	// The first check only records what is already ready
+	source.setReady("bd-1")
+	if sent, err := notifier.Check(ctx); err != nil || sent != 0 {
+		t.Fatalf("first check: sent %d, %v", sent, err)
+	}
+
+	source.setReady("bd-1", "bd-2", "bd-3", "bd-4")
+	if sent, err := notifier.Check(ctx); err != nil || sent != 6 {
+		t.Fatalf("second check: sent %d, %v", sent, err)
+	}
+	for name, want := range map[string][]string{"cpu": {"bd-3"}, "gpu": {"bd-2", "bd-3", "bd-4"}, "docs": {"bd-3", "bd-4"}} {
+		got := recv.take(name)
+		if len(got) != len(want) {
+			t.Errorf("%s received %v, want %v", name, got, want)
+			continue
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("%s received %v, want %v", name, got, want)
+				break
+			}
+		}
+	}
+
+	// Nothing new: nothing sent
+	if sent, _ := notifier.Check(ctx); sent != 0 {
+		t.Errorf("repeat check sent %d", sent)
+	}
+
+	// bd-3 leaves the ready set and comes back while the receiver is down;
+	// the notification goes out once it recovers
+	source.setReady("bd-1", "bd-2", "bd-4")
+	_, _ = notifier.Check(ctx)
+	source.setReady("bd-1", "bd-2", "bd-3", "bd-4")
+	recv.fail = true
+	if _, err := notifier.Check(ctx); err == nil {
+		t.Error("expected a delivery error")
+	}
+	recv.fail = false
+	if sent, err := notifier.Check(ctx); err != nil || sent != 3 {
+		t.Errorf("retry: sent %d, %v", sent, err)
+	}
+	if got := recv.take("cpu"); len(got) != 1 || got[0] != "bd-3" {
+		t.Errorf("cpu received %v after retry", got)
+	}
+}
@@ -0,0 +1,136 @@
+// Package webhook notifies HTTP endpoints about issue activity.
+//
+// Lifecycle webhooks (webhook.*, see EventConfigPrefix) POST each event in
+// the events table, such as an issue being created or closed, to every
+// endpoint whose filter it passes.
+//
+// Readiness subscriptions (ready_webhook.<name>.*) POST an issue to their
+// URL when it becomes ready to work on. Each subscription carries a filter so
+// an orchestrator only hears about work it can take:
+//
+//	ready_webhook.<name>.url       endpoint (required)
+//	ready_webhook.<name>.labels    comma-separated labels the issue must all have
+//	ready_webhook.<name>.requires  capability tags the subscriber provides; issues
+//	                               labeled requires:<tag> only match when every
+//	                               such tag is listed
+//	ready_webhook.<name>.priority  lowest priority to notify about (1 = P0 and P1)
+package webhook
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ReadyConfigPrefix starts every readiness subscription config key
+const ReadyConfigPrefix = "ready_webhook."
+
+// RequiresLabelPrefix marks a capability an issue needs (requires:gpu)
+const RequiresLabelPrefix = "requires:"
+
+// Subscription is one readiness webhook and its filter
+type Subscription struct {
+	Name   string
+	URL    string
+	Labels []string
+	// Requires is nil when the subscriber didn't declare capabilities, which
+	// disables capability matching
+	Requires    []string
+	MaxPriority *int
+}
+
+// Matches reports whether an issue with these labels passes the filter
+func (s *Subscription) Matches(issue *types.Issue, labels []string) bool {
+	if s.MaxPriority != nil && issue.Priority > *s.MaxPriority {
+		return false
+	}
+	has := make(map[string]bool, len(labels))
+	for _, label := range labels {
+		has[label] = true
+	}
+	for _, label := range s.Labels {
+		if !has[label] {
+			return false
+		}
+	}
+	if s.Requires != nil {
+		provides := make(map[string]bool, len(s.Requires))
+		for _, tag := range s.Requires {
+			provides[tag] = true
+		}
+		for _, label := range labels {
+			if tag, ok := strings.CutPrefix(label, RequiresLabelPrefix); ok && !provides[tag] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// ParseReadySubscriptions reads the ready_webhook.* keys out of a config map
+// and returns the subscriptions ordered by name
+func ParseReadySubscriptions(config map[string]string) ([]Subscription, error) {
+	byName := make(map[string]*Subscription)
+	for key, value := range config {
+		rest, ok := strings.CutPrefix(key, ReadyConfigPrefix)
+		if !ok {
+			continue
+		}
+		dot := strings.LastIndex(rest, ".")
+		if dot <= 0 {
+			return nil, fmt.Errorf("invalid config key %s (want %s<name>.<field>)", key, ReadyConfigPrefix)
+		}
+		name, field := rest[:dot], rest[dot+1:]
+		sub := byName[name]
+		if sub == nil {
+			sub = &Subscription{Name: name}
+			byName[name] = sub
+		}
+		value = strings.TrimSpace(value)
+		switch field {
+		case "url":
+			sub.URL = value
+		case "labels":
+			sub.Labels = splitList(value)
+		case "requires":
+			sub.Requires = splitList(value)
+			if sub.Requires == nil {
+				sub.Requires = []string{}
+			}
+		case "priority":
+			p, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(value), "P"))
+			if err != nil || p < 0 || p > 4 {
+				return nil, fmt.Errorf("invalid %s %q (want 0-4)", key, value)
+			}
+			sub.MaxPriority = &p
+		default:
+			return nil, fmt.Errorf("unknown config key %s (want url, labels, requires or priority)", key)
+		}
+	}
+
+	subs := make([]Subscription, 0, len(byName))
+	for _, sub := range byName {
+		if sub.URL == "" {
+			return nil, fmt.Errorf("ready webhook %q has no %s%s.url", sub.Name, ReadyConfigPrefix, sub.Name)
+		}
+		if !strings.HasPrefix(sub.URL, "http://") && !strings.HasPrefix(sub.URL, "https://") {
+			return nil, fmt.Errorf("ready webhook %q: url must start with http:// or https://", sub.Name)
+		}
+		subs = append(subs, *sub)
+	}
+	sort.Slice(subs, func(i, j int) bool { return subs[i].Name < subs[j].Name })
+	return subs, nil
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
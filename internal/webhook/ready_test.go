@@ -0,0 +1,81 @@
+package webhook
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestParseReadySubscriptions(t *testing.T) {
+	subs, err := ParseReadySubscriptions(map[string]string{
+		"issue_prefix":               "bd",
+		"ready_webhook.gpu.url":      "https://orch.example.com/hook",
+		"ready_webhook.gpu.requires": "gpu, cuda",
+		"ready_webhook.gpu.priority": "P1",
+		"ready_webhook.docs.url":     "http://localhost:9000/ready",
+		"ready_webhook.docs.labels":  "docs",
+		"ready_webhook.any.url":      "http://localhost:9001/",
+		"ready_webhook.any.requires": "",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(subs) != 3 || subs[0].Name != "any" || subs[1].Name != "docs" || subs[2].Name != "gpu" {
+		t.Fatalf("subscriptions = %+v", subs)
+	}
+	if subs[0].Requires == nil || len(subs[0].Requires) != 0 {
+		t.Errorf("empty requires should mean no capabilities, got %#v", subs[0].Requires)
+	}
+	if subs[1].Requires != nil || len(subs[1].Labels) != 1 {
+		t.Errorf("docs = %+v", subs[1])
+	}
+	gpu := subs[2]
+	if len(gpu.Requires) != 2 || gpu.Requires[1] != "cuda" || gpu.MaxPriority == nil || *gpu.MaxPriority != 1 {
+		t.Errorf("gpu = %+v", gpu)
+	}
+
+	for config, want := range map[string]string{
+		"ready_webhook.x.labels":   "has no ready_webhook.x.url",
+		"ready_webhook.x.priority": "invalid ready_webhook.x.priority",
+		"ready_webhook.x.colour":   "unknown config key",
+		"ready_webhook.url":        "invalid config key",
+	} {
+		_, err := ParseReadySubscriptions(map[string]string{config: "9"})
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: error = %v, want %q", config, err, want)
+		}
+	}
+	if _, err := ParseReadySubscriptions(map[string]string{"ready_webhook.x.url": "ftp://host"}); err == nil {
+		t.Error("expected an error for a non-HTTP url")
+	}
+}
+
+func TestSubscriptionMatches(t *testing.T) {
+	one := 1
+	issue := func(priority int) *types.Issue {
+		return &types.Issue{ID: "bd-1", Priority: priority}
+	}
+	tests := []struct {
+		name   string
+		sub    Subscription
+		issue  *types.Issue
+		labels []string
+		want   bool
+	}{
+		{"no filter", Subscription{}, issue(3), []string{"requires:gpu"}, true},
+		{"labels all present", Subscription{Labels: []string{"backend", "api"}}, issue(2), []string{"api", "backend", "x"}, true},
+		{"label missing", Subscription{Labels: []string{"backend", "api"}}, issue(2), []string{"backend"}, false},
+		{"priority in range", Subscription{MaxPriority: &one}, issue(1), nil, true},
+		{"priority too low", Subscription{MaxPriority: &one}, issue(2), nil, false},
+		{"capabilities cover requirements", Subscription{Requires: []string{"gpu", "cuda"}}, issue(2), []string{"requires:gpu"}, true},
+		{"missing capability", Subscription{Requires: []string{"gpu"}}, issue(2), []string{"requires:gpu", "requires:arm64"}, false},
+		{"no requirements", Subscription{Requires: []string{"gpu"}}, issue(2), []string{"backend"}, true},
+		{"no capabilities", Subscription{Requires: []string{}}, issue(2), []string{"requires:gpu"}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.sub.Matches(tt.issue, tt.labels); got != tt.want {
+			t.Errorf("%s: Matches = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package webui
+
+import (
+	"context"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// GraphNode is an issue in a dependency graph
+type GraphNode struct {
+	ID        string          `json:"id"`
+	Title     string          `json:"title"`
+	Status    types.Status    `json:"status"`
+	Priority  int             `json:"priority"`
+	IssueType types.IssueType `json:"issue_type"`
+	// Level places the node relative to the root: -1 for what the root
+	// depends on, 1 for what depends on the root, and so on outwards
+	Level int `json:"level"`
+}
+
+// GraphEdge is a dependency: From depends on To
+type GraphEdge struct {
+	From string               `json:"from"`
+	To   string               `json:"to"`
+	Type types.DependencyType `json:"type"`
+}
+
+// Graph is the neighbourhood of an issue, as returned by GET /api/graph/{id}
+type Graph struct {
+	Root      string       `json:"root"`
+	Nodes     []*GraphNode `json:"nodes"`
+	Edges     []*GraphEdge `json:"edges"`
+	Truncated bool         `json:"truncated,omitempty"` // Stopped at maxGraphNodes
+}
+
+// BuildGraph collects the issues within depth relations of id, in both
+// directions. Returns nil if id doesn't exist.
+func BuildGraph(ctx context.Context, source Source, id string, depth int) (*Graph, error) {
+	root, err := source.GetIssue(ctx, id)
+	if err != nil || root == nil {
+		return nil, err
+	}
+	graph := &Graph{Root: root.ID}
+	nodes := map[string]*GraphNode{}
+	edges := map[GraphEdge]bool{}
+	addNode := func(issue *types.Issue, level int) bool {
+		if nodes[issue.ID] != nil {
+			return false
+		}
+		if len(nodes) >= maxGraphNodes {
+			graph.Truncated = true
+			return false
+		}
+		node := &GraphNode{ID: issue.ID, Title: issue.Title, Status: issue.Status, Priority: issue.Priority, IssueType: issue.IssueType, Level: level}
+		nodes[issue.ID] = node
+		graph.Nodes = append(graph.Nodes, node)
+		return true
+	}
+	addEdge := func(from, to string, depType types.DependencyType) {
+		edge := GraphEdge{From: from, To: to, Type: depType}
+		if !edges[edge] {
+			edges[edge] = true
+			graph.Edges = append(graph.Edges, &edge)
+		}
+	}
+
+	addNode(root.Issue, 0)
+	frontier := []*IssueDetail{root}
+	for distance := 1; distance <= depth && len(frontier) > 0; distance++ {
+		var next []string
+		for _, detail := range frontier {
+			level := nodes[detail.ID].Level
+			for _, dep := range detail.Dependencies {
+				if addNode(&dep.Issue, level-1) {
+					next = append(next, dep.ID)
+				}
+				if nodes[dep.ID] != nil {
+					addEdge(detail.ID, dep.ID, dep.DependencyType)
+				}
+			}
+			for _, dep := range detail.Dependents {
+				if addNode(&dep.Issue, level+1) {
+					next = append(next, dep.ID)
+				}
+				if nodes[dep.ID] != nil {
+					addEdge(dep.ID, detail.ID, dep.DependencyType)
+				}
+			}
+		}
+		if distance == depth {
+			break
+		}
+		frontier = frontier[:0]
+		for _, id := range next {
+			detail, err := source.GetIssue(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			if detail != nil {
+				frontier = append(frontier, detail)
+			}
+		}
+	}
+	return graph, nil
+}
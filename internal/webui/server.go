@@ -0,0 +1,168 @@
+// Package webui serves a read-only web viewer for a beads project: an
+// issue list with search and filters, issue details, and dependency graphs,
+// for people who browse the tracker without installing bd.
+package webui
+
+import (
+	"embed"
+	"encoding/json"
+	"io/fs"
+	"net/http"
+	"strconv"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+//go:embed static
+var staticFiles embed.FS
+
+const (
+	defaultListLimit = 500
+	maxGraphDepth    = 3
+	maxGraphNodes    = 150
+)
+
+// Server serves the viewer and the JSON API behind it:
+//
+//	GET /api/project           Project name and statistics
+//	GET /api/issues            Issues (?q=&status=&type=&assignee=&priority=&label=&limit=)
+//	GET /api/issues/{id}       An issue with relations and comments
+//	GET /api/graph/{id}        Dependency graph around an issue (?depth=1-3)
+type Server struct {
+	source  Source
+	project string
+	mux     *http.ServeMux
+}
+
+// NewServer creates a viewer for the project named project
+func NewServer(source Source, project string) *Server {
+	s := &Server{source: source, project: project, mux: http.NewServeMux()}
+	static, _ := fs.Sub(staticFiles, "static")
+	s.mux.Handle("GET /", http.FileServer(http.FS(static)))
+	s.mux.HandleFunc("GET /api/project", s.handleProject)
+	s.mux.HandleFunc("GET /api/issues", s.handleList)
+	s.mux.HandleFunc("GET /api/issues/{id}", s.handleGet)
+	s.mux.HandleFunc("GET /api/graph/{id}", s.handleGraph)
+	return s
+}
+
+// ServeHTTP implements http.Handler. Anything but GET and HEAD is refused:
+// the viewer is read-only.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		writeError(w, http.StatusMethodNotAllowed, "the web viewer is read-only")
+		return
+	}
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Content-Security-Policy", "default-src 'self'; style-src 'self'; img-src 'self' data:")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	s.mux.ServeHTTP(w, r)
+}
+
+// ProjectResponse is the body of GET /api/project
+type ProjectResponse struct {
+	Name       string            `json:"name"`
+	Statistics *types.Statistics `json:"statistics"`
+}
+
+func (s *Server) handleProject(w http.ResponseWriter, r *http.Request) {
+	stats, err := s.source.Statistics(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, ProjectResponse{Name: s.project, Statistics: stats})
+}
+
+func (s *Server) handleList(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	filter := types.IssueFilter{Limit: defaultListLimit}
+	if v := q.Get("status"); v != "" && v != "all" {
+		status := types.Status(v)
+		filter.Status = &status
+	}
+	if v := q.Get("type"); v != "" {
+		issueType := types.IssueType(v)
+		filter.IssueType = &issueType
+	}
+	if v := q.Get("assignee"); v != "" {
+		filter.Assignee = &v
+	}
+	if v := q.Get("priority"); v != "" {
+		p, err := strconv.Atoi(v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid priority")
+			return
+		}
+		filter.Priority = &p
+	}
+	for _, label := range q["label"] {
+		if label != "" {
+			filter.Labels = append(filter.Labels, label)
+		}
+	}
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeError(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+		filter.Limit = n
+	}
+
+	issues, err := s.source.ListIssues(r.Context(), q.Get("q"), filter)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if issues == nil {
+		issues = []*types.Issue{}
+	}
+	writeJSON(w, http.StatusOK, issues)
+}
+
+func (s *Server) handleGet(w http.ResponseWriter, r *http.Request) {
+	detail, err := s.source.GetIssue(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if detail == nil {
+		writeError(w, http.StatusNotFound, "issue not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, detail)
+}
+
+func (s *Server) handleGraph(w http.ResponseWriter, r *http.Request) {
+	depth := 2
+	if v := r.URL.Query().Get("depth"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxGraphDepth {
+			writeError(w, http.StatusBadRequest, "invalid depth (1-3)")
+			return
+		}
+		depth = n
+	}
+	graph, err := BuildGraph(r.Context(), s.source, r.PathValue("id"), depth)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if graph == nil {
+		writeError(w, http.StatusNotFound, "issue not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, graph)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
@@ -0,0 +1,147 @@
+package webui
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func newTestServer(t *testing.T) (*Server, map[string]string) {
+	t.Helper()
+	ctx := context.Background()
+	store, err := sqlite.New(ctx, filepath.Join(t.TempDir(), "beads.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	if err := store.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+		t.Fatal(err)
+	}
+
+	ids := map[string]string{}
+	for _, title := range []string{"Epic", "Schema", "API", "Docs"} {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatal(err)
+		}
+		ids[title] = issue.ID
+	}
+	for _, dep := range []*types.Dependency{
+		{IssueID: ids["Schema"], DependsOnID: ids["Epic"], Type: types.DepParentChild},
+		{IssueID: ids["API"], DependsOnID: ids["Schema"], Type: types.DepBlocks},
+		{IssueID: ids["Docs"], DependsOnID: ids["API"], Type: types.DepBlocks},
+	} {
+		if err := store.AddDependency(ctx, dep, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.AddLabel(ctx, ids["API"], "backend", "test"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.AddIssueComment(ctx, ids["API"], "alice", "<script>alert(1)</script>"); err != nil {
+		t.Fatal(err)
+	}
+	return NewServer(StoreSource{Store: store}, "demo"), ids
+}
+
+func get(t *testing.T, s *Server, method, path string, v interface{}) int {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+	if v != nil && rec.Code == http.StatusOK {
+		if err := json.Unmarshal(rec.Body.Bytes(), v); err != nil {
+			t.Fatalf("%s: %v\n%s", path, err, rec.Body)
+		}
+	}
+	return rec.Code
+}
+
+func TestServer(t *testing.T) {
+	s, ids := newTestServer(t)
+
+	var issues []*types.Issue
+	if code := get(t, s, http.MethodGet, "/api/issues?label=backend", &issues); code != http.StatusOK || len(issues) != 1 || issues[0].ID != ids["API"] {
+		t.Fatalf("labeled list = %d %+v", code, issues)
+	}
+	if len(issues[0].Labels) != 1 {
+		t.Errorf("list labels = %v", issues[0].Labels)
+	}
+	if code := get(t, s, http.MethodGet, "/api/issues?q=Schema&status=open", &issues); code != http.StatusOK || len(issues) != 1 {
+		t.Errorf("search = %d %+v", code, issues)
+	}
+	if code := get(t, s, http.MethodGet, "/api/issues?priority=high", nil); code != http.StatusBadRequest {
+		t.Errorf("bad priority = %d", code)
+	}
+
+	var detail struct {
+		ID           string `json:"id"`
+		Dependencies []struct {
+			ID             string `json:"id"`
+			DependencyType string `json:"dependency_type"`
+		} `json:"dependencies"`
+		Dependents []struct{ ID string } `json:"dependents"`
+		Comments   []*types.Comment      `json:"comments"`
+	}
+	if code := get(t, s, http.MethodGet, "/api/issues/"+ids["API"], &detail); code != http.StatusOK {
+		t.Fatalf("detail = %d", code)
+	}
+	if len(detail.Dependencies) != 1 || detail.Dependencies[0].DependencyType != "blocks" || len(detail.Dependents) != 1 || len(detail.Comments) != 1 {
+		t.Errorf("detail = %+v", detail)
+	}
+	if code := get(t, s, http.MethodGet, "/api/issues/bd-nope", nil); code != http.StatusNotFound {
+		t.Errorf("missing issue = %d", code)
+	}
+
+	var graph Graph
+	if code := get(t, s, http.MethodGet, "/api/graph/"+ids["API"]+"?depth=1", &graph); code != http.StatusOK {
+		t.Fatalf("graph = %d", code)
+	}
+	if len(graph.Nodes) != 3 || len(graph.Edges) != 2 {
+		t.Errorf("depth 1 graph = %d nodes, %d edges", len(graph.Nodes), len(graph.Edges))
+	}
+	if code := get(t, s, http.MethodGet, "/api/graph/"+ids["API"], &graph); code != http.StatusOK || len(graph.Nodes) != 4 {
+		t.Fatalf("depth 2 graph = %d, %d nodes", code, len(graph.Nodes))
+	}
+	levels := map[string]int{}
+	for _, n := range graph.Nodes {
+		levels[n.ID] = n.Level
+	}
+	if levels[ids["Epic"]] != -2 || levels[ids["Schema"]] != -1 || levels[ids["API"]] != 0 || levels[ids["Docs"]] != 1 {
+		t.Errorf("levels = %v", levels)
+	}
+	if code := get(t, s, http.MethodGet, "/api/graph/"+ids["API"]+"?depth=9", nil); code != http.StatusBadRequest {
+		t.Errorf("depth 9 = %d", code)
+	}
+
+	var project ProjectResponse
+	if code := get(t, s, http.MethodGet, "/api/project", &project); code != http.StatusOK || project.Name != "demo" || project.Statistics.TotalIssues != 4 {
+		t.Errorf("project = %d %+v", code, project)
+	}
+}
+
+func TestServerReadOnlyAndAssets(t *testing.T) {
+	s, ids := newTestServer(t)
+	for _, method := range []string{http.MethodPost, http.MethodPatch, http.MethodDelete} {
+		if code := get(t, s, method, "/api/issues/"+ids["API"], nil); code != http.StatusMethodNotAllowed {
+			t.Errorf("%s = %d, want 405", method, code)
+		}
+	}
+
+	for path, want := range map[string]string{"/": "<title>beads</title>", "/app.js": "/api/issues", "/style.css": "#graph"} {
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), want) {
+			t.Errorf("%s = %d, missing %q", path, rec.Code, want)
+		}
+		if rec.Header().Get("Content-Security-Policy") == "" {
+			t.Errorf("%s has no Content-Security-Policy", path)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package webui
+
+import (
+	"context"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Source is the tracker data the viewer reads. The viewer never writes.
+type Source interface {
+	// ListIssues searches issues, with their labels
+	ListIssues(ctx context.Context, query string, filter types.IssueFilter) ([]*types.Issue, error)
+	// GetIssue returns an issue with its relations and comments, or nil
+	GetIssue(ctx context.Context, id string) (*IssueDetail, error)
+	Statistics(ctx context.Context) (*types.Statistics, error)
+}
+
+// IssueDetail is an issue as the detail page shows it
+type IssueDetail struct {
+	*types.Issue
+	Dependencies []*types.IssueWithDependencyMetadata `json:"dependencies"`
+	Dependents   []*types.IssueWithDependencyMetadata `json:"dependents"`
+	Comments     []*types.Comment                     `json:"comments"`
+}
+
+// metadataStore is implemented by stores that know each relation's type
+type metadataStore interface {
+	GetDependenciesWithMetadata(ctx context.Context, issueID string) ([]*types.IssueWithDependencyMetadata, error)
+	GetDependentsWithMetadata(ctx context.Context, issueID string) ([]*types.IssueWithDependencyMetadata, error)
+}
+
+// StoreSource reads straight from a store
+type StoreSource struct {
+	Store storage.Storage
+}
+
+// ListIssues implements Source
+func (s StoreSource) ListIssues(ctx context.Context, query string, filter types.IssueFilter) ([]*types.Issue, error) {
+	issues, err := s.Store.SearchIssues(ctx, query, filter)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	labels, err := s.Store.GetLabelsForIssues(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, issue := range issues {
+		issue.Labels = labels[issue.ID]
+	}
+	return issues, nil
+}
+
+// GetIssue implements Source
+func (s StoreSource) GetIssue(ctx context.Context, id string) (*IssueDetail, error) {
+	issue, err := s.Store.GetIssue(ctx, id)
+	if err != nil || issue == nil {
+		return nil, err
+	}
+	detail := &IssueDetail{Issue: issue}
+	if issue.Labels, err = s.Store.GetLabels(ctx, id); err != nil {
+		return nil, err
+	}
+
+	if ms, ok := s.Store.(metadataStore); ok {
+		if detail.Dependencies, err = ms.GetDependenciesWithMetadata(ctx, id); err != nil {
+			return nil, err
+		}
+		if detail.Dependents, err = ms.GetDependentsWithMetadata(ctx, id); err != nil {
+			return nil, err
+		}
+	} else {
+		// Without relation types, treat everything as blocking
+		deps, err := s.Store.GetDependencies(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range deps {
+			detail.Dependencies = append(detail.Dependencies, &types.IssueWithDependencyMetadata{Issue: *d, DependencyType: types.DepBlocks})
+		}
+		dependents, err := s.Store.GetDependents(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range dependents {
+			detail.Dependents = append(detail.Dependents, &types.IssueWithDependencyMetadata{Issue: *d, DependencyType: types.DepBlocks})
+		}
+	}
+
+	if detail.Comments, err = s.Store.GetIssueComments(ctx, id); err != nil {
+		return nil, err
+	}
+	return detail, nil
+}
+
+// Statistics implements Source
+func (s StoreSource) Statistics(ctx context.Context) (*types.Statistics, error) {
+	return s.Store.GetStatistics(ctx)
+}
@@ -0,0 +1,235 @@
+// beads web viewer: a read-only single page over the /api endpoints.
+// Everything from the tracker is inserted as text, never as HTML.
+'use strict';
+
+const $ = (id) => document.getElementById(id);
+
+function el(tag, attrs, ...children) {
+  const node = document.createElement(tag);
+  for (const [key, value] of Object.entries(attrs || {})) {
+    if (key === 'class') node.className = value;
+    else node.setAttribute(key, value);
+  }
+  for (const child of children) {
+    if (child === null || child === undefined) continue;
+    node.append(child instanceof Node ? child : String(child));
+  }
+  return node;
+}
+
+async function api(path) {
+  const resp = await fetch(path, { headers: { Accept: 'application/json' } });
+  const body = await resp.json();
+  if (!resp.ok) throw new Error(body.error || resp.statusText);
+  return body;
+}
+
+function showError(err) {
+  $('error').textContent = err ? String(err.message || err) : '';
+  $('error').hidden = !err;
+}
+
+function issueLink(id) {
+  return el('a', { href: '#/issue/' + encodeURIComponent(id), class: 'id' }, id);
+}
+
+function statusBadge(status) {
+  return el('span', { class: 'status status-' + status }, status.replace('_', ' '));
+}
+
+function labels(list) {
+  return (list || []).map((l) => el('span', { class: 'label' }, l));
+}
+
+function formatDate(value) {
+  if (!value) return '';
+  const d = new Date(value);
+  return isNaN(d) ? value : d.toLocaleDateString(undefined, { year: 'numeric', month: 'short', day: 'numeric' });
+}
+
+// Header: project name and counts
+async function loadProject() {
+  const project = await api('/api/project');
+  $('project').textContent = project.name;
+  document.title = 'beads · ' + project.name;
+  const s = project.statistics || {};
+  const stats = $('stats');
+  stats.replaceChildren();
+  for (const [label, value] of [['open', s.open_issues], ['in progress', s.in_progress_issues], ['blocked', s.blocked_issues], ['ready', s.ready_issues], ['closed', s.closed_issues]]) {
+    stats.append(el('span', {}, el('b', {}, value || 0), ' ' + label));
+  }
+}
+
+// Issue list
+const filterIDs = ['q', 'status', 'type', 'priority', 'label', 'assignee'];
+let listTimer = null;
+
+function filterQuery() {
+  const params = new URLSearchParams();
+  for (const id of filterIDs) {
+    const value = $(id).value.trim();
+    if (value) params.set(id, value);
+  }
+  return params;
+}
+
+async function loadList() {
+  const params = filterQuery();
+  const issues = await api('/api/issues?' + params);
+  $('count').textContent = issues.length === 1 ? '1 issue' : issues.length + ' issues';
+  const rows = issues.map((issue) => {
+    const row = el('tr', {},
+      el('td', {}, issueLink(issue.id)),
+      el('td', {}, 'P' + issue.priority),
+      el('td', {}, issue.issue_type),
+      el('td', {}, statusBadge(issue.status)),
+      el('td', {}, issue.title),
+      el('td', {}, issue.assignee || ''),
+      el('td', {}, ...labels(issue.labels)),
+      el('td', { class: 'muted' }, formatDate(issue.updated_at)));
+    row.addEventListener('click', (e) => {
+      if (e.target.tagName !== 'A') location.hash = '#/issue/' + encodeURIComponent(issue.id);
+    });
+    return row;
+  });
+  $('issues').replaceChildren(...rows);
+}
+
+function scheduleList() {
+  clearTimeout(listTimer);
+  listTimer = setTimeout(() => loadList().then(() => showError(null), showError), 200);
+}
+
+// Issue detail
+async function loadDetail(id) {
+  const issue = await api('/api/issues/' + encodeURIComponent(id));
+  $('d-id').textContent = issue.id;
+  $('d-title').textContent = issue.title;
+
+  const meta = [
+    ['Status', statusBadge(issue.status)],
+    ['Priority', 'P' + issue.priority],
+    ['Type', issue.issue_type],
+    ['Assignee', issue.assignee],
+    ['Labels', issue.labels && issue.labels.length ? el('span', {}, ...labels(issue.labels)) : null],
+    ['Close reason', issue.close_reason],
+    ['Created', formatDate(issue.created_at)],
+    ['Updated', formatDate(issue.updated_at)],
+    ['Closed', formatDate(issue.closed_at)],
+  ];
+  $('d-meta').replaceChildren(...meta.filter(([, v]) => v).flatMap(([k, v]) => [el('dt', {}, k), el('dd', {}, v)]));
+
+  const text = [];
+  for (const [title, value] of [['Description', issue.description], ['Design', issue.design], ['Acceptance criteria', issue.acceptance_criteria], ['Notes', issue.notes]]) {
+    if (value) text.push(el('h2', {}, title), el('pre', { class: 'text' }, value));
+  }
+  $('d-text').replaceChildren(...text);
+
+  const relations = [];
+  for (const [title, list] of [['Depends on', issue.dependencies], ['Depended on by', issue.dependents]]) {
+    if (!list || !list.length) continue;
+    relations.push(el('h2', {}, title + ' (' + list.length + ')'));
+    relations.push(el('ul', { class: 'relations' }, ...list.map((d) =>
+      el('li', {}, issueLink(d.id), ' ', statusBadge(d.status), ' ', d.title, ' ', el('span', { class: 'muted' }, d.dependency_type)))));
+  }
+  $('d-relations').replaceChildren(...relations);
+
+  const comments = issue.comments || [];
+  $('d-comments').replaceChildren(...(comments.length ? [el('h2', {}, 'Comments (' + comments.length + ')')] : []),
+    ...comments.map((c) => el('div', { class: 'comment' },
+      el('header', { class: 'muted' }, c.author + ' · ' + formatDate(c.created_at)),
+      el('pre', { class: 'text' }, c.text))));
+
+  await loadGraph(issue.id);
+}
+
+// Dependency graph: one column per level, dependencies to the left
+const SVG = 'http://www.w3.org/2000/svg';
+const NODE_W = 220, NODE_H = 44, COL_GAP = 70, ROW_GAP = 14, PAD = 16;
+
+function svg(tag, attrs, ...children) {
+  const node = document.createElementNS(SVG, tag);
+  for (const [key, value] of Object.entries(attrs || {})) node.setAttribute(key, value);
+  for (const child of children) node.append(child);
+  return node;
+}
+
+function clip(text, n) {
+  return text.length > n ? text.slice(0, n - 1) + '…' : text;
+}
+
+async function loadGraph(id) {
+  const graph = await api('/api/graph/' + encodeURIComponent(id) + '?depth=' + $('depth').value);
+  const levels = graph.nodes.map((n) => n.level);
+  const minLevel = Math.min(...levels);
+  const columns = {};
+  const pos = {};
+  for (const node of graph.nodes) {
+    const col = node.level - minLevel;
+    const row = (columns[col] = (columns[col] || 0) + 1) - 1;
+    pos[node.id] = { x: PAD + col * (NODE_W + COL_GAP), y: PAD + row * (NODE_H + ROW_GAP) };
+  }
+  const width = PAD * 2 + (Math.max(...levels) - minLevel + 1) * (NODE_W + COL_GAP) - COL_GAP;
+  const height = PAD * 2 + Math.max(...Object.values(columns)) * (NODE_H + ROW_GAP) - ROW_GAP;
+
+  const root = svg('svg', { width, height, viewBox: `0 0 ${width} ${height}` },
+    svg('defs', {}, svg('marker', { id: 'arrow', viewBox: '0 0 10 10', refX: 10, refY: 5, markerWidth: 7, markerHeight: 7, orient: 'auto-start-reverse' },
+      svg('path', { d: 'M 0 0 L 10 5 L 0 10 z', fill: '#8c959f' }))));
+
+  for (const edge of graph.edges) {
+    const from = pos[edge.from], to = pos[edge.to];
+    if (!from || !to) continue;
+    // From depends on To: the arrow points at the dependency
+    const leftward = from.x >= to.x;
+    const x1 = leftward ? from.x : from.x + NODE_W, y1 = from.y + NODE_H / 2;
+    const x2 = leftward ? to.x + NODE_W : to.x, y2 = to.y + NODE_H / 2;
+    const bend = from.x === to.x ? NODE_W / 2 + 20 : Math.abs(x2 - x1) / 2;
+    const c1 = leftward ? x1 - bend : x1 + bend, c2 = leftward ? x2 + bend : x2 - bend;
+    const path = svg('path', { class: 'edge ' + edge.type, d: `M ${x1} ${y1} C ${c1} ${y1}, ${c2} ${y2}, ${x2} ${y2}`, 'marker-end': 'url(#arrow)' });
+    path.append(svg('title', {}, edge.from + ' ' + edge.type + ' ' + edge.to));
+    root.append(path);
+  }
+
+  for (const node of graph.nodes) {
+    const p = pos[node.id];
+    const link = svg('a', { href: '#/issue/' + encodeURIComponent(node.id), class: 'node' + (node.id === graph.root ? ' root' : '') },
+      svg('rect', { x: p.x, y: p.y, width: NODE_W, height: NODE_H, rx: 6 }),
+      svg('text', { x: p.x + 8, y: p.y + 17 }, clip(node.title, 30)),
+      svg('text', { x: p.x + 8, y: p.y + 34, class: 'sub' }, node.id + ' · P' + node.priority + ' · ' + node.status.replace('_', ' ')));
+    link.append(svg('title', {}, node.id + ': ' + node.title));
+    root.append(link);
+  }
+
+  const note = graph.truncated ? [el('p', { class: 'muted' }, 'Showing the first ' + graph.nodes.length + ' issues.')] : [];
+  $('graph').replaceChildren(root, ...note);
+}
+
+// Routing: #/ is the list, #/issue/<id> an issue
+async function route() {
+  const match = location.hash.match(/^#\/issue\/(.+)$/);
+  $('list-view').hidden = !!match;
+  $('detail-view').hidden = !match;
+  try {
+    if (match) {
+      await loadDetail(decodeURIComponent(match[1]));
+      window.scrollTo(0, 0);
+    } else {
+      await loadList();
+    }
+    showError(null);
+  } catch (err) {
+    showError(err);
+  }
+}
+
+for (const id of filterIDs) {
+  $(id).addEventListener(id === 'q' || id === 'label' || id === 'assignee' ? 'input' : 'change', scheduleList);
+}
+$('filters').addEventListener('submit', (e) => e.preventDefault());
+$('depth').addEventListener('change', () => {
+  const match = location.hash.match(/^#\/issue\/(.+)$/);
+  if (match) loadGraph(decodeURIComponent(match[1])).catch(showError);
+});
+window.addEventListener('hashchange', route);
+loadProject().catch(showError);
+route();